@@ -20,6 +20,62 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// BlobLayout is how the data of a blob is interpreted as the symbols of the polynomial it is committed to.
+type BlobLayout int32
+
+const (
+	// FREE_FORM accepts any data, packed in symbols of 31 bytes, the last one being padded with zeros.
+	BlobLayout_FREE_FORM BlobLayout = 0
+	// FIELD_ELEMENTS interprets the data as 4096 field elements of 32 big-endian bytes each. The data must be exactly
+	// 131072 bytes, and each field element must be canonical in the bn254 scalar field, i.e. below its modulus, or the
+	// request is rejected. This is not the EIP-4844 blob format, whose field elements are canonical in the larger
+	// BLS12-381 scalar field: an EIP-4844 blob is only accepted if all of its field elements are also below the bn254
+	// modulus. The blob is committed to over the 4096 field elements, with a data_length of 4096.
+	// The layout is not part of the blob header: the EigenDA Nodes, the retrieval clients and the disperser only decode
+	// the free-form layout, so such a blob can only be read back with RetrieveBlob while the disperser stores it, and
+	// retrievers reconstructing it from the EigenDA Nodes must read its symbols as 32-byte field elements themselves.
+	BlobLayout_FIELD_ELEMENTS BlobLayout = 1
+)
+
+// Enum value maps for BlobLayout.
+var (
+	BlobLayout_name = map[int32]string{
+		0: "FREE_FORM",
+		1: "FIELD_ELEMENTS",
+	}
+	BlobLayout_value = map[string]int32{
+		"FREE_FORM":      0,
+		"FIELD_ELEMENTS": 1,
+	}
+)
+
+func (x BlobLayout) Enum() *BlobLayout {
+	p := new(BlobLayout)
+	*p = x
+	return p
+}
+
+func (x BlobLayout) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (BlobLayout) Descriptor() protoreflect.EnumDescriptor {
+	return file_disperser_disperser_proto_enumTypes[0].Descriptor()
+}
+
+func (BlobLayout) Type() protoreflect.EnumType {
+	return &file_disperser_disperser_proto_enumTypes[0]
+}
+
+func (x BlobLayout) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use BlobLayout.Descriptor instead.
+func (BlobLayout) EnumDescriptor() ([]byte, []int) {
+	return file_disperser_disperser_proto_rawDescGZIP(), []int{0}
+}
+
 type BlobStatus int32
 
 const (
@@ -27,7 +83,7 @@ const (
 	// PROCESSING means that the blob is currently being processed by the disperser
 	BlobStatus_PROCESSING BlobStatus = 1
 	// CONFIRMED means that the blob has been dispersed to DA Nodes and the dispersed
-	// batch containing the blob has been confirmed onchain
+	// batch containing the blob has been confirmed onchain, with the blob confirmed in all of its quorums
 	BlobStatus_CONFIRMED BlobStatus = 2
 	// FAILED means that the blob has failed permanently (for reasons other than insufficient
 	// signatures, which is a separate state)
@@ -37,6 +93,9 @@ const (
 	// INSUFFICIENT_SIGNATURES means that the quorum threshold for the blob was not met
 	// for at least one quorum.
 	BlobStatus_INSUFFICIENT_SIGNATURES BlobStatus = 5
+	// QUOTA_EXCEEDED means that the blob was not batched because the account that dispersed it
+	// exceeded its daily quota, and it was failed after waiting for the quota to free up.
+	BlobStatus_QUOTA_EXCEEDED BlobStatus = 6
 )
 
 // Enum value maps for BlobStatus.
@@ -48,6 +107,7 @@ var (
 		3: "FAILED",
 		4: "FINALIZED",
 		5: "INSUFFICIENT_SIGNATURES",
+		6: "QUOTA_EXCEEDED",
 	}
 	BlobStatus_value = map[string]int32{
 		"UNKNOWN":                 0,
@@ -56,6 +116,7 @@ var (
 		"FAILED":                  3,
 		"FINALIZED":               4,
 		"INSUFFICIENT_SIGNATURES": 5,
+		"QUOTA_EXCEEDED":          6,
 	}
 )
 
@@ -70,11 +131,11 @@ func (x BlobStatus) String() string {
 }
 
 func (BlobStatus) Descriptor() protoreflect.EnumDescriptor {
-	return file_disperser_disperser_proto_enumTypes[0].Descriptor()
+	return file_disperser_disperser_proto_enumTypes[1].Descriptor()
 }
 
 func (BlobStatus) Type() protoreflect.EnumType {
-	return &file_disperser_disperser_proto_enumTypes[0]
+	return &file_disperser_disperser_proto_enumTypes[1]
 }
 
 func (x BlobStatus) Number() protoreflect.EnumNumber {
@@ -83,7 +144,7 @@ func (x BlobStatus) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use BlobStatus.Descriptor instead.
 func (BlobStatus) EnumDescriptor() ([]byte, []int) {
-	return file_disperser_disperser_proto_rawDescGZIP(), []int{0}
+	return file_disperser_disperser_proto_rawDescGZIP(), []int{1}
 }
 
 type DisperseBlobRequest struct {
@@ -93,6 +154,11 @@ type DisperseBlobRequest struct {
 
 	// The data to be dispersed.
 	// The size of data must be <= 512KiB.
+	// The data is encoded in symbols of 31 bytes, the last one being padded with zeros.
+	// RetrieveBlob returns the data exactly as dispersed, but the data reconstructed from
+	// the EigenDA Nodes (e.g. by the retriever) is padded with zeros to the length of the
+	// encoded blob. Clients that need to recover the exact data from the nodes should
+	// pad it themselves, e.g. by prefixing it with its length as the Go client does.
 	Data []byte `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
 	// Security parameters allowing clients to customize the safety (via adversary threshold)
 	// and liveness (via quorum threshold).
@@ -100,6 +166,35 @@ type DisperseBlobRequest struct {
 	// The disperser will ensure that the encoded blobs for each quorum are all processed
 	// within the same batch.
 	SecurityParams []*SecurityParams `protobuf:"bytes,2,rep,name=security_params,json=securityParams,proto3" json:"security_params,omitempty"`
+	// Optional namespace (e.g. an application ID) declared by the client, used to attribute
+	// blobs in metrics and queries. It is not authenticated.
+	// Requires: at most 64 characters from [a-zA-Z0-9._-].
+	Namespace string `protobuf:"bytes,3,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// If true, the request is validated, and rate limited, like a dispersal, but the blob
+	// isn't stored nor dispersed. The reply then has the UNKNOWN result and no request_id,
+	// and holds the commitment of the blob along with its blob_quorum_params, so that
+	// clients can pre-flight their requests.
+	DryRun bool `protobuf:"varint,4,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	// Optional labels declared by the client, stored with the blob and returned by GetBlobStatus.
+	// They are not authenticated nor interpreted by the disperser.
+	// Requires: at most 16 labels, with non-empty keys, and at most 1KiB of keys and values in total.
+	Metadata map[string]string `protobuf:"bytes,5,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// How the data is interpreted as symbols when it is encoded and committed to. Defaults to FREE_FORM.
+	Layout BlobLayout `protobuf:"varint,6,opt,name=layout,proto3,enum=disperser.BlobLayout" json:"layout,omitempty"`
+	// Optional MIME type of the data, e.g. "application/json", stored with the blob and returned by
+	// GetBlobStatus and RetrieveBlob, so that consumers know how to interpret the data. It is not
+	// authenticated, and doesn't affect the commitment of the blob.
+	// Requires: at most 255 characters forming a valid media type, e.g. "text/plain; charset=utf-8".
+	ContentType string `protobuf:"bytes,7,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	// If true, RetrieveBlob only returns the data of the blob to its submitter until the blob is FINALIZED, after which
+	// it is public. The submitter proves it by signing its retrieval requests with the key of submitter_public_key.
+	// RetrieveBatchBlobs withholds the data of the blob until then. The data the EigenDA Nodes store is public
+	// regardless, so retrievers reconstructing the blob from the nodes are unaffected.
+	PrivateUntilFinalized bool `protobuf:"varint,8,opt,name=private_until_finalized,json=privateUntilFinalized,proto3" json:"private_until_finalized,omitempty"`
+	// The ECDSA (secp256k1) public key of the submitter of a private blob, compressed (33 bytes) or uncompressed
+	// (65 bytes).
+	// Requires: set if and only if private_until_finalized is true.
+	SubmitterPublicKey []byte `protobuf:"bytes,9,opt,name=submitter_public_key,json=submitterPublicKey,proto3" json:"submitter_public_key,omitempty"`
 }
 
 func (x *DisperseBlobRequest) Reset() {
@@ -148,6 +243,55 @@ func (x *DisperseBlobRequest) GetSecurityParams() []*SecurityParams {
 	return nil
 }
 
+func (x *DisperseBlobRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *DisperseBlobRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+func (x *DisperseBlobRequest) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *DisperseBlobRequest) GetLayout() BlobLayout {
+	if x != nil {
+		return x.Layout
+	}
+	return BlobLayout_FREE_FORM
+}
+
+func (x *DisperseBlobRequest) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *DisperseBlobRequest) GetPrivateUntilFinalized() bool {
+	if x != nil {
+		return x.PrivateUntilFinalized
+	}
+	return false
+}
+
+func (x *DisperseBlobRequest) GetSubmitterPublicKey() []byte {
+	if x != nil {
+		return x.SubmitterPublicKey
+	}
+	return nil
+}
+
 type DisperseBlobReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -163,7 +307,19 @@ type DisperseBlobReply struct {
 	// times will also have different IDs.
 	// The client should use this ID to query the processing status of the request (via
 	// the GetBlobStatus API).
+	// The request ID starts with the version of its format, e.g. "v1:", and should be treated as
+	// an opaque value by the clients.
 	RequestId []byte `protobuf:"bytes,2,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	// The parameters of the blob in each of its quorums, in the order of the security_params of the request, with the
+	// encoded_length estimated from the current operator set of the quorum. The blob is encoded against the operator
+	// set at the reference block of its batch, so the encoded_length confirmed in the BlobHeader of GetBlobStatus differs
+	// if the operator set changes in between. It is empty if the disperser couldn't read the operator set.
+	BlobQuorumParams []*BlobQuorumParam `protobuf:"bytes,3,rep,name=blob_quorum_params,json=blobQuorumParams,proto3" json:"blob_quorum_params,omitempty"`
+	// The KZG commitment to the blob, only set for dry runs. It is empty if the disperser
+	// isn't configured to compute commitments.
+	Commitment []byte `protobuf:"bytes,4,opt,name=commitment,proto3" json:"commitment,omitempty"`
+	// The length of the blob in symbols (each symbol is 31 bytes), only set for dry runs.
+	DataLength uint32 `protobuf:"varint,5,opt,name=data_length,json=dataLength,proto3" json:"data_length,omitempty"`
 }
 
 func (x *DisperseBlobReply) Reset() {
@@ -212,6 +368,27 @@ func (x *DisperseBlobReply) GetRequestId() []byte {
 	return nil
 }
 
+func (x *DisperseBlobReply) GetBlobQuorumParams() []*BlobQuorumParam {
+	if x != nil {
+		return x.BlobQuorumParams
+	}
+	return nil
+}
+
+func (x *DisperseBlobReply) GetCommitment() []byte {
+	if x != nil {
+		return x.Commitment
+	}
+	return nil
+}
+
+func (x *DisperseBlobReply) GetDataLength() uint32 {
+	if x != nil {
+		return x.DataLength
+	}
+	return 0
+}
+
 // BlobStatusRequest is used to query the status of a blob.
 type BlobStatusRequest struct {
 	state         protoimpl.MessageState
@@ -269,6 +446,22 @@ type BlobStatusReply struct {
 	Status BlobStatus `protobuf:"varint,1,opt,name=status,proto3,enum=disperser.BlobStatus" json:"status,omitempty"`
 	// The blob info needed for clients to confirm the blob against the EigenDA contracts.
 	Info *BlobInfo `protobuf:"bytes,2,opt,name=info,proto3" json:"info,omitempty"`
+	// The namespace declared in the DisperseBlobRequest, if any.
+	Namespace string `protobuf:"bytes,3,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// The confirmation status of the blob in each of its quorums, in the order of BlobHeader.blob_quorum_params.
+	// It is set once the batch of the blob was signed, so that clients can see which quorums are confirmed when the
+	// blob is only partially confirmed. The status of the blob is CONFIRMED only when it is confirmed in all of its
+	// quorums.
+	QuorumStatuses []*BlobQuorumStatus `protobuf:"bytes,4,rep,name=quorum_statuses,json=quorumStatuses,proto3" json:"quorum_statuses,omitempty"`
+	// The delay in milliseconds after which the disperser recommends polling the status of the blob again, while it is
+	// PROCESSING. It is derived from how long the blob has been processing relative to the typical time to confirm a
+	// blob: it is longer right after the dispersal and shorter as the expected confirmation approaches. It is 0 when
+	// the disperser makes no recommendation, in which case clients poll at their own interval.
+	NextPollDelayMs uint64 `protobuf:"varint,5,opt,name=next_poll_delay_ms,json=nextPollDelayMs,proto3" json:"next_poll_delay_ms,omitempty"`
+	// The labels declared in the metadata of the DisperseBlobRequest, if any.
+	Metadata map[string]string `protobuf:"bytes,6,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// The content type declared in the DisperseBlobRequest, if any.
+	ContentType string `protobuf:"bytes,7,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
 }
 
 func (x *BlobStatusReply) Reset() {
@@ -317,18 +510,59 @@ func (x *BlobStatusReply) GetInfo() *BlobInfo {
 	return nil
 }
 
-// RetrieveBlobRequest contains parameters to retrieve the blob.
-type RetrieveBlobRequest struct {
+func (x *BlobStatusReply) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *BlobStatusReply) GetQuorumStatuses() []*BlobQuorumStatus {
+	if x != nil {
+		return x.QuorumStatuses
+	}
+	return nil
+}
+
+func (x *BlobStatusReply) GetNextPollDelayMs() uint64 {
+	if x != nil {
+		return x.NextPollDelayMs
+	}
+	return 0
+}
+
+func (x *BlobStatusReply) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *BlobStatusReply) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+// BlobQuorumStatus is the confirmation status of a blob in one of its quorums.
+type BlobQuorumStatus struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	BatchHeaderHash []byte `protobuf:"bytes,1,opt,name=batch_header_hash,json=batchHeaderHash,proto3" json:"batch_header_hash,omitempty"`
-	BlobIndex       uint32 `protobuf:"varint,2,opt,name=blob_index,json=blobIndex,proto3" json:"blob_index,omitempty"`
+	// The ID of the quorum.
+	QuorumNumber uint32 `protobuf:"varint,1,opt,name=quorum_number,json=quorumNumber,proto3" json:"quorum_number,omitempty"`
+	// The percentage of the quorum stake that signed the batch of the blob.
+	SignedPercentage uint32 `protobuf:"varint,2,opt,name=signed_percentage,json=signedPercentage,proto3" json:"signed_percentage,omitempty"`
+	// Same as BlobQuorumParam.quorum_threshold_percentage.
+	QuorumThresholdPercentage uint32 `protobuf:"varint,3,opt,name=quorum_threshold_percentage,json=quorumThresholdPercentage,proto3" json:"quorum_threshold_percentage,omitempty"`
+	// Whether signed_percentage reaches quorum_threshold_percentage.
+	Confirmed bool `protobuf:"varint,4,opt,name=confirmed,proto3" json:"confirmed,omitempty"`
 }
 
-func (x *RetrieveBlobRequest) Reset() {
-	*x = RetrieveBlobRequest{}
+func (x *BlobQuorumStatus) Reset() {
+	*x = BlobQuorumStatus{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_disperser_disperser_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -336,13 +570,13 @@ func (x *RetrieveBlobRequest) Reset() {
 	}
 }
 
-func (x *RetrieveBlobRequest) String() string {
+func (x *BlobQuorumStatus) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RetrieveBlobRequest) ProtoMessage() {}
+func (*BlobQuorumStatus) ProtoMessage() {}
 
-func (x *RetrieveBlobRequest) ProtoReflect() protoreflect.Message {
+func (x *BlobQuorumStatus) ProtoReflect() protoreflect.Message {
 	mi := &file_disperser_disperser_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -354,36 +588,64 @@ func (x *RetrieveBlobRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RetrieveBlobRequest.ProtoReflect.Descriptor instead.
-func (*RetrieveBlobRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use BlobQuorumStatus.ProtoReflect.Descriptor instead.
+func (*BlobQuorumStatus) Descriptor() ([]byte, []int) {
 	return file_disperser_disperser_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *RetrieveBlobRequest) GetBatchHeaderHash() []byte {
+func (x *BlobQuorumStatus) GetQuorumNumber() uint32 {
 	if x != nil {
-		return x.BatchHeaderHash
+		return x.QuorumNumber
 	}
-	return nil
+	return 0
 }
 
-func (x *RetrieveBlobRequest) GetBlobIndex() uint32 {
+func (x *BlobQuorumStatus) GetSignedPercentage() uint32 {
 	if x != nil {
-		return x.BlobIndex
+		return x.SignedPercentage
 	}
 	return 0
 }
 
-// RetrieveBlobReply contains the retrieved blob data
-type RetrieveBlobReply struct {
+func (x *BlobQuorumStatus) GetQuorumThresholdPercentage() uint32 {
+	if x != nil {
+		return x.QuorumThresholdPercentage
+	}
+	return 0
+}
+
+func (x *BlobQuorumStatus) GetConfirmed() bool {
+	if x != nil {
+		return x.Confirmed
+	}
+	return false
+}
+
+// RetrieveBlobRequest contains parameters to retrieve the blob.
+type RetrieveBlobRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Data []byte `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	BatchHeaderHash []byte `protobuf:"bytes,1,opt,name=batch_header_hash,json=batchHeaderHash,proto3" json:"batch_header_hash,omitempty"`
+	BlobIndex       uint32 `protobuf:"varint,2,opt,name=blob_index,json=blobIndex,proto3" json:"blob_index,omitempty"`
+	// If true, the disperser re-derives the commitment of the retrieved data and checks it
+	// against the commitment the blob was confirmed with before returning it, failing if they
+	// differ. This catches a corrupted storage at the cost of encoding the blob, and requires
+	// the disperser to be configured with the SRS.
+	Verify bool `protobuf:"varint,3,opt,name=verify,proto3" json:"verify,omitempty"`
+	// The time of the request in seconds since the Unix epoch, which must be within 5 minutes of the time of the
+	// disperser. Only required to retrieve a private blob which isn't finalized, see
+	// DisperseBlobRequest.private_until_finalized.
+	AuthTimestamp uint64 `protobuf:"varint,4,opt,name=auth_timestamp,json=authTimestamp,proto3" json:"auth_timestamp,omitempty"`
+	// The 65-byte [R || S || V] ECDSA signature by the key of DisperseBlobRequest.submitter_public_key of
+	// keccak256("EigenDA RetrieveBlob" || batch_header_hash || blob_index || auth_timestamp), the integers being
+	// big-endian. Only required to retrieve a private blob which isn't finalized.
+	AuthSignature []byte `protobuf:"bytes,5,opt,name=auth_signature,json=authSignature,proto3" json:"auth_signature,omitempty"`
 }
 
-func (x *RetrieveBlobReply) Reset() {
-	*x = RetrieveBlobReply{}
+func (x *RetrieveBlobRequest) Reset() {
+	*x = RetrieveBlobRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_disperser_disperser_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -391,13 +653,13 @@ func (x *RetrieveBlobReply) Reset() {
 	}
 }
 
-func (x *RetrieveBlobReply) String() string {
+func (x *RetrieveBlobRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RetrieveBlobReply) ProtoMessage() {}
+func (*RetrieveBlobRequest) ProtoMessage() {}
 
-func (x *RetrieveBlobReply) ProtoReflect() protoreflect.Message {
+func (x *RetrieveBlobRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_disperser_disperser_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -409,56 +671,60 @@ func (x *RetrieveBlobReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RetrieveBlobReply.ProtoReflect.Descriptor instead.
-func (*RetrieveBlobReply) Descriptor() ([]byte, []int) {
+// Deprecated: Use RetrieveBlobRequest.ProtoReflect.Descriptor instead.
+func (*RetrieveBlobRequest) Descriptor() ([]byte, []int) {
 	return file_disperser_disperser_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *RetrieveBlobReply) GetData() []byte {
+func (x *RetrieveBlobRequest) GetBatchHeaderHash() []byte {
 	if x != nil {
-		return x.Data
+		return x.BatchHeaderHash
 	}
 	return nil
 }
 
-// SecurityParams contains the security parameters for a given quorum.
-type SecurityParams struct {
+func (x *RetrieveBlobRequest) GetBlobIndex() uint32 {
+	if x != nil {
+		return x.BlobIndex
+	}
+	return 0
+}
+
+func (x *RetrieveBlobRequest) GetVerify() bool {
+	if x != nil {
+		return x.Verify
+	}
+	return false
+}
+
+func (x *RetrieveBlobRequest) GetAuthTimestamp() uint64 {
+	if x != nil {
+		return x.AuthTimestamp
+	}
+	return 0
+}
+
+func (x *RetrieveBlobRequest) GetAuthSignature() []byte {
+	if x != nil {
+		return x.AuthSignature
+	}
+	return nil
+}
+
+// RetrieveBlobReply contains the retrieved blob data
+type RetrieveBlobReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// The ID of the quorum.
-	// The quorum must be already registered on EigenLayer. The ID must be
-	// in range [0, 255].
-	QuorumId uint32 `protobuf:"varint,1,opt,name=quorum_id,json=quorumId,proto3" json:"quorum_id,omitempty"`
-	// The max percentage of stake within the quorum that can be held by or delegated
-	// to adversarial operators.
-	//
-	// Clients use this to customize the trust assumption (safety).
-	//
-	// Requires: 1 <= adversary_threshold < 100
-	AdversaryThreshold uint32 `protobuf:"varint,2,opt,name=adversary_threshold,json=adversaryThreshold,proto3" json:"adversary_threshold,omitempty"`
-	// The min percentage of stake that must attest in order to consider
-	// the dispersal is successful.
-	//
-	// Clients use this to customize liveness requirement. The higher this number,
-	// the more operators may need to be up for attesting the blob, so the chance
-	// the dispersal request to fail may be higher (liveness for dispersal).
-	//
-	// Requires:
-	//
-	//	1 <= quorum_threshld <= 100
-	//	quorum_threshld > adversary_threshold.
-	//
-	// Note: The adversary_threshold and quorum_threshold will directly influence the
-	// cost of encoding for the blob to be dispersed, roughly by a factor of
-	// 100 / (quorum_threshold - adversary_threshold). See the spec for more details:
-	// https://github.com/Layr-Labs/eigenda/blob/master/docs/spec/protocol-modules/storage/overview.md
-	QuorumThreshold uint32 `protobuf:"varint,3,opt,name=quorum_threshold,json=quorumThreshold,proto3" json:"quorum_threshold,omitempty"`
+	// The data of the blob, exactly as in DisperseBlobRequest.data.
+	Data []byte `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	// The content type declared in the DisperseBlobRequest, if any.
+	ContentType string `protobuf:"bytes,2,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
 }
 
-func (x *SecurityParams) Reset() {
-	*x = SecurityParams{}
+func (x *RetrieveBlobReply) Reset() {
+	*x = RetrieveBlobReply{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_disperser_disperser_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -466,13 +732,13 @@ func (x *SecurityParams) Reset() {
 	}
 }
 
-func (x *SecurityParams) String() string {
+func (x *RetrieveBlobReply) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SecurityParams) ProtoMessage() {}
+func (*RetrieveBlobReply) ProtoMessage() {}
 
-func (x *SecurityParams) ProtoReflect() protoreflect.Message {
+func (x *RetrieveBlobReply) ProtoReflect() protoreflect.Message {
 	mi := &file_disperser_disperser_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -484,44 +750,37 @@ func (x *SecurityParams) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SecurityParams.ProtoReflect.Descriptor instead.
-func (*SecurityParams) Descriptor() ([]byte, []int) {
+// Deprecated: Use RetrieveBlobReply.ProtoReflect.Descriptor instead.
+func (*RetrieveBlobReply) Descriptor() ([]byte, []int) {
 	return file_disperser_disperser_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *SecurityParams) GetQuorumId() uint32 {
-	if x != nil {
-		return x.QuorumId
-	}
-	return 0
-}
-
-func (x *SecurityParams) GetAdversaryThreshold() uint32 {
+func (x *RetrieveBlobReply) GetData() []byte {
 	if x != nil {
-		return x.AdversaryThreshold
+		return x.Data
 	}
-	return 0
+	return nil
 }
 
-func (x *SecurityParams) GetQuorumThreshold() uint32 {
+func (x *RetrieveBlobReply) GetContentType() string {
 	if x != nil {
-		return x.QuorumThreshold
+		return x.ContentType
 	}
-	return 0
+	return ""
 }
 
-// BlobInfo contains information needed to confirm the blob against the EigenDA contracts
-type BlobInfo struct {
+// RetrieveBatchBlobsRequest is used to retrieve all the confirmed blobs of a batch.
+type RetrieveBatchBlobsRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	BlobHeader            *BlobHeader            `protobuf:"bytes,1,opt,name=blob_header,json=blobHeader,proto3" json:"blob_header,omitempty"`
-	BlobVerificationProof *BlobVerificationProof `protobuf:"bytes,2,opt,name=blob_verification_proof,json=blobVerificationProof,proto3" json:"blob_verification_proof,omitempty"`
+	// The hash of the batch header, as in BatchMetadata.batch_header_hash.
+	BatchHeaderHash []byte `protobuf:"bytes,1,opt,name=batch_header_hash,json=batchHeaderHash,proto3" json:"batch_header_hash,omitempty"`
 }
 
-func (x *BlobInfo) Reset() {
-	*x = BlobInfo{}
+func (x *RetrieveBatchBlobsRequest) Reset() {
+	*x = RetrieveBatchBlobsRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_disperser_disperser_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -529,13 +788,13 @@ func (x *BlobInfo) Reset() {
 	}
 }
 
-func (x *BlobInfo) String() string {
+func (x *RetrieveBatchBlobsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BlobInfo) ProtoMessage() {}
+func (*RetrieveBatchBlobsRequest) ProtoMessage() {}
 
-func (x *BlobInfo) ProtoReflect() protoreflect.Message {
+func (x *RetrieveBatchBlobsRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_disperser_disperser_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -547,40 +806,41 @@ func (x *BlobInfo) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BlobInfo.ProtoReflect.Descriptor instead.
-func (*BlobInfo) Descriptor() ([]byte, []int) {
+// Deprecated: Use RetrieveBatchBlobsRequest.ProtoReflect.Descriptor instead.
+func (*RetrieveBatchBlobsRequest) Descriptor() ([]byte, []int) {
 	return file_disperser_disperser_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *BlobInfo) GetBlobHeader() *BlobHeader {
+func (x *RetrieveBatchBlobsRequest) GetBatchHeaderHash() []byte {
 	if x != nil {
-		return x.BlobHeader
-	}
-	return nil
-}
-
-func (x *BlobInfo) GetBlobVerificationProof() *BlobVerificationProof {
-	if x != nil {
-		return x.BlobVerificationProof
+		return x.BatchHeaderHash
 	}
 	return nil
 }
 
-type BlobHeader struct {
+// RetrieveBatchBlobsReply contains one of the retrieved blobs of a batch.
+type RetrieveBatchBlobsReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// KZG commitment to the blob.
-	Commitment []byte `protobuf:"bytes,1,opt,name=commitment,proto3" json:"commitment,omitempty"`
-	// The length of the blob in symbols (each symbol is 31 bytes).
-	DataLength uint32 `protobuf:"varint,2,opt,name=data_length,json=dataLength,proto3" json:"data_length,omitempty"`
-	// The params of the quorums that this blob participates in.
-	BlobQuorumParams []*BlobQuorumParam `protobuf:"bytes,3,rep,name=blob_quorum_params,json=blobQuorumParams,proto3" json:"blob_quorum_params,omitempty"`
+	// The index of the blob in the batch, as in BlobVerificationProof.blob_index.
+	BlobIndex uint32 `protobuf:"varint,1,opt,name=blob_index,json=blobIndex,proto3" json:"blob_index,omitempty"`
+	// The size in bytes of the data of the blob.
+	Size uint32 `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	// The data of the blob, exactly as in DisperseBlobRequest.data.
+	Data []byte `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	// The number of confirmed blobs of the batch, i.e. of messages in the stream.
+	NumBlobs uint32 `protobuf:"varint,4,opt,name=num_blobs,json=numBlobs,proto3" json:"num_blobs,omitempty"`
+	// The content type declared in the DisperseBlobRequest of the blob, if any.
+	ContentType string `protobuf:"bytes,5,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	// Whether the data of the blob is withheld, as the blob is private until it is finalized. Its data and size are
+	// then empty, and it can be retrieved by its submitter with RetrieveBlob.
+	Withheld bool `protobuf:"varint,6,opt,name=withheld,proto3" json:"withheld,omitempty"`
 }
 
-func (x *BlobHeader) Reset() {
-	*x = BlobHeader{}
+func (x *RetrieveBatchBlobsReply) Reset() {
+	*x = RetrieveBatchBlobsReply{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_disperser_disperser_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -588,13 +848,13 @@ func (x *BlobHeader) Reset() {
 	}
 }
 
-func (x *BlobHeader) String() string {
+func (x *RetrieveBatchBlobsReply) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BlobHeader) ProtoMessage() {}
+func (*RetrieveBatchBlobsReply) ProtoMessage() {}
 
-func (x *BlobHeader) ProtoReflect() protoreflect.Message {
+func (x *RetrieveBatchBlobsReply) ProtoReflect() protoreflect.Message {
 	mi := &file_disperser_disperser_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -606,56 +866,65 @@ func (x *BlobHeader) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BlobHeader.ProtoReflect.Descriptor instead.
-func (*BlobHeader) Descriptor() ([]byte, []int) {
+// Deprecated: Use RetrieveBatchBlobsReply.ProtoReflect.Descriptor instead.
+func (*RetrieveBatchBlobsReply) Descriptor() ([]byte, []int) {
 	return file_disperser_disperser_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *BlobHeader) GetCommitment() []byte {
+func (x *RetrieveBatchBlobsReply) GetBlobIndex() uint32 {
 	if x != nil {
-		return x.Commitment
+		return x.BlobIndex
 	}
-	return nil
+	return 0
 }
 
-func (x *BlobHeader) GetDataLength() uint32 {
+func (x *RetrieveBatchBlobsReply) GetSize() uint32 {
 	if x != nil {
-		return x.DataLength
+		return x.Size
 	}
 	return 0
 }
 
-func (x *BlobHeader) GetBlobQuorumParams() []*BlobQuorumParam {
+func (x *RetrieveBatchBlobsReply) GetData() []byte {
 	if x != nil {
-		return x.BlobQuorumParams
+		return x.Data
 	}
 	return nil
 }
 
-type BlobQuorumParam struct {
+func (x *RetrieveBatchBlobsReply) GetNumBlobs() uint32 {
+	if x != nil {
+		return x.NumBlobs
+	}
+	return 0
+}
+
+func (x *RetrieveBatchBlobsReply) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *RetrieveBatchBlobsReply) GetWithheld() bool {
+	if x != nil {
+		return x.Withheld
+	}
+	return false
+}
+
+// BatchVerificationProofsRequest is used to query the verification proofs of the blobs of a batch.
+type BatchVerificationProofsRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// The ID of the quorum.
-	QuorumNumber uint32 `protobuf:"varint,1,opt,name=quorum_number,json=quorumNumber,proto3" json:"quorum_number,omitempty"`
-	// Same as SecurityParams.adversary_threshold.
-	AdversaryThresholdPercentage uint32 `protobuf:"varint,2,opt,name=adversary_threshold_percentage,json=adversaryThresholdPercentage,proto3" json:"adversary_threshold_percentage,omitempty"`
-	// Same as SecurityParams.quorum_threshold.
-	QuorumThresholdPercentage uint32 `protobuf:"varint,3,opt,name=quorum_threshold_percentage,json=quorumThresholdPercentage,proto3" json:"quorum_threshold_percentage,omitempty"`
-	// This determines the nominal number of chunks for the blob, which is
-	// nominal_num_chunks = quantization_param * num_operators.
-	// A chunk is the smallest unit that's distributed to DA Nodes, corresponding to
-	// a set of evaluations of the polynomial (representing the blob) and a KZG multiproof.
-	// See more details in data model of EigenDA:
-	// https://github.com/Layr-Labs/eigenda/blob/master/docs/spec/data-model.md
-	QuantizationParam uint32 `protobuf:"varint,4,opt,name=quantization_param,json=quantizationParam,proto3" json:"quantization_param,omitempty"`
-	// The length of the blob after encoding (in number of symbols).
-	EncodedLength uint64 `protobuf:"varint,5,opt,name=encoded_length,json=encodedLength,proto3" json:"encoded_length,omitempty"`
+	// The hash of the batch header, as in BatchMetadata.batch_header_hash.
+	BatchHeaderHash []byte `protobuf:"bytes,1,opt,name=batch_header_hash,json=batchHeaderHash,proto3" json:"batch_header_hash,omitempty"`
 }
 
-func (x *BlobQuorumParam) Reset() {
-	*x = BlobQuorumParam{}
+func (x *BatchVerificationProofsRequest) Reset() {
+	*x = BatchVerificationProofsRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_disperser_disperser_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -663,13 +932,13 @@ func (x *BlobQuorumParam) Reset() {
 	}
 }
 
-func (x *BlobQuorumParam) String() string {
+func (x *BatchVerificationProofsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BlobQuorumParam) ProtoMessage() {}
+func (*BatchVerificationProofsRequest) ProtoMessage() {}
 
-func (x *BlobQuorumParam) ProtoReflect() protoreflect.Message {
+func (x *BatchVerificationProofsRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_disperser_disperser_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -681,82 +950,33 @@ func (x *BlobQuorumParam) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BlobQuorumParam.ProtoReflect.Descriptor instead.
-func (*BlobQuorumParam) Descriptor() ([]byte, []int) {
+// Deprecated: Use BatchVerificationProofsRequest.ProtoReflect.Descriptor instead.
+func (*BatchVerificationProofsRequest) Descriptor() ([]byte, []int) {
 	return file_disperser_disperser_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *BlobQuorumParam) GetQuorumNumber() uint32 {
-	if x != nil {
-		return x.QuorumNumber
-	}
-	return 0
-}
-
-func (x *BlobQuorumParam) GetAdversaryThresholdPercentage() uint32 {
-	if x != nil {
-		return x.AdversaryThresholdPercentage
-	}
-	return 0
-}
-
-func (x *BlobQuorumParam) GetQuorumThresholdPercentage() uint32 {
-	if x != nil {
-		return x.QuorumThresholdPercentage
-	}
-	return 0
-}
-
-func (x *BlobQuorumParam) GetQuantizationParam() uint32 {
-	if x != nil {
-		return x.QuantizationParam
-	}
-	return 0
-}
-
-func (x *BlobQuorumParam) GetEncodedLength() uint64 {
+func (x *BatchVerificationProofsRequest) GetBatchHeaderHash() []byte {
 	if x != nil {
-		return x.EncodedLength
+		return x.BatchHeaderHash
 	}
-	return 0
+	return nil
 }
 
-type BlobVerificationProof struct {
+// BatchVerificationProofsReply contains the metadata of a batch along with the proofs of its confirmed blobs.
+type BatchVerificationProofsReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	// batch_id is an incremental ID assigned to a batch by EigenDAServiceManager
-	BatchId uint32 `protobuf:"varint,1,opt,name=batch_id,json=batchId,proto3" json:"batch_id,omitempty"`
-	// The index of the blob in the batch (which is logically an ordered list of blobs).
-	BlobIndex     uint32         `protobuf:"varint,2,opt,name=blob_index,json=blobIndex,proto3" json:"blob_index,omitempty"`
-	BatchMetadata *BatchMetadata `protobuf:"bytes,3,opt,name=batch_metadata,json=batchMetadata,proto3" json:"batch_metadata,omitempty"`
-	// inclusion_proof is a merkle proof for a blob header's inclusion in a batch
-	InclusionProof []byte `protobuf:"bytes,4,opt,name=inclusion_proof,json=inclusionProof,proto3" json:"inclusion_proof,omitempty"`
-	// indexes of quorums in BatchHeader.quorum_numbers that match the quorums in BlobHeader.blob_quorum_params
-	// Ex. BlobHeader.blob_quorum_params = [
-	//
-	//	{
-	//		quorum_number = 0,
-	//		...
-	//	},
-	//	{
-	//		quorum_number = 3,
-	//		...
-	//	},
-	//	{
-	//		quorum_number = 5,
-	//		...
-	//	},
-	//
-	// ]
-	// BatchHeader.quorum_numbers = [0, 5, 3] => 0x000503
-	// Then, quorum_indexes = [0, 2, 1] => 0x000201
-	QuorumIndexes []byte `protobuf:"bytes,5,opt,name=quorum_indexes,json=quorumIndexes,proto3" json:"quorum_indexes,omitempty"`
+	BatchId       uint32         `protobuf:"varint,1,opt,name=batch_id,json=batchId,proto3" json:"batch_id,omitempty"`
+	BatchMetadata *BatchMetadata `protobuf:"bytes,2,opt,name=batch_metadata,json=batchMetadata,proto3" json:"batch_metadata,omitempty"`
+	// The proofs of the confirmed blobs of the batch, ordered by blob index.
+	BlobProofs []*BlobInclusionProof `protobuf:"bytes,3,rep,name=blob_proofs,json=blobProofs,proto3" json:"blob_proofs,omitempty"`
 }
 
-func (x *BlobVerificationProof) Reset() {
-	*x = BlobVerificationProof{}
+func (x *BatchVerificationProofsReply) Reset() {
+	*x = BatchVerificationProofsReply{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_disperser_disperser_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -764,13 +984,13 @@ func (x *BlobVerificationProof) Reset() {
 	}
 }
 
-func (x *BlobVerificationProof) String() string {
+func (x *BatchVerificationProofsReply) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BlobVerificationProof) ProtoMessage() {}
+func (*BatchVerificationProofsReply) ProtoMessage() {}
 
-func (x *BlobVerificationProof) ProtoReflect() protoreflect.Message {
+func (x *BatchVerificationProofsReply) ProtoReflect() protoreflect.Message {
 	mi := &file_disperser_disperser_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -782,67 +1002,44 @@ func (x *BlobVerificationProof) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BlobVerificationProof.ProtoReflect.Descriptor instead.
-func (*BlobVerificationProof) Descriptor() ([]byte, []int) {
+// Deprecated: Use BatchVerificationProofsReply.ProtoReflect.Descriptor instead.
+func (*BatchVerificationProofsReply) Descriptor() ([]byte, []int) {
 	return file_disperser_disperser_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *BlobVerificationProof) GetBatchId() uint32 {
+func (x *BatchVerificationProofsReply) GetBatchId() uint32 {
 	if x != nil {
 		return x.BatchId
 	}
 	return 0
 }
 
-func (x *BlobVerificationProof) GetBlobIndex() uint32 {
-	if x != nil {
-		return x.BlobIndex
-	}
-	return 0
-}
-
-func (x *BlobVerificationProof) GetBatchMetadata() *BatchMetadata {
+func (x *BatchVerificationProofsReply) GetBatchMetadata() *BatchMetadata {
 	if x != nil {
 		return x.BatchMetadata
 	}
 	return nil
 }
 
-func (x *BlobVerificationProof) GetInclusionProof() []byte {
-	if x != nil {
-		return x.InclusionProof
-	}
-	return nil
-}
-
-func (x *BlobVerificationProof) GetQuorumIndexes() []byte {
+func (x *BatchVerificationProofsReply) GetBlobProofs() []*BlobInclusionProof {
 	if x != nil {
-		return x.QuorumIndexes
+		return x.BlobProofs
 	}
 	return nil
 }
 
-type BatchMetadata struct {
+// BlobProofBundleRequest is used to query the proof bundle of a confirmed blob.
+type BlobProofBundleRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	BatchHeader *BatchHeader `protobuf:"bytes,1,opt,name=batch_header,json=batchHeader,proto3" json:"batch_header,omitempty"`
-	// The hash of all public keys of the operators that did not sign the batch.
-	SignatoryRecordHash []byte `protobuf:"bytes,2,opt,name=signatory_record_hash,json=signatoryRecordHash,proto3" json:"signatory_record_hash,omitempty"`
-	// The gas fee of confirming this batch. It's the bytes representation of
-	// a big.Int value.
-	Fee []byte `protobuf:"bytes,3,opt,name=fee,proto3" json:"fee,omitempty"`
-	// The Ethereum block number at which the batch is confirmed onchain.
-	ConfirmationBlockNumber uint32 `protobuf:"varint,4,opt,name=confirmation_block_number,json=confirmationBlockNumber,proto3" json:"confirmation_block_number,omitempty"`
-	// This is the hash of the ReducedBatchHeader defined onchain, see:
-	// https://github.com/Layr-Labs/eigenda/blob/master/contracts/src/interfaces/IEigenDAServiceManager.sol#L43
-	// The is the message that the operators will sign their signatures on.
-	BatchHeaderHash []byte `protobuf:"bytes,5,opt,name=batch_header_hash,json=batchHeaderHash,proto3" json:"batch_header_hash,omitempty"`
+	// The ID of the blob, as returned by DisperseBlob.
+	RequestId []byte `protobuf:"bytes,1,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
 }
 
-func (x *BatchMetadata) Reset() {
-	*x = BatchMetadata{}
+func (x *BlobProofBundleRequest) Reset() {
+	*x = BlobProofBundleRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_disperser_disperser_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -850,13 +1047,13 @@ func (x *BatchMetadata) Reset() {
 	}
 }
 
-func (x *BatchMetadata) String() string {
+func (x *BlobProofBundleRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BatchMetadata) ProtoMessage() {}
+func (*BlobProofBundleRequest) ProtoMessage() {}
 
-func (x *BatchMetadata) ProtoReflect() protoreflect.Message {
+func (x *BlobProofBundleRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_disperser_disperser_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -868,81 +1065,112 @@ func (x *BatchMetadata) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BatchMetadata.ProtoReflect.Descriptor instead.
-func (*BatchMetadata) Descriptor() ([]byte, []int) {
+// Deprecated: Use BlobProofBundleRequest.ProtoReflect.Descriptor instead.
+func (*BlobProofBundleRequest) Descriptor() ([]byte, []int) {
 	return file_disperser_disperser_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *BatchMetadata) GetBatchHeader() *BatchHeader {
+func (x *BlobProofBundleRequest) GetRequestId() []byte {
 	if x != nil {
-		return x.BatchHeader
+		return x.RequestId
 	}
 	return nil
 }
 
-func (x *BatchMetadata) GetSignatoryRecordHash() []byte {
-	if x != nil {
-		return x.SignatoryRecordHash
+// BlobProofBundleReply contains the proof bundle of a confirmed blob.
+type BlobProofBundleReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The ABI encoding of the bundle, abi.encode(blobHeader, blobVerificationProof, signature)
+	// where blobHeader is an IEigenDAServiceManager.BlobHeader, blobVerificationProof is an
+	// EigenDABlobUtils.BlobVerificationProof and signature is the tuple
+	// (BN254.G2Point apkG2, BN254.G1Point sigma, BN254.G1Point[] quorumApks, bytes32[] nonSignerPubkeyHashes)
+	// of the aggregate signature of the batch over its reduced batch header hash.
+	Bundle []byte `protobuf:"bytes,1,opt,name=bundle,proto3" json:"bundle,omitempty"`
+	// The hash of the batch header of the blob, as in BatchMetadata.batch_header_hash.
+	BatchHeaderHash []byte `protobuf:"bytes,2,opt,name=batch_header_hash,json=batchHeaderHash,proto3" json:"batch_header_hash,omitempty"`
+}
+
+func (x *BlobProofBundleReply) Reset() {
+	*x = BlobProofBundleReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_disperser_disperser_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return nil
 }
 
-func (x *BatchMetadata) GetFee() []byte {
-	if x != nil {
-		return x.Fee
+func (x *BlobProofBundleReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlobProofBundleReply) ProtoMessage() {}
+
+func (x *BlobProofBundleReply) ProtoReflect() protoreflect.Message {
+	mi := &file_disperser_disperser_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *BatchMetadata) GetConfirmationBlockNumber() uint32 {
+// Deprecated: Use BlobProofBundleReply.ProtoReflect.Descriptor instead.
+func (*BlobProofBundleReply) Descriptor() ([]byte, []int) {
+	return file_disperser_disperser_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *BlobProofBundleReply) GetBundle() []byte {
 	if x != nil {
-		return x.ConfirmationBlockNumber
+		return x.Bundle
 	}
-	return 0
+	return nil
 }
 
-func (x *BatchMetadata) GetBatchHeaderHash() []byte {
+func (x *BlobProofBundleReply) GetBatchHeaderHash() []byte {
 	if x != nil {
 		return x.BatchHeaderHash
 	}
 	return nil
 }
 
-type BatchHeader struct {
+// OperatorStateAtBatchRequest is used to query the operator state that a batch was made with.
+type OperatorStateAtBatchRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// The root of the merkle tree with the hashes of blob headers as leaves.
-	BatchRoot []byte `protobuf:"bytes,1,opt,name=batch_root,json=batchRoot,proto3" json:"batch_root,omitempty"`
-	// All quorums associated with blobs in this batch.
-	QuorumNumbers []byte `protobuf:"bytes,2,opt,name=quorum_numbers,json=quorumNumbers,proto3" json:"quorum_numbers,omitempty"`
-	// The percentage of stake that has signed for this batch.
-	// The quorum_signed_percentages[i] is percentage for the quorum_numbers[i].
-	QuorumSignedPercentages []byte `protobuf:"bytes,3,opt,name=quorum_signed_percentages,json=quorumSignedPercentages,proto3" json:"quorum_signed_percentages,omitempty"`
-	// The Ethereum block number at which the batch was created.
-	// The Disperser will encode and disperse the blobs based on the onchain info
-	// (e.g. operator stakes) at this block number.
-	ReferenceBlockNumber uint32 `protobuf:"varint,4,opt,name=reference_block_number,json=referenceBlockNumber,proto3" json:"reference_block_number,omitempty"`
+	// The hash of the batch header, as in BatchMetadata.batch_header_hash.
+	BatchHeaderHash []byte `protobuf:"bytes,1,opt,name=batch_header_hash,json=batchHeaderHash,proto3" json:"batch_header_hash,omitempty"`
+	// The maximum number of operator stakes to return. It is capped by the disperser, which
+	// also applies its cap when it is 0.
+	PageSize uint32 `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// The next_page_token of the previous reply, or empty for the first page.
+	PageToken string `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
 }
 
-func (x *BatchHeader) Reset() {
-	*x = BatchHeader{}
+func (x *OperatorStateAtBatchRequest) Reset() {
+	*x = OperatorStateAtBatchRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_disperser_disperser_proto_msgTypes[12]
+		mi := &file_disperser_disperser_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *BatchHeader) String() string {
+func (x *OperatorStateAtBatchRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BatchHeader) ProtoMessage() {}
+func (*OperatorStateAtBatchRequest) ProtoMessage() {}
 
-func (x *BatchHeader) ProtoReflect() protoreflect.Message {
-	mi := &file_disperser_disperser_proto_msgTypes[12]
+func (x *OperatorStateAtBatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_disperser_disperser_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -953,191 +1181,2557 @@ func (x *BatchHeader) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BatchHeader.ProtoReflect.Descriptor instead.
-func (*BatchHeader) Descriptor() ([]byte, []int) {
-	return file_disperser_disperser_proto_rawDescGZIP(), []int{12}
+// Deprecated: Use OperatorStateAtBatchRequest.ProtoReflect.Descriptor instead.
+func (*OperatorStateAtBatchRequest) Descriptor() ([]byte, []int) {
+	return file_disperser_disperser_proto_rawDescGZIP(), []int{13}
 }
 
-func (x *BatchHeader) GetBatchRoot() []byte {
+func (x *OperatorStateAtBatchRequest) GetBatchHeaderHash() []byte {
 	if x != nil {
-		return x.BatchRoot
+		return x.BatchHeaderHash
 	}
 	return nil
 }
 
-func (x *BatchHeader) GetQuorumNumbers() []byte {
+func (x *OperatorStateAtBatchRequest) GetPageSize() uint32 {
 	if x != nil {
-		return x.QuorumNumbers
+		return x.PageSize
 	}
-	return nil
+	return 0
 }
 
-func (x *BatchHeader) GetQuorumSignedPercentages() []byte {
+func (x *OperatorStateAtBatchRequest) GetPageToken() string {
 	if x != nil {
-		return x.QuorumSignedPercentages
+		return x.PageToken
 	}
-	return nil
+	return ""
 }
 
-func (x *BatchHeader) GetReferenceBlockNumber() uint32 {
-	if x != nil {
-		return x.ReferenceBlockNumber
+// OperatorStateAtBatchReply contains a page of the operator state that a batch was made with.
+type OperatorStateAtBatchReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The reference block number of the batch, at which the operator state was read.
+	ReferenceBlockNumber uint32 `protobuf:"varint,1,opt,name=reference_block_number,json=referenceBlockNumber,proto3" json:"reference_block_number,omitempty"`
+	// The quantization factor the chunks of the batch were assigned with.
+	QuantizationFactor uint32 `protobuf:"varint,2,opt,name=quantization_factor,json=quantizationFactor,proto3" json:"quantization_factor,omitempty"`
+	// The total stake of each quorum of the batch, ordered by quorum number. It is the same in all pages.
+	QuorumTotals []*QuorumStake `protobuf:"bytes,3,rep,name=quorum_totals,json=quorumTotals,proto3" json:"quorum_totals,omitempty"`
+	// The stakes of the operators in each quorum, ordered by quorum number and operator index.
+	Operators []*OperatorStake `protobuf:"bytes,4,rep,name=operators,proto3" json:"operators,omitempty"`
+	// The token to request the next page with, or empty if this is the last page.
+	NextPageToken string `protobuf:"bytes,5,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	// The margin by which the chunks of the batch were over-provisioned, as a percentage of
+	// the nominal number of chunks.
+	OverprovisionPercent uint32 `protobuf:"varint,6,opt,name=overprovision_percent,json=overprovisionPercent,proto3" json:"overprovision_percent,omitempty"`
+}
+
+func (x *OperatorStateAtBatchReply) Reset() {
+	*x = OperatorStateAtBatchReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_disperser_disperser_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return 0
 }
 
-var File_disperser_disperser_proto protoreflect.FileDescriptor
+func (x *OperatorStateAtBatchReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
 
-var file_disperser_disperser_proto_rawDesc = []byte{
-	0x0a, 0x19, 0x64, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65, 0x72, 0x2f, 0x64, 0x69, 0x73, 0x70,
-	0x65, 0x72, 0x73, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x09, 0x64, 0x69, 0x73,
-	0x70, 0x65, 0x72, 0x73, 0x65, 0x72, 0x22, 0x6d, 0x0a, 0x13, 0x44, 0x69, 0x73, 0x70, 0x65, 0x72,
-	0x73, 0x65, 0x42, 0x6c, 0x6f, 0x62, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a,
-	0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74,
-	0x61, 0x12, 0x42, 0x0a, 0x0f, 0x73, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x5f, 0x70, 0x61,
+func (*OperatorStateAtBatchReply) ProtoMessage() {}
+
+func (x *OperatorStateAtBatchReply) ProtoReflect() protoreflect.Message {
+	mi := &file_disperser_disperser_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OperatorStateAtBatchReply.ProtoReflect.Descriptor instead.
+func (*OperatorStateAtBatchReply) Descriptor() ([]byte, []int) {
+	return file_disperser_disperser_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *OperatorStateAtBatchReply) GetReferenceBlockNumber() uint32 {
+	if x != nil {
+		return x.ReferenceBlockNumber
+	}
+	return 0
+}
+
+func (x *OperatorStateAtBatchReply) GetQuantizationFactor() uint32 {
+	if x != nil {
+		return x.QuantizationFactor
+	}
+	return 0
+}
+
+func (x *OperatorStateAtBatchReply) GetQuorumTotals() []*QuorumStake {
+	if x != nil {
+		return x.QuorumTotals
+	}
+	return nil
+}
+
+func (x *OperatorStateAtBatchReply) GetOperators() []*OperatorStake {
+	if x != nil {
+		return x.Operators
+	}
+	return nil
+}
+
+func (x *OperatorStateAtBatchReply) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *OperatorStateAtBatchReply) GetOverprovisionPercent() uint32 {
+	if x != nil {
+		return x.OverprovisionPercent
+	}
+	return 0
+}
+
+// QuorumStake is the total stake of the operators of a quorum.
+type QuorumStake struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	QuorumNumber uint32 `protobuf:"varint,1,opt,name=quorum_number,json=quorumNumber,proto3" json:"quorum_number,omitempty"`
+	// The total stake, as a big-endian unsigned integer.
+	Stake []byte `protobuf:"bytes,2,opt,name=stake,proto3" json:"stake,omitempty"`
+	// The number of operators in the quorum.
+	NumOperators uint32 `protobuf:"varint,3,opt,name=num_operators,json=numOperators,proto3" json:"num_operators,omitempty"`
+}
+
+func (x *QuorumStake) Reset() {
+	*x = QuorumStake{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_disperser_disperser_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *QuorumStake) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuorumStake) ProtoMessage() {}
+
+func (x *QuorumStake) ProtoReflect() protoreflect.Message {
+	mi := &file_disperser_disperser_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuorumStake.ProtoReflect.Descriptor instead.
+func (*QuorumStake) Descriptor() ([]byte, []int) {
+	return file_disperser_disperser_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *QuorumStake) GetQuorumNumber() uint32 {
+	if x != nil {
+		return x.QuorumNumber
+	}
+	return 0
+}
+
+func (x *QuorumStake) GetStake() []byte {
+	if x != nil {
+		return x.Stake
+	}
+	return nil
+}
+
+func (x *QuorumStake) GetNumOperators() uint32 {
+	if x != nil {
+		return x.NumOperators
+	}
+	return 0
+}
+
+// OperatorStake is the stake of an operator in a quorum.
+type OperatorStake struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	OperatorId   []byte `protobuf:"bytes,1,opt,name=operator_id,json=operatorId,proto3" json:"operator_id,omitempty"`
+	QuorumNumber uint32 `protobuf:"varint,2,opt,name=quorum_number,json=quorumNumber,proto3" json:"quorum_number,omitempty"`
+	// The index of the operator within the quorum.
+	Index uint32 `protobuf:"varint,3,opt,name=index,proto3" json:"index,omitempty"`
+	// The stake, as a big-endian unsigned integer.
+	Stake []byte `protobuf:"bytes,4,opt,name=stake,proto3" json:"stake,omitempty"`
+}
+
+func (x *OperatorStake) Reset() {
+	*x = OperatorStake{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_disperser_disperser_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OperatorStake) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OperatorStake) ProtoMessage() {}
+
+func (x *OperatorStake) ProtoReflect() protoreflect.Message {
+	mi := &file_disperser_disperser_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OperatorStake.ProtoReflect.Descriptor instead.
+func (*OperatorStake) Descriptor() ([]byte, []int) {
+	return file_disperser_disperser_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *OperatorStake) GetOperatorId() []byte {
+	if x != nil {
+		return x.OperatorId
+	}
+	return nil
+}
+
+func (x *OperatorStake) GetQuorumNumber() uint32 {
+	if x != nil {
+		return x.QuorumNumber
+	}
+	return 0
+}
+
+func (x *OperatorStake) GetIndex() uint32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *OperatorStake) GetStake() []byte {
+	if x != nil {
+		return x.Stake
+	}
+	return nil
+}
+
+// ExtendBlobRetentionRequest is used to extend the retention of a dispersed blob.
+type ExtendBlobRetentionRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The ID of the blob, as returned by DisperseBlob.
+	RequestId []byte `protobuf:"bytes,1,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	// The duration in seconds to add to the current expiry of the blob.
+	AdditionalDurationSeconds uint64 `protobuf:"varint,2,opt,name=additional_duration_seconds,json=additionalDurationSeconds,proto3" json:"additional_duration_seconds,omitempty"`
+}
+
+func (x *ExtendBlobRetentionRequest) Reset() {
+	*x = ExtendBlobRetentionRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_disperser_disperser_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExtendBlobRetentionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExtendBlobRetentionRequest) ProtoMessage() {}
+
+func (x *ExtendBlobRetentionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_disperser_disperser_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExtendBlobRetentionRequest.ProtoReflect.Descriptor instead.
+func (*ExtendBlobRetentionRequest) Descriptor() ([]byte, []int) {
+	return file_disperser_disperser_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ExtendBlobRetentionRequest) GetRequestId() []byte {
+	if x != nil {
+		return x.RequestId
+	}
+	return nil
+}
+
+func (x *ExtendBlobRetentionRequest) GetAdditionalDurationSeconds() uint64 {
+	if x != nil {
+		return x.AdditionalDurationSeconds
+	}
+	return 0
+}
+
+// ExtendBlobRetentionReply contains the new expiry of the blob.
+type ExtendBlobRetentionReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The unix epoch time in seconds at which the blob now expires.
+	Expiry uint64 `protobuf:"varint,1,opt,name=expiry,proto3" json:"expiry,omitempty"`
+}
+
+func (x *ExtendBlobRetentionReply) Reset() {
+	*x = ExtendBlobRetentionReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_disperser_disperser_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExtendBlobRetentionReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExtendBlobRetentionReply) ProtoMessage() {}
+
+func (x *ExtendBlobRetentionReply) ProtoReflect() protoreflect.Message {
+	mi := &file_disperser_disperser_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExtendBlobRetentionReply.ProtoReflect.Descriptor instead.
+func (*ExtendBlobRetentionReply) Descriptor() ([]byte, []int) {
+	return file_disperser_disperser_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ExtendBlobRetentionReply) GetExpiry() uint64 {
+	if x != nil {
+		return x.Expiry
+	}
+	return 0
+}
+
+// ResubmitBlobRequest is used to disperse again a blob which failed.
+type ResubmitBlobRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The ID of the failed blob, as returned by DisperseBlob.
+	RequestId []byte `protobuf:"bytes,1,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	// The security params of the new dispersal, as in DisperseBlobRequest.security_params.
+	SecurityParams []*SecurityParams `protobuf:"bytes,2,rep,name=security_params,json=securityParams,proto3" json:"security_params,omitempty"`
+}
+
+func (x *ResubmitBlobRequest) Reset() {
+	*x = ResubmitBlobRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_disperser_disperser_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ResubmitBlobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResubmitBlobRequest) ProtoMessage() {}
+
+func (x *ResubmitBlobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_disperser_disperser_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResubmitBlobRequest.ProtoReflect.Descriptor instead.
+func (*ResubmitBlobRequest) Descriptor() ([]byte, []int) {
+	return file_disperser_disperser_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ResubmitBlobRequest) GetRequestId() []byte {
+	if x != nil {
+		return x.RequestId
+	}
+	return nil
+}
+
+func (x *ResubmitBlobRequest) GetSecurityParams() []*SecurityParams {
+	if x != nil {
+		return x.SecurityParams
+	}
+	return nil
+}
+
+// ResubmitBlobReply contains the request ID of the new dispersal of the blob.
+type ResubmitBlobReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The status of the blob associated with the request_id.
+	Result BlobStatus `protobuf:"varint,1,opt,name=result,proto3,enum=disperser.BlobStatus" json:"result,omitempty"`
+	// The request ID of the new dispersal, to query its status with GetBlobStatus.
+	RequestId []byte `protobuf:"bytes,2,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+}
+
+func (x *ResubmitBlobReply) Reset() {
+	*x = ResubmitBlobReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_disperser_disperser_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ResubmitBlobReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResubmitBlobReply) ProtoMessage() {}
+
+func (x *ResubmitBlobReply) ProtoReflect() protoreflect.Message {
+	mi := &file_disperser_disperser_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResubmitBlobReply.ProtoReflect.Descriptor instead.
+func (*ResubmitBlobReply) Descriptor() ([]byte, []int) {
+	return file_disperser_disperser_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ResubmitBlobReply) GetResult() BlobStatus {
+	if x != nil {
+		return x.Result
+	}
+	return BlobStatus_UNKNOWN
+}
+
+func (x *ResubmitBlobReply) GetRequestId() []byte {
+	if x != nil {
+		return x.RequestId
+	}
+	return nil
+}
+
+// BatchCostRequest is used to query the cost of the confirmation of a batch.
+type BatchCostRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The hash of the batch header, as in BatchMetadata.batch_header_hash.
+	BatchHeaderHash []byte `protobuf:"bytes,1,opt,name=batch_header_hash,json=batchHeaderHash,proto3" json:"batch_header_hash,omitempty"`
+}
+
+func (x *BatchCostRequest) Reset() {
+	*x = BatchCostRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_disperser_disperser_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BatchCostRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchCostRequest) ProtoMessage() {}
+
+func (x *BatchCostRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_disperser_disperser_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchCostRequest.ProtoReflect.Descriptor instead.
+func (*BatchCostRequest) Descriptor() ([]byte, []int) {
+	return file_disperser_disperser_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *BatchCostRequest) GetBatchHeaderHash() []byte {
+	if x != nil {
+		return x.BatchHeaderHash
+	}
+	return nil
+}
+
+// BatchCostReply contains the cost of the confirmation transaction of a batch.
+// The amounts in wei are big-endian unsigned integers.
+type BatchCostReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ConfirmationTxnHash     []byte `protobuf:"bytes,1,opt,name=confirmation_txn_hash,json=confirmationTxnHash,proto3" json:"confirmation_txn_hash,omitempty"`
+	ConfirmationBlockNumber uint32 `protobuf:"varint,2,opt,name=confirmation_block_number,json=confirmationBlockNumber,proto3" json:"confirmation_block_number,omitempty"`
+	GasUsed                 uint64 `protobuf:"varint,3,opt,name=gas_used,json=gasUsed,proto3" json:"gas_used,omitempty"`
+	// The price in wei paid per unit of gas.
+	EffectiveGasPrice []byte `protobuf:"bytes,4,opt,name=effective_gas_price,json=effectiveGasPrice,proto3" json:"effective_gas_price,omitempty"`
+	// The cost in wei of the confirmation transaction, gas_used * effective_gas_price.
+	TotalCost []byte `protobuf:"bytes,5,opt,name=total_cost,json=totalCost,proto3" json:"total_cost,omitempty"`
+	// The shares of the total cost attributed to the confirmed blobs of the batch,
+	// ordered by blob index. They sum to total_cost.
+	BlobCosts []*BlobCost `protobuf:"bytes,6,rep,name=blob_costs,json=blobCosts,proto3" json:"blob_costs,omitempty"`
+}
+
+func (x *BatchCostReply) Reset() {
+	*x = BatchCostReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_disperser_disperser_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BatchCostReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchCostReply) ProtoMessage() {}
+
+func (x *BatchCostReply) ProtoReflect() protoreflect.Message {
+	mi := &file_disperser_disperser_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchCostReply.ProtoReflect.Descriptor instead.
+func (*BatchCostReply) Descriptor() ([]byte, []int) {
+	return file_disperser_disperser_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *BatchCostReply) GetConfirmationTxnHash() []byte {
+	if x != nil {
+		return x.ConfirmationTxnHash
+	}
+	return nil
+}
+
+func (x *BatchCostReply) GetConfirmationBlockNumber() uint32 {
+	if x != nil {
+		return x.ConfirmationBlockNumber
+	}
+	return 0
+}
+
+func (x *BatchCostReply) GetGasUsed() uint64 {
+	if x != nil {
+		return x.GasUsed
+	}
+	return 0
+}
+
+func (x *BatchCostReply) GetEffectiveGasPrice() []byte {
+	if x != nil {
+		return x.EffectiveGasPrice
+	}
+	return nil
+}
+
+func (x *BatchCostReply) GetTotalCost() []byte {
+	if x != nil {
+		return x.TotalCost
+	}
+	return nil
+}
+
+func (x *BatchCostReply) GetBlobCosts() []*BlobCost {
+	if x != nil {
+		return x.BlobCosts
+	}
+	return nil
+}
+
+// BlobCost is the share of the cost of a batch attributed to one of its blobs.
+type BlobCost struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The ID of the blob, as returned by DisperseBlob.
+	RequestId []byte `protobuf:"bytes,1,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	BlobIndex uint32 `protobuf:"varint,2,opt,name=blob_index,json=blobIndex,proto3" json:"blob_index,omitempty"`
+	// The encoded length of the blob in symbols, summed over its quorums.
+	EncodedLength uint64 `protobuf:"varint,3,opt,name=encoded_length,json=encodedLength,proto3" json:"encoded_length,omitempty"`
+	// The share of the cost of the batch in wei.
+	Cost []byte `protobuf:"bytes,4,opt,name=cost,proto3" json:"cost,omitempty"`
+}
+
+func (x *BlobCost) Reset() {
+	*x = BlobCost{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_disperser_disperser_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BlobCost) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlobCost) ProtoMessage() {}
+
+func (x *BlobCost) ProtoReflect() protoreflect.Message {
+	mi := &file_disperser_disperser_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlobCost.ProtoReflect.Descriptor instead.
+func (*BlobCost) Descriptor() ([]byte, []int) {
+	return file_disperser_disperser_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *BlobCost) GetRequestId() []byte {
+	if x != nil {
+		return x.RequestId
+	}
+	return nil
+}
+
+func (x *BlobCost) GetBlobIndex() uint32 {
+	if x != nil {
+		return x.BlobIndex
+	}
+	return 0
+}
+
+func (x *BlobCost) GetEncodedLength() uint64 {
+	if x != nil {
+		return x.EncodedLength
+	}
+	return 0
+}
+
+func (x *BlobCost) GetCost() []byte {
+	if x != nil {
+		return x.Cost
+	}
+	return nil
+}
+
+// OperatorVersionsRequest is used to query the versions of the operators which signed a batch.
+type OperatorVersionsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The hash of the batch header, as in BatchMetadata.batch_header_hash.
+	BatchHeaderHash []byte `protobuf:"bytes,1,opt,name=batch_header_hash,json=batchHeaderHash,proto3" json:"batch_header_hash,omitempty"`
+}
+
+func (x *OperatorVersionsRequest) Reset() {
+	*x = OperatorVersionsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_disperser_disperser_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OperatorVersionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OperatorVersionsRequest) ProtoMessage() {}
+
+func (x *OperatorVersionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_disperser_disperser_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OperatorVersionsRequest.ProtoReflect.Descriptor instead.
+func (*OperatorVersionsRequest) Descriptor() ([]byte, []int) {
+	return file_disperser_disperser_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *OperatorVersionsRequest) GetBatchHeaderHash() []byte {
+	if x != nil {
+		return x.BatchHeaderHash
+	}
+	return nil
+}
+
+// OperatorVersionsReply contains the versions advertised by the operators for a batch.
+type OperatorVersionsReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The versions of the operators which replied to the dispersal, ordered by operator ID.
+	Operators []*OperatorVersion `protobuf:"bytes,1,rep,name=operators,proto3" json:"operators,omitempty"`
+	// The share of the stake of each quorum advertising each optional feature known to
+	// the Disperser, ordered by feature and quorum number.
+	Features []*FeatureStake `protobuf:"bytes,2,rep,name=features,proto3" json:"features,omitempty"`
+}
+
+func (x *OperatorVersionsReply) Reset() {
+	*x = OperatorVersionsReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_disperser_disperser_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OperatorVersionsReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OperatorVersionsReply) ProtoMessage() {}
+
+func (x *OperatorVersionsReply) ProtoReflect() protoreflect.Message {
+	mi := &file_disperser_disperser_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OperatorVersionsReply.ProtoReflect.Descriptor instead.
+func (*OperatorVersionsReply) Descriptor() ([]byte, []int) {
+	return file_disperser_disperser_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *OperatorVersionsReply) GetOperators() []*OperatorVersion {
+	if x != nil {
+		return x.Operators
+	}
+	return nil
+}
+
+func (x *OperatorVersionsReply) GetFeatures() []*FeatureStake {
+	if x != nil {
+		return x.Features
+	}
+	return nil
+}
+
+// OperatorVersion is the version advertised by an operator.
+type OperatorVersion struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	OperatorId []byte `protobuf:"bytes,1,opt,name=operator_id,json=operatorId,proto3" json:"operator_id,omitempty"`
+	// The semantic version of the node software, or empty if the node doesn't advertise it.
+	Version string `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	// The bitmask of the optional features supported by the node.
+	Features uint64 `protobuf:"varint,3,opt,name=features,proto3" json:"features,omitempty"`
+}
+
+func (x *OperatorVersion) Reset() {
+	*x = OperatorVersion{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_disperser_disperser_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OperatorVersion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OperatorVersion) ProtoMessage() {}
+
+func (x *OperatorVersion) ProtoReflect() protoreflect.Message {
+	mi := &file_disperser_disperser_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OperatorVersion.ProtoReflect.Descriptor instead.
+func (*OperatorVersion) Descriptor() ([]byte, []int) {
+	return file_disperser_disperser_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *OperatorVersion) GetOperatorId() []byte {
+	if x != nil {
+		return x.OperatorId
+	}
+	return nil
+}
+
+func (x *OperatorVersion) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *OperatorVersion) GetFeatures() uint64 {
+	if x != nil {
+		return x.Features
+	}
+	return 0
+}
+
+// FeatureStake is the share of the stake of a quorum advertising an optional feature.
+type FeatureStake struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The bit of the feature in OperatorVersion.features.
+	Feature      uint64 `protobuf:"varint,1,opt,name=feature,proto3" json:"feature,omitempty"`
+	QuorumNumber uint32 `protobuf:"varint,2,opt,name=quorum_number,json=quorumNumber,proto3" json:"quorum_number,omitempty"`
+	// The percentage of the stake of the quorum advertising the feature.
+	Percentage uint32 `protobuf:"varint,3,opt,name=percentage,proto3" json:"percentage,omitempty"`
+}
+
+func (x *FeatureStake) Reset() {
+	*x = FeatureStake{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_disperser_disperser_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FeatureStake) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FeatureStake) ProtoMessage() {}
+
+func (x *FeatureStake) ProtoReflect() protoreflect.Message {
+	mi := &file_disperser_disperser_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FeatureStake.ProtoReflect.Descriptor instead.
+func (*FeatureStake) Descriptor() ([]byte, []int) {
+	return file_disperser_disperser_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *FeatureStake) GetFeature() uint64 {
+	if x != nil {
+		return x.Feature
+	}
+	return 0
+}
+
+func (x *FeatureStake) GetQuorumNumber() uint32 {
+	if x != nil {
+		return x.QuorumNumber
+	}
+	return 0
+}
+
+func (x *FeatureStake) GetPercentage() uint32 {
+	if x != nil {
+		return x.Percentage
+	}
+	return 0
+}
+
+// DisperserConfigRequest is used to query the configuration of the Disperser.
+type DisperserConfigRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *DisperserConfigRequest) Reset() {
+	*x = DisperserConfigRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_disperser_disperser_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DisperserConfigRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DisperserConfigRequest) ProtoMessage() {}
+
+func (x *DisperserConfigRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_disperser_disperser_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DisperserConfigRequest.ProtoReflect.Descriptor instead.
+func (*DisperserConfigRequest) Descriptor() ([]byte, []int) {
+	return file_disperser_disperser_proto_rawDescGZIP(), []int{28}
+}
+
+// DisperserConfigReply contains the configuration of the Disperser.
+type DisperserConfigReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The policy the security params of the dispersed blobs must comply with.
+	// Dispersals violating it are rejected with the SECURITY_POLICY_VIOLATION reason.
+	SecurityPolicy *SecurityPolicy `protobuf:"bytes,1,opt,name=security_policy,json=securityPolicy,proto3" json:"security_policy,omitempty"`
+	// The quantization factor the chunks of the blobs are assigned with, which
+	// must be allowed by the security policy of each quorum of a blob.
+	QuantizationFactor uint32 `protobuf:"varint,2,opt,name=quantization_factor,json=quantizationFactor,proto3" json:"quantization_factor,omitempty"`
+}
+
+func (x *DisperserConfigReply) Reset() {
+	*x = DisperserConfigReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_disperser_disperser_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DisperserConfigReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DisperserConfigReply) ProtoMessage() {}
+
+func (x *DisperserConfigReply) ProtoReflect() protoreflect.Message {
+	mi := &file_disperser_disperser_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DisperserConfigReply.ProtoReflect.Descriptor instead.
+func (*DisperserConfigReply) Descriptor() ([]byte, []int) {
+	return file_disperser_disperser_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *DisperserConfigReply) GetSecurityPolicy() *SecurityPolicy {
+	if x != nil {
+		return x.SecurityPolicy
+	}
+	return nil
+}
+
+func (x *DisperserConfigReply) GetQuantizationFactor() uint32 {
+	if x != nil {
+		return x.QuantizationFactor
+	}
+	return 0
+}
+
+// RateLimitStatusRequest is used to query the rate limit status of the account of
+// the caller.
+type RateLimitStatusRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *RateLimitStatusRequest) Reset() {
+	*x = RateLimitStatusRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_disperser_disperser_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RateLimitStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RateLimitStatusRequest) ProtoMessage() {}
+
+func (x *RateLimitStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_disperser_disperser_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RateLimitStatusRequest.ProtoReflect.Descriptor instead.
+func (*RateLimitStatusRequest) Descriptor() ([]byte, []int) {
+	return file_disperser_disperser_proto_rawDescGZIP(), []int{30}
+}
+
+// RateLimitStatusReply contains the rate limit status of the account of the caller.
+type RateLimitStatusReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The account the requests of the caller are charged to, e.g. "ip:1.2.3.4".
+	AccountId string `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	// The number of bytes the account can disperse in any 24 hour window, 0 if the
+	// account has no daily quota. Dispersals exceeding it are rejected with the
+	// DAILY_QUOTA_EXCEEDED reason.
+	DailyQuota uint64 `protobuf:"varint,2,opt,name=daily_quota,json=dailyQuota,proto3" json:"daily_quota,omitempty"`
+	// The number of bytes the account dispersed in the last 24 hours.
+	DailyQuotaUsed uint64 `protobuf:"varint,3,opt,name=daily_quota_used,json=dailyQuotaUsed,proto3" json:"daily_quota_used,omitempty"`
+	// The number of bytes the account can still disperse in the current window.
+	DailyQuotaRemaining uint64 `protobuf:"varint,4,opt,name=daily_quota_remaining,json=dailyQuotaRemaining,proto3" json:"daily_quota_remaining,omitempty"`
+}
+
+func (x *RateLimitStatusReply) Reset() {
+	*x = RateLimitStatusReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_disperser_disperser_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RateLimitStatusReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RateLimitStatusReply) ProtoMessage() {}
+
+func (x *RateLimitStatusReply) ProtoReflect() protoreflect.Message {
+	mi := &file_disperser_disperser_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RateLimitStatusReply.ProtoReflect.Descriptor instead.
+func (*RateLimitStatusReply) Descriptor() ([]byte, []int) {
+	return file_disperser_disperser_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *RateLimitStatusReply) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *RateLimitStatusReply) GetDailyQuota() uint64 {
+	if x != nil {
+		return x.DailyQuota
+	}
+	return 0
+}
+
+func (x *RateLimitStatusReply) GetDailyQuotaUsed() uint64 {
+	if x != nil {
+		return x.DailyQuotaUsed
+	}
+	return 0
+}
+
+func (x *RateLimitStatusReply) GetDailyQuotaRemaining() uint64 {
+	if x != nil {
+		return x.DailyQuotaRemaining
+	}
+	return 0
+}
+
+// SecurityPolicy bounds the security params of the dispersed blobs in each quorum.
+type SecurityPolicy struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The bounds of the quorums which have no bounds of their own.
+	DefaultBounds *SecurityParamBounds `protobuf:"bytes,1,opt,name=default_bounds,json=defaultBounds,proto3" json:"default_bounds,omitempty"`
+	// The bounds of the quorums which have bounds of their own, applying instead
+	// of the default ones.
+	QuorumBounds []*QuorumSecurityParamBounds `protobuf:"bytes,2,rep,name=quorum_bounds,json=quorumBounds,proto3" json:"quorum_bounds,omitempty"`
+}
+
+func (x *SecurityPolicy) Reset() {
+	*x = SecurityPolicy{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_disperser_disperser_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SecurityPolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SecurityPolicy) ProtoMessage() {}
+
+func (x *SecurityPolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_disperser_disperser_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SecurityPolicy.ProtoReflect.Descriptor instead.
+func (*SecurityPolicy) Descriptor() ([]byte, []int) {
+	return file_disperser_disperser_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *SecurityPolicy) GetDefaultBounds() *SecurityParamBounds {
+	if x != nil {
+		return x.DefaultBounds
+	}
+	return nil
+}
+
+func (x *SecurityPolicy) GetQuorumBounds() []*QuorumSecurityParamBounds {
+	if x != nil {
+		return x.QuorumBounds
+	}
+	return nil
+}
+
+// QuorumSecurityParamBounds are the bounds of the security params of a quorum.
+type QuorumSecurityParamBounds struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	QuorumId uint32               `protobuf:"varint,1,opt,name=quorum_id,json=quorumId,proto3" json:"quorum_id,omitempty"`
+	Bounds   *SecurityParamBounds `protobuf:"bytes,2,opt,name=bounds,proto3" json:"bounds,omitempty"`
+}
+
+func (x *QuorumSecurityParamBounds) Reset() {
+	*x = QuorumSecurityParamBounds{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_disperser_disperser_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *QuorumSecurityParamBounds) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuorumSecurityParamBounds) ProtoMessage() {}
+
+func (x *QuorumSecurityParamBounds) ProtoReflect() protoreflect.Message {
+	mi := &file_disperser_disperser_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuorumSecurityParamBounds.ProtoReflect.Descriptor instead.
+func (*QuorumSecurityParamBounds) Descriptor() ([]byte, []int) {
+	return file_disperser_disperser_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *QuorumSecurityParamBounds) GetQuorumId() uint32 {
+	if x != nil {
+		return x.QuorumId
+	}
+	return 0
+}
+
+func (x *QuorumSecurityParamBounds) GetBounds() *SecurityParamBounds {
+	if x != nil {
+		return x.Bounds
+	}
+	return nil
+}
+
+// SecurityParamBounds bounds the security params of a quorum. A bound which is 0
+// isn't enforced.
+type SecurityParamBounds struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MinAdversaryThreshold uint32 `protobuf:"varint,1,opt,name=min_adversary_threshold,json=minAdversaryThreshold,proto3" json:"min_adversary_threshold,omitempty"`
+	MaxAdversaryThreshold uint32 `protobuf:"varint,2,opt,name=max_adversary_threshold,json=maxAdversaryThreshold,proto3" json:"max_adversary_threshold,omitempty"`
+	MinQuorumThreshold    uint32 `protobuf:"varint,3,opt,name=min_quorum_threshold,json=minQuorumThreshold,proto3" json:"min_quorum_threshold,omitempty"`
+	MaxQuorumThreshold    uint32 `protobuf:"varint,4,opt,name=max_quorum_threshold,json=maxQuorumThreshold,proto3" json:"max_quorum_threshold,omitempty"`
+	// The quantization factors allowed for the quorum. Any is allowed when empty.
+	AllowedQuantizationFactors []uint32 `protobuf:"varint,5,rep,packed,name=allowed_quantization_factors,json=allowedQuantizationFactors,proto3" json:"allowed_quantization_factors,omitempty"`
+}
+
+func (x *SecurityParamBounds) Reset() {
+	*x = SecurityParamBounds{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_disperser_disperser_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SecurityParamBounds) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SecurityParamBounds) ProtoMessage() {}
+
+func (x *SecurityParamBounds) ProtoReflect() protoreflect.Message {
+	mi := &file_disperser_disperser_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SecurityParamBounds.ProtoReflect.Descriptor instead.
+func (*SecurityParamBounds) Descriptor() ([]byte, []int) {
+	return file_disperser_disperser_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *SecurityParamBounds) GetMinAdversaryThreshold() uint32 {
+	if x != nil {
+		return x.MinAdversaryThreshold
+	}
+	return 0
+}
+
+func (x *SecurityParamBounds) GetMaxAdversaryThreshold() uint32 {
+	if x != nil {
+		return x.MaxAdversaryThreshold
+	}
+	return 0
+}
+
+func (x *SecurityParamBounds) GetMinQuorumThreshold() uint32 {
+	if x != nil {
+		return x.MinQuorumThreshold
+	}
+	return 0
+}
+
+func (x *SecurityParamBounds) GetMaxQuorumThreshold() uint32 {
+	if x != nil {
+		return x.MaxQuorumThreshold
+	}
+	return 0
+}
+
+func (x *SecurityParamBounds) GetAllowedQuantizationFactors() []uint32 {
+	if x != nil {
+		return x.AllowedQuantizationFactors
+	}
+	return nil
+}
+
+// SecurityParams contains the security parameters for a given quorum.
+type SecurityParams struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The ID of the quorum.
+	// The quorum must be already registered on EigenLayer. The ID must be
+	// in range [0, 255].
+	QuorumId uint32 `protobuf:"varint,1,opt,name=quorum_id,json=quorumId,proto3" json:"quorum_id,omitempty"`
+	// The max percentage of stake within the quorum that can be held by or delegated
+	// to adversarial operators.
+	//
+	// Clients use this to customize the trust assumption (safety).
+	//
+	// Requires: 1 <= adversary_threshold < 100
+	AdversaryThreshold uint32 `protobuf:"varint,2,opt,name=adversary_threshold,json=adversaryThreshold,proto3" json:"adversary_threshold,omitempty"`
+	// The min percentage of stake that must attest in order to consider
+	// the dispersal is successful.
+	//
+	// Clients use this to customize liveness requirement. The higher this number,
+	// the more operators may need to be up for attesting the blob, so the chance
+	// the dispersal request to fail may be higher (liveness for dispersal).
+	//
+	// Requires:
+	//     1 <= quorum_threshld <= 100
+	//     quorum_threshld > adversary_threshold + 10.
+	//
+	// Note: The adversary_threshold and quorum_threshold will directly influence the
+	// cost of encoding for the blob to be dispersed, roughly by a factor of
+	// 100 / (quorum_threshold - adversary_threshold). See the spec for more details:
+	// https://github.com/Layr-Labs/eigenda/blob/master/docs/spec/protocol-modules/storage/overview.md
+	// Currently it's required that the difference must be at least 10.
+	QuorumThreshold uint32 `protobuf:"varint,3,opt,name=quorum_threshold,json=quorumThreshold,proto3" json:"quorum_threshold,omitempty"`
+}
+
+func (x *SecurityParams) Reset() {
+	*x = SecurityParams{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_disperser_disperser_proto_msgTypes[35]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SecurityParams) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SecurityParams) ProtoMessage() {}
+
+func (x *SecurityParams) ProtoReflect() protoreflect.Message {
+	mi := &file_disperser_disperser_proto_msgTypes[35]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SecurityParams.ProtoReflect.Descriptor instead.
+func (*SecurityParams) Descriptor() ([]byte, []int) {
+	return file_disperser_disperser_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *SecurityParams) GetQuorumId() uint32 {
+	if x != nil {
+		return x.QuorumId
+	}
+	return 0
+}
+
+func (x *SecurityParams) GetAdversaryThreshold() uint32 {
+	if x != nil {
+		return x.AdversaryThreshold
+	}
+	return 0
+}
+
+func (x *SecurityParams) GetQuorumThreshold() uint32 {
+	if x != nil {
+		return x.QuorumThreshold
+	}
+	return 0
+}
+
+// BlobInfo contains information needed to confirm the blob against the EigenDA contracts
+type BlobInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BlobHeader            *BlobHeader            `protobuf:"bytes,1,opt,name=blob_header,json=blobHeader,proto3" json:"blob_header,omitempty"`
+	BlobVerificationProof *BlobVerificationProof `protobuf:"bytes,2,opt,name=blob_verification_proof,json=blobVerificationProof,proto3" json:"blob_verification_proof,omitempty"`
+}
+
+func (x *BlobInfo) Reset() {
+	*x = BlobInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_disperser_disperser_proto_msgTypes[36]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BlobInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlobInfo) ProtoMessage() {}
+
+func (x *BlobInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_disperser_disperser_proto_msgTypes[36]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlobInfo.ProtoReflect.Descriptor instead.
+func (*BlobInfo) Descriptor() ([]byte, []int) {
+	return file_disperser_disperser_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *BlobInfo) GetBlobHeader() *BlobHeader {
+	if x != nil {
+		return x.BlobHeader
+	}
+	return nil
+}
+
+func (x *BlobInfo) GetBlobVerificationProof() *BlobVerificationProof {
+	if x != nil {
+		return x.BlobVerificationProof
+	}
+	return nil
+}
+
+type BlobHeader struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// KZG commitment to the blob.
+	Commitment []byte `protobuf:"bytes,1,opt,name=commitment,proto3" json:"commitment,omitempty"`
+	// The length of the blob in symbols (each symbol is 31 bytes).
+	DataLength uint32 `protobuf:"varint,2,opt,name=data_length,json=dataLength,proto3" json:"data_length,omitempty"`
+	// The params of the quorums that this blob participates in.
+	BlobQuorumParams []*BlobQuorumParam `protobuf:"bytes,3,rep,name=blob_quorum_params,json=blobQuorumParams,proto3" json:"blob_quorum_params,omitempty"`
+}
+
+func (x *BlobHeader) Reset() {
+	*x = BlobHeader{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_disperser_disperser_proto_msgTypes[37]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BlobHeader) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlobHeader) ProtoMessage() {}
+
+func (x *BlobHeader) ProtoReflect() protoreflect.Message {
+	mi := &file_disperser_disperser_proto_msgTypes[37]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlobHeader.ProtoReflect.Descriptor instead.
+func (*BlobHeader) Descriptor() ([]byte, []int) {
+	return file_disperser_disperser_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *BlobHeader) GetCommitment() []byte {
+	if x != nil {
+		return x.Commitment
+	}
+	return nil
+}
+
+func (x *BlobHeader) GetDataLength() uint32 {
+	if x != nil {
+		return x.DataLength
+	}
+	return 0
+}
+
+func (x *BlobHeader) GetBlobQuorumParams() []*BlobQuorumParam {
+	if x != nil {
+		return x.BlobQuorumParams
+	}
+	return nil
+}
+
+type BlobQuorumParam struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The ID of the quorum.
+	QuorumNumber uint32 `protobuf:"varint,1,opt,name=quorum_number,json=quorumNumber,proto3" json:"quorum_number,omitempty"`
+	// Same as SecurityParams.adversary_threshold.
+	AdversaryThresholdPercentage uint32 `protobuf:"varint,2,opt,name=adversary_threshold_percentage,json=adversaryThresholdPercentage,proto3" json:"adversary_threshold_percentage,omitempty"`
+	// Same as SecurityParams.quorum_threshold.
+	QuorumThresholdPercentage uint32 `protobuf:"varint,3,opt,name=quorum_threshold_percentage,json=quorumThresholdPercentage,proto3" json:"quorum_threshold_percentage,omitempty"`
+	// This determines the nominal number of chunks for the blob, which is
+	// nominal_num_chunks = quantization_param * num_operators.
+	// A chunk is the smallest unit that's distributed to DA Nodes, corresponding to
+	// a set of evaluations of the polynomial (representing the blob) and a KZG multiproof.
+	// See more details in data model of EigenDA:
+	// https://github.com/Layr-Labs/eigenda/blob/master/docs/spec/data-model.md
+	QuantizationParam uint32 `protobuf:"varint,4,opt,name=quantization_param,json=quantizationParam,proto3" json:"quantization_param,omitempty"`
+	// The length of the blob after encoding (in number of symbols), which is
+	// encoded_length = chunk_length * ceil(nominal_num_chunks * (100 + overprovision_percent) / 100).
+	EncodedLength uint64 `protobuf:"varint,5,opt,name=encoded_length,json=encodedLength,proto3" json:"encoded_length,omitempty"`
+	// The margin by which the chunks assigned to the DA Nodes exceed the nominal number
+	// of chunks, as a percentage of it, so that the blob can still be retrieved when some
+	// of the DA Nodes go offline.
+	OverprovisionPercent uint32 `protobuf:"varint,6,opt,name=overprovision_percent,json=overprovisionPercent,proto3" json:"overprovision_percent,omitempty"`
+}
+
+func (x *BlobQuorumParam) Reset() {
+	*x = BlobQuorumParam{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_disperser_disperser_proto_msgTypes[38]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BlobQuorumParam) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlobQuorumParam) ProtoMessage() {}
+
+func (x *BlobQuorumParam) ProtoReflect() protoreflect.Message {
+	mi := &file_disperser_disperser_proto_msgTypes[38]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlobQuorumParam.ProtoReflect.Descriptor instead.
+func (*BlobQuorumParam) Descriptor() ([]byte, []int) {
+	return file_disperser_disperser_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *BlobQuorumParam) GetQuorumNumber() uint32 {
+	if x != nil {
+		return x.QuorumNumber
+	}
+	return 0
+}
+
+func (x *BlobQuorumParam) GetAdversaryThresholdPercentage() uint32 {
+	if x != nil {
+		return x.AdversaryThresholdPercentage
+	}
+	return 0
+}
+
+func (x *BlobQuorumParam) GetQuorumThresholdPercentage() uint32 {
+	if x != nil {
+		return x.QuorumThresholdPercentage
+	}
+	return 0
+}
+
+func (x *BlobQuorumParam) GetQuantizationParam() uint32 {
+	if x != nil {
+		return x.QuantizationParam
+	}
+	return 0
+}
+
+func (x *BlobQuorumParam) GetEncodedLength() uint64 {
+	if x != nil {
+		return x.EncodedLength
+	}
+	return 0
+}
+
+func (x *BlobQuorumParam) GetOverprovisionPercent() uint32 {
+	if x != nil {
+		return x.OverprovisionPercent
+	}
+	return 0
+}
+
+type BlobVerificationProof struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// batch_id is an incremental ID assigned to a batch by EigenDAServiceManager
+	BatchId uint32 `protobuf:"varint,1,opt,name=batch_id,json=batchId,proto3" json:"batch_id,omitempty"`
+	// The index of the blob in the batch (which is logically an ordered list of blobs).
+	BlobIndex     uint32         `protobuf:"varint,2,opt,name=blob_index,json=blobIndex,proto3" json:"blob_index,omitempty"`
+	BatchMetadata *BatchMetadata `protobuf:"bytes,3,opt,name=batch_metadata,json=batchMetadata,proto3" json:"batch_metadata,omitempty"`
+	// inclusion_proof is a merkle proof for a blob header's inclusion in a batch
+	InclusionProof []byte `protobuf:"bytes,4,opt,name=inclusion_proof,json=inclusionProof,proto3" json:"inclusion_proof,omitempty"`
+	// indexes of quorums in BatchHeader.quorum_numbers that match the quorums in BlobHeader.blob_quorum_params
+	// Ex. BlobHeader.blob_quorum_params = [
+	// 	{
+	//		quorum_number = 0,
+	// 		...
+	// 	},
+	// 	{
+	//		quorum_number = 3,
+	// 		...
+	// 	},
+	// 	{
+	//		quorum_number = 5,
+	// 		...
+	// 	},
+	// ]
+	// BatchHeader.quorum_numbers = [0, 5, 3] => 0x000503
+	// Then, quorum_indexes = [0, 2, 1] => 0x000201
+	QuorumIndexes []byte `protobuf:"bytes,5,opt,name=quorum_indexes,json=quorumIndexes,proto3" json:"quorum_indexes,omitempty"`
+}
+
+func (x *BlobVerificationProof) Reset() {
+	*x = BlobVerificationProof{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_disperser_disperser_proto_msgTypes[39]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BlobVerificationProof) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlobVerificationProof) ProtoMessage() {}
+
+func (x *BlobVerificationProof) ProtoReflect() protoreflect.Message {
+	mi := &file_disperser_disperser_proto_msgTypes[39]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlobVerificationProof.ProtoReflect.Descriptor instead.
+func (*BlobVerificationProof) Descriptor() ([]byte, []int) {
+	return file_disperser_disperser_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *BlobVerificationProof) GetBatchId() uint32 {
+	if x != nil {
+		return x.BatchId
+	}
+	return 0
+}
+
+func (x *BlobVerificationProof) GetBlobIndex() uint32 {
+	if x != nil {
+		return x.BlobIndex
+	}
+	return 0
+}
+
+func (x *BlobVerificationProof) GetBatchMetadata() *BatchMetadata {
+	if x != nil {
+		return x.BatchMetadata
+	}
+	return nil
+}
+
+func (x *BlobVerificationProof) GetInclusionProof() []byte {
+	if x != nil {
+		return x.InclusionProof
+	}
+	return nil
+}
+
+func (x *BlobVerificationProof) GetQuorumIndexes() []byte {
+	if x != nil {
+		return x.QuorumIndexes
+	}
+	return nil
+}
+
+// BlobInclusionProof is the part of a BlobVerificationProof that is specific to a blob. Together with the batch_id
+// and batch_metadata of its BatchVerificationProofsReply, it makes the BlobVerificationProof of the blob.
+type BlobInclusionProof struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The request ID of the blob, as returned by DisperseBlob().
+	RequestId  []byte      `protobuf:"bytes,1,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	BlobHeader *BlobHeader `protobuf:"bytes,2,opt,name=blob_header,json=blobHeader,proto3" json:"blob_header,omitempty"`
+	// The index of the blob in the batch (which is logically an ordered list of blobs).
+	BlobIndex uint32 `protobuf:"varint,3,opt,name=blob_index,json=blobIndex,proto3" json:"blob_index,omitempty"`
+	// inclusion_proof is a merkle proof for a blob header's inclusion in a batch
+	InclusionProof []byte `protobuf:"bytes,4,opt,name=inclusion_proof,json=inclusionProof,proto3" json:"inclusion_proof,omitempty"`
+	// indexes of quorums in BatchHeader.quorum_numbers that match the quorums in BlobHeader.blob_quorum_params,
+	// as in BlobVerificationProof.quorum_indexes
+	QuorumIndexes []byte `protobuf:"bytes,5,opt,name=quorum_indexes,json=quorumIndexes,proto3" json:"quorum_indexes,omitempty"`
+}
+
+func (x *BlobInclusionProof) Reset() {
+	*x = BlobInclusionProof{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_disperser_disperser_proto_msgTypes[40]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BlobInclusionProof) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlobInclusionProof) ProtoMessage() {}
+
+func (x *BlobInclusionProof) ProtoReflect() protoreflect.Message {
+	mi := &file_disperser_disperser_proto_msgTypes[40]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlobInclusionProof.ProtoReflect.Descriptor instead.
+func (*BlobInclusionProof) Descriptor() ([]byte, []int) {
+	return file_disperser_disperser_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *BlobInclusionProof) GetRequestId() []byte {
+	if x != nil {
+		return x.RequestId
+	}
+	return nil
+}
+
+func (x *BlobInclusionProof) GetBlobHeader() *BlobHeader {
+	if x != nil {
+		return x.BlobHeader
+	}
+	return nil
+}
+
+func (x *BlobInclusionProof) GetBlobIndex() uint32 {
+	if x != nil {
+		return x.BlobIndex
+	}
+	return 0
+}
+
+func (x *BlobInclusionProof) GetInclusionProof() []byte {
+	if x != nil {
+		return x.InclusionProof
+	}
+	return nil
+}
+
+func (x *BlobInclusionProof) GetQuorumIndexes() []byte {
+	if x != nil {
+		return x.QuorumIndexes
+	}
+	return nil
+}
+
+type BatchMetadata struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BatchHeader *BatchHeader `protobuf:"bytes,1,opt,name=batch_header,json=batchHeader,proto3" json:"batch_header,omitempty"`
+	// The hash of all public keys of the operators that did not sign the batch.
+	SignatoryRecordHash []byte `protobuf:"bytes,2,opt,name=signatory_record_hash,json=signatoryRecordHash,proto3" json:"signatory_record_hash,omitempty"`
+	// The fee payment paid by users for dispersing this batch. It's the bytes
+	// representation of a big.Int value.
+	Fee []byte `protobuf:"bytes,3,opt,name=fee,proto3" json:"fee,omitempty"`
+	// The Ethereum block number at which the batch is confirmed onchain.
+	ConfirmationBlockNumber uint32 `protobuf:"varint,4,opt,name=confirmation_block_number,json=confirmationBlockNumber,proto3" json:"confirmation_block_number,omitempty"`
+	// This is the hash of the ReducedBatchHeader defined onchain, see:
+	// https://github.com/Layr-Labs/eigenda/blob/master/contracts/src/interfaces/IEigenDAServiceManager.sol#L43
+	// The is the message that the operators will sign their signatures on.
+	BatchHeaderHash []byte `protobuf:"bytes,5,opt,name=batch_header_hash,json=batchHeaderHash,proto3" json:"batch_header_hash,omitempty"`
+}
+
+func (x *BatchMetadata) Reset() {
+	*x = BatchMetadata{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_disperser_disperser_proto_msgTypes[41]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BatchMetadata) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchMetadata) ProtoMessage() {}
+
+func (x *BatchMetadata) ProtoReflect() protoreflect.Message {
+	mi := &file_disperser_disperser_proto_msgTypes[41]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchMetadata.ProtoReflect.Descriptor instead.
+func (*BatchMetadata) Descriptor() ([]byte, []int) {
+	return file_disperser_disperser_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *BatchMetadata) GetBatchHeader() *BatchHeader {
+	if x != nil {
+		return x.BatchHeader
+	}
+	return nil
+}
+
+func (x *BatchMetadata) GetSignatoryRecordHash() []byte {
+	if x != nil {
+		return x.SignatoryRecordHash
+	}
+	return nil
+}
+
+func (x *BatchMetadata) GetFee() []byte {
+	if x != nil {
+		return x.Fee
+	}
+	return nil
+}
+
+func (x *BatchMetadata) GetConfirmationBlockNumber() uint32 {
+	if x != nil {
+		return x.ConfirmationBlockNumber
+	}
+	return 0
+}
+
+func (x *BatchMetadata) GetBatchHeaderHash() []byte {
+	if x != nil {
+		return x.BatchHeaderHash
+	}
+	return nil
+}
+
+type BatchHeader struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The root of the merkle tree with the hashes of blob headers as leaves.
+	BatchRoot []byte `protobuf:"bytes,1,opt,name=batch_root,json=batchRoot,proto3" json:"batch_root,omitempty"`
+	// All quorums associated with blobs in this batch.
+	QuorumNumbers []byte `protobuf:"bytes,2,opt,name=quorum_numbers,json=quorumNumbers,proto3" json:"quorum_numbers,omitempty"`
+	// The percentage of stake that has signed for this batch.
+	// The quorum_signed_percentages[i] is percentage for the quorum_numbers[i].
+	QuorumSignedPercentages []byte `protobuf:"bytes,3,opt,name=quorum_signed_percentages,json=quorumSignedPercentages,proto3" json:"quorum_signed_percentages,omitempty"`
+	// The Ethereum block number at which the batch was created.
+	// The Disperser will encode and disperse the blobs based on the onchain info
+	// (e.g. operator stakes) at this block number.
+	ReferenceBlockNumber uint32 `protobuf:"varint,4,opt,name=reference_block_number,json=referenceBlockNumber,proto3" json:"reference_block_number,omitempty"`
+}
+
+func (x *BatchHeader) Reset() {
+	*x = BatchHeader{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_disperser_disperser_proto_msgTypes[42]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BatchHeader) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchHeader) ProtoMessage() {}
+
+func (x *BatchHeader) ProtoReflect() protoreflect.Message {
+	mi := &file_disperser_disperser_proto_msgTypes[42]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchHeader.ProtoReflect.Descriptor instead.
+func (*BatchHeader) Descriptor() ([]byte, []int) {
+	return file_disperser_disperser_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *BatchHeader) GetBatchRoot() []byte {
+	if x != nil {
+		return x.BatchRoot
+	}
+	return nil
+}
+
+func (x *BatchHeader) GetQuorumNumbers() []byte {
+	if x != nil {
+		return x.QuorumNumbers
+	}
+	return nil
+}
+
+func (x *BatchHeader) GetQuorumSignedPercentages() []byte {
+	if x != nil {
+		return x.QuorumSignedPercentages
+	}
+	return nil
+}
+
+func (x *BatchHeader) GetReferenceBlockNumber() uint32 {
+	if x != nil {
+		return x.ReferenceBlockNumber
+	}
+	return 0
+}
+
+var File_disperser_disperser_proto protoreflect.FileDescriptor
+
+var file_disperser_disperser_proto_rawDesc = []byte{
+	0x0a, 0x19, 0x64, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65, 0x72, 0x2f, 0x64, 0x69, 0x73, 0x70,
+	0x65, 0x72, 0x73, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x09, 0x64, 0x69, 0x73,
+	0x70, 0x65, 0x72, 0x73, 0x65, 0x72, 0x22, 0xe7, 0x03, 0x0a, 0x13, 0x44, 0x69, 0x73, 0x70, 0x65,
+	0x72, 0x73, 0x65, 0x42, 0x6c, 0x6f, 0x62, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12,
+	0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61,
+	0x74, 0x61, 0x12, 0x42, 0x0a, 0x0f, 0x73, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x5f, 0x70,
+	0x61, 0x72, 0x61, 0x6d, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x64, 0x69,
+	0x73, 0x70, 0x65, 0x72, 0x73, 0x65, 0x72, 0x2e, 0x53, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79,
+	0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x52, 0x0e, 0x73, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79,
+	0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70,
+	0x61, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73,
+	0x70, 0x61, 0x63, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x64, 0x72, 0x79, 0x5f, 0x72, 0x75, 0x6e, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x64, 0x72, 0x79, 0x52, 0x75, 0x6e, 0x12, 0x48, 0x0a,
+	0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x2c, 0x2e, 0x64, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65, 0x72, 0x2e, 0x44, 0x69, 0x73, 0x70,
+	0x65, 0x72, 0x73, 0x65, 0x42, 0x6c, 0x6f, 0x62, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e,
+	0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x08, 0x6d,
+	0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x2d, 0x0a, 0x06, 0x6c, 0x61, 0x79, 0x6f, 0x75,
+	0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x15, 0x2e, 0x64, 0x69, 0x73, 0x70, 0x65, 0x72,
+	0x73, 0x65, 0x72, 0x2e, 0x42, 0x6c, 0x6f, 0x62, 0x4c, 0x61, 0x79, 0x6f, 0x75, 0x74, 0x52, 0x06,
+	0x6c, 0x61, 0x79, 0x6f, 0x75, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e,
+	0x74, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x6f,
+	0x6e, 0x74, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x36, 0x0a, 0x17, 0x70, 0x72, 0x69,
+	0x76, 0x61, 0x74, 0x65, 0x5f, 0x75, 0x6e, 0x74, 0x69, 0x6c, 0x5f, 0x66, 0x69, 0x6e, 0x61, 0x6c,
+	0x69, 0x7a, 0x65, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x08, 0x52, 0x15, 0x70, 0x72, 0x69, 0x76,
+	0x61, 0x74, 0x65, 0x55, 0x6e, 0x74, 0x69, 0x6c, 0x46, 0x69, 0x6e, 0x61, 0x6c, 0x69, 0x7a, 0x65,
+	0x64, 0x12, 0x30, 0x0a, 0x14, 0x73, 0x75, 0x62, 0x6d, 0x69, 0x74, 0x74, 0x65, 0x72, 0x5f, 0x70,
+	0x75, 0x62, 0x6c, 0x69, 0x63, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x12, 0x73, 0x75, 0x62, 0x6d, 0x69, 0x74, 0x74, 0x65, 0x72, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63,
+	0x4b, 0x65, 0x79, 0x1a, 0x3b, 0x0a, 0x0d, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01,
+	0x22, 0xec, 0x01, 0x0a, 0x11, 0x44, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65, 0x42, 0x6c, 0x6f,
+	0x62, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x2d, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x15, 0x2e, 0x64, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73,
+	0x65, 0x72, 0x2e, 0x42, 0x6c, 0x6f, 0x62, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x72,
+	0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x72, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x49, 0x64, 0x12, 0x48, 0x0a, 0x12, 0x62, 0x6c, 0x6f, 0x62, 0x5f, 0x71, 0x75, 0x6f,
+	0x72, 0x75, 0x6d, 0x5f, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x1a, 0x2e, 0x64, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65, 0x72, 0x2e, 0x42, 0x6c, 0x6f,
+	0x62, 0x51, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x52, 0x10, 0x62, 0x6c,
+	0x6f, 0x62, 0x51, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x12, 0x1e,
+	0x0a, 0x0a, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x0a, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x1f,
+	0x0a, 0x0b, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x0a, 0x64, 0x61, 0x74, 0x61, 0x4c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x22,
+	0x32, 0x0a, 0x11, 0x42, 0x6c, 0x6f, 0x62, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x49, 0x64, 0x22, 0xa0, 0x03, 0x0a, 0x0f, 0x42, 0x6c, 0x6f, 0x62, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x2d, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x15, 0x2e, 0x64, 0x69, 0x73, 0x70, 0x65, 0x72,
+	0x73, 0x65, 0x72, 0x2e, 0x42, 0x6c, 0x6f, 0x62, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06,
+	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x27, 0x0a, 0x04, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x64, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65, 0x72,
+	0x2e, 0x42, 0x6c, 0x6f, 0x62, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x04, 0x69, 0x6e, 0x66, 0x6f, 0x12,
+	0x1c, 0x0a, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x44, 0x0a,
+	0x0f, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x65, 0x73,
+	0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x64, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73,
+	0x65, 0x72, 0x2e, 0x42, 0x6c, 0x6f, 0x62, 0x51, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x53, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x52, 0x0e, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x65, 0x73, 0x12, 0x2b, 0x0a, 0x12, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x70, 0x6f, 0x6c, 0x6c,
+	0x5f, 0x64, 0x65, 0x6c, 0x61, 0x79, 0x5f, 0x6d, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x0f, 0x6e, 0x65, 0x78, 0x74, 0x50, 0x6f, 0x6c, 0x6c, 0x44, 0x65, 0x6c, 0x61, 0x79, 0x4d, 0x73,
+	0x12, 0x44, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x06, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x28, 0x2e, 0x64, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65, 0x72, 0x2e, 0x42,
+	0x6c, 0x6f, 0x62, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x2e, 0x4d,
+	0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x08, 0x6d, 0x65,
+	0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e,
+	0x74, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x6f,
+	0x6e, 0x74, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x1a, 0x3b, 0x0a, 0x0d, 0x4d, 0x65, 0x74,
+	0x61, 0x64, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65,
+	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xc2, 0x01, 0x0a, 0x10, 0x42, 0x6c, 0x6f, 0x62, 0x51,
+	0x75, 0x6f, 0x72, 0x75, 0x6d, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x71,
+	0x75, 0x6f, 0x72, 0x75, 0x6d, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x0c, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72,
+	0x12, 0x2b, 0x0a, 0x11, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x5f, 0x70, 0x65, 0x72, 0x63, 0x65,
+	0x6e, 0x74, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x10, 0x73, 0x69, 0x67,
+	0x6e, 0x65, 0x64, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x61, 0x67, 0x65, 0x12, 0x3e, 0x0a,
+	0x1b, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x5f, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c,
+	0x64, 0x5f, 0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x19, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68,
+	0x6f, 0x6c, 0x64, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x61, 0x67, 0x65, 0x12, 0x1c, 0x0a,
+	0x09, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x09, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x65, 0x64, 0x22, 0xc6, 0x01, 0x0a, 0x13,
+	0x52, 0x65, 0x74, 0x72, 0x69, 0x65, 0x76, 0x65, 0x42, 0x6c, 0x6f, 0x62, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x2a, 0x0a, 0x11, 0x62, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x68, 0x65, 0x61,
+	0x64, 0x65, 0x72, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0f,
+	0x62, 0x61, 0x74, 0x63, 0x68, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x48, 0x61, 0x73, 0x68, 0x12,
+	0x1d, 0x0a, 0x0a, 0x62, 0x6c, 0x6f, 0x62, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x09, 0x62, 0x6c, 0x6f, 0x62, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x16,
+	0x0a, 0x06, 0x76, 0x65, 0x72, 0x69, 0x66, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06,
+	0x76, 0x65, 0x72, 0x69, 0x66, 0x79, 0x12, 0x25, 0x0a, 0x0e, 0x61, 0x75, 0x74, 0x68, 0x5f, 0x74,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0d,
+	0x61, 0x75, 0x74, 0x68, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x25, 0x0a,
+	0x0e, 0x61, 0x75, 0x74, 0x68, 0x5f, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0d, 0x61, 0x75, 0x74, 0x68, 0x53, 0x69, 0x67, 0x6e, 0x61,
+	0x74, 0x75, 0x72, 0x65, 0x22, 0x4a, 0x0a, 0x11, 0x52, 0x65, 0x74, 0x72, 0x69, 0x65, 0x76, 0x65,
+	0x42, 0x6c, 0x6f, 0x62, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74,
+	0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x21, 0x0a,
+	0x0c, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65,
+	0x22, 0x47, 0x0a, 0x19, 0x52, 0x65, 0x74, 0x72, 0x69, 0x65, 0x76, 0x65, 0x42, 0x61, 0x74, 0x63,
+	0x68, 0x42, 0x6c, 0x6f, 0x62, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2a, 0x0a,
+	0x11, 0x62, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x5f, 0x68, 0x61,
+	0x73, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0f, 0x62, 0x61, 0x74, 0x63, 0x68, 0x48,
+	0x65, 0x61, 0x64, 0x65, 0x72, 0x48, 0x61, 0x73, 0x68, 0x22, 0xbc, 0x01, 0x0a, 0x17, 0x52, 0x65,
+	0x74, 0x72, 0x69, 0x65, 0x76, 0x65, 0x42, 0x61, 0x74, 0x63, 0x68, 0x42, 0x6c, 0x6f, 0x62, 0x73,
+	0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x1d, 0x0a, 0x0a, 0x62, 0x6c, 0x6f, 0x62, 0x5f, 0x69, 0x6e,
+	0x64, 0x65, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x62, 0x6c, 0x6f, 0x62, 0x49,
+	0x6e, 0x64, 0x65, 0x78, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x1b, 0x0a, 0x09,
+	0x6e, 0x75, 0x6d, 0x5f, 0x62, 0x6c, 0x6f, 0x62, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x08, 0x6e, 0x75, 0x6d, 0x42, 0x6c, 0x6f, 0x62, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x6f, 0x6e,
+	0x74, 0x65, 0x6e, 0x74, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0b, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1a, 0x0a, 0x08,
+	0x77, 0x69, 0x74, 0x68, 0x68, 0x65, 0x6c, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08,
+	0x77, 0x69, 0x74, 0x68, 0x68, 0x65, 0x6c, 0x64, 0x22, 0x4c, 0x0a, 0x1e, 0x42, 0x61, 0x74, 0x63,
+	0x68, 0x56, 0x65, 0x72, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x72, 0x6f,
+	0x6f, 0x66, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2a, 0x0a, 0x11, 0x62, 0x61,
+	0x74, 0x63, 0x68, 0x5f, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0f, 0x62, 0x61, 0x74, 0x63, 0x68, 0x48, 0x65, 0x61, 0x64,
+	0x65, 0x72, 0x48, 0x61, 0x73, 0x68, 0x22, 0xba, 0x01, 0x0a, 0x1c, 0x42, 0x61, 0x74, 0x63, 0x68,
+	0x56, 0x65, 0x72, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x72, 0x6f, 0x6f,
+	0x66, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x19, 0x0a, 0x08, 0x62, 0x61, 0x74, 0x63, 0x68,
+	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x62, 0x61, 0x74, 0x63, 0x68,
+	0x49, 0x64, 0x12, 0x3f, 0x0a, 0x0e, 0x62, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x6d, 0x65, 0x74, 0x61,
+	0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x64, 0x69, 0x73,
+	0x70, 0x65, 0x72, 0x73, 0x65, 0x72, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x4d, 0x65, 0x74, 0x61,
+	0x64, 0x61, 0x74, 0x61, 0x52, 0x0d, 0x62, 0x61, 0x74, 0x63, 0x68, 0x4d, 0x65, 0x74, 0x61, 0x64,
+	0x61, 0x74, 0x61, 0x12, 0x3e, 0x0a, 0x0b, 0x62, 0x6c, 0x6f, 0x62, 0x5f, 0x70, 0x72, 0x6f, 0x6f,
+	0x66, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x64, 0x69, 0x73, 0x70, 0x65,
+	0x72, 0x73, 0x65, 0x72, 0x2e, 0x42, 0x6c, 0x6f, 0x62, 0x49, 0x6e, 0x63, 0x6c, 0x75, 0x73, 0x69,
+	0x6f, 0x6e, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x52, 0x0a, 0x62, 0x6c, 0x6f, 0x62, 0x50, 0x72, 0x6f,
+	0x6f, 0x66, 0x73, 0x22, 0x37, 0x0a, 0x16, 0x42, 0x6c, 0x6f, 0x62, 0x50, 0x72, 0x6f, 0x6f, 0x66,
+	0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a,
+	0x0a, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x09, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x64, 0x22, 0x5a, 0x0a, 0x14,
+	0x42, 0x6c, 0x6f, 0x62, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x52,
+	0x65, 0x70, 0x6c, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x62, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x62, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x12, 0x2a, 0x0a, 0x11,
+	0x62, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x5f, 0x68, 0x61, 0x73,
+	0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0f, 0x62, 0x61, 0x74, 0x63, 0x68, 0x48, 0x65,
+	0x61, 0x64, 0x65, 0x72, 0x48, 0x61, 0x73, 0x68, 0x22, 0x85, 0x01, 0x0a, 0x1b, 0x4f, 0x70, 0x65,
+	0x72, 0x61, 0x74, 0x6f, 0x72, 0x53, 0x74, 0x61, 0x74, 0x65, 0x41, 0x74, 0x42, 0x61, 0x74, 0x63,
+	0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2a, 0x0a, 0x11, 0x62, 0x61, 0x74, 0x63,
+	0x68, 0x5f, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x0f, 0x62, 0x61, 0x74, 0x63, 0x68, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72,
+	0x48, 0x61, 0x73, 0x68, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x69, 0x7a,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a,
+	0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e,
+	0x22, 0xd4, 0x02, 0x0a, 0x19, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x53, 0x74, 0x61,
+	0x74, 0x65, 0x41, 0x74, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x34,
+	0x0a, 0x16, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x5f, 0x62, 0x6c, 0x6f, 0x63,
+	0x6b, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x14,
+	0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x4e, 0x75,
+	0x6d, 0x62, 0x65, 0x72, 0x12, 0x2f, 0x0a, 0x13, 0x71, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x7a, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x66, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x12, 0x71, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x46,
+	0x61, 0x63, 0x74, 0x6f, 0x72, 0x12, 0x3b, 0x0a, 0x0d, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x5f,
+	0x74, 0x6f, 0x74, 0x61, 0x6c, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x64,
+	0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65, 0x72, 0x2e, 0x51, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x53,
+	0x74, 0x61, 0x6b, 0x65, 0x52, 0x0c, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x54, 0x6f, 0x74, 0x61,
+	0x6c, 0x73, 0x12, 0x36, 0x0a, 0x09, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x18,
+	0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x64, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65,
+	0x72, 0x2e, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x53, 0x74, 0x61, 0x6b, 0x65, 0x52,
+	0x09, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x12, 0x26, 0x0a, 0x0f, 0x6e, 0x65,
+	0x78, 0x74, 0x5f, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0d, 0x6e, 0x65, 0x78, 0x74, 0x50, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b,
+	0x65, 0x6e, 0x12, 0x33, 0x0a, 0x15, 0x6f, 0x76, 0x65, 0x72, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x73,
+	0x69, 0x6f, 0x6e, 0x5f, 0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x14, 0x6f, 0x76, 0x65, 0x72, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e,
+	0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x22, 0x6d, 0x0a, 0x0b, 0x51, 0x75, 0x6f, 0x72, 0x75,
+	0x6d, 0x53, 0x74, 0x61, 0x6b, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d,
+	0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0c, 0x71,
+	0x75, 0x6f, 0x72, 0x75, 0x6d, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x73,
+	0x74, 0x61, 0x6b, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x73, 0x74, 0x61, 0x6b,
+	0x65, 0x12, 0x23, 0x0a, 0x0d, 0x6e, 0x75, 0x6d, 0x5f, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f,
+	0x72, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0c, 0x6e, 0x75, 0x6d, 0x4f, 0x70, 0x65,
+	0x72, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x22, 0x81, 0x01, 0x0a, 0x0d, 0x4f, 0x70, 0x65, 0x72, 0x61,
+	0x74, 0x6f, 0x72, 0x53, 0x74, 0x61, 0x6b, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x6f, 0x70, 0x65, 0x72,
+	0x61, 0x74, 0x6f, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a, 0x6f,
+	0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x49, 0x64, 0x12, 0x23, 0x0a, 0x0d, 0x71, 0x75, 0x6f,
+	0x72, 0x75, 0x6d, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x0c, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x14,
+	0x0a, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x69,
+	0x6e, 0x64, 0x65, 0x78, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x6b, 0x65, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x05, 0x73, 0x74, 0x61, 0x6b, 0x65, 0x22, 0x7b, 0x0a, 0x1a, 0x45, 0x78,
+	0x74, 0x65, 0x6e, 0x64, 0x42, 0x6c, 0x6f, 0x62, 0x52, 0x65, 0x74, 0x65, 0x6e, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x72, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x72, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x64, 0x12, 0x3e, 0x0a, 0x1b, 0x61, 0x64, 0x64, 0x69, 0x74,
+	0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x5f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x73,
+	0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x19, 0x61, 0x64,
+	0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x22, 0x32, 0x0a, 0x18, 0x45, 0x78, 0x74, 0x65, 0x6e,
+	0x64, 0x42, 0x6c, 0x6f, 0x62, 0x52, 0x65, 0x74, 0x65, 0x6e, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65,
+	0x70, 0x6c, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x78, 0x70, 0x69, 0x72, 0x79, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x06, 0x65, 0x78, 0x70, 0x69, 0x72, 0x79, 0x22, 0x78, 0x0a, 0x13, 0x52,
+	0x65, 0x73, 0x75, 0x62, 0x6d, 0x69, 0x74, 0x42, 0x6c, 0x6f, 0x62, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49,
+	0x64, 0x12, 0x42, 0x0a, 0x0f, 0x73, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x5f, 0x70, 0x61,
 	0x72, 0x61, 0x6d, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x64, 0x69, 0x73,
 	0x70, 0x65, 0x72, 0x73, 0x65, 0x72, 0x2e, 0x53, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x50,
 	0x61, 0x72, 0x61, 0x6d, 0x73, 0x52, 0x0e, 0x73, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x50,
-	0x61, 0x72, 0x61, 0x6d, 0x73, 0x22, 0x61, 0x0a, 0x11, 0x44, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73,
-	0x65, 0x42, 0x6c, 0x6f, 0x62, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x2d, 0x0a, 0x06, 0x72, 0x65,
+	0x61, 0x72, 0x61, 0x6d, 0x73, 0x22, 0x61, 0x0a, 0x11, 0x52, 0x65, 0x73, 0x75, 0x62, 0x6d, 0x69,
+	0x74, 0x42, 0x6c, 0x6f, 0x62, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x2d, 0x0a, 0x06, 0x72, 0x65,
 	0x73, 0x75, 0x6c, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x15, 0x2e, 0x64, 0x69, 0x73,
 	0x70, 0x65, 0x72, 0x73, 0x65, 0x72, 0x2e, 0x42, 0x6c, 0x6f, 0x62, 0x53, 0x74, 0x61, 0x74, 0x75,
 	0x73, 0x52, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x72, 0x65, 0x71,
 	0x75, 0x65, 0x73, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x72,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x64, 0x22, 0x32, 0x0a, 0x11, 0x42, 0x6c, 0x6f, 0x62,
-	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a,
-	0x0a, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x0c, 0x52, 0x09, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x64, 0x22, 0x69, 0x0a, 0x0f,
-	0x42, 0x6c, 0x6f, 0x62, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12,
-	0x2d, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32,
-	0x15, 0x2e, 0x64, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65, 0x72, 0x2e, 0x42, 0x6c, 0x6f, 0x62,
-	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x27,
-	0x0a, 0x04, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x64,
-	0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65, 0x72, 0x2e, 0x42, 0x6c, 0x6f, 0x62, 0x49, 0x6e, 0x66,
-	0x6f, 0x52, 0x04, 0x69, 0x6e, 0x66, 0x6f, 0x22, 0x60, 0x0a, 0x13, 0x52, 0x65, 0x74, 0x72, 0x69,
-	0x65, 0x76, 0x65, 0x42, 0x6c, 0x6f, 0x62, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2a,
-	0x0a, 0x11, 0x62, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x5f, 0x68,
-	0x61, 0x73, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0f, 0x62, 0x61, 0x74, 0x63, 0x68,
-	0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x48, 0x61, 0x73, 0x68, 0x12, 0x1d, 0x0a, 0x0a, 0x62, 0x6c,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x64, 0x22, 0x3e, 0x0a, 0x10, 0x42, 0x61, 0x74, 0x63,
+	0x68, 0x43, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2a, 0x0a, 0x11,
+	0x62, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x5f, 0x68, 0x61, 0x73,
+	0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0f, 0x62, 0x61, 0x74, 0x63, 0x68, 0x48, 0x65,
+	0x61, 0x64, 0x65, 0x72, 0x48, 0x61, 0x73, 0x68, 0x22, 0x9e, 0x02, 0x0a, 0x0e, 0x42, 0x61, 0x74,
+	0x63, 0x68, 0x43, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x32, 0x0a, 0x15, 0x63,
+	0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x78, 0x6e, 0x5f,
+	0x68, 0x61, 0x73, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x13, 0x63, 0x6f, 0x6e, 0x66,
+	0x69, 0x72, 0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x78, 0x6e, 0x48, 0x61, 0x73, 0x68, 0x12,
+	0x3a, 0x0a, 0x19, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f,
+	0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x17, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x19, 0x0a, 0x08, 0x67,
+	0x61, 0x73, 0x5f, 0x75, 0x73, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x67,
+	0x61, 0x73, 0x55, 0x73, 0x65, 0x64, 0x12, 0x2e, 0x0a, 0x13, 0x65, 0x66, 0x66, 0x65, 0x63, 0x74,
+	0x69, 0x76, 0x65, 0x5f, 0x67, 0x61, 0x73, 0x5f, 0x70, 0x72, 0x69, 0x63, 0x65, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x11, 0x65, 0x66, 0x66, 0x65, 0x63, 0x74, 0x69, 0x76, 0x65, 0x47, 0x61,
+	0x73, 0x50, 0x72, 0x69, 0x63, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f,
+	0x63, 0x6f, 0x73, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x74, 0x6f, 0x74, 0x61,
+	0x6c, 0x43, 0x6f, 0x73, 0x74, 0x12, 0x32, 0x0a, 0x0a, 0x62, 0x6c, 0x6f, 0x62, 0x5f, 0x63, 0x6f,
+	0x73, 0x74, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x64, 0x69, 0x73, 0x70,
+	0x65, 0x72, 0x73, 0x65, 0x72, 0x2e, 0x42, 0x6c, 0x6f, 0x62, 0x43, 0x6f, 0x73, 0x74, 0x52, 0x09,
+	0x62, 0x6c, 0x6f, 0x62, 0x43, 0x6f, 0x73, 0x74, 0x73, 0x22, 0x83, 0x01, 0x0a, 0x08, 0x42, 0x6c,
+	0x6f, 0x62, 0x43, 0x6f, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x72, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x62, 0x6c, 0x6f, 0x62, 0x5f, 0x69, 0x6e,
+	0x64, 0x65, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x62, 0x6c, 0x6f, 0x62, 0x49,
+	0x6e, 0x64, 0x65, 0x78, 0x12, 0x25, 0x0a, 0x0e, 0x65, 0x6e, 0x63, 0x6f, 0x64, 0x65, 0x64, 0x5f,
+	0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0d, 0x65, 0x6e,
+	0x63, 0x6f, 0x64, 0x65, 0x64, 0x4c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x12, 0x12, 0x0a, 0x04, 0x63,
+	0x6f, 0x73, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x63, 0x6f, 0x73, 0x74, 0x22,
+	0x45, 0x0a, 0x17, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x56, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2a, 0x0a, 0x11, 0x62, 0x61,
+	0x74, 0x63, 0x68, 0x5f, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0f, 0x62, 0x61, 0x74, 0x63, 0x68, 0x48, 0x65, 0x61, 0x64,
+	0x65, 0x72, 0x48, 0x61, 0x73, 0x68, 0x22, 0x86, 0x01, 0x0a, 0x15, 0x4f, 0x70, 0x65, 0x72, 0x61,
+	0x74, 0x6f, 0x72, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79,
+	0x12, 0x38, 0x0a, 0x09, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x64, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65, 0x72, 0x2e,
+	0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x52,
+	0x09, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x12, 0x33, 0x0a, 0x08, 0x66, 0x65,
+	0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x64,
+	0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65, 0x72, 0x2e, 0x46, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65,
+	0x53, 0x74, 0x61, 0x6b, 0x65, 0x52, 0x08, 0x66, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x22,
+	0x68, 0x0a, 0x0f, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x56, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x12, 0x1f, 0x0a, 0x0b, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x5f, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f,
+	0x72, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x0a,
+	0x08, 0x66, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x08, 0x66, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x22, 0x6d, 0x0a, 0x0c, 0x46, 0x65, 0x61,
+	0x74, 0x75, 0x72, 0x65, 0x53, 0x74, 0x61, 0x6b, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x66, 0x65, 0x61,
+	0x74, 0x75, 0x72, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x66, 0x65, 0x61, 0x74,
+	0x75, 0x72, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x5f, 0x6e, 0x75,
+	0x6d, 0x62, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0c, 0x71, 0x75, 0x6f, 0x72,
+	0x75, 0x6d, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x1e, 0x0a, 0x0a, 0x70, 0x65, 0x72, 0x63,
+	0x65, 0x6e, 0x74, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x70, 0x65,
+	0x72, 0x63, 0x65, 0x6e, 0x74, 0x61, 0x67, 0x65, 0x22, 0x18, 0x0a, 0x16, 0x44, 0x69, 0x73, 0x70,
+	0x65, 0x72, 0x73, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x22, 0x8b, 0x01, 0x0a, 0x14, 0x44, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65, 0x72,
+	0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x42, 0x0a, 0x0f, 0x73,
+	0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x5f, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x64, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65, 0x72,
+	0x2e, 0x53, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x52,
+	0x0e, 0x73, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12,
+	0x2f, 0x0a, 0x13, 0x71, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f,
+	0x66, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x12, 0x71, 0x75,
+	0x61, 0x6e, 0x74, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x46, 0x61, 0x63, 0x74, 0x6f, 0x72,
+	0x22, 0x18, 0x0a, 0x16, 0x52, 0x61, 0x74, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x53, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0xb4, 0x01, 0x0a, 0x14, 0x52,
+	0x61, 0x74, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65,
+	0x70, 0x6c, 0x79, 0x12, 0x1d, 0x0a, 0x0a, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x49, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x64, 0x61, 0x69, 0x6c, 0x79, 0x5f, 0x71, 0x75, 0x6f, 0x74,
+	0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x64, 0x61, 0x69, 0x6c, 0x79, 0x51, 0x75,
+	0x6f, 0x74, 0x61, 0x12, 0x28, 0x0a, 0x10, 0x64, 0x61, 0x69, 0x6c, 0x79, 0x5f, 0x71, 0x75, 0x6f,
+	0x74, 0x61, 0x5f, 0x75, 0x73, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0e, 0x64,
+	0x61, 0x69, 0x6c, 0x79, 0x51, 0x75, 0x6f, 0x74, 0x61, 0x55, 0x73, 0x65, 0x64, 0x12, 0x32, 0x0a,
+	0x15, 0x64, 0x61, 0x69, 0x6c, 0x79, 0x5f, 0x71, 0x75, 0x6f, 0x74, 0x61, 0x5f, 0x72, 0x65, 0x6d,
+	0x61, 0x69, 0x6e, 0x69, 0x6e, 0x67, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x13, 0x64, 0x61,
+	0x69, 0x6c, 0x79, 0x51, 0x75, 0x6f, 0x74, 0x61, 0x52, 0x65, 0x6d, 0x61, 0x69, 0x6e, 0x69, 0x6e,
+	0x67, 0x22, 0xa2, 0x01, 0x0a, 0x0e, 0x53, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x50, 0x6f,
+	0x6c, 0x69, 0x63, 0x79, 0x12, 0x45, 0x0a, 0x0e, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x5f,
+	0x62, 0x6f, 0x75, 0x6e, 0x64, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x64,
+	0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65, 0x72, 0x2e, 0x53, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74,
+	0x79, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x42, 0x6f, 0x75, 0x6e, 0x64, 0x73, 0x52, 0x0d, 0x64, 0x65,
+	0x66, 0x61, 0x75, 0x6c, 0x74, 0x42, 0x6f, 0x75, 0x6e, 0x64, 0x73, 0x12, 0x49, 0x0a, 0x0d, 0x71,
+	0x75, 0x6f, 0x72, 0x75, 0x6d, 0x5f, 0x62, 0x6f, 0x75, 0x6e, 0x64, 0x73, 0x18, 0x02, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x24, 0x2e, 0x64, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65, 0x72, 0x2e, 0x51,
+	0x75, 0x6f, 0x72, 0x75, 0x6d, 0x53, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x50, 0x61, 0x72,
+	0x61, 0x6d, 0x42, 0x6f, 0x75, 0x6e, 0x64, 0x73, 0x52, 0x0c, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d,
+	0x42, 0x6f, 0x75, 0x6e, 0x64, 0x73, 0x22, 0x70, 0x0a, 0x19, 0x51, 0x75, 0x6f, 0x72, 0x75, 0x6d,
+	0x53, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x42, 0x6f, 0x75,
+	0x6e, 0x64, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x5f, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x49, 0x64,
+	0x12, 0x36, 0x0a, 0x06, 0x62, 0x6f, 0x75, 0x6e, 0x64, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1e, 0x2e, 0x64, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65, 0x72, 0x2e, 0x53, 0x65, 0x63,
+	0x75, 0x72, 0x69, 0x74, 0x79, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x42, 0x6f, 0x75, 0x6e, 0x64, 0x73,
+	0x52, 0x06, 0x62, 0x6f, 0x75, 0x6e, 0x64, 0x73, 0x22, 0xab, 0x02, 0x0a, 0x13, 0x53, 0x65, 0x63,
+	0x75, 0x72, 0x69, 0x74, 0x79, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x42, 0x6f, 0x75, 0x6e, 0x64, 0x73,
+	0x12, 0x36, 0x0a, 0x17, 0x6d, 0x69, 0x6e, 0x5f, 0x61, 0x64, 0x76, 0x65, 0x72, 0x73, 0x61, 0x72,
+	0x79, 0x5f, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x15, 0x6d, 0x69, 0x6e, 0x41, 0x64, 0x76, 0x65, 0x72, 0x73, 0x61, 0x72, 0x79, 0x54,
+	0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x12, 0x36, 0x0a, 0x17, 0x6d, 0x61, 0x78, 0x5f,
+	0x61, 0x64, 0x76, 0x65, 0x72, 0x73, 0x61, 0x72, 0x79, 0x5f, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68,
+	0x6f, 0x6c, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x15, 0x6d, 0x61, 0x78, 0x41, 0x64,
+	0x76, 0x65, 0x72, 0x73, 0x61, 0x72, 0x79, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64,
+	0x12, 0x30, 0x0a, 0x14, 0x6d, 0x69, 0x6e, 0x5f, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x5f, 0x74,
+	0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x12,
+	0x6d, 0x69, 0x6e, 0x51, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f,
+	0x6c, 0x64, 0x12, 0x30, 0x0a, 0x14, 0x6d, 0x61, 0x78, 0x5f, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d,
+	0x5f, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x12, 0x6d, 0x61, 0x78, 0x51, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x54, 0x68, 0x72, 0x65, 0x73,
+	0x68, 0x6f, 0x6c, 0x64, 0x12, 0x40, 0x0a, 0x1c, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x5f,
+	0x71, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x66, 0x61, 0x63,
+	0x74, 0x6f, 0x72, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x1a, 0x61, 0x6c, 0x6c, 0x6f,
+	0x77, 0x65, 0x64, 0x51, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x46,
+	0x61, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x22, 0x89, 0x01, 0x0a, 0x0e, 0x53, 0x65, 0x63, 0x75, 0x72,
+	0x69, 0x74, 0x79, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x71, 0x75, 0x6f,
+	0x72, 0x75, 0x6d, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x71, 0x75,
+	0x6f, 0x72, 0x75, 0x6d, 0x49, 0x64, 0x12, 0x2f, 0x0a, 0x13, 0x61, 0x64, 0x76, 0x65, 0x72, 0x73,
+	0x61, 0x72, 0x79, 0x5f, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x12, 0x61, 0x64, 0x76, 0x65, 0x72, 0x73, 0x61, 0x72, 0x79, 0x54, 0x68,
+	0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x12, 0x29, 0x0a, 0x10, 0x71, 0x75, 0x6f, 0x72, 0x75,
+	0x6d, 0x5f, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x0f, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f,
+	0x6c, 0x64, 0x22, 0x9c, 0x01, 0x0a, 0x08, 0x42, 0x6c, 0x6f, 0x62, 0x49, 0x6e, 0x66, 0x6f, 0x12,
+	0x36, 0x0a, 0x0b, 0x62, 0x6c, 0x6f, 0x62, 0x5f, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x64, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65, 0x72,
+	0x2e, 0x42, 0x6c, 0x6f, 0x62, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x52, 0x0a, 0x62, 0x6c, 0x6f,
+	0x62, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x58, 0x0a, 0x17, 0x62, 0x6c, 0x6f, 0x62, 0x5f,
+	0x76, 0x65, 0x72, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x70, 0x72, 0x6f,
+	0x6f, 0x66, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x64, 0x69, 0x73, 0x70, 0x65,
+	0x72, 0x73, 0x65, 0x72, 0x2e, 0x42, 0x6c, 0x6f, 0x62, 0x56, 0x65, 0x72, 0x69, 0x66, 0x69, 0x63,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x52, 0x15, 0x62, 0x6c, 0x6f, 0x62,
+	0x56, 0x65, 0x72, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x72, 0x6f, 0x6f,
+	0x66, 0x22, 0x97, 0x01, 0x0a, 0x0a, 0x42, 0x6c, 0x6f, 0x62, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72,
+	0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74,
+	0x12, 0x1f, 0x0a, 0x0b, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x64, 0x61, 0x74, 0x61, 0x4c, 0x65, 0x6e, 0x67, 0x74,
+	0x68, 0x12, 0x48, 0x0a, 0x12, 0x62, 0x6c, 0x6f, 0x62, 0x5f, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d,
+	0x5f, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
+	0x64, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65, 0x72, 0x2e, 0x42, 0x6c, 0x6f, 0x62, 0x51, 0x75,
+	0x6f, 0x72, 0x75, 0x6d, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x52, 0x10, 0x62, 0x6c, 0x6f, 0x62, 0x51,
+	0x75, 0x6f, 0x72, 0x75, 0x6d, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x22, 0xc7, 0x02, 0x0a, 0x0f,
+	0x42, 0x6c, 0x6f, 0x62, 0x51, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x12,
+	0x23, 0x0a, 0x0d, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0c, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x4e, 0x75,
+	0x6d, 0x62, 0x65, 0x72, 0x12, 0x44, 0x0a, 0x1e, 0x61, 0x64, 0x76, 0x65, 0x72, 0x73, 0x61, 0x72,
+	0x79, 0x5f, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x5f, 0x70, 0x65, 0x72, 0x63,
+	0x65, 0x6e, 0x74, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x1c, 0x61, 0x64,
+	0x76, 0x65, 0x72, 0x73, 0x61, 0x72, 0x79, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64,
+	0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x61, 0x67, 0x65, 0x12, 0x3e, 0x0a, 0x1b, 0x71, 0x75,
+	0x6f, 0x72, 0x75, 0x6d, 0x5f, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x5f, 0x70,
+	0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x19, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64,
+	0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x61, 0x67, 0x65, 0x12, 0x2d, 0x0a, 0x12, 0x71, 0x75,
+	0x61, 0x6e, 0x74, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x70, 0x61, 0x72, 0x61, 0x6d,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x11, 0x71, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x7a, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x12, 0x25, 0x0a, 0x0e, 0x65, 0x6e, 0x63,
+	0x6f, 0x64, 0x65, 0x64, 0x5f, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x0d, 0x65, 0x6e, 0x63, 0x6f, 0x64, 0x65, 0x64, 0x4c, 0x65, 0x6e, 0x67, 0x74, 0x68,
+	0x12, 0x33, 0x0a, 0x15, 0x6f, 0x76, 0x65, 0x72, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x73, 0x69, 0x6f,
+	0x6e, 0x5f, 0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x14, 0x6f, 0x76, 0x65, 0x72, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x50, 0x65,
+	0x72, 0x63, 0x65, 0x6e, 0x74, 0x22, 0xe2, 0x01, 0x0a, 0x15, 0x42, 0x6c, 0x6f, 0x62, 0x56, 0x65,
+	0x72, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x12,
+	0x19, 0x0a, 0x08, 0x62, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x07, 0x62, 0x61, 0x74, 0x63, 0x68, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x62, 0x6c,
 	0x6f, 0x62, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09,
-	0x62, 0x6c, 0x6f, 0x62, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x22, 0x27, 0x0a, 0x11, 0x52, 0x65, 0x74,
-	0x72, 0x69, 0x65, 0x76, 0x65, 0x42, 0x6c, 0x6f, 0x62, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x12,
-	0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61,
-	0x74, 0x61, 0x22, 0x89, 0x01, 0x0a, 0x0e, 0x53, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x50,
-	0x61, 0x72, 0x61, 0x6d, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x5f,
-	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d,
-	0x49, 0x64, 0x12, 0x2f, 0x0a, 0x13, 0x61, 0x64, 0x76, 0x65, 0x72, 0x73, 0x61, 0x72, 0x79, 0x5f,
-	0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52,
-	0x12, 0x61, 0x64, 0x76, 0x65, 0x72, 0x73, 0x61, 0x72, 0x79, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68,
-	0x6f, 0x6c, 0x64, 0x12, 0x29, 0x0a, 0x10, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x5f, 0x74, 0x68,
-	0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0f, 0x71,
-	0x75, 0x6f, 0x72, 0x75, 0x6d, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x22, 0x9c,
-	0x01, 0x0a, 0x08, 0x42, 0x6c, 0x6f, 0x62, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x36, 0x0a, 0x0b, 0x62,
-	0x6c, 0x6f, 0x62, 0x5f, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x15, 0x2e, 0x64, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65, 0x72, 0x2e, 0x42, 0x6c, 0x6f,
-	0x62, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x52, 0x0a, 0x62, 0x6c, 0x6f, 0x62, 0x48, 0x65, 0x61,
-	0x64, 0x65, 0x72, 0x12, 0x58, 0x0a, 0x17, 0x62, 0x6c, 0x6f, 0x62, 0x5f, 0x76, 0x65, 0x72, 0x69,
-	0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x64, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65, 0x72,
-	0x2e, 0x42, 0x6c, 0x6f, 0x62, 0x56, 0x65, 0x72, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f,
-	0x6e, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x52, 0x15, 0x62, 0x6c, 0x6f, 0x62, 0x56, 0x65, 0x72, 0x69,
-	0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x22, 0x97, 0x01,
-	0x0a, 0x0a, 0x42, 0x6c, 0x6f, 0x62, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x1e, 0x0a, 0x0a,
-	0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c,
-	0x52, 0x0a, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x1f, 0x0a, 0x0b,
-	0x64, 0x61, 0x74, 0x61, 0x5f, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x0d, 0x52, 0x0a, 0x64, 0x61, 0x74, 0x61, 0x4c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x12, 0x48, 0x0a,
-	0x12, 0x62, 0x6c, 0x6f, 0x62, 0x5f, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x5f, 0x70, 0x61, 0x72,
-	0x61, 0x6d, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x64, 0x69, 0x73, 0x70,
-	0x65, 0x72, 0x73, 0x65, 0x72, 0x2e, 0x42, 0x6c, 0x6f, 0x62, 0x51, 0x75, 0x6f, 0x72, 0x75, 0x6d,
-	0x50, 0x61, 0x72, 0x61, 0x6d, 0x52, 0x10, 0x62, 0x6c, 0x6f, 0x62, 0x51, 0x75, 0x6f, 0x72, 0x75,
-	0x6d, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x22, 0x92, 0x02, 0x0a, 0x0f, 0x42, 0x6c, 0x6f, 0x62,
-	0x51, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x12, 0x23, 0x0a, 0x0d, 0x71,
-	0x75, 0x6f, 0x72, 0x75, 0x6d, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x0d, 0x52, 0x0c, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72,
-	0x12, 0x44, 0x0a, 0x1e, 0x61, 0x64, 0x76, 0x65, 0x72, 0x73, 0x61, 0x72, 0x79, 0x5f, 0x74, 0x68,
-	0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x5f, 0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x61,
-	0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x1c, 0x61, 0x64, 0x76, 0x65, 0x72, 0x73,
-	0x61, 0x72, 0x79, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x50, 0x65, 0x72, 0x63,
-	0x65, 0x6e, 0x74, 0x61, 0x67, 0x65, 0x12, 0x3e, 0x0a, 0x1b, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d,
-	0x5f, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x5f, 0x70, 0x65, 0x72, 0x63, 0x65,
-	0x6e, 0x74, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x19, 0x71, 0x75, 0x6f,
-	0x72, 0x75, 0x6d, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x50, 0x65, 0x72, 0x63,
-	0x65, 0x6e, 0x74, 0x61, 0x67, 0x65, 0x12, 0x2d, 0x0a, 0x12, 0x71, 0x75, 0x61, 0x6e, 0x74, 0x69,
-	0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x18, 0x04, 0x20, 0x01,
-	0x28, 0x0d, 0x52, 0x11, 0x71, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e,
-	0x50, 0x61, 0x72, 0x61, 0x6d, 0x12, 0x25, 0x0a, 0x0e, 0x65, 0x6e, 0x63, 0x6f, 0x64, 0x65, 0x64,
-	0x5f, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0d, 0x65,
-	0x6e, 0x63, 0x6f, 0x64, 0x65, 0x64, 0x4c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x22, 0xe2, 0x01, 0x0a,
-	0x15, 0x42, 0x6c, 0x6f, 0x62, 0x56, 0x65, 0x72, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f,
-	0x6e, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x12, 0x19, 0x0a, 0x08, 0x62, 0x61, 0x74, 0x63, 0x68, 0x5f,
-	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x62, 0x61, 0x74, 0x63, 0x68, 0x49,
-	0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x62, 0x6c, 0x6f, 0x62, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x62, 0x6c, 0x6f, 0x62, 0x49, 0x6e, 0x64, 0x65, 0x78,
-	0x12, 0x3f, 0x0a, 0x0e, 0x62, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61,
-	0x74, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x64, 0x69, 0x73, 0x70, 0x65,
-	0x72, 0x73, 0x65, 0x72, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61,
-	0x74, 0x61, 0x52, 0x0d, 0x62, 0x61, 0x74, 0x63, 0x68, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74,
-	0x61, 0x12, 0x27, 0x0a, 0x0f, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x70,
-	0x72, 0x6f, 0x6f, 0x66, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0e, 0x69, 0x6e, 0x63, 0x6c,
-	0x75, 0x73, 0x69, 0x6f, 0x6e, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x12, 0x25, 0x0a, 0x0e, 0x71, 0x75,
-	0x6f, 0x72, 0x75, 0x6d, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x65, 0x73, 0x18, 0x05, 0x20, 0x01,
-	0x28, 0x0c, 0x52, 0x0d, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x65,
-	0x73, 0x22, 0xf8, 0x01, 0x0a, 0x0d, 0x42, 0x61, 0x74, 0x63, 0x68, 0x4d, 0x65, 0x74, 0x61, 0x64,
-	0x61, 0x74, 0x61, 0x12, 0x39, 0x0a, 0x0c, 0x62, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x68, 0x65, 0x61,
-	0x64, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x64, 0x69, 0x73, 0x70,
-	0x65, 0x72, 0x73, 0x65, 0x72, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x48, 0x65, 0x61, 0x64, 0x65,
-	0x72, 0x52, 0x0b, 0x62, 0x61, 0x74, 0x63, 0x68, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x32,
-	0x0a, 0x15, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x79, 0x5f, 0x72, 0x65, 0x63, 0x6f,
-	0x72, 0x64, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x13, 0x73,
-	0x69, 0x67, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x48, 0x61,
-	0x73, 0x68, 0x12, 0x10, 0x0a, 0x03, 0x66, 0x65, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52,
-	0x03, 0x66, 0x65, 0x65, 0x12, 0x3a, 0x0a, 0x19, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x61,
-	0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65,
-	0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x17, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d,
-	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72,
-	0x12, 0x2a, 0x0a, 0x11, 0x62, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72,
-	0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0f, 0x62, 0x61, 0x74,
-	0x63, 0x68, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x48, 0x61, 0x73, 0x68, 0x22, 0xc5, 0x01, 0x0a,
-	0x0b, 0x42, 0x61, 0x74, 0x63, 0x68, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x1d, 0x0a, 0x0a,
-	0x62, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x72, 0x6f, 0x6f, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c,
-	0x52, 0x09, 0x62, 0x61, 0x74, 0x63, 0x68, 0x52, 0x6f, 0x6f, 0x74, 0x12, 0x25, 0x0a, 0x0e, 0x71,
-	0x75, 0x6f, 0x72, 0x75, 0x6d, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x0c, 0x52, 0x0d, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x4e, 0x75, 0x6d, 0x62, 0x65,
-	0x72, 0x73, 0x12, 0x3a, 0x0a, 0x19, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x5f, 0x73, 0x69, 0x67,
-	0x6e, 0x65, 0x64, 0x5f, 0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x61, 0x67, 0x65, 0x73, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x17, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x53, 0x69, 0x67,
-	0x6e, 0x65, 0x64, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x61, 0x67, 0x65, 0x73, 0x12, 0x34,
-	0x0a, 0x16, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x5f, 0x62, 0x6c, 0x6f, 0x63,
-	0x6b, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x14,
-	0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x4e, 0x75,
-	0x6d, 0x62, 0x65, 0x72, 0x2a, 0x70, 0x0a, 0x0a, 0x42, 0x6c, 0x6f, 0x62, 0x53, 0x74, 0x61, 0x74,
-	0x75, 0x73, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12,
-	0x0e, 0x0a, 0x0a, 0x50, 0x52, 0x4f, 0x43, 0x45, 0x53, 0x53, 0x49, 0x4e, 0x47, 0x10, 0x01, 0x12,
-	0x0d, 0x0a, 0x09, 0x43, 0x4f, 0x4e, 0x46, 0x49, 0x52, 0x4d, 0x45, 0x44, 0x10, 0x02, 0x12, 0x0a,
-	0x0a, 0x06, 0x46, 0x41, 0x49, 0x4c, 0x45, 0x44, 0x10, 0x03, 0x12, 0x0d, 0x0a, 0x09, 0x46, 0x49,
-	0x4e, 0x41, 0x4c, 0x49, 0x5a, 0x45, 0x44, 0x10, 0x04, 0x12, 0x1b, 0x0a, 0x17, 0x49, 0x4e, 0x53,
-	0x55, 0x46, 0x46, 0x49, 0x43, 0x49, 0x45, 0x4e, 0x54, 0x5f, 0x53, 0x49, 0x47, 0x4e, 0x41, 0x54,
-	0x55, 0x52, 0x45, 0x53, 0x10, 0x05, 0x32, 0xf8, 0x01, 0x0a, 0x09, 0x44, 0x69, 0x73, 0x70, 0x65,
-	0x72, 0x73, 0x65, 0x72, 0x12, 0x4e, 0x0a, 0x0c, 0x44, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65,
-	0x42, 0x6c, 0x6f, 0x62, 0x12, 0x1e, 0x2e, 0x64, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65, 0x72,
-	0x2e, 0x44, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65, 0x42, 0x6c, 0x6f, 0x62, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x64, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65, 0x72,
-	0x2e, 0x44, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65, 0x42, 0x6c, 0x6f, 0x62, 0x52, 0x65, 0x70,
-	0x6c, 0x79, 0x22, 0x00, 0x12, 0x4b, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x42, 0x6c, 0x6f, 0x62, 0x53,
-	0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1c, 0x2e, 0x64, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65,
-	0x72, 0x2e, 0x42, 0x6c, 0x6f, 0x62, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x64, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65, 0x72, 0x2e,
-	0x42, 0x6c, 0x6f, 0x62, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22,
-	0x00, 0x12, 0x4e, 0x0a, 0x0c, 0x52, 0x65, 0x74, 0x72, 0x69, 0x65, 0x76, 0x65, 0x42, 0x6c, 0x6f,
+	0x62, 0x6c, 0x6f, 0x62, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x3f, 0x0a, 0x0e, 0x62, 0x61, 0x74,
+	0x63, 0x68, 0x5f, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x18, 0x2e, 0x64, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65, 0x72, 0x2e, 0x42, 0x61,
+	0x74, 0x63, 0x68, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x0d, 0x62, 0x61, 0x74,
+	0x63, 0x68, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x27, 0x0a, 0x0f, 0x69, 0x6e,
+	0x63, 0x6c, 0x75, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x0e, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x73, 0x69, 0x6f, 0x6e, 0x50, 0x72,
+	0x6f, 0x6f, 0x66, 0x12, 0x25, 0x0a, 0x0e, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x5f, 0x69, 0x6e,
+	0x64, 0x65, 0x78, 0x65, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0d, 0x71, 0x75, 0x6f,
+	0x72, 0x75, 0x6d, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x65, 0x73, 0x22, 0xda, 0x01, 0x0a, 0x12, 0x42,
+	0x6c, 0x6f, 0x62, 0x49, 0x6e, 0x63, 0x6c, 0x75, 0x73, 0x69, 0x6f, 0x6e, 0x50, 0x72, 0x6f, 0x6f,
+	0x66, 0x12, 0x1d, 0x0a, 0x0a, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x64,
+	0x12, 0x36, 0x0a, 0x0b, 0x62, 0x6c, 0x6f, 0x62, 0x5f, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x64, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65,
+	0x72, 0x2e, 0x42, 0x6c, 0x6f, 0x62, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x52, 0x0a, 0x62, 0x6c,
+	0x6f, 0x62, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x1d, 0x0a, 0x0a, 0x62, 0x6c, 0x6f, 0x62,
+	0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x62, 0x6c,
+	0x6f, 0x62, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x27, 0x0a, 0x0f, 0x69, 0x6e, 0x63, 0x6c, 0x75,
+	0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x0e, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x73, 0x69, 0x6f, 0x6e, 0x50, 0x72, 0x6f, 0x6f, 0x66,
+	0x12, 0x25, 0x0a, 0x0e, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78,
+	0x65, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0d, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d,
+	0x49, 0x6e, 0x64, 0x65, 0x78, 0x65, 0x73, 0x22, 0xf8, 0x01, 0x0a, 0x0d, 0x42, 0x61, 0x74, 0x63,
+	0x68, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x39, 0x0a, 0x0c, 0x62, 0x61, 0x74,
+	0x63, 0x68, 0x5f, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x16, 0x2e, 0x64, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65, 0x72, 0x2e, 0x42, 0x61, 0x74, 0x63,
+	0x68, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x52, 0x0b, 0x62, 0x61, 0x74, 0x63, 0x68, 0x48, 0x65,
+	0x61, 0x64, 0x65, 0x72, 0x12, 0x32, 0x0a, 0x15, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x6f, 0x72,
+	0x79, 0x5f, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x13, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65,
+	0x63, 0x6f, 0x72, 0x64, 0x48, 0x61, 0x73, 0x68, 0x12, 0x10, 0x0a, 0x03, 0x66, 0x65, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x66, 0x65, 0x65, 0x12, 0x3a, 0x0a, 0x19, 0x63, 0x6f,
+	0x6e, 0x66, 0x69, 0x72, 0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b,
+	0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x17, 0x63,
+	0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x6c, 0x6f, 0x63, 0x6b,
+	0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x2a, 0x0a, 0x11, 0x62, 0x61, 0x74, 0x63, 0x68, 0x5f,
+	0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x0f, 0x62, 0x61, 0x74, 0x63, 0x68, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x48, 0x61,
+	0x73, 0x68, 0x22, 0xc5, 0x01, 0x0a, 0x0b, 0x42, 0x61, 0x74, 0x63, 0x68, 0x48, 0x65, 0x61, 0x64,
+	0x65, 0x72, 0x12, 0x1d, 0x0a, 0x0a, 0x62, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x72, 0x6f, 0x6f, 0x74,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x62, 0x61, 0x74, 0x63, 0x68, 0x52, 0x6f, 0x6f,
+	0x74, 0x12, 0x25, 0x0a, 0x0e, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x5f, 0x6e, 0x75, 0x6d, 0x62,
+	0x65, 0x72, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0d, 0x71, 0x75, 0x6f, 0x72, 0x75,
+	0x6d, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x12, 0x3a, 0x0a, 0x19, 0x71, 0x75, 0x6f, 0x72,
+	0x75, 0x6d, 0x5f, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x5f, 0x70, 0x65, 0x72, 0x63, 0x65, 0x6e,
+	0x74, 0x61, 0x67, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x17, 0x71, 0x75, 0x6f,
+	0x72, 0x75, 0x6d, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74,
+	0x61, 0x67, 0x65, 0x73, 0x12, 0x34, 0x0a, 0x16, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63,
+	0x65, 0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x14, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x42,
+	0x6c, 0x6f, 0x63, 0x6b, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x2a, 0x2f, 0x0a, 0x0a, 0x42, 0x6c,
+	0x6f, 0x62, 0x4c, 0x61, 0x79, 0x6f, 0x75, 0x74, 0x12, 0x0d, 0x0a, 0x09, 0x46, 0x52, 0x45, 0x45,
+	0x5f, 0x46, 0x4f, 0x52, 0x4d, 0x10, 0x00, 0x12, 0x12, 0x0a, 0x0e, 0x46, 0x49, 0x45, 0x4c, 0x44,
+	0x5f, 0x45, 0x4c, 0x45, 0x4d, 0x45, 0x4e, 0x54, 0x53, 0x10, 0x01, 0x2a, 0x84, 0x01, 0x0a, 0x0a,
+	0x42, 0x6c, 0x6f, 0x62, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e,
+	0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x0e, 0x0a, 0x0a, 0x50, 0x52, 0x4f, 0x43, 0x45,
+	0x53, 0x53, 0x49, 0x4e, 0x47, 0x10, 0x01, 0x12, 0x0d, 0x0a, 0x09, 0x43, 0x4f, 0x4e, 0x46, 0x49,
+	0x52, 0x4d, 0x45, 0x44, 0x10, 0x02, 0x12, 0x0a, 0x0a, 0x06, 0x46, 0x41, 0x49, 0x4c, 0x45, 0x44,
+	0x10, 0x03, 0x12, 0x0d, 0x0a, 0x09, 0x46, 0x49, 0x4e, 0x41, 0x4c, 0x49, 0x5a, 0x45, 0x44, 0x10,
+	0x04, 0x12, 0x1b, 0x0a, 0x17, 0x49, 0x4e, 0x53, 0x55, 0x46, 0x46, 0x49, 0x43, 0x49, 0x45, 0x4e,
+	0x54, 0x5f, 0x53, 0x49, 0x47, 0x4e, 0x41, 0x54, 0x55, 0x52, 0x45, 0x53, 0x10, 0x05, 0x12, 0x12,
+	0x0a, 0x0e, 0x51, 0x55, 0x4f, 0x54, 0x41, 0x5f, 0x45, 0x58, 0x43, 0x45, 0x45, 0x44, 0x45, 0x44,
+	0x10, 0x06, 0x32, 0xad, 0x09, 0x0a, 0x09, 0x44, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65, 0x72,
+	0x12, 0x4e, 0x0a, 0x0c, 0x44, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65, 0x42, 0x6c, 0x6f, 0x62,
+	0x12, 0x1e, 0x2e, 0x64, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65, 0x72, 0x2e, 0x44, 0x69, 0x73,
+	0x70, 0x65, 0x72, 0x73, 0x65, 0x42, 0x6c, 0x6f, 0x62, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x1c, 0x2e, 0x64, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65, 0x72, 0x2e, 0x44, 0x69, 0x73,
+	0x70, 0x65, 0x72, 0x73, 0x65, 0x42, 0x6c, 0x6f, 0x62, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00,
+	0x12, 0x4b, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x42, 0x6c, 0x6f, 0x62, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x12, 0x1c, 0x2e, 0x64, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65, 0x72, 0x2e, 0x42, 0x6c,
+	0x6f, 0x62, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x1a, 0x2e, 0x64, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65, 0x72, 0x2e, 0x42, 0x6c, 0x6f, 0x62,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x4e, 0x0a,
+	0x0c, 0x52, 0x65, 0x74, 0x72, 0x69, 0x65, 0x76, 0x65, 0x42, 0x6c, 0x6f, 0x62, 0x12, 0x1e, 0x2e,
+	0x64, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65, 0x72, 0x2e, 0x52, 0x65, 0x74, 0x72, 0x69, 0x65,
+	0x76, 0x65, 0x42, 0x6c, 0x6f, 0x62, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e,
+	0x64, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65, 0x72, 0x2e, 0x52, 0x65, 0x74, 0x72, 0x69, 0x65,
+	0x76, 0x65, 0x42, 0x6c, 0x6f, 0x62, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x62, 0x0a,
+	0x12, 0x52, 0x65, 0x74, 0x72, 0x69, 0x65, 0x76, 0x65, 0x42, 0x61, 0x74, 0x63, 0x68, 0x42, 0x6c,
+	0x6f, 0x62, 0x73, 0x12, 0x24, 0x2e, 0x64, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65, 0x72, 0x2e,
+	0x52, 0x65, 0x74, 0x72, 0x69, 0x65, 0x76, 0x65, 0x42, 0x61, 0x74, 0x63, 0x68, 0x42, 0x6c, 0x6f,
+	0x62, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x64, 0x69, 0x73, 0x70,
+	0x65, 0x72, 0x73, 0x65, 0x72, 0x2e, 0x52, 0x65, 0x74, 0x72, 0x69, 0x65, 0x76, 0x65, 0x42, 0x61,
+	0x74, 0x63, 0x68, 0x42, 0x6c, 0x6f, 0x62, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x30,
+	0x01, 0x12, 0x72, 0x0a, 0x1a, 0x47, 0x65, 0x74, 0x42, 0x61, 0x74, 0x63, 0x68, 0x56, 0x65, 0x72,
+	0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x73, 0x12,
+	0x29, 0x2e, 0x64, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65, 0x72, 0x2e, 0x42, 0x61, 0x74, 0x63,
+	0x68, 0x56, 0x65, 0x72, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x72, 0x6f,
+	0x6f, 0x66, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x27, 0x2e, 0x64, 0x69, 0x73,
+	0x70, 0x65, 0x72, 0x73, 0x65, 0x72, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x56, 0x65, 0x72, 0x69,
+	0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x73, 0x52, 0x65,
+	0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x5a, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x42, 0x6c, 0x6f, 0x62,
+	0x50, 0x72, 0x6f, 0x6f, 0x66, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x12, 0x21, 0x2e, 0x64, 0x69,
+	0x73, 0x70, 0x65, 0x72, 0x73, 0x65, 0x72, 0x2e, 0x42, 0x6c, 0x6f, 0x62, 0x50, 0x72, 0x6f, 0x6f,
+	0x66, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f,
+	0x2e, 0x64, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65, 0x72, 0x2e, 0x42, 0x6c, 0x6f, 0x62, 0x50,
+	0x72, 0x6f, 0x6f, 0x66, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22,
+	0x00, 0x12, 0x69, 0x0a, 0x17, 0x47, 0x65, 0x74, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72,
+	0x53, 0x74, 0x61, 0x74, 0x65, 0x41, 0x74, 0x42, 0x61, 0x74, 0x63, 0x68, 0x12, 0x26, 0x2e, 0x64,
+	0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65, 0x72, 0x2e, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f,
+	0x72, 0x53, 0x74, 0x61, 0x74, 0x65, 0x41, 0x74, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x64, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65, 0x72,
+	0x2e, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x53, 0x74, 0x61, 0x74, 0x65, 0x41, 0x74,
+	0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x63, 0x0a, 0x13,
+	0x45, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x42, 0x6c, 0x6f, 0x62, 0x52, 0x65, 0x74, 0x65, 0x6e, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0x25, 0x2e, 0x64, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65, 0x72, 0x2e,
+	0x45, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x42, 0x6c, 0x6f, 0x62, 0x52, 0x65, 0x74, 0x65, 0x6e, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x64, 0x69, 0x73,
+	0x70, 0x65, 0x72, 0x73, 0x65, 0x72, 0x2e, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x42, 0x6c, 0x6f,
+	0x62, 0x52, 0x65, 0x74, 0x65, 0x6e, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22,
+	0x00, 0x12, 0x4e, 0x0a, 0x0c, 0x52, 0x65, 0x73, 0x75, 0x62, 0x6d, 0x69, 0x74, 0x42, 0x6c, 0x6f,
 	0x62, 0x12, 0x1e, 0x2e, 0x64, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65, 0x72, 0x2e, 0x52, 0x65,
-	0x74, 0x72, 0x69, 0x65, 0x76, 0x65, 0x42, 0x6c, 0x6f, 0x62, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x73, 0x75, 0x62, 0x6d, 0x69, 0x74, 0x42, 0x6c, 0x6f, 0x62, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
 	0x74, 0x1a, 0x1c, 0x2e, 0x64, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65, 0x72, 0x2e, 0x52, 0x65,
-	0x74, 0x72, 0x69, 0x65, 0x76, 0x65, 0x42, 0x6c, 0x6f, 0x62, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22,
-	0x00, 0x42, 0x31, 0x5a, 0x2f, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
-	0x4c, 0x61, 0x79, 0x72, 0x2d, 0x4c, 0x61, 0x62, 0x73, 0x2f, 0x65, 0x69, 0x67, 0x65, 0x6e, 0x64,
-	0x61, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x67, 0x72, 0x70, 0x63, 0x2f, 0x64, 0x69, 0x73, 0x70, 0x65,
-	0x72, 0x73, 0x65, 0x72, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x73, 0x75, 0x62, 0x6d, 0x69, 0x74, 0x42, 0x6c, 0x6f, 0x62, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22,
+	0x00, 0x12, 0x48, 0x0a, 0x0c, 0x47, 0x65, 0x74, 0x42, 0x61, 0x74, 0x63, 0x68, 0x43, 0x6f, 0x73,
+	0x74, 0x12, 0x1b, 0x2e, 0x64, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65, 0x72, 0x2e, 0x42, 0x61,
+	0x74, 0x63, 0x68, 0x43, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19,
+	0x2e, 0x64, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65, 0x72, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68,
+	0x43, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x5a, 0x0a, 0x12, 0x47,
+	0x65, 0x74, 0x44, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x12, 0x21, 0x2e, 0x64, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65, 0x72, 0x2e, 0x44, 0x69,
+	0x73, 0x70, 0x65, 0x72, 0x73, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x64, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65, 0x72,
+	0x2e, 0x44, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67,
+	0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x5a, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x52, 0x61,
+	0x74, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x21, 0x2e,
+	0x64, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65, 0x72, 0x2e, 0x52, 0x61, 0x74, 0x65, 0x4c, 0x69,
+	0x6d, 0x69, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x1f, 0x2e, 0x64, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65, 0x72, 0x2e, 0x52, 0x61, 0x74,
+	0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x70, 0x6c,
+	0x79, 0x22, 0x00, 0x12, 0x5d, 0x0a, 0x13, 0x47, 0x65, 0x74, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74,
+	0x6f, 0x72, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x22, 0x2e, 0x64, 0x69, 0x73,
+	0x70, 0x65, 0x72, 0x73, 0x65, 0x72, 0x2e, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x56,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20,
+	0x2e, 0x64, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65, 0x72, 0x2e, 0x4f, 0x70, 0x65, 0x72, 0x61,
+	0x74, 0x6f, 0x72, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79,
+	0x22, 0x00, 0x42, 0x31, 0x5a, 0x2f, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d,
+	0x2f, 0x4c, 0x61, 0x79, 0x72, 0x2d, 0x4c, 0x61, 0x62, 0x73, 0x2f, 0x65, 0x69, 0x67, 0x65, 0x6e,
+	0x64, 0x61, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x67, 0x72, 0x70, 0x63, 0x2f, 0x64, 0x69, 0x73, 0x70,
+	0x65, 0x72, 0x73, 0x65, 0x72, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1152,45 +3746,117 @@ func file_disperser_disperser_proto_rawDescGZIP() []byte {
 	return file_disperser_disperser_proto_rawDescData
 }
 
-var file_disperser_disperser_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_disperser_disperser_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_disperser_disperser_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_disperser_disperser_proto_msgTypes = make([]protoimpl.MessageInfo, 45)
 var file_disperser_disperser_proto_goTypes = []interface{}{
-	(BlobStatus)(0),               // 0: disperser.BlobStatus
-	(*DisperseBlobRequest)(nil),   // 1: disperser.DisperseBlobRequest
-	(*DisperseBlobReply)(nil),     // 2: disperser.DisperseBlobReply
-	(*BlobStatusRequest)(nil),     // 3: disperser.BlobStatusRequest
-	(*BlobStatusReply)(nil),       // 4: disperser.BlobStatusReply
-	(*RetrieveBlobRequest)(nil),   // 5: disperser.RetrieveBlobRequest
-	(*RetrieveBlobReply)(nil),     // 6: disperser.RetrieveBlobReply
-	(*SecurityParams)(nil),        // 7: disperser.SecurityParams
-	(*BlobInfo)(nil),              // 8: disperser.BlobInfo
-	(*BlobHeader)(nil),            // 9: disperser.BlobHeader
-	(*BlobQuorumParam)(nil),       // 10: disperser.BlobQuorumParam
-	(*BlobVerificationProof)(nil), // 11: disperser.BlobVerificationProof
-	(*BatchMetadata)(nil),         // 12: disperser.BatchMetadata
-	(*BatchHeader)(nil),           // 13: disperser.BatchHeader
+	(BlobLayout)(0),                        // 0: disperser.BlobLayout
+	(BlobStatus)(0),                        // 1: disperser.BlobStatus
+	(*DisperseBlobRequest)(nil),            // 2: disperser.DisperseBlobRequest
+	(*DisperseBlobReply)(nil),              // 3: disperser.DisperseBlobReply
+	(*BlobStatusRequest)(nil),              // 4: disperser.BlobStatusRequest
+	(*BlobStatusReply)(nil),                // 5: disperser.BlobStatusReply
+	(*BlobQuorumStatus)(nil),               // 6: disperser.BlobQuorumStatus
+	(*RetrieveBlobRequest)(nil),            // 7: disperser.RetrieveBlobRequest
+	(*RetrieveBlobReply)(nil),              // 8: disperser.RetrieveBlobReply
+	(*RetrieveBatchBlobsRequest)(nil),      // 9: disperser.RetrieveBatchBlobsRequest
+	(*RetrieveBatchBlobsReply)(nil),        // 10: disperser.RetrieveBatchBlobsReply
+	(*BatchVerificationProofsRequest)(nil), // 11: disperser.BatchVerificationProofsRequest
+	(*BatchVerificationProofsReply)(nil),   // 12: disperser.BatchVerificationProofsReply
+	(*BlobProofBundleRequest)(nil),         // 13: disperser.BlobProofBundleRequest
+	(*BlobProofBundleReply)(nil),           // 14: disperser.BlobProofBundleReply
+	(*OperatorStateAtBatchRequest)(nil),    // 15: disperser.OperatorStateAtBatchRequest
+	(*OperatorStateAtBatchReply)(nil),      // 16: disperser.OperatorStateAtBatchReply
+	(*QuorumStake)(nil),                    // 17: disperser.QuorumStake
+	(*OperatorStake)(nil),                  // 18: disperser.OperatorStake
+	(*ExtendBlobRetentionRequest)(nil),     // 19: disperser.ExtendBlobRetentionRequest
+	(*ExtendBlobRetentionReply)(nil),       // 20: disperser.ExtendBlobRetentionReply
+	(*ResubmitBlobRequest)(nil),            // 21: disperser.ResubmitBlobRequest
+	(*ResubmitBlobReply)(nil),              // 22: disperser.ResubmitBlobReply
+	(*BatchCostRequest)(nil),               // 23: disperser.BatchCostRequest
+	(*BatchCostReply)(nil),                 // 24: disperser.BatchCostReply
+	(*BlobCost)(nil),                       // 25: disperser.BlobCost
+	(*OperatorVersionsRequest)(nil),        // 26: disperser.OperatorVersionsRequest
+	(*OperatorVersionsReply)(nil),          // 27: disperser.OperatorVersionsReply
+	(*OperatorVersion)(nil),                // 28: disperser.OperatorVersion
+	(*FeatureStake)(nil),                   // 29: disperser.FeatureStake
+	(*DisperserConfigRequest)(nil),         // 30: disperser.DisperserConfigRequest
+	(*DisperserConfigReply)(nil),           // 31: disperser.DisperserConfigReply
+	(*RateLimitStatusRequest)(nil),         // 32: disperser.RateLimitStatusRequest
+	(*RateLimitStatusReply)(nil),           // 33: disperser.RateLimitStatusReply
+	(*SecurityPolicy)(nil),                 // 34: disperser.SecurityPolicy
+	(*QuorumSecurityParamBounds)(nil),      // 35: disperser.QuorumSecurityParamBounds
+	(*SecurityParamBounds)(nil),            // 36: disperser.SecurityParamBounds
+	(*SecurityParams)(nil),                 // 37: disperser.SecurityParams
+	(*BlobInfo)(nil),                       // 38: disperser.BlobInfo
+	(*BlobHeader)(nil),                     // 39: disperser.BlobHeader
+	(*BlobQuorumParam)(nil),                // 40: disperser.BlobQuorumParam
+	(*BlobVerificationProof)(nil),          // 41: disperser.BlobVerificationProof
+	(*BlobInclusionProof)(nil),             // 42: disperser.BlobInclusionProof
+	(*BatchMetadata)(nil),                  // 43: disperser.BatchMetadata
+	(*BatchHeader)(nil),                    // 44: disperser.BatchHeader
+	nil,                                    // 45: disperser.DisperseBlobRequest.MetadataEntry
+	nil,                                    // 46: disperser.BlobStatusReply.MetadataEntry
 }
 var file_disperser_disperser_proto_depIdxs = []int32{
-	7,  // 0: disperser.DisperseBlobRequest.security_params:type_name -> disperser.SecurityParams
-	0,  // 1: disperser.DisperseBlobReply.result:type_name -> disperser.BlobStatus
-	0,  // 2: disperser.BlobStatusReply.status:type_name -> disperser.BlobStatus
-	8,  // 3: disperser.BlobStatusReply.info:type_name -> disperser.BlobInfo
-	9,  // 4: disperser.BlobInfo.blob_header:type_name -> disperser.BlobHeader
-	11, // 5: disperser.BlobInfo.blob_verification_proof:type_name -> disperser.BlobVerificationProof
-	10, // 6: disperser.BlobHeader.blob_quorum_params:type_name -> disperser.BlobQuorumParam
-	12, // 7: disperser.BlobVerificationProof.batch_metadata:type_name -> disperser.BatchMetadata
-	13, // 8: disperser.BatchMetadata.batch_header:type_name -> disperser.BatchHeader
-	1,  // 9: disperser.Disperser.DisperseBlob:input_type -> disperser.DisperseBlobRequest
-	3,  // 10: disperser.Disperser.GetBlobStatus:input_type -> disperser.BlobStatusRequest
-	5,  // 11: disperser.Disperser.RetrieveBlob:input_type -> disperser.RetrieveBlobRequest
-	2,  // 12: disperser.Disperser.DisperseBlob:output_type -> disperser.DisperseBlobReply
-	4,  // 13: disperser.Disperser.GetBlobStatus:output_type -> disperser.BlobStatusReply
-	6,  // 14: disperser.Disperser.RetrieveBlob:output_type -> disperser.RetrieveBlobReply
-	12, // [12:15] is the sub-list for method output_type
-	9,  // [9:12] is the sub-list for method input_type
-	9,  // [9:9] is the sub-list for extension type_name
-	9,  // [9:9] is the sub-list for extension extendee
-	0,  // [0:9] is the sub-list for field type_name
+	37, // 0: disperser.DisperseBlobRequest.security_params:type_name -> disperser.SecurityParams
+	45, // 1: disperser.DisperseBlobRequest.metadata:type_name -> disperser.DisperseBlobRequest.MetadataEntry
+	0,  // 2: disperser.DisperseBlobRequest.layout:type_name -> disperser.BlobLayout
+	1,  // 3: disperser.DisperseBlobReply.result:type_name -> disperser.BlobStatus
+	40, // 4: disperser.DisperseBlobReply.blob_quorum_params:type_name -> disperser.BlobQuorumParam
+	1,  // 5: disperser.BlobStatusReply.status:type_name -> disperser.BlobStatus
+	38, // 6: disperser.BlobStatusReply.info:type_name -> disperser.BlobInfo
+	6,  // 7: disperser.BlobStatusReply.quorum_statuses:type_name -> disperser.BlobQuorumStatus
+	46, // 8: disperser.BlobStatusReply.metadata:type_name -> disperser.BlobStatusReply.MetadataEntry
+	43, // 9: disperser.BatchVerificationProofsReply.batch_metadata:type_name -> disperser.BatchMetadata
+	42, // 10: disperser.BatchVerificationProofsReply.blob_proofs:type_name -> disperser.BlobInclusionProof
+	17, // 11: disperser.OperatorStateAtBatchReply.quorum_totals:type_name -> disperser.QuorumStake
+	18, // 12: disperser.OperatorStateAtBatchReply.operators:type_name -> disperser.OperatorStake
+	37, // 13: disperser.ResubmitBlobRequest.security_params:type_name -> disperser.SecurityParams
+	1,  // 14: disperser.ResubmitBlobReply.result:type_name -> disperser.BlobStatus
+	25, // 15: disperser.BatchCostReply.blob_costs:type_name -> disperser.BlobCost
+	28, // 16: disperser.OperatorVersionsReply.operators:type_name -> disperser.OperatorVersion
+	29, // 17: disperser.OperatorVersionsReply.features:type_name -> disperser.FeatureStake
+	34, // 18: disperser.DisperserConfigReply.security_policy:type_name -> disperser.SecurityPolicy
+	36, // 19: disperser.SecurityPolicy.default_bounds:type_name -> disperser.SecurityParamBounds
+	35, // 20: disperser.SecurityPolicy.quorum_bounds:type_name -> disperser.QuorumSecurityParamBounds
+	36, // 21: disperser.QuorumSecurityParamBounds.bounds:type_name -> disperser.SecurityParamBounds
+	39, // 22: disperser.BlobInfo.blob_header:type_name -> disperser.BlobHeader
+	41, // 23: disperser.BlobInfo.blob_verification_proof:type_name -> disperser.BlobVerificationProof
+	40, // 24: disperser.BlobHeader.blob_quorum_params:type_name -> disperser.BlobQuorumParam
+	43, // 25: disperser.BlobVerificationProof.batch_metadata:type_name -> disperser.BatchMetadata
+	39, // 26: disperser.BlobInclusionProof.blob_header:type_name -> disperser.BlobHeader
+	44, // 27: disperser.BatchMetadata.batch_header:type_name -> disperser.BatchHeader
+	2,  // 28: disperser.Disperser.DisperseBlob:input_type -> disperser.DisperseBlobRequest
+	4,  // 29: disperser.Disperser.GetBlobStatus:input_type -> disperser.BlobStatusRequest
+	7,  // 30: disperser.Disperser.RetrieveBlob:input_type -> disperser.RetrieveBlobRequest
+	9,  // 31: disperser.Disperser.RetrieveBatchBlobs:input_type -> disperser.RetrieveBatchBlobsRequest
+	11, // 32: disperser.Disperser.GetBatchVerificationProofs:input_type -> disperser.BatchVerificationProofsRequest
+	13, // 33: disperser.Disperser.GetBlobProofBundle:input_type -> disperser.BlobProofBundleRequest
+	15, // 34: disperser.Disperser.GetOperatorStateAtBatch:input_type -> disperser.OperatorStateAtBatchRequest
+	19, // 35: disperser.Disperser.ExtendBlobRetention:input_type -> disperser.ExtendBlobRetentionRequest
+	21, // 36: disperser.Disperser.ResubmitBlob:input_type -> disperser.ResubmitBlobRequest
+	23, // 37: disperser.Disperser.GetBatchCost:input_type -> disperser.BatchCostRequest
+	30, // 38: disperser.Disperser.GetDisperserConfig:input_type -> disperser.DisperserConfigRequest
+	32, // 39: disperser.Disperser.GetRateLimitStatus:input_type -> disperser.RateLimitStatusRequest
+	26, // 40: disperser.Disperser.GetOperatorVersions:input_type -> disperser.OperatorVersionsRequest
+	3,  // 41: disperser.Disperser.DisperseBlob:output_type -> disperser.DisperseBlobReply
+	5,  // 42: disperser.Disperser.GetBlobStatus:output_type -> disperser.BlobStatusReply
+	8,  // 43: disperser.Disperser.RetrieveBlob:output_type -> disperser.RetrieveBlobReply
+	10, // 44: disperser.Disperser.RetrieveBatchBlobs:output_type -> disperser.RetrieveBatchBlobsReply
+	12, // 45: disperser.Disperser.GetBatchVerificationProofs:output_type -> disperser.BatchVerificationProofsReply
+	14, // 46: disperser.Disperser.GetBlobProofBundle:output_type -> disperser.BlobProofBundleReply
+	16, // 47: disperser.Disperser.GetOperatorStateAtBatch:output_type -> disperser.OperatorStateAtBatchReply
+	20, // 48: disperser.Disperser.ExtendBlobRetention:output_type -> disperser.ExtendBlobRetentionReply
+	22, // 49: disperser.Disperser.ResubmitBlob:output_type -> disperser.ResubmitBlobReply
+	24, // 50: disperser.Disperser.GetBatchCost:output_type -> disperser.BatchCostReply
+	31, // 51: disperser.Disperser.GetDisperserConfig:output_type -> disperser.DisperserConfigReply
+	33, // 52: disperser.Disperser.GetRateLimitStatus:output_type -> disperser.RateLimitStatusReply
+	27, // 53: disperser.Disperser.GetOperatorVersions:output_type -> disperser.OperatorVersionsReply
+	41, // [41:54] is the sub-list for method output_type
+	28, // [28:41] is the sub-list for method input_type
+	28, // [28:28] is the sub-list for extension type_name
+	28, // [28:28] is the sub-list for extension extendee
+	0,  // [0:28] is the sub-list for field type_name
 }
 
 func init() { file_disperser_disperser_proto_init() }
@@ -1211,8 +3877,92 @@ func file_disperser_disperser_proto_init() {
 				return nil
 			}
 		}
-		file_disperser_disperser_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DisperseBlobReply); i {
+		file_disperser_disperser_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DisperseBlobReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_disperser_disperser_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BlobStatusRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_disperser_disperser_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BlobStatusReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_disperser_disperser_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BlobQuorumStatus); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_disperser_disperser_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RetrieveBlobRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_disperser_disperser_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RetrieveBlobReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_disperser_disperser_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RetrieveBatchBlobsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_disperser_disperser_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RetrieveBatchBlobsReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1223,8 +3973,8 @@ func file_disperser_disperser_proto_init() {
 				return nil
 			}
 		}
-		file_disperser_disperser_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*BlobStatusRequest); i {
+		file_disperser_disperser_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BatchVerificationProofsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1235,8 +3985,8 @@ func file_disperser_disperser_proto_init() {
 				return nil
 			}
 		}
-		file_disperser_disperser_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*BlobStatusReply); i {
+		file_disperser_disperser_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BatchVerificationProofsReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1247,8 +3997,8 @@ func file_disperser_disperser_proto_init() {
 				return nil
 			}
 		}
-		file_disperser_disperser_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RetrieveBlobRequest); i {
+		file_disperser_disperser_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BlobProofBundleRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1259,8 +4009,8 @@ func file_disperser_disperser_proto_init() {
 				return nil
 			}
 		}
-		file_disperser_disperser_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RetrieveBlobReply); i {
+		file_disperser_disperser_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BlobProofBundleReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1271,7 +4021,271 @@ func file_disperser_disperser_proto_init() {
 				return nil
 			}
 		}
-		file_disperser_disperser_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+		file_disperser_disperser_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OperatorStateAtBatchRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_disperser_disperser_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OperatorStateAtBatchReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_disperser_disperser_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*QuorumStake); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_disperser_disperser_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OperatorStake); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_disperser_disperser_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExtendBlobRetentionRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_disperser_disperser_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExtendBlobRetentionReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_disperser_disperser_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResubmitBlobRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_disperser_disperser_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResubmitBlobReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_disperser_disperser_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BatchCostRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_disperser_disperser_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BatchCostReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_disperser_disperser_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BlobCost); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_disperser_disperser_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OperatorVersionsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_disperser_disperser_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OperatorVersionsReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_disperser_disperser_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OperatorVersion); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_disperser_disperser_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FeatureStake); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_disperser_disperser_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DisperserConfigRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_disperser_disperser_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DisperserConfigReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_disperser_disperser_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RateLimitStatusRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_disperser_disperser_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RateLimitStatusReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_disperser_disperser_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SecurityPolicy); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_disperser_disperser_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*QuorumSecurityParamBounds); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_disperser_disperser_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SecurityParamBounds); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_disperser_disperser_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*SecurityParams); i {
 			case 0:
 				return &v.state
@@ -1283,7 +4297,7 @@ func file_disperser_disperser_proto_init() {
 				return nil
 			}
 		}
-		file_disperser_disperser_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+		file_disperser_disperser_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*BlobInfo); i {
 			case 0:
 				return &v.state
@@ -1295,7 +4309,7 @@ func file_disperser_disperser_proto_init() {
 				return nil
 			}
 		}
-		file_disperser_disperser_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+		file_disperser_disperser_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*BlobHeader); i {
 			case 0:
 				return &v.state
@@ -1307,7 +4321,7 @@ func file_disperser_disperser_proto_init() {
 				return nil
 			}
 		}
-		file_disperser_disperser_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+		file_disperser_disperser_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*BlobQuorumParam); i {
 			case 0:
 				return &v.state
@@ -1319,7 +4333,7 @@ func file_disperser_disperser_proto_init() {
 				return nil
 			}
 		}
-		file_disperser_disperser_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+		file_disperser_disperser_proto_msgTypes[39].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*BlobVerificationProof); i {
 			case 0:
 				return &v.state
@@ -1331,7 +4345,19 @@ func file_disperser_disperser_proto_init() {
 				return nil
 			}
 		}
-		file_disperser_disperser_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+		file_disperser_disperser_proto_msgTypes[40].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BlobInclusionProof); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_disperser_disperser_proto_msgTypes[41].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*BatchMetadata); i {
 			case 0:
 				return &v.state
@@ -1343,7 +4369,7 @@ func file_disperser_disperser_proto_init() {
 				return nil
 			}
 		}
-		file_disperser_disperser_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+		file_disperser_disperser_proto_msgTypes[42].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*BatchHeader); i {
 			case 0:
 				return &v.state
@@ -1361,8 +4387,8 @@ func file_disperser_disperser_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_disperser_disperser_proto_rawDesc,
-			NumEnums:      1,
-			NumMessages:   13,
+			NumEnums:      2,
+			NumMessages:   45,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
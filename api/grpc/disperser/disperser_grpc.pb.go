@@ -19,9 +19,19 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	Disperser_DisperseBlob_FullMethodName  = "/disperser.Disperser/DisperseBlob"
-	Disperser_GetBlobStatus_FullMethodName = "/disperser.Disperser/GetBlobStatus"
-	Disperser_RetrieveBlob_FullMethodName  = "/disperser.Disperser/RetrieveBlob"
+	Disperser_DisperseBlob_FullMethodName               = "/disperser.Disperser/DisperseBlob"
+	Disperser_GetBlobStatus_FullMethodName              = "/disperser.Disperser/GetBlobStatus"
+	Disperser_RetrieveBlob_FullMethodName               = "/disperser.Disperser/RetrieveBlob"
+	Disperser_RetrieveBatchBlobs_FullMethodName         = "/disperser.Disperser/RetrieveBatchBlobs"
+	Disperser_GetBatchVerificationProofs_FullMethodName = "/disperser.Disperser/GetBatchVerificationProofs"
+	Disperser_GetBlobProofBundle_FullMethodName         = "/disperser.Disperser/GetBlobProofBundle"
+	Disperser_GetOperatorStateAtBatch_FullMethodName    = "/disperser.Disperser/GetOperatorStateAtBatch"
+	Disperser_ExtendBlobRetention_FullMethodName        = "/disperser.Disperser/ExtendBlobRetention"
+	Disperser_ResubmitBlob_FullMethodName               = "/disperser.Disperser/ResubmitBlob"
+	Disperser_GetBatchCost_FullMethodName               = "/disperser.Disperser/GetBatchCost"
+	Disperser_GetDisperserConfig_FullMethodName         = "/disperser.Disperser/GetDisperserConfig"
+	Disperser_GetRateLimitStatus_FullMethodName         = "/disperser.Disperser/GetRateLimitStatus"
+	Disperser_GetOperatorVersions_FullMethodName        = "/disperser.Disperser/GetOperatorVersions"
 )
 
 // DisperserClient is the client API for Disperser service.
@@ -41,7 +51,55 @@ type DisperserClient interface {
 	// api/proto/retriever/retriever.proto).
 	// The blob should have been initially dispersed via this Disperser service
 	// for this API to work.
+	// If the Disperser no longer stores the blob, it may reconstruct it from the
+	// DA Nodes. The "blob-source" trailer tells whether the blob was read from
+	// the Disperser's storage ("cache") or reconstructed ("reconstructed").
 	RetrieveBlob(ctx context.Context, in *RetrieveBlobRequest, opts ...grpc.CallOption) (*RetrieveBlobReply, error)
+	// This streams the data of all the confirmed blobs of a batch, one message per
+	// blob in increasing blob index, so that neither the Disperser nor the client
+	// hold the whole batch in memory. It is a more efficient way to retrieve a whole
+	// batch than calling RetrieveBlob() for each of its blobs. The blobs are read
+	// like in RetrieveBlob(), and the stream fails at the first blob which can't be.
+	RetrieveBatchBlobs(ctx context.Context, in *RetrieveBatchBlobsRequest, opts ...grpc.CallOption) (Disperser_RetrieveBatchBlobsClient, error)
+	// This returns the verification proofs of all the confirmed blobs of a batch,
+	// sending the metadata they share once. It is a more efficient way to get
+	// the proofs of many blobs of a batch than calling GetBlobStatus() for each.
+	GetBatchVerificationProofs(ctx context.Context, in *BatchVerificationProofsRequest, opts ...grpc.CallOption) (*BatchVerificationProofsReply, error)
+	// This returns a self-contained proof of the confirmation of a blob, which can be
+	// submitted to a verifier contract: the blob header, its verification proof and the
+	// aggregate signature of its batch, ABI encoded. The bundle can be checked with
+	// VerifyProofBundle of the Go client.
+	GetBlobProofBundle(ctx context.Context, in *BlobProofBundleRequest, opts ...grpc.CallOption) (*BlobProofBundleReply, error)
+	// This returns the operator state that a batch was made with, i.e. the stakes of
+	// the operators at the reference block of the batch, for auditing the batch once
+	// the operator set changed. The operators are paginated.
+	GetOperatorStateAtBatch(ctx context.Context, in *OperatorStateAtBatchRequest, opts ...grpc.CallOption) (*OperatorStateAtBatchReply, error)
+	// This extends the retention of a dispersed blob past its expiry, up to the
+	// maximum retention configured by the Disperser. It is only available to the
+	// callers trusted by the Disperser, and fails if the blob already expired.
+	ExtendBlobRetention(ctx context.Context, in *ExtendBlobRetentionRequest, opts ...grpc.CallOption) (*ExtendBlobRetentionReply, error)
+	// This disperses again a blob which failed, e.g. with INSUFFICIENT_SIGNATURES,
+	// with new security params, reusing the data the Disperser still stores instead
+	// of uploading it again. It fails if the blob expired, or is still PROCESSING or
+	// already CONFIRMED. The new dispersal has its own request ID, and is rate
+	// limited like DisperseBlob; the status of the original request is unchanged.
+	ResubmitBlob(ctx context.Context, in *ResubmitBlobRequest, opts ...grpc.CallOption) (*ResubmitBlobReply, error)
+	// This returns the onchain cost of the confirmation of a batch, and the share of
+	// the cost attributed to each of its confirmed blobs, in proportion to their
+	// encoded length. It is only available to the callers trusted by the Disperser.
+	GetBatchCost(ctx context.Context, in *BatchCostRequest, opts ...grpc.CallOption) (*BatchCostReply, error)
+	// This returns the configuration of the Disperser which the dispersal requests
+	// must comply with, such as its security policy, so that clients can validate
+	// their requests before sending them.
+	GetDisperserConfig(ctx context.Context, in *DisperserConfigRequest, opts ...grpc.CallOption) (*DisperserConfigReply, error)
+	// This returns the rate limit status of the account of the caller, such as the
+	// bytes it can still disperse within its daily quota.
+	GetRateLimitStatus(ctx context.Context, in *RateLimitStatusRequest, opts ...grpc.CallOption) (*RateLimitStatusReply, error)
+	// This returns the software versions and the optional features advertised by the
+	// operators in their replies to the dispersal of a batch, and the share of the stake
+	// of each quorum advertising each feature. It is only available to the callers
+	// trusted by the Disperser.
+	GetOperatorVersions(ctx context.Context, in *OperatorVersionsRequest, opts ...grpc.CallOption) (*OperatorVersionsReply, error)
 }
 
 type disperserClient struct {
@@ -79,6 +137,119 @@ func (c *disperserClient) RetrieveBlob(ctx context.Context, in *RetrieveBlobRequ
 	return out, nil
 }
 
+func (c *disperserClient) RetrieveBatchBlobs(ctx context.Context, in *RetrieveBatchBlobsRequest, opts ...grpc.CallOption) (Disperser_RetrieveBatchBlobsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Disperser_ServiceDesc.Streams[0], Disperser_RetrieveBatchBlobs_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &disperserRetrieveBatchBlobsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Disperser_RetrieveBatchBlobsClient interface {
+	Recv() (*RetrieveBatchBlobsReply, error)
+	grpc.ClientStream
+}
+
+type disperserRetrieveBatchBlobsClient struct {
+	grpc.ClientStream
+}
+
+func (x *disperserRetrieveBatchBlobsClient) Recv() (*RetrieveBatchBlobsReply, error) {
+	m := new(RetrieveBatchBlobsReply)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *disperserClient) GetBatchVerificationProofs(ctx context.Context, in *BatchVerificationProofsRequest, opts ...grpc.CallOption) (*BatchVerificationProofsReply, error) {
+	out := new(BatchVerificationProofsReply)
+	err := c.cc.Invoke(ctx, Disperser_GetBatchVerificationProofs_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *disperserClient) GetBlobProofBundle(ctx context.Context, in *BlobProofBundleRequest, opts ...grpc.CallOption) (*BlobProofBundleReply, error) {
+	out := new(BlobProofBundleReply)
+	err := c.cc.Invoke(ctx, Disperser_GetBlobProofBundle_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *disperserClient) GetOperatorStateAtBatch(ctx context.Context, in *OperatorStateAtBatchRequest, opts ...grpc.CallOption) (*OperatorStateAtBatchReply, error) {
+	out := new(OperatorStateAtBatchReply)
+	err := c.cc.Invoke(ctx, Disperser_GetOperatorStateAtBatch_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *disperserClient) ExtendBlobRetention(ctx context.Context, in *ExtendBlobRetentionRequest, opts ...grpc.CallOption) (*ExtendBlobRetentionReply, error) {
+	out := new(ExtendBlobRetentionReply)
+	err := c.cc.Invoke(ctx, Disperser_ExtendBlobRetention_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *disperserClient) ResubmitBlob(ctx context.Context, in *ResubmitBlobRequest, opts ...grpc.CallOption) (*ResubmitBlobReply, error) {
+	out := new(ResubmitBlobReply)
+	err := c.cc.Invoke(ctx, Disperser_ResubmitBlob_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *disperserClient) GetBatchCost(ctx context.Context, in *BatchCostRequest, opts ...grpc.CallOption) (*BatchCostReply, error) {
+	out := new(BatchCostReply)
+	err := c.cc.Invoke(ctx, Disperser_GetBatchCost_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *disperserClient) GetDisperserConfig(ctx context.Context, in *DisperserConfigRequest, opts ...grpc.CallOption) (*DisperserConfigReply, error) {
+	out := new(DisperserConfigReply)
+	err := c.cc.Invoke(ctx, Disperser_GetDisperserConfig_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *disperserClient) GetRateLimitStatus(ctx context.Context, in *RateLimitStatusRequest, opts ...grpc.CallOption) (*RateLimitStatusReply, error) {
+	out := new(RateLimitStatusReply)
+	err := c.cc.Invoke(ctx, Disperser_GetRateLimitStatus_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *disperserClient) GetOperatorVersions(ctx context.Context, in *OperatorVersionsRequest, opts ...grpc.CallOption) (*OperatorVersionsReply, error) {
+	out := new(OperatorVersionsReply)
+	err := c.cc.Invoke(ctx, Disperser_GetOperatorVersions_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // DisperserServer is the server API for Disperser service.
 // All implementations must embed UnimplementedDisperserServer
 // for forward compatibility
@@ -96,7 +267,55 @@ type DisperserServer interface {
 	// api/proto/retriever/retriever.proto).
 	// The blob should have been initially dispersed via this Disperser service
 	// for this API to work.
+	// If the Disperser no longer stores the blob, it may reconstruct it from the
+	// DA Nodes. The "blob-source" trailer tells whether the blob was read from
+	// the Disperser's storage ("cache") or reconstructed ("reconstructed").
 	RetrieveBlob(context.Context, *RetrieveBlobRequest) (*RetrieveBlobReply, error)
+	// This streams the data of all the confirmed blobs of a batch, one message per
+	// blob in increasing blob index, so that neither the Disperser nor the client
+	// hold the whole batch in memory. It is a more efficient way to retrieve a whole
+	// batch than calling RetrieveBlob() for each of its blobs. The blobs are read
+	// like in RetrieveBlob(), and the stream fails at the first blob which can't be.
+	RetrieveBatchBlobs(*RetrieveBatchBlobsRequest, Disperser_RetrieveBatchBlobsServer) error
+	// This returns the verification proofs of all the confirmed blobs of a batch,
+	// sending the metadata they share once. It is a more efficient way to get
+	// the proofs of many blobs of a batch than calling GetBlobStatus() for each.
+	GetBatchVerificationProofs(context.Context, *BatchVerificationProofsRequest) (*BatchVerificationProofsReply, error)
+	// This returns a self-contained proof of the confirmation of a blob, which can be
+	// submitted to a verifier contract: the blob header, its verification proof and the
+	// aggregate signature of its batch, ABI encoded. The bundle can be checked with
+	// VerifyProofBundle of the Go client.
+	GetBlobProofBundle(context.Context, *BlobProofBundleRequest) (*BlobProofBundleReply, error)
+	// This returns the operator state that a batch was made with, i.e. the stakes of
+	// the operators at the reference block of the batch, for auditing the batch once
+	// the operator set changed. The operators are paginated.
+	GetOperatorStateAtBatch(context.Context, *OperatorStateAtBatchRequest) (*OperatorStateAtBatchReply, error)
+	// This extends the retention of a dispersed blob past its expiry, up to the
+	// maximum retention configured by the Disperser. It is only available to the
+	// callers trusted by the Disperser, and fails if the blob already expired.
+	ExtendBlobRetention(context.Context, *ExtendBlobRetentionRequest) (*ExtendBlobRetentionReply, error)
+	// This disperses again a blob which failed, e.g. with INSUFFICIENT_SIGNATURES,
+	// with new security params, reusing the data the Disperser still stores instead
+	// of uploading it again. It fails if the blob expired, or is still PROCESSING or
+	// already CONFIRMED. The new dispersal has its own request ID, and is rate
+	// limited like DisperseBlob; the status of the original request is unchanged.
+	ResubmitBlob(context.Context, *ResubmitBlobRequest) (*ResubmitBlobReply, error)
+	// This returns the onchain cost of the confirmation of a batch, and the share of
+	// the cost attributed to each of its confirmed blobs, in proportion to their
+	// encoded length. It is only available to the callers trusted by the Disperser.
+	GetBatchCost(context.Context, *BatchCostRequest) (*BatchCostReply, error)
+	// This returns the configuration of the Disperser which the dispersal requests
+	// must comply with, such as its security policy, so that clients can validate
+	// their requests before sending them.
+	GetDisperserConfig(context.Context, *DisperserConfigRequest) (*DisperserConfigReply, error)
+	// This returns the rate limit status of the account of the caller, such as the
+	// bytes it can still disperse within its daily quota.
+	GetRateLimitStatus(context.Context, *RateLimitStatusRequest) (*RateLimitStatusReply, error)
+	// This returns the software versions and the optional features advertised by the
+	// operators in their replies to the dispersal of a batch, and the share of the stake
+	// of each quorum advertising each feature. It is only available to the callers
+	// trusted by the Disperser.
+	GetOperatorVersions(context.Context, *OperatorVersionsRequest) (*OperatorVersionsReply, error)
 	mustEmbedUnimplementedDisperserServer()
 }
 
@@ -113,6 +332,36 @@ func (UnimplementedDisperserServer) GetBlobStatus(context.Context, *BlobStatusRe
 func (UnimplementedDisperserServer) RetrieveBlob(context.Context, *RetrieveBlobRequest) (*RetrieveBlobReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RetrieveBlob not implemented")
 }
+func (UnimplementedDisperserServer) RetrieveBatchBlobs(*RetrieveBatchBlobsRequest, Disperser_RetrieveBatchBlobsServer) error {
+	return status.Errorf(codes.Unimplemented, "method RetrieveBatchBlobs not implemented")
+}
+func (UnimplementedDisperserServer) GetBatchVerificationProofs(context.Context, *BatchVerificationProofsRequest) (*BatchVerificationProofsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBatchVerificationProofs not implemented")
+}
+func (UnimplementedDisperserServer) GetBlobProofBundle(context.Context, *BlobProofBundleRequest) (*BlobProofBundleReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBlobProofBundle not implemented")
+}
+func (UnimplementedDisperserServer) GetOperatorStateAtBatch(context.Context, *OperatorStateAtBatchRequest) (*OperatorStateAtBatchReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOperatorStateAtBatch not implemented")
+}
+func (UnimplementedDisperserServer) ExtendBlobRetention(context.Context, *ExtendBlobRetentionRequest) (*ExtendBlobRetentionReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExtendBlobRetention not implemented")
+}
+func (UnimplementedDisperserServer) ResubmitBlob(context.Context, *ResubmitBlobRequest) (*ResubmitBlobReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResubmitBlob not implemented")
+}
+func (UnimplementedDisperserServer) GetBatchCost(context.Context, *BatchCostRequest) (*BatchCostReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBatchCost not implemented")
+}
+func (UnimplementedDisperserServer) GetDisperserConfig(context.Context, *DisperserConfigRequest) (*DisperserConfigReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDisperserConfig not implemented")
+}
+func (UnimplementedDisperserServer) GetRateLimitStatus(context.Context, *RateLimitStatusRequest) (*RateLimitStatusReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRateLimitStatus not implemented")
+}
+func (UnimplementedDisperserServer) GetOperatorVersions(context.Context, *OperatorVersionsRequest) (*OperatorVersionsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOperatorVersions not implemented")
+}
 func (UnimplementedDisperserServer) mustEmbedUnimplementedDisperserServer() {}
 
 // UnsafeDisperserServer may be embedded to opt out of forward compatibility for this service.
@@ -180,6 +429,189 @@ func _Disperser_RetrieveBlob_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Disperser_RetrieveBatchBlobs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(RetrieveBatchBlobsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DisperserServer).RetrieveBatchBlobs(m, &disperserRetrieveBatchBlobsServer{stream})
+}
+
+type Disperser_RetrieveBatchBlobsServer interface {
+	Send(*RetrieveBatchBlobsReply) error
+	grpc.ServerStream
+}
+
+type disperserRetrieveBatchBlobsServer struct {
+	grpc.ServerStream
+}
+
+func (x *disperserRetrieveBatchBlobsServer) Send(m *RetrieveBatchBlobsReply) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Disperser_GetBatchVerificationProofs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchVerificationProofsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DisperserServer).GetBatchVerificationProofs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Disperser_GetBatchVerificationProofs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DisperserServer).GetBatchVerificationProofs(ctx, req.(*BatchVerificationProofsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Disperser_GetBlobProofBundle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BlobProofBundleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DisperserServer).GetBlobProofBundle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Disperser_GetBlobProofBundle_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DisperserServer).GetBlobProofBundle(ctx, req.(*BlobProofBundleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Disperser_GetOperatorStateAtBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OperatorStateAtBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DisperserServer).GetOperatorStateAtBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Disperser_GetOperatorStateAtBatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DisperserServer).GetOperatorStateAtBatch(ctx, req.(*OperatorStateAtBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Disperser_ExtendBlobRetention_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExtendBlobRetentionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DisperserServer).ExtendBlobRetention(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Disperser_ExtendBlobRetention_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DisperserServer).ExtendBlobRetention(ctx, req.(*ExtendBlobRetentionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Disperser_ResubmitBlob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResubmitBlobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DisperserServer).ResubmitBlob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Disperser_ResubmitBlob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DisperserServer).ResubmitBlob(ctx, req.(*ResubmitBlobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Disperser_GetBatchCost_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchCostRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DisperserServer).GetBatchCost(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Disperser_GetBatchCost_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DisperserServer).GetBatchCost(ctx, req.(*BatchCostRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Disperser_GetDisperserConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DisperserConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DisperserServer).GetDisperserConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Disperser_GetDisperserConfig_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DisperserServer).GetDisperserConfig(ctx, req.(*DisperserConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Disperser_GetRateLimitStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RateLimitStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DisperserServer).GetRateLimitStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Disperser_GetRateLimitStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DisperserServer).GetRateLimitStatus(ctx, req.(*RateLimitStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Disperser_GetOperatorVersions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OperatorVersionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DisperserServer).GetOperatorVersions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Disperser_GetOperatorVersions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DisperserServer).GetOperatorVersions(ctx, req.(*OperatorVersionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Disperser_ServiceDesc is the grpc.ServiceDesc for Disperser service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -199,7 +631,49 @@ var Disperser_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RetrieveBlob",
 			Handler:    _Disperser_RetrieveBlob_Handler,
 		},
+		{
+			MethodName: "GetBatchVerificationProofs",
+			Handler:    _Disperser_GetBatchVerificationProofs_Handler,
+		},
+		{
+			MethodName: "GetBlobProofBundle",
+			Handler:    _Disperser_GetBlobProofBundle_Handler,
+		},
+		{
+			MethodName: "GetOperatorStateAtBatch",
+			Handler:    _Disperser_GetOperatorStateAtBatch_Handler,
+		},
+		{
+			MethodName: "ExtendBlobRetention",
+			Handler:    _Disperser_ExtendBlobRetention_Handler,
+		},
+		{
+			MethodName: "ResubmitBlob",
+			Handler:    _Disperser_ResubmitBlob_Handler,
+		},
+		{
+			MethodName: "GetBatchCost",
+			Handler:    _Disperser_GetBatchCost_Handler,
+		},
+		{
+			MethodName: "GetDisperserConfig",
+			Handler:    _Disperser_GetDisperserConfig_Handler,
+		},
+		{
+			MethodName: "GetRateLimitStatus",
+			Handler:    _Disperser_GetRateLimitStatus_Handler,
+		},
+		{
+			MethodName: "GetOperatorVersions",
+			Handler:    _Disperser_GetOperatorVersions_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "RetrieveBatchBlobs",
+			Handler:       _Disperser_RetrieveBatchBlobs_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "disperser/disperser.proto",
 }
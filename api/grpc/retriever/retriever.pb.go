@@ -105,6 +105,8 @@ type BlobReply struct {
 	unknownFields protoimpl.UnknownFields
 
 	// The blob retrieved and reconstructed from the EigenDA Nodes per BlobRequest.
+	// The data is padded with zeros to the length of the encoded blob, a multiple of
+	// the 31 bytes of a symbol (see DisperseBlobRequest.data).
 	Data []byte `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
 }
 
@@ -0,0 +1,377 @@
+package clients
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	disperser_rpc "github.com/Layr-Labs/eigenda/api/grpc/disperser"
+	_ "github.com/Layr-Labs/eigenda/common/grpc" // registers the compressors
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/disperser"
+	"github.com/ethereum/go-ethereum/crypto"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+// defaultRetryDelay is used when the disperser throttles a request without indicating when to retry
+const defaultRetryDelay = time.Second
+
+type DisperserClientConfig struct {
+	Hostname          string
+	Port              string
+	Timeout           time.Duration
+	UseSecureGrpcFlag bool
+	// StatusPollInterval is the interval at which DisperseAndWait polls the status of a dispersed blob, unless the
+	// disperser recommends another delay
+	StatusPollInterval time.Duration
+	// Namespace is the optional application identifier declared with every dispersed blob
+	Namespace string
+	// Metadata is the optional labels declared with every dispersed blob, returned by GetBlobStatus
+	Metadata map[string]string
+	// Compression is the compressor of the requests and replies, commongrpc.GzipCompressor or
+	// commongrpc.ZstdCompressor. The messages are sent uncompressed if it is empty. The size limits of the disperser
+	// apply to the blobs once decompressed.
+	Compression string
+	// Padding is the padding scheme of the dispersed blobs, undone by RetrieveBlob. NoBlobPadding by default.
+	Padding BlobPadding
+	// PrivateUntilFinalized makes the dispersed blobs retrievable only with a signature of SignerKey until they are
+	// finalized
+	PrivateUntilFinalized bool
+	// SignerKey is the key the private blobs are dispersed and retrieved with. RetrieveBlob signs its requests with it
+	// whenever it is set.
+	SignerKey *ecdsa.PrivateKey
+}
+
+// DisperserClient is a client of the disperser API. The errors returned by the disperser are *DisperserError.
+type DisperserClient interface {
+	DisperseBlob(ctx context.Context, data []byte, securityParams []*core.SecurityParam) (*disperser.BlobStatus, []byte, error)
+	GetBlobStatus(ctx context.Context, requestID []byte) (*disperser_rpc.BlobStatusReply, error)
+	// DisperseAndWait disperses the blob and blocks until it is confirmed or has failed. Requests throttled by the disperser
+	// are retried after the delay indicated by the disperser, and the status is polled again after the delay
+	// recommended by the disperser, if any. A blob which failed for insufficient signatures returns an
+	// *InsufficientSignaturesError.
+	DisperseAndWait(ctx context.Context, data []byte, securityParams []*core.SecurityParam) (*disperser_rpc.BlobStatusReply, error)
+	// RetrieveBlob retrieves the data of a confirmed blob from the disperser, as it was passed to DisperseBlob
+	RetrieveBlob(ctx context.Context, batchHeaderHash []byte, blobIndex uint32) ([]byte, error)
+	// RetrieveBatchBlobs retrieves the data of all the confirmed blobs of a batch, passing each blob to handle in
+	// increasing blob index as soon as it is received, so that the batch isn't held in memory. The data of the blobs
+	// private until finalized is withheld by the disperser, and passed to handle as nil. It stops at the first error of
+	// handle, which it returns, and fails if the stream ends before all the blobs of the batch were received.
+	// The timeout of the client applies to the retrieval of the whole batch.
+	RetrieveBatchBlobs(ctx context.Context, batchHeaderHash []byte, handle func(blobIndex uint32, data []byte) error) error
+	// GetBlobProofBundle returns the ABI encoded proof bundle of a confirmed blob, which can be checked with
+	// VerifyProofBundle before it is submitted to a verifier contract
+	GetBlobProofBundle(ctx context.Context, requestID []byte) ([]byte, error)
+}
+
+type disperserClient struct {
+	config *DisperserClientConfig
+}
+
+var _ DisperserClient = (*disperserClient)(nil)
+
+func NewDisperserClient(config *DisperserClientConfig) DisperserClient {
+	return &disperserClient{
+		config: config,
+	}
+}
+
+func (c *disperserClient) getDialOptions() []grpc.DialOption {
+	var opts []grpc.DialOption
+	if c.config.UseSecureGrpcFlag {
+		config := &tls.Config{}
+		credential := credentials.NewTLS(config)
+		opts = append(opts, grpc.WithTransportCredentials(credential))
+	} else {
+		opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	}
+	if c.config.Compression != "" {
+		opts = append(opts, grpc.WithDefaultCallOptions(grpc.UseCompressor(c.config.Compression)))
+	}
+	return opts
+}
+
+func (c *disperserClient) DisperseBlob(ctx context.Context, data []byte, securityParams []*core.SecurityParam) (*disperser.BlobStatus, []byte, error) {
+	if c.config.PrivateUntilFinalized && c.config.SignerKey == nil {
+		return nil, nil, errors.New("private blobs require a signer key")
+	}
+	data, err := padBlob(c.config.Padding, data)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	addr := fmt.Sprintf("%v:%v", c.config.Hostname, c.config.Port)
+
+	conn, err := grpc.Dial(addr, c.getDialOptions()...)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer func() { _ = conn.Close() }()
+
+	client := disperser_rpc.NewDisperserClient(conn)
+	ctxTimeout, cancel := context.WithTimeout(ctx, c.config.Timeout)
+	defer cancel()
+
+	params := make([]*disperser_rpc.SecurityParams, len(securityParams))
+	for i, param := range securityParams {
+		params[i] = &disperser_rpc.SecurityParams{
+			QuorumId:           uint32(param.QuorumID),
+			AdversaryThreshold: uint32(param.AdversaryThreshold),
+			QuorumThreshold:    uint32(param.QuorumThreshold),
+		}
+	}
+
+	request := &disperser_rpc.DisperseBlobRequest{
+		Data:           data,
+		SecurityParams: params,
+		Namespace:      c.config.Namespace,
+		Metadata:       c.config.Metadata,
+	}
+	if c.config.PrivateUntilFinalized {
+		request.PrivateUntilFinalized = true
+		request.SubmitterPublicKey = crypto.CompressPubkey(&c.config.SignerKey.PublicKey)
+	}
+
+	reply, err := client.DisperseBlob(ctxTimeout, request)
+	if err != nil {
+		return nil, nil, decodeDisperserError(err)
+	}
+
+	blobStatus, err := disperser.FromBlobStatusProto(reply.GetResult())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return blobStatus, reply.GetRequestId(), nil
+}
+
+func (c *disperserClient) GetBlobStatus(ctx context.Context, requestID []byte) (*disperser_rpc.BlobStatusReply, error) {
+	addr := fmt.Sprintf("%v:%v", c.config.Hostname, c.config.Port)
+
+	conn, err := grpc.Dial(addr, c.getDialOptions()...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = conn.Close() }()
+
+	client := disperser_rpc.NewDisperserClient(conn)
+	ctxTimeout, cancel := context.WithTimeout(ctx, c.config.Timeout)
+	defer cancel()
+
+	request := &disperser_rpc.BlobStatusRequest{
+		RequestId: requestID,
+	}
+
+	reply, err := client.GetBlobStatus(ctxTimeout, request)
+	if err != nil {
+		return nil, decodeDisperserError(err)
+	}
+	return reply, nil
+}
+
+func (c *disperserClient) GetBlobProofBundle(ctx context.Context, requestID []byte) ([]byte, error) {
+	addr := fmt.Sprintf("%v:%v", c.config.Hostname, c.config.Port)
+
+	conn, err := grpc.Dial(addr, c.getDialOptions()...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = conn.Close() }()
+
+	client := disperser_rpc.NewDisperserClient(conn)
+	ctxTimeout, cancel := context.WithTimeout(ctx, c.config.Timeout)
+	defer cancel()
+
+	reply, err := client.GetBlobProofBundle(ctxTimeout, &disperser_rpc.BlobProofBundleRequest{
+		RequestId: requestID,
+	})
+	if err != nil {
+		return nil, decodeDisperserError(err)
+	}
+	return reply.GetBundle(), nil
+}
+
+func (c *disperserClient) RetrieveBlob(ctx context.Context, batchHeaderHash []byte, blobIndex uint32) ([]byte, error) {
+	addr := fmt.Sprintf("%v:%v", c.config.Hostname, c.config.Port)
+
+	conn, err := grpc.Dial(addr, c.getDialOptions()...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = conn.Close() }()
+
+	client := disperser_rpc.NewDisperserClient(conn)
+	ctxTimeout, cancel := context.WithTimeout(ctx, c.config.Timeout)
+	defer cancel()
+
+	request := &disperser_rpc.RetrieveBlobRequest{
+		BatchHeaderHash: batchHeaderHash,
+		BlobIndex:       blobIndex,
+	}
+	if c.config.SignerKey != nil {
+		if len(batchHeaderHash) != 32 {
+			return nil, fmt.Errorf("batch header hash must be 32 bytes, but found %d", len(batchHeaderHash))
+		}
+		request.AuthTimestamp = uint64(time.Now().Unix())
+		request.AuthSignature, err = disperser.SignRetrieval(c.config.SignerKey, [32]byte(batchHeaderHash), blobIndex, request.AuthTimestamp)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	reply, err := client.RetrieveBlob(ctxTimeout, request)
+	if err != nil {
+		return nil, decodeDisperserError(err)
+	}
+
+	return unpadBlob(c.config.Padding, reply.GetData())
+}
+
+func (c *disperserClient) RetrieveBatchBlobs(ctx context.Context, batchHeaderHash []byte, handle func(blobIndex uint32, data []byte) error) error {
+	addr := fmt.Sprintf("%v:%v", c.config.Hostname, c.config.Port)
+
+	conn, err := grpc.Dial(addr, c.getDialOptions()...)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = conn.Close() }()
+
+	client := disperser_rpc.NewDisperserClient(conn)
+	ctxTimeout, cancel := context.WithTimeout(ctx, c.config.Timeout)
+	defer cancel()
+
+	stream, err := client.RetrieveBatchBlobs(ctxTimeout, &disperser_rpc.RetrieveBatchBlobsRequest{
+		BatchHeaderHash: batchHeaderHash,
+	})
+	if err != nil {
+		return decodeDisperserError(err)
+	}
+	numReceived, numBlobs := uint32(0), uint32(0)
+	for {
+		reply, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return decodeDisperserError(err)
+		}
+		numReceived++
+		numBlobs = reply.GetNumBlobs()
+		if reply.GetWithheld() {
+			if err := handle(reply.GetBlobIndex(), nil); err != nil {
+				return err
+			}
+			continue
+		}
+		if uint32(len(reply.GetData())) != reply.GetSize() {
+			return fmt.Errorf("blob %d of the batch has %d bytes, but its size is %d", reply.GetBlobIndex(), len(reply.GetData()), reply.GetSize())
+		}
+		data, err := unpadBlob(c.config.Padding, reply.GetData())
+		if err != nil {
+			return err
+		}
+		if err := handle(reply.GetBlobIndex(), data); err != nil {
+			return err
+		}
+	}
+	if numReceived != numBlobs {
+		return fmt.Errorf("received %d blobs of the batch, but it has %d", numReceived, numBlobs)
+	}
+	return nil
+}
+
+func (c *disperserClient) DisperseAndWait(ctx context.Context, data []byte, securityParams []*core.SecurityParam) (*disperser_rpc.BlobStatusReply, error) {
+	var requestID []byte
+	for {
+		_, id, err := c.DisperseBlob(ctx, data, securityParams)
+		if err == nil {
+			requestID = id
+			break
+		}
+
+		retryDelay, throttled := GetRetryDelay(err)
+		if !throttled {
+			return nil, err
+		}
+		// A zero delay would retry in a busy loop against the throttling disperser
+		if retryDelay <= 0 {
+			retryDelay = defaultRetryDelay
+		}
+
+		if err := sleep(ctx, retryDelay); err != nil {
+			return nil, err
+		}
+	}
+
+	pollDelay := c.config.StatusPollInterval
+	for {
+		if err := sleep(ctx, pollDelay); err != nil {
+			return nil, err
+		}
+
+		reply, err := c.GetBlobStatus(ctx, requestID)
+		if err != nil {
+			return nil, err
+		}
+
+		switch reply.GetStatus() {
+		case disperser_rpc.BlobStatus_CONFIRMED, disperser_rpc.BlobStatus_FINALIZED:
+			return reply, nil
+		case disperser_rpc.BlobStatus_FAILED:
+			return reply, errors.New("blob dispersal failed")
+		case disperser_rpc.BlobStatus_INSUFFICIENT_SIGNATURES:
+			return reply, newInsufficientSignaturesError(reply)
+		case disperser_rpc.BlobStatus_QUOTA_EXCEEDED:
+			return reply, errors.New("blob dispersal failed: the daily quota of the account was exceeded")
+		}
+		pollDelay = GetNextPollDelay(reply, c.config.StatusPollInterval)
+	}
+}
+
+// GetNextPollDelay returns the delay after which the disperser recommends polling the status of the blob again, or
+// defaultDelay if the disperser made no recommendation
+func GetNextPollDelay(reply *disperser_rpc.BlobStatusReply, defaultDelay time.Duration) time.Duration {
+	if reply.GetNextPollDelayMs() == 0 {
+		return defaultDelay
+	}
+	return time.Duration(reply.GetNextPollDelayMs()) * time.Millisecond
+}
+
+// GetRetryDelay returns whether the error indicates that the request was throttled by the disperser and, if so, how long
+// to wait before retrying it. The delay is read from the RetryInfo attached to the error, falling back to a default delay
+// when the disperser did not provide one.
+func GetRetryDelay(err error) (time.Duration, bool) {
+	st, ok := status.FromError(err)
+	if !ok || st.Code() != codes.ResourceExhausted {
+		return 0, false
+	}
+
+	for _, detail := range st.Details() {
+		if retryInfo, ok := detail.(*errdetails.RetryInfo); ok && retryInfo.GetRetryDelay() != nil {
+			return retryInfo.GetRetryDelay().AsDuration(), true
+		}
+	}
+
+	return defaultRetryDelay, true
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,114 @@
+package clients
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	disperser_rpc "github.com/Layr-Labs/eigenda/api/grpc/disperser"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// FieldViolation is a field of a request rejected by the disperser
+type FieldViolation struct {
+	// Field is the path of the field in the request, e.g. security_params[1].quorum_id
+	Field       string
+	Description string
+}
+
+// DisperserError is an error returned by the disperser, with the details of its gRPC status decoded. The errors of the
+// DisperserClient methods can be inspected with errors.As.
+type DisperserError struct {
+	Code    codes.Code
+	Message string
+	// Reason is the reason of the error, one of the disperser.Reason* constants, or empty if the disperser didn't
+	// provide one
+	Reason string
+	// FieldViolations are the fields of the request that were rejected
+	FieldViolations []FieldViolation
+	// RetryDelay is how long to wait before retrying a throttled request, or 0 if the disperser didn't provide it
+	RetryDelay time.Duration
+
+	status *status.Status
+}
+
+func (e *DisperserError) Error() string {
+	return e.status.Err().Error()
+}
+
+// GRPCStatus returns the status returned by the disperser, so that the error can still be inspected with the status
+// package
+func (e *DisperserError) GRPCStatus() *status.Status {
+	return e.status
+}
+
+// decodeDisperserError converts an error returned by a gRPC call to the disperser into a DisperserError. The errors
+// without a gRPC status are returned as is.
+func decodeDisperserError(err error) error {
+	st, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+
+	disperserErr := &DisperserError{
+		Code:    st.Code(),
+		Message: st.Message(),
+		status:  st,
+	}
+	for _, detail := range st.Details() {
+		switch detail := detail.(type) {
+		case *errdetails.ErrorInfo:
+			disperserErr.Reason = detail.GetReason()
+		case *errdetails.BadRequest:
+			for _, violation := range detail.GetFieldViolations() {
+				disperserErr.FieldViolations = append(disperserErr.FieldViolations, FieldViolation{
+					Field:       violation.GetField(),
+					Description: violation.GetDescription(),
+				})
+			}
+		case *errdetails.RetryInfo:
+			disperserErr.RetryDelay = detail.GetRetryDelay().AsDuration()
+		}
+	}
+	return disperserErr
+}
+
+// QuorumSignatures is the percentage of the stake of a quorum of a blob which signed its batch
+type QuorumSignatures struct {
+	QuorumID         uint8
+	SignedPercentage uint8
+	// QuorumThreshold is the percentage of the stake of the quorum which had to sign for the blob to be confirmed
+	QuorumThreshold uint8
+}
+
+// InsufficientSignaturesError is the error of DisperseAndWait for a blob which failed because the stake which signed
+// its batches stayed below the threshold of at least one of its quorums. The blob may be dispersed again with lower
+// thresholds, e.g. from the percentages achieved by its last batch.
+type InsufficientSignaturesError struct {
+	// Quorums are the percentages of the stake of each quorum of the blob which signed its last batch
+	Quorums []QuorumSignatures
+}
+
+func (e *InsufficientSignaturesError) Error() string {
+	quorums := make([]string, len(e.Quorums))
+	for i, quorum := range e.Quorums {
+		quorums[i] = fmt.Sprintf("quorum %d signed %d%% of %d%%", quorum.QuorumID, quorum.SignedPercentage, quorum.QuorumThreshold)
+	}
+	return fmt.Sprintf("blob dispersal failed: insufficient signatures (%s)", strings.Join(quorums, ", "))
+}
+
+// newInsufficientSignaturesError makes the error of a blob whose status is INSUFFICIENT_SIGNATURES from the quorum
+// statuses of the reply
+func newInsufficientSignaturesError(reply *disperser_rpc.BlobStatusReply) *InsufficientSignaturesError {
+	quorums := make([]QuorumSignatures, len(reply.GetQuorumStatuses()))
+	for i, quorumStatus := range reply.GetQuorumStatuses() {
+		quorums[i] = QuorumSignatures{
+			QuorumID:         uint8(quorumStatus.GetQuorumNumber()),
+			SignedPercentage: uint8(quorumStatus.GetSignedPercentage()),
+			QuorumThreshold:  uint8(quorumStatus.GetQuorumThresholdPercentage()),
+		}
+	}
+	return &InsufficientSignaturesError{Quorums: quorums}
+}
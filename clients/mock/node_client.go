@@ -2,6 +2,7 @@ package mock
 
 import (
 	"context"
+	"errors"
 
 	"github.com/Layr-Labs/eigenda/clients"
 	"github.com/Layr-Labs/eigenda/core"
@@ -54,6 +55,14 @@ func (c *MockNodeClient) GetChunks(
 ) {
 	args := c.Called(opID, opInfo, batchHeaderHash, blobIndex)
 	encodedBlob := (args.Get(0)).(core.EncodedBlob)
+	// The operators missing from the encoded blob are offline
+	if _, ok := encodedBlob[opID]; !ok {
+		chunksChan <- clients.RetrievedChunks{
+			OperatorID: opID,
+			Err:        errors.New("operator is offline"),
+		}
+		return
+	}
 	chunksChan <- clients.RetrievedChunks{
 		OperatorID: opID,
 		Err:        nil,
@@ -5,10 +5,12 @@ import (
 	"time"
 
 	"github.com/Layr-Labs/eigenda/api/grpc/node"
+	commongrpc "github.com/Layr-Labs/eigenda/common/grpc"
 	"github.com/Layr-Labs/eigenda/core"
 	node_utils "github.com/Layr-Labs/eigenda/node/grpc"
 	"github.com/wealdtech/go-merkletree"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
 )
 
@@ -24,12 +26,22 @@ type NodeClient interface {
 }
 
 type client struct {
-	timeout time.Duration
+	timeout        time.Duration
+	maxMessageSize int
+	creds          credentials.TransportCredentials
 }
 
-func NewNodeClient(timeout time.Duration) NodeClient {
+// NewNodeClient creates a client of the retrieval servers of the nodes. The replies of the nodes can be up to
+// maxMessageSize bytes, or up to the gRPC default if it is 0. The nodes are dialed with the given transport credentials,
+// or without TLS if they are nil.
+func NewNodeClient(timeout time.Duration, maxMessageSize int, creds credentials.TransportCredentials) NodeClient {
+	if creds == nil {
+		creds = insecure.NewCredentials()
+	}
 	return client{
-		timeout: timeout,
+		timeout:        timeout,
+		maxMessageSize: maxMessageSize,
+		creds:          creds,
 	}
 }
 
@@ -41,7 +53,8 @@ func (c client) GetBlobHeader(
 ) (*core.BlobHeader, *merkletree.Proof, error) {
 	conn, err := grpc.Dial(
 		core.OperatorSocket(socket).GetRetrievalSocket(),
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithTransportCredentials(c.creds),
+		commongrpc.MaxMessageSizeDialOption(c.maxMessageSize),
 	)
 	if err != nil {
 		return nil, nil, err
@@ -86,7 +99,8 @@ func (c client) GetChunks(
 ) {
 	conn, err := grpc.Dial(
 		core.OperatorSocket(opInfo.Socket).GetRetrievalSocket(),
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithTransportCredentials(c.creds),
+		commongrpc.MaxMessageSizeDialOption(c.maxMessageSize),
 	)
 	if err != nil {
 		chunksChan <- RetrievedChunks{
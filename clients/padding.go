@@ -0,0 +1,85 @@
+package clients
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/Layr-Labs/eigenda/pkg/kzg/bn254"
+)
+
+// BlobPadding is the scheme with which the client pads the blobs it disperses.
+//
+// The blobs are encoded in symbols of bn254.BYTES_PER_COEFFICIENT bytes, the last one being padded with zeros. The
+// disperser returns the blobs exactly as dispersed, but the blobs reconstructed from the chunks of the nodes (e.g. by
+// the retriever) are padded with zeros to the length of the encoded blob, so that their trailing zeros can't be told
+// apart from the padding. A blob padded with LengthPrefixedBlobPadding can be recovered exactly from either.
+type BlobPadding string
+
+const (
+	// NoBlobPadding disperses the blobs as is
+	NoBlobPadding BlobPadding = ""
+	// LengthPrefixedBlobPadding prefixes the blobs with their length in bytes, as a big endian uint32, and pads them
+	// with zeros to a whole number of symbols. See PadBlob and UnpadBlob.
+	LengthPrefixedBlobPadding BlobPadding = "length-prefixed"
+)
+
+// blobLengthPrefixSize is the size of the length prefix of LengthPrefixedBlobPadding
+const blobLengthPrefixSize = 4
+
+var ErrInvalidBlobPadding = errors.New("invalid blob padding")
+
+// PadBlob pads the data with LengthPrefixedBlobPadding
+func PadBlob(data []byte) []byte {
+	size := blobLengthPrefixSize + len(data)
+	if remainder := size % bn254.BYTES_PER_COEFFICIENT; remainder != 0 {
+		size += bn254.BYTES_PER_COEFFICIENT - remainder
+	}
+	padded := make([]byte, size)
+	binary.BigEndian.PutUint32(padded, uint32(len(data)))
+	copy(padded[blobLengthPrefixSize:], data)
+	return padded
+}
+
+// UnpadBlob returns the data of a blob padded with LengthPrefixedBlobPadding. The blob may have any number of trailing
+// zeros, as the blobs reconstructed from the nodes do.
+func UnpadBlob(padded []byte) ([]byte, error) {
+	if len(padded) < blobLengthPrefixSize {
+		return nil, fmt.Errorf("%w: blob of %d bytes is too short for its length prefix", ErrInvalidBlobPadding, len(padded))
+	}
+	length := uint64(binary.BigEndian.Uint32(padded))
+	end := blobLengthPrefixSize + length
+	if end > uint64(len(padded)) {
+		return nil, fmt.Errorf("%w: blob of %d bytes is too short for its declared length of %d bytes", ErrInvalidBlobPadding, len(padded), length)
+	}
+	for _, b := range padded[end:] {
+		if b != 0 {
+			return nil, fmt.Errorf("%w: blob has non-zero bytes past its declared length of %d bytes", ErrInvalidBlobPadding, length)
+		}
+	}
+	return padded[blobLengthPrefixSize:end], nil
+}
+
+// padBlob pads the data with the padding scheme
+func padBlob(padding BlobPadding, data []byte) ([]byte, error) {
+	switch padding {
+	case NoBlobPadding:
+		return data, nil
+	case LengthPrefixedBlobPadding:
+		return PadBlob(data), nil
+	default:
+		return nil, fmt.Errorf("unknown blob padding %q", padding)
+	}
+}
+
+// unpadBlob returns the data of a blob padded with the padding scheme
+func unpadBlob(padding BlobPadding, padded []byte) ([]byte, error) {
+	switch padding {
+	case NoBlobPadding:
+		return padded, nil
+	case LengthPrefixedBlobPadding:
+		return UnpadBlob(padded)
+	default:
+		return nil, fmt.Errorf("unknown blob padding %q", padding)
+	}
+}
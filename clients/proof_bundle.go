@@ -0,0 +1,139 @@
+package clients
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/disperser"
+	"github.com/Layr-Labs/eigenda/pkg/kzg/bn254"
+	bn "github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fp"
+	"github.com/wealdtech/go-merkletree"
+	"github.com/wealdtech/go-merkletree/keccak256"
+)
+
+// ErrInvalidProofBundle is returned by VerifyProofBundle for a bundle which doesn't prove the confirmation of its blob
+var ErrInvalidProofBundle = errors.New("invalid proof bundle")
+
+// VerifyProofBundle decodes a proof bundle returned by GetBlobProofBundle and verifies it as the verifier contract
+// does: the batch metadata hashes to batchMetadataHash, the blob header is included in the batch root at the blob
+// index, the quorums of the blob were signed by their thresholds in the batch header, and the signatory record hash
+// commits to the non-signers. batchMetadataHash must be read from batchIdToBatchMetadataHash of the
+// EigenDAServiceManager at the batch ID of the bundle: the service manager only stores it once it verified the
+// signature of the batch against the operators registered at the reference block, so the signature of the bundle and
+// the batch header it signed are authenticated by the hash rather than checked here. Returns the decoded bundle.
+func VerifyProofBundle(data []byte, batchMetadataHash [32]byte) (*disperser.BlobProofBundle, error) {
+	bundle, err := disperser.DecodeBlobProofBundle(data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidProofBundle, err)
+	}
+	proof := bundle.BlobVerificationProof
+	batchHeader := proof.BatchMetadata.BatchHeader
+
+	// The batch metadata, and thus the threshold percentages of its quorums, is the one confirmed onchain
+	metadataHash, err := proof.BatchMetadata.Hash()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidProofBundle, err)
+	}
+	if metadataHash != batchMetadataHash {
+		return nil, fmt.Errorf("%w: batch metadata doesn't match the metadata of batch %d confirmed onchain", ErrInvalidProofBundle, proof.BatchId)
+	}
+
+	// The blob header hash is the leaf of the blob in the batch root
+	blobHeader, err := toCoreBlobHeader(bundle.BlobHeader)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidProofBundle, err)
+	}
+	blobHeaderHash, err := blobHeader.GetBlobHeaderHash()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidProofBundle, err)
+	}
+	if len(proof.InclusionProof)%32 != 0 {
+		return nil, fmt.Errorf("%w: inclusion proof length %d isn't a multiple of 32", ErrInvalidProofBundle, len(proof.InclusionProof))
+	}
+	hashes := make([][]byte, len(proof.InclusionProof)/32)
+	for i := range hashes {
+		hashes[i] = proof.InclusionProof[i*32 : (i+1)*32]
+	}
+	included, err := merkletree.VerifyProofUsing(blobHeaderHash[:], false, &merkletree.Proof{Hashes: hashes, Index: uint64(proof.BlobIndex)}, [][]byte{batchHeader.BlobHeadersRoot[:]}, keccak256.New())
+	if err != nil || !included {
+		return nil, fmt.Errorf("%w: blob header is not included in the batch root at index %d", ErrInvalidProofBundle, proof.BlobIndex)
+	}
+
+	// Each quorum of the blob must have been signed by its threshold in the batch
+	if len(batchHeader.QuorumThresholdPercentages) != len(batchHeader.QuorumNumbers) {
+		return nil, fmt.Errorf("%w: batch header has %d threshold percentages for %d quorums", ErrInvalidProofBundle, len(batchHeader.QuorumThresholdPercentages), len(batchHeader.QuorumNumbers))
+	}
+	if len(proof.QuorumThresholdIndexes) != len(bundle.BlobHeader.QuorumBlobParams) {
+		return nil, fmt.Errorf("%w: %d quorum threshold indexes for %d quorums", ErrInvalidProofBundle, len(proof.QuorumThresholdIndexes), len(bundle.BlobHeader.QuorumBlobParams))
+	}
+	for i, param := range bundle.BlobHeader.QuorumBlobParams {
+		index := int(proof.QuorumThresholdIndexes[i])
+		if index >= len(batchHeader.QuorumNumbers) || batchHeader.QuorumNumbers[index] != param.QuorumNumber {
+			return nil, fmt.Errorf("%w: quorum %d of the blob is not at index %d of the batch header", ErrInvalidProofBundle, param.QuorumNumber, index)
+		}
+		if batchHeader.QuorumThresholdPercentages[index] < param.QuorumThresholdPercentage {
+			return nil, fmt.Errorf("%w: quorum %d signed %d%% of the %d%% threshold of the blob", ErrInvalidProofBundle, param.QuorumNumber, batchHeader.QuorumThresholdPercentages[index], param.QuorumThresholdPercentage)
+		}
+	}
+
+	signature := bundle.Signature
+	if len(signature.QuorumApks) != len(batchHeader.QuorumNumbers) {
+		return nil, fmt.Errorf("%w: %d quorum aggregate public keys for %d quorums", ErrInvalidProofBundle, len(signature.QuorumApks), len(batchHeader.QuorumNumbers))
+	}
+	nonSignerPubKeyHashes := make([]core.OperatorID, len(signature.NonSignerPubkeyHashes))
+	copy(nonSignerPubKeyHashes, signature.NonSignerPubkeyHashes)
+	if core.HashSignatoryRecord(batchHeader.ReferenceBlockNumber, nonSignerPubKeyHashes) != proof.BatchMetadata.SignatoryRecordHash {
+		return nil, fmt.Errorf("%w: signatory record hash doesn't match the non-signers", ErrInvalidProofBundle)
+	}
+	return bundle, nil
+}
+
+// toCoreBlobHeader converts the blob header of a bundle to the blob header it was made of, so that it is hashed as the
+// batcher hashed it
+func toCoreBlobHeader(header disperser.ProofBundleBlobHeader) (*core.BlobHeader, error) {
+	commitment, err := toG1Affine(header.Commitment)
+	if err != nil || !commitment.IsOnCurve() {
+		return nil, core.ErrInvalidCommitment
+	}
+	quorumInfos := make([]*core.BlobQuorumInfo, len(header.QuorumBlobParams))
+	for i, param := range header.QuorumBlobParams {
+		quorumInfos[i] = &core.BlobQuorumInfo{
+			SecurityParam: core.SecurityParam{
+				QuorumID:           param.QuorumNumber,
+				AdversaryThreshold: param.AdversaryThresholdPercentage,
+				QuorumThreshold:    param.QuorumThresholdPercentage,
+			},
+			QuantizationFactor: uint(param.QuantizationParameter),
+		}
+	}
+	return &core.BlobHeader{
+		BlobCommitments: core.BlobCommitments{
+			Commitment: &core.Commitment{G1Point: (*bn254.G1Point)(commitment)},
+			Length:     uint(header.DataLength),
+		},
+		QuorumInfos: quorumInfos,
+	}, nil
+}
+
+func toG1Affine(p disperser.ProofBundleG1Point) (*bn.G1Affine, error) {
+	point := new(bn.G1Affine)
+	if err := setFp(&point.X, p.X); err != nil {
+		return nil, err
+	}
+	if err := setFp(&point.Y, p.Y); err != nil {
+		return nil, err
+	}
+	return point, nil
+}
+
+// setFp sets the field element to the coordinate, which the contracts reject unless it is below the field modulus
+func setFp(element *fp.Element, x *big.Int) error {
+	if x == nil || x.Cmp(fp.Modulus()) >= 0 {
+		return fmt.Errorf("point coordinate is not a field element")
+	}
+	element.SetBigInt(x)
+	return nil
+}
@@ -13,6 +13,8 @@ import (
 )
 
 type RetrievalClient interface {
+	// RetrieveBlob reconstructs a blob from the chunks of the operators of the quorum. The data is padded with zeros to
+	// the length of the encoded blob, see BlobPadding.
 	RetrieveBlob(
 		ctx context.Context,
 		batchHeaderHash [32]byte,
@@ -29,6 +31,7 @@ type retrievalClient struct {
 	nodeClient            NodeClient
 	encoder               core.Encoder
 	numConnections        int
+	socketIndexer         *core.OperatorSocketIndexer
 }
 
 var _ RetrievalClient = (*retrievalClient)(nil)
@@ -51,6 +54,12 @@ func NewRetrievalClient(
 	}
 }
 
+// SetOperatorSocketIndexer sets the indexer resolving the latest sockets of the operators, which are dialed instead of
+// the sockets of the operator state at the reference block of the blob
+func (r *retrievalClient) SetOperatorSocketIndexer(socketIndexer *core.OperatorSocketIndexer) {
+	r.socketIndexer = socketIndexer
+}
+
 func (r *retrievalClient) RetrieveBlob(
 	ctx context.Context,
 	batchHeaderHash [32]byte,
@@ -66,6 +75,7 @@ func (r *retrievalClient) RetrieveBlob(
 	if !ok {
 		return nil, fmt.Errorf("no quorum with ID: %d", quorumID)
 	}
+	indexedOperatorState = r.resolveSockets(indexedOperatorState)
 
 	// Get blob header from any operator
 	var blobHeader *core.BlobHeader
@@ -113,7 +123,7 @@ func (r *retrievalClient) RetrieveBlob(
 		return nil, fmt.Errorf("no quorum header for quorum %d", quorumID)
 	}
 
-	assignements, info, err := r.assignmentCoordinator.GetAssignments(indexedOperatorState.OperatorState, quorumID, uint(quorumHeader.QuantizationFactor))
+	assignements, info, err := r.assignmentCoordinator.GetAssignments(indexedOperatorState.OperatorState, quorumID, quorumHeader.QuantizationFactor, quorumHeader.OverprovisionPercent)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get assignments")
 	}
@@ -159,3 +169,23 @@ func (r *retrievalClient) RetrieveBlob(
 
 	return r.encoder.Decode(chunks, indices, encodingParams, uint64(blobHeader.Length)*bn254.BYTES_PER_COEFFICIENT)
 }
+
+// resolveSockets returns a copy of the operator state with the latest sockets of the operators
+func (r *retrievalClient) resolveSockets(state *core.IndexedOperatorState) *core.IndexedOperatorState {
+	if r.socketIndexer == nil {
+		return state
+	}
+	indexedOperators := make(map[core.OperatorID]*core.IndexedOperatorInfo, len(state.IndexedOperators))
+	for operatorID, operator := range state.IndexedOperators {
+		indexedOperators[operatorID] = &core.IndexedOperatorInfo{
+			PubkeyG1: operator.PubkeyG1,
+			PubkeyG2: operator.PubkeyG2,
+			Socket:   r.socketIndexer.ResolveOperatorSocket(operatorID, operator.Socket),
+		}
+	}
+	return &core.IndexedOperatorState{
+		OperatorState:    state.OperatorState,
+		IndexedOperators: indexedOperators,
+		AggKeys:          state.AggKeys,
+	}
+}
@@ -0,0 +1,361 @@
+package retriever
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	disperser_rpc "github.com/Layr-Labs/eigenda/api/grpc/disperser"
+	"github.com/Layr-Labs/eigenda/clients"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// throttlingDisperser rejects the first numThrottled dispersal requests with the given retry delay
+type throttlingDisperser struct {
+	disperser_rpc.UnimplementedDisperserServer
+
+	numThrottled int32
+	retryDelay   time.Duration
+	numRequests  atomic.Int32
+}
+
+func (d *throttlingDisperser) DisperseBlob(ctx context.Context, req *disperser_rpc.DisperseBlobRequest) (*disperser_rpc.DisperseBlobReply, error) {
+	if d.numRequests.Add(1) <= d.numThrottled {
+		st, err := status.New(codes.ResourceExhausted, "request ratelimited: account limit").WithDetails(&errdetails.RetryInfo{
+			RetryDelay: durationpb.New(d.retryDelay),
+		})
+		if err != nil {
+			return nil, err
+		}
+		return nil, st.Err()
+	}
+
+	return &disperser_rpc.DisperseBlobReply{
+		Result:    disperser_rpc.BlobStatus_PROCESSING,
+		RequestId: []byte("request-id"),
+	}, nil
+}
+
+func (d *throttlingDisperser) GetBlobStatus(ctx context.Context, req *disperser_rpc.BlobStatusRequest) (*disperser_rpc.BlobStatusReply, error) {
+	return &disperser_rpc.BlobStatusReply{
+		Status: disperser_rpc.BlobStatus_CONFIRMED,
+	}, nil
+}
+
+func startDisperser(t *testing.T, server disperser_rpc.DisperserServer) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	gs := grpc.NewServer()
+	disperser_rpc.RegisterDisperserServer(gs, server)
+	go func() {
+		_ = gs.Serve(listener)
+	}()
+	t.Cleanup(gs.Stop)
+
+	_, port, err := net.SplitHostPort(listener.Addr().String())
+	require.NoError(t, err)
+	return port
+}
+
+func TestDisperseAndWaitHonorsRetryDelay(t *testing.T) {
+	server := &throttlingDisperser{
+		numThrottled: 2,
+		retryDelay:   100 * time.Millisecond,
+	}
+	port := startDisperser(t, server)
+
+	client := clients.NewDisperserClient(&clients.DisperserClientConfig{
+		Hostname:           "127.0.0.1",
+		Port:               port,
+		Timeout:            time.Second,
+		StatusPollInterval: 10 * time.Millisecond,
+	})
+
+	start := time.Now()
+	reply, err := client.DisperseAndWait(context.Background(), []byte("data"), []*core.SecurityParam{
+		{
+			QuorumID:           0,
+			AdversaryThreshold: 80,
+			QuorumThreshold:    100,
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, disperser_rpc.BlobStatus_CONFIRMED, reply.GetStatus())
+	assert.Equal(t, int32(3), server.numRequests.Load())
+	assert.GreaterOrEqual(t, time.Since(start), 2*server.retryDelay)
+}
+
+func TestDisperseAndWaitFloorsZeroRetryDelay(t *testing.T) {
+	server := &throttlingDisperser{
+		numThrottled: 1,
+		retryDelay:   0,
+	}
+	port := startDisperser(t, server)
+
+	client := clients.NewDisperserClient(&clients.DisperserClientConfig{
+		Hostname:           "127.0.0.1",
+		Port:               port,
+		Timeout:            time.Second,
+		StatusPollInterval: 10 * time.Millisecond,
+	})
+
+	// The zero delay of the disperser is raised to the default delay of a second
+	start := time.Now()
+	reply, err := client.DisperseAndWait(context.Background(), []byte("data"), []*core.SecurityParam{
+		{
+			QuorumID:           0,
+			AdversaryThreshold: 80,
+			QuorumThreshold:    100,
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, disperser_rpc.BlobStatus_CONFIRMED, reply.GetStatus())
+	assert.Equal(t, int32(2), server.numRequests.Load())
+	assert.GreaterOrEqual(t, time.Since(start), time.Second)
+}
+
+func TestDisperseAndWaitStopsOnContextCancellation(t *testing.T) {
+	server := &throttlingDisperser{
+		numThrottled: 100,
+		retryDelay:   time.Minute,
+	}
+	port := startDisperser(t, server)
+
+	client := clients.NewDisperserClient(&clients.DisperserClientConfig{
+		Hostname:           "127.0.0.1",
+		Port:               port,
+		Timeout:            time.Second,
+		StatusPollInterval: 10 * time.Millisecond,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	_, err := client.DisperseAndWait(ctx, []byte("data"), []*core.SecurityParam{{QuorumID: 0, AdversaryThreshold: 80, QuorumThreshold: 100}})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, int32(1), server.numRequests.Load())
+}
+
+// pollingDisperser replies that the blob is processing, with the given recommended poll delay, to the first
+// numProcessing status requests
+type pollingDisperser struct {
+	disperser_rpc.UnimplementedDisperserServer
+
+	numProcessing int32
+	pollDelay     time.Duration
+	numPolls      atomic.Int32
+}
+
+func (d *pollingDisperser) DisperseBlob(ctx context.Context, req *disperser_rpc.DisperseBlobRequest) (*disperser_rpc.DisperseBlobReply, error) {
+	return &disperser_rpc.DisperseBlobReply{
+		Result:    disperser_rpc.BlobStatus_PROCESSING,
+		RequestId: []byte("request-id"),
+	}, nil
+}
+
+func (d *pollingDisperser) GetBlobStatus(ctx context.Context, req *disperser_rpc.BlobStatusRequest) (*disperser_rpc.BlobStatusReply, error) {
+	if d.numPolls.Add(1) <= d.numProcessing {
+		return &disperser_rpc.BlobStatusReply{
+			Status:          disperser_rpc.BlobStatus_PROCESSING,
+			NextPollDelayMs: uint64(d.pollDelay.Milliseconds()),
+		}, nil
+	}
+	return &disperser_rpc.BlobStatusReply{
+		Status: disperser_rpc.BlobStatus_CONFIRMED,
+	}, nil
+}
+
+func TestDisperseAndWaitHonorsNextPollDelay(t *testing.T) {
+	server := &pollingDisperser{
+		numProcessing: 2,
+		pollDelay:     100 * time.Millisecond,
+	}
+	port := startDisperser(t, server)
+
+	client := clients.NewDisperserClient(&clients.DisperserClientConfig{
+		Hostname:           "127.0.0.1",
+		Port:               port,
+		Timeout:            time.Second,
+		StatusPollInterval: 10 * time.Millisecond,
+	})
+
+	start := time.Now()
+	reply, err := client.DisperseAndWait(context.Background(), []byte("data"), []*core.SecurityParam{{QuorumID: 0, AdversaryThreshold: 80, QuorumThreshold: 100}})
+	require.NoError(t, err)
+	assert.Equal(t, disperser_rpc.BlobStatus_CONFIRMED, reply.GetStatus())
+	assert.Equal(t, int32(3), server.numPolls.Load())
+	assert.GreaterOrEqual(t, time.Since(start), 2*server.pollDelay)
+}
+
+// insufficientSignaturesDisperser replies that the blob failed with insufficient signatures in its second quorum
+type insufficientSignaturesDisperser struct {
+	pollingDisperser
+}
+
+func (d *insufficientSignaturesDisperser) GetBlobStatus(ctx context.Context, req *disperser_rpc.BlobStatusRequest) (*disperser_rpc.BlobStatusReply, error) {
+	return &disperser_rpc.BlobStatusReply{
+		Status: disperser_rpc.BlobStatus_INSUFFICIENT_SIGNATURES,
+		QuorumStatuses: []*disperser_rpc.BlobQuorumStatus{
+			{QuorumNumber: 0, SignedPercentage: 90, QuorumThresholdPercentage: 80, Confirmed: true},
+			{QuorumNumber: 1, SignedPercentage: 40, QuorumThresholdPercentage: 80},
+		},
+	}, nil
+}
+
+func TestDisperseAndWaitInsufficientSignatures(t *testing.T) {
+	port := startDisperser(t, &insufficientSignaturesDisperser{})
+
+	client := clients.NewDisperserClient(&clients.DisperserClientConfig{
+		Hostname:           "127.0.0.1",
+		Port:               port,
+		Timeout:            time.Second,
+		StatusPollInterval: 10 * time.Millisecond,
+	})
+
+	reply, err := client.DisperseAndWait(context.Background(), []byte("data"), []*core.SecurityParam{
+		{QuorumID: 0, AdversaryThreshold: 50, QuorumThreshold: 80},
+		{QuorumID: 1, AdversaryThreshold: 50, QuorumThreshold: 80},
+	})
+	assert.Equal(t, disperser_rpc.BlobStatus_INSUFFICIENT_SIGNATURES, reply.GetStatus())
+	var insufficientSignaturesErr *clients.InsufficientSignaturesError
+	require.ErrorAs(t, err, &insufficientSignaturesErr)
+	assert.Equal(t, []clients.QuorumSignatures{
+		{QuorumID: 0, SignedPercentage: 90, QuorumThreshold: 80},
+		{QuorumID: 1, SignedPercentage: 40, QuorumThreshold: 80},
+	}, insufficientSignaturesErr.Quorums)
+	assert.EqualError(t, err, "blob dispersal failed: insufficient signatures (quorum 0 signed 90% of 80%, quorum 1 signed 40% of 80%)")
+}
+
+func TestGetNextPollDelay(t *testing.T) {
+	assert.Equal(t, time.Second, clients.GetNextPollDelay(&disperser_rpc.BlobStatusReply{}, time.Second))
+	assert.Equal(t, 1500*time.Millisecond, clients.GetNextPollDelay(&disperser_rpc.BlobStatusReply{NextPollDelayMs: 1500}, time.Second))
+}
+
+func TestGetRetryDelay(t *testing.T) {
+	_, throttled := clients.GetRetryDelay(status.Error(codes.InvalidArgument, "bad request"))
+	assert.False(t, throttled)
+
+	retryDelay, throttled := clients.GetRetryDelay(status.Error(codes.ResourceExhausted, "request ratelimited"))
+	assert.True(t, throttled)
+	assert.Equal(t, time.Second, retryDelay)
+}
+
+// storingDisperser returns the data of the last dispersed blob on retrieval
+type storingDisperser struct {
+	disperser_rpc.UnimplementedDisperserServer
+
+	data []byte
+}
+
+func (d *storingDisperser) DisperseBlob(ctx context.Context, req *disperser_rpc.DisperseBlobRequest) (*disperser_rpc.DisperseBlobReply, error) {
+	d.data = req.GetData()
+	return &disperser_rpc.DisperseBlobReply{
+		Result:    disperser_rpc.BlobStatus_PROCESSING,
+		RequestId: []byte("request-id"),
+	}, nil
+}
+
+func (d *storingDisperser) RetrieveBlob(ctx context.Context, req *disperser_rpc.RetrieveBlobRequest) (*disperser_rpc.RetrieveBlobReply, error) {
+	return &disperser_rpc.RetrieveBlobReply{
+		Data: d.data,
+	}, nil
+}
+
+// batchDisperser streams the blobs of a batch, stopping after numSent of them
+type batchDisperser struct {
+	disperser_rpc.UnimplementedDisperserServer
+
+	blobs   [][]byte
+	numSent int
+}
+
+func (d *batchDisperser) RetrieveBatchBlobs(req *disperser_rpc.RetrieveBatchBlobsRequest, stream disperser_rpc.Disperser_RetrieveBatchBlobsServer) error {
+	for i, data := range d.blobs[:d.numSent] {
+		if err := stream.Send(&disperser_rpc.RetrieveBatchBlobsReply{
+			BlobIndex: uint32(i),
+			Size:      uint32(len(data)),
+			Data:      data,
+			NumBlobs:  uint32(len(d.blobs)),
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestRetrieveBatchBlobs(t *testing.T) {
+	blobs := [][]byte{clients.PadBlob([]byte("first")), clients.PadBlob([]byte("second\x00")), clients.PadBlob([]byte("third"))}
+	server := &batchDisperser{blobs: blobs, numSent: len(blobs)}
+	client := clients.NewDisperserClient(&clients.DisperserClientConfig{
+		Hostname: "127.0.0.1",
+		Port:     startDisperser(t, server),
+		Timeout:  time.Second,
+		Padding:  clients.LengthPrefixedBlobPadding,
+	})
+
+	var retrieved []string
+	err := client.RetrieveBatchBlobs(context.Background(), []byte("batch-header-hash"), func(blobIndex uint32, data []byte) error {
+		assert.Equal(t, uint32(len(retrieved)), blobIndex)
+		retrieved = append(retrieved, string(data))
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"first", "second\x00", "third"}, retrieved)
+
+	// The retrieval stops at the first error of the handler
+	handlerErr := errors.New("handler error")
+	numHandled := 0
+	err = client.RetrieveBatchBlobs(context.Background(), []byte("batch-header-hash"), func(blobIndex uint32, data []byte) error {
+		numHandled++
+		return handlerErr
+	})
+	assert.ErrorIs(t, err, handlerErr)
+	assert.Equal(t, 1, numHandled)
+
+	// A stream missing blobs of the batch fails
+	server.numSent = 2
+	err = client.RetrieveBatchBlobs(context.Background(), []byte("batch-header-hash"), func(blobIndex uint32, data []byte) error { return nil })
+	assert.EqualError(t, err, "received 2 blobs of the batch, but it has 3")
+}
+
+func TestDisperseAndRetrievePaddedBlob(t *testing.T) {
+	server := &storingDisperser{}
+	port := startDisperser(t, server)
+	securityParams := []*core.SecurityParam{{QuorumID: 0, AdversaryThreshold: 80, QuorumThreshold: 100}}
+	data := []byte("data with trailing zeros\x00\x00")
+
+	for _, padding := range []clients.BlobPadding{clients.NoBlobPadding, clients.LengthPrefixedBlobPadding} {
+		client := clients.NewDisperserClient(&clients.DisperserClientConfig{
+			Hostname: "127.0.0.1",
+			Port:     port,
+			Timeout:  time.Second,
+			Padding:  padding,
+		})
+		_, _, err := client.DisperseBlob(context.Background(), data, securityParams)
+		require.NoError(t, err)
+		retrieved, err := client.RetrieveBlob(context.Background(), []byte("batch-header-hash"), 0)
+		require.NoError(t, err)
+		assert.Equal(t, data, retrieved, padding)
+	}
+	assert.Equal(t, clients.PadBlob(data), server.data)
+
+	client := clients.NewDisperserClient(&clients.DisperserClientConfig{
+		Hostname: "127.0.0.1",
+		Port:     port,
+		Timeout:  time.Second,
+		Padding:  "unknown",
+	})
+	_, _, err := client.DisperseBlob(context.Background(), data, securityParams)
+	assert.EqualError(t, err, `unknown blob padding "unknown"`)
+}
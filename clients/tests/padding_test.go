@@ -0,0 +1,49 @@
+package retriever
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/clients"
+	"github.com/Layr-Labs/eigenda/pkg/encoding/encoder"
+	"github.com/Layr-Labs/eigenda/pkg/kzg/bn254"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPadBlob(t *testing.T) {
+	for _, data := range [][]byte{
+		{},
+		{0},
+		[]byte("data"),
+		bytes.Repeat([]byte{1}, bn254.BYTES_PER_COEFFICIENT-4),
+		bytes.Repeat([]byte{1}, bn254.BYTES_PER_COEFFICIENT-3),
+		append(bytes.Repeat([]byte{1}, 100), 0, 0, 0),
+	} {
+		padded := clients.PadBlob(data)
+		assert.Zero(t, len(padded)%bn254.BYTES_PER_COEFFICIENT)
+		assert.Less(t, len(padded), len(data)+4+bn254.BYTES_PER_COEFFICIENT)
+
+		unpadded, err := clients.UnpadBlob(padded)
+		require.NoError(t, err)
+		assert.Equal(t, data, unpadded)
+
+		// The blobs reconstructed from the nodes are padded to the length of the encoded blob
+		symbols := encoder.ToFrArray(padded)
+		reconstructed := encoder.ToByteArray(append(symbols, make([]bn254.Fr, 3)...), uint64(len(symbols)+3)*bn254.BYTES_PER_COEFFICIENT)
+		unpadded, err = clients.UnpadBlob(reconstructed)
+		require.NoError(t, err)
+		assert.Equal(t, data, unpadded)
+	}
+}
+
+func TestUnpadInvalidBlob(t *testing.T) {
+	_, err := clients.UnpadBlob([]byte{0, 0, 1})
+	assert.ErrorIs(t, err, clients.ErrInvalidBlobPadding)
+
+	_, err = clients.UnpadBlob([]byte{0, 0, 0, 4, 1, 2, 3})
+	assert.ErrorIs(t, err, clients.ErrInvalidBlobPadding)
+
+	_, err = clients.UnpadBlob([]byte{0, 0, 0, 2, 1, 2, 0, 3})
+	assert.ErrorIs(t, err, clients.ErrInvalidBlobPadding)
+}
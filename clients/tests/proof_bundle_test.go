@@ -0,0 +1,270 @@
+package retriever_test
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/clients"
+	binding "github.com/Layr-Labs/eigenda/contracts/bindings/EigenDAServiceManager"
+	rollupbinding "github.com/Layr-Labs/eigenda/contracts/bindings/MockRollup"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/disperser"
+	"github.com/Layr-Labs/eigenda/pkg/kzg/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fp"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// makeConfirmedBlob makes the confirmation info of the second blob of a batch of three blobs in quorums 0 and 1, signed
+// by two of three operators, and the signature of the batch, as the batcher records them
+func makeConfirmedBlob(t *testing.T) (*disperser.ConfirmationInfo, *disperser.BatchSignature) {
+	blobHeaders := make([]*core.BlobHeader, 3)
+	for i := range blobHeaders {
+		// Any point of the curve will do as the commitment of the blob
+		commitment, err := core.GenRandomBlsKeys()
+		require.NoError(t, err)
+		blobHeaders[i] = &core.BlobHeader{
+			BlobCommitments: core.BlobCommitments{
+				Commitment: &core.Commitment{G1Point: (*bn254.G1Point)(commitment.GetPubKeyG1().G1Affine)},
+				Length:     uint(10 + i),
+			},
+			QuorumInfos: []*core.BlobQuorumInfo{
+				{SecurityParam: core.SecurityParam{QuorumID: 1, AdversaryThreshold: 50, QuorumThreshold: 60}, QuantizationFactor: 1},
+				{SecurityParam: core.SecurityParam{QuorumID: 0, AdversaryThreshold: 50, QuorumThreshold: 70}, QuantizationFactor: 1},
+			},
+		}
+	}
+	batchHeader := &core.BatchHeader{ReferenceBlockNumber: 132}
+	tree, err := batchHeader.SetBatchRoot(blobHeaders)
+	require.NoError(t, err)
+	batchHeaderHash, err := batchHeader.GetBatchHeaderHash()
+	require.NoError(t, err)
+
+	keys := make([]*core.KeyPair, 3)
+	for i := range keys {
+		keys[i], err = core.GenRandomBlsKeys()
+		require.NoError(t, err)
+	}
+	// All the operators are in both quorums, and the last one doesn't sign
+	quorumAggPubKey := keys[0].GetPubKeyG1().Deserialize(keys[0].GetPubKeyG1().Serialize())
+	quorumAggPubKey.Add(keys[1].GetPubKeyG1())
+	quorumAggPubKey.Add(keys[2].GetPubKeyG1())
+	aggSignature := keys[0].SignMessage(batchHeaderHash)
+	aggSignature.Add(keys[1].SignMessage(batchHeaderHash).G1Point)
+	aggPubKey := keys[0].GetPubKeyG2().Deserialize(keys[0].GetPubKeyG2().Serialize())
+	aggPubKey.Add(keys[1].GetPubKeyG2())
+	nonSigners := []*core.G1Point{keys[2].GetPubKeyG1()}
+
+	blobHeaderHash, err := blobHeaders[1].GetBlobHeaderHash()
+	require.NoError(t, err)
+	merkleProof, err := tree.GenerateProof(blobHeaderHash[:], 0)
+	require.NoError(t, err)
+	inclusionProof := make([]byte, 0)
+	for _, hash := range merkleProof.Hashes {
+		inclusionProof = append(inclusionProof, hash...)
+	}
+
+	info := &disperser.ConfirmationInfo{
+		BatchHeaderHash:      batchHeaderHash,
+		BlobIndex:            1,
+		BlobCount:            3,
+		SignatoryRecordHash:  core.ComputeSignatoryRecordHash(uint32(batchHeader.ReferenceBlockNumber), nonSigners),
+		ReferenceBlockNumber: uint32(batchHeader.ReferenceBlockNumber),
+		BatchRoot:            batchHeader.BatchRoot[:],
+		BlobInclusionProof:   inclusionProof,
+		BlobCommitment:       &blobHeaders[1].BlobCommitments,
+		BatchID:              99,
+		Fee:                  []byte{0},
+		QuorumResults: map[core.QuorumID]*core.QuorumResult{
+			0: {QuorumID: 0, PercentSigned: 75},
+			1: {QuorumID: 1, PercentSigned: 66},
+		},
+		BlobQuorumInfos:         blobHeaders[1].QuorumInfos,
+		ConfirmationBlockNumber: 150,
+	}
+	signature := disperser.NewBatchSignature([]core.QuorumID{0, 1}, &core.SignatureAggregation{
+		NonSigners:       nonSigners,
+		QuorumAggPubKeys: []*core.G1Point{quorumAggPubKey, quorumAggPubKey},
+		AggPubKey:        aggPubKey,
+		AggSignature:     aggSignature,
+	})
+	return info, signature
+}
+
+// confirmedMetadataHash returns the hash of the metadata of the batch of the blob, as the EigenDAServiceManager stores it
+// in batchIdToBatchMetadataHash once the batch is confirmed
+func confirmedMetadataHash(t *testing.T, info *disperser.ConfirmationInfo) [32]byte {
+	var batchRoot [32]byte
+	copy(batchRoot[:], info.BatchRoot)
+	batchHeaderHash, err := core.HashBatchHeader(binding.IEigenDAServiceManagerBatchHeader{
+		BlobHeadersRoot:            batchRoot,
+		QuorumNumbers:              []byte{0, 1},
+		QuorumThresholdPercentages: []byte{info.QuorumResults[0].PercentSigned, info.QuorumResults[1].PercentSigned},
+		ReferenceBlockNumber:       info.ReferenceBlockNumber,
+	})
+	require.NoError(t, err)
+	fee := new(big.Int).SetBytes(info.Fee).FillBytes(make([]byte, 12))
+	confirmationBlockNumber := new(big.Int).SetUint64(uint64(info.ConfirmationBlockNumber)).FillBytes(make([]byte, 4))
+
+	var hash [32]byte
+	copy(hash[:], crypto.Keccak256(batchHeaderHash[:], info.SignatoryRecordHash[:], fee, confirmationBlockNumber))
+	return hash
+}
+
+func TestVerifyProofBundle(t *testing.T) {
+	info, signature := makeConfirmedBlob(t)
+	bundle, err := disperser.NewBlobProofBundle(info, signature)
+	require.NoError(t, err)
+	data, err := bundle.Encode()
+	require.NoError(t, err)
+
+	verified, err := clients.VerifyProofBundle(data, confirmedMetadataHash(t, info))
+	require.NoError(t, err)
+	assert.Equal(t, bundle, verified)
+
+	// The contracts decode the blob header and the verification proof of the bundle as they were confirmed
+	rollupABI, err := abi.JSON(strings.NewReader(rollupbinding.ContractMockRollupMetaData.ABI))
+	require.NoError(t, err)
+	values, err := rollupABI.Methods["postCommitment"].Inputs.Unpack(data)
+	require.NoError(t, err)
+	blobHeader := *abi.ConvertType(values[0], new(rollupbinding.IEigenDAServiceManagerBlobHeader)).(*rollupbinding.IEigenDAServiceManagerBlobHeader)
+	proof := *abi.ConvertType(values[1], new(rollupbinding.EigenDABlobUtilsBlobVerificationProof)).(*rollupbinding.EigenDABlobUtilsBlobVerificationProof)
+
+	assert.Equal(t, info.BlobCommitment.Commitment.X.BigInt(new(big.Int)), blobHeader.Commitment.X)
+	assert.Equal(t, info.BlobCommitment.Commitment.Y.BigInt(new(big.Int)), blobHeader.Commitment.Y)
+	assert.Equal(t, uint32(11), blobHeader.DataLength)
+	assert.Equal(t, []rollupbinding.IEigenDAServiceManagerQuorumBlobParam{
+		{QuorumNumber: 1, AdversaryThresholdPercentage: 50, QuorumThresholdPercentage: 60, QuantizationParameter: 1},
+		{QuorumNumber: 0, AdversaryThresholdPercentage: 50, QuorumThresholdPercentage: 70, QuantizationParameter: 1},
+	}, blobHeader.QuorumBlobParams)
+
+	assert.Equal(t, uint32(99), proof.BatchId)
+	assert.Equal(t, uint8(1), proof.BlobIndex)
+	assert.Equal(t, info.BatchRoot, proof.BatchMetadata.BatchHeader.BlobHeadersRoot[:])
+	// The quorums of the batch header are in increasing order, as confirmed onchain
+	assert.Equal(t, []byte{0, 1}, proof.BatchMetadata.BatchHeader.QuorumNumbers)
+	assert.Equal(t, []byte{75, 66}, proof.BatchMetadata.BatchHeader.QuorumThresholdPercentages)
+	assert.Equal(t, uint32(132), proof.BatchMetadata.BatchHeader.ReferenceBlockNumber)
+	assert.Equal(t, info.SignatoryRecordHash, proof.BatchMetadata.SignatoryRecordHash)
+	assert.Equal(t, uint32(150), proof.BatchMetadata.ConfirmationBlockNumber)
+	assert.Equal(t, info.BlobInclusionProof, proof.InclusionProof)
+	assert.Equal(t, []byte{1, 0}, proof.QuorumThresholdIndexes)
+}
+
+func TestVerifyProofBundleRejectsTampering(t *testing.T) {
+	tests := []struct {
+		name   string
+		tamper func(bundle *disperser.BlobProofBundle)
+	}{
+		{
+			name: "blob header",
+			tamper: func(bundle *disperser.BlobProofBundle) {
+				bundle.BlobHeader.DataLength++
+			},
+		},
+		{
+			name: "blob index",
+			tamper: func(bundle *disperser.BlobProofBundle) {
+				bundle.BlobVerificationProof.BlobIndex = 0
+			},
+		},
+		{
+			name: "inclusion proof",
+			tamper: func(bundle *disperser.BlobProofBundle) {
+				proof := append([]byte{}, bundle.BlobVerificationProof.InclusionProof...)
+				proof[0] ^= 1
+				bundle.BlobVerificationProof.InclusionProof = proof
+			},
+		},
+		{
+			name: "quorum below threshold",
+			tamper: func(bundle *disperser.BlobProofBundle) {
+				bundle.BlobVerificationProof.BatchMetadata.BatchHeader.QuorumThresholdPercentages = []byte{69, 66}
+			},
+		},
+		{
+			name: "quorum threshold index",
+			tamper: func(bundle *disperser.BlobProofBundle) {
+				bundle.BlobVerificationProof.QuorumThresholdIndexes = []byte{0, 1}
+			},
+		},
+		{
+			name: "missing non-signer",
+			tamper: func(bundle *disperser.BlobProofBundle) {
+				bundle.Signature.NonSignerPubkeyHashes = [][32]byte{}
+			},
+		},
+		{
+			name: "reference block number",
+			tamper: func(bundle *disperser.BlobProofBundle) {
+				bundle.BlobVerificationProof.BatchMetadata.BatchHeader.ReferenceBlockNumber++
+			},
+		},
+		{
+			name: "coordinate above the field modulus",
+			tamper: func(bundle *disperser.BlobProofBundle) {
+				bundle.BlobHeader.Commitment.X = new(big.Int).Add(bundle.BlobHeader.Commitment.X, fp.Modulus())
+			},
+		},
+		{
+			name: "fee above uint96",
+			tamper: func(bundle *disperser.BlobProofBundle) {
+				bundle.BlobVerificationProof.BatchMetadata.Fee = new(big.Int).Lsh(big.NewInt(1), 96)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info, signature := makeConfirmedBlob(t)
+			bundle, err := disperser.NewBlobProofBundle(info, signature)
+			require.NoError(t, err)
+			tt.tamper(bundle)
+			data, err := bundle.Encode()
+			require.NoError(t, err)
+
+			_, err = clients.VerifyProofBundle(data, confirmedMetadataHash(t, info))
+			assert.ErrorIs(t, err, clients.ErrInvalidProofBundle)
+		})
+	}
+
+	_, err := clients.VerifyProofBundle([]byte("not a bundle"), [32]byte{})
+	assert.ErrorIs(t, err, clients.ErrInvalidProofBundle)
+}
+
+func TestVerifyProofBundleRejectsForeignSignature(t *testing.T) {
+	info, _ := makeConfirmedBlob(t)
+	metadataHash := confirmedMetadataHash(t, info)
+
+	// A batch of other blobs, signed by operators which aren't registered, claims the ID of the confirmed batch. The
+	// bundle is consistent with its own signature and aggregate public keys.
+	forgedInfo, forgedSignature := makeConfirmedBlob(t)
+	bundle, err := disperser.NewBlobProofBundle(forgedInfo, forgedSignature)
+	require.NoError(t, err)
+	assert.Equal(t, info.BatchID, bundle.BlobVerificationProof.BatchId)
+	data, err := bundle.Encode()
+	require.NoError(t, err)
+	_, err = clients.VerifyProofBundle(data, confirmedMetadataHash(t, forgedInfo))
+	require.NoError(t, err)
+
+	_, err = clients.VerifyProofBundle(data, metadataHash)
+	assert.ErrorIs(t, err, clients.ErrInvalidProofBundle)
+
+	// The blob of the confirmed batch, re-signed with a foreign key which claims that all of the stake signed it
+	info.QuorumResults[0].PercentSigned = 100
+	info.QuorumResults[1].PercentSigned = 100
+	foreignKey, err := core.GenRandomBlsKeys()
+	require.NoError(t, err)
+	bundle, err = disperser.NewBlobProofBundle(info, disperser.NewBatchSignature([]core.QuorumID{0, 1}, &core.SignatureAggregation{
+		QuorumAggPubKeys: []*core.G1Point{foreignKey.GetPubKeyG1(), foreignKey.GetPubKeyG1()},
+		AggPubKey:        foreignKey.GetPubKeyG2(),
+		AggSignature:     foreignKey.SignMessage(info.BatchHeaderHash),
+	}))
+	require.NoError(t, err)
+	data, err = bundle.Encode()
+	require.NoError(t, err)
+	_, err = clients.VerifyProofBundle(data, metadataHash)
+	assert.ErrorIs(t, err, clients.ErrInvalidProofBundle)
+}
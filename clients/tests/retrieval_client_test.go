@@ -5,6 +5,7 @@ import (
 	"context"
 	"runtime"
 	"testing"
+	"time"
 
 	"github.com/Layr-Labs/eigenda/clients"
 	clientsmock "github.com/Layr-Labs/eigenda/clients/mock"
@@ -36,7 +37,7 @@ func makeTestEncoder() (core.Encoder, error) {
 	}
 
 	return &encoding.Encoder{
-		EncoderGroup: kzgEncoderGroup,
+		Backend: &encoding.Bn254Backend{EncoderGroup: kzgEncoderGroup},
 	}, nil
 }
 
@@ -52,7 +53,7 @@ var (
 	gettysburgAddressBytes = []byte("Fourscore and seven years ago our fathers brought forth, on this continent, a new nation, conceived in liberty, and dedicated to the proposition that all men are created equal. Now we are engaged in a great civil war, testing whether that nation, or any nation so conceived, and so dedicated, can long endure. We are met on a great battle-field of that war. We have come to dedicate a portion of that field, as a final resting-place for those who here gave their lives, that that nation might live. It is altogether fitting and proper that we should do this. But, in a larger sense, we cannot dedicate, we cannot consecrate—we cannot hallow—this ground. The brave men, living and dead, who struggled here, have consecrated it far above our poor power to add or detract. The world will little note, nor long remember what we say here, but it can never forget what they did here. It is for us the living, rather, to be dedicated here to the unfinished work which they who fought here have thus far so nobly advanced. It is rather for us to be here dedicated to the great task remaining before us—that from these honored dead we take increased devotion to that cause for which they here gave the last full measure of devotion—that we here highly resolve that these dead shall not have died in vain—that this nation, under God, shall have a new birth of freedom, and that government of the people, by the people, for the people, shall not perish from the earth.")
 )
 
-func setup(t *testing.T) {
+func setup(t *testing.T, adversaryThreshold, quorumThreshold uint8, overprovisionPercent uint) {
 
 	var err error
 	indexedChainState, err = coremock.NewChainDataMock(core.OperatorIndex(numOperators))
@@ -75,13 +76,12 @@ func setup(t *testing.T) {
 	var (
 		quorumID           core.QuorumID = 0
 		quantizationFactor uint          = 2
-		adversaryThreshold uint8         = 80
-		quorumThreshold    uint8         = 90
 	)
 	securityParams := []*core.SecurityParam{
 		{
 			QuorumID:           quorumID,
 			AdversaryThreshold: adversaryThreshold,
+			QuorumThreshold:    quorumThreshold,
 		},
 	}
 	blob := core.Blob{
@@ -95,7 +95,7 @@ func setup(t *testing.T) {
 		t.Fatalf("failed to get operator state: %s", err)
 	}
 
-	assignments, info, err := coordinator.GetAssignments(operatorState, quorumID, quantizationFactor)
+	assignments, info, err := coordinator.GetAssignments(operatorState, quorumID, quantizationFactor, overprovisionPercent)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -118,13 +118,16 @@ func setup(t *testing.T) {
 		t.Fatal(err)
 	}
 
+	encodedLength, err := core.GetNominalEncodedBlobLength(params.ChunkLength, numOperators, quantizationFactor, overprovisionPercent)
+	if err != nil {
+		t.Fatal(err)
+	}
+
 	quorumHeader := &core.BlobQuorumInfo{
-		SecurityParam: core.SecurityParam{
-			QuorumID:           quorumID,
-			AdversaryThreshold: adversaryThreshold,
-		},
-		QuantizationFactor: quantizationFactor,
-		EncodedBlobLength:  quantizationFactor * chunkLength * numOperators,
+		SecurityParam:        *securityParams[0],
+		QuantizationFactor:   quantizationFactor,
+		OverprovisionPercent: overprovisionPercent,
+		EncodedBlobLength:    encodedLength,
 	}
 
 	blobHeader = &core.BlobHeader{
@@ -168,7 +171,7 @@ func setup(t *testing.T) {
 
 func TestInvalidBlobHeader(t *testing.T) {
 
-	setup(t)
+	setup(t, 80, 90, 0)
 
 	// TODO: add the blob proof to the response
 	nodeClient.On("GetBlobHeader", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(blobHeader, [][]byte{{1}}, uint64(0), nil).Times(numOperators)
@@ -183,7 +186,7 @@ func TestInvalidBlobHeader(t *testing.T) {
 
 func TestValidBlobHeader(t *testing.T) {
 
-	setup(t)
+	setup(t, 80, 90, 0)
 
 	// TODO: add the blob proof to the response
 	nodeClient.On("GetBlobHeader", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(blobHeader, [][]byte{}, uint64(0), nil).Once()
@@ -198,3 +201,98 @@ func TestValidBlobHeader(t *testing.T) {
 	assert.Equal(t, gettysburgAddressBytes, recovered)
 
 }
+
+func TestRetrieveBlobWithOfflineOperators(t *testing.T) {
+
+	setup(t, 50, 100, 50)
+
+	// Only the 2 operators with the largest stake are online. They hold a third of the stake, which is less than the
+	// half that the nominal chunks need, but the chunks are over-provisioned by half.
+	operatorState, err := indexedChainState.GetOperatorState(context.Background(), 0, []core.QuorumID{0})
+	assert.NoError(t, err)
+	online := make(core.EncodedBlob)
+	for id, message := range encodedBlob {
+		if operatorState.Operators[0][id].Index >= numOperators-2 {
+			online[id] = message
+		}
+	}
+	assert.Len(t, online, 2)
+
+	// Without the margin, the online operators would hold fewer symbols than the blob has, with chunks of the minimum length
+	assignments, _, err := coordinator.GetAssignments(operatorState, 0, 2, 0)
+	assert.NoError(t, err)
+	numChunks := uint(0)
+	for id := range online {
+		numChunks += assignments[id].NumChunks
+	}
+	chunkLength, err := coordinator.GetMinimumChunkLength(numOperators, blobHeader.Length, 2, 100, 50)
+	assert.NoError(t, err)
+	assert.Less(t, numChunks*chunkLength, blobHeader.Length)
+
+	nodeClient.On("GetBlobHeader", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(blobHeader, [][]byte{}, uint64(0), nil).Once()
+	nodeClient.
+		On("GetChunks", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(online)
+
+	data, err := retrievalClient.RetrieveBlob(context.Background(), batchHeaderHash, 0, 0, batchRoot, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, gettysburgAddressBytes, bytes.TrimRight(data, "\x00"))
+
+}
+
+func TestRetrieveBlobWithUpdatedSockets(t *testing.T) {
+
+	setup(t, 80, 90, 0)
+
+	state, err := indexedChainState.GetIndexedOperatorState(context.Background(), 0, []core.QuorumID{0})
+	assert.NoError(t, err)
+	sockets := make(map[core.OperatorID]core.OperatorSocket, len(state.IndexedOperators))
+	for id, operator := range state.IndexedOperators {
+		sockets[id] = core.OperatorSocket(operator.Socket)
+	}
+	source := &coremock.MockOperatorSocketSource{}
+	source.On("GetOperatorSockets").Return(sockets, nil)
+	events := make(chan core.OperatorSocketUpdate)
+	source.On("WatchOperatorSocketUpdates").Return(events, nil)
+	logger, err := logging.GetLogger(logging.DefaultCLIConfig())
+	assert.NoError(t, err)
+	socketIndexer := core.NewOperatorSocketIndexer(source, time.Minute, logger)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	updates := socketIndexer.Subscribe(ctx)
+	socketIndexer.Start(ctx)
+	for range state.IndexedOperators {
+		<-updates
+	}
+
+	// All the operators update their socket after the blob was dispersed
+	for id := range state.IndexedOperators {
+		events <- core.OperatorSocketUpdate{OperatorID: id, Socket: core.MakeOperatorSocket("updated", "32000", "32001")}
+		<-updates
+	}
+
+	encoder, err := makeTestEncoder()
+	assert.NoError(t, err)
+	client := clients.NewRetrievalClient(logger, indexedChainState, coordinator, nodeClient, encoder, 2)
+	client.SetOperatorSocketIndexer(socketIndexer)
+
+	updatedSocket := core.MakeOperatorSocket("updated", "32000", "32001").String()
+	nodeClient.On("GetBlobHeader", updatedSocket, mock.Anything, mock.Anything, mock.Anything).Return(blobHeader, [][]byte{}, uint64(0), nil).Once()
+	nodeClient.
+		On("GetChunks", mock.Anything, mock.MatchedBy(func(opInfo *core.IndexedOperatorInfo) bool {
+			return opInfo.Socket == updatedSocket
+		}), mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(encodedBlob)
+
+	data, err := client.RetrieveBlob(context.Background(), batchHeaderHash, 0, 0, batchRoot, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, gettysburgAddressBytes, bytes.TrimRight(data, "\x00"))
+	nodeClient.AssertNumberOfCalls(t, "GetChunks", len(state.IndexedOperators))
+
+	// The operator state of the chain is left untouched
+	state, err = indexedChainState.GetIndexedOperatorState(context.Background(), 0, []core.QuorumID{0})
+	assert.NoError(t, err)
+	for _, operator := range state.IndexedOperators {
+		assert.NotEqual(t, updatedSocket, operator.Socket)
+	}
+}
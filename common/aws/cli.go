@@ -10,6 +10,8 @@ var (
 	AccessKeyIdFlagName     = "aws.access-key-id"
 	SecretAccessKeyFlagName = "aws.secret-access-key"
 	EndpointURLFlagName     = "aws.endpoint-url"
+	CACertFileFlagName      = "aws.ca-cert-file"
+	SkipVerifyFlagName      = "aws.insecure-skip-verify"
 )
 
 type ClientConfig struct {
@@ -17,6 +19,10 @@ type ClientConfig struct {
 	AccessKey       string
 	SecretAccessKey string
 	EndpointURL     string
+	// CACertFile is the PEM file of the CA certificates used to verify the endpoint, in place of the system roots
+	CACertFile string
+	// InsecureSkipVerify skips the verification of the endpoint's certificate, e.g. for self-signed test certificates
+	InsecureSkipVerify bool
 }
 
 func ClientFlags(envPrefix string, flagPrefix string) []cli.Flag {
@@ -48,14 +54,29 @@ func ClientFlags(envPrefix string, flagPrefix string) []cli.Flag {
 			Value:    "",
 			EnvVar:   common.PrefixEnvVar(envPrefix, "AWS_ENDPOINT_URL"),
 		},
+		cli.StringFlag{
+			Name:     common.PrefixFlag(flagPrefix, CACertFileFlagName),
+			Usage:    "PEM file of the CA certificates used to verify the AWS endpoint",
+			Required: false,
+			Value:    "",
+			EnvVar:   common.PrefixEnvVar(envPrefix, "AWS_CA_CERT_FILE"),
+		},
+		cli.BoolFlag{
+			Name:     common.PrefixFlag(flagPrefix, SkipVerifyFlagName),
+			Usage:    "Skip the verification of the AWS endpoint's TLS certificate. Only meant for testing",
+			Required: false,
+			EnvVar:   common.PrefixEnvVar(envPrefix, "AWS_INSECURE_SKIP_VERIFY"),
+		},
 	}
 }
 
 func ReadClientConfig(ctx *cli.Context, flagPrefix string) ClientConfig {
 	return ClientConfig{
-		Region:          ctx.GlobalString(common.PrefixFlag(flagPrefix, RegionFlagName)),
-		AccessKey:       ctx.GlobalString(common.PrefixFlag(flagPrefix, AccessKeyIdFlagName)),
-		SecretAccessKey: ctx.GlobalString(common.PrefixFlag(flagPrefix, SecretAccessKeyFlagName)),
-		EndpointURL:     ctx.GlobalString(common.PrefixFlag(flagPrefix, EndpointURLFlagName)),
+		Region:             ctx.GlobalString(common.PrefixFlag(flagPrefix, RegionFlagName)),
+		AccessKey:          ctx.GlobalString(common.PrefixFlag(flagPrefix, AccessKeyIdFlagName)),
+		SecretAccessKey:    ctx.GlobalString(common.PrefixFlag(flagPrefix, SecretAccessKeyFlagName)),
+		EndpointURL:        ctx.GlobalString(common.PrefixFlag(flagPrefix, EndpointURLFlagName)),
+		CACertFile:         ctx.GlobalString(common.PrefixFlag(flagPrefix, CACertFileFlagName)),
+		InsecureSkipVerify: ctx.GlobalBool(common.PrefixFlag(flagPrefix, SkipVerifyFlagName)),
 	}
 }
@@ -0,0 +1,96 @@
+package aws
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+)
+
+// LoadConfig loads the AWS SDK config of the clients for the given client config. Requests are sent to the endpoint URL
+// when it is set, using its scheme and host as given, and over TLS with the configured CA and verification settings.
+func LoadConfig(ctx context.Context, cfg ClientConfig) (aws.Config, error) {
+	if cfg.EndpointURL != "" {
+		if err := validateEndpointURL(cfg.EndpointURL); err != nil {
+			return aws.Config{}, err
+		}
+	}
+
+	customResolver := aws.EndpointResolverWithOptionsFunc(func(service, region string, options ...interface{}) (aws.Endpoint, error) {
+		if cfg.EndpointURL != "" {
+			return aws.Endpoint{
+				PartitionID:       "aws",
+				URL:               cfg.EndpointURL,
+				SigningRegion:     cfg.Region,
+				HostnameImmutable: true,
+			}, nil
+		}
+
+		// returning EndpointNotFoundError will allow the service to fallback to its default resolution
+		return aws.Endpoint{}, &aws.EndpointNotFoundError{}
+	})
+
+	options := [](func(*config.LoadOptions) error){
+		config.WithRegion(cfg.Region),
+		config.WithEndpointResolverWithOptions(customResolver),
+		config.WithRetryMode(aws.RetryModeStandard),
+	}
+	// If access key and secret access key are not provided, use the default credential provider
+	if len(cfg.AccessKey) > 0 && len(cfg.SecretAccessKey) > 0 {
+		options = append(options, config.WithCredentialsProvider(credentials.NewStaticCredentialsProvider(cfg.AccessKey, cfg.SecretAccessKey, "")))
+	}
+	if cfg.CACertFile != "" || cfg.InsecureSkipVerify {
+		tlsConfig, err := cfg.tlsConfig()
+		if err != nil {
+			return aws.Config{}, err
+		}
+		httpClient := awshttp.NewBuildableClient().WithTransportOptions(func(tr *http.Transport) {
+			tr.TLSClientConfig = tlsConfig
+		})
+		options = append(options, config.WithHTTPClient(httpClient))
+	}
+
+	return config.LoadDefaultConfig(ctx, options...)
+}
+
+func validateEndpointURL(endpointURL string) error {
+	u, err := url.Parse(endpointURL)
+	if err != nil {
+		return fmt.Errorf("invalid AWS endpoint URL %q: %w", endpointURL, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("invalid AWS endpoint URL %q: scheme must be http or https", endpointURL)
+	}
+	if u.Host == "" {
+		return fmt.Errorf("invalid AWS endpoint URL %q: missing host", endpointURL)
+	}
+	return nil
+}
+
+func (cfg ClientConfig) tlsConfig() (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: cfg.InsecureSkipVerify,
+	}
+	if cfg.CACertFile != "" {
+		pem, err := os.ReadFile(cfg.CACertFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the AWS CA certificate: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, errors.New("failed to parse the AWS CA certificate")
+		}
+		tlsConfig.RootCAs = pool
+	}
+	return tlsConfig, nil
+}
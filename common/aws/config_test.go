@@ -0,0 +1,69 @@
+package aws_test
+
+import (
+	"context"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	commonaws "github.com/Layr-Labs/eigenda/common/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTLSEndpoint(t *testing.T) *httptest.Server {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/x-amz-json-1.0")
+		_, _ = w.Write([]byte(`{"TableNames":[]}`))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func listTables(cfg commonaws.ClientConfig) error {
+	awsConfig, err := commonaws.LoadConfig(context.Background(), cfg)
+	if err != nil {
+		return err
+	}
+	awsConfig.RetryMaxAttempts = 1
+	_, err = dynamodb.NewFromConfig(awsConfig).ListTables(context.Background(), &dynamodb.ListTablesInput{})
+	return err
+}
+
+func TestLoadConfigTLS(t *testing.T) {
+	server := newTLSEndpoint(t)
+	cfg := commonaws.ClientConfig{
+		Region:          "us-east-1",
+		AccessKey:       "localstack",
+		SecretAccessKey: "localstack",
+		EndpointURL:     server.URL,
+	}
+
+	// The self-signed certificate of the endpoint isn't trusted by default
+	assert.Error(t, listTables(cfg))
+
+	insecure := cfg
+	insecure.InsecureSkipVerify = true
+	assert.NoError(t, listTables(insecure))
+
+	caCertFile := filepath.Join(t.TempDir(), "ca.pem")
+	caCert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	require.NoError(t, os.WriteFile(caCertFile, caCert, 0644))
+	withCA := cfg
+	withCA.CACertFile = caCertFile
+	assert.NoError(t, listTables(withCA))
+}
+
+func TestLoadConfigEndpointURL(t *testing.T) {
+	for _, endpoint := range []string{"0.0.0.0:4566", "ftp://0.0.0.0:4566", "http://"} {
+		_, err := commonaws.LoadConfig(context.Background(), commonaws.ClientConfig{Region: "us-east-1", EndpointURL: endpoint})
+		assert.Error(t, err, endpoint)
+	}
+
+	_, err := commonaws.LoadConfig(context.Background(), commonaws.ClientConfig{Region: "us-east-1", EndpointURL: "https://localstack.example.com:4566"})
+	assert.NoError(t, err)
+}
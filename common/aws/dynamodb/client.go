@@ -2,6 +2,7 @@ package dynamodb
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math"
 	"sync"
@@ -9,8 +10,6 @@ import (
 	"github.com/Layr-Labs/eigenda/common"
 	commonaws "github.com/Layr-Labs/eigenda/common/aws"
 	"github.com/aws/aws-sdk-go-v2/aws"
-	"github.com/aws/aws-sdk-go-v2/config"
-	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/expression"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
@@ -31,6 +30,9 @@ const (
 var (
 	once      sync.Once
 	clientRef *Client
+
+	// ErrConditionFailed is returned when the condition of a conditional write does not hold
+	ErrConditionFailed = errors.New("condition failed")
 )
 
 type Item = map[string]types.AttributeValue
@@ -45,31 +47,7 @@ type Client struct {
 func NewClient(cfg commonaws.ClientConfig, logger common.Logger) (*Client, error) {
 	var err error
 	once.Do(func() {
-		createClient := func(service, region string, options ...interface{}) (aws.Endpoint, error) {
-			if cfg.EndpointURL != "" {
-				return aws.Endpoint{
-					PartitionID:   "aws",
-					URL:           cfg.EndpointURL,
-					SigningRegion: cfg.Region,
-				}, nil
-			}
-
-			// returning EndpointNotFoundError will allow the service to fallback to its default resolution
-			return aws.Endpoint{}, &aws.EndpointNotFoundError{}
-		}
-		customResolver := aws.EndpointResolverWithOptionsFunc(createClient)
-
-		options := [](func(*config.LoadOptions) error){
-			config.WithRegion(cfg.Region),
-			config.WithEndpointResolverWithOptions(customResolver),
-			config.WithRetryMode(aws.RetryModeStandard),
-		}
-		// If access key and secret access key are not provided, use the default credential provider
-		if len(cfg.AccessKey) > 0 && len(cfg.SecretAccessKey) > 0 {
-			options = append(options, config.WithCredentialsProvider(credentials.NewStaticCredentialsProvider(cfg.AccessKey, cfg.SecretAccessKey, "")))
-		}
-		awsConfig, errCfg := config.LoadDefaultConfig(context.Background(), options...)
-
+		awsConfig, errCfg := commonaws.LoadConfig(context.Background(), cfg)
 		if errCfg != nil {
 			err = errCfg
 			return
@@ -100,6 +78,28 @@ func (c *Client) PutItem(ctx context.Context, tableName string, item Item) (err
 	return nil
 }
 
+// PutItemWithCondition puts the item only if the condition holds for the stored item with the same key, if any. It
+// returns ErrConditionFailed if the condition does not hold.
+func (c *Client) PutItemWithCondition(ctx context.Context, tableName string, item Item, condition expression.ConditionBuilder) error {
+	expr, err := expression.NewBuilder().WithCondition(condition).Build()
+	if err != nil {
+		return err
+	}
+
+	_, err = c.dynamoClient.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName:                 aws.String(tableName),
+		Item:                      item,
+		ConditionExpression:       expr.Condition(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+	})
+	var conditionErr *types.ConditionalCheckFailedException
+	if errors.As(err, &conditionErr) {
+		return ErrConditionFailed
+	}
+	return err
+}
+
 // PutItems puts items in batches of 25 items (which is a limit DynamoDB imposes)
 // It returns the items that failed to be put.
 func (c *Client) PutItems(ctx context.Context, tableName string, items []Item) ([]Item, error) {
@@ -107,6 +107,22 @@ func (c *Client) PutItems(ctx context.Context, tableName string, items []Item) (
 }
 
 func (c *Client) UpdateItem(ctx context.Context, tableName string, key Key, item Item) (Item, error) {
+	return c.updateItem(ctx, tableName, key, item, nil, types.ReturnValueUpdatedNew)
+}
+
+// UpdateItemReturningOld updates the item like UpdateItem, but returns all the attributes of the item as they were
+// before the update, or nil if the item didn't exist
+func (c *Client) UpdateItemReturningOld(ctx context.Context, tableName string, key Key, item Item) (Item, error) {
+	return c.updateItem(ctx, tableName, key, item, nil, types.ReturnValueAllOld)
+}
+
+// UpdateItemWithCondition updates the item only if the condition holds for the stored item. It returns
+// ErrConditionFailed if the condition does not hold, or the item does not exist.
+func (c *Client) UpdateItemWithCondition(ctx context.Context, tableName string, key Key, item Item, condition expression.ConditionBuilder) (Item, error) {
+	return c.updateItem(ctx, tableName, key, item, &condition, types.ReturnValueUpdatedNew)
+}
+
+func (c *Client) updateItem(ctx context.Context, tableName string, key Key, item Item, condition *expression.ConditionBuilder, returnValues types.ReturnValue) (Item, error) {
 	update := expression.UpdateBuilder{}
 	for itemKey, itemValue := range item {
 		if _, ok := key[itemKey]; ok {
@@ -116,7 +132,11 @@ func (c *Client) UpdateItem(ctx context.Context, tableName string, key Key, item
 		update = update.Set(expression.Name(itemKey), expression.Value(itemValue))
 	}
 
-	expr, err := expression.NewBuilder().WithUpdate(update).Build()
+	builder := expression.NewBuilder().WithUpdate(update)
+	if condition != nil {
+		builder = builder.WithCondition(*condition)
+	}
+	expr, err := builder.Build()
 	if err != nil {
 		return nil, err
 	}
@@ -127,9 +147,14 @@ func (c *Client) UpdateItem(ctx context.Context, tableName string, key Key, item
 		ExpressionAttributeNames:  expr.Names(),
 		ExpressionAttributeValues: expr.Values(),
 		UpdateExpression:          expr.Update(),
-		ReturnValues:              types.ReturnValueUpdatedNew,
+		ConditionExpression:       expr.Condition(),
+		ReturnValues:              returnValues,
 	})
 
+	var conditionErr *types.ConditionalCheckFailedException
+	if errors.As(err, &conditionErr) {
+		return nil, ErrConditionFailed
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -137,6 +162,53 @@ func (c *Client) UpdateItem(ctx context.Context, tableName string, key Key, item
 	return resp.Attributes, err
 }
 
+// UpdateItemWithExpression applies the update expression to the item only if the condition holds for the stored item,
+// e.g. to remove attributes, which UpdateItem can't. It returns ErrConditionFailed if the condition does not hold.
+func (c *Client) UpdateItemWithExpression(ctx context.Context, tableName string, key Key, update expression.UpdateBuilder, condition expression.ConditionBuilder) error {
+	expr, err := expression.NewBuilder().WithUpdate(update).WithCondition(condition).Build()
+	if err != nil {
+		return err
+	}
+
+	_, err = c.dynamoClient.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName:                 aws.String(tableName),
+		Key:                       key,
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		UpdateExpression:          expr.Update(),
+		ConditionExpression:       expr.Condition(),
+	})
+	var conditionErr *types.ConditionalCheckFailedException
+	if errors.As(err, &conditionErr) {
+		return ErrConditionFailed
+	}
+	return err
+}
+
+// IncrementBy atomically adds the value to the numeric attribute of the item, creating the item if it does not exist,
+// and returns the updated attributes
+func (c *Client) IncrementBy(ctx context.Context, tableName string, key Key, attr string, value uint64) (Item, error) {
+	update := expression.Add(expression.Name(attr), expression.Value(value))
+	expr, err := expression.NewBuilder().WithUpdate(update).Build()
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.dynamoClient.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName:                 aws.String(tableName),
+		Key:                       key,
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		UpdateExpression:          expr.Update(),
+		ReturnValues:              types.ReturnValueUpdatedNew,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Attributes, nil
+}
+
 func (c *Client) GetItem(ctx context.Context, tableName string, key Key) (Item, error) {
 	resp, err := c.dynamoClient.GetItem(ctx, &dynamodb.GetItemInput{Key: key, TableName: aws.String(tableName)})
 	if err != nil {
@@ -146,6 +218,41 @@ func (c *Client) GetItem(ctx context.Context, tableName string, key Key) (Item,
 	return resp.Item, nil
 }
 
+// Query returns the items of the table matching the key condition
+func (c *Client) Query(ctx context.Context, tableName string, keyCondition string, expAttributeValues ExpresseionValues) ([]Item, error) {
+	response, err := c.dynamoClient.Query(ctx, &dynamodb.QueryInput{
+		TableName:                 aws.String(tableName),
+		KeyConditionExpression:    aws.String(keyCondition),
+		ExpressionAttributeValues: expAttributeValues,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return response.Items, nil
+}
+
+// QueryWithPagination returns a page of the items of the table matching the key condition, starting after
+// exclusiveStartKey, or from the first item if it is nil. A limit of 0 returns as many items as a single query does.
+// The returned key is to be passed as exclusiveStartKey to get the next page, and is nil if there are no more items.
+func (c *Client) QueryWithPagination(ctx context.Context, tableName string, keyCondition string, expAttributeValues ExpresseionValues, limit int32, exclusiveStartKey Key) ([]Item, Key, error) {
+	input := &dynamodb.QueryInput{
+		TableName:                 aws.String(tableName),
+		KeyConditionExpression:    aws.String(keyCondition),
+		ExpressionAttributeValues: expAttributeValues,
+		ExclusiveStartKey:         exclusiveStartKey,
+	}
+	if limit > 0 {
+		input.Limit = aws.Int32(limit)
+	}
+	response, err := c.dynamoClient.Query(ctx, input)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return response.Items, response.LastEvaluatedKey, nil
+}
+
 // QueryIndex returns all items in the index that match the given key
 func (c *Client) QueryIndex(ctx context.Context, tableName string, indexName string, keyCondition string, expAttributeValues ExpresseionValues) ([]Item, error) {
 	response, err := c.dynamoClient.Query(ctx, &dynamodb.QueryInput{
@@ -161,6 +268,62 @@ func (c *Client) QueryIndex(ctx context.Context, tableName string, indexName str
 	return response.Items, nil
 }
 
+// QueryIndexWithPagination returns a page of at most limit items of the index that match the given key, starting after
+// exclusiveStartKey, or from the first item if it is nil. A limit of 0 returns as many items as a single query does.
+// The returned key is the key of the last item of the page, to be passed as exclusiveStartKey to get the next page,
+// and is nil if there are no more items.
+func (c *Client) QueryIndexWithPagination(ctx context.Context, tableName string, indexName string, keyCondition string, expAttributeValues ExpresseionValues, limit int32, exclusiveStartKey Key) ([]Item, Key, error) {
+	return c.QueryIndexWithFilterAndPagination(ctx, tableName, indexName, keyCondition, "", nil, expAttributeValues, limit, exclusiveStartKey)
+}
+
+// QueryIndexWithFilterAndPagination is QueryIndexWithPagination with the items that match the key further filtered by
+// filterCondition, unless it is empty. expAttributeNames are the substitutions of the attribute names of the filter,
+// for the names which aren't valid in an expression.
+// The filter is applied by DynamoDB after reading the page of matching keys, so the limit bounds the items read rather
+// than the items returned: a page can have fewer than limit items, or none at all, while the returned key is not nil.
+// The items left out by the filter still consume read capacity.
+func (c *Client) QueryIndexWithFilterAndPagination(ctx context.Context, tableName string, indexName string, keyCondition string, filterCondition string, expAttributeNames map[string]string, expAttributeValues ExpresseionValues, limit int32, exclusiveStartKey Key) ([]Item, Key, error) {
+	input := &dynamodb.QueryInput{
+		TableName:                 aws.String(tableName),
+		IndexName:                 aws.String(indexName),
+		KeyConditionExpression:    aws.String(keyCondition),
+		ExpressionAttributeNames:  expAttributeNames,
+		ExpressionAttributeValues: expAttributeValues,
+		ExclusiveStartKey:         exclusiveStartKey,
+	}
+	if filterCondition != "" {
+		input.FilterExpression = aws.String(filterCondition)
+	}
+	if limit > 0 {
+		input.Limit = aws.Int32(limit)
+	}
+	response, err := c.dynamoClient.Query(ctx, input)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return response.Items, response.LastEvaluatedKey, nil
+}
+
+// ScanWithPagination reads a page of up to limit items of the table, starting after exclusiveStartKey, or from the
+// first item if it is nil. A limit of 0 returns as many items as a single scan does. The returned key is to be passed
+// as exclusiveStartKey to read the next page, and is nil once all the items were read.
+func (c *Client) ScanWithPagination(ctx context.Context, tableName string, limit int32, exclusiveStartKey Key) ([]Item, Key, error) {
+	input := &dynamodb.ScanInput{
+		TableName:         aws.String(tableName),
+		ExclusiveStartKey: exclusiveStartKey,
+	}
+	if limit > 0 {
+		input.Limit = aws.Int32(limit)
+	}
+	response, err := c.dynamoClient.Scan(ctx, input)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return response.Items, response.LastEvaluatedKey, nil
+}
+
 func (c *Client) DeleteItem(ctx context.Context, tableName string, key Key) error {
 	_, err := c.dynamoClient.DeleteItem(ctx, &dynamodb.DeleteItemInput{Key: key, TableName: aws.String(tableName)})
 	if err != nil {
@@ -211,7 +374,11 @@ func (c *Client) writeItems(ctx context.Context, tableName string, requestItems
 		// check for unprocessed items
 		if len(output.UnprocessedItems) > 0 {
 			for _, req := range output.UnprocessedItems[tableName] {
-				failedItems = append(failedItems, req.DeleteRequest.Key)
+				if req.PutRequest != nil {
+					failedItems = append(failedItems, req.PutRequest.Item)
+				} else {
+					failedItems = append(failedItems, req.DeleteRequest.Key)
+				}
 			}
 		}
 
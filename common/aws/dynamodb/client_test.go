@@ -12,6 +12,7 @@ import (
 	"github.com/Layr-Labs/eigenda/common/logging"
 	"github.com/Layr-Labs/eigenda/inabox/deploy"
 	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/expression"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
 	"github.com/ory/dockertest/v3"
@@ -57,12 +58,7 @@ func setup(m *testing.M) {
 		panic("failed to get logger")
 	}
 
-	clientConfig = commonaws.ClientConfig{
-		Region:          "us-east-1",
-		AccessKey:       "localstack",
-		SecretAccessKey: "localstack",
-		EndpointURL:     fmt.Sprintf("http://0.0.0.0:%s", localStackPort),
-	}
+	clientConfig = deploy.LocalstackClientConfig(localStackPort)
 	dynamoClient, err = commondynamodb.NewClient(clientConfig, logger)
 	if err != nil {
 		teardown()
@@ -176,6 +172,25 @@ func TestBasicOperations(t *testing.T) {
 	assert.Equal(t, "0x123", item["BatchHeaderHash"].(*types.AttributeValueMemberS).Value)
 	assert.Equal(t, "0", item["BlobIndex"].(*types.AttributeValueMemberN).Value)
 
+	// Conditional updates only apply when the condition holds
+	_, err = dynamoClient.UpdateItemWithCondition(ctx, tableName, commondynamodb.Key{
+		"MetadataKey": &types.AttributeValueMemberS{Value: "key"},
+	}, commondynamodb.Item{
+		"BlobIndex": &types.AttributeValueMemberN{Value: "1"},
+	}, expression.Name("Status").Equal(expression.Value("Processing")))
+	assert.ErrorIs(t, err, commondynamodb.ErrConditionFailed)
+	_, err = dynamoClient.UpdateItemWithCondition(ctx, tableName, commondynamodb.Key{
+		"MetadataKey": &types.AttributeValueMemberS{Value: "key"},
+	}, commondynamodb.Item{
+		"BlobIndex": &types.AttributeValueMemberN{Value: "2"},
+	}, expression.Name("Status").Equal(expression.Value("Confirmed")))
+	assert.NoError(t, err)
+	item, err = dynamoClient.GetItem(ctx, tableName, commondynamodb.Key{
+		"MetadataKey": &types.AttributeValueMemberS{Value: "key"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "2", item["BlobIndex"].(*types.AttributeValueMemberN).Value)
+
 	err = dynamoClient.DeleteTable(ctx, tableName)
 	assert.NoError(t, err)
 }
@@ -6,8 +6,6 @@ import (
 
 	commonaws "github.com/Layr-Labs/eigenda/common/aws"
 	"github.com/aws/aws-sdk-go-v2/aws"
-	"github.com/aws/aws-sdk-go-v2/config"
-	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
 )
@@ -39,28 +37,9 @@ func CreateTable(ctx context.Context, cfg commonaws.ClientConfig, name string, i
 }
 
 func getClient(clientConfig commonaws.ClientConfig) (*dynamodb.Client, error) {
-	createClient := func(service, region string, options ...interface{}) (aws.Endpoint, error) {
-		if clientConfig.EndpointURL != "" {
-			return aws.Endpoint{
-				PartitionID:   "aws",
-				URL:           clientConfig.EndpointURL,
-				SigningRegion: clientConfig.Region,
-			}, nil
-		}
-
-		// returning EndpointNotFoundError will allow the service to fallback to its default resolution
-		return aws.Endpoint{}, &aws.EndpointNotFoundError{}
-	}
-	customResolver := aws.EndpointResolverWithOptionsFunc(createClient)
-
-	cfg, errCfg := config.LoadDefaultConfig(context.Background(),
-		config.WithRegion(clientConfig.Region),
-		config.WithCredentialsProvider(credentials.NewStaticCredentialsProvider(clientConfig.AccessKey, clientConfig.SecretAccessKey, "")),
-		config.WithEndpointResolverWithOptions(customResolver),
-		config.WithRetryMode(aws.RetryModeStandard),
-	)
-	if errCfg != nil {
-		return nil, errCfg
+	cfg, err := commonaws.LoadConfig(context.Background(), clientConfig)
+	if err != nil {
+		return nil, err
 	}
 	return dynamodb.NewFromConfig(cfg), nil
 }
@@ -5,14 +5,14 @@ import (
 	"context"
 	"errors"
 	"sync"
+	"time"
 
 	"github.com/Layr-Labs/eigenda/common"
 	commonaws "github.com/Layr-Labs/eigenda/common/aws"
 	"github.com/aws/aws-sdk-go-v2/aws"
-	"github.com/aws/aws-sdk-go-v2/config"
-	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/aws/aws-sdk-go-v2/feature/s3/manager"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
 )
 
 var (
@@ -24,6 +24,8 @@ var (
 type Object struct {
 	Key  string
 	Size int64
+	// LastModified is the time the object was last written
+	LastModified time.Time
 }
 
 type client struct {
@@ -36,42 +38,29 @@ var _ Client = (*client)(nil)
 func NewClient(ctx context.Context, cfg commonaws.ClientConfig, logger common.Logger) (*client, error) {
 	var err error
 	once.Do(func() {
-		customResolver := aws.EndpointResolverWithOptionsFunc(func(service, region string, options ...interface{}) (aws.Endpoint, error) {
-			if cfg.EndpointURL != "" {
-				return aws.Endpoint{
-					PartitionID:   "aws",
-					URL:           cfg.EndpointURL,
-					SigningRegion: cfg.Region,
-				}, nil
-			}
-
-			// returning EndpointNotFoundError will allow the service to fallback to its default resolution
-			return aws.Endpoint{}, &aws.EndpointNotFoundError{}
-		})
-
-		options := [](func(*config.LoadOptions) error){
-			config.WithRegion(cfg.Region),
-			config.WithEndpointResolverWithOptions(customResolver),
-			config.WithRetryMode(aws.RetryModeStandard),
-		}
-		// If access key and secret access key are not provided, use the default credential provider
-		if len(cfg.AccessKey) > 0 && len(cfg.SecretAccessKey) > 0 {
-			options = append(options, config.WithCredentialsProvider(credentials.NewStaticCredentialsProvider(cfg.AccessKey, cfg.SecretAccessKey, "")))
-		}
-		awsConfig, errCfg := config.LoadDefaultConfig(context.Background(), options...)
-
-		if errCfg != nil {
-			err = errCfg
-			return
-		}
-		s3Client := s3.NewFromConfig(awsConfig, func(o *s3.Options) {
-			o.UsePathStyle = true
-		})
-		ref = &client{s3Client: s3Client, logger: logger}
+		ref, err = newClient(ctx, cfg, logger)
 	})
 	return ref, err
 }
 
+// NewRegionalClient creates a client of the given region, e.g. to read the replicas of a bucket in other regions than
+// the configured one. Unlike the client returned by NewClient, it isn't shared.
+func NewRegionalClient(ctx context.Context, cfg commonaws.ClientConfig, region string, logger common.Logger) (*client, error) {
+	cfg.Region = region
+	return newClient(ctx, cfg, logger)
+}
+
+func newClient(ctx context.Context, cfg commonaws.ClientConfig, logger common.Logger) (*client, error) {
+	awsConfig, err := commonaws.LoadConfig(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	s3Client := s3.NewFromConfig(awsConfig, func(o *s3.Options) {
+		o.UsePathStyle = true
+	})
+	return &client{s3Client: s3Client, logger: logger}, nil
+}
+
 func (s *client) DownloadObject(ctx context.Context, bucket string, key string) ([]byte, error) {
 	var partMiBs int64 = 10
 	downloader := manager.NewDownloader(s.s3Client, func(d *manager.Downloader) {
@@ -84,6 +73,10 @@ func (s *client) DownloadObject(ctx context.Context, bucket string, key string)
 		Bucket: aws.String(bucket),
 		Key:    aws.String(key),
 	})
+	var noSuchKey *types.NoSuchKey
+	if errors.As(err, &noSuchKey) {
+		return nil, ErrObjectNotFound
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -96,17 +89,30 @@ func (s *client) DownloadObject(ctx context.Context, bucket string, key string)
 }
 
 func (s *client) UploadObject(ctx context.Context, bucket string, key string, data []byte) error {
+	return s.upload(ctx, &s3.PutObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+		Body:   bytes.NewReader(data),
+	})
+}
+
+func (s *client) UploadObjectWithContentType(ctx context.Context, bucket string, key string, data []byte, contentType string) error {
+	return s.upload(ctx, &s3.PutObjectInput{
+		Bucket:      aws.String(bucket),
+		Key:         aws.String(key),
+		Body:        bytes.NewReader(data),
+		ContentType: aws.String(contentType),
+	})
+}
+
+func (s *client) upload(ctx context.Context, input *s3.PutObjectInput) error {
 	var partMiBs int64 = 10
 	uploader := manager.NewUploader(s.s3Client, func(u *manager.Uploader) {
 		u.PartSize = partMiBs * 1024 * 1024 // 10MB per part
 		u.Concurrency = 3                   //The number of goroutines to spin up in parallel per call to Upload when sending parts
 	})
 
-	_, err := uploader.Upload(ctx, &s3.PutObjectInput{
-		Bucket: aws.String(bucket),
-		Key:    aws.String(key),
-		Body:   bytes.NewReader(data),
-	})
+	_, err := uploader.Upload(ctx, input)
 	if err != nil {
 		return err
 	}
@@ -138,8 +144,9 @@ func (s *client) ListObjects(ctx context.Context, bucket string, prefix string)
 	objects := make([]Object, 0, len(output.Contents))
 	for _, object := range output.Contents {
 		objects = append(objects, Object{
-			Key:  *object.Key,
-			Size: object.Size,
+			Key:          *object.Key,
+			Size:         object.Size,
+			LastModified: aws.ToTime(object.LastModified),
 		})
 	}
 	return objects, nil
@@ -5,6 +5,15 @@ import "context"
 type Client interface {
 	DownloadObject(ctx context.Context, bucket string, key string) ([]byte, error)
 	UploadObject(ctx context.Context, bucket string, key string, data []byte) error
+	// UploadObjectWithContentType uploads an object like UploadObject, setting the Content-Type of the object
+	UploadObjectWithContentType(ctx context.Context, bucket string, key string, data []byte, contentType string) error
 	DeleteObject(ctx context.Context, bucket string, key string) error
 	ListObjects(ctx context.Context, bucket string, prefix string) ([]Object, error)
 }
+
+// Endpoint is a bucket of a region, accessed through the client of the region
+type Endpoint struct {
+	Region string
+	Bucket string
+	Client Client
+}
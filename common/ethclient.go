@@ -39,6 +39,11 @@ type EthClient interface {
 	TransactionInBlock(ctx context.Context, blockHash common.Hash, index uint) (*types.Transaction, error)
 	TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error)
 	EstimateGasPriceAndLimitAndSendTx(ctx context.Context, tx *types.Transaction, tag string, value *big.Int) (*types.Receipt, error)
+	// EstimateGasPriceAndLimitAndReplaceTx is EstimateGasPriceAndLimitAndSendTx sending the txn with the nonce of the
+	// replaced txn and fees bumped over its fees, so that it replaces the replaced txn if still pending. A nil replaced
+	// txn sends the txn as EstimateGasPriceAndLimitAndSendTx does. The txn sent is returned along with its receipt,
+	// also when waiting for the receipt fails.
+	EstimateGasPriceAndLimitAndReplaceTx(ctx context.Context, tx *types.Transaction, replaced *types.Transaction, tag string, value *big.Int) (*types.Receipt, *types.Transaction, error)
 	UpdateGas(ctx context.Context, tx *types.Transaction, value *big.Int) (*types.Transaction, error)
 	EnsureTransactionEvaled(ctx context.Context, tx *types.Transaction, tag string) (*types.Receipt, error)
 }
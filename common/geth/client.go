@@ -17,6 +17,10 @@ import (
 	"github.com/ethereum/go-ethereum/ethclient"
 )
 
+// ReplacementFeeBumpPercent is the percentage by which the fees of a replacement txn are bumped at least over the fees
+// of the txn it replaces. Nodes only accept replacements bumping both the tip and the fee caps by at least 10%.
+const ReplacementFeeBumpPercent = 15
+
 var (
 	FallbackGasTipCap       = big.NewInt(15000000000)
 	ErrCannotGetECDSAPubKey = errors.New("ErrCannotGetECDSAPubKey")
@@ -109,6 +113,16 @@ func (c *EthClient) UpdateGas(
 	ctx context.Context,
 	tx *types.Transaction,
 	value *big.Int,
+) (*types.Transaction, error) {
+	return c.updateGas(ctx, tx, nil, value)
+}
+
+// updateGas is UpdateGas, taking the nonce of the replaced txn and bumping the fees over its fees if it isn't nil
+func (c *EthClient) updateGas(
+	ctx context.Context,
+	tx *types.Transaction,
+	replaced *types.Transaction,
+	value *big.Int,
 ) (*types.Transaction, error) {
 	gasTipCap, err := c.SuggestGasTipCap(ctx)
 	if err != nil {
@@ -135,6 +149,11 @@ func (c *EthClient) UpdateGas(
 		return nil, err
 	}
 	gasFeeCap := getGasFeeCap(gasTipCap, header.BaseFee)
+	nonce := tx.Nonce()
+	if replaced != nil {
+		gasTipCap, gasFeeCap = bumpFees(gasTipCap, gasFeeCap, replaced)
+		nonce = replaced.Nonce()
+	}
 
 	// The estimated gas limits performed by RawTransact fail semi-regularly
 	// with out of gas exceptions. To remedy this we extract the internal calls
@@ -156,7 +175,7 @@ func (c *EthClient) UpdateGas(
 	opts := new(bind.TransactOpts)
 	*opts = *c.NoSendTransactOpts
 	opts.Context = ctx
-	opts.Nonce = new(big.Int).SetUint64(tx.Nonce())
+	opts.Nonce = new(big.Int).SetUint64(nonce)
 	opts.GasTipCap = gasTipCap
 	opts.GasFeeCap = gasFeeCap
 	opts.GasLimit = addGasBuffer(gasLimit)
@@ -185,13 +204,29 @@ func (c *EthClient) EstimateGasPriceAndLimitAndSendTx(
 	tag string,
 	value *big.Int,
 ) (*types.Receipt, error) {
-	tx, err := c.UpdateGas(ctx, tx, value)
+	receipt, _, err := c.EstimateGasPriceAndLimitAndReplaceTx(ctx, tx, nil, tag, value)
+	return receipt, err
+}
+
+// EstimateGasPriceAndLimitAndReplaceTx is EstimateGasPriceAndLimitAndSendTx, sending the txn with the nonce of the
+// replaced txn and with fees bumped by at least ReplacementFeeBumpPercent over its fees, so that the txn replaces it
+// if it is still pending. The txn sent is returned along with its receipt, also when waiting for the receipt fails.
+//
+// Note: tx must be a to a contract, not an EOA
+func (c *EthClient) EstimateGasPriceAndLimitAndReplaceTx(
+	ctx context.Context,
+	tx *types.Transaction,
+	replaced *types.Transaction,
+	tag string,
+	value *big.Int,
+) (*types.Receipt, *types.Transaction, error) {
+	tx, err := c.updateGas(ctx, tx, replaced, value)
 	if err != nil {
-		return nil, fmt.Errorf("EstimateGasPriceAndLimitAndSendTx: failed to update gas for txn (%s): %w", tag, err)
+		return nil, nil, fmt.Errorf("EstimateGasPriceAndLimitAndReplaceTx: failed to update gas for txn (%s): %w", tag, err)
 	}
 	err = c.SendTransaction(ctx, tx)
 	if err != nil {
-		return nil, fmt.Errorf("EstimateGasPriceAndLimitAndSendTx: failed to send txn (%s): %w", tag, err)
+		return nil, nil, fmt.Errorf("EstimateGasPriceAndLimitAndReplaceTx: failed to send txn (%s): %w", tag, err)
 	}
 
 	receipt, err := c.EnsureTransactionEvaled(
@@ -200,10 +235,10 @@ func (c *EthClient) EstimateGasPriceAndLimitAndSendTx(
 		tag,
 	)
 	if err != nil {
-		return nil, err
+		return nil, tx, err
 	}
 
-	return receipt, err
+	return receipt, tx, err
 }
 
 func (c *EthClient) EnsureTransactionEvaled(ctx context.Context, tx *types.Transaction, tag string) (*types.Receipt, error) {
@@ -226,6 +261,28 @@ func getGasFeeCap(gasTipCap *big.Int, baseFee *big.Int) *big.Int {
 	return new(big.Int).Add(new(big.Int).Mul(baseFee, big.NewInt(2)), gasTipCap)
 }
 
+// bumpFees returns the gas tip and fee caps raised to at least ReplacementFeeBumpPercent over the ones of the
+// replaced txn
+func bumpFees(gasTipCap *big.Int, gasFeeCap *big.Int, replaced *types.Transaction) (*big.Int, *big.Int) {
+	if minTipCap := bumpFee(replaced.GasTipCap()); gasTipCap.Cmp(minTipCap) < 0 {
+		gasTipCap = minTipCap
+	}
+	if minFeeCap := bumpFee(replaced.GasFeeCap()); gasFeeCap.Cmp(minFeeCap) < 0 {
+		gasFeeCap = minFeeCap
+	}
+	if gasFeeCap.Cmp(gasTipCap) < 0 {
+		gasFeeCap = new(big.Int).Set(gasTipCap)
+	}
+	return gasTipCap, gasFeeCap
+}
+
+// bumpFee returns the fee increased by ReplacementFeeBumpPercent, rounded up
+func bumpFee(fee *big.Int) *big.Int {
+	bumped := new(big.Int).Mul(fee, big.NewInt(100+ReplacementFeeBumpPercent))
+	bumped.Add(bumped, big.NewInt(99))
+	return bumped.Quo(bumped, big.NewInt(100))
+}
+
 func addGasBuffer(gasLimit uint64) uint64 {
 	return 6 * gasLimit / 5 // add 20% buffer to gas limit
 }
@@ -470,26 +470,45 @@ func (iec *InstrumentedEthClient) TransactionSender(
 	return address, nil
 }
 
+// EstimateGasPriceAndLimitAndSendTx sends and returns an otherwise identical txn
+// to the one provided but with updated gas prices sampled from the existing network
+// conditions and an accurate gasLimit
+//
+// Note: tx must be a to a contract, not an EOA
+func (c *InstrumentedEthClient) EstimateGasPriceAndLimitAndSendTx(
+	ctx context.Context,
+	tx *types.Transaction,
+	tag string,
+	value *big.Int,
+) (*types.Receipt, error) {
+	receipt, _, err := c.EstimateGasPriceAndLimitAndReplaceTx(ctx, tx, nil, tag, value)
+	return receipt, err
+}
+
 // Copied from ethclient.go so make sure to change this implementation if the other one changes!
 // We need to do this because this method makes a bunch of internal eth_ calls so copying them
 // here forces them to use the instrumented versions instead of ethClient's non instrumented versions
 // eg: c.HeaderByNumber(ctx, nil) below calls the instrumented HeaderByNumber implemented in this file.
-// if we didn't overwrite EstimateGasPriceAndLimitAndSendTx it would be calling the non instrumented version
+// if we didn't overwrite EstimateGasPriceAndLimitAndReplaceTx it would be calling the non instrumented version
 // which would be equivalent to having all calls here be c.Client.HeaderByNumber instead of c.HeaderByNumber
 //
-// EstimateGasPriceAndLimitAndSendTx sends and returns an otherwise identical txn
+// EstimateGasPriceAndLimitAndReplaceTx sends and returns an otherwise identical txn
 // to the one provided but with updated gas prices sampled from the existing network
-// conditions and an accurate gasLimit
+// conditions and an accurate gasLimit. If the replaced txn isn't nil, the txn takes its
+// nonce and its fees are bumped by at least ReplacementFeeBumpPercent over its fees, so
+// that the txn replaces it if it is still pending. The txn sent is returned along with
+// its receipt, also when waiting for the receipt fails.
 //
 // Note: tx must be a to a contract, not an EOA
 //
 // Slightly modified from: https://github.com/ethereum-optimism/optimism/blob/ec266098641820c50c39c31048aa4e953bece464/batch-submitter/drivers/sequencer/driver.go#L314
-func (c *InstrumentedEthClient) EstimateGasPriceAndLimitAndSendTx(
+func (c *InstrumentedEthClient) EstimateGasPriceAndLimitAndReplaceTx(
 	ctx context.Context,
 	tx *types.Transaction,
+	replaced *types.Transaction,
 	tag string,
 	value *big.Int,
-) (*types.Receipt, error) {
+) (*types.Receipt, *types.Transaction, error) {
 	gasTipCap, err := c.SuggestGasTipCap(ctx)
 	if err != nil {
 		// If the transaction failed because the backend does not support
@@ -504,9 +523,14 @@ func (c *InstrumentedEthClient) EstimateGasPriceAndLimitAndSendTx(
 
 	header, err := c.HeaderByNumber(ctx, nil)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	gasFeeCap := new(big.Int).Add(header.BaseFee, gasTipCap)
+	nonce := tx.Nonce()
+	if replaced != nil {
+		gasTipCap, gasFeeCap = bumpFees(gasTipCap, gasFeeCap, replaced)
+		nonce = replaced.Nonce()
+	}
 
 	// The estimated gas limits performed by RawTransact fail semi-regularly
 	// with out of gas exceptions. To remedy this we extract the internal calls
@@ -522,15 +546,15 @@ func (c *InstrumentedEthClient) EstimateGasPriceAndLimitAndSendTx(
 	})
 
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	opts, err := bind.NewKeyedTransactorWithChainID(c.privateKey, tx.ChainId())
 	if err != nil {
-		return nil, fmt.Errorf("EstimateGasPriceAndLimitAndSendTx: cannot create transactOpts: %w", err)
+		return nil, nil, fmt.Errorf("EstimateGasPriceAndLimitAndReplaceTx: cannot create transactOpts: %w", err)
 	}
 	opts.Context = ctx
-	opts.Nonce = new(big.Int).SetUint64(tx.Nonce())
+	opts.Nonce = new(big.Int).SetUint64(nonce)
 	opts.GasTipCap = gasTipCap
 	opts.GasFeeCap = gasFeeCap
 	opts.GasLimit = addGasBuffer(gasLimit)
@@ -546,7 +570,7 @@ func (c *InstrumentedEthClient) EstimateGasPriceAndLimitAndSendTx(
 
 	tx, err = contract.RawTransact(opts, tx.Data())
 	if err != nil {
-		return nil, fmt.Errorf("EstimateGasPriceAndLimitAndSendTx: failed to send txn (%s): %w", tag, err)
+		return nil, nil, fmt.Errorf("EstimateGasPriceAndLimitAndReplaceTx: failed to send txn (%s): %w", tag, err)
 	}
 
 	receipt, err := c.EnsureTransactionEvaled(
@@ -555,10 +579,10 @@ func (c *InstrumentedEthClient) EstimateGasPriceAndLimitAndSendTx(
 		tag,
 	)
 	if err != nil {
-		return nil, err
+		return nil, tx, err
 	}
 
-	return receipt, err
+	return receipt, tx, err
 }
 
 // Generic function used to instrument all the eth calls that we make below
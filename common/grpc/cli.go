@@ -0,0 +1,138 @@
+package grpc
+
+import (
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/urfave/cli"
+)
+
+const (
+	TLSCertFileFlagName     = "grpc.tls-cert-file"
+	TLSKeyFileFlagName      = "grpc.tls-key-file"
+	TLSCertPEMFlagName      = "grpc.tls-cert-pem"
+	TLSKeyPEMFlagName       = "grpc.tls-key-pem"
+	TLSClientCAFileFlagName = "grpc.tls-client-ca-file"
+	TLSClientCAPEMFlagName  = "grpc.tls-client-ca-pem"
+	MaxMessageSizeFlagName  = "grpc.max-message-size"
+
+	ClientTLSEnabledFlagName  = "grpc.client-tls-enabled"
+	ClientTLSCAFileFlagName   = "grpc.client-tls-ca-file"
+	ClientTLSCAPEMFlagName    = "grpc.client-tls-ca-pem"
+	ClientTLSCertFileFlagName = "grpc.client-tls-cert-file"
+	ClientTLSKeyFileFlagName  = "grpc.client-tls-key-file"
+	ClientTLSCertPEMFlagName  = "grpc.client-tls-cert-pem"
+	ClientTLSKeyPEMFlagName   = "grpc.client-tls-key-pem"
+)
+
+func TLSCLIFlags(envPrefix string, flagPrefix string) []cli.Flag {
+	return []cli.Flag{
+		cli.StringFlag{
+			Name:   common.PrefixFlag(flagPrefix, TLSCertFileFlagName),
+			Usage:  "Path to the PEM encoded TLS certificate of the gRPC server. The certificate is reloaded when the file changes or on SIGHUP. TLS is disabled if no certificate is set",
+			EnvVar: common.PrefixEnvVar(envPrefix, "GRPC_TLS_CERT_FILE"),
+		},
+		cli.StringFlag{
+			Name:   common.PrefixFlag(flagPrefix, TLSKeyFileFlagName),
+			Usage:  "Path to the PEM encoded private key of the TLS certificate",
+			EnvVar: common.PrefixEnvVar(envPrefix, "GRPC_TLS_KEY_FILE"),
+		},
+		cli.StringFlag{
+			Name:   common.PrefixFlag(flagPrefix, TLSCertPEMFlagName),
+			Usage:  "PEM encoded TLS certificate of the gRPC server, as an alternative to the certificate file",
+			EnvVar: common.PrefixEnvVar(envPrefix, "GRPC_TLS_CERT_PEM"),
+		},
+		cli.StringFlag{
+			Name:   common.PrefixFlag(flagPrefix, TLSKeyPEMFlagName),
+			Usage:  "PEM encoded private key of the TLS certificate, as an alternative to the key file",
+			EnvVar: common.PrefixEnvVar(envPrefix, "GRPC_TLS_KEY_PEM"),
+		},
+		cli.StringFlag{
+			Name:   common.PrefixFlag(flagPrefix, TLSClientCAFileFlagName),
+			Usage:  "Path to the PEM encoded CA used to verify client certificates. Setting a client CA enables mutual TLS",
+			EnvVar: common.PrefixEnvVar(envPrefix, "GRPC_TLS_CLIENT_CA_FILE"),
+		},
+		cli.StringFlag{
+			Name:   common.PrefixFlag(flagPrefix, TLSClientCAPEMFlagName),
+			Usage:  "PEM encoded CA used to verify client certificates, as an alternative to the client CA file",
+			EnvVar: common.PrefixEnvVar(envPrefix, "GRPC_TLS_CLIENT_CA_PEM"),
+		},
+	}
+}
+
+func ReadTLSCLIConfig(ctx *cli.Context, flagPrefix string) TLSConfig {
+	return TLSConfig{
+		CertFile:     ctx.GlobalString(common.PrefixFlag(flagPrefix, TLSCertFileFlagName)),
+		KeyFile:      ctx.GlobalString(common.PrefixFlag(flagPrefix, TLSKeyFileFlagName)),
+		CertPEM:      []byte(ctx.GlobalString(common.PrefixFlag(flagPrefix, TLSCertPEMFlagName))),
+		KeyPEM:       []byte(ctx.GlobalString(common.PrefixFlag(flagPrefix, TLSKeyPEMFlagName))),
+		ClientCAFile: ctx.GlobalString(common.PrefixFlag(flagPrefix, TLSClientCAFileFlagName)),
+		ClientCAPEM:  []byte(ctx.GlobalString(common.PrefixFlag(flagPrefix, TLSClientCAPEMFlagName))),
+	}
+}
+
+// ClientTLSCLIFlags are the flags of the TLS of the gRPC clients of a binary, dialing the servers of the other components
+func ClientTLSCLIFlags(envPrefix string, flagPrefix string) []cli.Flag {
+	return []cli.Flag{
+		cli.BoolFlag{
+			Name:   common.PrefixFlag(flagPrefix, ClientTLSEnabledFlagName),
+			Usage:  "Dial the gRPC servers of the other components with TLS",
+			EnvVar: common.PrefixEnvVar(envPrefix, "GRPC_CLIENT_TLS_ENABLED"),
+		},
+		cli.StringFlag{
+			Name:   common.PrefixFlag(flagPrefix, ClientTLSCAFileFlagName),
+			Usage:  "Path to the PEM encoded CA used to verify the certificates of the servers. The system roots are used if no CA is set",
+			EnvVar: common.PrefixEnvVar(envPrefix, "GRPC_CLIENT_TLS_CA_FILE"),
+		},
+		cli.StringFlag{
+			Name:   common.PrefixFlag(flagPrefix, ClientTLSCAPEMFlagName),
+			Usage:  "PEM encoded CA used to verify the certificates of the servers, as an alternative to the CA file",
+			EnvVar: common.PrefixEnvVar(envPrefix, "GRPC_CLIENT_TLS_CA_PEM"),
+		},
+		cli.StringFlag{
+			Name:   common.PrefixFlag(flagPrefix, ClientTLSCertFileFlagName),
+			Usage:  "Path to the PEM encoded client certificate presented to the servers requiring mutual TLS",
+			EnvVar: common.PrefixEnvVar(envPrefix, "GRPC_CLIENT_TLS_CERT_FILE"),
+		},
+		cli.StringFlag{
+			Name:   common.PrefixFlag(flagPrefix, ClientTLSKeyFileFlagName),
+			Usage:  "Path to the PEM encoded private key of the client certificate",
+			EnvVar: common.PrefixEnvVar(envPrefix, "GRPC_CLIENT_TLS_KEY_FILE"),
+		},
+		cli.StringFlag{
+			Name:   common.PrefixFlag(flagPrefix, ClientTLSCertPEMFlagName),
+			Usage:  "PEM encoded client certificate, as an alternative to the client certificate file",
+			EnvVar: common.PrefixEnvVar(envPrefix, "GRPC_CLIENT_TLS_CERT_PEM"),
+		},
+		cli.StringFlag{
+			Name:   common.PrefixFlag(flagPrefix, ClientTLSKeyPEMFlagName),
+			Usage:  "PEM encoded private key of the client certificate, as an alternative to the key file",
+			EnvVar: common.PrefixEnvVar(envPrefix, "GRPC_CLIENT_TLS_KEY_PEM"),
+		},
+	}
+}
+
+func ReadClientTLSCLIConfig(ctx *cli.Context, flagPrefix string) ClientTLSConfig {
+	return ClientTLSConfig{
+		Enabled:  ctx.GlobalBool(common.PrefixFlag(flagPrefix, ClientTLSEnabledFlagName)),
+		CAFile:   ctx.GlobalString(common.PrefixFlag(flagPrefix, ClientTLSCAFileFlagName)),
+		CAPEM:    []byte(ctx.GlobalString(common.PrefixFlag(flagPrefix, ClientTLSCAPEMFlagName))),
+		CertFile: ctx.GlobalString(common.PrefixFlag(flagPrefix, ClientTLSCertFileFlagName)),
+		KeyFile:  ctx.GlobalString(common.PrefixFlag(flagPrefix, ClientTLSKeyFileFlagName)),
+		CertPEM:  []byte(ctx.GlobalString(common.PrefixFlag(flagPrefix, ClientTLSCertPEMFlagName))),
+		KeyPEM:   []byte(ctx.GlobalString(common.PrefixFlag(flagPrefix, ClientTLSKeyPEMFlagName))),
+	}
+}
+
+// MaxMessageSizeCLIFlag is the flag of the max size of the gRPC messages of a binary, with the default size of the
+// binary
+func MaxMessageSizeCLIFlag(envPrefix string, flagPrefix string, defaultSize int) cli.Flag {
+	return cli.IntFlag{
+		Name:   common.PrefixFlag(flagPrefix, MaxMessageSizeFlagName),
+		Usage:  "Max size in bytes of the gRPC messages sent and received by the servers of the binary and by its clients of the other components",
+		Value:  defaultSize,
+		EnvVar: common.PrefixEnvVar(envPrefix, "GRPC_MAX_MESSAGE_SIZE"),
+	}
+}
+
+func ReadMaxMessageSize(ctx *cli.Context, flagPrefix string) int {
+	return ctx.GlobalInt(common.PrefixFlag(flagPrefix, MaxMessageSizeFlagName))
+}
@@ -0,0 +1,108 @@
+package grpc
+
+import (
+	"io"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
+	"google.golang.org/grpc/encoding"
+	"google.golang.org/grpc/encoding/gzip"
+)
+
+// The compressors that the gRPC servers and clients of this process can negotiate per call, registered when this
+// package is imported. A client selects one with grpc.UseCompressor, and the server replies with the same one.
+const (
+	GzipCompressor = gzip.Name
+	ZstdCompressor = "zstd"
+)
+
+// zstdMaxWindowSize bounds the memory a zstd stream can make the decoder allocate. The encoders of this package use
+// smaller windows.
+const zstdMaxWindowSize = 8 << 20 // 8 MiB
+
+func init() {
+	encoding.RegisterCompressor(&zstdCompressor{})
+}
+
+// zstdCompressor is a gRPC compressor of the zstd format. The size of the decompressed messages is bounded by the
+// max receive message size of the gRPC server or client, which stops reading from the decompressor past it, and
+// messages declaring a larger size in their frame header are rejected before any decompression.
+type zstdCompressor struct {
+	encoders sync.Pool
+	decoders sync.Pool
+}
+
+var _ encoding.Compressor = (*zstdCompressor)(nil)
+
+func (c *zstdCompressor) Name() string {
+	return ZstdCompressor
+}
+
+func (c *zstdCompressor) Compress(w io.Writer) (io.WriteCloser, error) {
+	if e, ok := c.encoders.Get().(*zstdWriter); ok {
+		e.Reset(w)
+		return e, nil
+	}
+	e, err := zstd.NewWriter(w, zstd.WithEncoderConcurrency(1), zstd.WithWindowSize(zstdMaxWindowSize))
+	if err != nil {
+		return nil, err
+	}
+	return &zstdWriter{Encoder: e, pool: &c.encoders}, nil
+}
+
+func (c *zstdCompressor) Decompress(r io.Reader) (io.Reader, error) {
+	if d, ok := c.decoders.Get().(*zstdReader); ok {
+		if err := d.Reset(r); err != nil {
+			c.decoders.Put(d)
+			return nil, err
+		}
+		return d, nil
+	}
+	d, err := zstd.NewReader(r, zstd.WithDecoderConcurrency(1), zstd.WithDecoderLowmem(true), zstd.WithDecoderMaxWindow(zstdMaxWindowSize))
+	if err != nil {
+		return nil, err
+	}
+	return &zstdReader{Decoder: d, pool: &c.decoders}, nil
+}
+
+// DecompressedSize returns the size of the content declared in the header of the first frame of the message, or -1 if
+// it isn't declared. gRPC rejects the messages declaring more than its max receive message size without decompressing
+// them.
+func (c *zstdCompressor) DecompressedSize(compressed []byte) int {
+	var header zstd.Header
+	if err := header.Decode(compressed); err != nil || !header.HasFCS {
+		return -1
+	}
+	if header.FrameContentSize > uint64(maxInt) {
+		return maxInt
+	}
+	return int(header.FrameContentSize)
+}
+
+const maxInt = int(^uint(0) >> 1)
+
+// zstdWriter returns the encoder to the pool once the message is compressed
+type zstdWriter struct {
+	*zstd.Encoder
+	pool *sync.Pool
+}
+
+func (w *zstdWriter) Close() error {
+	err := w.Encoder.Close()
+	w.pool.Put(w)
+	return err
+}
+
+// zstdReader returns the decoder to the pool once the message is decompressed
+type zstdReader struct {
+	*zstd.Decoder
+	pool *sync.Pool
+}
+
+func (r *zstdReader) Read(p []byte) (int, error) {
+	n, err := r.Decoder.Read(p)
+	if err == io.EOF {
+		r.pool.Put(r)
+	}
+	return n, err
+}
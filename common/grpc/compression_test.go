@@ -0,0 +1,69 @@
+package grpc_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"io"
+	"testing"
+
+	commongrpc "github.com/Layr-Labs/eigenda/common/grpc"
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/encoding"
+)
+
+func compress(t *testing.T, compressor encoding.Compressor, data []byte) []byte {
+	var buf bytes.Buffer
+	w, err := compressor.Compress(&buf)
+	require.NoError(t, err)
+	_, err = w.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func TestCompressorsRoundTrip(t *testing.T) {
+	random := make([]byte, 4096)
+	_, err := rand.Read(random)
+	require.NoError(t, err)
+	compressible := bytes.Repeat([]byte("rollup batch "), 10000)
+
+	for _, name := range []string{commongrpc.GzipCompressor, commongrpc.ZstdCompressor} {
+		compressor := encoding.GetCompressor(name)
+		require.NotNil(t, compressor, name)
+
+		// The pooled encoders and decoders are reused across messages
+		for i := 0; i < 3; i++ {
+			for _, data := range [][]byte{random, compressible} {
+				compressed := compress(t, compressor, data)
+				r, err := compressor.Decompress(bytes.NewReader(compressed))
+				require.NoError(t, err)
+				decompressed, err := io.ReadAll(r)
+				require.NoError(t, err)
+				assert.Equal(t, data, decompressed, name)
+			}
+		}
+		assert.Less(t, len(compress(t, compressor, compressible)), len(compressible)/10, name)
+	}
+}
+
+func TestZstdDecompressedSize(t *testing.T) {
+	compressor := encoding.GetCompressor(commongrpc.ZstdCompressor).(interface {
+		DecompressedSize(compressed []byte) int
+	})
+
+	// The size of a message compressed at once is declared in its frame header, so that a bomb is detected from its
+	// first bytes
+	encoder, err := zstd.NewWriter(nil)
+	require.NoError(t, err)
+	bomb := encoder.EncodeAll(make([]byte, 64<<20), nil)
+	assert.Less(t, len(bomb), 64<<10)
+	assert.Equal(t, 64<<20, compressor.DecompressedSize(bomb))
+	assert.Equal(t, 64<<20, compressor.DecompressedSize(bomb[:32]))
+
+	// The size of a streamed message is unknown
+	streamed := compress(t, encoding.GetCompressor(commongrpc.ZstdCompressor), make([]byte, 1<<20))
+	assert.Equal(t, -1, compressor.DecompressedSize(streamed))
+	assert.Equal(t, -1, compressor.DecompressedSize([]byte("not zstd")))
+}
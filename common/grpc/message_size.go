@@ -0,0 +1,29 @@
+package grpc
+
+import (
+	"google.golang.org/grpc"
+)
+
+// MaxMessageSizeServerOptions limits the size of the messages received and sent by a server. The limit applies to the
+// messages once decompressed. The gRPC defaults apply if the size is not positive.
+func MaxMessageSizeServerOptions(size int) []grpc.ServerOption {
+	if size <= 0 {
+		return nil
+	}
+	return []grpc.ServerOption{
+		grpc.MaxRecvMsgSize(size),
+		grpc.MaxSendMsgSize(size),
+	}
+}
+
+// MaxMessageSizeDialOption limits the size of the messages sent and received by a client. The gRPC defaults apply if
+// the size is not positive.
+func MaxMessageSizeDialOption(size int) grpc.DialOption {
+	if size <= 0 {
+		return grpc.EmptyDialOption{}
+	}
+	return grpc.WithDefaultCallOptions(
+		grpc.MaxCallRecvMsgSize(size),
+		grpc.MaxCallSendMsgSize(size),
+	)
+}
@@ -0,0 +1,100 @@
+package grpc_test
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/api/grpc/node"
+	commongrpc "github.com/Layr-Labs/eigenda/common/grpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+const messageSizeLimit = 1024 * 1024
+
+// retrievalServer replies with a chunk of the size requested in the blob index
+type retrievalServer struct {
+	node.UnimplementedRetrievalServer
+}
+
+func (s *retrievalServer) RetrieveChunks(ctx context.Context, in *node.RetrieveChunksRequest) (*node.RetrieveChunksReply, error) {
+	return &node.RetrieveChunksReply{Chunks: [][]byte{make([]byte, in.GetBlobIndex())}}, nil
+}
+
+func startRetrievalServer(t *testing.T, maxMessageSize int, clientMaxMessageSize int) node.RetrievalClient {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	gs := grpc.NewServer(commongrpc.MaxMessageSizeServerOptions(maxMessageSize)...)
+	node.RegisterRetrievalServer(gs, &retrievalServer{})
+	go func() { _ = gs.Serve(listener) }()
+	t.Cleanup(gs.Stop)
+
+	conn, err := grpc.Dial(
+		listener.Addr().String(),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		commongrpc.MaxMessageSizeDialOption(clientMaxMessageSize),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	return node.NewRetrievalClient(conn)
+}
+
+// requestOfSize returns a request whose encoding is exactly size bytes, which asks for a reply of replySize bytes
+func requestOfSize(size int, replySize uint32) *node.RetrieveChunksRequest {
+	request := &node.RetrieveChunksRequest{BlobIndex: replySize}
+	for n := size; n > 0; n-- {
+		request.BatchHeaderHash = make([]byte, n)
+		if proto.Size(request) <= size {
+			break
+		}
+	}
+	return request
+}
+
+// replySizeOf returns the chunk size for which the encoding of the reply is exactly size bytes
+func replySizeOf(size int) uint32 {
+	for n := size; n > 0; n-- {
+		if proto.Size(&node.RetrieveChunksReply{Chunks: [][]byte{make([]byte, n)}}) <= size {
+			return uint32(n)
+		}
+	}
+	return 0
+}
+
+func TestMaxMessageSizeServer(t *testing.T) {
+	client := startRetrievalServer(t, messageSizeLimit, 2*messageSizeLimit)
+
+	request := requestOfSize(messageSizeLimit, 0)
+	require.Equal(t, messageSizeLimit, proto.Size(request))
+	_, err := client.RetrieveChunks(context.Background(), request)
+	assert.NoError(t, err)
+
+	request = requestOfSize(messageSizeLimit+1, 0)
+	require.Equal(t, messageSizeLimit+1, proto.Size(request))
+	_, err = client.RetrieveChunks(context.Background(), request)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+}
+
+func TestMaxMessageSizeClient(t *testing.T) {
+	client := startRetrievalServer(t, 2*messageSizeLimit, messageSizeLimit)
+
+	replySize := replySizeOf(messageSizeLimit)
+	require.Equal(t, messageSizeLimit, proto.Size(&node.RetrieveChunksReply{Chunks: [][]byte{make([]byte, replySize)}}))
+	_, err := client.RetrieveChunks(context.Background(), &node.RetrieveChunksRequest{BlobIndex: replySize})
+	assert.NoError(t, err)
+
+	replySize = replySizeOf(messageSizeLimit + 1)
+	require.Equal(t, messageSizeLimit+1, proto.Size(&node.RetrieveChunksReply{Chunks: [][]byte{make([]byte, replySize)}}))
+	_, err = client.RetrieveChunks(context.Background(), &node.RetrieveChunksRequest{BlobIndex: replySize})
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	// The client can't send requests over its limit either
+	_, err = client.RetrieveChunks(context.Background(), requestOfSize(messageSizeLimit+1, 0))
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+}
@@ -0,0 +1,361 @@
+package grpc
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sync"
+	"syscall"
+
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/fsnotify/fsnotify"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// TLSConfig configures TLS on a gRPC server. The certificate and key can either be read from files, in which case they are
+// reloaded when the files change or the process receives SIGHUP, or supplied directly as PEM blocks. Setting a client CA
+// enables mutual TLS: clients must then present a certificate signed by that CA.
+type TLSConfig struct {
+	CertFile string
+	KeyFile  string
+	CertPEM  []byte
+	KeyPEM   []byte
+
+	ClientCAFile string
+	ClientCAPEM  []byte
+}
+
+// Enabled returns whether a certificate has been configured
+func (c TLSConfig) Enabled() bool {
+	return c.CertFile != "" || len(c.CertPEM) > 0
+}
+
+func (c TLSConfig) Validate() error {
+	if c.CertFile != "" && len(c.CertPEM) > 0 {
+		return errors.New("only one of the certificate file and certificate PEM can be set")
+	}
+	if (c.CertFile == "") != (c.KeyFile == "") {
+		return errors.New("the certificate file and key file must be set together")
+	}
+	if (len(c.CertPEM) == 0) != (len(c.KeyPEM) == 0) {
+		return errors.New("the certificate PEM and key PEM must be set together")
+	}
+	if c.ClientCAFile != "" && len(c.ClientCAPEM) > 0 {
+		return errors.New("only one of the client CA file and client CA PEM can be set")
+	}
+	if !c.Enabled() && (c.ClientCAFile != "" || len(c.ClientCAPEM) > 0) {
+		return errors.New("a client CA requires a server certificate")
+	}
+	return nil
+}
+
+func (c TLSConfig) mutual() bool {
+	return c.ClientCAFile != "" || len(c.ClientCAPEM) > 0
+}
+
+// files returns the files the configuration is read from
+func (c TLSConfig) files() []string {
+	files := make([]string, 0, 3)
+	for _, f := range []string{c.CertFile, c.KeyFile, c.ClientCAFile} {
+		if f != "" {
+			files = append(files, f)
+		}
+	}
+	return files
+}
+
+// CertReloader serves the most recently loaded certificate and client CAs to incoming TLS handshakes, so that certificates
+// can be rotated without restarting the server. Connections that are already established keep their certificate.
+type CertReloader struct {
+	config TLSConfig
+	logger common.Logger
+
+	mu        sync.RWMutex
+	cert      *tls.Certificate
+	clientCAs *x509.CertPool
+}
+
+func NewCertReloader(config TLSConfig, logger common.Logger) (*CertReloader, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	if !config.Enabled() {
+		return nil, errors.New("no certificate configured")
+	}
+
+	r := &CertReloader{
+		config: config,
+		logger: logger,
+	}
+	if err := r.Reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Reload reads the certificate, key and client CA again. On failure, the previously loaded ones remain in use.
+func (r *CertReloader) Reload() error {
+	certPEM, keyPEM := r.config.CertPEM, r.config.KeyPEM
+	if r.config.CertFile != "" {
+		var err error
+		certPEM, err = os.ReadFile(r.config.CertFile)
+		if err != nil {
+			return fmt.Errorf("failed to read certificate: %w", err)
+		}
+		keyPEM, err = os.ReadFile(r.config.KeyFile)
+		if err != nil {
+			return fmt.Errorf("failed to read key: %w", err)
+		}
+	}
+
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return fmt.Errorf("failed to parse key pair: %w", err)
+	}
+
+	var clientCAs *x509.CertPool
+	if r.config.mutual() {
+		caPEM := r.config.ClientCAPEM
+		if r.config.ClientCAFile != "" {
+			caPEM, err = os.ReadFile(r.config.ClientCAFile)
+			if err != nil {
+				return fmt.Errorf("failed to read client CA: %w", err)
+			}
+		}
+
+		clientCAs = x509.NewCertPool()
+		if !clientCAs.AppendCertsFromPEM(caPEM) {
+			return errors.New("failed to parse client CA")
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cert = &cert
+	r.clientCAs = clientCAs
+	return nil
+}
+
+// TLSConfig returns a tls.Config that resolves the certificate and client CAs at handshake time. The callbacks are set
+// on the returned config itself, rather than on a config made for each client, so that the settings applied to it by
+// its users, e.g. the ALPN protocols set by credentials.NewTLS, apply to the handshakes.
+func (r *CertReloader) TLSConfig() *tls.Config {
+	config := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetCertificate: func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+			r.mu.RLock()
+			defer r.mu.RUnlock()
+			return r.cert, nil
+		},
+	}
+	if r.config.mutual() {
+		// The client certificates are verified against the current client CAs rather than the ones of the config
+		config.ClientAuth = tls.RequireAnyClientCert
+		config.VerifyConnection = r.verifyClient
+	}
+	return config
+}
+
+// verifyClient verifies the certificate chain presented by the client against the client CAs, as
+// tls.RequireAndVerifyClientCert does
+func (r *CertReloader) verifyClient(state tls.ConnectionState) error {
+	if len(state.PeerCertificates) == 0 {
+		return errors.New("client didn't provide a certificate")
+	}
+	r.mu.RLock()
+	clientCAs := r.clientCAs
+	r.mu.RUnlock()
+
+	intermediates := x509.NewCertPool()
+	for _, cert := range state.PeerCertificates[1:] {
+		intermediates.AddCert(cert)
+	}
+	_, err := state.PeerCertificates[0].Verify(x509.VerifyOptions{
+		Roots:         clientCAs,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to verify client certificate: %w", err)
+	}
+	return nil
+}
+
+// Watch reloads the certificates whenever the process receives SIGHUP or one of the configured files changes, until the
+// context is cancelled. The directories containing the files are watched rather than the files themselves so that
+// rotations which atomically replace the files (e.g. symlink swaps) are picked up.
+func (r *CertReloader) Watch(ctx context.Context) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to create file watcher: %w", err)
+	}
+
+	watched := make(map[string]bool)
+	for _, f := range r.config.files() {
+		dir := filepath.Dir(f)
+		if watched[dir] {
+			continue
+		}
+		if err := watcher.Add(dir); err != nil {
+			_ = watcher.Close()
+			return fmt.Errorf("failed to watch %s: %w", dir, err)
+		}
+		watched[dir] = true
+	}
+
+	sighup := make(chan os.Signal, 1)
+	signal.Notify(sighup, syscall.SIGHUP)
+
+	go func() {
+		defer func() {
+			signal.Stop(sighup)
+			_ = watcher.Close()
+		}()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-sighup:
+				r.reload("SIGHUP")
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if event.Has(fsnotify.Chmod) && !event.Has(fsnotify.Write) {
+					continue
+				}
+				r.reload(event.String())
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				r.logger.Error("certificate watcher error", "err", err)
+			}
+		}
+	}()
+
+	return nil
+}
+
+func (r *CertReloader) reload(trigger string) {
+	if err := r.Reload(); err != nil {
+		// Files may be observed midway through a rotation, in which case a later event will complete the reload
+		r.logger.Warn("failed to reload TLS certificates, keeping the current ones", "trigger", trigger, "err", err)
+		return
+	}
+	r.logger.Info("reloaded TLS certificates", "trigger", trigger)
+}
+
+// ServerOptions returns the gRPC server options applying the TLS configuration, or no options if TLS is not enabled.
+// Certificates read from files are reloaded on change until the context is cancelled.
+func ServerOptions(ctx context.Context, config TLSConfig, logger common.Logger) ([]grpc.ServerOption, error) {
+	if !config.Enabled() {
+		if err := config.Validate(); err != nil {
+			return nil, err
+		}
+		return nil, nil
+	}
+
+	reloader, err := NewCertReloader(config, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(config.files()) > 0 {
+		if err := reloader.Watch(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	return []grpc.ServerOption{grpc.Creds(credentials.NewTLS(reloader.TLSConfig()))}, nil
+}
+
+// ClientTLSConfig configures TLS on the gRPC clients of the servers with TLS enabled. The servers are verified against the
+// CA, or against the system roots if no CA is set. A client certificate authenticates the client to the servers
+// requiring mutual TLS. The clients don't use TLS unless it is enabled.
+type ClientTLSConfig struct {
+	Enabled bool
+
+	CAFile string
+	CAPEM  []byte
+
+	CertFile string
+	KeyFile  string
+	CertPEM  []byte
+	KeyPEM   []byte
+}
+
+func (c ClientTLSConfig) Validate() error {
+	if c.CAFile != "" && len(c.CAPEM) > 0 {
+		return errors.New("only one of the CA file and CA PEM can be set")
+	}
+	if c.CertFile != "" && len(c.CertPEM) > 0 {
+		return errors.New("only one of the client certificate file and client certificate PEM can be set")
+	}
+	if (c.CertFile == "") != (c.KeyFile == "") {
+		return errors.New("the client certificate file and key file must be set together")
+	}
+	if (len(c.CertPEM) == 0) != (len(c.KeyPEM) == 0) {
+		return errors.New("the client certificate PEM and key PEM must be set together")
+	}
+	if !c.Enabled && (c.CAFile != "" || len(c.CAPEM) > 0 || c.CertFile != "" || len(c.CertPEM) > 0) {
+		return errors.New("a CA or client certificate requires client TLS to be enabled")
+	}
+	return nil
+}
+
+// Credentials returns the transport credentials of the clients, which are insecure if TLS is not enabled
+func (c ClientTLSConfig) Credentials() (credentials.TransportCredentials, error) {
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	if !c.Enabled {
+		return insecure.NewCredentials(), nil
+	}
+
+	config := &tls.Config{MinVersion: tls.VersionTLS12}
+	caPEM := c.CAPEM
+	if c.CAFile != "" {
+		var err error
+		caPEM, err = os.ReadFile(c.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA: %w", err)
+		}
+	}
+	if len(caPEM) > 0 {
+		config.RootCAs = x509.NewCertPool()
+		if !config.RootCAs.AppendCertsFromPEM(caPEM) {
+			return nil, errors.New("failed to parse CA")
+		}
+	}
+
+	certPEM, keyPEM := c.CertPEM, c.KeyPEM
+	if c.CertFile != "" {
+		var err error
+		certPEM, err = os.ReadFile(c.CertFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read client certificate: %w", err)
+		}
+		keyPEM, err = os.ReadFile(c.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read client key: %w", err)
+		}
+	}
+	if len(certPEM) > 0 {
+		cert, err := tls.X509KeyPair(certPEM, keyPEM)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse client key pair: %w", err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+
+	return credentials.NewTLS(config), nil
+}
@@ -0,0 +1,325 @@
+package grpc_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	commongrpc "github.com/Layr-Labs/eigenda/common/grpc"
+	"github.com/Layr-Labs/eigenda/common/healthcheck"
+	"github.com/Layr-Labs/eigenda/common/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pem  []byte
+}
+
+func newTestCA(t *testing.T) *testCA {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return &testCA{
+		cert: cert,
+		key:  key,
+		pem:  pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+	}
+}
+
+// issue returns a PEM encoded certificate and key signed by the CA
+func (ca *testCA) issue(t *testing.T, serial int64, usage x509.ExtKeyUsage) ([]byte, []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		DNSNames:     []string{"localhost"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	require.NoError(t, err)
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+// writeFile atomically replaces the file, as certificate managers do
+func writeFile(t *testing.T, path string, data []byte) {
+	tmp := path + ".tmp"
+	require.NoError(t, os.WriteFile(tmp, data, 0o600))
+	require.NoError(t, os.Rename(tmp, path))
+}
+
+func startServer(t *testing.T, ctx context.Context, config commongrpc.TLSConfig) string {
+	opts, err := commongrpc.ServerOptions(ctx, config, &mock.Logger{})
+	require.NoError(t, err)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	gs := grpc.NewServer(opts...)
+	healthcheck.RegisterHealthServer(gs)
+	go func() {
+		_ = gs.Serve(listener)
+	}()
+	t.Cleanup(gs.Stop)
+
+	return listener.Addr().String()
+}
+
+func checkHealth(addr string, clientConfig *tls.Config) error {
+	return checkHealthWithCredentials(addr, credentials.NewTLS(clientConfig))
+}
+
+func checkHealthWithCredentials(addr string, creds credentials.TransportCredentials) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	conn, err := grpc.DialContext(ctx, addr, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	_, err = grpc_health_v1.NewHealthClient(conn).Check(ctx, &grpc_health_v1.HealthCheckRequest{})
+	return err
+}
+
+// servedSerial returns the serial number of the certificate presented by the server
+func servedSerial(t *testing.T, addr string, roots *x509.CertPool) int64 {
+	return handshake(t, addr, &tls.Config{RootCAs: roots, NextProtos: []string{"h2"}}).PeerCertificates[0].SerialNumber.Int64()
+}
+
+func serverCert(t *testing.T, certPEM []byte, keyPEM []byte) tls.Certificate {
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	require.NoError(t, err)
+	return cert
+}
+
+// handshake returns the state of a TLS connection to the server
+func handshake(t *testing.T, addr string, config *tls.Config) tls.ConnectionState {
+	conn, err := tls.Dial("tcp", addr, config)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	return conn.ConnectionState()
+}
+
+func TestTLS(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ca := newTestCA(t)
+	certPEM, keyPEM := ca.issue(t, 2, x509.ExtKeyUsageServerAuth)
+
+	addr := startServer(t, ctx, commongrpc.TLSConfig{
+		CertPEM: certPEM,
+		KeyPEM:  keyPEM,
+	})
+
+	roots := x509.NewCertPool()
+	roots.AppendCertsFromPEM(ca.pem)
+
+	assert.NoError(t, checkHealth(addr, &tls.Config{RootCAs: roots}))
+	// HTTP/2 is negotiated with ALPN, as gRPC clients require
+	assert.Equal(t, "h2", handshake(t, addr, &tls.Config{RootCAs: roots, NextProtos: []string{"h2"}}).NegotiatedProtocol)
+
+	// Clients that don't trust the CA are rejected
+	assert.Error(t, checkHealth(addr, &tls.Config{RootCAs: x509.NewCertPool()}))
+}
+
+func TestMutualTLS(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ca := newTestCA(t)
+	dir := t.TempDir()
+
+	certPEM, keyPEM := ca.issue(t, 2, x509.ExtKeyUsageServerAuth)
+	writeFile(t, filepath.Join(dir, "server.crt"), certPEM)
+	writeFile(t, filepath.Join(dir, "server.key"), keyPEM)
+	writeFile(t, filepath.Join(dir, "ca.crt"), ca.pem)
+
+	addr := startServer(t, ctx, commongrpc.TLSConfig{
+		CertFile:     filepath.Join(dir, "server.crt"),
+		KeyFile:      filepath.Join(dir, "server.key"),
+		ClientCAFile: filepath.Join(dir, "ca.crt"),
+	})
+
+	roots := x509.NewCertPool()
+	roots.AppendCertsFromPEM(ca.pem)
+
+	// Without a client certificate
+	assert.Error(t, checkHealth(addr, &tls.Config{RootCAs: roots}))
+
+	// With a client certificate signed by the client CA
+	clientCertPEM, clientKeyPEM := ca.issue(t, 3, x509.ExtKeyUsageClientAuth)
+	clientCert, err := tls.X509KeyPair(clientCertPEM, clientKeyPEM)
+	require.NoError(t, err)
+	assert.NoError(t, checkHealth(addr, &tls.Config{RootCAs: roots, Certificates: []tls.Certificate{clientCert}}))
+	assert.Equal(t, "h2", handshake(t, addr, &tls.Config{RootCAs: roots, Certificates: []tls.Certificate{clientCert}, NextProtos: []string{"h2"}}).NegotiatedProtocol)
+
+	// With a server certificate signed by the client CA
+	assert.Error(t, checkHealth(addr, &tls.Config{RootCAs: roots, Certificates: []tls.Certificate{serverCert(t, certPEM, keyPEM)}}))
+
+	// With a client certificate signed by another CA
+	otherCertPEM, otherKeyPEM := newTestCA(t).issue(t, 4, x509.ExtKeyUsageClientAuth)
+	otherCert, err := tls.X509KeyPair(otherCertPEM, otherKeyPEM)
+	require.NoError(t, err)
+	assert.Error(t, checkHealth(addr, &tls.Config{RootCAs: roots, Certificates: []tls.Certificate{otherCert}}))
+}
+
+func TestCertificateReload(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ca := newTestCA(t)
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "server.crt")
+	keyFile := filepath.Join(dir, "server.key")
+
+	certPEM, keyPEM := ca.issue(t, 2, x509.ExtKeyUsageServerAuth)
+	writeFile(t, certFile, certPEM)
+	writeFile(t, keyFile, keyPEM)
+
+	addr := startServer(t, ctx, commongrpc.TLSConfig{
+		CertFile: certFile,
+		KeyFile:  keyFile,
+	})
+
+	roots := x509.NewCertPool()
+	roots.AppendCertsFromPEM(ca.pem)
+	assert.Equal(t, int64(2), servedSerial(t, addr, roots))
+
+	// Rotate the certificate while the server is running
+	certPEM, keyPEM = ca.issue(t, 5, x509.ExtKeyUsageServerAuth)
+	writeFile(t, keyFile, keyPEM)
+	writeFile(t, certFile, certPEM)
+
+	assert.Eventually(t, func() bool {
+		return servedSerial(t, addr, roots) == 5
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.NoError(t, checkHealth(addr, &tls.Config{RootCAs: roots}))
+
+	// A broken rotation keeps the current certificate
+	writeFile(t, certFile, []byte("not a certificate"))
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, int64(5), servedSerial(t, addr, roots))
+}
+
+func TestTLSConfigValidate(t *testing.T) {
+	assert.NoError(t, commongrpc.TLSConfig{}.Validate())
+	assert.NoError(t, commongrpc.TLSConfig{CertFile: "cert", KeyFile: "key", ClientCAFile: "ca"}.Validate())
+
+	assert.Error(t, commongrpc.TLSConfig{CertFile: "cert"}.Validate())
+	assert.Error(t, commongrpc.TLSConfig{CertPEM: []byte("cert")}.Validate())
+	assert.Error(t, commongrpc.TLSConfig{CertFile: "cert", KeyFile: "key", CertPEM: []byte("cert"), KeyPEM: []byte("key")}.Validate())
+	assert.Error(t, commongrpc.TLSConfig{ClientCAFile: "ca"}.Validate())
+
+	// Plaintext servers have no options
+	opts, err := commongrpc.ServerOptions(context.Background(), commongrpc.TLSConfig{}, &mock.Logger{})
+	assert.NoError(t, err)
+	assert.Empty(t, opts)
+}
+
+func TestClientTLS(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ca := newTestCA(t)
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "ca.crt"), ca.pem)
+
+	certPEM, keyPEM := ca.issue(t, 2, x509.ExtKeyUsageServerAuth)
+	addr := startServer(t, ctx, commongrpc.TLSConfig{
+		CertPEM:     certPEM,
+		KeyPEM:      keyPEM,
+		ClientCAPEM: ca.pem,
+	})
+
+	clientCertPEM, clientKeyPEM := ca.issue(t, 3, x509.ExtKeyUsageClientAuth)
+	writeFile(t, filepath.Join(dir, "client.crt"), clientCertPEM)
+	writeFile(t, filepath.Join(dir, "client.key"), clientKeyPEM)
+
+	// With the CA and a client certificate, from files or PEM
+	creds, err := commongrpc.ClientTLSConfig{
+		Enabled:  true,
+		CAFile:   filepath.Join(dir, "ca.crt"),
+		CertFile: filepath.Join(dir, "client.crt"),
+		KeyFile:  filepath.Join(dir, "client.key"),
+	}.Credentials()
+	require.NoError(t, err)
+	assert.NoError(t, checkHealthWithCredentials(addr, creds))
+
+	creds, err = commongrpc.ClientTLSConfig{Enabled: true, CAPEM: ca.pem, CertPEM: clientCertPEM, KeyPEM: clientKeyPEM}.Credentials()
+	require.NoError(t, err)
+	assert.NoError(t, checkHealthWithCredentials(addr, creds))
+
+	// Without a client certificate
+	creds, err = commongrpc.ClientTLSConfig{Enabled: true, CAPEM: ca.pem}.Credentials()
+	require.NoError(t, err)
+	assert.Error(t, checkHealthWithCredentials(addr, creds))
+
+	// Without trusting the CA of the server
+	creds, err = commongrpc.ClientTLSConfig{Enabled: true, CAPEM: newTestCA(t).pem, CertPEM: clientCertPEM, KeyPEM: clientKeyPEM}.Credentials()
+	require.NoError(t, err)
+	assert.Error(t, checkHealthWithCredentials(addr, creds))
+
+	// Without TLS
+	creds, err = commongrpc.ClientTLSConfig{}.Credentials()
+	require.NoError(t, err)
+	assert.Equal(t, "insecure", creds.Info().SecurityProtocol)
+	assert.Error(t, checkHealthWithCredentials(addr, creds))
+}
+
+func TestClientTLSConfigValidate(t *testing.T) {
+	assert.NoError(t, commongrpc.ClientTLSConfig{}.Validate())
+	assert.NoError(t, commongrpc.ClientTLSConfig{Enabled: true}.Validate())
+	assert.NoError(t, commongrpc.ClientTLSConfig{Enabled: true, CAFile: "ca", CertFile: "cert", KeyFile: "key"}.Validate())
+
+	assert.Error(t, commongrpc.ClientTLSConfig{CAFile: "ca"}.Validate())
+	assert.Error(t, commongrpc.ClientTLSConfig{Enabled: true, CAFile: "ca", CAPEM: []byte("ca")}.Validate())
+	assert.Error(t, commongrpc.ClientTLSConfig{Enabled: true, CertFile: "cert"}.Validate())
+	assert.Error(t, commongrpc.ClientTLSConfig{Enabled: true, KeyPEM: []byte("key")}.Validate())
+
+	_, err := commongrpc.ClientTLSConfig{Enabled: true, CAPEM: []byte("not a CA")}.Credentials()
+	assert.Error(t, err)
+}
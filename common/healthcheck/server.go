@@ -7,7 +7,10 @@ import (
 	"google.golang.org/grpc/health/grpc_health_v1"
 )
 
-type HealthServer struct{}
+type HealthServer struct {
+	// ready reports whether the server is ready to serve. The server is always ready if nil.
+	ready func() bool
+}
 
 // Watch implements grpc_health_v1.HealthServer.
 func (*HealthServer) Watch(*grpc_health_v1.HealthCheckRequest, grpc_health_v1.Health_WatchServer) error {
@@ -15,6 +18,12 @@ func (*HealthServer) Watch(*grpc_health_v1.HealthCheckRequest, grpc_health_v1.He
 }
 
 func (s *HealthServer) Check(ctx context.Context, req *grpc_health_v1.HealthCheckRequest) (*grpc_health_v1.HealthCheckResponse, error) {
+	if s.ready != nil && !s.ready() {
+		return &grpc_health_v1.HealthCheckResponse{
+			Status: grpc_health_v1.HealthCheckResponse_NOT_SERVING,
+		}, nil
+	}
+
 	// If the server is healthy, return a response with status "SERVING".
 	return &grpc_health_v1.HealthCheckResponse{
 		Status: grpc_health_v1.HealthCheckResponse_SERVING,
@@ -23,6 +32,12 @@ func (s *HealthServer) Check(ctx context.Context, req *grpc_health_v1.HealthChec
 
 // RegisterHealthServer registers the HealthServer with the provided gRPC server.
 func RegisterHealthServer(server *grpc.Server) {
-	healthServer := &HealthServer{} // Initialize your health server implementation
+	RegisterHealthServerWithReadiness(server, nil)
+}
+
+// RegisterHealthServerWithReadiness registers a HealthServer reporting NOT_SERVING until ready returns true, e.g. while
+// the server warms up, with the provided gRPC server.
+func RegisterHealthServerWithReadiness(server *grpc.Server, ready func() bool) {
+	healthServer := &HealthServer{ready: ready}
 	grpc_health_v1.RegisterHealthServer(server, healthServer)
 }
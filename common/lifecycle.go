@@ -0,0 +1,235 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// DefaultStopTimeout is the time given to each component to stop when Run is given no stop timeout
+const DefaultStopTimeout = 30 * time.Second
+
+// Component is a part of a service, started and stopped by Run
+type Component interface {
+	// Name identifies the component in the logs
+	Name() string
+	// Start starts the component, which then runs in the background until it is stopped. The context only bounds the
+	// start: it is done once the service shuts down, before the component is stopped.
+	Start(ctx context.Context) error
+	// Stop stops the component, giving up on a graceful stop once the context is done
+	Stop(ctx context.Context) error
+}
+
+// CrashingComponent is a component which can fail after it started, e.g. a server whose listener fails. The service is
+// shut down once the component sends an error on the channel.
+type CrashingComponent interface {
+	Component
+	Crashed() <-chan error
+}
+
+// Run starts the components in order, so that each component can depend on the components before it, and stops them
+// in the reverse order once the context is done, the process receives SIGINT or SIGTERM, or a component crashes. Each
+// component is given the stop timeout to stop, unless it was wrapped with WithStopTimeout, after which it is abandoned
+// and the next components are stopped. The components started so far are stopped as well if a component fails to
+// start. Run returns an error if a component failed to start, crashed or failed to stop, and nil on a clean shutdown.
+func Run(ctx context.Context, logger Logger, stopTimeout time.Duration, components ...Component) error {
+	if stopTimeout <= 0 {
+		stopTimeout = DefaultStopTimeout
+	}
+	ctx, stopSignals := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM)
+	defer stopSignals()
+
+	crashes := make(chan error, len(components))
+	done := make(chan struct{})
+	defer close(done)
+
+	var err error
+	started := make([]Component, 0, len(components))
+	for _, component := range components {
+		logger.Info("starting component", "component", component.Name())
+		if startErr := component.Start(ctx); startErr != nil {
+			err = fmt.Errorf("failed to start %s: %w", component.Name(), startErr)
+			logger.Error("failed to start component", "component", component.Name(), "err", startErr)
+			break
+		}
+		started = append(started, component)
+		if crashing, ok := unwrapComponent(component).(CrashingComponent); ok {
+			go func(component CrashingComponent) {
+				select {
+				case crashErr := <-component.Crashed():
+					crashes <- fmt.Errorf("%s crashed: %w", component.Name(), crashErr)
+				case <-done:
+				}
+			}(crashing)
+		}
+	}
+
+	if err == nil {
+		select {
+		case <-ctx.Done():
+			logger.Info("shutting down")
+		case err = <-crashes:
+			logger.Error("shutting down after a component crashed", "err", err)
+		}
+	}
+
+	for i := len(started) - 1; i >= 0; i-- {
+		if stopErr := stopComponent(started[i], stopTimeout); stopErr != nil {
+			logger.Error("failed to stop component", "component", started[i].Name(), "err", stopErr)
+			err = errors.Join(err, stopErr)
+			continue
+		}
+		logger.Info("stopped component", "component", started[i].Name())
+	}
+	return err
+}
+
+// stopComponent stops the component, abandoning it if it doesn't stop within its stop timeout
+func stopComponent(component Component, stopTimeout time.Duration) error {
+	if withTimeout, ok := component.(*stopTimeoutComponent); ok {
+		stopTimeout = withTimeout.stopTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), stopTimeout)
+	defer cancel()
+
+	result := make(chan error, 1)
+	go func() {
+		result <- component.Stop(ctx)
+	}()
+	select {
+	case err := <-result:
+		if err != nil {
+			return fmt.Errorf("failed to stop %s: %w", component.Name(), err)
+		}
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("%s did not stop within %s", component.Name(), stopTimeout)
+	}
+}
+
+type stopTimeoutComponent struct {
+	Component
+	stopTimeout time.Duration
+}
+
+// unwrapComponent returns the component wrapped by WithStopTimeout, if any
+func unwrapComponent(component Component) Component {
+	if withTimeout, ok := component.(*stopTimeoutComponent); ok {
+		return withTimeout.Component
+	}
+	return component
+}
+
+// WithStopTimeout overrides the stop timeout given to the component by Run
+func WithStopTimeout(component Component, stopTimeout time.Duration) Component {
+	return &stopTimeoutComponent{Component: component, stopTimeout: stopTimeout}
+}
+
+type funcComponent struct {
+	name  string
+	start func(ctx context.Context) error
+	stop  func(ctx context.Context) error
+}
+
+// NewComponent makes a component of its start and stop functions. The stop function may be nil for the components
+// which release nothing.
+func NewComponent(name string, start func(ctx context.Context) error, stop func(ctx context.Context) error) Component {
+	return &funcComponent{name: name, start: start, stop: stop}
+}
+
+func (c *funcComponent) Name() string {
+	return c.name
+}
+
+func (c *funcComponent) Start(ctx context.Context) error {
+	return c.start(ctx)
+}
+
+func (c *funcComponent) Stop(ctx context.Context) error {
+	if c.stop == nil {
+		return nil
+	}
+	return c.stop(ctx)
+}
+
+type backgroundComponent struct {
+	name   string
+	start  func(ctx context.Context) error
+	cancel context.CancelFunc
+}
+
+// NewBackgroundComponent makes a component of a start function which runs the component in the background until the
+// context it is given is done. The context is canceled to stop the component, which isn't waited for.
+func NewBackgroundComponent(name string, start func(ctx context.Context) error) Component {
+	return &backgroundComponent{name: name, start: start}
+}
+
+func (c *backgroundComponent) Name() string {
+	return c.name
+}
+
+func (c *backgroundComponent) Start(ctx context.Context) error {
+	runCtx, cancel := context.WithCancel(context.Background())
+	c.cancel = cancel
+	if err := c.start(runCtx); err != nil {
+		cancel()
+		return err
+	}
+	return nil
+}
+
+func (c *backgroundComponent) Stop(ctx context.Context) error {
+	c.cancel()
+	return nil
+}
+
+type serverComponent struct {
+	name    string
+	serve   func() error
+	stop    func(ctx context.Context) error
+	crashed chan error
+
+	mu       sync.Mutex
+	stopping bool
+}
+
+// NewServerComponent makes a component of a server whose serve function blocks until the server is stopped by the stop
+// function. The server crashes if it stops serving before it is stopped.
+func NewServerComponent(name string, serve func() error, stop func(ctx context.Context) error) CrashingComponent {
+	return &serverComponent{name: name, serve: serve, stop: stop, crashed: make(chan error, 1)}
+}
+
+func (c *serverComponent) Name() string {
+	return c.name
+}
+
+func (c *serverComponent) Start(ctx context.Context) error {
+	go func() {
+		err := c.serve()
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		if c.stopping {
+			return
+		}
+		if err == nil {
+			err = errors.New("the server stopped serving")
+		}
+		c.crashed <- err
+	}()
+	return nil
+}
+
+func (c *serverComponent) Stop(ctx context.Context) error {
+	c.mu.Lock()
+	c.stopping = true
+	c.mu.Unlock()
+	return c.stop(ctx)
+}
+
+func (c *serverComponent) Crashed() <-chan error {
+	return c.crashed
+}
@@ -0,0 +1,123 @@
+package common_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/common/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+// lifecycleLog records the order in which the components are started and stopped
+type lifecycleLog struct {
+	mu     sync.Mutex
+	events []string
+}
+
+func (l *lifecycleLog) add(event string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.events = append(l.events, event)
+}
+
+func (l *lifecycleLog) get() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]string{}, l.events...)
+}
+
+func newTestComponent(name string, log *lifecycleLog, startErr error) common.Component {
+	return common.NewComponent(name, func(ctx context.Context) error {
+		log.add("start " + name)
+		return startErr
+	}, func(ctx context.Context) error {
+		log.add("stop " + name)
+		return nil
+	})
+}
+
+func TestRunStopsInReverseOrder(t *testing.T) {
+	log := &lifecycleLog{}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := common.Run(ctx, mock.NewLogger(false), time.Second,
+		newTestComponent("a", log, nil),
+		newTestComponent("b", log, nil),
+		newTestComponent("c", log, nil),
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"start a", "start b", "start c", "stop c", "stop b", "stop a"}, log.get())
+}
+
+func TestRunComponentFailsToStart(t *testing.T) {
+	log := &lifecycleLog{}
+	startErr := errors.New("no database")
+
+	err := common.Run(context.Background(), mock.NewLogger(false), time.Second,
+		newTestComponent("a", log, nil),
+		newTestComponent("b", log, nil),
+		newTestComponent("c", log, startErr),
+		newTestComponent("d", log, nil),
+	)
+	assert.ErrorIs(t, err, startErr)
+	assert.ErrorContains(t, err, "failed to start c")
+	// The components started before c are stopped, and d is never started
+	assert.Equal(t, []string{"start a", "start b", "start c", "stop b", "stop a"}, log.get())
+}
+
+func TestRunComponentHangsOnStop(t *testing.T) {
+	log := &lifecycleLog{}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	release := make(chan struct{})
+	defer close(release)
+	hanging := common.NewComponent("hanging", func(ctx context.Context) error {
+		log.add("start hanging")
+		return nil
+	}, func(ctx context.Context) error {
+		log.add("stop hanging")
+		// Ignores the context of the stop
+		<-release
+		return nil
+	})
+
+	started := time.Now()
+	err := common.Run(ctx, mock.NewLogger(false), time.Minute,
+		newTestComponent("a", log, nil),
+		common.WithStopTimeout(hanging, 50*time.Millisecond),
+		newTestComponent("b", log, nil),
+	)
+	assert.ErrorContains(t, err, "hanging did not stop within 50ms")
+	// The hanging component is abandoned after its own stop timeout, and the next component is still stopped
+	assert.Less(t, time.Since(started), 10*time.Second)
+	assert.Equal(t, []string{"start a", "start hanging", "start b", "stop b", "stop hanging", "stop a"}, log.get())
+}
+
+func TestRunComponentCrashes(t *testing.T) {
+	log := &lifecycleLog{}
+	serveErr := errors.New("listener closed")
+	serving := make(chan struct{})
+
+	server := common.NewServerComponent("server", func() error {
+		<-serving
+		return serveErr
+	}, func(ctx context.Context) error {
+		log.add("stop server")
+		return nil
+	})
+	close(serving)
+
+	err := common.Run(context.Background(), mock.NewLogger(false), time.Second,
+		newTestComponent("a", log, nil),
+		server,
+	)
+	assert.ErrorIs(t, err, serveErr)
+	assert.ErrorContains(t, err, "server crashed")
+	assert.Equal(t, []string{"start a", "stop server", "stop a"}, log.get())
+}
@@ -201,6 +201,20 @@ func (mock *MockEthClient) EstimateGasPriceAndLimitAndSendTx(ctx context.Context
 	return result, args.Error(1)
 }
 
+func (mock *MockEthClient) EstimateGasPriceAndLimitAndReplaceTx(ctx context.Context, tx *types.Transaction, replaced *types.Transaction, tag string, value *big.Int) (*types.Receipt, *types.Transaction, error) {
+	args := mock.Called()
+	var result *types.Receipt
+	if args.Get(0) != nil {
+		result = args.Get(0).(*types.Receipt)
+	}
+	var sent *types.Transaction
+	if args.Get(1) != nil {
+		sent = args.Get(1).(*types.Transaction)
+	}
+
+	return result, sent, args.Error(2)
+}
+
 func (mock *MockEthClient) EnsureTransactionEvaled(ctx context.Context, tx *types.Transaction, tag string) (*types.Receipt, error) {
 	args := mock.Called()
 	var result *types.Receipt
@@ -2,6 +2,7 @@ package mock
 
 import (
 	"context"
+	"time"
 
 	"github.com/Layr-Labs/eigenda/common"
 )
@@ -12,3 +13,7 @@ type NoopRatelimiter struct {
 func (r *NoopRatelimiter) AllowRequest(ctx context.Context, retrieverID string, blobSize uint, rate common.RateParam) (bool, error) {
 	return true, nil
 }
+
+func (r *NoopRatelimiter) RetryAfter(ctx context.Context, retrieverID string, blobSize uint, rate common.RateParam) (time.Duration, error) {
+	return 0, nil
+}
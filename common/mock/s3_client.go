@@ -3,18 +3,21 @@ package mock
 import (
 	"context"
 	"strings"
+	"time"
 
 	"github.com/Layr-Labs/eigenda/common/aws/s3"
 )
 
 type S3Client struct {
-	bucket map[string][]byte
+	bucket       map[string][]byte
+	contentTypes map[string]string
+	modified     map[string]time.Time
 }
 
 var _ s3.Client = (*S3Client)(nil)
 
 func NewS3Client() *S3Client {
-	return &S3Client{bucket: make(map[string][]byte)}
+	return &S3Client{bucket: make(map[string][]byte), contentTypes: make(map[string]string), modified: make(map[string]time.Time)}
 }
 
 func (s *S3Client) DownloadObject(ctx context.Context, bucket string, key string) ([]byte, error) {
@@ -27,11 +30,27 @@ func (s *S3Client) DownloadObject(ctx context.Context, bucket string, key string
 
 func (s *S3Client) UploadObject(ctx context.Context, bucket string, key string, data []byte) error {
 	s.bucket[key] = data
+	delete(s.contentTypes, key)
+	s.modified[key] = time.Now()
 	return nil
 }
 
+func (s *S3Client) UploadObjectWithContentType(ctx context.Context, bucket string, key string, data []byte, contentType string) error {
+	s.bucket[key] = data
+	s.contentTypes[key] = contentType
+	s.modified[key] = time.Now()
+	return nil
+}
+
+// ContentType returns the content type the object was uploaded with, if any
+func (s *S3Client) ContentType(key string) string {
+	return s.contentTypes[key]
+}
+
 func (s *S3Client) DeleteObject(ctx context.Context, bucket string, key string) error {
 	delete(s.bucket, key)
+	delete(s.contentTypes, key)
+	delete(s.modified, key)
 	return nil
 }
 
@@ -39,7 +58,7 @@ func (s *S3Client) ListObjects(ctx context.Context, bucket string, prefix string
 	objects := make([]s3.Object, 0, 5)
 	for k, v := range s.bucket {
 		if strings.HasPrefix(k, prefix) {
-			objects = append(objects, s3.Object{Key: k, Size: int64(len(v))})
+			objects = append(objects, s3.Object{Key: k, Size: int64(len(v)), LastModified: s.modified[k]})
 		}
 	}
 	return objects, nil
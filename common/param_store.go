@@ -1,10 +1,16 @@
 package common
 
-import "context"
+import (
+	"context"
+	"errors"
+)
+
+// ErrKeyNotFound is returned by KVStore.GetItem when no value is associated with the key
+var ErrKeyNotFound = errors.New("key not found")
 
 // KVStore is a simple key value store interface.
 type KVStore[T any] interface {
-	// GetItem returns the value associated with a given key.
+	// GetItem returns the value associated with a given key, or ErrKeyNotFound if there is none.
 	GetItem(ctx context.Context, key string) (*T, error)
 	// UpdateItem updates the value for the given key.
 	UpdateItem(ctx context.Context, key string, value *T) error
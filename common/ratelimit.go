@@ -17,6 +17,10 @@ type RequesterID = string
 
 type RateLimiter interface {
 	AllowRequest(ctx context.Context, requesterID RequesterID, blobSize uint, rate RateParam) (bool, error)
+	// RetryAfter estimates how long the requester must wait before a request of the given size would be allowed
+	// at the given rate, based on the current state of the requester's buckets. It returns 0 if the request would
+	// be allowed immediately.
+	RetryAfter(ctx context.Context, requesterID RequesterID, blobSize uint, rate RateParam) (time.Duration, error)
 }
 
 type GlobalRateParams struct {
@@ -54,7 +54,7 @@ func (d *rateLimiter) AllowRequest(ctx context.Context, requesterID common.Reque
 	for i, size := range d.globalRateParams.BucketSizes {
 
 		// Determine bucket deduction
-		deduction := time.Microsecond * time.Duration(1e6*float32(blobSize)/float32(rate)/d.globalRateParams.Multipliers[i])
+		deduction := d.getDeduction(i, blobSize, rate)
 
 		// Update the bucket level
 		bucketParams.BucketLevels[i] = getBucketLevel(bucketParams.BucketLevels[i], size, interval, deduction)
@@ -77,6 +77,50 @@ func (d *rateLimiter) AllowRequest(ctx context.Context, requesterID common.Reque
 	// (DA Node) Store the rate params and account ID along with the blob
 }
 
+// RetryAfter returns the time until every bucket of the given requesterID has refilled enough to admit a request of the
+// given size. Buckets that have never been used are full. Note that a request whose deduction exceeds the size of a bucket
+// can never be admitted, so the returned hint is only meaningful for requests that fit in every bucket.
+func (d *rateLimiter) RetryAfter(ctx context.Context, requesterID common.RequesterID, blobSize uint, rate common.RateParam) (time.Duration, error) {
+
+	bucketParams, err := d.bucketStore.GetItem(ctx, requesterID)
+	if err != nil {
+		// No bucket state means the buckets are full
+		return 0, nil
+	}
+
+	interval := time.Since(bucketParams.LastRequestTime)
+
+	var retryAfter time.Duration
+	for i, size := range d.globalRateParams.BucketSizes {
+		if i >= len(bucketParams.BucketLevels) {
+			break
+		}
+
+		level := bucketParams.BucketLevels[i] + interval
+		if level > size {
+			level = size
+		}
+
+		// A request is allowed once the level remaining after the deduction is positive
+		deduction := d.getDeduction(i, blobSize, rate)
+		if level > deduction {
+			continue
+		}
+
+		wait := deduction - level + time.Microsecond
+		if wait > retryAfter {
+			retryAfter = wait
+		}
+	}
+
+	return retryAfter, nil
+}
+
+// getDeduction returns the amount of time the given blob consumes from the i-th bucket at the given rate
+func (d *rateLimiter) getDeduction(i int, blobSize uint, rate common.RateParam) time.Duration {
+	return time.Microsecond * time.Duration(1e6*float32(blobSize)/float32(rate)/d.globalRateParams.Multipliers[i])
+}
+
 func getBucketLevel(bucketLevel, bucketSize, interval, deduction time.Duration) time.Duration {
 
 	newLevel := bucketLevel + interval - deduction
@@ -50,3 +50,45 @@ func TestRatelimit(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, false, allow)
 }
+
+func TestRetryAfter(t *testing.T) {
+
+	ratelimiter, err := makeTestRatelimiter()
+	assert.NoError(t, err)
+
+	ctx := context.Background()
+
+	retreiverID := "testRetriever"
+
+	// Unknown requesters have full buckets
+	retryAfter, err := ratelimiter.RetryAfter(ctx, retreiverID, 10, 100)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Duration(0), retryAfter)
+
+	for i := 0; i < 10; i++ {
+		allow, err := ratelimiter.AllowRequest(ctx, retreiverID, 10, 100)
+		assert.NoError(t, err)
+		assert.Equal(t, true, allow)
+	}
+
+	allow, err := ratelimiter.AllowRequest(ctx, retreiverID, 10, 100)
+	assert.NoError(t, err)
+	assert.Equal(t, false, allow)
+
+	// 10 bytes at 100 bytes/sec needs 100ms worth of bucket capacity
+	retryAfter, err = ratelimiter.RetryAfter(ctx, retreiverID, 10, 100)
+	assert.NoError(t, err)
+	assert.Greater(t, retryAfter, time.Duration(0))
+	assert.LessOrEqual(t, retryAfter, 100*time.Millisecond+time.Microsecond)
+
+	// Larger requests need proportionally longer
+	largeRetryAfter, err := ratelimiter.RetryAfter(ctx, retreiverID, 50, 100)
+	assert.NoError(t, err)
+	assert.Greater(t, largeRetryAfter, 400*time.Millisecond)
+
+	time.Sleep(retryAfter)
+
+	allow, err = ratelimiter.AllowRequest(ctx, retreiverID, 10, 100)
+	assert.NoError(t, err)
+	assert.Equal(t, true, allow)
+}
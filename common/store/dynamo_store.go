@@ -37,7 +37,7 @@ func (s *dynamodbBucketStore[T]) GetItem(ctx context.Context, requesterID string
 		return nil, err
 	}
 	if item == nil {
-		return nil, fmt.Errorf("item not found")
+		return nil, fmt.Errorf("item not found: %w", common.ErrKeyNotFound)
 	}
 
 	params := new(T)
@@ -2,7 +2,7 @@ package store
 
 import (
 	"context"
-	"errors"
+	"fmt"
 
 	"github.com/Layr-Labs/eigenda/common"
 	lru "github.com/hashicorp/golang-lru/v2"
@@ -27,7 +27,7 @@ func (s *localParamStore[T]) GetItem(ctx context.Context, key string) (*T, error
 
 	obj, ok := s.cache.Get(key)
 	if !ok {
-		return nil, errors.New("error retrieving key")
+		return nil, fmt.Errorf("error retrieving key: %w", common.ErrKeyNotFound)
 	}
 
 	return &obj, nil
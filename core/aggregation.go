@@ -3,6 +3,7 @@ package core
 import (
 	"bytes"
 	"errors"
+	"fmt"
 	"math/big"
 	"sort"
 
@@ -14,12 +15,21 @@ var (
 	ErrPubKeysNotEqual     = errors.New("public keys are not equal")
 	ErrInsufficientEthSigs = errors.New("insufficient eth signatures")
 	ErrAggSigNotValid      = errors.New("aggregated signature is not valid")
+	// ErrBatchExpired is the error of the operators which refused a batch received after its dispersal deadline. It
+	// is a soft failure: the operator is healthy, but the batch reached it too late to be signed.
+	ErrBatchExpired = errors.New("batch received after its dispersal deadline")
 )
 
+// BatchExpiredReason is the reason of the ErrorInfo details of the errors with which the nodes refuse the batches
+// received after their dispersal deadline
+const BatchExpiredReason = "BATCH_EXPIRED"
+
 type SignerMessage struct {
 	Signature *Signature
 	Operator  OperatorID
-	Err       error
+	// Version is the version and the features advertised by the operator along with its signature
+	Version NodeVersion
+	Err     error
 }
 
 // SignatureAggregation contains the results of aggregating signatures from a set of operators
@@ -91,6 +101,10 @@ func (a *StdSignatureAggregator) AggregateSignatures(state *IndexedOperatorState
 		if op, ok := state.IndexedOperators[r.Operator]; ok {
 			socket = op.Socket
 		}
+		if errors.Is(r.Err, ErrBatchExpired) {
+			a.Logger.Info("[AggregateSignatures] batch expired before reaching operator", "operator", operatorIDHex, "socket", socket, "err", r.Err)
+			continue
+		}
 		if r.Err != nil {
 			a.Logger.Warn("[AggregateSignatures] error returned from messageChan", "operator", operatorIDHex, "socket", socket, "err", r.Err)
 			continue
@@ -245,3 +259,93 @@ func GetSignedPercentage(state *OperatorState, quorum QuorumID, stakeAmount *big
 
 	return quorumThreshold
 }
+
+// BatchSignature is the aggregated signature of the operators over a batch header, as confirmed on chain
+type BatchSignature struct {
+	// AggSignature is the aggregated signature of the signers, aggregated across the quorums like
+	// SignatureAggregation.AggSignature
+	AggSignature *Signature
+	// AggPubKey is the aggregated public key of the signers, aggregated across the quorums like
+	// SignatureAggregation.AggPubKey
+	AggPubKey *G2Point
+	// NonSigners are the operators which did not sign the batch header
+	NonSigners []*NonSigner
+}
+
+// NonSigner is an operator which did not sign a batch header
+type NonSigner struct {
+	PubKey *G1Point
+	// Stakes contains the stake of the operator in each of the quorums it belongs to
+	Stakes map[QuorumID]*big.Int
+}
+
+// QuorumAggregate contains the aggregated public key and the total stake of all of the operators of a quorum
+type QuorumAggregate struct {
+	AggPubKey  *G1Point
+	TotalStake *big.Int
+}
+
+// VerifyBatchSignature verifies that the batch header was signed by all of the operators of the quorums but the
+// non-signers, and returns the percentage of the stake of each quorum which signed it. This allows clients to verify
+// independently that a batch was signed by enough stake, given the operator sets of the quorums at the reference block.
+func VerifyBatchSignature(header *BatchHeader, signature *BatchSignature, quorums map[QuorumID]*QuorumAggregate) (map[QuorumID]*QuorumResult, error) {
+	if signature.AggSignature == nil || signature.AggPubKey == nil {
+		return nil, ErrAggSigNotValid
+	}
+	message, err := header.GetBatchHeaderHash()
+	if err != nil {
+		return nil, err
+	}
+
+	var signersAggKey *G1Point
+	quorumResults := make(map[QuorumID]*QuorumResult, len(quorums))
+	for id, quorum := range quorums {
+		if quorum.TotalStake.Sign() <= 0 {
+			return nil, fmt.Errorf("quorum %d has no stake", id)
+		}
+
+		// The signers of the quorum are all of its operators but the non-signers
+		quorumSignersKey := quorum.AggPubKey.Deserialize(quorum.AggPubKey.Serialize())
+		signedStake := new(big.Int).Set(quorum.TotalStake)
+		for _, nonSigner := range signature.NonSigners {
+			stake, ok := nonSigner.Stakes[id]
+			if !ok {
+				continue
+			}
+			quorumSignersKey.Sub(nonSigner.PubKey)
+			signedStake.Sub(signedStake, stake)
+		}
+		if signedStake.Sign() < 0 {
+			return nil, fmt.Errorf("non-signers of quorum %d have more stake than the quorum", id)
+		}
+
+		if signersAggKey == nil {
+			signersAggKey = quorumSignersKey
+		} else {
+			signersAggKey.Add(quorumSignersKey)
+		}
+
+		signedStake.Mul(signedStake, new(big.Int).SetUint64(PercentMultiplier))
+		quorumResults[id] = &QuorumResult{
+			QuorumID:      id,
+			PercentSigned: uint8(signedStake.Div(signedStake, quorum.TotalStake).Uint64()),
+		}
+	}
+	if signersAggKey == nil {
+		return nil, errors.New("no quorum to verify the signature against")
+	}
+
+	// Verify that the G2 public key of the signers matches their G1 public key derived from the operator sets
+	ok, err := signersAggKey.VerifyEquivalence(signature.AggPubKey)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrPubKeysNotEqual
+	}
+	if !signature.AggSignature.Verify(signature.AggPubKey, message) {
+		return nil, ErrAggSigNotValid
+	}
+
+	return quorumResults, nil
+}
@@ -3,6 +3,7 @@ package core_test
 import (
 	"context"
 	"errors"
+	"fmt"
 	"log"
 	"math/big"
 	"testing"
@@ -161,3 +162,133 @@ func TestSortNonsigners(t *testing.T) {
 		assert.Equal(t, currHashInt.Cmp(prevHashInt), 1)
 	}
 }
+
+// makeBatchSignatureVector makes a batch signature of four operators with fixed keys and stakes 1 to 4. All of them are
+// in quorum 0 and the first three in quorum 1. The last operator doesn't sign.
+func makeBatchSignatureVector(t *testing.T, header *core.BatchHeader) (*core.BatchSignature, map[core.QuorumID]*core.QuorumAggregate) {
+	message, err := header.GetBatchHeaderHash()
+	assert.NoError(t, err)
+
+	quorums := map[core.QuorumID]*core.QuorumAggregate{
+		0: {TotalStake: big.NewInt(10)},
+		1: {TotalStake: big.NewInt(6)},
+	}
+	signature := &core.BatchSignature{}
+	for i := 0; i < 4; i++ {
+		keyPair, err := core.MakeKeyPairFromString(fmt.Sprint(i + 1))
+		assert.NoError(t, err)
+		operatorQuorums := []core.QuorumID{0}
+		if i < 3 {
+			operatorQuorums = append(operatorQuorums, 1)
+		}
+
+		stakes := make(map[core.QuorumID]*big.Int)
+		for _, id := range operatorQuorums {
+			stakes[id] = big.NewInt(int64(i + 1))
+			if quorums[id].AggPubKey == nil {
+				quorums[id].AggPubKey = keyPair.GetPubKeyG1().Deserialize(keyPair.GetPubKeyG1().Serialize())
+			} else {
+				quorums[id].AggPubKey.Add(keyPair.GetPubKeyG1())
+			}
+		}
+		if i == 3 {
+			signature.NonSigners = append(signature.NonSigners, &core.NonSigner{PubKey: keyPair.GetPubKeyG1(), Stakes: stakes})
+			continue
+		}
+
+		// The signature of an operator is aggregated once per quorum it signs for
+		for range operatorQuorums {
+			if signature.AggSignature == nil {
+				signature.AggSignature = keyPair.SignMessage(message)
+				signature.AggPubKey = keyPair.GetPubKeyG2()
+			} else {
+				signature.AggSignature.Add(keyPair.SignMessage(message).G1Point)
+				signature.AggPubKey.Add(keyPair.GetPubKeyG2())
+			}
+		}
+	}
+	return signature, quorums
+}
+
+func TestVerifyBatchSignature(t *testing.T) {
+	header := &core.BatchHeader{
+		ReferenceBlockNumber: 100,
+		BatchRoot:            [32]byte{1, 2, 3},
+	}
+
+	signature, quorums := makeBatchSignatureVector(t, header)
+	quorumResults, err := core.VerifyBatchSignature(header, signature, quorums)
+	assert.NoError(t, err)
+	assert.Equal(t, map[core.QuorumID]*core.QuorumResult{
+		0: {QuorumID: 0, PercentSigned: 60},
+		1: {QuorumID: 1, PercentSigned: 100},
+	}, quorumResults)
+
+	// The signature is over another batch header
+	otherHeader := &core.BatchHeader{
+		ReferenceBlockNumber: 101,
+		BatchRoot:            header.BatchRoot,
+	}
+	_, err = core.VerifyBatchSignature(otherHeader, signature, quorums)
+	assert.ErrorIs(t, err, core.ErrAggSigNotValid)
+
+	// A non-signer is hidden, to claim more stake signed
+	signature, quorums = makeBatchSignatureVector(t, header)
+	signature.NonSigners = nil
+	_, err = core.VerifyBatchSignature(header, signature, quorums)
+	assert.ErrorIs(t, err, core.ErrPubKeysNotEqual)
+
+	// The signature of the quorums isn't aggregated as many times as the signers are in the quorums
+	signature, quorums = makeBatchSignatureVector(t, header)
+	delete(quorums, 1)
+	_, err = core.VerifyBatchSignature(header, signature, quorums)
+	assert.ErrorIs(t, err, core.ErrPubKeysNotEqual)
+
+	signature, quorums = makeBatchSignatureVector(t, header)
+	signature.AggSignature = nil
+	_, err = core.VerifyBatchSignature(header, signature, quorums)
+	assert.ErrorIs(t, err, core.ErrAggSigNotValid)
+}
+
+func TestVerifyBatchSignatureFromAggregation(t *testing.T) {
+	state := dat.GetTotalOperatorState(context.Background(), 0)
+	header := &core.BatchHeader{
+		ReferenceBlockNumber: 0,
+		BatchRoot:            [32]byte{4, 5, 6},
+	}
+	message, err := header.GetBatchHeaderHash()
+	assert.NoError(t, err)
+
+	update := make(chan core.SignerMessage)
+	go simulateOperators(*state, message, update, 2)
+	quorumIDs := []core.QuorumID{0, 1}
+	sigAgg, err := agg.AggregateSignatures(state.IndexedOperatorState, quorumIDs, message, update)
+	assert.NoError(t, err)
+
+	signature := &core.BatchSignature{
+		AggSignature: sigAgg.AggSignature,
+		AggPubKey:    sigAgg.AggPubKey,
+	}
+	for _, pubKey := range sigAgg.NonSigners {
+		nonSigner := &core.NonSigner{PubKey: pubKey, Stakes: make(map[core.QuorumID]*big.Int)}
+		for operatorID, op := range state.IndexedOperators {
+			if op.PubkeyG1.Equal(pubKey.G1Affine) {
+				for _, id := range quorumIDs {
+					nonSigner.Stakes[id] = state.Operators[id][operatorID].Stake
+				}
+			}
+		}
+		signature.NonSigners = append(signature.NonSigners, nonSigner)
+	}
+	quorums := make(map[core.QuorumID]*core.QuorumAggregate)
+	for _, id := range quorumIDs {
+		quorums[id] = &core.QuorumAggregate{
+			AggPubKey:  state.AggKeys[id],
+			TotalStake: state.Totals[id].Stake,
+		}
+	}
+
+	quorumResults, err := core.VerifyBatchSignature(header, signature, quorums)
+	assert.NoError(t, err)
+	assert.Equal(t, sigAgg.QuorumResults, quorumResults)
+}
@@ -2,7 +2,12 @@ package core
 
 import (
 	"errors"
+	"fmt"
 	"math/big"
+	"math/bits"
+
+	"github.com/Layr-Labs/eigenda/pkg/encoding/encoder"
+	"github.com/Layr-Labs/eigenda/pkg/kzg/bn254"
 )
 
 // Assignment
@@ -40,13 +45,15 @@ func (c *Assignment) GetIndices() []ChunkNumber {
 type AssignmentCoordinator interface {
 
 	// GetAssignments calculates the full set of node assignments. The assignment of indices to nodes depends only on the OperatorState
-	// for a given quorum and the quantizationFactor. In particular, it does not depend on the security parameters.
-	GetAssignments(state *OperatorState, quorumID QuorumID, quantizationFactor uint) (map[OperatorID]Assignment, AssignmentInfo, error)
+	// for a given quorum, the quantizationFactor and the overprovisionPercent, by which the number of chunks of each node is inflated.
+	// In particular, it does not depend on the security parameters.
+	GetAssignments(state *OperatorState, quorumID QuorumID, quantizationFactor, overprovisionPercent uint) (map[OperatorID]Assignment, AssignmentInfo, error)
 
 	// GetOperatorAssignment calculates the assignment for a specific DA node
-	GetOperatorAssignment(state *OperatorState, quorum QuorumID, quantizationFactor uint, id OperatorID) (Assignment, AssignmentInfo, error)
+	GetOperatorAssignment(state *OperatorState, quorum QuorumID, quantizationFactor, overprovisionPercent uint, id OperatorID) (Assignment, AssignmentInfo, error)
 
-	// GetMinimumChunkLength calculates the minimum chunkSize that is sufficient for a given blob for each quorum
+	// GetMinimumChunkLength calculates the minimum chunkSize that is sufficient for a given blob for each quorum, raised to
+	// the floor of the coordinator if any
 	GetMinimumChunkLength(numOperators, blobLength, quantizationFactor uint, quorumThreshold, adversaryThreshold uint8) (uint, error)
 
 	// GetChunkLengthFromHeader calculates the chunk length from the blob header
@@ -57,21 +64,50 @@ type AssignmentCoordinator interface {
 
 const PercentMultiplier = 100
 
+// MaxOverprovisionPercent is the max margin by which the chunks of a quorum can be over-provisioned
+const MaxOverprovisionPercent = 100
+
 var (
 	ErrNotFound = errors.New("not found")
+	// ErrOverflow is returned when the number of chunks or the encoded length of a blob overflows, e.g. for a header with a
+	// huge quantization factor, rather than wrapping around to a value which could pass the validation
+	ErrOverflow = errors.New("encoding parameters overflow")
 )
 
+// MaxMinChunkLength bounds the floor of the chunk lengths. The validators accept the chunk lengths raised by a floor up
+// to this length, so that the dispersers can set their floor independently of the nodes.
+const MaxMinChunkLength = 256
+
 type StdAssignmentCoordinator struct {
+	// MinChunkLength is the floor of the chunk lengths returned by GetMinimumChunkLength, so that the chunks of the small
+	// blobs or of the large quorums aren't degenerate, with a proof for every symbol or two. The floor doesn't change the
+	// number of chunks of a quorum (AssignmentInfo.TotalChunks), which is derived from the stakes: the encoded length of
+	// the blob, ChunkLength * NumChunks, grows with the chunk length instead, and must stay within the SRS. No floor is
+	// applied when it is 0, and it must not exceed MaxMinChunkLength.
+	MinChunkLength uint
+	// LargeQuorumOperatorThreshold and LargeQuorumMinChunkBytes raise the chunk lengths of the quorums of at least
+	// LargeQuorumOperatorThreshold operators, whose chunks are the shortest, to LargeQuorumMinChunkBytes bytes, rounded
+	// up to a power of 2 symbols and bounded by MaxMinChunkLength. The number of chunks, and so of proofs, of a blob is
+	// still derived from the stakes, but each proof covers more symbols: the encoding is padded, in exchange for fewer
+	// proofs per encoded byte. The raised chunk length is recorded in the EncodedBlobLength of the BlobQuorumInfo, from
+	// which the validators derive it. No chunk length is raised when either is 0.
+	LargeQuorumOperatorThreshold uint
+	LargeQuorumMinChunkBytes     uint
 }
 
 var _ AssignmentCoordinator = (*StdAssignmentCoordinator)(nil)
 
-func (c *StdAssignmentCoordinator) GetAssignments(state *OperatorState, quorum QuorumID, quantizationFactor uint) (map[OperatorID]Assignment, AssignmentInfo, error) {
+func (c *StdAssignmentCoordinator) GetAssignments(state *OperatorState, quorum QuorumID, quantizationFactor, overprovisionPercent uint) (map[OperatorID]Assignment, AssignmentInfo, error) {
+
+	if overprovisionPercent > MaxOverprovisionPercent {
+		return nil, AssignmentInfo{}, fmt.Errorf("overprovision percent %d exceeds the max of %d", overprovisionPercent, MaxOverprovisionPercent)
+	}
 
 	numOperators := len(state.Operators[quorum])
 	numOperatorsBig := new(big.Int).SetUint64(uint64(numOperators))
 
 	quantizationFactorBig := new(big.Int).SetUint64(uint64(quantizationFactor))
+	overprovisionBig := new(big.Int).SetUint64(uint64(PercentMultiplier + overprovisionPercent))
 
 	chunksByOperator := make([]uint, numOperators)
 
@@ -80,11 +116,21 @@ func (c *StdAssignmentCoordinator) GetAssignments(state *OperatorState, quorum Q
 	totalStakes := state.Totals[quorum].Stake
 	for _, r := range state.Operators[quorum] {
 
+		// The chunks of each operator are inflated by the margin, so that the operators holding the fraction of the stake
+		// required to reconstruct the blob hold the margin in excess of the chunks needed
 		m := new(big.Int).Mul(numOperatorsBig, r.Stake)
 		m = m.Mul(m, quantizationFactorBig)
-		m = roundUpDivideBig(m, totalStakes)
+		m = m.Mul(m, overprovisionBig)
+		m = roundUpDivideBig(m, new(big.Int).Mul(totalStakes, big.NewInt(PercentMultiplier)))
+		if !m.IsUint64() || m.Uint64() > uint64(^uint(0)) {
+			return nil, AssignmentInfo{}, fmt.Errorf("%w: the %s chunks of an operator of quorum %d", ErrOverflow, m, quorum)
+		}
 
-		numChunks += uint(m.Uint64())
+		var err error
+		numChunks, err = checkedAdd(numChunks, uint(m.Uint64()))
+		if err != nil {
+			return nil, AssignmentInfo{}, fmt.Errorf("the total number of chunks of quorum %d: %w", quorum, err)
+		}
 		chunksByOperator[r.Index] = uint(m.Uint64())
 	}
 
@@ -134,9 +180,9 @@ func getOperatorAtIndex(headerHash [32]byte, index, numOperators int) int {
 	return int(operatorIndex.Uint64())
 }
 
-func (c *StdAssignmentCoordinator) GetOperatorAssignment(state *OperatorState, quorum QuorumID, quantizationFactor uint, id OperatorID) (Assignment, AssignmentInfo, error) {
+func (c *StdAssignmentCoordinator) GetOperatorAssignment(state *OperatorState, quorum QuorumID, quantizationFactor, overprovisionPercent uint, id OperatorID) (Assignment, AssignmentInfo, error) {
 
-	assignments, info, err := c.GetAssignments(state, quorum, quantizationFactor)
+	assignments, info, err := c.GetAssignments(state, quorum, quantizationFactor, overprovisionPercent)
 	if err != nil {
 		return Assignment{}, AssignmentInfo{}, err
 	}
@@ -155,25 +201,72 @@ func (c *StdAssignmentCoordinator) GetMinimumChunkLength(numOperators, blobLengt
 		return 0, errors.New("invalid header: quorum threshold does not exceed adversary threshold")
 	}
 
-	numSys := roundUpDivide(uint(quorumThreshold-adversaryThreshold)*numOperators*quantizationFactor, PercentMultiplier)
+	numSys, err := checkedMul(uint(quorumThreshold-adversaryThreshold), numOperators, quantizationFactor)
+	if err != nil {
+		return 0, fmt.Errorf("the number of systematic chunks of %d operators with a quantization factor of %d: %w", numOperators, quantizationFactor, err)
+	}
+	numSys = roundUpDivide(numSys, PercentMultiplier)
 	chunkLength := roundUpDivide(blobLength, numSys)
+	if minChunkLength := c.minChunkLength(numOperators); chunkLength < minChunkLength {
+		chunkLength = minChunkLength
+	}
 	return chunkLength, nil
 
 }
 
+// minChunkLength returns the floor of the chunk lengths of a quorum of numOperators operators
+func (c *StdAssignmentCoordinator) minChunkLength(numOperators uint) uint {
+	minChunkLength := c.MinChunkLength
+	if c.LargeQuorumOperatorThreshold == 0 || c.LargeQuorumMinChunkBytes == 0 || numOperators < c.LargeQuorumOperatorThreshold {
+		return minChunkLength
+	}
+
+	// The validators only accept the chunk lengths raised to a power of 2 up to MaxMinChunkLength
+	largeQuorumMinChunkLength := uint(MaxMinChunkLength)
+	if c.LargeQuorumMinChunkBytes < MaxMinChunkLength*bn254.BYTES_PER_COEFFICIENT {
+		largeQuorumMinChunkLength = uint(encoder.NextPowerOf2(uint64(roundUpDivide(c.LargeQuorumMinChunkBytes, bn254.BYTES_PER_COEFFICIENT))))
+	}
+	if largeQuorumMinChunkLength > minChunkLength {
+		minChunkLength = largeQuorumMinChunkLength
+	}
+	return minChunkLength
+}
+
 func (c *StdAssignmentCoordinator) GetChunkLengthFromHeader(state *OperatorState, header *BlobQuorumInfo) (uint, error) {
 
 	// Validate the chunk length
 	numOperators := uint(len(state.Operators[header.QuorumID]))
-	chunkLength := header.EncodedBlobLength / (header.QuantizationFactor * numOperators)
+	numChunks, err := GetNumNominalChunks(numOperators, header.QuantizationFactor, header.OverprovisionPercent)
+	if err != nil {
+		return 0, err
+	}
+	if numChunks == 0 {
+		return 0, errors.New("invalid header")
+	}
+	chunkLength := header.EncodedBlobLength / numChunks
 
-	if chunkLength*header.QuantizationFactor*numOperators != header.EncodedBlobLength {
+	if chunkLength*numChunks != header.EncodedBlobLength {
 		return 0, errors.New("invalid header")
 	}
 
 	return chunkLength, nil
 }
 
+// GetNumNominalChunks returns the nominal number of chunks of a quorum, QuantizationFactor * NumOperatorsForQuorum,
+// inflated by the overprovisionPercent and rounded up. The encoded length of a blob is its chunk length times the
+// nominal number of chunks. ErrOverflow is returned if the number of chunks doesn't fit in a uint.
+func GetNumNominalChunks(numOperators, quantizationFactor, overprovisionPercent uint) (uint, error) {
+	overprovision, err := checkedAdd(PercentMultiplier, overprovisionPercent)
+	if err != nil {
+		return 0, fmt.Errorf("the overprovision percent %d: %w", overprovisionPercent, err)
+	}
+	numChunks, err := checkedMul(numOperators, quantizationFactor, overprovision)
+	if err != nil {
+		return 0, fmt.Errorf("the number of chunks of %d operators with a quantization factor of %d: %w", numOperators, quantizationFactor, err)
+	}
+	return roundUpDivide(numChunks, PercentMultiplier), nil
+}
+
 func roundUpDivideBig(a, b *big.Int) *big.Int {
 
 	one := new(big.Int).SetUint64(1)
@@ -185,7 +278,46 @@ func roundUpDivideBig(a, b *big.Int) *big.Int {
 
 }
 
+// GetNominalEncodedBlobLength returns the encoded length of a blob of a quorum, its chunk length times the nominal number of
+// chunks of the quorum. ErrOverflow is returned if the encoded length doesn't fit in a uint.
+func GetNominalEncodedBlobLength(chunkLength, numOperators, quantizationFactor, overprovisionPercent uint) (uint, error) {
+	numChunks, err := GetNumNominalChunks(numOperators, quantizationFactor, overprovisionPercent)
+	if err != nil {
+		return 0, err
+	}
+	encodedLength, err := checkedMul(chunkLength, numChunks)
+	if err != nil {
+		return 0, fmt.Errorf("the encoded length of %d chunks of length %d: %w", numChunks, chunkLength, err)
+	}
+	return encodedLength, nil
+}
+
+// roundUpDivide divides a by b rounding up, without overflowing for a close to the max uint
 func roundUpDivide(a, b uint) uint {
-	return (a + b - 1) / b
+	if a%b == 0 {
+		return a / b
+	}
+	return a/b + 1
+}
 
+// checkedMul returns the product of the values, or ErrOverflow if it doesn't fit in a uint
+func checkedMul(values ...uint) (uint, error) {
+	product := uint(1)
+	for _, value := range values {
+		hi, lo := bits.Mul(product, value)
+		if hi != 0 {
+			return 0, ErrOverflow
+		}
+		product = lo
+	}
+	return product, nil
+}
+
+// checkedAdd returns the sum of a and b, or ErrOverflow if it doesn't fit in a uint
+func checkedAdd(a, b uint) (uint, error) {
+	sum, carry := bits.Add(a, b, 0)
+	if carry != 0 {
+		return 0, ErrOverflow
+	}
+	return sum, nil
 }
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/pkg/kzg/bn254"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -21,7 +22,7 @@ func TestOperatorAssignments(t *testing.T) {
 	operatorState := state.OperatorState
 	coordinator := &core.StdAssignmentCoordinator{}
 
-	assignments, info, err := coordinator.GetAssignments(operatorState, 0, uint(2))
+	assignments, info, err := coordinator.GetAssignments(operatorState, 0, uint(2), 0)
 	assert.NoError(t, err)
 	expectedAssignments := map[core.OperatorID]core.Assignment{
 		makeOperatorId(0): {
@@ -74,7 +75,7 @@ func TestOperatorAssignments(t *testing.T) {
 	for operatorID, assignment := range assignments {
 		assert.Equal(t, assignment, expectedAssignments[operatorID])
 
-		assignment, info, err := coordinator.GetOperatorAssignment(operatorState, 0, uint(2), operatorID)
+		assignment, info, err := coordinator.GetOperatorAssignment(operatorState, 0, uint(2), 0, operatorID)
 		assert.NoError(t, err)
 
 		assert.Equal(t, assignment, expectedAssignments[operatorID])
@@ -83,3 +84,141 @@ func TestOperatorAssignments(t *testing.T) {
 	}
 
 }
+
+func TestEncodedLengthOverflow(t *testing.T) {
+	maxUint := ^uint(0)
+
+	// The largest quantization factor whose nominal number of chunks still fits in a uint
+	maxQuantizationFactor := maxUint / core.PercentMultiplier
+	numChunks, err := core.GetNumNominalChunks(1, maxQuantizationFactor, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, maxQuantizationFactor, numChunks)
+
+	tests := []struct {
+		name                 string
+		chunkLength          uint
+		numOperators         uint
+		quantizationFactor   uint
+		overprovisionPercent uint
+	}{
+		{name: "quantization factor", chunkLength: 1, numOperators: 1, quantizationFactor: maxQuantizationFactor + 1},
+		{name: "number of operators", chunkLength: 1, numOperators: maxUint/core.PercentMultiplier + 1, quantizationFactor: 1},
+		{name: "overprovision percent", chunkLength: 1, numOperators: 1, quantizationFactor: 1, overprovisionPercent: maxUint},
+		{name: "overprovisioned chunks", chunkLength: 1, numOperators: 1, quantizationFactor: maxQuantizationFactor, overprovisionPercent: 1},
+		{name: "chunk length", chunkLength: maxUint/2 + 1, numOperators: 2, quantizationFactor: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := core.GetNominalEncodedBlobLength(tt.chunkLength, tt.numOperators, tt.quantizationFactor, tt.overprovisionPercent)
+			assert.ErrorIs(t, err, core.ErrOverflow)
+		})
+	}
+
+	coordinator := &core.StdAssignmentCoordinator{}
+	_, err = coordinator.GetMinimumChunkLength(2, 1000, maxUint/2+1, 100, 50)
+	assert.ErrorIs(t, err, core.ErrOverflow)
+
+	maxPowerOf2 := maxUint/2 + 1
+	params, err := core.GetEncodingParams(maxPowerOf2, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, maxPowerOf2, params.ChunkLength)
+	_, err = core.GetEncodingParams(maxPowerOf2+1, 1)
+	assert.ErrorIs(t, err, core.ErrOverflow)
+	_, err = core.GetEncodingParams(1, maxPowerOf2+1)
+	assert.ErrorIs(t, err, core.ErrOverflow)
+}
+
+func TestOperatorAssignmentsOverflow(t *testing.T) {
+	state := dat.GetTotalOperatorState(context.Background(), 0)
+	coordinator := &core.StdAssignmentCoordinator{}
+
+	// The chunks of each operator are computed with big ints, but their sum must fit in a uint
+	_, _, err := coordinator.GetAssignments(state.OperatorState, 0, ^uint(0)/2, 0)
+	assert.ErrorIs(t, err, core.ErrOverflow)
+}
+
+func TestMinimumChunkLengthFloor(t *testing.T) {
+	// A small blob spread over many operators has degenerate chunks of a single symbol
+	chunkLength, err := (&core.StdAssignmentCoordinator{}).GetMinimumChunkLength(100, 10, 1, 100, 50)
+	assert.NoError(t, err)
+	assert.Equal(t, uint(1), chunkLength)
+
+	coordinator := &core.StdAssignmentCoordinator{MinChunkLength: 16}
+	chunkLength, err = coordinator.GetMinimumChunkLength(100, 10, 1, 100, 50)
+	assert.NoError(t, err)
+	assert.Equal(t, uint(16), chunkLength)
+
+	// The chunk lengths above the floor are unchanged
+	chunkLength, err = coordinator.GetMinimumChunkLength(4, 1000, 1, 100, 50)
+	assert.NoError(t, err)
+	assert.Equal(t, uint(500), chunkLength)
+}
+
+func TestMinimumChunkLengthLargeQuorums(t *testing.T) {
+	coordinator := &core.StdAssignmentCoordinator{LargeQuorumOperatorThreshold: 200, LargeQuorumMinChunkBytes: 3900}
+
+	// The quorums below the threshold are unchanged
+	chunkLength, err := coordinator.GetMinimumChunkLength(199, 1024, 1, 100, 50)
+	assert.NoError(t, err)
+	assert.Equal(t, uint(11), chunkLength)
+
+	// 3900 bytes are 126 symbols, rounded up to 128
+	chunkLength, err = coordinator.GetMinimumChunkLength(200, 1024, 1, 100, 50)
+	assert.NoError(t, err)
+	assert.Equal(t, uint(128), chunkLength)
+
+	// The floor of the coordinator applies if higher
+	coordinator.MinChunkLength = 256
+	chunkLength, err = coordinator.GetMinimumChunkLength(200, 1024, 1, 100, 50)
+	assert.NoError(t, err)
+	assert.Equal(t, uint(256), chunkLength)
+
+	// The chunk sizes beyond MaxMinChunkLength are bounded
+	coordinator = &core.StdAssignmentCoordinator{LargeQuorumOperatorThreshold: 200, LargeQuorumMinChunkBytes: 1 << 20}
+	chunkLength, err = coordinator.GetMinimumChunkLength(1000, 1024, 1, 100, 50)
+	assert.NoError(t, err)
+	assert.Equal(t, uint(core.MaxMinChunkLength), chunkLength)
+}
+
+// TestLargeQuorumChunkSizingAnalysis quantifies the effect of the large quorum chunk sizing on the encodings of the
+// blobs of a few sizes over large quorums: the number of proofs of a blob is derived from the stakes, while the proofs
+// per MiB of encoded data drop by the factor by which the encoded size grows
+func TestLargeQuorumChunkSizingAnalysis(t *testing.T) {
+	const mib = 1 << 20
+	const minChunkBytes = 128 * bn254.BYTES_PER_COEFFICIENT
+	baseline := &core.StdAssignmentCoordinator{}
+	adaptive := &core.StdAssignmentCoordinator{LargeQuorumOperatorThreshold: 200, LargeQuorumMinChunkBytes: minChunkBytes}
+
+	encode := func(coordinator *core.StdAssignmentCoordinator, numOperators, blobLength uint) core.EncodingParams {
+		chunkLength, err := coordinator.GetMinimumChunkLength(numOperators, blobLength, 1, 100, 50)
+		assert.NoError(t, err)
+		numChunks, err := core.GetNumNominalChunks(numOperators, 1, 0)
+		assert.NoError(t, err)
+		params, err := core.GetEncodingParams(chunkLength, numChunks)
+		assert.NoError(t, err)
+		return params
+	}
+
+	for _, numOperators := range []uint{200, 1000, 4000} {
+		for _, blobSize := range []uint{1024, 64 * 1024, 2 * mib} {
+			blobLength := core.GetBlobLength(blobSize)
+			before := encode(baseline, numOperators, blobLength)
+			after := encode(adaptive, numOperators, blobLength)
+
+			// Each chunk of the large quorums holds at least minChunkBytes, or the chunks were already longer
+			chunkBytes := after.ChunkLength * bn254.BYTES_PER_COEFFICIENT
+			assert.True(t, chunkBytes >= minChunkBytes || after.ChunkLength == before.ChunkLength)
+			assert.Equal(t, before.NumChunks, after.NumChunks, "the proofs of a blob are derived from the stakes")
+
+			encodedBefore := before.ChunkLength * before.NumChunks * bn254.BYTES_PER_COEFFICIENT
+			encodedAfter := after.ChunkLength * after.NumChunks * bn254.BYTES_PER_COEFFICIENT
+			proofsPerMiBBefore := float64(before.NumChunks) * mib / float64(encodedBefore)
+			proofsPerMiBAfter := float64(after.NumChunks) * mib / float64(encodedAfter)
+			growth := float64(encodedAfter) / float64(encodedBefore)
+			assert.InDelta(t, proofsPerMiBBefore/growth, proofsPerMiBAfter, 1e-9)
+			assert.LessOrEqual(t, proofsPerMiBAfter, float64(mib)/minChunkBytes)
+			t.Logf("%d operators, %d byte blob: chunks of %d -> %d bytes, %.0f -> %.0f proofs per MiB encoded, encoded size x%.0f",
+				numOperators, blobSize, before.ChunkLength*bn254.BYTES_PER_COEFFICIENT, chunkBytes, proofsPerMiBBefore, proofsPerMiBAfter, growth)
+		}
+	}
+}
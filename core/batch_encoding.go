@@ -0,0 +1,101 @@
+package core
+
+import (
+	"fmt"
+)
+
+// BlobEncodingRequest is a blob of a batch whose encoding params are computed by GetBatchEncodingParams
+type BlobEncodingRequest struct {
+	// BlobLength is the length of the blob in symbols
+	BlobLength     uint
+	SecurityParams []*SecurityParam
+}
+
+// BlobQuorumEncoding contains the params of the encoding of a blob in one of its quorums, along with the assignments of
+// the chunks of the quorum
+type BlobQuorumEncoding struct {
+	BlobQuorumInfo
+	EncodingParams EncodingParams
+	// Assignments and AssignmentInfo are the assignments of the quorum, shared by all the blobs of the batch
+	Assignments    map[OperatorID]Assignment
+	AssignmentInfo AssignmentInfo
+}
+
+type quorumAssignments struct {
+	assignments map[OperatorID]Assignment
+	info        AssignmentInfo
+}
+
+type encodingParamsKey struct {
+	quorumID           QuorumID
+	blobLength         uint
+	quorumThreshold    uint8
+	adversaryThreshold uint8
+}
+
+// GetBatchEncodingParams computes the encoding params of each blob of a batch in each of its quorums, in the order of
+// the blobs and of their security params, against a single operator state, with the chunks of each quorum
+// over-provisioned by overprovisionPercent. The assignments of each quorum, and the params shared by the blobs of the
+// same length and security params, are only computed once. The results are the same as computing the params of each
+// blob with GetMinimumChunkLength and GetEncodingParams.
+func GetBatchEncodingParams(asgn AssignmentCoordinator, state *OperatorState, quantizationFactor, overprovisionPercent uint, blobs []BlobEncodingRequest) ([][]*BlobQuorumEncoding, error) {
+	assignments := make(map[QuorumID]quorumAssignments)
+	params := make(map[encodingParamsKey]EncodingParams)
+
+	results := make([][]*BlobQuorumEncoding, len(blobs))
+	for i, blob := range blobs {
+		results[i] = make([]*BlobQuorumEncoding, len(blob.SecurityParams))
+		for j, param := range blob.SecurityParams {
+			numOperators := uint(len(state.Operators[param.QuorumID]))
+			if numOperators == 0 {
+				return nil, fmt.Errorf("blob %d: quorum %d has no operators at block %d", i, param.QuorumID, state.BlockNumber)
+			}
+
+			quorum, ok := assignments[param.QuorumID]
+			if !ok {
+				a, info, err := asgn.GetAssignments(state, param.QuorumID, quantizationFactor, overprovisionPercent)
+				if err != nil {
+					return nil, fmt.Errorf("failed to get the assignments of quorum %d: %w", param.QuorumID, err)
+				}
+				quorum = quorumAssignments{assignments: a, info: info}
+				assignments[param.QuorumID] = quorum
+			}
+
+			key := encodingParamsKey{
+				quorumID:           param.QuorumID,
+				blobLength:         blob.BlobLength,
+				quorumThreshold:    param.QuorumThreshold,
+				adversaryThreshold: param.AdversaryThreshold,
+			}
+			encodingParams, ok := params[key]
+			if !ok {
+				chunkLength, err := asgn.GetMinimumChunkLength(numOperators, blob.BlobLength, quantizationFactor, param.QuorumThreshold, param.AdversaryThreshold)
+				if err != nil {
+					return nil, fmt.Errorf("blob %d, quorum %d: %w", i, param.QuorumID, err)
+				}
+				encodingParams, err = GetEncodingParams(chunkLength, quorum.info.TotalChunks)
+				if err != nil {
+					return nil, fmt.Errorf("blob %d, quorum %d: %w", i, param.QuorumID, err)
+				}
+				params[key] = encodingParams
+			}
+			encodedLength, err := GetNominalEncodedBlobLength(encodingParams.ChunkLength, numOperators, quantizationFactor, overprovisionPercent)
+			if err != nil {
+				return nil, fmt.Errorf("blob %d, quorum %d: %w", i, param.QuorumID, err)
+			}
+
+			results[i][j] = &BlobQuorumEncoding{
+				BlobQuorumInfo: BlobQuorumInfo{
+					SecurityParam:        *param,
+					QuantizationFactor:   quantizationFactor,
+					OverprovisionPercent: overprovisionPercent,
+					EncodedBlobLength:    encodedLength,
+				},
+				EncodingParams: encodingParams,
+				Assignments:    quorum.assignments,
+				AssignmentInfo: quorum.info,
+			}
+		}
+	}
+	return results, nil
+}
@@ -0,0 +1,88 @@
+package core_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetBatchEncodingParams(t *testing.T) {
+	asn := &core.StdAssignmentCoordinator{}
+	state, err := dat.GetOperatorState(context.Background(), 0, []core.QuorumID{0, 1, 2})
+	require.NoError(t, err)
+
+	params := []*core.SecurityParam{
+		{QuorumID: 0, AdversaryThreshold: 50, QuorumThreshold: 100},
+		{QuorumID: 1, AdversaryThreshold: 80, QuorumThreshold: 90, QuorumRate: 32000},
+		{QuorumID: 2, AdversaryThreshold: 33, QuorumThreshold: 67},
+		{QuorumID: 0, AdversaryThreshold: 10, QuorumThreshold: 20},
+	}
+	blobs := []core.BlobEncodingRequest{
+		{BlobLength: 1, SecurityParams: params[:1]},
+		{BlobLength: 100, SecurityParams: params[:3]},
+		{BlobLength: 100, SecurityParams: params[1:3]},
+		{BlobLength: 1000, SecurityParams: params[3:]},
+		{BlobLength: 16384, SecurityParams: params},
+	}
+
+	for _, quantizationFactor := range []uint{1, 2, 10} {
+		for _, overprovisionPercent := range []uint{0, 50} {
+			encodings, err := core.GetBatchEncodingParams(asn, state, quantizationFactor, overprovisionPercent, blobs)
+			require.NoError(t, err)
+			require.Len(t, encodings, len(blobs))
+
+			// The params are those of the per blob path
+			for i, blob := range blobs {
+				require.Len(t, encodings[i], len(blob.SecurityParams))
+				for j, param := range blob.SecurityParams {
+					assignments, info, err := asn.GetAssignments(state, param.QuorumID, quantizationFactor, overprovisionPercent)
+					require.NoError(t, err)
+					numOperators := uint(len(state.Operators[param.QuorumID]))
+					chunkLength, err := asn.GetMinimumChunkLength(numOperators, blob.BlobLength, quantizationFactor, param.QuorumThreshold, param.AdversaryThreshold)
+					require.NoError(t, err)
+					encodingParams, err := core.GetEncodingParams(chunkLength, info.TotalChunks)
+					require.NoError(t, err)
+					encodedLength, err := core.GetNominalEncodedBlobLength(encodingParams.ChunkLength, numOperators, quantizationFactor, overprovisionPercent)
+					require.NoError(t, err)
+
+					assert.Equal(t, &core.BlobQuorumEncoding{
+						BlobQuorumInfo: core.BlobQuorumInfo{
+							SecurityParam:        *param,
+							QuantizationFactor:   quantizationFactor,
+							OverprovisionPercent: overprovisionPercent,
+							EncodedBlobLength:    encodedLength,
+						},
+						EncodingParams: encodingParams,
+						Assignments:    assignments,
+						AssignmentInfo: info,
+					}, encodings[i][j], "blob %d, quorum %d, overprovision %d%%", i, param.QuorumID, overprovisionPercent)
+				}
+			}
+
+			// The assignments of a quorum are shared by the blobs
+			assert.Equal(t, reflect.ValueOf(encodings[0][0].Assignments).Pointer(), reflect.ValueOf(encodings[4][3].Assignments).Pointer())
+			assert.Equal(t, reflect.ValueOf(encodings[1][1].Assignments).Pointer(), reflect.ValueOf(encodings[2][0].Assignments).Pointer())
+		}
+	}
+}
+
+func TestGetBatchEncodingParamsErrors(t *testing.T) {
+	asn := &core.StdAssignmentCoordinator{}
+	state, err := dat.GetOperatorState(context.Background(), 0, []core.QuorumID{0})
+	require.NoError(t, err)
+
+	_, err = core.GetBatchEncodingParams(asn, state, 1, 0, []core.BlobEncodingRequest{
+		{BlobLength: 100, SecurityParams: []*core.SecurityParam{{QuorumID: 0, AdversaryThreshold: 50, QuorumThreshold: 100}}},
+		{BlobLength: 100, SecurityParams: []*core.SecurityParam{{QuorumID: 0, AdversaryThreshold: 80, QuorumThreshold: 80}}},
+	})
+	assert.ErrorContains(t, err, "blob 1, quorum 0: invalid header")
+
+	_, err = core.GetBatchEncodingParams(asn, state, 1, 0, []core.BlobEncodingRequest{
+		{BlobLength: 100, SecurityParams: []*core.SecurityParam{{QuorumID: 1, AdversaryThreshold: 50, QuorumThreshold: 100}}},
+	})
+	assert.EqualError(t, err, "blob 0: quorum 1 has no operators at block 0")
+}
@@ -1,7 +1,9 @@
 package core
 
 import (
+	"encoding/binary"
 	"errors"
+	"hash/crc32"
 
 	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/pkg/kzg/bn254"
@@ -48,6 +50,19 @@ type BlobRequestHeader struct {
 	SecurityParams []*SecurityParam `json:"security_params"`
 	// AccountID is the account that is paying for the blob to be stored
 	AccountID AccountID `json:"account_id"`
+	// Namespace is the optional, unauthenticated application identifier declared by the client
+	Namespace string `json:"namespace"`
+	// Labels are the optional, unauthenticated labels declared by the client in the metadata of its request
+	Labels map[string]string `json:"labels,omitempty"`
+	// Layout is how the data of the blob is interpreted as symbols
+	Layout BlobLayout `json:"layout,omitempty"`
+	// ContentType is the optional, unauthenticated MIME type of the data declared by the client. It doesn't affect the
+	// commitment of the blob.
+	ContentType string `json:"content_type,omitempty"`
+	// SubmitterPublicKey is the compressed ECDSA public key of the submitter of a private blob, whose data the
+	// disperser only returns to the requests signed with the key until the blob is finalized. The blob is public if it
+	// is empty.
+	SubmitterPublicKey []byte `json:"submitter_public_key,omitempty"`
 }
 
 func (h *BlobRequestHeader) Validate() error {
@@ -70,7 +85,11 @@ type BlobQuorumInfo struct {
 	SecurityParam
 	// QuantizationFactor determines the nominal number of chunks
 	QuantizationFactor uint
-	// EncodedBlobLength is the nominal endcoded length of the blob in symbols; EncodedBlobLength = QuantizationFactor * NumOperatorsForQuorum * ChunkLength
+	// OverprovisionPercent is the margin by which the chunks assigned to the operators exceed the nominal number of
+	// chunks, as a percentage of it, so that the blob can still be retrieved when some of the operators go offline.
+	// It is at most MaxOverprovisionPercent.
+	OverprovisionPercent uint
+	// EncodedBlobLength is the nominal endcoded length of the blob in symbols; EncodedBlobLength = GetNumNominalChunks(NumOperatorsForQuorum, QuantizationFactor, OverprovisionPercent) * ChunkLength
 	EncodedBlobLength uint
 }
 
@@ -123,12 +142,43 @@ type Chunk struct {
 	// interpolating polynomial, I(X), used in the KZG multi-reveal (https://dankradfeist.de/ethereum/2020/06/16/kate-polynomial-commitments.html#multiproofs)
 	Coeffs []Symbol
 	Proof  Proof
+	// Checksum is the CRC32C of the coefficients and the proof, computed when the chunk was encoded, by which a chunk
+	// corrupted on the wire or in storage is detected before its costlier verification against the blob commitment.
+	// It is 0 for the chunks encoded without checksums.
+	Checksum uint32
 }
 
+// castagnoli is the CRC32C table of the chunk checksums
+var castagnoli = crc32.MakeTable(crc32.Castagnoli)
+
 func (c *Chunk) Length() int {
 	return len(c.Coeffs)
 }
 
+// ComputeChecksum returns the CRC32C of the limbs of the coefficients and the proof of the chunk, which is how they are
+// represented in memory and serialized
+func (c *Chunk) ComputeChecksum() uint32 {
+	data := make([]byte, 0, (len(c.Coeffs)+2)*bn254.BYTES_PER_COEFFICIENT)
+	for i := range c.Coeffs {
+		for _, limb := range c.Coeffs[i] {
+			data = binary.LittleEndian.AppendUint64(data, limb)
+		}
+	}
+	for _, limb := range c.Proof.X {
+		data = binary.LittleEndian.AppendUint64(data, limb)
+	}
+	for _, limb := range c.Proof.Y {
+		data = binary.LittleEndian.AppendUint64(data, limb)
+	}
+	return crc32.Checksum(data, castagnoli)
+}
+
+// HasValidChecksum returns whether the chunk matches its checksum, which is always the case for the chunks without
+// checksum
+func (c *Chunk) HasValidChecksum() bool {
+	return c.Checksum == 0 || c.ComputeChecksum() == c.Checksum
+}
+
 // Returns the size of chunk in bytes.
 func (c *Chunk) Size() int {
 	return c.Length() * bn254.BYTES_PER_COEFFICIENT
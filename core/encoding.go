@@ -1,10 +1,14 @@
 package core
 
 import (
+	"errors"
 	"fmt"
+	"math"
+	"math/bits"
 
 	"github.com/Layr-Labs/eigenda/pkg/encoding/encoder"
 	"github.com/Layr-Labs/eigenda/pkg/kzg/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
 )
 
 // Commitments
@@ -26,6 +30,71 @@ type Symbol = bn254.Fr
 type EncodingParams struct {
 	ChunkLength uint // ChunkSize is the length of the chunk in symbols
 	NumChunks   uint
+	// Layout is how the data is interpreted as symbols when it is encoded. The chunks don't depend on it once encoded.
+	Layout BlobLayout
+}
+
+// BlobLayout is how the data of a blob is interpreted as the symbols it is encoded in
+type BlobLayout uint8
+
+const (
+	// FreeFormLayout packs any data in symbols of 31 bytes, the last one being padded with zeros
+	FreeFormLayout BlobLayout = iota
+	// FieldElementsLayout interprets the data as FieldElementsPerBlob field elements of 32 big-endian bytes each, all
+	// canonical in the bn254 scalar field. Unlike EIP-4844 blobs, whose elements are canonical in the BLS12-381 scalar
+	// field, elements at or above the bn254 modulus are rejected.
+	FieldElementsLayout
+)
+
+const (
+	FieldElementsPerBlob  = 4096
+	BytesPerFieldElement  = 32
+	FieldElementsBlobSize = FieldElementsPerBlob * BytesPerFieldElement
+)
+
+// ErrInvalidBlobLayout is returned for data which doesn't conform to the layout of its blob
+var ErrInvalidBlobLayout = errors.New("data doesn't conform to the blob layout")
+
+func (l BlobLayout) String() string {
+	switch l {
+	case FreeFormLayout:
+		return "free-form"
+	case FieldElementsLayout:
+		return "field-elements"
+	default:
+		return fmt.Sprintf("unknown(%d)", uint8(l))
+	}
+}
+
+// BlobLength returns the length in symbols of a blob of the given size in bytes in the layout
+func (l BlobLayout) BlobLength(blobSize uint) uint {
+	if l == FieldElementsLayout {
+		return blobSize / BytesPerFieldElement
+	}
+	return GetBlobLength(blobSize)
+}
+
+// ToSymbols interprets the data as symbols in the layout, returning an ErrInvalidBlobLayout error if it doesn't conform
+// to the layout
+func (l BlobLayout) ToSymbols(data []byte) ([]Symbol, error) {
+	switch l {
+	case FreeFormLayout:
+		return encoder.ToFrArray(data), nil
+	case FieldElementsLayout:
+		if len(data) != FieldElementsBlobSize {
+			return nil, fmt.Errorf("%w: a blob in the %s layout must be %d bytes, but found %d", ErrInvalidBlobLayout, l, FieldElementsBlobSize, len(data))
+		}
+		symbols := make([]Symbol, FieldElementsPerBlob)
+		for i := range symbols {
+			element := data[i*BytesPerFieldElement : (i+1)*BytesPerFieldElement]
+			if err := (*fr.Element)(&symbols[i]).SetBytesCanonical(element); err != nil {
+				return nil, fmt.Errorf("%w: field element %d is not canonical in the bn254 scalar field", ErrInvalidBlobLayout, i)
+			}
+		}
+		return symbols, nil
+	default:
+		return nil, fmt.Errorf("%w: unknown layout %d", ErrInvalidBlobLayout, uint8(l))
+	}
 }
 
 // Encoder is responsible for encoding, decoding, and chunk verification
@@ -38,13 +107,33 @@ type Encoder interface {
 	// VerifyChunks takes in the chunks, indices, commitments, and encoding parameters and returns an error if the chunks are invalid.
 	VerifyChunks(chunks []*Chunk, indices []ChunkNumber, commitments BlobCommitments, params EncodingParams) error
 
+	// VerifyChunksDetailed verifies the chunks like VerifyChunks, but reports how many chunks passed and the indices of
+	// those which failed. In the collect all mode, every chunk is verified rather than stopping at the first invalid
+	// one. The error is only returned if the chunks can't be verified at all.
+	VerifyChunksDetailed(chunks []*Chunk, indices []ChunkNumber, commitments BlobCommitments, params EncodingParams, collectAll bool) (*ChunkVerificationResult, error)
+
 	// VerifyBlobLength takes in the commitments and returns an error if the blob length is invalid.
 	VerifyBlobLength(commitments BlobCommitments) error
 
-	// Decode takes in the chunks, indices, and encoding parameters and returns the decoded blob
+	// Decode takes in the chunks, indices, and encoding parameters and returns the decoded blob, trimmed to inputSize.
+	// The chunks can be any subset of the chunks of the blob, in any order, the duplicate chunks being used once. It
+	// returns an ErrInsufficientChunks error if there are too few distinct chunks to recover the blob.
 	Decode(chunks []*Chunk, indices []ChunkNumber, params EncodingParams, inputSize uint64) ([]byte, error)
 }
 
+// ChunkVerificationResult is the result of the verification of a set of chunks
+type ChunkVerificationResult struct {
+	// NumVerified is the number of chunks which passed the verification
+	NumVerified int
+	// FailedIndices are the indices of the chunks which failed the verification, in the order of the chunks. Only the
+	// first invalid chunk is reported unless all the chunks are verified.
+	FailedIndices []ChunkNumber
+}
+
+// ErrInsufficientChunks is returned when decoding a blob from too few distinct chunks. It reports how many more are
+// needed.
+type ErrInsufficientChunks = encoder.ErrInsufficientChunks
+
 // GetBlobLength converts from blob size in bytes to blob size in symbols
 func GetBlobLength(blobSize uint) uint {
 	symSize := uint(bn254.BYTES_PER_COEFFICIENT)
@@ -64,6 +153,11 @@ func GetEncodedBlobLength(blobLength uint, quorumThreshold, advThreshold uint8)
 // GetEncodingParams takes in the minimum chunk length and the minimum number of chunks and returns the encoding parameters.
 // Both the ChunkLength and NumChunks must be powers of 2, and the ChunkLength returned here should be used in constructing the BlobHeader.
 func GetEncodingParams(minChunkLength, minNumChunks uint) (EncodingParams, error) {
+	// The next power of 2 of a value above the highest power of 2 of a uint doesn't fit in a uint
+	maxPowerOf2 := uint(1) << (bits.UintSize - 1)
+	if minChunkLength > maxPowerOf2 || minNumChunks > maxPowerOf2 {
+		return EncodingParams{}, fmt.Errorf("%w: the chunk length %d or the number of chunks %d exceeds %d", ErrOverflow, minChunkLength, minNumChunks, maxPowerOf2)
+	}
 	return EncodingParams{
 		ChunkLength: uint(encoder.NextPowerOf2(uint64(minChunkLength))),
 		NumChunks:   uint(encoder.NextPowerOf2(uint64(minNumChunks))),
@@ -73,11 +167,12 @@ func GetEncodingParams(minChunkLength, minNumChunks uint) (EncodingParams, error
 // ValidateEncodingParams takes in the encoding parameters and returns an error if they are invalid.
 func ValidateEncodingParams(params EncodingParams, blobLength, SRSOrder int) error {
 
-	if int(params.ChunkLength*params.NumChunks) >= SRSOrder {
+	encodedLength, err := checkedMul(params.ChunkLength, params.NumChunks)
+	if err != nil || encodedLength > math.MaxInt || int(encodedLength) >= SRSOrder {
 		return fmt.Errorf("the supplied encoding parameters are not valid with respect to the SRS")
 	}
 
-	if int(params.ChunkLength*params.NumChunks) < blobLength {
+	if int(encodedLength) < blobLength {
 		return fmt.Errorf("the supplied encoding parameters are not sufficient for the size of the data input")
 	}
 
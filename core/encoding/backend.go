@@ -0,0 +1,82 @@
+package encoding
+
+import (
+	"github.com/Layr-Labs/eigenda/pkg/encoding/kzgEncoder"
+	"github.com/Layr-Labs/eigenda/pkg/kzg/bn254"
+)
+
+// KZGBackend provides the cryptographic primitives used by the Encoder. The Encoder is responsible for translating between
+// the DA-level types (blobs, chunks, commitments) and the backend, which only deals with polynomials, frames and proofs.
+// This allows alternative pairing libraries to be plugged in without touching the call sites of the Encoder. The
+// backend only exchanges serialized field elements and points with the Encoder, so that it doesn't depend on the
+// pairing library of the reference backend.
+type KZGBackend interface {
+	// Commit returns the commitment to the polynomial whose coefficients are the symbols of the data along with a proof
+	// of its degree together with the opening of the encoded polynomial at all of the chunk cosets.
+	Commit(symbols []Symbol, params Params) (commit, lengthProof Point, frames []Frame, err error)
+
+	// VerifyLength verifies that the committed polynomial has a degree of at most the given degree.
+	VerifyLength(commit, lengthProof Point, degree uint64) error
+
+	// FrameVerifier returns the verifier of the frames encoded with the given params, which verifies the frames one by
+	// one. It returns an error if the params are invalid.
+	FrameVerifier(params Params) (FrameVerifier, error)
+
+	// VerifyFrames verifies the opening proofs of a set of frames at the given chunk indices, returning an error
+	// if any of them is invalid.
+	VerifyFrames(commit Point, frames []Frame, indices []uint64, params Params) error
+
+	// Decode reconstructs the original data from a sufficient set of frames. The result is trimmed to maxInputSize.
+	Decode(frames []Frame, indices []uint64, params Params, maxInputSize uint64) ([]byte, error)
+}
+
+// FrameVerifier verifies the opening proofs of the frames encoded with a set of encoding params
+type FrameVerifier interface {
+	// VerifyFrame verifies the opening proof of a single frame at the given chunk index.
+	VerifyFrame(commit Point, frame *Frame, index uint64) error
+}
+
+// NativeKZGBackend is a KZGBackend which also works on the gnark-crypto bn254 types of the DA-level types. The Encoder
+// calls these methods rather than the ones of KZGBackend when the backend implements them, which saves serializing and
+// parsing every field element and point exchanged with the backend.
+type NativeKZGBackend interface {
+	KZGBackend
+
+	// CommitNative is Commit on the parsed symbols, returning the parsed commitments and frames
+	CommitNative(symbols []bn254.Fr, params Params) (commit, lengthProof *bn254.G1Point, frames []kzgEncoder.Frame, err error)
+
+	// VerifyLengthNative is VerifyLength on the parsed points. It checks that the points are on the curve.
+	VerifyLengthNative(commit, lengthProof *bn254.G1Point, degree uint64) error
+
+	// NativeFrameVerifier is FrameVerifier, returning a verifier of the parsed frames
+	NativeFrameVerifier(params Params) (NativeFrameVerifier, error)
+
+	// DecodeNative is Decode on the parsed frames
+	DecodeNative(frames []kzgEncoder.Frame, indices []uint64, params Params, maxInputSize uint64) ([]byte, error)
+}
+
+// NativeFrameVerifier verifies the opening proofs of the parsed frames encoded with a set of encoding params
+type NativeFrameVerifier interface {
+	// VerifyFrameNative verifies the opening proof of a single frame at the given chunk index. It checks that the
+	// commitment and the proof are on the curve.
+	VerifyFrameNative(commit *bn254.G1Point, frame *kzgEncoder.Frame, index uint64) error
+}
+
+// Symbol is a field element of the scalar field of the curve, in big endian
+type Symbol [32]byte
+
+// Point is a G1 point of the curve, as its affine coordinates x and y in big endian, the point at infinity being
+// (0, 0) as in the EVM precompiles
+type Point [64]byte
+
+// Frame is a chunk of the encoded polynomial along with the proof of its opening at the coset of the chunk
+type Frame struct {
+	Proof  Point
+	Coeffs []Symbol
+}
+
+// Params are the encoding params of a backend. Both the number of chunks and the chunk length are powers of 2.
+type Params struct {
+	NumChunks   uint64
+	ChunkLength uint64
+}
@@ -0,0 +1,233 @@
+package encoding
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Layr-Labs/eigenda/pkg/encoding/encoder"
+	"github.com/Layr-Labs/eigenda/pkg/encoding/kzgEncoder"
+	"github.com/Layr-Labs/eigenda/pkg/kzg/bn254"
+	bn "github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+)
+
+// Bn254Backend is the reference KZGBackend implementation based on the gnark-crypto bn254 curve.
+type Bn254Backend struct {
+	EncoderGroup *kzgEncoder.KzgEncoderGroup
+}
+
+var _ NativeKZGBackend = &Bn254Backend{}
+
+func NewBn254Backend(config *kzgEncoder.KzgConfig) (*Bn254Backend, error) {
+	kzgEncoderGroup, err := kzgEncoder.NewKzgEncoderGroup(config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Bn254Backend{
+		EncoderGroup: kzgEncoderGroup,
+	}, nil
+}
+
+func (b *Bn254Backend) Commit(symbols []Symbol, params Params) (Point, Point, []Frame, error) {
+	coeffs, err := fromSymbols(symbols)
+	if err != nil {
+		return Point{}, Point{}, nil, err
+	}
+	commit, lowDegreeProof, kzgFrames, err := b.CommitNative(coeffs, params)
+	if err != nil {
+		return Point{}, Point{}, nil, err
+	}
+
+	frames := make([]Frame, len(kzgFrames))
+	for i := range kzgFrames {
+		frames[i] = Frame{
+			Proof:  toPoint(&kzgFrames[i].Proof),
+			Coeffs: toSymbols(kzgFrames[i].Coeffs),
+		}
+	}
+	return toPoint(commit), toPoint(lowDegreeProof), frames, nil
+}
+
+func (b *Bn254Backend) CommitNative(symbols []bn254.Fr, params Params) (*bn254.G1Point, *bn254.G1Point, []kzgEncoder.Frame, error) {
+	enc, err := b.EncoderGroup.GetKzgEncoder(toKzgParams(params))
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	commit, lowDegreeProof, frames, _, err := enc.Encode(symbols)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return commit, lowDegreeProof, frames, nil
+}
+
+func (b *Bn254Backend) VerifyLength(commit, lengthProof Point, degree uint64) error {
+	commitPoint, err := fromPoint(commit)
+	if err != nil {
+		return err
+	}
+	lengthProofPoint, err := fromPoint(lengthProof)
+	if err != nil {
+		return err
+	}
+	return b.EncoderGroup.VerifyCommit(commitPoint, lengthProofPoint, degree)
+}
+
+func (b *Bn254Backend) VerifyLengthNative(commit, lengthProof *bn254.G1Point, degree uint64) error {
+	if err := checkPoint(commit); err != nil {
+		return err
+	}
+	if err := checkPoint(lengthProof); err != nil {
+		return err
+	}
+	return b.EncoderGroup.VerifyCommit(commit, lengthProof, degree)
+}
+
+func (b *Bn254Backend) FrameVerifier(params Params) (FrameVerifier, error) {
+	return b.frameVerifier(params)
+}
+
+func (b *Bn254Backend) NativeFrameVerifier(params Params) (NativeFrameVerifier, error) {
+	return b.frameVerifier(params)
+}
+
+func (b *Bn254Backend) frameVerifier(params Params) (*bn254FrameVerifier, error) {
+	verifier, err := b.EncoderGroup.GetKzgVerifier(toKzgParams(params))
+	if err != nil {
+		return nil, err
+	}
+	return &bn254FrameVerifier{verifier: verifier}, nil
+}
+
+func (b *Bn254Backend) VerifyFrames(commit Point, frames []Frame, indices []uint64, params Params) error {
+	verifier, err := b.FrameVerifier(params)
+	if err != nil {
+		return err
+	}
+
+	for ind := range frames {
+		err = verifier.VerifyFrame(commit, &frames[ind], indices[ind])
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (b *Bn254Backend) Decode(frames []Frame, indices []uint64, params Params, maxInputSize uint64) ([]byte, error) {
+	kzgFrames := make([]kzgEncoder.Frame, len(frames))
+	for i := range frames {
+		kzgFrame, err := toKzgFrame(&frames[i])
+		if err != nil {
+			return nil, err
+		}
+		kzgFrames[i] = *kzgFrame
+	}
+	return b.DecodeNative(kzgFrames, indices, params, maxInputSize)
+}
+
+func (b *Bn254Backend) DecodeNative(frames []kzgEncoder.Frame, indices []uint64, params Params, maxInputSize uint64) ([]byte, error) {
+	enc, err := b.EncoderGroup.GetKzgEncoder(toKzgParams(params))
+	if err != nil {
+		return nil, err
+	}
+	return enc.Decode(frames, indices, maxInputSize)
+}
+
+// bn254FrameVerifier is the FrameVerifier and the NativeFrameVerifier of the Bn254Backend
+type bn254FrameVerifier struct {
+	verifier *kzgEncoder.KzgVerifier
+}
+
+func (v *bn254FrameVerifier) VerifyFrame(commit Point, frame *Frame, index uint64) error {
+	commitPoint, err := fromPoint(commit)
+	if err != nil {
+		return err
+	}
+	kzgFrame, err := toKzgFrame(frame)
+	if err != nil {
+		return err
+	}
+	return v.verifier.VerifyFrame(commitPoint, kzgFrame, index)
+}
+
+func (v *bn254FrameVerifier) VerifyFrameNative(commit *bn254.G1Point, frame *kzgEncoder.Frame, index uint64) error {
+	if err := checkPoint(commit); err != nil {
+		return err
+	}
+	if err := checkPoint(&frame.Proof); err != nil {
+		return err
+	}
+	return v.verifier.VerifyFrame(commit, frame, index)
+}
+
+func toKzgParams(params Params) encoder.EncodingParams {
+	return encoder.EncodingParams{
+		NumChunks: params.NumChunks,
+		ChunkLen:  params.ChunkLength,
+	}
+}
+
+func toKzgFrame(frame *Frame) (*kzgEncoder.Frame, error) {
+	proof, err := fromPoint(frame.Proof)
+	if err != nil {
+		return nil, err
+	}
+	coeffs, err := fromSymbols(frame.Coeffs)
+	if err != nil {
+		return nil, err
+	}
+	return &kzgEncoder.Frame{Proof: *proof, Coeffs: coeffs}, nil
+}
+
+func toPoint(p *bn254.G1Point) Point {
+	var point Point
+	x := (*bn.G1Affine)(p).X.Bytes()
+	y := (*bn.G1Affine)(p).Y.Bytes()
+	copy(point[:32], x[:])
+	copy(point[32:], y[:])
+	return point
+}
+
+// fromPoint parses the point, checking that its coordinates are canonical and that it is on the curve, which is enough
+// for it to be in the G1 subgroup of bn254
+func fromPoint(point Point) (*bn254.G1Point, error) {
+	var p bn.G1Affine
+	if err := p.X.SetBytesCanonical(point[:32]); err != nil {
+		return nil, fmt.Errorf("invalid point: %w", err)
+	}
+	if err := p.Y.SetBytesCanonical(point[32:]); err != nil {
+		return nil, fmt.Errorf("invalid point: %w", err)
+	}
+	if err := checkPoint((*bn254.G1Point)(&p)); err != nil {
+		return nil, err
+	}
+	return (*bn254.G1Point)(&p), nil
+}
+
+// checkPoint checks that the point is on the curve, which is enough for it to be in the G1 subgroup of bn254
+func checkPoint(p *bn254.G1Point) error {
+	if !(*bn.G1Affine)(p).IsOnCurve() {
+		return errors.New("invalid point: not on the curve")
+	}
+	return nil
+}
+
+func toSymbols(coeffs []bn254.Fr) []Symbol {
+	symbols := make([]Symbol, len(coeffs))
+	for i := range coeffs {
+		symbols[i] = bn254.FrTo32(&coeffs[i])
+	}
+	return symbols
+}
+
+func fromSymbols(symbols []Symbol) ([]bn254.Fr, error) {
+	coeffs := make([]bn254.Fr, len(symbols))
+	for i := range symbols {
+		if err := (*fr.Element)(&coeffs[i]).SetBytesCanonical(symbols[i][:]); err != nil {
+			return nil, fmt.Errorf("invalid symbol %d: %w", i, err)
+		}
+	}
+	return coeffs, nil
+}
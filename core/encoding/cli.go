@@ -16,7 +16,11 @@ const (
 	NumWorkerFlagName         = "kzg.num-workers"
 	VerboseFlagName           = "kzg.verbose"
 	PreloadEncoderFlagName    = "kzg.preload-encoder"
+	EncoderCacheSizeFlagName  = "kzg.encoder-cache-size"
+	LazyLoadSRSFlagName       = "kzg.lazy-load-srs"
+	VerifySRSFlagName         = "kzg.verify-srs"
 	CacheEncodedBlobsFlagName = "cache-encoded-blobs"
+	ChunkChecksumsFlagName    = "chunk-checksums"
 )
 
 func CLIFlags(envPrefix string) []cli.Flag {
@@ -47,7 +51,7 @@ func CLIFlags(envPrefix string) []cli.Flag {
 		},
 		cli.Uint64Flag{
 			Name:     NumWorkerFlagName,
-			Usage:    "Number of workers for multithreading",
+			Usage:    "Number of workers used to parallelize chunk interpolation and proof computation within a blob. Higher values use more memory per blob; lower it when co-located with other services",
 			Required: false,
 			EnvVar:   common.PrefixEnvVar(envPrefix, "NUM_WORKERS"),
 			Value:    uint64(runtime.GOMAXPROCS(0)),
@@ -64,13 +68,54 @@ func CLIFlags(envPrefix string) []cli.Flag {
 			Required: false,
 			EnvVar:   common.PrefixEnvVar(envPrefix, "CACHE_ENCODED_BLOBS"),
 		},
+		cli.BoolFlag{
+			Name:     ChunkChecksumsFlagName,
+			Usage:    "Enable to compute a CRC32C checksum of each encoded chunk, by which the nodes reject the corrupted chunks before verifying them against the commitment",
+			Required: false,
+			EnvVar:   common.PrefixEnvVar(envPrefix, "CHUNK_CHECKSUMS"),
+		},
 		cli.BoolFlag{
 			Name:     PreloadEncoderFlagName,
 			Usage:    "Set to enable Encoder PreLoading",
 			Required: false,
 			EnvVar:   common.PrefixEnvVar(envPrefix, "PRELOAD_ENCODER"),
 		},
+		cli.Uint64Flag{
+			Name:     EncoderCacheSizeFlagName,
+			Usage:    "Maximum memory in bytes held by the FFT domains and SRS tables cached for each set of encoding parameters, evicting the least recently used first. Unbounded if 0",
+			Required: false,
+			EnvVar:   common.PrefixEnvVar(envPrefix, "ENCODER_CACHE_SIZE"),
+		},
+		cli.BoolFlag{
+			Name:     LazyLoadSRSFlagName,
+			Usage:    "Read the SRS files on the first encoding or verification rather than at startup. Ignored when the encoders are preloaded",
+			Required: false,
+			EnvVar:   common.PrefixEnvVar(envPrefix, "LAZY_LOAD_SRS"),
+		},
+		cli.BoolFlag{
+			Name:     VerifySRSFlagName,
+			Usage:    "Check with pairings that the SRS points are the powers of the same secret when the SRS is loaded. Slows down loading a large SRS",
+			Required: false,
+			EnvVar:   common.PrefixEnvVar(envPrefix, "VERIFY_SRS"),
+		},
+	}
+}
+
+// OptionalCLIFlags returns the same flags as CLIFlags, none of them being required, for the binaries that only encode
+// when the encoder is configured
+func OptionalCLIFlags(envPrefix string) []cli.Flag {
+	flags := CLIFlags(envPrefix)
+	for i, flag := range flags {
+		switch f := flag.(type) {
+		case cli.StringFlag:
+			f.Required = false
+			flags[i] = f
+		case cli.Uint64Flag:
+			f.Required = false
+			flags[i] = f
+		}
 	}
+	return flags
 }
 
 func ReadCLIConfig(ctx *cli.Context) EncoderConfig {
@@ -82,8 +127,12 @@ func ReadCLIConfig(ctx *cli.Context) EncoderConfig {
 	cfg.NumWorker = ctx.GlobalUint64(NumWorkerFlagName)
 	cfg.Verbose = ctx.GlobalBool(VerboseFlagName)
 	cfg.PreloadEncoder = ctx.GlobalBool(PreloadEncoderFlagName)
+	cfg.EncoderCacheSize = ctx.GlobalUint64(EncoderCacheSizeFlagName)
+	cfg.LazyLoadSRS = ctx.GlobalBool(LazyLoadSRSFlagName)
+	cfg.VerifySRS = ctx.GlobalBool(VerifySRSFlagName)
 	return EncoderConfig{
 		KzgConfig:         cfg,
 		CacheEncodedBlobs: ctx.GlobalBoolT(CacheEncodedBlobsFlagName),
+		ChunkChecksums:    ctx.GlobalBool(ChunkChecksumsFlagName),
 	}
 }
@@ -2,45 +2,60 @@ package encoding
 
 import (
 	"crypto/sha256"
+	"errors"
+	"fmt"
 
 	"github.com/Layr-Labs/eigenda/core"
 	"github.com/Layr-Labs/eigenda/pkg/encoding/encoder"
 	"github.com/Layr-Labs/eigenda/pkg/encoding/kzgEncoder"
+	"github.com/Layr-Labs/eigenda/pkg/kzg/bn254"
 	lru "github.com/hashicorp/golang-lru/v2"
 )
 
-func toEncParams(params core.EncodingParams) encoder.EncodingParams {
-	return encoder.ParamsFromMins(uint64(params.NumChunks), uint64(params.ChunkLength))
+func toParams(params core.EncodingParams) Params {
+	encParams := encoder.ParamsFromMins(uint64(params.NumChunks), uint64(params.ChunkLength))
+	return Params{
+		NumChunks:   encParams.NumChunks,
+		ChunkLength: encParams.ChunkLen,
+	}
 }
 
 type EncoderConfig struct {
 	KzgConfig         kzgEncoder.KzgConfig
 	CacheEncodedBlobs bool
+	// ChunkChecksums makes the encoder compute the checksum of each chunk it encodes
+	ChunkChecksums bool
 }
 
 type Encoder struct {
-	Config       EncoderConfig
-	EncoderGroup *kzgEncoder.KzgEncoderGroup
-	Cache        *lru.Cache[string, encodedValue]
+	Config  EncoderConfig
+	Backend KZGBackend
+	Cache   *lru.Cache[string, encodedValue]
 }
 
 var _ core.Encoder = &Encoder{}
 
+// NewEncoder creates an Encoder using the reference bn254 KZG backend
 func NewEncoder(config EncoderConfig) (*Encoder, error) {
-	kzgEncoderGroup, err := kzgEncoder.NewKzgEncoderGroup(&config.KzgConfig)
+	backend, err := NewBn254Backend(&config.KzgConfig)
 	if err != nil {
 		return nil, err
 	}
 
+	return NewEncoderWithBackend(config, backend)
+}
+
+// NewEncoderWithBackend creates an Encoder which delegates all cryptographic operations to the given backend
+func NewEncoderWithBackend(config EncoderConfig, backend KZGBackend) (*Encoder, error) {
 	cache, err := lru.New[string, encodedValue](128)
 	if err != nil {
 		return nil, err
 	}
 
 	return &Encoder{
-		EncoderGroup: kzgEncoderGroup,
-		Cache:        cache,
-		Config:       config,
+		Backend: backend,
+		Cache:   cache,
+		Config:  config,
 	}, nil
 }
 
@@ -59,31 +74,32 @@ func (e *Encoder) Encode(data []byte, params core.EncodingParams) (core.BlobComm
 			return v.commitments, v.chunks, v.err
 		}
 	}
-	encParams := toEncParams(params)
-
-	enc, err := e.EncoderGroup.GetKzgEncoder(encParams)
+	symbols, err := params.Layout.ToSymbols(data)
 	if err != nil {
 		return core.BlobCommitments{}, nil, err
 	}
-
-	commit, lowDegreeProof, kzgFrames, _, err := enc.EncodeBytes(data)
+	var (
+		commitPoint, lowDegreeProofPoint *bn254.G1Point
+		chunks                           []*core.Chunk
+	)
+	if native, ok := e.Backend.(NativeKZGBackend); ok {
+		commitPoint, lowDegreeProofPoint, chunks, err = commitNative(native, symbols, params)
+	} else {
+		commitPoint, lowDegreeProofPoint, chunks, err = commitSerialized(e.Backend, symbols, params)
+	}
 	if err != nil {
 		return core.BlobCommitments{}, nil, err
 	}
-
-	chunks := make([]*core.Chunk, len(kzgFrames))
-	for ind, frame := range kzgFrames {
-
-		chunks[ind] = &core.Chunk{
-			Coeffs: frame.Coeffs,
-			Proof:  frame.Proof,
+	if e.Config.ChunkChecksums {
+		for _, chunk := range chunks {
+			chunk.Checksum = chunk.ComputeChecksum()
 		}
 	}
 
-	length := uint(len(encoder.ToFrArray(data)))
+	length := uint(len(symbols))
 	commitments := core.BlobCommitments{
-		Commitment:  &core.Commitment{G1Point: commit},
-		LengthProof: &core.Commitment{G1Point: lowDegreeProof},
+		Commitment:  &core.Commitment{G1Point: commitPoint},
+		LengthProof: &core.Commitment{G1Point: lowDegreeProofPoint},
 		Length:      length,
 	}
 
@@ -97,56 +113,161 @@ func (e *Encoder) Encode(data []byte, params core.EncodingParams) (core.BlobComm
 	return commitments, chunks, nil
 }
 
+// commitSerialized commits to the symbols with the backend, parsing the commitments and the frames it returns
+func commitSerialized(backend KZGBackend, symbols []core.Symbol, params core.EncodingParams) (*bn254.G1Point, *bn254.G1Point, []*core.Chunk, error) {
+	commit, lowDegreeProof, frames, err := backend.Commit(toSymbols(symbols), toParams(params))
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	commitPoint, err := fromPoint(commit)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	lowDegreeProofPoint, err := fromPoint(lowDegreeProof)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	chunks := make([]*core.Chunk, len(frames))
+	for ind := range frames {
+		chunks[ind], err = toChunk(&frames[ind])
+		if err != nil {
+			return nil, nil, nil, err
+		}
+	}
+	return commitPoint, lowDegreeProofPoint, chunks, nil
+}
+
+// commitNative commits to the symbols with the native backend, whose frames are the chunks as they are
+func commitNative(backend NativeKZGBackend, symbols []core.Symbol, params core.EncodingParams) (*bn254.G1Point, *bn254.G1Point, []*core.Chunk, error) {
+	commitPoint, lowDegreeProofPoint, frames, err := backend.CommitNative(symbols, toParams(params))
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	chunks := make([]*core.Chunk, len(frames))
+	for ind := range frames {
+		chunks[ind] = &core.Chunk{Coeffs: frames[ind].Coeffs, Proof: frames[ind].Proof}
+	}
+	return commitPoint, lowDegreeProofPoint, chunks, nil
+}
+
 func (e *Encoder) VerifyBlobLength(commitments core.BlobCommitments) error {
+	if native, ok := e.Backend.(NativeKZGBackend); ok {
+		return native.VerifyLengthNative(commitments.Commitment.G1Point, commitments.LengthProof.G1Point, uint64(commitments.Length-1))
+	}
 
-	return e.EncoderGroup.VerifyCommit(commitments.Commitment.G1Point, commitments.LengthProof.G1Point, uint64(commitments.Length-1))
+	return e.Backend.VerifyLength(toPoint(commitments.Commitment.G1Point), toPoint(commitments.LengthProof.G1Point), uint64(commitments.Length-1))
 
 }
 
 func (e *Encoder) VerifyChunks(chunks []*core.Chunk, indices []core.ChunkNumber, commitments core.BlobCommitments, params core.EncodingParams) error {
+	if _, ok := e.Backend.(NativeKZGBackend); ok {
+		verifyChunk, err := e.chunkVerifier(commitments.Commitment.G1Point, chunks, params)
+		if err != nil {
+			return err
+		}
+		for i := range chunks {
+			if err := verifyChunk(i, uint64(indices[i])); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
 
-	encParams := toEncParams(params)
+	return e.Backend.VerifyFrames(toPoint(commitments.Commitment.G1Point), toFrames(chunks), toUint64Array(indices), toParams(params))
+
+}
 
-	verifier, err := e.EncoderGroup.GetKzgVerifier(encParams)
+func (e *Encoder) VerifyChunksDetailed(chunks []*core.Chunk, indices []core.ChunkNumber, commitments core.BlobCommitments, params core.EncodingParams, collectAll bool) (*core.ChunkVerificationResult, error) {
+	if len(chunks) != len(indices) {
+		return nil, fmt.Errorf("number of chunks %d does not match number of indices %d", len(chunks), len(indices))
+	}
+	if commitments.Commitment == nil || commitments.Commitment.G1Point == nil {
+		return nil, errors.New("missing commitment")
+	}
+	verifyChunk, err := e.chunkVerifier(commitments.Commitment.G1Point, chunks, params)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	for ind := range chunks {
-		err = verifier.VerifyFrame(
-			commitments.Commitment.G1Point,
-			&kzgEncoder.Frame{
-				Proof:  chunks[ind].Proof,
-				Coeffs: chunks[ind].Coeffs,
-			},
-			uint64(indices[ind]),
-		)
+	result := &core.ChunkVerificationResult{}
+	for i := range chunks {
+		if err := verifyChunk(i, uint64(indices[i])); err != nil {
+			result.FailedIndices = append(result.FailedIndices, indices[i])
+			if !collectAll {
+				break
+			}
+			continue
+		}
+		result.NumVerified++
+	}
+	return result, nil
+}
 
+// chunkVerifier returns the function verifying the chunk of the given position at the given chunk index, through the
+// native methods of the backend if it has them
+func (e *Encoder) chunkVerifier(commit *bn254.G1Point, chunks []*core.Chunk, params core.EncodingParams) (func(i int, index uint64) error, error) {
+	if native, ok := e.Backend.(NativeKZGBackend); ok {
+		verifier, err := native.NativeFrameVerifier(toParams(params))
 		if err != nil {
-			return err
+			return nil, err
 		}
+		return func(i int, index uint64) error {
+			return verifier.VerifyFrameNative(commit, chunkFrame(chunks[i]), index)
+		}, nil
 	}
 
-	return nil
-
+	verifier, err := e.Backend.FrameVerifier(toParams(params))
+	if err != nil {
+		return nil, err
+	}
+	commitPoint := toPoint(commit)
+	frames := toFrames(chunks)
+	return func(i int, index uint64) error {
+		return verifier.VerifyFrame(commitPoint, &frames[i], index)
+	}, nil
 }
 
 // Decode takes in the chunks, indices, and encoding parameters and returns the decoded blob
 // The result is trimmed to the given maxInputSize.
 func (e *Encoder) Decode(chunks []*core.Chunk, indices []core.ChunkNumber, params core.EncodingParams, maxInputSize uint64) ([]byte, error) {
-	frames := make([]kzgEncoder.Frame, len(chunks))
+	if native, ok := e.Backend.(NativeKZGBackend); ok {
+		frames := make([]kzgEncoder.Frame, len(chunks))
+		for i := range chunks {
+			frames[i] = *chunkFrame(chunks[i])
+		}
+		return native.DecodeNative(frames, toUint64Array(indices), toParams(params), maxInputSize)
+	}
+
+	return e.Backend.Decode(toFrames(chunks), toUint64Array(indices), toParams(params), maxInputSize)
+}
+
+func toFrames(chunks []*core.Chunk) []Frame {
+	frames := make([]Frame, len(chunks))
 	for i := range chunks {
-		frames[i] = kzgEncoder.Frame{
-			Proof:  chunks[i].Proof,
-			Coeffs: chunks[i].Coeffs,
+		frames[i] = Frame{
+			Proof:  toPoint(&chunks[i].Proof),
+			Coeffs: toSymbols(chunks[i].Coeffs),
 		}
 	}
-	encoder, err := e.EncoderGroup.GetKzgEncoder(toEncParams(params))
+	return frames
+}
+
+func toChunk(frame *Frame) (*core.Chunk, error) {
+	proof, err := fromPoint(frame.Proof)
 	if err != nil {
 		return nil, err
 	}
+	coeffs, err := fromSymbols(frame.Coeffs)
+	if err != nil {
+		return nil, err
+	}
+	return &core.Chunk{Coeffs: coeffs, Proof: *proof}, nil
+}
 
-	return encoder.Decode(frames, toUint64Array(indices), maxInputSize)
+// chunkFrame returns the frame of the chunk, sharing its coefficients
+func chunkFrame(chunk *core.Chunk) *kzgEncoder.Frame {
+	return &kzgEncoder.Frame{Proof: chunk.Proof, Coeffs: chunk.Coeffs}
 }
 
 func toUint64Array(chunkIndices []core.ChunkNumber) []uint64 {
@@ -160,6 +281,6 @@ func toUint64Array(chunkIndices []core.ChunkNumber) []uint64 {
 func hashBlob(data []byte, params core.EncodingParams) string {
 	h := sha256.New()
 	h.Write(data)
-	h.Write([]byte{byte(params.ChunkLength), byte(params.NumChunks)})
+	h.Write([]byte{byte(params.ChunkLength), byte(params.NumChunks), byte(params.Layout)})
 	return string(h.Sum(nil))
 }
@@ -2,6 +2,7 @@ package encoding_test
 
 import (
 	"crypto/rand"
+	"errors"
 	"log"
 	"runtime"
 	"testing"
@@ -9,6 +10,8 @@ import (
 	"github.com/Layr-Labs/eigenda/core"
 	"github.com/Layr-Labs/eigenda/core/encoding"
 	"github.com/Layr-Labs/eigenda/pkg/encoding/kzgEncoder"
+	"github.com/Layr-Labs/eigenda/pkg/kzg/bn254"
+	bn "github.com/consensys/gnark-crypto/ecc/bn254"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -78,6 +81,260 @@ func TestEncoder(t *testing.T) {
 	assert.Equal(t, gettysburgAddressBytes, decoded)
 }
 
+func TestVerifyChunksDetailed(t *testing.T) {
+	params := core.EncodingParams{
+		ChunkLength: 5,
+		NumChunks:   5,
+	}
+	commitments, chunks, err := enc.Encode(gettysburgAddressBytes, params)
+	assert.NoError(t, err)
+
+	result, err := enc.VerifyChunksDetailed(chunks, []core.ChunkNumber{0, 1, 2, 3, 4, 5, 6, 7}, commitments, params, true)
+	assert.NoError(t, err)
+	assert.Equal(t, &core.ChunkVerificationResult{NumVerified: 8}, result)
+
+	// The chunks 2 and 5 are verified at each other's index
+	indices := []core.ChunkNumber{0, 1, 5, 3, 4, 2, 6, 7}
+	result, err = enc.VerifyChunksDetailed(chunks, indices, commitments, params, true)
+	assert.NoError(t, err)
+	assert.Equal(t, &core.ChunkVerificationResult{NumVerified: 6, FailedIndices: []core.ChunkNumber{5, 2}}, result)
+
+	// Only the first invalid chunk is reported without collecting all the failures
+	result, err = enc.VerifyChunksDetailed(chunks, indices, commitments, params, false)
+	assert.NoError(t, err)
+	assert.Equal(t, &core.ChunkVerificationResult{NumVerified: 2, FailedIndices: []core.ChunkNumber{5}}, result)
+
+	_, err = enc.VerifyChunksDetailed(chunks, indices[:4], commitments, params, true)
+	assert.Error(t, err)
+
+	// The chunks can't be verified at all without a commitment
+	_, err = enc.VerifyChunksDetailed(chunks, indices, core.BlobCommitments{}, params, true)
+	assert.Error(t, err)
+}
+
+func TestDecodeFromChunkSubsets(t *testing.T) {
+	params := core.EncodingParams{
+		ChunkLength: 8,
+		NumChunks:   16,
+	}
+	_, chunks, err := enc.Encode(gettysburgAddressBytes, params)
+	assert.NoError(t, err)
+	maxInputSize := uint64(len(gettysburgAddressBytes))
+	length := core.GetBlobLength(uint(len(gettysburgAddressBytes)))
+	required := int((length + params.ChunkLength - 1) / params.ChunkLength)
+
+	// Exactly the minimum number of chunks, with non-contiguous indices and duplicates
+	subset := make([]*core.Chunk, 0)
+	indices := make([]core.ChunkNumber, 0)
+	for i := 0; i < required; i++ {
+		index := (3*i + 1) % len(chunks)
+		subset = append(subset, chunks[index], chunks[index])
+		indices = append(indices, core.ChunkNumber(index), core.ChunkNumber(index))
+	}
+	decoded, err := enc.Decode(subset, indices, params, maxInputSize)
+	assert.NoError(t, err)
+	assert.Equal(t, gettysburgAddressBytes, decoded)
+
+	// A superset of the minimum
+	decoded, err = enc.Decode(append(subset, chunks[0]), append(indices, 0), params, maxInputSize)
+	assert.NoError(t, err)
+	assert.Equal(t, gettysburgAddressBytes, decoded)
+
+	// One chunk short
+	_, err = enc.Decode(subset[2:], indices[2:], params, maxInputSize)
+	var insufficient *core.ErrInsufficientChunks
+	assert.ErrorAs(t, err, &insufficient)
+	assert.Equal(t, uint64(1), insufficient.Needed())
+}
+
+// countingBackend wraps a KZGBackend and counts the frames it is asked to verify and the verifiers it is asked for,
+// failing to return the verifiers with verifierErr if it is set
+type countingBackend struct {
+	encoding.KZGBackend
+	verifiedFrames  int
+	verifierLookups int
+	verifierErr     error
+}
+
+func (b *countingBackend) VerifyFrames(commit encoding.Point, frames []encoding.Frame, indices []uint64, params encoding.Params) error {
+	b.verifiedFrames += len(frames)
+	return b.KZGBackend.VerifyFrames(commit, frames, indices, params)
+}
+
+func (b *countingBackend) FrameVerifier(params encoding.Params) (encoding.FrameVerifier, error) {
+	b.verifierLookups++
+	if b.verifierErr != nil {
+		return nil, b.verifierErr
+	}
+	return b.KZGBackend.FrameVerifier(params)
+}
+
+func TestEncoderWithCustomBackend(t *testing.T) {
+	reference := enc.(*encoding.Encoder)
+	backend := &countingBackend{KZGBackend: reference.Backend}
+	customEnc, err := encoding.NewEncoderWithBackend(encoding.EncoderConfig{}, backend)
+	assert.NoError(t, err)
+
+	params := core.EncodingParams{
+		ChunkLength: 5,
+		NumChunks:   5,
+	}
+	commitments, chunks, err := customEnc.Encode(gettysburgAddressBytes, params)
+	assert.NoError(t, err)
+
+	indices := []core.ChunkNumber{0, 1, 2, 3, 4, 5, 6, 7}
+	err = customEnc.VerifyChunks(chunks, indices, commitments, params)
+	assert.NoError(t, err)
+	assert.Equal(t, len(chunks), backend.verifiedFrames)
+
+	// The verifier is looked up once for all the chunks, and its errors are returned rather than failing the chunks
+	result, err := customEnc.VerifyChunksDetailed(chunks, indices, commitments, params, true)
+	assert.NoError(t, err)
+	assert.Equal(t, len(chunks), result.NumVerified)
+	assert.Equal(t, 1, backend.verifierLookups)
+	backend.verifierErr = errors.New("invalid params")
+	_, err = customEnc.VerifyChunksDetailed(chunks, indices, commitments, params, true)
+	assert.ErrorIs(t, err, backend.verifierErr)
+
+	// The encoder should produce identical results regardless of which wrapper of the backend is used
+	expectedCommitments, _, err := enc.Encode(gettysburgAddressBytes, params)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedCommitments, commitments)
+}
+
+func TestBn254BackendRejectsInvalidPoints(t *testing.T) {
+	backend := enc.(*encoding.Encoder).Backend
+	params := encoding.Params{NumChunks: 8, ChunkLength: 8}
+
+	// (1, 1) is not on the curve, and the all ones coordinates exceed the base field
+	var offCurve, nonCanonical encoding.Point
+	offCurve[31], offCurve[63] = 1, 1
+	for i := range nonCanonical {
+		nonCanonical[i] = 0xff
+	}
+	verifier, err := backend.FrameVerifier(params)
+	assert.NoError(t, err)
+	for _, point := range []encoding.Point{offCurve, nonCanonical} {
+		assert.Error(t, backend.VerifyLength(point, point, 0))
+		assert.Error(t, verifier.VerifyFrame(point, &encoding.Frame{Coeffs: make([]encoding.Symbol, 8)}, 0))
+	}
+}
+
+// serializingBackend hides the native methods of the backend it wraps, so that the encoder exchanges serialized field
+// elements and points with it
+type serializingBackend struct {
+	encoding.KZGBackend
+}
+
+func TestNativeBackendMatchesSerialized(t *testing.T) {
+	reference := enc.(*encoding.Encoder)
+	_, ok := reference.Backend.(encoding.NativeKZGBackend)
+	assert.True(t, ok)
+	serialized, err := encoding.NewEncoderWithBackend(encoding.EncoderConfig{}, serializingBackend{KZGBackend: reference.Backend})
+	assert.NoError(t, err)
+
+	params := core.EncodingParams{
+		ChunkLength: 8,
+		NumChunks:   16,
+	}
+	commitments, chunks, err := enc.Encode(gettysburgAddressBytes, params)
+	assert.NoError(t, err)
+	serializedCommitments, serializedChunks, err := serialized.Encode(gettysburgAddressBytes, params)
+	assert.NoError(t, err)
+	assert.Equal(t, serializedCommitments, commitments)
+	assert.Equal(t, serializedChunks, chunks)
+
+	// Either path verifies and decodes the chunks of the other
+	indices := make([]core.ChunkNumber, len(chunks))
+	for i := range indices {
+		indices[i] = core.ChunkNumber(i)
+	}
+	assert.NoError(t, enc.VerifyBlobLength(serializedCommitments))
+	assert.NoError(t, serialized.VerifyBlobLength(commitments))
+	assert.NoError(t, enc.VerifyChunks(serializedChunks, indices, serializedCommitments, params))
+	assert.NoError(t, serialized.VerifyChunks(chunks, indices, commitments, params))
+	decoded, err := enc.Decode(serializedChunks, indices, params, uint64(len(gettysburgAddressBytes)))
+	assert.NoError(t, err)
+	assert.Equal(t, gettysburgAddressBytes, decoded)
+
+	// The native path rejects the points which aren't on the curve, as parsing them does on the serialized path
+	var offCurve bn254.G1Point
+	(*bn.G1Affine)(&offCurve).X.SetOne()
+	(*bn.G1Affine)(&offCurve).Y.SetOne()
+	invalid := *chunks[0]
+	invalid.Proof = offCurve
+	for _, e := range []core.Encoder{enc, serialized} {
+		assert.Error(t, e.VerifyChunks([]*core.Chunk{&invalid}, []core.ChunkNumber{0}, commitments, params))
+		result, err := e.VerifyChunksDetailed([]*core.Chunk{&invalid}, []core.ChunkNumber{0}, commitments, params, true)
+		assert.NoError(t, err)
+		assert.Equal(t, []core.ChunkNumber{0}, result.FailedIndices)
+		assert.Error(t, e.VerifyBlobLength(core.BlobCommitments{
+			Commitment:  &core.Commitment{G1Point: &offCurve},
+			LengthProof: commitments.LengthProof,
+			Length:      commitments.Length,
+		}))
+	}
+}
+
+func TestEncoderChunkChecksums(t *testing.T) {
+	reference := enc.(*encoding.Encoder)
+	checksumEnc, err := encoding.NewEncoderWithBackend(encoding.EncoderConfig{ChunkChecksums: true}, reference.Backend)
+	assert.NoError(t, err)
+
+	params := core.EncodingParams{
+		ChunkLength: 5,
+		NumChunks:   5,
+	}
+	_, chunks, err := checksumEnc.Encode(gettysburgAddressBytes, params)
+	assert.NoError(t, err)
+	for _, chunk := range chunks {
+		assert.NotZero(t, chunk.Checksum)
+		assert.True(t, chunk.HasValidChecksum())
+	}
+
+	// The checksums are only computed when enabled
+	_, chunks, err = enc.Encode(gettysburgAddressBytes, params)
+	assert.NoError(t, err)
+	for _, chunk := range chunks {
+		assert.Zero(t, chunk.Checksum)
+	}
+}
+
+func TestEncoderFieldElementsLayout(t *testing.T) {
+	params := core.EncodingParams{
+		ChunkLength: 64,
+		NumChunks:   128,
+		Layout:      core.FieldElementsLayout,
+	}
+
+	// Canonical field elements, each below the modulus as its first byte is zero
+	data := make([]byte, core.FieldElementsBlobSize)
+	_, err := rand.Read(data)
+	assert.NoError(t, err)
+	for i := 0; i < len(data); i += core.BytesPerFieldElement {
+		data[i] = 0
+	}
+	commitments, chunks, err := enc.Encode(data, params)
+	assert.NoError(t, err)
+	assert.Equal(t, uint(core.FieldElementsPerBlob), commitments.Length)
+	err = enc.VerifyChunks(chunks[:4], []core.ChunkNumber{0, 1, 2, 3}, commitments, params)
+	assert.NoError(t, err)
+
+	// A field element which is canonical in the BLS12-381 scalar field of EIP-4844, but not in the bn254 one
+	data[core.BytesPerFieldElement] = 0x40
+	_, _, err = enc.Encode(data, params)
+	assert.ErrorIs(t, err, core.ErrInvalidBlobLayout)
+
+	// A non canonical field element
+	data[core.BytesPerFieldElement] = 0xff
+	_, _, err = enc.Encode(data, params)
+	assert.ErrorIs(t, err, core.ErrInvalidBlobLayout)
+
+	// A blob of the wrong size
+	_, _, err = enc.Encode(gettysburgAddressBytes, params)
+	assert.ErrorIs(t, err, core.ErrInvalidBlobLayout)
+}
+
 // Ballpark number for 400KiB blob encoding
 //
 // goos: darwin
@@ -106,3 +363,130 @@ func BenchmarkEncode(b *testing.B) {
 		_, _, _ = enc.Encode(blobs[i%numSamples], params)
 	}
 }
+
+// Verifying the chunks of a 400KiB blob through the native methods of the backend, and through its serialized ones
+//
+// goos: linux
+// goarch: amd64
+// pkg: github.com/Layr-Labs/eigenda/core/encoding
+// BenchmarkVerifyChunks/native         	       3	2910053385 ns/op
+// BenchmarkVerifyChunks/serialized     	       3	2944524646 ns/op
+func BenchmarkVerifyChunks(b *testing.B) {
+	params := core.EncodingParams{
+		ChunkLength: 512,
+		NumChunks:   256,
+	}
+	blob := make([]byte, 400*1024)
+	_, _ = rand.Read(blob)
+	commitments, chunks, err := enc.Encode(blob, params)
+	if err != nil {
+		b.Fatal(err)
+	}
+	indices := make([]core.ChunkNumber, len(chunks))
+	for i := range indices {
+		indices[i] = core.ChunkNumber(i)
+	}
+	serialized, err := encoding.NewEncoderWithBackend(encoding.EncoderConfig{}, serializingBackend{KZGBackend: enc.(*encoding.Encoder).Backend})
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	for _, bc := range []struct {
+		name string
+		enc  core.Encoder
+	}{
+		{name: "native", enc: enc},
+		{name: "serialized", enc: serialized},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				_ = bc.enc.VerifyChunks(chunks, indices, commitments, params)
+			}
+		})
+	}
+}
+
+// makeSmallTestEncoder makes an encoder over a small SRS, whose encoder cache holds at most encoderCacheSize bytes
+func makeSmallTestEncoder(encoderCacheSize uint64) (*encoding.Encoder, error) {
+	return encoding.NewEncoder(encoding.EncoderConfig{KzgConfig: kzgEncoder.KzgConfig{
+		G1Path:           "../../inabox/resources/kzg/g1.point",
+		G2Path:           "../../inabox/resources/kzg/g2.point",
+		CacheDir:         "../../inabox/resources/kzg/SRSTables",
+		SRSOrder:         3000,
+		NumWorker:        uint64(runtime.GOMAXPROCS(0)),
+		EncoderCacheSize: encoderCacheSize,
+	}})
+}
+
+func TestEncoderCacheMatchesUncached(t *testing.T) {
+	cached, err := makeSmallTestEncoder(0)
+	assert.NoError(t, err)
+	// No encoder fits in a single byte, so the working state is rebuilt for every blob
+	uncached, err := makeSmallTestEncoder(1)
+	assert.NoError(t, err)
+
+	params := core.EncodingParams{
+		ChunkLength: 64,
+		NumChunks:   16,
+	}
+	for i := 0; i < 3; i++ {
+		blob := make([]byte, 1000)
+		_, err := rand.Read(blob)
+		assert.NoError(t, err)
+
+		cachedCommitments, cachedChunks, err := cached.Encode(blob, params)
+		assert.NoError(t, err)
+		uncachedCommitments, uncachedChunks, err := uncached.Encode(blob, params)
+		assert.NoError(t, err)
+
+		assert.Equal(t, uncachedCommitments, cachedCommitments)
+		assert.Equal(t, uncachedChunks, cachedChunks)
+	}
+
+	assert.Equal(t, 1, cached.Backend.(*encoding.Bn254Backend).EncoderGroup.NumCachedEncoders())
+	assert.Equal(t, 0, uncached.Backend.(*encoding.Bn254Backend).EncoderGroup.NumCachedEncoders())
+}
+
+// Encoding a batch of 200 blobs of identical size, which share their encoding parameters
+//
+// goos: linux
+// goarch: amd64
+// pkg: github.com/Layr-Labs/eigenda/core/encoding
+// BenchmarkEncodeIdenticalSizeBlobs/cached         	       1	40170410877 ns/op
+// BenchmarkEncodeIdenticalSizeBlobs/uncached       	       1	46779503695 ns/op
+func BenchmarkEncodeIdenticalSizeBlobs(b *testing.B) {
+	params := core.EncodingParams{
+		ChunkLength: 64,
+		NumChunks:   32,
+	}
+	numBlobs := 200
+	blobs := make([][]byte, numBlobs)
+	for i := range blobs {
+		blobs[i] = make([]byte, 4*1024)
+		_, _ = rand.Read(blobs[i])
+	}
+
+	for _, bc := range []struct {
+		name             string
+		encoderCacheSize uint64
+	}{
+		{name: "cached", encoderCacheSize: 0},
+		{name: "uncached", encoderCacheSize: 1},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			enc, err := makeSmallTestEncoder(bc.encoderCacheSize)
+			if err != nil {
+				b.Fatal(err)
+			}
+			// Warm up: ensures the SRS tables are precomputed on disk so that they aren't included in the benchmark
+			_, _, _ = enc.Encode(blobs[0], params)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				for _, blob := range blobs {
+					_, _, _ = enc.Encode(blob, params)
+				}
+			}
+		})
+	}
+}
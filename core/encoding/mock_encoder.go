@@ -27,6 +27,16 @@ func (e *MockEncoder) VerifyChunks(chunks []*core.Chunk, indices []core.ChunkNum
 	return args.Error(0)
 }
 
+func (e *MockEncoder) VerifyChunksDetailed(chunks []*core.Chunk, indices []core.ChunkNumber, commitments core.BlobCommitments, params core.EncodingParams, collectAll bool) (*core.ChunkVerificationResult, error) {
+	args := e.Called(chunks, indices, commitments, params, collectAll)
+	time.Sleep(e.Delay)
+	var result *core.ChunkVerificationResult
+	if args.Get(0) != nil {
+		result = args.Get(0).(*core.ChunkVerificationResult)
+	}
+	return result, args.Error(1)
+}
+
 func (e *MockEncoder) VerifyBlobLength(commitments core.BlobCommitments) error {
 
 	args := e.Called(commitments)
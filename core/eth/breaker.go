@@ -0,0 +1,267 @@
+package eth
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/urfave/cli"
+)
+
+const (
+	BreakerFailureThresholdFlagName = "chain.breaker-failure-threshold"
+	BreakerCooldownFlagName         = "chain.breaker-cooldown"
+)
+
+// ErrBreakerOpen is returned by the calls of a BreakerTransactor failing fast while the chain RPC is deemed degraded
+var ErrBreakerOpen = errors.New("transactor circuit breaker is open")
+
+// BreakerState is the state of the circuit breaker of a BreakerTransactor
+type BreakerState int
+
+const (
+	// BreakerClosed lets all the calls through
+	BreakerClosed BreakerState = iota
+	// BreakerHalfOpen lets a single trial call through once the cooldown elapsed, whose result closes or reopens the
+	// breaker
+	BreakerHalfOpen
+	// BreakerOpen fails the calls fast, serving the cached values where possible
+	BreakerOpen
+)
+
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerClosed:
+		return "closed"
+	case BreakerHalfOpen:
+		return "half-open"
+	default:
+		return "open"
+	}
+}
+
+type BreakerConfig struct {
+	// FailureThreshold is the number of consecutive failed calls opening the breaker. The breaker is disabled when 0.
+	FailureThreshold int
+	// Cooldown is how long the breaker fails the calls fast before letting a trial call through
+	Cooldown time.Duration
+}
+
+func BreakerCLIFlags(envPrefix string, flagPrefix string) []cli.Flag {
+	return []cli.Flag{
+		cli.IntFlag{
+			Name:   common.PrefixFlag(flagPrefix, BreakerFailureThresholdFlagName),
+			Usage:  "Number of consecutive failed chain calls after which the calls fail fast for the cooldown, serving the cached block number and quorum count. Set to 0 to disable the circuit breaker",
+			Value:  5,
+			EnvVar: common.PrefixEnvVar(envPrefix, "CHAIN_BREAKER_FAILURE_THRESHOLD"),
+		},
+		cli.DurationFlag{
+			Name:   common.PrefixFlag(flagPrefix, BreakerCooldownFlagName),
+			Usage:  "Duration the chain calls fail fast once the circuit breaker opened, before a trial call is let through",
+			Value:  30 * time.Second,
+			EnvVar: common.PrefixEnvVar(envPrefix, "CHAIN_BREAKER_COOLDOWN"),
+		},
+	}
+}
+
+func ReadBreakerCLIConfig(ctx *cli.Context, flagPrefix string) BreakerConfig {
+	return BreakerConfig{
+		FailureThreshold: ctx.GlobalInt(common.PrefixFlag(flagPrefix, BreakerFailureThresholdFlagName)),
+		Cooldown:         ctx.GlobalDuration(common.PrefixFlag(flagPrefix, BreakerCooldownFlagName)),
+	}
+}
+
+// BreakerTransactor wraps the calls of the disperser to a transactor in a circuit breaker, so that a degraded chain
+// RPC fails them fast instead of stalling the batcher and the server on retries. After FailureThreshold consecutive
+// failures, the calls fail with ErrBreakerOpen for the cooldown, except GetCurrentBlockNumber and GetQuorumCount which
+// serve the last values read from the chain. The quorum count is served for any block number, as quorums are seldom
+// created. The calls of the operators, such as their registration, aren't wrapped.
+type BreakerTransactor struct {
+	core.Transactor
+
+	config        BreakerConfig
+	logger        common.Logger
+	onStateChange func(BreakerState)
+
+	mu       sync.Mutex
+	state    BreakerState
+	failures int
+	openedAt time.Time
+
+	blockNumber    uint32
+	hasBlockNumber bool
+	quorumCount    uint16
+	hasQuorumCount bool
+}
+
+var _ core.Transactor = (*BreakerTransactor)(nil)
+
+// NewBreakerTransactor wraps the transactor in a circuit breaker. onStateChange, if not nil, is called with each new
+// state of the breaker.
+func NewBreakerTransactor(tx core.Transactor, config BreakerConfig, logger common.Logger, onStateChange func(BreakerState)) *BreakerTransactor {
+	if onStateChange != nil {
+		onStateChange(BreakerClosed)
+	}
+	return &BreakerTransactor{
+		Transactor:    tx,
+		config:        config,
+		logger:        logger,
+		onStateChange: onStateChange,
+	}
+}
+
+// State returns the current state of the breaker
+func (t *BreakerTransactor) State() BreakerState {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.state
+}
+
+func (t *BreakerTransactor) GetCurrentBlockNumber(ctx context.Context) (uint32, error) {
+	blockNumber, err := callWithBreaker(t, func() (uint32, error) {
+		return t.Transactor.GetCurrentBlockNumber(ctx)
+	})
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if err == nil {
+		t.blockNumber, t.hasBlockNumber = blockNumber, true
+	} else if errors.Is(err, ErrBreakerOpen) && t.hasBlockNumber {
+		return t.blockNumber, nil
+	}
+	return blockNumber, err
+}
+
+func (t *BreakerTransactor) GetQuorumCount(ctx context.Context, blockNumber uint32) (uint16, error) {
+	quorumCount, err := callWithBreaker(t, func() (uint16, error) {
+		return t.Transactor.GetQuorumCount(ctx, blockNumber)
+	})
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if err == nil {
+		t.quorumCount, t.hasQuorumCount = quorumCount, true
+	} else if errors.Is(err, ErrBreakerOpen) && t.hasQuorumCount {
+		return t.quorumCount, nil
+	}
+	return quorumCount, err
+}
+
+func (t *BreakerTransactor) ConfirmBatch(ctx context.Context, batchHeader core.BatchHeader, quorums map[core.QuorumID]*core.QuorumResult, signatureAggregation core.SignatureAggregation, replaced *types.Transaction) (*types.Receipt, *types.Transaction, error) {
+	var sent *types.Transaction
+	receipt, err := callWithBreaker(t, func() (*types.Receipt, error) {
+		var (
+			receipt *types.Receipt
+			err     error
+		)
+		receipt, sent, err = t.Transactor.ConfirmBatch(ctx, batchHeader, quorums, signatureAggregation, replaced)
+		return receipt, err
+	})
+	return receipt, sent, err
+}
+
+func (t *BreakerTransactor) GetOperatorStakes(ctx context.Context, operatorID core.OperatorID, blockNumber uint32) ([][]core.OperatorStake, []core.QuorumID, error) {
+	var quorumIDs []core.QuorumID
+	stakes, err := callWithBreaker(t, func() ([][]core.OperatorStake, error) {
+		stakes, ids, err := t.Transactor.GetOperatorStakes(ctx, operatorID, blockNumber)
+		quorumIDs = ids
+		return stakes, err
+	})
+	return stakes, quorumIDs, err
+}
+
+func (t *BreakerTransactor) GetOperatorStakesForQuorums(ctx context.Context, quorums []core.QuorumID, blockNumber uint32) ([][]core.OperatorStake, error) {
+	return callWithBreaker(t, func() ([][]core.OperatorStake, error) {
+		return t.Transactor.GetOperatorStakesForQuorums(ctx, quorums, blockNumber)
+	})
+}
+
+func (t *BreakerTransactor) GetNumberOfRegisteredOperatorForQuorum(ctx context.Context, quorumID core.QuorumID) (uint32, error) {
+	return callWithBreaker(t, func() (uint32, error) {
+		return t.Transactor.GetNumberOfRegisteredOperatorForQuorum(ctx, quorumID)
+	})
+}
+
+func (t *BreakerTransactor) GetBlockStaleMeasure(ctx context.Context) (uint32, error) {
+	return callWithBreaker(t, func() (uint32, error) {
+		return t.Transactor.GetBlockStaleMeasure(ctx)
+	})
+}
+
+func (t *BreakerTransactor) GetStoreDurationBlocks(ctx context.Context) (uint32, error) {
+	return callWithBreaker(t, func() (uint32, error) {
+		return t.Transactor.GetStoreDurationBlocks(ctx)
+	})
+}
+
+// callWithBreaker makes the call unless the breaker is open, and records its result
+func callWithBreaker[T any](t *BreakerTransactor, call func() (T, error)) (T, error) {
+	if !t.allow() {
+		var zero T
+		return zero, ErrBreakerOpen
+	}
+	result, err := call()
+	t.record(err)
+	return result, err
+}
+
+// allow returns whether a call can be made, letting a single trial call through once the cooldown elapsed
+func (t *BreakerTransactor) allow() bool {
+	if t.config.FailureThreshold <= 0 {
+		return true
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	switch t.state {
+	case BreakerClosed:
+		return true
+	case BreakerOpen:
+		if time.Since(t.openedAt) < t.config.Cooldown {
+			return false
+		}
+		t.setState(BreakerHalfOpen)
+		return true
+	default:
+		// The trial call is in flight
+		return false
+	}
+}
+
+func (t *BreakerTransactor) record(err error) {
+	if t.config.FailureThreshold <= 0 {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if err == nil {
+		t.failures = 0
+		if t.state != BreakerClosed {
+			t.logger.Info("Transactor circuit breaker closed")
+			t.setState(BreakerClosed)
+		}
+		return
+	}
+	// The calls canceled by their caller say nothing of the chain RPC: a canceled trial call lets the next one through
+	if errors.Is(err, context.Canceled) {
+		if t.state == BreakerHalfOpen {
+			t.setState(BreakerOpen)
+		}
+		return
+	}
+	t.failures++
+	if t.state == BreakerHalfOpen || t.failures >= t.config.FailureThreshold {
+		t.logger.Warn("Transactor circuit breaker opened, failing the chain calls fast", "failures", t.failures, "cooldown", t.config.Cooldown, "err", err)
+		t.failures = 0
+		t.openedAt = time.Now()
+		t.setState(BreakerOpen)
+	}
+}
+
+func (t *BreakerTransactor) setState(state BreakerState) {
+	t.state = state
+	if t.onStateChange != nil {
+		t.onStateChange(state)
+	}
+}
@@ -0,0 +1,100 @@
+package eth_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	commonmock "github.com/Layr-Labs/eigenda/common/mock"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/core/eth"
+	coremock "github.com/Layr-Labs/eigenda/core/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errRPC = errors.New("rpc unavailable")
+
+func TestBreakerTransactorServesCachedValuesWhileOpen(t *testing.T) {
+	ctx := context.Background()
+	tx := &coremock.MockTransactor{}
+	var states []eth.BreakerState
+	breaker := eth.NewBreakerTransactor(tx, eth.BreakerConfig{FailureThreshold: 2, Cooldown: 50 * time.Millisecond}, &commonmock.Logger{}, func(state eth.BreakerState) {
+		states = append(states, state)
+	})
+
+	tx.On("GetCurrentBlockNumber").Return(uint32(100), nil).Once()
+	tx.On("GetQuorumCount").Return(uint16(2), nil).Once()
+	blockNumber, err := breaker.GetCurrentBlockNumber(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(100), blockNumber)
+	quorumCount, err := breaker.GetQuorumCount(ctx, blockNumber)
+	require.NoError(t, err)
+	assert.Equal(t, uint16(2), quorumCount)
+
+	// Two consecutive failures open the breaker
+	tx.On("GetCurrentBlockNumber").Return(uint32(0), errRPC).Twice()
+	_, err = breaker.GetCurrentBlockNumber(ctx)
+	assert.ErrorIs(t, err, errRPC)
+	assert.Equal(t, eth.BreakerClosed, breaker.State())
+	_, err = breaker.GetCurrentBlockNumber(ctx)
+	assert.ErrorIs(t, err, errRPC)
+	assert.Equal(t, eth.BreakerOpen, breaker.State())
+
+	// The open breaker serves the cached values, and fails the other calls fast
+	blockNumber, err = breaker.GetCurrentBlockNumber(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(100), blockNumber)
+	quorumCount, err = breaker.GetQuorumCount(ctx, 101)
+	require.NoError(t, err)
+	assert.Equal(t, uint16(2), quorumCount)
+	_, _, err = breaker.ConfirmBatch(ctx, core.BatchHeader{}, nil, core.SignatureAggregation{}, nil)
+	assert.ErrorIs(t, err, eth.ErrBreakerOpen)
+	_, err = breaker.GetNumberOfRegisteredOperatorForQuorum(ctx, 0)
+	assert.ErrorIs(t, err, eth.ErrBreakerOpen)
+	tx.AssertNumberOfCalls(t, "GetCurrentBlockNumber", 3)
+	tx.AssertNumberOfCalls(t, "GetQuorumCount", 1)
+
+	// After the cooldown, a successful trial call closes the breaker
+	time.Sleep(60 * time.Millisecond)
+	tx.On("GetCurrentBlockNumber").Return(uint32(105), nil).Once()
+	blockNumber, err = breaker.GetCurrentBlockNumber(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(105), blockNumber)
+	assert.Equal(t, eth.BreakerClosed, breaker.State())
+	assert.Equal(t, []eth.BreakerState{eth.BreakerClosed, eth.BreakerOpen, eth.BreakerHalfOpen, eth.BreakerClosed}, states)
+}
+
+func TestBreakerTransactorReopensOnFailedTrial(t *testing.T) {
+	ctx := context.Background()
+	tx := &coremock.MockTransactor{}
+	breaker := eth.NewBreakerTransactor(tx, eth.BreakerConfig{FailureThreshold: 1, Cooldown: 50 * time.Millisecond}, &commonmock.Logger{}, nil)
+
+	// Without a cached value, the open breaker fails the call
+	tx.On("GetCurrentBlockNumber").Return(uint32(0), errRPC).Twice()
+	_, err := breaker.GetCurrentBlockNumber(ctx)
+	assert.ErrorIs(t, err, errRPC)
+	_, err = breaker.GetCurrentBlockNumber(ctx)
+	assert.ErrorIs(t, err, eth.ErrBreakerOpen)
+
+	// The failed trial call reopens the breaker for another cooldown
+	time.Sleep(60 * time.Millisecond)
+	_, err = breaker.GetCurrentBlockNumber(ctx)
+	assert.ErrorIs(t, err, errRPC)
+	assert.Equal(t, eth.BreakerOpen, breaker.State())
+	_, err = breaker.GetCurrentBlockNumber(ctx)
+	assert.ErrorIs(t, err, eth.ErrBreakerOpen)
+	tx.AssertNumberOfCalls(t, "GetCurrentBlockNumber", 2)
+
+	// The calls canceled by their caller don't count as failures
+	time.Sleep(60 * time.Millisecond)
+	tx.On("GetNumberOfRegisteredOperatorForQuorum").Return(uint32(0), context.Canceled).Once()
+	_, err = breaker.GetNumberOfRegisteredOperatorForQuorum(ctx, 0)
+	assert.ErrorIs(t, err, context.Canceled)
+	tx.On("GetNumberOfRegisteredOperatorForQuorum").Return(uint32(3), nil).Once()
+	count, err := breaker.GetNumberOfRegisteredOperatorForQuorum(ctx, 0)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(3), count)
+	assert.Equal(t, eth.BreakerClosed, breaker.State())
+}
@@ -3,6 +3,7 @@ package eth
 import (
 	"context"
 	"math/big"
+	"sort"
 
 	"github.com/Layr-Labs/eigenda/api/grpc/churner"
 	"github.com/Layr-Labs/eigenda/common"
@@ -386,7 +387,8 @@ func (t *Transactor) GetOperatorStakesForQuorums(ctx context.Context, quorums []
 
 // ConfirmBatch confirms a batch header and signature aggregation. The signature aggregation must satisfy the quorum thresholds
 // specified in the batch header. If the signature aggregation does not satisfy the quorum thresholds, the transaction will fail.
-func (t *Transactor) ConfirmBatch(ctx context.Context, batchHeader core.BatchHeader, quorums map[core.QuorumID]*core.QuorumResult, signatureAggregation core.SignatureAggregation) (*types.Receipt, error) {
+// If the replaced transaction isn't nil, the transaction replaces it at the same nonce with bumped fees.
+func (t *Transactor) ConfirmBatch(ctx context.Context, batchHeader core.BatchHeader, quorums map[core.QuorumID]*core.QuorumResult, signatureAggregation core.SignatureAggregation, replaced *types.Transaction) (*types.Receipt, *types.Transaction, error) {
 	quorumNumbers := quorumParamsToQuorumNumbers(quorums)
 	nonSignerOperatorIds := make([][32]byte, len(signatureAggregation.NonSigners))
 	for i := range signatureAggregation.NonSigners {
@@ -404,7 +406,7 @@ func (t *Transactor) ConfirmBatch(ctx context.Context, batchHeader core.BatchHea
 	)
 	if err != nil {
 		t.Logger.Error("Failed to fetch checkSignaturesIndices", err)
-		return nil, err
+		return nil, nil, err
 	}
 
 	nonSignerPubkeys := make([]eigendasrvmg.BN254G1Point, len(signatureAggregation.NonSigners))
@@ -444,16 +446,20 @@ func (t *Transactor) ConfirmBatch(ctx context.Context, batchHeader core.BatchHea
 	tx, err := t.Bindings.EigenDAServiceManager.ConfirmBatch(t.EthClient.GetNoSendTransactOpts(), batchH, signatureChecker)
 	if err != nil {
 		t.Logger.Error("Failed to confirm batch", "err", err)
-		return nil, err
+		return nil, nil, err
 	}
 
-	t.Logger.Info("confirming batch onchain")
-	receipt, err := t.EthClient.EstimateGasPriceAndLimitAndSendTx(ctx, tx, "ConfirmBatch", nil)
+	if replaced != nil {
+		t.Logger.Info("confirming batch onchain, replacing the pending confirmation", "replacedTxHash", replaced.Hash().Hex(), "nonce", replaced.Nonce())
+	} else {
+		t.Logger.Info("confirming batch onchain")
+	}
+	receipt, sent, err := t.EthClient.EstimateGasPriceAndLimitAndReplaceTx(ctx, tx, replaced, "ConfirmBatch", nil)
 	if err != nil {
 		t.Logger.Error("Failed to estimate gas price and limit", "err", err)
-		return nil, err
+		return nil, sent, err
 	}
-	return receipt, nil
+	return receipt, sent, nil
 }
 
 func (t *Transactor) StakeRegistry(ctx context.Context) (gethcommon.Address, error) {
@@ -655,22 +661,29 @@ func quorumIDsToQuorumNumbers(quorumIds []core.QuorumID) []byte {
 	return quorumNumbers
 }
 
+// sortedQuorumResults returns the quorum results in increasing order of quorum, the order of the quorums of the batch
+// header and of the aggregate public keys of the quorums
+func sortedQuorumResults(quorumParams map[core.QuorumID]*core.QuorumResult) []*core.QuorumResult {
+	results := make([]*core.QuorumResult, 0, len(quorumParams))
+	for _, qp := range quorumParams {
+		results = append(results, qp)
+	}
+	sort.Slice(results, func(i, j int) bool { return results[i].QuorumID < results[j].QuorumID })
+	return results
+}
+
 func quorumParamsToQuorumNumbers(quorumParams map[core.QuorumID]*core.QuorumResult) []byte {
 	quorumNumbers := make([]byte, len(quorumParams))
-	i := 0
-	for _, qp := range quorumParams {
+	for i, qp := range sortedQuorumResults(quorumParams) {
 		quorumNumbers[i] = byte(qp.QuorumID)
-		i++
 	}
 	return quorumNumbers
 }
 
 func quorumParamsToThresholdPercentages(quorumParams map[core.QuorumID]*core.QuorumResult) []byte {
 	thresholdPercentages := make([]byte, len(quorumParams))
-	i := 0
-	for _, qp := range quorumParams {
+	for i, qp := range sortedQuorumResults(quorumParams) {
 		thresholdPercentages[i] = byte(qp.PercentSigned)
-		i++
 	}
 	return thresholdPercentages
 }
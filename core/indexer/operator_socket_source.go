@@ -0,0 +1,55 @@
+package indexer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Layr-Labs/eigenda/core"
+)
+
+// operatorSocketSource is the source of the operator sockets of the chain: the socket update events of the registry
+// coordinator, and the sockets of the operators of all the quorums at the current block
+type operatorSocketSource struct {
+	filterer   OperatorSocketsFilterer
+	tx         core.Transactor
+	chainState core.IndexedChainState
+}
+
+var _ core.OperatorSocketSource = (*operatorSocketSource)(nil)
+
+func NewOperatorSocketSource(filterer OperatorSocketsFilterer, tx core.Transactor, chainState core.IndexedChainState) *operatorSocketSource {
+	return &operatorSocketSource{
+		filterer:   filterer,
+		tx:         tx,
+		chainState: chainState,
+	}
+}
+
+func (s *operatorSocketSource) GetOperatorSockets(ctx context.Context) (map[core.OperatorID]core.OperatorSocket, error) {
+	blockNumber, err := s.tx.GetCurrentBlockNumber(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the current block number: %w", err)
+	}
+	quorumCount, err := s.tx.GetQuorumCount(ctx, blockNumber)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the quorum count: %w", err)
+	}
+	quorums := make([]core.QuorumID, quorumCount)
+	for i := range quorums {
+		quorums[i] = core.QuorumID(i)
+	}
+
+	state, err := s.chainState.GetIndexedOperatorState(ctx, uint(blockNumber), quorums)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the operator state at block %d: %w", blockNumber, err)
+	}
+	sockets := make(map[core.OperatorID]core.OperatorSocket, len(state.IndexedOperators))
+	for operatorID, operator := range state.IndexedOperators {
+		sockets[operatorID] = core.OperatorSocket(operator.Socket)
+	}
+	return sockets, nil
+}
+
+func (s *operatorSocketSource) WatchOperatorSocketUpdates(ctx context.Context) (<-chan core.OperatorSocketUpdate, error) {
+	return s.filterer.WatchOperatorSocketUpdates(ctx)
+}
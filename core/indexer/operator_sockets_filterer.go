@@ -18,6 +18,7 @@ type OperatorSocketsFilterer interface {
 	SetSyncPoint(latestHeader *indexer.Header) error
 	FilterFastMode(headers indexer.Headers) (*indexer.Header, indexer.Headers, error)
 	WatchOperatorSocketUpdate(ctx context.Context, operatorId core.OperatorID) (chan string, error)
+	WatchOperatorSocketUpdates(ctx context.Context) (<-chan core.OperatorSocketUpdate, error)
 }
 
 type operatorSocketsFilterer struct {
@@ -107,6 +108,8 @@ func (f *operatorSocketsFilterer) FilterFastMode(headers indexer.Headers) (*inde
 	return nil, headers, nil
 }
 
+// WatchOperatorSocketUpdate watches the socket updates of an operator. The channel is closed, and the subscription
+// released, when the subscription fails or the context is done.
 func (f *operatorSocketsFilterer) WatchOperatorSocketUpdate(ctx context.Context, operatorId core.OperatorID) (chan string, error) {
 	filterer, err := blsregcoord.NewContractBLSRegistryCoordinatorWithIndicesFilterer(f.Address, f.Filterer)
 	if err != nil {
@@ -115,21 +118,63 @@ func (f *operatorSocketsFilterer) WatchOperatorSocketUpdate(ctx context.Context,
 
 	sink := make(chan *blsregcoord.ContractBLSRegistryCoordinatorWithIndicesOperatorSocketUpdate)
 	operatorID := []core.OperatorID{operatorId}
-	_, err = filterer.WatchOperatorSocketUpdate(&bind.WatchOpts{Context: ctx}, sink, operatorID)
+	sub, err := filterer.WatchOperatorSocketUpdate(&bind.WatchOpts{Context: ctx}, sink, operatorID)
 	if err != nil {
 		return nil, err
 	}
 	socketChan := make(chan string)
 	go func() {
 		defer close(socketChan)
+		defer sub.Unsubscribe()
 		for {
 			select {
 			case <-ctx.Done():
 				return
+			case <-sub.Err():
+				return
 			case event := <-sink:
-				socketChan <- event.Socket
+				select {
+				case socketChan <- event.Socket:
+				case <-ctx.Done():
+					return
+				}
 			}
 		}
 	}()
 	return socketChan, nil
 }
+
+// WatchOperatorSocketUpdates watches the socket updates of all the operators. The channel is closed when the
+// subscription fails or the context is done.
+func (f *operatorSocketsFilterer) WatchOperatorSocketUpdates(ctx context.Context) (<-chan core.OperatorSocketUpdate, error) {
+	filterer, err := blsregcoord.NewContractBLSRegistryCoordinatorWithIndicesFilterer(f.Address, f.Filterer)
+	if err != nil {
+		return nil, err
+	}
+
+	sink := make(chan *blsregcoord.ContractBLSRegistryCoordinatorWithIndicesOperatorSocketUpdate)
+	sub, err := filterer.WatchOperatorSocketUpdate(&bind.WatchOpts{Context: ctx}, sink, nil)
+	if err != nil {
+		return nil, err
+	}
+	updates := make(chan core.OperatorSocketUpdate)
+	go func() {
+		defer close(updates)
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-sub.Err():
+				return
+			case event := <-sink:
+				select {
+				case updates <- core.OperatorSocketUpdate{OperatorID: event.OperatorId, Socket: core.OperatorSocket(event.Socket)}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return updates, nil
+}
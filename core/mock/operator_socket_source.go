@@ -0,0 +1,32 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockOperatorSocketSource struct {
+	mock.Mock
+}
+
+var _ core.OperatorSocketSource = (*MockOperatorSocketSource)(nil)
+
+func (s *MockOperatorSocketSource) GetOperatorSockets(ctx context.Context) (map[core.OperatorID]core.OperatorSocket, error) {
+	args := s.Called()
+	result := args.Get(0)
+	if result == nil {
+		return nil, args.Error(1)
+	}
+	return result.(map[core.OperatorID]core.OperatorSocket), args.Error(1)
+}
+
+func (s *MockOperatorSocketSource) WatchOperatorSocketUpdates(ctx context.Context) (<-chan core.OperatorSocketUpdate, error) {
+	args := s.Called()
+	result := args.Get(0)
+	if result == nil {
+		return nil, args.Error(1)
+	}
+	return result.(chan core.OperatorSocketUpdate), args.Error(1)
+}
@@ -44,3 +44,9 @@ func (t *MockOperatorSocketsFilterer) WatchOperatorSocketUpdate(ctx context.Cont
 	result := args.Get(0)
 	return result.(chan string), args.Error(1)
 }
+
+func (t *MockOperatorSocketsFilterer) WatchOperatorSocketUpdates(ctx context.Context) (<-chan core.OperatorSocketUpdate, error) {
+	args := t.Called()
+	result := args.Get(0)
+	return result.(<-chan core.OperatorSocketUpdate), args.Error(1)
+}
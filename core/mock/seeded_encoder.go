@@ -0,0 +1,205 @@
+package mock
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/pkg/encoding/encoder"
+	"github.com/Layr-Labs/eigenda/pkg/kzg/bn254"
+)
+
+// SeededEncoder is a core.Encoder for tests which produces deterministic, structurally valid commitments and chunks
+// without the KZG machinery or the SRS. The first chunks hold the blob data and the rest are derived from the seed, and
+// every commitment and proof is a multiple of the generator derived from the seed and the content it commits to. This
+// makes VerifyChunks and VerifyBlobLength accept exactly what Encode produced with the same seed, but the commitments
+// offer no security and must never be used outside of tests.
+type SeededEncoder struct {
+	Seed int64
+}
+
+var _ core.Encoder = (*SeededEncoder)(nil)
+
+func NewSeededEncoder(seed int64) *SeededEncoder {
+	return &SeededEncoder{Seed: seed}
+}
+
+func (e *SeededEncoder) Encode(data []byte, params core.EncodingParams) (core.BlobCommitments, []*core.Chunk, error) {
+	symbols, err := params.Layout.ToSymbols(data)
+	if err != nil {
+		return core.BlobCommitments{}, nil, err
+	}
+	length := uint(len(symbols))
+	if params.ChunkLength*params.NumChunks < length {
+		return core.BlobCommitments{}, nil, errors.New("the supplied encoding parameters are not sufficient for the size of the data input")
+	}
+
+	commitment := e.point("commitment", data)
+	commitments := core.BlobCommitments{
+		Commitment:  &core.Commitment{G1Point: commitment},
+		LengthProof: &core.Commitment{G1Point: e.lengthProof(commitment, length)},
+		Length:      length,
+	}
+
+	chunks := make([]*core.Chunk, params.NumChunks)
+	for i := range chunks {
+		coeffs := make([]core.Symbol, params.ChunkLength)
+		for j := range coeffs {
+			k := uint(i)*params.ChunkLength + uint(j)
+			if k < uint(len(symbols)) {
+				coeffs[j] = symbols[k]
+			} else {
+				coeffs[j] = e.scalar("symbol", bn254.ToCompressedG1(commitment), uint64Bytes(uint64(k)))
+			}
+		}
+		chunks[i] = &core.Chunk{
+			Coeffs: coeffs,
+			Proof:  *e.chunkProof(commitment, core.ChunkNumber(i), coeffs),
+		}
+	}
+
+	return commitments, chunks, nil
+}
+
+func (e *SeededEncoder) VerifyChunks(chunks []*core.Chunk, indices []core.ChunkNumber, commitments core.BlobCommitments, params core.EncodingParams) error {
+	if err := checkChunks(chunks, indices, commitments); err != nil {
+		return err
+	}
+
+	for i, chunk := range chunks {
+		if err := e.verifyChunk(chunk, indices[i], commitments, params); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (e *SeededEncoder) VerifyChunksDetailed(chunks []*core.Chunk, indices []core.ChunkNumber, commitments core.BlobCommitments, params core.EncodingParams, collectAll bool) (*core.ChunkVerificationResult, error) {
+	if err := checkChunks(chunks, indices, commitments); err != nil {
+		return nil, err
+	}
+
+	result := &core.ChunkVerificationResult{}
+	for i, chunk := range chunks {
+		if err := e.verifyChunk(chunk, indices[i], commitments, params); err != nil {
+			result.FailedIndices = append(result.FailedIndices, indices[i])
+			if !collectAll {
+				break
+			}
+			continue
+		}
+		result.NumVerified++
+	}
+
+	return result, nil
+}
+
+func checkChunks(chunks []*core.Chunk, indices []core.ChunkNumber, commitments core.BlobCommitments) error {
+	if len(chunks) != len(indices) {
+		return fmt.Errorf("number of chunks %d does not match number of indices %d", len(chunks), len(indices))
+	}
+	if commitments.Commitment == nil || commitments.Commitment.G1Point == nil {
+		return errors.New("missing commitment")
+	}
+	return nil
+}
+
+func (e *SeededEncoder) verifyChunk(chunk *core.Chunk, index core.ChunkNumber, commitments core.BlobCommitments, params core.EncodingParams) error {
+	if uint(index) >= params.NumChunks {
+		return fmt.Errorf("chunk index %d out of range", index)
+	}
+	if uint(len(chunk.Coeffs)) != params.ChunkLength {
+		return fmt.Errorf("chunk %d has length %d, expected %d", index, len(chunk.Coeffs), params.ChunkLength)
+	}
+	expected := e.chunkProof(commitments.Commitment.G1Point, index, chunk.Coeffs)
+	if !bn254.EqualG1(expected, &chunk.Proof) {
+		return fmt.Errorf("chunk %d does not match the commitment", index)
+	}
+	return nil
+}
+
+func (e *SeededEncoder) VerifyBlobLength(commitments core.BlobCommitments) error {
+	if commitments.Commitment == nil || commitments.Commitment.G1Point == nil || commitments.LengthProof == nil || commitments.LengthProof.G1Point == nil {
+		return errors.New("missing commitment")
+	}
+	if !bn254.EqualG1(e.lengthProof(commitments.Commitment.G1Point, commitments.Length), commitments.LengthProof.G1Point) {
+		return errors.New("length proof does not match the commitment")
+	}
+	return nil
+}
+
+// Decode reconstructs the blob from the systematic chunks, which must all be present
+func (e *SeededEncoder) Decode(chunks []*core.Chunk, indices []core.ChunkNumber, params core.EncodingParams, maxInputSize uint64) ([]byte, error) {
+	if len(chunks) != len(indices) {
+		return nil, fmt.Errorf("number of chunks %d does not match number of indices %d", len(chunks), len(indices))
+	}
+	if params.ChunkLength == 0 {
+		return nil, errors.New("chunk length must be positive")
+	}
+
+	length := uint(encoder.GetNumElement(maxInputSize, bn254.BYTES_PER_COEFFICIENT))
+	numSystematic := (length + params.ChunkLength - 1) / params.ChunkLength
+
+	systematic := make([]*core.Chunk, numSystematic)
+	for i, index := range indices {
+		if uint(index) < numSystematic {
+			systematic[index] = chunks[i]
+		}
+	}
+
+	symbols := make([]bn254.Fr, 0, numSystematic*params.ChunkLength)
+	for i, chunk := range systematic {
+		if chunk == nil {
+			return nil, fmt.Errorf("missing systematic chunk %d", i)
+		}
+		symbols = append(symbols, chunk.Coeffs...)
+	}
+
+	return encoder.ToByteArray(symbols, maxInputSize), nil
+}
+
+func (e *SeededEncoder) lengthProof(commitment *bn254.G1Point, length uint) *bn254.G1Point {
+	return e.point("length", bn254.ToCompressedG1(commitment), uint64Bytes(uint64(length)))
+}
+
+func (e *SeededEncoder) chunkProof(commitment *bn254.G1Point, index core.ChunkNumber, coeffs []core.Symbol) *bn254.G1Point {
+	parts := make([][]byte, 0, len(coeffs)+2)
+	parts = append(parts, bn254.ToCompressedG1(commitment), uint64Bytes(uint64(index)))
+	for i := range coeffs {
+		b := bn254.FrToBytes(&coeffs[i])
+		parts = append(parts, b[:])
+	}
+	return e.point("proof", parts...)
+}
+
+// point returns the generator multiplied by the scalar derived from the parts
+func (e *SeededEncoder) point(domain string, parts ...[]byte) *bn254.G1Point {
+	s := e.scalar(domain, parts...)
+	var p bn254.G1Point
+	bn254.MulG1(&p, &bn254.GenG1, &s)
+	return &p
+}
+
+// scalar hashes the seed, the domain and the parts into a field element
+func (e *SeededEncoder) scalar(domain string, parts ...[]byte) bn254.Fr {
+	h := sha256.New()
+	h.Write(uint64Bytes(uint64(e.Seed)))
+	h.Write([]byte(domain))
+	for _, p := range parts {
+		h.Write(uint64Bytes(uint64(len(p))))
+		h.Write(p)
+	}
+
+	var s bn254.Fr
+	bn254.FrSetBytes(&s, h.Sum(nil))
+	return s
+}
+
+func uint64Bytes(v uint64) []byte {
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], v)
+	return b[:]
+}
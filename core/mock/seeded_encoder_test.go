@@ -0,0 +1,50 @@
+package mock_test
+
+import (
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/core/mock"
+	"github.com/Layr-Labs/eigenda/pkg/kzg/bn254"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var gettysburgAddressBytes = []byte("Fourscore and seven years ago our fathers brought forth, on this continent, a new nation, conceived in liberty, and dedicated to the proposition that all men are created equal.")
+
+func TestSeededEncoder(t *testing.T) {
+	params := core.EncodingParams{ChunkLength: 16, NumChunks: 8}
+	enc := mock.NewSeededEncoder(1)
+
+	commitments, chunks, err := enc.Encode(gettysburgAddressBytes, params)
+	require.NoError(t, err)
+	require.Len(t, chunks, int(params.NumChunks))
+	assert.Equal(t, core.GetBlobLength(uint(len(gettysburgAddressBytes))), commitments.Length)
+
+	// Encoding is deterministic for a given seed
+	commitments2, chunks2, err := mock.NewSeededEncoder(1).Encode(gettysburgAddressBytes, params)
+	require.NoError(t, err)
+	assert.True(t, bn254.EqualG1(commitments.Commitment.G1Point, commitments2.Commitment.G1Point))
+	assert.Equal(t, chunks, chunks2)
+
+	indices := []core.ChunkNumber{0, 1, 2, 3, 4, 5, 6, 7}
+	assert.NoError(t, enc.VerifyBlobLength(commitments))
+	assert.NoError(t, enc.VerifyChunks(chunks, indices, commitments, params))
+
+	// Chunks must be verified at their own index
+	assert.Error(t, enc.VerifyChunks(chunks[:1], []core.ChunkNumber{1}, commitments, params))
+	result, err := enc.VerifyChunksDetailed(chunks[:3], []core.ChunkNumber{1, 1, 0}, commitments, params, true)
+	require.NoError(t, err)
+	assert.Equal(t, &core.ChunkVerificationResult{NumVerified: 1, FailedIndices: []core.ChunkNumber{1, 0}}, result)
+
+	decoded, err := enc.Decode(chunks[:6], indices[:6], params, uint64(len(gettysburgAddressBytes)))
+	require.NoError(t, err)
+	assert.Equal(t, gettysburgAddressBytes, decoded)
+
+	_, err = enc.Decode(chunks[1:], indices[1:], params, uint64(len(gettysburgAddressBytes)))
+	assert.Error(t, err)
+
+	// Parameters that can't hold the data are rejected
+	_, _, err = enc.Encode(gettysburgAddressBytes, core.EncodingParams{ChunkLength: 1, NumChunks: 1})
+	assert.Error(t, err)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"math/big"
+	"sync"
 
 	"github.com/Layr-Labs/eigenda/core"
 	"github.com/stretchr/testify/mock"
@@ -14,6 +15,9 @@ type ChainDataMock struct {
 
 	KeyPairs     []*core.KeyPair
 	NumOperators core.OperatorIndex
+
+	mu              sync.RWMutex
+	operatorQuorums map[core.OperatorID][]core.QuorumID
 }
 
 var _ core.ChainState = (*ChainDataMock)(nil)
@@ -183,7 +187,24 @@ func (d *ChainDataMock) GetOperatorState(ctx context.Context, blockNumber uint,
 
 }
 
+// SetOperatorQuorums sets the quorums the operator is registered in, which GetOperatorStateByOperator returns the state
+// of. By default, operators are registered in all the quorums.
+func (d *ChainDataMock) SetOperatorQuorums(operator core.OperatorID, quorums []core.QuorumID) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.operatorQuorums == nil {
+		d.operatorQuorums = make(map[core.OperatorID][]core.QuorumID)
+	}
+	d.operatorQuorums[operator] = quorums
+}
+
 func (d *ChainDataMock) GetOperatorStateByOperator(ctx context.Context, blockNumber uint, operator core.OperatorID) (*core.OperatorState, error) {
+	d.mu.RLock()
+	quorums, ok := d.operatorQuorums[operator]
+	d.mu.RUnlock()
+	if ok {
+		return d.GetTotalOperatorStateWithQuorums(ctx, blockNumber, quorums).OperatorState, nil
+	}
 
 	state := d.GetTotalOperatorState(ctx, blockNumber)
 
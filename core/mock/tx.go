@@ -71,13 +71,17 @@ func (t *MockTransactor) GetOperatorStakesForQuorums(ctx context.Context, quorum
 	return result.([][]core.OperatorStake), args.Error(1)
 }
 
-func (t *MockTransactor) ConfirmBatch(ctx context.Context, batchHeader core.BatchHeader, quorums map[core.QuorumID]*core.QuorumResult, signatureAggregation core.SignatureAggregation) (*types.Receipt, error) {
+func (t *MockTransactor) ConfirmBatch(ctx context.Context, batchHeader core.BatchHeader, quorums map[core.QuorumID]*core.QuorumResult, signatureAggregation core.SignatureAggregation, replaced *types.Transaction) (*types.Receipt, *types.Transaction, error) {
 	args := t.Called()
 	var receipt *types.Receipt
 	if args.Get(0) != nil {
 		receipt = args.Get(0).(*types.Receipt)
 	}
-	return receipt, args.Error(1)
+	var sent *types.Transaction
+	if args.Get(1) != nil {
+		sent = args.Get(1).(*types.Transaction)
+	}
+	return receipt, sent, args.Error(2)
 }
 
 func (t *MockTransactor) StakeRegistry(ctx context.Context) (gethcommon.Address, error) {
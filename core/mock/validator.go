@@ -23,11 +23,20 @@ func NewMockChunkValidator() *MockChunkValidator {
 	return &MockChunkValidator{}
 }
 
-func (v *MockChunkValidator) ValidateBlob(blob *core.BlobMessage, operatorState *core.OperatorState) error {
-	args := v.Called(blob, operatorState)
+func (v *MockChunkValidator) ValidateBlob(blob *core.BlobMessage, operatorState *core.OperatorState, referenceBlockNumber uint) error {
+	args := v.Called(blob, operatorState, referenceBlockNumber)
 	return args.Error(0)
 }
 
+func (v *MockChunkValidator) ValidateBlobDetailed(blob *core.BlobMessage, operatorState *core.OperatorState, referenceBlockNumber uint) (map[core.QuorumID]error, error) {
+	args := v.Called(blob, operatorState, referenceBlockNumber)
+	var results map[core.QuorumID]error
+	if args.Get(0) != nil {
+		results = args.Get(0).(map[core.QuorumID]error)
+	}
+	return results, args.Error(1)
+}
+
 func (v *MockChunkValidator) UpdateOperatorID(operatorID core.OperatorID) {
 	v.Called(operatorID)
 }
@@ -7,6 +7,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
 	"math/big"
 	"regexp"
 
@@ -23,10 +24,19 @@ import (
 var ErrInvalidCommitment = errors.New("invalid commitment")
 
 func ComputeSignatoryRecordHash(referenceBlockNumber uint32, nonSignerKeys []*G1Point) [32]byte {
+	nonSignerIDs := make([]OperatorID, len(nonSignerKeys))
+	for i, nonSignerKey := range nonSignerKeys {
+		nonSignerIDs[i] = nonSignerKey.GetOperatorID()
+	}
+	return HashSignatoryRecord(referenceBlockNumber, nonSignerIDs)
+}
+
+// HashSignatoryRecord returns the signatory record hash of a batch from the hashes of the public keys of its non-signers,
+// i.e. their operator IDs, as computed by the service manager contract
+func HashSignatoryRecord(referenceBlockNumber uint32, nonSignerPubKeyHashes []OperatorID) [32]byte {
 	buf := make([]byte, 4)
 	binary.BigEndian.PutUint32(buf, referenceBlockNumber)
-	for _, nonSignerKey := range nonSignerKeys {
-		hash := nonSignerKey.GetOperatorID()
+	for _, hash := range nonSignerPubKeyHashes {
 		buf = append(buf, hash[:]...)
 	}
 
@@ -59,6 +69,11 @@ func (h *BatchHeader) SetBatchRoot(blobHeaders []*BlobHeader) (*merkletree.Merkl
 }
 
 func (h *BatchHeader) Encode() ([]byte, error) {
+	// The reference block number is a uint32 on chain, truncating it would sign a batch header the contract never hashes
+	if h.ReferenceBlockNumber > math.MaxUint32 {
+		return nil, fmt.Errorf("reference block number %d overflows uint32", h.ReferenceBlockNumber)
+	}
+
 	// The order here has to match the field ordering of ReducedBatchHeader defined in IEigenDAServiceManager.sol
 	// ref: https://github.com/Layr-Labs/eigenda/blob/master/contracts/src/interfaces/IEigenDAServiceManager.sol#L43
 	batchHeaderType, err := abi.NewType("tuple", "", []abi.ArgumentMarshaling{
@@ -97,7 +112,9 @@ func (h *BatchHeader) Encode() ([]byte, error) {
 	return bytes, nil
 }
 
-// GetBatchHeaderHash returns the hash of the reduced BatchHeader that is used to sign the Batch
+// GetBatchHeaderHash returns the hash of the reduced BatchHeader that is used to sign the Batch. This is the canonical batch
+// header hash which the batcher and the node must use rather than re-deriving it, as it must match the hash computed by
+// the service manager contract.
 // ref: https://github.com/Layr-Labs/eigenda/blob/master/contracts/src/libraries/EigenDAHasher.sol#L65
 func (h BatchHeader) GetBatchHeaderHash() ([32]byte, error) {
 	headerByte, err := h.Encode()
@@ -170,7 +187,9 @@ func HashBatchHeader(batchHeader binding.IEigenDAServiceManagerBatchHeader) ([32
 	return headerHash, nil
 }
 
-// GetBlobHeaderHash returns the hash of the BlobHeader that is used to sign the Blob
+// GetBlobHeaderHash returns the hash of the BlobHeader that is used to sign the Blob. This is the canonical blob header hash
+// used as the leaf of the batch Merkle tree and must be used by all components (batcher, node, retriever) rather than re-deriving it.
+// ref: https://github.com/Layr-Labs/eigenda/blob/master/contracts/src/libraries/EigenDAHasher.sol#L73
 func (h BlobHeader) GetBlobHeaderHash() ([32]byte, error) {
 	headerByte, err := h.Encode()
 	if err != nil {
@@ -396,6 +415,87 @@ func (c *Commitment) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// securityParamJSON is the JSON encoding of a SecurityParam. Its field names and decimal values are read by the
+// diagnostics tooling, so they must not change.
+type securityParamJSON struct {
+	QuorumID           QuorumID `json:"quorum_id"`
+	AdversaryThreshold uint8    `json:"adversary_threshold"`
+	QuorumThreshold    uint8    `json:"quorum_threshold"`
+	QuorumRate         uint32   `json:"quorum_rate"`
+}
+
+func (p SecurityParam) toJSON() securityParamJSON {
+	return securityParamJSON{
+		QuorumID:           p.QuorumID,
+		AdversaryThreshold: p.AdversaryThreshold,
+		QuorumThreshold:    p.QuorumThreshold,
+		QuorumRate:         p.QuorumRate,
+	}
+}
+
+func (p securityParamJSON) toSecurityParam() SecurityParam {
+	return SecurityParam{
+		QuorumID:           p.QuorumID,
+		AdversaryThreshold: p.AdversaryThreshold,
+		QuorumThreshold:    p.QuorumThreshold,
+		QuorumRate:         p.QuorumRate,
+	}
+}
+
+func (p SecurityParam) MarshalJSON() ([]byte, error) {
+	return json.Marshal(p.toJSON())
+}
+
+func (p *SecurityParam) UnmarshalJSON(data []byte) error {
+	var wire securityParamJSON
+	if err := json.Unmarshal(data, &wire); err != nil {
+		return err
+	}
+	*p = wire.toSecurityParam()
+	return nil
+}
+
+func (p SecurityParam) String() string {
+	return fmt.Sprintf("{quorum_id=%d adversary_threshold=%d quorum_threshold=%d quorum_rate=%d}", p.QuorumID, p.AdversaryThreshold, p.QuorumThreshold, p.QuorumRate)
+}
+
+// blobQuorumInfoJSON is the JSON encoding of a BlobQuorumInfo, which flattens its security param like the struct does
+type blobQuorumInfoJSON struct {
+	securityParamJSON
+	QuantizationFactor   uint `json:"quantization_factor"`
+	OverprovisionPercent uint `json:"overprovision_percent"`
+	EncodedBlobLength    uint `json:"encoded_blob_length"`
+}
+
+// MarshalJSON is defined on BlobQuorumInfo rather than promoted from its SecurityParam, which would drop its other
+// fields
+func (i BlobQuorumInfo) MarshalJSON() ([]byte, error) {
+	return json.Marshal(blobQuorumInfoJSON{
+		securityParamJSON:    i.SecurityParam.toJSON(),
+		QuantizationFactor:   i.QuantizationFactor,
+		OverprovisionPercent: i.OverprovisionPercent,
+		EncodedBlobLength:    i.EncodedBlobLength,
+	})
+}
+
+func (i *BlobQuorumInfo) UnmarshalJSON(data []byte) error {
+	var wire blobQuorumInfoJSON
+	if err := json.Unmarshal(data, &wire); err != nil {
+		return err
+	}
+	*i = BlobQuorumInfo{
+		SecurityParam:        wire.securityParamJSON.toSecurityParam(),
+		QuantizationFactor:   wire.QuantizationFactor,
+		OverprovisionPercent: wire.OverprovisionPercent,
+		EncodedBlobLength:    wire.EncodedBlobLength,
+	}
+	return nil
+}
+
+func (i BlobQuorumInfo) String() string {
+	return fmt.Sprintf("{quorum_id=%d adversary_threshold=%d quorum_threshold=%d quorum_rate=%d quantization_factor=%d overprovision_percent=%d encoded_blob_length=%d}", i.QuorumID, i.AdversaryThreshold, i.QuorumThreshold, i.QuorumRate, i.QuantizationFactor, i.OverprovisionPercent, i.EncodedBlobLength)
+}
+
 func encode(obj any) ([]byte, error) {
 	var buf bytes.Buffer
 	enc := gob.NewEncoder(&buf)
@@ -1,7 +1,10 @@
 package core_test
 
 import (
+	"bytes"
 	"encoding/json"
+	"fmt"
+	"math"
 	"math/big"
 	"testing"
 
@@ -10,8 +13,10 @@ import (
 	kzgbn254 "github.com/Layr-Labs/eigenda/pkg/kzg/bn254"
 	"github.com/consensys/gnark-crypto/ecc/bn254"
 	"github.com/consensys/gnark-crypto/ecc/bn254/fp"
+	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -21,6 +26,8 @@ const (
 	batchHeaderHash        = "0xa48219ff51a67bf779c6f7858e3bf9760ef10a766e5dc5d461318c8e9d5607b6"
 	encodedBlobHeader      = "0x000000000000000000000000000000000000000000000000000000000000002000000000000000000000000000000000000000000000000000000000000000010000000000000000000000000000000000000000000000000000000000000002000000000000000000000000000000000000000000000000000000000000000a000000000000000000000000000000000000000000000000000000000000008000000000000000000000000000000000000000000000000000000000000000010000000000000000000000000000000000000000000000000000000000000001000000000000000000000000000000000000000000000000000000000000005000000000000000000000000000000000000000000000000000000000000000640000000000000000000000000000000000000000000000000000000000000014"
 	blobHeaderHash         = "0x48b3e6540820e2f2c185764e22d438d5ff03551a867299b26cccf57fa2c3f237"
+	// multiQuorumBlobHeaderHash pins the hash of makeBlobHeader(1, 2, 1000, []uint8{0, 1, 2})
+	multiQuorumBlobHeaderHash = "0xa56ff8e182e849ae7b97dbe635bdcecd67d6e810f1cf8920300f7ebbcd0cb6bf"
 )
 
 func TestBatchHeaderEncoding(t *testing.T) {
@@ -50,6 +57,90 @@ func TestBatchHeaderEncoding(t *testing.T) {
 	assert.Equal(t, hexutil.Encode(hash[:]), batchHeaderHash)
 }
 
+// contractBatchHeaderTypes returns the BatchHeader struct taken by confirmBatch in the ABI of the service manager contract,
+// and the ReducedBatchHeader struct derived from it the way EigenDAHasher.convertBatchHeaderToReducedBatchHeader does
+func contractBatchHeaderTypes(t *testing.T) (abi.Type, abi.Type) {
+	contractAbi, err := binding.ContractEigenDAServiceManagerMetaData.GetAbi()
+	assert.NoError(t, err)
+	confirmBatch, ok := contractAbi.Methods["confirmBatch"]
+	assert.True(t, ok)
+	batchHeaderType := confirmBatch.Inputs[0].Type
+
+	var reducedFields []abi.ArgumentMarshaling
+	for i, name := range batchHeaderType.TupleRawNames {
+		if name == "blobHeadersRoot" || name == "referenceBlockNumber" {
+			reducedFields = append(reducedFields, abi.ArgumentMarshaling{Name: name, Type: batchHeaderType.TupleElems[i].String()})
+		}
+	}
+	assert.Len(t, reducedFields, 2)
+	reducedBatchHeaderType, err := abi.NewType("tuple", "", reducedFields)
+	assert.NoError(t, err)
+	return batchHeaderType, reducedBatchHeaderType
+}
+
+func TestBatchHeaderHashParity(t *testing.T) {
+	batchHeaderType, reducedBatchHeaderType := contractBatchHeaderTypes(t)
+
+	var maxRoot [32]byte
+	for i := range maxRoot {
+		maxRoot[i] = 0xff
+	}
+	tests := []struct {
+		name                       string
+		batchRoot                  [32]byte
+		quorumNumbers              []byte
+		quorumThresholdPercentages []byte
+		referenceBlockNumber       uint
+	}{
+		{name: "zero values", quorumNumbers: []byte{}, quorumThresholdPercentages: []byte{}},
+		{name: "empty quorums", batchRoot: [32]byte{1, 2, 3}, quorumNumbers: []byte{}, quorumThresholdPercentages: []byte{}, referenceBlockNumber: 100},
+		{name: "single quorum", batchRoot: [32]byte{1}, quorumNumbers: []byte{0}, quorumThresholdPercentages: []byte{100}, referenceBlockNumber: 1},
+		{name: "multiple quorums", batchRoot: [32]byte{0xab, 0xcd}, quorumNumbers: []byte{0, 1, 2}, quorumThresholdPercentages: []byte{80, 67, 55}, referenceBlockNumber: 19_000_000},
+		{name: "maximal values", batchRoot: maxRoot, quorumNumbers: bytes.Repeat([]byte{0xff}, 256), quorumThresholdPercentages: bytes.Repeat([]byte{0xff}, 256), referenceBlockNumber: math.MaxUint32},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			onchainBatchHeader := binding.IEigenDAServiceManagerBatchHeader{
+				BlobHeadersRoot:            tt.batchRoot,
+				QuorumNumbers:              tt.quorumNumbers,
+				QuorumThresholdPercentages: tt.quorumThresholdPercentages,
+				ReferenceBlockNumber:       uint32(tt.referenceBlockNumber),
+			}
+			encoded, err := abi.Arguments{{Type: batchHeaderType}}.Pack(onchainBatchHeader)
+			assert.NoError(t, err)
+			hash, err := core.HashBatchHeader(onchainBatchHeader)
+			assert.NoError(t, err)
+			assert.Equal(t, crypto.Keccak256Hash(encoded).Bytes(), hash[:])
+
+			reducedBatchHeader := struct {
+				BlobHeadersRoot      [32]byte
+				ReferenceBlockNumber uint32
+			}{
+				BlobHeadersRoot:      tt.batchRoot,
+				ReferenceBlockNumber: uint32(tt.referenceBlockNumber),
+			}
+			encoded, err = abi.Arguments{{Type: reducedBatchHeaderType}}.Pack(reducedBatchHeader)
+			assert.NoError(t, err)
+			batchHeader := core.BatchHeader{
+				ReferenceBlockNumber: tt.referenceBlockNumber,
+				BatchRoot:            tt.batchRoot,
+			}
+			hash, err = batchHeader.GetBatchHeaderHash()
+			assert.NoError(t, err)
+			assert.Equal(t, crypto.Keccak256Hash(encoded).Bytes(), hash[:])
+		})
+	}
+}
+
+func TestBatchHeaderHashReferenceBlockNumberOverflow(t *testing.T) {
+	batchHeader := core.BatchHeader{
+		ReferenceBlockNumber: math.MaxUint32 + 1,
+		BatchRoot:            [32]byte{1},
+	}
+	_, err := batchHeader.GetBatchHeaderHash()
+	assert.ErrorContains(t, err, "overflows uint32")
+}
+
 func TestBlobHeaderEncoding(t *testing.T) {
 
 	var commitX, commitY, lengthX, lengthY fp.Element
@@ -170,3 +261,118 @@ func TestQuorumParamsHash(t *testing.T) {
 	expected := "90a8cc415c00b8bc3dcc3b21f240277e93ef712327e0001094b045ec60dff65c"
 	assert.Equal(t, common.Bytes2Hex(hash[:]), expected)
 }
+
+func TestBlobHeaderHashMultipleQuorums(t *testing.T) {
+	blobHeader := makeBlobHeader(1, 2, 1000, []uint8{0, 1, 2})
+
+	h, err := blobHeader.GetBlobHeaderHash()
+	assert.NoError(t, err)
+	assert.Equal(t, multiQuorumBlobHeaderHash, hexutil.Encode(h[:]))
+
+	// The hash must not depend on fields that are not part of the on-chain encoding
+	blobHeader.AccountID = "account"
+	blobHeader.QuorumInfos[0].EncodedBlobLength = 12345
+	h, err = blobHeader.GetBlobHeaderHash()
+	assert.NoError(t, err)
+	assert.Equal(t, multiQuorumBlobHeaderHash, hexutil.Encode(h[:]))
+}
+
+func TestBlobHeaderHashNilCommitment(t *testing.T) {
+	blobHeader := &core.BlobHeader{}
+	_, err := blobHeader.GetBlobHeaderHash()
+	assert.ErrorIs(t, err, core.ErrInvalidCommitment)
+}
+
+func FuzzBlobHeaderHashRoundTrip(f *testing.F) {
+	f.Add(int64(1), int64(2), uint32(10), []byte{1})
+	f.Add(int64(7), int64(11), uint32(1000), []byte{0, 1, 2})
+	f.Fuzz(func(t *testing.T, x, y int64, length uint32, quorums []byte) {
+		blobHeader := makeBlobHeader(x, y, uint(length), quorums)
+		expected, err := blobHeader.GetBlobHeaderHash()
+		assert.NoError(t, err)
+
+		data, err := blobHeader.Serialize()
+		assert.NoError(t, err)
+		recovered, err := new(core.BlobHeader).Deserialize(data)
+		assert.NoError(t, err)
+
+		actual, err := recovered.GetBlobHeaderHash()
+		assert.NoError(t, err)
+		assert.Equal(t, expected, actual)
+	})
+}
+
+func makeBlobHeader(x, y int64, length uint, quorums []uint8) *core.BlobHeader {
+	var commitX, commitY fp.Element
+	commitX.SetBigInt(big.NewInt(x))
+	commitY.SetBigInt(big.NewInt(y))
+
+	quorumInfos := make([]*core.BlobQuorumInfo, len(quorums))
+	for i, q := range quorums {
+		quorumInfos[i] = &core.BlobQuorumInfo{
+			SecurityParam: core.SecurityParam{
+				QuorumID:           q,
+				AdversaryThreshold: 50 + q%40,
+				QuorumThreshold:    60 + q%40,
+			},
+			QuantizationFactor: uint(q%4) + 1,
+		}
+	}
+
+	return &core.BlobHeader{
+		BlobCommitments: core.BlobCommitments{
+			Commitment: &core.Commitment{
+				G1Point: &kzgbn254.G1Point{
+					X: commitX,
+					Y: commitY,
+				},
+			},
+			LengthProof: &core.Commitment{
+				G1Point: &kzgbn254.G1Point{
+					X: commitX,
+					Y: commitY,
+				},
+			},
+			Length: length,
+		},
+		QuorumInfos: quorumInfos,
+	}
+}
+
+// The JSON of the security params and quorum infos is read by the diagnostics tooling, so these goldens pin its format
+const (
+	securityParamJSON  = `{"quorum_id":1,"adversary_threshold":33,"quorum_threshold":67,"quorum_rate":512}`
+	blobQuorumInfoJSON = `{"quorum_id":2,"adversary_threshold":50,"quorum_threshold":100,"quorum_rate":0,"quantization_factor":1,"overprovision_percent":25,"encoded_blob_length":1024}`
+)
+
+func TestSecurityParamJSON(t *testing.T) {
+	param := &core.SecurityParam{QuorumID: 1, AdversaryThreshold: 33, QuorumThreshold: 67, QuorumRate: 512}
+	data, err := json.Marshal(param)
+	assert.NoError(t, err)
+	assert.Equal(t, securityParamJSON, string(data))
+
+	var decoded core.SecurityParam
+	assert.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, *param, decoded)
+	assert.Equal(t, "{quorum_id=1 adversary_threshold=33 quorum_threshold=67 quorum_rate=512}", param.String())
+	assert.Equal(t, "[{quorum_id=1 adversary_threshold=33 quorum_threshold=67 quorum_rate=512}]", fmt.Sprintf("%v", []*core.SecurityParam{param}))
+
+	assert.Error(t, json.Unmarshal([]byte(`{"quorum_id":256}`), &decoded))
+}
+
+func TestBlobQuorumInfoJSON(t *testing.T) {
+	info := &core.BlobQuorumInfo{
+		SecurityParam:        core.SecurityParam{QuorumID: 2, AdversaryThreshold: 50, QuorumThreshold: 100},
+		QuantizationFactor:   1,
+		OverprovisionPercent: 25,
+		EncodedBlobLength:    1024,
+	}
+	data, err := json.Marshal(info)
+	assert.NoError(t, err)
+	assert.Equal(t, blobQuorumInfoJSON, string(data))
+
+	var decoded core.BlobQuorumInfo
+	assert.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, *info, decoded)
+	assert.Equal(t, "{quorum_id=2 adversary_threshold=50 quorum_threshold=100 quorum_rate=0 quantization_factor=1 overprovision_percent=25 encoded_blob_length=1024}", info.String())
+}
@@ -0,0 +1,185 @@
+package core
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// OperatorSocketUpdate is a change of the socket of an operator. The socket is empty when the operator has been
+// deregistered.
+type OperatorSocketUpdate struct {
+	OperatorID OperatorID
+	Socket     OperatorSocket
+}
+
+// OperatorSocketSource is the source of the sockets indexed by the OperatorSocketIndexer
+type OperatorSocketSource interface {
+	// GetOperatorSockets returns the current sockets of all the registered operators
+	GetOperatorSockets(ctx context.Context) (map[OperatorID]OperatorSocket, error)
+	// WatchOperatorSocketUpdates streams the socket updates of all the operators. The channel is closed when the
+	// subscription fails or the context is done.
+	WatchOperatorSocketUpdates(ctx context.Context) (<-chan OperatorSocketUpdate, error)
+}
+
+// subscriberBufferSize is the number of updates buffered for each subscriber, beyond which the updates are dropped
+// rather than blocking the indexer
+const subscriberBufferSize = 100
+
+// OperatorSocketIndexer maintains the latest sockets of the operators, so that the batcher and the retriever don't
+// dial the sockets of the operator state at the reference block of a batch, which are stale after an operator has
+// updated its socket. The sockets are updated from the socket update events, and fully refreshed periodically in case
+// events are missed or the subscription isn't supported by the chain client.
+type OperatorSocketIndexer struct {
+	source          OperatorSocketSource
+	refreshInterval time.Duration
+	logger          common.Logger
+
+	mu          sync.RWMutex
+	sockets     map[OperatorID]OperatorSocket
+	subscribers map[chan OperatorSocketUpdate]struct{}
+}
+
+func NewOperatorSocketIndexer(source OperatorSocketSource, refreshInterval time.Duration, logger common.Logger) *OperatorSocketIndexer {
+	return &OperatorSocketIndexer{
+		source:          source,
+		refreshInterval: refreshInterval,
+		logger:          logger,
+		sockets:         make(map[OperatorID]OperatorSocket),
+		subscribers:     make(map[chan OperatorSocketUpdate]struct{}),
+	}
+}
+
+// Start refreshes the sockets, and keeps them up to date until the context is done
+func (i *OperatorSocketIndexer) Start(ctx context.Context) {
+	go func() {
+		if err := i.Refresh(ctx); err != nil {
+			i.logger.Error("failed to refresh the operator sockets", "err", err)
+		}
+		updates := i.watch(ctx)
+
+		ticker := time.NewTicker(i.refreshInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case update, ok := <-updates:
+				if !ok {
+					// Resubscribe on the next refresh
+					updates = nil
+					continue
+				}
+				i.UpdateOperatorSocket(update)
+			case <-ticker.C:
+				if err := i.Refresh(ctx); err != nil {
+					i.logger.Error("failed to refresh the operator sockets", "err", err)
+				}
+				if updates == nil {
+					updates = i.watch(ctx)
+				}
+			}
+		}
+	}()
+}
+
+// watch subscribes to the socket updates, returning nil when the subscription fails so that the sockets are only
+// kept up to date by the refreshes
+func (i *OperatorSocketIndexer) watch(ctx context.Context) <-chan OperatorSocketUpdate {
+	updates, err := i.source.WatchOperatorSocketUpdates(ctx)
+	if err != nil {
+		i.logger.Warn("failed to watch the operator socket updates, relying on the periodic refresh", "refreshInterval", i.refreshInterval, "err", err)
+		return nil
+	}
+	return updates
+}
+
+// Refresh replaces the indexed sockets by the current sockets of the source
+func (i *OperatorSocketIndexer) Refresh(ctx context.Context) error {
+	sockets, err := i.source.GetOperatorSockets(ctx)
+	if err != nil {
+		return err
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	for operatorID, socket := range sockets {
+		i.setSocket(operatorID, socket)
+	}
+	for operatorID := range i.sockets {
+		if _, ok := sockets[operatorID]; !ok {
+			i.setSocket(operatorID, "")
+		}
+	}
+	return nil
+}
+
+// UpdateOperatorSocket applies a socket update to the index
+func (i *OperatorSocketIndexer) UpdateOperatorSocket(update OperatorSocketUpdate) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.setSocket(update.OperatorID, update.Socket)
+}
+
+// setSocket sets the socket of the operator and notifies the subscribers if it changed. It must be called with the
+// lock held.
+func (i *OperatorSocketIndexer) setSocket(operatorID OperatorID, socket OperatorSocket) {
+	current, ok := i.sockets[operatorID]
+	if (ok && current == socket) || (!ok && socket == "") {
+		return
+	}
+	if socket == "" {
+		delete(i.sockets, operatorID)
+	} else {
+		i.sockets[operatorID] = socket
+	}
+
+	update := OperatorSocketUpdate{OperatorID: operatorID, Socket: socket}
+	for subscriber := range i.subscribers {
+		select {
+		case subscriber <- update:
+		default:
+			i.logger.Warn("dropped an operator socket update of a slow subscriber", "operator", hexutil.Encode(operatorID[:]), "socket", socket)
+		}
+	}
+}
+
+// GetOperatorSocket returns the latest socket of the operator, and whether it is indexed
+func (i *OperatorSocketIndexer) GetOperatorSocket(operatorID OperatorID) (OperatorSocket, bool) {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	socket, ok := i.sockets[operatorID]
+	return socket, ok
+}
+
+// ResolveOperatorSocket returns the latest socket of the operator, or the given socket when the operator isn't indexed.
+// It may be called on a nil indexer, which doesn't index any operator.
+func (i *OperatorSocketIndexer) ResolveOperatorSocket(operatorID OperatorID, socket string) string {
+	if i == nil {
+		return socket
+	}
+	if latest, ok := i.GetOperatorSocket(operatorID); ok {
+		return latest.String()
+	}
+	return socket
+}
+
+// Subscribe returns a channel receiving the changes of the indexed sockets, which is closed when the context is done
+func (i *OperatorSocketIndexer) Subscribe(ctx context.Context) <-chan OperatorSocketUpdate {
+	subscriber := make(chan OperatorSocketUpdate, subscriberBufferSize)
+	i.mu.Lock()
+	i.subscribers[subscriber] = struct{}{}
+	i.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		i.mu.Lock()
+		delete(i.subscribers, subscriber)
+		close(subscriber)
+		i.mu.Unlock()
+	}()
+	return subscriber
+}
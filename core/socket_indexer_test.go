@@ -0,0 +1,141 @@
+package core_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	commonmock "github.com/Layr-Labs/eigenda/common/mock"
+	"github.com/Layr-Labs/eigenda/core"
+	coremock "github.com/Layr-Labs/eigenda/core/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	operator1 = core.OperatorID{1}
+	operator2 = core.OperatorID{2}
+)
+
+func TestOperatorSocketIndexerRefresh(t *testing.T) {
+	source := &coremock.MockOperatorSocketSource{}
+	source.On("GetOperatorSockets").Return(map[core.OperatorID]core.OperatorSocket{
+		operator1: "host1:32000;32001",
+		operator2: "host2:32000;32001",
+	}, nil).Once()
+	source.On("GetOperatorSockets").Return(map[core.OperatorID]core.OperatorSocket{
+		operator1: "host3:32000;32001",
+	}, nil).Once()
+	indexer := core.NewOperatorSocketIndexer(source, time.Minute, &commonmock.Logger{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	updates := indexer.Subscribe(ctx)
+
+	err := indexer.Refresh(ctx)
+	assert.NoError(t, err)
+	socket, ok := indexer.GetOperatorSocket(operator1)
+	assert.True(t, ok)
+	assert.Equal(t, core.OperatorSocket("host1:32000;32001"), socket)
+	assert.Len(t, updates, 2)
+	<-updates
+	<-updates
+
+	// The socket of operator 1 changes and operator 2 is deregistered
+	err = indexer.Refresh(ctx)
+	assert.NoError(t, err)
+	socket, ok = indexer.GetOperatorSocket(operator1)
+	assert.True(t, ok)
+	assert.Equal(t, core.OperatorSocket("host3:32000;32001"), socket)
+	_, ok = indexer.GetOperatorSocket(operator2)
+	assert.False(t, ok)
+	assert.Equal(t, "host2:32000;32001", indexer.ResolveOperatorSocket(operator2, "host2:32000;32001"))
+
+	received := map[core.OperatorID]core.OperatorSocket{}
+	for i := 0; i < 2; i++ {
+		update := <-updates
+		received[update.OperatorID] = update.Socket
+	}
+	assert.Equal(t, map[core.OperatorID]core.OperatorSocket{operator1: "host3:32000;32001", operator2: ""}, received)
+	assert.Len(t, updates, 0)
+
+	source.On("GetOperatorSockets").Return(nil, errors.New("rpc error")).Once()
+	err = indexer.Refresh(ctx)
+	assert.ErrorContains(t, err, "rpc error")
+	socket, ok = indexer.GetOperatorSocket(operator1)
+	assert.True(t, ok)
+	assert.Equal(t, core.OperatorSocket("host3:32000;32001"), socket)
+}
+
+func TestOperatorSocketIndexerUpdatesMidBatch(t *testing.T) {
+	source := &coremock.MockOperatorSocketSource{}
+	source.On("GetOperatorSockets").Return(map[core.OperatorID]core.OperatorSocket{
+		operator1: "host1:32000;32001",
+		operator2: "host2:32000;32001",
+	}, nil)
+	events := make(chan core.OperatorSocketUpdate)
+	source.On("WatchOperatorSocketUpdates").Return(events, nil)
+	indexer := core.NewOperatorSocketIndexer(source, time.Minute, &commonmock.Logger{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	updates := indexer.Subscribe(ctx)
+	indexer.Start(ctx)
+	assert.Eventually(t, func() bool {
+		_, ok := indexer.GetOperatorSocket(operator2)
+		return ok
+	}, time.Second, 10*time.Millisecond)
+	<-updates
+	<-updates
+
+	// The batch was created with the operator state in which operator 1 has its previous socket
+	batchSocket := "host1:32000;32001"
+	events <- core.OperatorSocketUpdate{OperatorID: operator1, Socket: "host3:32000;32001"}
+	update := <-updates
+	assert.Equal(t, core.OperatorSocketUpdate{OperatorID: operator1, Socket: "host3:32000;32001"}, update)
+	assert.Equal(t, "host3:32000;32001", indexer.ResolveOperatorSocket(operator1, batchSocket))
+	assert.Equal(t, "host2:32000;32001", indexer.ResolveOperatorSocket(operator2, "host2:32000;32001"))
+
+	// An update which doesn't change the socket isn't notified
+	events <- core.OperatorSocketUpdate{OperatorID: operator1, Socket: "host3:32000;32001"}
+	events <- core.OperatorSocketUpdate{OperatorID: operator2, Socket: "host4:32000;32001"}
+	update = <-updates
+	assert.Equal(t, core.OperatorSocketUpdate{OperatorID: operator2, Socket: "host4:32000;32001"}, update)
+
+	cancel()
+	_, ok := <-updates
+	assert.False(t, ok)
+}
+
+func TestOperatorSocketIndexerFallsBackToRefresh(t *testing.T) {
+	source := &coremock.MockOperatorSocketSource{}
+	source.On("GetOperatorSockets").Return(map[core.OperatorID]core.OperatorSocket{
+		operator1: "host1:32000;32001",
+	}, nil).Once()
+	source.On("GetOperatorSockets").Return(map[core.OperatorID]core.OperatorSocket{
+		operator1: "host3:32000;32001",
+	}, nil)
+	var watchAttempts atomic.Int32
+	source.On("WatchOperatorSocketUpdates").Return(nil, errors.New("notifications not supported")).Run(func(mock.Arguments) {
+		watchAttempts.Add(1)
+	})
+	indexer := core.NewOperatorSocketIndexer(source, 10*time.Millisecond, &commonmock.Logger{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	indexer.Start(ctx)
+	assert.Eventually(t, func() bool {
+		return indexer.ResolveOperatorSocket(operator1, "") == "host3:32000;32001"
+	}, time.Second, 10*time.Millisecond)
+	// The subscription is retried on the next refreshes
+	assert.Eventually(t, func() bool {
+		return watchAttempts.Load() > 1
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestResolveOperatorSocketWithoutIndexer(t *testing.T) {
+	var indexer *core.OperatorSocketIndexer
+	assert.Equal(t, "host1:32000;32001", indexer.ResolveOperatorSocket(operator1, "host1:32000;32001"))
+}
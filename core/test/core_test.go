@@ -79,7 +79,7 @@ func prepareBatch(t *testing.T, cst core.IndexedChainState, blob core.Blob, quor
 		t.Fatal(err)
 	}
 
-	assignments, info, err := asn.GetAssignments(state, quorumID, quantizationFactor)
+	assignments, info, err := asn.GetAssignments(state, quorumID, quantizationFactor, 0)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -149,7 +149,7 @@ func prepareBatch(t *testing.T, cst core.IndexedChainState, blob core.Blob, quor
 // checkBatch runs the verification logic for each DA node in the current OperatorState, and returns an error if any of
 // the DA nodes' validation checks fails
 func checkBatch(t *testing.T, cst core.IndexedChainState, encodedBlob core.EncodedBlob, header core.BatchHeader) {
-	val := core.NewChunkValidator(enc, asn, cst, [32]byte{})
+	val := core.NewChunkValidator(enc, asn, cst, [32]byte{}, 0, 0, 0)
 
 	quorums := []core.QuorumID{0}
 	state, _ := cst.GetIndexedOperatorState(context.Background(), header.ReferenceBlockNumber, quorums)
@@ -159,7 +159,7 @@ func checkBatch(t *testing.T, cst core.IndexedChainState, encodedBlob core.Encod
 		blobMessage := encodedBlob[id]
 
 		val.UpdateOperatorID(id)
-		err := val.ValidateBlob(blobMessage, state.OperatorState)
+		err := val.ValidateBlob(blobMessage, state.OperatorState, header.ReferenceBlockNumber)
 		assert.NoError(t, err)
 	}
 
@@ -62,7 +62,9 @@ type Transactor interface {
 
 	// ConfirmBatch confirms a batch header and signature aggregation. The signature aggregation must satisfy the quorum thresholds
 	// specified in the batch header. If the signature aggregation does not satisfy the quorum thresholds, the transaction will fail.
-	ConfirmBatch(ctx context.Context, batchHeader BatchHeader, quorums map[QuorumID]*QuorumResult, signatureAggregation SignatureAggregation) (*types.Receipt, error)
+	// If the replaced transaction isn't nil, the transaction replaces it at the same nonce with bumped fees. The transaction sent
+	// is returned along with its receipt, also when waiting for the receipt fails.
+	ConfirmBatch(ctx context.Context, batchHeader BatchHeader, quorums map[QuorumID]*QuorumResult, signatureAggregation SignatureAggregation, replaced *types.Transaction) (*types.Receipt, *types.Transaction, error)
 
 	// GetBlockStaleMeasure returns the BLOCK_STALE_MEASURE defined onchain.
 	GetBlockStaleMeasure(ctx context.Context) (uint32, error)
@@ -2,15 +2,30 @@ package core
 
 import (
 	"errors"
+	"fmt"
+	"sync"
 )
 
 var (
-	ErrChunkLengthMismatch = errors.New("chunk length mismatch")
-	ErrInvalidHeader       = errors.New("invalid header")
+	ErrChunkLengthMismatch     = errors.New("chunk length mismatch")
+	ErrInvalidHeader           = errors.New("invalid header")
+	ErrStaleOperatorState      = errors.New("stale operator state")
+	ErrInvalidChunkIndices     = errors.New("invalid chunk indices")
+	ErrReferenceBlockTooRecent = errors.New("reference block too recent")
+	ErrChunkChecksumMismatch   = errors.New("chunk checksum mismatch")
 )
 
 type ChunkValidator interface {
-	ValidateBlob(*BlobMessage, *OperatorState) error
+	// ValidateBlob validates the chunks of the blob against the operator state, which must be within the max block gap of
+	// the validator from the reference block of the blob's batch
+	ValidateBlob(blob *BlobMessage, operatorState *OperatorState, referenceBlockNumber uint) error
+	// ValidateBlobDetailed validates the blob like ValidateBlob, but validates all of its quorums rather than stopping at
+	// the first invalid one. It returns the result of each quorum, nil for the valid quorums, along with an error
+	// summarizing the invalid quorums if any. The results are nil if the blob fails the checks which don't depend on
+	// its quorums.
+	ValidateBlobDetailed(blob *BlobMessage, operatorState *OperatorState, referenceBlockNumber uint) (map[QuorumID]error, error)
+	// UpdateOperatorID changes the operator whose chunks are validated. The blobs being validated keep the operator
+	// they started with.
 	UpdateOperatorID(OperatorID)
 }
 
@@ -19,98 +34,262 @@ type chunkValidator struct {
 	encoder    Encoder
 	assignment AssignmentCoordinator
 	chainState ChainState
-	operatorID OperatorID
+	// operatorID is guarded by operatorIDMu, as it can be updated while blobs are validated
+	operatorIDMu sync.RWMutex
+	operatorID   OperatorID
+	// maxBlockGap is the max number of blocks between the operator state and the reference block of a blob
+	maxBlockGap uint
+	// minReferenceBlockAge is the min number of blocks between the reference block of a blob and the current block
+	minReferenceBlockAge uint
+	// concurrency is the max number of quorums of a blob validated concurrently, or 0 to validate them sequentially
+	concurrency uint
 }
 
-func NewChunkValidator(enc Encoder, asgn AssignmentCoordinator, cst ChainState, operatorID OperatorID) ChunkValidator {
+// NewChunkValidator creates a chunk validator. The assignments of a blob are computed from the operator state at the
+// reference block of its batch, so the validator rejects the operator states more than maxBlockGap blocks away from it,
+// rather than failing on the chunk counts of assignments computed from a different operator set. It also rejects the
+// blobs whose reference block is less than minReferenceBlockAge blocks behind the current block of the chain state, as
+// the operator state at a block that recent could still be reorged, or accepts any reference block if it is 0. The
+// quorums of a blob are validated by up to concurrency workers, or sequentially if concurrency is 0; either way the
+// same error is returned for an invalid blob.
+func NewChunkValidator(enc Encoder, asgn AssignmentCoordinator, cst ChainState, operatorID OperatorID, maxBlockGap uint, minReferenceBlockAge uint, concurrency uint) ChunkValidator {
 	return &chunkValidator{
-		encoder:    enc,
-		assignment: asgn,
-		chainState: cst,
-		operatorID: operatorID,
+		encoder:              enc,
+		assignment:           asgn,
+		chainState:           cst,
+		operatorID:           operatorID,
+		maxBlockGap:          maxBlockGap,
+		minReferenceBlockAge: minReferenceBlockAge,
+		concurrency:          concurrency,
 	}
 }
 
-func (v *chunkValidator) ValidateBlob(blob *BlobMessage, operatorState *OperatorState) error {
-	if len(blob.Bundles) != len(blob.BlobHeader.QuorumInfos) {
-		return errors.New("number of bundles does not match number of quorums")
-	}
-
-	// Validate the blob length
-	err := v.encoder.VerifyBlobLength(blob.BlobHeader.BlobCommitments)
-	if err != nil {
+func (v *chunkValidator) ValidateBlob(blob *BlobMessage, operatorState *OperatorState, referenceBlockNumber uint) error {
+	if err := v.validateBlobHeader(blob, operatorState, referenceBlockNumber); err != nil {
 		return err
 	}
 
-	for _, quorumHeader := range blob.BlobHeader.QuorumInfos {
-
-		if quorumHeader.AdversaryThreshold >= quorumHeader.QuorumThreshold {
-			return errors.New("invalid header: quorum threshold does not exceed adversary threshold")
-		}
-
-		// Check if the operator is a member of the quorum
-		if _, ok := operatorState.Operators[quorumHeader.QuorumID]; !ok {
-			continue
-		}
-
-		// Get the assignments for the quorum
-		assignment, info, err := v.assignment.GetOperatorAssignment(operatorState, quorumHeader.QuorumID, quorumHeader.QuantizationFactor, v.operatorID)
+	// The error of the first invalid quorum is returned, whether the quorums are validated sequentially or not
+	for _, err := range v.validateQuorums(blob, operatorState, true) {
 		if err != nil {
 			return err
 		}
+	}
+	return nil
+}
+
+func (v *chunkValidator) ValidateBlobDetailed(blob *BlobMessage, operatorState *OperatorState, referenceBlockNumber uint) (map[QuorumID]error, error) {
+	if err := v.validateBlobHeader(blob, operatorState, referenceBlockNumber); err != nil {
+		return nil, err
+	}
 
-		// Validate the number of chunks
-		if assignment.NumChunks == 0 {
+	quorumInfos := blob.BlobHeader.QuorumInfos
+	results := make(map[QuorumID]error, len(quorumInfos))
+	failed := make([]QuorumID, 0)
+	errs := make([]error, 0)
+	for i, err := range v.validateQuorums(blob, operatorState, false) {
+		quorumID := quorumInfos[i].QuorumID
+		// A quorum listed more than once keeps the error of its first invalid entry
+		if results[quorumID] != nil {
 			continue
 		}
-		if assignment.NumChunks != uint(len(blob.Bundles[quorumHeader.QuorumID])) {
-			return errors.New("number of chunks does not match assignment")
-		}
-
-		chunkLength, err := v.assignment.GetChunkLengthFromHeader(operatorState, quorumHeader)
+		results[quorumID] = err
 		if err != nil {
-			return err
+			failed = append(failed, quorumID)
+			errs = append(errs, fmt.Errorf("quorum %d: %w", quorumID, err))
 		}
+	}
+	if len(failed) > 0 {
+		return results, fmt.Errorf("%d of %d quorums failed validation %v: %w", len(failed), len(results), failed, errors.Join(errs...))
+	}
+	return results, nil
+}
+
+// validateBlobHeader runs the checks of the blob which don't depend on its quorums
+func (v *chunkValidator) validateBlobHeader(blob *BlobMessage, operatorState *OperatorState, referenceBlockNumber uint) error {
+	gap := operatorState.BlockNumber - referenceBlockNumber
+	if operatorState.BlockNumber < referenceBlockNumber {
+		gap = referenceBlockNumber - operatorState.BlockNumber
+	}
+	if gap > v.maxBlockGap {
+		return fmt.Errorf("%w: the operator state is at block %d, %d blocks away from the reference block %d, which exceeds the max gap of %d blocks", ErrStaleOperatorState, operatorState.BlockNumber, gap, referenceBlockNumber, v.maxBlockGap)
+	}
 
-		// Validate the chunkLength against the quorum and adversary threshold parameters
-		numOperators := uint(len(operatorState.Operators[quorumHeader.QuorumID]))
-		minChunkLength, err := v.assignment.GetMinimumChunkLength(numOperators, blob.BlobHeader.BlobCommitments.Length, quorumHeader.QuantizationFactor, quorumHeader.QuorumThreshold, quorumHeader.AdversaryThreshold)
+	if v.minReferenceBlockAge > 0 {
+		currentBlock, err := v.chainState.GetCurrentBlockNumber()
 		if err != nil {
-			return err
+			return fmt.Errorf("failed to get the current block number: %w", err)
 		}
-		params, err := GetEncodingParams(minChunkLength, info.TotalChunks)
-		if err != nil {
-			return err
+		if currentBlock < referenceBlockNumber+v.minReferenceBlockAge {
+			age := uint(0)
+			if currentBlock > referenceBlockNumber {
+				age = currentBlock - referenceBlockNumber
+			}
+			return fmt.Errorf("%w: the reference block %d is %d blocks behind the current block %d, less than the min age of %d blocks", ErrReferenceBlockTooRecent, referenceBlockNumber, age, currentBlock, v.minReferenceBlockAge)
 		}
+	}
 
-		if params.ChunkLength != chunkLength {
-			return errors.New("number of chunks does not match assignment")
-		}
+	if len(blob.Bundles) != len(blob.BlobHeader.QuorumInfos) {
+		return errors.New("number of bundles does not match number of quorums")
+	}
+
+	// Validate the blob length
+	return v.encoder.VerifyBlobLength(blob.BlobHeader.BlobCommitments)
+}
 
-		// Get the chunk length
-		chunks := blob.Bundles[quorumHeader.QuorumID]
-		for _, chunk := range chunks {
-			if uint(chunk.Length()) != chunkLength {
-				return ErrChunkLengthMismatch
+// validateQuorums validates the quorums of the blob, returning the error of each quorum in the order of the quorum infos
+// of its header. When they are validated sequentially, the validation stops at the first invalid quorum if
+// stopOnError is set, leaving the errors of the next quorums nil.
+func (v *chunkValidator) validateQuorums(blob *BlobMessage, operatorState *OperatorState, stopOnError bool) []error {
+	quorumInfos := blob.BlobHeader.QuorumInfos
+	errs := make([]error, len(quorumInfos))
+	// All the quorums of a blob are validated for the same operator
+	operatorID := v.getOperatorID()
+	if v.concurrency == 0 {
+		for i, quorumHeader := range quorumInfos {
+			errs[i] = v.validateQuorum(blob, operatorState, quorumHeader, operatorID)
+			if errs[i] != nil && stopOnError {
+				break
 			}
 		}
+		return errs
+	}
+
+	// The quorums are validated by at most concurrency workers
+	next := make(chan int, len(quorumInfos))
+	for i := range quorumInfos {
+		next <- i
+	}
+	close(next)
+	numWorkers := int(v.concurrency)
+	if numWorkers > len(quorumInfos) {
+		numWorkers = len(quorumInfos)
+	}
+	var wg sync.WaitGroup
+	for w := 0; w < numWorkers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				errs[i] = v.validateQuorum(blob, operatorState, quorumInfos[i], operatorID)
+			}
+		}()
+	}
+	wg.Wait()
+	return errs
+}
+
+// validateQuorum validates the chunks of the blob in one of its quorums, as assigned to the operator
+func (v *chunkValidator) validateQuorum(blob *BlobMessage, operatorState *OperatorState, quorumHeader *BlobQuorumInfo, operatorID OperatorID) error {
+	if quorumHeader.AdversaryThreshold >= quorumHeader.QuorumThreshold {
+		return errors.New("invalid header: quorum threshold does not exceed adversary threshold")
+	}
+	if quorumHeader.OverprovisionPercent > MaxOverprovisionPercent {
+		return fmt.Errorf("%w: overprovision percent %d exceeds the max of %d", ErrInvalidHeader, quorumHeader.OverprovisionPercent, MaxOverprovisionPercent)
+	}
+
+	// Check if the operator is a member of the quorum
+	if _, ok := operatorState.Operators[quorumHeader.QuorumID]; !ok {
+		return nil
+	}
+
+	// Get the assignments for the quorum
+	assignment, info, err := v.assignment.GetOperatorAssignment(operatorState, quorumHeader.QuorumID, quorumHeader.QuantizationFactor, quorumHeader.OverprovisionPercent, operatorID)
+	if err != nil {
+		return err
+	}
 
-		// Validate the chunk length
-		if chunkLength*quorumHeader.QuantizationFactor*numOperators != quorumHeader.EncodedBlobLength {
-			return ErrInvalidHeader
+	// Validate the number of chunks
+	if assignment.NumChunks == 0 {
+		return nil
+	}
+	if numChunks := uint(len(blob.Bundles[quorumHeader.QuorumID])); assignment.NumChunks != numChunks {
+		return fmt.Errorf("number of chunks does not match assignment: quorum %d is assigned %d chunks, but found %d", quorumHeader.QuorumID, assignment.NumChunks, numChunks)
+	}
+
+	chunkLength, err := v.assignment.GetChunkLengthFromHeader(operatorState, quorumHeader)
+	if err != nil {
+		return err
+	}
+
+	// Validate the chunkLength against the quorum and adversary threshold parameters
+	numOperators := uint(len(operatorState.Operators[quorumHeader.QuorumID]))
+	minChunkLength, err := v.assignment.GetMinimumChunkLength(numOperators, blob.BlobHeader.BlobCommitments.Length, quorumHeader.QuantizationFactor, quorumHeader.QuorumThreshold, quorumHeader.AdversaryThreshold)
+	if err != nil {
+		return err
+	}
+	params, err := GetEncodingParams(minChunkLength, info.TotalChunks)
+	if err != nil {
+		return err
+	}
+
+	// The disperser may raise the chunk length with its floor, up to MaxMinChunkLength, which only adds redundancy to
+	// the encoding
+	if params.ChunkLength != chunkLength {
+		if chunkLength < params.ChunkLength || chunkLength > MaxMinChunkLength || chunkLength&(chunkLength-1) != 0 {
+			return fmt.Errorf("%w: quorum %d requires a chunk length of %d, or a power of two up to %d, but the header has a chunk length of %d", ErrChunkLengthMismatch, quorumHeader.QuorumID, params.ChunkLength, uint(MaxMinChunkLength), chunkLength)
 		}
+		params.ChunkLength = chunkLength
+	}
 
-		// Check the received chunks against the commitment
-		err = v.encoder.VerifyChunks(chunks, assignment.GetIndices(), blob.BlobHeader.BlobCommitments, params)
-		if err != nil {
-			return err
+	indices := assignment.GetIndices()
+	if err := validateChunkIndices(indices, params.NumChunks); err != nil {
+		return fmt.Errorf("quorum %d: %w", quorumHeader.QuorumID, err)
+	}
+
+	// Get the chunk length
+	chunks := blob.Bundles[quorumHeader.QuorumID]
+	for _, chunk := range chunks {
+		if uint(chunk.Length()) != chunkLength {
+			return fmt.Errorf("%w: quorum %d has a chunk length of %d, but found a chunk of length %d", ErrChunkLengthMismatch, quorumHeader.QuorumID, chunkLength, chunk.Length())
 		}
+	}
 
+	// Validate the chunk length
+	encodedLength, err := GetNominalEncodedBlobLength(chunkLength, numOperators, quorumHeader.QuantizationFactor, quorumHeader.OverprovisionPercent)
+	if err != nil {
+		return fmt.Errorf("%w: quorum %d: %w", ErrInvalidHeader, quorumHeader.QuorumID, err)
+	}
+	if encodedLength != quorumHeader.EncodedBlobLength {
+		return ErrInvalidHeader
 	}
 
+	// The chunks corrupted on the wire or in storage are rejected by their checksums, sparing the pairings of their
+	// verification against the commitment
+	for i, chunk := range chunks {
+		if !chunk.HasValidChecksum() {
+			return fmt.Errorf("%w: quorum %d, chunk %d", ErrChunkChecksumMismatch, quorumHeader.QuorumID, indices[i])
+		}
+	}
+
+	// Check the received chunks against the commitment
+	return v.encoder.VerifyChunks(chunks, indices, blob.BlobHeader.BlobCommitments, params)
+}
+
+// validateChunkIndices checks that the indices of the chunks of a bundle are distinct and within the numChunks chunks of
+// the encoding, so that a bundle can't cover the same chunk more than once
+func validateChunkIndices(indices []ChunkNumber, numChunks uint) error {
+	seen := make(map[ChunkNumber]struct{}, len(indices))
+	for _, index := range indices {
+		if uint(index) >= numChunks {
+			return fmt.Errorf("%w: index %d is out of the range of the %d chunks of the encoding", ErrInvalidChunkIndices, index, numChunks)
+		}
+		if _, ok := seen[index]; ok {
+			return fmt.Errorf("%w: index %d is duplicated", ErrInvalidChunkIndices, index)
+		}
+		seen[index] = struct{}{}
+	}
 	return nil
 }
 
 func (v *chunkValidator) UpdateOperatorID(operatorID OperatorID) {
+	v.operatorIDMu.Lock()
+	defer v.operatorIDMu.Unlock()
 	v.operatorID = operatorID
 }
+
+func (v *chunkValidator) getOperatorID() OperatorID {
+	v.operatorIDMu.RLock()
+	defer v.operatorIDMu.RUnlock()
+	return v.operatorID
+}
@@ -0,0 +1,531 @@
+package core_test
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/core/mock"
+	"github.com/Layr-Labs/eigenda/pkg/kzg/bn254"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSeed = 42
+
+// makeBlobMessages encodes the data with the seeded encoder and returns the blob message for each operator of quorum 0
+func makeBlobMessages(t *testing.T, enc core.Encoder, data []byte, securityParam core.SecurityParam, quantizationFactor, overprovisionPercent uint) (*core.OperatorState, map[core.OperatorID]*core.BlobMessage) {
+	return makeBlobMessagesWithCoordinator(t, &core.StdAssignmentCoordinator{}, enc, data, securityParam, quantizationFactor, overprovisionPercent)
+}
+
+// makeBlobMessagesWithCoordinator is makeBlobMessages with the chunk lengths of the given assignment coordinator
+func makeBlobMessagesWithCoordinator(t *testing.T, asn *core.StdAssignmentCoordinator, enc core.Encoder, data []byte, securityParam core.SecurityParam, quantizationFactor, overprovisionPercent uint) (*core.OperatorState, map[core.OperatorID]*core.BlobMessage) {
+	state, err := dat.GetOperatorState(context.Background(), 0, []core.QuorumID{securityParam.QuorumID})
+	require.NoError(t, err)
+
+	assignments, info, err := asn.GetAssignments(state, securityParam.QuorumID, quantizationFactor, overprovisionPercent)
+	require.NoError(t, err)
+
+	numOperators := uint(len(state.Operators[securityParam.QuorumID]))
+	chunkLength, err := asn.GetMinimumChunkLength(numOperators, core.GetBlobLength(uint(len(data))), quantizationFactor, securityParam.QuorumThreshold, securityParam.AdversaryThreshold)
+	require.NoError(t, err)
+	params, err := core.GetEncodingParams(chunkLength, info.TotalChunks)
+	require.NoError(t, err)
+
+	commitments, chunks, err := enc.Encode(data, params)
+	require.NoError(t, err)
+	encodedLength, err := core.GetNominalEncodedBlobLength(params.ChunkLength, numOperators, quantizationFactor, overprovisionPercent)
+	require.NoError(t, err)
+
+	messages := make(map[core.OperatorID]*core.BlobMessage, len(assignments))
+	for id, assignment := range assignments {
+		messages[id] = &core.BlobMessage{
+			BlobHeader: &core.BlobHeader{
+				BlobCommitments: commitments,
+				QuorumInfos: []*core.BlobQuorumInfo{{
+					SecurityParam:        securityParam,
+					QuantizationFactor:   quantizationFactor,
+					OverprovisionPercent: overprovisionPercent,
+					EncodedBlobLength:    encodedLength,
+				}},
+			},
+			Bundles: map[core.QuorumID]core.Bundle{
+				securityParam.QuorumID: chunks[assignment.StartIndex : assignment.StartIndex+assignment.NumChunks],
+			},
+		}
+	}
+	return state, messages
+}
+
+// makeMultiQuorumBlobMessages encodes the data in each of the quorums of the security params and returns the blob
+// message for each operator, with the chunks of all the quorums
+func makeMultiQuorumBlobMessages(t *testing.T, enc core.Encoder, data []byte, securityParams []core.SecurityParam) (*core.OperatorState, map[core.OperatorID]*core.BlobMessage) {
+	quorumIDs := make([]core.QuorumID, len(securityParams))
+	for i, securityParam := range securityParams {
+		quorumIDs[i] = securityParam.QuorumID
+	}
+	state, err := dat.GetOperatorState(context.Background(), 0, quorumIDs)
+	require.NoError(t, err)
+
+	messages := make(map[core.OperatorID]*core.BlobMessage)
+	for _, securityParam := range securityParams {
+		_, quorumMessages := makeBlobMessages(t, enc, data, securityParam, 1, 0)
+		for id, quorumMessage := range quorumMessages {
+			message, ok := messages[id]
+			if !ok {
+				message = &core.BlobMessage{
+					BlobHeader: &core.BlobHeader{BlobCommitments: quorumMessage.BlobHeader.BlobCommitments},
+					Bundles:    make(map[core.QuorumID]core.Bundle),
+				}
+				messages[id] = message
+			}
+			message.BlobHeader.QuorumInfos = append(message.BlobHeader.QuorumInfos, quorumMessage.BlobHeader.QuorumInfos...)
+			message.Bundles[securityParam.QuorumID] = quorumMessage.Bundles[securityParam.QuorumID]
+		}
+	}
+	for _, message := range messages {
+		require.Len(t, message.Bundles, len(securityParams), "the operators must be in all the quorums")
+	}
+	return state, messages
+}
+
+func validateAll(state *core.OperatorState, enc core.Encoder, messages map[core.OperatorID]*core.BlobMessage) error {
+	val := core.NewChunkValidator(enc, &core.StdAssignmentCoordinator{}, dat, core.OperatorID{}, 0, 0, 0)
+	for id, message := range messages {
+		val.UpdateOperatorID(id)
+		if err := val.ValidateBlob(message, state, state.BlockNumber); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+var defaultSecurityParam = core.SecurityParam{QuorumID: 0, AdversaryThreshold: 50, QuorumThreshold: 100}
+
+func TestValidateBlob(t *testing.T) {
+	enc := mock.NewSeededEncoder(testSeed)
+
+	for _, securityParam := range []core.SecurityParam{defaultSecurityParam, {QuorumID: 0, AdversaryThreshold: 80, QuorumThreshold: 90}} {
+		for _, quantizationFactor := range []uint{1, 10} {
+			for _, overprovisionPercent := range []uint{0, 33, core.MaxOverprovisionPercent} {
+				state, messages := makeBlobMessages(t, enc, GETTYSBURG_ADDRESS_BYTES, securityParam, quantizationFactor, overprovisionPercent)
+				assert.NoError(t, validateAll(state, enc, messages))
+			}
+		}
+	}
+}
+
+func TestValidateBlobMinChunkLength(t *testing.T) {
+	enc := mock.NewSeededEncoder(testSeed)
+	asn := &core.StdAssignmentCoordinator{}
+	state, messages := makeBlobMessages(t, enc, GETTYSBURG_ADDRESS_BYTES, defaultSecurityParam, 1, 0)
+	for _, message := range messages {
+		chunkLength, err := asn.GetChunkLengthFromHeader(state, message.BlobHeader.QuorumInfos[0])
+		require.NoError(t, err)
+		require.Less(t, chunkLength, uint(core.MaxMinChunkLength), "the floor must raise the chunk length")
+		break
+	}
+
+	// The chunk lengths raised by the floor of the disperser are accepted by the validators without a floor
+	state, messages = makeBlobMessagesWithCoordinator(t, &core.StdAssignmentCoordinator{MinChunkLength: core.MaxMinChunkLength}, enc, GETTYSBURG_ADDRESS_BYTES, defaultSecurityParam, 1, 0)
+	for _, message := range messages {
+		chunkLength, err := asn.GetChunkLengthFromHeader(state, message.BlobHeader.QuorumInfos[0])
+		require.NoError(t, err)
+		assert.Equal(t, uint(core.MaxMinChunkLength), chunkLength)
+	}
+	assert.NoError(t, validateAll(state, enc, messages))
+
+	// but not beyond MaxMinChunkLength
+	state, messages = makeBlobMessagesWithCoordinator(t, &core.StdAssignmentCoordinator{MinChunkLength: 2 * core.MaxMinChunkLength}, enc, GETTYSBURG_ADDRESS_BYTES, defaultSecurityParam, 1, 0)
+	assert.Error(t, validateAll(state, enc, messages))
+}
+
+func TestValidateBlobLargeQuorumChunkSizing(t *testing.T) {
+	enc := mock.NewSeededEncoder(testSeed)
+
+	// The chunk lengths raised for the large quorums by the disperser are accepted by the validators without the policy
+	state, err := dat.GetOperatorState(context.Background(), 0, []core.QuorumID{0})
+	require.NoError(t, err)
+	asn := &core.StdAssignmentCoordinator{
+		LargeQuorumOperatorThreshold: uint(len(state.Operators[0])),
+		LargeQuorumMinChunkBytes:     64 * bn254.BYTES_PER_COEFFICIENT,
+	}
+	state, messages := makeBlobMessagesWithCoordinator(t, asn, enc, GETTYSBURG_ADDRESS_BYTES, defaultSecurityParam, 1, 0)
+	for _, message := range messages {
+		chunkLength, err := (&core.StdAssignmentCoordinator{}).GetChunkLengthFromHeader(state, message.BlobHeader.QuorumInfos[0])
+		require.NoError(t, err)
+		assert.Equal(t, uint(64), chunkLength)
+	}
+	assert.NoError(t, validateAll(state, enc, messages))
+
+	// The quorums below the threshold keep their chunk lengths
+	asn.LargeQuorumOperatorThreshold++
+	state, messages = makeBlobMessagesWithCoordinator(t, asn, enc, GETTYSBURG_ADDRESS_BYTES, defaultSecurityParam, 1, 0)
+	for _, message := range messages {
+		chunkLength, err := (&core.StdAssignmentCoordinator{}).GetChunkLengthFromHeader(state, message.BlobHeader.QuorumInfos[0])
+		require.NoError(t, err)
+		assert.Less(t, chunkLength, uint(64))
+	}
+	assert.NoError(t, validateAll(state, enc, messages))
+}
+
+func TestValidateBlobStructuralChecks(t *testing.T) {
+	enc := mock.NewSeededEncoder(testSeed)
+
+	tests := []struct {
+		name   string
+		tamper func(*core.BlobMessage)
+		err    error
+	}{
+		{
+			name: "bundle count",
+			tamper: func(m *core.BlobMessage) {
+				m.Bundles[1] = m.Bundles[0]
+			},
+		},
+		{
+			name: "thresholds",
+			tamper: func(m *core.BlobMessage) {
+				m.BlobHeader.QuorumInfos[0].AdversaryThreshold = m.BlobHeader.QuorumInfos[0].QuorumThreshold
+			},
+		},
+		{
+			name: "overprovision percent",
+			tamper: func(m *core.BlobMessage) {
+				m.BlobHeader.QuorumInfos[0].OverprovisionPercent = 50
+			},
+		},
+		{
+			name: "max overprovision percent",
+			tamper: func(m *core.BlobMessage) {
+				m.BlobHeader.QuorumInfos[0].OverprovisionPercent = core.MaxOverprovisionPercent + 1
+			},
+			err: core.ErrInvalidHeader,
+		},
+		{
+			name: "quantization factor overflow",
+			tamper: func(m *core.BlobMessage) {
+				m.BlobHeader.QuorumInfos[0].QuantizationFactor = ^uint(0) / 2
+			},
+			err: core.ErrOverflow,
+		},
+		{
+			name: "number of chunks",
+			tamper: func(m *core.BlobMessage) {
+				m.Bundles[0] = m.Bundles[0][1:]
+			},
+		},
+		{
+			name: "chunk length",
+			tamper: func(m *core.BlobMessage) {
+				chunk := *m.Bundles[0][0]
+				chunk.Coeffs = chunk.Coeffs[1:]
+				m.Bundles[0] = append(core.Bundle{&chunk}, m.Bundles[0][1:]...)
+			},
+			err: core.ErrChunkLengthMismatch,
+		},
+		{
+			name: "chunk length below the minimum",
+			tamper: func(m *core.BlobMessage) {
+				m.BlobHeader.QuorumInfos[0].EncodedBlobLength /= 2
+			},
+			err: core.ErrChunkLengthMismatch,
+		},
+		{
+			name: "encoded blob length",
+			tamper: func(m *core.BlobMessage) {
+				m.BlobHeader.QuorumInfos[0].EncodedBlobLength++
+			},
+		},
+		{
+			name: "blob length",
+			tamper: func(m *core.BlobMessage) {
+				m.BlobHeader.BlobCommitments.Length++
+			},
+		},
+		{
+			name: "chunk contents",
+			tamper: func(m *core.BlobMessage) {
+				chunk := *m.Bundles[0][0]
+				chunk.Coeffs = append([]core.Symbol{}, chunk.Coeffs...)
+				bn254.AsFr(&chunk.Coeffs[0], 7)
+				m.Bundles[0] = append(core.Bundle{&chunk}, m.Bundles[0][1:]...)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state, messages := makeBlobMessages(t, enc, GETTYSBURG_ADDRESS_BYTES, defaultSecurityParam, 2, 0)
+			for _, message := range messages {
+				tt.tamper(message)
+			}
+
+			err := validateAll(state, enc, messages)
+			assert.Error(t, err)
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+			}
+		})
+	}
+}
+
+func TestValidateBlobRejectsOtherSeed(t *testing.T) {
+	state, messages := makeBlobMessages(t, mock.NewSeededEncoder(testSeed), GETTYSBURG_ADDRESS_BYTES, defaultSecurityParam, 1, 0)
+	assert.Error(t, validateAll(state, mock.NewSeededEncoder(testSeed+1), messages))
+}
+
+func TestValidateBlobStaleOperatorState(t *testing.T) {
+	enc := mock.NewSeededEncoder(testSeed)
+	state, messages := makeBlobMessages(t, enc, GETTYSBURG_ADDRESS_BYTES, defaultSecurityParam, 1, 0)
+	state.BlockNumber = 100
+
+	val := core.NewChunkValidator(enc, &core.StdAssignmentCoordinator{}, dat, core.OperatorID{}, 5, 0, 0)
+	for id, message := range messages {
+		val.UpdateOperatorID(id)
+
+		// The state may be a few blocks before or after the reference block
+		for _, referenceBlockNumber := range []uint{95, 100, 105} {
+			assert.NoError(t, val.ValidateBlob(message, state, referenceBlockNumber))
+		}
+
+		for _, referenceBlockNumber := range []uint{0, 94, 106} {
+			err := val.ValidateBlob(message, state, referenceBlockNumber)
+			assert.ErrorIs(t, err, core.ErrStaleOperatorState)
+		}
+	}
+
+	var message *core.BlobMessage
+	for _, message = range messages {
+		break
+	}
+	err := core.NewChunkValidator(enc, &core.StdAssignmentCoordinator{}, dat, core.OperatorID{}, 0, 0, 0).ValidateBlob(message, state, 106)
+	assert.EqualError(t, err, "stale operator state: the operator state is at block 100, 6 blocks away from the reference block 106, which exceeds the max gap of 0 blocks")
+}
+
+func TestValidateBlobReferenceBlockTooRecent(t *testing.T) {
+	enc := mock.NewSeededEncoder(testSeed)
+	state, messages := makeBlobMessages(t, enc, GETTYSBURG_ADDRESS_BYTES, defaultSecurityParam, 1, 0)
+	state.BlockNumber = 100
+
+	cst, err := mock.NewChainDataMock(10)
+	require.NoError(t, err)
+	cst.On("GetCurrentBlockNumber").Return(uint(110), nil)
+
+	val := core.NewChunkValidator(enc, &core.StdAssignmentCoordinator{}, cst, core.OperatorID{}, 10, 10, 0)
+	for id, message := range messages {
+		val.UpdateOperatorID(id)
+
+		// The reference block must be at least 10 blocks behind the current block
+		for _, referenceBlockNumber := range []uint{95, 100} {
+			assert.NoError(t, val.ValidateBlob(message, state, referenceBlockNumber))
+		}
+
+		for _, referenceBlockNumber := range []uint{101, 110} {
+			err := val.ValidateBlob(message, state, referenceBlockNumber)
+			assert.ErrorIs(t, err, core.ErrReferenceBlockTooRecent)
+		}
+	}
+
+	var id core.OperatorID
+	var message *core.BlobMessage
+	for id, message = range messages {
+		break
+	}
+	val.UpdateOperatorID(id)
+	err = val.ValidateBlob(message, state, 104)
+	assert.EqualError(t, err, "reference block too recent: the reference block 104 is 6 blocks behind the current block 110, less than the min age of 10 blocks")
+
+	// The current block isn't read with the default margin of zero
+	cst = &mock.ChainDataMock{}
+	assert.NoError(t, core.NewChunkValidator(enc, &core.StdAssignmentCoordinator{}, cst, id, 0, 0, 0).ValidateBlob(message, state, 100))
+	cst.AssertNotCalled(t, "GetCurrentBlockNumber")
+}
+
+// verifyCountingEncoder counts the chunks verified against their commitment
+type verifyCountingEncoder struct {
+	core.Encoder
+	verified int
+}
+
+func (e *verifyCountingEncoder) VerifyChunks(chunks []*core.Chunk, indices []core.ChunkNumber, commitments core.BlobCommitments, params core.EncodingParams) error {
+	e.verified += len(chunks)
+	return e.Encoder.VerifyChunks(chunks, indices, commitments, params)
+}
+
+func TestValidateBlobChunkChecksums(t *testing.T) {
+	enc := &verifyCountingEncoder{Encoder: mock.NewSeededEncoder(testSeed)}
+	state, messages := makeBlobMessages(t, enc, GETTYSBURG_ADDRESS_BYTES, defaultSecurityParam, 1, 0)
+	var id core.OperatorID
+	var message *core.BlobMessage
+	for id, message = range messages {
+		break
+	}
+
+	// The checksums are serialized along with the chunks
+	bundle := message.Bundles[defaultSecurityParam.QuorumID]
+	for i, chunk := range bundle {
+		checksummed := *chunk
+		checksummed.Checksum = chunk.ComputeChecksum()
+		data, err := checksummed.Serialize()
+		require.NoError(t, err)
+		bundle[i], err = new(core.Chunk).Deserialize(data)
+		require.NoError(t, err)
+		assert.Equal(t, checksummed.Checksum, bundle[i].Checksum)
+	}
+	val := core.NewChunkValidator(enc, &core.StdAssignmentCoordinator{}, dat, id, 0, 0, 0)
+	assert.NoError(t, val.ValidateBlob(message, state, state.BlockNumber))
+	assert.Equal(t, len(bundle), enc.verified)
+
+	// A corrupted chunk is rejected by its checksum, without being verified against the commitment
+	enc.verified = 0
+	corrupted := *bundle[0]
+	corrupted.Coeffs = append([]core.Symbol{}, corrupted.Coeffs...)
+	bn254.AddModFr(&corrupted.Coeffs[0], &corrupted.Coeffs[0], &corrupted.Coeffs[1])
+	bundle[0] = &corrupted
+	err := val.ValidateBlob(message, state, state.BlockNumber)
+	assert.ErrorIs(t, err, core.ErrChunkChecksumMismatch)
+	assert.Equal(t, 0, enc.verified)
+
+	// Without its checksum, the chunk is still rejected by its verification
+	corrupted.Checksum = 0
+	assert.Error(t, val.ValidateBlob(message, state, state.BlockNumber))
+	assert.Equal(t, len(bundle), enc.verified)
+}
+
+func TestValidateBlobConcurrency(t *testing.T) {
+	enc := mock.NewSeededEncoder(testSeed)
+	securityParams := []core.SecurityParam{
+		defaultSecurityParam,
+		{QuorumID: 1, AdversaryThreshold: 80, QuorumThreshold: 90},
+		{QuorumID: 2, AdversaryThreshold: 33, QuorumThreshold: 67},
+	}
+
+	tamperings := map[string]func(*core.BlobMessage){
+		"valid": func(*core.BlobMessage) {},
+		"first quorum": func(m *core.BlobMessage) {
+			m.BlobHeader.QuorumInfos[0].EncodedBlobLength++
+		},
+		"last quorum": func(m *core.BlobMessage) {
+			m.BlobHeader.QuorumInfos[2].AdversaryThreshold = m.BlobHeader.QuorumInfos[2].QuorumThreshold
+		},
+		"all quorums": func(m *core.BlobMessage) {
+			m.BlobHeader.QuorumInfos[1].OverprovisionPercent = core.MaxOverprovisionPercent + 1
+			m.BlobHeader.QuorumInfos[2].EncodedBlobLength++
+			chunk := *m.Bundles[0][0]
+			chunk.Coeffs = chunk.Coeffs[1:]
+			m.Bundles[0] = append(core.Bundle{&chunk}, m.Bundles[0][1:]...)
+		},
+	}
+
+	for name, tamper := range tamperings {
+		t.Run(name, func(t *testing.T) {
+			state, messages := makeMultiQuorumBlobMessages(t, enc, GETTYSBURG_ADDRESS_BYTES, securityParams)
+			for _, message := range messages {
+				tamper(message)
+			}
+
+			// The error of each operator's blob is the same whether its quorums are validated sequentially or not
+			for id, message := range messages {
+				sequential := core.NewChunkValidator(enc, &core.StdAssignmentCoordinator{}, dat, id, 0, 0, 0).ValidateBlob(message, state, state.BlockNumber)
+				if name == "valid" {
+					assert.NoError(t, sequential)
+				} else {
+					assert.Error(t, sequential)
+				}
+				for _, concurrency := range []uint{1, 2, 3, 8} {
+					err := core.NewChunkValidator(enc, &core.StdAssignmentCoordinator{}, dat, id, 0, 0, concurrency).ValidateBlob(message, state, state.BlockNumber)
+					assert.Equal(t, sequential, err, "concurrency %d", concurrency)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateBlobDetailed(t *testing.T) {
+	enc := mock.NewSeededEncoder(testSeed)
+	securityParams := []core.SecurityParam{
+		defaultSecurityParam,
+		{QuorumID: 1, AdversaryThreshold: 80, QuorumThreshold: 90},
+		{QuorumID: 2, AdversaryThreshold: 33, QuorumThreshold: 67},
+	}
+
+	tamperings := map[string]struct {
+		tamper func(*core.BlobMessage)
+		failed []core.QuorumID
+	}{
+		"valid": {func(*core.BlobMessage) {}, nil},
+		"middle quorum": {func(m *core.BlobMessage) {
+			m.BlobHeader.QuorumInfos[1].EncodedBlobLength++
+		}, []core.QuorumID{1}},
+		"all quorums": {func(m *core.BlobMessage) {
+			m.BlobHeader.QuorumInfos[0].EncodedBlobLength++
+			m.BlobHeader.QuorumInfos[1].OverprovisionPercent = core.MaxOverprovisionPercent + 1
+			m.BlobHeader.QuorumInfos[2].AdversaryThreshold = m.BlobHeader.QuorumInfos[2].QuorumThreshold
+		}, []core.QuorumID{0, 1, 2}},
+	}
+
+	for name, tampering := range tamperings {
+		t.Run(name, func(t *testing.T) {
+			state, messages := makeMultiQuorumBlobMessages(t, enc, GETTYSBURG_ADDRESS_BYTES, securityParams)
+			for id, message := range messages {
+				tampering.tamper(message)
+				for _, concurrency := range []uint{0, 2} {
+					val := core.NewChunkValidator(enc, &core.StdAssignmentCoordinator{}, dat, id, 0, 0, concurrency)
+					results, err := val.ValidateBlobDetailed(message, state, state.BlockNumber)
+					require.Len(t, results, len(securityParams))
+					if len(tampering.failed) == 0 {
+						assert.NoError(t, err)
+					} else {
+						assert.ErrorContains(t, err, fmt.Sprintf("%d of 3 quorums failed validation", len(tampering.failed)))
+					}
+					for _, securityParam := range securityParams {
+						failed := slices.Contains(tampering.failed, securityParam.QuorumID)
+						assert.Equal(t, failed, results[securityParam.QuorumID] != nil, "quorum %d", securityParam.QuorumID)
+					}
+
+					// ValidateBlob returns the error of the first invalid quorum
+					if len(tampering.failed) > 0 {
+						assert.Equal(t, results[tampering.failed[0]], val.ValidateBlob(message, state, state.BlockNumber))
+					}
+				}
+			}
+		})
+	}
+
+	// The blobs failing the checks which don't depend on their quorums have no per-quorum results
+	state, messages := makeMultiQuorumBlobMessages(t, enc, GETTYSBURG_ADDRESS_BYTES, securityParams)
+	for id, message := range messages {
+		delete(message.Bundles, 2)
+		results, err := core.NewChunkValidator(enc, &core.StdAssignmentCoordinator{}, dat, id, 0, 0, 0).ValidateBlobDetailed(message, state, state.BlockNumber)
+		assert.Nil(t, results)
+		assert.Error(t, err)
+	}
+}
+
+// shiftedAssignmentCoordinator shifts the chunk indices assigned to the operators
+type shiftedAssignmentCoordinator struct {
+	core.StdAssignmentCoordinator
+	shift core.ChunkNumber
+}
+
+func (c *shiftedAssignmentCoordinator) GetOperatorAssignment(state *core.OperatorState, quorum core.QuorumID, quantizationFactor, overprovisionPercent uint, id core.OperatorID) (core.Assignment, core.AssignmentInfo, error) {
+	assignment, info, err := c.StdAssignmentCoordinator.GetOperatorAssignment(state, quorum, quantizationFactor, overprovisionPercent, id)
+	assignment.StartIndex += c.shift
+	return assignment, info, err
+}
+
+func TestValidateBlobChunkIndicesOutOfRange(t *testing.T) {
+	enc := mock.NewSeededEncoder(testSeed)
+	state, messages := makeBlobMessages(t, enc, GETTYSBURG_ADDRESS_BYTES, defaultSecurityParam, 1, 0)
+
+	// The chunks of every operator are assigned past the chunks of the encoding
+	asn := &shiftedAssignmentCoordinator{shift: 1 << 20}
+	for id, message := range messages {
+		err := core.NewChunkValidator(enc, asn, dat, id, 0, 0, 0).ValidateBlob(message, state, state.BlockNumber)
+		assert.ErrorIs(t, err, core.ErrInvalidChunkIndices)
+		assert.ErrorContains(t, err, "quorum 0: invalid chunk indices: index")
+	}
+}
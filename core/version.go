@@ -0,0 +1,74 @@
+package core
+
+import (
+	"math/big"
+)
+
+// NodeFeatures is a bitmask of the optional features supported by a node. The nodes advertise it in their replies to
+// StoreChunks, so that the disperser only starts using a feature once enough of the stake supports it.
+type NodeFeatures uint64
+
+const (
+	// FeatureBundleEncodingV1 is set by the nodes which can decode the chunks bundles encoded in the v1 format
+	FeatureBundleEncodingV1 NodeFeatures = 1 << iota
+)
+
+// KnownFeatures lists the features of NodeFeatures known to this software
+var KnownFeatures = []NodeFeatures{FeatureBundleEncodingV1}
+
+// Has returns whether all the features of feature are set in f
+func (f NodeFeatures) Has(feature NodeFeatures) bool {
+	return f&feature == feature
+}
+
+func (f NodeFeatures) String() string {
+	switch f {
+	case FeatureBundleEncodingV1:
+		return "bundle_encoding_v1"
+	default:
+		return "unknown"
+	}
+}
+
+// NodeVersion is the software version and the features advertised by a node. Nodes predating the advertisement
+// report an empty Version and no features.
+type NodeVersion struct {
+	Version  string
+	Features NodeFeatures
+}
+
+// FeatureStakePercentage returns the percentage of the stake of the quorum held by the operators advertising the
+// feature in versions. The operators missing from versions, e.g. because they didn't reply, don't count towards it.
+func FeatureStakePercentage(state *OperatorState, versions map[OperatorID]NodeVersion, quorumID QuorumID, feature NodeFeatures) uint8 {
+	total, ok := state.Totals[quorumID]
+	if !ok || total.Stake == nil || (*big.Int)(total.Stake).Sign() == 0 {
+		return 0
+	}
+	stake := new(big.Int)
+	for operatorID, info := range state.Operators[quorumID] {
+		if version, ok := versions[operatorID]; ok && version.Features.Has(feature) {
+			stake.Add(stake, info.Stake)
+		}
+	}
+	percentage := new(big.Int).Div(new(big.Int).Mul(stake, big.NewInt(100)), (*big.Int)(total.Stake))
+	return uint8(percentage.Uint64())
+}
+
+// EnabledFeatures returns the features of KnownFeatures advertised by at least thresholdPercent of the stake of every
+// quorum of the state, which are therefore safe to use for the batches dispersed to these operators
+func EnabledFeatures(state *OperatorState, versions map[OperatorID]NodeVersion, thresholdPercent uint8) NodeFeatures {
+	var enabled NodeFeatures
+	for _, feature := range KnownFeatures {
+		supported := len(state.Totals) > 0
+		for quorumID := range state.Totals {
+			if FeatureStakePercentage(state, versions, quorumID, feature) < thresholdPercent {
+				supported = false
+				break
+			}
+		}
+		if supported {
+			enabled |= feature
+		}
+	}
+	return enabled
+}
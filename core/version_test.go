@@ -0,0 +1,46 @@
+package core_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnabledFeatures(t *testing.T) {
+	upgraded := core.OperatorID{1}
+	old := core.OperatorID{2}
+	state := &core.OperatorState{
+		Operators: map[core.QuorumID]map[core.OperatorID]*core.OperatorInfo{
+			0: {
+				upgraded: {Stake: big.NewInt(80), Index: 0},
+				old:      {Stake: big.NewInt(20), Index: 1},
+			},
+			1: {
+				upgraded: {Stake: big.NewInt(50), Index: 0},
+				old:      {Stake: big.NewInt(50), Index: 1},
+			},
+		},
+		Totals: map[core.QuorumID]*core.OperatorInfo{
+			0: {Stake: big.NewInt(100), Index: 2},
+			1: {Stake: big.NewInt(100), Index: 2},
+		},
+	}
+	versions := map[core.OperatorID]core.NodeVersion{
+		upgraded: {Version: "0.6.0", Features: core.FeatureBundleEncodingV1},
+		old:      {},
+	}
+
+	assert.Equal(t, uint8(80), core.FeatureStakePercentage(state, versions, 0, core.FeatureBundleEncodingV1))
+	assert.Equal(t, uint8(50), core.FeatureStakePercentage(state, versions, 1, core.FeatureBundleEncodingV1))
+	assert.Equal(t, uint8(0), core.FeatureStakePercentage(state, versions, 2, core.FeatureBundleEncodingV1))
+
+	// The feature must be advertised by enough of the stake of every quorum
+	assert.Equal(t, core.FeatureBundleEncodingV1, core.EnabledFeatures(state, versions, 50))
+	assert.Equal(t, core.NodeFeatures(0), core.EnabledFeatures(state, versions, 60))
+
+	// The operators which didn't reply don't count towards the stake advertising the feature
+	delete(versions, upgraded)
+	assert.Equal(t, core.NodeFeatures(0), core.EnabledFeatures(state, versions, 1))
+}
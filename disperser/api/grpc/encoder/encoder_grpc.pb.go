@@ -19,7 +19,8 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	Encoder_EncodeBlob_FullMethodName = "/encoder.Encoder/EncodeBlob"
+	Encoder_EncodeBlob_FullMethodName       = "/encoder.Encoder/EncodeBlob"
+	Encoder_EncodeBlobStream_FullMethodName = "/encoder.Encoder/EncodeBlobStream"
 )
 
 // EncoderClient is the client API for Encoder service.
@@ -27,6 +28,9 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type EncoderClient interface {
 	EncodeBlob(ctx context.Context, in *EncodeBlobRequest, opts ...grpc.CallOption) (*EncodeBlobReply, error)
+	// EncodeBlobStream encodes the blob like EncodeBlob, streaming the reply in frames so that the encoded blob isn't
+	// limited by the maximum size of a gRPC message
+	EncodeBlobStream(ctx context.Context, in *EncodeBlobRequest, opts ...grpc.CallOption) (Encoder_EncodeBlobStreamClient, error)
 }
 
 type encoderClient struct {
@@ -46,11 +50,46 @@ func (c *encoderClient) EncodeBlob(ctx context.Context, in *EncodeBlobRequest, o
 	return out, nil
 }
 
+func (c *encoderClient) EncodeBlobStream(ctx context.Context, in *EncodeBlobRequest, opts ...grpc.CallOption) (Encoder_EncodeBlobStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Encoder_ServiceDesc.Streams[0], Encoder_EncodeBlobStream_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &encoderEncodeBlobStreamClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Encoder_EncodeBlobStreamClient interface {
+	Recv() (*EncodeBlobFrame, error)
+	grpc.ClientStream
+}
+
+type encoderEncodeBlobStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *encoderEncodeBlobStreamClient) Recv() (*EncodeBlobFrame, error) {
+	m := new(EncodeBlobFrame)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // EncoderServer is the server API for Encoder service.
 // All implementations must embed UnimplementedEncoderServer
 // for forward compatibility
 type EncoderServer interface {
 	EncodeBlob(context.Context, *EncodeBlobRequest) (*EncodeBlobReply, error)
+	// EncodeBlobStream encodes the blob like EncodeBlob, streaming the reply in frames so that the encoded blob isn't
+	// limited by the maximum size of a gRPC message
+	EncodeBlobStream(*EncodeBlobRequest, Encoder_EncodeBlobStreamServer) error
 	mustEmbedUnimplementedEncoderServer()
 }
 
@@ -61,6 +100,9 @@ type UnimplementedEncoderServer struct {
 func (UnimplementedEncoderServer) EncodeBlob(context.Context, *EncodeBlobRequest) (*EncodeBlobReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method EncodeBlob not implemented")
 }
+func (UnimplementedEncoderServer) EncodeBlobStream(*EncodeBlobRequest, Encoder_EncodeBlobStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method EncodeBlobStream not implemented")
+}
 func (UnimplementedEncoderServer) mustEmbedUnimplementedEncoderServer() {}
 
 // UnsafeEncoderServer may be embedded to opt out of forward compatibility for this service.
@@ -92,6 +134,27 @@ func _Encoder_EncodeBlob_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Encoder_EncodeBlobStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(EncodeBlobRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(EncoderServer).EncodeBlobStream(m, &encoderEncodeBlobStreamServer{stream})
+}
+
+type Encoder_EncodeBlobStreamServer interface {
+	Send(*EncodeBlobFrame) error
+	grpc.ServerStream
+}
+
+type encoderEncodeBlobStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *encoderEncodeBlobStreamServer) Send(m *EncodeBlobFrame) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 // Encoder_ServiceDesc is the grpc.ServiceDesc for Encoder service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -104,6 +167,12 @@ var Encoder_ServiceDesc = grpc.ServiceDesc{
 			Handler:    _Encoder_EncodeBlob_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "EncodeBlobStream",
+			Handler:       _Encoder_EncodeBlobStream_Handler,
+			ServerStreams: true,
+		},
+	},
 	Metadata: "encoder/encoder.proto",
 }
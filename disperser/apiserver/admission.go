@@ -0,0 +1,106 @@
+package apiserver
+
+import (
+	"context"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/disperser"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// overloadedRetryAfter is the delay after which the clients of the shed requests are recommended to retry
+const overloadedRetryAfter = time.Second
+
+// Reasons of the shed requests reported in the metrics
+const (
+	shedQueueFull    = "queue-full"
+	shedQueueTimeout = "queue-timeout"
+)
+
+// admissionController bounds the number of requests of a method processed at once. The requests arriving once all the
+// slots are taken wait in a bounded queue, and are shed with a ResourceExhausted error once the queue is full or they
+// waited for too long, rather than piling up in the gRPC server.
+type admissionController struct {
+	method       string
+	slots        chan struct{}
+	queue        chan struct{}
+	queueTimeout time.Duration
+	metrics      *disperser.Metrics
+}
+
+// newAdmissionController returns the admission control of a method, or nil if the requests aren't bounded
+func newAdmissionController(method string, maxConcurrent, maxQueued int, queueTimeout time.Duration, metrics *disperser.Metrics) *admissionController {
+	if maxConcurrent <= 0 {
+		return nil
+	}
+	if maxQueued < 0 {
+		maxQueued = 0
+	}
+	return &admissionController{
+		method:       method,
+		slots:        make(chan struct{}, maxConcurrent),
+		queue:        make(chan struct{}, maxQueued),
+		queueTimeout: queueTimeout,
+		metrics:      metrics,
+	}
+}
+
+// admit waits for a slot for the request, and returns the function releasing it once the request is processed. A nil
+// controller admits all the requests.
+func (a *admissionController) admit(ctx context.Context) (func(), error) {
+	if a == nil {
+		return func() {}, nil
+	}
+
+	select {
+	case a.slots <- struct{}{}:
+		return a.release, nil
+	default:
+	}
+
+	select {
+	case a.queue <- struct{}{}:
+	default:
+		return nil, a.shed(shedQueueFull)
+	}
+	a.metrics.AddQueuedRequests(1, a.method)
+	defer func() {
+		<-a.queue
+		a.metrics.AddQueuedRequests(-1, a.method)
+	}()
+
+	var timeout <-chan time.Time
+	if a.queueTimeout > 0 {
+		timer := time.NewTimer(a.queueTimeout)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+	select {
+	case a.slots <- struct{}{}:
+		return a.release, nil
+	case <-timeout:
+		return nil, a.shed(shedQueueTimeout)
+	case <-ctx.Done():
+		return nil, status.FromContextError(ctx.Err()).Err()
+	}
+}
+
+func (a *admissionController) release() {
+	<-a.slots
+}
+
+// shed returns the ResourceExhausted error of a shed request, with the delay after which the client may retry
+func (a *admissionController) shed(reason string) error {
+	a.metrics.IncrementShedRequestNum(reason, a.method)
+	st := status.New(codes.ResourceExhausted, "the disperser is overloaded, retry later")
+	withDetails, err := st.WithDetails(newErrorInfo(disperser.ReasonOverloaded), &errdetails.RetryInfo{
+		RetryDelay: durationpb.New(overloadedRetryAfter),
+	})
+	if err != nil {
+		return st.Err()
+	}
+	return withDetails.Err()
+}
@@ -0,0 +1,269 @@
+package apiserver
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"sync"
+	"time"
+
+	pb "github.com/Layr-Labs/eigenda/api/grpc/disperser"
+	"github.com/Layr-Labs/eigenda/common"
+	commondynamodb "github.com/Layr-Labs/eigenda/common/aws/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/status"
+)
+
+// Decisions of the audited dispersal requests
+const (
+	AuditDecisionAccepted = "accepted"
+	AuditDecisionDryRun   = "dry_run"
+	AuditDecisionRejected = "rejected"
+)
+
+// auditQueueSize is the number of audit events waiting to be written, beyond which the events are dropped rather
+// than delaying the requests
+const auditQueueSize = 1000
+
+// auditWriteTimeout bounds the write of an audit event to the sink
+const auditWriteTimeout = 5 * time.Second
+
+// AuditEvent records a dispersal request for abuse forensics. The payload isn't recorded, only its hash, which
+// correlates the repeated submissions of a blob.
+type AuditEvent struct {
+	// RequestedAt is the time at which the request was received, in nanoseconds since the epoch
+	RequestedAt int64  `json:"requested_at" dynamodbav:"RequestedAt"`
+	Origin      string `json:"origin" dynamodbav:"Origin"`
+	// AccountID is the account charged by the rate limiter for the request
+	AccountID      string                `json:"account_id" dynamodbav:"AccountID"`
+	BlobSize       int                   `json:"blob_size" dynamodbav:"BlobSize"`
+	PayloadHash    string                `json:"payload_hash" dynamodbav:"PayloadHash"`
+	SecurityParams []AuditSecurityParams `json:"security_params" dynamodbav:"SecurityParams"`
+	Namespace      string                `json:"namespace,omitempty" dynamodbav:"Namespace,omitempty"`
+	// RequestID is the ID issued for the request, which is empty unless the blob was accepted
+	RequestID string `json:"request_id,omitempty" dynamodbav:"RequestID,omitempty"`
+	Decision  string `json:"decision" dynamodbav:"Decision"`
+	// Code, Reason and Error describe why a rejected request was rejected
+	Code   string `json:"code,omitempty" dynamodbav:"Code,omitempty"`
+	Reason string `json:"reason,omitempty" dynamodbav:"Reason,omitempty"`
+	Error  string `json:"error,omitempty" dynamodbav:"Error,omitempty"`
+
+	// payloadHash is the sampling key of the event
+	payloadHash []byte
+}
+
+type AuditSecurityParams struct {
+	QuorumID           uint32 `json:"quorum_id" dynamodbav:"QuorumID"`
+	AdversaryThreshold uint32 `json:"adversary_threshold" dynamodbav:"AdversaryThreshold"`
+	QuorumThreshold    uint32 `json:"quorum_threshold" dynamodbav:"QuorumThreshold"`
+}
+
+// AuditSink stores the audit events
+type AuditSink interface {
+	WriteAuditEvent(ctx context.Context, event *AuditEvent) error
+}
+
+// WriterAuditSink writes the audit events as JSON lines, e.g. to a file or to stdout
+type WriterAuditSink struct {
+	mu     sync.Mutex
+	writer io.Writer
+}
+
+var _ AuditSink = (*WriterAuditSink)(nil)
+
+func NewWriterAuditSink(writer io.Writer) *WriterAuditSink {
+	return &WriterAuditSink{writer: writer}
+}
+
+func (s *WriterAuditSink) WriteAuditEvent(ctx context.Context, event *AuditEvent) error {
+	line, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to serialize audit event: %w", err)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, err = s.writer.Write(append(line, '\n'))
+	return err
+}
+
+// DynamoDBAuditSink writes the audit events to a DynamoDB table keyed by the payload hash and the request time, so
+// that all the submissions of a blob can be queried together
+type DynamoDBAuditSink struct {
+	dynamoDBClient *commondynamodb.Client
+	tableName      string
+}
+
+var _ AuditSink = (*DynamoDBAuditSink)(nil)
+
+func NewDynamoDBAuditSink(dynamoDBClient *commondynamodb.Client, tableName string) *DynamoDBAuditSink {
+	return &DynamoDBAuditSink{
+		dynamoDBClient: dynamoDBClient,
+		tableName:      tableName,
+	}
+}
+
+func (s *DynamoDBAuditSink) WriteAuditEvent(ctx context.Context, event *AuditEvent) error {
+	item, err := attributevalue.MarshalMap(event)
+	if err != nil {
+		return fmt.Errorf("failed to serialize audit event: %w", err)
+	}
+	return s.dynamoDBClient.PutItem(ctx, s.tableName, item)
+}
+
+func GenerateAuditTableSchema(tableName string, readCapacityUnits int64, writeCapacityUnits int64) *dynamodb.CreateTableInput {
+	return &dynamodb.CreateTableInput{
+		AttributeDefinitions: []types.AttributeDefinition{
+			{
+				AttributeName: aws.String("PayloadHash"),
+				AttributeType: types.ScalarAttributeTypeS,
+			},
+			{
+				AttributeName: aws.String("RequestedAt"),
+				AttributeType: types.ScalarAttributeTypeN,
+			},
+		},
+		KeySchema: []types.KeySchemaElement{
+			{
+				AttributeName: aws.String("PayloadHash"),
+				KeyType:       types.KeyTypeHash,
+			},
+			{
+				AttributeName: aws.String("RequestedAt"),
+				KeyType:       types.KeyTypeRange,
+			},
+		},
+		TableName: aws.String(tableName),
+		ProvisionedThroughput: &types.ProvisionedThroughput{
+			ReadCapacityUnits:  aws.Int64(readCapacityUnits),
+			WriteCapacityUnits: aws.Int64(writeCapacityUnits),
+		},
+	}
+}
+
+// AuditSampler samples the requests to audit at a rate in [0, 1]. The sampling is deterministic in the sampling key,
+// so that a request is sampled on every submission or on none of them.
+type AuditSampler struct {
+	threshold uint64
+	all       bool
+}
+
+func NewAuditSampler(rate float64) AuditSampler {
+	if rate >= 1 {
+		return AuditSampler{all: true}
+	}
+	if rate <= 0 {
+		return AuditSampler{}
+	}
+	return AuditSampler{threshold: uint64(rate * math.MaxUint64)}
+}
+
+// Sample returns whether the request with the key is audited
+func (s AuditSampler) Sample(key []byte) bool {
+	if s.all {
+		return true
+	}
+	return binary.BigEndian.Uint64(crypto.Keccak256(key)[:8]) < s.threshold
+}
+
+// RequestAuditor writes the audit events of the dispersal requests to a sink in the background. The rejected requests
+// are always audited, and the other ones are sampled by their payload hash. They aren't sampled by their request ID,
+// which includes the time of the request, so that the repeated submissions of a blob are all audited or none is.
+type RequestAuditor struct {
+	sink    AuditSink
+	sampler AuditSampler
+	events  chan *AuditEvent
+	logger  common.Logger
+}
+
+func NewRequestAuditor(sink AuditSink, sampleRate float64, logger common.Logger) *RequestAuditor {
+	return &RequestAuditor{
+		sink:    sink,
+		sampler: NewAuditSampler(sampleRate),
+		events:  make(chan *AuditEvent, auditQueueSize),
+		logger:  logger,
+	}
+}
+
+// Start writes the audit events until the context is done
+func (a *RequestAuditor) Start(ctx context.Context) {
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event := <-a.events:
+				writeCtx, cancel := context.WithTimeout(ctx, auditWriteTimeout)
+				if err := a.sink.WriteAuditEvent(writeCtx, event); err != nil {
+					a.logger.Error("failed to write audit event", "payloadHash", event.PayloadHash, "decision", event.Decision, "err", err)
+				}
+				cancel()
+			}
+		}
+	}()
+}
+
+// newAuditEvent returns the audit event of a dispersal request received from the origin
+func newAuditEvent(req *pb.DisperseBlobRequest, origin string, requestedAt time.Time) *AuditEvent {
+	securityParams := make([]AuditSecurityParams, len(req.GetSecurityParams()))
+	for i, param := range req.GetSecurityParams() {
+		securityParams[i] = AuditSecurityParams{
+			QuorumID:           param.GetQuorumId(),
+			AdversaryThreshold: param.GetAdversaryThreshold(),
+			QuorumThreshold:    param.GetQuorumThreshold(),
+		}
+	}
+	payloadHash := crypto.Keccak256(req.GetData())
+	event := &AuditEvent{
+		RequestedAt:    requestedAt.UnixNano(),
+		Origin:         origin,
+		BlobSize:       len(req.GetData()),
+		PayloadHash:    hexutil.Encode(payloadHash),
+		SecurityParams: securityParams,
+		Namespace:      req.GetNamespace(),
+		payloadHash:    payloadHash,
+	}
+	if origin != "" {
+		event.AccountID = accountIDOf(origin)
+	}
+	return event
+}
+
+// Audit records the decision on the request of the event, and queues the event if it is audited
+func (a *RequestAuditor) Audit(event *AuditEvent, reply *pb.DisperseBlobReply, err error) {
+	switch {
+	case err != nil:
+		event.Decision = AuditDecisionRejected
+		st, _ := status.FromError(err)
+		event.Code = st.Code().String()
+		event.Error = st.Message()
+		for _, detail := range st.Details() {
+			if info, ok := detail.(*errdetails.ErrorInfo); ok {
+				event.Reason = info.GetReason()
+			}
+		}
+	case len(reply.GetRequestId()) == 0:
+		event.Decision = AuditDecisionDryRun
+	default:
+		event.Decision = AuditDecisionAccepted
+		event.RequestID = string(reply.GetRequestId())
+	}
+
+	if event.Decision != AuditDecisionRejected && !a.sampler.Sample(event.payloadHash) {
+		return
+	}
+
+	select {
+	case a.events <- event:
+	default:
+		a.logger.Warn("dropped audit event, the audit queue is full", "payloadHash", event.PayloadHash, "decision", event.Decision)
+	}
+}
@@ -0,0 +1,158 @@
+package apiserver_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	pb "github.com/Layr-Labs/eigenda/api/grpc/disperser"
+	"github.com/Layr-Labs/eigenda/common/logging"
+	"github.com/Layr-Labs/eigenda/core/mock"
+	"github.com/Layr-Labs/eigenda/disperser"
+	"github.com/Layr-Labs/eigenda/disperser/apiserver"
+	"github.com/Layr-Labs/eigenda/disperser/common/inmem"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/peer"
+)
+
+// memoryAuditSink collects the audit events
+type memoryAuditSink struct {
+	events chan *apiserver.AuditEvent
+}
+
+func (s *memoryAuditSink) WriteAuditEvent(ctx context.Context, event *apiserver.AuditEvent) error {
+	s.events <- event
+	return nil
+}
+
+func TestAuditSamplerIsDeterministic(t *testing.T) {
+	sampler := apiserver.NewAuditSampler(0.25)
+	sampled := 0
+	for i := 0; i < 10000; i++ {
+		key := []byte(fmt.Sprintf("payload-%d", i))
+		sample := sampler.Sample(key)
+		// The same key is sampled by any sampler with the same rate, on every submission
+		assert.Equal(t, sample, sampler.Sample(key))
+		assert.Equal(t, sample, apiserver.NewAuditSampler(0.25).Sample(key))
+		if sample {
+			sampled++
+			// A key sampled at a rate is sampled at any higher rate
+			assert.True(t, apiserver.NewAuditSampler(0.5).Sample(key))
+		}
+	}
+	assert.InDelta(t, 2500, sampled, 200)
+
+	key := []byte("payload")
+	assert.False(t, apiserver.NewAuditSampler(0).Sample(key))
+	assert.True(t, apiserver.NewAuditSampler(1).Sample(key))
+}
+
+func TestDisperseBlobAudit(t *testing.T) {
+	logger, err := logging.GetLogger(logging.DefaultCLIConfig())
+	require.NoError(t, err)
+	tx := &mock.MockTransactor{}
+	tx.On("GetCurrentBlockNumber").Return(uint32(100), nil)
+	tx.On("GetQuorumCount").Return(uint16(2), nil)
+	tx.On("GetNumberOfRegisteredOperatorForQuorum").Return(uint32(4), nil)
+	server := apiserver.NewDispersalServer(disperser.ServerConfig{
+		GrpcPort: "51023",
+	}, inmem.NewBlobStore(), tx, logger, disperser.NewMetrics("9023", nil, logger), nil, apiserver.RateConfig{})
+	sink := &memoryAuditSink{events: make(chan *apiserver.AuditEvent, 10)}
+	auditor := apiserver.NewRequestAuditor(sink, 0.5, logger)
+	server.SetRequestAuditor(auditor)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	auditor.Start(ctx)
+
+	ctx = peer.NewContext(ctx, &peer.Peer{
+		Addr: &net.TCPAddr{IP: net.ParseIP("1.2.3.4"), Port: 51001},
+	})
+	sampler := apiserver.NewAuditSampler(0.5)
+	payload := func(sampled bool) []byte {
+		for i := 0; ; i++ {
+			data := []byte(fmt.Sprintf("payload-%d", i))
+			if sampler.Sample(crypto.Keccak256(data)) == sampled {
+				return data
+			}
+		}
+	}
+	request := func(data []byte, adversaryThreshold uint32) *pb.DisperseBlobRequest {
+		return &pb.DisperseBlobRequest{
+			Data: data,
+			SecurityParams: []*pb.SecurityParams{
+				{QuorumId: 1, AdversaryThreshold: adversaryThreshold, QuorumThreshold: 100},
+			},
+		}
+	}
+
+	// Every submission of a sampled blob is audited
+	sampledData := payload(true)
+	for i := 0; i < 2; i++ {
+		reply, err := server.DisperseBlob(ctx, request(sampledData, 50))
+		require.NoError(t, err)
+		event := <-sink.events
+		assert.Equal(t, apiserver.AuditDecisionAccepted, event.Decision)
+		assert.Equal(t, string(reply.GetRequestId()), event.RequestID)
+		assert.Equal(t, hexutil.Encode(crypto.Keccak256(sampledData)), event.PayloadHash)
+		assert.Equal(t, len(sampledData), event.BlobSize)
+		assert.Equal(t, "1.2.3.4", event.Origin)
+		assert.Equal(t, "ip:1.2.3.4", event.AccountID)
+		assert.Equal(t, []apiserver.AuditSecurityParams{{QuorumID: 1, AdversaryThreshold: 50, QuorumThreshold: 100}}, event.SecurityParams)
+	}
+
+	// A blob which isn't sampled isn't audited, unless it's rejected
+	unsampledData := payload(false)
+	_, err = server.DisperseBlob(ctx, request(unsampledData, 50))
+	require.NoError(t, err)
+	_, err = server.DisperseBlob(ctx, request(unsampledData, 100))
+	require.Error(t, err)
+	event := <-sink.events
+	assert.Equal(t, apiserver.AuditDecisionRejected, event.Decision)
+	assert.Equal(t, "InvalidArgument", event.Code)
+	assert.Equal(t, disperser.ReasonInvalidSecurityParams, event.Reason)
+	assert.Contains(t, event.Error, "invalid request")
+	assert.Empty(t, event.RequestID)
+	assert.Equal(t, hexutil.Encode(crypto.Keccak256(unsampledData)), event.PayloadHash)
+
+	// A sampled dry run is audited without request ID
+	dryRun := request(sampledData, 50)
+	dryRun.DryRun = true
+	_, err = server.DisperseBlob(ctx, dryRun)
+	require.NoError(t, err)
+	event = <-sink.events
+	assert.Equal(t, apiserver.AuditDecisionDryRun, event.Decision)
+	assert.Empty(t, event.RequestID)
+	select {
+	case event := <-sink.events:
+		t.Fatalf("unexpected audit event: %+v", event)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestWriterAuditSink(t *testing.T) {
+	var buf bytes.Buffer
+	sink := apiserver.NewWriterAuditSink(&buf)
+	event := &apiserver.AuditEvent{
+		RequestedAt: 1,
+		Origin:      "1.2.3.4",
+		PayloadHash: "0x01",
+		Decision:    apiserver.AuditDecisionRejected,
+		Reason:      disperser.ReasonBlobTooLarge,
+	}
+	require.NoError(t, sink.WriteAuditEvent(context.Background(), event))
+	require.NoError(t, sink.WriteAuditEvent(context.Background(), event))
+
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	require.Len(t, lines, 2)
+	var decoded apiserver.AuditEvent
+	require.NoError(t, json.Unmarshal(lines[1], &decoded))
+	assert.Equal(t, *event, decoded)
+	assert.NotContains(t, string(lines[0]), "request_id")
+}
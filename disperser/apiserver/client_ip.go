@@ -0,0 +1,145 @@
+package apiserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+
+	proxyproto "github.com/pires/go-proxyproto"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// Reasons of the requests whose client address is taken from the connection although the client IP header is
+// configured, reported in the metrics
+const (
+	clientIPHeaderMissing    = "missing"
+	clientIPHeaderUnparsable = "unparsable"
+)
+
+var (
+	// ErrNoClientIP is returned when the client IP header has no entry
+	ErrNoClientIP = errors.New("no client IP in the header")
+	// ErrInvalidClientIP is returned when none of the entries of the client IP header trusted by the proxy depth is an
+	// IP address
+	ErrInvalidClientIP = errors.New("invalid client IP in the header")
+)
+
+// CanonicalIP returns the canonical form of an address, so that a client has a single key whatever the form in which
+// its address is received. The address can be an IPv4 or IPv6 address, optionally with a port, in brackets or with a
+// zone, e.g. "1.2.3.4:80", "[2001:DB8::1]:443" or "fe80::1%eth0". IPv4-mapped IPv6 addresses are returned as IPv4.
+func CanonicalIP(addr string) (string, error) {
+	addr = strings.Trim(strings.TrimSpace(addr), `"`)
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		addr = host
+	}
+	if strings.HasPrefix(addr, "[") && strings.HasSuffix(addr, "]") {
+		addr = addr[1 : len(addr)-1]
+	}
+	if zone := strings.IndexByte(addr, '%'); zone >= 0 {
+		addr = addr[:zone]
+	}
+	ip := net.ParseIP(addr)
+	if ip == nil {
+		return "", fmt.Errorf("invalid IP address %q", addr)
+	}
+	if ipv4 := ip.To4(); ipv4 != nil {
+		ip = ipv4
+	}
+	return ip.String(), nil
+}
+
+// proxyProtocolPolicy requires the PROXY protocol header from the connections of the load balancers in the given CIDRs,
+// and rejects it from any other connection, whose client could otherwise spoof its address
+func proxyProtocolPolicy(loadBalancers []*net.IPNet) proxyproto.PolicyFunc {
+	return func(upstream net.Addr) (proxyproto.Policy, error) {
+		// The policy doesn't return errors, which would stop the server from accepting connections
+		host, err := CanonicalIP(upstream.String())
+		if err != nil {
+			return proxyproto.REJECT, nil
+		}
+		ip := net.ParseIP(host)
+		for _, loadBalancer := range loadBalancers {
+			if loadBalancer.Contains(ip) {
+				return proxyproto.REQUIRE, nil
+			}
+		}
+		return proxyproto.REJECT, nil
+	}
+}
+
+// ClientIPFromHeader returns the canonical address of the client from the values of an X-Forwarded-For style header,
+// each holding a comma-separated list of addresses to which every proxy appends the address it received the request
+// from. Only the proxyDepth rightmost entries, appended by the trusted proxies, are considered: the entries further left
+// are set by the client and can be spoofed. The client is the rightmost public address among them, or the leftmost
+// address if they are all private, e.g. for the clients in the private network. The entries which aren't IP addresses
+// are skipped.
+func ClientIPFromHeader(values []string, proxyDepth int) (string, error) {
+	entries := splitHeaderValues(values)
+	if len(entries) == 0 {
+		return "", ErrNoClientIP
+	}
+	if proxyDepth > 0 && len(entries) > proxyDepth {
+		entries = entries[len(entries)-proxyDepth:]
+	}
+
+	leftmost := ""
+	for i := len(entries) - 1; i >= 0; i-- {
+		ip, err := CanonicalIP(entries[i])
+		if err != nil {
+			continue
+		}
+		if isPublicIP(net.ParseIP(ip)) {
+			return ip, nil
+		}
+		leftmost = ip
+	}
+	if leftmost == "" {
+		return "", fmt.Errorf("%w: %q", ErrInvalidClientIP, strings.Join(entries, ", "))
+	}
+	return leftmost, nil
+}
+
+// isPublicIP returns whether the address isn't private, loopback, link-local or unspecified
+func isPublicIP(ip net.IP) bool {
+	return !ip.IsPrivate() && !ip.IsLoopback() && !ip.IsLinkLocalUnicast() && !ip.IsUnspecified()
+}
+
+func splitHeaderValues(values []string) []string {
+	var entries []string
+	for _, value := range values {
+		for _, entry := range strings.Split(value, ",") {
+			if entry = strings.TrimSpace(entry); entry != "" {
+				entries = append(entries, entry)
+			}
+		}
+	}
+	return entries
+}
+
+// clientAddress returns the canonical address of the client of the request, which keys its rate limits. It is taken
+// from the client IP header if one is configured, and from the connection otherwise, or when the header is missing or
+// has no valid address.
+func (s *DispersalServer) clientAddress(ctx context.Context) (string, error) {
+	if header := s.rateConfig.ClientIPHeader; header != "" {
+		md, _ := metadata.FromIncomingContext(ctx)
+		ip, err := ClientIPFromHeader(md.Get(header), s.rateConfig.ClientIPProxyDepth)
+		if err == nil {
+			return ip, nil
+		}
+		reason := clientIPHeaderUnparsable
+		if errors.Is(err, ErrNoClientIP) {
+			reason = clientIPHeaderMissing
+		}
+		s.metrics.IncrementClientIPFallbackNum(reason)
+		s.logger.Debug("taking the client address from the connection", "header", header, "err", err)
+	}
+
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return "", fmt.Errorf("failed to get peer from request")
+	}
+	return CanonicalIP(p.Addr.String())
+}
@@ -0,0 +1,330 @@
+package apiserver_test
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	pb "github.com/Layr-Labs/eigenda/api/grpc/disperser"
+	"github.com/Layr-Labs/eigenda/common/logging"
+	"github.com/Layr-Labs/eigenda/core/mock"
+	"github.com/Layr-Labs/eigenda/disperser"
+	"github.com/Layr-Labs/eigenda/disperser/apiserver"
+	"github.com/Layr-Labs/eigenda/disperser/common/inmem"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+func TestCanonicalIP(t *testing.T) {
+	tests := []struct {
+		addr     string
+		expected string
+	}{
+		{addr: "1.2.3.4", expected: "1.2.3.4"},
+		{addr: " 1.2.3.4 ", expected: "1.2.3.4"},
+		{addr: "1.2.3.4:5678", expected: "1.2.3.4"},
+		{addr: `"1.2.3.4"`, expected: "1.2.3.4"},
+		{addr: "2001:db8::1", expected: "2001:db8::1"},
+		{addr: "2001:DB8:0:0:0:0:0:1", expected: "2001:db8::1"},
+		{addr: "[2001:db8::1]", expected: "2001:db8::1"},
+		{addr: "[2001:db8::1]:443", expected: "2001:db8::1"},
+		{addr: `"[2001:db8::1]:443"`, expected: "2001:db8::1"},
+		{addr: "fe80::1%eth0", expected: "fe80::1"},
+		{addr: "[fe80::1%eth0]:80", expected: "fe80::1"},
+		{addr: "::ffff:1.2.3.4", expected: "1.2.3.4"},
+		{addr: "[::ffff:1.2.3.4]:80", expected: "1.2.3.4"},
+		{addr: "::1", expected: "::1"},
+	}
+	for _, tt := range tests {
+		ip, err := apiserver.CanonicalIP(tt.addr)
+		assert.NoError(t, err, tt.addr)
+		assert.Equal(t, tt.expected, ip, tt.addr)
+	}
+
+	for _, addr := range []string{"", "unknown", "1.2.3", "1.2.3.4.5", "example.com:80", "[1.2.3.4", "2001:db8::g", "_hidden"} {
+		_, err := apiserver.CanonicalIP(addr)
+		assert.Error(t, err, addr)
+	}
+}
+
+func TestClientIPFromHeader(t *testing.T) {
+	tests := []struct {
+		name       string
+		values     []string
+		proxyDepth int
+		expected   string
+		err        error
+	}{
+		{
+			name:       "single address",
+			values:     []string{"1.2.3.4"},
+			proxyDepth: 2,
+			expected:   "1.2.3.4",
+		},
+		{
+			name:       "client behind an internal proxy",
+			values:     []string{"1.2.3.4, 10.0.0.1"},
+			proxyDepth: 2,
+			expected:   "1.2.3.4",
+		},
+		{
+			name:       "spoofed entries beyond the proxy depth",
+			values:     []string{"6.6.6.6, 7.7.7.7, 1.2.3.4, 10.0.0.1"},
+			proxyDepth: 2,
+			expected:   "1.2.3.4",
+		},
+		{
+			name:       "spoofed private entry beyond the proxy depth",
+			values:     []string{"10.9.9.9, 1.2.3.4"},
+			proxyDepth: 1,
+			expected:   "1.2.3.4",
+		},
+		{
+			name:       "rightmost public address",
+			values:     []string{"5.5.5.5, 1.2.3.4, 192.168.1.1, 127.0.0.1"},
+			proxyDepth: 4,
+			expected:   "1.2.3.4",
+		},
+		{
+			name:       "all the entries trusted",
+			values:     []string{"5.5.5.5, 10.0.0.1, 10.0.0.2"},
+			proxyDepth: 0,
+			expected:   "5.5.5.5",
+		},
+		{
+			name:       "all private addresses",
+			values:     []string{"10.0.0.5, 172.16.0.1, 192.168.0.1"},
+			proxyDepth: 2,
+			expected:   "172.16.0.1",
+		},
+		{
+			name:       "fewer entries than the proxy depth",
+			values:     []string{"10.0.0.5"},
+			proxyDepth: 3,
+			expected:   "10.0.0.5",
+		},
+		{
+			name:       "multiple header values",
+			values:     []string{"6.6.6.6, 1.2.3.4", "10.0.0.1"},
+			proxyDepth: 2,
+			expected:   "1.2.3.4",
+		},
+		{
+			name:       "empty entries and spaces",
+			values:     []string{" , 1.2.3.4 ,,", ""},
+			proxyDepth: 2,
+			expected:   "1.2.3.4",
+		},
+		{
+			name:       "ipv4 with port",
+			values:     []string{"1.2.3.4:5678, 10.0.0.1:80"},
+			proxyDepth: 2,
+			expected:   "1.2.3.4",
+		},
+		{
+			name:       "ipv6",
+			values:     []string{"2001:db8::1, 10.0.0.1"},
+			proxyDepth: 2,
+			expected:   "2001:db8::1",
+		},
+		{
+			name:       "ipv6 with brackets and port",
+			values:     []string{"[2001:DB8::1]:443"},
+			proxyDepth: 2,
+			expected:   "2001:db8::1",
+		},
+		{
+			name:       "private ipv6 behind a public ipv6 proxy",
+			values:     []string{"fd00::1, 2001:db8::2"},
+			proxyDepth: 2,
+			expected:   "2001:db8::2",
+		},
+		{
+			name:       "ipv4-mapped ipv6",
+			values:     []string{"::ffff:1.2.3.4"},
+			proxyDepth: 1,
+			expected:   "1.2.3.4",
+		},
+		{
+			name:       "link-local ipv6 with zone",
+			values:     []string{"1.2.3.4, fe80::1%eth0"},
+			proxyDepth: 2,
+			expected:   "1.2.3.4",
+		},
+		{
+			name:       "unparsable entries are skipped",
+			values:     []string{"1.2.3.4, unknown"},
+			proxyDepth: 2,
+			expected:   "1.2.3.4",
+		},
+		{
+			name:       "quoted entries",
+			values:     []string{`"[2001:db8::1]:443", "10.0.0.1"`},
+			proxyDepth: 2,
+			expected:   "2001:db8::1",
+		},
+		{
+			name:       "no header",
+			values:     nil,
+			proxyDepth: 2,
+			err:        apiserver.ErrNoClientIP,
+		},
+		{
+			name:       "empty header",
+			values:     []string{" , "},
+			proxyDepth: 2,
+			err:        apiserver.ErrNoClientIP,
+		},
+		{
+			name:       "no address in the trusted entries",
+			values:     []string{"1.2.3.4, unknown, _hidden"},
+			proxyDepth: 2,
+			err:        apiserver.ErrInvalidClientIP,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ip, err := apiserver.ClientIPFromHeader(tt.values, tt.proxyDepth)
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, ip)
+		})
+	}
+}
+
+func TestClientAddress(t *testing.T) {
+	logger, err := logging.GetLogger(logging.DefaultCLIConfig())
+	require.NoError(t, err)
+	metrics := disperser.NewMetrics("9031", nil, logger)
+	server := apiserver.NewDispersalServer(disperser.ServerConfig{
+		GrpcPort: "51031",
+	}, inmem.NewBlobStore(), &mock.MockTransactor{}, logger, metrics, nil, apiserver.RateConfig{
+		ClientIPHeader:     "x-forwarded-for",
+		ClientIPProxyDepth: 2,
+	})
+
+	accountID := func(peerAddr net.Addr, header ...string) string {
+		ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: peerAddr})
+		if len(header) > 0 {
+			ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("x-forwarded-for", header[0]))
+		}
+		status, err := server.GetRateLimitStatus(ctx, &pb.RateLimitStatusRequest{})
+		require.NoError(t, err)
+		return status.GetAccountId()
+	}
+	proxy := &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 51001}
+
+	// The same client has a single account whatever the form of its address
+	assert.Equal(t, "ip:1.2.3.4", accountID(proxy, "1.2.3.4"))
+	assert.Equal(t, "ip:1.2.3.4", accountID(proxy, "::ffff:1.2.3.4, 10.0.0.2"))
+	assert.Equal(t, "ip:1.2.3.4", accountID(proxy, "[::ffff:1.2.3.4]:1234"))
+	assert.Equal(t, "ip:2001:db8::1", accountID(proxy, "[2001:DB8:0::1]:443"))
+	assert.Equal(t, "ip:2001:db8::1", accountID(proxy, "2001:db8::1"))
+
+	// The address of the connection is taken when the header is missing or has no valid address
+	assert.Equal(t, "ip:10.0.0.1", accountID(proxy))
+	assert.Equal(t, "ip:10.0.0.1", accountID(proxy, "unknown"))
+	assert.Equal(t, "ip:2001:db8::5", accountID(&net.TCPAddr{IP: net.ParseIP("2001:db8::5"), Port: 51001}))
+	assert.Equal(t, float64(2), testutil.ToFloat64(metrics.ClientIPFallbacks.WithLabelValues("missing")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(metrics.ClientIPFallbacks.WithLabelValues("unparsable")))
+}
+
+func TestProxyProtocol(t *testing.T) {
+	logger, err := logging.GetLogger(logging.DefaultCLIConfig())
+	require.NoError(t, err)
+	_, loopback, err := net.ParseCIDR("127.0.0.0/8")
+	require.NoError(t, err)
+	_, other, err := net.ParseCIDR("10.0.0.0/8")
+	require.NoError(t, err)
+	startServer(t, apiserver.NewDispersalServer(disperser.ServerConfig{
+		GrpcPort: "51032",
+	}, inmem.NewBlobStore(), &mock.MockTransactor{}, logger, disperser.NewMetrics("9032", nil, logger), nil, apiserver.RateConfig{
+		ProxyProtocolCIDRs: []*net.IPNet{other, loopback},
+	}), "51032")
+	startServer(t, apiserver.NewDispersalServer(disperser.ServerConfig{
+		GrpcPort: "51037",
+	}, inmem.NewBlobStore(), &mock.MockTransactor{}, logger, disperser.NewMetrics("9037", nil, logger), nil, apiserver.RateConfig{
+		ProxyProtocolCIDRs: []*net.IPNet{other},
+	}), "51037")
+
+	// The client address is read from the PROXY protocol header of the connections from the load balancers
+	accountID, err := proxiedAccountID("51032", "PROXY TCP4 203.0.113.7 10.0.0.1 40000 51032\r\n")
+	assert.NoError(t, err)
+	assert.Equal(t, "ip:203.0.113.7", accountID)
+	accountID, err = proxiedAccountID("51032", "PROXY TCP6 2001:db8::7 2001:db8::1 40000 51032\r\n")
+	assert.NoError(t, err)
+	assert.Equal(t, "ip:2001:db8::7", accountID)
+
+	// Which must send it
+	_, err = proxiedAccountID("51032", "")
+	assert.Error(t, err)
+
+	// The other connections can't spoof their address with the header
+	_, err = proxiedAccountID("51037", "PROXY TCP4 203.0.113.7 10.0.0.1 40000 51037\r\n")
+	assert.Error(t, err)
+	accountID, err = proxiedAccountID("51037", "")
+	assert.NoError(t, err)
+	assert.Equal(t, "ip:127.0.0.1", accountID)
+}
+
+func TestProxyProtocolConnectionRateLimit(t *testing.T) {
+	logger, err := logging.GetLogger(logging.DefaultCLIConfig())
+	require.NoError(t, err)
+	_, loopback, err := net.ParseCIDR("127.0.0.0/8")
+	require.NoError(t, err)
+	startServer(t, apiserver.NewDispersalServer(disperser.ServerConfig{
+		GrpcPort: "51038",
+	}, inmem.NewBlobStore(), &mock.MockTransactor{}, logger, disperser.NewMetrics("9038", nil, logger), nil, apiserver.RateConfig{
+		ProxyProtocolCIDRs: []*net.IPNet{loopback},
+		// The bucket doesn't refill during the test
+		IPConnectionRate:  0.001,
+		IPConnectionBurst: 1,
+	}), "51038")
+
+	// The connections are limited on the client addresses of their header rather than on the load balancer address
+	_, err = proxiedAccountID("51038", "PROXY TCP4 203.0.113.7 10.0.0.1 40000 51038\r\n")
+	assert.NoError(t, err)
+	_, err = proxiedAccountID("51038", "PROXY TCP4 203.0.113.7 10.0.0.1 40001 51038\r\n")
+	assert.Error(t, err)
+	_, err = proxiedAccountID("51038", "PROXY TCP4 203.0.113.8 10.0.0.1 40000 51038\r\n")
+	assert.NoError(t, err)
+}
+
+// proxiedAccountID returns the account ID of the caller of a new connection to the server on the given port, which
+// starts with the given PROXY protocol header unless it is empty
+func proxiedAccountID(port string, proxyHeader string) (string, error) {
+	conn, err := grpc.Dial("localhost:"+port,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithContextDialer(func(ctx context.Context, addr string) (net.Conn, error) {
+			conn, err := (&net.Dialer{}).DialContext(ctx, "tcp", addr)
+			if err != nil || proxyHeader == "" {
+				return conn, err
+			}
+			if _, err := conn.Write([]byte(proxyHeader)); err != nil {
+				_ = conn.Close()
+				return nil, err
+			}
+			return conn, nil
+		}),
+	)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = conn.Close() }()
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	status, err := pb.NewDisperserClient(conn).GetRateLimitStatus(ctx, &pb.RateLimitStatusRequest{})
+	if err != nil {
+		return "", err
+	}
+	return status.GetAccountId(), nil
+}
@@ -0,0 +1,60 @@
+package apiserver
+
+import (
+	"context"
+	"path"
+
+	"github.com/Layr-Labs/eigenda/disperser"
+	"google.golang.org/grpc/stats"
+)
+
+// noCompression is the compression label of the requests sent uncompressed
+const noCompression = "identity"
+
+// maxRequestSize is the max size of the request messages once decompressed. gRPC stops decompressing a request past
+// it, so that a small compressed request can't make the server decompress an arbitrarily large payload. The largest
+// requests are dispersals of blobs of maxBlobSize, with their security params and namespace.
+const maxRequestSize = 2 * maxBlobSize
+
+// compressionStatsHandler records the compressed and decompressed sizes of the request messages
+type compressionStatsHandler struct {
+	metrics *disperser.Metrics
+}
+
+var _ stats.Handler = (*compressionStatsHandler)(nil)
+
+type rpcCompressionKey struct{}
+
+// rpcCompression is the compressor and method of an RPC, known once its header is received
+type rpcCompression struct {
+	method      string
+	compression string
+}
+
+func (h *compressionStatsHandler) TagRPC(ctx context.Context, info *stats.RPCTagInfo) context.Context {
+	return context.WithValue(ctx, rpcCompressionKey{}, &rpcCompression{
+		method:      path.Base(info.FullMethodName),
+		compression: noCompression,
+	})
+}
+
+func (h *compressionStatsHandler) HandleRPC(ctx context.Context, s stats.RPCStats) {
+	rpc, ok := ctx.Value(rpcCompressionKey{}).(*rpcCompression)
+	if !ok {
+		return
+	}
+	switch s := s.(type) {
+	case *stats.InHeader:
+		if s.Compression != "" {
+			rpc.compression = s.Compression
+		}
+	case *stats.InPayload:
+		h.metrics.AddReceivedBytes(s.CompressedLength, s.Length, rpc.compression, rpc.method)
+	}
+}
+
+func (h *compressionStatsHandler) TagConn(ctx context.Context, _ *stats.ConnTagInfo) context.Context {
+	return ctx
+}
+
+func (h *compressionStatsHandler) HandleConn(context.Context, stats.ConnStats) {}
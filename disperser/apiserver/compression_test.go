@@ -0,0 +1,127 @@
+package apiserver_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/clients"
+	commongrpc "github.com/Layr-Labs/eigenda/common/grpc"
+	"github.com/Layr-Labs/eigenda/common/logging"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/core/mock"
+	"github.com/Layr-Labs/eigenda/disperser"
+	"github.com/Layr-Labs/eigenda/disperser/apiserver"
+	"github.com/Layr-Labs/eigenda/disperser/common/inmem"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/encoding"
+	"google.golang.org/grpc/status"
+)
+
+// countingCompressor counts the bytes decompressed by the compressor it wraps. It doesn't expose the decompressed size
+// of the messages, so that gRPC has to decompress them to learn it.
+type countingCompressor struct {
+	encoding.Compressor
+	name         string
+	decompressed atomic.Int64
+}
+
+func (c *countingCompressor) Name() string {
+	return c.name
+}
+
+func (c *countingCompressor) Decompress(r io.Reader) (io.Reader, error) {
+	dr, err := c.Compressor.Decompress(r)
+	if err != nil {
+		return nil, err
+	}
+	return &countingReader{Reader: dr, count: &c.decompressed}, nil
+}
+
+type countingReader struct {
+	io.Reader
+	count *atomic.Int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.Reader.Read(p)
+	r.count.Add(int64(n))
+	return n, err
+}
+
+var (
+	countingGzip = &countingCompressor{Compressor: encoding.GetCompressor(commongrpc.GzipCompressor), name: "counting-gzip"}
+	countingZstd = &countingCompressor{Compressor: encoding.GetCompressor(commongrpc.ZstdCompressor), name: "counting-zstd"}
+)
+
+func init() {
+	encoding.RegisterCompressor(countingGzip)
+	encoding.RegisterCompressor(countingZstd)
+}
+
+func TestDisperseCompressedBlob(t *testing.T) {
+	logger, err := logging.GetLogger(logging.DefaultCLIConfig())
+	require.NoError(t, err)
+	tx := &mock.MockTransactor{}
+	tx.On("GetCurrentBlockNumber").Return(uint32(100), nil)
+	tx.On("GetQuorumCount").Return(uint16(2), nil)
+	tx.On("GetNumberOfRegisteredOperatorForQuorum").Return(uint32(4), nil)
+	metrics := disperser.NewMetrics("9013", nil, logger)
+	server := apiserver.NewDispersalServer(disperser.ServerConfig{
+		GrpcPort: "51013",
+	}, inmem.NewBlobStore(), tx, logger, metrics, nil, apiserver.RateConfig{})
+	go func() {
+		_ = server.Start(context.Background())
+	}()
+	require.Eventually(t, func() bool {
+		conn, err := net.Dial("tcp", "localhost:51013")
+		if err == nil {
+			_ = conn.Close()
+		}
+		return err == nil
+	}, 10*time.Second, 10*time.Millisecond)
+
+	newClient := func(compression string) clients.DisperserClient {
+		return clients.NewDisperserClient(&clients.DisperserClientConfig{
+			Hostname:    "localhost",
+			Port:        "51013",
+			Timeout:     10 * time.Second,
+			Compression: compression,
+		})
+	}
+	securityParams := []*core.SecurityParam{{QuorumID: 0, AdversaryThreshold: 50, QuorumThreshold: 100}}
+
+	// A compressible blob is dispersed with a fraction of its size on the wire
+	data := bytes.Repeat([]byte("rollup batch "), 30000)
+	for _, compression := range []string{commongrpc.GzipCompressor, commongrpc.ZstdCompressor} {
+		blobStatus, requestID, err := newClient(compression).DisperseBlob(context.Background(), data, securityParams)
+		require.NoError(t, err, compression)
+		assert.Equal(t, disperser.Processing, *blobStatus)
+		assert.NotEmpty(t, requestID)
+
+		compressed := testutil.ToFloat64(metrics.ReceivedBytes.WithLabelValues("compressed", compression, "DisperseBlob"))
+		decompressed := testutil.ToFloat64(metrics.ReceivedBytes.WithLabelValues("decompressed", compression, "DisperseBlob"))
+		assert.Greater(t, decompressed, float64(len(data)), compression)
+		assert.Less(t, compressed, decompressed/10, compression)
+	}
+
+	// The size limit applies to the decompressed blob
+	_, _, err = newClient(commongrpc.ZstdCompressor).DisperseBlob(context.Background(), make([]byte, 600*1024), securityParams)
+	assert.ErrorContains(t, err, "blob size cannot exceed 512 KiB")
+
+	// A bomb is rejected once the decompressed bytes exceed the decode limit, without decompressing the rest of it
+	bomb := make([]byte, 64<<20)
+	for _, compressor := range []*countingCompressor{countingGzip, countingZstd} {
+		_, _, err := newClient(compressor.name).DisperseBlob(context.Background(), bomb, securityParams)
+		assert.Equal(t, codes.ResourceExhausted, status.Code(err), compressor.name)
+		assert.Positive(t, compressor.decompressed.Load(), compressor.name)
+		assert.Less(t, compressor.decompressed.Load(), int64(2<<20), compressor.name)
+	}
+}
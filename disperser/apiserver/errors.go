@@ -0,0 +1,45 @@
+package apiserver
+
+import (
+	"github.com/Layr-Labs/eigenda/disperser"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// newInvalidArgumentError returns an InvalidArgument error whose details carry the reason of the rejection, one of the
+// disperser.Reason* constants, and the path of the offending field of the request, e.g. security_params[1].quorum_id
+func newInvalidArgumentError(reason string, field string, msg string) error {
+	st := status.New(codes.InvalidArgument, msg)
+	withDetails, err := st.WithDetails(
+		newErrorInfo(reason),
+		&errdetails.BadRequest{
+			FieldViolations: []*errdetails.BadRequest_FieldViolation{{
+				Field:       field,
+				Description: msg,
+			}},
+		},
+	)
+	if err != nil {
+		return st.Err()
+	}
+	return withDetails.Err()
+}
+
+func newErrorInfo(reason string) *errdetails.ErrorInfo {
+	return &errdetails.ErrorInfo{
+		Reason: reason,
+		Domain: disperser.ErrorDomain,
+	}
+}
+
+// newPermissionDeniedError returns a PermissionDenied error whose details carry the reason of the rejection, one of the
+// disperser.Reason* constants
+func newPermissionDeniedError(reason string, msg string) error {
+	st := status.New(codes.PermissionDenied, msg)
+	withDetails, err := st.WithDetails(newErrorInfo(reason))
+	if err != nil {
+		return st.Err()
+	}
+	return withDetails.Err()
+}
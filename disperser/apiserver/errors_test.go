@@ -0,0 +1,112 @@
+package apiserver_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/clients"
+	"github.com/Layr-Labs/eigenda/common/logging"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/core/mock"
+	"github.com/Layr-Labs/eigenda/disperser"
+	"github.com/Layr-Labs/eigenda/disperser/apiserver"
+	"github.com/Layr-Labs/eigenda/disperser/common/inmem"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// assertErrorDetails asserts the code of the status of the error and its details: the reason of its ErrorInfo and, if
+// field isn't empty, the offending field of its BadRequest
+func assertErrorDetails(t *testing.T, err error, code codes.Code, reason string, field string) {
+	t.Helper()
+	st := status.Convert(err)
+	assert.Equal(t, code, st.Code())
+
+	var errorInfo *errdetails.ErrorInfo
+	var badRequest *errdetails.BadRequest
+	for _, detail := range st.Details() {
+		switch detail := detail.(type) {
+		case *errdetails.ErrorInfo:
+			errorInfo = detail
+		case *errdetails.BadRequest:
+			badRequest = detail
+		}
+	}
+	if assert.NotNil(t, errorInfo) {
+		assert.Equal(t, reason, errorInfo.GetReason())
+		assert.Equal(t, disperser.ErrorDomain, errorInfo.GetDomain())
+	}
+	if field == "" {
+		assert.Nil(t, badRequest)
+		return
+	}
+	if assert.NotNil(t, badRequest) && assert.Len(t, badRequest.GetFieldViolations(), 1) {
+		assert.Equal(t, field, badRequest.GetFieldViolations()[0].GetField())
+		assert.Equal(t, st.Message(), badRequest.GetFieldViolations()[0].GetDescription())
+	}
+}
+
+func TestDisperserErrorDetails(t *testing.T) {
+	logger, err := logging.GetLogger(logging.DefaultCLIConfig())
+	require.NoError(t, err)
+	tx := &mock.MockTransactor{}
+	tx.On("GetCurrentBlockNumber").Return(uint32(100), nil)
+	tx.On("GetQuorumCount").Return(uint16(2), nil)
+	tx.On("GetNumberOfRegisteredOperatorForQuorum").Return(uint32(4), nil)
+	startServer(t, apiserver.NewDispersalServer(disperser.ServerConfig{
+		GrpcPort:         "51014",
+		EnableReflection: true,
+	}, inmem.NewBlobStore(), tx, logger, disperser.NewMetrics("9014", nil, logger), nil, apiserver.RateConfig{}), "51014")
+
+	// The services can be listed through reflection, e.g. with grpcurl
+	services, err := listServices("localhost:51014")
+	require.NoError(t, err)
+	assert.Contains(t, services, "disperser.Disperser")
+
+	// The client decodes the details of the errors
+	client := clients.NewDisperserClient(&clients.DisperserClientConfig{
+		Hostname: "localhost",
+		Port:     "51014",
+		Timeout:  10 * time.Second,
+	})
+	_, _, err = client.DisperseBlob(context.Background(), []byte("data"), []*core.SecurityParam{
+		{QuorumID: 0, AdversaryThreshold: 50, QuorumThreshold: 100},
+		{QuorumID: 2, AdversaryThreshold: 50, QuorumThreshold: 100},
+	})
+	var disperserErr *clients.DisperserError
+	require.ErrorAs(t, err, &disperserErr)
+	assert.Equal(t, codes.InvalidArgument, disperserErr.Code)
+	assert.Equal(t, disperser.ReasonInvalidQuorum, disperserErr.Reason)
+	assert.Equal(t, []clients.FieldViolation{{
+		Field:       "security_params[1].quorum_id",
+		Description: "invalid request: the quorum_id must be in range [0, 1], but found 2",
+	}}, disperserErr.FieldViolations)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, _, err = client.DisperseBlob(context.Background(), []byte("data"), []*core.SecurityParam{
+		{QuorumID: 0, AdversaryThreshold: 50, QuorumThreshold: 100},
+		{QuorumID: 1, AdversaryThreshold: 95, QuorumThreshold: 100},
+	})
+	require.ErrorAs(t, err, &disperserErr)
+	assert.Equal(t, disperser.ReasonInvalidSecurityParams, disperserErr.Reason)
+	assert.Equal(t, []clients.FieldViolation{{
+		Field:       "security_params[1]",
+		Description: "invalid request: quorum threshold must be >= 10 + adversary threshold",
+	}}, disperserErr.FieldViolations)
+
+	_, _, err = client.DisperseBlob(context.Background(), make([]byte, 600*1024), []*core.SecurityParam{
+		{QuorumID: 0, AdversaryThreshold: 50, QuorumThreshold: 100},
+	})
+	require.ErrorAs(t, err, &disperserErr)
+	assert.Equal(t, disperser.ReasonBlobTooLarge, disperserErr.Reason)
+	assert.Equal(t, []clients.FieldViolation{{Field: "data", Description: "blob size cannot exceed 512 KiB"}}, disperserErr.FieldViolations)
+
+	_, err = client.GetBlobStatus(context.Background(), []byte("malformed"))
+	require.ErrorAs(t, err, &disperserErr)
+	assert.Equal(t, disperser.ReasonInvalidRequestID, disperserErr.Reason)
+	assert.Equal(t, "request_id", disperserErr.FieldViolations[0].Field)
+}
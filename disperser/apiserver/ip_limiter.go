@@ -0,0 +1,199 @@
+package apiserver
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/disperser"
+	lru "github.com/hashicorp/golang-lru/v2"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// maxTrackedIPs bounds the number of source IPs whose rates are tracked. The least recently seen IPs are forgotten
+// first, which at worst gives them a full burst again.
+const maxTrackedIPs = 100_000
+
+// Kinds of the events limited per source IP reported in the metrics
+const (
+	ipLimitedRequest    = "request"
+	ipLimitedConnection = "connection"
+)
+
+// ipBucket is the token bucket of a source IP
+type ipBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// ipRateLimiter limits the rate of events, e.g. requests or new connections, of each source IP with a token bucket
+// refilled at rate tokens per second, and holding up to burst tokens. Unlike the throughput RateLimiter, it counts the
+// events regardless of their size, which protects the server from floods of tiny requests.
+type ipRateLimiter struct {
+	rate  float64
+	burst float64
+
+	mu      sync.Mutex
+	buckets *lru.Cache[string, *ipBucket]
+	now     func() time.Time
+}
+
+// newIPRateLimiter returns the limiter of the events of each source IP, or nil if they aren't limited
+func newIPRateLimiter(rate float64, burst int) *ipRateLimiter {
+	if rate <= 0 {
+		return nil
+	}
+	buckets, err := lru.New[string, *ipBucket](maxTrackedIPs)
+	if err != nil {
+		// Only returned for a non-positive size
+		panic(err)
+	}
+	return &ipRateLimiter{
+		rate:    rate,
+		burst:   math.Max(float64(burst), 1),
+		buckets: buckets,
+		now:     time.Now,
+	}
+}
+
+// allow takes a token from the bucket of the IP, and returns whether there was one. A nil limiter allows all the
+// events.
+func (l *ipRateLimiter) allow(ip string) bool {
+	if l == nil {
+		return true
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	bucket, ok := l.buckets.Get(ip)
+	if !ok {
+		bucket = &ipBucket{tokens: l.burst, last: now}
+		l.buckets.Add(ip, bucket)
+	}
+	if elapsed := now.Sub(bucket.last); elapsed > 0 {
+		bucket.tokens = math.Min(l.burst, bucket.tokens+elapsed.Seconds()*l.rate)
+		bucket.last = now
+	}
+	if bucket.tokens < 1 {
+		return false
+	}
+	bucket.tokens--
+	return true
+}
+
+// retryAfter is the delay after which a rejected source gets a token again
+func (l *ipRateLimiter) retryAfter() time.Duration {
+	return time.Duration(float64(time.Second) / l.rate)
+}
+
+// ipRequestLimit rejects the requests of the source IPs exceeding their request rate, before they reach the handlers.
+// The source IP is read from the client IP header like for the throughput limits, and the trusted callers aren't
+// limited.
+type ipRequestLimit struct {
+	server  *DispersalServer
+	limiter *ipRateLimiter
+}
+
+func (l *ipRequestLimit) check(ctx context.Context, fullMethod string) error {
+	origin, err := l.server.clientAddress(ctx)
+	if err != nil {
+		// The handlers reject the requests without a client address
+		return nil
+	}
+	if l.limiter.allow(origin) || l.server.trustReason(ctx, origin) != "" {
+		return nil
+	}
+
+	method := methodName(fullMethod)
+	l.server.metrics.IncrementIPRateLimitedNum(ipLimitedRequest, method)
+	l.server.logger.Debug("rejecting request over the rate of its source IP", "origin", origin, "method", method)
+	st := status.New(codes.ResourceExhausted, "request rate limit of the source IP exceeded, retry later")
+	withDetails, err := st.WithDetails(newErrorInfo(disperser.ReasonIPRateLimit), &errdetails.RetryInfo{
+		RetryDelay: durationpb.New(l.limiter.retryAfter()),
+	})
+	if err != nil {
+		return st.Err()
+	}
+	return withDetails.Err()
+}
+
+func (l *ipRequestLimit) unaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if err := l.check(ctx, info.FullMethod); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func (l *ipRequestLimit) streamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if err := l.check(ss.Context(), info.FullMethod); err != nil {
+		return err
+	}
+	return handler(srv, ss)
+}
+
+// methodName returns the name of the method of a full gRPC method name, e.g. DisperseBlob for
+// /disperser.Disperser/DisperseBlob
+func methodName(fullMethod string) string {
+	for i := len(fullMethod) - 1; i >= 0; i-- {
+		if fullMethod[i] == '/' {
+			return fullMethod[i+1:]
+		}
+	}
+	return fullMethod
+}
+
+// errConnectionRateLimited is returned by the reads of the connections over the connection rate of their source IP
+var errConnectionRateLimited = errors.New("connection rate limited")
+
+// ipConnectionLimitListener closes the connections of the remote IPs exceeding their connection rate. It limits the
+// remote address of the connection, which is the client address of its PROXY protocol header if any: behind a load
+// balancer setting a client IP header instead, all the connections share the address of the load balancer.
+type ipConnectionLimitListener struct {
+	net.Listener
+	limiter *ipRateLimiter
+	metrics *disperser.Metrics
+	logger  common.Logger
+}
+
+func (l *ipConnectionLimitListener) Accept() (net.Conn, error) {
+	conn, err := l.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+	return &ipLimitedConn{Conn: conn, listener: l}, nil
+}
+
+// ipLimitedConn checks the connection rate of its remote IP on its first read, by the goroutine serving it, as
+// resolving the remote address blocks on reading the PROXY protocol header
+type ipLimitedConn struct {
+	net.Conn
+	listener *ipConnectionLimitListener
+	once     sync.Once
+	err      error
+}
+
+func (c *ipLimitedConn) Read(b []byte) (int, error) {
+	c.once.Do(func() {
+		host, err := CanonicalIP(c.Conn.RemoteAddr().String())
+		if err != nil || c.listener.limiter.allow(host) {
+			return
+		}
+		c.listener.metrics.IncrementIPRateLimitedNum(ipLimitedConnection, "")
+		c.listener.logger.Debug("closing connection over the rate of its source IP", "origin", host)
+		c.err = errConnectionRateLimited
+		_ = c.Conn.Close()
+	})
+	if c.err != nil {
+		return 0, c.err
+	}
+	return c.Conn.Read(b)
+}
@@ -0,0 +1,82 @@
+package apiserver_test
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	pb "github.com/Layr-Labs/eigenda/api/grpc/disperser"
+	"github.com/Layr-Labs/eigenda/common/logging"
+	"github.com/Layr-Labs/eigenda/core/mock"
+	"github.com/Layr-Labs/eigenda/disperser"
+	"github.com/Layr-Labs/eigenda/disperser/apiserver"
+	"github.com/Layr-Labs/eigenda/disperser/common/inmem"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestIPRequestRateLimit(t *testing.T) {
+	logger, err := logging.GetLogger(logging.DefaultCLIConfig())
+	require.NoError(t, err)
+	startServer(t, apiserver.NewDispersalServer(disperser.ServerConfig{
+		GrpcPort: "51029",
+	}, inmem.NewBlobStore(), &mock.MockTransactor{}, logger, disperser.NewMetrics("9029", nil, logger), nil, apiserver.RateConfig{
+		// The bucket doesn't refill during the test
+		IPRequestRate:  0.001,
+		IPRequestBurst: 2,
+		TrustedAPIKeys: []string{"secret"},
+	}), "51029")
+
+	conn, err := grpc.Dial("localhost:51029", grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer func() { _ = conn.Close() }()
+	client := pb.NewDisperserClient(conn)
+
+	// The requests are limited regardless of their outcome, here rejected by the handler for the missing request ID
+	for i := 0; i < 2; i++ {
+		_, err = client.GetBlobStatus(context.Background(), &pb.BlobStatusRequest{})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	}
+	_, err = client.GetBlobStatus(context.Background(), &pb.BlobStatusRequest{})
+	assertErrorDetails(t, err, codes.ResourceExhausted, disperser.ReasonIPRateLimit, "")
+	_, err = client.GetBlobStatus(context.Background(), &pb.BlobStatusRequest{})
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	// The trusted callers aren't limited
+	ctx := metadata.AppendToOutgoingContext(context.Background(), apiserver.TrustedAPIKeyHeader, "secret")
+	_, err = client.GetBlobStatus(ctx, &pb.BlobStatusRequest{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestIPConnectionRateLimit(t *testing.T) {
+	logger, err := logging.GetLogger(logging.DefaultCLIConfig())
+	require.NoError(t, err)
+	// startServer opens the first connection to wait for the server
+	startServer(t, apiserver.NewDispersalServer(disperser.ServerConfig{
+		GrpcPort: "51030",
+	}, inmem.NewBlobStore(), &mock.MockTransactor{}, logger, disperser.NewMetrics("9030", nil, logger), nil, apiserver.RateConfig{
+		IPConnectionRate:  0.001,
+		IPConnectionBurst: 2,
+	}), "51030")
+
+	conn, err := grpc.Dial("localhost:51030", grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer func() { _ = conn.Close() }()
+	_, err = pb.NewDisperserClient(conn).GetBlobStatus(context.Background(), &pb.BlobStatusRequest{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// The connections over the rate are closed by the server, rather than waiting for the client preface
+	rejected, err := net.Dial("tcp", "localhost:51030")
+	require.NoError(t, err)
+	defer func() { _ = rejected.Close() }()
+	require.NoError(t, rejected.SetReadDeadline(time.Now().Add(5*time.Second)))
+	_, err = io.ReadAll(rejected)
+	assert.NoError(t, err)
+}
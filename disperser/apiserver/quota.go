@@ -0,0 +1,88 @@
+package apiserver
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/core"
+)
+
+// quotaAccountKey prefixes the keys of the quota usages, so that they can share the store of the rate buckets
+const quotaAccountKey = "quota"
+
+// QuotaWindow is the rolling window over which the daily quotas are enforced
+const QuotaWindow = 24 * time.Hour
+
+// quotaSlotDuration is the granularity at which the usage of the quotas is tracked. The bytes dispersed in a slot leave
+// the rolling window together, at the end of the slot.
+const quotaSlotDuration = time.Hour
+
+var errDailyQuotaExceeded = fmt.Errorf("request exceeds the daily quota of the account")
+
+// QuotaStore stores the usage of the daily quotas of the accounts
+type QuotaStore = common.KVStore[QuotaUsage]
+
+// QuotaUsage is the number of bytes dispersed by an account in each slot of the rolling window
+type QuotaUsage struct {
+	Slots []QuotaSlot
+}
+
+type QuotaSlot struct {
+	// Start is the start of the slot, in UTC
+	Start time.Time
+	Bytes uint64
+}
+
+// Used returns the number of bytes dispersed in the rolling window ending at now
+func (u *QuotaUsage) Used(now time.Time) uint64 {
+	used := uint64(0)
+	for _, slot := range u.Slots {
+		if slot.Start.Add(quotaSlotDuration).After(now.Add(-QuotaWindow)) {
+			used += slot.Bytes
+		}
+	}
+	return used
+}
+
+// Add records the bytes dispersed at now, and drops the slots which left the rolling window
+func (u *QuotaUsage) Add(now time.Time, bytes uint64) {
+	start := now.UTC().Truncate(quotaSlotDuration)
+	slots := make([]QuotaSlot, 0, len(u.Slots)+1)
+	for _, slot := range u.Slots {
+		if slot.Start.Add(quotaSlotDuration).After(now.Add(-QuotaWindow)) {
+			slots = append(slots, slot)
+		}
+	}
+	if len(slots) > 0 && slots[len(slots)-1].Start.Equal(start) {
+		slots[len(slots)-1].Bytes += bytes
+	} else {
+		slots = append(slots, QuotaSlot{Start: start, Bytes: bytes})
+	}
+	u.Slots = slots
+}
+
+// RetryAfter returns the time until enough bytes leave the rolling window for the account to disperse the given number
+// of bytes more than it can at now
+func (u *QuotaUsage) RetryAfter(now time.Time, excess uint64) time.Duration {
+	freed := uint64(0)
+	for _, slot := range u.Slots {
+		end := slot.Start.Add(quotaSlotDuration).Add(QuotaWindow)
+		if !end.After(now) {
+			continue
+		}
+		freed += slot.Bytes
+		if freed >= excess {
+			return end.Sub(now)
+		}
+	}
+	return QuotaWindow
+}
+
+// dailyQuota returns the number of bytes the account can disperse over the rolling window, 0 if it has no quota
+func (c RateConfig) dailyQuota(accountID core.AccountID) uint64 {
+	if quota, ok := c.AccountDailyQuotas[accountID]; ok {
+		return quota
+	}
+	return c.DailyQuota
+}
@@ -0,0 +1,37 @@
+package apiserver_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/disperser/apiserver"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuotaUsageRollingWindow(t *testing.T) {
+	start := time.Date(2024, 1, 1, 10, 30, 0, 0, time.UTC)
+	usage := &apiserver.QuotaUsage{}
+	usage.Add(start, 100)
+	usage.Add(start.Add(10*time.Minute), 50)
+	usage.Add(start.Add(2*time.Hour), 200)
+	assert.Len(t, usage.Slots, 2)
+	assert.Equal(t, uint64(350), usage.Used(start.Add(2*time.Hour)))
+
+	// The bytes of the first slot, which started at 10:00, leave the window 24 hours after the end of the slot
+	assert.Equal(t, uint64(350), usage.Used(start.Add(apiserver.QuotaWindow+29*time.Minute)))
+	assert.Equal(t, uint64(200), usage.Used(start.Add(apiserver.QuotaWindow+30*time.Minute)))
+	assert.Equal(t, uint64(0), usage.Used(start.Add(apiserver.QuotaWindow+3*time.Hour)))
+
+	// Freeing 100 bytes requires the first slot to leave the window, and freeing 200 bytes requires both slots to
+	now := start.Add(3 * time.Hour)
+	assert.Equal(t, apiserver.QuotaWindow-150*time.Minute, usage.RetryAfter(now, 100))
+	assert.Equal(t, apiserver.QuotaWindow-30*time.Minute, usage.RetryAfter(now, 200))
+	assert.Equal(t, apiserver.QuotaWindow, usage.RetryAfter(now, 1000))
+
+	// The slots which left the window are dropped
+	usage.Add(start.Add(apiserver.QuotaWindow+2*time.Hour), 10)
+	assert.Equal(t, []apiserver.QuotaSlot{
+		{Start: start.Truncate(time.Hour).Add(2 * time.Hour), Bytes: 200},
+		{Start: start.Truncate(time.Hour).Add(apiserver.QuotaWindow + 2*time.Hour), Bytes: 10},
+	}, usage.Slots)
+}
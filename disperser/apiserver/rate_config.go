@@ -1,6 +1,16 @@
 package apiserver
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
 	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/core"
 	"github.com/urfave/cli"
@@ -11,16 +21,79 @@ const (
 	TotalUnauthThroughputFlagName   = "auth.total-unauth-throughput"
 	PerUserUnauthThroughputFlagName = "auth.per-user-unauth-throughput"
 	ClientIPHeaderFlagName          = "auth.client-ip-header"
+	ClientIPProxyDepthFlagName      = "auth.client-ip-proxy-depth"
+	ProxyProtocolCIDRsFlagName      = "auth.proxy-protocol-cidrs"
+	ReservationsFileFlagName        = "auth.reservations-file"
+	ReservationsRefreshFlagName     = "auth.reservations-refresh-interval"
+	TrustedAPIKeysFlagName          = "auth.trusted-api-keys"
+	TrustedCIDRsFlagName            = "auth.trusted-cidrs"
+	DailyQuotaFlagName              = "auth.daily-quota"
+	AccountDailyQuotasFlagName      = "auth.account-daily-quotas"
+	IPRequestRateFlagName           = "auth.ip-request-rate"
+	IPRequestBurstFlagName          = "auth.ip-request-burst"
+	IPConnectionRateFlagName        = "auth.ip-connection-rate"
+	IPConnectionBurstFlagName       = "auth.ip-connection-burst"
 )
 
+// TrustedAPIKeyHeader is the gRPC metadata key in which trusted callers present their API key
+const TrustedAPIKeyHeader = "x-eigenda-api-key"
+
+// QuorumRateInfo is the throughput (Bytes/sec) of the unauthenticated requests to a quorum. The throughputs must be
+// positive: to block the unauthenticated requests to a quorum, the quorum isn't registered.
 type QuorumRateInfo struct {
 	PerUserUnauthThroughput common.RateParam
 	TotalUnauthThroughput   common.RateParam
 }
 
+// Reservations maps an account to the throughput (Bytes/sec) reserved for it on each quorum. Traffic from a reserved
+// account is charged against its reservation first and only overflows into the shared unauthenticated pool once
+// the reservation is exhausted. A reservation of 0 is no reservation.
+type Reservations map[core.AccountID]map[core.QuorumID]common.RateParam
+
 type RateConfig struct {
 	QuorumRateInfos map[core.QuorumID]QuorumRateInfo
-	ClientIPHeader  string
+	// ClientIPHeader is the X-Forwarded-For style header from which the client addresses are read, or empty to read
+	// them from the connections
+	ClientIPHeader string
+	// ClientIPProxyDepth is the number of rightmost entries of the client IP header appended by the trusted proxies,
+	// among which the client is the rightmost public address. All the entries are considered if it is 0.
+	ClientIPProxyDepth int
+	// ProxyProtocolCIDRs are the CIDRs of the load balancers which send the client addresses of their connections in a
+	// PROXY protocol header, for the load balancers which don't set a client IP header. The connections from these
+	// CIDRs must start with the header, and the connections from any other address must not. The PROXY protocol is
+	// disabled when it is empty.
+	ProxyProtocolCIDRs []*net.IPNet
+
+	// Reservations are the reservations in effect at startup
+	Reservations Reservations
+	// ReservationsFile is the JSON file from which Reservations are loaded. If set, the file is periodically
+	// reloaded so that reservations can be changed without restarting the server.
+	ReservationsFile string
+	// ReservationsRefreshInterval is the interval at which ReservationsFile is reloaded
+	ReservationsRefreshInterval time.Duration
+
+	// TrustedAPIKeys and TrustedCIDRs identify the trusted callers, e.g. internal tooling, which are not rate limited.
+	// A caller is trusted if it presents one of the keys in the TrustedAPIKeyHeader metadata, or if its address is in
+	// one of the CIDRs. No caller is trusted when both are empty.
+	TrustedAPIKeys []string
+	TrustedCIDRs   []*net.IPNet
+
+	// DailyQuota is the number of bytes each account can disperse in any 24 hour window, on top of the throughput
+	// limits. The accounts have no daily quota if it is 0, except for those in AccountDailyQuotas.
+	DailyQuota uint64
+	// AccountDailyQuotas overrides DailyQuota for the given accounts, 0 exempting an account from the quota
+	AccountDailyQuotas map[core.AccountID]uint64
+
+	// IPRequestRate is the number of requests per second each source IP can send to any method, regardless of their
+	// size, with bursts of up to IPRequestBurst requests. The requests aren't limited per IP if it is 0.
+	IPRequestRate  float64
+	IPRequestBurst int
+	// IPConnectionRate is the number of connections per second each remote address can open, with bursts of up to
+	// IPConnectionBurst connections. It applies to the address of the connection, or to the client address of its
+	// PROXY protocol header, rather than to the client IP header, so it is 0, i.e. disabled, when the server is behind
+	// a load balancer setting a client IP header.
+	IPConnectionRate  float64
+	IPConnectionBurst int
 }
 
 func CLIFlags(envPrefix string) []cli.Flag {
@@ -45,18 +118,102 @@ func CLIFlags(envPrefix string) []cli.Flag {
 		},
 		cli.StringFlag{
 			Name:     ClientIPHeaderFlagName,
-			Usage:    "The name of the header used to get the client IP address. If set to empty string, the IP address will be taken from the connection. The rightmost value of the header will be used. For AWS, this should be set to 'x-forwarded-for'.",
+			Usage:    "The name of the header used to get the client IP address. If set to empty string, the IP address will be taken from the connection, as well as when the header is missing or unparsable. The rightmost public address among the trusted entries of the header will be used. For AWS, this should be set to 'x-forwarded-for'.",
 			Required: false,
 			Value:    "",
 			EnvVar:   common.PrefixEnvVar(envPrefix, "CLIENT_IP_HEADER"),
 		},
+		cli.IntFlag{
+			Name:     ClientIPProxyDepthFlagName,
+			Usage:    "The number of rightmost entries of the client IP header appended by the trusted proxies. The entries further left are set by the clients and ignored. All the entries are trusted if 0",
+			Required: false,
+			Value:    2,
+			EnvVar:   common.PrefixEnvVar(envPrefix, "CLIENT_IP_PROXY_DEPTH"),
+		},
+		cli.StringSliceFlag{
+			Name:     ProxyProtocolCIDRsFlagName,
+			Usage:    "CIDRs (e.g. '10.0.0.0/8') of the load balancers sending the client addresses of their connections in a PROXY protocol (v1 or v2) header, for the load balancers which don't set a client IP header. The header is required from these CIDRs and rejected from any other address. The PROXY protocol is disabled if empty",
+			Required: false,
+			EnvVar:   common.PrefixEnvVar(envPrefix, "PROXY_PROTOCOL_CIDRS"),
+		},
+		cli.StringFlag{
+			Name:     ReservationsFileFlagName,
+			Usage:    "Path to a JSON file mapping account IDs (e.g. 'ip:1.2.3.4') to the reserved throughput per quorum (Bytes/sec), e.g. {\"ip:1.2.3.4\": {\"0\": 1000}}",
+			Required: false,
+			Value:    "",
+			EnvVar:   common.PrefixEnvVar(envPrefix, "RESERVATIONS_FILE"),
+		},
+		cli.DurationFlag{
+			Name:     ReservationsRefreshFlagName,
+			Usage:    "Interval at which the reservations file is reloaded",
+			Required: false,
+			Value:    time.Minute,
+			EnvVar:   common.PrefixEnvVar(envPrefix, "RESERVATIONS_REFRESH_INTERVAL"),
+		},
+		cli.StringSliceFlag{
+			Name:     TrustedAPIKeysFlagName,
+			Usage:    "API keys of the trusted callers, which bypass the rate limits when they present one in the '" + TrustedAPIKeyHeader + "' header",
+			Required: false,
+			EnvVar:   common.PrefixEnvVar(envPrefix, "TRUSTED_API_KEYS"),
+		},
+		cli.StringSliceFlag{
+			Name:     TrustedCIDRsFlagName,
+			Usage:    "CIDRs (e.g. '10.0.0.0/8') of the trusted callers, which bypass the rate limits",
+			Required: false,
+			EnvVar:   common.PrefixEnvVar(envPrefix, "TRUSTED_CIDRS"),
+		},
+		cli.Uint64Flag{
+			Name:     DailyQuotaFlagName,
+			Usage:    "Number of bytes each account can disperse in any 24 hour window. Unlimited if 0",
+			Required: false,
+			Value:    0,
+			EnvVar:   common.PrefixEnvVar(envPrefix, "DAILY_QUOTA"),
+		},
+		cli.StringSliceFlag{
+			Name:     AccountDailyQuotasFlagName,
+			Usage:    "Daily quotas (bytes) of the accounts overriding the default daily quota, e.g. 'ip:1.2.3.4=1000000'. An account with a quota of 0 is unlimited",
+			Required: false,
+			EnvVar:   common.PrefixEnvVar(envPrefix, "ACCOUNT_DAILY_QUOTAS"),
+		},
+		cli.Float64Flag{
+			Name:     IPRequestRateFlagName,
+			Usage:    "Number of requests per second each source IP can send, regardless of their size. Unlimited if 0",
+			Required: false,
+			Value:    0,
+			EnvVar:   common.PrefixEnvVar(envPrefix, "IP_REQUEST_RATE"),
+		},
+		cli.IntFlag{
+			Name:     IPRequestBurstFlagName,
+			Usage:    "Number of requests each source IP can send at once above its request rate",
+			Required: false,
+			Value:    20,
+			EnvVar:   common.PrefixEnvVar(envPrefix, "IP_REQUEST_BURST"),
+		},
+		cli.Float64Flag{
+			Name:     IPConnectionRateFlagName,
+			Usage:    "Number of connections per second each remote address can open. Unlimited if 0. It applies to the address of the connection, so it must be 0 behind a load balancer",
+			Required: false,
+			Value:    0,
+			EnvVar:   common.PrefixEnvVar(envPrefix, "IP_CONNECTION_RATE"),
+		},
+		cli.IntFlag{
+			Name:     IPConnectionBurstFlagName,
+			Usage:    "Number of connections each remote address can open at once above its connection rate",
+			Required: false,
+			Value:    10,
+			EnvVar:   common.PrefixEnvVar(envPrefix, "IP_CONNECTION_BURST"),
+		},
 	}
 }
 
-func ReadCLIConfig(c *cli.Context) RateConfig {
+func ReadCLIConfig(c *cli.Context) (RateConfig, error) {
 
+	quorumIDs := c.IntSlice(RegisteredQuorumFlagName)
+	if len(c.IntSlice(TotalUnauthThroughputFlagName)) != len(quorumIDs) || len(c.IntSlice(PerUserUnauthThroughputFlagName)) != len(quorumIDs) {
+		return RateConfig{}, fmt.Errorf("the %s and %s flags must have a value for each of the %d registered quorums", TotalUnauthThroughputFlagName, PerUserUnauthThroughputFlagName, len(quorumIDs))
+	}
 	quorumRateInfos := make(map[core.QuorumID]QuorumRateInfo)
-	for ind, quorumID := range c.IntSlice(RegisteredQuorumFlagName) {
+	for ind, quorumID := range quorumIDs {
 
 		quorumRateInfos[core.QuorumID(quorumID)] = QuorumRateInfo{
 			TotalUnauthThroughput:   common.RateParam(c.IntSlice(TotalUnauthThroughputFlagName)[ind]),
@@ -64,8 +221,131 @@ func ReadCLIConfig(c *cli.Context) RateConfig {
 		}
 	}
 
+	reservations := make(Reservations)
+	reservationsFile := c.String(ReservationsFileFlagName)
+	if reservationsFile != "" {
+		var err error
+		reservations, err = ReadReservations(reservationsFile)
+		if err != nil {
+			return RateConfig{}, err
+		}
+	}
+
+	trustedCIDRs := make([]*net.IPNet, 0)
+	for _, cidr := range c.StringSlice(TrustedCIDRsFlagName) {
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return RateConfig{}, fmt.Errorf("invalid trusted CIDR %q: %w", cidr, err)
+		}
+		trustedCIDRs = append(trustedCIDRs, ipNet)
+	}
+
+	proxyProtocolCIDRs := make([]*net.IPNet, 0)
+	for _, cidr := range c.StringSlice(ProxyProtocolCIDRsFlagName) {
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return RateConfig{}, fmt.Errorf("invalid PROXY protocol CIDR %q: %w", cidr, err)
+		}
+		proxyProtocolCIDRs = append(proxyProtocolCIDRs, ipNet)
+	}
+
+	accountDailyQuotas := make(map[core.AccountID]uint64)
+	for _, accountQuota := range c.StringSlice(AccountDailyQuotasFlagName) {
+		separator := strings.LastIndex(accountQuota, "=")
+		if separator <= 0 {
+			return RateConfig{}, fmt.Errorf("invalid account daily quota %q: expected <account>=<bytes>", accountQuota)
+		}
+		quota, err := strconv.ParseUint(accountQuota[separator+1:], 10, 64)
+		if err != nil {
+			return RateConfig{}, fmt.Errorf("invalid account daily quota %q: %w", accountQuota, err)
+		}
+		accountDailyQuotas[accountQuota[:separator]] = quota
+	}
+
 	return RateConfig{
-		QuorumRateInfos: quorumRateInfos,
-		ClientIPHeader:  c.String(ClientIPHeaderFlagName),
+		QuorumRateInfos:             quorumRateInfos,
+		ClientIPHeader:              c.String(ClientIPHeaderFlagName),
+		ClientIPProxyDepth:          c.Int(ClientIPProxyDepthFlagName),
+		ProxyProtocolCIDRs:          proxyProtocolCIDRs,
+		Reservations:                reservations,
+		ReservationsFile:            reservationsFile,
+		ReservationsRefreshInterval: c.Duration(ReservationsRefreshFlagName),
+		TrustedAPIKeys:              c.StringSlice(TrustedAPIKeysFlagName),
+		TrustedCIDRs:                trustedCIDRs,
+		DailyQuota:                  c.Uint64(DailyQuotaFlagName),
+		AccountDailyQuotas:          accountDailyQuotas,
+		IPRequestRate:               c.Float64(IPRequestRateFlagName),
+		IPRequestBurst:              c.Int(IPRequestBurstFlagName),
+		IPConnectionRate:            c.Float64(IPConnectionRateFlagName),
+		IPConnectionBurst:           c.Int(IPConnectionBurstFlagName),
+	}, nil
+}
+
+// Validate checks the config before the server starts, and returns all the violations at once
+func (c RateConfig) Validate() error {
+	var errs []error
+	quorumIDs := make([]core.QuorumID, 0, len(c.QuorumRateInfos))
+	for quorumID := range c.QuorumRateInfos {
+		quorumIDs = append(quorumIDs, quorumID)
+	}
+	sort.Slice(quorumIDs, func(i, j int) bool { return quorumIDs[i] < quorumIDs[j] })
+	for _, quorumID := range quorumIDs {
+		info := c.QuorumRateInfos[quorumID]
+		if info.TotalUnauthThroughput == 0 || info.PerUserUnauthThroughput == 0 {
+			errs = append(errs, fmt.Errorf("the unauthenticated throughputs of quorum %d must be positive, but found %d in total and %d per user: a quorum is blocked by not registering it", quorumID, info.TotalUnauthThroughput, info.PerUserUnauthThroughput))
+		} else if info.PerUserUnauthThroughput > info.TotalUnauthThroughput {
+			errs = append(errs, fmt.Errorf("the per-user unauthenticated throughput of quorum %d (%d) exceeds its total unauthenticated throughput (%d)", quorumID, info.PerUserUnauthThroughput, info.TotalUnauthThroughput))
+		}
 	}
+
+	accounts := make([]core.AccountID, 0, len(c.Reservations))
+	for account := range c.Reservations {
+		accounts = append(accounts, account)
+	}
+	sort.Strings(accounts)
+	for _, account := range accounts {
+		for quorumID, rate := range c.Reservations[account] {
+			if _, ok := c.QuorumRateInfos[quorumID]; !ok && rate > 0 {
+				errs = append(errs, fmt.Errorf("account %s has a reservation on quorum %d, which is not registered", account, quorumID))
+			}
+		}
+	}
+	if c.ReservationsFile != "" && c.ReservationsRefreshInterval <= 0 {
+		errs = append(errs, fmt.Errorf("the reservations refresh interval must be positive with a reservations file, but found %s", c.ReservationsRefreshInterval))
+	}
+	for _, key := range c.TrustedAPIKeys {
+		if key == "" {
+			errs = append(errs, errors.New("the trusted API keys must not be empty"))
+			break
+		}
+	}
+	if c.ClientIPProxyDepth < 0 {
+		errs = append(errs, fmt.Errorf("the client IP proxy depth must not be negative (0 to trust all the entries), but found %d", c.ClientIPProxyDepth))
+	}
+	if c.IPRequestRate < 0 {
+		errs = append(errs, fmt.Errorf("the request rate per IP must not be negative (0 for no limit), but found %g", c.IPRequestRate))
+	} else if c.IPRequestRate > 0 && c.IPRequestBurst < 1 {
+		errs = append(errs, fmt.Errorf("the request burst per IP must be positive with a request rate per IP, but found %d", c.IPRequestBurst))
+	}
+	if c.IPConnectionRate < 0 {
+		errs = append(errs, fmt.Errorf("the connection rate per IP must not be negative (0 for no limit), but found %g", c.IPConnectionRate))
+	} else if c.IPConnectionRate > 0 && c.IPConnectionBurst < 1 {
+		errs = append(errs, fmt.Errorf("the connection burst per IP must be positive with a connection rate per IP, but found %d", c.IPConnectionBurst))
+	}
+	return errors.Join(errs...)
+}
+
+// ReadReservations reads the reservations from the given JSON file
+func ReadReservations(path string) (Reservations, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read reservations file: %w", err)
+	}
+
+	reservations := make(Reservations)
+	if err := json.Unmarshal(data, &reservations); err != nil {
+		return nil, fmt.Errorf("failed to parse reservations file: %w", err)
+	}
+
+	return reservations, nil
 }
@@ -0,0 +1,98 @@
+package apiserver_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/disperser/apiserver"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateConfigValidate(t *testing.T) {
+	valid := func() apiserver.RateConfig {
+		return apiserver.RateConfig{
+			QuorumRateInfos: map[core.QuorumID]apiserver.QuorumRateInfo{
+				0: {TotalUnauthThroughput: 10_000, PerUserUnauthThroughput: 1_000},
+				1: {TotalUnauthThroughput: 10_000, PerUserUnauthThroughput: 10_000},
+			},
+			Reservations:                apiserver.Reservations{"ip:1.2.3.4": {0: 1_000, 2: 0}},
+			ReservationsRefreshInterval: time.Minute,
+		}
+	}
+	config := valid()
+	assert.NoError(t, config.Validate())
+
+	tests := []struct {
+		name   string
+		modify func(*apiserver.RateConfig)
+		errors []string
+	}{
+		{
+			name: "zero throughputs",
+			modify: func(c *apiserver.RateConfig) {
+				c.QuorumRateInfos[0] = apiserver.QuorumRateInfo{TotalUnauthThroughput: 0, PerUserUnauthThroughput: 0}
+				c.QuorumRateInfos[1] = apiserver.QuorumRateInfo{TotalUnauthThroughput: 10_000, PerUserUnauthThroughput: 0}
+			},
+			errors: []string{
+				"the unauthenticated throughputs of quorum 0 must be positive, but found 0 in total and 0 per user: a quorum is blocked by not registering it",
+				"the unauthenticated throughputs of quorum 1 must be positive, but found 10000 in total and 0 per user: a quorum is blocked by not registering it",
+			},
+		},
+		{
+			name: "per-user throughput above the total",
+			modify: func(c *apiserver.RateConfig) {
+				c.QuorumRateInfos[1] = apiserver.QuorumRateInfo{TotalUnauthThroughput: 1_000, PerUserUnauthThroughput: 2_000}
+			},
+			errors: []string{"the per-user unauthenticated throughput of quorum 1 (2000) exceeds its total unauthenticated throughput (1000)"},
+		},
+		{
+			name: "reservation on an unregistered quorum",
+			modify: func(c *apiserver.RateConfig) {
+				c.Reservations["ip:5.6.7.8"] = map[core.QuorumID]common.RateParam{3: 500}
+			},
+			errors: []string{"account ip:5.6.7.8 has a reservation on quorum 3, which is not registered"},
+		},
+		{
+			name: "reservations file without refresh interval",
+			modify: func(c *apiserver.RateConfig) {
+				c.ReservationsFile = "reservations.json"
+				c.ReservationsRefreshInterval = 0
+			},
+			errors: []string{"the reservations refresh interval must be positive with a reservations file, but found 0s"},
+		},
+		{
+			name:   "empty trusted API key",
+			modify: func(c *apiserver.RateConfig) { c.TrustedAPIKeys = []string{"key", ""} },
+			errors: []string{"the trusted API keys must not be empty"},
+		},
+		{
+			name:   "negative client IP proxy depth",
+			modify: func(c *apiserver.RateConfig) { c.ClientIPProxyDepth = -1 },
+			errors: []string{"the client IP proxy depth must not be negative (0 to trust all the entries), but found -1"},
+		},
+		{
+			name: "ip rates",
+			modify: func(c *apiserver.RateConfig) {
+				c.IPRequestRate = 10
+				c.IPConnectionRate = -1
+			},
+			errors: []string{
+				"the request burst per IP must be positive with a request rate per IP, but found 0",
+				"the connection rate per IP must not be negative (0 for no limit), but found -1",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := valid()
+			tt.modify(&config)
+			err := config.Validate()
+			if assert.Error(t, err) {
+				assert.Equal(t, strings.Join(tt.errors, "\n"), err.Error())
+			}
+		})
+	}
+}
@@ -1,30 +1,108 @@
 package apiserver
 
 import (
+	"bytes"
 	"context"
+	"crypto/subtle"
 	"errors"
 	"fmt"
+	"math/big"
+	"mime"
 	"net"
+	"sort"
+	"strconv"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	pb "github.com/Layr-Labs/eigenda/api/grpc/disperser"
+	"github.com/Layr-Labs/eigenda/clients"
 	"github.com/Layr-Labs/eigenda/common"
+	commongrpc "github.com/Layr-Labs/eigenda/common/grpc"
 	healthcheck "github.com/Layr-Labs/eigenda/common/healthcheck"
 	"github.com/Layr-Labs/eigenda/core"
 	"github.com/Layr-Labs/eigenda/disperser"
+	"github.com/Layr-Labs/eigenda/disperser/batcher"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	proxyproto "github.com/pires/go-proxyproto"
 	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
 )
 
 var errSystemRateLimit = fmt.Errorf("request ratelimited: system limit")
 var errAccountRateLimit = fmt.Errorf("request ratelimited: account limit")
 
+// rateLimitError wraps a rate limit rejection with the estimated time until the exhausted bucket can admit the request
+type rateLimitError struct {
+	err        error
+	retryAfter time.Duration
+}
+
+func (e *rateLimitError) Error() string {
+	return e.err.Error()
+}
+
+func (e *rateLimitError) Unwrap() error {
+	return e.err
+}
+
+// GRPCStatus converts the rejection into a ResourceExhausted status carrying the retry hint, so that clients can back off
+// for the right amount of time instead of retrying in a tight loop, and the rate limit which rejected the request.
+func (e *rateLimitError) GRPCStatus() *status.Status {
+	reason := disperser.ReasonAccountRateLimit
+	if errors.Is(e.err, errSystemRateLimit) {
+		reason = disperser.ReasonSystemRateLimit
+	} else if errors.Is(e.err, errDailyQuotaExceeded) {
+		reason = disperser.ReasonDailyQuotaExceeded
+	}
+	st := status.New(codes.ResourceExhausted, e.err.Error())
+	withDetails, err := st.WithDetails(newErrorInfo(reason), &errdetails.RetryInfo{
+		RetryDelay: durationpb.New(e.retryAfter),
+	})
+	if err != nil {
+		return st
+	}
+	return withDetails
+}
+
 const systemAccountKey = "system"
+const reservedAccountKey = "reserved"
 
 const maxBlobSize = 1024 * 512 // 512 KiB
 
+const maxNamespaceLength = 64
+
+// maxMetadataLabels and maxMetadataSize bound the number of labels of a dispersal, and the total size of their keys and
+// values
+const maxMetadataLabels = 16
+const maxMetadataSize = 1024
+
+// maxContentTypeLength bounds the length of the content type of a dispersal
+const maxContentTypeLength = 255
+
+// maxRetrievalAuthSkew bounds the difference between the time of a signed retrieval of a private blob and the time of
+// the disperser, which limits how long a signature can be replayed
+const maxRetrievalAuthSkew = 5 * time.Minute
+
+// maxOperatorStatePageSize bounds the number of operator stakes in a reply of GetOperatorStateAtBatch, to keep the
+// replies well under the gRPC message size limit for large operator sets
+const maxOperatorStatePageSize = 1000
+
+// operatorCountTTL is how long the numbers of operators of the quorums are cached to estimate the encoded lengths of
+// the blobs at dispersal
+const operatorCountTTL = time.Minute
+
+type operatorCount struct {
+	count     uint32
+	fetchedAt time.Time
+}
+
 type DispersalServer struct {
 	pb.UnimplementedDisperserServer
 	mu *sync.Mutex
@@ -35,14 +113,54 @@ type DispersalServer struct {
 	tx          core.Transactor
 	quorumCount uint16
 
-	rateConfig  RateConfig
-	ratelimiter common.RateLimiter
+	assignmentCoordinator core.AssignmentCoordinator
+	operatorCounts        map[core.QuorumID]operatorCount
+	operatorCountsMu      sync.Mutex
+
+	rateConfig   RateConfig
+	ratelimiter  common.RateLimiter
+	reservations Reservations
+	// quotaStore tracks the usage of the daily quotas, which aren't enforced if nil
+	quotaStore QuotaStore
+
+	securityPolicy disperser.SecurityPolicy
 
 	metrics *disperser.Metrics
 
+	// retrievalClient reconstructs the blobs from the operators when their content is no longer stored. Retrievals
+	// don't fall back to it if nil.
+	retrievalClient clients.RetrievalClient
+	// encoder computes the commitments of the blobs of the dry runs, which return no commitment if nil, and of the
+	// verified retrievals, which fail if nil
+	encoder core.Encoder
+	// auditor audits the dispersal requests, which aren't audited if nil
+	auditor *RequestAuditor
+	// draining is set once the server stops accepting new blobs, ahead of the maintenance of the disperser
+	draining atomic.Bool
+	// warmingUp is set while the encoder warms up
+	warmingUp atomic.Bool
+	// grpcServer is the server serving the requests once started
+	grpcServer *grpc.Server
+	// dispersalAdmission bounds the number of DisperseBlob requests processed at once, which aren't bounded if nil
+	dispersalAdmission *admissionController
+	// requestLimiter and connectionLimiter limit the rates of the requests and connections of each source IP, which
+	// aren't limited if nil
+	requestLimiter    *ipRateLimiter
+	connectionLimiter *ipRateLimiter
+
 	logger common.Logger
 }
 
+// Blob sources set in the BlobSourceTrailer of the RetrieveBlob replies
+const (
+	BlobSourceTrailer = "blob-source"
+
+	// BlobSourceCache is the source of the blobs read from the content stored by the disperser
+	BlobSourceCache = "cache"
+	// BlobSourceReconstructed is the source of the blobs reconstructed from the chunks of the operators
+	BlobSourceReconstructed = "reconstructed"
+)
+
 // NewServer creates a new Server struct with the provided parameters.
 //
 // Note: The Server's chunks store will be created at config.DbPath+"/chunk".
@@ -56,144 +174,511 @@ func NewDispersalServer(
 	rateConfig RateConfig,
 ) *DispersalServer {
 	return &DispersalServer{
-		config:      config,
-		blobStore:   store,
-		tx:          tx,
-		quorumCount: 0,
-		metrics:     metrics,
-		logger:      logger,
-		ratelimiter: ratelimiter,
-		rateConfig:  rateConfig,
-		mu:          &sync.Mutex{},
+		config:       config,
+		blobStore:    store,
+		tx:           tx,
+		quorumCount:  0,
+		metrics:      metrics,
+		logger:       logger,
+		ratelimiter:  ratelimiter,
+		rateConfig:   rateConfig,
+		reservations: rateConfig.Reservations,
+		mu:           &sync.Mutex{},
+
+		securityPolicy: config.SecurityPolicy,
+
+		assignmentCoordinator: &core.StdAssignmentCoordinator{
+			MinChunkLength:               config.MinChunkLength,
+			LargeQuorumOperatorThreshold: config.LargeQuorumOperatorThreshold,
+			LargeQuorumMinChunkBytes:     config.LargeQuorumMinChunkBytes,
+		},
+		operatorCounts: make(map[core.QuorumID]operatorCount),
+
+		dispersalAdmission: newAdmissionController("DisperseBlob", config.MaxConcurrentDispersals, config.MaxQueuedDispersals, config.DispersalQueueTimeout, metrics),
+		requestLimiter:     newIPRateLimiter(rateConfig.IPRequestRate, rateConfig.IPRequestBurst),
+		connectionLimiter:  newIPRateLimiter(rateConfig.IPConnectionRate, rateConfig.IPConnectionBurst),
+	}
+}
+
+// SetRetrievalClient makes RetrieveBlob reconstruct the confirmed blobs from the operators with the retrieval client
+// when their content is no longer stored, e.g. past its retention.
+func (s *DispersalServer) SetRetrievalClient(retrievalClient clients.RetrievalClient) {
+	s.retrievalClient = retrievalClient
+}
+
+// SetEncoder makes the dry runs of DisperseBlob compute the commitments of their blobs with the encoder, and enables
+// the verified retrievals of RetrieveBlob. If the config warms up the encoder, the warm-up starts in the background,
+// and the health check reports NOT_SERVING until it is done.
+func (s *DispersalServer) SetEncoder(encoder core.Encoder) {
+	s.encoder = encoder
+	if s.config.WarmUpEncoder {
+		s.warmingUp.Store(true)
+		go func() {
+			defer s.warmingUp.Store(false)
+			s.warmUpEncoder()
+		}()
 	}
 }
 
+// warmUpEncoder computes the commitments of a blob of each power of two length up to the one of the max blob size,
+// which loads the SRS and creates the encoders of all the blob lengths the requests can have. The warm-up stops at the
+// first failure, since the longer blobs would fail as well, leaving the encoders of the longer blobs to be created by
+// the requests.
+func (s *DispersalServer) warmUpEncoder() {
+	start := time.Now()
+	// The blobs are encoded in a single chunk of their length, rounded up to a power of two
+	params, err := core.GetEncodingParams(core.GetBlobLength(maxBlobSize), 1)
+	if err != nil {
+		s.logger.Error("failed to warm up the encoder", "err", err)
+		return
+	}
+	maxLength := params.ChunkLength
+	for length := uint(1); length <= maxLength; length *= 2 {
+		data := make([]byte, core.GetBlobSize(length))
+		if _, err := s.computeCommitments(data, core.FreeFormLayout); err != nil {
+			s.logger.Error("failed to warm up the encoder", "blobLength", length, "err", err)
+			return
+		}
+	}
+	s.logger.Info("warmed up the encoder", "maxBlobLength", maxLength, "duration", time.Since(start))
+}
+
+// isReady returns whether the server is ready to serve the requests, i.e. once the encoder is warmed up
+func (s *DispersalServer) isReady() bool {
+	return !s.warmingUp.Load()
+}
+
+// SetQuotaStore makes DisperseBlob enforce the daily quotas of the rate config, tracking their usage in the store. The
+// quotas are enforced along with the throughput limits, so they aren't enforced without a rate limiter.
+func (s *DispersalServer) SetQuotaStore(quotaStore QuotaStore) {
+	s.quotaStore = quotaStore
+}
+
+// SetRequestAuditor makes DisperseBlob audit the requests with the auditor
+func (s *DispersalServer) SetRequestAuditor(auditor *RequestAuditor) {
+	s.auditor = auditor
+}
+
+// Drain makes the server reject the new blobs, while the blobs already dispersed are batched, ahead of the maintenance
+// of the disperser. The other requests, including the dry runs, are still served.
+func (s *DispersalServer) Drain() {
+	if !s.draining.Swap(true) {
+		s.logger.Info("draining: the new blobs are rejected")
+	}
+}
+
+// checkNotDraining returns an Unavailable error if the server is draining
+func (s *DispersalServer) checkNotDraining(method string) error {
+	if !s.draining.Load() {
+		return nil
+	}
+	s.metrics.IncrementFailedBlobRequestNum("", "", method)
+	return status.Error(codes.Unavailable, "the disperser is draining for maintenance and doesn't accept new blobs")
+}
+
 func (s *DispersalServer) DisperseBlob(ctx context.Context, req *pb.DisperseBlobRequest) (*pb.DisperseBlobReply, error) {
+	if s.auditor == nil {
+		return s.disperseBlob(ctx, req)
+	}
+
+	// The origin of a request whose client address can't be determined is left empty, since the request is rejected
+	origin, _ := s.clientAddress(ctx)
+	event := newAuditEvent(req, origin, time.Now())
+	reply, err := s.disperseBlob(ctx, req)
+	s.auditor.Audit(event, reply, err)
+	return reply, err
+}
+
+func (s *DispersalServer) disperseBlob(ctx context.Context, req *pb.DisperseBlobRequest) (*pb.DisperseBlobReply, error) {
 	timer := prometheus.NewTimer(prometheus.ObserverFunc(func(f float64) {
 		s.metrics.ObserveLatency("DisperseBlob", f*1000) // make milliseconds
 	}))
 	defer timer.ObserveDuration()
 
-	securityParams := req.GetSecurityParams()
-	if len(securityParams) == 0 {
-		return nil, fmt.Errorf("invalid request: security_params must not be empty")
+	// Bound the request, so that a slow blob store aborts it instead of completing writes after the client gave up. The
+	// client's deadline applies if it is earlier.
+	if s.config.DisperseRequestTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.config.DisperseRequestTimeout)
+		defer cancel()
 	}
-	if len(securityParams) > 256 {
-		return nil, fmt.Errorf("invalid request: security_params must not exceed 256")
+
+	// The request waits for a slot within its deadline, and is shed if the server is overloaded
+	release, err := s.dispersalAdmission.admit(ctx)
+	if err != nil {
+		return nil, err
 	}
+	defer release()
 
-	seenQuorums := make(map[uint32]struct{})
-	// The quorum ID must be in range [0, 255]. It'll actually be converted
-	// to uint8, so it cannot be greater than 255.
-	for _, param := range securityParams {
-		if _, ok := seenQuorums[param.QuorumId]; ok {
-			return nil, fmt.Errorf("invalid request: security_params must not contain duplicate quorum_id")
+	if !req.GetDryRun() {
+		if err := s.checkNotDraining("DisperseBlob"); err != nil {
+			return nil, err
 		}
-		seenQuorums[param.QuorumId] = struct{}{}
-
-		if param.GetQuorumId() >= uint32(s.quorumCount) {
-			err := s.updateQuorumCount(ctx)
-			if err != nil {
-				return nil, fmt.Errorf("failed to get onchain quorum count: %w", err)
-			}
+	}
 
-			if param.GetQuorumId() >= uint32(s.quorumCount) {
-				return nil, fmt.Errorf("invalid request: the quorum_id must be in range [0, %d], but found %d", s.quorumCount-1, param.GetQuorumId())
-			}
-		}
+	securityParams := req.GetSecurityParams()
+	if err := s.validateRequestedQuorums(ctx, securityParams); err != nil {
+		return nil, err
 	}
 
 	blobSize := len(req.GetData())
 	// The blob size in bytes must be in range [1, maxBlobSize].
 	if blobSize > maxBlobSize {
-		return nil, fmt.Errorf("blob size cannot exceed 512 KiB")
+		return nil, newInvalidArgumentError(disperser.ReasonBlobTooLarge, "data", "blob size cannot exceed 512 KiB")
 	}
 	if blobSize == 0 {
-		return nil, fmt.Errorf("blob size must be greater than 0")
+		return nil, newInvalidArgumentError(disperser.ReasonEmptyBlob, "data", "blob size must be greater than 0")
+	}
+	if err := validateBlobLayout(req.GetLayout(), req.GetData()); err != nil {
+		return nil, err
+	}
+
+	namespace := req.GetNamespace()
+	if err := validateNamespace(namespace); err != nil {
+		return nil, err
+	}
+	if err := validateMetadata(req.GetMetadata()); err != nil {
+		return nil, err
+	}
+	if err := validateContentType(req.GetContentType()); err != nil {
+		return nil, err
+	}
+	submitterPublicKey, err := getSubmitterPublicKey(req)
+	if err != nil {
+		return nil, err
 	}
 
 	blob := getBlobFromRequest(req)
+	blob.RequestHeader.SubmitterPublicKey = submitterPublicKey
 
-	origin, err := common.GetClientAddress(ctx, s.rateConfig.ClientIPHeader, 2, true)
+	origin, err := s.clientAddress(ctx)
 	if err != nil {
 		for _, param := range securityParams {
 			quorumId := string(uint8(param.GetQuorumId()))
-			s.metrics.HandleFailedRequest(quorumId, blobSize, "DisperseBlob")
+			s.metrics.HandleFailedRequest(quorumId, namespace, blobSize, "DisperseBlob")
 		}
 		return nil, err
 	}
 
-	s.logger.Debug("received a new blob request", "origin", origin, "securityParams", securityParams)
+	s.logger.Debug("received a new blob request", "origin", origin, "securityParams", blob.RequestHeader.SecurityParams, "namespace", namespace)
 
-	if err := blob.RequestHeader.Validate(); err != nil {
+	if err := validateSecurityParams(blob.RequestHeader.SecurityParams); err != nil {
 		s.logger.Warn("invalid header", "err", err)
 		for _, param := range securityParams {
 			quorumId := string(uint8(param.GetQuorumId()))
-			s.metrics.HandleFailedRequest(quorumId, blobSize, "DisperseBlob")
+			s.metrics.HandleFailedRequest(quorumId, namespace, blobSize, "DisperseBlob")
 		}
 		return nil, err
 	}
 
-	if s.ratelimiter != nil {
-		err := s.checkRateLimitsAndAddRates(ctx, blob, origin)
+	if err := s.validateSecurityPolicy(blob.RequestHeader.SecurityParams); err != nil {
+		s.logger.Debug("security params violate the security policy", "err", err)
+		for _, param := range securityParams {
+			quorumId := string(uint8(param.GetQuorumId()))
+			s.metrics.HandleFailedRequest(quorumId, namespace, blobSize, "DisperseBlob")
+		}
+		return nil, err
+	}
+
+	// The account is set whether or not the caller is rate limited, since the batcher attributes the blob to it
+	blob.RequestHeader.AccountID = accountIDOf(origin)
+	if s.ratelimiter != nil && !s.isTrustedCaller(ctx, origin, "DisperseBlob") {
+		err := s.checkRateLimitsAndAddRates(ctx, &blob.RequestHeader, len(blob.Data), origin, req.GetDryRun())
 		if err != nil {
 			for _, param := range securityParams {
 				quorumId := string(uint8(param.GetQuorumId()))
 				if errors.Is(err, errSystemRateLimit) {
-					s.metrics.HandleSystemRateLimitedRequest(quorumId, blobSize, "DisperseBlob")
+					s.metrics.HandleSystemRateLimitedRequest(quorumId, namespace, blobSize, "DisperseBlob")
 				} else if errors.Is(err, errAccountRateLimit) {
-					s.metrics.HandleAccountRateLimitedRequest(quorumId, blobSize, "DisperseBlob")
+					s.metrics.HandleAccountRateLimitedRequest(quorumId, namespace, blobSize, "DisperseBlob")
+				} else if errors.Is(err, errDailyQuotaExceeded) {
+					s.metrics.HandleQuotaExceededRequest(quorumId, namespace, blobSize, "DisperseBlob")
 				} else {
-					s.metrics.HandleFailedRequest(quorumId, blobSize, "DisperseBlob")
+					s.metrics.HandleFailedRequest(quorumId, namespace, blobSize, "DisperseBlob")
 				}
 			}
 			return nil, err
 		}
 	}
 
+	if req.GetDryRun() {
+		return s.disperseBlobDryRun(ctx, blob)
+	}
+
 	requestedAt := uint64(time.Now().UnixNano())
 	metadataKey, err := s.blobStore.StoreBlob(ctx, blob, requestedAt)
 	if err != nil {
 		for _, param := range securityParams {
 			quorumId := string(uint8(param.GetQuorumId()))
-			s.metrics.HandleFailedRequest(quorumId, blobSize, "DisperseBlob")
+			s.metrics.HandleFailedRequest(quorumId, namespace, blobSize, "DisperseBlob")
+		}
+		// Report a request abandoned by the client as such rather than as a server failure
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, status.FromContextError(ctxErr).Err()
 		}
 		return nil, err
 	}
 
 	for _, param := range securityParams {
 		quorumId := string(uint8(param.GetQuorumId()))
-		s.metrics.HandleSuccessfulRequest(quorumId, blobSize, "DisperseBlob")
+		s.metrics.HandleSuccessfulRequest(quorumId, namespace, blobSize, "DisperseBlob")
 	}
 
 	s.logger.Info("received a new blob: ", "key", metadataKey.String())
+
+	// The estimate is informative, so the blob, which is already stored, is accepted without it if it fails
+	blobQuorumParams, err := s.estimateBlobQuorumParams(ctx, blob)
+	if err != nil {
+		s.logger.Warn("failed to estimate the encoded length of the blob", "key", metadataKey.String(), "err", err)
+		blobQuorumParams = nil
+	}
+
 	return &pb.DisperseBlobReply{
-		Result:    pb.BlobStatus_PROCESSING,
-		RequestId: []byte(metadataKey.String()),
+		Result:           pb.BlobStatus_PROCESSING,
+		RequestId:        metadataKey.RequestID(),
+		BlobQuorumParams: blobQuorumParams,
+	}, nil
+}
+
+// validateRequestedQuorums checks that the security params of a request name between 1 and the max number of quorums
+// per blob distinct quorums, which exist onchain
+func (s *DispersalServer) validateRequestedQuorums(ctx context.Context, securityParams []*pb.SecurityParams) error {
+	if len(securityParams) == 0 {
+		return newInvalidArgumentError(disperser.ReasonInvalidSecurityParams, "security_params", "invalid request: security_params must not be empty")
+	}
+	if len(securityParams) > 256 {
+		return newInvalidArgumentError(disperser.ReasonInvalidSecurityParams, "security_params", "invalid request: security_params must not exceed 256")
+	}
+	if err := s.validateNumQuorums(ctx, len(securityParams)); err != nil {
+		return err
+	}
+
+	seenQuorums := make(map[uint32]struct{})
+	// The quorum ID must be in range [0, 255]. It'll actually be converted
+	// to uint8, so it cannot be greater than 255.
+	for i, param := range securityParams {
+		if _, ok := seenQuorums[param.QuorumId]; ok {
+			return newInvalidArgumentError(disperser.ReasonInvalidSecurityParams, fmt.Sprintf("security_params[%d].quorum_id", i), "invalid request: security_params must not contain duplicate quorum_id")
+		}
+		seenQuorums[param.QuorumId] = struct{}{}
+
+		if param.GetQuorumId() >= uint32(s.getQuorumCount()) {
+			err := s.updateQuorumCount(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to get onchain quorum count: %w", err)
+			}
+
+			if quorumCount := s.getQuorumCount(); param.GetQuorumId() >= uint32(quorumCount) {
+				msg := fmt.Sprintf("invalid request: the quorum_id must be in range [0, %d], but found %d", quorumCount-1, param.GetQuorumId())
+				return newInvalidArgumentError(disperser.ReasonInvalidQuorum, fmt.Sprintf("security_params[%d].quorum_id", i), msg)
+			}
+		}
+	}
+	return nil
+}
+
+// disperseBlobDryRun replies to a dry run of a validated and rate limited blob with its commitment and the params of
+// its quorums, without storing it
+func (s *DispersalServer) disperseBlobDryRun(ctx context.Context, blob *core.Blob) (*pb.DisperseBlobReply, error) {
+	for _, param := range blob.RequestHeader.SecurityParams {
+		s.metrics.HandleSuccessfulRequest(string(param.QuorumID), blob.RequestHeader.Namespace, len(blob.Data), "DisperseBlobDryRun")
+	}
+
+	blobQuorumParams, err := s.estimateBlobQuorumParams(ctx, blob)
+	if err != nil {
+		s.logger.Warn("failed to estimate the encoded length of the blob of a dry run", "err", err)
+		blobQuorumParams = nil
+	}
+
+	blobLength := blob.RequestHeader.Layout.BlobLength(uint(len(blob.Data)))
+	var commitment []byte
+	if s.encoder != nil {
+		commitments, err := s.computeCommitments(blob.Data, blob.RequestHeader.Layout)
+		if err != nil {
+			return nil, err
+		}
+		commitment, err = commitments.Commitment.Serialize()
+		if err != nil {
+			return nil, fmt.Errorf("failed to serialize the commitment of the blob: %w", err)
+		}
+	}
+
+	return &pb.DisperseBlobReply{
+		Result:           pb.BlobStatus_UNKNOWN,
+		BlobQuorumParams: blobQuorumParams,
+		Commitment:       commitment,
+		DataLength:       uint32(blobLength),
 	}, nil
 }
 
-func (s *DispersalServer) checkRateLimitsAndAddRates(ctx context.Context, blob *core.Blob, origin string) error {
+// computeCommitments returns the commitments of the blob data in the layout, which are the ones the batcher computes
+func (s *DispersalServer) computeCommitments(data []byte, layout core.BlobLayout) (core.BlobCommitments, error) {
+	// The commitment doesn't depend on the encoding params, so the blob is encoded in a single chunk, which proves the
+	// fewest chunks
+	params, err := core.GetEncodingParams(layout.BlobLength(uint(len(data))), 1)
+	if err != nil {
+		return core.BlobCommitments{}, err
+	}
+	params.Layout = layout
+	commitments, _, err := s.encoder.Encode(data, params)
+	if err != nil {
+		return core.BlobCommitments{}, fmt.Errorf("failed to compute the commitment of the blob: %w", err)
+	}
+	return commitments, nil
+}
+
+// estimateBlobQuorumParams returns the parameters of the blob in each of its quorums, with the length of its encoding
+// computed like the batcher does, from the current number of operators of the quorum and without over-provisioning
+func (s *DispersalServer) estimateBlobQuorumParams(ctx context.Context, blob *core.Blob) ([]*pb.BlobQuorumParam, error) {
+	blobLength := blob.RequestHeader.Layout.BlobLength(uint(len(blob.Data)))
+	params := make([]*pb.BlobQuorumParam, len(blob.RequestHeader.SecurityParams))
+	for i, param := range blob.RequestHeader.SecurityParams {
+		numOperators, err := s.getNumOperators(ctx, param.QuorumID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the number of operators of quorum %d: %w", param.QuorumID, err)
+		}
+		if numOperators == 0 {
+			return nil, fmt.Errorf("quorum %d has no operators", param.QuorumID)
+		}
+
+		chunkLength, err := s.assignmentCoordinator.GetMinimumChunkLength(uint(numOperators), blobLength, batcher.QuantizationFactor, param.QuorumThreshold, param.AdversaryThreshold)
+		if err != nil {
+			return nil, err
+		}
+		encodingParams, err := core.GetEncodingParams(chunkLength, uint(numOperators)*batcher.QuantizationFactor)
+		if err != nil {
+			return nil, err
+		}
+
+		params[i] = &pb.BlobQuorumParam{
+			QuorumNumber:                 uint32(param.QuorumID),
+			AdversaryThresholdPercentage: uint32(param.AdversaryThreshold),
+			QuorumThresholdPercentage:    uint32(param.QuorumThreshold),
+			QuantizationParam:            uint32(batcher.QuantizationFactor),
+			EncodedLength:                uint64(encodingParams.ChunkLength * batcher.QuantizationFactor * uint(numOperators)),
+		}
+	}
+	return params, nil
+}
+
+// getNumOperators returns the number of operators registered in the quorum, read from the chain at most once per
+// operatorCountTTL
+func (s *DispersalServer) getNumOperators(ctx context.Context, quorumID core.QuorumID) (uint32, error) {
+	s.operatorCountsMu.Lock()
+	cached, ok := s.operatorCounts[quorumID]
+	s.operatorCountsMu.Unlock()
+	if ok && time.Since(cached.fetchedAt) < operatorCountTTL {
+		return cached.count, nil
+	}
+
+	count, err := s.tx.GetNumberOfRegisteredOperatorForQuorum(ctx, quorumID)
+	if err != nil {
+		return 0, err
+	}
+
+	s.operatorCountsMu.Lock()
+	s.operatorCounts[quorumID] = operatorCount{count: count, fetchedAt: time.Now()}
+	s.operatorCountsMu.Unlock()
+	return count, nil
+}
+
+// isTrustedCaller returns whether the caller is trusted, and thus not rate limited, because it presented a trusted API
+// key or its address is in a trusted CIDR. The requests of trusted callers are logged and metered on their own.
+func (s *DispersalServer) isTrustedCaller(ctx context.Context, origin string, method string) bool {
+	reason := s.trustReason(ctx, origin)
+	if reason == "" {
+		return false
+	}
+
+	s.logger.Info("bypassing rate limits for trusted caller", "origin", origin, "reason", reason, "method", method)
+	s.metrics.IncrementTrustedRequestNum(reason, method)
+	return true
+}
+
+// trustReason returns why the caller is trusted: "api_key" if it presented a trusted API key, "cidr" if its address is
+// in a trusted CIDR, or "" if it isn't trusted
+func (s *DispersalServer) trustReason(ctx context.Context, origin string) string {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		for _, key := range md.Get(TrustedAPIKeyHeader) {
+			for _, trustedKey := range s.rateConfig.TrustedAPIKeys {
+				if trustedKey != "" && subtle.ConstantTimeCompare([]byte(key), []byte(trustedKey)) == 1 {
+					return "api_key"
+				}
+			}
+		}
+	}
+	if ip := net.ParseIP(origin); ip != nil {
+		for _, cidr := range s.rateConfig.TrustedCIDRs {
+			if cidr.Contains(ip) {
+				return "cidr"
+			}
+		}
+	}
+	return ""
+}
+
+// accountIDOf returns the account of the requests from the origin
+func accountIDOf(origin string) core.AccountID {
+	return "ip:" + origin
+}
+
+// checkRateLimitsAndAddRates checks the request against the daily quota and the throughput limits of its account, and
+// charges it to them. A dry run is charged to the throughput limits, but not to the daily quota, since its blob isn't
+// dispersed.
+func (s *DispersalServer) checkRateLimitsAndAddRates(ctx context.Context, requestHeader *core.BlobRequestHeader, blobSize int, origin string, dryRun bool) error {
 
 	// TODO(robert): Remove these locks once we have resolved ratelimiting approach
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	for _, param := range blob.RequestHeader.SecurityParams {
+	reservation := s.reservations[requestHeader.AccountID]
+
+	// The daily quota is charged by the size of the blob, regardless of its quorums
+	now := time.Now()
+	quota, usage, err := s.getQuotaUsage(ctx, requestHeader.AccountID)
+	if err != nil {
+		return err
+	}
+	if quota > 0 {
+		if used := usage.Used(now); used+uint64(blobSize) > quota {
+			s.logger.Warn("daily quota exceeded", "accountID", requestHeader.AccountID, "quota", quota, "used", used, "blobSize", blobSize)
+			return &rateLimitError{
+				err:        errDailyQuotaExceeded,
+				retryAfter: usage.RetryAfter(now, used+uint64(blobSize)-quota),
+			}
+		}
+	}
+
+	for _, param := range requestHeader.SecurityParams {
 
 		rates, ok := s.rateConfig.QuorumRateInfos[param.QuorumID]
 		if !ok {
 			return fmt.Errorf("no configured rate exists for quorum %d", param.QuorumID)
 		}
 
-		// Get the encoded blob size from the blob header. Calculation is done in a way that nodes can replicate
-		blobSize := len(blob.Data)
+		// Get the encoded blob size from the blob header. Calculation is done in a way that nodes can replicate. The
+		// blob is charged by its decompressed size, however the client compressed the request.
 		length := core.GetBlobLength(uint(blobSize))
-		encodedLength := core.GetEncodedBlobLength(length, uint8(blob.RequestHeader.SecurityParams[param.QuorumID].QuorumThreshold), uint8(blob.RequestHeader.SecurityParams[param.QuorumID].AdversaryThreshold))
+		encodedLength := core.GetEncodedBlobLength(length, uint8(param.QuorumThreshold), uint8(param.AdversaryThreshold))
 		encodedSize := core.GetBlobSize(encodedLength)
 
 		s.logger.Debug("checking rate limits", "origin", origin, "quorum", param.QuorumID, "encodedSize", encodedSize, "blobSize", blobSize)
 
+		// Charge the reservation first, if the account has one for this quorum. Once the reservation is exhausted,
+		// the request overflows into the shared pool below.
+		if reservedRate, ok := reservation[param.QuorumID]; ok && reservedRate > 0 {
+			reservedQuorumKey := fmt.Sprintf("%s:%s:%d", reservedAccountKey, requestHeader.AccountID, param.QuorumID)
+			allowed, err := s.ratelimiter.AllowRequest(ctx, reservedQuorumKey, encodedSize, reservedRate)
+			if err != nil {
+				return fmt.Errorf("ratelimiter error: %v", err)
+			}
+			if allowed {
+				param.QuorumRate = reservedRate
+				continue
+			}
+			s.logger.Debug("reservation exhausted, falling back to shared pool", "reservedQuorumKey", reservedQuorumKey, "rate", reservedRate)
+		}
+
 		// Check System Ratelimit
 		systemQuorumKey := fmt.Sprintf("%s:%d", systemAccountKey, param.QuorumID)
 		allowed, err := s.ratelimiter.AllowRequest(ctx, systemQuorumKey, encodedSize, rates.TotalUnauthThroughput)
@@ -202,91 +687,283 @@ func (s *DispersalServer) checkRateLimitsAndAddRates(ctx context.Context, blob *
 		}
 		if !allowed {
 			s.logger.Warn("system ratelimit exceeded", "systemQuorumKey", systemQuorumKey, "rate", rates.TotalUnauthThroughput)
-			return errSystemRateLimit
+			return s.newRateLimitError(ctx, errSystemRateLimit, systemQuorumKey, encodedSize, rates.TotalUnauthThroughput)
 		}
 
-		blob.RequestHeader.AccountID = "ip:" + origin
-
-		userQuorumKey := fmt.Sprintf("%s:%d", blob.RequestHeader.AccountID, param.QuorumID)
+		userQuorumKey := fmt.Sprintf("%s:%d", requestHeader.AccountID, param.QuorumID)
 		allowed, err = s.ratelimiter.AllowRequest(ctx, userQuorumKey, encodedSize, rates.PerUserUnauthThroughput)
 		if err != nil {
 			return fmt.Errorf("ratelimiter error: %v", err)
 		}
 		if !allowed {
 			s.logger.Warn("account ratelimit exceeded", "userQuorumKey", userQuorumKey, "rate", rates.PerUserUnauthThroughput)
-			return errAccountRateLimit
+			return s.newRateLimitError(ctx, errAccountRateLimit, userQuorumKey, encodedSize, rates.PerUserUnauthThroughput)
 		}
 
 		// Update the quorum rate
-		blob.RequestHeader.SecurityParams[param.QuorumID].QuorumRate = rates.PerUserUnauthThroughput
+		param.QuorumRate = rates.PerUserUnauthThroughput
+	}
+
+	if quota > 0 && !dryRun {
+		usage.Add(now, uint64(blobSize))
+		quotaKey := fmt.Sprintf("%s:%s", quotaAccountKey, requestHeader.AccountID)
+		if err := s.quotaStore.UpdateItem(ctx, quotaKey, usage); err != nil {
+			return fmt.Errorf("failed to update the daily quota usage: %w", err)
+		}
 	}
 	return nil
 
 }
 
-func (s *DispersalServer) GetBlobStatus(ctx context.Context, req *pb.BlobStatusRequest) (*pb.BlobStatusReply, error) {
-	timer := prometheus.NewTimer(prometheus.ObserverFunc(func(f float64) {
-		s.metrics.ObserveLatency("GetBlobStatus", f*1000) // make milliseconds
-	}))
-	defer timer.ObserveDuration()
-
-	requestID := req.GetRequestId()
-	if len(requestID) == 0 {
-		return nil, fmt.Errorf("invalid request: request_id must not be empty")
+// getQuotaUsage returns the daily quota of the account and its usage. The quota is 0 if the account has no daily quota,
+// or if the quotas aren't enforced. It fails if the usage can't be read, rather than taking it as empty, since the
+// usage charged to the request would then overwrite the usage of the account.
+func (s *DispersalServer) getQuotaUsage(ctx context.Context, accountID core.AccountID) (uint64, *QuotaUsage, error) {
+	quota := s.rateConfig.dailyQuota(accountID)
+	if s.ratelimiter == nil || s.quotaStore == nil || quota == 0 {
+		return 0, &QuotaUsage{}, nil
 	}
-
-	s.logger.Info("received a new blob status request", "requestID", string(requestID))
-	metadataKey, err := disperser.ParseBlobKey(string(requestID))
-	if err != nil {
-		return nil, err
+	// The accounts which haven't dispersed within the capacity of the store have no usage
+	usage, err := s.quotaStore.GetItem(ctx, fmt.Sprintf("%s:%s", quotaAccountKey, accountID))
+	if errors.Is(err, common.ErrKeyNotFound) {
+		return quota, &QuotaUsage{}, nil
 	}
-
-	s.logger.Debug("metadataKey", "metadataKey", metadataKey.String())
-	metadata, err := s.blobStore.GetBlobMetadata(ctx, metadataKey)
 	if err != nil {
-		return nil, err
+		return 0, nil, fmt.Errorf("failed to get the daily quota usage: %w", err)
 	}
+	return quota, usage, nil
+}
 
-	isConfirmed, err := metadata.IsConfirmed()
-	if err != nil {
-		return nil, err
+// newRateLimitError attaches to the rejection the time until the bucket identified by key has capacity for the request
+func (s *DispersalServer) newRateLimitError(ctx context.Context, err error, key string, encodedSize uint, rate common.RateParam) error {
+	retryAfter, retryErr := s.ratelimiter.RetryAfter(ctx, key, encodedSize, rate)
+	if retryErr != nil {
+		s.logger.Warn("failed to estimate retry delay", "key", key, "err", retryErr)
+	}
+	return &rateLimitError{
+		err:        err,
+		retryAfter: retryAfter,
 	}
+}
 
-	s.logger.Debug("isConfirmed", "metadata", metadata, "isConfirmed", isConfirmed)
-	if isConfirmed {
-		confirmationInfo := metadata.ConfirmationInfo
-		commit, err := confirmationInfo.BlobCommitment.Commitment.Serialize()
-		if err != nil {
-			return nil, err
+// UpdateReservations replaces the reservations in effect. Requests already being rate limited are not affected.
+func (s *DispersalServer) UpdateReservations(reservations Reservations) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.reservations = reservations
+}
+
+// refreshReservations periodically reloads the reservations from the configured reservations file
+func (s *DispersalServer) refreshReservations(ctx context.Context) {
+	ticker := time.NewTicker(s.rateConfig.ReservationsRefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			reservations, err := ReadReservations(s.rateConfig.ReservationsFile)
+			if err != nil {
+				s.logger.Error("failed to reload reservations, keeping the current ones", "err", err)
+				continue
+			}
+			s.UpdateReservations(reservations)
+			s.logger.Debug("reloaded reservations", "numAccounts", len(reservations))
 		}
+	}
+}
 
-		dataLength := uint32(confirmationInfo.BlobCommitment.Length)
-		quorumInfos := confirmationInfo.BlobQuorumInfos
-		blobQuorumParams := make([]*pb.BlobQuorumParam, len(quorumInfos))
-		quorumNumbers := make([]byte, len(quorumInfos))
-		quorumPercentSigned := make([]byte, len(quorumInfos))
-		quorumIndexes := make([]byte, len(quorumInfos))
-		for i, quorumInfo := range quorumInfos {
-			blobQuorumParams[i] = &pb.BlobQuorumParam{
-				QuorumNumber:                 uint32(quorumInfo.QuorumID),
-				AdversaryThresholdPercentage: uint32(quorumInfo.AdversaryThreshold),
-				QuorumThresholdPercentage:    uint32(quorumInfo.QuorumThreshold),
-				QuantizationParam:            uint32(quorumInfo.QuantizationFactor),
-				EncodedLength:                uint64(quorumInfo.EncodedBlobLength),
+// UpdateSecurityPolicy replaces the security policy in effect. Requests already validated are not affected.
+func (s *DispersalServer) UpdateSecurityPolicy(policy disperser.SecurityPolicy) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.securityPolicy = policy
+}
+
+// refreshSecurityPolicy periodically reloads the security policy from the configured security policy file
+func (s *DispersalServer) refreshSecurityPolicy(ctx context.Context) {
+	ticker := time.NewTicker(s.config.SecurityPolicyRefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			policy, err := disperser.ReadSecurityPolicy(s.config.SecurityPolicyFile)
+			if err != nil {
+				s.logger.Error("failed to reload security policy, keeping the current one", "err", err)
+				continue
 			}
-			quorumNumbers[i] = quorumInfo.QuorumID
-			quorumPercentSigned[i] = confirmationInfo.QuorumResults[quorumInfo.QuorumID].PercentSigned
-			quorumIndexes[i] = byte(i)
+			s.UpdateSecurityPolicy(policy)
+			s.logger.Debug("reloaded security policy", "numQuorums", len(policy.Quorums))
 		}
+	}
+}
 
-		return &pb.BlobStatusReply{
-			Status: getResponseStatus(metadata.BlobStatus),
+// validateSecurityPolicy checks that the security params are within the bounds of the security policy of their quorum
+func (s *DispersalServer) validateSecurityPolicy(securityParams []*core.SecurityParam) error {
+	s.mu.Lock()
+	policy := s.securityPolicy
+	s.mu.Unlock()
+
+	for i, param := range securityParams {
+		bounds := policy.Bounds(param.QuorumID)
+		var field, msg string
+		switch {
+		case bounds.MinAdversaryThreshold != 0 && param.AdversaryThreshold < bounds.MinAdversaryThreshold:
+			field = "adversary_threshold"
+			msg = fmt.Sprintf("adversary_threshold of quorum %d must be at least %d, but found %d", param.QuorumID, bounds.MinAdversaryThreshold, param.AdversaryThreshold)
+		case bounds.MaxAdversaryThreshold != 0 && param.AdversaryThreshold > bounds.MaxAdversaryThreshold:
+			field = "adversary_threshold"
+			msg = fmt.Sprintf("adversary_threshold of quorum %d must be at most %d, but found %d", param.QuorumID, bounds.MaxAdversaryThreshold, param.AdversaryThreshold)
+		case bounds.MinQuorumThreshold != 0 && param.QuorumThreshold < bounds.MinQuorumThreshold:
+			field = "quorum_threshold"
+			msg = fmt.Sprintf("quorum_threshold of quorum %d must be at least %d, but found %d", param.QuorumID, bounds.MinQuorumThreshold, param.QuorumThreshold)
+		case bounds.MaxQuorumThreshold != 0 && param.QuorumThreshold > bounds.MaxQuorumThreshold:
+			field = "quorum_threshold"
+			msg = fmt.Sprintf("quorum_threshold of quorum %d must be at most %d, but found %d", param.QuorumID, bounds.MaxQuorumThreshold, param.QuorumThreshold)
+		case !bounds.AllowsQuantizationFactor(batcher.QuantizationFactor):
+			field = "quorum_id"
+			msg = fmt.Sprintf("quorum %d only allows the quantization factors %v, but blobs are assigned with %d", param.QuorumID, bounds.AllowedQuantizationFactors, batcher.QuantizationFactor)
+		default:
+			continue
+		}
+		return newInvalidArgumentError(disperser.ReasonSecurityPolicyViolation, fmt.Sprintf("security_params[%d].%s", i, field), "invalid request: "+msg)
+	}
+	return nil
+}
+
+// GetDisperserConfig returns the configuration the dispersal requests must comply with
+func (s *DispersalServer) GetDisperserConfig(ctx context.Context, req *pb.DisperserConfigRequest) (*pb.DisperserConfigReply, error) {
+	s.mu.Lock()
+	policy := s.securityPolicy
+	s.mu.Unlock()
+
+	quorumIDs := make([]core.QuorumID, 0, len(policy.Quorums))
+	for quorumID := range policy.Quorums {
+		quorumIDs = append(quorumIDs, quorumID)
+	}
+	sort.Slice(quorumIDs, func(i, j int) bool { return quorumIDs[i] < quorumIDs[j] })
+
+	quorumBounds := make([]*pb.QuorumSecurityParamBounds, len(quorumIDs))
+	for i, quorumID := range quorumIDs {
+		quorumBounds[i] = &pb.QuorumSecurityParamBounds{
+			QuorumId: uint32(quorumID),
+			Bounds:   getSecurityParamBoundsReply(policy.Quorums[quorumID]),
+		}
+	}
+
+	return &pb.DisperserConfigReply{
+		SecurityPolicy: &pb.SecurityPolicy{
+			DefaultBounds: getSecurityParamBoundsReply(policy.Default),
+			QuorumBounds:  quorumBounds,
+		},
+		QuantizationFactor: uint32(batcher.QuantizationFactor),
+	}, nil
+}
+
+func getSecurityParamBoundsReply(bounds disperser.SecurityParamBounds) *pb.SecurityParamBounds {
+	factors := make([]uint32, len(bounds.AllowedQuantizationFactors))
+	for i, factor := range bounds.AllowedQuantizationFactors {
+		factors[i] = uint32(factor)
+	}
+	return &pb.SecurityParamBounds{
+		MinAdversaryThreshold:      uint32(bounds.MinAdversaryThreshold),
+		MaxAdversaryThreshold:      uint32(bounds.MaxAdversaryThreshold),
+		MinQuorumThreshold:         uint32(bounds.MinQuorumThreshold),
+		MaxQuorumThreshold:         uint32(bounds.MaxQuorumThreshold),
+		AllowedQuantizationFactors: factors,
+	}
+}
+
+// GetRateLimitStatus returns the daily quota of the account of the caller and its usage
+func (s *DispersalServer) GetRateLimitStatus(ctx context.Context, req *pb.RateLimitStatusRequest) (*pb.RateLimitStatusReply, error) {
+	timer := prometheus.NewTimer(prometheus.ObserverFunc(func(f float64) {
+		s.metrics.ObserveLatency("GetRateLimitStatus", f*1000) // make milliseconds
+	}))
+	defer timer.ObserveDuration()
+
+	origin, err := s.clientAddress(ctx)
+	if err != nil {
+		s.metrics.IncrementFailedBlobRequestNum("", "", "GetRateLimitStatus")
+		return nil, err
+	}
+
+	accountID := accountIDOf(origin)
+	quota, usage, err := s.getQuotaUsage(ctx, accountID)
+	if err != nil {
+		s.metrics.IncrementFailedBlobRequestNum("", "", "GetRateLimitStatus")
+		return nil, err
+	}
+	reply := &pb.RateLimitStatusReply{
+		AccountId:  accountID,
+		DailyQuota: quota,
+	}
+	if quota > 0 {
+		reply.DailyQuotaUsed = usage.Used(time.Now())
+		if reply.DailyQuotaUsed < quota {
+			reply.DailyQuotaRemaining = quota - reply.DailyQuotaUsed
+		}
+	}
+	return reply, nil
+}
+
+func (s *DispersalServer) GetBlobStatus(ctx context.Context, req *pb.BlobStatusRequest) (*pb.BlobStatusReply, error) {
+	timer := prometheus.NewTimer(prometheus.ObserverFunc(func(f float64) {
+		s.metrics.ObserveLatency("GetBlobStatus", f*1000) // make milliseconds
+	}))
+	defer timer.ObserveDuration()
+
+	requestID := req.GetRequestId()
+	if len(requestID) == 0 {
+		return nil, newInvalidArgumentError(disperser.ReasonInvalidRequestID, "request_id", "invalid request: request_id must not be empty")
+	}
+
+	s.logger.Info("received a new blob status request", "requestID", string(requestID))
+	metadataKey, err := disperser.ParseBlobKey(string(requestID))
+	if err != nil {
+		return nil, newInvalidArgumentError(disperser.ReasonInvalidRequestID, "request_id", err.Error())
+	}
+
+	s.logger.Debug("metadataKey", "metadataKey", metadataKey.String())
+	metadata, err := s.blobStore.GetBlobMetadata(ctx, metadataKey)
+	if err != nil {
+		return nil, err
+	}
+
+	isConfirmed, err := metadata.IsConfirmed()
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Debug("isConfirmed", "metadata", metadata, "isConfirmed", isConfirmed)
+	if isConfirmed {
+		confirmationInfo := metadata.ConfirmationInfo
+		blobHeader, err := getBlobHeaderProto(confirmationInfo)
+		if err != nil {
+			return nil, err
+		}
+
+		quorumInfos := confirmationInfo.BlobQuorumInfos
+		quorumNumbers := make([]byte, len(quorumInfos))
+		quorumPercentSigned := make([]byte, len(quorumInfos))
+		quorumIndexes := make([]byte, len(quorumInfos))
+		for i, quorumInfo := range quorumInfos {
+			quorumNumbers[i] = quorumInfo.QuorumID
+			quorumPercentSigned[i] = confirmationInfo.QuorumResults[quorumInfo.QuorumID].PercentSigned
+			quorumIndexes[i] = byte(i)
+		}
+
+		return &pb.BlobStatusReply{
+			Status:         getResponseStatus(metadata.BlobStatus),
+			Namespace:      metadata.RequestMetadata.Namespace,
+			Metadata:       metadata.RequestMetadata.Labels,
+			ContentType:    metadata.RequestMetadata.ContentType,
+			QuorumStatuses: getQuorumStatusesProto(confirmationInfo),
 			Info: &pb.BlobInfo{
-				BlobHeader: &pb.BlobHeader{
-					Commitment:       commit,
-					DataLength:       dataLength,
-					BlobQuorumParams: blobQuorumParams,
-				},
+				BlobHeader: blobHeader,
 				BlobVerificationProof: &pb.BlobVerificationProof{
 					BatchId:   confirmationInfo.BatchID,
 					BlobIndex: confirmationInfo.BlobIndex,
@@ -310,10 +987,37 @@ func (s *DispersalServer) GetBlobStatus(ctx context.Context, req *pb.BlobStatusR
 		}, nil
 	}
 
-	return &pb.BlobStatusReply{
-		Status: getResponseStatus(metadata.BlobStatus),
-		Info:   &pb.BlobInfo{},
-	}, nil
+	// A blob which isn't confirmed may still have been signed in some of its quorums
+	var quorumStatuses []*pb.BlobQuorumStatus
+	if metadata.ConfirmationInfo != nil {
+		quorumStatuses = getQuorumStatusesProto(metadata.ConfirmationInfo)
+	}
+	reply := &pb.BlobStatusReply{
+		Status:         getResponseStatus(metadata.BlobStatus),
+		Namespace:      metadata.RequestMetadata.Namespace,
+		Metadata:       metadata.RequestMetadata.Labels,
+		ContentType:    metadata.RequestMetadata.ContentType,
+		Info:           &pb.BlobInfo{},
+		QuorumStatuses: quorumStatuses,
+	}
+	if metadata.BlobStatus == disperser.Processing && s.config.ExpectedConfirmationTime > 0 {
+		processingTime := time.Since(time.Unix(0, int64(metadata.RequestMetadata.RequestedAt)))
+		reply.NextPollDelayMs = uint64(nextPollDelay(s.config.ExpectedConfirmationTime, processingTime).Milliseconds())
+	}
+	return reply, nil
+}
+
+// nextPollDelay returns the delay after which a client should poll the status of a blob which has been processing for
+// processingTime again. The delay is half of the time left until the expected confirmation, so that the polls get more
+// frequent as the confirmation approaches, bounded below by a tenth of the expected confirmation time, at which the
+// clients keep polling the blobs whose confirmation is overdue.
+func nextPollDelay(expectedConfirmationTime time.Duration, processingTime time.Duration) time.Duration {
+	minDelay := expectedConfirmationTime / 10
+	delay := (expectedConfirmationTime - processingTime) / 2
+	if delay < minDelay {
+		return minDelay
+	}
+	return delay
 }
 
 func (s *DispersalServer) RetrieveBlob(ctx context.Context, req *pb.RetrieveBlobRequest) (*pb.RetrieveBlobReply, error) {
@@ -322,7 +1026,11 @@ func (s *DispersalServer) RetrieveBlob(ctx context.Context, req *pb.RetrieveBlob
 	}))
 	defer timer.ObserveDuration()
 
-	s.logger.Info("received a new blob retrieval request", "batchHeaderHash", req.BatchHeaderHash, "blobIndex", req.BlobIndex)
+	s.logger.Info("received a new blob retrieval request", "batchHeaderHash", req.BatchHeaderHash, "blobIndex", req.BlobIndex, "verify", req.GetVerify())
+	if req.GetVerify() && s.encoder == nil {
+		s.metrics.IncrementFailedBlobRequestNum("", "", "RetrieveBlob")
+		return nil, status.Error(codes.FailedPrecondition, "the blobs can't be verified on this disperser")
+	}
 
 	batchHeaderHash := req.GetBatchHeaderHash()
 	// Convert to [32]byte
@@ -334,23 +1042,868 @@ func (s *DispersalServer) RetrieveBlob(ctx context.Context, req *pb.RetrieveBlob
 	blobMetadata, err := s.blobStore.GetMetadataInBatch(ctx, batchHeaderHash32, blobIndex)
 	if err != nil {
 		s.logger.Error("Failed to retrieve blob metadata", "err", err)
-		s.metrics.IncrementFailedBlobRequestNum("", "RetrieveBlob")
+		s.metrics.IncrementFailedBlobRequestNum("", "", "RetrieveBlob")
+
+		return nil, err
+	}
+
+	isConfirmed, err := blobMetadata.IsConfirmed()
+	if err != nil || !isConfirmed {
+		s.metrics.IncrementFailedBlobRequestNum("", "", "RetrieveBlob")
+		if err == nil {
+			err = fmt.Errorf("blob %s is not confirmed", blobMetadata.GetBlobKey().String())
+		}
+		return nil, err
+	}
+
+	if blobMetadata.IsPrivate() {
+		if err := authorizeRetrieval(blobMetadata, batchHeaderHash32, req, time.Now()); err != nil {
+			s.logger.Warn("denied the retrieval of a private blob", "blobKey", blobMetadata.GetBlobKey().String(), "err", err)
+			s.metrics.IncrementFailedBlobRequestNum("", "", "RetrieveBlob")
+			return nil, newPermissionDeniedError(disperser.ReasonRetrievalUnauthorized, err.Error())
+		}
+	}
+
+	data, source, err := s.retrieveBlobData(ctx, blobMetadata, req.GetVerify())
+	if err != nil {
+		s.metrics.HandleFailedRequest("", "", len(data), "RetrieveBlob")
 
 		return nil, err
 	}
 
+	s.metrics.HandleSuccessfulRequest("", "", len(data), "RetrieveBlob")
+	if err := grpc.SetTrailer(ctx, metadata.Pairs(BlobSourceTrailer, source)); err != nil {
+		s.logger.Debug("Failed to set the blob source trailer", "err", err)
+	}
+
+	return &pb.RetrieveBlobReply{
+		Data:        data,
+		ContentType: blobMetadata.RequestMetadata.ContentType,
+	}, nil
+}
+
+// retrieveBlobData returns the data of a confirmed blob exactly as it was dispersed, and the source it was read from.
+// The data is read from the storage of the disperser, or reconstructed from the operators if the disperser no longer
+// stores it. On failure, the data read so far is returned along with the error.
+func (s *DispersalServer) retrieveBlobData(ctx context.Context, blobMetadata *disperser.BlobMetadata, verify bool) ([]byte, string, error) {
+	source := BlobSourceCache
 	data, err := s.blobStore.GetBlobContent(ctx, blobMetadata.BlobHash)
+	if errors.Is(err, disperser.ErrBlobNotFound) && s.retrievalClient != nil {
+		s.logger.Info("Blob content not found, reconstructing it from the operators", "blobKey", blobMetadata.GetBlobKey().String())
+		source = BlobSourceReconstructed
+		data, err = s.reconstructBlob(ctx, blobMetadata)
+	}
 	if err != nil {
 		s.logger.Error("Failed to retrieve blob", "err", err)
-		s.metrics.HandleFailedRequest("", len(data), "RetrieveBlob")
+		return data, source, err
+	}
+
+	// Return exactly the bytes that were dispersed, whatever the padding of the content, and catch a truncated or
+	// corrupted object before it reaches the client as a decoding failure
+	trimmed, err := blobMetadata.TrimBlobPadding(data)
+	if err != nil {
+		s.logger.Error("Retrieved blob does not match its metadata", "err", err)
+		return data, source, err
+	}
+
+	if verify {
+		if err := s.verifyBlobCommitment(blobMetadata, trimmed); err != nil {
+			s.logger.Error("Retrieved blob does not match its commitment", "blobKey", blobMetadata.GetBlobKey().String(), "source", source, "err", err)
+			return trimmed, source, err
+		}
+	}
+	return trimmed, source, nil
+}
 
+// verifyBlobCommitment re-derives the commitment of the blob data and checks it against the commitment the blob was
+// confirmed with, failing with ErrBlobIntegrity if they differ
+func (s *DispersalServer) verifyBlobCommitment(blobMetadata *disperser.BlobMetadata, data []byte) error {
+	confirmationInfo := blobMetadata.ConfirmationInfo
+	if confirmationInfo == nil || confirmationInfo.BlobCommitment == nil || confirmationInfo.BlobCommitment.Commitment == nil {
+		return fmt.Errorf("blob %s has no confirmed commitment to verify it against", blobMetadata.GetBlobKey().String())
+	}
+	commitments, err := s.computeCommitments(data, blobMetadata.RequestMetadata.Layout)
+	if err != nil {
+		return err
+	}
+	commitment, err := commitments.Commitment.Serialize()
+	if err != nil {
+		return fmt.Errorf("failed to serialize the commitment of the blob: %w", err)
+	}
+	expected, err := confirmationInfo.BlobCommitment.Commitment.Serialize()
+	if err != nil {
+		return fmt.Errorf("failed to serialize the confirmed commitment of the blob: %w", err)
+	}
+	if commitments.Length != confirmationInfo.BlobCommitment.Length || !bytes.Equal(commitment, expected) {
+		return fmt.Errorf("%w: blob %s does not match the commitment it was confirmed with", disperser.ErrBlobIntegrity, blobMetadata.GetBlobKey().String())
+	}
+	return nil
+}
+
+// reconstructBlob retrieves the blob from the operators, trying the quorums with the smallest encoded blob first as
+// they have the fewest chunks to download
+func (s *DispersalServer) reconstructBlob(ctx context.Context, blobMetadata *disperser.BlobMetadata) ([]byte, error) {
+	// The retrieval client decodes the symbols in the free-form layout, which drops the first byte of the 32-byte field
+	// elements of the other layouts
+	if layout := blobMetadata.RequestMetadata.Layout; layout != core.FreeFormLayout {
+		return nil, fmt.Errorf("blob %s in the %s layout can't be reconstructed from the operators", blobMetadata.GetBlobKey().String(), layout)
+	}
+	confirmationInfo := blobMetadata.ConfirmationInfo
+	quorumInfos := make([]*core.BlobQuorumInfo, len(confirmationInfo.BlobQuorumInfos))
+	copy(quorumInfos, confirmationInfo.BlobQuorumInfos)
+	sort.SliceStable(quorumInfos, func(i, j int) bool {
+		return quorumInfos[i].EncodedBlobLength < quorumInfos[j].EncodedBlobLength
+	})
+	var batchRoot [32]byte
+	copy(batchRoot[:], confirmationInfo.BatchRoot)
+
+	err := errors.New("blob has no quorum to retrieve it from")
+	for _, quorumInfo := range quorumInfos {
+		var data []byte
+		data, err = s.retrievalClient.RetrieveBlob(ctx, confirmationInfo.BatchHeaderHash, confirmationInfo.BlobIndex, uint(confirmationInfo.ReferenceBlockNumber), batchRoot, quorumInfo.QuorumID)
+		if err == nil {
+			// The decoded data is padded to the length of the encoded blob, which is trimmed by the caller
+			return data, nil
+		}
+		if ctx.Err() != nil {
+			break
+		}
+		s.logger.Warn("Failed to reconstruct blob from quorum", "blobKey", blobMetadata.GetBlobKey().String(), "quorum", quorumInfo.QuorumID, "err", err)
+	}
+	return nil, fmt.Errorf("failed to reconstruct blob %s: %w", blobMetadata.GetBlobKey().String(), err)
+}
+
+// getConfirmedBlobsInBatch returns the metadata of the confirmed blobs of a batch, ordered by blob index, failing with
+// ErrBlobNotFound if the batch has none
+func (s *DispersalServer) getConfirmedBlobsInBatch(ctx context.Context, batchHeaderHash [32]byte) ([]*disperser.BlobMetadata, error) {
+	metadatas, err := s.blobStore.GetAllBlobMetadataByBatch(ctx, batchHeaderHash)
+	if err != nil {
+		s.logger.Error("Failed to retrieve the blob metadata of the batch", "err", err)
 		return nil, err
 	}
 
-	s.metrics.HandleSuccessfulRequest("", len(data), "RetrieveBlob")
+	confirmed := make([]*disperser.BlobMetadata, 0, len(metadatas))
+	for _, metadata := range metadatas {
+		isConfirmed, err := metadata.IsConfirmed()
+		if err != nil {
+			return nil, err
+		}
+		if isConfirmed {
+			confirmed = append(confirmed, metadata)
+		}
+	}
+	if len(confirmed) == 0 {
+		return nil, fmt.Errorf("%w: no confirmed blob in batch %s", disperser.ErrBlobNotFound, hexutil.Encode(batchHeaderHash[:]))
+	}
+	sort.Slice(confirmed, func(i, j int) bool {
+		return confirmed[i].ConfirmationInfo.BlobIndex < confirmed[j].ConfirmationInfo.BlobIndex
+	})
+	return confirmed, nil
+}
+
+// RetrieveBatchBlobs streams the data of the confirmed blobs of a batch in increasing blob index. Only one blob is read
+// at a time, so that the memory used by the request is bounded by the size of the largest blob.
+func (s *DispersalServer) RetrieveBatchBlobs(req *pb.RetrieveBatchBlobsRequest, stream pb.Disperser_RetrieveBatchBlobsServer) error {
+	timer := prometheus.NewTimer(prometheus.ObserverFunc(func(f float64) {
+		s.metrics.ObserveLatency("RetrieveBatchBlobs", f*1000) // make milliseconds
+	}))
+	defer timer.ObserveDuration()
 
-	return &pb.RetrieveBlobReply{
-		Data: data,
+	batchHeaderHash := req.GetBatchHeaderHash()
+	if len(batchHeaderHash) != 32 {
+		s.metrics.IncrementFailedBlobRequestNum("", "", "RetrieveBatchBlobs")
+		return newInvalidArgumentError(disperser.ReasonInvalidBatchHeaderHash, "batch_header_hash", fmt.Sprintf("invalid request: batch_header_hash must be 32 bytes, but found %d", len(batchHeaderHash)))
+	}
+	var batchHeaderHash32 [32]byte
+	copy(batchHeaderHash32[:], batchHeaderHash)
+
+	ctx := stream.Context()
+	s.logger.Info("received a new batch blobs retrieval request", "batchHeaderHash", hexutil.Encode(batchHeaderHash))
+	confirmed, err := s.getConfirmedBlobsInBatch(ctx, batchHeaderHash32)
+	if err != nil {
+		s.metrics.IncrementFailedBlobRequestNum("", "", "RetrieveBatchBlobs")
+		return err
+	}
+
+	totalSize := 0
+	for _, metadata := range confirmed {
+		// The data of the private blobs is only returned to their submitter, by RetrieveBlob
+		if metadata.IsPrivate() {
+			if err := stream.Send(&pb.RetrieveBatchBlobsReply{
+				BlobIndex:   metadata.ConfirmationInfo.BlobIndex,
+				NumBlobs:    uint32(len(confirmed)),
+				ContentType: metadata.RequestMetadata.ContentType,
+				Withheld:    true,
+			}); err != nil {
+				s.logger.Debug("Failed to send the withheld blob of the batch", "blobKey", metadata.GetBlobKey().String(), "err", err)
+				s.metrics.HandleFailedRequest("", "", totalSize, "RetrieveBatchBlobs")
+				return err
+			}
+			continue
+		}
+		data, _, err := s.retrieveBlobData(ctx, metadata, false)
+		if err != nil {
+			s.metrics.HandleFailedRequest("", "", totalSize+len(data), "RetrieveBatchBlobs")
+			return err
+		}
+		if err := stream.Send(&pb.RetrieveBatchBlobsReply{
+			BlobIndex:   metadata.ConfirmationInfo.BlobIndex,
+			Size:        uint32(len(data)),
+			Data:        data,
+			NumBlobs:    uint32(len(confirmed)),
+			ContentType: metadata.RequestMetadata.ContentType,
+		}); err != nil {
+			s.logger.Debug("Failed to send the blob of the batch", "blobKey", metadata.GetBlobKey().String(), "err", err)
+			s.metrics.HandleFailedRequest("", "", totalSize, "RetrieveBatchBlobs")
+			return err
+		}
+		totalSize += len(data)
+	}
+
+	s.metrics.HandleSuccessfulRequest("", "", totalSize, "RetrieveBatchBlobs")
+	return nil
+}
+
+func (s *DispersalServer) GetBatchVerificationProofs(ctx context.Context, req *pb.BatchVerificationProofsRequest) (*pb.BatchVerificationProofsReply, error) {
+	timer := prometheus.NewTimer(prometheus.ObserverFunc(func(f float64) {
+		s.metrics.ObserveLatency("GetBatchVerificationProofs", f*1000) // make milliseconds
+	}))
+	defer timer.ObserveDuration()
+
+	batchHeaderHash := req.GetBatchHeaderHash()
+	if len(batchHeaderHash) != 32 {
+		return nil, newInvalidArgumentError(disperser.ReasonInvalidBatchHeaderHash, "batch_header_hash", fmt.Sprintf("invalid request: batch_header_hash must be 32 bytes, but found %d", len(batchHeaderHash)))
+	}
+	var batchHeaderHash32 [32]byte
+	copy(batchHeaderHash32[:], batchHeaderHash)
+
+	s.logger.Info("received a new batch verification proofs request", "batchHeaderHash", hexutil.Encode(batchHeaderHash))
+	confirmed, err := s.getConfirmedBlobsInBatch(ctx, batchHeaderHash32)
+	if err != nil {
+		s.metrics.IncrementFailedBlobRequestNum("", "", "GetBatchVerificationProofs")
+		return nil, err
+	}
+
+	// The batch header covers all the quorums of the batch, in increasing order
+	batchInfo := confirmed[0].ConfirmationInfo
+	quorumIDs := make([]core.QuorumID, 0, len(batchInfo.QuorumResults))
+	for quorumID := range batchInfo.QuorumResults {
+		quorumIDs = append(quorumIDs, quorumID)
+	}
+	sort.Slice(quorumIDs, func(i, j int) bool { return quorumIDs[i] < quorumIDs[j] })
+	quorumNumbers := make([]byte, len(quorumIDs))
+	quorumPercentSigned := make([]byte, len(quorumIDs))
+	quorumIndex := make(map[core.QuorumID]byte, len(quorumIDs))
+	for i, quorumID := range quorumIDs {
+		quorumNumbers[i] = quorumID
+		quorumPercentSigned[i] = batchInfo.QuorumResults[quorumID].PercentSigned
+		quorumIndex[quorumID] = byte(i)
+	}
+
+	blobProofs := make([]*pb.BlobInclusionProof, len(confirmed))
+	for i, metadata := range confirmed {
+		confirmationInfo := metadata.ConfirmationInfo
+		blobHeader, err := getBlobHeaderProto(confirmationInfo)
+		if err != nil {
+			return nil, err
+		}
+		quorumIndexes := make([]byte, len(confirmationInfo.BlobQuorumInfos))
+		for j, quorumInfo := range confirmationInfo.BlobQuorumInfos {
+			index, ok := quorumIndex[quorumInfo.QuorumID]
+			if !ok {
+				return nil, fmt.Errorf("quorum %d of blob %d is not in the batch", quorumInfo.QuorumID, confirmationInfo.BlobIndex)
+			}
+			quorumIndexes[j] = index
+		}
+		blobProofs[i] = &pb.BlobInclusionProof{
+			RequestId:      metadata.GetBlobKey().RequestID(),
+			BlobHeader:     blobHeader,
+			BlobIndex:      confirmationInfo.BlobIndex,
+			InclusionProof: confirmationInfo.BlobInclusionProof,
+			QuorumIndexes:  quorumIndexes,
+		}
+	}
+
+	s.metrics.HandleSuccessfulRequest("", "", 0, "GetBatchVerificationProofs")
+
+	return &pb.BatchVerificationProofsReply{
+		BatchId: batchInfo.BatchID,
+		BatchMetadata: &pb.BatchMetadata{
+			BatchHeader: &pb.BatchHeader{
+				BatchRoot:               batchInfo.BatchRoot,
+				QuorumNumbers:           quorumNumbers,
+				QuorumSignedPercentages: quorumPercentSigned,
+				ReferenceBlockNumber:    batchInfo.ReferenceBlockNumber,
+			},
+			SignatoryRecordHash:     batchInfo.SignatoryRecordHash[:],
+			Fee:                     batchInfo.Fee,
+			ConfirmationBlockNumber: batchInfo.ConfirmationBlockNumber,
+			BatchHeaderHash:         batchInfo.BatchHeaderHash[:],
+		},
+		BlobProofs: blobProofs,
+	}, nil
+}
+
+// GetBlobProofBundle returns the proof bundle of a confirmed blob, assembled from its confirmation info and the
+// signature of its batch recorded by the batcher
+func (s *DispersalServer) GetBlobProofBundle(ctx context.Context, req *pb.BlobProofBundleRequest) (*pb.BlobProofBundleReply, error) {
+	timer := prometheus.NewTimer(prometheus.ObserverFunc(func(f float64) {
+		s.metrics.ObserveLatency("GetBlobProofBundle", f*1000) // make milliseconds
+	}))
+	defer timer.ObserveDuration()
+
+	requestID := req.GetRequestId()
+	if len(requestID) == 0 {
+		return nil, newInvalidArgumentError(disperser.ReasonInvalidRequestID, "request_id", "invalid request: request_id must not be empty")
+	}
+	metadataKey, err := disperser.ParseBlobKey(string(requestID))
+	if err != nil {
+		return nil, newInvalidArgumentError(disperser.ReasonInvalidRequestID, "request_id", err.Error())
+	}
+
+	metadata, err := s.blobStore.GetBlobMetadata(ctx, metadataKey)
+	if errors.Is(err, disperser.ErrBlobNotFound) {
+		s.metrics.IncrementFailedBlobRequestNum("", "", "GetBlobProofBundle")
+		return nil, status.Errorf(codes.NotFound, "blob %s not found", metadataKey.String())
+	}
+	if err != nil {
+		s.logger.Error("Failed to retrieve the blob metadata", "err", err)
+		s.metrics.IncrementFailedBlobRequestNum("", "", "GetBlobProofBundle")
+		return nil, err
+	}
+	isConfirmed, err := metadata.IsConfirmed()
+	if err != nil {
+		s.metrics.IncrementFailedBlobRequestNum("", "", "GetBlobProofBundle")
+		return nil, err
+	}
+	if !isConfirmed {
+		s.metrics.IncrementFailedBlobRequestNum("", "", "GetBlobProofBundle")
+		return nil, status.Errorf(codes.FailedPrecondition, "blob %s is not confirmed", metadataKey.String())
+	}
+
+	confirmationInfo := metadata.ConfirmationInfo
+	signature, err := s.blobStore.GetBatchSignature(ctx, confirmationInfo.BatchHeaderHash)
+	if errors.Is(err, disperser.ErrBatchSignatureNotFound) {
+		s.metrics.IncrementFailedBlobRequestNum("", "", "GetBlobProofBundle")
+		return nil, status.Errorf(codes.NotFound, "no signature stored for batch %s", hexutil.Encode(confirmationInfo.BatchHeaderHash[:]))
+	}
+	if err != nil {
+		s.logger.Error("Failed to retrieve the signature of the batch", "err", err)
+		s.metrics.IncrementFailedBlobRequestNum("", "", "GetBlobProofBundle")
+		return nil, err
+	}
+
+	bundle, err := disperser.NewBlobProofBundle(confirmationInfo, signature)
+	if err != nil {
+		s.logger.Error("Failed to assemble the proof bundle", "blobKey", metadataKey.String(), "err", err)
+		s.metrics.IncrementFailedBlobRequestNum("", "", "GetBlobProofBundle")
+		return nil, err
+	}
+	data, err := bundle.Encode()
+	if err != nil {
+		s.metrics.IncrementFailedBlobRequestNum("", "", "GetBlobProofBundle")
+		return nil, err
+	}
+
+	s.metrics.HandleSuccessfulRequest("", "", 0, "GetBlobProofBundle")
+
+	return &pb.BlobProofBundleReply{
+		Bundle:          data,
+		BatchHeaderHash: confirmationInfo.BatchHeaderHash[:],
+	}, nil
+}
+
+func (s *DispersalServer) GetOperatorStateAtBatch(ctx context.Context, req *pb.OperatorStateAtBatchRequest) (*pb.OperatorStateAtBatchReply, error) {
+	timer := prometheus.NewTimer(prometheus.ObserverFunc(func(f float64) {
+		s.metrics.ObserveLatency("GetOperatorStateAtBatch", f*1000) // make milliseconds
+	}))
+	defer timer.ObserveDuration()
+
+	batchHeaderHash := req.GetBatchHeaderHash()
+	if len(batchHeaderHash) != 32 {
+		return nil, newInvalidArgumentError(disperser.ReasonInvalidBatchHeaderHash, "batch_header_hash", fmt.Sprintf("invalid request: batch_header_hash must be 32 bytes, but found %d", len(batchHeaderHash)))
+	}
+	var batchHeaderHash32 [32]byte
+	copy(batchHeaderHash32[:], batchHeaderHash)
+	offset := 0
+	if req.GetPageToken() != "" {
+		var err error
+		offset, err = strconv.Atoi(req.GetPageToken())
+		if err != nil || offset < 0 {
+			return nil, newInvalidArgumentError(disperser.ReasonInvalidPageToken, "page_token", "invalid request: malformed page_token")
+		}
+	}
+	pageSize := int(req.GetPageSize())
+	if pageSize == 0 || pageSize > maxOperatorStatePageSize {
+		pageSize = maxOperatorStatePageSize
+	}
+
+	s.logger.Info("received a new operator state request", "batchHeaderHash", hexutil.Encode(batchHeaderHash))
+	// The reference block number of the batch is recorded in the confirmation info of its blobs
+	metadatas, err := s.blobStore.GetAllBlobMetadataByBatch(ctx, batchHeaderHash32)
+	if err != nil {
+		s.logger.Error("Failed to retrieve the blob metadata of the batch", "err", err)
+		s.metrics.IncrementFailedBlobRequestNum("", "", "GetOperatorStateAtBatch")
+		return nil, err
+	}
+	var batchInfo *disperser.ConfirmationInfo
+	for _, metadata := range metadatas {
+		if metadata.ConfirmationInfo != nil {
+			batchInfo = metadata.ConfirmationInfo
+			break
+		}
+	}
+	if batchInfo == nil {
+		s.metrics.IncrementFailedBlobRequestNum("", "", "GetOperatorStateAtBatch")
+		return nil, status.Errorf(codes.NotFound, "batch %s not found", hexutil.Encode(batchHeaderHash))
+	}
+
+	snapshot, err := s.blobStore.GetOperatorState(ctx, batchHeaderHash32)
+	if errors.Is(err, disperser.ErrOperatorStateNotFound) {
+		s.metrics.IncrementFailedBlobRequestNum("", "", "GetOperatorStateAtBatch")
+		return nil, status.Errorf(codes.NotFound, "no operator state stored for batch %s", hexutil.Encode(batchHeaderHash))
+	}
+	if err != nil {
+		s.logger.Error("Failed to retrieve the operator state of the batch", "err", err)
+		s.metrics.IncrementFailedBlobRequestNum("", "", "GetOperatorStateAtBatch")
+		return nil, err
+	}
+	if snapshot.ReferenceBlockNumber != uint(batchInfo.ReferenceBlockNumber) {
+		s.metrics.IncrementFailedBlobRequestNum("", "", "GetOperatorStateAtBatch")
+		return nil, fmt.Errorf("operator state of batch %s is at block %d, but the batch reference block is %d", hexutil.Encode(batchHeaderHash), snapshot.ReferenceBlockNumber, batchInfo.ReferenceBlockNumber)
+	}
+
+	quorumTotals, operators := getOperatorStakesProto(snapshot.State)
+	if offset > len(operators) {
+		offset = len(operators)
+	}
+	end := offset + pageSize
+	nextPageToken := strconv.Itoa(end)
+	if end >= len(operators) {
+		end = len(operators)
+		nextPageToken = ""
+	}
+
+	s.metrics.HandleSuccessfulRequest("", "", 0, "GetOperatorStateAtBatch")
+
+	return &pb.OperatorStateAtBatchReply{
+		ReferenceBlockNumber: batchInfo.ReferenceBlockNumber,
+		QuantizationFactor:   uint32(snapshot.QuantizationFactor),
+		OverprovisionPercent: uint32(snapshot.OverprovisionPercent),
+		QuorumTotals:         quorumTotals,
+		Operators:            operators[offset:end],
+		NextPageToken:        nextPageToken,
+	}, nil
+}
+
+// getOperatorStakesProto returns the total stakes of the quorums of the state ordered by quorum, and the stakes of
+// their operators ordered by quorum and operator index, so that the pages of the operators are stable
+func getOperatorStakesProto(state *core.OperatorState) ([]*pb.QuorumStake, []*pb.OperatorStake) {
+	quorumIDs := make([]core.QuorumID, 0, len(state.Totals))
+	for quorumID := range state.Totals {
+		quorumIDs = append(quorumIDs, quorumID)
+	}
+	sort.Slice(quorumIDs, func(i, j int) bool { return quorumIDs[i] < quorumIDs[j] })
+
+	quorumTotals := make([]*pb.QuorumStake, len(quorumIDs))
+	operators := make([]*pb.OperatorStake, 0)
+	for i, quorumID := range quorumIDs {
+		total := state.Totals[quorumID]
+		quorumTotals[i] = &pb.QuorumStake{
+			QuorumNumber: uint32(quorumID),
+			Stake:        (*big.Int)(total.Stake).Bytes(),
+			NumOperators: uint32(total.Index),
+		}
+
+		quorumOperators := make([]*pb.OperatorStake, 0, len(state.Operators[quorumID]))
+		for operatorID, info := range state.Operators[quorumID] {
+			operatorID := operatorID
+			quorumOperators = append(quorumOperators, &pb.OperatorStake{
+				OperatorId:   operatorID[:],
+				QuorumNumber: uint32(quorumID),
+				Index:        uint32(info.Index),
+				Stake:        (*big.Int)(info.Stake).Bytes(),
+			})
+		}
+		sort.Slice(quorumOperators, func(i, j int) bool { return quorumOperators[i].Index < quorumOperators[j].Index })
+		operators = append(operators, quorumOperators...)
+	}
+	return quorumTotals, operators
+}
+
+// getBlobHeaderProto returns the header of the confirmed blob
+func getBlobHeaderProto(confirmationInfo *disperser.ConfirmationInfo) (*pb.BlobHeader, error) {
+	commit, err := confirmationInfo.BlobCommitment.Commitment.Serialize()
+	if err != nil {
+		return nil, err
+	}
+
+	quorumInfos := confirmationInfo.BlobQuorumInfos
+	blobQuorumParams := make([]*pb.BlobQuorumParam, len(quorumInfos))
+	for i, quorumInfo := range quorumInfos {
+		blobQuorumParams[i] = &pb.BlobQuorumParam{
+			QuorumNumber:                 uint32(quorumInfo.QuorumID),
+			AdversaryThresholdPercentage: uint32(quorumInfo.AdversaryThreshold),
+			QuorumThresholdPercentage:    uint32(quorumInfo.QuorumThreshold),
+			QuantizationParam:            uint32(quorumInfo.QuantizationFactor),
+			EncodedLength:                uint64(quorumInfo.EncodedBlobLength),
+			OverprovisionPercent:         uint32(quorumInfo.OverprovisionPercent),
+		}
+	}
+	return &pb.BlobHeader{
+		Commitment:       commit,
+		DataLength:       uint32(confirmationInfo.BlobCommitment.Length),
+		BlobQuorumParams: blobQuorumParams,
+	}, nil
+}
+
+func getQuorumStatusesProto(confirmationInfo *disperser.ConfirmationInfo) []*pb.BlobQuorumStatus {
+	quorumStatuses := confirmationInfo.QuorumStatuses()
+	statuses := make([]*pb.BlobQuorumStatus, len(quorumStatuses))
+	for i, quorumStatus := range quorumStatuses {
+		statuses[i] = &pb.BlobQuorumStatus{
+			QuorumNumber:              uint32(quorumStatus.QuorumID),
+			SignedPercentage:          uint32(quorumStatus.PercentSigned),
+			QuorumThresholdPercentage: uint32(quorumStatus.QuorumThreshold),
+			Confirmed:                 quorumStatus.Confirmed,
+		}
+	}
+	return statuses
+}
+
+// ExtendBlobRetention postpones the expiry of a blob by the requested duration. Only the trusted callers can extend the
+// retention of the blobs, and the total retention of a blob, from its dispersal, is capped by MaxBlobRetention.
+func (s *DispersalServer) ExtendBlobRetention(ctx context.Context, req *pb.ExtendBlobRetentionRequest) (*pb.ExtendBlobRetentionReply, error) {
+	timer := prometheus.NewTimer(prometheus.ObserverFunc(func(f float64) {
+		s.metrics.ObserveLatency("ExtendBlobRetention", f*1000) // make milliseconds
+	}))
+	defer timer.ObserveDuration()
+
+	origin, err := s.clientAddress(ctx)
+	if err != nil {
+		s.metrics.IncrementFailedBlobRequestNum("", "", "ExtendBlobRetention")
+		return nil, err
+	}
+	reason := s.trustReason(ctx, origin)
+	if reason == "" {
+		s.metrics.IncrementFailedBlobRequestNum("", "", "ExtendBlobRetention")
+		return nil, status.Error(codes.PermissionDenied, "only trusted callers can extend the retention of blobs")
+	}
+	s.metrics.IncrementTrustedRequestNum(reason, "ExtendBlobRetention")
+	if s.config.MaxBlobRetention == 0 {
+		s.metrics.IncrementFailedBlobRequestNum("", "", "ExtendBlobRetention")
+		return nil, status.Error(codes.FailedPrecondition, "the retention of blobs can't be extended on this disperser")
+	}
+
+	requestID := req.GetRequestId()
+	if len(requestID) == 0 {
+		return nil, newInvalidArgumentError(disperser.ReasonInvalidRequestID, "request_id", "invalid request: request_id must not be empty")
+	}
+	additionalDuration := req.GetAdditionalDurationSeconds()
+	if additionalDuration == 0 {
+		return nil, newInvalidArgumentError(disperser.ReasonInvalidRetentionExtension, "additional_duration_seconds", "invalid request: additional_duration_seconds must be positive")
+	}
+	metadataKey, err := disperser.ParseBlobKey(string(requestID))
+	if err != nil {
+		return nil, newInvalidArgumentError(disperser.ReasonInvalidRequestID, "request_id", err.Error())
+	}
+
+	metadata, err := s.blobStore.GetBlobMetadata(ctx, metadataKey)
+	if errors.Is(err, disperser.ErrBlobNotFound) {
+		s.metrics.IncrementFailedBlobRequestNum("", "", "ExtendBlobRetention")
+		return nil, status.Errorf(codes.NotFound, "blob %s not found", metadataKey.String())
+	}
+	if err != nil {
+		s.logger.Error("Failed to retrieve the blob metadata", "err", err)
+		s.metrics.IncrementFailedBlobRequestNum("", "", "ExtendBlobRetention")
+		return nil, err
+	}
+	if metadata.Expiry == 0 {
+		s.metrics.IncrementFailedBlobRequestNum("", "", "ExtendBlobRetention")
+		return nil, status.Errorf(codes.FailedPrecondition, "blob %s doesn't expire", metadataKey.String())
+	}
+	if metadata.Expiry <= uint64(time.Now().Unix()) {
+		s.metrics.IncrementFailedBlobRequestNum("", "", "ExtendBlobRetention")
+		return nil, status.Errorf(codes.FailedPrecondition, "blob %s expired at %d", metadataKey.String(), metadata.Expiry)
+	}
+	if metadata.RequestMetadata == nil {
+		s.metrics.IncrementFailedBlobRequestNum("", "", "ExtendBlobRetention")
+		return nil, fmt.Errorf("missing request metadata for blob %s", metadataKey.String())
+	}
+	dispersedAt := metadata.RequestMetadata.RequestedAt / uint64(time.Second)
+	maxExpiry := dispersedAt + uint64(s.config.MaxBlobRetention/time.Second)
+	if metadata.Expiry > maxExpiry || additionalDuration > maxExpiry-metadata.Expiry {
+		s.metrics.IncrementFailedBlobRequestNum("", "", "ExtendBlobRetention")
+		return nil, status.Errorf(codes.OutOfRange, "the retention of blob %s can't be extended past %d", metadataKey.String(), maxExpiry)
+	}
+
+	updated, err := s.blobStore.ExtendBlobExpiry(ctx, metadata, metadata.Expiry+additionalDuration)
+	if err != nil {
+		s.metrics.IncrementFailedBlobRequestNum("", "", "ExtendBlobRetention")
+		switch {
+		case errors.Is(err, disperser.ErrBlobExpired):
+			return nil, status.Errorf(codes.FailedPrecondition, "blob %s expired", metadataKey.String())
+		case errors.Is(err, disperser.ErrBlobExpiryChanged):
+			return nil, status.Errorf(codes.Aborted, "the expiry of blob %s changed, try again", metadataKey.String())
+		}
+		s.logger.Error("Failed to extend the blob retention", "err", err)
+		return nil, err
+	}
+
+	s.logger.Info("extended the retention of blob", "blobKey", metadataKey.String(), "origin", origin, "reason", reason, "previousExpiry", metadata.Expiry, "expiry", updated.Expiry)
+	return &pb.ExtendBlobRetentionReply{Expiry: updated.Expiry}, nil
+}
+
+// ResubmitBlob disperses the content of a failed blob again with new security params. The new dispersal keeps the
+// namespace and labels of the original, and is validated and rate limited like DisperseBlob.
+func (s *DispersalServer) ResubmitBlob(ctx context.Context, req *pb.ResubmitBlobRequest) (*pb.ResubmitBlobReply, error) {
+	timer := prometheus.NewTimer(prometheus.ObserverFunc(func(f float64) {
+		s.metrics.ObserveLatency("ResubmitBlob", f*1000) // make milliseconds
+	}))
+	defer timer.ObserveDuration()
+
+	if s.config.DisperseRequestTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.config.DisperseRequestTimeout)
+		defer cancel()
+	}
+
+	if err := s.checkNotDraining("ResubmitBlob"); err != nil {
+		return nil, err
+	}
+
+	requestID := req.GetRequestId()
+	if len(requestID) == 0 {
+		return nil, newInvalidArgumentError(disperser.ReasonInvalidRequestID, "request_id", "invalid request: request_id must not be empty")
+	}
+	metadataKey, err := disperser.ParseBlobKey(string(requestID))
+	if err != nil {
+		return nil, newInvalidArgumentError(disperser.ReasonInvalidRequestID, "request_id", err.Error())
+	}
+	securityParams := req.GetSecurityParams()
+	if err := s.validateRequestedQuorums(ctx, securityParams); err != nil {
+		return nil, err
+	}
+
+	metadata, err := s.blobStore.GetBlobMetadata(ctx, metadataKey)
+	if errors.Is(err, disperser.ErrBlobNotFound) {
+		s.metrics.IncrementFailedBlobRequestNum("", "", "ResubmitBlob")
+		return nil, status.Errorf(codes.NotFound, "blob %s not found", metadataKey.String())
+	}
+	if err != nil {
+		s.logger.Error("Failed to retrieve the blob metadata", "err", err)
+		s.metrics.IncrementFailedBlobRequestNum("", "", "ResubmitBlob")
+		return nil, err
+	}
+	if err := metadata.CheckResubmittable(uint64(time.Now().Unix())); err != nil {
+		s.metrics.IncrementFailedBlobRequestNum("", "", "ResubmitBlob")
+		if errors.Is(err, disperser.ErrBlobNotResubmittable) || errors.Is(err, disperser.ErrBlobExpired) {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+		return nil, err
+	}
+
+	requestHeader := core.BlobRequestHeader{
+		SecurityParams: getSecurityParamsFromRequest(securityParams),
+		Namespace:      metadata.RequestMetadata.Namespace,
+		Labels:         metadata.RequestMetadata.Labels,
+		Layout:         metadata.RequestMetadata.Layout,
+		ContentType:    metadata.RequestMetadata.ContentType,
+		// The resubmitted blob is as private as the original one
+		SubmitterPublicKey: metadata.RequestMetadata.SubmitterPublicKey,
+	}
+	namespace := requestHeader.Namespace
+	blobSize := int(metadata.RequestMetadata.BlobSize)
+	handleFailedRequest := func() {
+		for _, param := range requestHeader.SecurityParams {
+			s.metrics.HandleFailedRequest(string(param.QuorumID), namespace, blobSize, "ResubmitBlob")
+		}
+	}
+
+	origin, err := s.clientAddress(ctx)
+	if err != nil {
+		handleFailedRequest()
+		return nil, err
+	}
+	if err := validateSecurityParams(requestHeader.SecurityParams); err != nil {
+		handleFailedRequest()
+		return nil, err
+	}
+	if err := s.validateSecurityPolicy(requestHeader.SecurityParams); err != nil {
+		handleFailedRequest()
+		return nil, err
+	}
+
+	requestHeader.AccountID = accountIDOf(origin)
+	if s.ratelimiter != nil && !s.isTrustedCaller(ctx, origin, "ResubmitBlob") {
+		err := s.checkRateLimitsAndAddRates(ctx, &requestHeader, blobSize, origin, false)
+		if err != nil {
+			for _, param := range requestHeader.SecurityParams {
+				quorumId := string(param.QuorumID)
+				if errors.Is(err, errSystemRateLimit) {
+					s.metrics.HandleSystemRateLimitedRequest(quorumId, namespace, blobSize, "ResubmitBlob")
+				} else if errors.Is(err, errAccountRateLimit) {
+					s.metrics.HandleAccountRateLimitedRequest(quorumId, namespace, blobSize, "ResubmitBlob")
+				} else if errors.Is(err, errDailyQuotaExceeded) {
+					s.metrics.HandleQuotaExceededRequest(quorumId, namespace, blobSize, "ResubmitBlob")
+				} else {
+					s.metrics.HandleFailedRequest(quorumId, namespace, blobSize, "ResubmitBlob")
+				}
+			}
+			return nil, err
+		}
+	}
+
+	newKey, err := s.blobStore.ResubmitBlob(ctx, metadata, requestHeader, uint64(time.Now().UnixNano()))
+	if err != nil {
+		handleFailedRequest()
+		switch {
+		case errors.Is(err, disperser.ErrBlobNotFound):
+			return nil, status.Errorf(codes.NotFound, "the content of blob %s is no longer stored", metadataKey.String())
+		case errors.Is(err, disperser.ErrBlobNotResubmittable), errors.Is(err, disperser.ErrBlobExpired):
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, status.FromContextError(ctxErr).Err()
+		}
+		s.logger.Error("Failed to resubmit the blob", "err", err)
+		return nil, err
+	}
+
+	for _, param := range requestHeader.SecurityParams {
+		s.metrics.HandleSuccessfulRequest(string(param.QuorumID), namespace, blobSize, "ResubmitBlob")
+	}
+	s.logger.Info("resubmitted blob", "blobKey", metadataKey.String(), "key", newKey.String(), "origin", origin)
+	return &pb.ResubmitBlobReply{
+		Result:    pb.BlobStatus_PROCESSING,
+		RequestId: newKey.RequestID(),
+	}, nil
+}
+
+func (s *DispersalServer) GetBatchCost(ctx context.Context, req *pb.BatchCostRequest) (*pb.BatchCostReply, error) {
+	timer := prometheus.NewTimer(prometheus.ObserverFunc(func(f float64) {
+		s.metrics.ObserveLatency("GetBatchCost", f*1000) // make milliseconds
+	}))
+	defer timer.ObserveDuration()
+
+	origin, err := s.clientAddress(ctx)
+	if err != nil {
+		s.metrics.IncrementFailedBlobRequestNum("", "", "GetBatchCost")
+		return nil, err
+	}
+	reason := s.trustReason(ctx, origin)
+	if reason == "" {
+		s.metrics.IncrementFailedBlobRequestNum("", "", "GetBatchCost")
+		return nil, status.Error(codes.PermissionDenied, "only trusted callers can get the cost of batches")
+	}
+	s.metrics.IncrementTrustedRequestNum(reason, "GetBatchCost")
+
+	batchHeaderHash := req.GetBatchHeaderHash()
+	if len(batchHeaderHash) != 32 {
+		return nil, newInvalidArgumentError(disperser.ReasonInvalidBatchHeaderHash, "batch_header_hash", fmt.Sprintf("invalid request: batch_header_hash must be 32 bytes, but found %d", len(batchHeaderHash)))
+	}
+	var batchHeaderHash32 [32]byte
+	copy(batchHeaderHash32[:], batchHeaderHash)
+
+	cost, err := s.blobStore.GetBatchCost(ctx, batchHeaderHash32)
+	if errors.Is(err, disperser.ErrBatchCostNotFound) {
+		s.metrics.IncrementFailedBlobRequestNum("", "", "GetBatchCost")
+		return nil, status.Errorf(codes.NotFound, "no cost recorded for batch %s", hexutil.Encode(batchHeaderHash))
+	}
+	if err != nil {
+		s.logger.Error("Failed to retrieve the cost of the batch", "err", err)
+		s.metrics.IncrementFailedBlobRequestNum("", "", "GetBatchCost")
+		return nil, err
+	}
+
+	blobCosts := make([]*pb.BlobCost, len(cost.BlobCosts))
+	for i, blobCost := range cost.BlobCosts {
+		blobCosts[i] = &pb.BlobCost{
+			RequestId:     blobCost.BlobKey.RequestID(),
+			BlobIndex:     blobCost.BlobIndex,
+			EncodedLength: uint64(blobCost.EncodedLength),
+			Cost:          blobCost.Cost.Bytes(),
+		}
+	}
+	return &pb.BatchCostReply{
+		ConfirmationTxnHash:     cost.ConfirmationTxnHash[:],
+		ConfirmationBlockNumber: cost.ConfirmationBlockNumber,
+		GasUsed:                 cost.GasUsed,
+		EffectiveGasPrice:       cost.EffectiveGasPrice.Bytes(),
+		TotalCost:               cost.TotalCost.Bytes(),
+		BlobCosts:               blobCosts,
+	}, nil
+}
+
+// GetOperatorVersions returns the versions advertised by the operators which replied to the dispersal of a batch, as
+// recorded with the operator state snapshot of the batch
+func (s *DispersalServer) GetOperatorVersions(ctx context.Context, req *pb.OperatorVersionsRequest) (*pb.OperatorVersionsReply, error) {
+	timer := prometheus.NewTimer(prometheus.ObserverFunc(func(f float64) {
+		s.metrics.ObserveLatency("GetOperatorVersions", f*1000) // make milliseconds
+	}))
+	defer timer.ObserveDuration()
+
+	origin, err := s.clientAddress(ctx)
+	if err != nil {
+		s.metrics.IncrementFailedBlobRequestNum("", "", "GetOperatorVersions")
+		return nil, err
+	}
+	reason := s.trustReason(ctx, origin)
+	if reason == "" {
+		s.metrics.IncrementFailedBlobRequestNum("", "", "GetOperatorVersions")
+		return nil, status.Error(codes.PermissionDenied, "only trusted callers can get the versions of the operators")
+	}
+	s.metrics.IncrementTrustedRequestNum(reason, "GetOperatorVersions")
+
+	batchHeaderHash := req.GetBatchHeaderHash()
+	if len(batchHeaderHash) != 32 {
+		return nil, newInvalidArgumentError(disperser.ReasonInvalidBatchHeaderHash, "batch_header_hash", fmt.Sprintf("invalid request: batch_header_hash must be 32 bytes, but found %d", len(batchHeaderHash)))
+	}
+	var batchHeaderHash32 [32]byte
+	copy(batchHeaderHash32[:], batchHeaderHash)
+
+	snapshot, err := s.blobStore.GetOperatorState(ctx, batchHeaderHash32)
+	if errors.Is(err, disperser.ErrOperatorStateNotFound) {
+		s.metrics.IncrementFailedBlobRequestNum("", "", "GetOperatorVersions")
+		return nil, status.Errorf(codes.NotFound, "no operator state stored for batch %s", hexutil.Encode(batchHeaderHash))
+	}
+	if err != nil {
+		s.logger.Error("Failed to retrieve the operator state of the batch", "err", err)
+		s.metrics.IncrementFailedBlobRequestNum("", "", "GetOperatorVersions")
+		return nil, err
+	}
+
+	operators := make([]*pb.OperatorVersion, 0, len(snapshot.OperatorVersions))
+	for operatorID, version := range snapshot.OperatorVersions {
+		operatorID := operatorID
+		operators = append(operators, &pb.OperatorVersion{
+			OperatorId: operatorID[:],
+			Version:    version.Version,
+			Features:   uint64(version.Features),
+		})
+	}
+	sort.Slice(operators, func(i, j int) bool {
+		return string(operators[i].OperatorId) < string(operators[j].OperatorId)
+	})
+
+	quorumIDs := make([]core.QuorumID, 0, len(snapshot.State.Totals))
+	for quorumID := range snapshot.State.Totals {
+		quorumIDs = append(quorumIDs, quorumID)
+	}
+	sort.Slice(quorumIDs, func(i, j int) bool { return quorumIDs[i] < quorumIDs[j] })
+	features := make([]*pb.FeatureStake, 0, len(core.KnownFeatures)*len(quorumIDs))
+	for _, feature := range core.KnownFeatures {
+		for _, quorumID := range quorumIDs {
+			features = append(features, &pb.FeatureStake{
+				Feature:      uint64(feature),
+				QuorumNumber: uint32(quorumID),
+				Percentage:   uint32(core.FeatureStakePercentage(snapshot.State, snapshot.OperatorVersions, quorumID, feature)),
+			})
+		}
+	}
+	return &pb.OperatorVersionsReply{
+		Operators: operators,
+		Features:  features,
 	}, nil
 }
 
@@ -358,20 +1911,61 @@ func (s *DispersalServer) Start(ctx context.Context) error {
 	s.logger.Trace("Entering Start function...")
 	defer s.logger.Trace("Exiting Start function...")
 
+	if s.rateConfig.ReservationsFile != "" && s.rateConfig.ReservationsRefreshInterval > 0 {
+		go s.refreshReservations(ctx)
+	}
+	if s.config.SecurityPolicyFile != "" && s.config.SecurityPolicyRefreshInterval > 0 {
+		go s.refreshSecurityPolicy(ctx)
+	}
+	if s.auditor != nil {
+		s.auditor.Start(ctx)
+	}
+
 	// Serve grpc requests
 	addr := fmt.Sprintf("%s:%s", disperser.Localhost, s.config.GrpcPort)
 	listener, err := net.Listen("tcp", addr)
 	if err != nil {
 		return fmt.Errorf("could not start tcp listener")
 	}
+	if len(s.rateConfig.ProxyProtocolCIDRs) > 0 {
+		// The header is read by the goroutine serving the connection, rather than while accepting it
+		listener = &proxyproto.Listener{Listener: listener, Policy: proxyProtocolPolicy(s.rateConfig.ProxyProtocolCIDRs)}
+	}
+	// The connections are limited on the client addresses of their PROXY protocol header
+	if s.connectionLimiter != nil {
+		listener = &ipConnectionLimitListener{Listener: listener, limiter: s.connectionLimiter, metrics: s.metrics, logger: s.logger}
+	}
+
+	tlsOpts, err := commongrpc.ServerOptions(ctx, s.config.TLS, s.logger)
+	if err != nil {
+		return fmt.Errorf("could not configure TLS: %w", err)
+	}
 
-	opt := grpc.MaxRecvMsgSize(1024 * 1024 * 300) // 300 MiB
-	gs := grpc.NewServer(opt)
-	reflection.Register(gs)
+	// The clients can compress their requests with any of the compressors registered by commongrpc. The size limits
+	// apply to the decompressed requests.
+	opts := append(tlsOpts,
+		grpc.MaxRecvMsgSize(maxRequestSize),
+		grpc.StatsHandler(&compressionStatsHandler{metrics: s.metrics}),
+	)
+	if s.config.MaxGRPCMessageSize > 0 {
+		opts = append(opts, grpc.MaxSendMsgSize(s.config.MaxGRPCMessageSize))
+	}
+	if s.requestLimiter != nil {
+		limit := &ipRequestLimit{server: s, limiter: s.requestLimiter}
+		opts = append(opts, grpc.ChainUnaryInterceptor(limit.unaryInterceptor), grpc.ChainStreamInterceptor(limit.streamInterceptor))
+	}
+	gs := grpc.NewServer(opts...)
+	if s.config.EnableReflection {
+		reflection.Register(gs)
+	}
 	pb.RegisterDisperserServer(gs, s)
 
 	// Register Server for Health Checks
-	healthcheck.RegisterHealthServer(gs)
+	healthcheck.RegisterHealthServerWithReadiness(gs, s.isReady)
+
+	s.mu.Lock()
+	s.grpcServer = gs
+	s.mu.Unlock()
 
 	s.logger.Info("port", s.config.GrpcPort, "address", listener.Addr().String(), "GRPC Listening")
 	if err := gs.Serve(listener); err != nil {
@@ -381,6 +1975,43 @@ func (s *DispersalServer) Start(ctx context.Context) error {
 	return nil
 }
 
+// validateNumQuorums checks that a blob is dispersed to at most the max number of quorums per blob, or the onchain
+// quorum count if it isn't configured
+// Stop stops serving once the pending requests are served, or right away once the context is done
+func (s *DispersalServer) Stop(ctx context.Context) error {
+	s.mu.Lock()
+	gs := s.grpcServer
+	s.mu.Unlock()
+	if gs == nil {
+		return nil
+	}
+	return commongrpc.GracefulStop(ctx, gs)
+}
+
+func (s *DispersalServer) validateNumQuorums(ctx context.Context, numQuorums int) error {
+	maxQuorums := s.config.MaxQuorumsPerBlob
+	if maxQuorums == 0 {
+		if numQuorums > int(s.getQuorumCount()) {
+			if err := s.updateQuorumCount(ctx); err != nil {
+				return fmt.Errorf("failed to get onchain quorum count: %w", err)
+			}
+		}
+		maxQuorums = uint(s.getQuorumCount())
+	}
+	if uint(numQuorums) > maxQuorums {
+		msg := fmt.Sprintf("invalid request: a blob can be dispersed to at most %d quorums, but found %d", maxQuorums, numQuorums)
+		return newInvalidArgumentError(disperser.ReasonTooManyQuorums, "security_params", msg)
+	}
+	return nil
+}
+
+// getQuorumCount returns the onchain quorum count last fetched, which is updated by concurrent requests
+func (s *DispersalServer) getQuorumCount() uint16 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.quorumCount
+}
+
 func (s *DispersalServer) updateQuorumCount(ctx context.Context) error {
 	currentBlock, err := s.tx.GetCurrentBlockNumber(ctx)
 	if err != nil {
@@ -410,27 +2041,141 @@ func getResponseStatus(status disperser.BlobStatus) pb.BlobStatus {
 		return pb.BlobStatus_FINALIZED
 	case disperser.InsufficientSignatures:
 		return pb.BlobStatus_INSUFFICIENT_SIGNATURES
+	case disperser.QuotaExceeded:
+		return pb.BlobStatus_QUOTA_EXCEEDED
 	default:
 		return pb.BlobStatus_UNKNOWN
 	}
 }
 
-func getBlobFromRequest(req *pb.DisperseBlobRequest) *core.Blob {
-	params := make([]*core.SecurityParam, len(req.SecurityParams))
+// validateNamespace checks that the namespace is at most maxNamespaceLength characters from [a-zA-Z0-9._-]. The empty
+// namespace is valid.
+func validateNamespace(namespace string) error {
+	if len(namespace) > maxNamespaceLength {
+		return newInvalidArgumentError(disperser.ReasonInvalidNamespace, "namespace", fmt.Sprintf("invalid request: namespace must not exceed %d characters", maxNamespaceLength))
+	}
+	for _, c := range namespace {
+		if (c < 'a' || c > 'z') && (c < 'A' || c > 'Z') && (c < '0' || c > '9') && c != '.' && c != '_' && c != '-' {
+			return newInvalidArgumentError(disperser.ReasonInvalidNamespace, "namespace", fmt.Sprintf("invalid request: namespace contains invalid character %q", c))
+		}
+	}
+	return nil
+}
 
-	for i, param := range req.GetSecurityParams() {
+// validateMetadata checks that the metadata has at most maxMetadataLabels labels, with non-empty keys, and at most
+// maxMetadataSize bytes of keys and values in total
+func validateMetadata(metadata map[string]string) error {
+	if len(metadata) > maxMetadataLabels {
+		return newInvalidArgumentError(disperser.ReasonInvalidMetadata, "metadata", fmt.Sprintf("invalid request: metadata must not exceed %d labels", maxMetadataLabels))
+	}
+	size := 0
+	for key, value := range metadata {
+		if key == "" {
+			return newInvalidArgumentError(disperser.ReasonInvalidMetadata, "metadata", "invalid request: metadata keys must not be empty")
+		}
+		size += len(key) + len(value)
+	}
+	if size > maxMetadataSize {
+		return newInvalidArgumentError(disperser.ReasonInvalidMetadata, "metadata", fmt.Sprintf("invalid request: metadata must not exceed %d bytes", maxMetadataSize))
+	}
+	return nil
+}
+
+// getSubmitterPublicKey returns the compressed public key of the submitter of a private blob, or nil for a public blob
+func getSubmitterPublicKey(req *pb.DisperseBlobRequest) ([]byte, error) {
+	key := req.GetSubmitterPublicKey()
+	if !req.GetPrivateUntilFinalized() {
+		if len(key) > 0 {
+			return nil, newInvalidArgumentError(disperser.ReasonInvalidSubmitterKey, "submitter_public_key", "invalid request: submitter_public_key is only accepted for the private blobs")
+		}
+		return nil, nil
+	}
+	if len(key) == 0 {
+		return nil, newInvalidArgumentError(disperser.ReasonInvalidSubmitterKey, "submitter_public_key", "invalid request: a private blob requires a submitter_public_key")
+	}
+	compressed, err := disperser.ParseSubmitterPublicKey(key)
+	if err != nil {
+		return nil, newInvalidArgumentError(disperser.ReasonInvalidSubmitterKey, "submitter_public_key", fmt.Sprintf("invalid request: %v", err))
+	}
+	return compressed, nil
+}
+
+// authorizeRetrieval checks that the retrieval of a private blob is signed by its submitter, at a time within
+// maxRetrievalAuthSkew of now
+func authorizeRetrieval(metadata *disperser.BlobMetadata, batchHeaderHash [32]byte, req *pb.RetrieveBlobRequest, now time.Time) error {
+	if len(req.GetAuthSignature()) == 0 {
+		return fmt.Errorf("%w: blob %s is private until it is finalized, and the request isn't signed", disperser.ErrRetrievalUnauthorized, metadata.GetBlobKey().String())
+	}
+	requestTime := time.Unix(int64(req.GetAuthTimestamp()), 0)
+	if requestTime.Before(now.Add(-maxRetrievalAuthSkew)) || requestTime.After(now.Add(maxRetrievalAuthSkew)) {
+		return fmt.Errorf("%w: the auth timestamp %d is more than %s away from the time of the disperser", disperser.ErrRetrievalUnauthorized, req.GetAuthTimestamp(), maxRetrievalAuthSkew)
+	}
+	return disperser.VerifyRetrievalSignature(metadata.RequestMetadata.SubmitterPublicKey, batchHeaderHash, req.GetBlobIndex(), req.GetAuthTimestamp(), req.GetAuthSignature())
+}
+
+// validateContentType checks that the content type is empty, or a media type of at most maxContentTypeLength characters
+func validateContentType(contentType string) error {
+	if contentType == "" {
+		return nil
+	}
+	if len(contentType) > maxContentTypeLength {
+		return newInvalidArgumentError(disperser.ReasonInvalidContentType, "content_type", fmt.Sprintf("invalid request: content type must not exceed %d characters", maxContentTypeLength))
+	}
+	if _, _, err := mime.ParseMediaType(contentType); err != nil {
+		return newInvalidArgumentError(disperser.ReasonInvalidContentType, "content_type", fmt.Sprintf("invalid request: content type %q is not a media type: %v", contentType, err))
+	}
+	return nil
+}
+
+// validateBlobLayout checks that the layout is known and that the data conforms to it
+func validateBlobLayout(layout pb.BlobLayout, data []byte) error {
+	if _, ok := pb.BlobLayout_name[int32(layout)]; !ok {
+		return newInvalidArgumentError(disperser.ReasonInvalidBlobLayout, "layout", fmt.Sprintf("invalid request: unknown layout %d", layout))
+	}
+	// Any data conforms to the free-form layout
+	if layout == pb.BlobLayout_FREE_FORM {
+		return nil
+	}
+	if _, err := core.BlobLayout(layout).ToSymbols(data); err != nil {
+		return newInvalidArgumentError(disperser.ReasonInvalidBlobLayout, "data", fmt.Sprintf("invalid request: %v", err))
+	}
+	return nil
+}
+
+// validateSecurityParams validates the thresholds of each security param like core.BlobRequestHeader.Validate, so that
+// the error names the offending security param
+func validateSecurityParams(securityParams []*core.SecurityParam) error {
+	for i, param := range securityParams {
+		header := core.BlobRequestHeader{SecurityParams: []*core.SecurityParam{param}}
+		if err := header.Validate(); err != nil {
+			return newInvalidArgumentError(disperser.ReasonInvalidSecurityParams, fmt.Sprintf("security_params[%d]", i), err.Error())
+		}
+	}
+	return nil
+}
+
+func getSecurityParamsFromRequest(securityParams []*pb.SecurityParams) []*core.SecurityParam {
+	params := make([]*core.SecurityParam, len(securityParams))
+	for i, param := range securityParams {
 		params[i] = &core.SecurityParam{
 			QuorumID:           core.QuorumID(param.QuorumId),
 			AdversaryThreshold: uint8(param.AdversaryThreshold),
 			QuorumThreshold:    uint8(param.QuorumThreshold),
 		}
 	}
+	return params
+}
 
+func getBlobFromRequest(req *pb.DisperseBlobRequest) *core.Blob {
 	data := req.GetData()
 
 	blob := &core.Blob{
 		RequestHeader: core.BlobRequestHeader{
-			SecurityParams: params,
+			SecurityParams: getSecurityParamsFromRequest(req.GetSecurityParams()),
+			Namespace:      req.GetNamespace(),
+			Labels:         req.GetMetadata(),
+			Layout:         core.BlobLayout(req.GetLayout()),
+			ContentType:    req.GetContentType(),
 		},
 		Data: data,
 	}
@@ -2,34 +2,59 @@ package apiserver_test
 
 import (
 	"context"
+	"crypto/ecdsa"
 	"crypto/rand"
+	"errors"
 	"fmt"
+	"io"
+	"math/big"
 	"net"
 	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/Layr-Labs/eigenda/disperser/apiserver"
 	"github.com/Layr-Labs/eigenda/disperser/common/blobstore"
 	gethcommon "github.com/ethereum/go-ethereum/common"
+	gethcrypto "github.com/ethereum/go-ethereum/crypto"
 	"github.com/google/uuid"
 
 	pb "github.com/Layr-Labs/eigenda/api/grpc/disperser"
+	"github.com/Layr-Labs/eigenda/clients"
+	clientsmock "github.com/Layr-Labs/eigenda/clients/mock"
 	"github.com/Layr-Labs/eigenda/common"
-	"github.com/Layr-Labs/eigenda/common/aws"
 	"github.com/Layr-Labs/eigenda/common/aws/dynamodb"
 	"github.com/Layr-Labs/eigenda/common/aws/s3"
 	"github.com/Layr-Labs/eigenda/common/logging"
+	"github.com/Layr-Labs/eigenda/common/ratelimit"
+	"github.com/Layr-Labs/eigenda/common/store"
 	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/core/encoding"
 	"github.com/Layr-Labs/eigenda/core/mock"
 	"github.com/Layr-Labs/eigenda/disperser"
 	"github.com/Layr-Labs/eigenda/disperser/batcher"
+	"github.com/Layr-Labs/eigenda/disperser/common/inmem"
 	"github.com/Layr-Labs/eigenda/inabox/deploy"
+	"github.com/Layr-Labs/eigenda/pkg/encoding/kzgEncoder"
 	"github.com/Layr-Labs/eigenda/pkg/kzg/bn254"
 	"github.com/consensys/gnark-crypto/ecc/bn254/fp"
 	"github.com/ory/dockertest/v3"
 	"github.com/stretchr/testify/assert"
+	tmock "github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/peer"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+	"google.golang.org/grpc/status"
 )
 
 var (
@@ -86,6 +111,25 @@ func TestDisperseBlobWithInvalidQuorum(t *testing.T) {
 		},
 	})
 	assert.ErrorContains(t, err, "invalid request: the quorum_id must be in range [0, 1], but found 2")
+	assertErrorDetails(t, err, codes.InvalidArgument, disperser.ReasonInvalidQuorum, "security_params[0].quorum_id")
+
+	_, err = dispersalServer.DisperseBlob(ctx, &pb.DisperseBlobRequest{
+		Data: data,
+		SecurityParams: []*pb.SecurityParams{
+			{
+				QuorumId:           0,
+				AdversaryThreshold: 80,
+				QuorumThreshold:    100,
+			},
+			{
+				QuorumId:           2,
+				AdversaryThreshold: 80,
+				QuorumThreshold:    100,
+			},
+		},
+	})
+	assert.ErrorContains(t, err, "invalid request: the quorum_id must be in range [0, 1], but found 2")
+	assertErrorDetails(t, err, codes.InvalidArgument, disperser.ReasonInvalidQuorum, "security_params[1].quorum_id")
 
 	_, err = dispersalServer.DisperseBlob(ctx, &pb.DisperseBlobRequest{
 		Data: data,
@@ -103,6 +147,7 @@ func TestDisperseBlobWithInvalidQuorum(t *testing.T) {
 		},
 	})
 	assert.ErrorContains(t, err, "invalid request: security_params must not contain duplicate quorum_id")
+	assertErrorDetails(t, err, codes.InvalidArgument, disperser.ReasonInvalidSecurityParams, "security_params[1].quorum_id")
 }
 
 func TestGetBlobStatus(t *testing.T) {
@@ -179,6 +224,112 @@ func TestGetBlobStatus(t *testing.T) {
 	})
 	assert.Equal(t, reply.GetInfo().GetBlobVerificationProof().GetInclusionProof(), confirmedMetadata.ConfirmationInfo.BlobInclusionProof)
 	assert.Equal(t, reply.GetInfo().GetBlobVerificationProof().GetQuorumIndexes(), quorumIndexes)
+	for i, quorumStatus := range reply.GetQuorumStatuses() {
+		assert.Equal(t, uint32(securityParams[i].QuorumID), quorumStatus.GetQuorumNumber())
+		assert.True(t, quorumStatus.GetConfirmed())
+	}
+	assert.Len(t, reply.GetQuorumStatuses(), len(securityParams))
+}
+
+func TestGetBlobStatusPartiallyConfirmed(t *testing.T) {
+	logger, err := logging.GetLogger(logging.DefaultCLIConfig())
+	assert.NoError(t, err)
+	blobStore := inmem.NewBlobStore()
+	tx := &mock.MockTransactor{}
+	server := apiserver.NewDispersalServer(disperser.ServerConfig{
+		GrpcPort: "51008",
+	}, blobStore, tx, logger, disperser.NewMetrics("9008", nil, logger), nil, apiserver.RateConfig{})
+
+	ctx := context.Background()
+	blobKey, err := blobStore.StoreBlob(ctx, &core.Blob{Data: []byte("hello")}, uint64(time.Now().UnixNano()))
+	assert.NoError(t, err)
+	metadata, err := blobStore.GetBlobMetadata(ctx, blobKey)
+	assert.NoError(t, err)
+
+	// Quorum 0 reached its threshold while quorum 1 lags
+	_, err = blobStore.MarkBlobInsufficientSignatures(ctx, metadata, &disperser.ConfirmationInfo{
+		BatchHeaderHash: [32]byte{4, 5, 6},
+		QuorumResults: map[core.QuorumID]*core.QuorumResult{
+			0: {QuorumID: 0, PercentSigned: 90},
+			1: {QuorumID: 1, PercentSigned: 40},
+		},
+		BlobQuorumInfos: []*core.BlobQuorumInfo{
+			{SecurityParam: core.SecurityParam{QuorumID: 0, AdversaryThreshold: 50, QuorumThreshold: 80}},
+			{SecurityParam: core.SecurityParam{QuorumID: 1, AdversaryThreshold: 50, QuorumThreshold: 80}},
+		},
+	})
+	assert.NoError(t, err)
+
+	reply, err := server.GetBlobStatus(ctx, &pb.BlobStatusRequest{
+		RequestId: blobKey.RequestID(),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, pb.BlobStatus_INSUFFICIENT_SIGNATURES, reply.GetStatus())
+	assert.Equal(t, []*pb.BlobQuorumStatus{
+		{QuorumNumber: 0, SignedPercentage: 90, QuorumThresholdPercentage: 80, Confirmed: true},
+		{QuorumNumber: 1, SignedPercentage: 40, QuorumThresholdPercentage: 80, Confirmed: false},
+	}, reply.GetQuorumStatuses())
+}
+
+func TestGetBlobStatusNextPollDelay(t *testing.T) {
+	logger, err := logging.GetLogger(logging.DefaultCLIConfig())
+	assert.NoError(t, err)
+	blobStore := inmem.NewBlobStore()
+	tx := &mock.MockTransactor{}
+	server := apiserver.NewDispersalServer(disperser.ServerConfig{
+		GrpcPort:                 "51018",
+		ExpectedConfirmationTime: 100 * time.Second,
+	}, blobStore, tx, logger, disperser.NewMetrics("9018", nil, logger), nil, apiserver.RateConfig{})
+
+	ctx := context.Background()
+	getNextPollDelay := func(server *apiserver.DispersalServer, blobKey disperser.BlobKey) time.Duration {
+		reply, err := server.GetBlobStatus(ctx, &pb.BlobStatusRequest{
+			RequestId: blobKey.RequestID(),
+		})
+		assert.NoError(t, err)
+		return time.Duration(reply.GetNextPollDelayMs()) * time.Millisecond
+	}
+
+	// The delay is half of the time left until the expected confirmation, and at least a tenth of the expected
+	// confirmation time once the confirmation is near or overdue
+	for _, tc := range []struct {
+		processingTime time.Duration
+		nextPollDelay  time.Duration
+	}{
+		{0, 50 * time.Second},
+		{60 * time.Second, 20 * time.Second},
+		{90 * time.Second, 10 * time.Second},
+		{200 * time.Second, 10 * time.Second},
+	} {
+		requestedAt := time.Now().Add(-tc.processingTime)
+		blobKey, err := blobStore.StoreBlob(ctx, &core.Blob{Data: []byte(tc.processingTime.String())}, uint64(requestedAt.UnixNano()))
+		assert.NoError(t, err)
+		assert.InDelta(t, tc.nextPollDelay, getNextPollDelay(server, blobKey), float64(time.Second), "processing for %s", tc.processingTime)
+	}
+
+	// No delay is recommended for the blobs which are no longer processing
+	blobKey, err := blobStore.StoreBlob(ctx, &core.Blob{Data: []byte("failed")}, uint64(time.Now().UnixNano()))
+	assert.NoError(t, err)
+	assert.NoError(t, blobStore.MarkBlobFailed(ctx, blobKey))
+	assert.Zero(t, getNextPollDelay(server, blobKey))
+
+	// Nor by a server without an expected confirmation time
+	blobKey, err = blobStore.StoreBlob(ctx, &core.Blob{Data: []byte("processing")}, uint64(time.Now().UnixNano()))
+	assert.NoError(t, err)
+	server = apiserver.NewDispersalServer(disperser.ServerConfig{
+		GrpcPort: "51018",
+	}, blobStore, tx, logger, disperser.NewMetrics("9018", nil, logger), nil, apiserver.RateConfig{})
+	assert.Zero(t, getNextPollDelay(server, blobKey))
+}
+
+func TestGetBlobStatusInvalidRequestID(t *testing.T) {
+	server := newTestServerWithRatelimiter(t, apiserver.QuorumRateInfo{}, nil)
+	for _, requestID := range [][]byte{nil, []byte("v1:"), []byte("abc-"), []byte("v2:abc-def"), {0xff, '-', 0xfe}} {
+		_, err := server.GetBlobStatus(context.Background(), &pb.BlobStatusRequest{
+			RequestId: requestID,
+		})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err), string(requestID))
+	}
 }
 
 func TestRetrieveBlob(t *testing.T) {
@@ -226,134 +377,2304 @@ func TestRetrieveBlob(t *testing.T) {
 	assert.Equal(t, data, retrieveData)
 }
 
-func TestRetrieveBlobFailsWhenBlobNotConfirmed(t *testing.T) {
-	// Create random data
-	data := make([]byte, 1024)
-	_, err := rand.Read(data)
+func TestRetrieveBlobDetectsTruncatedContent(t *testing.T) {
+	logger, err := logging.GetLogger(logging.DefaultCLIConfig())
 	assert.NoError(t, err)
+	blobStore := inmem.NewBlobStore()
+	tx := &mock.MockTransactor{}
+	tx.On("GetCurrentBlockNumber").Return(uint32(100), nil)
+	tx.On("GetQuorumCount").Return(uint16(2), nil)
+	tx.On("GetNumberOfRegisteredOperatorForQuorum").Return(uint32(4), nil)
+	server := apiserver.NewDispersalServer(disperser.ServerConfig{
+		GrpcPort: "51003",
+	}, blobStore, tx, logger, disperser.NewMetrics("9003", nil, logger), nil, apiserver.RateConfig{})
 
-	// Disperse the random data
-	status, _, requestID := disperseBlob(t, dispersalServer, data)
-	assert.Equal(t, status, pb.BlobStatus_PROCESSING)
-	assert.NotNil(t, requestID)
-
-	reply, err := dispersalServer.GetBlobStatus(context.Background(), &pb.BlobStatusRequest{
-		RequestId: requestID,
+	ctx := context.Background()
+	data := make([]byte, 1024)
+	_, err = rand.Read(data)
+	assert.NoError(t, err)
+	blobKey, err := blobStore.StoreBlob(ctx, &core.Blob{Data: data}, uint64(time.Now().UnixNano()))
+	assert.NoError(t, err)
+	metadata, err := blobStore.GetBlobMetadata(ctx, blobKey)
+	assert.NoError(t, err)
+	_, err = blobStore.MarkBlobConfirmed(ctx, metadata, &disperser.ConfirmationInfo{
+		BatchHeaderHash: [32]byte{1, 2, 3},
+		BlobIndex:       2,
 	})
 	assert.NoError(t, err)
-	assert.Equal(t, reply.GetStatus(), pb.BlobStatus_PROCESSING)
-
-	// Try to retrieve the blob before it is confirmed
-	_, err = retrieveBlob(t, dispersalServer, 2)
-	assert.Error(t, err)
-}
 
-func TestDisperseBlobWithExceedSizeLimit(t *testing.T) {
-	data := make([]byte, 1024*512+10)
-	_, err := rand.Read(data)
+	retrieveData, err := retrieveBlob(t, server, 2)
 	assert.NoError(t, err)
+	assert.Equal(t, data, retrieveData)
 
-	p := &peer.Peer{
-		Addr: &net.TCPAddr{
-			IP:   net.ParseIP("0.0.0.0"),
-			Port: 51001,
-		},
-	}
-	ctx := peer.NewContext(context.Background(), p)
-	_, err = dispersalServer.DisperseBlob(ctx, &pb.DisperseBlobRequest{
-		Data: data,
-		SecurityParams: []*pb.SecurityParams{
-			{
-				QuorumId:           0,
-				AdversaryThreshold: 80,
-				QuorumThreshold:    100,
-			},
-			{
-				QuorumId:           1,
-				AdversaryThreshold: 80,
-				QuorumThreshold:    100,
-			},
+	// Simulate a truncated object in the storage
+	blobStore.(*inmem.BlobStore).Blobs[blobKey.BlobHash].Data = data[:512]
+	_, err = retrieveBlob(t, server, 2)
+	assert.ErrorIs(t, err, disperser.ErrBlobIntegrity)
+}
+
+func TestRetrieveBlobVerified(t *testing.T) {
+	logger, err := logging.GetLogger(logging.DefaultCLIConfig())
+	require.NoError(t, err)
+	blobStore := inmem.NewBlobStore()
+	tx := &mock.MockTransactor{}
+	tx.On("GetCurrentBlockNumber").Return(uint32(100), nil)
+	tx.On("GetQuorumCount").Return(uint16(2), nil)
+	tx.On("GetNumberOfRegisteredOperatorForQuorum").Return(uint32(4), nil)
+	server := apiserver.NewDispersalServer(disperser.ServerConfig{
+		GrpcPort: "51028",
+	}, blobStore, tx, logger, disperser.NewMetrics("9028", nil, logger), nil, apiserver.RateConfig{})
+	encoder, err := encoding.NewEncoder(encoding.EncoderConfig{
+		KzgConfig: kzgEncoder.KzgConfig{
+			G1Path:    "../../inabox/resources/kzg/g1.point",
+			G2Path:    "../../inabox/resources/kzg/g2.point",
+			CacheDir:  "../../inabox/resources/kzg/SRSTables",
+			SRSOrder:  3000,
+			NumWorker: uint64(runtime.GOMAXPROCS(0)),
 		},
 	})
-	assert.NotNil(t, err)
-	assert.Equal(t, err.Error(), "blob size cannot exceed 512 KiB")
+	require.NoError(t, err)
+
+	ctx := peer.NewContext(context.Background(), &peer.Peer{
+		Addr: &net.TCPAddr{IP: net.ParseIP("0.0.0.0"), Port: 51001},
+	})
+	data := make([]byte, 1000)
+	_, err = rand.Read(data)
+	require.NoError(t, err)
+	blobKey, err := blobStore.StoreBlob(ctx, &core.Blob{Data: data}, uint64(time.Now().UnixNano()))
+	require.NoError(t, err)
+	metadata, err := blobStore.GetBlobMetadata(ctx, blobKey)
+	require.NoError(t, err)
+
+	// The blob is confirmed with the commitment the batcher computes
+	params, err := core.GetEncodingParams(32, 4)
+	require.NoError(t, err)
+	commitments, _, err := encoder.Encode(data, params)
+	require.NoError(t, err)
+	_, err = blobStore.MarkBlobConfirmed(ctx, metadata, &disperser.ConfirmationInfo{
+		BatchHeaderHash: [32]byte{1, 2, 3},
+		BlobIndex:       4,
+		BlobCommitment:  &commitments,
+	})
+	require.NoError(t, err)
+	request := &pb.RetrieveBlobRequest{BatchHeaderHash: []byte{1, 2, 3}, BlobIndex: 4, Verify: true}
+
+	// The blobs can't be verified without the SRS
+	_, err = server.RetrieveBlob(ctx, request)
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	server.SetEncoder(encoder)
+	reply, err := server.RetrieveBlob(ctx, request)
+	require.NoError(t, err)
+	assert.Equal(t, data, reply.GetData())
+
+	// Simulate a corrupted object in the storage, which is only caught by the verified retrievals
+	corrupted := append([]byte{}, data...)
+	corrupted[500] ^= 1
+	blobStore.(*inmem.BlobStore).Blobs[blobKey.BlobHash].Data = corrupted
+	reply, err = server.RetrieveBlob(ctx, &pb.RetrieveBlobRequest{BatchHeaderHash: []byte{1, 2, 3}, BlobIndex: 4})
+	require.NoError(t, err)
+	assert.Equal(t, corrupted, reply.GetData())
+	_, err = server.RetrieveBlob(ctx, request)
+	assert.ErrorIs(t, err, disperser.ErrBlobIntegrity)
 }
 
-func setup(m *testing.M) {
+func TestRetrieveBatchBlobs(t *testing.T) {
+	logger, err := logging.GetLogger(logging.DefaultCLIConfig())
+	require.NoError(t, err)
+	blobStore := inmem.NewBlobStore()
+	startServer(t, apiserver.NewDispersalServer(disperser.ServerConfig{
+		GrpcPort: "51034",
+	}, blobStore, &mock.MockTransactor{}, logger, disperser.NewMetrics("9034", nil, logger), nil, apiserver.RateConfig{}), "51034")
 
-	deployLocalStack = !(os.Getenv("DEPLOY_LOCALSTACK") == "false")
-	if !deployLocalStack {
-		localStackPort = os.Getenv("LOCALSTACK_PORT")
+	// The blobs of the batch have different sizes, and are stored in the reverse order of their index
+	ctx := context.Background()
+	batchHeaderHash := [32]byte{4, 5, 6}
+	blobs := make([][]byte, 3)
+	for i := range blobs {
+		blobs[i] = make([]byte, 100*(i+1))
+		_, err = rand.Read(blobs[i])
+		require.NoError(t, err)
 	}
+	for i := len(blobs) - 1; i >= 0; i-- {
+		blobKey, err := blobStore.StoreBlob(ctx, &core.Blob{Data: blobs[i]}, uint64(time.Now().UnixNano()))
+		require.NoError(t, err)
+		metadata, err := blobStore.GetBlobMetadata(ctx, blobKey)
+		require.NoError(t, err)
+		_, err = blobStore.MarkBlobConfirmed(ctx, metadata, &disperser.ConfirmationInfo{
+			BatchHeaderHash: batchHeaderHash,
+			BlobIndex:       uint32(i),
+		})
+		require.NoError(t, err)
+	}
+	// A blob of another batch isn't retrieved
+	blobKey, err := blobStore.StoreBlob(ctx, &core.Blob{Data: []byte("other batch")}, uint64(time.Now().UnixNano()))
+	require.NoError(t, err)
+	metadata, err := blobStore.GetBlobMetadata(ctx, blobKey)
+	require.NoError(t, err)
+	_, err = blobStore.MarkBlobConfirmed(ctx, metadata, &disperser.ConfirmationInfo{BatchHeaderHash: [32]byte{7}})
+	require.NoError(t, err)
 
-	if deployLocalStack {
+	conn, err := grpc.Dial("localhost:51034", grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer func() { _ = conn.Close() }()
+	client := pb.NewDisperserClient(conn)
 
-		var err error
-		dockertestPool, dockertestResource, err = deploy.StartDockertestWithLocalstackContainer(localStackPort)
-		if err != nil {
-			teardown()
-			panic("failed to start localstack container")
-		}
+	// The blobs are streamed in increasing blob index
+	stream, err := client.RetrieveBatchBlobs(ctx, &pb.RetrieveBatchBlobsRequest{BatchHeaderHash: batchHeaderHash[:]})
+	require.NoError(t, err)
+	for i, data := range blobs {
+		reply, err := stream.Recv()
+		require.NoError(t, err)
+		assert.Equal(t, uint32(i), reply.GetBlobIndex())
+		assert.Equal(t, uint32(len(data)), reply.GetSize())
+		assert.Equal(t, data, reply.GetData())
+		assert.Equal(t, uint32(len(blobs)), reply.GetNumBlobs())
+	}
+	_, err = stream.Recv()
+	assert.ErrorIs(t, err, io.EOF)
+
+	// The stream fails for an invalid or unknown batch
+	stream, err = client.RetrieveBatchBlobs(ctx, &pb.RetrieveBatchBlobsRequest{BatchHeaderHash: []byte{4, 5, 6}})
+	require.NoError(t, err)
+	_, err = stream.Recv()
+	assertErrorDetails(t, err, codes.InvalidArgument, disperser.ReasonInvalidBatchHeaderHash, "batch_header_hash")
+	unknownBatch := [32]byte{8, 9}
+	stream, err = client.RetrieveBatchBlobs(ctx, &pb.RetrieveBatchBlobsRequest{BatchHeaderHash: unknownBatch[:]})
+	require.NoError(t, err)
+	_, err = stream.Recv()
+	assert.ErrorContains(t, err, "no confirmed blob in batch")
+}
+
+func TestGetBatchVerificationProofs(t *testing.T) {
+	logger, err := logging.GetLogger(logging.DefaultCLIConfig())
+	assert.NoError(t, err)
+	blobStore := inmem.NewBlobStore()
+	tx := &mock.MockTransactor{}
+	tx.On("GetCurrentBlockNumber").Return(uint32(100), nil)
+	tx.On("GetQuorumCount").Return(uint16(2), nil)
+	tx.On("GetNumberOfRegisteredOperatorForQuorum").Return(uint32(4), nil)
+	server := apiserver.NewDispersalServer(disperser.ServerConfig{
+		GrpcPort: "51005",
+	}, blobStore, tx, logger, disperser.NewMetrics("9005", nil, logger), nil, apiserver.RateConfig{})
 
+	// The first blob of the batch is only in quorum 1, the second one in quorums 1 and 0
+	ctx := context.Background()
+	batchHeaderHash := [32]byte{4, 5, 6}
+	quorumResults := map[core.QuorumID]*core.QuorumResult{
+		0: {QuorumID: 0, PercentSigned: 90},
+		1: {QuorumID: 1, PercentSigned: 80},
 	}
+	blobQuorums := [][]core.QuorumID{{1}, {1, 0}}
+	requestIDs := make([][]byte, len(blobQuorums))
+	for i, quorums := range blobQuorums {
+		blobKey, err := blobStore.StoreBlob(ctx, &core.Blob{Data: []byte{byte(i)}}, uint64(time.Now().UnixNano()))
+		assert.NoError(t, err)
+		requestIDs[i] = blobKey.RequestID()
+		metadata, err := blobStore.GetBlobMetadata(ctx, blobKey)
+		assert.NoError(t, err)
 
-	err := deploy.DeployResources(dockertestPool, localStackPort, metadataTableName, bucketTableName)
-	if err != nil {
-		teardown()
-		panic("failed to deploy AWS resources")
+		quorumInfos := make([]*core.BlobQuorumInfo, len(quorums))
+		for j, quorumID := range quorums {
+			quorumInfos[j] = &core.BlobQuorumInfo{
+				SecurityParam:      core.SecurityParam{QuorumID: quorumID, AdversaryThreshold: 80, QuorumThreshold: 100},
+				QuantizationFactor: batcher.QuantizationFactor,
+				EncodedBlobLength:  32,
+			}
+		}
+		_, err = blobStore.MarkBlobConfirmed(ctx, metadata, &disperser.ConfirmationInfo{
+			BatchHeaderHash:      batchHeaderHash,
+			BlobIndex:            uint32(len(blobQuorums) - 1 - i),
+			ReferenceBlockNumber: 132,
+			BatchRoot:            []byte("hello"),
+			BlobInclusionProof:   []byte{byte(i)},
+			BlobCommitment: &core.BlobCommitments{
+				Commitment: &core.Commitment{G1Point: &bn254.G1Point{}},
+				Length:     1,
+			},
+			BatchID:                 99,
+			ConfirmationBlockNumber: 150,
+			Fee:                     []byte{0},
+			QuorumResults:           quorumResults,
+			BlobQuorumInfos:         quorumInfos,
+		})
+		assert.NoError(t, err)
 	}
 
-	dispersalServer = newTestServer(m)
+	reply, err := server.GetBatchVerificationProofs(ctx, &pb.BatchVerificationProofsRequest{
+		BatchHeaderHash: batchHeaderHash[:],
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(99), reply.GetBatchId())
+	assert.Equal(t, []byte{0, 1}, reply.GetBatchMetadata().GetBatchHeader().GetQuorumNumbers())
+	assert.Equal(t, []byte{90, 80}, reply.GetBatchMetadata().GetBatchHeader().GetQuorumSignedPercentages())
+	assert.Equal(t, batchHeaderHash[:], reply.GetBatchMetadata().GetBatchHeaderHash())
+
+	// The proofs are ordered by blob index
+	proofs := reply.GetBlobProofs()
+	assert.Len(t, proofs, 2)
+	assert.Equal(t, requestIDs[1], proofs[0].GetRequestId())
+	assert.Equal(t, uint32(0), proofs[0].GetBlobIndex())
+	assert.Equal(t, []byte{1}, proofs[0].GetInclusionProof())
+	assert.Equal(t, []byte{1, 0}, proofs[0].GetQuorumIndexes())
+	assert.Len(t, proofs[0].GetBlobHeader().GetBlobQuorumParams(), 2)
+	assert.Equal(t, requestIDs[0], proofs[1].GetRequestId())
+	assert.Equal(t, uint32(1), proofs[1].GetBlobIndex())
+	assert.Equal(t, []byte{0}, proofs[1].GetInclusionProof())
+	assert.Equal(t, []byte{1}, proofs[1].GetQuorumIndexes())
+
+	_, err = server.GetBatchVerificationProofs(ctx, &pb.BatchVerificationProofsRequest{
+		BatchHeaderHash: []byte{4, 5, 6},
+	})
+	assert.Error(t, err)
+	unknownBatch := [32]byte{7, 8, 9}
+	_, err = server.GetBatchVerificationProofs(ctx, &pb.BatchVerificationProofsRequest{
+		BatchHeaderHash: unknownBatch[:],
+	})
+	assert.ErrorIs(t, err, disperser.ErrBlobNotFound)
 }
 
-func teardown() {
-	if deployLocalStack {
-		deploy.PurgeDockertestResources(dockertestPool, dockertestResource)
+func TestGetBlobProofBundle(t *testing.T) {
+	logger, err := logging.GetLogger(logging.DefaultCLIConfig())
+	assert.NoError(t, err)
+	blobStore := inmem.NewBlobStore()
+	server := apiserver.NewDispersalServer(disperser.ServerConfig{
+		GrpcPort: "51027",
+	}, blobStore, &mock.MockTransactor{}, logger, disperser.NewMetrics("9027", nil, logger), nil, apiserver.RateConfig{})
+	ctx := context.Background()
+
+	// A batch of a single blob, signed by its only operator
+	keys, err := core.GenRandomBlsKeys()
+	assert.NoError(t, err)
+	blobHeader := &core.BlobHeader{
+		BlobCommitments: core.BlobCommitments{
+			Commitment: &core.Commitment{G1Point: (*bn254.G1Point)(keys.GetPubKeyG1().G1Affine)},
+			Length:     1,
+		},
+		QuorumInfos: []*core.BlobQuorumInfo{{
+			SecurityParam:      core.SecurityParam{QuorumID: 0, AdversaryThreshold: 80, QuorumThreshold: 100},
+			QuantizationFactor: batcher.QuantizationFactor,
+		}},
 	}
+	batchHeader := &core.BatchHeader{ReferenceBlockNumber: 132}
+	_, err = batchHeader.SetBatchRoot([]*core.BlobHeader{blobHeader})
+	assert.NoError(t, err)
+	batchHeaderHash, err := batchHeader.GetBatchHeaderHash()
+	assert.NoError(t, err)
+
+	blobKey, err := blobStore.StoreBlob(ctx, &core.Blob{Data: []byte{1}}, uint64(time.Now().UnixNano()))
+	assert.NoError(t, err)
+	requestID := blobKey.RequestID()
+	_, err = server.GetBlobProofBundle(ctx, &pb.BlobProofBundleRequest{RequestId: requestID})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	metadata, err := blobStore.GetBlobMetadata(ctx, blobKey)
+	assert.NoError(t, err)
+	_, err = blobStore.MarkBlobConfirmed(ctx, metadata, &disperser.ConfirmationInfo{
+		BatchHeaderHash:      batchHeaderHash,
+		BlobIndex:            0,
+		BlobCount:            1,
+		SignatoryRecordHash:  core.ComputeSignatoryRecordHash(132, nil),
+		ReferenceBlockNumber: 132,
+		BatchRoot:            batchHeader.BatchRoot[:],
+		BlobInclusionProof:   []byte{},
+		BlobCommitment:       &blobHeader.BlobCommitments,
+		BatchID:              99,
+		Fee:                  []byte{0},
+		QuorumResults: map[core.QuorumID]*core.QuorumResult{
+			0: {QuorumID: 0, PercentSigned: 100},
+		},
+		BlobQuorumInfos:         blobHeader.QuorumInfos,
+		ConfirmationBlockNumber: 150,
+	})
+	assert.NoError(t, err)
+
+	// The batches confirmed before their signature was recorded have no bundle
+	_, err = server.GetBlobProofBundle(ctx, &pb.BlobProofBundleRequest{RequestId: requestID})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	err = blobStore.StoreBatchSignature(ctx, batchHeaderHash, disperser.NewBatchSignature([]core.QuorumID{0}, &core.SignatureAggregation{
+		QuorumAggPubKeys: []*core.G1Point{keys.GetPubKeyG1()},
+		AggPubKey:        keys.GetPubKeyG2(),
+		AggSignature:     keys.SignMessage(batchHeaderHash),
+	}))
+	assert.NoError(t, err)
+	reply, err := server.GetBlobProofBundle(ctx, &pb.BlobProofBundleRequest{RequestId: requestID})
+	assert.NoError(t, err)
+	assert.Equal(t, batchHeaderHash[:], reply.GetBatchHeaderHash())
+	decoded, err := disperser.DecodeBlobProofBundle(reply.GetBundle())
+	assert.NoError(t, err)
+	metadataHash, err := decoded.BlobVerificationProof.BatchMetadata.Hash()
+	assert.NoError(t, err)
+	bundle, err := clients.VerifyProofBundle(reply.GetBundle(), metadataHash)
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(99), bundle.BlobVerificationProof.BatchId)
+	assert.Equal(t, []byte{0}, bundle.BlobVerificationProof.BatchMetadata.BatchHeader.QuorumNumbers)
+
+	_, err = server.GetBlobProofBundle(ctx, &pb.BlobProofBundleRequest{})
+	assertErrorDetails(t, err, codes.InvalidArgument, disperser.ReasonInvalidRequestID, "request_id")
 }
 
-func newTestServer(m *testing.M) *apiserver.DispersalServer {
+func TestGetOperatorStateAtBatch(t *testing.T) {
 	logger, err := logging.GetLogger(logging.DefaultCLIConfig())
-	if err != nil {
-		panic("failed to create a new logger")
-	}
+	assert.NoError(t, err)
+	blobStore := inmem.NewBlobStore()
+	tx := &mock.MockTransactor{}
+	server := apiserver.NewDispersalServer(disperser.ServerConfig{
+		GrpcPort: "51009",
+	}, blobStore, tx, logger, disperser.NewMetrics("9009", nil, logger), nil, apiserver.RateConfig{})
 
-	bucketName := "test-eigenda-blobstore"
-	awsConfig := aws.ClientConfig{
-		Region:          "us-east-1",
-		AccessKey:       "localstack",
-		SecretAccessKey: "localstack",
-		EndpointURL:     fmt.Sprintf("http://0.0.0.0:%s", localStackPort),
+	ctx := context.Background()
+	cst, err := mock.NewChainDataMock(10)
+	assert.NoError(t, err)
+	state, err := cst.GetOperatorState(ctx, 10, []core.QuorumID{0, 1})
+	assert.NoError(t, err)
+
+	batchHeaderHash := [32]byte{4, 5, 6}
+	blobKey, err := blobStore.StoreBlob(ctx, &core.Blob{Data: []byte("hello")}, uint64(time.Now().UnixNano()))
+	assert.NoError(t, err)
+	metadata, err := blobStore.GetBlobMetadata(ctx, blobKey)
+	assert.NoError(t, err)
+	_, err = blobStore.MarkBlobConfirmed(ctx, metadata, &disperser.ConfirmationInfo{
+		BatchHeaderHash:      batchHeaderHash,
+		ReferenceBlockNumber: 10,
+	})
+	assert.NoError(t, err)
+
+	// The batch is found but its operator state wasn't stored
+	_, err = server.GetOperatorStateAtBatch(ctx, &pb.OperatorStateAtBatchRequest{BatchHeaderHash: batchHeaderHash[:]})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	err = blobStore.StoreOperatorState(ctx, batchHeaderHash, &disperser.OperatorStateSnapshot{
+		ReferenceBlockNumber: 10,
+		QuantizationFactor:   batcher.QuantizationFactor,
+		State:                state,
+	})
+	assert.NoError(t, err)
+
+	// Reassemble the operator state from its pages
+	pageSize := 3
+	reassembled := &core.OperatorState{
+		Operators: make(map[core.QuorumID]map[core.OperatorID]*core.OperatorInfo),
+		Totals:    make(map[core.QuorumID]*core.OperatorInfo),
 	}
-	s3Client, err := s3.NewClient(context.Background(), awsConfig, logger)
-	if err != nil {
-		panic("failed to create s3 client")
+	pageToken := ""
+	numPages := 0
+	for {
+		reply, err := server.GetOperatorStateAtBatch(ctx, &pb.OperatorStateAtBatchRequest{
+			BatchHeaderHash: batchHeaderHash[:],
+			PageSize:        uint32(pageSize),
+			PageToken:       pageToken,
+		})
+		assert.NoError(t, err)
+		numPages++
+		assert.Equal(t, uint32(10), reply.GetReferenceBlockNumber())
+		assert.Equal(t, uint32(batcher.QuantizationFactor), reply.GetQuantizationFactor())
+		assert.LessOrEqual(t, len(reply.GetOperators()), pageSize)
+		for _, total := range reply.GetQuorumTotals() {
+			reassembled.Totals[core.QuorumID(total.GetQuorumNumber())] = &core.OperatorInfo{
+				Stake: new(big.Int).SetBytes(total.GetStake()),
+				Index: core.OperatorIndex(total.GetNumOperators()),
+			}
+		}
+		for _, operator := range reply.GetOperators() {
+			quorumID := core.QuorumID(operator.GetQuorumNumber())
+			if reassembled.Operators[quorumID] == nil {
+				reassembled.Operators[quorumID] = make(map[core.OperatorID]*core.OperatorInfo)
+			}
+			var operatorID core.OperatorID
+			copy(operatorID[:], operator.GetOperatorId())
+			reassembled.Operators[quorumID][operatorID] = &core.OperatorInfo{
+				Stake: new(big.Int).SetBytes(operator.GetStake()),
+				Index: core.OperatorIndex(operator.GetIndex()),
+			}
+		}
+		pageToken = reply.GetNextPageToken()
+		if pageToken == "" {
+			break
+		}
 	}
-	dynamoClient, err := dynamodb.NewClient(awsConfig, logger)
-	if err != nil {
-		panic("failed to create dynamoDB client")
+	numOperators := 0
+	for _, operators := range state.Operators {
+		numOperators += len(operators)
 	}
-	blobMetadataStore := blobstore.NewBlobMetadataStore(dynamoClient, logger, metadataTableName, time.Hour)
+	assert.Equal(t, (numOperators+pageSize-1)/pageSize, numPages)
+	assert.Equal(t, state.Operators, reassembled.Operators)
+	assert.Equal(t, state.Totals, reassembled.Totals)
 
-	var ratelimiter common.RateLimiter
-	rateConfig := apiserver.RateConfig{
-		QuorumRateInfos: map[core.QuorumID]apiserver.QuorumRateInfo{
-			0: {
-				PerUserUnauthThroughput: 0,
-				TotalUnauthThroughput:   0,
-			},
+	_, err = server.GetOperatorStateAtBatch(ctx, &pb.OperatorStateAtBatchRequest{BatchHeaderHash: batchHeaderHash[:], PageToken: "abc"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = server.GetOperatorStateAtBatch(ctx, &pb.OperatorStateAtBatchRequest{BatchHeaderHash: []byte{1, 2}})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	unknown := [32]byte{7, 8, 9}
+	_, err = server.GetOperatorStateAtBatch(ctx, &pb.OperatorStateAtBatchRequest{BatchHeaderHash: unknown[:]})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+// trailerStream records the trailer of a unary call
+type trailerStream struct {
+	grpc.ServerTransportStream
+	trailer metadata.MD
+}
+
+func (s *trailerStream) SetTrailer(md metadata.MD) error {
+	s.trailer = metadata.Join(s.trailer, md)
+	return nil
+}
+
+func TestRetrieveBlobFallback(t *testing.T) {
+	logger, err := logging.GetLogger(logging.DefaultCLIConfig())
+	assert.NoError(t, err)
+	blobStore := inmem.NewBlobStore()
+	tx := &mock.MockTransactor{}
+	tx.On("GetCurrentBlockNumber").Return(uint32(100), nil)
+	tx.On("GetQuorumCount").Return(uint16(2), nil)
+	tx.On("GetNumberOfRegisteredOperatorForQuorum").Return(uint32(4), nil)
+	server := apiserver.NewDispersalServer(disperser.ServerConfig{
+		GrpcPort: "51006",
+	}, blobStore, tx, logger, disperser.NewMetrics("9006", nil, logger), nil, apiserver.RateConfig{})
+
+	ctx := context.Background()
+	data := make([]byte, 1024)
+	_, err = rand.Read(data)
+	assert.NoError(t, err)
+	blobKey, err := blobStore.StoreBlob(ctx, &core.Blob{Data: data}, uint64(time.Now().UnixNano()))
+	assert.NoError(t, err)
+	metadata, err := blobStore.GetBlobMetadata(ctx, blobKey)
+	assert.NoError(t, err)
+	batchHeaderHash := [32]byte{4, 5, 6}
+	_, err = blobStore.MarkBlobConfirmed(ctx, metadata, &disperser.ConfirmationInfo{
+		BatchHeaderHash:      batchHeaderHash,
+		BlobIndex:            3,
+		ReferenceBlockNumber: 132,
+		BatchRoot:            []byte("hello"),
+		BlobQuorumInfos: []*core.BlobQuorumInfo{
+			{SecurityParam: core.SecurityParam{QuorumID: 0}, EncodedBlobLength: 64},
+			{SecurityParam: core.SecurityParam{QuorumID: 1}, EncodedBlobLength: 32},
 		},
-		ClientIPHeader: "",
+	})
+	assert.NoError(t, err)
+
+	retrieve := func() ([]byte, string, error) {
+		stream := &trailerStream{}
+		reply, err := server.RetrieveBlob(grpc.NewContextWithServerTransportStream(ctx, stream), &pb.RetrieveBlobRequest{
+			BatchHeaderHash: batchHeaderHash[:],
+			BlobIndex:       3,
+		})
+		source := stream.trailer.Get(apiserver.BlobSourceTrailer)
+		if len(source) == 0 {
+			return reply.GetData(), "", err
+		}
+		return reply.GetData(), source[0], err
 	}
 
-	queue = blobstore.NewSharedStorage(bucketName, s3Client, blobMetadataStore, logger)
+	// The blob is read from the stored content while it's there
+	retrievalClient := clientsmock.NewRetrievalClient()
+	server.SetRetrievalClient(retrievalClient)
+	retrieved, source, err := retrieve()
+	assert.NoError(t, err)
+	assert.Equal(t, data, retrieved)
+	assert.Equal(t, apiserver.BlobSourceCache, source)
+	retrievalClient.AssertNotCalled(t, "RetrieveBlob")
+
+	// The blob is reconstructed from the operators once the content is gone, trying the next quorum on failure
+	delete(blobStore.(*inmem.BlobStore).Blobs, blobKey.BlobHash)
+	retrievalClient.On("RetrieveBlob").Return([]byte(nil), errors.New("not enough chunks")).Once()
+	retrievalClient.On("RetrieveBlob").Return(append(data, make([]byte, 32)...), nil).Once()
+	retrieved, source, err = retrieve()
+	assert.NoError(t, err)
+	assert.Equal(t, data, retrieved)
+	assert.Equal(t, apiserver.BlobSourceReconstructed, source)
+	retrievalClient.AssertNumberOfCalls(t, "RetrieveBlob", 2)
+
+	retrievalClient.On("RetrieveBlob").Return([]byte(nil), errors.New("not enough chunks")).Twice()
+	_, _, err = retrieve()
+	assert.ErrorContains(t, err, "not enough chunks")
+
+	// The missing content is an error without the fallback
+	server.SetRetrievalClient(nil)
+	_, _, err = retrieve()
+	assert.ErrorIs(t, err, disperser.ErrBlobNotFound)
+}
+
+func TestRetrieveBlobUnalignedLength(t *testing.T) {
+	logger, err := logging.GetLogger(logging.DefaultCLIConfig())
+	assert.NoError(t, err)
+	blobStore := inmem.NewBlobStore()
 	tx := &mock.MockTransactor{}
 	tx.On("GetCurrentBlockNumber").Return(uint32(100), nil)
 	tx.On("GetQuorumCount").Return(uint16(2), nil)
+	tx.On("GetNumberOfRegisteredOperatorForQuorum").Return(uint32(4), nil)
+	server := apiserver.NewDispersalServer(disperser.ServerConfig{
+		GrpcPort: "51016",
+	}, blobStore, tx, logger, disperser.NewMetrics("9016", nil, logger), nil, apiserver.RateConfig{})
+	retrievalClient := clientsmock.NewRetrievalClient()
+	server.SetRetrievalClient(retrievalClient)
 
-	return apiserver.NewDispersalServer(disperser.ServerConfig{
-		GrpcPort: "51001",
-	}, queue, tx, logger, disperser.NewMetrics("9001", logger), ratelimiter, rateConfig)
+	// A payload which is neither a power of two nor a whole number of symbols
+	ctx := context.Background()
+	data := make([]byte, 1000)
+	_, err = rand.Read(data)
+	assert.NoError(t, err)
+	data[len(data)-1] = 1
+	blobKey, err := blobStore.StoreBlob(ctx, &core.Blob{Data: data}, uint64(time.Now().UnixNano()))
+	assert.NoError(t, err)
+	metadata, err := blobStore.GetBlobMetadata(ctx, blobKey)
+	assert.NoError(t, err)
+	_, err = blobStore.MarkBlobConfirmed(ctx, metadata, &disperser.ConfirmationInfo{
+		BatchHeaderHash: [32]byte{1, 2, 3},
+		BlobIndex:       4,
+		BlobQuorumInfos: []*core.BlobQuorumInfo{
+			{SecurityParam: core.SecurityParam{QuorumID: 0}, EncodedBlobLength: 64},
+		},
+	})
+	assert.NoError(t, err)
+
+	retrieveData, err := retrieveBlob(t, server, 4)
+	assert.NoError(t, err)
+	assert.Equal(t, data, retrieveData)
+
+	// The stored content padded to a whole number of symbols is trimmed to the dispersed bytes
+	padded := append(append([]byte{}, data...), make([]byte, 24)...)
+	blobStore.(*inmem.BlobStore).Blobs[blobKey.BlobHash].Data = padded
+	retrieveData, err = retrieveBlob(t, server, 4)
+	assert.NoError(t, err)
+	assert.Equal(t, data, retrieveData)
+
+	// So is the blob reconstructed from the operators, padded to the length of the encoded blob
+	delete(blobStore.(*inmem.BlobStore).Blobs, blobKey.BlobHash)
+	retrievalClient.On("RetrieveBlob").Return(append(append([]byte{}, data...), make([]byte, 64*32-len(data))...), nil).Once()
+	retrieveData, err = retrieveBlob(t, server, 4)
+	assert.NoError(t, err)
+	assert.Equal(t, data, retrieveData)
+
+	// Non-zero bytes past the size of the blob aren't padding
+	corrupted := append(append([]byte{}, data...), 0, 0, 1)
+	retrievalClient.On("RetrieveBlob").Return(corrupted, nil).Once()
+	_, err = retrieveBlob(t, server, 4)
+	assert.ErrorIs(t, err, disperser.ErrBlobIntegrity)
+}
+
+func TestRetrieveBlobNotConfirmed(t *testing.T) {
+	logger, err := logging.GetLogger(logging.DefaultCLIConfig())
+	assert.NoError(t, err)
+	blobStore := inmem.NewBlobStore()
+	tx := &mock.MockTransactor{}
+	server := apiserver.NewDispersalServer(disperser.ServerConfig{
+		GrpcPort: "51007",
+	}, blobStore, tx, logger, disperser.NewMetrics("9007", nil, logger), nil, apiserver.RateConfig{})
+	retrievalClient := clientsmock.NewRetrievalClient()
+	server.SetRetrievalClient(retrievalClient)
+
+	ctx := context.Background()
+	blobKey, err := blobStore.StoreBlob(ctx, &core.Blob{Data: []byte("hello")}, uint64(time.Now().UnixNano()))
+	assert.NoError(t, err)
+	metadata, err := blobStore.GetBlobMetadata(ctx, blobKey)
+	assert.NoError(t, err)
+	batchHeaderHash := [32]byte{7, 8, 9}
+	_, err = blobStore.MarkBlobInsufficientSignatures(ctx, metadata, &disperser.ConfirmationInfo{
+		BatchHeaderHash: batchHeaderHash,
+		BlobIndex:       0,
+	})
+	assert.NoError(t, err)
+
+	_, err = server.RetrieveBlob(ctx, &pb.RetrieveBlobRequest{
+		BatchHeaderHash: batchHeaderHash[:],
+		BlobIndex:       0,
+	})
+	assert.ErrorContains(t, err, "not confirmed")
+	retrievalClient.AssertNotCalled(t, "RetrieveBlob")
+}
+
+func TestRetrieveBlobFailsWhenBlobNotConfirmed(t *testing.T) {
+	// Create random data
+	data := make([]byte, 1024)
+	_, err := rand.Read(data)
+	assert.NoError(t, err)
+
+	// Disperse the random data
+	status, _, requestID := disperseBlob(t, dispersalServer, data)
+	assert.Equal(t, status, pb.BlobStatus_PROCESSING)
+	assert.NotNil(t, requestID)
+
+	reply, err := dispersalServer.GetBlobStatus(context.Background(), &pb.BlobStatusRequest{
+		RequestId: requestID,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, reply.GetStatus(), pb.BlobStatus_PROCESSING)
+
+	// Try to retrieve the blob before it is confirmed
+	_, err = retrieveBlob(t, dispersalServer, 2)
+	assert.Error(t, err)
+}
+
+// cancelingBlobStore cancels the request while the blob is being stored, as if the client went away
+type cancelingBlobStore struct {
+	disperser.BlobStore
+	cancel context.CancelFunc
+}
+
+func (s *cancelingBlobStore) StoreBlob(ctx context.Context, blob *core.Blob, requestedAt uint64) (disperser.BlobKey, error) {
+	s.cancel()
+	return disperser.BlobKey{}, ctx.Err()
+}
+
+func TestDisperseBlobCanceled(t *testing.T) {
+	logger, err := logging.GetLogger(logging.DefaultCLIConfig())
+	assert.NoError(t, err)
+	tx := &mock.MockTransactor{}
+	tx.On("GetCurrentBlockNumber").Return(uint32(100), nil)
+	tx.On("GetQuorumCount").Return(uint16(2), nil)
+	tx.On("GetNumberOfRegisteredOperatorForQuorum").Return(uint32(4), nil)
+
+	ctx, cancel := context.WithCancel(peer.NewContext(context.Background(), &peer.Peer{
+		Addr: &net.TCPAddr{
+			IP:   net.ParseIP("0.0.0.0"),
+			Port: 51001,
+		},
+	}))
+	defer cancel()
+	server := apiserver.NewDispersalServer(disperser.ServerConfig{
+		GrpcPort: "51004",
+	}, &cancelingBlobStore{BlobStore: inmem.NewBlobStore(), cancel: cancel}, tx, logger, disperser.NewMetrics("9004", nil, logger), nil, apiserver.RateConfig{})
+
+	_, err = server.DisperseBlob(ctx, &pb.DisperseBlobRequest{
+		Data: []byte("hello"),
+		SecurityParams: []*pb.SecurityParams{
+			{
+				QuorumId:           0,
+				AdversaryThreshold: 50,
+				QuorumThreshold:    100,
+			},
+		},
+	})
+	assert.Equal(t, codes.Canceled, status.Code(err))
+}
+
+// stallingBlobStore doesn't store the blobs before the request is done, as if the backend were too slow to serve it
+type stallingBlobStore struct {
+	disperser.BlobStore
+	deadline time.Time
+}
+
+func (s *stallingBlobStore) StoreBlob(ctx context.Context, blob *core.Blob, requestedAt uint64) (disperser.BlobKey, error) {
+	s.deadline, _ = ctx.Deadline()
+	<-ctx.Done()
+	return disperser.BlobKey{}, ctx.Err()
+}
+
+func TestDisperseBlobDeadlineExceeded(t *testing.T) {
+	logger, err := logging.GetLogger(logging.DefaultCLIConfig())
+	assert.NoError(t, err)
+	tx := &mock.MockTransactor{}
+	tx.On("GetCurrentBlockNumber").Return(uint32(100), nil)
+	tx.On("GetQuorumCount").Return(uint16(2), nil)
+	tx.On("GetNumberOfRegisteredOperatorForQuorum").Return(uint32(4), nil)
+
+	request := &pb.DisperseBlobRequest{
+		Data:           []byte("hello"),
+		SecurityParams: []*pb.SecurityParams{{QuorumId: 0, AdversaryThreshold: 50, QuorumThreshold: 100}},
+	}
+	peerCtx := peer.NewContext(context.Background(), &peer.Peer{
+		Addr: &net.TCPAddr{IP: net.ParseIP("0.0.0.0"), Port: 51001},
+	})
+	newServer := func(timeout time.Duration) (*apiserver.DispersalServer, *stallingBlobStore) {
+		blobStore := &stallingBlobStore{BlobStore: inmem.NewBlobStore()}
+		return apiserver.NewDispersalServer(disperser.ServerConfig{
+			GrpcPort:               "51010",
+			DisperseRequestTimeout: timeout,
+		}, blobStore, tx, logger, disperser.NewMetrics("9010", nil, logger), nil, apiserver.RateConfig{}), blobStore
+	}
+
+	// The server aborts the request once its timeout passes
+	server, blobStore := newServer(50 * time.Millisecond)
+	start := time.Now()
+	_, err = server.DisperseBlob(peerCtx, request)
+	assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+	assert.Less(t, time.Since(start), 5*time.Second)
+	assert.WithinDuration(t, start.Add(50*time.Millisecond), blobStore.deadline, 40*time.Millisecond)
+
+	// The client's deadline applies when it is earlier than the server's timeout
+	server, blobStore = newServer(time.Hour)
+	ctx, cancel := context.WithTimeout(peerCtx, 50*time.Millisecond)
+	defer cancel()
+	clientDeadline, _ := ctx.Deadline()
+	_, err = server.DisperseBlob(ctx, request)
+	assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+	assert.Equal(t, clientDeadline, blobStore.deadline)
+}
+
+func TestDisperseBlobMaxQuorumsPerBlob(t *testing.T) {
+	logger, err := logging.GetLogger(logging.DefaultCLIConfig())
+	assert.NoError(t, err)
+	tx := &mock.MockTransactor{}
+	tx.On("GetCurrentBlockNumber").Return(uint32(100), nil)
+	tx.On("GetQuorumCount").Return(uint16(3), nil)
+	tx.On("GetNumberOfRegisteredOperatorForQuorum").Return(uint32(4), nil)
+	newServer := func(maxQuorumsPerBlob uint) *apiserver.DispersalServer {
+		return apiserver.NewDispersalServer(disperser.ServerConfig{
+			GrpcPort:          "51019",
+			MaxQuorumsPerBlob: maxQuorumsPerBlob,
+		}, inmem.NewBlobStore(), tx, logger, disperser.NewMetrics("9019", nil, logger), nil, apiserver.RateConfig{})
+	}
+	request := func(numQuorums int) *pb.DisperseBlobRequest {
+		params := make([]*pb.SecurityParams, numQuorums)
+		for i := range params {
+			params[i] = &pb.SecurityParams{QuorumId: uint32(i), AdversaryThreshold: 80, QuorumThreshold: 100}
+		}
+		return &pb.DisperseBlobRequest{Data: make([]byte, 100), SecurityParams: params}
+	}
+	ctx := peer.NewContext(context.Background(), &peer.Peer{
+		Addr: &net.TCPAddr{IP: net.ParseIP("0.0.0.0"), Port: 51001},
+	})
+
+	server := newServer(2)
+	_, err = server.DisperseBlob(ctx, request(2))
+	assert.NoError(t, err)
+	_, err = server.DisperseBlob(ctx, request(3))
+	assert.ErrorContains(t, err, "invalid request: a blob can be dispersed to at most 2 quorums, but found 3")
+	assertErrorDetails(t, err, codes.InvalidArgument, disperser.ReasonTooManyQuorums, "security_params")
+
+	// By default, a blob can be dispersed to all the onchain quorums
+	server = newServer(0)
+	_, err = server.DisperseBlob(ctx, request(3))
+	assert.NoError(t, err)
+	params := request(3).GetSecurityParams()
+	_, err = server.DisperseBlob(ctx, &pb.DisperseBlobRequest{
+		Data:           make([]byte, 100),
+		SecurityParams: append(params, &pb.SecurityParams{QuorumId: 3, AdversaryThreshold: 80, QuorumThreshold: 100}),
+	})
+	assert.ErrorContains(t, err, "invalid request: a blob can be dispersed to at most 3 quorums, but found 4")
+	assertErrorDetails(t, err, codes.InvalidArgument, disperser.ReasonTooManyQuorums, "security_params")
+}
+
+func TestDisperseBlobSecurityPolicy(t *testing.T) {
+	logger, err := logging.GetLogger(logging.DefaultCLIConfig())
+	assert.NoError(t, err)
+	tx := &mock.MockTransactor{}
+	tx.On("GetCurrentBlockNumber").Return(uint32(100), nil)
+	tx.On("GetQuorumCount").Return(uint16(3), nil)
+	tx.On("GetNumberOfRegisteredOperatorForQuorum").Return(uint32(4), nil)
+	server := apiserver.NewDispersalServer(disperser.ServerConfig{
+		GrpcPort: "51020",
+		SecurityPolicy: disperser.SecurityPolicy{
+			Default: disperser.SecurityParamBounds{MinAdversaryThreshold: 10},
+			Quorums: map[core.QuorumID]disperser.SecurityParamBounds{
+				0: {MinAdversaryThreshold: 33, MaxAdversaryThreshold: 50, MinQuorumThreshold: 55, MaxQuorumThreshold: 90},
+				1: {AllowedQuantizationFactors: []uint{2, 4}},
+			},
+		},
+	}, inmem.NewBlobStore(), tx, logger, disperser.NewMetrics("9020", nil, logger), nil, apiserver.RateConfig{})
+	ctx := peer.NewContext(context.Background(), &peer.Peer{
+		Addr: &net.TCPAddr{IP: net.ParseIP("0.0.0.0"), Port: 51001},
+	})
+	disperse := func(param *pb.SecurityParams) error {
+		_, err := server.DisperseBlob(ctx, &pb.DisperseBlobRequest{Data: make([]byte, 100), SecurityParams: []*pb.SecurityParams{param}})
+		return err
+	}
+
+	// The bounds of quorum 0 are inclusive
+	assert.NoError(t, disperse(&pb.SecurityParams{QuorumId: 0, AdversaryThreshold: 33, QuorumThreshold: 55}))
+	assert.NoError(t, disperse(&pb.SecurityParams{QuorumId: 0, AdversaryThreshold: 50, QuorumThreshold: 90}))
+
+	err = disperse(&pb.SecurityParams{QuorumId: 0, AdversaryThreshold: 32, QuorumThreshold: 55})
+	assert.ErrorContains(t, err, "invalid request: adversary_threshold of quorum 0 must be at least 33, but found 32")
+	assertErrorDetails(t, err, codes.InvalidArgument, disperser.ReasonSecurityPolicyViolation, "security_params[0].adversary_threshold")
+	err = disperse(&pb.SecurityParams{QuorumId: 0, AdversaryThreshold: 51, QuorumThreshold: 90})
+	assert.ErrorContains(t, err, "invalid request: adversary_threshold of quorum 0 must be at most 50, but found 51")
+	assertErrorDetails(t, err, codes.InvalidArgument, disperser.ReasonSecurityPolicyViolation, "security_params[0].adversary_threshold")
+	err = disperse(&pb.SecurityParams{QuorumId: 0, AdversaryThreshold: 33, QuorumThreshold: 54})
+	assert.ErrorContains(t, err, "invalid request: quorum_threshold of quorum 0 must be at least 55, but found 54")
+	assertErrorDetails(t, err, codes.InvalidArgument, disperser.ReasonSecurityPolicyViolation, "security_params[0].quorum_threshold")
+	err = disperse(&pb.SecurityParams{QuorumId: 0, AdversaryThreshold: 33, QuorumThreshold: 91})
+	assert.ErrorContains(t, err, "invalid request: quorum_threshold of quorum 0 must be at most 90, but found 91")
+	assertErrorDetails(t, err, codes.InvalidArgument, disperser.ReasonSecurityPolicyViolation, "security_params[0].quorum_threshold")
+
+	// Quorum 1 doesn't allow the quantization factor the blobs are assigned with
+	err = disperse(&pb.SecurityParams{QuorumId: 1, AdversaryThreshold: 80, QuorumThreshold: 100})
+	assert.ErrorContains(t, err, "invalid request: quorum 1 only allows the quantization factors [2 4], but blobs are assigned with 1")
+	assertErrorDetails(t, err, codes.InvalidArgument, disperser.ReasonSecurityPolicyViolation, "security_params[0].quorum_id")
+
+	// Quorum 2 has no bounds of its own, so the default ones apply
+	assert.NoError(t, disperse(&pb.SecurityParams{QuorumId: 2, AdversaryThreshold: 10, QuorumThreshold: 100}))
+	err = disperse(&pb.SecurityParams{QuorumId: 2, AdversaryThreshold: 9, QuorumThreshold: 100})
+	assert.ErrorContains(t, err, "invalid request: adversary_threshold of quorum 2 must be at least 10, but found 9")
+	assertErrorDetails(t, err, codes.InvalidArgument, disperser.ReasonSecurityPolicyViolation, "security_params[0].adversary_threshold")
+
+	// Without a policy, only the protocol requirements apply
+	server.UpdateSecurityPolicy(disperser.SecurityPolicy{})
+	assert.NoError(t, disperse(&pb.SecurityParams{QuorumId: 0, AdversaryThreshold: 1, QuorumThreshold: 100}))
+	assert.NoError(t, disperse(&pb.SecurityParams{QuorumId: 1, AdversaryThreshold: 80, QuorumThreshold: 100}))
+}
+
+func TestGetDisperserConfig(t *testing.T) {
+	logger, err := logging.GetLogger(logging.DefaultCLIConfig())
+	require.NoError(t, err)
+	policyFile := filepath.Join(t.TempDir(), "policy.json")
+	require.NoError(t, os.WriteFile(policyFile, []byte(`{"default": {"min_adversary_threshold": 10}, "quorums": {"1": {"allowed_quantization_factors": [1, 2]}, "0": {"min_adversary_threshold": 33, "min_quorum_threshold": 55}}}`), 0644))
+	policy, err := disperser.ReadSecurityPolicy(policyFile)
+	require.NoError(t, err)
+
+	server := apiserver.NewDispersalServer(disperser.ServerConfig{
+		GrpcPort:                      "51021",
+		SecurityPolicy:                policy,
+		SecurityPolicyFile:            policyFile,
+		SecurityPolicyRefreshInterval: 10 * time.Millisecond,
+	}, inmem.NewBlobStore(), &mock.MockTransactor{}, logger, disperser.NewMetrics("9021", nil, logger), nil, apiserver.RateConfig{})
+	startServer(t, server, "51021")
+
+	reply, err := server.GetDisperserConfig(context.Background(), &pb.DisperserConfigRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, uint32(batcher.QuantizationFactor), reply.GetQuantizationFactor())
+	assert.Equal(t, uint32(10), reply.GetSecurityPolicy().GetDefaultBounds().GetMinAdversaryThreshold())
+	// The quorums are ordered by ID
+	quorumBounds := reply.GetSecurityPolicy().GetQuorumBounds()
+	require.Len(t, quorumBounds, 2)
+	assert.Equal(t, uint32(0), quorumBounds[0].GetQuorumId())
+	assert.Equal(t, uint32(33), quorumBounds[0].GetBounds().GetMinAdversaryThreshold())
+	assert.Equal(t, uint32(55), quorumBounds[0].GetBounds().GetMinQuorumThreshold())
+	assert.Equal(t, uint32(1), quorumBounds[1].GetQuorumId())
+	assert.Equal(t, []uint32{1, 2}, quorumBounds[1].GetBounds().GetAllowedQuantizationFactors())
+
+	// A change of the policy file is picked up without restarting the server
+	require.NoError(t, os.WriteFile(policyFile, []byte(`{"quorums": {"0": {"max_adversary_threshold": 40}}}`), 0644))
+	require.Eventually(t, func() bool {
+		reply, err := server.GetDisperserConfig(context.Background(), &pb.DisperserConfigRequest{})
+		require.NoError(t, err)
+		bounds := reply.GetSecurityPolicy().GetQuorumBounds()
+		return len(bounds) == 1 && bounds[0].GetBounds().GetMaxAdversaryThreshold() == 40
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// An invalid policy is rejected, and the current one remains in effect
+	require.NoError(t, os.WriteFile(policyFile, []byte(`{"quorums": {"0": {"min_adversary_threshold": 50, "max_adversary_threshold": 40}}}`), 0644))
+	time.Sleep(100 * time.Millisecond)
+	reply, err = server.GetDisperserConfig(context.Background(), &pb.DisperserConfigRequest{})
+	require.NoError(t, err)
+	require.Len(t, reply.GetSecurityPolicy().GetQuorumBounds(), 1)
+	assert.Equal(t, uint32(40), reply.GetSecurityPolicy().GetQuorumBounds()[0].GetBounds().GetMaxAdversaryThreshold())
+	assert.Equal(t, uint32(0), reply.GetSecurityPolicy().GetQuorumBounds()[0].GetBounds().GetMinAdversaryThreshold())
+}
+
+func TestDisperseBlobDryRun(t *testing.T) {
+	logger, err := logging.GetLogger(logging.DefaultCLIConfig())
+	require.NoError(t, err)
+	tx := &mock.MockTransactor{}
+	tx.On("GetCurrentBlockNumber").Return(uint32(100), nil)
+	tx.On("GetQuorumCount").Return(uint16(2), nil)
+	tx.On("GetNumberOfRegisteredOperatorForQuorum").Return(uint32(4), nil)
+	blobStore := inmem.NewBlobStore()
+	server := apiserver.NewDispersalServer(disperser.ServerConfig{
+		GrpcPort: "51022",
+	}, blobStore, tx, logger, disperser.NewMetrics("9022", nil, logger), nil, apiserver.RateConfig{})
+	encoder, err := encoding.NewEncoder(encoding.EncoderConfig{
+		KzgConfig: kzgEncoder.KzgConfig{
+			G1Path:    "../../inabox/resources/kzg/g1.point",
+			G2Path:    "../../inabox/resources/kzg/g2.point",
+			CacheDir:  "../../inabox/resources/kzg/SRSTables",
+			SRSOrder:  3000,
+			NumWorker: uint64(runtime.GOMAXPROCS(0)),
+		},
+	})
+	require.NoError(t, err)
+	ctx := peer.NewContext(context.Background(), &peer.Peer{
+		Addr: &net.TCPAddr{IP: net.ParseIP("0.0.0.0"), Port: 51001},
+	})
+	data := make([]byte, 1000)
+	_, err = rand.Read(data)
+	require.NoError(t, err)
+	request := &pb.DisperseBlobRequest{
+		Data: data,
+		SecurityParams: []*pb.SecurityParams{
+			{QuorumId: 0, AdversaryThreshold: 50, QuorumThreshold: 100},
+			{QuorumId: 1, AdversaryThreshold: 80, QuorumThreshold: 100},
+		},
+		DryRun: true,
+	}
+
+	// Without an encoder, the dry run returns no commitment
+	reply, err := server.DisperseBlob(ctx, request)
+	require.NoError(t, err)
+	assert.Empty(t, reply.GetCommitment())
+
+	server.SetEncoder(encoder)
+	reply, err = server.DisperseBlob(ctx, request)
+	require.NoError(t, err)
+	assert.Equal(t, pb.BlobStatus_UNKNOWN, reply.GetResult())
+	assert.Empty(t, reply.GetRequestId())
+	assert.Equal(t, uint32(33), reply.GetDataLength())
+	// The params are the ones of a real dispersal
+	require.Len(t, reply.GetBlobQuorumParams(), 2)
+	assert.Equal(t, uint64(32*4), reply.GetBlobQuorumParams()[0].GetEncodedLength())
+	assert.Equal(t, uint64(64*4), reply.GetBlobQuorumParams()[1].GetEncodedLength())
+
+	// The commitment is the one the batcher computes, whatever the encoding params
+	params, err := core.GetEncodingParams(32, 4)
+	require.NoError(t, err)
+	commitments, _, err := encoder.Encode(data, params)
+	require.NoError(t, err)
+	commitment, err := commitments.Commitment.Serialize()
+	require.NoError(t, err)
+	assert.Equal(t, commitment, reply.GetCommitment())
+
+	// Nothing was stored
+	metadata, err := blobStore.GetBlobMetadataByStatus(context.Background(), disperser.Processing)
+	require.NoError(t, err)
+	assert.Empty(t, metadata)
+
+	// The request is validated like a dispersal
+	request.SecurityParams[1].QuorumId = 2
+	_, err = server.DisperseBlob(ctx, request)
+	assertErrorDetails(t, err, codes.InvalidArgument, disperser.ReasonInvalidQuorum, "security_params[1].quorum_id")
+}
+
+func TestDisperseBlobDryRunRateLimited(t *testing.T) {
+	server := newTestServerWithRatelimiter(t, apiserver.QuorumRateInfo{
+		PerUserUnauthThroughput: 10_000,
+		TotalUnauthThroughput:   100_000,
+	}, nil)
+	data := make([]byte, 1024)
+	_, err := rand.Read(data)
+	require.NoError(t, err)
+	ctx := peer.NewContext(context.Background(), &peer.Peer{
+		Addr: &net.TCPAddr{IP: net.ParseIP("1.1.1.1"), Port: 51001},
+	})
+	request := &pb.DisperseBlobRequest{
+		Data:           data,
+		SecurityParams: []*pb.SecurityParams{{QuorumId: 0, AdversaryThreshold: 80, QuorumThreshold: 100}},
+		DryRun:         true,
+	}
+
+	// The dry runs are charged like dispersals, so that they can't be abused
+	_, err = server.DisperseBlob(ctx, request)
+	assert.NoError(t, err)
+	_, err = server.DisperseBlob(ctx, request)
+	assert.ErrorContains(t, err, "request ratelimited")
+	_, err = disperseBlobFrom(server, "1.1.1.1", data)
+	assert.ErrorContains(t, err, "request ratelimited")
+}
+
+func TestDisperseBlobEstimatesEncodedLength(t *testing.T) {
+	logger, err := logging.GetLogger(logging.DefaultCLIConfig())
+	assert.NoError(t, err)
+	newServer := func(tx core.Transactor) *apiserver.DispersalServer {
+		return apiserver.NewDispersalServer(disperser.ServerConfig{
+			GrpcPort: "51012",
+		}, inmem.NewBlobStore(), tx, logger, disperser.NewMetrics("9012", nil, logger), nil, apiserver.RateConfig{})
+	}
+	request := &pb.DisperseBlobRequest{
+		Data: make([]byte, 1000),
+		SecurityParams: []*pb.SecurityParams{
+			{QuorumId: 0, AdversaryThreshold: 50, QuorumThreshold: 100},
+			{QuorumId: 1, AdversaryThreshold: 80, QuorumThreshold: 100},
+		},
+	}
+	ctx := peer.NewContext(context.Background(), &peer.Peer{
+		Addr: &net.TCPAddr{IP: net.ParseIP("0.0.0.0"), Port: 51001},
+	})
+
+	tx := &mock.MockTransactor{}
+	tx.On("GetCurrentBlockNumber").Return(uint32(100), nil)
+	tx.On("GetQuorumCount").Return(uint16(2), nil)
+	tx.On("GetNumberOfRegisteredOperatorForQuorum").Return(uint32(4), nil)
+	server := newServer(tx)
+
+	// The blob has 33 symbols. With 4 operators, quorum 0 needs 2 systematic chunks of 17 symbols, rounded up to 32,
+	// and quorum 1 a single chunk of 33 symbols, rounded up to 64.
+	reply, err := server.DisperseBlob(ctx, request)
+	assert.NoError(t, err)
+	assert.Equal(t, []*pb.BlobQuorumParam{
+		{QuorumNumber: 0, AdversaryThresholdPercentage: 50, QuorumThresholdPercentage: 100, QuantizationParam: 1, EncodedLength: 128},
+		{QuorumNumber: 1, AdversaryThresholdPercentage: 80, QuorumThresholdPercentage: 100, QuantizationParam: 1, EncodedLength: 256},
+	}, reply.GetBlobQuorumParams())
+
+	// The numbers of operators are cached
+	_, err = server.DisperseBlob(ctx, request)
+	assert.NoError(t, err)
+	tx.AssertNumberOfCalls(t, "GetNumberOfRegisteredOperatorForQuorum", 2)
+
+	// The blob is accepted without the estimate if the operator set can't be read
+	tx = &mock.MockTransactor{}
+	tx.On("GetCurrentBlockNumber").Return(uint32(100), nil)
+	tx.On("GetQuorumCount").Return(uint16(2), nil)
+	tx.On("GetNumberOfRegisteredOperatorForQuorum").Return(uint32(0), errors.New("rpc unavailable"))
+	reply, err = newServer(tx).DisperseBlob(ctx, request)
+	assert.NoError(t, err)
+	assert.Equal(t, pb.BlobStatus_PROCESSING, reply.GetResult())
+	assert.NotEmpty(t, reply.GetRequestId())
+	assert.Empty(t, reply.GetBlobQuorumParams())
+}
+
+func TestExtendBlobRetention(t *testing.T) {
+	logger, err := logging.GetLogger(logging.DefaultCLIConfig())
+	assert.NoError(t, err)
+	tx := &mock.MockTransactor{}
+	tx.On("GetCurrentBlockNumber").Return(uint32(100), nil)
+	tx.On("GetQuorumCount").Return(uint16(2), nil)
+	tx.On("GetNumberOfRegisteredOperatorForQuorum").Return(uint32(4), nil)
+	newServer := func(maxRetention time.Duration) (*apiserver.DispersalServer, *inmem.BlobStore) {
+		blobStore := inmem.NewBlobStore().(*inmem.BlobStore)
+		return apiserver.NewDispersalServer(disperser.ServerConfig{
+			GrpcPort:         "51011",
+			MaxBlobRetention: maxRetention,
+		}, blobStore, tx, logger, disperser.NewMetrics("9011", nil, logger), nil, apiserver.RateConfig{
+			TrustedAPIKeys: []string{"secret"},
+		}), blobStore
+	}
+	withAPIKey := func(key string) context.Context {
+		p := &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("1.1.1.1"), Port: 51001}}
+		return metadata.NewIncomingContext(peer.NewContext(context.Background(), p), metadata.Pairs(apiserver.TrustedAPIKeyHeader, key))
+	}
+
+	server, blobStore := newServer(2 * time.Hour)
+	reply, err := disperseBlobFrom(server, "1.1.1.1", []byte("hello"))
+	assert.NoError(t, err)
+	blobKey, err := disperser.ParseBlobKey(string(reply.GetRequestId()))
+	assert.NoError(t, err)
+	initialExpiry := uint64(time.Now().Add(time.Hour).Unix())
+	blobStore.Metadata[blobKey].Expiry = initialExpiry
+	extend := func(ctx context.Context, server *apiserver.DispersalServer, additional time.Duration) (*pb.ExtendBlobRetentionReply, error) {
+		return server.ExtendBlobRetention(ctx, &pb.ExtendBlobRetentionRequest{
+			RequestId:                 reply.GetRequestId(),
+			AdditionalDurationSeconds: uint64(additional / time.Second),
+		})
+	}
+
+	// Only the trusted callers can extend the retention
+	_, err = extend(withAPIKey("other"), server, 30*time.Minute)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	// The extension is added to the current expiry and recorded in the metadata
+	extended, err := extend(withAPIKey("secret"), server, 30*time.Minute)
+	assert.NoError(t, err)
+	assert.Equal(t, initialExpiry+1800, extended.GetExpiry())
+	assert.Equal(t, initialExpiry+1800, blobStore.Metadata[blobKey].Expiry)
+	assert.Len(t, blobStore.Metadata[blobKey].RetentionExtensions, 1)
+	assert.Equal(t, initialExpiry, blobStore.Metadata[blobKey].RetentionExtensions[0].PreviousExpiry)
+	assert.Equal(t, initialExpiry+1800, blobStore.Metadata[blobKey].RetentionExtensions[0].Expiry)
+
+	// The total retention of the blob is capped
+	_, err = extend(withAPIKey("secret"), server, time.Hour)
+	assert.Equal(t, codes.OutOfRange, status.Code(err))
+	assert.Equal(t, initialExpiry+1800, blobStore.Metadata[blobKey].Expiry)
+
+	// The retention of an expired blob can't be extended
+	blobStore.Metadata[blobKey].Expiry = uint64(time.Now().Add(-time.Second).Unix())
+	_, err = extend(withAPIKey("secret"), server, time.Minute)
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	assert.Len(t, blobStore.Metadata[blobKey].RetentionExtensions, 1)
+
+	// Unknown blobs aren't found
+	_, err = server.ExtendBlobRetention(withAPIKey("secret"), &pb.ExtendBlobRetentionRequest{
+		RequestId:                 disperser.BlobKey{BlobHash: strings.Repeat("ab", 32), MetadataHash: "cd"}.RequestID(),
+		AdditionalDurationSeconds: 60,
+	})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	// The extensions are disabled without a maximum retention
+	server, blobStore = newServer(0)
+	reply, err = disperseBlobFrom(server, "1.1.1.1", []byte("hello"))
+	assert.NoError(t, err)
+	blobKey, err = disperser.ParseBlobKey(string(reply.GetRequestId()))
+	assert.NoError(t, err)
+	blobStore.Metadata[blobKey].Expiry = initialExpiry
+	_, err = extend(withAPIKey("secret"), server, time.Minute)
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	assert.Equal(t, initialExpiry, blobStore.Metadata[blobKey].Expiry)
+}
+
+func TestResubmitBlob(t *testing.T) {
+	logger, err := logging.GetLogger(logging.DefaultCLIConfig())
+	assert.NoError(t, err)
+	tx := &mock.MockTransactor{}
+	tx.On("GetCurrentBlockNumber").Return(uint32(100), nil)
+	tx.On("GetQuorumCount").Return(uint16(2), nil)
+	tx.On("GetNumberOfRegisteredOperatorForQuorum").Return(uint32(4), nil)
+	blobStore := inmem.NewBlobStore().(*inmem.BlobStore)
+	server := apiserver.NewDispersalServer(disperser.ServerConfig{
+		GrpcPort: "51018",
+	}, blobStore, tx, logger, disperser.NewMetrics("9018", nil, logger), nil, apiserver.RateConfig{})
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("1.1.1.1"), Port: 51001}})
+
+	reply, err := disperseBlobFrom(server, "1.1.1.1", []byte("hello"))
+	assert.NoError(t, err)
+	blobKey, err := disperser.ParseBlobKey(string(reply.GetRequestId()))
+	assert.NoError(t, err)
+	blobStore.Metadata[blobKey].RequestMetadata.Namespace = "rollup"
+	resubmit := func(requestID []byte) (*pb.ResubmitBlobReply, error) {
+		return server.ResubmitBlob(ctx, &pb.ResubmitBlobRequest{
+			RequestId: requestID,
+			SecurityParams: []*pb.SecurityParams{{
+				QuorumId:           0,
+				AdversaryThreshold: 40,
+				QuorumThreshold:    50,
+			}},
+		})
+	}
+
+	// A blob still processing can't be resubmitted
+	_, err = resubmit(reply.GetRequestId())
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	// A blob which failed with insufficient signatures is resubmitted with relaxed thresholds as a new blob with the
+	// same content
+	blobStore.Metadata[blobKey].BlobStatus = disperser.InsufficientSignatures
+	resubmitted, err := resubmit(reply.GetRequestId())
+	assert.NoError(t, err)
+	assert.Equal(t, pb.BlobStatus_PROCESSING, resubmitted.GetResult())
+	assert.NotEqual(t, reply.GetRequestId(), resubmitted.GetRequestId())
+	resubmittedKey, err := disperser.ParseBlobKey(string(resubmitted.GetRequestId()))
+	assert.NoError(t, err)
+	assert.Equal(t, blobKey.BlobHash, resubmittedKey.BlobHash)
+	metadata := blobStore.Metadata[resubmittedKey]
+	require.NotNil(t, metadata)
+	assert.Equal(t, disperser.Processing, metadata.BlobStatus)
+	assert.Equal(t, "rollup", metadata.RequestMetadata.Namespace)
+	assert.Equal(t, uint8(50), metadata.RequestMetadata.SecurityParams[0].QuorumThreshold)
+	assert.Equal(t, uint(len("hello")), metadata.RequestMetadata.BlobSize)
+	assert.Equal(t, disperser.InsufficientSignatures, blobStore.Metadata[blobKey].BlobStatus)
+
+	// Confirmed blobs can't be resubmitted
+	blobStore.Metadata[resubmittedKey].BlobStatus = disperser.Confirmed
+	_, err = resubmit(resubmitted.GetRequestId())
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	// Expired blobs can't be resubmitted
+	blobStore.Metadata[blobKey].Expiry = uint64(time.Now().Add(-time.Second).Unix())
+	_, err = resubmit(reply.GetRequestId())
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	// Blobs whose content was removed can't be resubmitted
+	blobStore.Metadata[blobKey].Expiry = uint64(time.Now().Add(time.Hour).Unix())
+	delete(blobStore.Blobs, blobKey.BlobHash)
+	_, err = resubmit(reply.GetRequestId())
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	// Unknown blobs aren't found, and malformed request IDs are rejected
+	_, err = resubmit(disperser.BlobKey{BlobHash: strings.Repeat("ab", 32), MetadataHash: "cd"}.RequestID())
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	_, err = resubmit([]byte("invalid"))
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestDisperseBlobWhileDraining(t *testing.T) {
+	logger, err := logging.GetLogger(logging.DefaultCLIConfig())
+	assert.NoError(t, err)
+	tx := &mock.MockTransactor{}
+	tx.On("GetCurrentBlockNumber").Return(uint32(100), nil)
+	tx.On("GetQuorumCount").Return(uint16(2), nil)
+	tx.On("GetNumberOfRegisteredOperatorForQuorum").Return(uint32(4), nil)
+	blobStore := inmem.NewBlobStore().(*inmem.BlobStore)
+	server := apiserver.NewDispersalServer(disperser.ServerConfig{
+		GrpcPort: "51024",
+	}, blobStore, tx, logger, disperser.NewMetrics("9024", nil, logger), nil, apiserver.RateConfig{})
+
+	reply, err := disperseBlobFrom(server, "1.1.1.1", []byte("hello"))
+	assert.NoError(t, err)
+	requestID := reply.GetRequestId()
+
+	server.Drain()
+	_, err = disperseBlobFrom(server, "1.1.1.1", []byte("world"))
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.ErrorContains(t, err, "draining")
+	assert.Len(t, blobStore.Metadata, 1)
+
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("1.1.1.1"), Port: 51001}})
+	_, err = server.ResubmitBlob(ctx, &pb.ResubmitBlobRequest{
+		RequestId:      requestID,
+		SecurityParams: []*pb.SecurityParams{{QuorumId: 0, AdversaryThreshold: 40, QuorumThreshold: 50}},
+	})
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+
+	// The dry runs and the status of the blobs dispersed before the drain are still served
+	_, err = server.DisperseBlob(ctx, &pb.DisperseBlobRequest{
+		Data:           []byte("world"),
+		SecurityParams: []*pb.SecurityParams{{QuorumId: 0, AdversaryThreshold: 80, QuorumThreshold: 100}},
+		DryRun:         true,
+	})
+	assert.NoError(t, err)
+	statusReply, err := server.GetBlobStatus(ctx, &pb.BlobStatusRequest{RequestId: requestID})
+	assert.NoError(t, err)
+	assert.Equal(t, pb.BlobStatus_PROCESSING, statusReply.GetStatus())
+}
+
+// slowBlobStore holds the blobs being stored until it is released, counting the blobs stored at once
+type slowBlobStore struct {
+	disperser.BlobStore
+	release chan struct{}
+
+	mu             sync.Mutex
+	inFlight       int
+	maxInFlight    int
+	storedInFlight chan struct{}
+	// storeMu serializes the writes to the in-memory blob store
+	storeMu sync.Mutex
+}
+
+func (s *slowBlobStore) StoreBlob(ctx context.Context, blob *core.Blob, requestedAt uint64) (disperser.BlobKey, error) {
+	s.mu.Lock()
+	s.inFlight++
+	if s.inFlight > s.maxInFlight {
+		s.maxInFlight = s.inFlight
+	}
+	s.mu.Unlock()
+	s.storedInFlight <- struct{}{}
+	defer func() {
+		s.mu.Lock()
+		s.inFlight--
+		s.mu.Unlock()
+	}()
+
+	select {
+	case <-s.release:
+		s.storeMu.Lock()
+		defer s.storeMu.Unlock()
+		return s.BlobStore.StoreBlob(ctx, blob, requestedAt)
+	case <-ctx.Done():
+		return disperser.BlobKey{}, ctx.Err()
+	}
+}
+
+func TestDisperseBlobLoadShedding(t *testing.T) {
+	logger, err := logging.GetLogger(logging.DefaultCLIConfig())
+	assert.NoError(t, err)
+	tx := &mock.MockTransactor{}
+	tx.On("GetCurrentBlockNumber").Return(uint32(100), nil)
+	tx.On("GetQuorumCount").Return(uint16(2), nil)
+	tx.On("GetNumberOfRegisteredOperatorForQuorum").Return(uint32(4), nil)
+
+	const maxConcurrent, maxQueued, numRequests = 4, 4, 50
+	blobStore := &slowBlobStore{
+		BlobStore:      inmem.NewBlobStore(),
+		release:        make(chan struct{}),
+		storedInFlight: make(chan struct{}, numRequests),
+	}
+	server := apiserver.NewDispersalServer(disperser.ServerConfig{
+		GrpcPort:                "51025",
+		MaxConcurrentDispersals: maxConcurrent,
+		MaxQueuedDispersals:     maxQueued,
+		DispersalQueueTimeout:   time.Minute,
+	}, blobStore, tx, logger, disperser.NewMetrics("9025", nil, logger), nil, apiserver.RateConfig{})
+
+	// The first requests take all the slots, and wait on the blob store
+	type result struct {
+		err     error
+		elapsed time.Duration
+	}
+	admitted := make(chan result, maxConcurrent+maxQueued)
+	for i := 0; i < maxConcurrent; i++ {
+		go func(i int) {
+			_, err := disperseBlobFrom(server, "1.1.1.1", []byte(fmt.Sprintf("admitted %d", i)))
+			admitted <- result{err: err}
+		}(i)
+	}
+	for i := 0; i < maxConcurrent; i++ {
+		<-blobStore.storedInFlight
+	}
+
+	// The next requests fill the queue, and the others are shed right away
+	var wg sync.WaitGroup
+	shed := make(chan result, numRequests)
+	for i := maxConcurrent; i < numRequests; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			start := time.Now()
+			_, err := disperseBlobFrom(server, "1.1.1.1", []byte(fmt.Sprintf("request %d", i)))
+			if status.Code(err) == codes.ResourceExhausted {
+				shed <- result{err: err, elapsed: time.Since(start)}
+				return
+			}
+			admitted <- result{err: err}
+		}(i)
+	}
+	for i := 0; i < numRequests-maxConcurrent-maxQueued; i++ {
+		r := <-shed
+		assertErrorDetails(t, r.err, codes.ResourceExhausted, disperser.ReasonOverloaded, "")
+		var retryInfo *errdetails.RetryInfo
+		for _, detail := range status.Convert(r.err).Details() {
+			if detail, ok := detail.(*errdetails.RetryInfo); ok {
+				retryInfo = detail
+			}
+		}
+		if assert.NotNil(t, retryInfo) {
+			assert.Greater(t, retryInfo.GetRetryDelay().AsDuration(), time.Duration(0))
+		}
+		assert.Less(t, r.elapsed, time.Second)
+	}
+	assert.Len(t, admitted, 0)
+
+	// The queued requests are processed once the blob store catches up, never more than maxConcurrent at once
+	close(blobStore.release)
+	wg.Wait()
+	for i := 0; i < maxConcurrent+maxQueued; i++ {
+		assert.NoError(t, (<-admitted).err)
+	}
+	assert.Len(t, shed, 0)
+	assert.Equal(t, maxConcurrent, blobStore.maxInFlight)
+}
+
+func TestDisperseBlobQueueTimeout(t *testing.T) {
+	logger, err := logging.GetLogger(logging.DefaultCLIConfig())
+	assert.NoError(t, err)
+	tx := &mock.MockTransactor{}
+	tx.On("GetCurrentBlockNumber").Return(uint32(100), nil)
+	tx.On("GetQuorumCount").Return(uint16(2), nil)
+	tx.On("GetNumberOfRegisteredOperatorForQuorum").Return(uint32(4), nil)
+
+	blobStore := &slowBlobStore{
+		BlobStore:      inmem.NewBlobStore(),
+		release:        make(chan struct{}),
+		storedInFlight: make(chan struct{}, 1),
+	}
+	server := apiserver.NewDispersalServer(disperser.ServerConfig{
+		GrpcPort:                "51026",
+		MaxConcurrentDispersals: 1,
+		MaxQueuedDispersals:     1,
+		DispersalQueueTimeout:   50 * time.Millisecond,
+	}, blobStore, tx, logger, disperser.NewMetrics("9026", nil, logger), nil, apiserver.RateConfig{})
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := disperseBlobFrom(server, "1.1.1.1", []byte("slow"))
+		done <- err
+	}()
+	<-blobStore.storedInFlight
+
+	// The queued request is shed once it waited for the queue timeout
+	start := time.Now()
+	_, err = disperseBlobFrom(server, "1.1.1.1", []byte("queued"))
+	assertErrorDetails(t, err, codes.ResourceExhausted, disperser.ReasonOverloaded, "")
+	assert.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+	assert.Less(t, time.Since(start), 5*time.Second)
+
+	close(blobStore.release)
+	assert.NoError(t, <-done)
+}
+
+func TestGetBatchCost(t *testing.T) {
+	logger, err := logging.GetLogger(logging.DefaultCLIConfig())
+	assert.NoError(t, err)
+	blobStore := inmem.NewBlobStore()
+	server := apiserver.NewDispersalServer(disperser.ServerConfig{
+		GrpcPort: "51015",
+	}, blobStore, &mock.MockTransactor{}, logger, disperser.NewMetrics("9015", nil, logger), nil, apiserver.RateConfig{
+		TrustedAPIKeys: []string{"secret"},
+	})
+	withAPIKey := func(key string) context.Context {
+		p := &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("1.1.1.1"), Port: 51001}}
+		return metadata.NewIncomingContext(peer.NewContext(context.Background(), p), metadata.Pairs(apiserver.TrustedAPIKeyHeader, key))
+	}
+
+	batchHeaderHash := [32]byte{1, 2, 3}
+	blobKey := disperser.BlobKey{BlobHash: "blob", MetadataHash: "1"}
+	err = blobStore.StoreBatchCost(context.Background(), batchHeaderHash, &disperser.BatchCost{
+		ConfirmationTxnHash:     gethcommon.HexToHash("0x1234"),
+		ConfirmationBlockNumber: 123,
+		GasUsed:                 100_000,
+		EffectiveGasPrice:       big.NewInt(30_000_000_000),
+		TotalCost:               big.NewInt(3_000_000_000_000_000),
+		BlobCosts: []*disperser.BlobCost{
+			{BlobKey: blobKey, BlobIndex: 0, EncodedLength: 256, Cost: big.NewInt(3_000_000_000_000_000)},
+		},
+	})
+	assert.NoError(t, err)
+
+	reply, err := server.GetBatchCost(withAPIKey("secret"), &pb.BatchCostRequest{BatchHeaderHash: batchHeaderHash[:]})
+	assert.NoError(t, err)
+	assert.Equal(t, gethcommon.HexToHash("0x1234").Bytes(), reply.GetConfirmationTxnHash())
+	assert.Equal(t, uint32(123), reply.GetConfirmationBlockNumber())
+	assert.Equal(t, uint64(100_000), reply.GetGasUsed())
+	assert.Equal(t, big.NewInt(30_000_000_000), new(big.Int).SetBytes(reply.GetEffectiveGasPrice()))
+	assert.Equal(t, big.NewInt(3_000_000_000_000_000), new(big.Int).SetBytes(reply.GetTotalCost()))
+	assert.Len(t, reply.GetBlobCosts(), 1)
+	assert.Equal(t, blobKey.RequestID(), reply.GetBlobCosts()[0].GetRequestId())
+	assert.Equal(t, uint64(256), reply.GetBlobCosts()[0].GetEncodedLength())
+	assert.Equal(t, big.NewInt(3_000_000_000_000_000), new(big.Int).SetBytes(reply.GetBlobCosts()[0].GetCost()))
+
+	_, err = server.GetBatchCost(withAPIKey("wrong"), &pb.BatchCostRequest{BatchHeaderHash: batchHeaderHash[:]})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	_, err = server.GetBatchCost(withAPIKey("secret"), &pb.BatchCostRequest{BatchHeaderHash: []byte{1}})
+	assertErrorDetails(t, err, codes.InvalidArgument, disperser.ReasonInvalidBatchHeaderHash, "batch_header_hash")
+	_, err = server.GetBatchCost(withAPIKey("secret"), &pb.BatchCostRequest{BatchHeaderHash: make([]byte, 32)})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestDisperseBlobWithExceedSizeLimit(t *testing.T) {
+	data := make([]byte, 1024*512+10)
+	_, err := rand.Read(data)
+	assert.NoError(t, err)
+
+	p := &peer.Peer{
+		Addr: &net.TCPAddr{
+			IP:   net.ParseIP("0.0.0.0"),
+			Port: 51001,
+		},
+	}
+	ctx := peer.NewContext(context.Background(), p)
+	_, err = dispersalServer.DisperseBlob(ctx, &pb.DisperseBlobRequest{
+		Data: data,
+		SecurityParams: []*pb.SecurityParams{
+			{
+				QuorumId:           0,
+				AdversaryThreshold: 80,
+				QuorumThreshold:    100,
+			},
+			{
+				QuorumId:           1,
+				AdversaryThreshold: 80,
+				QuorumThreshold:    100,
+			},
+		},
+	})
+	assert.NotNil(t, err)
+	assert.Equal(t, status.Convert(err).Message(), "blob size cannot exceed 512 KiB")
+	assertErrorDetails(t, err, codes.InvalidArgument, disperser.ReasonBlobTooLarge, "data")
+}
+
+func TestDisperseBlobWithInvalidLayout(t *testing.T) {
+	p := &peer.Peer{
+		Addr: &net.TCPAddr{
+			IP:   net.ParseIP("0.0.0.0"),
+			Port: 51001,
+		},
+	}
+	ctx := peer.NewContext(context.Background(), p)
+	securityParams := []*pb.SecurityParams{
+		{
+			QuorumId:           0,
+			AdversaryThreshold: 80,
+			QuorumThreshold:    100,
+		},
+	}
+
+	_, err := dispersalServer.DisperseBlob(ctx, &pb.DisperseBlobRequest{
+		Data:           make([]byte, 1024),
+		SecurityParams: securityParams,
+		Layout:         pb.BlobLayout(7),
+	})
+	assert.ErrorContains(t, err, "invalid request: unknown layout 7")
+	assertErrorDetails(t, err, codes.InvalidArgument, disperser.ReasonInvalidBlobLayout, "layout")
+
+	// A blob in the field elements layout must be exactly 4096 field elements
+	_, err = dispersalServer.DisperseBlob(ctx, &pb.DisperseBlobRequest{
+		Data:           make([]byte, 1024),
+		SecurityParams: securityParams,
+		Layout:         pb.BlobLayout_FIELD_ELEMENTS,
+	})
+	assert.ErrorContains(t, err, "a blob in the field-elements layout must be 131072 bytes, but found 1024")
+	assertErrorDetails(t, err, codes.InvalidArgument, disperser.ReasonInvalidBlobLayout, "data")
+
+	// Whose field elements are all canonical
+	data := make([]byte, core.FieldElementsBlobSize)
+	for i := range data[core.BytesPerFieldElement : 2*core.BytesPerFieldElement] {
+		data[core.BytesPerFieldElement+i] = 0xff
+	}
+	_, err = dispersalServer.DisperseBlob(ctx, &pb.DisperseBlobRequest{
+		Data:           data,
+		SecurityParams: securityParams,
+		Layout:         pb.BlobLayout_FIELD_ELEMENTS,
+	})
+	assert.ErrorContains(t, err, "field element 1 is not canonical")
+	assertErrorDetails(t, err, codes.InvalidArgument, disperser.ReasonInvalidBlobLayout, "data")
+}
+
+func TestDisperseBlobWithNamespace(t *testing.T) {
+	data := make([]byte, 1024)
+	_, err := rand.Read(data)
+	assert.NoError(t, err)
+
+	server := newTestServerWithRatelimiter(t, apiserver.QuorumRateInfo{
+		PerUserUnauthThroughput: 1_000_000,
+		TotalUnauthThroughput:   1_000_000,
+	}, nil)
+
+	p := &peer.Peer{
+		Addr: &net.TCPAddr{
+			IP:   net.ParseIP("0.0.0.0"),
+			Port: 51001,
+		},
+	}
+	ctx := peer.NewContext(context.Background(), p)
+	disperse := func(namespace string) (*pb.DisperseBlobReply, error) {
+		return server.DisperseBlob(ctx, &pb.DisperseBlobRequest{
+			Data: data,
+			SecurityParams: []*pb.SecurityParams{
+				{
+					QuorumId:           0,
+					AdversaryThreshold: 50,
+					QuorumThreshold:    100,
+				},
+			},
+			Namespace: namespace,
+		})
+	}
+
+	reply, err := disperse("rollup-1.mainnet_v2")
+	assert.NoError(t, err)
+	status, err := server.GetBlobStatus(ctx, &pb.BlobStatusRequest{RequestId: reply.GetRequestId()})
+	assert.NoError(t, err)
+	assert.Equal(t, "rollup-1.mainnet_v2", status.GetNamespace())
+
+	_, err = disperse(strings.Repeat("a", 65))
+	assert.ErrorContains(t, err, "invalid request: namespace must not exceed 64 characters")
+	assertErrorDetails(t, err, codes.InvalidArgument, disperser.ReasonInvalidNamespace, "namespace")
+
+	_, err = disperse("rollup/1")
+	assert.ErrorContains(t, err, "invalid request: namespace contains invalid character")
+}
+
+func TestDisperseBlobWithMetadata(t *testing.T) {
+	data := make([]byte, 1024)
+	_, err := rand.Read(data)
+	assert.NoError(t, err)
+
+	server := newTestServerWithRatelimiter(t, apiserver.QuorumRateInfo{
+		PerUserUnauthThroughput: 1_000_000,
+		TotalUnauthThroughput:   1_000_000,
+	}, nil)
+
+	p := &peer.Peer{
+		Addr: &net.TCPAddr{
+			IP:   net.ParseIP("0.0.0.0"),
+			Port: 51001,
+		},
+	}
+	ctx := peer.NewContext(context.Background(), p)
+	disperse := func(metadata map[string]string) (*pb.DisperseBlobReply, error) {
+		return server.DisperseBlob(ctx, &pb.DisperseBlobRequest{
+			Data: data,
+			SecurityParams: []*pb.SecurityParams{
+				{
+					QuorumId:           0,
+					AdversaryThreshold: 50,
+					QuorumThreshold:    100,
+				},
+			},
+			Metadata: metadata,
+		})
+	}
+
+	labels := map[string]string{"app": "rollup-1", "batch": "42"}
+	reply, err := disperse(labels)
+	assert.NoError(t, err)
+	status, err := server.GetBlobStatus(ctx, &pb.BlobStatusRequest{RequestId: reply.GetRequestId()})
+	assert.NoError(t, err)
+	assert.Equal(t, labels, status.GetMetadata())
+
+	tooMany := make(map[string]string)
+	for i := 0; i < 17; i++ {
+		tooMany[fmt.Sprintf("key%d", i)] = "value"
+	}
+	_, err = disperse(tooMany)
+	assert.ErrorContains(t, err, "invalid request: metadata must not exceed 16 labels")
+	assertErrorDetails(t, err, codes.InvalidArgument, disperser.ReasonInvalidMetadata, "metadata")
+
+	_, err = disperse(map[string]string{"key": strings.Repeat("a", 1022)})
+	assert.ErrorContains(t, err, "invalid request: metadata must not exceed 1024 bytes")
+
+	_, err = disperse(map[string]string{"": "value"})
+	assert.ErrorContains(t, err, "invalid request: metadata keys must not be empty")
+}
+
+func TestDisperseBlobWithContentType(t *testing.T) {
+	logger, err := logging.GetLogger(logging.DefaultCLIConfig())
+	require.NoError(t, err)
+	blobStore := inmem.NewBlobStore()
+	tx := &mock.MockTransactor{}
+	tx.On("GetCurrentBlockNumber").Return(uint32(100), nil)
+	tx.On("GetQuorumCount").Return(uint16(2), nil)
+	tx.On("GetNumberOfRegisteredOperatorForQuorum").Return(uint32(4), nil)
+	server := apiserver.NewDispersalServer(disperser.ServerConfig{
+		GrpcPort: "51035",
+	}, blobStore, tx, logger, disperser.NewMetrics("9035", nil, logger), nil, apiserver.RateConfig{
+		QuorumRateInfos: map[core.QuorumID]apiserver.QuorumRateInfo{
+			0: {PerUserUnauthThroughput: 1_000_000, TotalUnauthThroughput: 1_000_000},
+		},
+	})
+
+	ctx := peer.NewContext(context.Background(), &peer.Peer{
+		Addr: &net.TCPAddr{IP: net.ParseIP("0.0.0.0"), Port: 51001},
+	})
+	data := []byte(`{"key": "value"}`)
+	disperse := func(contentType string) (*pb.DisperseBlobReply, error) {
+		return server.DisperseBlob(ctx, &pb.DisperseBlobRequest{
+			Data:           data,
+			SecurityParams: []*pb.SecurityParams{{QuorumId: 0, AdversaryThreshold: 50, QuorumThreshold: 100}},
+			ContentType:    contentType,
+		})
+	}
+
+	// The content type is returned by GetBlobStatus and RetrieveBlob
+	reply, err := disperse("application/json; charset=utf-8")
+	require.NoError(t, err)
+	status, err := server.GetBlobStatus(ctx, &pb.BlobStatusRequest{RequestId: reply.GetRequestId()})
+	require.NoError(t, err)
+	assert.Equal(t, "application/json; charset=utf-8", status.GetContentType())
+
+	blobKey, err := disperser.ParseBlobKey(string(reply.GetRequestId()))
+	require.NoError(t, err)
+	metadata, err := blobStore.GetBlobMetadata(ctx, blobKey)
+	require.NoError(t, err)
+	_, err = blobStore.MarkBlobConfirmed(ctx, metadata, &disperser.ConfirmationInfo{
+		BatchHeaderHash: [32]byte{1, 2, 3},
+		BlobIndex:       0,
+	})
+	require.NoError(t, err)
+	retrieved, err := server.RetrieveBlob(ctx, &pb.RetrieveBlobRequest{BatchHeaderHash: []byte{1, 2, 3}, BlobIndex: 0})
+	require.NoError(t, err)
+	assert.Equal(t, data, retrieved.GetData())
+	assert.Equal(t, "application/json; charset=utf-8", retrieved.GetContentType())
+
+	// The content type is optional
+	reply, err = disperse("")
+	require.NoError(t, err)
+	status, err = server.GetBlobStatus(ctx, &pb.BlobStatusRequest{RequestId: reply.GetRequestId()})
+	require.NoError(t, err)
+	assert.Empty(t, status.GetContentType())
+
+	_, err = disperse("not a media type")
+	assertErrorDetails(t, err, codes.InvalidArgument, disperser.ReasonInvalidContentType, "content_type")
+	_, err = disperse("text/" + strings.Repeat("a", 251))
+	assert.ErrorContains(t, err, "invalid request: content type must not exceed 255 characters")
+	assertErrorDetails(t, err, codes.InvalidArgument, disperser.ReasonInvalidContentType, "content_type")
+}
+
+func TestPrivateBlobRetrieval(t *testing.T) {
+	logger, err := logging.GetLogger(logging.DefaultCLIConfig())
+	require.NoError(t, err)
+	blobStore := inmem.NewBlobStore()
+	tx := &mock.MockTransactor{}
+	tx.On("GetCurrentBlockNumber").Return(uint32(100), nil)
+	tx.On("GetQuorumCount").Return(uint16(2), nil)
+	tx.On("GetNumberOfRegisteredOperatorForQuorum").Return(uint32(4), nil)
+	server := apiserver.NewDispersalServer(disperser.ServerConfig{
+		GrpcPort: "51036",
+	}, blobStore, tx, logger, disperser.NewMetrics("9036", nil, logger), nil, apiserver.RateConfig{
+		QuorumRateInfos: map[core.QuorumID]apiserver.QuorumRateInfo{
+			0: {PerUserUnauthThroughput: 1_000_000, TotalUnauthThroughput: 1_000_000},
+		},
+	})
+	startServer(t, server, "51036")
+
+	submitterKey, err := gethcrypto.GenerateKey()
+	require.NoError(t, err)
+	otherKey, err := gethcrypto.GenerateKey()
+	require.NoError(t, err)
+	newClient := func(private bool, key *ecdsa.PrivateKey) clients.DisperserClient {
+		return clients.NewDisperserClient(&clients.DisperserClientConfig{
+			Hostname:              "localhost",
+			Port:                  "51036",
+			Timeout:               10 * time.Second,
+			PrivateUntilFinalized: private,
+			SignerKey:             key,
+		})
+	}
+	submitter := newClient(true, submitterKey)
+	anonymous := newClient(false, nil)
+	other := newClient(false, otherKey)
+
+	// A private and a public blob are confirmed in the same batch
+	ctx := context.Background()
+	securityParams := []*core.SecurityParam{{QuorumID: 0, AdversaryThreshold: 50, QuorumThreshold: 100}}
+	privateData, publicData := []byte("private blob"), []byte("public blob")
+	_, privateRequestID, err := submitter.DisperseBlob(ctx, privateData, securityParams)
+	require.NoError(t, err)
+	_, publicRequestID, err := anonymous.DisperseBlob(ctx, publicData, securityParams)
+	require.NoError(t, err)
+	batchHeaderHash := [32]byte{10, 11}
+	for i, requestID := range [][]byte{privateRequestID, publicRequestID} {
+		blobKey, err := disperser.ParseBlobKey(string(requestID))
+		require.NoError(t, err)
+		metadata, err := blobStore.GetBlobMetadata(ctx, blobKey)
+		require.NoError(t, err)
+		_, err = blobStore.MarkBlobConfirmed(ctx, metadata, &disperser.ConfirmationInfo{
+			BatchHeaderHash: batchHeaderHash,
+			BlobIndex:       uint32(i),
+		})
+		require.NoError(t, err)
+	}
+
+	// Only the submitter retrieves the private blob until it is finalized
+	data, err := submitter.RetrieveBlob(ctx, batchHeaderHash[:], 0)
+	require.NoError(t, err)
+	assert.Equal(t, privateData, data)
+	for _, client := range []clients.DisperserClient{anonymous, other} {
+		_, err = client.RetrieveBlob(ctx, batchHeaderHash[:], 0)
+		var disperserErr *clients.DisperserError
+		require.ErrorAs(t, err, &disperserErr)
+		assert.Equal(t, codes.PermissionDenied, disperserErr.Code)
+		assert.Equal(t, disperser.ReasonRetrievalUnauthorized, disperserErr.Reason)
+	}
+	data, err = anonymous.RetrieveBlob(ctx, batchHeaderHash[:], 1)
+	require.NoError(t, err)
+	assert.Equal(t, publicData, data)
+
+	// A signature is bound to its blob and expires
+	now := uint64(time.Now().Unix())
+	signature, err := disperser.SignRetrieval(submitterKey, batchHeaderHash, 1, now)
+	require.NoError(t, err)
+	_, err = server.RetrieveBlob(ctx, &pb.RetrieveBlobRequest{BatchHeaderHash: batchHeaderHash[:], BlobIndex: 0, AuthTimestamp: now, AuthSignature: signature})
+	assertErrorDetails(t, err, codes.PermissionDenied, disperser.ReasonRetrievalUnauthorized, "")
+	expired := now - 600
+	signature, err = disperser.SignRetrieval(submitterKey, batchHeaderHash, 0, expired)
+	require.NoError(t, err)
+	_, err = server.RetrieveBlob(ctx, &pb.RetrieveBlobRequest{BatchHeaderHash: batchHeaderHash[:], BlobIndex: 0, AuthTimestamp: expired, AuthSignature: signature})
+	assertErrorDetails(t, err, codes.PermissionDenied, disperser.ReasonRetrievalUnauthorized, "")
+
+	// The data of the private blob is withheld from the retrieval of the batch
+	retrieved := make(map[uint32][]byte)
+	err = anonymous.RetrieveBatchBlobs(ctx, batchHeaderHash[:], func(blobIndex uint32, data []byte) error {
+		retrieved[blobIndex] = data
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[uint32][]byte{0: nil, 1: publicData}, retrieved)
+
+	// The blob is public once finalized
+	privateKey, err := disperser.ParseBlobKey(string(privateRequestID))
+	require.NoError(t, err)
+	require.NoError(t, blobStore.MarkBlobFinalized(ctx, privateKey))
+	data, err = anonymous.RetrieveBlob(ctx, batchHeaderHash[:], 0)
+	require.NoError(t, err)
+	assert.Equal(t, privateData, data)
+
+	// A private blob requires the key of its submitter, and only a private blob accepts one
+	_, _, err = newClient(true, nil).DisperseBlob(ctx, privateData, securityParams)
+	assert.ErrorContains(t, err, "private blobs require a signer key")
+	request := &pb.DisperseBlobRequest{
+		Data:                  privateData,
+		SecurityParams:        []*pb.SecurityParams{{QuorumId: 0, AdversaryThreshold: 50, QuorumThreshold: 100}},
+		PrivateUntilFinalized: true,
+	}
+	peerCtx := peer.NewContext(ctx, &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("0.0.0.0"), Port: 51001}})
+	_, err = server.DisperseBlob(peerCtx, request)
+	assertErrorDetails(t, err, codes.InvalidArgument, disperser.ReasonInvalidSubmitterKey, "submitter_public_key")
+	request.SubmitterPublicKey = []byte{1, 2, 3}
+	_, err = server.DisperseBlob(peerCtx, request)
+	assertErrorDetails(t, err, codes.InvalidArgument, disperser.ReasonInvalidSubmitterKey, "submitter_public_key")
+	request.PrivateUntilFinalized = false
+	request.SubmitterPublicKey = gethcrypto.FromECDSAPub(&submitterKey.PublicKey)
+	_, err = server.DisperseBlob(peerCtx, request)
+	assertErrorDetails(t, err, codes.InvalidArgument, disperser.ReasonInvalidSubmitterKey, "submitter_public_key")
+	// The uncompressed form of the key is accepted
+	request.PrivateUntilFinalized = true
+	_, err = server.DisperseBlob(peerCtx, request)
+	assert.NoError(t, err)
+}
+
+func TestReservedAccountRateLimit(t *testing.T) {
+	reservedAccount := "1.1.1.1"
+	unreservedAccount := "2.2.2.2"
+	server := newTestServerWithRatelimiter(t, apiserver.QuorumRateInfo{
+		PerUserUnauthThroughput: 10_000,
+		TotalUnauthThroughput:   10_000,
+	}, apiserver.Reservations{
+		"ip:" + reservedAccount: {0: 100_000},
+	})
+
+	data := make([]byte, 1024)
+	_, err := rand.Read(data)
+	assert.NoError(t, err)
+
+	// The unreserved account exhausts the shared pool after its first request
+	_, err = disperseBlobFrom(server, unreservedAccount, data)
+	assert.NoError(t, err)
+	_, err = disperseBlobFrom(server, unreservedAccount, data)
+	assert.ErrorContains(t, err, "request ratelimited")
+
+	// The reserved account sustains its rate from its reservation even though the shared pool is exhausted
+	for i := 0; i < 5; i++ {
+		reply, err := disperseBlobFrom(server, reservedAccount, data)
+		assert.NoError(t, err)
+		assert.Equal(t, pb.BlobStatus_PROCESSING, reply.GetResult())
+	}
+
+	// On-demand traffic is still rejected although the reservation has headroom
+	_, err = disperseBlobFrom(server, unreservedAccount, data)
+	assert.ErrorContains(t, err, "request ratelimited")
+
+	// Once the reservation is removed, the account falls back to the exhausted shared pool
+	server.UpdateReservations(apiserver.Reservations{})
+	_, err = disperseBlobFrom(server, reservedAccount, data)
+	assert.ErrorContains(t, err, "request ratelimited")
+}
+
+func TestTrustedCallerRateLimitBypass(t *testing.T) {
+	_, trustedCIDR, err := net.ParseCIDR("10.0.0.0/8")
+	assert.NoError(t, err)
+	blobStore := inmem.NewBlobStore()
+	server := newTestServerWithBlobStore(t, apiserver.RateConfig{
+		QuorumRateInfos: map[core.QuorumID]apiserver.QuorumRateInfo{
+			0: {
+				PerUserUnauthThroughput: 10_000,
+				TotalUnauthThroughput:   10_000,
+			},
+		},
+		TrustedAPIKeys: []string{"secret"},
+		TrustedCIDRs:   []*net.IPNet{trustedCIDR},
+	}, blobStore)
+
+	data := make([]byte, 1024)
+	_, err = rand.Read(data)
+	assert.NoError(t, err)
+
+	// The external caller exhausts the rate limits after its first request
+	_, err = disperseBlobFrom(server, "1.1.1.1", data)
+	assert.NoError(t, err)
+	_, err = disperseBlobFrom(server, "1.1.1.1", data)
+	assert.ErrorContains(t, err, "request ratelimited")
+
+	// A caller in the trusted CIDR isn't rate limited, but its blobs are still attributed to its account
+	for i := 0; i < 3; i++ {
+		reply, err := disperseBlobFrom(server, "10.1.2.3", data)
+		assert.NoError(t, err)
+		blobKey, err := disperser.ParseBlobKey(string(reply.GetRequestId()))
+		assert.NoError(t, err)
+		meta, err := blobStore.GetBlobMetadata(context.Background(), blobKey)
+		assert.NoError(t, err)
+		assert.Equal(t, core.AccountID("ip:10.1.2.3"), meta.RequestMetadata.AccountID)
+	}
+
+	// A caller presenting a trusted API key isn't rate limited, unlike one presenting another key
+	withAPIKey := func(key string) context.Context {
+		p := &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("1.1.1.1"), Port: 51001}}
+		return metadata.NewIncomingContext(peer.NewContext(context.Background(), p), metadata.Pairs(apiserver.TrustedAPIKeyHeader, key))
+	}
+	request := &pb.DisperseBlobRequest{
+		Data:           data,
+		SecurityParams: []*pb.SecurityParams{{QuorumId: 0, AdversaryThreshold: 80, QuorumThreshold: 100}},
+	}
+	for i := 0; i < 3; i++ {
+		_, err = server.DisperseBlob(withAPIKey("secret"), request)
+		assert.NoError(t, err)
+	}
+	_, err = server.DisperseBlob(withAPIKey("wrong"), request)
+	assert.ErrorContains(t, err, "request ratelimited")
+}
+
+func TestNoTrustedCallersByDefault(t *testing.T) {
+	server := newTestServerWithRatelimiter(t, apiserver.QuorumRateInfo{
+		PerUserUnauthThroughput: 10_000,
+		TotalUnauthThroughput:   10_000,
+	}, nil)
+
+	data := make([]byte, 1024)
+	_, err := rand.Read(data)
+	assert.NoError(t, err)
+
+	// Neither private addresses nor empty API keys are trusted
+	_, err = disperseBlobFrom(server, "10.1.2.3", data)
+	assert.NoError(t, err)
+	p := &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("10.1.2.3"), Port: 51001}}
+	ctx := metadata.NewIncomingContext(peer.NewContext(context.Background(), p), metadata.Pairs(apiserver.TrustedAPIKeyHeader, ""))
+	_, err = server.DisperseBlob(ctx, &pb.DisperseBlobRequest{
+		Data:           data,
+		SecurityParams: []*pb.SecurityParams{{QuorumId: 0, AdversaryThreshold: 80, QuorumThreshold: 100}},
+	})
+	assert.ErrorContains(t, err, "request ratelimited")
+}
+
+func TestRateLimitRetryHint(t *testing.T) {
+	data := make([]byte, 1024)
+	_, err := rand.Read(data)
+	assert.NoError(t, err)
+
+	testCases := []struct {
+		name         string
+		rates        apiserver.QuorumRateInfo
+		secondSender string
+		errMsg       string
+		reason       string
+	}{
+		{
+			name: "account limit",
+			rates: apiserver.QuorumRateInfo{
+				PerUserUnauthThroughput: 10_000,
+				TotalUnauthThroughput:   1_000_000,
+			},
+			secondSender: "1.1.1.1",
+			errMsg:       "account limit",
+			reason:       disperser.ReasonAccountRateLimit,
+		},
+		{
+			name: "system limit",
+			rates: apiserver.QuorumRateInfo{
+				PerUserUnauthThroughput: 1_000_000,
+				TotalUnauthThroughput:   10_000,
+			},
+			secondSender: "2.2.2.2",
+			errMsg:       "system limit",
+			reason:       disperser.ReasonSystemRateLimit,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := newTestServerWithRatelimiter(t, tc.rates, nil)
+
+			_, err := disperseBlobFrom(server, "1.1.1.1", data)
+			assert.NoError(t, err)
+
+			_, err = disperseBlobFrom(server, tc.secondSender, data)
+			assert.ErrorContains(t, err, tc.errMsg)
+			assertErrorDetails(t, err, codes.ResourceExhausted, tc.reason, "")
+
+			// The hint is bounded by the time needed to refill the 1 second bucket
+			retryDelay, throttled := clients.GetRetryDelay(err)
+			assert.True(t, throttled)
+			assert.Greater(t, retryDelay, time.Duration(0))
+			assert.LessOrEqual(t, retryDelay, time.Second)
+
+			// Retrying right away is rejected, but retrying after the hint succeeds
+			_, err = disperseBlobFrom(server, tc.secondSender, data)
+			assert.ErrorContains(t, err, tc.errMsg)
+
+			time.Sleep(retryDelay)
+			_, err = disperseBlobFrom(server, tc.secondSender, data)
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestDailyQuota(t *testing.T) {
+	rates := apiserver.QuorumRateInfo{
+		PerUserUnauthThroughput: 1_000_000,
+		TotalUnauthThroughput:   1_000_000,
+	}
+	server := newTestServerWithRateConfig(t, apiserver.RateConfig{
+		QuorumRateInfos: map[core.QuorumID]apiserver.QuorumRateInfo{0: rates},
+		DailyQuota:      2500,
+		AccountDailyQuotas: map[core.AccountID]uint64{
+			"ip:2.2.2.2": 0,
+		},
+	})
+	quotaStore, err := store.NewLocalParamStore[apiserver.QuotaUsage](1000)
+	assert.NoError(t, err)
+	server.SetQuotaStore(quotaStore)
+
+	data := make([]byte, 1024)
+	_, err = rand.Read(data)
+	assert.NoError(t, err)
+
+	ctx := peer.NewContext(context.Background(), &peer.Peer{
+		Addr: &net.TCPAddr{IP: net.ParseIP("1.1.1.1"), Port: 51001},
+	})
+	status, err := server.GetRateLimitStatus(ctx, &pb.RateLimitStatusRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, "ip:1.1.1.1", status.GetAccountId())
+	assert.Equal(t, uint64(2500), status.GetDailyQuota())
+	assert.Equal(t, uint64(2500), status.GetDailyQuotaRemaining())
+
+	// A dry run is checked against the quota without being charged to it
+	dryRun := &pb.DisperseBlobRequest{
+		Data:           data,
+		SecurityParams: []*pb.SecurityParams{{QuorumId: 0, AdversaryThreshold: 80, QuorumThreshold: 100}},
+		DryRun:         true,
+	}
+	_, err = server.DisperseBlob(ctx, dryRun)
+	assert.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		_, err = disperseBlobFrom(server, "1.1.1.1", data)
+		assert.NoError(t, err)
+	}
+	status, err = server.GetRateLimitStatus(ctx, &pb.RateLimitStatusRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(2048), status.GetDailyQuotaUsed())
+	assert.Equal(t, uint64(452), status.GetDailyQuotaRemaining())
+
+	// The third blob exceeds the quota, which is reported apart from the throughput limits
+	_, err = disperseBlobFrom(server, "1.1.1.1", data)
+	assert.ErrorContains(t, err, "request exceeds the daily quota of the account")
+	assertErrorDetails(t, err, codes.ResourceExhausted, disperser.ReasonDailyQuotaExceeded, "")
+	retryDelay, throttled := clients.GetRetryDelay(err)
+	assert.True(t, throttled)
+	assert.Greater(t, retryDelay, 23*time.Hour)
+	_, err = server.DisperseBlob(ctx, dryRun)
+	assert.ErrorContains(t, err, "daily quota")
+
+	// A smaller blob fits in the remaining quota, and the other accounts have their own quotas
+	_, err = disperseBlobFrom(server, "1.1.1.1", data[:452])
+	assert.NoError(t, err)
+	_, err = disperseBlobFrom(server, "3.3.3.3", data)
+	assert.NoError(t, err)
+
+	// An account with a quota of 0 is unlimited
+	for i := 0; i < 5; i++ {
+		_, err = disperseBlobFrom(server, "2.2.2.2", data)
+		assert.NoError(t, err)
+	}
+}
+
+// failingQuotaStore fails to read the usage of the quotas while failing is set
+type failingQuotaStore struct {
+	apiserver.QuotaStore
+	failing bool
+}
+
+func (s *failingQuotaStore) GetItem(ctx context.Context, key string) (*apiserver.QuotaUsage, error) {
+	if s.failing {
+		return nil, errors.New("quota store unavailable")
+	}
+	return s.QuotaStore.GetItem(ctx, key)
+}
+
+func TestDailyQuotaStoreFailure(t *testing.T) {
+	rates := apiserver.QuorumRateInfo{
+		PerUserUnauthThroughput: 1_000_000,
+		TotalUnauthThroughput:   1_000_000,
+	}
+	server := newTestServerWithRateConfig(t, apiserver.RateConfig{
+		QuorumRateInfos: map[core.QuorumID]apiserver.QuorumRateInfo{0: rates},
+		DailyQuota:      2500,
+	})
+	localStore, err := store.NewLocalParamStore[apiserver.QuotaUsage](1000)
+	assert.NoError(t, err)
+	quotaStore := &failingQuotaStore{QuotaStore: localStore}
+	server.SetQuotaStore(quotaStore)
+
+	data := make([]byte, 1024)
+	_, err = rand.Read(data)
+	assert.NoError(t, err)
+	for i := 0; i < 2; i++ {
+		_, err = disperseBlobFrom(server, "1.1.1.1", data)
+		assert.NoError(t, err)
+	}
+
+	// The requests fail while the usage can't be read, rather than resetting it
+	quotaStore.failing = true
+	_, err = disperseBlobFrom(server, "1.1.1.1", data)
+	assert.ErrorContains(t, err, "quota store unavailable")
+	ctx := peer.NewContext(context.Background(), &peer.Peer{
+		Addr: &net.TCPAddr{IP: net.ParseIP("1.1.1.1"), Port: 51001},
+	})
+	_, err = server.GetRateLimitStatus(ctx, &pb.RateLimitStatusRequest{})
+	assert.ErrorContains(t, err, "quota store unavailable")
+
+	quotaStore.failing = false
+	status, err := server.GetRateLimitStatus(ctx, &pb.RateLimitStatusRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(2048), status.GetDailyQuotaUsed())
+	_, err = disperseBlobFrom(server, "1.1.1.1", data)
+	assert.ErrorContains(t, err, "request exceeds the daily quota of the account")
+}
+
+func setup(m *testing.M) {
+
+	deployLocalStack = !(os.Getenv("DEPLOY_LOCALSTACK") == "false")
+	if !deployLocalStack {
+		localStackPort = os.Getenv("LOCALSTACK_PORT")
+	}
+
+	if deployLocalStack {
+
+		var err error
+		dockertestPool, dockertestResource, err = deploy.StartDockertestWithLocalstackContainer(localStackPort)
+		if err != nil {
+			teardown()
+			panic("failed to start localstack container")
+		}
+
+	}
+
+	err := deploy.DeployResources(dockertestPool, localStackPort, metadataTableName, bucketTableName)
+	if err != nil {
+		teardown()
+		panic("failed to deploy AWS resources")
+	}
+
+	dispersalServer = newTestServer(m)
+}
+
+func teardown() {
+	if deployLocalStack {
+		deploy.PurgeDockertestResources(dockertestPool, dockertestResource)
+	}
+}
+
+func newTestServer(m *testing.M) *apiserver.DispersalServer {
+	logger, err := logging.GetLogger(logging.DefaultCLIConfig())
+	if err != nil {
+		panic("failed to create a new logger")
+	}
+
+	bucketName := "test-eigenda-blobstore"
+	awsConfig := deploy.LocalstackClientConfig(localStackPort)
+	s3Client, err := s3.NewClient(context.Background(), awsConfig, logger)
+	if err != nil {
+		panic("failed to create s3 client")
+	}
+	dynamoClient, err := dynamodb.NewClient(awsConfig, logger)
+	if err != nil {
+		panic("failed to create dynamoDB client")
+	}
+	blobMetadataStore := blobstore.NewBlobMetadataStore(dynamoClient, logger, metadataTableName, time.Hour)
+
+	var ratelimiter common.RateLimiter
+	rateConfig := apiserver.RateConfig{
+		QuorumRateInfos: map[core.QuorumID]apiserver.QuorumRateInfo{
+			0: {
+				PerUserUnauthThroughput: 0,
+				TotalUnauthThroughput:   0,
+			},
+		},
+		ClientIPHeader: "",
+	}
+
+	queue = blobstore.NewSharedStorage(bucketName, "", s3Client, blobMetadataStore, logger)
+	tx := &mock.MockTransactor{}
+	tx.On("GetCurrentBlockNumber").Return(uint32(100), nil)
+	tx.On("GetQuorumCount").Return(uint16(2), nil)
+	tx.On("GetNumberOfRegisteredOperatorForQuorum").Return(uint32(4), nil)
+
+	return apiserver.NewDispersalServer(disperser.ServerConfig{
+		GrpcPort: "51001",
+	}, queue, tx, logger, disperser.NewMetrics("9001", nil, logger), ratelimiter, rateConfig)
+}
+
+func newTestServerWithRatelimiter(t *testing.T, rates apiserver.QuorumRateInfo, reservations apiserver.Reservations) *apiserver.DispersalServer {
+	return newTestServerWithRateConfig(t, apiserver.RateConfig{
+		QuorumRateInfos: map[core.QuorumID]apiserver.QuorumRateInfo{
+			0: rates,
+		},
+		Reservations: reservations,
+	})
+}
+
+func newTestServerWithRateConfig(t *testing.T, rateConfig apiserver.RateConfig) *apiserver.DispersalServer {
+	return newTestServerWithBlobStore(t, rateConfig, inmem.NewBlobStore())
+}
+
+func newTestServerWithBlobStore(t *testing.T, rateConfig apiserver.RateConfig, blobStore disperser.BlobStore) *apiserver.DispersalServer {
+	logger, err := logging.GetLogger(logging.DefaultCLIConfig())
+	assert.NoError(t, err)
+
+	bucketStore, err := store.NewLocalParamStore[common.RateBucketParams](1000)
+	assert.NoError(t, err)
+	ratelimiter := ratelimit.NewRateLimiter(common.GlobalRateParams{
+		BucketSizes: []time.Duration{time.Second},
+		Multipliers: []float32{1},
+	}, bucketStore, logger)
+
+	tx := &mock.MockTransactor{}
+	tx.On("GetCurrentBlockNumber").Return(uint32(100), nil)
+	tx.On("GetQuorumCount").Return(uint16(2), nil)
+	tx.On("GetNumberOfRegisteredOperatorForQuorum").Return(uint32(4), nil)
+
+	return apiserver.NewDispersalServer(disperser.ServerConfig{
+		GrpcPort: "51002",
+	}, blobStore, tx, logger, disperser.NewMetrics("9002", nil, logger), ratelimiter, rateConfig)
+}
+
+func TestReflectionDisabled(t *testing.T) {
+	logger, err := logging.GetLogger(logging.DefaultCLIConfig())
+	require.NoError(t, err)
+	startServer(t, apiserver.NewDispersalServer(disperser.ServerConfig{
+		GrpcPort: "51017",
+	}, inmem.NewBlobStore(), &mock.MockTransactor{}, logger, disperser.NewMetrics("9017", nil, logger), nil, apiserver.RateConfig{}), "51017")
+
+	// The reflection service isn't registered by default
+	_, err = listServices("localhost:51017")
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+}
+
+func TestEncoderWarmUp(t *testing.T) {
+	logger, err := logging.GetLogger(logging.DefaultCLIConfig())
+	require.NoError(t, err)
+	server := apiserver.NewDispersalServer(disperser.ServerConfig{
+		GrpcPort:      "51033",
+		WarmUpEncoder: true,
+	}, inmem.NewBlobStore(), &mock.MockTransactor{}, logger, disperser.NewMetrics("9033", nil, logger), nil, apiserver.RateConfig{})
+	encoder := &encoding.MockEncoder{Delay: 50 * time.Millisecond}
+	encoder.On("Encode", tmock.Anything, tmock.Anything).Return(core.BlobCommitments{}, []*core.Chunk(nil), nil)
+	server.SetEncoder(encoder)
+	startServer(t, server, "51033")
+
+	conn, err := grpc.Dial("localhost:51033", grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer func() { _ = conn.Close() }()
+	healthStatus := func() grpc_health_v1.HealthCheckResponse_ServingStatus {
+		reply, err := grpc_health_v1.NewHealthClient(conn).Check(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+		require.NoError(t, err)
+		return reply.GetStatus()
+	}
+
+	// The server isn't ready until a blob of each power of two length up to the max blob size is encoded
+	assert.Equal(t, grpc_health_v1.HealthCheckResponse_NOT_SERVING, healthStatus())
+	assert.Eventually(t, func() bool {
+		return healthStatus() == grpc_health_v1.HealthCheckResponse_SERVING
+	}, 10*time.Second, 10*time.Millisecond)
+	lengths := make([]uint, 0)
+	for _, call := range encoder.Calls {
+		params := call.Arguments.Get(1).(core.EncodingParams)
+		assert.Equal(t, uint(1), params.NumChunks)
+		assert.Len(t, call.Arguments.Get(0).([]byte), int(core.GetBlobSize(params.ChunkLength)))
+		lengths = append(lengths, params.ChunkLength)
+	}
+	assert.Equal(t, []uint{1, 2, 4, 8, 16, 32, 64, 128, 256, 512, 1024, 2048, 4096, 8192, 16384, 32768}, lengths)
+}
+
+// startServer starts the server and waits for it to listen on the port
+func startServer(t *testing.T, server *apiserver.DispersalServer, port string) {
+	go func() {
+		_ = server.Start(context.Background())
+	}()
+	require.Eventually(t, func() bool {
+		conn, err := net.Dial("tcp", "localhost:"+port)
+		if err == nil {
+			_ = conn.Close()
+		}
+		return err == nil
+	}, 10*time.Second, 10*time.Millisecond)
+}
+
+// listServices lists the services of the server at the address through reflection
+func listServices(addr string) ([]string, error) {
+	conn, err := grpc.Dial(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = conn.Close() }()
+	stream, err := reflectionpb.NewServerReflectionClient(conn).ServerReflectionInfo(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	err = stream.Send(&reflectionpb.ServerReflectionRequest{
+		MessageRequest: &reflectionpb.ServerReflectionRequest_ListServices{},
+	})
+	if err != nil {
+		return nil, err
+	}
+	reply, err := stream.Recv()
+	if err != nil {
+		return nil, err
+	}
+	var services []string
+	for _, service := range reply.GetListServicesResponse().GetService() {
+		services = append(services, service.GetName())
+	}
+	return services, nil
+}
+
+func disperseBlobFrom(server *apiserver.DispersalServer, ip string, data []byte) (*pb.DisperseBlobReply, error) {
+	p := &peer.Peer{
+		Addr: &net.TCPAddr{
+			IP:   net.ParseIP(ip),
+			Port: 51001,
+		},
+	}
+	ctx := peer.NewContext(context.Background(), p)
+
+	return server.DisperseBlob(ctx, &pb.DisperseBlobRequest{
+		Data: data,
+		SecurityParams: []*pb.SecurityParams{
+			{
+				QuorumId:           0,
+				AdversaryThreshold: 80,
+				QuorumThreshold:    100,
+			},
+		},
+	})
 }
 
 func disperseBlob(t *testing.T, server *apiserver.DispersalServer, data []byte) (pb.BlobStatus, uint, []byte) {
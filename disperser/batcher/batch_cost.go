@@ -0,0 +1,130 @@
+package batcher
+
+import (
+	"context"
+	"encoding/hex"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/disperser"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// recordBatchCost records the cost of the confirmation transaction of the batch in the batch journal and in the
+// metrics. The cost is informative, so failing to record it doesn't fail the confirmation of the batch.
+func (b *Batcher) recordBatchCost(ctx context.Context, pending *PendingBatch, receipt *types.Receipt) {
+	if receipt.EffectiveGasPrice == nil {
+		b.logger.Warn("[batcher] confirmation receipt has no effective gas price, not recording the cost of the batch", "txnHash", receipt.TxHash.Hex())
+		return
+	}
+
+	cost := computeBatchCost(pending, receipt)
+	b.Metrics.AddBatchCost(getQuorumSet(pending), cost.GasUsed, cost.TotalCost)
+	if err := b.Queue.StoreBatchCost(ctx, pending.BatchHeaderHash, cost); err != nil {
+		b.logger.Error("[batcher] failed to store the cost of the batch", "batchHeaderHash", hex.EncodeToString(pending.BatchHeaderHash[:]), "err", err)
+	}
+}
+
+// computeBatchCost computes the cost of the confirmation transaction of the batch, and attributes it to the blobs of
+// the batch in proportion to their encoded length
+func computeBatchCost(pending *PendingBatch, receipt *types.Receipt) *disperser.BatchCost {
+	totalCost := new(big.Int).Mul(new(big.Int).SetUint64(receipt.GasUsed), receipt.EffectiveGasPrice)
+
+	encodedLengths := make([]uint, len(pending.Blobs))
+	for i, blob := range pending.Blobs {
+		for _, quorumInfo := range blob.BlobHeader.QuorumInfos {
+			encodedLengths[i] += quorumInfo.EncodedBlobLength
+		}
+	}
+	shares := attributeCost(totalCost, encodedLengths)
+
+	blobCosts := make([]*disperser.BlobCost, len(pending.Blobs))
+	for i, blob := range pending.Blobs {
+		blobCosts[i] = &disperser.BlobCost{
+			BlobKey:       blob.Metadata.GetBlobKey(),
+			BlobIndex:     blob.BlobIndex,
+			EncodedLength: encodedLengths[i],
+			Cost:          shares[i],
+		}
+	}
+	sort.Slice(blobCosts, func(i, j int) bool {
+		return blobCosts[i].BlobIndex < blobCosts[j].BlobIndex
+	})
+
+	return &disperser.BatchCost{
+		ConfirmationTxnHash:     receipt.TxHash,
+		ConfirmationBlockNumber: uint32(receipt.BlockNumber.Uint64()),
+		GasUsed:                 receipt.GasUsed,
+		EffectiveGasPrice:       new(big.Int).Set(receipt.EffectiveGasPrice),
+		TotalCost:               totalCost,
+		BlobCosts:               blobCosts,
+	}
+}
+
+// attributeCost splits the cost in proportion to the weights, so that the shares sum exactly to the cost. Each share is
+// rounded down, and the wei left over by the rounding go to the shares with the largest remainders, the first ones
+// first on ties. The cost is split evenly if all the weights are 0.
+func attributeCost(cost *big.Int, weights []uint) []*big.Int {
+	shares := make([]*big.Int, len(weights))
+	if len(weights) == 0 {
+		return shares
+	}
+
+	bigWeights := make([]*big.Int, len(weights))
+	totalWeight := new(big.Int)
+	for i, weight := range weights {
+		bigWeights[i] = new(big.Int).SetUint64(uint64(weight))
+		totalWeight.Add(totalWeight, bigWeights[i])
+	}
+	if totalWeight.Sign() == 0 {
+		for i := range bigWeights {
+			bigWeights[i].SetInt64(1)
+		}
+		totalWeight.SetInt64(int64(len(weights)))
+	}
+
+	remainders := make([]*big.Int, len(weights))
+	leftover := new(big.Int).Set(cost)
+	for i := range weights {
+		shares[i], remainders[i] = new(big.Int).QuoRem(new(big.Int).Mul(cost, bigWeights[i]), totalWeight, new(big.Int))
+		leftover.Sub(leftover, shares[i])
+	}
+
+	// The leftover is less than the number of shares, as each share is rounded down by less than 1 wei
+	order := make([]int, len(weights))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return remainders[order[i]].Cmp(remainders[order[j]]) > 0
+	})
+	for i := 0; leftover.Sign() > 0; i++ {
+		shares[order[i]].Add(shares[order[i]], big.NewInt(1))
+		leftover.Sub(leftover, big.NewInt(1))
+	}
+	return shares
+}
+
+// getQuorumSet returns the quorums of the blobs of the batch, e.g. "0,1", to label the cost of the batch with
+func getQuorumSet(pending *PendingBatch) string {
+	quorums := make(map[core.QuorumID]struct{})
+	for _, blob := range pending.Blobs {
+		for _, quorumInfo := range blob.BlobHeader.QuorumInfos {
+			quorums[quorumInfo.QuorumID] = struct{}{}
+		}
+	}
+	quorumIDs := make([]int, 0, len(quorums))
+	for quorumID := range quorums {
+		quorumIDs = append(quorumIDs, int(quorumID))
+	}
+	sort.Ints(quorumIDs)
+
+	labels := make([]string, len(quorumIDs))
+	for i, quorumID := range quorumIDs {
+		labels[i] = strconv.Itoa(quorumID)
+	}
+	return strings.Join(labels, ",")
+}
@@ -0,0 +1,35 @@
+package batcher
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAttributeCost(t *testing.T) {
+	testCases := []struct {
+		name    string
+		cost    int64
+		weights []uint
+		shares  []int64
+	}{
+		{name: "exact", cost: 100, weights: []uint{1, 3}, shares: []int64{25, 75}},
+		{name: "largest remainders", cost: 100, weights: []uint{1, 1, 1}, shares: []int64{34, 33, 33}},
+		{name: "uneven remainders", cost: 10, weights: []uint{3, 3, 1}, shares: []int64{4, 4, 2}},
+		{name: "zero weight", cost: 7, weights: []uint{0, 2}, shares: []int64{0, 7}},
+		{name: "all zero weights", cost: 5, weights: []uint{0, 0}, shares: []int64{3, 2}},
+		{name: "zero cost", cost: 0, weights: []uint{1, 2}, shares: []int64{0, 0}},
+		{name: "no blobs", cost: 5, weights: []uint{}, shares: []int64{}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			shares := attributeCost(big.NewInt(tc.cost), tc.weights)
+			assert.Len(t, shares, len(tc.shares))
+			for i, share := range shares {
+				assert.Equal(t, big.NewInt(tc.shares[i]), share, "share %d", i)
+			}
+		})
+	}
+}
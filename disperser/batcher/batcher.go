@@ -3,14 +3,19 @@ package batcher
 import (
 	"bytes"
 	"context"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"math"
+	"sort"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/core"
 	"github.com/Layr-Labs/eigenda/disperser"
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/gammazero/workerpool"
@@ -46,13 +51,145 @@ type TimeoutConfig struct {
 type Config struct {
 	PullInterval             time.Duration
 	FinalizerInterval        time.Duration
-	EncoderSocket            string
+	EncoderSockets           []string
 	SRSOrder                 int
 	NumConnections           int
 	EncodingRequestQueueSize int
 	// BatchSizeMBLimit is the maximum size of a batch in MB
 	BatchSizeMBLimit     uint
 	MaxNumRetriesPerBlob uint
+	// MinSignedPercentage is the minimum percentage of the stake of each quorum of a blob which must sign the batch for
+	// the blob to be confirmed, even if the blob requires a lower quorum threshold
+	MinSignedPercentage uint8
+	// ConfirmationRetryInterval is the delay before attempting again to confirm a batch of the confirmation queue,
+	// doubling with each failed attempt
+	ConfirmationRetryInterval time.Duration
+	// MaxConfirmationAttempts is the number of attempts to confirm a batch of the confirmation queue before its blobs
+	// are failed, to be retried in another batch
+	MaxConfirmationAttempts uint
+	// MaxConfirmationBlockAge is the max number of blocks between the reference block of a batch of the confirmation
+	// queue and the current block for the batch to be confirmed. It should not exceed the BLOCK_STALE_MEASURE of the
+	// EigenDAServiceManager, past which the confirmation transaction reverts. Older batches are removed from the queue
+	// and their blobs retried in another batch. The age of the batches is not checked if it is 0.
+	MaxConfirmationBlockAge uint
+	// MaxReferenceBlockAge is the max number of blocks between the reference block of a batch and the current block
+	// when the batch is made, so that the nodes don't reject it as stale. The reference block isn't refreshed if it is 0.
+	MaxReferenceBlockAge uint
+	// EncodingOverprovisionPercent is the margin by which the chunks assigned to the operators exceed the nominal number
+	// of chunks of each quorum, as a percentage of it. It is recorded in the blob headers, from which the nodes and the
+	// retrievers read it, so that the blobs can still be retrieved when that many operators go offline.
+	EncodingOverprovisionPercent uint
+	// StuckBlobSLA is how long a blob may be processing before the watchdog reports it as stuck. The watchdog is
+	// disabled if it is 0.
+	StuckBlobSLA time.Duration
+	// WatchdogInterval is the interval at which the watchdog checks for stuck blobs
+	WatchdogInterval time.Duration
+	// RedriveStuckBlobs is whether the watchdog re-drives the stuck blobs, so that they are selected again
+	RedriveStuckBlobs bool
+	// DailyQuota is the number of bytes of each account which can be confirmed per UTC day, once an account usage store
+	// is set. The blobs of an account over its quota are not batched. The accounts have no daily quota if it is 0,
+	// except for those in AccountDailyQuotas.
+	DailyQuota uint64
+	// AccountDailyQuotas overrides DailyQuota for the given accounts, 0 exempting an account from the quota
+	AccountDailyQuotas map[core.AccountID]uint64
+	// QuotaGracePeriod is how long the blobs of an account over its quota wait for the quota to free up before they
+	// fail with the QuotaExceeded status
+	QuotaGracePeriod time.Duration
+	// AccountWeights are the weights of the accounts in the selection of the blobs to encode, which shares the
+	// selection between the accounts with pending blobs in proportion to their weights. The accounts not in it have a
+	// weight of 1.
+	AccountWeights map[core.AccountID]uint64
+	// MaxBlobWait is how long a blob may wait before it is selected ahead of the blobs of the other accounts, whatever
+	// the weights of the accounts. The blobs are only selected by weight if it is 0.
+	MaxBlobWait time.Duration
+	// FeatureStakeThreshold is the minimum percentage of the stake of each quorum which must advertise an optional node
+	// feature in its replies to the last batch for the batcher to use the feature. No optional feature is used if it
+	// is 0.
+	FeatureStakeThreshold uint8
+	// MinChunkLength is the floor of the chunk lengths of the encodings of the blobs, which raises the degenerate chunk
+	// lengths of the small blobs and of the large quorums. It is the MinChunkLength of the assignment coordinator, see
+	// core.StdAssignmentCoordinator, and must not exceed core.MaxMinChunkLength.
+	MinChunkLength uint
+	// LargeQuorumOperatorThreshold and LargeQuorumMinChunkBytes raise the chunk lengths of the quorums of at least
+	// LargeQuorumOperatorThreshold operators to LargeQuorumMinChunkBytes bytes, with fewer proofs per encoded byte. They
+	// are the fields of the assignment coordinator of the same names, see core.StdAssignmentCoordinator.
+	LargeQuorumOperatorThreshold uint
+	LargeQuorumMinChunkBytes     uint
+}
+
+// Validate checks the config before the batcher starts, and returns all the violations at once
+func (c Config) Validate() error {
+	var errs []error
+	if c.PullInterval <= 0 {
+		errs = append(errs, fmt.Errorf("the pull interval must be positive, but found %s", c.PullInterval))
+	}
+	if c.FinalizerInterval <= 0 {
+		errs = append(errs, fmt.Errorf("the finalizer interval must be positive, but found %s", c.FinalizerInterval))
+	}
+	if c.SRSOrder <= 0 {
+		errs = append(errs, fmt.Errorf("the SRS order must be positive, but found %d", c.SRSOrder))
+	}
+	if c.NumConnections <= 0 {
+		errs = append(errs, fmt.Errorf("the number of connections must be positive, but found %d", c.NumConnections))
+	}
+	if c.EncodingRequestQueueSize <= 0 {
+		errs = append(errs, fmt.Errorf("the encoding request queue size must be positive, but found %d", c.EncodingRequestQueueSize))
+	}
+	if c.BatchSizeMBLimit == 0 {
+		errs = append(errs, errors.New("the batch size limit must be positive"))
+	}
+	if c.MinSignedPercentage > 100 {
+		errs = append(errs, fmt.Errorf("the minimum signed percentage must be at most 100, but found %d", c.MinSignedPercentage))
+	}
+	if c.EncodingOverprovisionPercent > core.MaxOverprovisionPercent {
+		errs = append(errs, fmt.Errorf("the encoding overprovision percent must be at most %d, but found %d", core.MaxOverprovisionPercent, c.EncodingOverprovisionPercent))
+	}
+	if c.StuckBlobSLA < 0 {
+		errs = append(errs, fmt.Errorf("the stuck blob SLA must not be negative (0 to disable the watchdog), but found %s", c.StuckBlobSLA))
+	}
+	if c.StuckBlobSLA > 0 && c.WatchdogInterval <= 0 {
+		errs = append(errs, fmt.Errorf("the watchdog interval must be positive with a stuck blob SLA, but found %s", c.WatchdogInterval))
+	}
+	if c.QuotaGracePeriod < 0 {
+		errs = append(errs, fmt.Errorf("the quota grace period must not be negative, but found %s", c.QuotaGracePeriod))
+	}
+	for accountID, weight := range c.AccountWeights {
+		if weight == 0 {
+			errs = append(errs, fmt.Errorf("the weight of account %q must be positive", accountID))
+		}
+	}
+	if c.MaxBlobWait < 0 {
+		errs = append(errs, fmt.Errorf("the max blob wait must not be negative (0 to disable it), but found %s", c.MaxBlobWait))
+	}
+	if c.FeatureStakeThreshold > 100 {
+		errs = append(errs, fmt.Errorf("the feature stake threshold must be at most 100, but found %d", c.FeatureStakeThreshold))
+	}
+	if c.MinChunkLength > core.MaxMinChunkLength {
+		errs = append(errs, fmt.Errorf("the min chunk length must be at most %d, but found %d", core.MaxMinChunkLength, c.MinChunkLength))
+	}
+	if (c.LargeQuorumOperatorThreshold == 0) != (c.LargeQuorumMinChunkBytes == 0) {
+		errs = append(errs, errors.New("the large quorum operator threshold and min chunk bytes must be set together"))
+	}
+	return errors.Join(errs...)
+}
+
+// Validate checks that the timeouts are positive, and returns all the violations at once
+func (c TimeoutConfig) Validate() error {
+	var errs []error
+	for _, d := range []struct {
+		name  string
+		value time.Duration
+	}{
+		{"encoding timeout", c.EncodingTimeout},
+		{"attestation timeout", c.AttestationTimeout},
+		{"chain read timeout", c.ChainReadTimeout},
+		{"chain write timeout", c.ChainWriteTimeout},
+	} {
+		if d.value <= 0 {
+			errs = append(errs, fmt.Errorf("the %s must be positive, but found %s", d.name, d.value))
+		}
+	}
+	return errors.Join(errs...)
 }
 
 type Batcher struct {
@@ -63,16 +200,40 @@ type Batcher struct {
 	Dispatcher    disperser.Dispatcher
 	Confirmer     disperser.BatchConfirmer
 	EncoderClient disperser.EncoderClient
+	// ConfirmationQueue decouples the aggregation of the batches from their confirmation when it is set: the
+	// aggregated batches are pushed to the queue, which is drained by a separate confirmer. Otherwise, each batch is
+	// confirmed before the next one is made.
+	ConfirmationQueue ConfirmationQueue
 
 	ChainState            core.IndexedChainState
 	AssignmentCoordinator core.AssignmentCoordinator
 	Aggregator            core.SignatureAggregator
 	EncodingStreamer      *EncodingStreamer
 	Metrics               *Metrics
+	// Watchdog reports and re-drives the blobs stuck in processing. It is nil if the StuckBlobSLA is 0.
+	Watchdog *Watchdog
+	// AccountUsage records the bytes confirmed for each account, against which the daily quotas are enforced. The
+	// daily quotas are not enforced if it is nil.
+	AccountUsage disperser.AccountUsageStore
 
 	ethClient common.EthClient
 	finalizer Finalizer
 	logger    common.Logger
+
+	// confirmationNotify wakes up the confirmer when a batch is pushed to the confirmation queue
+	confirmationNotify chan struct{}
+
+	// enabledFeatures are the optional node features advertised by enough of the stake to be used, as of the last batch
+	enabledFeatures   core.NodeFeatures
+	enabledFeaturesMu sync.RWMutex
+
+	// draining is set once Drain is called, and drained once the blobs queued before drainCutoff are all processed
+	draining    atomic.Bool
+	drained     atomic.Bool
+	drainCutoff atomic.Uint64
+
+	// accountShares are the bytes of each account in the recent batches, reported to the metrics
+	accountShares accountShares
 }
 
 func NewBatcher(
@@ -90,14 +251,32 @@ func NewBatcher(
 	logger common.Logger,
 	metrics *Metrics,
 ) (*Batcher, error) {
+	if config.MinSignedPercentage > 100 {
+		return nil, fmt.Errorf("invalid minimum signed percentage %d: must be at most 100", config.MinSignedPercentage)
+	}
+	if config.FeatureStakeThreshold > 100 {
+		return nil, fmt.Errorf("invalid feature stake threshold %d: must be at most 100", config.FeatureStakeThreshold)
+	}
+	if config.MinChunkLength > core.MaxMinChunkLength {
+		return nil, fmt.Errorf("invalid minimum chunk length %d: must be at most %d", config.MinChunkLength, core.MaxMinChunkLength)
+	}
+
 	batchTrigger := NewEncodedSizeNotifier(
 		make(chan struct{}, 1),
 		uint64(config.BatchSizeMBLimit)*1024*1024, // convert to bytes
 	)
 	streamerConfig := StreamerConfig{
-		SRSOrder:               config.SRSOrder,
-		EncodingRequestTimeout: config.PullInterval,
-		EncodingQueueLimit:     config.EncodingRequestQueueSize,
+		SRSOrder:                     config.SRSOrder,
+		EncodingRequestTimeout:       config.PullInterval,
+		EncodingQueueLimit:           config.EncodingRequestQueueSize,
+		MaxReferenceBlockAge:         config.MaxReferenceBlockAge,
+		EncodingOverprovisionPercent: config.EncodingOverprovisionPercent,
+		DailyQuota:                   config.DailyQuota,
+		AccountDailyQuotas:           config.AccountDailyQuotas,
+		QuotaGracePeriod:             config.QuotaGracePeriod,
+		AccountWeights:               config.AccountWeights,
+		MaxBlobWait:                  config.MaxBlobWait,
+		BatchSizeLimit:               uint64(config.BatchSizeMBLimit) * 1024 * 1024,
 	}
 	encodingWorkerPool := workerpool.New(config.NumConnections)
 	encodingStreamer, err := NewEncodingStreamer(streamerConfig, queue, chainState, encoderClient, assignmentCoordinator, batchTrigger, encodingWorkerPool, metrics.EncodingStreamerMetrics, logger)
@@ -105,6 +284,16 @@ func NewBatcher(
 		return nil, err
 	}
 
+	var watchdog *Watchdog
+	if config.StuckBlobSLA > 0 {
+		watchdog = NewWatchdog(WatchdogConfig{
+			Interval:             config.WatchdogInterval,
+			SLA:                  config.StuckBlobSLA,
+			Redrive:              config.RedriveStuckBlobs,
+			MaxNumRetriesPerBlob: config.MaxNumRetriesPerBlob,
+		}, queue, encodingStreamer, metrics, logger)
+	}
+
 	return &Batcher{
 		Config:        config,
 		TimeoutConfig: timeoutConfig,
@@ -119,10 +308,13 @@ func NewBatcher(
 		Aggregator:            aggregator,
 		EncodingStreamer:      encodingStreamer,
 		Metrics:               metrics,
+		Watchdog:              watchdog,
 
 		ethClient: ethClient,
 		finalizer: finalizer,
 		logger:    logger,
+
+		confirmationNotify: make(chan struct{}, 1),
 	}, nil
 }
 
@@ -134,12 +326,21 @@ func (b *Batcher) Start(ctx context.Context) error {
 	// Wait for few seconds for indexer to index blockchain
 	// This won't be needed when we switch to using Graph node
 	time.Sleep(indexerWarmupDelay)
+	// Recover the confirmation queue before encoding, so that its blobs are not encoded again
+	if b.ConfirmationQueue != nil {
+		if err := b.startConfirmer(ctx); err != nil {
+			return err
+		}
+	}
 	err = b.EncodingStreamer.Start(ctx)
 	if err != nil {
 		return err
 	}
 	batchTrigger := b.EncodingStreamer.EncodedSizeNotifier
 	b.finalizer.Start(ctx)
+	if b.Watchdog != nil {
+		b.Watchdog.Start(ctx)
+	}
 
 	go func() {
 		ticker := time.NewTicker(b.PullInterval)
@@ -150,22 +351,10 @@ func (b *Batcher) Start(ctx context.Context) error {
 			case <-ctx.Done():
 				return
 			case <-ticker.C:
-				if err := b.HandleSingleBatch(ctx); err != nil {
-					if errors.Is(err, errNoEncodedResults) {
-						b.logger.Warn("no encoded results to make a batch with")
-					} else {
-						b.logger.Error("failed to process a batch", "err", err)
-					}
-				}
+				b.processBatch(ctx)
 			case <-batchTrigger.Notify:
 				ticker.Stop()
-				if err := b.HandleSingleBatch(ctx); err != nil {
-					if errors.Is(err, errNoEncodedResults) {
-						b.logger.Warn("no encoded results to make a batch with")
-					} else {
-						b.logger.Error("failed to process a batch", "err", err)
-					}
-				}
+				b.processBatch(ctx)
 				ticker.Reset(b.PullInterval)
 			}
 		}
@@ -174,6 +363,23 @@ func (b *Batcher) Start(ctx context.Context) error {
 	return nil
 }
 
+// processBatch makes a batch of the encoded blobs, unless the batcher is drained
+func (b *Batcher) processBatch(ctx context.Context) {
+	if b.drained.Load() {
+		return
+	}
+	if err := b.HandleSingleBatch(ctx); err != nil {
+		if errors.Is(err, errNoEncodedResults) {
+			b.logger.Warn("no encoded results to make a batch with")
+		} else {
+			b.logger.Error("failed to process a batch", "err", err)
+		}
+	}
+	if _, err := b.CheckDrained(ctx); err != nil {
+		b.logger.Error("failed to check whether the batcher is drained", "err", err)
+	}
+}
+
 func (b *Batcher) handleFailure(ctx context.Context, blobMetadatas []*disperser.BlobMetadata) error {
 	var result *multierror.Error
 	for _, metadata := range blobMetadatas {
@@ -189,6 +395,49 @@ func (b *Batcher) handleFailure(ctx context.Context, blobMetadatas []*disperser.
 	// Return the error(s)
 	return result.ErrorOrNil()
 }
+
+// handleInsufficientSignatures handles the blobs of the batch which received insufficient signatures as failures, to be
+// retried in a later batch, except that the blobs out of retries are marked with the InsufficientSignatures status
+// rather than failed. Their confirmation info records the percentage of the stake of each of their quorums which signed
+// the batch, so that their clients can tell how far they were from their thresholds.
+func (b *Batcher) handleInsufficientSignatures(ctx context.Context, batch *batch, headerHash [32]byte, quorumResults map[core.QuorumID]*core.QuorumResult, blobMetadatas []*disperser.BlobMetadata) error {
+	blobIndexes := make(map[disperser.BlobKey]int, len(batch.BlobMetadata))
+	for blobIndex, metadata := range batch.BlobMetadata {
+		blobIndexes[metadata.GetBlobKey()] = blobIndex
+	}
+
+	var result *multierror.Error
+	blobsToRetry := make([]*disperser.BlobMetadata, 0, len(blobMetadatas))
+	for _, metadata := range blobMetadatas {
+		if metadata.NumRetries < b.MaxNumRetriesPerBlob {
+			blobsToRetry = append(blobsToRetry, metadata)
+			continue
+		}
+
+		blobIndex := blobIndexes[metadata.GetBlobKey()]
+		confirmationInfo := &disperser.ConfirmationInfo{
+			BatchHeaderHash:      headerHash,
+			BlobIndex:            uint32(blobIndex),
+			ReferenceBlockNumber: uint32(batch.BatchHeader.ReferenceBlockNumber),
+			BatchRoot:            batch.BatchHeader.BatchRoot[:],
+			QuorumResults:        quorumResults,
+			BlobQuorumInfos:      batch.BlobHeaders[blobIndex].QuorumInfos,
+		}
+		if _, err := b.Queue.MarkBlobInsufficientSignatures(ctx, metadata, confirmationInfo); err != nil {
+			b.logger.Error("HandleSingleBatch: error marking blob with insufficient signatures", "blobKey", metadata.GetBlobKey().String(), "err", err)
+			result = multierror.Append(result, err)
+			continue
+		}
+		b.EncodingStreamer.RemoveEncodedBlob(metadata)
+		b.Metrics.UpdateCompletedBlob(int(metadata.RequestMetadata.BlobSize), disperser.InsufficientSignatures)
+	}
+	if err := b.handleFailure(ctx, blobsToRetry); err != nil {
+		result = multierror.Append(result, err)
+	}
+
+	return result.ErrorOrNil()
+}
+
 func (b *Batcher) HandleSingleBatch(ctx context.Context) error {
 	log := b.logger
 	// start a timer
@@ -203,11 +452,13 @@ func (b *Batcher) HandleSingleBatch(ctx context.Context) error {
 		return err
 	}
 	log.Trace("[batcher] CreateBatch took", "duration", time.Since(stageTimer))
+	b.Metrics.UpdateAccountBatchShares(b.accountShares.add(batch.BlobMetadata))
 
 	// Dispatch encoded batch
 	log.Trace("[batcher] Dispatching encoded batch...")
 	stageTimer = time.Now()
 	update := b.Dispatcher.DisperseBatch(ctx, batch.BatchMetadata.State, batch.EncodedBlobs, batch.BatchHeader)
+	update, versions := b.collectVersions(update, len(batch.BatchMetadata.State.IndexedOperators))
 	log.Trace("[batcher] DisperseBatch took", "duration", time.Since(stageTimer))
 
 	// Get the batch header hash
@@ -221,11 +472,13 @@ func (b *Batcher) HandleSingleBatch(ctx context.Context) error {
 	// Aggregate the signatures
 	log.Trace("[batcher] Aggregating signatures...")
 
-	// construct quorumParams
+	// construct quorumParams, in increasing order as the quorums of the batch header confirmed onchain, which the
+	// aggregate public keys of the quorums must match
 	quorumIDs := make([]core.QuorumID, 0, len(batch.BatchMetadata.QuorumInfos))
 	for quorumID := range batch.BatchMetadata.QuorumInfos {
 		quorumIDs = append(quorumIDs, quorumID)
 	}
+	sort.Slice(quorumIDs, func(i, j int) bool { return quorumIDs[i] < quorumIDs[j] })
 
 	stageTimer = time.Now()
 	aggSig, err := b.Aggregator.AggregateSignatures(batch.BatchMetadata.State, quorumIDs, headerHash, update)
@@ -236,99 +489,243 @@ func (b *Batcher) HandleSingleBatch(ctx context.Context) error {
 	log.Trace("[batcher] AggregateSignatures took", "duration", time.Since(stageTimer))
 	b.Metrics.ObserveLatency("AggregateSignatures", float64(time.Since(stageTimer).Milliseconds()))
 	b.Metrics.UpdateAttestation(len(batch.BatchMetadata.State.IndexedOperators), len(aggSig.NonSigners))
+	operatorVersions := <-versions
+	b.updateVersionCensus(batch.BatchMetadata.State.OperatorState, operatorVersions)
 
-	passed, numPassed := getBlobQuorumPassStatus(aggSig.QuorumResults, batch.BlobHeaders)
-	// TODO(mooselumph): Determine whether to confirm the batch based on the number of successes
+	passed, numPassed := getBlobQuorumPassStatus(aggSig.QuorumResults, batch.BlobHeaders, b.MinSignedPercentage)
 	if numPassed == 0 {
-		_ = b.handleFailure(ctx, batch.BlobMetadata)
+		_ = b.handleInsufficientSignatures(ctx, batch, headerHash, aggSig.QuorumResults, batch.BlobMetadata)
 		return fmt.Errorf("HandleSingleBatch: no blobs received sufficient signatures")
 	}
 
-	// Confirm the batch
+	// Keep the operator state the batch was signed against, for auditing the batch once the operator set changed. The
+	// batch is confirmed regardless, as the snapshot isn't needed to confirm it.
+	snapshot := &disperser.OperatorStateSnapshot{
+		ReferenceBlockNumber: batch.BatchHeader.ReferenceBlockNumber,
+		QuantizationFactor:   QuantizationFactor,
+		OverprovisionPercent: b.EncodingOverprovisionPercent,
+		State:                batch.BatchMetadata.State.OperatorState,
+		OperatorVersions:     operatorVersions,
+	}
+	if err := b.Queue.StoreOperatorState(ctx, headerHash, snapshot); err != nil {
+		log.Error("HandleSingleBatch: failed to store the operator state snapshot", "batchHeaderHash", hex.EncodeToString(headerHash[:]), "err", err)
+	}
+	// Keep the signature the batch is confirmed with, from which the proof bundles of its blobs are exported
+	if err := b.Queue.StoreBatchSignature(ctx, headerHash, disperser.NewBatchSignature(quorumIDs, aggSig)); err != nil {
+		log.Error("HandleSingleBatch: failed to store the batch signature", "batchHeaderHash", hex.EncodeToString(headerHash[:]), "err", err)
+	}
+
+	pending := &PendingBatch{
+		BatchHeader:     batch.BatchHeader,
+		BatchHeaderHash: headerHash,
+		Aggregation:     aggSig,
+		Blobs:           make([]*PendingBlob, 0, numPassed),
+	}
+	blobsToRetry := make([]*disperser.BlobMetadata, 0)
+	for blobIndex, metadata := range batch.BlobMetadata {
+		// Don't confirm the blob if it didn't get enough signatures, but retry it in a later batch until it runs out of
+		// retries and fails
+		if !passed[blobIndex] {
+			log.Warn("HandleSingleBatch: blob received insufficient signatures, retrying", "blobKey", metadata.GetBlobKey().String())
+			blobsToRetry = append(blobsToRetry, metadata)
+			continue
+		}
+		// generate inclusion proof
+		if blobIndex >= len(batch.BlobHeaders) {
+			return fmt.Errorf("HandleSingleBatch: error confirming blobs: blob header at index %d not found in batch", blobIndex)
+		}
+		blobHeader := batch.BlobHeaders[blobIndex]
+
+		blobHeaderHash, err := blobHeader.GetBlobHeaderHash()
+		if err != nil {
+			return fmt.Errorf("HandleSingleBatch: failed to get blob header hash: %w", err)
+		}
+		merkleProof, err := batch.MerkleTree.GenerateProof(blobHeaderHash[:], 0)
+		if err != nil {
+			return fmt.Errorf("HandleSingleBatch: failed to generate blob header inclusion proof: %w", err)
+		}
+
+		pending.Blobs = append(pending.Blobs, &PendingBlob{
+			Metadata:       metadata,
+			BlobHeader:     blobHeader,
+			BlobIndex:      uint32(blobIndex),
+			InclusionProof: serializeProof(merkleProof),
+		})
+	}
+	if len(blobsToRetry) > 0 {
+		_ = b.handleInsufficientSignatures(ctx, batch, headerHash, aggSig.QuorumResults, blobsToRetry)
+	}
+
+	if b.ConfirmationQueue == nil {
+		err := b.submitConfirmation(ctx, pending)
+		// Once the batch landed, only resolving its batch ID is retried, since its blobs must not be batched again
+		for err != nil && pending.Confirmed {
+			log.Warn("HandleSingleBatch: error resolving the batch ID of the confirmed batch, retrying", "err", err)
+			select {
+			case <-ctx.Done():
+				return fmt.Errorf("HandleSingleBatch: %w", err)
+			case <-time.After(b.PullInterval):
+			}
+			err = b.submitConfirmation(ctx, pending)
+		}
+		if err != nil {
+			_ = b.handleFailure(ctx, pending.blobMetadata())
+			return fmt.Errorf("HandleSingleBatch: %w", err)
+		}
+		return b.markBlobsConfirmed(ctx, pending)
+	}
+
+	// The blobs are not encoded again for another batch while they wait for the confirmation of this one
+	b.EncodingStreamer.SetAwaitingConfirmation(pending.blobKeys(), true)
+	if err := b.ConfirmationQueue.Push(ctx, pending); err != nil {
+		b.EncodingStreamer.SetAwaitingConfirmation(pending.blobKeys(), false)
+		_ = b.handleFailure(ctx, pending.blobMetadata())
+		return fmt.Errorf("HandleSingleBatch: error queueing batch for confirmation: %w", err)
+	}
+	for _, blob := range pending.Blobs {
+		b.EncodingStreamer.RemoveEncodedBlob(blob.Metadata)
+	}
+	log.Trace("[batcher] Batch queued for confirmation", "batchHeaderHash", hex.EncodeToString(headerHash[:]), "numBlobs", len(pending.Blobs))
+	select {
+	case b.confirmationNotify <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// submitConfirmation confirms the batch onchain, unless its confirmation already landed, and resolves its batch ID.
+// The confirmation is recorded on the batch as soon as it lands, so that failing to resolve the batch ID doesn't
+// confirm the batch again but only retries resolving it.
+func (b *Batcher) submitConfirmation(ctx context.Context, pending *PendingBatch) error {
+	var txnReceipt *types.Receipt
+	if !pending.Confirmed {
+		var err error
+		txnReceipt, err = b.sendConfirmation(ctx, pending)
+		if err != nil {
+			return err
+		}
+		pending.Confirmed = true
+		pending.ConfirmationTxnHash = txnReceipt.TxHash
+		pending.ConfirmationBlockNumber = uint32(txnReceipt.BlockNumber.Uint64())
+		b.recordBatchCost(ctx, pending, txnReceipt)
+	}
+	if pending.BatchIDResolved {
+		return nil
+	}
+
+	if txnReceipt == nil {
+		var err error
+		txnReceipt, err = b.ethClient.TransactionReceipt(ctx, pending.ConfirmationTxnHash)
+		if err != nil {
+			return fmt.Errorf("error fetching the receipt of the confirmation transaction: %w", err)
+		}
+	}
+	batchID, err := b.getBatchID(ctx, txnReceipt)
+	if err != nil {
+		return fmt.Errorf("error fetching batch ID: %w", err)
+	}
+	pending.BatchIDResolved = true
+	pending.BatchID = batchID
+	return nil
+}
+
+// sendConfirmation sends the confirmation transaction of the batch, replacing the last one sent for the batch with
+// bumped fees, and returns the receipt of the transaction which landed. A transaction sent by a previous attempt may
+// have landed since, in which case its receipt is returned without sending another one.
+func (b *Batcher) sendConfirmation(ctx context.Context, pending *PendingBatch) (*types.Receipt, error) {
+	log := b.logger
+	txnReceipt, err := b.findConfirmationReceipt(ctx, pending)
+	if err != nil {
+		return nil, err
+	}
+	if txnReceipt != nil {
+		log.Info("[batcher] Batch confirmed by a previous attempt", "blockNumber", txnReceipt.BlockNumber, "txnHash", txnReceipt.TxHash.Hex())
+		return txnReceipt, nil
+	}
+
+	var replaced *types.Transaction
+	if n := len(pending.ConfirmationTxns); n > 0 {
+		replaced = pending.ConfirmationTxns[n-1]
+	}
 	log.Trace("[batcher] Confirming batch...")
-	stageTimer = time.Now()
-	txnReceipt, err := b.Confirmer.ConfirmBatch(ctx, batch.BatchHeader, aggSig.QuorumResults, aggSig)
+	stageTimer := time.Now()
+	txnReceipt, txn, err := b.Confirmer.ConfirmBatch(ctx, pending.BatchHeader, pending.Aggregation.QuorumResults, pending.Aggregation, replaced)
+	if txn != nil && txn != replaced {
+		pending.ConfirmationTxns = append(pending.ConfirmationTxns, txn)
+	}
 	if err != nil {
-		_ = b.handleFailure(ctx, batch.BlobMetadata)
-		return fmt.Errorf("HandleSingleBatch: error confirming batch: %w", err)
+		return nil, fmt.Errorf("error confirming batch: %w", err)
 	}
 	log.Trace("[batcher] ConfirmBatch took", "duration", time.Since(stageTimer))
 	log.Info("[batcher] Batch confirmed at block", "blockNumber", txnReceipt.BlockNumber, "txnHash", txnReceipt.TxHash.Hex())
 	b.Metrics.ObserveLatency("ConfirmBatch", float64(time.Since(stageTimer).Milliseconds()))
 	b.Metrics.GasUsed.Set(float64(txnReceipt.GasUsed))
+	return txnReceipt, nil
+}
 
-	batchID, err := b.getBatchID(ctx, txnReceipt)
-	if err != nil {
-		_ = b.handleFailure(ctx, batch.BlobMetadata)
-		return fmt.Errorf("HandleSingleBatch: error fetching batch ID: %w", err)
+// findConfirmationReceipt returns the receipt of the confirmation transaction of the batch which landed, if any. If a
+// transaction reverted, the nonce of the transactions sent for the batch is used up: they are forgotten, so that the
+// next transaction takes a fresh nonce.
+func (b *Batcher) findConfirmationReceipt(ctx context.Context, pending *PendingBatch) (*types.Receipt, error) {
+	for _, txn := range pending.ConfirmationTxns {
+		receipt, err := b.ethClient.TransactionReceipt(ctx, txn.Hash())
+		if errors.Is(err, ethereum.NotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error fetching the receipt of confirmation transaction %s: %w", txn.Hash().Hex(), err)
+		}
+		if receipt.Status != types.ReceiptStatusSuccessful {
+			b.logger.Warn("[batcher] Confirmation transaction reverted", "txnHash", txn.Hash().Hex())
+			pending.ConfirmationTxns = nil
+			return nil, nil
+		}
+		return receipt, nil
 	}
+	return nil, nil
+}
 
-	// Mark the blobs as complete
+// markBlobsConfirmed marks the blobs of a confirmed batch as confirmed. The blobs whose metadata fails to be updated
+// are retried in another batch.
+func (b *Batcher) markBlobsConfirmed(ctx context.Context, pending *PendingBatch) error {
+	log := b.logger
 	log.Trace("[batcher] Marking blobs as complete...")
-	stageTimer = time.Now()
+	stageTimer := time.Now()
 	blobsToRetry := make([]*disperser.BlobMetadata, 0)
+	confirmed := make([]*disperser.BlobMetadata, 0, len(pending.Blobs))
 	var updateConfirmationInfoErr error
-	for blobIndex, metadata := range batch.BlobMetadata {
-		// Mark the blob failed if it didn't get enough signatures.
-		status := disperser.Confirmed
-		if !passed[blobIndex] {
-			status = disperser.InsufficientSignatures
-		}
-
-		var blobHeader *core.BlobHeader
-		var proof []byte
-		if status == disperser.Confirmed {
-			// generate inclusion proof
-			if blobIndex >= len(batch.BlobHeaders) {
-				return fmt.Errorf("HandleSingleBatch: error confirming blobs: blob header at index %d not found in batch", blobIndex)
-			}
-			blobHeader = batch.BlobHeaders[blobIndex]
-
-			blobHeaderHash, err := blobHeader.GetBlobHeaderHash()
-			if err != nil {
-				return fmt.Errorf("HandleSingleBatch: failed to get blob header hash: %w", err)
-			}
-			merkleProof, err := batch.MerkleTree.GenerateProof(blobHeaderHash[:], 0)
-			if err != nil {
-				return fmt.Errorf("HandleSingleBatch: failed to generate blob header inclusion proof: %w", err)
-			}
-			proof = serializeProof(merkleProof)
-		}
-
+	for _, blob := range pending.Blobs {
+		metadata := blob.Metadata
 		confirmationInfo := &disperser.ConfirmationInfo{
-			BatchHeaderHash:         headerHash,
-			BlobIndex:               uint32(blobIndex),
-			SignatoryRecordHash:     core.ComputeSignatoryRecordHash(uint32(batch.BatchHeader.ReferenceBlockNumber), aggSig.NonSigners),
-			ReferenceBlockNumber:    uint32(batch.BatchHeader.ReferenceBlockNumber),
-			BatchRoot:               batch.BatchHeader.BatchRoot[:],
-			BlobInclusionProof:      proof,
-			BlobCommitment:          &batch.BlobHeaders[blobIndex].BlobCommitments,
-			BatchID:                 uint32(batchID),
-			ConfirmationTxnHash:     txnReceipt.TxHash,
-			ConfirmationBlockNumber: uint32(txnReceipt.BlockNumber.Uint64()),
+			BatchHeaderHash:         pending.BatchHeaderHash,
+			BlobIndex:               blob.BlobIndex,
+			SignatoryRecordHash:     core.ComputeSignatoryRecordHash(uint32(pending.BatchHeader.ReferenceBlockNumber), pending.Aggregation.NonSigners),
+			ReferenceBlockNumber:    uint32(pending.BatchHeader.ReferenceBlockNumber),
+			BatchRoot:               pending.BatchHeader.BatchRoot[:],
+			BlobInclusionProof:      blob.InclusionProof,
+			BlobCommitment:          &blob.BlobHeader.BlobCommitments,
+			BatchID:                 pending.BatchID,
+			ConfirmationTxnHash:     pending.ConfirmationTxnHash,
+			ConfirmationBlockNumber: pending.ConfirmationBlockNumber,
 			Fee:                     []byte{0}, // No fee
-			QuorumResults:           aggSig.QuorumResults,
-			BlobQuorumInfos:         batch.BlobHeaders[blobIndex].QuorumInfos,
+			QuorumResults:           pending.Aggregation.QuorumResults,
+			BlobQuorumInfos:         blob.BlobHeader.QuorumInfos,
 		}
 
-		if status == disperser.Confirmed {
-			if _, updateConfirmationInfoErr = b.Queue.MarkBlobConfirmed(ctx, metadata, confirmationInfo); updateConfirmationInfoErr == nil {
-				b.Metrics.UpdateCompletedBlob(int(metadata.RequestMetadata.BlobSize), disperser.Confirmed)
-				// remove encoded blob from storage so we don't disperse it again
-				b.EncodingStreamer.RemoveEncodedBlob(metadata)
-			}
-		} else if status == disperser.InsufficientSignatures {
-			if _, updateConfirmationInfoErr = b.Queue.MarkBlobInsufficientSignatures(ctx, metadata, confirmationInfo); updateConfirmationInfoErr == nil {
-				b.Metrics.UpdateCompletedBlob(int(metadata.RequestMetadata.BlobSize), disperser.InsufficientSignatures)
-				// remove encoded blob from storage so we don't disperse it again
-				b.EncodingStreamer.RemoveEncodedBlob(metadata)
-			}
-		} else {
-			updateConfirmationInfoErr = fmt.Errorf("HandleSingleBatch: trying to update confirmation info for blob in status other than confirmed or insufficient signatures: %s", status.String())
+		if _, updateConfirmationInfoErr = b.Queue.MarkBlobConfirmed(ctx, metadata, confirmationInfo); updateConfirmationInfoErr == nil {
+			b.Metrics.UpdateCompletedBlob(int(metadata.RequestMetadata.BlobSize), disperser.Confirmed)
+			confirmed = append(confirmed, metadata)
+			// remove encoded blob from storage so we don't disperse it again
+			b.EncodingStreamer.RemoveEncodedBlob(metadata)
+		} else if errors.Is(updateConfirmationInfoErr, disperser.ErrBlobAlreadyConfirmed) {
+			// Another batcher confirmed the blob first, so there is nothing left to do for it
+			log.Warn("HandleSingleBatch: blob is already confirmed", "blobKey", metadata.GetBlobKey().String())
+			b.EncodingStreamer.RemoveEncodedBlob(metadata)
+			updateConfirmationInfoErr = nil
 		}
 		if updateConfirmationInfoErr != nil {
-			log.Error("HandleSingleBatch: error updating blob confirmed metadata", "err", updateConfirmationInfoErr)
-			blobsToRetry = append(blobsToRetry, batch.BlobMetadata[blobIndex])
+			log.Error("HandleSingleBatch: error updating blob confirmed metadata", "blobKey", metadata.GetBlobKey().String(), "confirmationInfo", confirmationInfo, "err", updateConfirmationInfoErr)
+			blobsToRetry = append(blobsToRetry, metadata)
 		}
 		requestTime := time.Unix(0, int64(metadata.RequestMetadata.RequestedAt))
 		b.Metrics.ObserveLatency("E2E", float64(time.Since(requestTime).Milliseconds()))
@@ -336,21 +733,152 @@ func (b *Batcher) HandleSingleBatch(ctx context.Context) error {
 
 	if len(blobsToRetry) > 0 {
 		_ = b.handleFailure(ctx, blobsToRetry)
-		if len(blobsToRetry) == len(batch.BlobMetadata) {
+		if len(blobsToRetry) == len(pending.Blobs) {
 			return fmt.Errorf("HandleSingleBatch: failed to update blob confirmed metadata for all blobs in batch: %w", updateConfirmationInfoErr)
 		}
 	}
 
+	b.recordConfirmedBytes(ctx, confirmed)
+
 	log.Trace("[batcher] Update confirmation info took", "duration", time.Since(stageTimer))
 	b.Metrics.ObserveLatency("UpdateConfirmationInfo", float64(time.Since(stageTimer).Milliseconds()))
 	batchSize := int64(0)
-	for _, blobMeta := range batch.BlobMetadata {
-		batchSize += int64(blobMeta.RequestMetadata.BlobSize)
+	for _, blob := range pending.Blobs {
+		batchSize += int64(blob.Metadata.RequestMetadata.BlobSize)
 	}
 	b.Metrics.IncrementBatchCount(batchSize)
 	return nil
 }
 
+// HandleConfirmations confirms the batches of the confirmation queue in order, until the queue is empty or a batch
+// fails to be confirmed. The batches after a failing batch wait for it to be confirmed, so that the confirmation
+// transactions are sent one at a time, in the order the batches were aggregated. A failing batch is attempted again
+// after an exponential backoff, until it failed MaxConfirmationAttempts times: it is then removed from the queue and its
+// blobs are retried in another batch. Each attempt replaces the transaction sent by the previous one at the same nonce,
+// with fees priced from the current network conditions and bumped over the fees of the replaced transaction, and
+// first checks whether any transaction sent for the batch landed meanwhile. A batch whose reference block is older
+// than MaxConfirmationBlockAge is removed from the queue without being attempted, since the contract would reject it,
+// and its blobs are retried in another batch, unless a transaction was already sent for it.
+//
+// Once the confirmation transaction landed, the confirmation is recorded in the queue and failing to resolve the
+// batch ID is retried after ConfirmationRetryInterval, without counting as a failed attempt nor confirming the batch
+// again. A batch is removed from the queue once its blobs are marked confirmed. If the batcher crashes after the
+// confirmation transaction landed, the batcher recovering the queue finds the transaction among the ones recorded for
+// the batch, unless the crash happened before the queue recorded it, in which case the batch is confirmed again.
+func (b *Batcher) HandleConfirmations(ctx context.Context) error {
+	batches, err := b.ConfirmationQueue.List(ctx)
+	if err != nil {
+		return fmt.Errorf("HandleConfirmations: error listing the confirmation queue: %w", err)
+	}
+
+	var currentBlock uint
+	if b.MaxConfirmationBlockAge > 0 && len(batches) > 0 {
+		currentBlock, err = b.ChainState.GetCurrentBlockNumber()
+		if err != nil {
+			return fmt.Errorf("HandleConfirmations: error getting the current block number: %w", err)
+		}
+	}
+
+	for _, pending := range batches {
+		if b.MaxConfirmationBlockAge > 0 && !pending.Confirmed && len(pending.ConfirmationTxns) == 0 && currentBlock > pending.BatchHeader.ReferenceBlockNumber+b.MaxConfirmationBlockAge {
+			b.logger.Error("HandleConfirmations: reference block of batch is too old to be confirmed, retrying its blobs in another batch", "batchHeaderHash", hex.EncodeToString(pending.BatchHeaderHash[:]), "referenceBlockNumber", pending.BatchHeader.ReferenceBlockNumber, "currentBlock", currentBlock)
+			_ = b.handleFailure(ctx, pending.blobMetadata())
+			if err := b.ConfirmationQueue.Remove(ctx, pending); err != nil {
+				return fmt.Errorf("HandleConfirmations: error removing batch from the confirmation queue: %w", err)
+			}
+			b.EncodingStreamer.SetAwaitingConfirmation(pending.blobKeys(), false)
+			continue
+		}
+
+		if time.Now().Before(pending.RetryAt) {
+			return nil
+		}
+
+		if err = b.submitConfirmation(ctx, pending); err != nil && pending.Confirmed {
+			pending.RetryAt = time.Now().Add(b.ConfirmationRetryInterval)
+			if updateErr := b.ConfirmationQueue.Update(ctx, pending); updateErr != nil {
+				b.logger.Error("HandleConfirmations: error updating the confirmation queue", "err", updateErr)
+			}
+			return fmt.Errorf("HandleConfirmations: batch confirmed but its batch ID couldn't be resolved, retrying in %s: %w", b.ConfirmationRetryInterval, err)
+		} else if err != nil {
+			pending.Attempts++
+			if pending.Attempts < b.MaxConfirmationAttempts {
+				backoff := b.ConfirmationRetryInterval * time.Duration(1<<(pending.Attempts-1))
+				pending.RetryAt = time.Now().Add(backoff)
+				if updateErr := b.ConfirmationQueue.Update(ctx, pending); updateErr != nil {
+					b.logger.Error("HandleConfirmations: error updating the confirmation queue", "err", updateErr)
+				}
+				return fmt.Errorf("HandleConfirmations: attempt %d failed, retrying in %s: %w", pending.Attempts, backoff, err)
+			}
+			b.logger.Error("HandleConfirmations: failed to confirm batch, retrying its blobs in another batch", "batchHeaderHash", hex.EncodeToString(pending.BatchHeaderHash[:]), "attempts", pending.Attempts, "err", err)
+			_ = b.handleFailure(ctx, pending.blobMetadata())
+		} else {
+			// Record the confirmation before marking the blobs, so that the batch isn't confirmed again after a crash
+			if err := b.ConfirmationQueue.Update(ctx, pending); err != nil {
+				b.logger.Error("HandleConfirmations: error updating the confirmation queue", "err", err)
+			}
+			if err := b.markBlobsConfirmed(ctx, pending); err != nil {
+				b.logger.Error("HandleConfirmations: error marking blobs confirmed", "err", err)
+			}
+		}
+
+		if err := b.ConfirmationQueue.Remove(ctx, pending); err != nil {
+			return fmt.Errorf("HandleConfirmations: error removing batch from the confirmation queue: %w", err)
+		}
+		b.EncodingStreamer.SetAwaitingConfirmation(pending.blobKeys(), false)
+	}
+	return nil
+}
+
+// startConfirmer recovers the batches left in the confirmation queue by a previous run, and drains the queue until
+// the context is done
+func (b *Batcher) startConfirmer(ctx context.Context) error {
+	batches, err := b.ConfirmationQueue.List(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to recover the confirmation queue: %w", err)
+	}
+	for _, pending := range batches {
+		b.EncodingStreamer.SetAwaitingConfirmation(pending.blobKeys(), true)
+	}
+	if len(batches) > 0 {
+		b.logger.Info("recovered batches waiting for confirmation", "numBatches", len(batches))
+	}
+
+	go func() {
+		ticker := time.NewTicker(b.PullInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			case <-b.confirmationNotify:
+			}
+			if err := b.HandleConfirmations(ctx); err != nil {
+				b.logger.Error("failed to confirm batches", "err", err)
+			}
+		}
+	}()
+	return nil
+}
+
+func (b *PendingBatch) blobMetadata() []*disperser.BlobMetadata {
+	metadata := make([]*disperser.BlobMetadata, len(b.Blobs))
+	for i, blob := range b.Blobs {
+		metadata[i] = blob.Metadata
+	}
+	return metadata
+}
+
+func (b *PendingBatch) blobKeys() []disperser.BlobKey {
+	keys := make([]disperser.BlobKey, len(b.Blobs))
+	for i, blob := range b.Blobs {
+		keys[i] = blob.Metadata.GetBlobKey()
+	}
+	return keys
+}
+
 func serializeProof(proof *merkletree.Proof) []byte {
 	proofBytes := make([]byte, 0)
 	for _, hash := range proof.Hashes {
@@ -436,14 +964,15 @@ func (b *Batcher) getBatchID(ctx context.Context, txReceipt *types.Receipt) (uin
 }
 
 // Determine failure status for each blob based on stake signed per quorum. We fail a blob if it received
-// insufficient signatures for any quorum
-func getBlobQuorumPassStatus(signedQuorums map[core.QuorumID]*core.QuorumResult, headers []*core.BlobHeader) ([]bool, int) {
+// insufficient signatures for any quorum, i.e. less than its quorum threshold or than the minimum signed percentage
+func getBlobQuorumPassStatus(signedQuorums map[core.QuorumID]*core.QuorumResult, headers []*core.BlobHeader, minSignedPercentage uint8) ([]bool, int) {
 	numPassed := 0
 	passed := make([]bool, len(headers))
 	for ind, blob := range headers {
 		thisPassed := true
 		for _, quorum := range blob.QuorumInfos {
-			if signedQuorums[quorum.QuorumID].PercentSigned < quorum.QuorumThreshold {
+			result, ok := signedQuorums[quorum.QuorumID]
+			if !ok || result.PercentSigned < quorum.QuorumThreshold || result.PercentSigned < minSignedPercentage {
 				thisPassed = false
 				break
 			}
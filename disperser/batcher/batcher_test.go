@@ -6,9 +6,11 @@ import (
 	"fmt"
 	"math/big"
 	"runtime"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/Layr-Labs/eigenda/clients"
 	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/common/logging"
 	cmock "github.com/Layr-Labs/eigenda/common/mock"
@@ -17,14 +19,18 @@ import (
 	coremock "github.com/Layr-Labs/eigenda/core/mock"
 	"github.com/Layr-Labs/eigenda/disperser"
 	bat "github.com/Layr-Labs/eigenda/disperser/batcher"
+	"github.com/Layr-Labs/eigenda/disperser/batcher/eth"
 	batchermock "github.com/Layr-Labs/eigenda/disperser/batcher/mock"
 	"github.com/Layr-Labs/eigenda/disperser/common/inmem"
 	dmock "github.com/Layr-Labs/eigenda/disperser/mock"
 	"github.com/Layr-Labs/eigenda/pkg/encoding/kzgEncoder"
+	"github.com/ethereum/go-ethereum"
 	gethcommon "github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 var (
@@ -37,6 +43,7 @@ type batcherComponents struct {
 	encoderClient    *disperser.LocalEncoderClient
 	encodingStreamer *bat.EncodingStreamer
 	ethClient        *cmock.MockEthClient
+	chainData        *coremock.ChainDataMock
 }
 
 // makeTestEncoder makes an encoder currently using the only supported backend.
@@ -63,6 +70,12 @@ func makeTestBlob(securityParams []*core.SecurityParam) core.Blob {
 }
 
 func makeBatcher(t *testing.T) (*batcherComponents, *bat.Batcher) {
+	return makeBatcherWithNonSigners(t, 0, 0)
+}
+
+// makeBatcherWithNonSigners makes a batcher whose batches are not signed by numNonSigners operators, confirming blobs
+// only when minSignedPercentage of the stake of their quorums signed
+func makeBatcherWithNonSigners(t *testing.T, numNonSigners int, minSignedPercentage uint8) (*batcherComponents, *bat.Batcher) {
 	// Common Components
 	logger, err := logging.GetLogger(logging.DefaultCLIConfig())
 	assert.NoError(t, err)
@@ -79,7 +92,7 @@ func makeBatcher(t *testing.T) (*batcherComponents, *bat.Batcher) {
 	state := cst.GetTotalOperatorState(context.Background(), 0)
 
 	// Disperser Components
-	dispatcher := dmock.NewDispatcher(state)
+	dispatcher := dmock.NewDispatcherWithNonSigners(state, numNonSigners)
 	confirmer := dmock.NewBatchConfirmer()
 	blobStore := inmem.NewBlobStore()
 
@@ -91,6 +104,7 @@ func makeBatcher(t *testing.T) (*batcherComponents, *bat.Batcher) {
 		BatchSizeMBLimit:         100,
 		SRSOrder:                 3000,
 		MaxNumRetriesPerBlob:     2,
+		MinSignedPercentage:      minSignedPercentage,
 	}
 	timeoutConfig := bat.TimeoutConfig{
 		EncodingTimeout:    10 * time.Second,
@@ -99,7 +113,7 @@ func makeBatcher(t *testing.T) (*batcherComponents, *bat.Batcher) {
 		ChainWriteTimeout:  10 * time.Second,
 	}
 
-	metrics := bat.NewMetrics("9100", logger)
+	metrics := bat.NewMetrics("9100", []string{"heavy", "light1", "light2", "priority"}, logger)
 
 	encoderClient := disperser.NewLocalEncoderClient(enc)
 	finalizer := batchermock.NewFinalizer()
@@ -115,6 +129,7 @@ func makeBatcher(t *testing.T) (*batcherComponents, *bat.Batcher) {
 		encoderClient:    encoderClient,
 		encodingStreamer: b.EncodingStreamer,
 		ethClient:        ethClient,
+		chainData:        cst,
 	}, b
 }
 
@@ -151,7 +166,7 @@ func TestBatcherIterations(t *testing.T) {
 		},
 		BlockNumber: big.NewInt(123),
 	}
-	components.confirmer.On("ConfirmBatch", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(receipt, nil)
+	components.confirmer.On("ConfirmBatch", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(receipt, nil, nil)
 	blobStore := components.blobStore
 	ctx := context.Background()
 	requestedAt1, blobKey1 := queueBlob(t, ctx, &blob1, blobStore)
@@ -184,6 +199,31 @@ func TestBatcherIterations(t *testing.T) {
 	assert.Equal(t, blobKey2, meta2.GetBlobKey())
 	assert.Equal(t, disperser.Confirmed, meta2.BlobStatus)
 
+	// The operator state the batch was made with is kept along with the batch
+	batchInfo := meta1.ConfirmationInfo
+	snapshot, err := blobStore.GetOperatorState(ctx, batchInfo.BatchHeaderHash)
+	assert.NoError(t, err)
+	state, err := components.chainData.GetOperatorState(ctx, uint(batchInfo.ReferenceBlockNumber), []core.QuorumID{0, 1})
+	assert.NoError(t, err)
+	assert.Equal(t, uint(batchInfo.ReferenceBlockNumber), snapshot.ReferenceBlockNumber)
+	assert.Equal(t, bat.QuantizationFactor, snapshot.QuantizationFactor)
+	assert.Equal(t, state, snapshot.State)
+
+	// The proof bundles of the confirmed blobs are exported with the signature the batch was confirmed with
+	signature, err := blobStore.GetBatchSignature(ctx, batchInfo.BatchHeaderHash)
+	assert.NoError(t, err)
+	assert.Equal(t, []core.QuorumID{0, 1}, signature.QuorumIDs)
+	for _, meta := range []*disperser.BlobMetadata{meta1, meta2} {
+		bundle, err := disperser.NewBlobProofBundle(meta.ConfirmationInfo, signature)
+		assert.NoError(t, err)
+		data, err := bundle.Encode()
+		assert.NoError(t, err)
+		metadataHash, err := bundle.BlobVerificationProof.BatchMetadata.Hash()
+		assert.NoError(t, err)
+		_, err = clients.VerifyProofBundle(data, metadataHash)
+		assert.NoError(t, err)
+	}
+
 	res, err := components.encodingStreamer.EncodedBlobstore.GetEncodingResult(meta1.GetBlobKey(), 0)
 	assert.ErrorContains(t, err, "no such key")
 	assert.Nil(t, res)
@@ -195,6 +235,104 @@ func TestBatcherIterations(t *testing.T) {
 	assert.Equal(t, uint64(0), size)
 }
 
+func TestBatchCost(t *testing.T) {
+	blob1 := makeTestBlob([]*core.SecurityParam{{
+		QuorumID:           0,
+		AdversaryThreshold: 80,
+		QuorumThreshold:    100,
+	}})
+	blob2 := makeTestBlob([]*core.SecurityParam{{
+		QuorumID:           0,
+		AdversaryThreshold: 50,
+		QuorumThreshold:    100,
+	}, {
+		QuorumID:           1,
+		AdversaryThreshold: 70,
+		QuorumThreshold:    100,
+	}})
+	components, batcher := makeBatcher(t)
+	logData, err := hex.DecodeString("00000000000000000000000000000000000000000000000000000000000000030000000000000000000000000000000000000000000000000000000000000000")
+	assert.NoError(t, err)
+
+	// The cost of the batch isn't a multiple of the total encoded length, so that the shares are rounded
+	receipt := &types.Receipt{
+		Logs: []*types.Log{
+			{
+				Topics: []gethcommon.Hash{common.BatchConfirmedEventSigHash, gethcommon.HexToHash("1234")},
+				Data:   logData,
+			},
+		},
+		BlockNumber:       big.NewInt(123),
+		TxHash:            gethcommon.HexToHash("0xabcd"),
+		GasUsed:           123_457,
+		EffectiveGasPrice: big.NewInt(1_000_000_007),
+	}
+	components.confirmer.On("ConfirmBatch", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(receipt, nil, nil)
+	ctx := context.Background()
+	_, blobKey1 := queueBlob(t, ctx, &blob1, components.blobStore)
+	_, blobKey2 := queueBlob(t, ctx, &blob2, components.blobStore)
+
+	out := make(chan bat.EncodingResultOrStatus)
+	err = components.encodingStreamer.RequestEncoding(ctx, out)
+	assert.NoError(t, err)
+	for i := 0; i < 3; i++ {
+		err = components.encodingStreamer.ProcessEncodedBlobs(ctx, <-out)
+		assert.NoError(t, err)
+	}
+	err = batcher.HandleSingleBatch(ctx)
+	assert.NoError(t, err)
+
+	meta1, err := components.blobStore.GetBlobMetadata(ctx, blobKey1)
+	assert.NoError(t, err)
+	meta2, err := components.blobStore.GetBlobMetadata(ctx, blobKey2)
+	assert.NoError(t, err)
+	cost, err := components.blobStore.GetBatchCost(ctx, meta1.ConfirmationInfo.BatchHeaderHash)
+	assert.NoError(t, err)
+
+	totalCost := big.NewInt(123_457 * 1_000_000_007)
+	assert.Equal(t, receipt.TxHash, cost.ConfirmationTxnHash)
+	assert.Equal(t, uint32(123), cost.ConfirmationBlockNumber)
+	assert.Equal(t, uint64(123_457), cost.GasUsed)
+	assert.Equal(t, big.NewInt(1_000_000_007), cost.EffectiveGasPrice)
+	assert.Equal(t, totalCost, cost.TotalCost)
+
+	// The blobs are charged in proportion to their encoded length over all their quorums, and the shares sum exactly
+	// to the cost of the batch
+	assert.Len(t, cost.BlobCosts, 2)
+	totalEncodedLength := new(big.Int)
+	sum := new(big.Int)
+	for _, blobCost := range cost.BlobCosts {
+		var meta *disperser.BlobMetadata
+		switch blobCost.BlobKey {
+		case blobKey1:
+			meta = meta1
+		case blobKey2:
+			meta = meta2
+		}
+		if !assert.NotNil(t, meta) {
+			continue
+		}
+		assert.Equal(t, meta.ConfirmationInfo.BlobIndex, blobCost.BlobIndex)
+		encodedLength := uint(0)
+		for _, quorumInfo := range meta.ConfirmationInfo.BlobQuorumInfos {
+			encodedLength += quorumInfo.EncodedBlobLength
+		}
+		assert.Equal(t, encodedLength, blobCost.EncodedLength)
+		totalEncodedLength.Add(totalEncodedLength, big.NewInt(int64(encodedLength)))
+		sum.Add(sum, blobCost.Cost)
+	}
+	assert.Equal(t, totalCost, sum)
+	for _, blobCost := range cost.BlobCosts {
+		// Each share is within 1 wei of its exact proportion of the cost
+		exact := new(big.Int).Mul(totalCost, big.NewInt(int64(blobCost.EncodedLength)))
+		diff := new(big.Int).Sub(new(big.Int).Mul(blobCost.Cost, totalEncodedLength), exact)
+		assert.Less(t, diff.CmpAbs(totalEncodedLength), 0)
+	}
+
+	assert.Equal(t, float64(123_457), testutil.ToFloat64(batcher.Metrics.BatchGasUsed.WithLabelValues("0,1")))
+	assert.InDelta(t, 123_457*1.000000007, testutil.ToFloat64(batcher.Metrics.BatchCost.WithLabelValues("0,1")), 1e-6)
+}
+
 func TestBlobFailures(t *testing.T) {
 	blob := makeTestBlob([]*core.SecurityParam{{
 		QuorumID:           0,
@@ -204,7 +342,7 @@ func TestBlobFailures(t *testing.T) {
 
 	components, batcher := makeBatcher(t)
 	confirmationErr := fmt.Errorf("error")
-	components.confirmer.On("ConfirmBatch").Return(nil, confirmationErr)
+	components.confirmer.On("ConfirmBatch").Return(nil, nil, confirmationErr)
 	blobStore := components.blobStore
 	ctx := context.Background()
 	requestedAt, blobKey := queueBlob(t, ctx, &blob, blobStore)
@@ -250,6 +388,469 @@ func TestBlobFailures(t *testing.T) {
 	assert.Equal(t, uint(2), meta.NumRetries)
 }
 
+func TestBlobsWithInsufficientSignatures(t *testing.T) {
+	blob1 := makeTestBlob([]*core.SecurityParam{{
+		QuorumID:           0,
+		AdversaryThreshold: 40,
+		QuorumThreshold:    50,
+	}})
+	blob2 := makeTestBlob([]*core.SecurityParam{{
+		QuorumID:           1,
+		AdversaryThreshold: 80,
+		QuorumThreshold:    100,
+	}})
+	// One of the operators doesn't sign, so less than 100% of the stake signs the batch
+	components, batcher := makeBatcherWithNonSigners(t, 1, 0)
+	logData, err := hex.DecodeString("00000000000000000000000000000000000000000000000000000000000000030000000000000000000000000000000000000000000000000000000000000000")
+	assert.NoError(t, err)
+	receipt := &types.Receipt{
+		Logs: []*types.Log{
+			{
+				Topics: []gethcommon.Hash{common.BatchConfirmedEventSigHash, gethcommon.HexToHash("1234")},
+				Data:   logData,
+			},
+		},
+		BlockNumber: big.NewInt(123),
+	}
+	components.confirmer.On("ConfirmBatch", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(receipt, nil, nil)
+	blobStore := components.blobStore
+	ctx := context.Background()
+	_, blobKey1 := queueBlob(t, ctx, &blob1, blobStore)
+	_, blobKey2 := queueBlob(t, ctx, &blob2, blobStore)
+
+	out := make(chan bat.EncodingResultOrStatus)
+	err = components.encodingStreamer.RequestEncoding(ctx, out)
+	assert.NoError(t, err)
+	err = components.encodingStreamer.ProcessEncodedBlobs(ctx, <-out)
+	assert.NoError(t, err)
+	err = components.encodingStreamer.ProcessEncodedBlobs(ctx, <-out)
+	assert.NoError(t, err)
+
+	err = batcher.HandleSingleBatch(ctx)
+	assert.NoError(t, err)
+	meta1, err := blobStore.GetBlobMetadata(ctx, blobKey1)
+	assert.NoError(t, err)
+	assert.Equal(t, disperser.Confirmed, meta1.BlobStatus)
+
+	// The blob requiring all of the stake to sign is not confirmed, but retried
+	meta2, err := blobStore.GetBlobMetadata(ctx, blobKey2)
+	assert.NoError(t, err)
+	assert.Equal(t, disperser.Processing, meta2.BlobStatus)
+	assert.Equal(t, uint(1), meta2.NumRetries)
+	assert.Nil(t, meta2.ConfirmationInfo)
+	count, _ := components.encodingStreamer.EncodedBlobstore.GetEncodedResultSize()
+	assert.Equal(t, 1, count)
+}
+
+func TestOperatorFailures(t *testing.T) {
+	blob := makeTestBlob([]*core.SecurityParam{{
+		QuorumID:           0,
+		AdversaryThreshold: 40,
+		QuorumThreshold:    50,
+	}})
+	components, batcher := makeBatcher(t)
+	logData, err := hex.DecodeString("00000000000000000000000000000000000000000000000000000000000000030000000000000000000000000000000000000000000000000000000000000000")
+	assert.NoError(t, err)
+	receipt := &types.Receipt{
+		Logs: []*types.Log{
+			{
+				Topics: []gethcommon.Hash{common.BatchConfirmedEventSigHash, gethcommon.HexToHash("1234")},
+				Data:   logData,
+			},
+		},
+		BlockNumber: big.NewInt(123),
+	}
+	components.confirmer.On("ConfirmBatch").Return(receipt, nil, nil)
+	ctx := context.Background()
+	state := components.chainData.GetTotalOperatorState(ctx, 0)
+	// One operator fails, and two others receive the batch past its dispersal deadline
+	batcher.Dispatcher = dmock.NewDispatcherWithExpiredBatches(state, 1, 2)
+
+	_, blobKey := queueBlob(t, ctx, &blob, components.blobStore)
+	out := make(chan bat.EncodingResultOrStatus)
+	err = components.encodingStreamer.RequestEncoding(ctx, out)
+	assert.NoError(t, err)
+	err = components.encodingStreamer.ProcessEncodedBlobs(ctx, <-out)
+	assert.NoError(t, err)
+	err = batcher.HandleSingleBatch(ctx)
+	assert.NoError(t, err)
+	meta, err := components.blobStore.GetBlobMetadata(ctx, blobKey)
+	assert.NoError(t, err)
+	assert.Equal(t, disperser.Confirmed, meta.BlobStatus)
+
+	// The expired batches are soft failures
+	assert.Equal(t, float64(2), testutil.ToFloat64(batcher.Metrics.OperatorFailures.WithLabelValues("soft")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(batcher.Metrics.OperatorFailures.WithLabelValues("hard")))
+	assert.Equal(t, float64(3), testutil.ToFloat64(batcher.Metrics.Attestation.WithLabelValues("non_signers")))
+}
+
+func TestBlobsOutOfRetriesWithInsufficientSignatures(t *testing.T) {
+	blob := makeTestBlob([]*core.SecurityParam{{
+		QuorumID:           1,
+		AdversaryThreshold: 80,
+		QuorumThreshold:    100,
+	}})
+	// One of the operators doesn't sign, so less than 100% of the stake signs each batch
+	components, batcher := makeBatcherWithNonSigners(t, 1, 0)
+	components.confirmer.On("ConfirmBatch").Return(nil, nil, fmt.Errorf("should not confirm"))
+	blobStore := components.blobStore
+	ctx := context.Background()
+	_, blobKey := queueBlob(t, ctx, &blob, blobStore)
+
+	out := make(chan bat.EncodingResultOrStatus)
+	err := components.encodingStreamer.RequestEncoding(ctx, out)
+	assert.NoError(t, err)
+	err = components.encodingStreamer.ProcessEncodedBlobs(ctx, <-out)
+	assert.NoError(t, err)
+
+	// The blob is retried until it runs out of retries
+	for retries := uint(1); retries <= 2; retries++ {
+		components.encodingStreamer.ReferenceBlockNumber = 10
+		err = batcher.HandleSingleBatch(ctx)
+		assert.ErrorContains(t, err, "no blobs received sufficient signatures")
+		meta, err := blobStore.GetBlobMetadata(ctx, blobKey)
+		assert.NoError(t, err)
+		assert.Equal(t, disperser.Processing, meta.BlobStatus)
+		assert.Equal(t, retries, meta.NumRetries)
+	}
+
+	// It then fails with the percentage of the stake of its quorum which signed the last batch
+	components.encodingStreamer.ReferenceBlockNumber = 10
+	err = batcher.HandleSingleBatch(ctx)
+	assert.ErrorContains(t, err, "no blobs received sufficient signatures")
+	meta, err := blobStore.GetBlobMetadata(ctx, blobKey)
+	assert.NoError(t, err)
+	assert.Equal(t, disperser.InsufficientSignatures, meta.BlobStatus)
+	require.NotNil(t, meta.ConfirmationInfo)
+	quorumStatuses := meta.ConfirmationInfo.QuorumStatuses()
+	require.Len(t, quorumStatuses, 1)
+	assert.Equal(t, core.QuorumID(1), quorumStatuses[0].QuorumID)
+	assert.Less(t, quorumStatuses[0].PercentSigned, uint8(100))
+	assert.False(t, quorumStatuses[0].Confirmed)
+	count, _ := components.encodingStreamer.EncodedBlobstore.GetEncodedResultSize()
+	assert.Equal(t, 0, count)
+}
+
+func TestResubmitBlobWithRelaxedThresholds(t *testing.T) {
+	blob := makeTestBlob([]*core.SecurityParam{{
+		QuorumID:           0,
+		AdversaryThreshold: 80,
+		QuorumThreshold:    100,
+	}})
+	// One of the operators doesn't sign, so less than 100% of the stake signs each batch
+	components, batcher := makeBatcherWithNonSigners(t, 1, 0)
+	logData, err := hex.DecodeString("00000000000000000000000000000000000000000000000000000000000000030000000000000000000000000000000000000000000000000000000000000000")
+	assert.NoError(t, err)
+	receipt := &types.Receipt{
+		Logs: []*types.Log{
+			{
+				Topics: []gethcommon.Hash{common.BatchConfirmedEventSigHash, gethcommon.HexToHash("1234")},
+				Data:   logData,
+			},
+		},
+		BlockNumber: big.NewInt(123),
+	}
+	components.confirmer.On("ConfirmBatch").Return(receipt, nil, nil)
+	blobStore := components.blobStore
+	ctx := context.Background()
+	_, blobKey := queueBlob(t, ctx, &blob, blobStore)
+
+	out := make(chan bat.EncodingResultOrStatus)
+	err = components.encodingStreamer.RequestEncoding(ctx, out)
+	assert.NoError(t, err)
+	err = components.encodingStreamer.ProcessEncodedBlobs(ctx, <-out)
+	assert.NoError(t, err)
+	for i := 0; i < 3; i++ {
+		components.encodingStreamer.ReferenceBlockNumber = 10
+		err = batcher.HandleSingleBatch(ctx)
+		assert.ErrorContains(t, err, "no blobs received sufficient signatures")
+	}
+	meta, err := blobStore.GetBlobMetadata(ctx, blobKey)
+	assert.NoError(t, err)
+	require.Equal(t, disperser.InsufficientSignatures, meta.BlobStatus)
+
+	// The blob is resubmitted with thresholds which the signers of the batches reach, without uploading it again
+	resubmittedKey, err := blobStore.ResubmitBlob(ctx, meta, core.BlobRequestHeader{
+		SecurityParams: []*core.SecurityParam{{
+			QuorumID:           0,
+			AdversaryThreshold: 40,
+			QuorumThreshold:    50,
+		}},
+	}, uint64(time.Now().UnixNano()))
+	assert.NoError(t, err)
+	assert.Equal(t, blobKey.BlobHash, resubmittedKey.BlobHash)
+
+	err = components.encodingStreamer.RequestEncoding(ctx, out)
+	assert.NoError(t, err)
+	err = components.encodingStreamer.ProcessEncodedBlobs(ctx, <-out)
+	assert.NoError(t, err)
+	components.encodingStreamer.ReferenceBlockNumber = 10
+	err = batcher.HandleSingleBatch(ctx)
+	assert.NoError(t, err)
+
+	resubmitted, err := blobStore.GetBlobMetadata(ctx, resubmittedKey)
+	assert.NoError(t, err)
+	assert.Equal(t, disperser.Confirmed, resubmitted.BlobStatus)
+	meta, err = blobStore.GetBlobMetadata(ctx, blobKey)
+	assert.NoError(t, err)
+	assert.Equal(t, disperser.InsufficientSignatures, meta.BlobStatus)
+}
+
+func TestFeatureGatingWithMixedVersions(t *testing.T) {
+	blob := makeTestBlob([]*core.SecurityParam{{
+		QuorumID:           0,
+		AdversaryThreshold: 80,
+		QuorumThreshold:    100,
+	}})
+	components, batcher := makeBatcher(t)
+	batcher.FeatureStakeThreshold = 75
+	logData, err := hex.DecodeString("00000000000000000000000000000000000000000000000000000000000000030000000000000000000000000000000000000000000000000000000000000000")
+	assert.NoError(t, err)
+	receipt := &types.Receipt{
+		Logs: []*types.Log{
+			{
+				Topics: []gethcommon.Hash{common.BatchConfirmedEventSigHash, gethcommon.HexToHash("1234")},
+				Data:   logData,
+			},
+		},
+		BlockNumber: big.NewInt(123),
+	}
+	components.confirmer.On("ConfirmBatch").Return(receipt, nil, nil)
+	blobStore := components.blobStore
+	ctx := context.Background()
+	state := components.chainData.GetTotalOperatorState(ctx, 0)
+
+	// The operator with index i has a stake of i+1, out of a total of 55. The operators with an index of at least
+	// minUpgradedIndex run the new version supporting the bundle encoding v1, and the others an old version which
+	// doesn't advertise its version.
+	versionsFrom := func(minUpgradedIndex core.OperatorIndex) map[core.OperatorID]core.NodeVersion {
+		versions := make(map[core.OperatorID]core.NodeVersion)
+		for id, op := range state.PrivateOperators {
+			if op.Index >= minUpgradedIndex {
+				versions[id] = core.NodeVersion{Version: "0.6.0", Features: core.FeatureBundleEncodingV1}
+			}
+		}
+		return versions
+	}
+	disperseBatch := func() [32]byte {
+		_, blobKey := queueBlob(t, ctx, &blob, blobStore)
+		out := make(chan bat.EncodingResultOrStatus)
+		err := components.encodingStreamer.RequestEncoding(ctx, out)
+		assert.NoError(t, err)
+		err = components.encodingStreamer.ProcessEncodedBlobs(ctx, <-out)
+		assert.NoError(t, err)
+		components.encodingStreamer.ReferenceBlockNumber = 10
+		err = batcher.HandleSingleBatch(ctx)
+		assert.NoError(t, err)
+		meta, err := blobStore.GetBlobMetadata(ctx, blobKey)
+		require.NoError(t, err)
+		require.Equal(t, disperser.Confirmed, meta.BlobStatus)
+		return meta.ConfirmationInfo.BatchHeaderHash
+	}
+
+	// The upgraded operators hold 40/55 = 72% of the stake, below the threshold
+	batcher.Dispatcher = dmock.NewDispatcherWithVersions(state, versionsFrom(5))
+	batchHeaderHash := disperseBatch()
+	assert.False(t, batcher.FeatureEnabled(core.FeatureBundleEncodingV1))
+	assert.Equal(t, float64(5), testutil.ToFloat64(batcher.Metrics.NodeVersions.WithLabelValues("0.6.0")))
+	assert.Equal(t, float64(5), testutil.ToFloat64(batcher.Metrics.NodeVersions.WithLabelValues("unknown")))
+	assert.Equal(t, float64(72), testutil.ToFloat64(batcher.Metrics.FeatureStake.WithLabelValues("bundle_encoding_v1", "0")))
+	snapshot, err := blobStore.GetOperatorState(ctx, batchHeaderHash)
+	require.NoError(t, err)
+	assert.Len(t, snapshot.OperatorVersions, 10)
+	assert.Equal(t, versionsFrom(5), filterAdvertised(snapshot.OperatorVersions))
+
+	// Once the operator with index 4 upgrades, the upgraded operators hold 45/55 = 81% of the stake
+	batcher.Dispatcher = dmock.NewDispatcherWithVersions(state, versionsFrom(4))
+	disperseBatch()
+	assert.True(t, batcher.FeatureEnabled(core.FeatureBundleEncodingV1))
+	assert.Equal(t, float64(81), testutil.ToFloat64(batcher.Metrics.FeatureStake.WithLabelValues("bundle_encoding_v1", "0")))
+
+	// The feature is disabled again if the upgraded operators stop advertising it, e.g. after a rollback
+	batcher.Dispatcher = dmock.NewDispatcherWithVersions(state, versionsFrom(10))
+	disperseBatch()
+	assert.False(t, batcher.FeatureEnabled(core.FeatureBundleEncodingV1))
+}
+
+// filterAdvertised returns the versions of the operators which advertised one
+func filterAdvertised(versions map[core.OperatorID]core.NodeVersion) map[core.OperatorID]core.NodeVersion {
+	advertised := make(map[core.OperatorID]core.NodeVersion)
+	for id, version := range versions {
+		if version.Version != "" {
+			advertised[id] = version
+		}
+	}
+	return advertised
+}
+
+func TestDrain(t *testing.T) {
+	blob := makeTestBlob([]*core.SecurityParam{{
+		QuorumID:           0,
+		AdversaryThreshold: 80,
+		QuorumThreshold:    100,
+	}})
+	components, batcher := makeBatcher(t)
+	logData, err := hex.DecodeString("00000000000000000000000000000000000000000000000000000000000000030000000000000000000000000000000000000000000000000000000000000000")
+	assert.NoError(t, err)
+	receipt := &types.Receipt{
+		Logs: []*types.Log{
+			{
+				Topics: []gethcommon.Hash{common.BatchConfirmedEventSigHash, gethcommon.HexToHash("1234")},
+				Data:   logData,
+			},
+		},
+		BlockNumber: big.NewInt(123),
+	}
+	components.confirmer.On("ConfirmBatch").Return(receipt, nil, nil)
+	blobStore := components.blobStore
+	ctx := context.Background()
+	_, queuedKey := queueBlob(t, ctx, &blob, blobStore)
+
+	drained, err := batcher.CheckDrained(ctx)
+	assert.NoError(t, err)
+	assert.False(t, drained)
+
+	batcher.Drain()
+	// The blob queued after the drain started is left processing
+	_, lateKey := queueBlob(t, ctx, &blob, blobStore)
+	drained, err = batcher.CheckDrained(ctx)
+	assert.NoError(t, err)
+	assert.False(t, drained)
+
+	out := make(chan bat.EncodingResultOrStatus)
+	err = components.encodingStreamer.RequestEncoding(ctx, out)
+	assert.NoError(t, err)
+	err = components.encodingStreamer.ProcessEncodedBlobs(ctx, <-out)
+	assert.NoError(t, err)
+	components.encodingStreamer.ReferenceBlockNumber = 10
+	err = batcher.HandleSingleBatch(ctx)
+	assert.NoError(t, err)
+
+	meta, err := blobStore.GetBlobMetadata(ctx, queuedKey)
+	assert.NoError(t, err)
+	assert.Equal(t, disperser.Confirmed, meta.BlobStatus)
+	meta, err = blobStore.GetBlobMetadata(ctx, lateKey)
+	assert.NoError(t, err)
+	assert.Equal(t, disperser.Processing, meta.BlobStatus)
+	drained, err = batcher.CheckDrained(ctx)
+	assert.NoError(t, err)
+	assert.True(t, drained)
+
+	// The late blob isn't encoded
+	err = components.encodingStreamer.RequestEncoding(ctx, out)
+	assert.NoError(t, err)
+	err = batcher.HandleSingleBatch(ctx)
+	assert.ErrorContains(t, err, "no encoded results")
+}
+
+func TestBlobsBelowMinSignedPercentage(t *testing.T) {
+	blob := makeTestBlob([]*core.SecurityParam{{
+		QuorumID:           0,
+		AdversaryThreshold: 40,
+		QuorumThreshold:    50,
+	}})
+	components, batcher := makeBatcherWithNonSigners(t, 1, 100)
+	components.confirmer.On("ConfirmBatch").Return(nil, nil, fmt.Errorf("should not confirm"))
+	blobStore := components.blobStore
+	ctx := context.Background()
+	_, blobKey := queueBlob(t, ctx, &blob, blobStore)
+
+	out := make(chan bat.EncodingResultOrStatus)
+	err := components.encodingStreamer.RequestEncoding(ctx, out)
+	assert.NoError(t, err)
+	err = components.encodingStreamer.ProcessEncodedBlobs(ctx, <-out)
+	assert.NoError(t, err)
+
+	// The blob meets its quorum threshold but not the minimum signed percentage, so it's retried until it fails with
+	// insufficient signatures
+	for i := 1; i <= 3; i++ {
+		components.encodingStreamer.ReferenceBlockNumber = 10
+		err = batcher.HandleSingleBatch(ctx)
+		assert.ErrorContains(t, err, "no blobs received sufficient signatures")
+		meta, err := blobStore.GetBlobMetadata(ctx, blobKey)
+		assert.NoError(t, err)
+		if i <= 2 {
+			assert.Equal(t, disperser.Processing, meta.BlobStatus)
+			assert.Equal(t, uint(i), meta.NumRetries)
+		} else {
+			assert.Equal(t, disperser.InsufficientSignatures, meta.BlobStatus)
+		}
+	}
+	components.confirmer.AssertNotCalled(t, "ConfirmBatch")
+
+	_, err = bat.NewBatcher(bat.Config{MinSignedPercentage: 101}, bat.TimeoutConfig{}, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	assert.Error(t, err)
+	_, err = bat.NewBatcher(bat.Config{MinChunkLength: core.MaxMinChunkLength + 1}, bat.TimeoutConfig{}, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	assert.Error(t, err)
+}
+
+func TestConfigValidate(t *testing.T) {
+	valid := bat.Config{
+		PullInterval:             time.Second,
+		FinalizerInterval:        time.Minute,
+		SRSOrder:                 3000,
+		NumConnections:           1,
+		EncodingRequestQueueSize: 100,
+		BatchSizeMBLimit:         100,
+	}
+	assert.NoError(t, valid.Validate())
+
+	tests := []struct {
+		name   string
+		modify func(*bat.Config)
+		errors []string
+	}{
+		{
+			name:   "zero pull interval",
+			modify: func(c *bat.Config) { c.PullInterval = 0 },
+			errors: []string{"the pull interval must be positive, but found 0s"},
+		},
+		{
+			name:   "zero batch size limit",
+			modify: func(c *bat.Config) { c.BatchSizeMBLimit = 0 },
+			errors: []string{"the batch size limit must be positive"},
+		},
+		{
+			name:   "overprovision percent above the max",
+			modify: func(c *bat.Config) { c.EncodingOverprovisionPercent = core.MaxOverprovisionPercent + 1 },
+			errors: []string{"the encoding overprovision percent must be at most 100, but found 101"},
+		},
+		{
+			name:   "stuck blob SLA without watchdog interval",
+			modify: func(c *bat.Config) { c.StuckBlobSLA = time.Hour },
+			errors: []string{"the watchdog interval must be positive with a stuck blob SLA, but found 0s"},
+		},
+		{
+			name:   "zero account weight",
+			modify: func(c *bat.Config) { c.AccountWeights = map[core.AccountID]uint64{"account": 0} },
+			errors: []string{"the weight of account \"account\" must be positive"},
+		},
+		{
+			name: "every violation is reported",
+			modify: func(c *bat.Config) {
+				c.SRSOrder = 0
+				c.MinSignedPercentage = 101
+				c.FeatureStakeThreshold = 101
+			},
+			errors: []string{
+				"the SRS order must be positive, but found 0",
+				"the minimum signed percentage must be at most 100, but found 101",
+				"the feature stake threshold must be at most 100, but found 101",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := valid
+			tt.modify(&config)
+			err := config.Validate()
+			if assert.Error(t, err) {
+				assert.Equal(t, strings.Join(tt.errors, "\n"), err.Error())
+			}
+		})
+	}
+}
+
 func TestRetryTxnReceipt(t *testing.T) {
 	var err error
 	blob := makeTestBlob([]*core.SecurityParam{{
@@ -281,7 +882,7 @@ func TestRetryTxnReceipt(t *testing.T) {
 		BlockNumber: big.NewInt(123),
 	}
 
-	components.confirmer.On("ConfirmBatch").Return(invalidReceipt, nil)
+	components.confirmer.On("ConfirmBatch").Return(invalidReceipt, nil, nil)
 	components.ethClient.On("TransactionReceipt").Return(invalidReceipt, nil).Twice()
 	components.ethClient.On("TransactionReceipt").Return(validReceipt, nil).Once()
 	blobStore := components.blobStore
@@ -306,3 +907,395 @@ func TestRetryTxnReceipt(t *testing.T) {
 	assert.Equal(t, meta.ConfirmationInfo.BatchID, uint32(3))
 	components.ethClient.AssertNumberOfCalls(t, "TransactionReceipt", 3)
 }
+
+// makeBatcherWithConfirmationQueue makes a batcher confirming its batches through the confirmation queue, with a
+// confirmer sending the transactions through tx
+func makeBatcherWithConfirmationQueue(t *testing.T, queue bat.ConfirmationQueue, tx *coremock.MockTransactor) (*batcherComponents, *bat.Batcher) {
+	components, batcher := makeBatcher(t)
+	confirmer, err := eth.NewBatchConfirmer(tx, 10*time.Second)
+	assert.NoError(t, err)
+	batcher.Confirmer = confirmer
+	batcher.ConfirmationQueue = queue
+	batcher.ConfirmationRetryInterval = time.Millisecond
+	batcher.MaxConfirmationAttempts = 3
+	return components, batcher
+}
+
+// makeConfirmationReceipt makes the receipt of a transaction confirming batch 3 at the block number
+func makeConfirmationReceipt(t *testing.T, blockNumber int64) *types.Receipt {
+	logData, err := hex.DecodeString("00000000000000000000000000000000000000000000000000000000000000030000000000000000000000000000000000000000000000000000000000000000")
+	assert.NoError(t, err)
+	return &types.Receipt{
+		Logs: []*types.Log{
+			{
+				Topics: []gethcommon.Hash{common.BatchConfirmedEventSigHash, gethcommon.HexToHash("1234")},
+				Data:   logData,
+			},
+		},
+		BlockNumber: big.NewInt(blockNumber),
+	}
+}
+
+func encodeQueuedBlobs(t *testing.T, ctx context.Context, encodingStreamer *bat.EncodingStreamer, numBlobs int) {
+	out := make(chan bat.EncodingResultOrStatus)
+	err := encodingStreamer.RequestEncoding(ctx, out)
+	assert.NoError(t, err)
+	for i := 0; i < numBlobs; i++ {
+		err = encodingStreamer.ProcessEncodedBlobs(ctx, <-out)
+		assert.NoError(t, err)
+	}
+}
+
+// expireRetries makes the batches of the queue ready to be confirmed again, as if their backoff elapsed
+func expireRetries(t *testing.T, ctx context.Context, queue bat.ConfirmationQueue) {
+	batches, err := queue.List(ctx)
+	assert.NoError(t, err)
+	for _, batch := range batches {
+		batch.RetryAt = time.Time{}
+		assert.NoError(t, queue.Update(ctx, batch))
+	}
+}
+
+// errRPCOutage is the error of the transactor while the RPC provider is down
+var errRPCOutage = fmt.Errorf("EnsureTransactionEvaled: failed to wait for transaction (ConfirmBatch) to mine: %w", context.DeadlineExceeded)
+
+func TestConfirmationQueue(t *testing.T) {
+	blob1 := makeTestBlob([]*core.SecurityParam{{
+		QuorumID:           0,
+		AdversaryThreshold: 80,
+		QuorumThreshold:    100,
+	}})
+	blob2 := makeTestBlob([]*core.SecurityParam{{
+		QuorumID:           1,
+		AdversaryThreshold: 70,
+		QuorumThreshold:    100,
+	}})
+	tx := &coremock.MockTransactor{}
+	tx.On("ConfirmBatch").Return(nil, nil, errRPCOutage).Twice()
+	tx.On("ConfirmBatch").Return(makeConfirmationReceipt(t, 123), nil, nil).Once()
+	tx.On("ConfirmBatch").Return(makeConfirmationReceipt(t, 124), nil, nil).Once()
+	queue := bat.NewInMemoryConfirmationQueue()
+	components, batcher := makeBatcherWithConfirmationQueue(t, queue, tx)
+	batcher.ConfirmationRetryInterval = time.Hour
+	blobStore := components.blobStore
+	ctx := context.Background()
+
+	// The first batch is aggregated, but fails to be confirmed while the RPC provider is down
+	_, blobKey1 := queueBlob(t, ctx, &blob1, blobStore)
+	encodeQueuedBlobs(t, ctx, components.encodingStreamer, 1)
+	err := batcher.HandleSingleBatch(ctx)
+	assert.NoError(t, err)
+	err = batcher.HandleConfirmations(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// The next batch is aggregated meanwhile, without encoding the blob waiting for confirmation again
+	_, blobKey2 := queueBlob(t, ctx, &blob2, blobStore)
+	encodeQueuedBlobs(t, ctx, components.encodingStreamer, 1)
+	assert.False(t, components.encodingStreamer.EncodedBlobstore.HasEncodingRequested(blobKey1, 0, 10))
+	err = batcher.HandleSingleBatch(ctx)
+	assert.NoError(t, err)
+	batches, err := queue.List(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, batches, 2)
+	for _, blobKey := range []disperser.BlobKey{blobKey1, blobKey2} {
+		meta, err := blobStore.GetBlobMetadata(ctx, blobKey)
+		assert.NoError(t, err)
+		assert.Equal(t, disperser.Processing, meta.BlobStatus)
+		assert.Equal(t, uint(0), meta.NumRetries)
+	}
+
+	// The first batch blocks the second one until it is confirmed, after its backoff
+	err = batcher.HandleConfirmations(ctx)
+	assert.NoError(t, err)
+	tx.AssertNumberOfCalls(t, "ConfirmBatch", 1)
+	assert.True(t, batches[0].RetryAt.After(time.Now().Add(59*time.Minute)))
+	expireRetries(t, ctx, queue)
+	err = batcher.HandleConfirmations(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	tx.AssertNumberOfCalls(t, "ConfirmBatch", 2)
+	batches, err = queue.List(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, uint(2), batches[0].Attempts)
+	assert.True(t, batches[0].RetryAt.After(time.Now().Add(119*time.Minute)))
+	expireRetries(t, ctx, queue)
+	err = batcher.HandleConfirmations(ctx)
+	assert.NoError(t, err)
+	tx.AssertNumberOfCalls(t, "ConfirmBatch", 4)
+
+	// The batches were confirmed in the order they were aggregated
+	meta1, err := blobStore.GetBlobMetadata(ctx, blobKey1)
+	assert.NoError(t, err)
+	assert.Equal(t, disperser.Confirmed, meta1.BlobStatus)
+	assert.Equal(t, uint32(123), meta1.ConfirmationInfo.ConfirmationBlockNumber)
+	meta2, err := blobStore.GetBlobMetadata(ctx, blobKey2)
+	assert.NoError(t, err)
+	assert.Equal(t, disperser.Confirmed, meta2.BlobStatus)
+	assert.Equal(t, uint32(124), meta2.ConfirmationInfo.ConfirmationBlockNumber)
+	batches, err = queue.List(ctx)
+	assert.NoError(t, err)
+	assert.Empty(t, batches)
+}
+
+func TestConfirmationQueueMaxAttempts(t *testing.T) {
+	blob := makeTestBlob([]*core.SecurityParam{{
+		QuorumID:           0,
+		AdversaryThreshold: 80,
+		QuorumThreshold:    100,
+	}})
+	tx := &coremock.MockTransactor{}
+	tx.On("ConfirmBatch").Return(nil, nil, errRPCOutage)
+	queue := bat.NewInMemoryConfirmationQueue()
+	components, batcher := makeBatcherWithConfirmationQueue(t, queue, tx)
+	blobStore := components.blobStore
+	ctx := context.Background()
+
+	_, blobKey := queueBlob(t, ctx, &blob, blobStore)
+	encodeQueuedBlobs(t, ctx, components.encodingStreamer, 1)
+	err := batcher.HandleSingleBatch(ctx)
+	assert.NoError(t, err)
+
+	// The batch is removed from the queue after its last attempt, and its blob is retried in another batch
+	for i := 0; i < 3; i++ {
+		expireRetries(t, ctx, queue)
+		err = batcher.HandleConfirmations(ctx)
+		if i < 2 {
+			assert.ErrorIs(t, err, context.DeadlineExceeded)
+		} else {
+			assert.NoError(t, err)
+		}
+	}
+	tx.AssertNumberOfCalls(t, "ConfirmBatch", 3)
+	batches, err := queue.List(ctx)
+	assert.NoError(t, err)
+	assert.Empty(t, batches)
+	meta, err := blobStore.GetBlobMetadata(ctx, blobKey)
+	assert.NoError(t, err)
+	assert.Equal(t, disperser.Processing, meta.BlobStatus)
+	assert.Equal(t, uint(1), meta.NumRetries)
+
+	encodeQueuedBlobs(t, ctx, components.encodingStreamer, 1)
+	count, _ := components.encodingStreamer.EncodedBlobstore.GetEncodedResultSize()
+	assert.Equal(t, 1, count)
+}
+
+func TestConfirmationQueueRecovery(t *testing.T) {
+	blob1 := makeTestBlob([]*core.SecurityParam{{
+		QuorumID:           0,
+		AdversaryThreshold: 80,
+		QuorumThreshold:    100,
+	}})
+	blob2 := makeTestBlob([]*core.SecurityParam{{
+		QuorumID:           1,
+		AdversaryThreshold: 70,
+		QuorumThreshold:    100,
+	}})
+	queue := bat.NewInMemoryConfirmationQueue()
+	crashedTx := &coremock.MockTransactor{}
+	components, crashed := makeBatcherWithConfirmationQueue(t, queue, crashedTx)
+	blobStore := components.blobStore
+	ctx := context.Background()
+
+	// The batcher crashes with two batches in the queue, the confirmation of the first one having landed
+	_, blobKey1 := queueBlob(t, ctx, &blob1, blobStore)
+	encodeQueuedBlobs(t, ctx, components.encodingStreamer, 1)
+	err := crashed.HandleSingleBatch(ctx)
+	assert.NoError(t, err)
+	_, blobKey2 := queueBlob(t, ctx, &blob2, blobStore)
+	encodeQueuedBlobs(t, ctx, components.encodingStreamer, 1)
+	err = crashed.HandleSingleBatch(ctx)
+	assert.NoError(t, err)
+	batches, err := queue.List(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, batches, 2)
+	batches[0].Confirmed = true
+	batches[0].BatchIDResolved = true
+	batches[0].BatchID = 7
+	batches[0].ConfirmationTxnHash = gethcommon.HexToHash("0x123")
+	batches[0].ConfirmationBlockNumber = 122
+	assert.NoError(t, queue.Update(ctx, batches[0]))
+
+	// The recovering batcher only marks the blob of the first batch confirmed, and confirms the second batch
+	tx := &coremock.MockTransactor{}
+	tx.On("ConfirmBatch").Return(makeConfirmationReceipt(t, 123), nil, nil).Once()
+	_, recovering := makeBatcherWithConfirmationQueue(t, queue, tx)
+	recovering.Queue = blobStore
+	err = recovering.HandleConfirmations(ctx)
+	assert.NoError(t, err)
+	tx.AssertNumberOfCalls(t, "ConfirmBatch", 1)
+	crashedTx.AssertNotCalled(t, "ConfirmBatch")
+
+	meta1, err := blobStore.GetBlobMetadata(ctx, blobKey1)
+	assert.NoError(t, err)
+	assert.Equal(t, disperser.Confirmed, meta1.BlobStatus)
+	assert.Equal(t, uint32(7), meta1.ConfirmationInfo.BatchID)
+	assert.Equal(t, uint32(122), meta1.ConfirmationInfo.ConfirmationBlockNumber)
+	meta2, err := blobStore.GetBlobMetadata(ctx, blobKey2)
+	assert.NoError(t, err)
+	assert.Equal(t, disperser.Confirmed, meta2.BlobStatus)
+	assert.Equal(t, uint32(3), meta2.ConfirmationInfo.BatchID)
+	assert.Equal(t, uint32(123), meta2.ConfirmationInfo.ConfirmationBlockNumber)
+	batches, err = queue.List(ctx)
+	assert.NoError(t, err)
+	assert.Empty(t, batches)
+}
+
+func TestConfirmationQueueBatchIDRetry(t *testing.T) {
+	blob := makeTestBlob([]*core.SecurityParam{{
+		QuorumID:           0,
+		AdversaryThreshold: 80,
+		QuorumThreshold:    100,
+	}})
+	tx := &coremock.MockTransactor{}
+	queue := bat.NewInMemoryConfirmationQueue()
+	components, batcher := makeBatcherWithConfirmationQueue(t, queue, tx)
+	blobStore := components.blobStore
+	ctx := context.Background()
+
+	// The confirmation of the batch landed, but its receipt can't be fetched to resolve the batch ID
+	_, blobKey := queueBlob(t, ctx, &blob, blobStore)
+	encodeQueuedBlobs(t, ctx, components.encodingStreamer, 1)
+	err := batcher.HandleSingleBatch(ctx)
+	assert.NoError(t, err)
+	batches, err := queue.List(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, batches, 1)
+	batches[0].Confirmed = true
+	batches[0].ConfirmationTxnHash = gethcommon.HexToHash("0x123")
+	batches[0].ConfirmationBlockNumber = 123
+	assert.NoError(t, queue.Update(ctx, batches[0]))
+	components.ethClient.On("TransactionReceipt").Return(nil, errRPCOutage).Once()
+	err = batcher.HandleConfirmations(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// Only resolving the batch ID is retried, without counting as a failed attempt nor confirming the batch again
+	batches, err = queue.List(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, batches, 1)
+	assert.Equal(t, uint(0), batches[0].Attempts)
+	assert.False(t, batches[0].BatchIDResolved)
+	meta, err := blobStore.GetBlobMetadata(ctx, blobKey)
+	assert.NoError(t, err)
+	assert.Equal(t, disperser.Processing, meta.BlobStatus)
+	assert.Equal(t, uint(0), meta.NumRetries)
+
+	components.ethClient.On("TransactionReceipt").Return(makeConfirmationReceipt(t, 123), nil).Once()
+	expireRetries(t, ctx, queue)
+	err = batcher.HandleConfirmations(ctx)
+	assert.NoError(t, err)
+	tx.AssertNotCalled(t, "ConfirmBatch")
+	meta, err = blobStore.GetBlobMetadata(ctx, blobKey)
+	assert.NoError(t, err)
+	assert.Equal(t, disperser.Confirmed, meta.BlobStatus)
+	assert.Equal(t, uint32(3), meta.ConfirmationInfo.BatchID)
+	assert.Equal(t, uint32(123), meta.ConfirmationInfo.ConfirmationBlockNumber)
+	batches, err = queue.List(ctx)
+	assert.NoError(t, err)
+	assert.Empty(t, batches)
+}
+
+func TestConfirmationQueueReplacesTxn(t *testing.T) {
+	blob := makeTestBlob([]*core.SecurityParam{{
+		QuorumID:           0,
+		AdversaryThreshold: 80,
+		QuorumThreshold:    100,
+	}})
+	sent1 := types.NewTx(&types.DynamicFeeTx{Nonce: 7, GasTipCap: big.NewInt(100), GasFeeCap: big.NewInt(200)})
+	sent2 := types.NewTx(&types.DynamicFeeTx{Nonce: 7, GasTipCap: big.NewInt(115), GasFeeCap: big.NewInt(230)})
+	tx := &coremock.MockTransactor{}
+	tx.On("ConfirmBatch").Return(nil, sent1, errRPCOutage).Once()
+	tx.On("ConfirmBatch").Return(nil, sent2, errRPCOutage).Once()
+	queue := bat.NewInMemoryConfirmationQueue()
+	components, batcher := makeBatcherWithConfirmationQueue(t, queue, tx)
+	blobStore := components.blobStore
+	ctx := context.Background()
+
+	// The confirmation transaction doesn't land in time, and is replaced by the next attempt
+	_, blobKey := queueBlob(t, ctx, &blob, blobStore)
+	encodeQueuedBlobs(t, ctx, components.encodingStreamer, 1)
+	err := batcher.HandleSingleBatch(ctx)
+	assert.NoError(t, err)
+	err = batcher.HandleConfirmations(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	components.ethClient.On("TransactionReceipt").Return(nil, ethereum.NotFound).Once()
+	expireRetries(t, ctx, queue)
+	err = batcher.HandleConfirmations(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	tx.AssertNumberOfCalls(t, "ConfirmBatch", 2)
+	batches, err := queue.List(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, batches, 1)
+	assert.Equal(t, []gethcommon.Hash{sent1.Hash(), sent2.Hash()}, []gethcommon.Hash{batches[0].ConfirmationTxns[0].Hash(), batches[0].ConfirmationTxns[1].Hash()})
+
+	// The replacement lands meanwhile, so the next attempt confirms the blob without sending another transaction
+	receipt := makeConfirmationReceipt(t, 123)
+	receipt.Status = types.ReceiptStatusSuccessful
+	receipt.TxHash = sent2.Hash()
+	components.ethClient.On("TransactionReceipt").Return(nil, ethereum.NotFound).Once()
+	components.ethClient.On("TransactionReceipt").Return(receipt, nil).Once()
+	expireRetries(t, ctx, queue)
+	err = batcher.HandleConfirmations(ctx)
+	assert.NoError(t, err)
+	tx.AssertNumberOfCalls(t, "ConfirmBatch", 2)
+	meta, err := blobStore.GetBlobMetadata(ctx, blobKey)
+	assert.NoError(t, err)
+	assert.Equal(t, disperser.Confirmed, meta.BlobStatus)
+	assert.Equal(t, sent2.Hash(), meta.ConfirmationInfo.ConfirmationTxnHash)
+	assert.Equal(t, uint32(3), meta.ConfirmationInfo.BatchID)
+}
+
+func TestConfirmationQueueStaleBatch(t *testing.T) {
+	blob1 := makeTestBlob([]*core.SecurityParam{{
+		QuorumID:           0,
+		AdversaryThreshold: 80,
+		QuorumThreshold:    100,
+	}})
+	blob2 := makeTestBlob([]*core.SecurityParam{{
+		QuorumID:           1,
+		AdversaryThreshold: 70,
+		QuorumThreshold:    100,
+	}})
+	tx := &coremock.MockTransactor{}
+	tx.On("ConfirmBatch").Return(nil, nil, errRPCOutage).Once()
+	tx.On("ConfirmBatch").Return(makeConfirmationReceipt(t, 123), nil, nil).Once()
+	queue := bat.NewInMemoryConfirmationQueue()
+	components, batcher := makeBatcherWithConfirmationQueue(t, queue, tx)
+	batcher.MaxConfirmationBlockAge = 20
+	blobStore := components.blobStore
+	ctx := context.Background()
+
+	// The first batch fails to be confirmed while the RPC provider is down
+	_, blobKey1 := queueBlob(t, ctx, &blob1, blobStore)
+	encodeQueuedBlobs(t, ctx, components.encodingStreamer, 1)
+	err := batcher.HandleSingleBatch(ctx)
+	assert.NoError(t, err)
+	err = batcher.HandleConfirmations(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	batches, err := queue.List(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, batches, 1)
+	referenceBlockNumber := batches[0].BatchHeader.ReferenceBlockNumber
+
+	// Its reference block gets too old to be confirmed by the time the RPC provider is back
+	components.chainData.ExpectedCalls = nil
+	components.chainData.On("GetCurrentBlockNumber").Return(referenceBlockNumber+21, nil)
+	_, blobKey2 := queueBlob(t, ctx, &blob2, blobStore)
+	encodeQueuedBlobs(t, ctx, components.encodingStreamer, 1)
+	err = batcher.HandleSingleBatch(ctx)
+	assert.NoError(t, err)
+	expireRetries(t, ctx, queue)
+
+	// The stale batch is dropped without being attempted, and its blob retried, while the next batch is confirmed
+	err = batcher.HandleConfirmations(ctx)
+	assert.NoError(t, err)
+	tx.AssertNumberOfCalls(t, "ConfirmBatch", 2)
+	meta1, err := blobStore.GetBlobMetadata(ctx, blobKey1)
+	assert.NoError(t, err)
+	assert.Equal(t, disperser.Processing, meta1.BlobStatus)
+	assert.Equal(t, uint(1), meta1.NumRetries)
+	meta2, err := blobStore.GetBlobMetadata(ctx, blobKey2)
+	assert.NoError(t, err)
+	assert.Equal(t, disperser.Confirmed, meta2.BlobStatus)
+	batches, err = queue.List(ctx)
+	assert.NoError(t, err)
+	assert.Empty(t, batches)
+}
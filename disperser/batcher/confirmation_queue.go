@@ -0,0 +1,390 @@
+package batcher
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	commondynamodb "github.com/Layr-Labs/eigenda/common/aws/dynamodb"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/disperser"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	gcommon "github.com/ethereum/go-ethereum/common"
+	gtypes "github.com/ethereum/go-ethereum/core/types"
+)
+
+// PendingBatch is a batch whose signatures were aggregated, waiting in the confirmation queue for its confirmation
+// transaction
+type PendingBatch struct {
+	// Sequence orders the batches of the queue in the order they were aggregated. It is assigned by the queue.
+	Sequence        uint64
+	BatchHeader     *core.BatchHeader
+	BatchHeaderHash [32]byte
+	Aggregation     *core.SignatureAggregation
+	// Blobs are the blobs of the batch which received sufficient signatures to be confirmed
+	Blobs []*PendingBlob
+
+	// Attempts is the number of failed attempts to confirm the batch
+	Attempts uint
+	// RetryAt is the time after which the confirmation of the batch is attempted again after a failure
+	RetryAt time.Time
+
+	// ConfirmationTxns are the confirmation transactions sent for the batch, each one replacing the previous one at the
+	// same nonce with bumped fees. Any of them may be the one landing.
+	ConfirmationTxns []*gtypes.Transaction
+
+	// Confirmed is set once a confirmation transaction of the batch landed, along with its hash and block number, so
+	// that a batcher recovering the queue after a crash marks its blobs confirmed without confirming the batch again
+	Confirmed               bool
+	ConfirmationTxnHash     gcommon.Hash
+	ConfirmationBlockNumber uint32
+	// BatchIDResolved is set once the batch ID was read from the receipt of the confirmation transaction
+	BatchIDResolved bool
+	BatchID         uint32
+}
+
+// PendingBlob is a blob of a PendingBatch
+type PendingBlob struct {
+	Metadata       *disperser.BlobMetadata
+	BlobHeader     *core.BlobHeader
+	BlobIndex      uint32
+	InclusionProof []byte
+}
+
+// ConfirmationQueue holds the batches waiting for their confirmation transaction, in the order they were aggregated
+type ConfirmationQueue interface {
+	// Push appends the batch to the queue, assigning its sequence number
+	Push(ctx context.Context, batch *PendingBatch) error
+	// List returns the batches of the queue ordered by sequence number
+	List(ctx context.Context) ([]*PendingBatch, error)
+	// Update persists the changes to a batch of the queue
+	Update(ctx context.Context, batch *PendingBatch) error
+	// Remove removes the batch from the queue. Removing a batch which isn't in the queue is not an error.
+	Remove(ctx context.Context, batch *PendingBatch) error
+}
+
+// sequencer assigns increasing sequence numbers to the batches pushed to a queue. The sequence numbers are based on
+// the time so that they keep increasing across restarts.
+type sequencer struct {
+	mu   sync.Mutex
+	last uint64
+}
+
+func (s *sequencer) next() uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	seq := uint64(time.Now().UnixNano())
+	if seq <= s.last {
+		seq = s.last + 1
+	}
+	s.last = seq
+	return seq
+}
+
+type inMemoryConfirmationQueue struct {
+	sequencer
+
+	mu      sync.Mutex
+	batches map[uint64][]byte
+}
+
+var _ ConfirmationQueue = (*inMemoryConfirmationQueue)(nil)
+
+// NewInMemoryConfirmationQueue creates a confirmation queue in memory. The batches are copied in and out of the queue,
+// as if they were persisted, but they are lost when the process exits.
+func NewInMemoryConfirmationQueue() ConfirmationQueue {
+	return &inMemoryConfirmationQueue{
+		batches: make(map[uint64][]byte),
+	}
+}
+
+func (q *inMemoryConfirmationQueue) Push(ctx context.Context, batch *PendingBatch) error {
+	batch.Sequence = q.next()
+	return q.Update(ctx, batch)
+}
+
+func (q *inMemoryConfirmationQueue) List(ctx context.Context) ([]*PendingBatch, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	batches := make([]*PendingBatch, 0, len(q.batches))
+	for _, data := range q.batches {
+		batch, err := DeserializePendingBatch(data)
+		if err != nil {
+			return nil, err
+		}
+		batches = append(batches, batch)
+	}
+	sort.Slice(batches, func(i, j int) bool {
+		return batches[i].Sequence < batches[j].Sequence
+	})
+	return batches, nil
+}
+
+func (q *inMemoryConfirmationQueue) Update(ctx context.Context, batch *PendingBatch) error {
+	data, err := batch.Serialize()
+	if err != nil {
+		return err
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.batches[batch.Sequence] = data
+	return nil
+}
+
+func (q *inMemoryConfirmationQueue) Remove(ctx context.Context, batch *PendingBatch) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	delete(q.batches, batch.Sequence)
+	return nil
+}
+
+// confirmationQueueID is the partition key of all the batches of a confirmation queue table, whose sort key is the
+// sequence number of the batches, so that querying the partition returns the batches in order
+const confirmationQueueID = "confirmation"
+
+// DynamoDBConfirmationQueue is a confirmation queue persisted in a DynamoDB table. The blobs of each batch are stored
+// in their own items, in a partition of the batch, so that the size of the items doesn't grow with the number of blobs
+// of the batches:
+// - (Partition Key: QueueID = "confirmation", Sort Key: Sequence) -> Batch, NumBlobs
+// - (Partition Key: QueueID = "confirmation#<Sequence of the batch>", Sort Key: Sequence = BlobIndex) -> Blob
+type DynamoDBConfirmationQueue struct {
+	sequencer
+
+	dynamoDBClient *commondynamodb.Client
+	tableName      string
+}
+
+var _ ConfirmationQueue = (*DynamoDBConfirmationQueue)(nil)
+
+func NewDynamoDBConfirmationQueue(dynamoDBClient *commondynamodb.Client, tableName string) *DynamoDBConfirmationQueue {
+	return &DynamoDBConfirmationQueue{
+		dynamoDBClient: dynamoDBClient,
+		tableName:      tableName,
+	}
+}
+
+// Push stores the blobs of the batch before the batch itself, so that the batches listed have all their blobs
+func (q *DynamoDBConfirmationQueue) Push(ctx context.Context, batch *PendingBatch) error {
+	batch.Sequence = q.next()
+
+	items := make([]commondynamodb.Item, len(batch.Blobs))
+	for i, blob := range batch.Blobs {
+		data, err := blob.serialize()
+		if err != nil {
+			return err
+		}
+		items[i] = q.blobKey(batch, i)
+		items[i]["Blob"] = &types.AttributeValueMemberB{Value: data}
+	}
+	failed, err := q.dynamoDBClient.PutItems(ctx, q.tableName, items)
+	if err != nil {
+		return err
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to store %d of the %d blobs of the batch", len(failed), len(items))
+	}
+
+	return q.Update(ctx, batch)
+}
+
+func (q *DynamoDBConfirmationQueue) List(ctx context.Context) ([]*PendingBatch, error) {
+	items, err := q.queryPartition(ctx, confirmationQueueID)
+	if err != nil {
+		return nil, err
+	}
+
+	batches := make([]*PendingBatch, len(items))
+	for i, item := range items {
+		data, ok := item["Batch"].(*types.AttributeValueMemberB)
+		if !ok {
+			return nil, fmt.Errorf("invalid confirmation queue item: missing batch")
+		}
+		numBlobs, ok := item["NumBlobs"].(*types.AttributeValueMemberN)
+		if !ok {
+			return nil, fmt.Errorf("invalid confirmation queue item: missing number of blobs")
+		}
+		batches[i], err = DeserializePendingBatch(data.Value)
+		if err != nil {
+			return nil, err
+		}
+		batches[i].Blobs, err = q.listBlobs(ctx, batches[i], numBlobs.Value)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return batches, nil
+}
+
+// listBlobs returns the blobs of the batch in the order of their index in the batch
+func (q *DynamoDBConfirmationQueue) listBlobs(ctx context.Context, batch *PendingBatch, numBlobs string) ([]*PendingBlob, error) {
+	items, err := q.queryPartition(ctx, blobsPartition(batch.Sequence))
+	if err != nil {
+		return nil, err
+	}
+	if strconv.Itoa(len(items)) != numBlobs {
+		return nil, fmt.Errorf("batch %d of the confirmation queue has %d of its %s blobs", batch.Sequence, len(items), numBlobs)
+	}
+
+	blobs := make([]*PendingBlob, len(items))
+	for i, item := range items {
+		data, ok := item["Blob"].(*types.AttributeValueMemberB)
+		if !ok {
+			return nil, fmt.Errorf("invalid confirmation queue item: missing blob")
+		}
+		blobs[i], err = deserializePendingBlob(data.Value)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return blobs, nil
+}
+
+// queryPartition returns all the items of the partition ordered by sequence number, reading as many pages as needed
+func (q *DynamoDBConfirmationQueue) queryPartition(ctx context.Context, queueID string) ([]commondynamodb.Item, error) {
+	var items []commondynamodb.Item
+	var startKey commondynamodb.Key
+	for {
+		page, lastKey, err := q.dynamoDBClient.QueryWithPagination(ctx, q.tableName, "QueueID = :queueID", commondynamodb.ExpresseionValues{
+			":queueID": &types.AttributeValueMemberS{
+				Value: queueID,
+			}}, 0, startKey)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, page...)
+		if lastKey == nil {
+			return items, nil
+		}
+		startKey = lastKey
+	}
+}
+
+// Update only stores the batch item, since the blobs of a batch don't change once it is pushed
+func (q *DynamoDBConfirmationQueue) Update(ctx context.Context, batch *PendingBatch) error {
+	withoutBlobs := *batch
+	withoutBlobs.Blobs = nil
+	data, err := withoutBlobs.Serialize()
+	if err != nil {
+		return err
+	}
+	item := q.key(batch)
+	item["Batch"] = &types.AttributeValueMemberB{Value: data}
+	item["NumBlobs"] = &types.AttributeValueMemberN{Value: strconv.Itoa(len(batch.Blobs))}
+	return q.dynamoDBClient.PutItem(ctx, q.tableName, item)
+}
+
+// Remove deletes the batch before its blobs, so that a batch whose blobs fail to be deleted is no longer listed
+func (q *DynamoDBConfirmationQueue) Remove(ctx context.Context, batch *PendingBatch) error {
+	if err := q.dynamoDBClient.DeleteItem(ctx, q.tableName, q.key(batch)); err != nil {
+		return err
+	}
+
+	keys := make([]commondynamodb.Key, len(batch.Blobs))
+	for i := range batch.Blobs {
+		keys[i] = q.blobKey(batch, i)
+	}
+	failed, err := q.dynamoDBClient.DeleteItems(ctx, q.tableName, keys)
+	if err != nil {
+		return err
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to delete %d of the %d blobs of the batch", len(failed), len(keys))
+	}
+	return nil
+}
+
+func (q *DynamoDBConfirmationQueue) key(batch *PendingBatch) map[string]types.AttributeValue {
+	return map[string]types.AttributeValue{
+		"QueueID": &types.AttributeValueMemberS{
+			Value: confirmationQueueID,
+		},
+		"Sequence": &types.AttributeValueMemberN{
+			Value: strconv.FormatUint(batch.Sequence, 10),
+		},
+	}
+}
+
+func (q *DynamoDBConfirmationQueue) blobKey(batch *PendingBatch, index int) map[string]types.AttributeValue {
+	return map[string]types.AttributeValue{
+		"QueueID": &types.AttributeValueMemberS{
+			Value: blobsPartition(batch.Sequence),
+		},
+		"Sequence": &types.AttributeValueMemberN{
+			Value: strconv.Itoa(index),
+		},
+	}
+}
+
+// blobsPartition is the partition key of the blobs of the batch with the given sequence number
+func blobsPartition(sequence uint64) string {
+	return fmt.Sprintf("%s#%d", confirmationQueueID, sequence)
+}
+
+func GenerateConfirmationQueueTableSchema(tableName string, readCapacityUnits int64, writeCapacityUnits int64) *dynamodb.CreateTableInput {
+	return &dynamodb.CreateTableInput{
+		AttributeDefinitions: []types.AttributeDefinition{
+			{
+				AttributeName: aws.String("QueueID"),
+				AttributeType: types.ScalarAttributeTypeS,
+			},
+			{
+				AttributeName: aws.String("Sequence"),
+				AttributeType: types.ScalarAttributeTypeN,
+			},
+		},
+		KeySchema: []types.KeySchemaElement{
+			{
+				AttributeName: aws.String("QueueID"),
+				KeyType:       types.KeyTypeHash,
+			},
+			{
+				AttributeName: aws.String("Sequence"),
+				KeyType:       types.KeyTypeRange,
+			},
+		},
+		TableName: aws.String(tableName),
+		ProvisionedThroughput: &types.ProvisionedThroughput{
+			ReadCapacityUnits:  aws.Int64(readCapacityUnits),
+			WriteCapacityUnits: aws.Int64(writeCapacityUnits),
+		},
+	}
+}
+
+func (b *PendingBatch) Serialize() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(b); err != nil {
+		return nil, fmt.Errorf("failed to serialize pending batch: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func DeserializePendingBatch(data []byte) (*PendingBatch, error) {
+	batch := new(PendingBatch)
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(batch); err != nil {
+		return nil, fmt.Errorf("failed to deserialize pending batch: %w", err)
+	}
+	return batch, nil
+}
+
+func (b *PendingBlob) serialize() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(b); err != nil {
+		return nil, fmt.Errorf("failed to serialize pending blob: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func deserializePendingBlob(data []byte) (*PendingBlob, error) {
+	blob := new(PendingBlob)
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(blob); err != nil {
+		return nil, fmt.Errorf("failed to deserialize pending blob: %w", err)
+	}
+	return blob, nil
+}
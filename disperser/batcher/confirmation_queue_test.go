@@ -0,0 +1,127 @@
+package batcher_test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/disperser"
+	bat "github.com/Layr-Labs/eigenda/disperser/batcher"
+	kzgbn254 "github.com/Layr-Labs/eigenda/pkg/kzg/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func makePendingBatch(referenceBlockNumber uint) *bat.PendingBatch {
+	_, _, g1Gen, g2Gen := bn254.Generators()
+	g1 := &core.G1Point{G1Affine: &g1Gen}
+	return &bat.PendingBatch{
+		BatchHeader: &core.BatchHeader{
+			ReferenceBlockNumber: referenceBlockNumber,
+			BatchRoot:            [32]byte{1, 2, 3},
+		},
+		BatchHeaderHash: [32]byte{4, 5, 6},
+		Aggregation: &core.SignatureAggregation{
+			NonSigners:       []*core.G1Point{g1},
+			QuorumAggPubKeys: []*core.G1Point{g1, g1},
+			AggPubKey:        &core.G2Point{G2Affine: &g2Gen},
+			AggSignature:     &core.Signature{G1Point: g1},
+			QuorumResults: map[core.QuorumID]*core.QuorumResult{
+				0: {QuorumID: 0, PercentSigned: 80},
+			},
+		},
+		Blobs: []*bat.PendingBlob{
+			{
+				Metadata: &disperser.BlobMetadata{
+					BlobHash:     "blobHash",
+					MetadataHash: "metadataHash",
+					BlobStatus:   disperser.Processing,
+					RequestMetadata: &disperser.RequestMetadata{
+						BlobRequestHeader: core.BlobRequestHeader{
+							SecurityParams: []*core.SecurityParam{{QuorumID: 0, AdversaryThreshold: 50, QuorumThreshold: 80}},
+						},
+						BlobSize:    100,
+						RequestedAt: 123,
+					},
+				},
+				BlobHeader: &core.BlobHeader{
+					BlobCommitments: core.BlobCommitments{
+						Commitment:  &core.Commitment{G1Point: (*kzgbn254.G1Point)(&g1Gen)},
+						LengthProof: &core.Commitment{G1Point: (*kzgbn254.G1Point)(&g1Gen)},
+						Length:      10,
+					},
+					QuorumInfos: []*core.BlobQuorumInfo{{
+						SecurityParam:      core.SecurityParam{QuorumID: 0, AdversaryThreshold: 50, QuorumThreshold: 80},
+						QuantizationFactor: 1,
+						EncodedBlobLength:  32,
+					}},
+				},
+				BlobIndex:      0,
+				InclusionProof: []byte{7, 8, 9},
+			},
+		},
+	}
+}
+
+func TestPendingBatchSerialization(t *testing.T) {
+	batch := makePendingBatch(10)
+	batch.Sequence = 42
+	batch.Attempts = 2
+	batch.RetryAt = time.Unix(1700000000, 0).UTC()
+	batch.ConfirmationTxns = []*types.Transaction{
+		types.NewTx(&types.DynamicFeeTx{Nonce: 7, GasTipCap: big.NewInt(100), GasFeeCap: big.NewInt(200), Data: []byte{1, 2}}),
+		types.NewTx(&types.DynamicFeeTx{Nonce: 7, GasTipCap: big.NewInt(115), GasFeeCap: big.NewInt(230), Data: []byte{1, 2}}),
+	}
+	batch.Confirmed = true
+	batch.ConfirmationTxnHash = gethcommon.HexToHash("0x123")
+	batch.ConfirmationBlockNumber = 150
+	batch.BatchIDResolved = true
+	batch.BatchID = 3
+
+	data, err := batch.Serialize()
+	assert.NoError(t, err)
+	deserialized, err := bat.DeserializePendingBatch(data)
+	assert.NoError(t, err)
+	// The transactions are compared by hash, since they also hold the time they were first seen
+	assert.Len(t, deserialized.ConfirmationTxns, 2)
+	for i, txn := range batch.ConfirmationTxns {
+		assert.Equal(t, txn.Hash(), deserialized.ConfirmationTxns[i].Hash())
+	}
+	batch.ConfirmationTxns, deserialized.ConfirmationTxns = nil, nil
+	assert.Equal(t, batch, deserialized)
+
+	_, err = bat.DeserializePendingBatch([]byte("invalid"))
+	assert.Error(t, err)
+}
+
+func TestInMemoryConfirmationQueue(t *testing.T) {
+	ctx := context.Background()
+	queue := bat.NewInMemoryConfirmationQueue()
+
+	first := makePendingBatch(10)
+	second := makePendingBatch(11)
+	assert.NoError(t, queue.Push(ctx, first))
+	assert.NoError(t, queue.Push(ctx, second))
+	assert.Less(t, first.Sequence, second.Sequence)
+
+	// The batches are listed in the order they were pushed, as copies
+	batches, err := queue.List(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []*bat.PendingBatch{first, second}, batches)
+	batches[0].Attempts = 1
+	batches, err = queue.List(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, uint(0), batches[0].Attempts)
+
+	first.Attempts = 1
+	assert.NoError(t, queue.Update(ctx, first))
+	assert.NoError(t, queue.Remove(ctx, second))
+	assert.NoError(t, queue.Remove(ctx, second))
+	batches, err = queue.List(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []*bat.PendingBatch{first}, batches)
+}
@@ -0,0 +1,72 @@
+package batcher
+
+import (
+	"context"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/disperser"
+)
+
+// Drain makes the batcher flush the blobs queued so far and then idle, ahead of its maintenance. The blobs queued
+// after the drain started are left processing, to be batched once the batcher restarts. The batcher is drained once
+// all the blobs queued before the drain are confirmed or failed, including those retried or waiting for their daily
+// quota, which is reported by CheckDrained.
+func (b *Batcher) Drain() {
+	if b.draining.Swap(true) {
+		return
+	}
+	cutoff := uint64(time.Now().UnixNano())
+	b.drainCutoff.Store(cutoff)
+	b.EncodingStreamer.setDrainCutoff(cutoff)
+	b.logger.Info("draining: the blobs queued from now on are not batched")
+}
+
+// CheckDrained returns whether the batcher is drained, i.e. it is draining and none of the blobs queued before the
+// drain started is processing anymore. The drained batcher makes no more batches.
+func (b *Batcher) CheckDrained(ctx context.Context) (bool, error) {
+	if !b.draining.Load() {
+		return false, nil
+	}
+	if b.drained.Load() {
+		return true, nil
+	}
+
+	metadatas, err := b.Queue.GetBlobMetadataByStatus(ctx, disperser.Processing)
+	if err != nil {
+		return false, err
+	}
+	cutoff := b.drainCutoff.Load()
+	for _, metadata := range metadatas {
+		if metadata.RequestMetadata.RequestedAt <= cutoff {
+			return false, nil
+		}
+	}
+	b.drained.Store(true)
+	b.logger.Info("drained: the blobs queued before the drain are all confirmed or failed, no more batches are made", "numQueuedAfterDrain", len(metadatas))
+	return true, nil
+}
+
+// setDrainCutoff makes the streamer only encode the blobs requested up to the cutoff, in nanoseconds since the epoch
+func (e *EncodingStreamer) setDrainCutoff(cutoff uint64) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.drainCutoff = cutoff
+}
+
+// excludeQueuedAfterDrain leaves out the blobs requested after the batcher started draining
+func (e *EncodingStreamer) excludeQueuedAfterDrain(metadatas []*disperser.BlobMetadata) []*disperser.BlobMetadata {
+	e.mu.RLock()
+	cutoff := e.drainCutoff
+	e.mu.RUnlock()
+	if cutoff == 0 {
+		return metadatas
+	}
+
+	included := make([]*disperser.BlobMetadata, 0, len(metadatas))
+	for _, metadata := range metadatas {
+		if metadata.RequestMetadata.RequestedAt <= cutoff {
+			included = append(included, metadata)
+		}
+	}
+	return included
+}
@@ -38,6 +38,25 @@ type StreamerConfig struct {
 
 	// EncodingQueueLimit is the maximum number of encoding requests that can be queued
 	EncodingQueueLimit int
+
+	// MaxReferenceBlockAge is the max number of blocks between the reference block and the current block. The blobs
+	// are encoded again at a newer reference block once it is exceeded. The reference block isn't refreshed if it is 0.
+	MaxReferenceBlockAge uint
+
+	// EncodingOverprovisionPercent is the margin by which the chunks of each quorum are over-provisioned, as a
+	// percentage of the nominal number of chunks
+	EncodingOverprovisionPercent uint
+
+	// DailyQuota, AccountDailyQuotas and QuotaGracePeriod configure the daily quotas of the accounts, enforced once an
+	// account usage store is set
+	DailyQuota         uint64
+	AccountDailyQuotas map[core.AccountID]uint64
+	QuotaGracePeriod   time.Duration
+
+	// AccountWeights, MaxBlobWait and BatchSizeLimit configure the selection of the blobs to encode, see selectBlobs
+	AccountWeights map[core.AccountID]uint64
+	MaxBlobWait    time.Duration
+	BatchSizeLimit uint64
 }
 
 type EncodingStreamer struct {
@@ -56,6 +75,13 @@ type EncodingStreamer struct {
 	assignmentCoordinator core.AssignmentCoordinator
 
 	encodingCtxCancelFuncs []context.CancelFunc
+	// awaitingConfirmation are the blobs whose batch is in the confirmation queue, which are not encoded again
+	awaitingConfirmation map[disperser.BlobKey]struct{}
+	// accountUsage records the bytes confirmed for each account. The daily quotas are not enforced if it is nil.
+	accountUsage disperser.AccountUsageStore
+	// drainCutoff is the time in nanoseconds since the epoch at which the batcher started draining, after which the
+	// queued blobs are not encoded. It is 0 if the batcher isn't draining.
+	drainCutoff uint64
 
 	metrics *EncodingStreamerMetrics
 	logger  common.Logger
@@ -64,6 +90,8 @@ type EncodingStreamer struct {
 type batchMetadata struct {
 	QuorumInfos map[core.QuorumID]QuorumInfo
 	State       *core.IndexedOperatorState
+	// BlobEncodings are the encoding params of each blob in each of its quorums, in the order of its security params
+	BlobEncodings map[disperser.BlobKey][]*core.BlobQuorumEncoding
 }
 
 type batch struct {
@@ -96,6 +124,9 @@ func NewEncodingStreamer(
 	if config.EncodingQueueLimit <= 0 {
 		return nil, fmt.Errorf("EncodingQueueLimit should be greater than 0")
 	}
+	if config.EncodingOverprovisionPercent > core.MaxOverprovisionPercent {
+		return nil, fmt.Errorf("EncodingOverprovisionPercent should be at most %d", core.MaxOverprovisionPercent)
+	}
 	return &EncodingStreamer{
 		StreamerConfig:         config,
 		EncodedBlobstore:       newEncodedBlobStore(logger),
@@ -107,6 +138,7 @@ func NewEncodingStreamer(
 		encoderClient:          encoderClient,
 		assignmentCoordinator:  assignmentCoordinator,
 		encodingCtxCancelFuncs: make([]context.CancelFunc, 0),
+		awaitingConfirmation:   make(map[disperser.BlobKey]struct{}),
 		metrics:                metrics,
 		logger:                 logger,
 	}, nil
@@ -155,9 +187,28 @@ func (e *EncodingStreamer) Start(ctx context.Context) error {
 	return nil
 }
 
+// SetAwaitingConfirmation sets whether the blobs are waiting for the confirmation of their batch, in which case they
+// are not encoded again for another batch
+func (e *EncodingStreamer) SetAwaitingConfirmation(blobKeys []disperser.BlobKey, awaiting bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for _, key := range blobKeys {
+		if awaiting {
+			e.awaitingConfirmation[key] = struct{}{}
+		} else {
+			delete(e.awaitingConfirmation, key)
+		}
+	}
+}
+
 func (e *EncodingStreamer) dedupRequests(metadatas []*disperser.BlobMetadata, referenceBlockNumber uint) []*disperser.BlobMetadata {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
 	res := make([]*disperser.BlobMetadata, 0)
 	for _, meta := range metadatas {
+		if _, ok := e.awaitingConfirmation[meta.GetBlobKey()]; ok {
+			continue
+		}
 		allQuorumsRequested := true
 		// check if the blob has been requested for all quorums
 		for _, quorum := range meta.RequestMetadata.SecurityParams {
@@ -181,6 +232,10 @@ func (e *EncodingStreamer) RequestEncoding(ctx context.Context, encoderChan chan
 	if err != nil {
 		return fmt.Errorf("error getting blob metadatas: %w", err)
 	}
+	metadatas = e.excludeQueuedAfterDrain(metadatas)
+	if e.accountUsage != nil {
+		metadatas = e.enforceDailyQuotas(ctx, metadatas, time.Now())
+	}
 	if len(metadatas) == 0 {
 		e.logger.Info("no new metadatas to encode")
 		return nil
@@ -191,12 +246,16 @@ func (e *EncodingStreamer) RequestEncoding(ctx context.Context, encoderChan chan
 	referenceBlockNumber := e.ReferenceBlockNumber
 	e.mu.RUnlock()
 
-	if referenceBlockNumber == 0 {
+	if referenceBlockNumber == 0 || e.MaxReferenceBlockAge > 0 {
 		// Update the reference block number for the next iteration
 		blockNumber, err := e.chainState.GetCurrentBlockNumber()
 		if err != nil {
 			return fmt.Errorf("failed to get current block number, won't request encoding: %w", err)
-		} else {
+		}
+		if referenceBlockNumber == 0 || e.isStale(referenceBlockNumber, blockNumber) {
+			if referenceBlockNumber != 0 {
+				e.logger.Info("[RequestEncoding] refreshing the stale reference block", "referenceBlockNumber", referenceBlockNumber, "blockNumber", blockNumber)
+			}
 			e.mu.Lock()
 			e.ReferenceBlockNumber = blockNumber
 			e.mu.Unlock()
@@ -221,9 +280,9 @@ func (e *EncodingStreamer) RequestEncoding(ctx context.Context, encoderChan chan
 		e.logger.Warn("[RequestEncoding] worker pool queue is full. skipping this round of encoding requests", "waitingQueueSize", waitingQueueSize, "encodingQueueLimit", e.EncodingQueueLimit)
 		return nil
 	}
-	// only process subset of blobs so it doesn't exceed the EncodingQueueLimit
+	// only process subset of blobs so it doesn't exceed the EncodingQueueLimit, shared fairly between the accounts
 	// TODO: this should be done at the request time and keep the cursor so that we don't fetch the same metadata every time
-	metadatas = metadatas[:numMetadatastoProcess]
+	metadatas = e.selectBlobs(metadatas, numMetadatastoProcess, time.Now())
 
 	e.logger.Trace("[encodingstreamer] new metadatas to encode", "numMetadata", len(metadatas), "duration", time.Since(stageTimer))
 
@@ -268,30 +327,16 @@ func (e *EncodingStreamer) RequestEncodingForBlob(ctx context.Context, metadata
 
 	pending := make([]pendingRequestInfo, 0, len(metadata.RequestMetadata.SecurityParams))
 
-	for ind := range metadata.RequestMetadata.SecurityParams {
-
-		quorum := metadata.RequestMetadata.SecurityParams[ind]
+	blobLength := metadata.RequestMetadata.Layout.BlobLength(metadata.RequestMetadata.BlobSize)
+	for _, encoding := range batchMetadata.BlobEncodings[blobKey] {
 		// Check if the blob has already been encoded for this quorum
-		if e.EncodedBlobstore.HasEncodingRequested(blobKey, quorum.QuorumID, referenceBlockNumber) {
-			continue
-		}
-
-		quorumInfo := batchMetadata.QuorumInfos[quorum.QuorumID]
-		blobLength := core.GetBlobLength(metadata.RequestMetadata.BlobSize)
-		numOperators := uint(len(quorumInfo.Assignments))
-		chunkLength, err := e.assignmentCoordinator.GetMinimumChunkLength(numOperators, blobLength, quorumInfo.QuantizationFactor, quorum.QuorumThreshold, quorum.AdversaryThreshold)
-		if err != nil {
-			// This error shouldn't happen because we check blob headers before adding them blob store
-			e.logger.Error("[RequestEncodingForBlob] invalid request parameters", "err", err)
-			continue
-		}
-		params, err := core.GetEncodingParams(chunkLength, quorumInfo.Info.TotalChunks)
-		if err != nil {
-			e.logger.Error("[RequestEncodingForBlob] error getting encoding params", "err", err)
+		if e.EncodedBlobstore.HasEncodingRequested(blobKey, encoding.QuorumID, referenceBlockNumber) {
 			continue
 		}
 
-		err = core.ValidateEncodingParams(params, int(blobLength), e.SRSOrder)
+		params := encoding.EncodingParams
+		params.Layout = metadata.RequestMetadata.Layout
+		err := core.ValidateEncodingParams(params, int(blobLength), e.SRSOrder)
 		if err != nil {
 			e.logger.Error("[RequestEncodingForBlob] invalid encoding params", "err", err)
 			// Cancel the blob
@@ -302,19 +347,10 @@ func (e *EncodingStreamer) RequestEncodingForBlob(ctx context.Context, metadata
 			return
 		}
 
-		blobQuorumInfo := &core.BlobQuorumInfo{
-			SecurityParam: core.SecurityParam{
-				QuorumID:           quorum.QuorumID,
-				AdversaryThreshold: quorum.AdversaryThreshold,
-				QuorumThreshold:    quorum.QuorumThreshold,
-				QuorumRate:         quorum.QuorumRate,
-			},
-			QuantizationFactor: quorumInfo.QuantizationFactor,
-			EncodedBlobLength:  params.ChunkLength * quorumInfo.QuantizationFactor * numOperators,
-		}
+		blobQuorumInfo := encoding.BlobQuorumInfo
 
 		pending = append(pending, pendingRequestInfo{
-			BlobQuorumInfo: blobQuorumInfo,
+			BlobQuorumInfo: &blobQuorumInfo,
 			EncodingParams: params,
 		})
 	}
@@ -419,6 +455,21 @@ func (e *EncodingStreamer) CreateBatch() (*batch, error) {
 		return nil, errNoEncodedResults
 	}
 
+	// Don't make a batch that the nodes would reject because of the age of its reference block. The blobs are encoded
+	// again at a newer reference block by the next run of RequestEncoding.
+	if e.MaxReferenceBlockAge > 0 {
+		blockNumber, err := e.chainState.GetCurrentBlockNumber()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get current block number: %w", err)
+		}
+		if e.isStale(e.ReferenceBlockNumber, blockNumber) {
+			e.logger.Warn("[CreateBatch] discarding the encoded results of a stale reference block", "referenceBlockNumber", e.ReferenceBlockNumber, "blockNumber", blockNumber)
+			_ = e.EncodedBlobstore.GetNewAndDeleteStaleEncodingResults(blockNumber)
+			e.ReferenceBlockNumber = 0
+			return nil, errNoEncodedResults
+		}
+	}
+
 	// Delete any encoded results that are not from the current batching iteration (i.e. that has different reference block number)
 	// If any pending encoded results are discarded here, it will be re-requested in the next iteration
 	encodedResults := e.EncodedBlobstore.GetNewAndDeleteStaleEncodingResults(e.ReferenceBlockNumber)
@@ -532,6 +583,26 @@ func (e *EncodingStreamer) CreateBatch() (*batch, error) {
 	}, nil
 }
 
+// isStale returns whether the reference block is older than the max reference block age at the current block
+func (e *EncodingStreamer) isStale(referenceBlockNumber, blockNumber uint) bool {
+	return e.MaxReferenceBlockAge > 0 && blockNumber > referenceBlockNumber+e.MaxReferenceBlockAge
+}
+
+// ReleaseBlob drops the encoding requests and results of the blob, so that it is encoded again in the next iteration,
+// unless the blob is awaiting the confirmation of its batch. It returns whether the blob was released.
+func (e *EncodingStreamer) ReleaseBlob(metadata *disperser.BlobMetadata) bool {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	if _, ok := e.awaitingConfirmation[metadata.GetBlobKey()]; ok {
+		return false
+	}
+	for _, sp := range metadata.RequestMetadata.SecurityParams {
+		e.EncodedBlobstore.DeleteEncodingRequest(metadata.GetBlobKey(), sp.QuorumID)
+		e.EncodedBlobstore.DeleteEncodingResult(metadata.GetBlobKey(), sp.QuorumID)
+	}
+	return true
+}
+
 func (e *EncodingStreamer) RemoveEncodedBlob(metadata *disperser.BlobMetadata) {
 	for _, sp := range metadata.RequestMetadata.SecurityParams {
 		e.EncodedBlobstore.DeleteEncodingResult(metadata.GetBlobKey(), sp.QuorumID)
@@ -559,20 +630,34 @@ func (e *EncodingStreamer) getBatchMetadata(ctx context.Context, metadatas []*di
 		return nil, fmt.Errorf("error getting operator state at block number %d: %w", blockNumber, err)
 	}
 
-	for quorumID := range quorums {
-		assignments, info, err := e.assignmentCoordinator.GetAssignments(state.OperatorState, quorumID, QuantizationFactor)
-		if err != nil {
-			return nil, err
+	// Compute the encoding params of all the blobs at once, so that the assignments of each quorum are only computed once
+	requests := make([]core.BlobEncodingRequest, len(metadatas))
+	for i, metadata := range metadatas {
+		requests[i] = core.BlobEncodingRequest{
+			BlobLength:     metadata.RequestMetadata.Layout.BlobLength(metadata.RequestMetadata.BlobSize),
+			SecurityParams: metadata.RequestMetadata.SecurityParams,
 		}
-		quorums[quorumID] = QuorumInfo{
-			Assignments:        assignments,
-			Info:               info,
-			QuantizationFactor: QuantizationFactor,
+	}
+	encodings, err := core.GetBatchEncodingParams(e.assignmentCoordinator, state.OperatorState, QuantizationFactor, e.EncodingOverprovisionPercent, requests)
+	if err != nil {
+		return nil, fmt.Errorf("error getting encoding params at block number %d: %w", blockNumber, err)
+	}
+
+	blobEncodings := make(map[disperser.BlobKey][]*core.BlobQuorumEncoding, len(metadatas))
+	for i, metadata := range metadatas {
+		blobEncodings[metadata.GetBlobKey()] = encodings[i]
+		for _, encoding := range encodings[i] {
+			quorums[encoding.QuorumID] = QuorumInfo{
+				Assignments:        encoding.Assignments,
+				Info:               encoding.AssignmentInfo,
+				QuantizationFactor: encoding.QuantizationFactor,
+			}
 		}
 	}
 
 	return &batchMetadata{
-		QuorumInfos: quorums,
-		State:       state,
+		QuorumInfos:   quorums,
+		State:         state,
+		BlobEncodings: blobEncodings,
 	}, nil
 }
@@ -46,7 +46,7 @@ func createEncodingStreamer(t *testing.T, initialBlockNumber uint, batchThreshol
 	asgn := &core.StdAssignmentCoordinator{}
 	sizeNotifier := batcher.NewEncodedSizeNotifier(make(chan struct{}, 1), batchThreshold)
 	workerpool := workerpool.New(5)
-	metrics := batcher.NewMetrics("9100", logger)
+	metrics := batcher.NewMetrics("9100", nil, logger)
 	encodingStreamer, err := batcher.NewEncodingStreamer(streamerConfig, blobStore, cst, encoderClient, asgn, sizeNotifier, workerpool, metrics.EncodingStreamerMetrics, logger)
 	assert.Nil(t, err)
 	encodingStreamer.ReferenceBlockNumber = initialBlockNumber
@@ -68,7 +68,7 @@ func TestEncodingQueueLimit(t *testing.T) {
 	asgn := &core.StdAssignmentCoordinator{}
 	sizeNotifier := batcher.NewEncodedSizeNotifier(make(chan struct{}, 1), 100000)
 	pool := &cmock.MockWorkerpool{}
-	metrics := batcher.NewMetrics("9100", logger)
+	metrics := batcher.NewMetrics("9100", nil, logger)
 	encodingStreamer, err := batcher.NewEncodingStreamer(streamerConfig, blobStore, cst, encoderClient, asgn, sizeNotifier, pool, metrics.EncodingStreamerMetrics, logger)
 	assert.Nil(t, err)
 	encodingStreamer.ReferenceBlockNumber = 10
@@ -299,7 +299,7 @@ func TestEncodingFailure(t *testing.T) {
 		EncodingRequestTimeout: 5 * time.Second,
 		EncodingQueueLimit:     100,
 	}
-	metrics := batcher.NewMetrics("9100", logger)
+	metrics := batcher.NewMetrics("9100", nil, logger)
 	encodingStreamer, err := batcher.NewEncodingStreamer(streamerConfig, blobStore, cst, encoderClient, asgn, sizeNotifier, workerpool, metrics.EncodingStreamerMetrics, logger)
 	assert.Nil(t, err)
 	encodingStreamer.ReferenceBlockNumber = 10
@@ -683,3 +683,145 @@ func TestGetBatch(t *testing.T) {
 	assert.Contains(t, batch.BlobMetadata, metadata1)
 	assert.Contains(t, batch.BlobMetadata, metadata2)
 }
+
+func TestEncodingOverprovision(t *testing.T) {
+	encodingStreamer, c := createEncodingStreamer(t, 10, 1e12, batcher.StreamerConfig{
+		SRSOrder:                     300000,
+		EncodingRequestTimeout:       5 * time.Second,
+		EncodingQueueLimit:           100,
+		EncodingOverprovisionPercent: 50,
+	})
+	ctx := context.Background()
+
+	blob := makeTestBlob([]*core.SecurityParam{{
+		QuorumID:           0,
+		AdversaryThreshold: 80,
+		QuorumThreshold:    100,
+	}})
+	_, err := c.blobStore.StoreBlob(ctx, &blob, uint64(time.Now().UnixNano()))
+	assert.Nil(t, err)
+
+	c.chainDataMock.On("GetCurrentBlockNumber").Return(uint(10), nil)
+	out := make(chan batcher.EncodingResultOrStatus)
+	err = encodingStreamer.RequestEncoding(ctx, out)
+	assert.Nil(t, err)
+	err = encodingStreamer.ProcessEncodedBlobs(ctx, <-out)
+	assert.Nil(t, err)
+	encodingStreamer.Pool.StopWait()
+
+	batch, err := encodingStreamer.CreateBatch()
+	assert.Nil(t, err)
+	assert.NotNil(t, batch)
+
+	// The chunks are inflated by the margin recorded in the blob header, from the 15 chunks without it
+	assert.Equal(t, uint(20), batch.BatchMetadata.QuorumInfos[0].Info.TotalChunks)
+	quorumInfo := batch.BlobHeaders[0].QuorumInfos[0]
+	assert.Equal(t, uint(50), quorumInfo.OverprovisionPercent)
+	numChunks, err := core.GetNumNominalChunks(numOperators, batcher.QuantizationFactor, 50)
+	assert.NoError(t, err)
+	assert.Equal(t, uint(0), quorumInfo.EncodedBlobLength%numChunks)
+
+	// The nodes recompute the same assignments and encoded length from the blob header
+	state := batch.BatchMetadata.State.OperatorState
+	enc, err := makeTestEncoder()
+	assert.Nil(t, err)
+	validator := core.NewChunkValidator(enc, &core.StdAssignmentCoordinator{}, c.chainDataMock, core.OperatorID{}, 0, 0, 0)
+	for id, message := range batch.EncodedBlobs[0] {
+		validator.UpdateOperatorID(id)
+		assert.Nil(t, validator.ValidateBlob(message, state, batch.BatchHeader.ReferenceBlockNumber))
+	}
+
+	_, err = batcher.NewEncodingStreamer(batcher.StreamerConfig{
+		EncodingQueueLimit:           100,
+		EncodingOverprovisionPercent: core.MaxOverprovisionPercent + 1,
+	}, c.blobStore, c.chainDataMock, c.encoderClient, &core.StdAssignmentCoordinator{}, nil, nil, nil, nil)
+	assert.EqualError(t, err, "EncodingOverprovisionPercent should be at most 100")
+}
+
+func TestStaleReferenceBlock(t *testing.T) {
+	encodingStreamer, c := createEncodingStreamer(t, 0, 1e12, batcher.StreamerConfig{
+		SRSOrder:               300000,
+		EncodingRequestTimeout: 5 * time.Second,
+		EncodingQueueLimit:     100,
+		MaxReferenceBlockAge:   10,
+	})
+	ctx := context.Background()
+
+	blob := makeTestBlob([]*core.SecurityParam{{
+		QuorumID:           0,
+		AdversaryThreshold: 80,
+		QuorumThreshold:    100,
+	}})
+	metadataKey, err := c.blobStore.StoreBlob(ctx, &blob, uint64(time.Now().UnixNano()))
+	assert.Nil(t, err)
+
+	out := make(chan batcher.EncodingResultOrStatus)
+	c.chainDataMock.On("GetCurrentBlockNumber").Return(uint(100), nil).Once()
+	err = encodingStreamer.RequestEncoding(ctx, out)
+	assert.Nil(t, err)
+	err = encodingStreamer.ProcessEncodedBlobs(ctx, <-out)
+	assert.Nil(t, err)
+	assert.Equal(t, uint(100), encodingStreamer.ReferenceBlockNumber)
+
+	// The reference block is kept up to the max age
+	c.chainDataMock.On("GetCurrentBlockNumber").Return(uint(110), nil).Once()
+	err = encodingStreamer.RequestEncoding(ctx, out)
+	assert.Nil(t, err)
+	assert.Equal(t, uint(100), encodingStreamer.ReferenceBlockNumber)
+	assert.Len(t, out, 0)
+
+	// The encoded results are discarded once the reference block is older than the max age
+	c.chainDataMock.On("GetCurrentBlockNumber").Return(uint(111), nil).Once()
+	batch, err := encodingStreamer.CreateBatch()
+	assert.ErrorContains(t, err, "no encoded results")
+	assert.Nil(t, batch)
+	assert.Equal(t, uint(0), encodingStreamer.ReferenceBlockNumber)
+	count, _ := encodingStreamer.EncodedBlobstore.GetEncodedResultSize()
+	assert.Equal(t, 0, count)
+
+	// The blob is encoded again at the current block
+	c.chainDataMock.On("GetCurrentBlockNumber").Return(uint(111), nil).Once()
+	err = encodingStreamer.RequestEncoding(ctx, out)
+	assert.Nil(t, err)
+	err = encodingStreamer.ProcessEncodedBlobs(ctx, <-out)
+	assert.Nil(t, err)
+	assert.True(t, encodingStreamer.EncodedBlobstore.HasEncodingRequested(metadataKey, core.QuorumID(0), 111))
+
+	// A batch is made at the max age
+	c.chainDataMock.On("GetCurrentBlockNumber").Return(uint(121), nil).Once()
+	batch, err = encodingStreamer.CreateBatch()
+	assert.Nil(t, err)
+	assert.NotNil(t, batch)
+	assert.Equal(t, uint(111), batch.BatchHeader.ReferenceBlockNumber)
+	encodingStreamer.Pool.StopWait()
+}
+
+func TestRefreshStaleReferenceBlock(t *testing.T) {
+	encodingStreamer, c := createEncodingStreamer(t, 100, 1e12, batcher.StreamerConfig{
+		SRSOrder:               300000,
+		EncodingRequestTimeout: 5 * time.Second,
+		EncodingQueueLimit:     100,
+		MaxReferenceBlockAge:   10,
+	})
+	ctx := context.Background()
+
+	blob := makeTestBlob([]*core.SecurityParam{{
+		QuorumID:           0,
+		AdversaryThreshold: 80,
+		QuorumThreshold:    100,
+	}})
+	metadataKey, err := c.blobStore.StoreBlob(ctx, &blob, uint64(time.Now().UnixNano()))
+	assert.Nil(t, err)
+
+	// The blobs are encoded at the current block rather than at a stale reference block
+	out := make(chan batcher.EncodingResultOrStatus)
+	c.chainDataMock.On("GetCurrentBlockNumber").Return(uint(111), nil).Once()
+	err = encodingStreamer.RequestEncoding(ctx, out)
+	assert.Nil(t, err)
+	err = encodingStreamer.ProcessEncodedBlobs(ctx, <-out)
+	assert.Nil(t, err)
+	assert.Equal(t, uint(111), encodingStreamer.ReferenceBlockNumber)
+	assert.False(t, encodingStreamer.EncodedBlobstore.HasEncodingRequested(metadataKey, core.QuorumID(0), 100))
+	assert.True(t, encodingStreamer.EncodedBlobstore.HasEncodingRequested(metadataKey, core.QuorumID(0), 111))
+	encodingStreamer.Pool.StopWait()
+}
@@ -36,25 +36,29 @@ func NewBatchConfirmer(tx core.Transactor, timeout time.Duration) (disperser.Bat
 
 var _ disperser.BatchConfirmer = (*BatchConfirmer)(nil)
 
-func (c *BatchConfirmer) ConfirmBatch(ctx context.Context, header *core.BatchHeader, quorums map[core.QuorumID]*core.QuorumResult, sigAgg *core.SignatureAggregation) (*types.Receipt, error) {
+// ConfirmBatch confirms the batch onchain, retrying the failures to send the confirmation transaction. Once a
+// transaction was sent, it is returned without retrying, so that the caller keeps track of the transactions which may
+// land and replaces the last one when retrying.
+func (c *BatchConfirmer) ConfirmBatch(ctx context.Context, header *core.BatchHeader, quorums map[core.QuorumID]*core.QuorumResult, sigAgg *core.SignatureAggregation, replaced *types.Transaction) (*types.Receipt, *types.Transaction, error) {
 	var (
 		txReceipt *types.Receipt
+		txn       *types.Transaction
 		err       error
 	)
 	ctxWithTimeout, cancel := context.WithTimeout(ctx, c.timeout)
 	defer cancel()
 	for i := 0; i < maxRetries; i++ {
-		txReceipt, err = c.Transactor.ConfirmBatch(ctxWithTimeout, *header, quorums, *sigAgg)
-		if err == nil {
+		txReceipt, txn, err = c.Transactor.ConfirmBatch(ctxWithTimeout, *header, quorums, *sigAgg, replaced)
+		if err == nil || txn != nil {
 			break
 		}
 
 		if errors.Is(err, context.DeadlineExceeded) {
-			return nil, err
+			return nil, nil, err
 		}
 
 		if strings.Contains(err.Error(), "execution reverted") {
-			return nil, err
+			return nil, nil, err
 		}
 
 		retrySec := math.Pow(2, float64(i))
@@ -62,8 +66,8 @@ func (c *BatchConfirmer) ConfirmBatch(ctx context.Context, header *core.BatchHea
 	}
 
 	if err != nil {
-		return nil, err
+		return nil, txn, err
 	}
 
-	return txReceipt, nil
+	return txReceipt, txn, nil
 }
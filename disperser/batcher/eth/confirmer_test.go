@@ -18,11 +18,11 @@ func TestConfirmerRetry(t *testing.T) {
 	tx := coremock.MockTransactor{}
 	confirmer, err := eth.NewBatchConfirmer(&tx, 10*time.Second)
 	assert.Nil(t, err)
-	tx.On("ConfirmBatch").Return(nil, fmt.Errorf("no good")).Twice()
+	tx.On("ConfirmBatch").Return(nil, nil, fmt.Errorf("no good")).Twice()
 	tx.On("ConfirmBatch").Return(&types.Receipt{
 		TxHash: common.HexToHash("0x0000000000000000000000000000000000000000000000000000000000000000"),
-	}, nil).Once()
-	_, err = confirmer.ConfirmBatch(context.Background(), &core.BatchHeader{
+	}, nil, nil).Once()
+	_, _, err = confirmer.ConfirmBatch(context.Background(), &core.BatchHeader{
 		ReferenceBlockNumber: 100,
 		BatchRoot:            [32]byte{},
 	}, map[core.QuorumID]*core.QuorumResult{}, &core.SignatureAggregation{
@@ -30,7 +30,7 @@ func TestConfirmerRetry(t *testing.T) {
 		QuorumAggPubKeys: []*core.G1Point{},
 		AggPubKey:        nil,
 		AggSignature:     nil,
-	})
+	}, nil)
 	assert.Nil(t, err)
 	tx.AssertNumberOfCalls(t, "ConfirmBatch", 3)
 }
@@ -39,8 +39,8 @@ func TestConfirmerTimeout(t *testing.T) {
 	tx := coremock.MockTransactor{}
 	confirmer, err := eth.NewBatchConfirmer(&tx, 100*time.Millisecond)
 	assert.Nil(t, err)
-	tx.On("ConfirmBatch").Return(nil, fmt.Errorf("EnsureTransactionEvaled: failed to wait for transaction (%s) to mine: %w", "123", context.DeadlineExceeded)).Once()
-	_, err = confirmer.ConfirmBatch(context.Background(), &core.BatchHeader{
+	tx.On("ConfirmBatch").Return(nil, nil, fmt.Errorf("EnsureTransactionEvaled: failed to wait for transaction (%s) to mine: %w", "123", context.DeadlineExceeded)).Once()
+	_, _, err = confirmer.ConfirmBatch(context.Background(), &core.BatchHeader{
 		ReferenceBlockNumber: 100,
 		BatchRoot:            [32]byte{},
 	}, map[core.QuorumID]*core.QuorumResult{}, &core.SignatureAggregation{
@@ -48,7 +48,28 @@ func TestConfirmerTimeout(t *testing.T) {
 		QuorumAggPubKeys: []*core.G1Point{},
 		AggPubKey:        nil,
 		AggSignature:     nil,
-	})
+	}, nil)
 	assert.ErrorIs(t, err, context.DeadlineExceeded)
 	tx.AssertNumberOfCalls(t, "ConfirmBatch", 1)
 }
+
+func TestConfirmerReturnsSentTxn(t *testing.T) {
+	tx := coremock.MockTransactor{}
+	confirmer, err := eth.NewBatchConfirmer(&tx, 10*time.Second)
+	assert.Nil(t, err)
+	// Once the txn was sent, failing to wait for it isn't retried, so that the caller replaces the txn
+	sent := types.NewTx(&types.DynamicFeeTx{Nonce: 7})
+	tx.On("ConfirmBatch").Return(nil, sent, fmt.Errorf("no good")).Once()
+	_, txn, err := confirmer.ConfirmBatch(context.Background(), &core.BatchHeader{
+		ReferenceBlockNumber: 100,
+		BatchRoot:            [32]byte{},
+	}, map[core.QuorumID]*core.QuorumResult{}, &core.SignatureAggregation{
+		NonSigners:       []*core.G1Point{},
+		QuorumAggPubKeys: []*core.G1Point{},
+		AggPubKey:        nil,
+		AggSignature:     nil,
+	}, nil)
+	assert.Error(t, err)
+	assert.Equal(t, sent, txn)
+	tx.AssertNumberOfCalls(t, "ConfirmBatch", 1)
+}
@@ -0,0 +1,124 @@
+package batcher
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/disperser"
+)
+
+// accountShareWindow is the number of recent batches over which the share of each account is reported
+const accountShareWindow = 10
+
+// accountWeight returns the weight of the account in the selection of the blobs, 1 unless set in AccountWeights
+func (c StreamerConfig) accountWeight(accountID core.AccountID) uint64 {
+	if weight, ok := c.AccountWeights[accountID]; ok && weight > 0 {
+		return weight
+	}
+	return 1
+}
+
+// selectBlobs returns the blobs to encode among the pending ones, at most maxBlobs of them and, past the first one, at
+// most BatchSizeLimit bytes of them when it is set, skipping the blobs which don't fit. The blobs are selected by
+// weighted fair queuing across the accounts: the blobs of each account are tagged, in the order they were requested,
+// with the cumulative bytes of the account divided by its weight, and the blobs with the lowest tags are selected
+// first. Each account thus gets a share of the selection proportional to its weight, whatever the backlog of the other
+// accounts. The blobs waiting for longer than MaxBlobWait, when it is set, are selected before all the others, the
+// oldest first, so that the blobs of an account with a low weight are not starved.
+func (e *EncodingStreamer) selectBlobs(metadatas []*disperser.BlobMetadata, maxBlobs int, now time.Time) []*disperser.BlobMetadata {
+	type candidate struct {
+		metadata *disperser.BlobMetadata
+		overdue  bool
+		tag      float64
+	}
+
+	sorted := make([]*disperser.BlobMetadata, len(metadatas))
+	copy(sorted, metadatas)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].RequestMetadata.RequestedAt < sorted[j].RequestMetadata.RequestedAt
+	})
+
+	accountBytes := make(map[core.AccountID]uint64)
+	candidates := make([]candidate, len(sorted))
+	for i, metadata := range sorted {
+		accountID := metadata.RequestMetadata.AccountID
+		accountBytes[accountID] += uint64(metadata.RequestMetadata.BlobSize)
+		requestedAt := time.Unix(0, int64(metadata.RequestMetadata.RequestedAt))
+		candidates[i] = candidate{
+			metadata: metadata,
+			overdue:  e.MaxBlobWait > 0 && now.Sub(requestedAt) >= e.MaxBlobWait,
+			tag:      float64(accountBytes[accountID]) / float64(e.accountWeight(accountID)),
+		}
+	}
+	// The candidates are sorted by request time, which breaks the ties of the tags and orders the overdue blobs
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].overdue != candidates[j].overdue {
+			return candidates[i].overdue
+		}
+		if candidates[i].overdue {
+			return false
+		}
+		return candidates[i].tag < candidates[j].tag
+	})
+
+	selected := make([]*disperser.BlobMetadata, 0, maxBlobs)
+	selectedBytes := uint64(0)
+	for _, c := range candidates {
+		if len(selected) >= maxBlobs {
+			break
+		}
+		size := uint64(c.metadata.RequestMetadata.BlobSize)
+		// A blob exceeding the remaining budget is skipped, leaving room for the smaller blobs after it. It is still
+		// selected alone when it exceeds the budget on its own, so that it isn't held back forever.
+		if e.BatchSizeLimit > 0 && len(selected) > 0 && selectedBytes+size > e.BatchSizeLimit {
+			continue
+		}
+		selected = append(selected, c.metadata)
+		selectedBytes += size
+	}
+	return selected
+}
+
+// accountShares tracks the bytes of each account in the recent batches
+type accountShares struct {
+	mu sync.Mutex
+
+	// batches are the bytes of each account in the last accountShareWindow batches, the oldest first
+	batches []map[core.AccountID]uint64
+}
+
+// add records the blobs of a batch, and returns the share of each account of the bytes of the recent batches as a
+// percentage
+func (s *accountShares) add(metadatas []*disperser.BlobMetadata) map[core.AccountID]float64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	batch := make(map[core.AccountID]uint64)
+	for _, metadata := range metadatas {
+		batch[metadata.RequestMetadata.AccountID] += uint64(metadata.RequestMetadata.BlobSize)
+	}
+	s.batches = append(s.batches, batch)
+	if len(s.batches) > accountShareWindow {
+		s.batches = s.batches[len(s.batches)-accountShareWindow:]
+	}
+
+	total := uint64(0)
+	bytes := make(map[core.AccountID]uint64)
+	for _, batch := range s.batches {
+		for accountID, size := range batch {
+			bytes[accountID] += size
+			total += size
+		}
+	}
+	shares := make(map[core.AccountID]float64, len(bytes))
+	for accountID, size := range bytes {
+		if total == 0 {
+			shares[accountID] = 0
+			continue
+		}
+		shares[accountID] = float64(size) * 100 / float64(total)
+	}
+	return shares
+}
@@ -0,0 +1,174 @@
+package batcher_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/disperser"
+	bat "github.com/Layr-Labs/eigenda/disperser/batcher"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// queueAccountBlob stores a blob of the account requested at the given time
+func queueAccountBlob(t *testing.T, ctx context.Context, components *batcherComponents, accountID core.AccountID, requestedAt time.Time) disperser.BlobKey {
+	return queueAccountBlobData(t, ctx, components, accountID, gettysburgAddressBytes, requestedAt)
+}
+
+// queueAccountBlobData stores a blob of the account with the given data requested at the given time
+func queueAccountBlobData(t *testing.T, ctx context.Context, components *batcherComponents, accountID core.AccountID, data []byte, requestedAt time.Time) disperser.BlobKey {
+	blob := makeTestBlob([]*core.SecurityParam{{
+		QuorumID:           0,
+		AdversaryThreshold: 80,
+		QuorumThreshold:    100,
+	}})
+	blob.RequestHeader.AccountID = accountID
+	blob.Data = data
+	blobKey, err := components.blobStore.StoreBlob(ctx, &blob, uint64(requestedAt.UnixNano()))
+	assert.NoError(t, err)
+	return blobKey
+}
+
+func countConfirmed(t *testing.T, ctx context.Context, components *batcherComponents, blobKeys []disperser.BlobKey) int {
+	confirmed := 0
+	for _, blobKey := range blobKeys {
+		metadata, err := components.blobStore.GetBlobMetadata(ctx, blobKey)
+		assert.NoError(t, err)
+		if metadata.BlobStatus == disperser.Confirmed {
+			confirmed++
+		}
+	}
+	return confirmed
+}
+
+func TestFairBlobSelection(t *testing.T) {
+	components, batcher := makeBatcher(t)
+	components.confirmer.On("ConfirmBatch", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(makeConfirmationReceipt(t, 123), nil, nil)
+	components.encodingStreamer.EncodingQueueLimit = 4
+	ctx := context.Background()
+
+	// The heavy account queued a backlog of blobs before the light accounts queued theirs
+	now := time.Now()
+	heavy := make([]disperser.BlobKey, 12)
+	for i := range heavy {
+		heavy[i] = queueAccountBlob(t, ctx, components, "heavy", now.Add(-time.Duration(len(heavy)-i)*time.Minute))
+	}
+	light := make([]disperser.BlobKey, 0, 4)
+	for _, accountID := range []core.AccountID{"light1", "light2"} {
+		for i := 0; i < 2; i++ {
+			light = append(light, queueAccountBlob(t, ctx, components, accountID, now.Add(-time.Duration(2-i)*time.Second)))
+		}
+	}
+
+	// Each batch is shared between the accounts, so that the light accounts don't wait for the backlog of the heavy
+	// account, which would take 3 batches
+	for i := 0; i < 2; i++ {
+		encodeQueuedBlobs(t, ctx, components.encodingStreamer, 4)
+		assert.NoError(t, batcher.HandleSingleBatch(ctx))
+		assert.Equal(t, 2*(i+1), countConfirmed(t, ctx, components, light))
+		assert.Equal(t, 2*(i+1), countConfirmed(t, ctx, components, heavy))
+	}
+	assert.Equal(t, float64(50), testutil.ToFloat64(batcher.Metrics.AccountBatchShare.WithLabelValues("heavy")))
+	assert.Equal(t, float64(25), testutil.ToFloat64(batcher.Metrics.AccountBatchShare.WithLabelValues("light1")))
+	assert.Equal(t, float64(25), testutil.ToFloat64(batcher.Metrics.AccountBatchShare.WithLabelValues("light2")))
+
+	// The heavy account gets the batches to itself once the light accounts have no pending blobs
+	encodeQueuedBlobs(t, ctx, components.encodingStreamer, 4)
+	assert.NoError(t, batcher.HandleSingleBatch(ctx))
+	assert.Equal(t, 8, countConfirmed(t, ctx, components, heavy))
+	assert.InDelta(t, float64(8)*100/12, testutil.ToFloat64(batcher.Metrics.AccountBatchShare.WithLabelValues("heavy")), 1e-9)
+}
+
+func TestWeightedBlobSelection(t *testing.T) {
+	components, batcher := makeBatcher(t)
+	components.confirmer.On("ConfirmBatch", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(makeConfirmationReceipt(t, 123), nil, nil)
+	components.encodingStreamer.EncodingQueueLimit = 4
+	components.encodingStreamer.AccountWeights = map[core.AccountID]uint64{"priority": 3}
+	ctx := context.Background()
+
+	now := time.Now()
+	priority := make([]disperser.BlobKey, 0, 4)
+	other := make([]disperser.BlobKey, 0, 4)
+	for i := 0; i < 4; i++ {
+		other = append(other, queueAccountBlob(t, ctx, components, "standard", now.Add(-time.Duration(8-i)*time.Minute)))
+		priority = append(priority, queueAccountBlob(t, ctx, components, "priority", now.Add(-time.Duration(4-i)*time.Minute)))
+	}
+
+	// The priority account gets 3 times the share of the other account, although its blobs were requested later
+	encodeQueuedBlobs(t, ctx, components.encodingStreamer, 4)
+	assert.NoError(t, batcher.HandleSingleBatch(ctx))
+	assert.Equal(t, 3, countConfirmed(t, ctx, components, priority))
+	assert.Equal(t, 1, countConfirmed(t, ctx, components, other))
+	assert.Equal(t, float64(75), testutil.ToFloat64(batcher.Metrics.AccountBatchShare.WithLabelValues("priority")))
+
+	// The accounts which are not allowlisted are reported together
+	assert.Equal(t, float64(25), testutil.ToFloat64(batcher.Metrics.AccountBatchShare.WithLabelValues(bat.OtherAccount)))
+	assert.Equal(t, 2, testutil.CollectAndCount(batcher.Metrics.AccountBatchShare))
+}
+
+func TestBlobSelectionMaxWait(t *testing.T) {
+	components, batcher := makeBatcher(t)
+	components.confirmer.On("ConfirmBatch", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(makeConfirmationReceipt(t, 123), nil, nil)
+	components.encodingStreamer.EncodingQueueLimit = 2
+	components.encodingStreamer.AccountWeights = map[core.AccountID]uint64{"priority": 100}
+	ctx := context.Background()
+
+	now := time.Now()
+	priority := make([]disperser.BlobKey, 0, 4)
+	for i := 0; i < 4; i++ {
+		priority = append(priority, queueAccountBlob(t, ctx, components, "priority", now.Add(-time.Duration(4-i)*time.Second)))
+	}
+	waiting := queueAccountBlob(t, ctx, components, "other", now.Add(-10*time.Minute))
+
+	// The blob of the other account is starved by the weight of the priority account
+	encodeQueuedBlobs(t, ctx, components.encodingStreamer, 2)
+	assert.NoError(t, batcher.HandleSingleBatch(ctx))
+	assert.Equal(t, 2, countConfirmed(t, ctx, components, priority))
+	assert.Equal(t, 0, countConfirmed(t, ctx, components, []disperser.BlobKey{waiting}))
+
+	// It is selected first once it waited for longer than the max wait
+	components.encodingStreamer.MaxBlobWait = 5 * time.Minute
+	encodeQueuedBlobs(t, ctx, components.encodingStreamer, 2)
+	assert.NoError(t, batcher.HandleSingleBatch(ctx))
+	assert.Equal(t, 3, countConfirmed(t, ctx, components, priority))
+	assert.Equal(t, 1, countConfirmed(t, ctx, components, []disperser.BlobKey{waiting}))
+}
+
+func TestBlobSelectionSizeLimit(t *testing.T) {
+	components, batcher := makeBatcher(t)
+	components.confirmer.On("ConfirmBatch", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(makeConfirmationReceipt(t, 123), nil, nil)
+	blobSize := uint64(len(gettysburgAddressBytes))
+	components.encodingStreamer.BatchSizeLimit = 2*blobSize + blobSize/2
+	ctx := context.Background()
+
+	now := time.Now()
+	blobKeys := make([]disperser.BlobKey, 0, 3)
+	for i := 0; i < 3; i++ {
+		blobKeys = append(blobKeys, queueAccountBlob(t, ctx, components, "account", now.Add(-time.Duration(3-i)*time.Minute)))
+	}
+
+	// Only the blobs fitting in the size limit are selected
+	encodeQueuedBlobs(t, ctx, components.encodingStreamer, 2)
+	assert.NoError(t, batcher.HandleSingleBatch(ctx))
+	assert.Equal(t, 2, countConfirmed(t, ctx, components, blobKeys))
+
+	// A blob exceeding the remaining size is skipped for the smaller blobs after it
+	components.encodingStreamer.BatchSizeLimit = 2*blobSize + blobSize/2
+	large := queueAccountBlobData(t, ctx, components, "account", bytes.Repeat(gettysburgAddressBytes, 2), now.Add(-30*time.Second))
+	small := queueAccountBlob(t, ctx, components, "account", now.Add(-20*time.Second))
+	encodeQueuedBlobs(t, ctx, components.encodingStreamer, 2)
+	assert.NoError(t, batcher.HandleSingleBatch(ctx))
+	assert.Equal(t, 3, countConfirmed(t, ctx, components, blobKeys))
+	assert.Equal(t, 0, countConfirmed(t, ctx, components, []disperser.BlobKey{large}))
+	assert.Equal(t, 1, countConfirmed(t, ctx, components, []disperser.BlobKey{small}))
+
+	// A blob is selected on its own even if it exceeds the size limit
+	components.encodingStreamer.BatchSizeLimit = blobSize / 2
+	encodeQueuedBlobs(t, ctx, components.encodingStreamer, 1)
+	assert.NoError(t, batcher.HandleSingleBatch(ctx))
+	assert.Equal(t, 1, countConfirmed(t, ctx, components, []disperser.BlobKey{large}))
+}
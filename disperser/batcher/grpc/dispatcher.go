@@ -2,19 +2,40 @@ package dispatcher
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"fmt"
 	"time"
 
 	"github.com/Layr-Labs/eigenda/api/grpc/node"
 	"github.com/Layr-Labs/eigenda/common"
+	commongrpc "github.com/Layr-Labs/eigenda/common/grpc"
 	"github.com/Layr-Labs/eigenda/core"
 	"github.com/Layr-Labs/eigenda/disperser"
+	"github.com/ethereum/go-ethereum/crypto"
 
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
 )
 
 type Config struct {
+	// Timeout is the time the operators have to reply to a batch, after which their signatures are no longer
+	// aggregated. It sets the dispersal deadline of the batches, past which the nodes neither store nor sign them.
 	Timeout time.Duration
+	// SigningKey is the key with which the batch headers of the StoreChunks requests are signed, so that the nodes can
+	// authenticate the disperser. The requests are not signed if it is nil.
+	SigningKey *ecdsa.PrivateKey
+	// SocketIndexer resolves the latest sockets of the operators, which are dialed instead of the sockets of the
+	// operator state of the batch. The sockets of the operator state are dialed if it is nil.
+	SocketIndexer *core.OperatorSocketIndexer
+	// MaxMessageSize is the max size in bytes of the StoreChunks request sent to each operator. It must not exceed the
+	// max message size of the nodes.
+	MaxMessageSize int
+	// Credentials are the transport credentials with which the operators are dialed, which are dialed without TLS if
+	// they are nil.
+	Credentials credentials.TransportCredentials
 }
 
 type dispatcher struct {
@@ -24,6 +45,9 @@ type dispatcher struct {
 }
 
 func NewDispatcher(cfg *Config, logger common.Logger) *dispatcher {
+	if cfg.Credentials == nil {
+		cfg.Credentials = insecure.NewCredentials()
+	}
 	return &dispatcher{
 		Config: cfg,
 		logger: logger,
@@ -36,12 +60,13 @@ func (c *dispatcher) DisperseBatch(ctx context.Context, state *core.IndexedOpera
 	update := make(chan core.SignerMessage, len(state.IndexedOperators))
 
 	// Disperse
-	c.sendAllChunks(ctx, state, blobs, header, update)
+	deadline := time.Now().Add(c.Timeout)
+	c.sendAllChunks(ctx, state, blobs, header, deadline, update)
 
 	return update
 }
 
-func (c *dispatcher) sendAllChunks(ctx context.Context, state *core.IndexedOperatorState, blobs []core.EncodedBlob, header *core.BatchHeader, update chan core.SignerMessage) {
+func (c *dispatcher) sendAllChunks(ctx context.Context, state *core.IndexedOperatorState, blobs []core.EncodedBlob, header *core.BatchHeader, deadline time.Time, update chan core.SignerMessage) {
 	for id, op := range state.IndexedOperators {
 		go func(op core.IndexedOperatorInfo, id core.OperatorID) {
 			blobMessages := make([]*core.BlobMessage, len(blobs))
@@ -49,7 +74,7 @@ func (c *dispatcher) sendAllChunks(ctx context.Context, state *core.IndexedOpera
 				blobMessages[i] = blob[id]
 			}
 
-			sig, err := c.sendChunks(ctx, blobMessages, header, &op)
+			sig, version, err := c.sendChunks(ctx, blobMessages, header, deadline, &op)
 			if err != nil {
 				update <- core.SignerMessage{
 					Err:       err,
@@ -60,6 +85,7 @@ func (c *dispatcher) sendAllChunks(ctx context.Context, state *core.IndexedOpera
 				update <- core.SignerMessage{
 					Signature: sig,
 					Operator:  id,
+					Version:   version,
 					Err:       nil,
 				}
 			}
@@ -67,44 +93,53 @@ func (c *dispatcher) sendAllChunks(ctx context.Context, state *core.IndexedOpera
 		}(core.IndexedOperatorInfo{
 			PubkeyG1: op.PubkeyG1,
 			PubkeyG2: op.PubkeyG2,
-			Socket:   op.Socket,
+			Socket:   c.SocketIndexer.ResolveOperatorSocket(id, op.Socket),
 		}, id)
 	}
 }
 
-func (c *dispatcher) sendChunks(ctx context.Context, blobs []*core.BlobMessage, header *core.BatchHeader, op *core.IndexedOperatorInfo) (*core.Signature, error) {
-	// TODO Add secure Grpc
-
+func (c *dispatcher) sendChunks(ctx context.Context, blobs []*core.BlobMessage, header *core.BatchHeader, deadline time.Time, op *core.IndexedOperatorInfo) (*core.Signature, core.NodeVersion, error) {
 	conn, err := grpc.Dial(
 		core.OperatorSocket(op.Socket).GetDispersalSocket(),
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithTransportCredentials(c.Credentials),
+		commongrpc.MaxMessageSizeDialOption(c.MaxMessageSize),
 	)
 	if err != nil {
 		c.logger.Error("Disperser cannot connect to operator dispersal socket", "dispersal_socket", core.OperatorSocket(op.Socket).GetDispersalSocket(), "err", err)
-		return nil, err
+		return nil, core.NodeVersion{}, err
 	}
 	defer conn.Close()
 
 	gc := node.NewDispersalClient(conn)
-	ctx, cancel := context.WithTimeout(ctx, c.Timeout)
+	ctx, cancel := context.WithDeadline(ctx, deadline)
 	defer cancel()
 
 	request, totalSize, err := GetStoreChunksRequest(blobs, header)
 	if err != nil {
-		return nil, err
+		return nil, core.NodeVersion{}, err
+	}
+	request.DispersalDeadline = uint64(deadline.UnixMilli())
+	if c.SigningKey != nil {
+		request.DisperserSignature, err = SignBatchHeader(header, c.SigningKey)
+		if err != nil {
+			return nil, core.NodeVersion{}, err
+		}
 	}
 
-	opt := grpc.MaxCallSendMsgSize(1024 * 1024 * 1024)
 	c.logger.Debug("sending chunks to operator", "operator", op.Socket, "size", totalSize)
-	reply, err := gc.StoreChunks(ctx, request, opt)
+	reply, err := gc.StoreChunks(ctx, request)
 
+	if isBatchExpired(err) {
+		return nil, core.NodeVersion{}, fmt.Errorf("%w: %v", core.ErrBatchExpired, err)
+	}
 	if err != nil {
-		return nil, err
+		return nil, core.NodeVersion{}, err
 	}
 
 	sigBytes := reply.GetSignature()
 	sig := &core.Signature{G1Point: new(core.Signature).Deserialize(sigBytes)}
-	return sig, nil
+	version := core.NodeVersion{Version: reply.GetVersion(), Features: core.NodeFeatures(reply.GetFeatures())}
+	return sig, version, nil
 }
 
 func GetStoreChunksRequest(blobMessages []*core.BlobMessage, header *core.BatchHeader) (*node.StoreChunksRequest, int64, error) {
@@ -127,6 +162,34 @@ func GetStoreChunksRequest(blobMessages []*core.BlobMessage, header *core.BatchH
 	return request, totalSize, nil
 }
 
+// isBatchExpired returns whether err is the error with which a node refuses a batch received after its dispersal deadline
+func isBatchExpired(err error) bool {
+	st, ok := status.FromError(err)
+	if !ok || err == nil {
+		return false
+	}
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok && info.GetReason() == core.BatchExpiredReason {
+			return true
+		}
+	}
+	return false
+}
+
+// SignBatchHeader signs the hash of the batch header with the key of the disperser, as the DisperserSignature of a
+// StoreChunks request
+func SignBatchHeader(header *core.BatchHeader, key *ecdsa.PrivateKey) ([]byte, error) {
+	batchHeaderHash, err := header.GetBatchHeaderHash()
+	if err != nil {
+		return nil, err
+	}
+	signature, err := crypto.Sign(batchHeaderHash[:], key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign the batch header: %w", err)
+	}
+	return signature, nil
+}
+
 func getBlobMessage(blob *core.BlobMessage) (*node.Blob, error) {
 	commitData, err := blob.BlobHeader.Commitment.Serialize()
 	if err != nil {
@@ -142,12 +205,13 @@ func getBlobMessage(blob *core.BlobMessage) (*node.Blob, error) {
 
 	for i, header := range blob.BlobHeader.QuorumInfos {
 		quorumHeaders[i] = &node.BlobQuorumInfo{
-			QuorumId:           uint32(header.QuorumID),
-			AdversaryThreshold: uint32(header.AdversaryThreshold),
-			QuantizationFactor: uint32(header.QuantizationFactor),
-			EncodedBlobLength:  uint32(header.EncodedBlobLength),
-			QuorumThreshold:    uint32(header.QuorumThreshold),
-			Ratelimit:          header.QuorumRate,
+			QuorumId:             uint32(header.QuorumID),
+			AdversaryThreshold:   uint32(header.AdversaryThreshold),
+			QuantizationFactor:   uint32(header.QuantizationFactor),
+			EncodedBlobLength:    uint32(header.EncodedBlobLength),
+			QuorumThreshold:      uint32(header.QuorumThreshold),
+			Ratelimit:            header.QuorumRate,
+			OverprovisionPercent: uint32(header.OverprovisionPercent),
 		}
 	}
 
@@ -2,11 +2,15 @@ package batcher
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"math/big"
 	"net/http"
 
 	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/core"
 	"github.com/Layr-Labs/eigenda/disperser"
+	"github.com/ethereum/go-ethereum/params"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/collectors"
 	"github.com/prometheus/client_golang/prometheus/promauto"
@@ -16,8 +20,14 @@ import (
 type MetricsConfig struct {
 	HTTPPort      string
 	EnableMetrics bool
+	// AccountAllowlist contains the accounts that are reported as their own metric label. The other accounts are
+	// reported together under OtherAccount, which keeps the cardinality of the metrics bounded.
+	AccountAllowlist []string
 }
 
+// OtherAccount is the account label of the accounts which are not in the allowlist
+const OtherAccount = "other"
+
 type EncodingStreamerMetrics struct {
 	EncodedBlobs *prometheus.GaugeVec
 }
@@ -32,15 +42,45 @@ type Metrics struct {
 	BatchProcLatency *prometheus.SummaryVec
 	GasUsed          prometheus.Gauge
 	Attestation      *prometheus.GaugeVec
+	// BatchGasUsed and BatchCost are the cumulative gas used and cost in gwei of the confirmation transactions
+	BatchGasUsed *prometheus.CounterVec
+	BatchCost    *prometheus.CounterVec
+	// StuckBlobs is the number of blobs processing beyond the SLA found by the last check of the watchdog
+	StuckBlobs prometheus.Gauge
+	// RedrivenBlobs is the number of stuck blobs re-driven by the watchdog, by whether they were retried or failed
+	RedrivenBlobs *prometheus.CounterVec
+	// NodeVersions is the number of operators which replied to the last batch, by advertised version
+	NodeVersions *prometheus.GaugeVec
+	// FeatureStake is the percentage of the stake of each quorum advertising each optional feature in the last batch
+	FeatureStake *prometheus.GaugeVec
+	// FallbackReads counts the objects read from the replicas of the bucket in other regions, by region
+	FallbackReads *prometheus.CounterVec
+	// TransactorBreaker is 1 for the current state of the circuit breaker of the chain calls, and 0 for the others
+	TransactorBreaker *prometheus.GaugeVec
+	// StatusEventsDropped counts the blob status events which weren't published, by reason
+	StatusEventsDropped *prometheus.CounterVec
+	// OperatorFailures counts the operators which didn't sign a batch, by whether they refused it past its dispersal
+	// deadline (soft) or failed to store or sign it (hard)
+	OperatorFailures *prometheus.CounterVec
+	// AccountBatchShare is the percentage of the bytes of the recent batches from each allowlisted account, and from
+	// the other accounts together
+	AccountBatchShare *prometheus.GaugeVec
 
-	httpPort string
-	logger   common.Logger
+	accounts   map[core.AccountID]struct{}
+	httpPort   string
+	httpServer *http.Server
+	logger     common.Logger
 }
 
-func NewMetrics(httpPort string, logger common.Logger) *Metrics {
+func NewMetrics(httpPort string, accountAllowlist []string, logger common.Logger) *Metrics {
 	namespace := "eigenda_batcher"
 	reg := prometheus.NewRegistry()
 	reg.MustRegister(collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}))
+
+	accounts := make(map[core.AccountID]struct{}, len(accountAllowlist))
+	for _, accountID := range accountAllowlist {
+		accounts[accountID] = struct{}{}
+	}
 	reg.MustRegister(collectors.NewGoCollector())
 
 	encodingStreamerMetrics := EncodingStreamerMetrics{
@@ -88,6 +128,93 @@ func NewMetrics(httpPort string, logger common.Logger) *Metrics {
 				Help:      "gas used for onchain batch confirmation",
 			},
 		),
+		BatchGasUsed: promauto.With(reg).NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "batch_gas_used_total",
+				Help:      "total gas used for onchain batch confirmation, by quorum set of the batches",
+			},
+			[]string{"quorums"},
+		),
+		BatchCost: promauto.With(reg).NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "batch_cost_gwei_total",
+				Help:      "total cost in gwei of onchain batch confirmation, by quorum set of the batches",
+			},
+			[]string{"quorums"},
+		),
+		StuckBlobs: promauto.With(reg).NewGauge(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "stuck_blobs",
+				Help:      "number of blobs processing beyond the SLA",
+			},
+		),
+		RedrivenBlobs: promauto.With(reg).NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "redriven_blobs_total",
+				Help:      "number of stuck blobs re-driven by the watchdog, by whether they were retried or failed",
+			},
+			[]string{"state"},
+		),
+		NodeVersions: promauto.With(reg).NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "node_versions",
+				Help:      "number of operators which replied to the last batch, by advertised version",
+			},
+			[]string{"version"},
+		),
+		FeatureStake: promauto.With(reg).NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "feature_stake_percentage",
+				Help:      "percentage of the stake of the quorum advertising the optional feature in the last batch",
+			},
+			[]string{"feature", "quorum"},
+		),
+		FallbackReads: promauto.With(reg).NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "s3_fallback_reads_total",
+				Help:      "number of objects read from the replicas of the bucket in other regions",
+			},
+			[]string{"region"},
+		),
+		StatusEventsDropped: promauto.With(reg).NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "status_events_dropped_total",
+				Help:      "number of blob status events dropped instead of being published",
+			},
+			[]string{"reason"},
+		),
+		OperatorFailures: promauto.With(reg).NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "operator_failures_total",
+				Help:      "number of operators which didn't sign a batch, by whether the batch expired before reaching them (soft) or they failed (hard)",
+			},
+			[]string{"type"},
+		),
+		AccountBatchShare: promauto.With(reg).NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "account_batch_share_percentage",
+				Help:      "percentage of the bytes of the recent batches from the account, or from the accounts which are not allowlisted",
+			},
+			[]string{"account"},
+		),
+		TransactorBreaker: promauto.With(reg).NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "transactor_breaker_state",
+				Help:      "state of the circuit breaker of the chain calls, 1 for the current state",
+			},
+			[]string{"state"},
+		),
 		Attestation: promauto.With(reg).NewGaugeVec(
 			prometheus.GaugeOpts{
 				Namespace: namespace,
@@ -96,6 +223,7 @@ func NewMetrics(httpPort string, logger common.Logger) *Metrics {
 			},
 			[]string{"type"},
 		),
+		accounts: accounts,
 		registry: reg,
 		httpPort: httpPort,
 		logger:   logger,
@@ -108,6 +236,61 @@ func (g *Metrics) UpdateAttestation(operatorCount, nonSignerCount int) {
 	g.Attestation.WithLabelValues("non_signers").Set(float64(nonSignerCount))
 }
 
+// UpdateNodeVersions replaces the census of the versions of the operators with the number of operators by version
+func (g *Metrics) UpdateNodeVersions(counts map[string]int) {
+	g.NodeVersions.Reset()
+	for version, count := range counts {
+		g.NodeVersions.WithLabelValues(version).Set(float64(count))
+	}
+}
+
+func (g *Metrics) UpdateFeatureStake(feature core.NodeFeatures, quorumID core.QuorumID, percentage uint8) {
+	g.FeatureStake.WithLabelValues(feature.String(), fmt.Sprintf("%d", quorumID)).Set(float64(percentage))
+}
+
+// IncrementFallbackReads increments the number of objects read from the replica of the bucket in the region
+func (g *Metrics) IncrementFallbackReads(region string) {
+	g.FallbackReads.WithLabelValues(region).Inc()
+}
+
+// IncrementStatusEventsDropped adds the number of blob status events dropped for the reason
+func (g *Metrics) IncrementStatusEventsDropped(reason string, count int) {
+	g.StatusEventsDropped.WithLabelValues(reason).Add(float64(count))
+}
+
+// IncrementOperatorFailures increments the number of operators which didn't sign a batch with the failure type
+func (g *Metrics) IncrementOperatorFailures(failureType string) {
+	g.OperatorFailures.WithLabelValues(failureType).Inc()
+}
+
+// UpdateAccountBatchShares replaces the shares of the accounts of the bytes of the recent batches, as percentages. The
+// shares of the accounts which are not allowlisted are summed under OtherAccount.
+func (g *Metrics) UpdateAccountBatchShares(shares map[core.AccountID]float64) {
+	labels := make(map[string]float64, len(shares))
+	for accountID, share := range shares {
+		labels[g.accountLabel(accountID)] += share
+	}
+	g.AccountBatchShare.Reset()
+	for label, share := range labels {
+		g.AccountBatchShare.WithLabelValues(label).Set(share)
+	}
+}
+
+// accountLabel returns the label reporting the account: the account itself if it is allowlisted, and OtherAccount
+// otherwise
+func (g *Metrics) accountLabel(accountID core.AccountID) string {
+	if _, ok := g.accounts[accountID]; ok {
+		return accountID
+	}
+	return OtherAccount
+}
+
+// UpdateTransactorBreakerState reports the current state of the circuit breaker of the chain calls
+func (g *Metrics) UpdateTransactorBreakerState(state string) {
+	g.TransactorBreaker.Reset()
+	g.TransactorBreaker.WithLabelValues(state).Set(1)
+}
+
 // UpdateCompletedBlob increments the number and updates size of processed blobs.
 func (g *Metrics) UpdateCompletedBlob(size int, status disperser.BlobStatus) {
 	switch status {
@@ -133,6 +316,13 @@ func (g *Metrics) IncrementBatchCount(size int64) {
 	g.Batch.WithLabelValues("size").Add(float64(size))
 }
 
+// AddBatchCost adds the gas used and the cost in wei of the confirmation of a batch to the totals of its quorum set
+func (g *Metrics) AddBatchCost(quorums string, gasUsed uint64, cost *big.Int) {
+	costGwei, _ := new(big.Float).Quo(new(big.Float).SetInt(cost), big.NewFloat(params.GWei)).Float64()
+	g.BatchGasUsed.WithLabelValues(quorums).Add(float64(gasUsed))
+	g.BatchCost.WithLabelValues(quorums).Add(costGwei)
+}
+
 func (g *Metrics) ObserveLatency(stage string, latencyMs float64) {
 	g.BatchProcLatency.WithLabelValues(stage).Observe(latencyMs)
 }
@@ -140,18 +330,28 @@ func (g *Metrics) ObserveLatency(stage string, latencyMs float64) {
 func (g *Metrics) Start(ctx context.Context) {
 	g.logger.Info("starting metrics server at ", "port", g.httpPort)
 	addr := fmt.Sprintf(":%s", g.httpPort)
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(
+		g.registry,
+		promhttp.HandlerOpts{},
+	))
+	g.httpServer = &http.Server{Addr: addr, Handler: mux}
 	go func() {
-		log := g.logger
-		mux := http.NewServeMux()
-		mux.Handle("/metrics", promhttp.HandlerFor(
-			g.registry,
-			promhttp.HandlerOpts{},
-		))
-		err := http.ListenAndServe(addr, mux)
-		log.Error("prometheus server failed", "err", err)
+		err := g.httpServer.ListenAndServe()
+		if !errors.Is(err, http.ErrServerClosed) {
+			g.logger.Error("prometheus server failed", "err", err)
+		}
 	}()
 }
 
+// Stop stops the metrics server started by Start
+func (g *Metrics) Stop(ctx context.Context) error {
+	if g.httpServer == nil {
+		return nil
+	}
+	return g.httpServer.Shutdown(ctx)
+}
+
 func (e *EncodingStreamerMetrics) UpdateEncodedBlobs(count int, size uint64) {
 	e.EncodedBlobs.WithLabelValues("size").Set(float64(size))
 	e.EncodedBlobs.WithLabelValues("number").Set(float64(count))
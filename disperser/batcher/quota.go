@@ -0,0 +1,112 @@
+package batcher
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/disperser"
+)
+
+// SetAccountUsageStore enables the daily quotas of the accounts: the bytes confirmed for each account are recorded in
+// the store, and the blobs of the accounts over their quota are not batched
+func (b *Batcher) SetAccountUsageStore(store disperser.AccountUsageStore) {
+	b.AccountUsage = store
+	b.EncodingStreamer.accountUsage = store
+}
+
+// recordConfirmedBytes adds the bytes of the confirmed blobs to the usage of their accounts
+func (b *Batcher) recordConfirmedBytes(ctx context.Context, confirmed []*disperser.BlobMetadata) {
+	if b.AccountUsage == nil {
+		return
+	}
+	confirmedBytes := make(map[core.AccountID]uint64)
+	for _, metadata := range confirmed {
+		if metadata.RequestMetadata.AccountID == "" {
+			continue
+		}
+		confirmedBytes[metadata.RequestMetadata.AccountID] += uint64(metadata.RequestMetadata.BlobSize)
+	}
+	now := time.Now()
+	for accountID, bytes := range confirmedBytes {
+		if err := b.AccountUsage.AddConfirmedBytes(ctx, accountID, now, bytes); err != nil {
+			b.logger.Error("failed to record the confirmed bytes of the account", "accountID", accountID, "bytes", bytes, "err", err)
+		}
+	}
+}
+
+// dailyQuota returns the number of bytes of the account which can be confirmed per UTC day, 0 if it has no quota
+func (c StreamerConfig) dailyQuota(accountID core.AccountID) uint64 {
+	if quota, ok := c.AccountDailyQuotas[accountID]; ok {
+		return quota
+	}
+	return c.DailyQuota
+}
+
+// enforceDailyQuotas returns the processing blobs which fit in the daily quotas of their accounts, in order. The blobs
+// of each account are admitted in the order they were requested, on top of the bytes already confirmed for the account
+// on the day, until one exceeds the quota: it is excluded along with all the later blobs of the account, so that the
+// blobs of an account are confirmed in order. The blobs are counted while they are processing, so that a blob
+// admitted in a previous iteration is still counted until it is confirmed. The excluded blobs are failed with the
+// QuotaExceeded status once they waited for the grace period.
+func (e *EncodingStreamer) enforceDailyQuotas(ctx context.Context, metadatas []*disperser.BlobMetadata, now time.Time) []*disperser.BlobMetadata {
+	byAccount := make(map[core.AccountID][]*disperser.BlobMetadata)
+	for _, metadata := range metadatas {
+		accountID := metadata.RequestMetadata.AccountID
+		if e.dailyQuota(accountID) > 0 {
+			byAccount[accountID] = append(byAccount[accountID], metadata)
+		}
+	}
+	if len(byAccount) == 0 {
+		return metadatas
+	}
+
+	excluded := make(map[disperser.BlobKey]struct{})
+	for accountID, blobs := range byAccount {
+		used, err := e.accountUsage.GetConfirmedBytes(ctx, accountID, now)
+		if err != nil {
+			// The quota isn't enforced rather than holding back all the blobs of the account
+			e.logger.Error("[enforceDailyQuotas] failed to get the confirmed bytes of the account", "accountID", accountID, "err", err)
+			continue
+		}
+		quota := e.dailyQuota(accountID)
+		sort.SliceStable(blobs, func(i, j int) bool {
+			return blobs[i].RequestMetadata.RequestedAt < blobs[j].RequestMetadata.RequestedAt
+		})
+		overQuota := false
+		for _, metadata := range blobs {
+			used += uint64(metadata.RequestMetadata.BlobSize)
+			if !overQuota && used <= quota {
+				continue
+			}
+			overQuota = true
+			excluded[metadata.GetBlobKey()] = struct{}{}
+			// Drop the encodings of the blob, if it was admitted before, so that it isn't batched. A blob awaiting the
+			// confirmation of its batch is left to be confirmed.
+			if !e.ReleaseBlob(metadata) {
+				continue
+			}
+
+			requestedAt := time.Unix(0, int64(metadata.RequestMetadata.RequestedAt))
+			if now.Sub(requestedAt) < e.QuotaGracePeriod {
+				continue
+			}
+			e.logger.Warn("[enforceDailyQuotas] failing blob of an account over its daily quota", "blobKey", metadata.GetBlobKey().String(), "accountID", accountID, "quota", quota)
+			if err := e.blobStore.MarkBlobQuotaExceeded(ctx, metadata.GetBlobKey()); err != nil {
+				e.logger.Error("[enforceDailyQuotas] error marking blob quota exceeded", "blobKey", metadata.GetBlobKey().String(), "err", err)
+			}
+		}
+	}
+	if len(excluded) == 0 {
+		return metadatas
+	}
+
+	admitted := make([]*disperser.BlobMetadata, 0, len(metadatas)-len(excluded))
+	for _, metadata := range metadatas {
+		if _, ok := excluded[metadata.GetBlobKey()]; !ok {
+			admitted = append(admitted, metadata)
+		}
+	}
+	return admitted
+}
@@ -0,0 +1,91 @@
+package batcher_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/disperser"
+	bat "github.com/Layr-Labs/eigenda/disperser/batcher"
+	"github.com/Layr-Labs/eigenda/disperser/common/inmem"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestDailyQuota(t *testing.T) {
+	components, batcher := makeBatcher(t)
+	components.confirmer.On("ConfirmBatch", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(makeConfirmationReceipt(t, 123), nil, nil)
+	usage := inmem.NewAccountUsageStore()
+	batcher.SetAccountUsageStore(usage)
+	blobSize := uint64(len(gettysburgAddressBytes))
+	components.encodingStreamer.AccountDailyQuotas = map[core.AccountID]uint64{"capped": 3*blobSize + blobSize/2}
+	components.encodingStreamer.QuotaGracePeriod = time.Hour
+	ctx := context.Background()
+
+	// The account already had a blob confirmed earlier in the day
+	now := time.Now()
+	assert.NoError(t, usage.AddConfirmedBytes(ctx, "capped", now, blobSize))
+
+	queue := func(accountID core.AccountID, data []byte, requestedAt time.Time) disperser.BlobKey {
+		blob := makeTestBlob([]*core.SecurityParam{{
+			QuorumID:           0,
+			AdversaryThreshold: 80,
+			QuorumThreshold:    100,
+		}})
+		blob.RequestHeader.AccountID = accountID
+		blob.Data = data
+		blobKey, err := components.blobStore.StoreBlob(ctx, &blob, uint64(requestedAt.UnixNano()))
+		assert.NoError(t, err)
+		return blobKey
+	}
+	// The blobs of the account are queued out of order
+	blobKey2 := queue("capped", gettysburgAddressBytes, now.Add(-3*time.Minute))
+	blobKey1 := queue("capped", gettysburgAddressBytes, now.Add(-4*time.Minute))
+	blobKey4 := queue("capped", gettysburgAddressBytes[:10], now.Add(-time.Minute))
+	blobKey3 := queue("capped", gettysburgAddressBytes, now.Add(-2*time.Minute))
+	uncappedKey := queue("uncapped", gettysburgAddressBytes, now.Add(-time.Minute))
+
+	// Only the first two blobs of the account fit in its quota. The small fourth blob would fit in what is left of the
+	// quota, but it waits for the third one, so that the blobs of the account are confirmed in order.
+	encodeQueuedBlobs(t, ctx, components.encodingStreamer, 3)
+	err := batcher.HandleSingleBatch(ctx)
+	assert.NoError(t, err)
+	for blobKey, status := range map[disperser.BlobKey]disperser.BlobStatus{
+		blobKey1:    disperser.Confirmed,
+		blobKey2:    disperser.Confirmed,
+		blobKey3:    disperser.Processing,
+		blobKey4:    disperser.Processing,
+		uncappedKey: disperser.Confirmed,
+	} {
+		metadata, err := components.blobStore.GetBlobMetadata(ctx, blobKey)
+		assert.NoError(t, err)
+		assert.Equal(t, status, metadata.BlobStatus)
+	}
+	used, err := usage.GetConfirmedBytes(ctx, "capped", now)
+	assert.NoError(t, err)
+	assert.Equal(t, 3*blobSize, used)
+	used, err = usage.GetConfirmedBytes(ctx, "uncapped", now)
+	assert.NoError(t, err)
+	assert.Equal(t, blobSize, used)
+
+	// The excluded blobs keep waiting for the quota during the grace period
+	out := make(chan bat.EncodingResultOrStatus, 1)
+	err = components.encodingStreamer.RequestEncoding(ctx, out)
+	assert.NoError(t, err)
+	assert.Len(t, out, 0)
+	metadata, err := components.blobStore.GetBlobMetadata(ctx, blobKey3)
+	assert.NoError(t, err)
+	assert.Equal(t, disperser.Processing, metadata.BlobStatus)
+
+	// and fail once it elapsed
+	components.encodingStreamer.QuotaGracePeriod = 90 * time.Second
+	err = components.encodingStreamer.RequestEncoding(ctx, out)
+	assert.NoError(t, err)
+	metadata, err = components.blobStore.GetBlobMetadata(ctx, blobKey3)
+	assert.NoError(t, err)
+	assert.Equal(t, disperser.QuotaExceeded, metadata.BlobStatus)
+	metadata, err = components.blobStore.GetBlobMetadata(ctx, blobKey4)
+	assert.NoError(t, err)
+	assert.Equal(t, disperser.Processing, metadata.BlobStatus)
+}
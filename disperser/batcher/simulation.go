@@ -0,0 +1,850 @@
+package batcher
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"math/rand"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/disperser"
+	"github.com/Layr-Labs/eigenda/disperser/common/inmem"
+	"github.com/Layr-Labs/eigenda/pkg/kzg/bn254"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+const (
+	defaultSimulatedBlockInterval = 12 * time.Second
+	// simulationEpoch is the wall-clock time at which the virtual time of the simulations starts, from which the
+	// request times of the simulated blobs are derived
+	simulationEpoch = int64(1_700_000_000) * int64(time.Second)
+)
+
+// Distribution is a distribution of a quantity of a simulated workload, sampled from the random source of the
+// simulation so that a simulation is reproducible from its seed
+type Distribution interface {
+	Sample(rng *rand.Rand) float64
+}
+
+// ConstantDistribution always samples the same value
+type ConstantDistribution float64
+
+func (d ConstantDistribution) Sample(*rand.Rand) float64 {
+	return float64(d)
+}
+
+// UniformDistribution samples values uniformly between Min and Max
+type UniformDistribution struct {
+	Min float64
+	Max float64
+}
+
+func (d UniformDistribution) Sample(rng *rand.Rand) float64 {
+	return d.Min + rng.Float64()*(d.Max-d.Min)
+}
+
+// ExponentialDistribution samples values exponentially distributed around Mean, as the long-tailed latencies
+type ExponentialDistribution struct {
+	Mean float64
+}
+
+func (d ExponentialDistribution) Sample(rng *rand.Rand) float64 {
+	return rng.ExpFloat64() * d.Mean
+}
+
+// Workload describes the synthetic load of a simulation
+type Workload struct {
+	// BlobRate is the mean number of blobs dispersed per second, the blobs arriving as a Poisson process
+	BlobRate float64
+	// BlobSize is the distribution of the sizes of the blobs in bytes
+	BlobSize Distribution
+	// SecurityParams are the quorums of every blob
+	SecurityParams []*core.SecurityParam
+	// NumOperators is the number of operators, which are registered in all the quorums with equal stakes
+	NumOperators int
+	// OperatorLatencies are the distributions of the time in seconds an operator takes to store and sign a batch, the
+	// i-th operator sampling from OperatorLatencies[i % len(OperatorLatencies)]. The operators which take longer than
+	// the attestation timeout don't sign the batch. The operators reply instantly if it is empty.
+	OperatorLatencies []Distribution
+	// Duration is how long the blobs keep arriving
+	Duration time.Duration
+}
+
+// SimulationConfig is the workload of a simulation along with the configuration of the simulated batcher
+type SimulationConfig struct {
+	Workload
+	Config
+	TimeoutConfig
+
+	// Seed is the seed of the random source of the simulation. The simulations of the same config and seed yield the
+	// same report, unless EncoderClient is set.
+	Seed int64
+	// EncoderClient encodes the blobs for real when it is set, each encoding taking the virtual time it took for real.
+	// Otherwise, the encodings are mocked and take a virtual time sampled from EncodingLatency.
+	EncoderClient disperser.EncoderClient
+	// EncodingLatency is the distribution of the time in seconds the mocked encoding of a blob for a quorum takes
+	EncodingLatency Distribution
+	// ConfirmationLatency is the time the confirmation transaction of a batch takes to land
+	ConfirmationLatency time.Duration
+	// BlockInterval is the interval between the blocks of the simulated chain, which ages the reference blocks. It
+	// defaults to 12s.
+	BlockInterval time.Duration
+	// DrainPeriod is how long the simulation keeps running after the last blob arrived, for the blobs in flight to be
+	// confirmed
+	DrainPeriod time.Duration
+}
+
+// SimulatedBatch describes a batch dispersed by the simulated batcher
+type SimulatedBatch struct {
+	// DispersedAt is the virtual time the batch was dispersed at
+	DispersedAt time.Duration
+	// NumBlobs is the number of blobs of the batch
+	NumBlobs int
+	// NumConfirmed is the number of blobs of the batch which were confirmed
+	NumConfirmed int
+	// ConfirmedBytes is the total size of the blobs of the batch which were confirmed
+	ConfirmedBytes uint64
+	// AttestationLatency is the time the operators took to sign the batch, capped by the attestation timeout
+	AttestationLatency time.Duration
+}
+
+// SimulationReport is the outcome of a simulation
+type SimulationReport struct {
+	// Duration is the virtual time simulated
+	Duration time.Duration
+	// NumBlobs is the number of blobs which arrived
+	NumBlobs int
+	// NumConfirmed, NumFailed and NumPending are the numbers of blobs which were confirmed, which failed, and which
+	// were still processing at the end of the simulation
+	NumConfirmed int
+	NumFailed    int
+	NumPending   int
+	// Batches are the batches dispersed, in order
+	Batches []SimulatedBatch
+	// ConfirmationLatencies are the times from the arrival of the confirmed blobs to their confirmation, in increasing
+	// order
+	ConfirmationLatencies []time.Duration
+	// WorkerUtilization is the fraction of the virtual time each encoding worker was busy
+	WorkerUtilization []float64
+}
+
+// ConfirmationLatencyPercentile returns the p-th percentile of the confirmation latencies, 0 if no blob was confirmed
+func (r *SimulationReport) ConfirmationLatencyPercentile(p float64) time.Duration {
+	if len(r.ConfirmationLatencies) == 0 {
+		return 0
+	}
+	i := int(math.Ceil(p/100*float64(len(r.ConfirmationLatencies)))) - 1
+	if i < 0 {
+		i = 0
+	}
+	if i >= len(r.ConfirmationLatencies) {
+		i = len(r.ConfirmationLatencies) - 1
+	}
+	return r.ConfirmationLatencies[i]
+}
+
+// MeanBatchSize returns the mean number of blobs of the batches
+func (r *SimulationReport) MeanBatchSize() float64 {
+	if len(r.Batches) == 0 {
+		return 0
+	}
+	total := 0
+	for _, batch := range r.Batches {
+		total += batch.NumBlobs
+	}
+	return float64(total) / float64(len(r.Batches))
+}
+
+// MeanWorkerUtilization returns the mean utilization of the encoding workers
+func (r *SimulationReport) MeanWorkerUtilization() float64 {
+	if len(r.WorkerUtilization) == 0 {
+		return 0
+	}
+	total := 0.0
+	for _, utilization := range r.WorkerUtilization {
+		total += utilization
+	}
+	return total / float64(len(r.WorkerUtilization))
+}
+
+func (r *SimulationReport) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "simulated %s: %d blobs, %d confirmed, %d failed, %d pending\n", r.Duration, r.NumBlobs, r.NumConfirmed, r.NumFailed, r.NumPending)
+	fmt.Fprintf(&b, "batches: %d, mean size %.1f blobs\n", len(r.Batches), r.MeanBatchSize())
+	fmt.Fprintf(&b, "confirmation latency: p50 %s, p90 %s, p99 %s\n", r.ConfirmationLatencyPercentile(50), r.ConfirmationLatencyPercentile(90), r.ConfirmationLatencyPercentile(99))
+	fmt.Fprintf(&b, "encoding worker utilization: %.1f%%\n", 100*r.MeanWorkerUtilization())
+	return b.String()
+}
+
+func (c *SimulationConfig) validate() error {
+	var errs []error
+	if c.BlobRate <= 0 {
+		errs = append(errs, fmt.Errorf("the blob rate must be positive, but found %f", c.BlobRate))
+	}
+	if c.BlobSize == nil {
+		errs = append(errs, errors.New("the blob size distribution must be set"))
+	}
+	if len(c.SecurityParams) == 0 {
+		errs = append(errs, errors.New("the blobs must have at least one quorum"))
+	}
+	if c.NumOperators <= 0 {
+		errs = append(errs, fmt.Errorf("the number of operators must be positive, but found %d", c.NumOperators))
+	}
+	if c.Duration <= 0 {
+		errs = append(errs, fmt.Errorf("the duration must be positive, but found %s", c.Duration))
+	}
+	if c.EncoderClient == nil && c.EncodingLatency == nil {
+		errs = append(errs, errors.New("the encoding latency distribution must be set to mock the encodings"))
+	}
+	if c.PullInterval <= 0 {
+		errs = append(errs, fmt.Errorf("the pull interval must be positive, but found %s", c.PullInterval))
+	}
+	if c.NumConnections <= 0 {
+		errs = append(errs, fmt.Errorf("the number of connections must be positive, but found %d", c.NumConnections))
+	}
+	if c.AttestationTimeout <= 0 {
+		errs = append(errs, fmt.Errorf("the attestation timeout must be positive, but found %s", c.AttestationTimeout))
+	}
+	if c.ConfirmationLatency < 0 || c.BlockInterval < 0 || c.DrainPeriod < 0 {
+		errs = append(errs, errors.New("the confirmation latency, block interval and drain period must not be negative"))
+	}
+	return errors.Join(errs...)
+}
+
+// Simulate runs the batcher against a synthetic workload in virtual time, to plan the capacity of a deployment. The
+// blobs go through the production code of the batcher: their selection and encoding requests by the encoding streamer,
+// the assignment of their chunks, the creation, dispersal and aggregation of the batches, and the handling of their
+// confirmation or failure. Only the operators, the chain and, unless an encoder client is set, the encoder are
+// simulated. The encoding streamer requests encodings at its usual interval, the encodings being scheduled on the
+// encoding workers in virtual time, and the batcher makes batches at the pull interval or once enough is encoded,
+// waiting for the dispersal and the confirmation of a batch before the next one.
+func Simulate(ctx context.Context, config SimulationConfig, logger common.Logger) (*SimulationReport, error) {
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+	if config.BlockInterval == 0 {
+		config.BlockInterval = defaultSimulatedBlockInterval
+	}
+
+	s := &simulation{
+		config:     config,
+		rng:        rand.New(rand.NewSource(config.Seed)),
+		logger:     logger,
+		out:        make(chan EncodingResultOrStatus, 1),
+		workerFree: make([]time.Duration, config.NumConnections),
+		workerBusy: make([]time.Duration, config.NumConnections),
+		arrivals:   make(map[disperser.BlobKey]time.Duration),
+		confirmed:  make(map[disperser.BlobKey]struct{}),
+		report:     &SimulationReport{},
+	}
+	if err := s.setup(); err != nil {
+		return nil, err
+	}
+	if err := s.run(ctx); err != nil {
+		return nil, err
+	}
+	return s.report, nil
+}
+
+type simulatedArrival struct {
+	at   time.Duration
+	size int
+}
+
+type simulatedEncoding struct {
+	result  EncodingResultOrStatus
+	worker  int
+	startAt time.Duration
+	readyAt time.Duration
+}
+
+type simulation struct {
+	config SimulationConfig
+	rng    *rand.Rand
+	logger common.Logger
+	now    time.Duration
+
+	store      disperser.BlobStore
+	dispatcher *simulatedDispatcher
+	pool       *simulatedPool
+	batcher    *Batcher
+	out        chan EncodingResultOrStatus
+
+	pendingArrivals []simulatedArrival
+	// encodings are the encodings requested which aren't yet processed by the encoding streamer
+	encodings  []*simulatedEncoding
+	workerFree []time.Duration
+	workerBusy []time.Duration
+	arrivals   map[disperser.BlobKey]time.Duration
+	confirmed  map[disperser.BlobKey]struct{}
+	busyUntil  time.Duration
+	triggered  bool
+	// triggeredAt is when the encoded size reached the batch size limit, which triggers a batch
+	triggeredAt time.Duration
+
+	report *SimulationReport
+}
+
+func (s *simulation) setup() error {
+	chainState := newSimulatedChainState(s.config.NumOperators, s.config.SecurityParams, func() uint {
+		return uint(s.now/s.config.BlockInterval) + 1
+	})
+	s.dispatcher = &simulatedDispatcher{
+		operators: chainState.operators,
+		keyPairs:  chainState.keyPairs,
+		latencies: s.config.OperatorLatencies,
+		timeout:   s.config.AttestationTimeout,
+		rng:       s.rng,
+	}
+	s.store = &simulatedBlobStore{BlobStore: inmem.NewBlobStore()}
+	encoderClient := s.config.EncoderClient
+	if encoderClient == nil {
+		encoderClient = simulatedEncoderClient{}
+	}
+	asgn := &core.StdAssignmentCoordinator{
+		MinChunkLength:               s.config.MinChunkLength,
+		LargeQuorumOperatorThreshold: s.config.LargeQuorumOperatorThreshold,
+		LargeQuorumMinChunkBytes:     s.config.LargeQuorumMinChunkBytes,
+	}
+	confirmer := &simulatedConfirmer{blockNumber: chainState.blockNumber}
+	metrics := NewMetrics("0", nil, s.logger)
+
+	var err error
+	s.batcher, err = NewBatcher(s.config.Config, s.config.TimeoutConfig, s.store, s.dispatcher, confirmer, chainState, asgn, encoderClient, core.NewStdSignatureAggregator(s.logger), nil, nil, s.logger, metrics)
+	if err != nil {
+		return err
+	}
+	// Run the encodings in virtual time rather than on the workers of the batcher
+	s.batcher.EncodingStreamer.Pool.Stop()
+	s.pool = &simulatedPool{size: s.config.NumConnections}
+	s.batcher.EncodingStreamer.Pool = s.pool
+
+	var at time.Duration
+	for {
+		at += secondsToDuration(s.rng.ExpFloat64() / s.config.BlobRate)
+		if at >= s.config.Duration {
+			break
+		}
+		size := int(s.config.BlobSize.Sample(s.rng))
+		if size < 1 {
+			size = 1
+		}
+		s.pendingArrivals = append(s.pendingArrivals, simulatedArrival{at: at, size: size})
+	}
+	return nil
+}
+
+// run processes the events of the simulation in the order of their virtual time: the arrival of the blobs, the end of
+// their encodings, the encoding requests of the encoding streamer, and the batches
+func (s *simulation) run(ctx context.Context) error {
+	end := s.config.Duration + s.config.DrainPeriod
+	nextEncoding := time.Duration(0)
+	nextBatch := s.config.PullInterval
+	for {
+		batchAt := nextBatch
+		if s.triggered {
+			batchAt = s.triggeredAt
+			if batchAt < s.busyUntil {
+				batchAt = s.busyUntil
+			}
+		}
+		t := nextEncoding
+		if batchAt < t {
+			t = batchAt
+		}
+		readyAt, ready := s.nextEncodingReady()
+		if ready && readyAt < t {
+			t = readyAt
+		}
+		if t > end {
+			break
+		}
+		s.now = t
+		if err := s.admitArrivals(ctx); err != nil {
+			return err
+		}
+
+		switch {
+		case ready && readyAt == t:
+			s.processEncodings(ctx)
+		case nextEncoding == t:
+			if err := s.requestEncodings(ctx); err != nil {
+				return err
+			}
+			nextEncoding += encodingInterval
+		default:
+			wasTriggered := s.triggered
+			s.triggered = false
+			if err := s.makeBatch(ctx); err != nil {
+				return err
+			}
+			if wasTriggered {
+				// The batcher resets its ticker after a batch triggered by the encoded size
+				nextBatch = s.busyUntil + s.config.PullInterval
+			} else {
+				// The ticks missed while the batch was confirmed fire as soon as the batcher is done with it
+				nextBatch = t + s.config.PullInterval
+				if nextBatch < s.busyUntil {
+					nextBatch = s.busyUntil
+				}
+			}
+		}
+
+		if t >= s.config.Duration && len(s.pendingArrivals) == 0 && len(s.encodings) == 0 {
+			pending, err := s.store.GetBlobMetadataByStatus(ctx, disperser.Processing)
+			if err != nil {
+				return err
+			}
+			if len(pending) == 0 {
+				break
+			}
+		}
+	}
+	return s.finish(ctx)
+}
+
+// admitArrivals stores the blobs which arrived by now, with their arrival time as request time
+func (s *simulation) admitArrivals(ctx context.Context) error {
+	for len(s.pendingArrivals) > 0 && s.pendingArrivals[0].at <= s.now {
+		arrival := s.pendingArrivals[0]
+		s.pendingArrivals = s.pendingArrivals[1:]
+
+		data := make([]byte, arrival.size)
+		_, _ = s.rng.Read(data)
+		blob := &core.Blob{
+			RequestHeader: core.BlobRequestHeader{SecurityParams: s.config.SecurityParams},
+			Data:          data,
+		}
+		key, err := s.store.StoreBlob(ctx, blob, uint64(simulationEpoch+int64(arrival.at)))
+		if err != nil {
+			return fmt.Errorf("failed to store simulated blob: %w", err)
+		}
+		s.arrivals[key] = arrival.at
+		s.report.NumBlobs++
+	}
+	return nil
+}
+
+func (s *simulation) nextEncodingReady() (time.Duration, bool) {
+	if len(s.encodings) == 0 {
+		return 0, false
+	}
+	readyAt := s.encodings[0].readyAt
+	for _, encoding := range s.encodings[1:] {
+		if encoding.readyAt < readyAt {
+			readyAt = encoding.readyAt
+		}
+	}
+	return readyAt, true
+}
+
+// requestEncodings runs an encoding request of the encoding streamer, and schedules the requested encodings on the
+// earliest available encoding workers
+func (s *simulation) requestEncodings(ctx context.Context) error {
+	waiting := 0
+	for _, encoding := range s.encodings {
+		if encoding.startAt > s.now {
+			waiting++
+		}
+	}
+	s.pool.waiting = waiting
+	if err := s.batcher.EncodingStreamer.RequestEncoding(ctx, s.out); err != nil {
+		s.logger.Warn("[simulation] error requesting encoding", "err", err)
+	}
+
+	type requested struct {
+		result  EncodingResultOrStatus
+		elapsed time.Duration
+	}
+	tasks := s.pool.tasks
+	s.pool.tasks = nil
+	results := make([]requested, 0, len(tasks))
+	for _, task := range tasks {
+		start := time.Now()
+		task()
+		results = append(results, requested{result: <-s.out, elapsed: time.Since(start)})
+	}
+	// Schedule the encodings in a deterministic order, regardless of the order they were requested in
+	sort.SliceStable(results, func(i, j int) bool {
+		a, b := results[i].result, results[j].result
+		if a.BlobMetadata.RequestMetadata.RequestedAt != b.BlobMetadata.RequestMetadata.RequestedAt {
+			return a.BlobMetadata.RequestMetadata.RequestedAt < b.BlobMetadata.RequestMetadata.RequestedAt
+		}
+		return a.BlobQuorumInfo.QuorumID < b.BlobQuorumInfo.QuorumID
+	})
+
+	for _, r := range results {
+		latency := r.elapsed
+		if s.config.EncoderClient == nil {
+			latency = secondsToDuration(s.config.EncodingLatency.Sample(s.rng))
+		}
+		worker := 0
+		for w := range s.workerFree {
+			if s.workerFree[w] < s.workerFree[worker] {
+				worker = w
+			}
+		}
+		startAt := s.workerFree[worker]
+		if startAt < s.now {
+			startAt = s.now
+		}
+		s.workerFree[worker] = startAt + latency
+		s.workerBusy[worker] += latency
+		s.encodings = append(s.encodings, &simulatedEncoding{
+			result:  r.result,
+			worker:  worker,
+			startAt: startAt,
+			readyAt: startAt + latency,
+		})
+	}
+	return nil
+}
+
+// processEncodings hands the encodings done by now to the encoding streamer, which triggers a batch once the encoded
+// size reaches the batch size limit
+func (s *simulation) processEncodings(ctx context.Context) {
+	remaining := s.encodings[:0]
+	for _, encoding := range s.encodings {
+		if encoding.readyAt > s.now {
+			remaining = append(remaining, encoding)
+			continue
+		}
+		if err := s.batcher.EncodingStreamer.ProcessEncodedBlobs(ctx, encoding.result); err != nil {
+			s.logger.Error("[simulation] error processing encoded blobs", "err", err)
+		}
+	}
+	s.encodings = remaining
+
+	select {
+	case <-s.batcher.EncodingStreamer.EncodedSizeNotifier.Notify:
+		if !s.triggered {
+			s.triggered = true
+			s.triggeredAt = s.now
+		}
+	default:
+	}
+}
+
+// makeBatch runs an iteration of the batcher, and records the batch it dispersed and the blobs it confirmed
+func (s *simulation) makeBatch(ctx context.Context) error {
+	s.dispatcher.dispersed = false
+	err := s.batcher.HandleSingleBatch(ctx)
+	if errors.Is(err, errNoEncodedResults) || !s.dispatcher.dispersed {
+		return nil
+	}
+	if err != nil {
+		s.logger.Warn("[simulation] error handling batch", "err", err)
+	}
+
+	confirmed, err := s.store.GetBlobMetadataByStatus(ctx, disperser.Confirmed)
+	if err != nil {
+		return err
+	}
+	batch := SimulatedBatch{
+		DispersedAt:        s.now,
+		NumBlobs:           s.dispatcher.numBlobs,
+		AttestationLatency: s.dispatcher.latency,
+	}
+	doneAt := s.now + s.dispatcher.latency
+	newlyConfirmed := make([]*disperser.BlobMetadata, 0)
+	for _, metadata := range confirmed {
+		if _, ok := s.confirmed[metadata.GetBlobKey()]; !ok {
+			newlyConfirmed = append(newlyConfirmed, metadata)
+		}
+	}
+	if len(newlyConfirmed) > 0 {
+		doneAt += s.config.ConfirmationLatency
+	}
+	for _, metadata := range newlyConfirmed {
+		key := metadata.GetBlobKey()
+		s.confirmed[key] = struct{}{}
+		batch.NumConfirmed++
+		batch.ConfirmedBytes += uint64(metadata.RequestMetadata.BlobSize)
+		s.report.ConfirmationLatencies = append(s.report.ConfirmationLatencies, doneAt-s.arrivals[key])
+	}
+	s.report.Batches = append(s.report.Batches, batch)
+	s.busyUntil = doneAt
+	return nil
+}
+
+func (s *simulation) finish(ctx context.Context) error {
+	// The simulation ends with the confirmation of the last batch
+	s.report.Duration = s.now
+	if s.report.Duration < s.busyUntil {
+		s.report.Duration = s.busyUntil
+	}
+	if s.report.Duration < s.config.Duration {
+		s.report.Duration = s.config.Duration
+	}
+	// Don't count the encodings past the end of the simulation
+	for _, encoding := range s.encodings {
+		startAt := encoding.startAt
+		if startAt < s.report.Duration {
+			startAt = s.report.Duration
+		}
+		if encoding.readyAt > startAt {
+			s.workerBusy[encoding.worker] -= encoding.readyAt - startAt
+		}
+	}
+	s.report.WorkerUtilization = make([]float64, len(s.workerBusy))
+	for w, busy := range s.workerBusy {
+		s.report.WorkerUtilization[w] = float64(busy) / float64(s.report.Duration)
+	}
+
+	sort.Slice(s.report.ConfirmationLatencies, func(i, j int) bool {
+		return s.report.ConfirmationLatencies[i] < s.report.ConfirmationLatencies[j]
+	})
+	s.report.NumConfirmed = len(s.confirmed)
+	for _, status := range []disperser.BlobStatus{disperser.Failed, disperser.InsufficientSignatures, disperser.Processing} {
+		metadatas, err := s.store.GetBlobMetadataByStatus(ctx, status)
+		if err != nil {
+			return err
+		}
+		if status == disperser.Processing {
+			s.report.NumPending = len(metadatas)
+		} else {
+			s.report.NumFailed += len(metadatas)
+		}
+	}
+	return nil
+}
+
+func secondsToDuration(seconds float64) time.Duration {
+	if seconds <= 0 {
+		return 0
+	}
+	return time.Duration(seconds * float64(time.Second))
+}
+
+// simulatedBlobStore lists the blobs in the order of their arrival, so that the encoding streamer selects the same
+// blobs in every run of a simulation
+type simulatedBlobStore struct {
+	disperser.BlobStore
+}
+
+func (s *simulatedBlobStore) GetBlobMetadataByStatus(ctx context.Context, status disperser.BlobStatus) ([]*disperser.BlobMetadata, error) {
+	metadatas, err := s.BlobStore.GetBlobMetadataByStatus(ctx, status)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(metadatas, func(i, j int) bool {
+		return metadatas[i].RequestMetadata.RequestedAt < metadatas[j].RequestMetadata.RequestedAt
+	})
+	return metadatas, nil
+}
+
+// simulatedPool collects the encoding tasks submitted by the encoding streamer, which the simulation runs itself
+type simulatedPool struct {
+	size    int
+	waiting int
+	tasks   []func()
+}
+
+var _ common.WorkerPool = (*simulatedPool)(nil)
+
+func (p *simulatedPool) Size() int                 { return p.size }
+func (p *simulatedPool) Stop()                     {}
+func (p *simulatedPool) StopWait()                 {}
+func (p *simulatedPool) Stopped() bool             { return false }
+func (p *simulatedPool) Submit(task func())        { p.tasks = append(p.tasks, task) }
+func (p *simulatedPool) SubmitWait(task func())    { task() }
+func (p *simulatedPool) WaitingQueueSize() int     { return p.waiting }
+func (p *simulatedPool) Pause(ctx context.Context) {}
+
+// simulatedEncoderClient mocks the encodings with commitments derived from the data and chunks of the encoded size,
+// which are as costly to disperse as real chunks but free to make
+type simulatedEncoderClient struct{}
+
+var _ disperser.EncoderClient = simulatedEncoderClient{}
+
+func (simulatedEncoderClient) EncodeBlob(ctx context.Context, data []byte, encodingParams core.EncodingParams) (*core.BlobCommitments, []*core.Chunk, error) {
+	hash := sha256.Sum256(data)
+	var scalar bn254.Fr
+	bn254.FrSetBytes(&scalar, hash[:])
+	var point bn254.G1Point
+	bn254.MulG1(&point, &bn254.GenG1, &scalar)
+	commitment := &core.Commitment{G1Point: &point}
+
+	coeffs := make([]core.Symbol, encodingParams.ChunkLength)
+	chunks := make([]*core.Chunk, encodingParams.NumChunks)
+	for i := range chunks {
+		chunks[i] = &core.Chunk{Coeffs: coeffs}
+	}
+	return &core.BlobCommitments{
+		Commitment:  commitment,
+		LengthProof: commitment,
+		Length:      encodingParams.Layout.BlobLength(uint(len(data))),
+	}, chunks, nil
+}
+
+// simulatedChainState is a chain of which the operators have equal stakes in all the quorums, and which advances a
+// block every block interval of virtual time
+type simulatedChainState struct {
+	operators   []core.OperatorID
+	keyPairs    map[core.OperatorID]*core.KeyPair
+	quorums     []core.QuorumID
+	blockNumber func() uint
+}
+
+var _ core.IndexedChainState = (*simulatedChainState)(nil)
+
+func newSimulatedChainState(numOperators int, securityParams []*core.SecurityParam, blockNumber func() uint) *simulatedChainState {
+	s := &simulatedChainState{
+		operators:   make([]core.OperatorID, numOperators),
+		keyPairs:    make(map[core.OperatorID]*core.KeyPair, numOperators),
+		blockNumber: blockNumber,
+	}
+	for i := range s.operators {
+		keyPair := core.MakeKeyPair(new(core.PrivateKey).SetUint64(uint64(i + 1)))
+		id := keyPair.GetPubKeyG1().GetOperatorID()
+		s.operators[i] = id
+		s.keyPairs[id] = keyPair
+	}
+	seen := make(map[core.QuorumID]bool)
+	for _, param := range securityParams {
+		if !seen[param.QuorumID] {
+			seen[param.QuorumID] = true
+			s.quorums = append(s.quorums, param.QuorumID)
+		}
+	}
+	return s
+}
+
+func (s *simulatedChainState) GetCurrentBlockNumber() (uint, error) {
+	return s.blockNumber(), nil
+}
+
+func (s *simulatedChainState) GetOperatorState(ctx context.Context, blockNumber uint, quorums []core.QuorumID) (*core.OperatorState, error) {
+	state, err := s.GetIndexedOperatorState(ctx, blockNumber, quorums)
+	if err != nil {
+		return nil, err
+	}
+	return state.OperatorState, nil
+}
+
+func (s *simulatedChainState) GetOperatorStateByOperator(ctx context.Context, blockNumber uint, operator core.OperatorID) (*core.OperatorState, error) {
+	return s.GetOperatorState(ctx, blockNumber, s.quorums)
+}
+
+func (s *simulatedChainState) GetIndexedOperatorState(ctx context.Context, blockNumber uint, quorums []core.QuorumID) (*core.IndexedOperatorState, error) {
+	state := &core.IndexedOperatorState{
+		OperatorState: &core.OperatorState{
+			Operators:   make(map[core.QuorumID]map[core.OperatorID]*core.OperatorInfo, len(quorums)),
+			Totals:      make(map[core.QuorumID]*core.OperatorInfo, len(quorums)),
+			BlockNumber: blockNumber,
+		},
+		IndexedOperators: make(map[core.OperatorID]*core.IndexedOperatorInfo, len(s.operators)),
+		AggKeys:          make(map[core.QuorumID]*core.G1Point, len(quorums)),
+	}
+	for i, id := range s.operators {
+		state.IndexedOperators[id] = &core.IndexedOperatorInfo{
+			Socket:   string(core.MakeOperatorSocket("localhost", fmt.Sprintf("%d", 32000+2*i), fmt.Sprintf("%d", 32001+2*i))),
+			PubkeyG1: s.keyPairs[id].GetPubKeyG1(),
+			PubkeyG2: s.keyPairs[id].GetPubKeyG2(),
+		}
+	}
+	for _, quorumID := range quorums {
+		operators := make(map[core.OperatorID]*core.OperatorInfo, len(s.operators))
+		var aggKey *core.G1Point
+		for i, id := range s.operators {
+			operators[id] = &core.OperatorInfo{Stake: big.NewInt(1), Index: core.OperatorIndex(i)}
+			pubKey := s.keyPairs[id].GetPubKeyG1()
+			if aggKey == nil {
+				aggKey = pubKey.Deserialize(pubKey.Serialize())
+			} else {
+				aggKey.Add(pubKey)
+			}
+		}
+		state.Operators[quorumID] = operators
+		state.Totals[quorumID] = &core.OperatorInfo{Stake: big.NewInt(int64(len(s.operators))), Index: core.OperatorIndex(len(s.operators))}
+		state.AggKeys[quorumID] = aggKey
+	}
+	return state, nil
+}
+
+func (s *simulatedChainState) Start(context.Context) error {
+	return nil
+}
+
+// simulatedDispatcher signs the batches on behalf of the operators, each operator taking a latency sampled from its
+// distribution, and records the latency of the slowest operator, capped by the attestation timeout
+type simulatedDispatcher struct {
+	operators []core.OperatorID
+	keyPairs  map[core.OperatorID]*core.KeyPair
+	latencies []Distribution
+	timeout   time.Duration
+	rng       *rand.Rand
+
+	dispersed bool
+	numBlobs  int
+	latency   time.Duration
+}
+
+var _ disperser.Dispatcher = (*simulatedDispatcher)(nil)
+
+func (d *simulatedDispatcher) DisperseBatch(ctx context.Context, state *core.IndexedOperatorState, blobs []core.EncodedBlob, header *core.BatchHeader) chan core.SignerMessage {
+	d.dispersed = true
+	d.numBlobs = len(blobs)
+	d.latency = 0
+
+	update := make(chan core.SignerMessage, len(state.IndexedOperators))
+	message, hashErr := header.GetBatchHeaderHash()
+	// Sample the latencies in the order of the operators, so that a simulation is reproducible
+	for i, id := range d.operators {
+		if _, ok := state.IndexedOperators[id]; !ok {
+			continue
+		}
+		var latency time.Duration
+		if len(d.latencies) > 0 {
+			latency = secondsToDuration(d.latencies[i%len(d.latencies)].Sample(d.rng))
+		}
+		reply := core.SignerMessage{Operator: id}
+		switch {
+		case latency > d.timeout:
+			latency = d.timeout
+			reply.Err = fmt.Errorf("operator didn't reply within the attestation timeout: %w", context.DeadlineExceeded)
+		case hashErr != nil:
+			reply.Err = hashErr
+		default:
+			reply.Signature = d.keyPairs[id].SignMessage(message)
+		}
+		if latency > d.latency {
+			d.latency = latency
+		}
+		update <- reply
+	}
+	return update
+}
+
+// simulatedConfirmer confirms the batches instantly, with receipts carrying the batch IDs in order
+type simulatedConfirmer struct {
+	blockNumber func() uint
+	batchID     uint32
+}
+
+var _ disperser.BatchConfirmer = (*simulatedConfirmer)(nil)
+
+func (c *simulatedConfirmer) ConfirmBatch(ctx context.Context, header *core.BatchHeader, quorums map[core.QuorumID]*core.QuorumResult, signatureAggregation *core.SignatureAggregation, replaced *types.Transaction) (*types.Receipt, *types.Transaction, error) {
+	// The data of the BatchConfirmed event is the batch ID and the fee, as two words
+	data := make([]byte, 64)
+	binary.BigEndian.PutUint32(data[28:32], c.batchID)
+	receipt := &types.Receipt{
+		Logs: []*types.Log{{
+			Topics: []gethcommon.Hash{common.BatchConfirmedEventSigHash},
+			Data:   data,
+		}},
+		BlockNumber:       new(big.Int).SetUint64(uint64(c.blockNumber())),
+		EffectiveGasPrice: big.NewInt(0),
+	}
+	c.batchID++
+	return receipt, nil, nil
+}
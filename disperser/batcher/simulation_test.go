@@ -0,0 +1,104 @@
+package batcher_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	cmock "github.com/Layr-Labs/eigenda/common/mock"
+	"github.com/Layr-Labs/eigenda/core"
+	bat "github.com/Layr-Labs/eigenda/disperser/batcher"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func makeSimulationConfig(seed int64) bat.SimulationConfig {
+	return bat.SimulationConfig{
+		Workload: bat.Workload{
+			BlobRate: 2,
+			BlobSize: bat.UniformDistribution{Min: 100, Max: 2000},
+			SecurityParams: []*core.SecurityParam{{
+				QuorumID:           0,
+				AdversaryThreshold: 80,
+				QuorumThreshold:    100,
+			}},
+			NumOperators:      4,
+			OperatorLatencies: []bat.Distribution{bat.UniformDistribution{Min: 0.1, Max: 0.5}},
+			Duration:          30 * time.Second,
+		},
+		Config: bat.Config{
+			PullInterval:             5 * time.Second,
+			NumConnections:           2,
+			EncodingRequestQueueSize: 100,
+			BatchSizeMBLimit:         10,
+			SRSOrder:                 3000,
+			MaxNumRetriesPerBlob:     2,
+		},
+		TimeoutConfig: bat.TimeoutConfig{
+			EncodingTimeout:    10 * time.Second,
+			AttestationTimeout: 10 * time.Second,
+			ChainReadTimeout:   10 * time.Second,
+			ChainWriteTimeout:  10 * time.Second,
+		},
+		Seed:                seed,
+		EncodingLatency:     bat.ExponentialDistribution{Mean: 0.5},
+		ConfirmationLatency: 12 * time.Second,
+		DrainPeriod:         time.Minute,
+	}
+}
+
+func TestSimulate(t *testing.T) {
+	ctx := context.Background()
+	report, err := bat.Simulate(ctx, makeSimulationConfig(1), &cmock.Logger{})
+	require.NoError(t, err)
+
+	assert.Greater(t, report.NumBlobs, 0)
+	assert.Equal(t, report.NumBlobs, report.NumConfirmed)
+	assert.Equal(t, 0, report.NumFailed)
+	assert.Equal(t, 0, report.NumPending)
+	assert.Len(t, report.ConfirmationLatencies, report.NumConfirmed)
+	assert.Greater(t, len(report.Batches), 1)
+	numConfirmed := 0
+	for _, batch := range report.Batches {
+		assert.LessOrEqual(t, batch.AttestationLatency, 500*time.Millisecond)
+		numConfirmed += batch.NumConfirmed
+	}
+	assert.Equal(t, report.NumConfirmed, numConfirmed)
+	// A blob waits at least for the confirmation of its batch
+	assert.GreaterOrEqual(t, report.ConfirmationLatencyPercentile(0), 12*time.Second)
+	assert.Len(t, report.WorkerUtilization, 2)
+	assert.Greater(t, report.MeanWorkerUtilization(), 0.0)
+	assert.Less(t, report.MeanWorkerUtilization(), 1.0)
+
+	// The same seed yields the same report, and another seed another report
+	again, err := bat.Simulate(ctx, makeSimulationConfig(1), &cmock.Logger{})
+	require.NoError(t, err)
+	assert.Equal(t, report, again)
+	other, err := bat.Simulate(ctx, makeSimulationConfig(2), &cmock.Logger{})
+	require.NoError(t, err)
+	assert.NotEqual(t, report, other)
+}
+
+func TestSimulateSlowOperators(t *testing.T) {
+	config := makeSimulationConfig(1)
+	// Half of the operators never reply within the attestation timeout, so that no blob reaches its quorum threshold
+	config.OperatorLatencies = []bat.Distribution{bat.ConstantDistribution(0.2), bat.ConstantDistribution(60)}
+	config.MaxNumRetriesPerBlob = 0
+
+	report, err := bat.Simulate(context.Background(), config, &cmock.Logger{})
+	require.NoError(t, err)
+	assert.Equal(t, 0, report.NumConfirmed)
+	assert.Equal(t, report.NumBlobs, report.NumFailed)
+	for _, batch := range report.Batches {
+		assert.Equal(t, config.AttestationTimeout, batch.AttestationLatency)
+	}
+}
+
+func TestSimulateInvalidWorkload(t *testing.T) {
+	config := makeSimulationConfig(1)
+	config.BlobRate = 0
+	config.NumOperators = 0
+	_, err := bat.Simulate(context.Background(), config, &cmock.Logger{})
+	assert.ErrorContains(t, err, "blob rate")
+	assert.ErrorContains(t, err, "number of operators")
+}
@@ -0,0 +1,81 @@
+package batcher
+
+import (
+	"errors"
+
+	"github.com/Layr-Labs/eigenda/core"
+)
+
+// unknownNodeVersion labels the operators which don't advertise their version in the census
+const unknownNodeVersion = "unknown"
+
+// Types of the failures of the operators which didn't sign a batch reported in the metrics. The soft failures are the
+// batches refused past their dispersal deadline, which don't mean the operator is unhealthy.
+const (
+	softOperatorFailure = "soft"
+	hardOperatorFailure = "hard"
+)
+
+// collectVersions relays the numOperators replies of a dispersal from update to the returned channel, recording the
+// versions advertised by the operators which signed, and counting the failures of the others. The versions are sent
+// once all the replies were relayed. The relay channel is buffered for all the replies, so that the relay doesn't block
+// if the aggregation stops early.
+func (b *Batcher) collectVersions(update chan core.SignerMessage, numOperators int) (chan core.SignerMessage, chan map[core.OperatorID]core.NodeVersion) {
+	relay := make(chan core.SignerMessage, numOperators)
+	versions := make(chan map[core.OperatorID]core.NodeVersion, 1)
+	go func() {
+		collected := make(map[core.OperatorID]core.NodeVersion, numOperators)
+		for i := 0; i < numOperators; i++ {
+			msg := <-update
+			switch {
+			case msg.Err == nil:
+				collected[msg.Operator] = msg.Version
+			case errors.Is(msg.Err, core.ErrBatchExpired):
+				b.Metrics.IncrementOperatorFailures(softOperatorFailure)
+			default:
+				b.Metrics.IncrementOperatorFailures(hardOperatorFailure)
+			}
+			relay <- msg
+		}
+		versions <- collected
+	}()
+	return relay, versions
+}
+
+// updateVersionCensus updates the metrics of the versions advertised by the operators for a batch, and the optional
+// features which are enabled for the next batches
+func (b *Batcher) updateVersionCensus(state *core.OperatorState, versions map[core.OperatorID]core.NodeVersion) {
+	counts := make(map[string]int)
+	for _, version := range versions {
+		label := version.Version
+		if label == "" {
+			label = unknownNodeVersion
+		}
+		counts[label]++
+	}
+	b.Metrics.UpdateNodeVersions(counts)
+	for _, feature := range core.KnownFeatures {
+		for quorumID := range state.Totals {
+			b.Metrics.UpdateFeatureStake(feature, quorumID, core.FeatureStakePercentage(state, versions, quorumID, feature))
+		}
+	}
+
+	var enabled core.NodeFeatures
+	if b.FeatureStakeThreshold > 0 {
+		enabled = core.EnabledFeatures(state, versions, b.FeatureStakeThreshold)
+	}
+	b.enabledFeaturesMu.Lock()
+	defer b.enabledFeaturesMu.Unlock()
+	if enabled != b.enabledFeatures {
+		b.logger.Info("the optional node features in use changed", "previous", uint64(b.enabledFeatures), "enabled", uint64(enabled))
+	}
+	b.enabledFeatures = enabled
+}
+
+// FeatureEnabled returns whether an optional node feature is advertised by enough of the stake of the operators of the
+// last batch to be used for the next batches
+func (b *Batcher) FeatureEnabled(feature core.NodeFeatures) bool {
+	b.enabledFeaturesMu.RLock()
+	defer b.enabledFeaturesMu.RUnlock()
+	return b.enabledFeatures.Has(feature)
+}
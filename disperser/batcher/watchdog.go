@@ -0,0 +1,136 @@
+package batcher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/disperser"
+)
+
+type WatchdogConfig struct {
+	// Interval is the interval at which the watchdog checks for stuck blobs
+	Interval time.Duration
+	// SLA is how long a blob may be processing since it was requested before it is considered stuck
+	SLA time.Duration
+	// Redrive is whether the stuck blobs are re-driven: their encoding is dropped and their retry count incremented, so
+	// that the batcher selects them again. Otherwise, they are only reported.
+	Redrive bool
+	// MaxNumRetriesPerBlob is the retry count from which the re-driven blobs are failed instead
+	MaxNumRetriesPerBlob uint
+}
+
+// Watchdog periodically reports the blobs processing beyond the SLA, which an earlier iteration of the batcher may have
+// dropped, and optionally re-drives them. The blobs awaiting the confirmation of their batch are being worked on, and
+// are only reported. A re-driven blob is given another SLA to be confirmed before it is re-driven again.
+type Watchdog struct {
+	WatchdogConfig
+
+	blobStore disperser.BlobStore
+	streamer  *EncodingStreamer
+	metrics   *Metrics
+	logger    common.Logger
+
+	// redrivenAt is when each stuck blob was last re-driven
+	redrivenAt map[disperser.BlobKey]time.Time
+}
+
+func NewWatchdog(config WatchdogConfig, blobStore disperser.BlobStore, streamer *EncodingStreamer, metrics *Metrics, logger common.Logger) *Watchdog {
+	return &Watchdog{
+		WatchdogConfig: config,
+		blobStore:      blobStore,
+		streamer:       streamer,
+		metrics:        metrics,
+		logger:         logger,
+		redrivenAt:     make(map[disperser.BlobKey]time.Time),
+	}
+}
+
+func (w *Watchdog) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(w.Interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if _, err := w.CheckStuckBlobs(ctx); err != nil {
+					w.logger.Error("failed to check the stuck blobs", "err", err)
+				}
+			}
+		}
+	}()
+}
+
+// CheckStuckBlobs reports the blobs processing beyond the SLA and, if enabled, re-drives them. It returns the stuck
+// blobs.
+func (w *Watchdog) CheckStuckBlobs(ctx context.Context) ([]*disperser.BlobMetadata, error) {
+	metadatas, err := w.blobStore.GetBlobMetadataByStatus(ctx, disperser.Processing)
+	if err != nil {
+		return nil, fmt.Errorf("CheckStuckBlobs: error getting the processing blobs: %w", err)
+	}
+
+	now := time.Now()
+	stuck := make([]*disperser.BlobMetadata, 0)
+	stuckKeys := make(map[disperser.BlobKey]struct{})
+	for _, metadata := range metadatas {
+		if metadata.RequestMetadata == nil {
+			continue
+		}
+		requestedAt := time.Unix(0, int64(metadata.RequestMetadata.RequestedAt))
+		if now.Sub(requestedAt) <= w.SLA {
+			continue
+		}
+		stuck = append(stuck, metadata)
+		stuckKeys[metadata.GetBlobKey()] = struct{}{}
+
+		w.logger.Warn("blob is processing beyond the SLA", "blobKey", metadata.GetBlobKey().String(), "requestedAt", requestedAt, "processingTime", now.Sub(requestedAt), "numRetries", metadata.NumRetries)
+		if w.Redrive {
+			w.redrive(ctx, metadata, now)
+		}
+	}
+	w.metrics.StuckBlobs.Set(float64(len(stuck)))
+
+	for key := range w.redrivenAt {
+		if _, ok := stuckKeys[key]; !ok {
+			delete(w.redrivenAt, key)
+		}
+	}
+	return stuck, nil
+}
+
+// redrive releases the encoding of the stuck blob and increments its retry count, unless it was re-driven less than
+// an SLA ago, it awaits the confirmation of its batch, or the batcher updated it concurrently
+func (w *Watchdog) redrive(ctx context.Context, metadata *disperser.BlobMetadata, now time.Time) {
+	blobKey := metadata.GetBlobKey()
+	if redrivenAt, ok := w.redrivenAt[blobKey]; ok && now.Sub(redrivenAt) <= w.SLA {
+		return
+	}
+	if !w.streamer.ReleaseBlob(metadata) {
+		w.logger.Info("not re-driving the stuck blob awaiting the confirmation of its batch", "blobKey", blobKey.String())
+		return
+	}
+
+	updated, err := w.blobStore.RedriveBlob(ctx, metadata, w.MaxNumRetriesPerBlob)
+	if errors.Is(err, disperser.ErrBlobStateChanged) {
+		w.logger.Info("not re-driving the stuck blob updated concurrently", "blobKey", blobKey.String())
+		return
+	}
+	if err != nil {
+		w.logger.Error("failed to re-drive the stuck blob", "blobKey", blobKey.String(), "err", err)
+		return
+	}
+
+	w.redrivenAt[blobKey] = now
+	if updated.BlobStatus == disperser.Failed {
+		w.metrics.RedrivenBlobs.WithLabelValues("failed").Inc()
+		w.logger.Warn("failed the stuck blob which reached the max number of retries", "blobKey", blobKey.String(), "numRetries", updated.NumRetries)
+		return
+	}
+	w.metrics.RedrivenBlobs.WithLabelValues("retried").Inc()
+	w.logger.Info("re-drove the stuck blob", "blobKey", blobKey.String(), "numRetries", updated.NumRetries)
+}
@@ -0,0 +1,116 @@
+package batcher_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common/logging"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/disperser"
+	bat "github.com/Layr-Labs/eigenda/disperser/batcher"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func makeWatchdog(t *testing.T, components *batcherComponents, b *bat.Batcher, redrive bool) *bat.Watchdog {
+	logger, err := logging.GetLogger(logging.DefaultCLIConfig())
+	assert.NoError(t, err)
+	return bat.NewWatchdog(bat.WatchdogConfig{
+		Interval:             100 * time.Millisecond,
+		SLA:                  30 * time.Minute,
+		Redrive:              redrive,
+		MaxNumRetriesPerBlob: 1,
+	}, components.blobStore, components.encodingStreamer, b.Metrics, logger)
+}
+
+// queueStuckBlob queues a blob requested beyond the SLA, whose encoding request was kept by an earlier iteration of the
+// batcher although its encoding was dropped
+func queueStuckBlob(t *testing.T, ctx context.Context, components *batcherComponents) disperser.BlobKey {
+	blob := makeTestBlob([]*core.SecurityParam{{QuorumID: 0, AdversaryThreshold: 80, QuorumThreshold: 100}})
+	blobKey, err := components.blobStore.StoreBlob(ctx, &blob, uint64(time.Now().Add(-time.Hour).UnixNano()))
+	assert.NoError(t, err)
+	components.encodingStreamer.EncodedBlobstore.PutEncodingRequest(blobKey, 0)
+	return blobKey
+}
+
+func TestWatchdogRedrivesStuckBlob(t *testing.T) {
+	ctx := context.Background()
+	components, b := makeBatcher(t)
+	watchdog := makeWatchdog(t, components, b, true)
+	stuckKey := queueStuckBlob(t, ctx, components)
+	blob := makeTestBlob([]*core.SecurityParam{{QuorumID: 1, AdversaryThreshold: 70, QuorumThreshold: 100}})
+	_, freshKey := queueBlob(t, ctx, &blob, components.blobStore)
+
+	// The stuck blob is not selected by the batcher
+	encodeQueuedBlobs(t, ctx, components.encodingStreamer, 1)
+	_, err := components.encodingStreamer.EncodedBlobstore.GetEncodingResult(stuckKey, 0)
+	assert.Error(t, err)
+
+	// After a single check of the watchdog, it is retried and selected again
+	stuck, err := watchdog.CheckStuckBlobs(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, stuck, 1)
+	assert.Equal(t, stuckKey, stuck[0].GetBlobKey())
+	assert.Equal(t, float64(1), testutil.ToFloat64(b.Metrics.StuckBlobs))
+	assert.Equal(t, float64(1), testutil.ToFloat64(b.Metrics.RedrivenBlobs.WithLabelValues("retried")))
+	metadata, err := components.blobStore.GetBlobMetadata(ctx, stuckKey)
+	assert.NoError(t, err)
+	assert.Equal(t, disperser.Processing, metadata.BlobStatus)
+	assert.Equal(t, uint(1), metadata.NumRetries)
+
+	encodeQueuedBlobs(t, ctx, components.encodingStreamer, 1)
+	_, err = components.encodingStreamer.EncodedBlobstore.GetEncodingResult(stuckKey, 0)
+	assert.NoError(t, err)
+	_, err = components.encodingStreamer.EncodedBlobstore.GetEncodingResult(freshKey, 1)
+	assert.NoError(t, err)
+
+	// The blob is still reported, but given another SLA before it is re-driven again
+	stuck, err = watchdog.CheckStuckBlobs(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, stuck, 1)
+	metadata, err = components.blobStore.GetBlobMetadata(ctx, stuckKey)
+	assert.NoError(t, err)
+	assert.Equal(t, uint(1), metadata.NumRetries)
+	assert.Equal(t, float64(1), testutil.ToFloat64(b.Metrics.RedrivenBlobs.WithLabelValues("retried")))
+}
+
+func TestWatchdogDoesNotRedriveActiveBlobs(t *testing.T) {
+	ctx := context.Background()
+	components, b := makeBatcher(t)
+	stuckKey := queueStuckBlob(t, ctx, components)
+
+	// Without re-driving, the stuck blob is only reported
+	stuck, err := makeWatchdog(t, components, b, false).CheckStuckBlobs(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, stuck, 1)
+	metadata, err := components.blobStore.GetBlobMetadata(ctx, stuckKey)
+	assert.NoError(t, err)
+	assert.Equal(t, uint(0), metadata.NumRetries)
+
+	// The blob awaiting the confirmation of its batch is left to the batcher
+	watchdog := makeWatchdog(t, components, b, true)
+	components.encodingStreamer.SetAwaitingConfirmation([]disperser.BlobKey{stuckKey}, true)
+	stuck, err = watchdog.CheckStuckBlobs(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, stuck, 1)
+	metadata, err = components.blobStore.GetBlobMetadata(ctx, stuckKey)
+	assert.NoError(t, err)
+	assert.Equal(t, uint(0), metadata.NumRetries)
+	assert.Equal(t, float64(0), testutil.ToFloat64(b.Metrics.RedrivenBlobs.WithLabelValues("retried")))
+
+	// Once it reached the max number of retries, the stuck blob is failed
+	components.encodingStreamer.SetAwaitingConfirmation([]disperser.BlobKey{stuckKey}, false)
+	assert.NoError(t, components.blobStore.IncrementBlobRetryCount(ctx, metadata))
+	_, err = watchdog.CheckStuckBlobs(ctx)
+	assert.NoError(t, err)
+	metadata, err = components.blobStore.GetBlobMetadata(ctx, stuckKey)
+	assert.NoError(t, err)
+	assert.Equal(t, disperser.Failed, metadata.BlobStatus)
+	assert.Equal(t, float64(1), testutil.ToFloat64(b.Metrics.RedrivenBlobs.WithLabelValues("failed")))
+
+	stuck, err = watchdog.CheckStuckBlobs(ctx)
+	assert.NoError(t, err)
+	assert.Empty(t, stuck)
+	assert.Equal(t, float64(0), testutil.ToFloat64(b.Metrics.StuckBlobs))
+}
@@ -1,14 +1,22 @@
 package main
 
 import (
+	"errors"
+	"fmt"
+	"time"
+
 	"github.com/Layr-Labs/eigenda/common/aws"
 	"github.com/Layr-Labs/eigenda/common/geth"
+	commongrpc "github.com/Layr-Labs/eigenda/common/grpc"
 	"github.com/Layr-Labs/eigenda/common/logging"
 	"github.com/Layr-Labs/eigenda/common/ratelimit"
+	"github.com/Layr-Labs/eigenda/core/encoding"
+	"github.com/Layr-Labs/eigenda/core/eth"
 	"github.com/Layr-Labs/eigenda/disperser"
 	"github.com/Layr-Labs/eigenda/disperser/apiserver"
 	"github.com/Layr-Labs/eigenda/disperser/cmd/apiserver/flags"
 	"github.com/Layr-Labs/eigenda/disperser/common/blobstore"
+	"github.com/Layr-Labs/eigenda/disperser/common/events"
 	"github.com/urfave/cli"
 )
 
@@ -24,9 +32,28 @@ type Config struct {
 	BucketTableName   string
 	BucketStoreSize   int
 	EthClientConfig   geth.EthClientConfig
+	BreakerConfig     eth.BreakerConfig
+	StatusEventConfig events.Config
 
 	BLSOperatorStateRetrieverAddr string
 	EigenDAServiceManagerAddr     string
+
+	// EnableRetrievalFallback makes the server reconstruct the blobs from the operators when their content is no
+	// longer stored
+	EnableRetrievalFallback bool
+	GraphUrl                string
+	RetrievalTimeout        time.Duration
+	RetrievalNumConnections int
+	EncoderConfig           encoding.EncoderConfig
+	// RetrievalTLSConfig configures the transport security of the connections to the retrieval servers of the nodes
+	RetrievalTLSConfig commongrpc.ClientTLSConfig
+
+	// AuditSink is where the dispersal requests are audited: stdout, file or dynamodb. The requests aren't audited if
+	// it is empty.
+	AuditSink       string
+	AuditFile       string
+	AuditTableName  string
+	AuditSampleRate float64
 }
 
 func NewConfig(ctx *cli.Context) (Config, error) {
@@ -36,22 +63,62 @@ func NewConfig(ctx *cli.Context) (Config, error) {
 		return Config{}, err
 	}
 
+	rateConfig, err := apiserver.ReadCLIConfig(ctx)
+	if err != nil {
+		return Config{}, err
+	}
+
+	var securityPolicy disperser.SecurityPolicy
+	securityPolicyFile := ctx.GlobalString(flags.SecurityPolicyFileFlag.Name)
+	if securityPolicyFile != "" {
+		securityPolicy, err = disperser.ReadSecurityPolicy(securityPolicyFile)
+		if err != nil {
+			return Config{}, err
+		}
+	}
+
 	config := Config{
-		AwsClientConfig: aws.ReadClientConfig(ctx, flags.FlagPrefix),
+		AwsClientConfig:   aws.ReadClientConfig(ctx, flags.FlagPrefix),
+		BreakerConfig:     eth.ReadBreakerCLIConfig(ctx, flags.FlagPrefix),
+		StatusEventConfig: events.ReadCLIConfig(ctx, flags.FlagPrefix),
 		ServerConfig: disperser.ServerConfig{
-			GrpcPort: ctx.GlobalString(flags.GrpcPortFlag.Name),
+			GrpcPort:                     ctx.GlobalString(flags.GrpcPortFlag.Name),
+			TLS:                          commongrpc.ReadTLSCLIConfig(ctx, flags.FlagPrefix),
+			MaxGRPCMessageSize:           commongrpc.ReadMaxMessageSize(ctx, flags.FlagPrefix),
+			DisperseRequestTimeout:       ctx.GlobalDuration(flags.DisperseRequestTimeoutFlag.Name),
+			MaxBlobRetention:             ctx.GlobalDuration(flags.MaxBlobRetentionFlag.Name),
+			EnableReflection:             ctx.GlobalBool(flags.EnableReflectionFlag.Name),
+			ExpectedConfirmationTime:     ctx.GlobalDuration(flags.ExpectedConfirmationTimeFlag.Name),
+			MaxQuorumsPerBlob:            ctx.GlobalUint(flags.MaxQuorumsPerBlobFlag.Name),
+			MaxConcurrentDispersals:      ctx.GlobalInt(flags.MaxConcurrentDispersalsFlag.Name),
+			MaxQueuedDispersals:          ctx.GlobalInt(flags.MaxQueuedDispersalsFlag.Name),
+			DispersalQueueTimeout:        ctx.GlobalDuration(flags.DispersalQueueTimeoutFlag.Name),
+			MinChunkLength:               ctx.GlobalUint(flags.MinChunkLengthFlag.Name),
+			LargeQuorumOperatorThreshold: ctx.GlobalUint(flags.LargeQuorumOperatorThresholdFlag.Name),
+			LargeQuorumMinChunkBytes:     ctx.GlobalUint(flags.LargeQuorumMinChunkBytesFlag.Name),
+			WarmUpEncoder:                ctx.GlobalBoolT(flags.WarmUpEncoderFlag.Name),
+
+			SecurityPolicy:                securityPolicy,
+			SecurityPolicyFile:            securityPolicyFile,
+			SecurityPolicyRefreshInterval: ctx.GlobalDuration(flags.SecurityPolicyRefreshIntervalFlag.Name),
 		},
 		BlobstoreConfig: blobstore.Config{
 			BucketName: ctx.GlobalString(flags.S3BucketNameFlag.Name),
+			KeyPrefix:  ctx.GlobalString(flags.S3KeyPrefixFlag.Name),
 			TableName:  ctx.GlobalString(flags.DynamoDBTableNameFlag.Name),
+			ReplicaConfig: blobstore.ReplicaConfig{
+				ReadTimeout:    ctx.GlobalDuration(flags.S3ReadTimeoutFlag.Name),
+				DemotionPeriod: ctx.GlobalDuration(flags.S3ReplicaDemotionPeriodFlag.Name),
+			},
 		},
 		LoggerConfig: logging.ReadCLIConfig(ctx, flags.FlagPrefix),
 		MetricsConfig: disperser.MetricsConfig{
-			HTTPPort:      ctx.GlobalString(flags.MetricsHTTPPort.Name),
-			EnableMetrics: ctx.GlobalBool(flags.EnableMetrics.Name),
+			HTTPPort:           ctx.GlobalString(flags.MetricsHTTPPort.Name),
+			EnableMetrics:      ctx.GlobalBool(flags.EnableMetrics.Name),
+			NamespaceAllowlist: ctx.GlobalStringSlice(flags.MetricsNamespaceAllowlist.Name),
 		},
 		RatelimiterConfig: ratelimiterConfig,
-		RateConfig:        apiserver.ReadCLIConfig(ctx),
+		RateConfig:        rateConfig,
 		EnableRatelimiter: ctx.GlobalBool(flags.EnableRatelimiter.Name),
 		BucketTableName:   ctx.GlobalString(flags.BucketTableName.Name),
 		BucketStoreSize:   ctx.GlobalInt(flags.BucketStoreSize.Name),
@@ -59,6 +126,60 @@ func NewConfig(ctx *cli.Context) (Config, error) {
 
 		BLSOperatorStateRetrieverAddr: ctx.GlobalString(flags.BlsOperatorStateRetrieverFlag.Name),
 		EigenDAServiceManagerAddr:     ctx.GlobalString(flags.EigenDAServiceManagerFlag.Name),
+
+		EnableRetrievalFallback: ctx.GlobalBool(flags.EnableRetrievalFallbackFlag.Name),
+		GraphUrl:                ctx.GlobalString(flags.GraphUrlFlag.Name),
+		RetrievalTimeout:        ctx.GlobalDuration(flags.RetrievalTimeoutFlag.Name),
+		RetrievalNumConnections: ctx.GlobalInt(flags.RetrievalNumConnectionsFlag.Name),
+		EncoderConfig:           encoding.ReadCLIConfig(ctx),
+		RetrievalTLSConfig:      commongrpc.ReadClientTLSCLIConfig(ctx, flags.FlagPrefix),
+
+		AuditSink:       ctx.GlobalString(flags.AuditSinkFlag.Name),
+		AuditFile:       ctx.GlobalString(flags.AuditFileFlag.Name),
+		AuditTableName:  ctx.GlobalString(flags.AuditTableNameFlag.Name),
+		AuditSampleRate: ctx.GlobalFloat64(flags.AuditSampleRateFlag.Name),
+	}
+	config.BlobstoreConfig.ReplicaBuckets, err = blobstore.ParseReplicaBuckets(ctx.GlobalStringSlice(flags.S3ReplicaBucketsFlag.Name))
+	if err != nil {
+		return Config{}, err
+	}
+	if err := config.Validate(); err != nil {
+		return Config{}, err
 	}
 	return config, nil
 }
+
+// Validate checks the config before any component of the server starts, and returns all the violations at once
+func (c Config) Validate() error {
+	errs := []error{
+		c.ServerConfig.Validate(),
+		c.RateConfig.Validate(),
+		c.BlobstoreConfig.Validate(),
+	}
+	if err := c.RetrievalTLSConfig.Validate(); err != nil {
+		errs = append(errs, fmt.Errorf("invalid retrieval TLS config: %w", err))
+	}
+	if c.EnableRatelimiter && c.BucketTableName == "" && c.BucketStoreSize <= 0 {
+		errs = append(errs, fmt.Errorf("the rate limiter requires a bucket table name or a positive bucket store size, but found %d", c.BucketStoreSize))
+	}
+	if c.EnableRetrievalFallback && (c.GraphUrl == "" || c.EncoderConfig.KzgConfig.G1Path == "") {
+		errs = append(errs, errors.New("the retrieval fallback requires the graph url and the kzg flags"))
+	}
+	switch c.AuditSink {
+	case "", "stdout":
+	case "file":
+		if c.AuditFile == "" {
+			errs = append(errs, errors.New("the file audit sink requires the audit file"))
+		}
+	case "dynamodb":
+		if c.AuditTableName == "" {
+			errs = append(errs, errors.New("the dynamodb audit sink requires the audit table name"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("unknown audit sink %q, expected stdout, file or dynamodb", c.AuditSink))
+	}
+	if c.AuditSampleRate < 0 || c.AuditSampleRate > 1 {
+		errs = append(errs, fmt.Errorf("the audit sample rate must be in [0, 1], but found %v", c.AuditSampleRate))
+	}
+	return errors.Join(errs...)
+}
@@ -1,12 +1,18 @@
 package flags
 
 import (
+	"time"
+
 	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/common/aws"
 	"github.com/Layr-Labs/eigenda/common/geth"
+	commongrpc "github.com/Layr-Labs/eigenda/common/grpc"
 	"github.com/Layr-Labs/eigenda/common/logging"
 	"github.com/Layr-Labs/eigenda/common/ratelimit"
+	"github.com/Layr-Labs/eigenda/core/encoding"
+	"github.com/Layr-Labs/eigenda/core/eth"
 	"github.com/Layr-Labs/eigenda/disperser/apiserver"
+	"github.com/Layr-Labs/eigenda/disperser/common/events"
 	"github.com/urfave/cli"
 )
 
@@ -23,6 +29,32 @@ var (
 		Required: true,
 		EnvVar:   common.PrefixEnvVar(envVarPrefix, "S3_BUCKET_NAME"),
 	}
+	S3KeyPrefixFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "s3-key-prefix"),
+		Usage:    "Prefix of the keys of the blobs stored in the bucket, e.g. to separate environments sharing a bucket. Blobs are stored at the root of the bucket if empty",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "S3_KEY_PREFIX"),
+	}
+	S3ReplicaBucketsFlag = cli.StringSliceFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "s3-replica-buckets"),
+		Usage:    "Replicas of the bucket in other regions, as region:bucket, read in order when the bucket fails to serve the blobs. The blobs are written to the bucket only",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "S3_REPLICA_BUCKETS"),
+	}
+	S3ReadTimeoutFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "s3-read-timeout"),
+		Usage:    "Timeout of each attempt at reading an object from the bucket or one of its replicas. Unbounded if 0",
+		Required: false,
+		Value:    30 * time.Second,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "S3_READ_TIMEOUT"),
+	}
+	S3ReplicaDemotionPeriodFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "s3-replica-demotion-period"),
+		Usage:    "Duration for which the bucket or a replica whose reads keep failing is read after the others",
+		Required: false,
+		Value:    time.Minute,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "S3_REPLICA_DEMOTION_PERIOD"),
+	}
 	DynamoDBTableNameFlag = cli.StringFlag{
 		Name:     common.PrefixFlag(FlagPrefix, "dynamodb-table-name"),
 		Usage:    "Name of the dynamodb table to store blob metadata",
@@ -61,6 +93,12 @@ var (
 		Required: true,
 		EnvVar:   common.PrefixEnvVar(envVarPrefix, "ENABLE_METRICS"),
 	}
+	MetricsNamespaceAllowlist = cli.StringSliceFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "metrics-namespace-allowlist"),
+		Usage:    "namespaces reported as their own label in the dispersal metrics; requests from other namespaces are reported as \"other\"",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "METRICS_NAMESPACE_ALLOWLIST"),
+	}
 	EnableRatelimiter = cli.BoolFlag{
 		Name:   common.PrefixFlag(FlagPrefix, "enable-ratelimiter"),
 		Usage:  "enable rate limiter",
@@ -79,6 +117,153 @@ var (
 		EnvVar:   common.PrefixEnvVar(envVarPrefix, "RATE_BUCKET_STORE_SIZE"),
 		Required: false,
 	}
+	EnableRetrievalFallbackFlag = cli.BoolFlag{
+		Name:   common.PrefixFlag(FlagPrefix, "enable-retrieval-fallback"),
+		Usage:  "reconstruct the confirmed blobs from the operators when their content is no longer stored. Requires the graph url and the kzg flags",
+		EnvVar: common.PrefixEnvVar(envVarPrefix, "ENABLE_RETRIEVAL_FALLBACK"),
+	}
+	GraphUrlFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "graph-url"),
+		Usage:    "The url of the graph node, to get the operator state when reconstructing blobs",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "GRAPH_URL"),
+	}
+	RetrievalTimeoutFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "retrieval-timeout"),
+		Usage:    "timeout of the chunk requests to the operators when reconstructing blobs",
+		Required: false,
+		Value:    30 * time.Second,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "RETRIEVAL_TIMEOUT"),
+	}
+	DisperseRequestTimeoutFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "disperse-request-timeout"),
+		Usage:    "maximum duration of a DisperseBlob request, after which it is aborted without storing the blob, unless the client set an earlier deadline. 0 disables the timeout",
+		Required: false,
+		Value:    30 * time.Second,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "DISPERSE_REQUEST_TIMEOUT"),
+	}
+	MaxBlobRetentionFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "max-blob-retention"),
+		Usage:    "maximum total retention of a blob, from its dispersal, up to which trusted callers can extend its retention. 0 disables the extensions",
+		Required: false,
+		Value:    0,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "MAX_BLOB_RETENTION"),
+	}
+	EnableReflectionFlag = cli.BoolFlag{
+		Name:   common.PrefixFlag(FlagPrefix, "enable-reflection"),
+		Usage:  "register the gRPC reflection service, so that reflection clients such as grpcurl can list the methods of the server. Meant for local debugging, not for production",
+		EnvVar: common.PrefixEnvVar(envVarPrefix, "ENABLE_REFLECTION"),
+	}
+	ExpectedConfirmationTimeFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "expected-confirmation-time"),
+		Usage:    "typical time from the dispersal of a blob to its confirmation, from which the delays after which clients are recommended to poll the status of the processing blobs again are derived. 0 disables the recommendations",
+		Required: false,
+		Value:    0,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "EXPECTED_CONFIRMATION_TIME"),
+	}
+	MaxQuorumsPerBlobFlag = cli.UintFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "max-quorums-per-blob"),
+		Usage:    "maximum number of quorums a blob may be dispersed to, bounding the cost of its encoding. 0 defaults to the onchain quorum count",
+		Required: false,
+		Value:    0,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "MAX_QUORUMS_PER_BLOB"),
+	}
+	MaxConcurrentDispersalsFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "max-concurrent-dispersals"),
+		Usage:    "maximum number of DisperseBlob requests processed at once. 0 disables the admission control",
+		Required: false,
+		Value:    0,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "MAX_CONCURRENT_DISPERSALS"),
+	}
+	MaxQueuedDispersalsFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "max-queued-dispersals"),
+		Usage:    "maximum number of DisperseBlob requests waiting to be processed, beyond which the requests are shed with a ResourceExhausted error",
+		Required: false,
+		Value:    100,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "MAX_QUEUED_DISPERSALS"),
+	}
+	DispersalQueueTimeoutFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "dispersal-queue-timeout"),
+		Usage:    "maximum duration a DisperseBlob request waits to be processed before it is shed. 0 bounds the wait by the request deadline only",
+		Required: false,
+		Value:    time.Second,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "DISPERSAL_QUEUE_TIMEOUT"),
+	}
+	MinChunkLengthFlag = cli.UintFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "min-chunk-length"),
+		Usage:    "floor of the chunk lengths of the batcher, in symbols, with which the encoded lengths of the dry runs are estimated",
+		Required: false,
+		Value:    0,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "MIN_CHUNK_LENGTH"),
+	}
+	LargeQuorumOperatorThresholdFlag = cli.UintFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "large-quorum-operator-threshold"),
+		Usage:    "large quorum operator threshold of the batcher, with which the encoded lengths of the dry runs are estimated",
+		Required: false,
+		Value:    0,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "LARGE_QUORUM_OPERATOR_THRESHOLD"),
+	}
+	LargeQuorumMinChunkBytesFlag = cli.UintFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "large-quorum-min-chunk-bytes"),
+		Usage:    "large quorum minimum chunk size of the batcher, in bytes, with which the encoded lengths of the dry runs are estimated",
+		Required: false,
+		Value:    0,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "LARGE_QUORUM_MIN_CHUNK_BYTES"),
+	}
+	WarmUpEncoderFlag = cli.BoolTFlag{
+		Name:   common.PrefixFlag(FlagPrefix, "warm-up-encoder"),
+		Usage:  "encode a blob of each length up to the max blob size at startup, reporting the server as not serving until done, so that the first dry runs and verified retrievals are fast. Requires the kzg flags",
+		EnvVar: common.PrefixEnvVar(envVarPrefix, "WARM_UP_ENCODER"),
+	}
+	SecurityPolicyFileFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "security-policy-file"),
+		Usage:    "Path to a JSON file bounding the security params of the dispersed blobs per quorum, e.g. {\"default\": {\"min_adversary_threshold\": 10}, \"quorums\": {\"0\": {\"min_adversary_threshold\": 33, \"min_quorum_threshold\": 55}}}",
+		Required: false,
+		Value:    "",
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "SECURITY_POLICY_FILE"),
+	}
+	SecurityPolicyRefreshIntervalFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "security-policy-refresh-interval"),
+		Usage:    "Interval at which the security policy file is reloaded",
+		Required: false,
+		Value:    time.Minute,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "SECURITY_POLICY_REFRESH_INTERVAL"),
+	}
+	RetrievalNumConnectionsFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "retrieval-num-connections"),
+		Usage:    "maximum number of connections to the operators when reconstructing a blob",
+		Required: false,
+		Value:    20,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "RETRIEVAL_NUM_CONNECTIONS"),
+	}
+	AuditSinkFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "audit-sink"),
+		Usage:    "Where the dispersal requests are audited: stdout, file or dynamodb. The requests aren't audited if empty",
+		Required: false,
+		Value:    "",
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "AUDIT_SINK"),
+	}
+	AuditFileFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "audit-file"),
+		Usage:    "Path to the file to which the audit events are appended, as JSON lines, with the file audit sink",
+		Required: false,
+		Value:    "",
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "AUDIT_FILE"),
+	}
+	AuditTableNameFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "audit-table-name"),
+		Usage:    "Name of the DynamoDB table storing the audit events with the dynamodb audit sink",
+		Required: false,
+		Value:    "",
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "AUDIT_TABLE_NAME"),
+	}
+	AuditSampleRateFlag = cli.Float64Flag{
+		Name:     common.PrefixFlag(FlagPrefix, "audit-sample-rate"),
+		Usage:    "Fraction of the accepted dispersal requests which are audited, sampled by payload hash. The rejected requests are always audited",
+		Required: false,
+		Value:    0.01,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "AUDIT_SAMPLE_RATE"),
+	}
 )
 
 var requiredFlags = []cli.Flag{
@@ -91,10 +276,37 @@ var requiredFlags = []cli.Flag{
 }
 
 var optionalFlags = []cli.Flag{
+	S3KeyPrefixFlag,
+	S3ReplicaBucketsFlag,
+	S3ReadTimeoutFlag,
+	S3ReplicaDemotionPeriodFlag,
 	MetricsHTTPPort,
 	EnableMetrics,
+	MetricsNamespaceAllowlist,
 	EnableRatelimiter,
 	BucketStoreSize,
+	EnableRetrievalFallbackFlag,
+	GraphUrlFlag,
+	RetrievalTimeoutFlag,
+	RetrievalNumConnectionsFlag,
+	DisperseRequestTimeoutFlag,
+	MaxBlobRetentionFlag,
+	EnableReflectionFlag,
+	ExpectedConfirmationTimeFlag,
+	MaxQuorumsPerBlobFlag,
+	MaxConcurrentDispersalsFlag,
+	MaxQueuedDispersalsFlag,
+	DispersalQueueTimeoutFlag,
+	MinChunkLengthFlag,
+	LargeQuorumOperatorThresholdFlag,
+	LargeQuorumMinChunkBytesFlag,
+	WarmUpEncoderFlag,
+	SecurityPolicyFileFlag,
+	SecurityPolicyRefreshIntervalFlag,
+	AuditSinkFlag,
+	AuditFileFlag,
+	AuditTableNameFlag,
+	AuditSampleRateFlag,
 }
 
 // Flags contains the list of configuration options available to the binary.
@@ -103,8 +315,14 @@ var Flags []cli.Flag
 func init() {
 	Flags = append(requiredFlags, optionalFlags...)
 	Flags = append(Flags, geth.EthClientFlags(envVarPrefix)...)
+	Flags = append(Flags, eth.BreakerCLIFlags(envVarPrefix, FlagPrefix)...)
+	Flags = append(Flags, events.CLIFlags(envVarPrefix, FlagPrefix)...)
 	Flags = append(Flags, logging.CLIFlags(envVarPrefix, FlagPrefix)...)
+	Flags = append(Flags, commongrpc.TLSCLIFlags(envVarPrefix, FlagPrefix)...)
+	Flags = append(Flags, commongrpc.ClientTLSCLIFlags(envVarPrefix, FlagPrefix)...)
+	Flags = append(Flags, commongrpc.MaxMessageSizeCLIFlag(envVarPrefix, FlagPrefix, 1024*1024*300)) // 300 MiB
 	Flags = append(Flags, ratelimit.RatelimiterCLIFlags(envVarPrefix, FlagPrefix)...)
 	Flags = append(Flags, aws.ClientFlags(envVarPrefix, FlagPrefix)...)
 	Flags = append(Flags, apiserver.CLIFlags(envVarPrefix)...)
+	Flags = append(Flags, encoding.OptionalCLIFlags(common.PrefixEnvVar(envVarPrefix, "KZG"))...)
 }
@@ -5,11 +5,15 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"os/signal"
+	"syscall"
 	"time"
 
+	"github.com/Layr-Labs/eigenda/clients"
 	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/disperser/apiserver"
 	"github.com/Layr-Labs/eigenda/disperser/common/blobstore"
+	"github.com/Layr-Labs/eigenda/disperser/common/events"
 
 	"github.com/Layr-Labs/eigenda/common/aws/dynamodb"
 	"github.com/Layr-Labs/eigenda/common/aws/s3"
@@ -17,9 +21,13 @@ import (
 	"github.com/Layr-Labs/eigenda/common/logging"
 	"github.com/Layr-Labs/eigenda/common/ratelimit"
 	"github.com/Layr-Labs/eigenda/common/store"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/core/encoding"
 	"github.com/Layr-Labs/eigenda/core/eth"
+	"github.com/Layr-Labs/eigenda/core/thegraph"
 	"github.com/Layr-Labs/eigenda/disperser"
 	"github.com/Layr-Labs/eigenda/disperser/cmd/apiserver/flags"
+	"github.com/shurcooL/graphql"
 	"github.com/urfave/cli"
 )
 
@@ -43,8 +51,6 @@ func main() {
 	if err != nil {
 		log.Fatalf("application failed: %v", err)
 	}
-
-	select {}
 }
 
 func RunDisperserServer(ctx *cli.Context) error {
@@ -64,10 +70,19 @@ func RunDisperserServer(ctx *cli.Context) error {
 		return err
 	}
 
-	transactor, err := eth.NewTransactor(logger, client, config.BLSOperatorStateRetrieverAddr, config.EigenDAServiceManagerAddr)
+	// TODO: create a separate metrics for batcher
+	metrics := disperser.NewMetrics(config.MetricsConfig.HTTPPort, config.MetricsConfig.NamespaceAllowlist, logger)
+
+	ethTransactor, err := eth.NewTransactor(logger, client, config.BLSOperatorStateRetrieverAddr, config.EigenDAServiceManagerAddr)
 	if err != nil {
 		return err
 	}
+	var transactor core.Transactor = ethTransactor
+	if config.BreakerConfig.FailureThreshold > 0 {
+		transactor = eth.NewBreakerTransactor(ethTransactor, config.BreakerConfig, logger, func(state eth.BreakerState) {
+			metrics.UpdateTransactorBreakerState(state.String())
+		})
+	}
 	blockStaleMeasure, err := transactor.GetBlockStaleMeasure(context.Background())
 	if err != nil {
 		return fmt.Errorf("failed to get BLOCK_STALE_MEASURE: %w", err)
@@ -88,14 +103,31 @@ func RunDisperserServer(ctx *cli.Context) error {
 	}
 
 	bucketName := config.BlobstoreConfig.BucketName
-	logger.Info("Creating blob store", "bucket", bucketName)
+	logger.Info("Creating blob store", "bucket", bucketName, "keyPrefix", config.BlobstoreConfig.KeyPrefix)
 	blobMetadataStore := blobstore.NewBlobMetadataStore(dynamoClient, logger, config.BlobstoreConfig.TableName, time.Duration((storeDurationBlocks+blockStaleMeasure)*12)*time.Second)
-	blobStore := blobstore.NewSharedStorage(bucketName, s3Client, blobMetadataStore, logger)
+	blobStore := blobstore.NewSharedStorage(bucketName, config.BlobstoreConfig.KeyPrefix, s3Client, blobMetadataStore, logger)
+	replicas, err := blobstore.NewReplicaEndpoints(context.Background(), config.AwsClientConfig, config.BlobstoreConfig.ReplicaBuckets, logger)
+	if err != nil {
+		return err
+	}
+	blobStore.SetReadReplicas(replicas, config.BlobstoreConfig.ReplicaConfig, metrics.IncrementFallbackReads)
+	statusEventSink, err := events.NewSink(context.Background(), config.StatusEventConfig, config.AwsClientConfig)
+	if err != nil {
+		return err
+	}
+	var statusEvents *events.Publisher
+	if statusEventSink != nil {
+		statusEvents = events.NewPublisher(statusEventSink, config.StatusEventConfig.QueueSize, logger, metrics.IncrementStatusEventsDropped)
+		blobMetadataStore.SetStatusEventPublisher(statusEvents)
+		logger.Info("Enabled the publication of the blob status events", "sink", config.StatusEventConfig.Sink)
+	}
 
 	var ratelimiter common.RateLimiter
+	var quotaStore apiserver.QuotaStore
 	if config.EnableRatelimiter {
 		globalParams := config.RatelimiterConfig.GlobalRateParams
 
+		// The usage of the daily quotas is stored along with the rate buckets
 		var bucketStore common.KVStore[common.RateBucketParams]
 		if config.BucketTableName != "" {
 			dynamoClient, err := dynamodb.NewClient(config.AwsClientConfig, logger)
@@ -103,25 +135,119 @@ func RunDisperserServer(ctx *cli.Context) error {
 				return err
 			}
 			bucketStore = store.NewDynamoParamStore[common.RateBucketParams](dynamoClient, config.BucketTableName)
+			quotaStore = store.NewDynamoParamStore[apiserver.QuotaUsage](dynamoClient, config.BucketTableName)
 		} else {
 			bucketStore, err = store.NewLocalParamStore[common.RateBucketParams](config.BucketStoreSize)
 			if err != nil {
 				return err
 			}
+			quotaStore, err = store.NewLocalParamStore[apiserver.QuotaUsage](config.BucketStoreSize)
+			if err != nil {
+				return err
+			}
 		}
 		ratelimiter = ratelimit.NewRateLimiter(globalParams, bucketStore, logger)
 	}
 
-	// TODO: create a separate metrics for batcher
-	metrics := disperser.NewMetrics(config.MetricsConfig.HTTPPort, logger)
 	server := apiserver.NewDispersalServer(config.ServerConfig, blobStore, transactor, logger, metrics, ratelimiter, config.RateConfig)
+	if quotaStore != nil && (config.RateConfig.DailyQuota > 0 || len(config.RateConfig.AccountDailyQuotas) > 0) {
+		server.SetQuotaStore(quotaStore)
+		logger.Info("Enabled the daily quotas", "dailyQuota", config.RateConfig.DailyQuota, "numAccountQuotas", len(config.RateConfig.AccountDailyQuotas))
+	}
+	// The encoder is only created when the SRS is configured, for the retrieval fallback, the commitments of the
+	// dry runs and the verified retrievals
+	var encoder *encoding.Encoder
+	if config.EncoderConfig.KzgConfig.G1Path != "" {
+		encoder, err = encoding.NewEncoder(config.EncoderConfig)
+		if err != nil {
+			return fmt.Errorf("failed to create the encoder: %w", err)
+		}
+		server.SetEncoder(encoder)
+	}
+	if config.EnableRetrievalFallback {
+		cs := eth.NewChainState(transactor, client)
+		querier := graphql.NewClient(config.GraphUrl, nil)
+		ics := thegraph.NewIndexedChainState(cs, querier, logger)
+		if err := ics.Start(context.Background()); err != nil {
+			return fmt.Errorf("failed to start the indexed chain state for the retrieval fallback: %w", err)
+		}
+		nodeCreds, err := config.RetrievalTLSConfig.Credentials()
+		if err != nil {
+			return fmt.Errorf("failed to create the node credentials: %w", err)
+		}
+		nodeClient := clients.NewNodeClient(config.RetrievalTimeout, config.ServerConfig.MaxGRPCMessageSize, nodeCreds)
+		retrievalClient := clients.NewRetrievalClient(logger, ics, &core.StdAssignmentCoordinator{}, nodeClient, encoder, config.RetrievalNumConnections)
+		server.SetRetrievalClient(retrievalClient)
+		logger.Info("Enabled the retrieval fallback", "graphUrl", config.GraphUrl)
+	}
+	if config.AuditSink != "" {
+		auditSink, err := newAuditSink(config, dynamoClient)
+		if err != nil {
+			return err
+		}
+		server.SetRequestAuditor(apiserver.NewRequestAuditor(auditSink, config.AuditSampleRate, logger))
+		logger.Info("Enabled the audit of the dispersal requests", "sink", config.AuditSink, "sampleRate", config.AuditSampleRate)
+	}
+
+	// The components are started in order and stopped in the reverse order, so that the server stops serving before
+	// the metrics and the cleanups of the blob store are stopped
+	components := []common.Component{
+		common.NewBackgroundComponent("blob store cleanup", func(ctx context.Context) error {
+			blobStore.StartCleanupRetries(ctx)
+			return nil
+		}),
+	}
+	if statusEvents != nil {
+		components = append(components, common.NewBackgroundComponent("status event publisher", func(ctx context.Context) error {
+			statusEvents.Start(ctx)
+			return nil
+		}))
+	}
 
 	// Enable Metrics Block
 	if config.MetricsConfig.EnableMetrics {
 		httpSocket := fmt.Sprintf(":%s", config.MetricsConfig.HTTPPort)
-		metrics.Start(context.Background())
-		logger.Info("Enabled metrics for Disperser", "socket", httpSocket)
+		components = append(components, common.NewComponent("metrics", func(ctx context.Context) error {
+			metrics.Start(ctx)
+			logger.Info("Enabled metrics for Disperser", "socket", httpSocket)
+			return nil
+		}, metrics.Stop))
 	}
 
-	return server.Start(context.Background())
+	// SIGUSR1 drains the server ahead of the maintenance of the disperser, along with the batcher
+	drainSignal := make(chan os.Signal, 1)
+	signal.Notify(drainSignal, syscall.SIGUSR1)
+	go func() {
+		for range drainSignal {
+			server.Drain()
+		}
+	}()
+
+	serverCtx, cancelServer := context.WithCancel(context.Background())
+	components = append(components, common.NewServerComponent("dispersal server", func() error {
+		return server.Start(serverCtx)
+	}, func(ctx context.Context) error {
+		defer cancelServer()
+		return server.Stop(ctx)
+	}))
+
+	return common.Run(context.Background(), logger, 0, components...)
+}
+
+// newAuditSink creates the sink of the audit events of the dispersal requests
+func newAuditSink(config Config, dynamoClient *dynamodb.Client) (apiserver.AuditSink, error) {
+	switch config.AuditSink {
+	case "stdout":
+		return apiserver.NewWriterAuditSink(os.Stdout), nil
+	case "file":
+		file, err := os.OpenFile(config.AuditFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open the audit file: %w", err)
+		}
+		return apiserver.NewWriterAuditSink(file), nil
+	case "dynamodb":
+		return apiserver.NewDynamoDBAuditSink(dynamoClient, config.AuditTableName), nil
+	default:
+		return nil, fmt.Errorf("unknown audit sink %q", config.AuditSink)
+	}
 }
@@ -1,55 +1,114 @@
 package main
 
 import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
 	"github.com/Layr-Labs/eigenda/common/aws"
 	"github.com/Layr-Labs/eigenda/common/geth"
+	commongrpc "github.com/Layr-Labs/eigenda/common/grpc"
 	"github.com/Layr-Labs/eigenda/common/logging"
+	"github.com/Layr-Labs/eigenda/core"
 	"github.com/Layr-Labs/eigenda/core/encoding"
+	coreeth "github.com/Layr-Labs/eigenda/core/eth"
 	"github.com/Layr-Labs/eigenda/disperser/batcher"
 	"github.com/Layr-Labs/eigenda/disperser/cmd/batcher/flags"
 	"github.com/Layr-Labs/eigenda/disperser/common/blobstore"
+	"github.com/Layr-Labs/eigenda/disperser/common/events"
 	"github.com/Layr-Labs/eigenda/indexer"
 	"github.com/urfave/cli"
 )
 
 type Config struct {
-	BatcherConfig   batcher.Config
-	TimeoutConfig   batcher.TimeoutConfig
-	BlobstoreConfig blobstore.Config
-	EthClientConfig geth.EthClientConfig
-	AwsClientConfig aws.ClientConfig
-	EncoderConfig   encoding.EncoderConfig
-	LoggerConfig    logging.Config
-	MetricsConfig   batcher.MetricsConfig
-	IndexerConfig   indexer.Config
-	GraphUrl        string
-	UseGraph        bool
+	BatcherConfig     batcher.Config
+	TimeoutConfig     batcher.TimeoutConfig
+	BlobstoreConfig   blobstore.Config
+	EthClientConfig   geth.EthClientConfig
+	BreakerConfig     coreeth.BreakerConfig
+	StatusEventConfig events.Config
+	AwsClientConfig   aws.ClientConfig
+	EncoderConfig     encoding.EncoderConfig
+	LoggerConfig      logging.Config
+	MetricsConfig     batcher.MetricsConfig
+	IndexerConfig     indexer.Config
+	GraphUrl          string
+	UseGraph          bool
 
 	IndexerDataDir string
 
+	// EncoderHealthCheckInterval is the interval at which the health of the encoder servers is checked
+	EncoderHealthCheckInterval time.Duration
+
+	// EnableConfirmationQueue decouples the aggregation of the batches from their confirmation
+	EnableConfirmationQueue bool
+	// ConfirmationQueueTableName is the name of the DynamoDB table persisting the confirmation queue. The queue is
+	// kept in memory when it is empty.
+	ConfirmationQueueTableName string
+
+	// OperatorSocketRefreshInterval is the interval at which the operator sockets are fully refreshed from the chain
+	OperatorSocketRefreshInterval time.Duration
+
+	// MaxGRPCMessageSize is the max size in bytes of the StoreChunks request sent to each operator, which bounds the
+	// chunks of a batch an operator can be sent
+	MaxGRPCMessageSize int
+	// NodeMaxGRPCMessageSize is the max size in bytes of the messages the nodes are configured to receive
+	NodeMaxGRPCMessageSize int
+	// NodeTLSConfig configures the transport security of the connections to the dispersal servers of the nodes
+	NodeTLSConfig commongrpc.ClientTLSConfig
+
+	// AccountUsageTableName is the name of the DynamoDB table recording the bytes confirmed for each account per day.
+	// The daily quotas are enforced only when it is set.
+	AccountUsageTableName string
+
 	BLSOperatorStateRetrieverAddr string
 	EigenDAServiceManagerAddr     string
 }
 
-func NewConfig(ctx *cli.Context) Config {
+func NewConfig(ctx *cli.Context) (Config, error) {
 	config := Config{
 		BlobstoreConfig: blobstore.Config{
 			BucketName: ctx.GlobalString(flags.S3BucketNameFlag.Name),
+			KeyPrefix:  ctx.GlobalString(flags.S3KeyPrefixFlag.Name),
 			TableName:  ctx.GlobalString(flags.DynamoDBTableNameFlag.Name),
+			ReplicaConfig: blobstore.ReplicaConfig{
+				ReadTimeout:    ctx.GlobalDuration(flags.S3ReadTimeoutFlag.Name),
+				DemotionPeriod: ctx.GlobalDuration(flags.S3ReplicaDemotionPeriodFlag.Name),
+			},
 		},
-		EthClientConfig: geth.ReadEthClientConfig(ctx),
-		AwsClientConfig: aws.ReadClientConfig(ctx, flags.FlagPrefix),
-		EncoderConfig:   encoding.ReadCLIConfig(ctx),
-		LoggerConfig:    logging.ReadCLIConfig(ctx, flags.FlagPrefix),
+		EthClientConfig:   geth.ReadEthClientConfig(ctx),
+		BreakerConfig:     coreeth.ReadBreakerCLIConfig(ctx, flags.FlagPrefix),
+		StatusEventConfig: events.ReadCLIConfig(ctx, flags.FlagPrefix),
+		AwsClientConfig:   aws.ReadClientConfig(ctx, flags.FlagPrefix),
+		EncoderConfig:     encoding.ReadCLIConfig(ctx),
+		LoggerConfig:      logging.ReadCLIConfig(ctx, flags.FlagPrefix),
 		BatcherConfig: batcher.Config{
-			PullInterval:             ctx.GlobalDuration(flags.PullIntervalFlag.Name),
-			FinalizerInterval:        ctx.GlobalDuration(flags.FinalizerIntervalFlag.Name),
-			EncoderSocket:            ctx.GlobalString(flags.EncoderSocket.Name),
-			NumConnections:           ctx.GlobalInt(flags.NumConnectionsFlag.Name),
-			EncodingRequestQueueSize: ctx.GlobalInt(flags.EncodingRequestQueueSizeFlag.Name),
-			BatchSizeMBLimit:         ctx.GlobalUint(flags.BatchSizeLimitFlag.Name),
-			SRSOrder:                 ctx.GlobalInt(flags.SRSOrderFlag.Name),
-			MaxNumRetriesPerBlob:     ctx.GlobalUint(flags.MaxNumRetriesPerBlobFlag.Name),
+			PullInterval:                 ctx.GlobalDuration(flags.PullIntervalFlag.Name),
+			FinalizerInterval:            ctx.GlobalDuration(flags.FinalizerIntervalFlag.Name),
+			EncoderSockets:               ctx.GlobalStringSlice(flags.EncoderSocket.Name),
+			NumConnections:               ctx.GlobalInt(flags.NumConnectionsFlag.Name),
+			EncodingRequestQueueSize:     ctx.GlobalInt(flags.EncodingRequestQueueSizeFlag.Name),
+			BatchSizeMBLimit:             ctx.GlobalUint(flags.BatchSizeLimitFlag.Name),
+			SRSOrder:                     ctx.GlobalInt(flags.SRSOrderFlag.Name),
+			MaxNumRetriesPerBlob:         ctx.GlobalUint(flags.MaxNumRetriesPerBlobFlag.Name),
+			MinSignedPercentage:          uint8(ctx.GlobalUint(flags.MinSignedPercentageFlag.Name)),
+			ConfirmationRetryInterval:    ctx.GlobalDuration(flags.ConfirmationRetryIntervalFlag.Name),
+			MaxConfirmationAttempts:      ctx.GlobalUint(flags.MaxConfirmationAttemptsFlag.Name),
+			MaxConfirmationBlockAge:      ctx.GlobalUint(flags.MaxConfirmationBlockAgeFlag.Name),
+			MaxReferenceBlockAge:         ctx.GlobalUint(flags.MaxReferenceBlockAgeFlag.Name),
+			EncodingOverprovisionPercent: ctx.GlobalUint(flags.EncodingOverprovisionPercentFlag.Name),
+			StuckBlobSLA:                 ctx.GlobalDuration(flags.StuckBlobSLAFlag.Name),
+			WatchdogInterval:             ctx.GlobalDuration(flags.WatchdogIntervalFlag.Name),
+			RedriveStuckBlobs:            ctx.GlobalBool(flags.RedriveStuckBlobsFlag.Name),
+			DailyQuota:                   ctx.GlobalUint64(flags.DailyQuotaFlag.Name),
+			QuotaGracePeriod:             ctx.GlobalDuration(flags.QuotaGracePeriodFlag.Name),
+			MaxBlobWait:                  ctx.GlobalDuration(flags.MaxBlobWaitFlag.Name),
+			FeatureStakeThreshold:        uint8(ctx.GlobalUint(flags.FeatureStakeThresholdFlag.Name)),
+			MinChunkLength:               ctx.GlobalUint(flags.MinChunkLengthFlag.Name),
+			LargeQuorumOperatorThreshold: ctx.GlobalUint(flags.LargeQuorumOperatorThresholdFlag.Name),
+			LargeQuorumMinChunkBytes:     ctx.GlobalUint(flags.LargeQuorumMinChunkBytesFlag.Name),
 		},
 		TimeoutConfig: batcher.TimeoutConfig{
 			EncodingTimeout:    ctx.GlobalDuration(flags.EncodingTimeoutFlag.Name),
@@ -58,8 +117,9 @@ func NewConfig(ctx *cli.Context) Config {
 			ChainWriteTimeout:  ctx.GlobalDuration(flags.ChainWriteTimeoutFlag.Name),
 		},
 		MetricsConfig: batcher.MetricsConfig{
-			HTTPPort:      ctx.GlobalString(flags.MetricsHTTPPort.Name),
-			EnableMetrics: ctx.GlobalBool(flags.EnableMetrics.Name),
+			HTTPPort:         ctx.GlobalString(flags.MetricsHTTPPort.Name),
+			EnableMetrics:    ctx.GlobalBool(flags.EnableMetrics.Name),
+			AccountAllowlist: ctx.GlobalStringSlice(flags.MetricsAccountAllowlistFlag.Name),
 		},
 		UseGraph:                      ctx.Bool(flags.UseGraphFlag.Name),
 		GraphUrl:                      ctx.GlobalString(flags.GraphUrlFlag.Name),
@@ -67,6 +127,82 @@ func NewConfig(ctx *cli.Context) Config {
 		EigenDAServiceManagerAddr:     ctx.GlobalString(flags.EigenDAServiceManagerFlag.Name),
 		IndexerDataDir:                ctx.GlobalString(flags.IndexerDataDirFlag.Name),
 		IndexerConfig:                 indexer.ReadIndexerConfig(ctx),
+		EncoderHealthCheckInterval:    ctx.GlobalDuration(flags.EncoderHealthCheckIntervalFlag.Name),
+		EnableConfirmationQueue:       ctx.GlobalBool(flags.EnableConfirmationQueueFlag.Name),
+		ConfirmationQueueTableName:    ctx.GlobalString(flags.ConfirmationQueueTableNameFlag.Name),
+		OperatorSocketRefreshInterval: ctx.GlobalDuration(flags.OperatorSocketRefreshIntervalFlag.Name),
+		MaxGRPCMessageSize:            commongrpc.ReadMaxMessageSize(ctx, flags.FlagPrefix),
+		NodeMaxGRPCMessageSize:        ctx.GlobalInt(flags.NodeMaxGRPCMessageSizeFlag.Name),
+		NodeTLSConfig:                 commongrpc.ReadClientTLSCLIConfig(ctx, flags.FlagPrefix),
+		AccountUsageTableName:         ctx.GlobalString(flags.AccountUsageTableNameFlag.Name),
+	}
+	replicaBuckets, err := blobstore.ParseReplicaBuckets(ctx.GlobalStringSlice(flags.S3ReplicaBucketsFlag.Name))
+	if err != nil {
+		return Config{}, err
+	}
+	config.BlobstoreConfig.ReplicaBuckets = replicaBuckets
+	accountDailyQuotas, err := parseAccountValues("daily quota", ctx.GlobalStringSlice(flags.AccountDailyQuotasFlag.Name))
+	if err != nil {
+		return Config{}, err
+	}
+	config.BatcherConfig.AccountDailyQuotas = accountDailyQuotas
+	accountWeights, err := parseAccountValues("weight", ctx.GlobalStringSlice(flags.AccountWeightsFlag.Name))
+	if err != nil {
+		return Config{}, err
+	}
+	config.BatcherConfig.AccountWeights = accountWeights
+	if err := config.Validate(); err != nil {
+		return Config{}, err
+	}
+	return config, nil
+}
+
+// Validate checks the config before any component of the batcher starts, and returns all the violations at once
+func (c Config) Validate() error {
+	errs := []error{
+		c.BatcherConfig.Validate(),
+		c.TimeoutConfig.Validate(),
+		c.BlobstoreConfig.Validate(),
+		validateMessageSizes(c.MaxGRPCMessageSize, c.NodeMaxGRPCMessageSize),
+	}
+	if err := c.NodeTLSConfig.Validate(); err != nil {
+		errs = append(errs, fmt.Errorf("invalid node TLS config: %w", err))
+	}
+	if c.UseGraph && c.GraphUrl == "" {
+		errs = append(errs, errors.New("the graph url must be set to use the graph"))
+	}
+	if c.EnableConfirmationQueue && (c.BatcherConfig.ConfirmationRetryInterval <= 0 || c.BatcherConfig.MaxConfirmationAttempts == 0) {
+		errs = append(errs, errors.New("the confirmation queue requires a positive confirmation retry interval and max confirmation attempts"))
+	}
+	return errors.Join(errs...)
+}
+
+// parseAccountValues parses the values of the accounts, such as their daily quotas in bytes or their weights, each
+// formatted as <account>=<value>
+func parseAccountValues(name string, accountValues []string) (map[core.AccountID]uint64, error) {
+	values := make(map[core.AccountID]uint64, len(accountValues))
+	for _, accountValue := range accountValues {
+		separator := strings.LastIndex(accountValue, "=")
+		if separator <= 0 {
+			return nil, fmt.Errorf("invalid account %s %q: expected <account>=<value>", name, accountValue)
+		}
+		value, err := strconv.ParseUint(accountValue[separator+1:], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid account %s %q: %w", name, accountValue, err)
+		}
+		values[accountValue[:separator]] = value
+	}
+	return values, nil
+}
+
+// validateMessageSizes checks that the StoreChunks requests fit under the message size limit of the nodes, so that the
+// nodes don't reject the requests of the large batches
+func validateMessageSizes(maxMessageSize int, nodeMaxMessageSize int) error {
+	if maxMessageSize <= 0 {
+		return fmt.Errorf("the max grpc message size must be positive, but found %d", maxMessageSize)
+	}
+	if maxMessageSize > nodeMaxMessageSize {
+		return fmt.Errorf("the max grpc message size (%d bytes) must not exceed the max grpc message size of the nodes (%d bytes)", maxMessageSize, nodeMaxMessageSize)
 	}
-	return config
+	return nil
 }
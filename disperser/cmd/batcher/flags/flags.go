@@ -6,7 +6,11 @@ import (
 	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/common/aws"
 	"github.com/Layr-Labs/eigenda/common/geth"
+	commongrpc "github.com/Layr-Labs/eigenda/common/grpc"
 	"github.com/Layr-Labs/eigenda/common/logging"
+	"github.com/Layr-Labs/eigenda/core/encoding"
+	coreeth "github.com/Layr-Labs/eigenda/core/eth"
+	"github.com/Layr-Labs/eigenda/disperser/common/events"
 	"github.com/Layr-Labs/eigenda/indexer"
 	"github.com/urfave/cli"
 )
@@ -24,6 +28,32 @@ var (
 		Required: true,
 		EnvVar:   common.PrefixEnvVar(envVarPrefix, "S3_BUCKET_NAME"),
 	}
+	S3KeyPrefixFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "s3-key-prefix"),
+		Usage:    "Prefix of the keys of the blobs stored in the bucket, e.g. to separate environments sharing a bucket. Blobs are stored at the root of the bucket if empty",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "S3_KEY_PREFIX"),
+	}
+	S3ReplicaBucketsFlag = cli.StringSliceFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "s3-replica-buckets"),
+		Usage:    "Replicas of the bucket in other regions, as region:bucket, read in order when the bucket fails to serve the blobs. The blobs are written to the bucket only",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "S3_REPLICA_BUCKETS"),
+	}
+	S3ReadTimeoutFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "s3-read-timeout"),
+		Usage:    "Timeout of each attempt at reading an object from the bucket or one of its replicas. Unbounded if 0",
+		Required: false,
+		Value:    30 * time.Second,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "S3_READ_TIMEOUT"),
+	}
+	S3ReplicaDemotionPeriodFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "s3-replica-demotion-period"),
+		Usage:    "Duration for which the bucket or a replica whose reads keep failing is read after the others",
+		Required: false,
+		Value:    time.Minute,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "S3_REPLICA_DEMOTION_PERIOD"),
+	}
 	DynamoDBTableNameFlag = cli.StringFlag{
 		Name:     common.PrefixFlag(FlagPrefix, "dynamodb-table-name"),
 		Usage:    "Name of the dynamodb table to store blob metadata",
@@ -48,10 +78,10 @@ var (
 		Required: true,
 		EnvVar:   common.PrefixEnvVar(envVarPrefix, "EIGENDA_SERVICE_MANAGER"),
 	}
-	EncoderSocket = cli.StringFlag{
+	EncoderSocket = cli.StringSliceFlag{
 		Name:     "encoder-socket",
-		Usage:    "the http ip:port which the distributed encoder server is listening",
-		Required: true,
+		Usage:    "the ip:port of the encoder servers, which encoding requests are distributed to in round-robin order. Blobs are encoded in process with the kzg flags if there are none, or if none of the servers is available",
+		Required: false,
 		EnvVar:   common.PrefixEnvVar(envVarPrefix, "ENCODER_ADDRESS"),
 	}
 	EnableMetrics = cli.BoolFlag{
@@ -86,12 +116,25 @@ var (
 		Value:    "9100",
 		EnvVar:   common.PrefixEnvVar(envVarPrefix, "METRICS_HTTP_PORT"),
 	}
+	MetricsAccountAllowlistFlag = cli.StringSliceFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "metrics-account-allowlist"),
+		Usage:    "accounts reported as their own label in the account share metrics; the other accounts are reported together as \"other\"",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "METRICS_ACCOUNT_ALLOWLIST"),
+	}
 	IndexerDataDirFlag = cli.StringFlag{
 		Name:   common.PrefixFlag(FlagPrefix, "indexer-data-dir"),
 		Usage:  "the data directory for the indexer",
 		EnvVar: common.PrefixEnvVar(envVarPrefix, "INDEXER_DATA_DIR"),
 		Value:  "./data/",
 	}
+	EncoderHealthCheckIntervalFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "encoder-health-check-interval"),
+		Usage:    "interval at which the health of the encoder servers is checked. Unhealthy servers don't receive encoding requests until they recover",
+		Required: false,
+		Value:    10 * time.Second,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "ENCODER_HEALTH_CHECK_INTERVAL"),
+	}
 	EncodingTimeoutFlag = cli.DurationFlag{
 		Name:     "encoding-timeout",
 		Usage:    "connection timeout from grpc call to encoder",
@@ -154,6 +197,159 @@ var (
 		EnvVar:   common.PrefixEnvVar(envVarPrefix, "MAX_NUM_RETRIES_PER_BLOB"),
 		Value:    2,
 	}
+	MinSignedPercentageFlag = cli.UintFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "min-signed-percentage"),
+		Usage:    "Minimum percentage of the stake of each quorum of a blob which must sign a batch for the blob to be confirmed, in addition to the quorum threshold of the blob. Blobs not meeting it are retried",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "MIN_SIGNED_PERCENTAGE"),
+		Value:    0,
+	}
+	EnableConfirmationQueueFlag = cli.BoolFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "enable-confirmation-queue"),
+		Usage:    "Whether to push the aggregated batches to a confirmation queue drained by a separate confirmer, so that batches keep being aggregated while earlier ones wait for confirmation",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "ENABLE_CONFIRMATION_QUEUE"),
+	}
+	ConfirmationQueueTableNameFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "confirmation-queue-table-name"),
+		Usage:    "Name of the dynamodb table persisting the confirmation queue. The queue is kept in memory when it is not set",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "CONFIRMATION_QUEUE_TABLE_NAME"),
+	}
+	ConfirmationRetryIntervalFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "confirmation-retry-interval"),
+		Usage:    "Delay before attempting again to confirm a batch of the confirmation queue, doubling with each failed attempt",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "CONFIRMATION_RETRY_INTERVAL"),
+		Value:    5 * time.Second,
+	}
+	MaxConfirmationAttemptsFlag = cli.UintFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "max-confirmation-attempts"),
+		Usage:    "Number of attempts to confirm a batch of the confirmation queue before its blobs are retried in another batch",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "MAX_CONFIRMATION_ATTEMPTS"),
+		Value:    5,
+	}
+	MaxConfirmationBlockAgeFlag = cli.UintFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "max-confirmation-block-age"),
+		Usage:    "Maximum number of blocks between the reference block of a batch of the confirmation queue and the current block for the batch to be confirmed. Older batches are dropped and their blobs retried in another batch. It should not exceed the BLOCK_STALE_MEASURE of the EigenDAServiceManager. If set to 0, the age of the batches is not checked.",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "MAX_CONFIRMATION_BLOCK_AGE"),
+		Value:    0,
+	}
+	MaxReferenceBlockAgeFlag = cli.UintFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "max-reference-block-age"),
+		Usage:    "Maximum number of blocks between the reference block of a batch and the current block when the batch is made. A newer reference block is selected, and the blobs encoded again, once it is exceeded. It should be below the max reference block age of the nodes, leaving time to dispatch the batch. If set to 0, the reference block is not refreshed.",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "MAX_REFERENCE_BLOCK_AGE"),
+	}
+	EncodingOverprovisionPercentFlag = cli.UintFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "encoding-overprovision-percent"),
+		Usage:    "Margin by which the chunks assigned to the operators exceed the nominal number of chunks of each quorum, as a percentage of it, so that the blobs can still be retrieved when some of the operators go offline. It is recorded in the blob headers. At most 100.",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "ENCODING_OVERPROVISION_PERCENT"),
+		Value:    0,
+	}
+	StuckBlobSLAFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "stuck-blob-sla"),
+		Usage:    "How long a blob may be processing since it was requested before the watchdog reports it as stuck. The watchdog is disabled if set to 0",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "STUCK_BLOB_SLA"),
+		Value:    0,
+	}
+	WatchdogIntervalFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "watchdog-interval"),
+		Usage:    "Interval at which the watchdog checks for the blobs processing beyond the stuck blob SLA",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "WATCHDOG_INTERVAL"),
+		Value:    time.Minute,
+	}
+	RedriveStuckBlobsFlag = cli.BoolFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "redrive-stuck-blobs"),
+		Usage:    "Whether the watchdog re-drives the stuck blobs, dropping their encoding and incrementing their retry count so that they are batched again, rather than only reporting them",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "REDRIVE_STUCK_BLOBS"),
+	}
+	OperatorSocketRefreshIntervalFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "operator-socket-refresh-interval"),
+		Usage:    "Interval at which the operator sockets are fully refreshed from the chain, in case socket update events are missed",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "OPERATOR_SOCKET_REFRESH_INTERVAL"),
+		Value:    5 * time.Minute,
+	}
+	AccountUsageTableNameFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "account-usage-table-name"),
+		Usage:    "Name of the dynamodb table recording the bytes confirmed for each account per day. The daily quotas are enforced only when it is set",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "ACCOUNT_USAGE_TABLE_NAME"),
+	}
+	DailyQuotaFlag = cli.Uint64Flag{
+		Name:     common.PrefixFlag(FlagPrefix, "daily-quota"),
+		Usage:    "Number of bytes of each account which can be confirmed per UTC day. Unlimited if 0",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "DAILY_QUOTA"),
+	}
+	AccountDailyQuotasFlag = cli.StringSliceFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "account-daily-quotas"),
+		Usage:    "Daily quotas (bytes) of the accounts overriding the default daily quota, e.g. 'ip:1.2.3.4=1000000'. An account with a quota of 0 is unlimited",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "ACCOUNT_DAILY_QUOTAS"),
+	}
+	QuotaGracePeriodFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "quota-grace-period"),
+		Usage:    "How long the blobs of an account over its daily quota wait for the quota to free up before they fail",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "QUOTA_GRACE_PERIOD"),
+		Value:    time.Hour,
+	}
+	AccountWeightsFlag = cli.StringSliceFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "account-weights"),
+		Usage:    "Weights of the accounts in the selection of the blobs to encode, e.g. 'ip:1.2.3.4=4'. The other accounts have a weight of 1",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "ACCOUNT_WEIGHTS"),
+	}
+	MaxBlobWaitFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "max-blob-wait"),
+		Usage:    "How long a blob may wait before it is selected ahead of the blobs of the other accounts, whatever their weights. Disabled if 0",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "MAX_BLOB_WAIT"),
+		Value:    10 * time.Minute,
+	}
+	FeatureStakeThresholdFlag = cli.UintFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "feature-stake-threshold"),
+		Usage:    "Minimum percentage of the stake of each quorum which must advertise an optional node feature for the batcher to use it. No optional feature is used if 0",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "FEATURE_STAKE_THRESHOLD"),
+		Value:    100,
+	}
+	MinChunkLengthFlag = cli.UintFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "min-chunk-length"),
+		Usage:    "Floor of the chunk lengths of the encodings, in symbols, raising the degenerate chunk lengths of the small blobs and of the large quorums. At most 256, no floor is applied if 0",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "MIN_CHUNK_LENGTH"),
+		Value:    0,
+	}
+	LargeQuorumOperatorThresholdFlag = cli.UintFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "large-quorum-operator-threshold"),
+		Usage:    "Number of operators from which the chunks of a quorum are raised to the large quorum minimum chunk size, trading a little encoded size for far fewer proofs. No chunk is raised if 0",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "LARGE_QUORUM_OPERATOR_THRESHOLD"),
+		Value:    0,
+	}
+	LargeQuorumMinChunkBytesFlag = cli.UintFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "large-quorum-min-chunk-bytes"),
+		Usage:    "Minimum size in bytes of the chunks of the quorums of at least large-quorum-operator-threshold operators, rounded up to a power of 2 symbols and bounded by 256 symbols",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "LARGE_QUORUM_MIN_CHUNK_BYTES"),
+		Value:    0,
+	}
+	NodeMaxGRPCMessageSizeFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "node-max-grpc-message-size"),
+		Usage:    "Max size in bytes of the gRPC messages the nodes are configured to receive, which the max size of the StoreChunks requests must not exceed",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "NODE_MAX_GRPC_MESSAGE_SIZE"),
+		Value:    1024 * 1024 * 1024, // 1 GiB
+	}
 )
 
 var requiredFlags = []cli.Flag{
@@ -162,7 +358,6 @@ var requiredFlags = []cli.Flag{
 	PullIntervalFlag,
 	BlsOperatorStateRetrieverFlag,
 	EigenDAServiceManagerFlag,
-	EncoderSocket,
 	EnableMetrics,
 	GraphUrlFlag,
 	BatchSizeLimitFlag,
@@ -171,7 +366,14 @@ var requiredFlags = []cli.Flag{
 }
 
 var optionalFlags = []cli.Flag{
+	EncoderSocket,
+	EncoderHealthCheckIntervalFlag,
+	S3KeyPrefixFlag,
+	S3ReplicaBucketsFlag,
+	S3ReadTimeoutFlag,
+	S3ReplicaDemotionPeriodFlag,
 	MetricsHTTPPort,
+	MetricsAccountAllowlistFlag,
 	IndexerDataDirFlag,
 	EncodingTimeoutFlag,
 	AttestationTimeoutFlag,
@@ -181,6 +383,29 @@ var optionalFlags = []cli.Flag{
 	FinalizerIntervalFlag,
 	EncodingRequestQueueSizeFlag,
 	MaxNumRetriesPerBlobFlag,
+	MinSignedPercentageFlag,
+	EnableConfirmationQueueFlag,
+	ConfirmationQueueTableNameFlag,
+	ConfirmationRetryIntervalFlag,
+	MaxConfirmationAttemptsFlag,
+	MaxConfirmationBlockAgeFlag,
+	MaxReferenceBlockAgeFlag,
+	EncodingOverprovisionPercentFlag,
+	StuckBlobSLAFlag,
+	WatchdogIntervalFlag,
+	RedriveStuckBlobsFlag,
+	OperatorSocketRefreshIntervalFlag,
+	NodeMaxGRPCMessageSizeFlag,
+	AccountUsageTableNameFlag,
+	DailyQuotaFlag,
+	AccountDailyQuotasFlag,
+	QuotaGracePeriodFlag,
+	AccountWeightsFlag,
+	MaxBlobWaitFlag,
+	FeatureStakeThresholdFlag,
+	MinChunkLengthFlag,
+	LargeQuorumOperatorThresholdFlag,
+	LargeQuorumMinChunkBytesFlag,
 }
 
 // Flags contains the list of configuration options available to the binary.
@@ -189,7 +414,14 @@ var Flags []cli.Flag
 func init() {
 	Flags = append(requiredFlags, optionalFlags...)
 	Flags = append(Flags, geth.EthClientFlags(envVarPrefix)...)
+	Flags = append(Flags, coreeth.BreakerCLIFlags(envVarPrefix, FlagPrefix)...)
+	Flags = append(Flags, events.CLIFlags(envVarPrefix, FlagPrefix)...)
 	Flags = append(Flags, logging.CLIFlags(envVarPrefix, FlagPrefix)...)
 	Flags = append(Flags, indexer.CLIFlags(envVarPrefix)...)
 	Flags = append(Flags, aws.ClientFlags(envVarPrefix, FlagPrefix)...)
+	// Bounds the size of the StoreChunks request sent to each operator
+	Flags = append(Flags, commongrpc.MaxMessageSizeCLIFlag(envVarPrefix, FlagPrefix, 1024*1024*1024)) // 1 GiB
+	Flags = append(Flags, commongrpc.ClientTLSCLIFlags(envVarPrefix, FlagPrefix)...)
+	// The kzg flags configure the in-process encoder. Their env vars are prefixed to tell them from the batcher's own
+	Flags = append(Flags, encoding.OptionalCLIFlags(common.PrefixEnvVar(envVarPrefix, "KZG"))...)
 }
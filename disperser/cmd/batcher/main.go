@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"os/signal"
+	"syscall"
 	"time"
 
 	"github.com/shurcooL/graphql"
@@ -15,18 +17,23 @@ import (
 	inmemstore "github.com/Layr-Labs/eigenda/indexer/inmem"
 	gethcommon "github.com/ethereum/go-ethereum/common"
 
+	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/common/aws/dynamodb"
 	"github.com/Layr-Labs/eigenda/common/aws/s3"
 	"github.com/Layr-Labs/eigenda/common/geth"
 	"github.com/Layr-Labs/eigenda/common/logging"
 	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/core/encoding"
 	coreeth "github.com/Layr-Labs/eigenda/core/eth"
+	"github.com/Layr-Labs/eigenda/disperser"
 	"github.com/Layr-Labs/eigenda/disperser/batcher"
 	"github.com/Layr-Labs/eigenda/disperser/batcher/eth"
 	dispatcher "github.com/Layr-Labs/eigenda/disperser/batcher/grpc"
 	"github.com/Layr-Labs/eigenda/disperser/cmd/batcher/flags"
 	"github.com/Layr-Labs/eigenda/disperser/common/blobstore"
+	"github.com/Layr-Labs/eigenda/disperser/common/events"
 	"github.com/Layr-Labs/eigenda/disperser/encoder"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/urfave/cli"
 )
@@ -51,12 +58,13 @@ func main() {
 	if err != nil {
 		log.Fatalf("application failed: %v", err)
 	}
-
-	select {}
 }
 
 func RunBatcher(ctx *cli.Context) error {
-	config := NewConfig(ctx)
+	config, err := NewConfig(ctx)
+	if err != nil {
+		return err
+	}
 
 	logger, err := logging.GetLogger(config.LoggerConfig)
 	if err != nil {
@@ -75,11 +83,17 @@ func RunBatcher(ctx *cli.Context) error {
 		return err
 	}
 
-	dispatcher := dispatcher.NewDispatcher(&dispatcher.Config{
-		Timeout: config.TimeoutConfig.AttestationTimeout,
-	}, logger)
+	// The nodes authenticate the disperser by the key with which it confirms the batches
+	signingKey, err := crypto.HexToECDSA(config.EthClientConfig.PrivateKeyString)
+	if err != nil {
+		return fmt.Errorf("failed to parse the private key: %w", err)
+	}
 	agg := core.NewStdSignatureAggregator(logger)
-	asgn := &core.StdAssignmentCoordinator{}
+	asgn := &core.StdAssignmentCoordinator{
+		MinChunkLength:               config.BatcherConfig.MinChunkLength,
+		LargeQuorumOperatorThreshold: config.BatcherConfig.LargeQuorumOperatorThreshold,
+		LargeQuorumMinChunkBytes:     config.BatcherConfig.LargeQuorumMinChunkBytes,
+	}
 
 	client, err := geth.NewClient(config.EthClientConfig, logger)
 	if err != nil {
@@ -90,10 +104,18 @@ func RunBatcher(ctx *cli.Context) error {
 	if err != nil {
 		return err
 	}
-	tx, err := coreeth.NewTransactor(logger, client, config.BLSOperatorStateRetrieverAddr, config.EigenDAServiceManagerAddr)
+	metrics := batcher.NewMetrics(config.MetricsConfig.HTTPPort, config.MetricsConfig.AccountAllowlist, logger)
+
+	transactor, err := coreeth.NewTransactor(logger, client, config.BLSOperatorStateRetrieverAddr, config.EigenDAServiceManagerAddr)
 	if err != nil {
 		return err
 	}
+	var tx core.Transactor = transactor
+	if config.BreakerConfig.FailureThreshold > 0 {
+		tx = coreeth.NewBreakerTransactor(transactor, config.BreakerConfig, logger, func(state coreeth.BreakerState) {
+			metrics.UpdateTransactorBreakerState(state.String())
+		})
+	}
 	confirmer, err := eth.NewBatchConfirmer(tx, config.TimeoutConfig.ChainWriteTimeout)
 	if err != nil {
 		return err
@@ -108,7 +130,22 @@ func RunBatcher(ctx *cli.Context) error {
 		return fmt.Errorf("failed to get STORE_DURATION_BLOCKS: %w", err)
 	}
 	blobMetadataStore := blobstore.NewBlobMetadataStore(dynamoClient, logger, config.BlobstoreConfig.TableName, time.Duration((storeDurationBlocks+blockStaleMeasure)*12)*time.Second)
-	queue := blobstore.NewSharedStorage(bucketName, s3Client, blobMetadataStore, logger)
+	queue := blobstore.NewSharedStorage(bucketName, config.BlobstoreConfig.KeyPrefix, s3Client, blobMetadataStore, logger)
+	replicas, err := blobstore.NewReplicaEndpoints(context.Background(), config.AwsClientConfig, config.BlobstoreConfig.ReplicaBuckets, logger)
+	if err != nil {
+		return err
+	}
+	queue.SetReadReplicas(replicas, config.BlobstoreConfig.ReplicaConfig, metrics.IncrementFallbackReads)
+	statusEventSink, err := events.NewSink(context.Background(), config.StatusEventConfig, config.AwsClientConfig)
+	if err != nil {
+		return err
+	}
+	var statusEvents *events.Publisher
+	if statusEventSink != nil {
+		statusEvents = events.NewPublisher(statusEventSink, config.StatusEventConfig.QueueSize, logger, metrics.IncrementStatusEventsDropped)
+		blobMetadataStore.SetStatusEventPublisher(statusEvents)
+		logger.Info("Enabled the publication of the blob status events", "sink", config.StatusEventConfig.Sink)
+	}
 
 	cs := coreeth.NewChainState(tx, client)
 
@@ -129,12 +166,24 @@ func RunBatcher(ctx *cli.Context) error {
 		}
 	}
 
-	metrics := batcher.NewMetrics(config.MetricsConfig.HTTPPort, logger)
-
-	if len(config.BatcherConfig.EncoderSocket) == 0 {
-		return fmt.Errorf("encoder socket must be specified")
+	socketsFilterer, err := indexer.NewOperatorSocketsFilterer(gethcommon.HexToAddress(config.EigenDAServiceManagerAddr), client)
+	if err != nil {
+		return err
+	}
+	socketIndexer := core.NewOperatorSocketIndexer(indexer.NewOperatorSocketSource(socketsFilterer, tx, ics), config.OperatorSocketRefreshInterval, logger)
+	nodeCreds, err := config.NodeTLSConfig.Credentials()
+	if err != nil {
+		return fmt.Errorf("failed to create the node credentials: %w", err)
 	}
-	encoderClient, err := encoder.NewEncoderClient(config.BatcherConfig.EncoderSocket, config.TimeoutConfig.EncodingTimeout)
+	dispatcher := dispatcher.NewDispatcher(&dispatcher.Config{
+		Timeout:        config.TimeoutConfig.AttestationTimeout,
+		SigningKey:     signingKey,
+		SocketIndexer:  socketIndexer,
+		MaxMessageSize: config.MaxGRPCMessageSize,
+		Credentials:    nodeCreds,
+	}, logger)
+
+	encoderClient, err := newEncoderClient(config, logger)
 	if err != nil {
 		return err
 	}
@@ -143,19 +192,90 @@ func RunBatcher(ctx *cli.Context) error {
 	if err != nil {
 		return err
 	}
+	if config.EnableConfirmationQueue {
+		batcher.ConfirmationQueue = newConfirmationQueue(config, dynamoClient, logger)
+	}
+	if config.AccountUsageTableName != "" {
+		batcher.SetAccountUsageStore(blobstore.NewAccountUsageStore(dynamoClient, config.AccountUsageTableName))
+		logger.Info("Enabled the daily quotas", "dailyQuota", config.BatcherConfig.DailyQuota, "numAccountQuotas", len(config.BatcherConfig.AccountDailyQuotas), "gracePeriod", config.BatcherConfig.QuotaGracePeriod)
+	}
 
+	var components []common.Component
 	// Enable Metrics Block
 	if config.MetricsConfig.EnableMetrics {
 		httpSocket := fmt.Sprintf(":%s", config.MetricsConfig.HTTPPort)
-		metrics.Start(context.Background())
-		logger.Info("Enabled metrics for Batcher", "socket", httpSocket)
+		components = append(components, common.NewComponent("metrics", func(ctx context.Context) error {
+			metrics.Start(ctx)
+			logger.Info("Enabled metrics for Batcher", "socket", httpSocket)
+			return nil
+		}, metrics.Stop))
 	}
 
-	err = batcher.Start(context.Background())
-	if err != nil {
-		return err
+	// Started before the batcher, so that the transitions of the blobs are published until the batcher stopped
+	if statusEvents != nil {
+		components = append(components, common.NewBackgroundComponent("status event publisher", func(ctx context.Context) error {
+			statusEvents.Start(ctx)
+			return nil
+		}))
 	}
+	components = append(components,
+		common.NewBackgroundComponent("batcher", batcher.Start),
+		// Started once the chain state is indexed, the operator state of the batches is dialed until then
+		common.NewBackgroundComponent("operator socket indexer", func(ctx context.Context) error {
+			socketIndexer.Start(ctx)
+			return nil
+		}),
+	)
+
+	// SIGUSR1 drains the batcher ahead of its maintenance, once the apiserver stopped accepting new blobs
+	drainSignal := make(chan os.Signal, 1)
+	signal.Notify(drainSignal, syscall.SIGUSR1)
+	go func() {
+		for range drainSignal {
+			batcher.Drain()
+		}
+	}()
 
-	return nil
+	return common.Run(context.Background(), logger, 0, components...)
+}
 
+// newConfirmationQueue creates the confirmation queue of the batcher, persisted in DynamoDB when its table is set
+func newConfirmationQueue(config Config, dynamoClient *dynamodb.Client, logger common.Logger) batcher.ConfirmationQueue {
+	if config.ConfirmationQueueTableName == "" {
+		logger.Warn("Confirmation queue kept in memory: the batches waiting for confirmation are lost on restart, and their blobs are batched again")
+		return batcher.NewInMemoryConfirmationQueue()
+	}
+	logger.Info("Confirmation queue persisted in DynamoDB", "table", config.ConfirmationQueueTableName)
+	return batcher.NewDynamoDBConfirmationQueue(dynamoClient, config.ConfirmationQueueTableName)
+}
+
+// newEncoderClient creates the client encoding the blobs: a pool of the encoder servers, falling back to encoding in
+// process when the kzg flags are set. Without encoder servers, all the blobs are encoded in process.
+func newEncoderClient(config Config, logger common.Logger) (disperser.EncoderClient, error) {
+	var localEncoder disperser.EncoderClient
+	if config.EncoderConfig.KzgConfig.G1Path != "" {
+		enc, err := encoding.NewEncoder(config.EncoderConfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create the in-process encoder: %w", err)
+		}
+		localEncoder = disperser.NewLocalEncoderClient(enc)
+	}
+
+	if len(config.BatcherConfig.EncoderSockets) == 0 {
+		if localEncoder == nil {
+			return nil, fmt.Errorf("either the encoder socket or the kzg flags must be specified")
+		}
+		logger.Info("Encoding blobs in process")
+		return localEncoder, nil
+	}
+
+	pool, err := encoder.NewPool(config.BatcherConfig.EncoderSockets, config.TimeoutConfig.EncodingTimeout, localEncoder, logger)
+	if err != nil {
+		return nil, err
+	}
+	if config.EncoderHealthCheckInterval > 0 {
+		pool.Start(context.Background(), config.EncoderHealthCheckInterval)
+	}
+	logger.Info("Encoding blobs with the encoder servers", "servers", config.BatcherConfig.EncoderSockets, "fallback", localEncoder != nil)
+	return pool, nil
 }
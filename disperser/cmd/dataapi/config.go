@@ -34,6 +34,7 @@ func NewConfig(ctx *cli.Context) Config {
 	config := Config{
 		BlobstoreConfig: blobstore.Config{
 			BucketName: ctx.GlobalString(flags.S3BucketNameFlag.Name),
+			KeyPrefix:  ctx.GlobalString(flags.S3KeyPrefixFlag.Name),
 			TableName:  ctx.GlobalString(flags.DynamoTableNameFlag.Name),
 		},
 		AwsClientConfig:               aws.ReadClientConfig(ctx, flags.FlagPrefix),
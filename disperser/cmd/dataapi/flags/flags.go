@@ -26,6 +26,12 @@ var (
 		Required: true,
 		EnvVar:   common.PrefixEnvVar(envVarPrefix, "S3_BUCKET_NAME"),
 	}
+	S3KeyPrefixFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "s3-key-prefix"),
+		Usage:    "Prefix of the keys of the blobs stored in the bucket, e.g. to separate environments sharing a bucket. Blobs are stored at the root of the bucket if empty",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "S3_KEY_PREFIX"),
+	}
 	SocketAddrFlag = cli.StringFlag{
 		Name:     common.PrefixFlag(FlagPrefix, "socket-addr"),
 		Usage:    "the socket address of the data access api",
@@ -129,6 +135,7 @@ var requiredFlags = []cli.Flag{
 }
 
 var optionalFlags = []cli.Flag{
+	S3KeyPrefixFlag,
 	ServerModeFlag,
 	MetricsHTTPPort,
 }
@@ -0,0 +1,92 @@
+package blobstore
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	commondynamodb "github.com/Layr-Labs/eigenda/common/aws/dynamodb"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/disperser"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// AccountUsageStore is an account usage storage backed by DynamoDB, with an item per account and UTC day:
+// (Partition Key: AccountID, Sort Key: Day) -> ConfirmedBytes
+type AccountUsageStore struct {
+	dynamoDBClient *commondynamodb.Client
+	tableName      string
+}
+
+var _ disperser.AccountUsageStore = (*AccountUsageStore)(nil)
+
+func NewAccountUsageStore(dynamoDBClient *commondynamodb.Client, tableName string) *AccountUsageStore {
+	return &AccountUsageStore{
+		dynamoDBClient: dynamoDBClient,
+		tableName:      tableName,
+	}
+}
+
+func (s *AccountUsageStore) AddConfirmedBytes(ctx context.Context, accountID core.AccountID, at time.Time, bytes uint64) error {
+	_, err := s.dynamoDBClient.IncrementBy(ctx, s.tableName, usageKey(accountID, at), "ConfirmedBytes", bytes)
+	return err
+}
+
+func (s *AccountUsageStore) GetConfirmedBytes(ctx context.Context, accountID core.AccountID, at time.Time) (uint64, error) {
+	item, err := s.dynamoDBClient.GetItem(ctx, s.tableName, usageKey(accountID, at))
+	if err != nil {
+		return 0, err
+	}
+	if item == nil {
+		return 0, nil
+	}
+	confirmedBytes, ok := item["ConfirmedBytes"].(*types.AttributeValueMemberN)
+	if !ok {
+		return 0, fmt.Errorf("invalid account usage item of account %s: missing confirmed bytes", accountID)
+	}
+	return strconv.ParseUint(confirmedBytes.Value, 10, 64)
+}
+
+func usageKey(accountID core.AccountID, at time.Time) commondynamodb.Key {
+	return commondynamodb.Key{
+		"AccountID": &types.AttributeValueMemberS{
+			Value: accountID,
+		},
+		"Day": &types.AttributeValueMemberS{
+			Value: at.UTC().Format(time.DateOnly),
+		},
+	}
+}
+
+func GenerateAccountUsageTableSchema(tableName string, readCapacityUnits int64, writeCapacityUnits int64) *dynamodb.CreateTableInput {
+	return &dynamodb.CreateTableInput{
+		AttributeDefinitions: []types.AttributeDefinition{
+			{
+				AttributeName: aws.String("AccountID"),
+				AttributeType: types.ScalarAttributeTypeS,
+			},
+			{
+				AttributeName: aws.String("Day"),
+				AttributeType: types.ScalarAttributeTypeS,
+			},
+		},
+		KeySchema: []types.KeySchemaElement{
+			{
+				AttributeName: aws.String("AccountID"),
+				KeyType:       types.KeyTypeHash,
+			},
+			{
+				AttributeName: aws.String("Day"),
+				KeyType:       types.KeyTypeRange,
+			},
+		},
+		TableName: aws.String(tableName),
+		ProvisionedThroughput: &types.ProvisionedThroughput{
+			ReadCapacityUnits:  aws.Int64(readCapacityUnits),
+			WriteCapacityUnits: aws.Int64(writeCapacityUnits),
+		},
+	}
+}
@@ -2,22 +2,27 @@ package blobstore
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strconv"
 	"time"
 
 	"github.com/Layr-Labs/eigenda/common"
 	commondynamodb "github.com/Layr-Labs/eigenda/common/aws/dynamodb"
+	"github.com/Layr-Labs/eigenda/core"
 	"github.com/Layr-Labs/eigenda/disperser"
+	"github.com/Layr-Labs/eigenda/disperser/common/events"
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/expression"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
 )
 
 const (
-	statusIndexName = "StatusIndex"
-	batchIndexName  = "BatchIndex"
+	statusIndexName  = "StatusIndex"
+	batchIndexName   = "BatchIndex"
+	accountIndexName = "AccountIndex"
 )
 
 // BlobMetadataStore is a blob metadata storage backed by DynamoDB
@@ -26,13 +31,26 @@ const (
 // - Indexes
 //   - StatusIndex: (Partition Key: Status, Sort Key: RequestedAt) -> Metadata
 //   - BatchIndex: (Partition Key: BatchHeaderHash, Sort Key: BlobIndex) -> Metadata
+//   - AccountIndex: (Partition Key: AccountID, Sort Key: RequestedAt) -> Metadata, only for the blobs with an account
 type BlobMetadataStore struct {
 	dynamoDBClient *commondynamodb.Client
 	logger         common.Logger
 	tableName      string
 	ttl            time.Duration
+	// statusEvents, if not nil, publishes the transitions of the status of the blobs
+	statusEvents *events.Publisher
 }
 
+// Pagination is the position of a page in the results of a query
+type Pagination struct {
+	// Limit is the maximum number of items of the page, or 0 for as many as a single query returns
+	Limit int32
+	// ExclusiveStartKey is the key of the last item of the previous page, or nil for the first page
+	ExclusiveStartKey commondynamodb.Key
+}
+
+// NewBlobMetadataStore creates a store of the blob metadata in the given table, which is set from the table name config
+// of the disperser, so that the disperser can be moved to another table, e.g. filled by CopyMetadata, by config.
 func NewBlobMetadataStore(dynamoDBClient *commondynamodb.Client, logger common.Logger, tableName string, ttl time.Duration) *BlobMetadataStore {
 	logger.Debugf("creating blob metadata store with table %s with TTL: %s", tableName, ttl)
 	return &BlobMetadataStore{
@@ -43,13 +61,26 @@ func NewBlobMetadataStore(dynamoDBClient *commondynamodb.Client, logger common.L
 	}
 }
 
+// SetStatusEventPublisher makes the store publish the transitions of the status of the blobs it makes, once they are
+// written. The publication is best effort, and never fails the updates.
+func (s *BlobMetadataStore) SetStatusEventPublisher(publisher *events.Publisher) {
+	s.statusEvents = publisher
+}
+
 func (s *BlobMetadataStore) QueueNewBlobMetadata(ctx context.Context, blobMetadata *disperser.BlobMetadata) error {
 	item, err := MarshalBlobMetadata(blobMetadata)
 	if err != nil {
 		return err
 	}
+	// Only the new items are stamped with the schema version, as the updates don't upgrade the items they update
+	item[schemaVersionAttribute] = &types.AttributeValueMemberN{Value: strconv.Itoa(MetadataSchemaVersion)}
 
-	return s.dynamoDBClient.PutItem(ctx, s.tableName, item)
+	err = s.dynamoDBClient.PutItem(ctx, s.tableName, item)
+	if err != nil {
+		return err
+	}
+	s.publishStatusEvent(blobMetadata, nil, blobMetadata.BlobStatus)
+	return nil
 }
 
 func (s *BlobMetadataStore) GetBlobMetadata(ctx context.Context, metadataKey disperser.BlobKey) (*disperser.BlobMetadata, error) {
@@ -65,12 +96,45 @@ func (s *BlobMetadataStore) GetBlobMetadata(ctx context.Context, metadataKey dis
 		return nil, err
 	}
 
-	metadata, err := UnmarshalBlobMetadata(item)
+	upgraded, version, update, err := upgradeMetadataItem(item)
 	if err != nil {
 		return nil, err
 	}
+	// The items of older schema versions are upgraded lazily as they are read, in addition to UpgradeMetadataSchema.
+	// The read succeeds even if the upgrade fails, as the item was upgraded for the read anyway.
+	if version < MetadataSchemaVersion && len(item) > 0 {
+		err := s.dynamoDBClient.UpdateItemWithExpression(ctx, s.tableName, getItemKey(item), update, schemaVersionCondition(version))
+		if err != nil && !errors.Is(err, commondynamodb.ErrConditionFailed) {
+			s.logger.Warn("failed to upgrade the schema of the blob metadata", "blobKey", metadataKey.String(), "version", version, "err", err)
+		}
+	}
 
-	return metadata, nil
+	return unmarshalUpgradedBlobMetadata(upgraded)
+}
+
+// HasBlobMetadata returns whether any blob metadata refers to the blob content with the given hash
+func (s *BlobMetadataStore) HasBlobMetadata(ctx context.Context, blobHash disperser.BlobHash) (bool, error) {
+	items, err := s.dynamoDBClient.Query(ctx, s.tableName, "BlobHash = :blobHash", commondynamodb.ExpresseionValues{
+		":blobHash": &types.AttributeValueMemberS{
+			Value: blobHash,
+		}})
+	if err != nil {
+		return false, err
+	}
+
+	return len(items) > 0, nil
+}
+
+// DeleteBlobMetadata deletes the blob metadata. Deleting metadata which doesn't exist is not an error.
+func (s *BlobMetadataStore) DeleteBlobMetadata(ctx context.Context, metadataKey disperser.BlobKey) error {
+	return s.dynamoDBClient.DeleteItem(ctx, s.tableName, map[string]types.AttributeValue{
+		"BlobHash": &types.AttributeValueMemberS{
+			Value: metadataKey.BlobHash,
+		},
+		"MetadataHash": &types.AttributeValueMemberS{
+			Value: metadataKey.MetadataHash,
+		},
+	})
 }
 
 // GetBlobMetadataByStatus returns all the metadata with the given status
@@ -96,6 +160,78 @@ func (s *BlobMetadataStore) GetBlobMetadataByStatus(ctx context.Context, status
 	return metadata, nil
 }
 
+// GetBlobMetadataByAccountAndTimeRange returns a page of the metadata of the blobs of the account requested between
+// start and end inclusive, in nanoseconds since the unix epoch, in the order of their request. It also returns the
+// pagination of the next page, or nil if there are no more blobs. A nil pagination returns the first page.
+func (s *BlobMetadataStore) GetBlobMetadataByAccountAndTimeRange(ctx context.Context, account core.AccountID, start uint64, end uint64, pagination *Pagination) ([]*disperser.BlobMetadata, *Pagination, error) {
+	return s.queryAccountIndex(ctx, account, start, end, "", nil, nil, pagination)
+}
+
+// GetBlobMetadataByAccountAndLabel is GetBlobMetadataByAccountAndTimeRange limited to the blobs whose metadata has the
+// label key set to value.
+//
+// The label is matched with a filter expression on the AccountIndex rather than with an index of its own: the query
+// reads every blob of the account in the time range and drops those without the label after reading them. The read
+// capacity it consumes is therefore that of the whole range, however few blobs match, and the pages are bounded by
+// the blobs read: a page can have fewer than the limit of blobs, or none at all, and still be followed by another.
+// Callers have to keep paging until the returned pagination is nil. If a label key is filtered on often, over ranges
+// where few blobs match, it is worth projecting it to a top-level attribute indexed by a GSI, e.g. with a partition
+// key of the account and label value, so that the query reads only the matching blobs.
+func (s *BlobMetadataStore) GetBlobMetadataByAccountAndLabel(ctx context.Context, account core.AccountID, start uint64, end uint64, key string, value string, pagination *Pagination) ([]*disperser.BlobMetadata, *Pagination, error) {
+	if key == "" {
+		return nil, nil, errors.New("the label key to filter on is empty")
+	}
+	return s.queryAccountIndex(ctx, account, start, end, "#labels.#key = :value", map[string]string{
+		"#labels": "Labels",
+		"#key":    key,
+	}, commondynamodb.ExpresseionValues{
+		":value": &types.AttributeValueMemberS{
+			Value: value,
+		},
+	}, pagination)
+}
+
+// queryAccountIndex returns a page of the metadata of the blobs of the account requested between start and end
+// inclusive, which match filterCondition unless it is empty
+func (s *BlobMetadataStore) queryAccountIndex(ctx context.Context, account core.AccountID, start uint64, end uint64, filterCondition string, filterNames map[string]string, filterValues commondynamodb.ExpresseionValues, pagination *Pagination) ([]*disperser.BlobMetadata, *Pagination, error) {
+	if pagination == nil {
+		pagination = &Pagination{}
+	}
+	values := commondynamodb.ExpresseionValues{
+		":account": &types.AttributeValueMemberS{
+			Value: account,
+		},
+		":start": &types.AttributeValueMemberN{
+			Value: strconv.FormatUint(start, 10),
+		},
+		":end": &types.AttributeValueMemberN{
+			Value: strconv.FormatUint(end, 10),
+		}}
+	for k, v := range filterValues {
+		values[k] = v
+	}
+	items, lastEvaluatedKey, err := s.dynamoDBClient.QueryIndexWithFilterAndPagination(ctx, s.tableName, accountIndexName, "AccountID = :account AND RequestedAt BETWEEN :start AND :end", filterCondition, filterNames, values, pagination.Limit, pagination.ExclusiveStartKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	metadata := make([]*disperser.BlobMetadata, len(items))
+	for i, item := range items {
+		metadata[i], err = UnmarshalBlobMetadata(item)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if lastEvaluatedKey == nil {
+		return metadata, nil, nil
+	}
+	return metadata, &Pagination{
+		Limit:             pagination.Limit,
+		ExclusiveStartKey: lastEvaluatedKey,
+	}, nil
+}
+
 func (s *BlobMetadataStore) GetAllBlobMetadataByBatch(ctx context.Context, batchHeaderHash [32]byte) ([]*disperser.BlobMetadata, error) {
 	items, err := s.dynamoDBClient.QueryIndex(ctx, s.tableName, batchIndexName, "BatchHeaderHash = :batch_header_hash", commondynamodb.ExpresseionValues{
 		":batch_header_hash": &types.AttributeValueMemberB{
@@ -171,33 +307,153 @@ func (s *BlobMetadataStore) UpdateBlobMetadata(ctx context.Context, metadataKey
 		return err
 	}
 
-	_, err = s.dynamoDBClient.UpdateItem(ctx, s.tableName, map[string]types.AttributeValue{
+	old, err := s.updateItem(ctx, metadataKey, item)
+	if err != nil {
+		return err
+	}
+	if s.statusEvents != nil {
+		s.publishStatusEvent(updated, s.statusOf(metadataKey, old), updated.BlobStatus)
+	}
+	return nil
+}
+
+// UpdateBlobMetadataFromStatus updates the blob metadata only if the stored blob is in the expected status. It returns
+// commondynamodb.ErrConditionFailed if the stored blob is in another status, e.g. because a concurrent update moved it.
+func (s *BlobMetadataStore) UpdateBlobMetadataFromStatus(ctx context.Context, metadataKey disperser.BlobKey, expected disperser.BlobStatus, updated *disperser.BlobMetadata) error {
+	item, err := MarshalBlobMetadata(updated)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.dynamoDBClient.UpdateItemWithCondition(ctx, s.tableName, map[string]types.AttributeValue{
 		"BlobHash": &types.AttributeValueMemberS{
 			Value: metadataKey.BlobHash,
 		},
 		"MetadataHash": &types.AttributeValueMemberS{
 			Value: metadataKey.MetadataHash,
 		},
-	}, item)
-
-	return err
+	}, item, expression.Name("BlobStatus").Equal(expression.Value(int(expected))))
+	if err != nil {
+		return err
+	}
+	s.publishStatusEvent(updated, &expected, updated.BlobStatus)
+	return nil
 }
 
-func (s *BlobMetadataStore) SetBlobStatus(ctx context.Context, metadataKey disperser.BlobKey, status disperser.BlobStatus) error {
-	_, err := s.dynamoDBClient.UpdateItem(ctx, s.tableName, map[string]types.AttributeValue{
+// UpdateBlobMetadataFromRetries updates the blob metadata only if the stored blob is in the expected status with the
+// expected number of retries. It returns commondynamodb.ErrConditionFailed otherwise.
+func (s *BlobMetadataStore) UpdateBlobMetadataFromRetries(ctx context.Context, metadataKey disperser.BlobKey, expectedStatus disperser.BlobStatus, expectedNumRetries uint, updated *disperser.BlobMetadata) error {
+	item, err := MarshalBlobMetadata(updated)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.dynamoDBClient.UpdateItemWithCondition(ctx, s.tableName, map[string]types.AttributeValue{
 		"BlobHash": &types.AttributeValueMemberS{
 			Value: metadataKey.BlobHash,
 		},
 		"MetadataHash": &types.AttributeValueMemberS{
 			Value: metadataKey.MetadataHash,
 		},
+	}, item, expression.Name("BlobStatus").Equal(expression.Value(int(expectedStatus))).And(
+		expression.Name("NumRetries").Equal(expression.Value(expectedNumRetries)),
+	))
+	if err != nil {
+		return err
+	}
+	s.publishStatusEvent(updated, &expectedStatus, updated.BlobStatus)
+	return nil
+}
+
+// UpdateBlobExpiry sets the expiry of the blob, which is the TTL attribute of its metadata item, and appends the
+// extension to its retention extensions. The update only applies if the stored expiry is still the expiry of the
+// existing metadata, and returns commondynamodb.ErrConditionFailed otherwise.
+func (s *BlobMetadataStore) UpdateBlobExpiry(ctx context.Context, existingMetadata *disperser.BlobMetadata, extension *disperser.RetentionExtension) error {
+	extensions := append(append([]*disperser.RetentionExtension{}, existingMetadata.RetentionExtensions...), extension)
+	extensionsAttribute, err := attributevalue.Marshal(extensions)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.dynamoDBClient.UpdateItemWithCondition(ctx, s.tableName, map[string]types.AttributeValue{
+		"BlobHash": &types.AttributeValueMemberS{
+			Value: existingMetadata.BlobHash,
+		},
+		"MetadataHash": &types.AttributeValueMemberS{
+			Value: existingMetadata.MetadataHash,
+		},
 	}, commondynamodb.Item{
+		"Expiry": &types.AttributeValueMemberN{
+			Value: strconv.FormatUint(extension.Expiry, 10),
+		},
+		"RetentionExtensions": extensionsAttribute,
+	}, expression.Name("Expiry").Equal(expression.Value(existingMetadata.Expiry)))
+
+	return err
+}
+
+func (s *BlobMetadataStore) SetBlobStatus(ctx context.Context, metadataKey disperser.BlobKey, status disperser.BlobStatus) error {
+	old, err := s.updateItem(ctx, metadataKey, commondynamodb.Item{
 		"BlobStatus": &types.AttributeValueMemberN{
 			Value: strconv.Itoa(int(status)),
 		},
 	})
+	if err != nil {
+		return err
+	}
+	if s.statusEvents != nil {
+		// The event carries the account and quorums of the blob as it was before the update
+		metadata := &disperser.BlobMetadata{BlobHash: metadataKey.BlobHash, MetadataHash: metadataKey.MetadataHash}
+		if len(old) > 0 {
+			if oldMetadata, err := UnmarshalBlobMetadata(old); err == nil {
+				metadata = oldMetadata
+			}
+		}
+		s.publishStatusEvent(metadata, s.statusOf(metadataKey, old), status)
+	}
+	return nil
+}
 
-	return err
+// updateItem updates the metadata item of the blob. When the status transitions are published, it returns the item
+// as it was before the update, which has the old status of the blob.
+func (s *BlobMetadataStore) updateItem(ctx context.Context, metadataKey disperser.BlobKey, item commondynamodb.Item) (commondynamodb.Item, error) {
+	key := map[string]types.AttributeValue{
+		"BlobHash": &types.AttributeValueMemberS{
+			Value: metadataKey.BlobHash,
+		},
+		"MetadataHash": &types.AttributeValueMemberS{
+			Value: metadataKey.MetadataHash,
+		},
+	}
+	if s.statusEvents == nil {
+		_, err := s.dynamoDBClient.UpdateItem(ctx, s.tableName, key, item)
+		return nil, err
+	}
+	return s.dynamoDBClient.UpdateItemReturningOld(ctx, s.tableName, key, item)
+}
+
+// statusOf returns the status of the blob in its metadata item, or nil if the item doesn't exist or has no status
+func (s *BlobMetadataStore) statusOf(metadataKey disperser.BlobKey, item commondynamodb.Item) *disperser.BlobStatus {
+	attribute, ok := item["BlobStatus"].(*types.AttributeValueMemberN)
+	if !ok {
+		return nil
+	}
+	status, err := strconv.Atoi(attribute.Value)
+	if err != nil {
+		s.logger.Warn("invalid status in blob metadata", "blobKey", metadataKey.String(), "status", attribute.Value)
+		return nil
+	}
+	blobStatus := disperser.BlobStatus(status)
+	return &blobStatus
+}
+
+// publishStatusEvent publishes the transition of the status of the blob, if the transitions are published and the
+// status changed
+func (s *BlobMetadataStore) publishStatusEvent(metadata *disperser.BlobMetadata, oldStatus *disperser.BlobStatus, newStatus disperser.BlobStatus) {
+	if s.statusEvents == nil || (oldStatus != nil && *oldStatus == newStatus) {
+		return
+	}
+	s.statusEvents.Publish(events.NewStatusEvent(metadata, oldStatus, newStatus, time.Now()))
 }
 
 func GenerateTableSchema(metadataTableName string, readCapacityUnits int64, writeCapacityUnits int64) *dynamodb.CreateTableInput {
@@ -227,6 +483,10 @@ func GenerateTableSchema(metadataTableName string, readCapacityUnits int64, writ
 				AttributeName: aws.String("BlobIndex"),
 				AttributeType: types.ScalarAttributeTypeN,
 			},
+			{
+				AttributeName: aws.String("AccountID"),
+				AttributeType: types.ScalarAttributeTypeS,
+			},
 		},
 		KeySchema: []types.KeySchemaElement{
 			{
@@ -280,6 +540,26 @@ func GenerateTableSchema(metadataTableName string, readCapacityUnits int64, writ
 					WriteCapacityUnits: aws.Int64(writeCapacityUnits),
 				},
 			},
+			{
+				IndexName: aws.String(accountIndexName),
+				KeySchema: []types.KeySchemaElement{
+					{
+						AttributeName: aws.String("AccountID"),
+						KeyType:       types.KeyTypeHash,
+					},
+					{
+						AttributeName: aws.String("RequestedAt"),
+						KeyType:       types.KeyTypeRange,
+					},
+				},
+				Projection: &types.Projection{
+					ProjectionType: types.ProjectionTypeAll,
+				},
+				ProvisionedThroughput: &types.ProvisionedThroughput{
+					ReadCapacityUnits:  aws.Int64(readCapacityUnits),
+					WriteCapacityUnits: aws.Int64(writeCapacityUnits),
+				},
+			},
 		},
 		ProvisionedThroughput: &types.ProvisionedThroughput{
 			ReadCapacityUnits:  aws.Int64(readCapacityUnits),
@@ -307,6 +587,10 @@ func MarshalBlobMetadata(metadata *disperser.BlobMetadata) (commondynamodb.Item,
 	for k, v := range requestMetadata {
 		basicFields[k] = v
 	}
+	// The key attributes of an index can't be empty, so the blobs without an account are left out of the AccountIndex
+	if metadata.RequestMetadata.AccountID == "" {
+		delete(basicFields, "AccountID")
+	}
 
 	if metadata.ConfirmationInfo == nil {
 		return basicFields, nil
@@ -325,7 +609,17 @@ func MarshalBlobMetadata(metadata *disperser.BlobMetadata) (commondynamodb.Item,
 	return basicFields, nil
 }
 
+// UnmarshalBlobMetadata decodes an item written with any schema version up to MetadataSchemaVersion, upgrading the
+// items of the older versions as it reads them. It returns ErrUnsupportedSchemaVersion for the items of newer versions.
 func UnmarshalBlobMetadata(item commondynamodb.Item) (*disperser.BlobMetadata, error) {
+	upgraded, _, _, err := upgradeMetadataItem(item)
+	if err != nil {
+		return nil, err
+	}
+	return unmarshalUpgradedBlobMetadata(upgraded)
+}
+
+func unmarshalUpgradedBlobMetadata(item commondynamodb.Item) (*disperser.BlobMetadata, error) {
 	metadata := disperser.BlobMetadata{}
 	err := attributevalue.UnmarshalMap(item, &metadata)
 	if err != nil {
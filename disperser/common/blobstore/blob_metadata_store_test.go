@@ -2,17 +2,22 @@ package blobstore_test
 
 import (
 	"context"
+	"fmt"
+	"strconv"
 	"testing"
 	"time"
 
 	commondynamodb "github.com/Layr-Labs/eigenda/common/aws/dynamodb"
 	"github.com/Layr-Labs/eigenda/core"
 	"github.com/Layr-Labs/eigenda/disperser"
+	"github.com/Layr-Labs/eigenda/disperser/common/blobstore"
+	"github.com/Layr-Labs/eigenda/disperser/common/events"
 	"github.com/Layr-Labs/eigenda/pkg/kzg/bn254"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
 	"github.com/consensys/gnark-crypto/ecc/bn254/fp"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestBlobMetadataStoreOperations(t *testing.T) {
@@ -99,6 +104,248 @@ func TestBlobMetadataStoreOperations(t *testing.T) {
 	})
 }
 
+func TestBlobMetadataStoreUpdateBlobExpiry(t *testing.T) {
+	ctx := context.Background()
+	blobKey := disperser.BlobKey{
+		BlobHash:     blobHash,
+		MetadataHash: "expiry",
+	}
+	expiry := uint64(time.Now().Add(time.Hour).Unix())
+	metadata := &disperser.BlobMetadata{
+		MetadataHash: blobKey.MetadataHash,
+		BlobHash:     blobKey.BlobHash,
+		BlobStatus:   disperser.Processing,
+		Expiry:       expiry,
+		RequestMetadata: &disperser.RequestMetadata{
+			BlobRequestHeader: blob.RequestHeader,
+			BlobSize:          blobSize,
+			RequestedAt:       123,
+		},
+	}
+	assert.NoError(t, blobMetadataStore.QueueNewBlobMetadata(ctx, metadata))
+
+	extension := &disperser.RetentionExtension{
+		ExtendedAt:     uint64(time.Now().Unix()),
+		PreviousExpiry: expiry,
+		Expiry:         expiry + 3600,
+	}
+	assert.NoError(t, blobMetadataStore.UpdateBlobExpiry(ctx, metadata, extension))
+
+	// The TTL attribute of the item is updated along with the record of the extension
+	item, err := dynamoClient.GetItem(ctx, metadataTableName, commondynamodb.Key{
+		"MetadataHash": &types.AttributeValueMemberS{Value: blobKey.MetadataHash},
+		"BlobHash":     &types.AttributeValueMemberS{Value: blobKey.BlobHash},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, &types.AttributeValueMemberN{Value: strconv.FormatUint(expiry+3600, 10)}, item["Expiry"])
+	fetchedMetadata, err := blobMetadataStore.GetBlobMetadata(ctx, blobKey)
+	assert.NoError(t, err)
+	assert.Equal(t, expiry+3600, fetchedMetadata.Expiry)
+	assert.Equal(t, []*disperser.RetentionExtension{extension}, fetchedMetadata.RetentionExtensions)
+
+	// An update from a stale expiry is rejected
+	err = blobMetadataStore.UpdateBlobExpiry(ctx, metadata, &disperser.RetentionExtension{Expiry: expiry + 7200})
+	assert.ErrorIs(t, err, commondynamodb.ErrConditionFailed)
+	fetchedMetadata, err = blobMetadataStore.GetBlobMetadata(ctx, blobKey)
+	assert.NoError(t, err)
+	assert.Equal(t, expiry+3600, fetchedMetadata.Expiry)
+
+	deleteItems(t, []commondynamodb.Key{
+		{
+			"MetadataHash": &types.AttributeValueMemberS{Value: blobKey.MetadataHash},
+			"BlobHash":     &types.AttributeValueMemberS{Value: blobKey.BlobHash},
+		},
+	})
+}
+
+func TestBlobMetadataStorePublishesStatusEvents(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	sink := events.NewMemorySink()
+	publisher := events.NewPublisher(sink, 10, logger, nil)
+	publisher.Start(ctx)
+	store := blobstore.NewBlobMetadataStore(dynamoClient, logger, metadataTableName, time.Hour)
+	store.SetStatusEventPublisher(publisher)
+
+	blobKey := disperser.BlobKey{
+		BlobHash:     blobHash,
+		MetadataHash: "events",
+	}
+	metadata := &disperser.BlobMetadata{
+		MetadataHash: blobKey.MetadataHash,
+		BlobHash:     blobKey.BlobHash,
+		BlobStatus:   disperser.Processing,
+		RequestMetadata: &disperser.RequestMetadata{
+			BlobRequestHeader: blob.RequestHeader,
+			BlobSize:          blobSize,
+			RequestedAt:       123,
+		},
+	}
+	assert.NoError(t, store.QueueNewBlobMetadata(ctx, metadata))
+
+	// The updates which don't change the status aren't published
+	retried := *metadata
+	retried.NumRetries = 1
+	assert.NoError(t, store.UpdateBlobMetadataFromRetries(ctx, blobKey, disperser.Processing, 0, &retried))
+	assert.NoError(t, store.UpdateBlobMetadata(ctx, blobKey, getConfirmedMetadata(t, blobKey)))
+	assert.NoError(t, store.SetBlobStatus(ctx, blobKey, disperser.Finalized))
+
+	require.Eventually(t, func() bool {
+		return len(sink.Events()) == 3
+	}, time.Second, 10*time.Millisecond)
+	published := sink.Events()
+	transitions := make([][2]string, len(published))
+	for i, event := range published {
+		assert.Equal(t, blobKey.String(), event.BlobKey)
+		assert.Equal(t, blob.RequestHeader.AccountID, event.Account)
+		assert.Len(t, event.Quorums, len(blob.RequestHeader.SecurityParams))
+		transitions[i] = [2]string{event.OldStatus, event.NewStatus}
+	}
+	assert.Equal(t, [][2]string{{"", "Processing"}, {"Processing", "Confirmed"}, {"Confirmed", "Finalized"}}, transitions)
+
+	deleteItems(t, []commondynamodb.Key{
+		{
+			"MetadataHash": &types.AttributeValueMemberS{Value: blobKey.MetadataHash},
+			"BlobHash":     &types.AttributeValueMemberS{Value: blobKey.BlobHash},
+		},
+	})
+}
+
+func TestBlobMetadataStoreGetBlobMetadataByAccountAndTimeRange(t *testing.T) {
+	ctx := context.Background()
+	seeded := seedAccountMetadata(t, "account-time-range")
+
+	// The bounds of the range are inclusive, and the blobs of other accounts or without one are left out
+	fetched, next, err := blobMetadataStore.GetBlobMetadataByAccountAndTimeRange(ctx, "account-time-range", 100, 200, nil)
+	assert.NoError(t, err)
+	assert.Nil(t, next)
+	assert.Equal(t, seeded[1:4], fetched)
+
+	fetched, _, err = blobMetadataStore.GetBlobMetadataByAccountAndTimeRange(ctx, "account-time-range", 101, 199, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, seeded[2:3], fetched)
+
+	fetched, _, err = blobMetadataStore.GetBlobMetadataByAccountAndTimeRange(ctx, "account-time-range", 202, 300, nil)
+	assert.NoError(t, err)
+	assert.Empty(t, fetched)
+
+	// The pages add up to the whole range, in the order of the requests
+	var paged []*disperser.BlobMetadata
+	pagination := &blobstore.Pagination{Limit: 2}
+	for pagination != nil {
+		var page []*disperser.BlobMetadata
+		page, pagination, err = blobMetadataStore.GetBlobMetadataByAccountAndTimeRange(ctx, "account-time-range", 0, 300, pagination)
+		assert.NoError(t, err)
+		assert.LessOrEqual(t, len(page), 2)
+		paged = append(paged, page...)
+	}
+	assert.Equal(t, seeded[:5], paged)
+
+	deleteSeededMetadata(t, seeded)
+}
+
+func TestBlobMetadataStoreGetBlobMetadataByAccountAndLabel(t *testing.T) {
+	ctx := context.Background()
+	seeded := seedAccountMetadata(t, "account-label")
+
+	// Only the blobs of the account in the range whose label has the value are returned
+	fetched, next, err := blobMetadataStore.GetBlobMetadataByAccountAndLabel(ctx, "account-label", 100, 200, "parity", "even", nil)
+	assert.NoError(t, err)
+	assert.Nil(t, next)
+	assert.Equal(t, []*disperser.BlobMetadata{seeded[2]}, fetched)
+
+	fetched, _, err = blobMetadataStore.GetBlobMetadataByAccountAndLabel(ctx, "account-label", 0, 300, "parity", "odd", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []*disperser.BlobMetadata{seeded[1], seeded[3]}, fetched)
+
+	fetched, _, err = blobMetadataStore.GetBlobMetadataByAccountAndLabel(ctx, "account-label", 0, 300, "unknown", "even", nil)
+	assert.NoError(t, err)
+	assert.Empty(t, fetched)
+
+	// The pages are filtered after the limit is applied, so they can be short or empty, but add up to the matches
+	var paged []*disperser.BlobMetadata
+	pagination := &blobstore.Pagination{Limit: 1}
+	for pagination != nil {
+		var page []*disperser.BlobMetadata
+		page, pagination, err = blobMetadataStore.GetBlobMetadataByAccountAndLabel(ctx, "account-label", 0, 300, "parity", "even", pagination)
+		assert.NoError(t, err)
+		assert.LessOrEqual(t, len(page), 1)
+		paged = append(paged, page...)
+	}
+	assert.Equal(t, []*disperser.BlobMetadata{seeded[0], seeded[2], seeded[4]}, paged)
+
+	_, _, err = blobMetadataStore.GetBlobMetadataByAccountAndLabel(ctx, "account-label", 0, 300, "", "even", nil)
+	assert.Error(t, err)
+
+	deleteSeededMetadata(t, seeded)
+}
+
+// seedAccountMetadata queues the metadata of blobs of the account requested around the range [100, 200], in mixed
+// statuses, followed by blobs requested in the range by another account and without an account. The blobs are labeled
+// with the parity of their index.
+func seedAccountMetadata(t *testing.T, account string) []*disperser.BlobMetadata {
+	requests := []struct {
+		account     string
+		requestedAt uint64
+		status      disperser.BlobStatus
+	}{
+		{account, 99, disperser.Confirmed},
+		{account, 100, disperser.Processing},
+		{account, 150, disperser.Confirmed},
+		{account, 200, disperser.Finalized},
+		{account, 201, disperser.Failed},
+		{account + "-other", 150, disperser.Confirmed},
+		{"", 150, disperser.Confirmed},
+	}
+
+	seeded := make([]*disperser.BlobMetadata, len(requests))
+	for i, request := range requests {
+		metadataKey := disperser.BlobKey{
+			BlobHash:     fmt.Sprintf("%s-blob-%d", account, i),
+			MetadataHash: "hash",
+		}
+		metadata := &disperser.BlobMetadata{
+			BlobHash:     metadataKey.BlobHash,
+			MetadataHash: metadataKey.MetadataHash,
+			BlobStatus:   request.status,
+			RequestMetadata: &disperser.RequestMetadata{
+				BlobRequestHeader: core.BlobRequestHeader{
+					SecurityParams: []*core.SecurityParam{
+						{QuorumID: 0, AdversaryThreshold: 50, QuorumThreshold: 100},
+						{QuorumID: 1, AdversaryThreshold: 80, QuorumThreshold: 90},
+					},
+					AccountID: request.account,
+					Labels:    map[string]string{"parity": []string{"even", "odd"}[i%2]},
+				},
+				BlobSize:    uint(1000 * (i + 1)),
+				RequestedAt: request.requestedAt,
+			},
+		}
+		if request.status == disperser.Confirmed || request.status == disperser.Finalized {
+			metadata.ConfirmationInfo = &disperser.ConfirmationInfo{
+				BlobQuorumInfos: []*core.BlobQuorumInfo{
+					{SecurityParam: *metadata.RequestMetadata.SecurityParams[0], EncodedBlobLength: 64},
+					{SecurityParam: *metadata.RequestMetadata.SecurityParams[1], EncodedBlobLength: 256},
+				},
+			}
+		}
+		assert.NoError(t, blobMetadataStore.QueueNewBlobMetadata(context.Background(), metadata))
+		seeded[i] = metadata
+	}
+	return seeded
+}
+
+func deleteSeededMetadata(t *testing.T, seeded []*disperser.BlobMetadata) {
+	keys := make([]commondynamodb.Key, len(seeded))
+	for i, metadata := range seeded {
+		keys[i] = commondynamodb.Key{
+			"MetadataHash": &types.AttributeValueMemberS{Value: metadata.MetadataHash},
+			"BlobHash":     &types.AttributeValueMemberS{Value: metadata.BlobHash},
+		}
+	}
+	deleteItems(t, keys)
+}
+
 func deleteItems(t *testing.T, keys []commondynamodb.Key) {
 	_, err := dynamoClient.DeleteItems(context.Background(), metadataTableName, keys)
 	assert.NoError(t, err)
@@ -7,7 +7,6 @@ import (
 	"testing"
 	"time"
 
-	"github.com/Layr-Labs/eigenda/common/aws"
 	"github.com/Layr-Labs/eigenda/common/aws/dynamodb"
 	test_utils "github.com/Layr-Labs/eigenda/common/aws/dynamodb/utils"
 	"github.com/google/uuid"
@@ -76,12 +75,7 @@ func setup(m *testing.M) {
 
 	}
 
-	cfg := aws.ClientConfig{
-		Region:          "us-east-1",
-		AccessKey:       "localstack",
-		SecretAccessKey: "localstack",
-		EndpointURL:     fmt.Sprintf("http://0.0.0.0:%s", localStackPort),
-	}
+	cfg := deploy.LocalstackClientConfig(localStackPort)
 
 	_, err := test_utils.CreateTable(context.Background(), cfg, metadataTableName, blobstore.GenerateTableSchema(metadataTableName, 10, 10))
 	if err != nil {
@@ -96,7 +90,7 @@ func setup(m *testing.M) {
 	}
 
 	blobMetadataStore = blobstore.NewBlobMetadataStore(dynamoClient, logger, metadataTableName, time.Hour)
-	sharedStorage = blobstore.NewSharedStorage(bucketName, s3Client, blobMetadataStore, logger)
+	sharedStorage = blobstore.NewSharedStorage(bucketName, "", s3Client, blobMetadataStore, logger)
 }
 
 func teardown() {
@@ -0,0 +1,168 @@
+package blobstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common"
+	commondynamodb "github.com/Layr-Labs/eigenda/common/aws/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/expression"
+)
+
+// defaultScanPageSize is the number of items read from a table at a time when ScanConfig.PageSize is 0
+const defaultScanPageSize = 100
+
+// ScanConfig throttles the migrations scanning a table, so that they don't take the capacity of the tables from the
+// disperser
+type ScanConfig struct {
+	// PageSize is the number of items read from the table at a time, or 0 for the default of 100
+	PageSize int32
+	// MaxItemsPerSecond is the max number of items written per second, or 0 for no limit
+	MaxItemsPerSecond int
+}
+
+// CopyResult is the outcome of CopyMetadata
+type CopyResult struct {
+	// Copied is the number of items written to the destination table
+	Copied int
+	// Skipped is the number of items left out because the destination table already had an item with the same key
+	Skipped int
+}
+
+// UpgradeResult is the outcome of UpgradeMetadataSchema
+type UpgradeResult struct {
+	// Upgraded is the number of items upgraded to MetadataSchemaVersion
+	Upgraded int
+	// Current is the number of items which were already at MetadataSchemaVersion, including those upgraded
+	// concurrently, e.g. by a read of the disperser
+	Current int
+}
+
+// CopyMetadata copies the blob metadata from the src table to the dst table, e.g. to move the disperser to a new table
+// by pointing its table name config at dst once the copy is done. The items are copied as stored, a page at a time, so
+// the tables must have the schema of GenerateTableSchema. The items which already exist in dst are left as they are,
+// so that the copy can be run again after the disperser switched to dst, to pick up the items written to src in the
+// meantime without overwriting their newer updates in dst.
+func CopyMetadata(ctx context.Context, dynamoDBClient *commondynamodb.Client, src string, dst string, config ScanConfig, logger common.Logger) (*CopyResult, error) {
+	if src == dst {
+		return nil, fmt.Errorf("the source and destination tables are the same table %s", src)
+	}
+	notExists := expression.AttributeNotExists(expression.Name("BlobHash"))
+
+	result := &CopyResult{}
+	throttle := newWriteThrottle(config.MaxItemsPerSecond)
+	defer throttle.stop()
+	err := scanTable(ctx, dynamoDBClient, src, config.PageSize, func(item commondynamodb.Item) error {
+		if err := throttle.wait(ctx); err != nil {
+			return err
+		}
+		err := dynamoDBClient.PutItemWithCondition(ctx, dst, item, notExists)
+		if errors.Is(err, commondynamodb.ErrConditionFailed) {
+			result.Skipped++
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to write the metadata to %s: %w", dst, err)
+		}
+		result.Copied++
+		return nil
+	}, func() {
+		logger.Debug("copied a page of blob metadata", "src", src, "dst", dst, "copied", result.Copied, "skipped", result.Skipped)
+	})
+	return result, err
+}
+
+// UpgradeMetadataSchema upgrades the blob metadata items of the table written with an older schema to
+// MetadataSchemaVersion. The reads of the disperser tolerate the items of any older version, which they upgrade as
+// they read them, so the upgrade can run while the disperser serves from the table. Only the attributes changed by the
+// upgrade are written, so the concurrent updates of the blobs are preserved. It fails on the items written with a newer
+// schema, i.e. if a newer disperser already writes to the table.
+func UpgradeMetadataSchema(ctx context.Context, dynamoDBClient *commondynamodb.Client, tableName string, config ScanConfig, logger common.Logger) (*UpgradeResult, error) {
+	result := &UpgradeResult{}
+	throttle := newWriteThrottle(config.MaxItemsPerSecond)
+	defer throttle.stop()
+	err := scanTable(ctx, dynamoDBClient, tableName, config.PageSize, func(item commondynamodb.Item) error {
+		_, version, update, err := upgradeMetadataItem(item)
+		if err != nil {
+			return fmt.Errorf("failed to upgrade the metadata of %s: %w", tableName, err)
+		}
+		if version == MetadataSchemaVersion {
+			result.Current++
+			return nil
+		}
+		if err := throttle.wait(ctx); err != nil {
+			return err
+		}
+		err = dynamoDBClient.UpdateItemWithExpression(ctx, tableName, getItemKey(item), update, schemaVersionCondition(version))
+		if errors.Is(err, commondynamodb.ErrConditionFailed) {
+			result.Current++
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to write the upgraded metadata to %s: %w", tableName, err)
+		}
+		result.Upgraded++
+		return nil
+	}, func() {
+		logger.Debug("upgraded a page of blob metadata", "table", tableName, "upgraded", result.Upgraded, "current", result.Current)
+	})
+	return result, err
+}
+
+// scanTable calls handleItem with each item of the table, reading the table a page at a time, and pageDone after each
+// page
+func scanTable(ctx context.Context, dynamoDBClient *commondynamodb.Client, tableName string, pageSize int32, handleItem func(commondynamodb.Item) error, pageDone func()) error {
+	if pageSize <= 0 {
+		pageSize = defaultScanPageSize
+	}
+	var exclusiveStartKey commondynamodb.Key
+	for {
+		items, lastEvaluatedKey, err := dynamoDBClient.ScanWithPagination(ctx, tableName, pageSize, exclusiveStartKey)
+		if err != nil {
+			return fmt.Errorf("failed to read the metadata from %s: %w", tableName, err)
+		}
+		for _, item := range items {
+			if err := handleItem(item); err != nil {
+				return err
+			}
+		}
+		pageDone()
+
+		if lastEvaluatedKey == nil {
+			return nil
+		}
+		exclusiveStartKey = lastEvaluatedKey
+	}
+}
+
+// writeThrottle paces the writes of a migration to a max number of items per second, or doesn't if it is 0
+type writeThrottle struct {
+	ticker *time.Ticker
+}
+
+func newWriteThrottle(maxItemsPerSecond int) *writeThrottle {
+	if maxItemsPerSecond <= 0 {
+		return &writeThrottle{}
+	}
+	return &writeThrottle{ticker: time.NewTicker(time.Second / time.Duration(maxItemsPerSecond))}
+}
+
+func (t *writeThrottle) wait(ctx context.Context) error {
+	if t.ticker == nil {
+		return nil
+	}
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.ticker.C:
+		return nil
+	}
+}
+
+func (t *writeThrottle) stop() {
+	if t.ticker != nil {
+		t.ticker.Stop()
+	}
+}
@@ -0,0 +1,200 @@
+package blobstore_test
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"testing"
+	"time"
+
+	commondynamodb "github.com/Layr-Labs/eigenda/common/aws/dynamodb"
+	test_utils "github.com/Layr-Labs/eigenda/common/aws/dynamodb/utils"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/disperser"
+	"github.com/Layr-Labs/eigenda/disperser/common/blobstore"
+	"github.com/Layr-Labs/eigenda/inabox/deploy"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCopyMetadata(t *testing.T) {
+	ctx := context.Background()
+	cfg := deploy.LocalstackClientConfig(localStackPort)
+	srcTableName := fmt.Sprintf("test-BlobMetadata-src-%v", UUID)
+	dstTableName := fmt.Sprintf("test-BlobMetadata-dst-%v", UUID)
+	for _, tableName := range []string{srcTableName, dstTableName} {
+		_, err := test_utils.CreateTable(ctx, cfg, tableName, blobstore.GenerateTableSchema(tableName, 10, 10))
+		require.NoError(t, err)
+	}
+	srcStore := blobstore.NewBlobMetadataStore(dynamoClient, logger, srcTableName, time.Hour)
+	dstStore := blobstore.NewBlobMetadataStore(dynamoClient, logger, dstTableName, time.Hour)
+
+	numBlobs := 5
+	keys := make([]disperser.BlobKey, numBlobs)
+	for i := 0; i < numBlobs; i++ {
+		metadata := &disperser.BlobMetadata{
+			BlobHash:     fmt.Sprintf("blob%d", i),
+			MetadataHash: "hash",
+			BlobStatus:   disperser.Processing,
+			RequestMetadata: &disperser.RequestMetadata{
+				BlobRequestHeader: blob.RequestHeader,
+				BlobSize:          blobSize,
+				RequestedAt:       uint64(i),
+			},
+		}
+		keys[i] = metadata.GetBlobKey()
+		require.NoError(t, srcStore.QueueNewBlobMetadata(ctx, metadata))
+	}
+	// The destination already has a newer version of one of the blobs
+	updated, err := srcStore.GetBlobMetadata(ctx, keys[0])
+	require.NoError(t, err)
+	updated.BlobStatus = disperser.Failed
+	require.NoError(t, dstStore.QueueNewBlobMetadata(ctx, updated))
+
+	result, err := blobstore.CopyMetadata(ctx, dynamoClient, srcTableName, dstTableName, blobstore.ScanConfig{
+		PageSize:          2,
+		MaxItemsPerSecond: 100,
+	}, logger)
+	assert.NoError(t, err)
+	assert.Equal(t, numBlobs-1, result.Copied)
+	assert.Equal(t, 1, result.Skipped)
+
+	for i, key := range keys {
+		copied, err := dstStore.GetBlobMetadata(ctx, key)
+		require.NoError(t, err)
+		if i == 0 {
+			assert.Equal(t, disperser.Failed, copied.BlobStatus)
+			continue
+		}
+		original, err := srcStore.GetBlobMetadata(ctx, key)
+		require.NoError(t, err)
+		assert.Equal(t, original, copied)
+	}
+	// The copied items are indexed in the destination
+	processing, err := dstStore.GetBlobMetadataByStatus(ctx, disperser.Processing)
+	assert.NoError(t, err)
+	assert.Len(t, processing, numBlobs-1)
+
+	// Copying again leaves the destination as is
+	result, err = blobstore.CopyMetadata(ctx, dynamoClient, srcTableName, dstTableName, blobstore.ScanConfig{}, logger)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, result.Copied)
+	assert.Equal(t, numBlobs, result.Skipped)
+
+	_, err = blobstore.CopyMetadata(ctx, dynamoClient, srcTableName, srcTableName, blobstore.ScanConfig{}, logger)
+	assert.Error(t, err)
+}
+
+func TestUpgradeMetadataSchema(t *testing.T) {
+	ctx := context.Background()
+	cfg := deploy.LocalstackClientConfig(localStackPort)
+	tableName := fmt.Sprintf("test-BlobMetadata-upgrade-%v", UUID)
+	_, err := test_utils.CreateTable(ctx, cfg, tableName, blobstore.GenerateTableSchema(tableName, 10, 10))
+	require.NoError(t, err)
+	store := blobstore.NewBlobMetadataStore(dynamoClient, logger, tableName, time.Hour)
+
+	// The items written before the schema was versioned have no schema version
+	numLegacyBlobs := 3
+	keys := make([]disperser.BlobKey, numLegacyBlobs)
+	for i := 0; i < numLegacyBlobs; i++ {
+		metadata := &disperser.BlobMetadata{
+			BlobHash:     fmt.Sprintf("legacy%d", i),
+			MetadataHash: "hash",
+			BlobStatus:   disperser.Processing,
+			RequestMetadata: &disperser.RequestMetadata{
+				BlobRequestHeader: blob.RequestHeader,
+				BlobSize:          blobSize,
+				RequestedAt:       uint64(i),
+			},
+		}
+		keys[i] = metadata.GetBlobKey()
+		item, err := blobstore.MarshalBlobMetadata(metadata)
+		require.NoError(t, err)
+		require.NoError(t, dynamoClient.PutItem(ctx, tableName, item))
+	}
+	require.NoError(t, store.QueueNewBlobMetadata(ctx, &disperser.BlobMetadata{
+		BlobHash:     "current",
+		MetadataHash: "hash",
+		BlobStatus:   disperser.Processing,
+		RequestMetadata: &disperser.RequestMetadata{
+			BlobRequestHeader: blob.RequestHeader,
+			BlobSize:          blobSize,
+		},
+	}))
+
+	// A read upgrades the legacy item it reads
+	metadata, err := store.GetBlobMetadata(ctx, keys[0])
+	require.NoError(t, err)
+	assert.Equal(t, disperser.Processing, metadata.BlobStatus)
+	assert.Equal(t, blob.RequestHeader.AccountID, metadata.RequestMetadata.AccountID)
+	assert.Equal(t, strconv.Itoa(blobstore.MetadataSchemaVersion), getSchemaVersion(t, ctx, tableName, keys[0]))
+
+	result, err := blobstore.UpgradeMetadataSchema(ctx, dynamoClient, tableName, blobstore.ScanConfig{
+		PageSize:          2,
+		MaxItemsPerSecond: 100,
+	}, logger)
+	assert.NoError(t, err)
+	assert.Equal(t, numLegacyBlobs-1, result.Upgraded)
+	assert.Equal(t, 2, result.Current)
+	for _, key := range keys {
+		assert.Equal(t, strconv.Itoa(blobstore.MetadataSchemaVersion), getSchemaVersion(t, ctx, tableName, key))
+		metadata, err := store.GetBlobMetadata(ctx, key)
+		require.NoError(t, err)
+		assert.Equal(t, blob.RequestHeader.AccountID, metadata.RequestMetadata.AccountID)
+	}
+
+	// The items written by a newer disperser can't be read, nor upgraded
+	item, err := blobstore.MarshalBlobMetadata(&disperser.BlobMetadata{
+		BlobHash:     "future",
+		MetadataHash: "hash",
+		BlobStatus:   disperser.Processing,
+		RequestMetadata: &disperser.RequestMetadata{
+			BlobRequestHeader: blob.RequestHeader,
+		},
+	})
+	require.NoError(t, err)
+	item["SchemaVersion"] = &types.AttributeValueMemberN{Value: strconv.Itoa(blobstore.MetadataSchemaVersion + 1)}
+	require.NoError(t, dynamoClient.PutItem(ctx, tableName, item))
+	_, err = store.GetBlobMetadata(ctx, disperser.BlobKey{BlobHash: "future", MetadataHash: "hash"})
+	assert.ErrorIs(t, err, blobstore.ErrUnsupportedSchemaVersion)
+	_, err = blobstore.UpgradeMetadataSchema(ctx, dynamoClient, tableName, blobstore.ScanConfig{}, logger)
+	assert.ErrorIs(t, err, blobstore.ErrUnsupportedSchemaVersion)
+}
+
+func TestUnmarshalLegacyBlobMetadata(t *testing.T) {
+	item, err := blobstore.MarshalBlobMetadata(&disperser.BlobMetadata{
+		BlobHash:     "legacy",
+		MetadataHash: "hash",
+		BlobStatus:   disperser.Processing,
+		RequestMetadata: &disperser.RequestMetadata{
+			BlobSize: blobSize,
+		},
+	})
+	require.NoError(t, err)
+	// The items written before the AccountIndex have an empty AccountID for the blobs without an account
+	item["AccountID"] = &types.AttributeValueMemberS{Value: ""}
+
+	metadata, err := blobstore.UnmarshalBlobMetadata(item)
+	assert.NoError(t, err)
+	assert.Equal(t, "legacy", metadata.BlobHash)
+	assert.Equal(t, core.AccountID(""), metadata.RequestMetadata.AccountID)
+	assert.Equal(t, blobSize, metadata.RequestMetadata.BlobSize)
+	// The item itself is left as is
+	assert.Contains(t, item, "AccountID")
+
+	item["SchemaVersion"] = &types.AttributeValueMemberN{Value: "x"}
+	_, err = blobstore.UnmarshalBlobMetadata(item)
+	assert.ErrorIs(t, err, blobstore.ErrUnsupportedSchemaVersion)
+}
+
+func getSchemaVersion(t *testing.T, ctx context.Context, tableName string, key disperser.BlobKey) string {
+	item, err := dynamoClient.GetItem(ctx, tableName, commondynamodb.Key{
+		"BlobHash":     &types.AttributeValueMemberS{Value: key.BlobHash},
+		"MetadataHash": &types.AttributeValueMemberS{Value: key.MetadataHash},
+	})
+	require.NoError(t, err)
+	version, ok := item["SchemaVersion"].(*types.AttributeValueMemberN)
+	require.True(t, ok)
+	return version.Value
+}
@@ -0,0 +1,146 @@
+package blobstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common"
+	commonaws "github.com/Layr-Labs/eigenda/common/aws"
+	"github.com/Layr-Labs/eigenda/common/aws/s3"
+)
+
+const (
+	// primaryRegion labels the endpoint of the bucket the blobs are written to, whatever its region
+	primaryRegion = "primary"
+	// maxReadFailures is the number of consecutive failed reads demoting an endpoint
+	maxReadFailures = 3
+)
+
+// ReplicaBucket is a replica of the bucket of the blobs in another region
+type ReplicaBucket struct {
+	Region string
+	Bucket string
+}
+
+type ReplicaConfig struct {
+	// ReadTimeout bounds each attempt at reading an object from an endpoint. The attempts are unbounded when 0.
+	ReadTimeout time.Duration
+	// DemotionPeriod is how long an endpoint whose reads keep failing is read after the others
+	DemotionPeriod time.Duration
+}
+
+// readEndpoint is an endpoint the objects are read from, along with its health
+type readEndpoint struct {
+	s3.Endpoint
+	// failures is the number of consecutive failed reads of the endpoint
+	failures int
+	// demotedUntil is the time until which the endpoint is read after the healthy ones
+	demotedUntil time.Time
+}
+
+// ParseReplicaBuckets parses the replicas of the bucket, given as region:bucket
+func ParseReplicaBuckets(values []string) ([]ReplicaBucket, error) {
+	replicas := make([]ReplicaBucket, 0, len(values))
+	for _, value := range values {
+		region, bucket, ok := strings.Cut(value, ":")
+		if !ok || region == "" || bucket == "" {
+			return nil, fmt.Errorf("invalid replica bucket %q, expected region:bucket", value)
+		}
+		replicas = append(replicas, ReplicaBucket{Region: region, Bucket: bucket})
+	}
+	return replicas, nil
+}
+
+// NewReplicaEndpoints creates the clients of the regions of the replicas
+func NewReplicaEndpoints(ctx context.Context, cfg commonaws.ClientConfig, replicas []ReplicaBucket, logger common.Logger) ([]s3.Endpoint, error) {
+	endpoints := make([]s3.Endpoint, len(replicas))
+	for i, replica := range replicas {
+		client, err := s3.NewRegionalClient(ctx, cfg, replica.Region, logger)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create the s3 client of region %s: %w", replica.Region, err)
+		}
+		endpoints[i] = s3.Endpoint{Region: replica.Region, Bucket: replica.Bucket, Client: client}
+	}
+	return endpoints, nil
+}
+
+// SetReadReplicas makes the store read the objects from the given replicas of the bucket, in order, when the bucket
+// fails to serve them. The objects are still written to the bucket only, and replicated by S3. onFallbackRead, if not
+// nil, is called with the region of each replica serving a read.
+func (s *SharedBlobStore) SetReadReplicas(replicas []s3.Endpoint, config ReplicaConfig, onFallbackRead func(region string)) {
+	s.readEndpointsMu.Lock()
+	defer s.readEndpointsMu.Unlock()
+	s.readEndpoints = s.readEndpoints[:1]
+	for _, replica := range replicas {
+		s.readEndpoints = append(s.readEndpoints, &readEndpoint{Endpoint: replica})
+	}
+	s.replicaConfig = config
+	s.onFallbackRead = onFallbackRead
+}
+
+// downloadObject reads the object from the first endpoint serving it, trying the healthy endpoints in order before the
+// demoted ones. An endpoint not finding the object is authoritative, as the objects are written to the bucket before
+// they are referenced.
+func (s *SharedBlobStore) downloadObject(ctx context.Context, key string) ([]byte, error) {
+	var errs error
+	for _, endpoint := range s.readOrder() {
+		attemptCtx, cancel := ctx, context.CancelFunc(func() {})
+		if s.replicaConfig.ReadTimeout > 0 {
+			attemptCtx, cancel = context.WithTimeout(ctx, s.replicaConfig.ReadTimeout)
+		}
+		data, err := endpoint.Client.DownloadObject(attemptCtx, endpoint.Bucket, key)
+		cancel()
+		if err == nil || errors.Is(err, s3.ErrObjectNotFound) {
+			s.recordRead(endpoint, nil)
+			if err == nil && endpoint.Region != primaryRegion && s.onFallbackRead != nil {
+				s.onFallbackRead(endpoint.Region)
+			}
+			return data, err
+		}
+		// The read was canceled by the caller, which says nothing of the endpoint
+		if ctx.Err() != nil {
+			return nil, errors.Join(errs, err)
+		}
+		s.recordRead(endpoint, err)
+		errs = errors.Join(errs, fmt.Errorf("failed to read %s from region %s: %w", key, endpoint.Region, err))
+	}
+	return nil, errs
+}
+
+// readOrder returns the endpoints in the order they are read: the healthy ones, then the demoted ones
+func (s *SharedBlobStore) readOrder() []*readEndpoint {
+	s.readEndpointsMu.Lock()
+	defer s.readEndpointsMu.Unlock()
+	now := time.Now()
+	healthy := make([]*readEndpoint, 0, len(s.readEndpoints))
+	demoted := make([]*readEndpoint, 0)
+	for _, endpoint := range s.readEndpoints {
+		if now.Before(endpoint.demotedUntil) {
+			demoted = append(demoted, endpoint)
+		} else {
+			healthy = append(healthy, endpoint)
+		}
+	}
+	return append(healthy, demoted...)
+}
+
+// recordRead updates the health of the endpoint with the result of a read, demoting it after maxReadFailures
+// consecutive failures
+func (s *SharedBlobStore) recordRead(endpoint *readEndpoint, err error) {
+	s.readEndpointsMu.Lock()
+	defer s.readEndpointsMu.Unlock()
+	if err == nil {
+		endpoint.failures = 0
+		return
+	}
+	endpoint.failures++
+	// A single endpoint has nothing to be demoted behind
+	if endpoint.failures >= maxReadFailures && len(s.readEndpoints) > 1 {
+		s.logger.Warn("Demoting the s3 endpoint whose reads keep failing", "region", endpoint.Region, "bucket", endpoint.Bucket, "failures", endpoint.failures, "period", s.replicaConfig.DemotionPeriod, "err", err)
+		endpoint.failures = 0
+		endpoint.demotedUntil = time.Now().Add(s.replicaConfig.DemotionPeriod)
+	}
+}
@@ -0,0 +1,95 @@
+package blobstore
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	commondynamodb "github.com/Layr-Labs/eigenda/common/aws/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/expression"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// MetadataSchemaVersion is the version of the encoding of the blob metadata items written by this software. The items
+// written before the schema was versioned have no SchemaVersion attribute, and are at version 0.
+const MetadataSchemaVersion = 1
+
+const schemaVersionAttribute = "SchemaVersion"
+
+// ErrUnsupportedSchemaVersion is returned when reading an item written with a newer schema than MetadataSchemaVersion
+var ErrUnsupportedSchemaVersion = errors.New("unsupported blob metadata schema version")
+
+// metadataSchemaUpgrade upgrades an item from the previous schema version. It rewrites the item in place, so that it
+// can be read as an item of the next version, and adds its changes to the update, so that they can be persisted
+// without overwriting the attributes left unchanged, which may be updated concurrently.
+type metadataSchemaUpgrade func(item commondynamodb.Item, update expression.UpdateBuilder) expression.UpdateBuilder
+
+// metadataSchemaUpgrades upgrades an item of version v to version v+1 with the upgrade at index v. A field added to
+// BlobMetadata which needs to be backfilled in the existing items comes with a new schema version and its upgrade.
+var metadataSchemaUpgrades = []metadataSchemaUpgrade{
+	// Version 1 leaves out the empty AccountID of the blobs without an account, which can't be a key of AccountIndex
+	func(item commondynamodb.Item, update expression.UpdateBuilder) expression.UpdateBuilder {
+		if accountID, ok := item["AccountID"].(*types.AttributeValueMemberS); ok && accountID.Value == "" {
+			delete(item, "AccountID")
+			update = update.Remove(expression.Name("AccountID"))
+		}
+		return update
+	},
+}
+
+// getSchemaVersion returns the schema version an item was written with
+func getSchemaVersion(item commondynamodb.Item) (int, error) {
+	attribute, ok := item[schemaVersionAttribute]
+	if !ok {
+		return 0, nil
+	}
+	number, ok := attribute.(*types.AttributeValueMemberN)
+	if !ok {
+		return 0, fmt.Errorf("%w: %s is not a number", ErrUnsupportedSchemaVersion, schemaVersionAttribute)
+	}
+	version, err := strconv.Atoi(number.Value)
+	if err != nil || version < 0 {
+		return 0, fmt.Errorf("%w: %s", ErrUnsupportedSchemaVersion, number.Value)
+	}
+	if version > MetadataSchemaVersion {
+		return 0, fmt.Errorf("%w: %d is newer than %d", ErrUnsupportedSchemaVersion, version, MetadataSchemaVersion)
+	}
+	return version, nil
+}
+
+// upgradeMetadataItem returns a copy of the item upgraded to MetadataSchemaVersion, the version it was written with,
+// and the update which persists the upgrade if the version is older
+func upgradeMetadataItem(item commondynamodb.Item) (commondynamodb.Item, int, expression.UpdateBuilder, error) {
+	var update expression.UpdateBuilder
+	version, err := getSchemaVersion(item)
+	if err != nil {
+		return nil, 0, update, err
+	}
+	upgraded := make(commondynamodb.Item, len(item))
+	for k, v := range item {
+		upgraded[k] = v
+	}
+	for v := version; v < MetadataSchemaVersion; v++ {
+		update = metadataSchemaUpgrades[v](upgraded, update)
+	}
+	update = update.Set(expression.Name(schemaVersionAttribute), expression.Value(MetadataSchemaVersion))
+	return upgraded, version, update, nil
+}
+
+// schemaVersionCondition holds if the stored item is still at the given schema version, so that an upgrade is only
+// persisted once
+func schemaVersionCondition(version int) expression.ConditionBuilder {
+	exists := expression.AttributeExists(expression.Name("BlobHash"))
+	if version == 0 {
+		return exists.And(expression.AttributeNotExists(expression.Name(schemaVersionAttribute)))
+	}
+	return exists.And(expression.Name(schemaVersionAttribute).Equal(expression.Value(version)))
+}
+
+// getItemKey returns the key of a blob metadata item
+func getItemKey(item commondynamodb.Item) commondynamodb.Key {
+	return commondynamodb.Key{
+		"BlobHash":     item["BlobHash"],
+		"MetadataHash": item["MetadataHash"],
+	}
+}
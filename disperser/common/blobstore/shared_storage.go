@@ -6,9 +6,12 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/Layr-Labs/eigenda/common"
+	commondynamodb "github.com/Layr-Labs/eigenda/common/aws/dynamodb"
 	"github.com/Layr-Labs/eigenda/common/aws/s3"
 	"github.com/Layr-Labs/eigenda/core"
 	"github.com/Layr-Labs/eigenda/disperser"
@@ -17,8 +20,19 @@ import (
 
 const (
 	maxS3BlobFetchWorkers = 64
+
+	// cleanupTimeout bounds the removal of the blob of a failed request, which runs after the request is done
+	cleanupTimeout = 10 * time.Second
+	// cleanupRetryInterval is the interval at which the removals of the failed requests which couldn't be completed
+	// are attempted again
+	cleanupRetryInterval = time.Minute
 )
 
+// OrphanGracePeriod is how long after its last write a blob object which no metadata refers to is kept. Objects are
+// shared by all the requests for the same content, so a concurrent request may have uploaded the object and not queued
+// its metadata yet.
+const OrphanGracePeriod = 10 * time.Minute
+
 // The shared blob store that the disperser is operating on.
 // The metadata store is backed by DynamoDB and the blob store is backed by S3.
 //
@@ -37,17 +51,62 @@ const (
 // This store tracks the blob, the state of the blob and the index (to facilitate retrieval).
 //
 // The blobs stored in S3 are key'd by the blob key and the metadata stored in DynamoDB.
+// The object keys can be namespaced under a prefix (e.g. per environment) so that several deployments
+// can share a bucket and lifecycle rules can be applied per prefix.
+// The objects are written to the bucket only. They can be read from the replicas of the bucket in other regions when
+// the bucket fails to serve them, see SetReadReplicas.
 // See blob_metadata_store.go for more details on BlobMetadataStore.
 type SharedBlobStore struct {
 	bucketName        string
+	keyPrefix         string
 	s3Client          s3.Client
 	blobMetadataStore *BlobMetadataStore
 	logger            common.Logger
+
+	// pendingCleanups are the failed requests whose writes couldn't be removed, by metadata key. The value is whether
+	// the metadata of the request may have been written. They are kept in memory, so they are lost on restart.
+	pendingCleanups map[disperser.BlobKey]bool
+	// orphanedBlobs are the blob objects of the failed requests, which are deleted once no metadata refers to them. See
+	// CollectOrphanedBlobs.
+	orphanedBlobs     map[disperser.BlobHash]struct{}
+	pendingCleanupsMu sync.Mutex
+
+	// readEndpoints are the endpoints the objects are read from, starting with the bucket they are written to and
+	// followed by its replicas in other regions. See SetReadReplicas.
+	readEndpoints   []*readEndpoint
+	readEndpointsMu sync.Mutex
+	replicaConfig   ReplicaConfig
+	onFallbackRead  func(region string)
 }
 
 type Config struct {
 	BucketName string
-	TableName  string
+	// KeyPrefix is prepended to the keys of all the objects stored in the bucket. Objects are stored at the root of the
+	// bucket when it is empty.
+	KeyPrefix string
+	TableName string
+	// ReplicaBuckets are the replicas of the bucket in other regions, read in order when the bucket fails to serve the
+	// objects
+	ReplicaBuckets []ReplicaBucket
+	ReplicaConfig  ReplicaConfig
+}
+
+// Validate checks the config before the blob store is created, and returns all the violations at once
+func (c Config) Validate() error {
+	var errs []error
+	if c.BucketName == "" {
+		errs = append(errs, errors.New("the S3 bucket name must not be empty"))
+	}
+	if c.TableName == "" {
+		errs = append(errs, errors.New("the DynamoDB table name must not be empty"))
+	}
+	if c.ReplicaConfig.ReadTimeout < 0 {
+		errs = append(errs, fmt.Errorf("the S3 read timeout must not be negative (0 for no timeout), but found %s", c.ReplicaConfig.ReadTimeout))
+	}
+	if c.ReplicaConfig.DemotionPeriod < 0 {
+		errs = append(errs, fmt.Errorf("the S3 replica demotion period must not be negative, but found %s", c.ReplicaConfig.DemotionPeriod))
+	}
+	return errors.Join(errs...)
 }
 
 // This represents the s3 fetch result for a blob.
@@ -63,12 +122,17 @@ type blobResultOrError struct {
 
 var _ disperser.BlobStore = (*SharedBlobStore)(nil)
 
-func NewSharedStorage(bucketName string, s3Client s3.Client, blobMetadataStore *BlobMetadataStore, logger common.Logger) *SharedBlobStore {
+// NewSharedStorage creates a SharedBlobStore storing the blobs in the given bucket, under keyPrefix if it isn't empty.
+func NewSharedStorage(bucketName string, keyPrefix string, s3Client s3.Client, blobMetadataStore *BlobMetadataStore, logger common.Logger) *SharedBlobStore {
 	return &SharedBlobStore{
 		bucketName:        bucketName,
+		keyPrefix:         strings.Trim(keyPrefix, "/"),
 		s3Client:          s3Client,
 		blobMetadataStore: blobMetadataStore,
 		logger:            logger,
+		pendingCleanups:   make(map[disperser.BlobKey]bool),
+		orphanedBlobs:     make(map[disperser.BlobHash]struct{}),
+		readEndpoints:     []*readEndpoint{{Endpoint: s3.Endpoint{Region: primaryRegion, Bucket: bucketName, Client: s3Client}}},
 	}
 }
 
@@ -87,9 +151,8 @@ func (s *SharedBlobStore) StoreBlob(ctx context.Context, blob *core.Blob, reques
 	metadataKey.BlobHash = blobHash
 	metadataKey.MetadataHash = metadataHash
 
-	err = s.s3Client.UploadObject(ctx, s.bucketName, blobObjectKey(blobHash), blob.Data)
-	if err != nil {
-		s.logger.Error("error uploading blob", "err", err)
+	// Don't spend capacity on requests the client already gave up on
+	if err := ctx.Err(); err != nil {
 		return metadataKey, err
 	}
 
@@ -110,27 +173,216 @@ func (s *SharedBlobStore) StoreBlob(ctx context.Context, blob *core.Blob, reques
 			RequestedAt:       requestedAt,
 		},
 	}
-	err = s.blobMetadataStore.QueueNewBlobMetadata(ctx, &metadata)
-	if err != nil {
+
+	// The metadata is only queued once the blob is uploaded, so the batcher never reads the metadata of a blob it can't
+	// fetch, and no status event is published for a request whose upload failed
+	if err := s.uploadBlob(ctx, blobHash, blob.Data, blob.RequestHeader.ContentType); err != nil {
+		s.logger.Error("error uploading blob", "err", err)
+		// The upload may still have been applied, e.g. if it timed out while it was in flight
+		s.removeBlob(ctx, metadataKey, false)
+		return metadataKey, err
+	}
+	if err := s.blobMetadataStore.QueueNewBlobMetadata(ctx, &metadata); err != nil {
 		s.logger.Error("error uploading blob metadata", "err", err)
+		// The write may still have been applied, e.g. if the request was canceled while it was in flight
+		s.removeBlob(ctx, metadataKey, true)
+		return metadataKey, err
+	}
+
+	// Once queued, the blob is dispersed even though the client never learns its key, so stop here if the request
+	// was canceled during the writes
+	if err := ctx.Err(); err != nil {
+		s.logger.Warn("blob request canceled after storing the blob", "blobHash", blobHash, "err", err)
+		s.removeBlob(ctx, metadataKey, true)
 		return metadataKey, err
 	}
 
 	return metadataKey, nil
 }
 
-// GetBlobContent retrieves blob content by the blob key.
+// removeBlob undoes the writes of a blob request which failed after writing the blob or its metadata. The metadata is
+// removed right away, while the blob object is left for CollectOrphanedBlobs, since it's shared by all the requests for
+// the same content. The removal runs even if the request's context is canceled. If it fails, the removal is tracked to
+// be retried later.
+func (s *SharedBlobStore) removeBlob(ctx context.Context, metadataKey disperser.BlobKey, removeMetadata bool) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), cleanupTimeout)
+	defer cancel()
+
+	if err := s.undoBlobRequest(ctx, metadataKey, removeMetadata); err != nil {
+		s.logger.Error("error removing failed blob request, retrying later", "blobKey", metadataKey.String(), "err", err)
+		s.pendingCleanupsMu.Lock()
+		s.pendingCleanups[metadataKey] = s.pendingCleanups[metadataKey] || removeMetadata
+		s.pendingCleanupsMu.Unlock()
+	}
+}
+
+// undoBlobRequest deletes the metadata of the blob request if removeMetadata is set, then tracks its blob object to be
+// deleted if no other metadata refers to it
+func (s *SharedBlobStore) undoBlobRequest(ctx context.Context, metadataKey disperser.BlobKey, removeMetadata bool) error {
+	if removeMetadata {
+		if err := s.blobMetadataStore.DeleteBlobMetadata(ctx, metadataKey); err != nil {
+			return fmt.Errorf("failed to remove the metadata: %w", err)
+		}
+	}
+
+	s.pendingCleanupsMu.Lock()
+	s.orphanedBlobs[metadataKey.BlobHash] = struct{}{}
+	s.pendingCleanupsMu.Unlock()
+	return nil
+}
+
+// RetryCleanups attempts again to undo the writes of the failed blob requests whose removal failed, then collects the
+// orphaned blob objects. It returns the number of requests whose removal is still pending.
+func (s *SharedBlobStore) RetryCleanups(ctx context.Context) int {
+	s.pendingCleanupsMu.Lock()
+	pending := make(map[disperser.BlobKey]bool, len(s.pendingCleanups))
+	for metadataKey, removeMetadata := range s.pendingCleanups {
+		pending[metadataKey] = removeMetadata
+	}
+	s.pendingCleanupsMu.Unlock()
+
+	for metadataKey, removeMetadata := range pending {
+		cleanupCtx, cancel := context.WithTimeout(ctx, cleanupTimeout)
+		err := s.undoBlobRequest(cleanupCtx, metadataKey, removeMetadata)
+		cancel()
+		if err != nil {
+			s.logger.Warn("error removing failed blob request", "blobKey", metadataKey.String(), "err", err)
+			continue
+		}
+		s.pendingCleanupsMu.Lock()
+		delete(s.pendingCleanups, metadataKey)
+		s.pendingCleanupsMu.Unlock()
+	}
+
+	s.CollectOrphanedBlobs(ctx, time.Now())
+	return s.NumPendingCleanups()
+}
+
+// CollectOrphanedBlobs deletes the blob objects of the failed requests which no metadata refers to, as of now. An object
+// is only deleted once it hasn't been written for OrphanGracePeriod, so that the requests which uploaded it again have
+// queued their metadata by the time its references are checked. It returns the number of objects still to be collected.
+func (s *SharedBlobStore) CollectOrphanedBlobs(ctx context.Context, now time.Time) int {
+	s.pendingCleanupsMu.Lock()
+	orphaned := make([]disperser.BlobHash, 0, len(s.orphanedBlobs))
+	for blobHash := range s.orphanedBlobs {
+		orphaned = append(orphaned, blobHash)
+	}
+	s.pendingCleanupsMu.Unlock()
+
+	for _, blobHash := range orphaned {
+		cleanupCtx, cancel := context.WithTimeout(ctx, cleanupTimeout)
+		collected, err := s.collectOrphanedBlob(cleanupCtx, blobHash, now)
+		cancel()
+		if err != nil {
+			s.logger.Warn("error removing orphaned blob", "blobHash", blobHash, "err", err)
+			continue
+		}
+		if collected {
+			s.pendingCleanupsMu.Lock()
+			delete(s.orphanedBlobs, blobHash)
+			s.pendingCleanupsMu.Unlock()
+		}
+	}
+	return s.NumOrphanedBlobs()
+}
+
+// collectOrphanedBlob deletes the object of the blob if it's past its grace period and no metadata refers to it. It
+// returns whether the blob no longer needs to be collected, i.e. it's gone or referenced again.
+func (s *SharedBlobStore) collectOrphanedBlob(ctx context.Context, blobHash disperser.BlobHash, now time.Time) (bool, error) {
+	key := s.blobObjectKey(blobHash)
+	objects, err := s.s3Client.ListObjects(ctx, s.bucketName, key)
+	if err != nil {
+		return false, fmt.Errorf("failed to look up the blob: %w", err)
+	}
+	var object *s3.Object
+	for i := range objects {
+		if objects[i].Key == key {
+			object = &objects[i]
+		}
+	}
+	if object == nil {
+		return true, nil
+	}
+	if now.Sub(object.LastModified) < OrphanGracePeriod {
+		return false, nil
+	}
+
+	referenced, err := s.blobMetadataStore.HasBlobMetadata(ctx, blobHash)
+	if err != nil {
+		return false, fmt.Errorf("failed to check the references to the blob: %w", err)
+	}
+	if referenced {
+		return true, nil
+	}
+	if err := s.s3Client.DeleteObject(ctx, s.bucketName, key); err != nil {
+		return false, fmt.Errorf("failed to remove the blob: %w", err)
+	}
+	return true, nil
+}
+
+// NumPendingCleanups returns the number of failed blob requests whose writes are still to be removed
+func (s *SharedBlobStore) NumPendingCleanups() int {
+	s.pendingCleanupsMu.Lock()
+	defer s.pendingCleanupsMu.Unlock()
+	return len(s.pendingCleanups)
+}
+
+// NumOrphanedBlobs returns the number of blob objects of the failed requests which are still to be collected
+func (s *SharedBlobStore) NumOrphanedBlobs() int {
+	s.pendingCleanupsMu.Lock()
+	defer s.pendingCleanupsMu.Unlock()
+	return len(s.orphanedBlobs)
+}
+
+// StartCleanupRetries retries the pending removals of the failed blob requests and collects their orphaned blob objects
+// periodically, until the context is done
+func (s *SharedBlobStore) StartCleanupRetries(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(cleanupRetryInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if s.NumPendingCleanups() == 0 && s.NumOrphanedBlobs() == 0 {
+					continue
+				}
+				if pending := s.RetryCleanups(ctx); pending > 0 {
+					s.logger.Warn("failed blob requests are still to be removed", "count", pending)
+				}
+			}
+		}
+	}()
+}
+
+// GetBlobContent retrieves blob content by the blob key. The content is checked against the blob hash, which is the
+// hash of the content it was stored with.
 func (s *SharedBlobStore) GetBlobContent(ctx context.Context, blobHash disperser.BlobHash) ([]byte, error) {
-	return s.s3Client.DownloadObject(ctx, s.bucketName, blobObjectKey(blobHash))
+	data, err := s.downloadObject(ctx, s.blobObjectKey(blobHash))
+	if errors.Is(err, s3.ErrObjectNotFound) {
+		return nil, fmt.Errorf("%w: no content for blob %s", disperser.ErrBlobNotFound, blobHash)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if hash := hashBlobData(data); hash != blobHash {
+		return nil, fmt.Errorf("%w: blob %s has content hash %s", disperser.ErrBlobIntegrity, blobHash, hash)
+	}
+	return data, nil
 }
 
-func (s *SharedBlobStore) getBlobContentParallel(ctx context.Context, blobKey disperser.BlobKey, blobRequestHeader core.BlobRequestHeader, resultChan chan<- blobResultOrError) {
-	blob, err := s.s3Client.DownloadObject(ctx, s.bucketName, blobObjectKey(blobKey.BlobHash))
+func (s *SharedBlobStore) getBlobContentParallel(ctx context.Context, metadata *disperser.BlobMetadata, resultChan chan<- blobResultOrError) {
+	blob, err := s.GetBlobContent(ctx, metadata.BlobHash)
 	if err != nil {
 		resultChan <- blobResultOrError{err: err}
 		return
 	}
-	resultChan <- blobResultOrError{blob: blob, blobKey: blobKey, blobRequestHeader: blobRequestHeader}
+	if err := metadata.VerifyBlobSize(blob); err != nil {
+		resultChan <- blobResultOrError{err: err}
+		return
+	}
+	resultChan <- blobResultOrError{blob: blob, blobKey: metadata.GetBlobKey(), blobRequestHeader: metadata.RequestMetadata.BlobRequestHeader}
 }
 
 func (s *SharedBlobStore) MarkBlobConfirmed(ctx context.Context, existingMetadata *disperser.BlobMetadata, confirmationInfo *disperser.ConfirmationInfo) (*disperser.BlobMetadata, error) {
@@ -142,7 +394,16 @@ func (s *SharedBlobStore) MarkBlobConfirmed(ctx context.Context, existingMetadat
 	}
 	newMetadata.BlobStatus = disperser.Confirmed
 	newMetadata.ConfirmationInfo = confirmationInfo
-	return &newMetadata, s.blobMetadataStore.UpdateBlobMetadata(ctx, existingMetadata.GetBlobKey(), &newMetadata)
+	// Only confirm blobs which are still processing, so that concurrent confirmations can't overwrite each other's
+	// confirmation info
+	err := s.blobMetadataStore.UpdateBlobMetadataFromStatus(ctx, existingMetadata.GetBlobKey(), disperser.Processing, &newMetadata)
+	if errors.Is(err, commondynamodb.ErrConditionFailed) {
+		return nil, disperser.ErrBlobAlreadyConfirmed
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &newMetadata, nil
 }
 
 func (s *SharedBlobStore) MarkBlobInsufficientSignatures(ctx context.Context, existingMetadata *disperser.BlobMetadata, confirmationInfo *disperser.ConfirmationInfo) (*disperser.BlobMetadata, error) {
@@ -164,6 +425,103 @@ func (s *SharedBlobStore) MarkBlobFailed(ctx context.Context, metadataKey disper
 	return s.blobMetadataStore.SetBlobStatus(ctx, metadataKey, disperser.Failed)
 }
 
+func (s *SharedBlobStore) MarkBlobQuotaExceeded(ctx context.Context, metadataKey disperser.BlobKey) error {
+	return s.blobMetadataStore.SetBlobStatus(ctx, metadataKey, disperser.QuotaExceeded)
+}
+
+// ExtendBlobExpiry postpones the expiry of the blob's metadata. The blob object is rewritten first, as the lifecycle
+// rules of the bucket expire the objects by age: rewriting the object restarts its age, so that it is kept at least as
+// long as the metadata referring to it.
+func (s *SharedBlobStore) ExtendBlobExpiry(ctx context.Context, existingMetadata *disperser.BlobMetadata, expiry uint64) (*disperser.BlobMetadata, error) {
+	now := uint64(time.Now().Unix())
+	if existingMetadata.Expiry != 0 && existingMetadata.Expiry <= now {
+		return nil, fmt.Errorf("%w: blob %s expired at %d", disperser.ErrBlobExpired, existingMetadata.GetBlobKey().String(), existingMetadata.Expiry)
+	}
+
+	data, err := s.GetBlobContent(ctx, existingMetadata.BlobHash)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.uploadBlob(ctx, existingMetadata.BlobHash, data, existingMetadata.RequestMetadata.ContentType); err != nil {
+		return nil, fmt.Errorf("failed to rewrite the blob: %w", err)
+	}
+
+	extension := &disperser.RetentionExtension{
+		ExtendedAt:     now,
+		PreviousExpiry: existingMetadata.Expiry,
+		Expiry:         expiry,
+	}
+	err = s.blobMetadataStore.UpdateBlobExpiry(ctx, existingMetadata, extension)
+	if errors.Is(err, commondynamodb.ErrConditionFailed) {
+		return nil, disperser.ErrBlobExpiryChanged
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	newMetadata := *existingMetadata
+	newMetadata.Expiry = expiry
+	newMetadata.RetentionExtensions = append(append([]*disperser.RetentionExtension{}, existingMetadata.RetentionExtensions...), extension)
+	return &newMetadata, nil
+}
+
+// ResubmitBlob queues a new request for the content of a failed blob. The metadata of the new request refers to the
+// object of the original blob rather than uploading it again, and the object is kept as long as any metadata refers to
+// it. The new request keeps the expiry of the original, since the lifecycle rules of the bucket expire the object from
+// when it was written.
+func (s *SharedBlobStore) ResubmitBlob(ctx context.Context, existingMetadata *disperser.BlobMetadata, requestHeader core.BlobRequestHeader, requestedAt uint64) (disperser.BlobKey, error) {
+	metadataKey := disperser.BlobKey{}
+	if err := existingMetadata.CheckResubmittable(uint64(time.Now().Unix())); err != nil {
+		return metadataKey, err
+	}
+	metadataHash, err := getMetadataHash(requestedAt, requestHeader.SecurityParams)
+	if err != nil {
+		s.logger.Error("error creating metadata key", "err", err)
+		return metadataKey, err
+	}
+	metadataKey.BlobHash = existingMetadata.BlobHash
+	metadataKey.MetadataHash = metadataHash
+
+	// The object may have been removed since the blob failed, e.g. by the lifecycle rules of the bucket
+	objectKey := s.blobObjectKey(existingMetadata.BlobHash)
+	objects, err := s.s3Client.ListObjects(ctx, s.bucketName, objectKey)
+	if err != nil {
+		return metadataKey, fmt.Errorf("failed to look up the blob: %w", err)
+	}
+	found := false
+	for _, object := range objects {
+		if object.Key == objectKey && object.Size == int64(existingMetadata.RequestMetadata.BlobSize) {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return metadataKey, fmt.Errorf("%w: no content for blob %s", disperser.ErrBlobNotFound, existingMetadata.BlobHash)
+	}
+
+	metadata := disperser.BlobMetadata{
+		BlobHash:     existingMetadata.BlobHash,
+		MetadataHash: metadataHash,
+		NumRetries:   0,
+		BlobStatus:   disperser.Processing,
+		Expiry:       existingMetadata.Expiry,
+		RequestMetadata: &disperser.RequestMetadata{
+			BlobRequestHeader: requestHeader,
+			BlobSize:          existingMetadata.RequestMetadata.BlobSize,
+			RequestedAt:       requestedAt,
+		},
+	}
+	if err := s.blobMetadataStore.QueueNewBlobMetadata(ctx, &metadata); err != nil {
+		s.logger.Error("error uploading blob metadata", "err", err)
+		// The metadata may still have been written, e.g. if the write timed out while it was in flight. The object is
+		// kept, as the metadata of the original blob still refers to it.
+		s.removeBlob(ctx, metadataKey, true)
+		return metadataKey, err
+	}
+
+	return metadataKey, nil
+}
+
 func (s *SharedBlobStore) IncrementBlobRetryCount(ctx context.Context, existingMetadata *disperser.BlobMetadata) error {
 	return s.blobMetadataStore.IncrementNumRetries(ctx, existingMetadata)
 }
@@ -178,7 +536,7 @@ func (s *SharedBlobStore) GetBlobsByMetadata(ctx context.Context, metadata []*di
 		mCopy := m // avoid capturing loop variable "m" directly by making a copy
 		pool.Submit(func() {
 			// Fetch blob content from S3
-			s.getBlobContentParallel(ctx, mCopy.GetBlobKey(), mCopy.RequestMetadata.BlobRequestHeader, resultChan)
+			s.getBlobContentParallel(ctx, mCopy, resultChan)
 		})
 	}
 
@@ -224,6 +582,90 @@ func (s *SharedBlobStore) HandleBlobFailure(ctx context.Context, metadata *dispe
 	}
 }
 
+func (s *SharedBlobStore) RedriveBlob(ctx context.Context, existingMetadata *disperser.BlobMetadata, maxRetry uint) (*disperser.BlobMetadata, error) {
+	newMetadata := redriveMetadata(existingMetadata, maxRetry)
+	err := s.blobMetadataStore.UpdateBlobMetadataFromRetries(ctx, existingMetadata.GetBlobKey(), disperser.Processing, existingMetadata.NumRetries, newMetadata)
+	if errors.Is(err, commondynamodb.ErrConditionFailed) {
+		return nil, disperser.ErrBlobStateChanged
+	}
+	if err != nil {
+		return nil, err
+	}
+	return newMetadata, nil
+}
+
+// redriveMetadata returns the metadata of a re-driven blob: its retry count is incremented, or it is failed once it
+// reached maxRetry, as with HandleBlobFailure
+func redriveMetadata(existingMetadata *disperser.BlobMetadata, maxRetry uint) *disperser.BlobMetadata {
+	newMetadata := *existingMetadata
+	if existingMetadata.NumRetries < maxRetry {
+		newMetadata.NumRetries++
+	} else {
+		newMetadata.BlobStatus = disperser.Failed
+	}
+	return &newMetadata
+}
+
+// StoreOperatorState stores the compressed snapshot of the operator state of a batch in the bucket, next to the blobs
+func (s *SharedBlobStore) StoreOperatorState(ctx context.Context, batchHeaderHash [32]byte, snapshot *disperser.OperatorStateSnapshot) error {
+	data, err := snapshot.Serialize()
+	if err != nil {
+		return err
+	}
+	return s.s3Client.UploadObject(ctx, s.bucketName, s.operatorStateObjectKey(batchHeaderHash), data)
+}
+
+func (s *SharedBlobStore) GetOperatorState(ctx context.Context, batchHeaderHash [32]byte) (*disperser.OperatorStateSnapshot, error) {
+	data, err := s.downloadObject(ctx, s.operatorStateObjectKey(batchHeaderHash))
+	if errors.Is(err, s3.ErrObjectNotFound) {
+		return nil, fmt.Errorf("%w: batch %s", disperser.ErrOperatorStateNotFound, hex.EncodeToString(batchHeaderHash[:]))
+	}
+	if err != nil {
+		return nil, err
+	}
+	return disperser.DeserializeOperatorStateSnapshot(data)
+}
+
+// StoreBatchCost stores the cost of the confirmation of a batch in the bucket, next to its operator state
+func (s *SharedBlobStore) StoreBatchCost(ctx context.Context, batchHeaderHash [32]byte, cost *disperser.BatchCost) error {
+	data, err := cost.Serialize()
+	if err != nil {
+		return err
+	}
+	return s.s3Client.UploadObject(ctx, s.bucketName, s.batchCostObjectKey(batchHeaderHash), data)
+}
+
+func (s *SharedBlobStore) GetBatchCost(ctx context.Context, batchHeaderHash [32]byte) (*disperser.BatchCost, error) {
+	data, err := s.downloadObject(ctx, s.batchCostObjectKey(batchHeaderHash))
+	if errors.Is(err, s3.ErrObjectNotFound) {
+		return nil, fmt.Errorf("%w: batch %s", disperser.ErrBatchCostNotFound, hex.EncodeToString(batchHeaderHash[:]))
+	}
+	if err != nil {
+		return nil, err
+	}
+	return disperser.DeserializeBatchCost(data)
+}
+
+// StoreBatchSignature stores the aggregated signature of a batch in the bucket, next to its cost
+func (s *SharedBlobStore) StoreBatchSignature(ctx context.Context, batchHeaderHash [32]byte, signature *disperser.BatchSignature) error {
+	data, err := signature.Serialize()
+	if err != nil {
+		return err
+	}
+	return s.s3Client.UploadObject(ctx, s.bucketName, s.batchSignatureObjectKey(batchHeaderHash), data)
+}
+
+func (s *SharedBlobStore) GetBatchSignature(ctx context.Context, batchHeaderHash [32]byte) (*disperser.BatchSignature, error) {
+	data, err := s.downloadObject(ctx, s.batchSignatureObjectKey(batchHeaderHash))
+	if errors.Is(err, s3.ErrObjectNotFound) {
+		return nil, fmt.Errorf("%w: batch %s", disperser.ErrBatchSignatureNotFound, hex.EncodeToString(batchHeaderHash[:]))
+	}
+	if err != nil {
+		return nil, err
+	}
+	return disperser.DeserializeBatchSignature(data)
+}
+
 func getMetadataHash(requestedAt uint64, securityParams []*core.SecurityParam) (string, error) {
 	var str string
 	str = fmt.Sprintf("%d/", requestedAt)
@@ -236,13 +678,55 @@ func getMetadataHash(requestedAt uint64, securityParams []*core.SecurityParam) (
 	return hex.EncodeToString(sha256.New().Sum(bytes)), nil
 }
 
-func blobObjectKey(blobHash disperser.BlobHash) string {
-	return fmt.Sprintf("blob/%s.json", blobHash)
+// uploadBlob uploads the data of a blob, setting the Content-Type of the object to the content type of the blob if it
+// has one. The objects are keyed by the hash of their data, so that the blobs with the same data share an object with
+// the content type of the last upload: the content type of a blob is the one of its metadata.
+func (s *SharedBlobStore) uploadBlob(ctx context.Context, blobHash disperser.BlobHash, data []byte, contentType string) error {
+	if contentType == "" {
+		return s.s3Client.UploadObject(ctx, s.bucketName, s.blobObjectKey(blobHash), data)
+	}
+	return s.s3Client.UploadObjectWithContentType(ctx, s.bucketName, s.blobObjectKey(blobHash), data, contentType)
+}
+
+func (s *SharedBlobStore) blobObjectKey(blobHash disperser.BlobHash) string {
+	key := fmt.Sprintf("blob/%s.json", blobHash)
+	if s.keyPrefix == "" {
+		return key
+	}
+	return s.keyPrefix + "/" + key
+}
+
+func (s *SharedBlobStore) operatorStateObjectKey(batchHeaderHash [32]byte) string {
+	key := fmt.Sprintf("operator-state/%s.gob.gz", hex.EncodeToString(batchHeaderHash[:]))
+	if s.keyPrefix == "" {
+		return key
+	}
+	return s.keyPrefix + "/" + key
+}
+
+func (s *SharedBlobStore) batchCostObjectKey(batchHeaderHash [32]byte) string {
+	key := fmt.Sprintf("batch-cost/%s.gob", hex.EncodeToString(batchHeaderHash[:]))
+	if s.keyPrefix == "" {
+		return key
+	}
+	return s.keyPrefix + "/" + key
+}
+
+func (s *SharedBlobStore) batchSignatureObjectKey(batchHeaderHash [32]byte) string {
+	key := fmt.Sprintf("batch-signature/%s.gob", hex.EncodeToString(batchHeaderHash[:]))
+	if s.keyPrefix == "" {
+		return key
+	}
+	return s.keyPrefix + "/" + key
 }
 
 func getBlobHash(blob *core.Blob) disperser.BlobHash {
+	return hashBlobData(blob.Data)
+}
+
+func hashBlobData(data []byte) disperser.BlobHash {
 	hasher := sha256.New()
-	hasher.Write(blob.Data)
+	hasher.Write(data)
 	hash := hasher.Sum(nil)
 	return hex.EncodeToString(hash)
 }
@@ -2,14 +2,21 @@ package blobstore_test
 
 import (
 	"context"
+	"crypto/rand"
 	"crypto/sha256"
 	"encoding/hex"
+	"errors"
 	"fmt"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/Layr-Labs/eigenda/common/aws/s3"
+	cmock "github.com/Layr-Labs/eigenda/common/mock"
 	"github.com/Layr-Labs/eigenda/core"
 	"github.com/Layr-Labs/eigenda/disperser"
+	"github.com/Layr-Labs/eigenda/disperser/common/blobstore"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -166,6 +173,378 @@ func TestSharedBlobStore(t *testing.T) {
 	assertMetadata(t, blobKey2, blobSize2, requestedAt, disperser.InsufficientSignatures, blob2Metadata)
 }
 
+func TestSharedBlobStoreKeyPrefix(t *testing.T) {
+	ctx := context.Background()
+	s3Client := cmock.NewS3Client()
+	prefixedStorage := blobstore.NewSharedStorage(bucketName, "/testnet/", s3Client, blobMetadataStore, logger)
+	unprefixedStorage := blobstore.NewSharedStorage(bucketName, "", s3Client, blobMetadataStore, logger)
+
+	blobKey, err := prefixedStorage.StoreBlob(ctx, blob, uint64(time.Now().UnixNano()))
+	assert.Nil(t, err)
+
+	objects, err := s3Client.ListObjects(ctx, bucketName, "")
+	assert.Nil(t, err)
+	assert.Len(t, objects, 1)
+	assert.Equal(t, fmt.Sprintf("testnet/blob/%s.json", blobKey.BlobHash), objects[0].Key)
+
+	data, err := prefixedStorage.GetBlobContent(ctx, blobKey.BlobHash)
+	assert.Nil(t, err)
+	assert.Equal(t, blob.Data, data)
+
+	// Stores without a prefix keep reading the objects at the root of the bucket
+	_, err = unprefixedStorage.GetBlobContent(ctx, blobKey.BlobHash)
+	assert.ErrorIs(t, err, s3.ErrObjectNotFound)
+	assert.Nil(t, s3Client.UploadObject(ctx, bucketName, fmt.Sprintf("blob/%s.json", blobKey.BlobHash), blob.Data))
+	data, err = unprefixedStorage.GetBlobContent(ctx, blobKey.BlobHash)
+	assert.Nil(t, err)
+	assert.Equal(t, blob.Data, data)
+
+	assert.Nil(t, prefixedStorage.MarkBlobFailed(ctx, blobKey))
+}
+
+func TestSharedBlobStoreDetectsCorruptedBlob(t *testing.T) {
+	ctx := context.Background()
+	s3Client := cmock.NewS3Client()
+	sharedStorage := blobstore.NewSharedStorage(bucketName, "", s3Client, blobMetadataStore, logger)
+
+	blobKey, err := sharedStorage.StoreBlob(ctx, blob, uint64(time.Now().UnixNano()))
+	assert.Nil(t, err)
+	metadata, err := sharedStorage.GetBlobMetadata(ctx, blobKey)
+	assert.Nil(t, err)
+
+	// Truncate the stored object
+	objectKey := fmt.Sprintf("blob/%s.json", blobKey.BlobHash)
+	assert.Nil(t, s3Client.UploadObject(ctx, bucketName, objectKey, blob.Data[:len(blob.Data)/2]))
+
+	_, err = sharedStorage.GetBlobContent(ctx, blobKey.BlobHash)
+	assert.ErrorIs(t, err, disperser.ErrBlobIntegrity)
+	_, err = sharedStorage.GetBlobsByMetadata(ctx, []*disperser.BlobMetadata{metadata})
+	assert.ErrorIs(t, err, disperser.ErrBlobIntegrity)
+
+	assert.Nil(t, sharedStorage.MarkBlobFailed(ctx, blobKey))
+}
+
+func TestSharedBlobStoreConcurrentConfirmation(t *testing.T) {
+	ctx := context.Background()
+	sharedStorage := blobstore.NewSharedStorage(bucketName, "", cmock.NewS3Client(), blobMetadataStore, logger)
+
+	blobKey, err := sharedStorage.StoreBlob(ctx, blob, uint64(time.Now().UnixNano()))
+	assert.Nil(t, err)
+	metadata, err := sharedStorage.GetBlobMetadata(ctx, blobKey)
+	assert.Nil(t, err)
+
+	// Two batchers confirm the blob in different batches at the same time
+	numConfirmations := 2
+	errs := make([]error, numConfirmations)
+	var wg sync.WaitGroup
+	for i := 0; i < numConfirmations; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			batchHeaderHash := [32]byte{byte(i + 1)}
+			_, errs[i] = sharedStorage.MarkBlobConfirmed(ctx, metadata, &disperser.ConfirmationInfo{
+				BatchHeaderHash:         batchHeaderHash,
+				BlobIndex:               uint32(i),
+				BlobCount:               uint32(i + 1),
+				ReferenceBlockNumber:    uint32(100 + i),
+				BatchRoot:               batchHeaderHash[:],
+				BlobCommitment:          &core.BlobCommitments{},
+				BatchID:                 uint32(i),
+				ConfirmationBlockNumber: uint32(200 + i),
+				Fee:                     []byte{0},
+			})
+		}(i)
+	}
+	wg.Wait()
+
+	winner := -1
+	for i, err := range errs {
+		if err == nil {
+			assert.Equal(t, -1, winner, "more than one confirmation succeeded")
+			winner = i
+		} else {
+			assert.ErrorIs(t, err, disperser.ErrBlobAlreadyConfirmed)
+		}
+	}
+	assert.NotEqual(t, -1, winner)
+
+	// The stored confirmation info comes entirely from the winning confirmation
+	confirmed, err := sharedStorage.GetBlobMetadata(ctx, blobKey)
+	assert.Nil(t, err)
+	assert.Equal(t, disperser.Confirmed, confirmed.BlobStatus)
+	assert.Equal(t, [32]byte{byte(winner + 1)}, confirmed.ConfirmationInfo.BatchHeaderHash)
+	assert.Equal(t, uint32(winner), confirmed.ConfirmationInfo.BlobIndex)
+	assert.Equal(t, uint32(winner+1), confirmed.ConfirmationInfo.BlobCount)
+	assert.Equal(t, uint32(100+winner), confirmed.ConfirmationInfo.ReferenceBlockNumber)
+	assert.Equal(t, uint32(200+winner), confirmed.ConfirmationInfo.ConfirmationBlockNumber)
+
+	_, err = sharedStorage.MarkBlobConfirmed(ctx, metadata, confirmed.ConfirmationInfo)
+	assert.ErrorIs(t, err, disperser.ErrBlobAlreadyConfirmed)
+
+	assert.Nil(t, sharedStorage.MarkBlobFinalized(ctx, blobKey))
+}
+
+func TestSharedBlobStoreRedriveBlob(t *testing.T) {
+	ctx := context.Background()
+	sharedStorage := blobstore.NewSharedStorage(bucketName, "", cmock.NewS3Client(), blobMetadataStore, logger)
+
+	blobKey, err := sharedStorage.StoreBlob(ctx, blob, uint64(time.Now().UnixNano()))
+	assert.Nil(t, err)
+	metadata, err := sharedStorage.GetBlobMetadata(ctx, blobKey)
+	assert.Nil(t, err)
+
+	redriven, err := sharedStorage.RedriveBlob(ctx, metadata, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, uint(1), redriven.NumRetries)
+	fetchedMetadata, err := sharedStorage.GetBlobMetadata(ctx, blobKey)
+	assert.Nil(t, err)
+	assert.Equal(t, disperser.Processing, fetchedMetadata.BlobStatus)
+	assert.Equal(t, uint(1), fetchedMetadata.NumRetries)
+
+	// The blob was retried since the metadata was read
+	_, err = sharedStorage.RedriveBlob(ctx, metadata, 1)
+	assert.ErrorIs(t, err, disperser.ErrBlobStateChanged)
+
+	// The blob is failed once it reached the max number of retries
+	_, err = sharedStorage.RedriveBlob(ctx, redriven, 1)
+	assert.Nil(t, err)
+	fetchedMetadata, err = sharedStorage.GetBlobMetadata(ctx, blobKey)
+	assert.Nil(t, err)
+	assert.Equal(t, disperser.Failed, fetchedMetadata.BlobStatus)
+	_, err = sharedStorage.RedriveBlob(ctx, fetchedMetadata, 1)
+	assert.ErrorIs(t, err, disperser.ErrBlobStateChanged)
+}
+
+// cancelingS3Client cancels the blob request once the blob is uploaded, as if the client went away
+type cancelingS3Client struct {
+	*cmock.S3Client
+	cancel context.CancelFunc
+}
+
+func (c *cancelingS3Client) UploadObject(ctx context.Context, bucket string, key string, data []byte) error {
+	err := c.S3Client.UploadObject(ctx, bucket, key, data)
+	c.cancel()
+	return err
+}
+
+func TestSharedBlobStoreCanceledAfterUpload(t *testing.T) {
+	s3Client := &cancelingS3Client{S3Client: cmock.NewS3Client()}
+	sharedStorage := blobstore.NewSharedStorage(bucketName, "", s3Client, blobMetadataStore, logger)
+
+	data := make([]byte, 64)
+	_, err := rand.Read(data)
+	assert.Nil(t, err)
+	canceledBlob := &core.Blob{RequestHeader: blob.RequestHeader, Data: data}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s3Client.cancel = cancel
+	blobKey, err := sharedStorage.StoreBlob(ctx, canceledBlob, uint64(time.Now().UnixNano()))
+	assert.ErrorIs(t, err, context.Canceled)
+
+	// The metadata of the canceled request is removed, and its object once it's past its grace period
+	referenced, err := blobMetadataStore.HasBlobMetadata(context.Background(), blobKey.BlobHash)
+	assert.Nil(t, err)
+	assert.False(t, referenced)
+	assert.Equal(t, 1, sharedStorage.CollectOrphanedBlobs(context.Background(), time.Now()))
+	objects, err := s3Client.ListObjects(context.Background(), bucketName, "")
+	assert.Nil(t, err)
+	assert.Len(t, objects, 1)
+	assert.Equal(t, 0, sharedStorage.CollectOrphanedBlobs(context.Background(), time.Now().Add(blobstore.OrphanGracePeriod)))
+	objects, err = s3Client.ListObjects(context.Background(), bucketName, "")
+	assert.Nil(t, err)
+	assert.Len(t, objects, 0)
+
+	// The object is kept if it's shared with a request which completed
+	ctx, cancel = context.WithCancel(context.Background())
+	s3Client.cancel = cancel
+	_, err = sharedStorage.StoreBlob(ctx, canceledBlob, uint64(time.Now().UnixNano()))
+	assert.ErrorIs(t, err, context.Canceled)
+	s3Client.cancel = func() {}
+	storedKey, err := sharedStorage.StoreBlob(context.Background(), canceledBlob, uint64(time.Now().UnixNano()))
+	assert.Nil(t, err)
+	assert.Equal(t, 0, sharedStorage.CollectOrphanedBlobs(context.Background(), time.Now().Add(blobstore.OrphanGracePeriod)))
+	content, err := sharedStorage.GetBlobContent(context.Background(), storedKey.BlobHash)
+	assert.Nil(t, err)
+	assert.Equal(t, data, content)
+
+	assert.Nil(t, sharedStorage.MarkBlobFailed(context.Background(), storedKey))
+}
+
+// failingDeleteS3Client fails the deletion of the objects the given number of times
+type failingDeleteS3Client struct {
+	*cmock.S3Client
+	failures int
+}
+
+func (c *failingDeleteS3Client) DeleteObject(ctx context.Context, bucket string, key string) error {
+	if c.failures > 0 {
+		c.failures--
+		return errors.New("delete failed")
+	}
+	return c.S3Client.DeleteObject(ctx, bucket, key)
+}
+
+func TestSharedBlobStoreMetadataWriteFailure(t *testing.T) {
+	s3Client := &failingDeleteS3Client{S3Client: cmock.NewS3Client()}
+	sharedStorage := blobstore.NewSharedStorage(bucketName, "", s3Client, blobMetadataStore, logger)
+
+	data := make([]byte, 64)
+	_, err := rand.Read(data)
+	assert.Nil(t, err)
+	// The metadata exceeds the maximum size of a DynamoDB item, so that it can't be written
+	header := blob.RequestHeader
+	header.Namespace = strings.Repeat("n", 500*1024)
+	failingBlob := &core.Blob{RequestHeader: header, Data: data}
+
+	blobKey, err := sharedStorage.StoreBlob(context.Background(), failingBlob, uint64(time.Now().UnixNano()))
+	assert.Error(t, err)
+	referenced, err := blobMetadataStore.HasBlobMetadata(context.Background(), blobKey.BlobHash)
+	assert.Nil(t, err)
+	assert.False(t, referenced)
+	assert.Equal(t, 0, sharedStorage.NumPendingCleanups())
+	assert.Equal(t, 1, sharedStorage.NumOrphanedBlobs())
+
+	// The removal of the object is retried until it succeeds
+	s3Client.failures = 2
+	collectAt := time.Now().Add(blobstore.OrphanGracePeriod)
+	assert.Equal(t, 1, sharedStorage.CollectOrphanedBlobs(context.Background(), collectAt))
+	assert.Equal(t, 1, sharedStorage.CollectOrphanedBlobs(context.Background(), collectAt))
+	objects, err := s3Client.ListObjects(context.Background(), bucketName, "")
+	assert.Nil(t, err)
+	assert.Len(t, objects, 1)
+	assert.Equal(t, 0, sharedStorage.CollectOrphanedBlobs(context.Background(), collectAt))
+	objects, err = s3Client.ListObjects(context.Background(), bucketName, "")
+	assert.Nil(t, err)
+	assert.Len(t, objects, 0)
+}
+
+// orderCheckingS3Client fails the uploads of the objects whose blob already has metadata queued
+type orderCheckingS3Client struct {
+	*cmock.S3Client
+	blobHash disperser.BlobHash
+}
+
+func (c *orderCheckingS3Client) UploadObject(ctx context.Context, bucket string, key string, data []byte) error {
+	referenced, err := blobMetadataStore.HasBlobMetadata(ctx, c.blobHash)
+	if err != nil {
+		return err
+	}
+	if referenced {
+		return errors.New("the metadata was queued before the upload")
+	}
+	return c.S3Client.UploadObject(ctx, bucket, key, data)
+}
+
+func TestSharedBlobStoreUploadsBeforeQueueing(t *testing.T) {
+	ctx := context.Background()
+	data := make([]byte, 64)
+	_, err := rand.Read(data)
+	assert.Nil(t, err)
+	orderedBlob := &core.Blob{RequestHeader: blob.RequestHeader, Data: data}
+	blobHash := sha256.Sum256(data)
+	s3Client := &orderCheckingS3Client{S3Client: cmock.NewS3Client(), blobHash: hex.EncodeToString(blobHash[:])}
+	sharedStorage := blobstore.NewSharedStorage(bucketName, "", s3Client, blobMetadataStore, logger)
+
+	// The upload fails if the metadata is visible before the object exists
+	blobKey, err := sharedStorage.StoreBlob(ctx, orderedBlob, uint64(time.Now().UnixNano()))
+	assert.Nil(t, err)
+	content, err := sharedStorage.GetBlobContent(ctx, blobKey.BlobHash)
+	assert.Nil(t, err)
+	assert.Equal(t, data, content)
+	metadata, err := sharedStorage.GetBlobMetadata(ctx, blobKey)
+	assert.Nil(t, err)
+	assert.Equal(t, disperser.Processing, metadata.BlobStatus)
+
+	assert.Nil(t, sharedStorage.MarkBlobFailed(ctx, blobKey))
+}
+
+// failingUploadS3Client fails the uploads of the objects
+type failingUploadS3Client struct {
+	*cmock.S3Client
+}
+
+func (c *failingUploadS3Client) UploadObject(ctx context.Context, bucket string, key string, data []byte) error {
+	return errors.New("upload failed")
+}
+
+func TestSharedBlobStoreUploadFailure(t *testing.T) {
+	s3Client := &failingUploadS3Client{S3Client: cmock.NewS3Client()}
+	sharedStorage := blobstore.NewSharedStorage(bucketName, "", s3Client, blobMetadataStore, logger)
+
+	data := make([]byte, 64)
+	_, err := rand.Read(data)
+	assert.Nil(t, err)
+	failingBlob := &core.Blob{RequestHeader: blob.RequestHeader, Data: data}
+
+	// No metadata is queued for the failed upload
+	blobKey, err := sharedStorage.StoreBlob(context.Background(), failingBlob, uint64(time.Now().UnixNano()))
+	assert.ErrorContains(t, err, "upload failed")
+	referenced, err := blobMetadataStore.HasBlobMetadata(context.Background(), blobKey.BlobHash)
+	assert.Nil(t, err)
+	assert.False(t, referenced)
+	assert.Equal(t, 0, sharedStorage.NumPendingCleanups())
+}
+
+func TestSharedBlobStoreExtendBlobExpiry(t *testing.T) {
+	ctx := context.Background()
+	s3Client := cmock.NewS3Client()
+	sharedStorage := blobstore.NewSharedStorage(bucketName, "", s3Client, blobMetadataStore, logger)
+	blobKey, err := sharedStorage.StoreBlob(ctx, blob, uint64(time.Now().UnixNano()))
+	assert.Nil(t, err)
+	metadata, err := sharedStorage.GetBlobMetadata(ctx, blobKey)
+	assert.Nil(t, err)
+
+	expiry := metadata.Expiry + 3600
+	extended, err := sharedStorage.ExtendBlobExpiry(ctx, metadata, expiry)
+	assert.Nil(t, err)
+	assert.Equal(t, expiry, extended.Expiry)
+	fetchedMetadata, err := sharedStorage.GetBlobMetadata(ctx, blobKey)
+	assert.Nil(t, err)
+	assert.Equal(t, extended, fetchedMetadata)
+	// The blob object is kept along with the metadata
+	content, err := sharedStorage.GetBlobContent(ctx, blobKey.BlobHash)
+	assert.Nil(t, err)
+	assert.Equal(t, blob.Data, content)
+
+	// The retention of an expired blob can't be extended
+	expired := *fetchedMetadata
+	expired.Expiry = uint64(time.Now().Add(-time.Second).Unix())
+	_, err = sharedStorage.ExtendBlobExpiry(ctx, &expired, expiry+3600)
+	assert.ErrorIs(t, err, disperser.ErrBlobExpired)
+
+	assert.Nil(t, sharedStorage.MarkBlobFailed(ctx, blobKey))
+}
+
+func TestSharedBlobStoreContentType(t *testing.T) {
+	ctx := context.Background()
+	s3Client := cmock.NewS3Client()
+	sharedStorage := blobstore.NewSharedStorage(bucketName, "", s3Client, blobMetadataStore, logger)
+	typedBlob := &core.Blob{
+		RequestHeader: core.BlobRequestHeader{
+			SecurityParams: blob.RequestHeader.SecurityParams,
+			ContentType:    "application/json",
+		},
+		Data: []byte(`{"key": "value"}`),
+	}
+
+	// The content type is stored in the metadata and as the content type of the object
+	blobKey, err := sharedStorage.StoreBlob(ctx, typedBlob, uint64(time.Now().UnixNano()))
+	assert.Nil(t, err)
+	metadata, err := sharedStorage.GetBlobMetadata(ctx, blobKey)
+	assert.Nil(t, err)
+	assert.Equal(t, "application/json", metadata.RequestMetadata.ContentType)
+	objectKey := fmt.Sprintf("blob/%s.json", blobKey.BlobHash)
+	assert.Equal(t, "application/json", s3Client.ContentType(objectKey))
+
+	// The rewritten object keeps its content type
+	assert.Nil(t, s3Client.UploadObject(ctx, bucketName, objectKey, typedBlob.Data))
+	_, err = sharedStorage.ExtendBlobExpiry(ctx, metadata, metadata.Expiry+3600)
+	assert.Nil(t, err)
+	assert.Equal(t, "application/json", s3Client.ContentType(objectKey))
+
+	assert.Nil(t, sharedStorage.MarkBlobFailed(ctx, blobKey))
+}
+
 func assertMetadata(t *testing.T, blobKey disperser.BlobKey, expectedBlobSize uint, expectedRequestedAt uint64, expectedStatus disperser.BlobStatus, actualMetadata *disperser.BlobMetadata) {
 	assert.NotNil(t, actualMetadata)
 	assert.Equal(t, expectedStatus, actualMetadata.BlobStatus)
@@ -196,3 +575,113 @@ func metadataSuffix(requestedAt uint64, securityParams []*core.SecurityParam) (s
 	bytes := []byte(str)
 	return hex.EncodeToString(sha256.New().Sum(bytes)), nil
 }
+
+func TestSharedBlobStoreResubmitBlob(t *testing.T) {
+	ctx := context.Background()
+	s3Client := cmock.NewS3Client()
+	sharedStorage := blobstore.NewSharedStorage(bucketName, "", s3Client, blobMetadataStore, logger)
+	data := make([]byte, 100)
+	_, err := rand.Read(data)
+	assert.Nil(t, err)
+	blob := &core.Blob{RequestHeader: core.BlobRequestHeader{SecurityParams: securityParams, Namespace: "rollup"}, Data: data}
+
+	blobKey, err := sharedStorage.StoreBlob(ctx, blob, uint64(time.Now().UnixNano()))
+	assert.Nil(t, err)
+	metadata, err := sharedStorage.GetBlobMetadata(ctx, blobKey)
+	assert.Nil(t, err)
+	relaxedHeader := core.BlobRequestHeader{
+		SecurityParams: []*core.SecurityParam{{QuorumID: 1, AdversaryThreshold: 50, QuorumThreshold: 60}},
+		Namespace:      "rollup",
+	}
+
+	// A blob still processing can't be resubmitted
+	_, err = sharedStorage.ResubmitBlob(ctx, metadata, relaxedHeader, uint64(time.Now().UnixNano()))
+	assert.ErrorIs(t, err, disperser.ErrBlobNotResubmittable)
+
+	// Once failed, it is resubmitted as a new blob referring to the same object
+	assert.Nil(t, sharedStorage.MarkBlobFailed(ctx, blobKey))
+	metadata, err = sharedStorage.GetBlobMetadata(ctx, blobKey)
+	assert.Nil(t, err)
+	resubmittedKey, err := sharedStorage.ResubmitBlob(ctx, metadata, relaxedHeader, uint64(time.Now().UnixNano()))
+	assert.Nil(t, err)
+	assert.Equal(t, blobKey.BlobHash, resubmittedKey.BlobHash)
+	assert.NotEqual(t, blobKey.MetadataHash, resubmittedKey.MetadataHash)
+	resubmitted, err := sharedStorage.GetBlobMetadata(ctx, resubmittedKey)
+	assert.Nil(t, err)
+	assert.Equal(t, disperser.Processing, resubmitted.BlobStatus)
+	assert.Equal(t, metadata.Expiry, resubmitted.Expiry)
+	assert.Equal(t, relaxedHeader.SecurityParams, resubmitted.RequestMetadata.SecurityParams)
+	assert.Equal(t, uint(len(data)), resubmitted.RequestMetadata.BlobSize)
+	objects, err := s3Client.ListObjects(ctx, bucketName, "")
+	assert.Nil(t, err)
+	assert.Len(t, objects, 1)
+	blobs, err := sharedStorage.GetBlobsByMetadata(ctx, []*disperser.BlobMetadata{resubmitted})
+	assert.Nil(t, err)
+	assert.Equal(t, data, blobs[resubmittedKey].Data)
+	assert.Nil(t, sharedStorage.MarkBlobFailed(ctx, resubmittedKey))
+
+	// A blob whose object was removed can't be resubmitted
+	assert.Nil(t, s3Client.DeleteObject(ctx, bucketName, fmt.Sprintf("blob/%s.json", blobKey.BlobHash)))
+	_, err = sharedStorage.ResubmitBlob(ctx, metadata, relaxedHeader, uint64(time.Now().UnixNano()))
+	assert.ErrorIs(t, err, disperser.ErrBlobNotFound)
+}
+
+// failingDownloadS3Client fails the downloads of the objects, counting them
+type failingDownloadS3Client struct {
+	*cmock.S3Client
+	downloads int
+}
+
+func (c *failingDownloadS3Client) DownloadObject(ctx context.Context, bucket string, key string) ([]byte, error) {
+	c.downloads++
+	return nil, errors.New("region unavailable")
+}
+
+func TestSharedBlobStoreReadsFromReplicas(t *testing.T) {
+	ctx := context.Background()
+	primary := &failingDownloadS3Client{S3Client: cmock.NewS3Client()}
+	sharedStorage := blobstore.NewSharedStorage(bucketName, "", primary, blobMetadataStore, logger)
+	replica := cmock.NewS3Client()
+	fallbackReads := make(map[string]int)
+	sharedStorage.SetReadReplicas([]s3.Endpoint{
+		{Region: "us-west-2", Bucket: bucketName + "-replica", Client: replica},
+	}, blobstore.ReplicaConfig{ReadTimeout: time.Second, DemotionPeriod: time.Hour}, func(region string) {
+		fallbackReads[region]++
+	})
+
+	// The blob written to the primary bucket is replicated
+	hash := sha256.Sum256(blob.Data)
+	blobHash := hex.EncodeToString(hash[:])
+	objectKey := fmt.Sprintf("blob/%s.json", blobHash)
+	assert.Nil(t, primary.UploadObject(ctx, bucketName, objectKey, blob.Data))
+	assert.Nil(t, replica.UploadObject(ctx, bucketName+"-replica", objectKey, blob.Data))
+
+	// The replica serves the blob while the primary fails
+	for i := 0; i < 5; i++ {
+		data, err := sharedStorage.GetBlobContent(ctx, blobHash)
+		assert.Nil(t, err)
+		assert.Equal(t, blob.Data, data)
+	}
+	assert.Equal(t, map[string]int{"us-west-2": 5}, fallbackReads)
+	// The primary is demoted after 3 failed reads, and read after the replica
+	assert.Equal(t, 3, primary.downloads)
+
+	// A blob the replica doesn't have isn't read from the demoted primary
+	_, err := sharedStorage.GetBlobContent(ctx, "missing")
+	assert.ErrorIs(t, err, disperser.ErrBlobNotFound)
+	assert.Equal(t, 3, primary.downloads)
+}
+
+func TestParseReplicaBuckets(t *testing.T) {
+	replicas, err := blobstore.ParseReplicaBuckets([]string{"us-west-2:blobs-west", "eu-central-1:blobs-eu"})
+	assert.Nil(t, err)
+	assert.Equal(t, []blobstore.ReplicaBucket{
+		{Region: "us-west-2", Bucket: "blobs-west"},
+		{Region: "eu-central-1", Bucket: "blobs-eu"},
+	}, replicas)
+
+	for _, value := range []string{"blobs-west", ":blobs-west", "us-west-2:"} {
+		_, err = blobstore.ParseReplicaBuckets([]string{value})
+		assert.Error(t, err, value)
+	}
+}
@@ -0,0 +1,152 @@
+package blobstore
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/disperser"
+)
+
+// UsageExportFormat is the format in which ExportAccountUsage writes the usage of an account
+type UsageExportFormat string
+
+const (
+	UsageExportCSV  UsageExportFormat = "csv"
+	UsageExportJSON UsageExportFormat = "json"
+)
+
+// QuorumUsage is the usage of a quorum by the blobs of an account
+type QuorumUsage struct {
+	QuorumID core.QuorumID `json:"quorum_id"`
+	NumBlobs uint64        `json:"num_blobs"`
+	// RawBytes is the total size in bytes of the blobs as dispersed
+	RawBytes uint64 `json:"raw_bytes"`
+	// EncodedBytes is the total nominal encoded size in bytes of the blobs in the quorum. Only the confirmed blobs have
+	// an encoded size.
+	EncodedBytes uint64 `json:"encoded_bytes"`
+}
+
+// AccountUsage is the usage of each quorum by the blobs of an account requested in a time range
+type AccountUsage struct {
+	AccountID core.AccountID `json:"account_id"`
+	// Start and End are the bounds, inclusive, of the time range in nanoseconds since the unix epoch
+	Start uint64 `json:"start"`
+	End   uint64 `json:"end"`
+	// ConfirmedOnly is whether the usage only counts the confirmed and finalized blobs
+	ConfirmedOnly bool `json:"confirmed_only"`
+	// Quorums is the usage of each quorum, in the order of the quorum IDs
+	Quorums []*QuorumUsage `json:"quorums"`
+}
+
+// AggregateAccountUsage adds up the usage of each quorum by the blobs of the metadata. If confirmedOnly is set, the
+// blobs which are neither confirmed nor finalized are left out.
+func AggregateAccountUsage(account core.AccountID, start uint64, end uint64, confirmedOnly bool, metadata []*disperser.BlobMetadata) *AccountUsage {
+	quorums := make(map[core.QuorumID]*QuorumUsage)
+	for _, m := range metadata {
+		confirmed := m.BlobStatus == disperser.Confirmed || m.BlobStatus == disperser.Finalized
+		if (confirmedOnly && !confirmed) || m.RequestMetadata == nil {
+			continue
+		}
+
+		encodedLengths := make(map[core.QuorumID]uint)
+		if m.ConfirmationInfo != nil {
+			for _, info := range m.ConfirmationInfo.BlobQuorumInfos {
+				encodedLengths[info.QuorumID] = info.EncodedBlobLength
+			}
+		}
+
+		for _, param := range m.RequestMetadata.SecurityParams {
+			quorum, ok := quorums[param.QuorumID]
+			if !ok {
+				quorum = &QuorumUsage{QuorumID: param.QuorumID}
+				quorums[param.QuorumID] = quorum
+			}
+			quorum.NumBlobs++
+			quorum.RawBytes += uint64(m.RequestMetadata.BlobSize)
+			quorum.EncodedBytes += uint64(core.GetBlobSize(encodedLengths[param.QuorumID]))
+		}
+	}
+
+	usage := &AccountUsage{
+		AccountID:     account,
+		Start:         start,
+		End:           end,
+		ConfirmedOnly: confirmedOnly,
+		Quorums:       make([]*QuorumUsage, 0, len(quorums)),
+	}
+	for _, quorum := range quorums {
+		usage.Quorums = append(usage.Quorums, quorum)
+	}
+	sort.Slice(usage.Quorums, func(i, j int) bool {
+		return usage.Quorums[i].QuorumID < usage.Quorums[j].QuorumID
+	})
+	return usage
+}
+
+// WriteCSV writes the usage as CSV, with a header row and a row per quorum
+func (u *AccountUsage) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	err := writer.Write([]string{"account_id", "start", "end", "confirmed_only", "quorum_id", "num_blobs", "raw_bytes", "encoded_bytes"})
+	if err != nil {
+		return err
+	}
+	for _, quorum := range u.Quorums {
+		err = writer.Write([]string{
+			u.AccountID,
+			strconv.FormatUint(u.Start, 10),
+			strconv.FormatUint(u.End, 10),
+			strconv.FormatBool(u.ConfirmedOnly),
+			strconv.Itoa(int(quorum.QuorumID)),
+			strconv.FormatUint(quorum.NumBlobs, 10),
+			strconv.FormatUint(quorum.RawBytes, 10),
+			strconv.FormatUint(quorum.EncodedBytes, 10),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// WriteJSON writes the usage as a JSON object
+func (u *AccountUsage) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(u)
+}
+
+// ExportAccountUsage writes the usage of each quorum by the blobs of the account requested between start and end
+// inclusive, in nanoseconds since the unix epoch, in the given format. If confirmedOnly is set, only the confirmed and
+// finalized blobs are counted.
+func ExportAccountUsage(ctx context.Context, store *BlobMetadataStore, account core.AccountID, start uint64, end uint64, confirmedOnly bool, format UsageExportFormat, w io.Writer) error {
+	if format != UsageExportCSV && format != UsageExportJSON {
+		return fmt.Errorf("unknown usage export format %q", format)
+	}
+
+	var metadata []*disperser.BlobMetadata
+	var pagination *Pagination
+	for {
+		page, next, err := store.GetBlobMetadataByAccountAndTimeRange(ctx, account, start, end, pagination)
+		if err != nil {
+			return fmt.Errorf("failed to get the blob metadata of account %s: %w", account, err)
+		}
+		metadata = append(metadata, page...)
+		if next == nil {
+			break
+		}
+		pagination = next
+	}
+
+	usage := AggregateAccountUsage(account, start, end, confirmedOnly, metadata)
+	if format == UsageExportCSV {
+		return usage.WriteCSV(w)
+	}
+	return usage.WriteJSON(w)
+}
@@ -0,0 +1,108 @@
+package blobstore_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/disperser"
+	"github.com/Layr-Labs/eigenda/disperser/common/blobstore"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAggregateAccountUsage(t *testing.T) {
+	params := []*core.SecurityParam{
+		{QuorumID: 0, AdversaryThreshold: 50, QuorumThreshold: 100},
+		{QuorumID: 1, AdversaryThreshold: 80, QuorumThreshold: 90},
+	}
+	metadata := []*disperser.BlobMetadata{
+		{
+			BlobStatus: disperser.Processing,
+			RequestMetadata: &disperser.RequestMetadata{
+				BlobRequestHeader: core.BlobRequestHeader{SecurityParams: params[1:]},
+				BlobSize:          100,
+			},
+		},
+		{
+			BlobStatus: disperser.Confirmed,
+			RequestMetadata: &disperser.RequestMetadata{
+				BlobRequestHeader: core.BlobRequestHeader{SecurityParams: params},
+				BlobSize:          200,
+			},
+			ConfirmationInfo: &disperser.ConfirmationInfo{
+				BlobQuorumInfos: []*core.BlobQuorumInfo{
+					{SecurityParam: *params[0], EncodedBlobLength: 16},
+					{SecurityParam: *params[1], EncodedBlobLength: 32},
+				},
+			},
+		},
+		{
+			BlobStatus: disperser.Finalized,
+			RequestMetadata: &disperser.RequestMetadata{
+				BlobRequestHeader: core.BlobRequestHeader{SecurityParams: params[1:]},
+				BlobSize:          300,
+			},
+			ConfirmationInfo: &disperser.ConfirmationInfo{
+				BlobQuorumInfos: []*core.BlobQuorumInfo{
+					{SecurityParam: *params[1], EncodedBlobLength: 64},
+				},
+			},
+		},
+	}
+
+	usage := blobstore.AggregateAccountUsage("account", 1, 2, false, metadata)
+	assert.Equal(t, &blobstore.AccountUsage{
+		AccountID: "account",
+		Start:     1,
+		End:       2,
+		Quorums: []*blobstore.QuorumUsage{
+			{QuorumID: 0, NumBlobs: 1, RawBytes: 200, EncodedBytes: 16 * 31},
+			{QuorumID: 1, NumBlobs: 3, RawBytes: 600, EncodedBytes: (32 + 64) * 31},
+		},
+	}, usage)
+
+	usage = blobstore.AggregateAccountUsage("account", 1, 2, true, metadata)
+	assert.Equal(t, []*blobstore.QuorumUsage{
+		{QuorumID: 0, NumBlobs: 1, RawBytes: 200, EncodedBytes: 16 * 31},
+		{QuorumID: 1, NumBlobs: 2, RawBytes: 500, EncodedBytes: (32 + 64) * 31},
+	}, usage.Quorums)
+
+	usage = blobstore.AggregateAccountUsage("account", 1, 2, true, nil)
+	assert.Empty(t, usage.Quorums)
+}
+
+func TestExportAccountUsage(t *testing.T) {
+	ctx := context.Background()
+	seeded := seedAccountMetadata(t, "account-export")
+
+	// Of the blobs requested in [100, 200], only those at 150 and 200 are confirmed
+	var buf bytes.Buffer
+	err := blobstore.ExportAccountUsage(ctx, blobMetadataStore, "account-export", 100, 200, false, blobstore.UsageExportCSV, &buf)
+	assert.NoError(t, err)
+	assert.Equal(t, "account_id,start,end,confirmed_only,quorum_id,num_blobs,raw_bytes,encoded_bytes\n"+
+		"account-export,100,200,false,0,3,9000,3968\n"+
+		"account-export,100,200,false,1,3,9000,15872\n", buf.String())
+
+	buf.Reset()
+	err = blobstore.ExportAccountUsage(ctx, blobMetadataStore, "account-export", 100, 200, true, blobstore.UsageExportJSON, &buf)
+	assert.NoError(t, err)
+	var usage blobstore.AccountUsage
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &usage))
+	assert.Equal(t, blobstore.AccountUsage{
+		AccountID:     "account-export",
+		Start:         100,
+		End:           200,
+		ConfirmedOnly: true,
+		Quorums: []*blobstore.QuorumUsage{
+			{QuorumID: 0, NumBlobs: 2, RawBytes: 7000, EncodedBytes: 3968},
+			{QuorumID: 1, NumBlobs: 2, RawBytes: 7000, EncodedBytes: 15872},
+		},
+	}, usage)
+
+	err = blobstore.ExportAccountUsage(ctx, blobMetadataStore, "account-export", 100, 200, false, "xml", &buf)
+	assert.EqualError(t, err, `unknown usage export format "xml"`)
+
+	deleteSeededMetadata(t, seeded)
+}
@@ -0,0 +1,99 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Layr-Labs/eigenda/common"
+	commonaws "github.com/Layr-Labs/eigenda/common/aws"
+	"github.com/urfave/cli"
+)
+
+// Sinks of the status events
+const (
+	SinkSNS   = "sns"
+	SinkKafka = "kafka"
+)
+
+const (
+	SinkFlagName         = "status-events.sink"
+	SNSTopicARNFlagName  = "status-events.sns-topic-arn"
+	KafkaBrokersFlagName = "status-events.kafka-brokers"
+	KafkaTopicFlagName   = "status-events.kafka-topic"
+	QueueSizeFlagName    = "status-events.queue-size"
+)
+
+type Config struct {
+	// Sink is where the status events are published: sns or kafka. The events aren't published if empty.
+	Sink         string
+	SNSTopicARN  string
+	KafkaBrokers []string
+	KafkaTopic   string
+	// QueueSize is the number of events waiting to be published, beyond which the events are dropped
+	QueueSize int
+}
+
+func CLIFlags(envPrefix string, flagPrefix string) []cli.Flag {
+	return []cli.Flag{
+		cli.StringFlag{
+			Name:   common.PrefixFlag(flagPrefix, SinkFlagName),
+			Usage:  "Where the blob status transitions are published: sns or kafka. The transitions aren't published if empty",
+			Value:  "",
+			EnvVar: common.PrefixEnvVar(envPrefix, "STATUS_EVENTS_SINK"),
+		},
+		cli.StringFlag{
+			Name:   common.PrefixFlag(flagPrefix, SNSTopicARNFlagName),
+			Usage:  "ARN of the SNS topic the blob status transitions are published to with the sns sink. On a FIFO topic, the transitions of a blob are delivered in order",
+			Value:  "",
+			EnvVar: common.PrefixEnvVar(envPrefix, "STATUS_EVENTS_SNS_TOPIC_ARN"),
+		},
+		cli.StringSliceFlag{
+			Name:   common.PrefixFlag(flagPrefix, KafkaBrokersFlagName),
+			Usage:  "Addresses of the Kafka brokers with the kafka sink",
+			EnvVar: common.PrefixEnvVar(envPrefix, "STATUS_EVENTS_KAFKA_BROKERS"),
+		},
+		cli.StringFlag{
+			Name:   common.PrefixFlag(flagPrefix, KafkaTopicFlagName),
+			Usage:  "Kafka topic the blob status transitions are published to with the kafka sink, keyed by blob key",
+			Value:  "",
+			EnvVar: common.PrefixEnvVar(envPrefix, "STATUS_EVENTS_KAFKA_TOPIC"),
+		},
+		cli.IntFlag{
+			Name:   common.PrefixFlag(flagPrefix, QueueSizeFlagName),
+			Usage:  "Number of blob status transitions waiting to be published, beyond which they are dropped rather than delaying the updates of the blobs",
+			Value:  10000,
+			EnvVar: common.PrefixEnvVar(envPrefix, "STATUS_EVENTS_QUEUE_SIZE"),
+		},
+	}
+}
+
+func ReadCLIConfig(ctx *cli.Context, flagPrefix string) Config {
+	return Config{
+		Sink:         ctx.GlobalString(common.PrefixFlag(flagPrefix, SinkFlagName)),
+		SNSTopicARN:  ctx.GlobalString(common.PrefixFlag(flagPrefix, SNSTopicARNFlagName)),
+		KafkaBrokers: ctx.GlobalStringSlice(common.PrefixFlag(flagPrefix, KafkaBrokersFlagName)),
+		KafkaTopic:   ctx.GlobalString(common.PrefixFlag(flagPrefix, KafkaTopicFlagName)),
+		QueueSize:    ctx.GlobalInt(common.PrefixFlag(flagPrefix, QueueSizeFlagName)),
+	}
+}
+
+// NewSink creates the sink of the config, which is nil if the status events aren't published
+func NewSink(ctx context.Context, config Config, awsConfig commonaws.ClientConfig) (Sink, error) {
+	switch config.Sink {
+	case "":
+		return nil, nil
+	case SinkSNS:
+		if config.SNSTopicARN == "" {
+			return nil, errors.New("the sns status event sink requires a topic ARN")
+		}
+		return NewSNSSink(ctx, awsConfig, config.SNSTopicARN)
+	case SinkKafka:
+		if len(config.KafkaBrokers) == 0 || config.KafkaTopic == "" {
+			return nil, errors.New("the kafka status event sink requires brokers and a topic")
+		}
+		return NewKafkaSink(config.KafkaBrokers, config.KafkaTopic), nil
+	default:
+		return nil, fmt.Errorf("unknown status event sink %q", config.Sink)
+	}
+}
@@ -0,0 +1,158 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/disperser"
+)
+
+// Reasons for which the status events are dropped
+const (
+	DropReasonQueueFull = "queue_full"
+	DropReasonSinkError = "sink_error"
+)
+
+const (
+	// maxBatchSize is the maximum number of queued events published to the sink at once
+	maxBatchSize = 10
+	// publishTimeout bounds the publication of a batch of events to the sink
+	publishTimeout = 5 * time.Second
+)
+
+// StatusEvent records a transition of the status of a blob
+type StatusEvent struct {
+	BlobKey string `json:"blob_key"`
+	// OldStatus is empty for the blobs which were just queued
+	OldStatus string `json:"old_status,omitempty"`
+	NewStatus string `json:"new_status"`
+	// Timestamp is the time of the transition, in nanoseconds since the epoch
+	Timestamp int64    `json:"timestamp"`
+	Account   string   `json:"account,omitempty"`
+	Quorums   []uint32 `json:"quorums"`
+}
+
+// NewStatusEvent returns the event of the transition of the blob of the metadata from the old status, or from no status
+// if nil, to the new status
+func NewStatusEvent(metadata *disperser.BlobMetadata, oldStatus *disperser.BlobStatus, newStatus disperser.BlobStatus, at time.Time) *StatusEvent {
+	event := &StatusEvent{
+		BlobKey:   metadata.GetBlobKey().String(),
+		NewStatus: newStatus.String(),
+		Timestamp: at.UnixNano(),
+		Quorums:   []uint32{},
+	}
+	if oldStatus != nil {
+		event.OldStatus = oldStatus.String()
+	}
+	if metadata.RequestMetadata != nil {
+		event.Account = metadata.RequestMetadata.AccountID
+		for _, param := range metadata.RequestMetadata.SecurityParams {
+			event.Quorums = append(event.Quorums, uint32(param.QuorumID))
+		}
+	}
+	return event
+}
+
+// Sink delivers the status events to their downstream consumers. The events of a blob must be delivered in the order
+// they are published.
+type Sink interface {
+	Publish(ctx context.Context, events []*StatusEvent) error
+}
+
+// PartialPublishError is returned by the sinks which failed to publish some of the events only
+type PartialPublishError struct {
+	// Failed is the number of events which weren't published
+	Failed int
+	Err    error
+}
+
+func (e *PartialPublishError) Error() string {
+	return fmt.Sprintf("failed to publish %d status events: %v", e.Failed, e.Err)
+}
+
+func (e *PartialPublishError) Unwrap() error {
+	return e.Err
+}
+
+// Publisher publishes the status events to a sink in the background, in the order they are queued. The publication is
+// best effort: the events are dropped rather than blocking the updates of the blobs when the queue is full, e.g.
+// because the sink is down, and when the sink fails to publish them.
+type Publisher struct {
+	sink   Sink
+	events chan *StatusEvent
+	logger common.Logger
+	// onDrop, if not nil, is called with the reason and the number of each drop of events
+	onDrop func(reason string, count int)
+}
+
+// NewPublisher creates a publisher queueing up to queueSize events
+func NewPublisher(sink Sink, queueSize int, logger common.Logger, onDrop func(reason string, count int)) *Publisher {
+	return &Publisher{
+		sink:   sink,
+		events: make(chan *StatusEvent, queueSize),
+		logger: logger,
+		onDrop: onDrop,
+	}
+}
+
+// Start publishes the queued events until the context is done. The events are published by a single goroutine, which
+// keeps them in order.
+func (p *Publisher) Start(ctx context.Context) {
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event := <-p.events:
+				p.publish(ctx, p.nextBatch(event))
+			}
+		}
+	}()
+}
+
+// Publish queues the event, dropping it if the queue is full
+func (p *Publisher) Publish(event *StatusEvent) {
+	select {
+	case p.events <- event:
+	default:
+		p.logger.Warn("dropped blob status event, the status event queue is full", "blobKey", event.BlobKey, "newStatus", event.NewStatus)
+		p.drop(DropReasonQueueFull, 1)
+	}
+}
+
+// nextBatch returns the event followed by the events queued after it, up to maxBatchSize events
+func (p *Publisher) nextBatch(event *StatusEvent) []*StatusEvent {
+	batch := []*StatusEvent{event}
+	for len(batch) < maxBatchSize {
+		select {
+		case next := <-p.events:
+			batch = append(batch, next)
+		default:
+			return batch
+		}
+	}
+	return batch
+}
+
+func (p *Publisher) publish(ctx context.Context, batch []*StatusEvent) {
+	publishCtx, cancel := context.WithTimeout(ctx, publishTimeout)
+	defer cancel()
+	if err := p.sink.Publish(publishCtx, batch); err != nil {
+		dropped := len(batch)
+		var partial *PartialPublishError
+		if errors.As(err, &partial) {
+			dropped = partial.Failed
+		}
+		p.logger.Error("failed to publish blob status events", "count", len(batch), "dropped", dropped, "err", err)
+		p.drop(DropReasonSinkError, dropped)
+	}
+}
+
+func (p *Publisher) drop(reason string, count int) {
+	if p.onDrop != nil {
+		p.onDrop(reason, count)
+	}
+}
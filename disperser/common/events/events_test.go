@@ -0,0 +1,151 @@
+package events_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	cmock "github.com/Layr-Labs/eigenda/common/mock"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/disperser"
+	"github.com/Layr-Labs/eigenda/disperser/common/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// blockingSink blocks the publications until it is released
+type blockingSink struct {
+	events.MemorySink
+	entered chan struct{}
+	release chan struct{}
+	once    sync.Once
+}
+
+func (s *blockingSink) Publish(ctx context.Context, batch []*events.StatusEvent) error {
+	s.once.Do(func() { close(s.entered) })
+	<-s.release
+	return s.MemorySink.Publish(ctx, batch)
+}
+
+type failingSink struct{}
+
+func (failingSink) Publish(ctx context.Context, batch []*events.StatusEvent) error {
+	return errors.New("sink unavailable")
+}
+
+// dropCounter counts the dropped events by reason
+type dropCounter struct {
+	mu      sync.Mutex
+	dropped map[string]int
+}
+
+func (c *dropCounter) onDrop(reason string, count int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.dropped[reason] += count
+}
+
+func (c *dropCounter) get(reason string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.dropped[reason]
+}
+
+func TestNewStatusEvent(t *testing.T) {
+	metadata := &disperser.BlobMetadata{
+		BlobHash:     "blob",
+		MetadataHash: "hash",
+		RequestMetadata: &disperser.RequestMetadata{
+			BlobRequestHeader: core.BlobRequestHeader{
+				AccountID:      "account",
+				SecurityParams: []*core.SecurityParam{{QuorumID: 0}, {QuorumID: 1}},
+			},
+		},
+	}
+	oldStatus := disperser.Processing
+	event := events.NewStatusEvent(metadata, &oldStatus, disperser.Confirmed, time.Unix(0, 42))
+	assert.Equal(t, &events.StatusEvent{
+		BlobKey:   "blob-hash",
+		OldStatus: "Processing",
+		NewStatus: "Confirmed",
+		Timestamp: 42,
+		Account:   "account",
+		Quorums:   []uint32{0, 1},
+	}, event)
+
+	event = events.NewStatusEvent(metadata, nil, disperser.Processing, time.Unix(0, 42))
+	assert.Empty(t, event.OldStatus)
+}
+
+func TestPublisherKeepsOrderPerBlobKey(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	sink := events.NewMemorySink()
+	publisher := events.NewPublisher(sink, 100, &cmock.Logger{}, nil)
+	publisher.Start(ctx)
+
+	// The transitions of several blobs are interleaved, and published in batches
+	numBlobs, numTransitions := 5, 20
+	for i := 0; i < numTransitions; i++ {
+		for b := 0; b < numBlobs; b++ {
+			publisher.Publish(&events.StatusEvent{BlobKey: fmt.Sprintf("blob%d", b), Timestamp: int64(i)})
+		}
+	}
+	require.Eventually(t, func() bool {
+		return len(sink.Events()) == numBlobs*numTransitions
+	}, time.Second, 10*time.Millisecond)
+
+	next := make(map[string]int64)
+	for _, event := range sink.Events() {
+		assert.Equal(t, next[event.BlobKey], event.Timestamp, "blob %s", event.BlobKey)
+		next[event.BlobKey]++
+	}
+}
+
+func TestPublisherDropsWhenQueueFull(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	sink := &blockingSink{entered: make(chan struct{}), release: make(chan struct{})}
+	drops := &dropCounter{dropped: make(map[string]int)}
+	publisher := events.NewPublisher(sink, 2, &cmock.Logger{}, drops.onDrop)
+	publisher.Start(ctx)
+
+	// The first event blocks in the sink, the next two fill the queue and the others are dropped
+	publisher.Publish(&events.StatusEvent{BlobKey: "blob", Timestamp: 0})
+	<-sink.entered
+	for i := 1; i < 6; i++ {
+		publisher.Publish(&events.StatusEvent{BlobKey: "blob", Timestamp: int64(i)})
+	}
+	assert.Equal(t, 3, drops.get(events.DropReasonQueueFull))
+
+	// Publishing resumes in order once the sink recovers
+	close(sink.release)
+	require.Eventually(t, func() bool {
+		return len(sink.Events()) == 3
+	}, time.Second, 10*time.Millisecond)
+	for i, event := range sink.Events() {
+		assert.Equal(t, int64(i), event.Timestamp)
+	}
+	publisher.Publish(&events.StatusEvent{BlobKey: "blob", Timestamp: 3})
+	require.Eventually(t, func() bool {
+		return len(sink.Events()) == 4
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, 3, drops.get(events.DropReasonQueueFull))
+}
+
+func TestPublisherDropsOnSinkError(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	drops := &dropCounter{dropped: make(map[string]int)}
+	publisher := events.NewPublisher(failingSink{}, 10, &cmock.Logger{}, drops.onDrop)
+	publisher.Start(ctx)
+
+	publisher.Publish(&events.StatusEvent{BlobKey: "blob"})
+	require.Eventually(t, func() bool {
+		return drops.get(events.DropReasonSinkError) == 1
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, 0, drops.get(events.DropReasonQueueFull))
+}
@@ -0,0 +1,56 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// KafkaSink publishes the status events to a Kafka topic, as JSON messages keyed by blob key. The messages of a blob
+// are in the same partition, which keeps them in order.
+type KafkaSink struct {
+	writer *kafka.Writer
+}
+
+var _ Sink = (*KafkaSink)(nil)
+
+func NewKafkaSink(brokers []string, topic string) *KafkaSink {
+	return &KafkaSink{
+		writer: &kafka.Writer{
+			Addr:     kafka.TCP(brokers...),
+			Topic:    topic,
+			Balancer: &kafka.Hash{},
+			// The publisher batches the events itself, so that the writes don't wait for more messages
+			BatchSize:    maxBatchSize,
+			BatchTimeout: 10 * time.Millisecond,
+			RequiredAcks: kafka.RequireAll,
+		},
+	}
+}
+
+func (s *KafkaSink) Publish(ctx context.Context, events []*StatusEvent) error {
+	messages := make([]kafka.Message, len(events))
+	for i, event := range events {
+		value, err := json.Marshal(event)
+		if err != nil {
+			return fmt.Errorf("failed to serialize status event: %w", err)
+		}
+		messages[i] = kafka.Message{Key: []byte(event.BlobKey), Value: value}
+	}
+
+	err := s.writer.WriteMessages(ctx, messages...)
+	var writeErrs kafka.WriteErrors
+	if errors.As(err, &writeErrs) {
+		return &PartialPublishError{Failed: writeErrs.Count(), Err: err}
+	}
+	return err
+}
+
+// Close flushes the pending messages and closes the connections to the brokers
+func (s *KafkaSink) Close() error {
+	return s.writer.Close()
+}
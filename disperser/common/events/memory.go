@@ -0,0 +1,32 @@
+package events
+
+import (
+	"context"
+	"sync"
+)
+
+// MemorySink keeps the status events in memory, e.g. for tests
+type MemorySink struct {
+	mu     sync.Mutex
+	events []*StatusEvent
+}
+
+var _ Sink = (*MemorySink)(nil)
+
+func NewMemorySink() *MemorySink {
+	return &MemorySink{}
+}
+
+func (s *MemorySink) Publish(ctx context.Context, events []*StatusEvent) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.events = append(s.events, events...)
+	return nil
+}
+
+// Events returns the events published so far, in order
+func (s *MemorySink) Events() []*StatusEvent {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]*StatusEvent{}, s.events...)
+}
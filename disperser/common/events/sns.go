@@ -0,0 +1,74 @@
+package events
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	commonaws "github.com/Layr-Labs/eigenda/common/aws"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sns"
+	"github.com/aws/aws-sdk-go-v2/service/sns/types"
+)
+
+// SNSSink publishes the status events to an SNS topic, as JSON messages. On a FIFO topic, the messages of a blob are
+// in the same message group, which keeps them in order.
+type SNSSink struct {
+	client   *sns.Client
+	topicARN string
+	fifo     bool
+}
+
+var _ Sink = (*SNSSink)(nil)
+
+func NewSNSSink(ctx context.Context, cfg commonaws.ClientConfig, topicARN string) (*SNSSink, error) {
+	awsConfig, err := commonaws.LoadConfig(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &SNSSink{
+		client:   sns.NewFromConfig(awsConfig),
+		topicARN: topicARN,
+		fifo:     strings.HasSuffix(topicARN, ".fifo"),
+	}, nil
+}
+
+func (s *SNSSink) Publish(ctx context.Context, events []*StatusEvent) error {
+	entries := make([]types.PublishBatchRequestEntry, len(events))
+	for i, event := range events {
+		message, err := json.Marshal(event)
+		if err != nil {
+			return fmt.Errorf("failed to serialize status event: %w", err)
+		}
+		entries[i] = types.PublishBatchRequestEntry{
+			Id:      aws.String(strconv.Itoa(i)),
+			Message: aws.String(string(message)),
+		}
+		if s.fifo {
+			// The blob keys are longer than the 128 characters a message group ID is limited to
+			groupID := sha256.Sum256([]byte(event.BlobKey))
+			deduplicationID := sha256.Sum256(message)
+			entries[i].MessageGroupId = aws.String(hex.EncodeToString(groupID[:]))
+			entries[i].MessageDeduplicationId = aws.String(hex.EncodeToString(deduplicationID[:]))
+		}
+	}
+
+	output, err := s.client.PublishBatch(ctx, &sns.PublishBatchInput{
+		TopicArn:                   aws.String(s.topicARN),
+		PublishBatchRequestEntries: entries,
+	})
+	if err != nil {
+		return err
+	}
+	if len(output.Failed) > 0 {
+		return &PartialPublishError{
+			Failed: len(output.Failed),
+			Err:    fmt.Errorf("%s: %s", aws.ToString(output.Failed[0].Code), aws.ToString(output.Failed[0].Message)),
+		}
+	}
+	return nil
+}
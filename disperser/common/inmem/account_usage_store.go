@@ -0,0 +1,42 @@
+package inmem
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/disperser"
+)
+
+// AccountUsageStore is an in-memory implementation of the AccountUsageStore interface
+type AccountUsageStore struct {
+	mu    sync.Mutex
+	usage map[accountDay]uint64
+}
+
+type accountDay struct {
+	accountID core.AccountID
+	day       string
+}
+
+var _ disperser.AccountUsageStore = (*AccountUsageStore)(nil)
+
+func NewAccountUsageStore() *AccountUsageStore {
+	return &AccountUsageStore{
+		usage: make(map[accountDay]uint64),
+	}
+}
+
+func (s *AccountUsageStore) AddConfirmedBytes(ctx context.Context, accountID core.AccountID, at time.Time, bytes uint64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.usage[accountDay{accountID: accountID, day: at.UTC().Format(time.DateOnly)}] += bytes
+	return nil
+}
+
+func (s *AccountUsageStore) GetConfirmedBytes(ctx context.Context, accountID core.AccountID, at time.Time) (uint64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.usage[accountDay{accountID: accountID, day: at.UTC().Format(time.DateOnly)}], nil
+}
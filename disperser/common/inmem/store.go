@@ -5,6 +5,7 @@ import (
 	"crypto/rand"
 	"encoding/hex"
 	"strconv"
+	"time"
 
 	"github.com/Layr-Labs/eigenda/core"
 	"github.com/Layr-Labs/eigenda/disperser"
@@ -14,6 +15,12 @@ import (
 type BlobStore struct {
 	Blobs    map[disperser.BlobHash]*BlobHolder
 	Metadata map[disperser.BlobKey]*disperser.BlobMetadata
+	// OperatorStates holds the serialized operator state snapshots by batch header hash
+	OperatorStates map[[32]byte][]byte
+	// BatchCosts holds the serialized batch costs by batch header hash
+	BatchCosts map[[32]byte][]byte
+	// BatchSignatures holds the serialized batch signatures by batch header hash
+	BatchSignatures map[[32]byte][]byte
 }
 
 // BlobHolder stores the blob along with its status and any other metadata
@@ -26,8 +33,11 @@ var _ disperser.BlobStore = (*BlobStore)(nil)
 // NewBlobStore creates an empty BlobStore
 func NewBlobStore() disperser.BlobStore {
 	return &BlobStore{
-		Blobs:    make(map[disperser.BlobHash]*BlobHolder),
-		Metadata: make(map[disperser.BlobKey]*disperser.BlobMetadata),
+		Blobs:           make(map[disperser.BlobHash]*BlobHolder),
+		Metadata:        make(map[disperser.BlobKey]*disperser.BlobMetadata),
+		OperatorStates:  make(map[[32]byte][]byte),
+		BatchCosts:      make(map[[32]byte][]byte),
+		BatchSignatures: make(map[[32]byte][]byte),
 	}
 }
 
@@ -71,9 +81,13 @@ func (q *BlobStore) GetBlobContent(ctx context.Context, blobHash disperser.BlobH
 
 func (q *BlobStore) MarkBlobConfirmed(ctx context.Context, existingMetadata *disperser.BlobMetadata, confirmationInfo *disperser.ConfirmationInfo) (*disperser.BlobMetadata, error) {
 	blobKey := existingMetadata.GetBlobKey()
-	if _, ok := q.Metadata[blobKey]; !ok {
+	stored, ok := q.Metadata[blobKey]
+	if !ok {
 		return nil, disperser.ErrBlobNotFound
 	}
+	if stored.BlobStatus != disperser.Processing {
+		return nil, disperser.ErrBlobAlreadyConfirmed
+	}
 	newMetadata := *existingMetadata
 	newMetadata.BlobStatus = disperser.Confirmed
 	newMetadata.ConfirmationInfo = confirmationInfo
@@ -93,6 +107,57 @@ func (q *BlobStore) MarkBlobInsufficientSignatures(ctx context.Context, existing
 	return &newMetadata, nil
 }
 
+func (q *BlobStore) ExtendBlobExpiry(ctx context.Context, existingMetadata *disperser.BlobMetadata, expiry uint64) (*disperser.BlobMetadata, error) {
+	blobKey := existingMetadata.GetBlobKey()
+	stored, ok := q.Metadata[blobKey]
+	if !ok {
+		return nil, disperser.ErrBlobNotFound
+	}
+	now := uint64(time.Now().Unix())
+	if stored.Expiry != 0 && stored.Expiry <= now {
+		return nil, disperser.ErrBlobExpired
+	}
+	if stored.Expiry != existingMetadata.Expiry {
+		return nil, disperser.ErrBlobExpiryChanged
+	}
+	newMetadata := *stored
+	newMetadata.Expiry = expiry
+	newMetadata.RetentionExtensions = append(append([]*disperser.RetentionExtension{}, stored.RetentionExtensions...), &disperser.RetentionExtension{
+		ExtendedAt:     now,
+		PreviousExpiry: stored.Expiry,
+		Expiry:         expiry,
+	})
+	q.Metadata[blobKey] = &newMetadata
+	return &newMetadata, nil
+}
+
+func (q *BlobStore) ResubmitBlob(ctx context.Context, existingMetadata *disperser.BlobMetadata, requestHeader core.BlobRequestHeader, requestedAt uint64) (disperser.BlobKey, error) {
+	blobKey := disperser.BlobKey{}
+	if err := existingMetadata.CheckResubmittable(uint64(time.Now().Unix())); err != nil {
+		return blobKey, err
+	}
+	if _, ok := q.Blobs[existingMetadata.BlobHash]; !ok {
+		return blobKey, disperser.ErrBlobNotFound
+	}
+	blobKey.BlobHash = existingMetadata.BlobHash
+	blobKey.MetadataHash = getMetadataHash(requestedAt)
+
+	q.Metadata[blobKey] = &disperser.BlobMetadata{
+		BlobHash:     blobKey.BlobHash,
+		MetadataHash: blobKey.MetadataHash,
+		BlobStatus:   disperser.Processing,
+		Expiry:       existingMetadata.Expiry,
+		NumRetries:   0,
+		RequestMetadata: &disperser.RequestMetadata{
+			BlobRequestHeader: requestHeader,
+			BlobSize:          existingMetadata.RequestMetadata.BlobSize,
+			RequestedAt:       requestedAt,
+		},
+	}
+
+	return blobKey, nil
+}
+
 func (q *BlobStore) MarkBlobFinalized(ctx context.Context, blobKey disperser.BlobKey) error {
 	if _, ok := q.Metadata[blobKey]; !ok {
 		return disperser.ErrBlobNotFound
@@ -120,6 +185,15 @@ func (q *BlobStore) MarkBlobFailed(ctx context.Context, blobKey disperser.BlobKe
 	return nil
 }
 
+func (q *BlobStore) MarkBlobQuotaExceeded(ctx context.Context, blobKey disperser.BlobKey) error {
+	if _, ok := q.Metadata[blobKey]; !ok {
+		return disperser.ErrBlobNotFound
+	}
+
+	q.Metadata[blobKey].BlobStatus = disperser.QuotaExceeded
+	return nil
+}
+
 func (q *BlobStore) IncrementBlobRetryCount(ctx context.Context, existingMetadata *disperser.BlobMetadata) error {
 	if _, ok := q.Metadata[existingMetadata.GetBlobKey()]; !ok {
 		return disperser.ErrBlobNotFound
@@ -189,6 +263,25 @@ func (q *BlobStore) HandleBlobFailure(ctx context.Context, metadata *disperser.B
 	}
 }
 
+func (q *BlobStore) RedriveBlob(ctx context.Context, existingMetadata *disperser.BlobMetadata, maxRetry uint) (*disperser.BlobMetadata, error) {
+	blobKey := existingMetadata.GetBlobKey()
+	stored, ok := q.Metadata[blobKey]
+	if !ok {
+		return nil, disperser.ErrBlobNotFound
+	}
+	if stored.BlobStatus != disperser.Processing || stored.NumRetries != existingMetadata.NumRetries {
+		return nil, disperser.ErrBlobStateChanged
+	}
+	newMetadata := *stored
+	if stored.NumRetries < maxRetry {
+		newMetadata.NumRetries++
+	} else {
+		newMetadata.BlobStatus = disperser.Failed
+	}
+	q.Metadata[blobKey] = &newMetadata
+	return &newMetadata, nil
+}
+
 // getNewBlobHash generates a new blob key
 func (q *BlobStore) getNewBlobHash() (disperser.BlobHash, error) {
 	var key disperser.BlobHash
@@ -213,3 +306,54 @@ func (q *BlobStore) getNewBlobHash() (disperser.BlobHash, error) {
 func getMetadataHash(requestedAt uint64) string {
 	return strconv.FormatUint(requestedAt, 10)
 }
+
+func (q *BlobStore) StoreOperatorState(ctx context.Context, batchHeaderHash [32]byte, snapshot *disperser.OperatorStateSnapshot) error {
+	data, err := snapshot.Serialize()
+	if err != nil {
+		return err
+	}
+	q.OperatorStates[batchHeaderHash] = data
+	return nil
+}
+
+func (q *BlobStore) GetOperatorState(ctx context.Context, batchHeaderHash [32]byte) (*disperser.OperatorStateSnapshot, error) {
+	data, ok := q.OperatorStates[batchHeaderHash]
+	if !ok {
+		return nil, disperser.ErrOperatorStateNotFound
+	}
+	return disperser.DeserializeOperatorStateSnapshot(data)
+}
+
+func (q *BlobStore) StoreBatchCost(ctx context.Context, batchHeaderHash [32]byte, cost *disperser.BatchCost) error {
+	data, err := cost.Serialize()
+	if err != nil {
+		return err
+	}
+	q.BatchCosts[batchHeaderHash] = data
+	return nil
+}
+
+func (q *BlobStore) GetBatchCost(ctx context.Context, batchHeaderHash [32]byte) (*disperser.BatchCost, error) {
+	data, ok := q.BatchCosts[batchHeaderHash]
+	if !ok {
+		return nil, disperser.ErrBatchCostNotFound
+	}
+	return disperser.DeserializeBatchCost(data)
+}
+
+func (q *BlobStore) StoreBatchSignature(ctx context.Context, batchHeaderHash [32]byte, signature *disperser.BatchSignature) error {
+	data, err := signature.Serialize()
+	if err != nil {
+		return err
+	}
+	q.BatchSignatures[batchHeaderHash] = data
+	return nil
+}
+
+func (q *BlobStore) GetBatchSignature(ctx context.Context, batchHeaderHash [32]byte) (*disperser.BatchSignature, error) {
+	data, ok := q.BatchSignatures[batchHeaderHash]
+	if !ok {
+		return nil, disperser.ErrBatchSignatureNotFound
+	}
+	return disperser.DeserializeBatchSignature(data)
+}
@@ -95,6 +95,10 @@ func TestBlobStore(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, disperser.Confirmed, updated.BlobStatus)
 
+	// A blob can only be confirmed once
+	_, err = bs.MarkBlobConfirmed(ctx, metadata, confirmationInfo)
+	assert.ErrorIs(t, err, disperser.ErrBlobAlreadyConfirmed)
+
 	meta2, err = bs.GetBlobMetadata(ctx, blobKey2)
 	assert.Nil(t, err)
 	assert.Equal(t, meta2.BlobStatus, disperser.Confirmed)
@@ -114,3 +118,28 @@ func TestBlobStore(t *testing.T) {
 	assert.Equal(t, 1, len(allMeta))
 	assert.Equal(t, allMeta[0].BlobStatus, disperser.Confirmed)
 }
+
+func TestBlobStoreRedriveBlob(t *testing.T) {
+	bs := inmem.NewBlobStore()
+	ctx := context.Background()
+	blobKey, err := bs.StoreBlob(ctx, &core.Blob{Data: []byte{1}}, uint64(time.Now().UnixNano()))
+	assert.Nil(t, err)
+	metadata, err := bs.GetBlobMetadata(ctx, blobKey)
+	assert.Nil(t, err)
+
+	redriven, err := bs.RedriveBlob(ctx, metadata, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, disperser.Processing, redriven.BlobStatus)
+	assert.Equal(t, uint(1), redriven.NumRetries)
+
+	// The blob was retried since the metadata was read
+	_, err = bs.RedriveBlob(ctx, metadata, 1)
+	assert.ErrorIs(t, err, disperser.ErrBlobStateChanged)
+
+	// The blob is failed once it reached the max number of retries
+	failed, err := bs.RedriveBlob(ctx, redriven, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, disperser.Failed, failed.BlobStatus)
+	_, err = bs.RedriveBlob(ctx, failed, 1)
+	assert.ErrorIs(t, err, disperser.ErrBlobStateChanged)
+}
@@ -23,8 +23,8 @@ func (s *server) getBlob(ctx context.Context, key string) (*BlobMetadataResponse
 	return convertMetadataToBlobMetadataResponse(metadata)
 }
 
-func (s *server) getBlobs(ctx context.Context, limit int) ([]*BlobMetadataResponse, error) {
-	_, blobMetadatas, err := s.getBlobMetadataByBatchesWithLimit(ctx, limit)
+func (s *server) getBlobs(ctx context.Context, limit int, namespace string) ([]*BlobMetadataResponse, error) {
+	_, blobMetadatas, err := s.getBlobMetadataByBatchesWithLimit(ctx, limit, namespace)
 	if err != nil {
 		return nil, err
 	}
@@ -70,6 +70,7 @@ func convertMetadataToBlobMetadataResponse(metadata *disperser.BlobMetadata) (*B
 			SecurityParams: metadata.RequestMetadata.SecurityParams,
 			RequestAt:      ConvertNanosecondToSecond(metadata.RequestMetadata.RequestedAt),
 			BlobStatus:     metadata.BlobStatus,
+			Namespace:      metadata.RequestMetadata.Namespace,
 		}, nil
 	}
 
@@ -88,5 +89,6 @@ func convertMetadataToBlobMetadataResponse(metadata *disperser.BlobMetadata) (*B
 		SecurityParams:          metadata.RequestMetadata.SecurityParams,
 		RequestAt:               ConvertNanosecondToSecond(metadata.RequestMetadata.RequestedAt),
 		BlobStatus:              metadata.BlobStatus,
+		Namespace:               metadata.RequestMetadata.Namespace,
 	}, nil
 }
@@ -30,6 +30,12 @@ const docTemplate = `{
                         "description": "Limit [default: 10]",
                         "name": "limit",
                         "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only return blobs dispersed with this namespace",
+                        "name": "namespace",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -345,6 +351,9 @@ const docTemplate = `{
                 "fee": {
                     "type": "string"
                 },
+                "namespace": {
+                    "type": "string"
+                },
                 "reference_block_number": {
                     "type": "integer"
                 },
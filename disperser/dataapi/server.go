@@ -46,6 +46,7 @@ type (
 		SecurityParams          []*core.SecurityParam `json:"security_params"`
 		RequestAt               uint64                `json:"requested_at"`
 		BlobStatus              disperser.BlobStatus  `json:"blob_status"`
+		Namespace               string                `json:"namespace"`
 	}
 
 	Metric struct {
@@ -207,8 +208,9 @@ func (s *server) FetchBlobHandler(c *gin.Context) {
 //	@Summary	Fetch blobs metadata list
 //	@Tags		Feed
 //	@Produce	json
-//	@Param		limit	query		int	false	"Limit [default: 10]"
-//	@Success	200		{object}	BlobsResponse
+//	@Param		limit		query		int		false	"Limit [default: 10]"
+//	@Param		namespace	query		string	false	"Only return blobs dispersed with this namespace"
+//	@Success	200			{object}	BlobsResponse
 //	@Failure	400		{object}	ErrorResponse	"error: Bad request"
 //	@Failure	404		{object}	ErrorResponse	"error: Not found"
 //	@Failure	500		{object}	ErrorResponse	"error: Server error"
@@ -224,7 +226,7 @@ func (s *server) FetchBlobsHandler(c *gin.Context) {
 		limit = 10
 	}
 
-	metadatas, err := s.getBlobs(c.Request.Context(), limit)
+	metadatas, err := s.getBlobs(c.Request.Context(), limit, c.Query("namespace"))
 	if err != nil {
 		s.metrics.IncrementFailedRequestNum("FetchBlobs")
 		errorResponse(c, err)
@@ -357,7 +359,9 @@ func (s *server) FetchNonSigners(c *gin.Context) {
 	c.JSON(http.StatusOK, metric)
 }
 
-func (s *server) getBlobMetadataByBatchesWithLimit(ctx context.Context, limit int) ([]*Batch, []*disperser.BlobMetadata, error) {
+// getBlobMetadataByBatchesWithLimit returns the metadata of up to limit blobs from the most recent batches. If namespace is
+// not empty, only the blobs dispersed with that namespace are returned.
+func (s *server) getBlobMetadataByBatchesWithLimit(ctx context.Context, limit int, namespace string) ([]*Batch, []*disperser.BlobMetadata, error) {
 	var (
 		blobMetadatas   = make([]*disperser.BlobMetadata, 0)
 		batches         = make([]*Batch, 0)
@@ -404,6 +408,9 @@ func (s *server) getBlobMetadataByBatchesWithLimit(ctx context.Context, limit in
 				continue
 			}
 			for _, bm := range metadatas {
+				if namespace != "" && (bm.RequestMetadata == nil || bm.RequestMetadata.Namespace != namespace) {
+					continue
+				}
 				blobKey := bm.GetBlobKey().String()
 				if _, found := blobKeyPresence[blobKey]; !found {
 					blobKeyPresence[blobKey] = struct{}{}
@@ -432,6 +439,8 @@ func errorResponse(c *gin.Context, err error) {
 	switch {
 	case errors.Is(err, errNotFound):
 		code = http.StatusNotFound
+	case errors.Is(err, disperser.ErrInvalidRequestID):
+		code = http.StatusBadRequest
 	default:
 		code = http.StatusInternalServerError
 	}
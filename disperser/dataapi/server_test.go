@@ -95,6 +95,19 @@ func TestFetchBlobHandler(t *testing.T) {
 	assert.Equal(t, uint64(5567830000), response.RequestAt)
 }
 
+func TestFetchBlobHandlerInvalidKey(t *testing.T) {
+	r := setUpRouter()
+	r.GET("/v1/feed/blobs/:blob_key", testDataApiServer.FetchBlobHandler)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/v1/feed/blobs/not-a-blob-key", nil)
+	r.ServeHTTP(w, req)
+
+	res := w.Result()
+	defer res.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+}
+
 func TestFetchBlobsHandler(t *testing.T) {
 	defer goleak.VerifyNone(t)
 
@@ -1,11 +1,17 @@
 package disperser
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
 	"crypto/sha256"
+	"encoding/gob"
 	"encoding/hex"
 	"fmt"
+	"math/big"
 	"strings"
+	"time"
+	"unicode/utf8"
 
 	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/core"
@@ -25,6 +31,9 @@ const (
 	Failed
 	Finalized
 	InsufficientSignatures
+	// QuotaExceeded is the terminal status of the blobs the batcher excluded for longer than the grace period because
+	// their account exceeded its daily quota
+	QuotaExceeded
 )
 
 var enumStrings = map[BlobStatus]string{
@@ -33,6 +42,7 @@ var enumStrings = map[BlobStatus]string{
 	Failed:                 "Failed",
 	Finalized:              "Finalized",
 	InsufficientSignatures: "InsufficientSignatures",
+	QuotaExceeded:          "QuotaExceeded",
 }
 
 func (bs BlobStatus) String() string {
@@ -50,21 +60,58 @@ type BlobKey struct {
 	MetadataHash MetadataHash
 }
 
+// requestIDVersion prefixes the request IDs issued to the clients, so that their format can evolve. Request IDs
+// issued before the versioning have no prefix.
+const requestIDVersion = "v1:"
+
 func (mk BlobKey) String() string {
 	return fmt.Sprintf("%s-%s", mk.BlobHash, mk.MetadataHash)
 }
 
+// RequestID returns the ID of the dispersal request of the blob, as issued to the clients
+func (mk BlobKey) RequestID() []byte {
+	return []byte(requestIDVersion + mk.String())
+}
+
+// ParseBlobKey parses a blob key from a request ID, with or without its version prefix, or from the string of a blob
+// key. It returns ErrInvalidRequestID if the key is malformed.
 func ParseBlobKey(key string) (BlobKey, error) {
-	parts := strings.Split(key, "-")
-	if len(parts) != 2 {
-		return BlobKey{}, fmt.Errorf("invalid metadata key: %s", key)
+	if !utf8.ValidString(key) {
+		return BlobKey{}, fmt.Errorf("%w: not valid UTF-8", ErrInvalidRequestID)
+	}
+	if version, rest, found := strings.Cut(key, ":"); found {
+		if version+":" != requestIDVersion {
+			return BlobKey{}, fmt.Errorf("%w: unsupported version %q", ErrInvalidRequestID, version)
+		}
+		key = rest
+	}
+
+	blobHash, metadataHash, found := strings.Cut(key, "-")
+	if !found || strings.Contains(metadataHash, "-") {
+		return BlobKey{}, fmt.Errorf("%w: %q is not made of a blob hash and a metadata hash", ErrInvalidRequestID, key)
+	}
+	// The blob hash is the hex encoding of a sha256 hash
+	if len(blobHash) != 2*sha256.Size || !isLowerHex(blobHash) {
+		return BlobKey{}, fmt.Errorf("%w: invalid blob hash %q", ErrInvalidRequestID, blobHash)
+	}
+	if len(metadataHash) == 0 || !isLowerHex(metadataHash) {
+		return BlobKey{}, fmt.Errorf("%w: invalid metadata hash %q", ErrInvalidRequestID, metadataHash)
 	}
 	return BlobKey{
-		BlobHash:     parts[0],
-		MetadataHash: parts[1],
+		BlobHash:     blobHash,
+		MetadataHash: metadataHash,
 	}, nil
 }
 
+func isLowerHex(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if (s[i] < '0' || s[i] > '9') && (s[i] < 'a' || s[i] > 'f') {
+			return false
+		}
+	}
+	return true
+}
+
 type BlobMetadata struct {
 	BlobHash     BlobHash     `json:"blob_hash"`
 	MetadataHash MetadataHash `json:"metadata_hash"`
@@ -81,6 +128,18 @@ type BlobMetadata struct {
 	// This field is nil if the blob has not been confirmed
 	// This field is omitted when marshalling to DynamoDB attributevalue as this field will be flattened
 	ConfirmationInfo *ConfirmationInfo `json:"blob_confirmation_info" dynamodbav:"-"`
+	// RetentionExtensions records the extensions of the retention of the blob past its initial expiry, for audit
+	RetentionExtensions []*RetentionExtension `json:"retention_extensions" dynamodbav:",omitempty"`
+}
+
+// RetentionExtension records an extension of the retention of a blob
+type RetentionExtension struct {
+	// ExtendedAt is unix epoch time in seconds at which the retention was extended
+	ExtendedAt uint64 `json:"extended_at"`
+	// PreviousExpiry is the expiry of the blob before the extension
+	PreviousExpiry uint64 `json:"previous_expiry"`
+	// Expiry is the expiry of the blob after the extension
+	Expiry uint64 `json:"expiry"`
 }
 
 func (m *BlobMetadata) GetBlobKey() BlobKey {
@@ -101,8 +160,62 @@ func (m *BlobMetadata) IsConfirmed() (bool, error) {
 	return true, nil
 }
 
+// IsPrivate returns whether the data of the blob is only returned to its submitter, which is the case of the private
+// blobs until they are finalized
+func (m *BlobMetadata) IsPrivate() bool {
+	return m.RequestMetadata != nil && len(m.RequestMetadata.SubmitterPublicKey) > 0 && m.BlobStatus != Finalized
+}
+
+// CheckResubmittable checks that the blob can be dispersed again, i.e. that it failed rather than still be processing
+// or be confirmed, and that it didn't expire at the given unix epoch time in seconds, as its content may then be gone
+func (m *BlobMetadata) CheckResubmittable(now uint64) error {
+	switch m.BlobStatus {
+	case Processing, Confirmed, Finalized:
+		return fmt.Errorf("%w: blob %s is %s", ErrBlobNotResubmittable, m.GetBlobKey().String(), m.BlobStatus)
+	}
+	if m.Expiry != 0 && m.Expiry <= now {
+		return fmt.Errorf("%w: blob %s expired at %d", ErrBlobExpired, m.GetBlobKey().String(), m.Expiry)
+	}
+	if m.RequestMetadata == nil {
+		return fmt.Errorf("missing request metadata for blob %s", m.GetBlobKey().String())
+	}
+	return nil
+}
+
+// VerifyBlobSize checks that the blob content has the size recorded when the blob was dispersed
+func (m *BlobMetadata) VerifyBlobSize(data []byte) error {
+	if m.RequestMetadata == nil {
+		return fmt.Errorf("missing request metadata for blob %s", m.GetBlobKey().String())
+	}
+	if uint(len(data)) != m.RequestMetadata.BlobSize {
+		return fmt.Errorf("%w: blob %s has %d bytes, expected %d", ErrBlobIntegrity, m.GetBlobKey().String(), len(data), m.RequestMetadata.BlobSize)
+	}
+	return nil
+}
+
+// TrimBlobPadding returns the blob content trimmed to the size recorded when the blob was dispersed. The content may be
+// padded with zeros, as the blobs reconstructed from the nodes are padded to the length of the encoded blob, but a
+// content shorter than the blob, or with non-zero bytes past its size, fails with ErrBlobIntegrity.
+func (m *BlobMetadata) TrimBlobPadding(data []byte) ([]byte, error) {
+	if m.RequestMetadata == nil {
+		return nil, fmt.Errorf("missing request metadata for blob %s", m.GetBlobKey().String())
+	}
+	size := m.RequestMetadata.BlobSize
+	if uint(len(data)) < size {
+		return nil, fmt.Errorf("%w: blob %s has %d bytes, expected %d", ErrBlobIntegrity, m.GetBlobKey().String(), len(data), size)
+	}
+	for _, b := range data[size:] {
+		if b != 0 {
+			return nil, fmt.Errorf("%w: blob %s has non-zero bytes past its size of %d bytes", ErrBlobIntegrity, m.GetBlobKey().String(), size)
+		}
+	}
+	return data[:size], nil
+}
+
 type RequestMetadata struct {
 	core.BlobRequestHeader
+	// BlobSize is the length in bytes of the blob as dispersed, to which the blobs reconstructed from the nodes are
+	// trimmed
 	BlobSize    uint   `json:"blob_size"`
 	RequestedAt uint64 `json:"requested_at"`
 }
@@ -124,6 +237,174 @@ type ConfirmationInfo struct {
 	BlobQuorumInfos         []*core.BlobQuorumInfo               `json:"blob_quorum_infos"`
 }
 
+// QuorumStatus is the confirmation status of a blob in one of its quorums
+type QuorumStatus struct {
+	QuorumID        core.QuorumID
+	QuorumThreshold uint8
+	// PercentSigned is the percentage of the quorum stake that signed the batch of the blob
+	PercentSigned uint8
+	// Confirmed is whether PercentSigned reaches the quorum threshold of the blob
+	Confirmed bool
+}
+
+// QuorumStatuses returns the confirmation status of the blob in each of its quorums, in the order of BlobQuorumInfos.
+// A quorum without result in QuorumResults didn't sign the batch and isn't confirmed. The blob is Confirmed only when
+// it is confirmed in all of its quorums: a blob confirmed in some of its quorums only is partially confirmed, and is
+// retried by the batcher rather than Confirmed.
+func (c *ConfirmationInfo) QuorumStatuses() []*QuorumStatus {
+	statuses := make([]*QuorumStatus, len(c.BlobQuorumInfos))
+	for i, quorumInfo := range c.BlobQuorumInfos {
+		status := &QuorumStatus{
+			QuorumID:        quorumInfo.QuorumID,
+			QuorumThreshold: quorumInfo.QuorumThreshold,
+		}
+		if result, ok := c.QuorumResults[quorumInfo.QuorumID]; ok {
+			status.PercentSigned = result.PercentSigned
+			status.Confirmed = result.PercentSigned >= quorumInfo.QuorumThreshold
+		}
+		statuses[i] = status
+	}
+	return statuses
+}
+
+// OperatorStateSnapshot is the operator state that the batcher made a batch with, persisted so that the stakes the
+// batch was signed against can be audited after the operator set changed
+type OperatorStateSnapshot struct {
+	// ReferenceBlockNumber is the reference block number of the batch, at which the operator state was read
+	ReferenceBlockNumber uint
+	// QuantizationFactor is the quantization factor the chunks of the batch were assigned with
+	QuantizationFactor uint
+	// OverprovisionPercent is the margin by which the chunks of the batch were over-provisioned
+	OverprovisionPercent uint
+	State                *core.OperatorState
+	// OperatorVersions are the versions advertised by the operators which replied to the dispersal of the batch
+	OperatorVersions map[core.OperatorID]core.NodeVersion
+}
+
+// operatorStateSnapshotWire is the serialized form of an OperatorStateSnapshot. The stakes are encoded as bytes, as gob
+// doesn't encode the core.StakeAmount type.
+type operatorStateSnapshotWire struct {
+	ReferenceBlockNumber uint
+	QuantizationFactor   uint
+	OverprovisionPercent uint
+	BlockNumber          uint
+	Operators            map[core.QuorumID]map[core.OperatorID]operatorInfoWire
+	Totals               map[core.QuorumID]operatorInfoWire
+	OperatorVersions     map[core.OperatorID]core.NodeVersion
+}
+
+type operatorInfoWire struct {
+	Stake []byte
+	Index core.OperatorIndex
+}
+
+// Serialize encodes the snapshot with gob and compresses it with gzip, as the state of large operator sets is
+// repetitive
+func (s *OperatorStateSnapshot) Serialize() ([]byte, error) {
+	wire := operatorStateSnapshotWire{
+		ReferenceBlockNumber: s.ReferenceBlockNumber,
+		QuantizationFactor:   s.QuantizationFactor,
+		OverprovisionPercent: s.OverprovisionPercent,
+		BlockNumber:          s.State.BlockNumber,
+		Operators:            make(map[core.QuorumID]map[core.OperatorID]operatorInfoWire, len(s.State.Operators)),
+		Totals:               make(map[core.QuorumID]operatorInfoWire, len(s.State.Totals)),
+		OperatorVersions:     s.OperatorVersions,
+	}
+	for quorumID, operators := range s.State.Operators {
+		wire.Operators[quorumID] = make(map[core.OperatorID]operatorInfoWire, len(operators))
+		for operatorID, info := range operators {
+			wire.Operators[quorumID][operatorID] = operatorInfoWire{Stake: (*big.Int)(info.Stake).Bytes(), Index: info.Index}
+		}
+	}
+	for quorumID, total := range s.State.Totals {
+		wire.Totals[quorumID] = operatorInfoWire{Stake: (*big.Int)(total.Stake).Bytes(), Index: total.Index}
+	}
+
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if err := gob.NewEncoder(zw).Encode(wire); err != nil {
+		return nil, fmt.Errorf("failed to serialize operator state snapshot: %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		return nil, fmt.Errorf("failed to compress operator state snapshot: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func DeserializeOperatorStateSnapshot(data []byte) (*OperatorStateSnapshot, error) {
+	zr, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress operator state snapshot: %w", err)
+	}
+	defer zr.Close()
+	var wire operatorStateSnapshotWire
+	if err := gob.NewDecoder(zr).Decode(&wire); err != nil {
+		return nil, fmt.Errorf("failed to deserialize operator state snapshot: %w", err)
+	}
+
+	state := &core.OperatorState{
+		Operators:   make(map[core.QuorumID]map[core.OperatorID]*core.OperatorInfo, len(wire.Operators)),
+		Totals:      make(map[core.QuorumID]*core.OperatorInfo, len(wire.Totals)),
+		BlockNumber: wire.BlockNumber,
+	}
+	for quorumID, operators := range wire.Operators {
+		state.Operators[quorumID] = make(map[core.OperatorID]*core.OperatorInfo, len(operators))
+		for operatorID, info := range operators {
+			state.Operators[quorumID][operatorID] = &core.OperatorInfo{Stake: new(big.Int).SetBytes(info.Stake), Index: info.Index}
+		}
+	}
+	for quorumID, total := range wire.Totals {
+		state.Totals[quorumID] = &core.OperatorInfo{Stake: new(big.Int).SetBytes(total.Stake), Index: total.Index}
+	}
+	return &OperatorStateSnapshot{
+		ReferenceBlockNumber: wire.ReferenceBlockNumber,
+		QuantizationFactor:   wire.QuantizationFactor,
+		OverprovisionPercent: wire.OverprovisionPercent,
+		State:                state,
+		OperatorVersions:     wire.OperatorVersions,
+	}, nil
+}
+
+// BatchCost is the onchain cost of the confirmation of a batch, recorded by the batcher once the confirmation
+// transaction is mined, along with the share of the cost attributed to each confirmed blob of the batch
+type BatchCost struct {
+	ConfirmationTxnHash     gcommon.Hash
+	ConfirmationBlockNumber uint32
+	GasUsed                 uint64
+	// EffectiveGasPrice is the price in wei paid per unit of gas by the confirmation transaction
+	EffectiveGasPrice *big.Int
+	// TotalCost is the cost in wei of the confirmation transaction, GasUsed * EffectiveGasPrice
+	TotalCost *big.Int
+	// BlobCosts are the shares of the total cost attributed to the confirmed blobs, in proportion to their encoded
+	// length, ordered by blob index. They sum to the total cost.
+	BlobCosts []*BlobCost
+}
+
+type BlobCost struct {
+	BlobKey   BlobKey
+	BlobIndex uint32
+	// EncodedLength is the encoded length of the blob in symbols, summed over its quorums
+	EncodedLength uint
+	// Cost is the share of the cost of the batch in wei attributed to the blob
+	Cost *big.Int
+}
+
+func (c *BatchCost) Serialize() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(c); err != nil {
+		return nil, fmt.Errorf("failed to serialize batch cost: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func DeserializeBatchCost(data []byte) (*BatchCost, error) {
+	var cost BatchCost
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&cost); err != nil {
+		return nil, fmt.Errorf("failed to deserialize batch cost: %w", err)
+	}
+	return &cost, nil
+}
+
 type BlobStore interface {
 	// StoreBlob adds a blob to the queue and returns a key that can be used to retrieve the blob later
 	StoreBlob(ctx context.Context, blob *core.Blob, requestedAt uint64) (BlobKey, error)
@@ -141,6 +422,8 @@ type BlobStore interface {
 	MarkBlobProcessing(ctx context.Context, blobKey BlobKey) error
 	// MarkBlobFailed marks a blob as failed
 	MarkBlobFailed(ctx context.Context, blobKey BlobKey) error
+	// MarkBlobQuotaExceeded marks a blob as failed because its account exceeded its daily quota
+	MarkBlobQuotaExceeded(ctx context.Context, blobKey BlobKey) error
 	// IncrementBlobRetryCount increments the retry count of a blob
 	IncrementBlobRetryCount(ctx context.Context, existingMetadata *BlobMetadata) error
 	// GetBlobsByMetadata retrieves a list of blobs given a list of metadata
@@ -155,6 +438,38 @@ type BlobStore interface {
 	GetBlobMetadata(ctx context.Context, blobKey BlobKey) (*BlobMetadata, error)
 	// HandleBlobFailure handles a blob failure by either incrementing the retry count or marking the blob as failed
 	HandleBlobFailure(ctx context.Context, metadata *BlobMetadata, maxRetry uint) error
+	// RedriveBlob handles a blob stuck in processing like a blob failure, but only if the stored blob is still
+	// processing with the retry count of the existing metadata, so that it doesn't override a concurrent update of the
+	// batcher. Returns the updated metadata, or ErrBlobStateChanged if the blob was updated concurrently.
+	RedriveBlob(ctx context.Context, existingMetadata *BlobMetadata, maxRetry uint) (*BlobMetadata, error)
+	// StoreOperatorState stores the snapshot of the operator state that the batch was made with
+	StoreOperatorState(ctx context.Context, batchHeaderHash [32]byte, snapshot *OperatorStateSnapshot) error
+	// GetOperatorState returns the snapshot of the operator state that the batch was made with
+	GetOperatorState(ctx context.Context, batchHeaderHash [32]byte) (*OperatorStateSnapshot, error)
+	// StoreBatchCost stores the cost of the confirmation of the batch
+	StoreBatchCost(ctx context.Context, batchHeaderHash [32]byte, cost *BatchCost) error
+	// GetBatchCost returns the cost of the confirmation of the batch
+	GetBatchCost(ctx context.Context, batchHeaderHash [32]byte) (*BatchCost, error)
+	// StoreBatchSignature stores the aggregated signature that the batch was confirmed with
+	StoreBatchSignature(ctx context.Context, batchHeaderHash [32]byte, signature *BatchSignature) error
+	// GetBatchSignature returns the aggregated signature that the batch was confirmed with
+	GetBatchSignature(ctx context.Context, batchHeaderHash [32]byte) (*BatchSignature, error)
+	// ExtendBlobExpiry postpones the expiry of a blob which hasn't expired yet, recording the extension in its metadata.
+	// Returns the updated metadata and error
+	ExtendBlobExpiry(ctx context.Context, existingMetadata *BlobMetadata, expiry uint64) (*BlobMetadata, error)
+	// ResubmitBlob queues a new request for the content of a blob which failed, with the given request header, and
+	// returns its key. The new request refers to the content already stored for the blob instead of storing it again.
+	// Returns ErrBlobNotResubmittable if the blob didn't fail, and ErrBlobExpired if it expired.
+	ResubmitBlob(ctx context.Context, existingMetadata *BlobMetadata, requestHeader core.BlobRequestHeader, requestedAt uint64) (BlobKey, error)
+}
+
+// AccountUsageStore records the number of bytes confirmed for each account on each UTC day, against which the batcher
+// enforces the daily quotas of the accounts
+type AccountUsageStore interface {
+	// AddConfirmedBytes adds the bytes confirmed for the account on the UTC day of the given time
+	AddConfirmedBytes(ctx context.Context, accountID core.AccountID, at time.Time, bytes uint64) error
+	// GetConfirmedBytes returns the bytes confirmed for the account on the UTC day of the given time
+	GetConfirmedBytes(ctx context.Context, accountID core.AccountID, at time.Time) (uint64, error)
 }
 
 type Dispatcher interface {
@@ -162,7 +477,10 @@ type Dispatcher interface {
 }
 
 type BatchConfirmer interface {
-	ConfirmBatch(context.Context, *core.BatchHeader, map[core.QuorumID]*core.QuorumResult, *core.SignatureAggregation) (*types.Receipt, error)
+	// ConfirmBatch confirms the batch onchain. If the replaced transaction isn't nil, the confirmation transaction
+	// replaces it at the same nonce with bumped fees. The last transaction sent is returned along with the receipt,
+	// also when the confirmation fails after sending it.
+	ConfirmBatch(ctx context.Context, header *core.BatchHeader, quorums map[core.QuorumID]*core.QuorumResult, sigAgg *core.SignatureAggregation, replaced *types.Transaction) (*types.Receipt, *types.Transaction, error)
 }
 
 // GenerateReverseIndexKey returns the key used to store the blob key in the reverse index
@@ -194,6 +512,12 @@ func FromBlobStatusProto(status disperser_rpc.BlobStatus) (*BlobStatus, error) {
 	case disperser_rpc.BlobStatus_FINALIZED:
 		res = Finalized
 		return &res, nil
+	case disperser_rpc.BlobStatus_INSUFFICIENT_SIGNATURES:
+		res = InsufficientSignatures
+		return &res, nil
+	case disperser_rpc.BlobStatus_QUOTA_EXCEEDED:
+		res = QuotaExceeded
+		return &res, nil
 	}
 
 	return nil, fmt.Errorf("unknown blob status: %v", status)
@@ -0,0 +1,173 @@
+package disperser_test
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/disperser"
+	"github.com/stretchr/testify/assert"
+)
+
+var testBlobKey = disperser.BlobKey{
+	BlobHash:     strings.Repeat("ab", 32),
+	MetadataHash: "1234abcd",
+}
+
+func TestParseBlobKey(t *testing.T) {
+	// Both the versioned request IDs and the legacy ones are accepted
+	for _, key := range []string{string(testBlobKey.RequestID()), testBlobKey.String()} {
+		parsed, err := disperser.ParseBlobKey(key)
+		assert.NoError(t, err, key)
+		assert.Equal(t, testBlobKey, parsed)
+	}
+	assert.True(t, strings.HasPrefix(string(testBlobKey.RequestID()), "v1:"))
+
+	legacy := testBlobKey.String()
+	for _, key := range []string{
+		"",
+		"-",
+		"v1:",
+		legacy[:10],
+		legacy[:2*32],
+		legacy[:2*32+1],
+		legacy[1:],
+		legacy + "-1234",
+		strings.ToUpper(testBlobKey.BlobHash) + "-1234",
+		"v2:" + legacy,
+		"v1:v1:" + legacy,
+		testBlobKey.BlobHash + "-\xff\xfe",
+		"\xc3\x28" + legacy,
+	} {
+		_, err := disperser.ParseBlobKey(key)
+		assert.ErrorIs(t, err, disperser.ErrInvalidRequestID, key)
+	}
+}
+
+func FuzzParseBlobKey(f *testing.F) {
+	f.Add(string(testBlobKey.RequestID()))
+	f.Add(testBlobKey.String())
+	f.Add("")
+	f.Add("v1:-")
+	f.Add("\xff")
+	f.Fuzz(func(t *testing.T, key string) {
+		parsed, err := disperser.ParseBlobKey(key)
+		if err != nil {
+			assert.ErrorIs(t, err, disperser.ErrInvalidRequestID)
+			return
+		}
+		// A parsed key round-trips through both formats
+		reparsed, err := disperser.ParseBlobKey(string(parsed.RequestID()))
+		assert.NoError(t, err)
+		assert.Equal(t, parsed, reparsed)
+		reparsed, err = disperser.ParseBlobKey(parsed.String())
+		assert.NoError(t, err)
+		assert.Equal(t, parsed, reparsed)
+	})
+}
+
+func TestQuorumStatuses(t *testing.T) {
+	confirmationInfo := &disperser.ConfirmationInfo{
+		QuorumResults: map[core.QuorumID]*core.QuorumResult{
+			0: {QuorumID: 0, PercentSigned: 80},
+			1: {QuorumID: 1, PercentSigned: 79},
+		},
+		BlobQuorumInfos: []*core.BlobQuorumInfo{
+			{SecurityParam: core.SecurityParam{QuorumID: 1, QuorumThreshold: 80}},
+			{SecurityParam: core.SecurityParam{QuorumID: 0, QuorumThreshold: 80}},
+			{SecurityParam: core.SecurityParam{QuorumID: 2, QuorumThreshold: 80}},
+		},
+	}
+
+	// The statuses follow the order of the blob quorums, and a quorum without result isn't confirmed
+	assert.Equal(t, []*disperser.QuorumStatus{
+		{QuorumID: 1, QuorumThreshold: 80, PercentSigned: 79, Confirmed: false},
+		{QuorumID: 0, QuorumThreshold: 80, PercentSigned: 80, Confirmed: true},
+		{QuorumID: 2, QuorumThreshold: 80, PercentSigned: 0, Confirmed: false},
+	}, confirmationInfo.QuorumStatuses())
+}
+
+func TestOperatorStateSnapshotSerialization(t *testing.T) {
+	operatorID := core.OperatorID{1, 2, 3}
+	snapshot := &disperser.OperatorStateSnapshot{
+		ReferenceBlockNumber: 10,
+		QuantizationFactor:   1,
+		OverprovisionPercent: 25,
+		State: &core.OperatorState{
+			Operators: map[core.QuorumID]map[core.OperatorID]*core.OperatorInfo{
+				0: {operatorID: {Stake: big.NewInt(100), Index: 0}},
+			},
+			Totals: map[core.QuorumID]*core.OperatorInfo{
+				0: {Stake: big.NewInt(100), Index: 1},
+			},
+			BlockNumber: 10,
+		},
+		OperatorVersions: map[core.OperatorID]core.NodeVersion{
+			operatorID: {Version: "0.6.0", Features: core.FeatureBundleEncodingV1},
+		},
+	}
+
+	data, err := snapshot.Serialize()
+	assert.NoError(t, err)
+	deserialized, err := disperser.DeserializeOperatorStateSnapshot(data)
+	assert.NoError(t, err)
+	assert.Equal(t, snapshot, deserialized)
+
+	_, err = disperser.DeserializeOperatorStateSnapshot([]byte("invalid"))
+	assert.Error(t, err)
+}
+
+func TestBatchSignatureSerialization(t *testing.T) {
+	keys, err := core.GenRandomBlsKeys()
+	assert.NoError(t, err)
+	signature := &disperser.BatchSignature{
+		QuorumIDs:             []core.QuorumID{0, 2},
+		QuorumAggPubKeys:      []*core.G1Point{keys.GetPubKeyG1(), keys.GetPubKeyG1()},
+		AggPubKey:             keys.GetPubKeyG2(),
+		AggSignature:          keys.SignMessage([32]byte{1, 2, 3}),
+		NonSignerPubKeyHashes: []core.OperatorID{keys.GetPubKeyG1().GetOperatorID()},
+	}
+
+	data, err := signature.Serialize()
+	assert.NoError(t, err)
+	deserialized, err := disperser.DeserializeBatchSignature(data)
+	assert.NoError(t, err)
+	assert.Equal(t, signature, deserialized)
+
+	_, err = disperser.DeserializeBatchSignature([]byte("invalid"))
+	assert.Error(t, err)
+}
+
+func TestTrimBlobPadding(t *testing.T) {
+	metadata := &disperser.BlobMetadata{
+		BlobHash:     testBlobKey.BlobHash,
+		MetadataHash: testBlobKey.MetadataHash,
+		RequestMetadata: &disperser.RequestMetadata{
+			BlobSize: 5,
+		},
+	}
+
+	for _, data := range [][]byte{
+		[]byte("hello"),
+		append([]byte("hello"), 0),
+		append([]byte("hello"), make([]byte, 27)...),
+	} {
+		trimmed, err := metadata.TrimBlobPadding(data)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("hello"), trimmed)
+	}
+
+	for _, data := range [][]byte{
+		nil,
+		[]byte("hell"),
+		[]byte("hello!"),
+		append([]byte("hello"), 0, 0, 1),
+	} {
+		_, err := metadata.TrimBlobPadding(data)
+		assert.ErrorIs(t, err, disperser.ErrBlobIntegrity)
+	}
+
+	_, err := (&disperser.BlobMetadata{}).TrimBlobPadding([]byte("hello"))
+	assert.ErrorContains(t, err, "missing request metadata")
+}
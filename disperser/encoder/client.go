@@ -2,21 +2,34 @@ package encoder
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"time"
 
 	"github.com/Layr-Labs/eigenda/core"
 	"github.com/Layr-Labs/eigenda/disperser"
 	pb "github.com/Layr-Labs/eigenda/disperser/api/grpc/encoder"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
 )
 
+// ErrIncompleteReply is returned when the stream of an encoding reply ends before all the chunks were received
+var ErrIncompleteReply = errors.New("incomplete encoding reply")
+
+// maxUnaryReplySize is the max size of the reply of the encoders which don't stream their replies, as the whole
+// encoded blob comes in a single message
+const maxUnaryReplySize = 1024 * 1024 * 1024 // 1 GiB
+
 type client struct {
 	addr    string
 	timeout time.Duration
 }
 
+// NewEncoderClient creates a client of the encoder server at addr. Each request must complete within the timeout, if
+// it is positive.
 func NewEncoderClient(addr string, timeout time.Duration) (disperser.EncoderClient, error) {
 	return client{
 		addr:    addr,
@@ -25,10 +38,15 @@ func NewEncoderClient(addr string, timeout time.Duration) (disperser.EncoderClie
 }
 
 func (c client) EncodeBlob(ctx context.Context, data []byte, encodingParams core.EncodingParams) (*core.BlobCommitments, []*core.Chunk, error) {
+	if c.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+
 	conn, err := grpc.Dial(
 		c.addr,
 		grpc.WithTransportCredentials(insecure.NewCredentials()),
-		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(1024*1024*1024)), // 1 GiB
 	)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to dial encoder: %w", err)
@@ -36,36 +54,95 @@ func (c client) EncodeBlob(ctx context.Context, data []byte, encodingParams core
 	defer conn.Close()
 
 	encoder := pb.NewEncoderClient(conn)
-	reply, err := encoder.EncodeBlob(ctx, &pb.EncodeBlobRequest{
+	req := &pb.EncodeBlobRequest{
 		Data: data,
 		EncodingParams: &pb.EncodingParams{
 			ChunkLength: uint32(encodingParams.ChunkLength),
 			NumChunks:   uint32(encodingParams.NumChunks),
+			Layout:      uint32(encodingParams.Layout),
 		},
-	})
+	}
+	commitment, chunks, err := encodeBlobStream(ctx, encoder, req)
+	if status.Code(err) == codes.Unimplemented {
+		// The encoder predates the streamed replies
+		commitment, chunks, err = encodeBlobUnary(ctx, encoder, req)
+	}
 	if err != nil {
 		return nil, nil, err
 	}
+	if uint(len(chunks)) != encodingParams.NumChunks {
+		return nil, nil, fmt.Errorf("%w: received %d of %d chunks", ErrIncompleteReply, len(chunks), encodingParams.NumChunks)
+	}
+
+	blobCommitment, err := new(core.Commitment).Deserialize(commitment.GetCommitment())
+	if err != nil {
+		return nil, nil, err
+	}
+	lengthProof, err := new(core.Commitment).Deserialize(commitment.GetLengthProof())
+	if err != nil {
+		return nil, nil, err
+	}
+	return &core.BlobCommitments{
+		Commitment:  blobCommitment,
+		LengthProof: lengthProof,
+		Length:      uint(commitment.GetLength()),
+	}, chunks, nil
+}
 
-	commitment, err := new(core.Commitment).Deserialize(reply.GetCommitment().GetCommitment())
+// encodeBlobStream encodes the blob with the streamed replies. The error of an encoder which doesn't implement them
+// has the Unimplemented code, the stream failing before any frame is received.
+func encodeBlobStream(ctx context.Context, encoder pb.EncoderClient, req *pb.EncodeBlobRequest) (*pb.BlobCommitment, []*core.Chunk, error) {
+	stream, err := encoder.EncodeBlobStream(ctx, req)
 	if err != nil {
 		return nil, nil, err
 	}
-	lengthProof, err := new(core.Commitment).Deserialize(reply.GetCommitment().GetLengthProof())
+
+	var commitment *pb.BlobCommitment
+	chunks := make([]*core.Chunk, 0, req.GetEncodingParams().GetNumChunks())
+	for {
+		frame, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		if commitment == nil {
+			commitment = frame.GetCommitment()
+			if commitment == nil {
+				return nil, nil, fmt.Errorf("%w: missing commitment", ErrIncompleteReply)
+			}
+		}
+		for _, chunk := range frame.GetChunks() {
+			deserialized, err := new(core.Chunk).Deserialize(chunk)
+			if err != nil {
+				return nil, nil, err
+			}
+			chunks = append(chunks, deserialized)
+		}
+	}
+	if commitment == nil {
+		return nil, nil, fmt.Errorf("%w: missing commitment", ErrIncompleteReply)
+	}
+	return commitment, chunks, nil
+}
+
+// encodeBlobUnary encodes the blob with a single reply, whose size is only bounded by maxUnaryReplySize
+func encodeBlobUnary(ctx context.Context, encoder pb.EncoderClient, req *pb.EncodeBlobRequest) (*pb.BlobCommitment, []*core.Chunk, error) {
+	reply, err := encoder.EncodeBlob(ctx, req, grpc.MaxCallRecvMsgSize(maxUnaryReplySize))
 	if err != nil {
 		return nil, nil, err
 	}
+	if reply.GetCommitment() == nil {
+		return nil, nil, fmt.Errorf("%w: missing commitment", ErrIncompleteReply)
+	}
+
 	chunks := make([]*core.Chunk, len(reply.GetChunks()))
 	for i, chunk := range reply.GetChunks() {
-		deserialized, err := new(core.Chunk).Deserialize(chunk)
+		chunks[i], err = new(core.Chunk).Deserialize(chunk)
 		if err != nil {
 			return nil, nil, err
 		}
-		chunks[i] = deserialized
 	}
-	return &core.BlobCommitments{
-		Commitment:  commitment,
-		LengthProof: lengthProof,
-		Length:      uint(reply.GetCommitment().GetLength()),
-	}, chunks, nil
+	return reply.GetCommitment(), chunks, nil
 }
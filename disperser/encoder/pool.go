@@ -0,0 +1,144 @@
+package encoder
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/disperser"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+// ErrNoEncoderAvailable is returned when none of the encoders of a pool is healthy
+var ErrNoEncoderAvailable = errors.New("no encoder available")
+
+// Pool distributes the encoding requests across encoder servers in round-robin order, skipping the servers failing
+// their health checks. A request failing on a server because it is unavailable, overloaded or too slow is retried on
+// the next one. When no server can encode the blob, the request goes to the fallback encoder, if there is one.
+type Pool struct {
+	servers  []*poolServer
+	next     atomic.Uint64
+	fallback disperser.EncoderClient
+	logger   common.Logger
+}
+
+type poolServer struct {
+	addr    string
+	client  disperser.EncoderClient
+	healthy atomic.Bool
+}
+
+var _ disperser.EncoderClient = (*Pool)(nil)
+
+// NewPool creates a pool of the encoder servers at addrs, each request to a server being bounded by the timeout. The
+// fallback may be nil, in which case the pool fails the requests that no server could encode.
+func NewPool(addrs []string, timeout time.Duration, fallback disperser.EncoderClient, logger common.Logger) (*Pool, error) {
+	if len(addrs) == 0 && fallback == nil {
+		return nil, errors.New("no encoder address nor fallback encoder provided")
+	}
+
+	servers := make([]*poolServer, len(addrs))
+	for i, addr := range addrs {
+		client, err := NewEncoderClient(addr, timeout)
+		if err != nil {
+			return nil, err
+		}
+		servers[i] = &poolServer{addr: addr, client: client}
+		servers[i].healthy.Store(true)
+	}
+	return &Pool{
+		servers:  servers,
+		fallback: fallback,
+		logger:   logger,
+	}, nil
+}
+
+func (p *Pool) EncodeBlob(ctx context.Context, data []byte, encodingParams core.EncodingParams) (*core.BlobCommitments, []*core.Chunk, error) {
+	err := ErrNoEncoderAvailable
+	start := p.next.Add(1) - 1
+	for i := range p.servers {
+		server := p.servers[(start+uint64(i))%uint64(len(p.servers))]
+		if !server.healthy.Load() {
+			continue
+		}
+
+		commits, chunks, encodeErr := server.client.EncodeBlob(ctx, data, encodingParams)
+		if encodeErr == nil {
+			return commits, chunks, nil
+		}
+		if ctx.Err() != nil || !retryable(encodeErr) {
+			return nil, nil, encodeErr
+		}
+		if status.Code(encodeErr) == codes.Unavailable {
+			server.healthy.Store(false)
+		}
+		p.logger.Warn("encoding failed, trying the next encoder", "encoder", server.addr, "err", encodeErr)
+		err = encodeErr
+	}
+
+	if p.fallback != nil {
+		return p.fallback.EncodeBlob(ctx, data, encodingParams)
+	}
+	return nil, nil, err
+}
+
+// retryable returns whether a request failing with err may succeed on another server
+func retryable(err error) bool {
+	if errors.Is(err, ErrIncompleteReply) {
+		return true
+	}
+	switch status.Code(err) {
+	case codes.Unavailable, codes.ResourceExhausted, codes.DeadlineExceeded, codes.Canceled:
+		return true
+	}
+	return false
+}
+
+// Start checks the health of the servers every interval until the context is done, so that requests skip the
+// unhealthy servers and go back to them once they recover.
+func (p *Pool) Start(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				p.CheckHealth(ctx, interval)
+			}
+		}
+	}()
+}
+
+// CheckHealth updates the health of each server, failing the servers that don't report they are serving within the
+// timeout
+func (p *Pool) CheckHealth(ctx context.Context, timeout time.Duration) {
+	for _, server := range p.servers {
+		healthy := checkHealth(ctx, server.addr, timeout)
+		if server.healthy.Swap(healthy) != healthy {
+			p.logger.Info("encoder health changed", "encoder", server.addr, "healthy", healthy)
+		}
+	}
+}
+
+func checkHealth(ctx context.Context, addr string, timeout time.Duration) bool {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	conn, err := grpc.DialContext(ctx, addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return false
+	}
+	defer conn.Close()
+
+	reply, err := grpc_health_v1.NewHealthClient(conn).Check(ctx, &grpc_health_v1.HealthCheckRequest{})
+	return err == nil && reply.GetStatus() == grpc_health_v1.HealthCheckResponse_SERVING
+}
@@ -0,0 +1,150 @@
+package encoder
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common/healthcheck"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/disperser"
+	pb "github.com/Layr-Labs/eigenda/disperser/api/grpc/encoder"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+// startEncoderServer serves the encoder on a local port and returns its address
+func startEncoderServer(t *testing.T, encoder pb.EncoderServer) (string, *grpc.Server) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	gs := grpc.NewServer()
+	pb.RegisterEncoderServer(gs, encoder)
+	healthcheck.RegisterHealthServer(gs)
+	go func() {
+		_ = gs.Serve(listener)
+	}()
+	t.Cleanup(gs.Stop)
+	return listener.Addr().String(), gs
+}
+
+// dyingEncoder stops its server in the middle of each request, after sending the commitment
+type dyingEncoder struct {
+	pb.UnimplementedEncoderServer
+	gs       *grpc.Server
+	requests atomic.Int32
+}
+
+func (e *dyingEncoder) EncodeBlobStream(req *pb.EncodeBlobRequest, stream pb.Encoder_EncodeBlobStreamServer) error {
+	e.requests.Add(1)
+	if err := stream.Send(&pb.EncodeBlobFrame{Commitment: &pb.BlobCommitment{}}); err != nil {
+		return err
+	}
+	go e.gs.Stop()
+	<-stream.Context().Done()
+	return stream.Context().Err()
+}
+
+// unaryEncoder only implements the unary EncodeBlob, as the encoders predating the streamed replies
+type unaryEncoder struct {
+	pb.UnimplementedEncoderServer
+	server *Server
+}
+
+func (e *unaryEncoder) EncodeBlob(ctx context.Context, req *pb.EncodeBlobRequest) (*pb.EncodeBlobReply, error) {
+	return e.server.EncodeBlob(ctx, req)
+}
+
+func assertDecodes(t *testing.T, chunks []*core.Chunk, encodingParams core.EncodingParams) {
+	require.Len(t, chunks, int(encodingParams.NumChunks))
+	indices := make([]core.ChunkNumber, len(chunks))
+	for i := range indices {
+		indices[i] = core.ChunkNumber(i)
+	}
+	decoded, err := testEncoder.Decode(chunks, indices, encodingParams, uint64(len(gettysburgAddressBytes))+10)
+	require.NoError(t, err)
+	assert.Equal(t, gettysburgAddressBytes, bytes.TrimRight(decoded, "\x00"))
+}
+
+func TestEncodeBlobStream(t *testing.T) {
+	// Send each chunk in its own frame
+	frameSize := maxFrameSize
+	maxFrameSize = 1
+	t.Cleanup(func() { maxFrameSize = frameSize })
+
+	addr, _ := startEncoderServer(t, newEncoderTestServer(t))
+	client, err := NewEncoderClient(addr, 10*time.Second)
+	require.NoError(t, err)
+
+	testBlob, testEncodingParams := getTestData()
+	commits, chunks, err := client.EncodeBlob(context.Background(), testBlob.Data, testEncodingParams)
+	require.NoError(t, err)
+	assert.NotNil(t, commits.Commitment)
+	assertDecodes(t, chunks, testEncodingParams)
+}
+
+func TestEncodeBlobUnaryFallback(t *testing.T) {
+	addr, _ := startEncoderServer(t, &unaryEncoder{server: newEncoderTestServer(t)})
+	client, err := NewEncoderClient(addr, 10*time.Second)
+	require.NoError(t, err)
+
+	testBlob, testEncodingParams := getTestData()
+	commits, chunks, err := client.EncodeBlob(context.Background(), testBlob.Data, testEncodingParams)
+	require.NoError(t, err)
+	assert.NotNil(t, commits.Commitment)
+	assertDecodes(t, chunks, testEncodingParams)
+}
+
+func TestPoolEncoderDiesMidRequest(t *testing.T) {
+	dying := &dyingEncoder{}
+	dyingAddr, dyingServer := startEncoderServer(t, dying)
+	dying.gs = dyingServer
+	healthyAddr, _ := startEncoderServer(t, newEncoderTestServer(t))
+
+	pool, err := NewPool([]string{dyingAddr, healthyAddr}, 10*time.Second, nil, logger)
+	require.NoError(t, err)
+
+	// The first request goes to the dying encoder and is retried on the healthy one
+	testBlob, testEncodingParams := getTestData()
+	_, chunks, err := pool.EncodeBlob(context.Background(), testBlob.Data, testEncodingParams)
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), dying.requests.Load())
+	assertDecodes(t, chunks, testEncodingParams)
+
+	// The dead encoder doesn't receive requests anymore
+	pool.CheckHealth(context.Background(), time.Second)
+	assert.False(t, pool.servers[0].healthy.Load())
+	assert.True(t, pool.servers[1].healthy.Load())
+	for i := 0; i < 2; i++ {
+		_, _, err = pool.EncodeBlob(context.Background(), testBlob.Data, testEncodingParams)
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, int32(1), dying.requests.Load())
+}
+
+func TestPoolFallback(t *testing.T) {
+	// Nothing listens on the address once the listener is closed
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := listener.Addr().String()
+	require.NoError(t, listener.Close())
+
+	testBlob, testEncodingParams := getTestData()
+	pool, err := NewPool([]string{addr}, time.Second, nil, logger)
+	require.NoError(t, err)
+	_, _, err = pool.EncodeBlob(context.Background(), testBlob.Data, testEncodingParams)
+	assert.Error(t, err)
+
+	pool, err = NewPool([]string{addr}, time.Second, disperser.NewLocalEncoderClient(testEncoder), logger)
+	require.NoError(t, err)
+	_, chunks, err := pool.EncodeBlob(context.Background(), testBlob.Data, testEncodingParams)
+	require.NoError(t, err)
+	assertDecodes(t, chunks, testEncodingParams)
+
+	_, err = NewPool(nil, time.Second, nil, logger)
+	assert.Error(t, err)
+}
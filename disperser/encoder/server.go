@@ -13,9 +13,15 @@ import (
 	"github.com/Layr-Labs/eigenda/disperser"
 	pb "github.com/Layr-Labs/eigenda/disperser/api/grpc/encoder"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
 )
 
+// maxFrameSize is the maximum size of the chunks sent in a frame of a streamed reply. It leaves room under the default
+// 4 MiB gRPC message limit for the commitment and the framing.
+var maxFrameSize = 3 * 1024 * 1024
+
 // TODO: Add EncodeMetrics
 type Server struct {
 	pb.UnimplementedEncoderServer
@@ -48,7 +54,7 @@ func (s *Server) EncodeBlob(ctx context.Context, req *pb.EncodeBlobRequest) (*pb
 	default:
 		s.metrics.IncrementRateLimitedBlobRequestNum()
 		s.logger.Warn("rate limiting as request pool is full", "requestPoolSize", s.config.RequestPoolSize, "maxConcurrentRequests", s.config.MaxConcurrentRequests)
-		return nil, fmt.Errorf("too many requests")
+		return nil, status.Error(codes.ResourceExhausted, "too many requests")
 	}
 	s.runningRequests <- struct{}{}
 	defer s.popRequest()
@@ -67,6 +73,30 @@ func (s *Server) EncodeBlob(ctx context.Context, req *pb.EncodeBlobRequest) (*pb
 	return reply, err
 }
 
+// EncodeBlobStream encodes the blob and sends the reply in frames: the first one holds the commitment and the chunks
+// follow, at most maxFrameSize bytes of them per frame.
+func (s *Server) EncodeBlobStream(req *pb.EncodeBlobRequest, stream pb.Encoder_EncodeBlobStreamServer) error {
+	reply, err := s.EncodeBlob(stream.Context(), req)
+	if err != nil {
+		return err
+	}
+
+	frame := &pb.EncodeBlobFrame{Commitment: reply.GetCommitment()}
+	frameSize := 0
+	for _, chunk := range reply.GetChunks() {
+		if frameSize > 0 && frameSize+len(chunk) > maxFrameSize {
+			if err := stream.Send(frame); err != nil {
+				return err
+			}
+			frame = &pb.EncodeBlobFrame{}
+			frameSize = 0
+		}
+		frame.Chunks = append(frame.Chunks, chunk)
+		frameSize += len(chunk)
+	}
+	return stream.Send(frame)
+}
+
 func (s *Server) popRequest() {
 	<-s.requestPool
 	<-s.runningRequests
@@ -79,6 +109,7 @@ func (s *Server) handleEncoding(ctx context.Context, req *pb.EncodeBlobRequest)
 	var encodingParams = core.EncodingParams{
 		ChunkLength: uint(req.EncodingParams.ChunkLength),
 		NumChunks:   uint(req.EncodingParams.NumChunks),
+		Layout:      core.BlobLayout(req.EncodingParams.Layout),
 	}
 
 	commits, chunks, err := s.coreEncoder.Encode(req.Data, encodingParams)
@@ -77,7 +77,7 @@ func getTestData() (core.Blob, core.EncodingParams) {
 		log.Fatalf("failed to get operator state: %s", err)
 	}
 	coordinator := &core.StdAssignmentCoordinator{}
-	_, info, err := coordinator.GetAssignments(operatorState, quorumID, quantizationFactor)
+	_, info, err := coordinator.GetAssignments(operatorState, quorumID, quantizationFactor, 0)
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -135,7 +135,7 @@ func TestEncodeBlob(t *testing.T) {
 	}
 
 	maxInputSize := uint64(len(gettysburgAddressBytes)) + 10
-	decoded, err := testEncoder.Decode(chunksData, indices, testEncodingParams, maxInputSize)
+	decoded, err := testEncoder.Decode(chunksData[:len(indices)], indices, testEncodingParams, maxInputSize)
 	assert.Nil(t, err)
 	recovered := bytes.TrimRight(decoded, "\x00")
 	assert.Equal(t, recovered, gettysburgAddressBytes)
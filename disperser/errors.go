@@ -4,4 +4,80 @@ import "errors"
 
 var (
 	ErrBlobNotFound = errors.New("blob not found")
+	// ErrBlobAlreadyConfirmed is returned when confirming a blob which is no longer processing, e.g. because another
+	// batcher confirmed it first
+	ErrBlobAlreadyConfirmed = errors.New("blob is already confirmed")
+	// ErrBlobIntegrity is returned when the stored blob content doesn't match its metadata, e.g. a truncated object
+	ErrBlobIntegrity = errors.New("blob content does not match its metadata")
+	// ErrInvalidRequestID is returned when parsing a malformed request ID or blob key
+	ErrInvalidRequestID = errors.New("invalid request ID")
+	// ErrOperatorStateNotFound is returned when no operator state snapshot was stored for a batch
+	ErrOperatorStateNotFound = errors.New("operator state not found")
+	// ErrBatchCostNotFound is returned when no cost was recorded for a batch
+	ErrBatchCostNotFound = errors.New("batch cost not found")
+	// ErrBatchSignatureNotFound is returned when no signature was recorded for a batch
+	ErrBatchSignatureNotFound = errors.New("batch signature not found")
+	// ErrBlobExpired is returned when extending the retention of a blob which already expired
+	ErrBlobExpired = errors.New("blob has expired")
+	// ErrBlobExpiryChanged is returned when extending the retention of a blob whose expiry was changed concurrently
+	ErrBlobExpiryChanged = errors.New("blob expiry was changed concurrently")
+	// ErrBlobStateChanged is returned when re-driving a blob which is no longer processing, or was retried concurrently
+	ErrBlobStateChanged = errors.New("blob state was changed concurrently")
+	// ErrBlobNotResubmittable is returned when resubmitting a blob which is still processing or was already confirmed
+	ErrBlobNotResubmittable = errors.New("blob can't be resubmitted")
+)
+
+// ErrorDomain is the domain of the ErrorInfo details of the errors returned by the disperser API
+const ErrorDomain = "disperser.eigenda.xyz"
+
+// Reasons of the ErrorInfo details of the errors returned by the disperser API
+const (
+	// ReasonInvalidSecurityParams is the reason of the requests with missing, duplicate or invalid security params
+	ReasonInvalidSecurityParams = "INVALID_SECURITY_PARAMS"
+	// ReasonTooManyQuorums is the reason of the dispersals to more quorums than the max number of quorums per blob
+	ReasonTooManyQuorums = "TOO_MANY_QUORUMS"
+	// ReasonSecurityPolicyViolation is the reason of the dispersals whose security params are out of the bounds of the
+	// security policy of the disperser
+	ReasonSecurityPolicyViolation = "SECURITY_POLICY_VIOLATION"
+	// ReasonInvalidQuorum is the reason of the requests for a quorum which doesn't exist onchain
+	ReasonInvalidQuorum = "INVALID_QUORUM"
+	// ReasonBlobTooLarge is the reason of the dispersals of blobs over the max blob size
+	ReasonBlobTooLarge = "BLOB_TOO_LARGE"
+	// ReasonEmptyBlob is the reason of the dispersals of empty blobs
+	ReasonEmptyBlob = "EMPTY_BLOB"
+	// ReasonInvalidBlobLayout is the reason of the dispersals whose data doesn't conform to the requested layout, or
+	// with an unknown layout
+	ReasonInvalidBlobLayout = "INVALID_BLOB_LAYOUT"
+	// ReasonInvalidNamespace is the reason of the dispersals with a namespace too long or with invalid characters
+	ReasonInvalidNamespace = "INVALID_NAMESPACE"
+	// ReasonInvalidMetadata is the reason of the dispersals with too many labels, labels too large or an empty label key
+	ReasonInvalidMetadata = "INVALID_METADATA"
+	// ReasonInvalidContentType is the reason of the dispersals with a content type too long or which isn't a media type
+	ReasonInvalidContentType = "INVALID_CONTENT_TYPE"
+	// ReasonInvalidSubmitterKey is the reason of the dispersals of private blobs without a valid submitter public key,
+	// or of public blobs with one
+	ReasonInvalidSubmitterKey = "INVALID_SUBMITTER_KEY"
+	// ReasonRetrievalUnauthorized is the reason of the retrievals of a private blob which isn't finalized, without a
+	// fresh signature of its submitter
+	ReasonRetrievalUnauthorized = "RETRIEVAL_UNAUTHORIZED"
+	// ReasonInvalidRequestID is the reason of the requests with a missing or malformed request ID
+	ReasonInvalidRequestID = "INVALID_REQUEST_ID"
+	// ReasonInvalidBatchHeaderHash is the reason of the requests with a batch header hash which isn't 32 bytes
+	ReasonInvalidBatchHeaderHash = "INVALID_BATCH_HEADER_HASH"
+	// ReasonInvalidPageToken is the reason of the requests with a page token which wasn't returned by the disperser
+	ReasonInvalidPageToken = "INVALID_PAGE_TOKEN"
+	// ReasonInvalidRetentionExtension is the reason of the retention extensions which aren't positive
+	ReasonInvalidRetentionExtension = "INVALID_RETENTION_EXTENSION"
+	// ReasonSystemRateLimit is the reason of the dispersals rejected by the rate limit shared by all the accounts
+	ReasonSystemRateLimit = "SYSTEM_RATE_LIMIT"
+	// ReasonAccountRateLimit is the reason of the dispersals rejected by the rate limit of the account
+	ReasonAccountRateLimit = "ACCOUNT_RATE_LIMIT"
+	// ReasonDailyQuotaExceeded is the reason of the dispersals rejected because the account dispersed its daily quota
+	ReasonDailyQuotaExceeded = "DAILY_QUOTA_EXCEEDED"
+	// ReasonOverloaded is the reason of the dispersals shed because the disperser is processing as many requests as it
+	// can and its wait queue is full
+	ReasonOverloaded = "OVERLOADED"
+	// ReasonIPRateLimit is the reason of the requests rejected because their source IP sent more requests per second
+	// than allowed, regardless of their size
+	ReasonIPRateLimit = "IP_RATE_LIMIT"
 )
@@ -0,0 +1,166 @@
+package disperser
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/Layr-Labs/eigenda/core"
+	gcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// confirmationInfoJSON is the JSON encoding of a ConfirmationInfo, with the hashes and bytes in hex and the quorum
+// results as the percentage of the stake of each quorum that signed. Its field names are read by the diagnostics
+// tooling, so they must not change.
+type confirmationInfoJSON struct {
+	BatchHeaderHash         gcommon.Hash            `json:"batch_header_hash"`
+	BlobIndex               uint32                  `json:"blob_index"`
+	BlobCount               uint32                  `json:"blob_count"`
+	SignatoryRecordHash     gcommon.Hash            `json:"signatory_record_hash"`
+	ReferenceBlockNumber    uint32                  `json:"reference_block_number"`
+	BatchRoot               hexutil.Bytes           `json:"batch_root"`
+	BlobInclusionProof      hexutil.Bytes           `json:"blob_inclusion_proof"`
+	BlobCommitment          *core.BlobCommitments   `json:"blob_commitment"`
+	BatchID                 uint32                  `json:"batch_id"`
+	ConfirmationTxnHash     gcommon.Hash            `json:"confirmation_txn_hash"`
+	ConfirmationBlockNumber uint32                  `json:"confirmation_block_number"`
+	Fee                     hexutil.Bytes           `json:"fee"`
+	QuorumResults           map[core.QuorumID]uint8 `json:"quorum_results"`
+	BlobQuorumInfos         []*core.BlobQuorumInfo  `json:"blob_quorum_infos"`
+}
+
+func (i ConfirmationInfo) MarshalJSON() ([]byte, error) {
+	wire := confirmationInfoJSON{
+		BatchHeaderHash:         i.BatchHeaderHash,
+		BlobIndex:               i.BlobIndex,
+		BlobCount:               i.BlobCount,
+		SignatoryRecordHash:     i.SignatoryRecordHash,
+		ReferenceBlockNumber:    i.ReferenceBlockNumber,
+		BatchRoot:               i.BatchRoot,
+		BlobInclusionProof:      i.BlobInclusionProof,
+		BlobCommitment:          i.BlobCommitment,
+		BatchID:                 i.BatchID,
+		ConfirmationTxnHash:     i.ConfirmationTxnHash,
+		ConfirmationBlockNumber: i.ConfirmationBlockNumber,
+		Fee:                     i.Fee,
+		BlobQuorumInfos:         i.BlobQuorumInfos,
+	}
+	if i.QuorumResults != nil {
+		wire.QuorumResults = make(map[core.QuorumID]uint8, len(i.QuorumResults))
+		for quorumID, result := range i.QuorumResults {
+			wire.QuorumResults[quorumID] = result.PercentSigned
+		}
+	}
+	return json.Marshal(wire)
+}
+
+func (i *ConfirmationInfo) UnmarshalJSON(data []byte) error {
+	var wire confirmationInfoJSON
+	if err := json.Unmarshal(data, &wire); err != nil {
+		return err
+	}
+	*i = ConfirmationInfo{
+		BatchHeaderHash:         wire.BatchHeaderHash,
+		BlobIndex:               wire.BlobIndex,
+		BlobCount:               wire.BlobCount,
+		SignatoryRecordHash:     wire.SignatoryRecordHash,
+		ReferenceBlockNumber:    wire.ReferenceBlockNumber,
+		BatchRoot:               wire.BatchRoot,
+		BlobInclusionProof:      wire.BlobInclusionProof,
+		BlobCommitment:          wire.BlobCommitment,
+		BatchID:                 wire.BatchID,
+		ConfirmationTxnHash:     wire.ConfirmationTxnHash,
+		ConfirmationBlockNumber: wire.ConfirmationBlockNumber,
+		Fee:                     wire.Fee,
+		BlobQuorumInfos:         wire.BlobQuorumInfos,
+	}
+	if wire.QuorumResults != nil {
+		i.QuorumResults = make(map[core.QuorumID]*core.QuorumResult, len(wire.QuorumResults))
+		for quorumID, percentSigned := range wire.QuorumResults {
+			i.QuorumResults[quorumID] = &core.QuorumResult{QuorumID: quorumID, PercentSigned: percentSigned}
+		}
+	}
+	return nil
+}
+
+// String formats the confirmation for the logs, leaving out the commitment and the inclusion proof
+func (i ConfirmationInfo) String() string {
+	percentSigned := make(map[core.QuorumID]uint8, len(i.QuorumResults))
+	for quorumID, result := range i.QuorumResults {
+		percentSigned[quorumID] = result.PercentSigned
+	}
+	return fmt.Sprintf("{batch_header_hash=%s blob_index=%d blob_count=%d reference_block_number=%d batch_id=%d confirmation_txn_hash=%s confirmation_block_number=%d quorum_results=%v blob_quorum_infos=%v}",
+		hexutil.Encode(i.BatchHeaderHash[:]), i.BlobIndex, i.BlobCount, i.ReferenceBlockNumber, i.BatchID, i.ConfirmationTxnHash.Hex(), i.ConfirmationBlockNumber, percentSigned, i.BlobQuorumInfos)
+}
+
+// blobMetadataJSON is the JSON encoding of a BlobMetadata, with the status by name
+type blobMetadataJSON struct {
+	BlobHash            BlobHash              `json:"blob_hash"`
+	MetadataHash        MetadataHash          `json:"metadata_hash"`
+	BlobStatus          string                `json:"blob_status"`
+	Expiry              uint64                `json:"expiry"`
+	NumRetries          uint                  `json:"num_retries"`
+	RequestMetadata     *RequestMetadata      `json:"request_metadata"`
+	ConfirmationInfo    *ConfirmationInfo     `json:"blob_confirmation_info"`
+	RetentionExtensions []*RetentionExtension `json:"retention_extensions"`
+}
+
+func (m BlobMetadata) MarshalJSON() ([]byte, error) {
+	if _, ok := enumStrings[m.BlobStatus]; !ok {
+		return nil, fmt.Errorf("unknown blob status %d", m.BlobStatus)
+	}
+	return json.Marshal(blobMetadataJSON{
+		BlobHash:            m.BlobHash,
+		MetadataHash:        m.MetadataHash,
+		BlobStatus:          m.BlobStatus.String(),
+		Expiry:              m.Expiry,
+		NumRetries:          m.NumRetries,
+		RequestMetadata:     m.RequestMetadata,
+		ConfirmationInfo:    m.ConfirmationInfo,
+		RetentionExtensions: m.RetentionExtensions,
+	})
+}
+
+func (m *BlobMetadata) UnmarshalJSON(data []byte) error {
+	var wire blobMetadataJSON
+	if err := json.Unmarshal(data, &wire); err != nil {
+		return err
+	}
+	status, err := parseBlobStatus(wire.BlobStatus)
+	if err != nil {
+		return err
+	}
+	*m = BlobMetadata{
+		BlobHash:            wire.BlobHash,
+		MetadataHash:        wire.MetadataHash,
+		BlobStatus:          status,
+		Expiry:              wire.Expiry,
+		NumRetries:          wire.NumRetries,
+		RequestMetadata:     wire.RequestMetadata,
+		ConfirmationInfo:    wire.ConfirmationInfo,
+		RetentionExtensions: wire.RetentionExtensions,
+	}
+	return nil
+}
+
+// String formats the metadata for the logs, leaving out the commitments of the blob
+func (m *BlobMetadata) String() string {
+	request := "<nil>"
+	if m.RequestMetadata != nil {
+		request = fmt.Sprintf("{account_id=%s namespace=%q blob_size=%d requested_at=%d security_params=%v}", m.RequestMetadata.AccountID, m.RequestMetadata.Namespace, m.RequestMetadata.BlobSize, m.RequestMetadata.RequestedAt, m.RequestMetadata.SecurityParams)
+	}
+	confirmation := "<nil>"
+	if m.ConfirmationInfo != nil {
+		confirmation = m.ConfirmationInfo.String()
+	}
+	return fmt.Sprintf("{blob_key=%s blob_status=%s expiry=%d num_retries=%d request_metadata=%s confirmation_info=%s}", m.GetBlobKey().String(), m.BlobStatus, m.Expiry, m.NumRetries, request, confirmation)
+}
+
+func parseBlobStatus(name string) (BlobStatus, error) {
+	for status, statusName := range enumStrings {
+		if statusName == name {
+			return status, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown blob status %q", name)
+}
@@ -0,0 +1,90 @@
+package disperser_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/disperser"
+	gcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// The JSON and the log format of the blob metadata are read by the diagnostics tooling, so these goldens pin them
+const (
+	confirmationInfoJSON   = `{"batch_header_hash":"0x0102030000000000000000000000000000000000000000000000000000000000","blob_index":4,"blob_count":5,"signatory_record_hash":"0x0607000000000000000000000000000000000000000000000000000000000000","reference_block_number":100,"batch_root":"0xaabb","blob_inclusion_proof":"0xcc","blob_commitment":null,"batch_id":9,"confirmation_txn_hash":"0x0000000000000000000000000000000000000000000000000000000000001234","confirmation_block_number":150,"fee":"0x00","quorum_results":{"0":100,"1":80},"blob_quorum_infos":[{"quorum_id":0,"adversary_threshold":50,"quorum_threshold":100,"quorum_rate":0,"quantization_factor":1,"overprovision_percent":0,"encoded_blob_length":64}]}`
+	blobMetadataJSON       = `{"blob_hash":"abababababababababababababababababababababababababababababababab","metadata_hash":"1234abcd","blob_status":"Confirmed","expiry":1700000000,"num_retries":1,"request_metadata":{"commitments":{"commitment":null,"length_proof":null,"length":0},"security_params":[{"quorum_id":0,"adversary_threshold":50,"quorum_threshold":100,"quorum_rate":0}],"account_id":"ip:1.2.3.4","namespace":"rollup","blob_size":1000,"requested_at":1699999000000000000},"blob_confirmation_info":` + confirmationInfoJSON + `,"retention_extensions":null}`
+	confirmationInfoString = `{batch_header_hash=0x0102030000000000000000000000000000000000000000000000000000000000 blob_index=4 blob_count=5 reference_block_number=100 batch_id=9 confirmation_txn_hash=0x0000000000000000000000000000000000000000000000000000000000001234 confirmation_block_number=150 quorum_results=map[0:100 1:80] blob_quorum_infos=[{quorum_id=0 adversary_threshold=50 quorum_threshold=100 quorum_rate=0 quantization_factor=1 overprovision_percent=0 encoded_blob_length=64}]}`
+	blobMetadataString     = `{blob_key=abababababababababababababababababababababababababababababababab-1234abcd blob_status=Confirmed expiry=1700000000 num_retries=1 request_metadata={account_id=ip:1.2.3.4 namespace="rollup" blob_size=1000 requested_at=1699999000000000000 security_params=[{quorum_id=0 adversary_threshold=50 quorum_threshold=100 quorum_rate=0}]} confirmation_info=` + confirmationInfoString + `}`
+)
+
+func makeTestConfirmationInfo() *disperser.ConfirmationInfo {
+	return &disperser.ConfirmationInfo{
+		BatchHeaderHash:         [32]byte{1, 2, 3},
+		BlobIndex:               4,
+		BlobCount:               5,
+		SignatoryRecordHash:     [32]byte{6, 7},
+		ReferenceBlockNumber:    100,
+		BatchRoot:               []byte{0xaa, 0xbb},
+		BlobInclusionProof:      []byte{0xcc},
+		BatchID:                 9,
+		ConfirmationTxnHash:     gcommon.HexToHash("0x1234"),
+		ConfirmationBlockNumber: 150,
+		Fee:                     []byte{0},
+		QuorumResults: map[core.QuorumID]*core.QuorumResult{
+			0: {QuorumID: 0, PercentSigned: 100},
+			1: {QuorumID: 1, PercentSigned: 80},
+		},
+		BlobQuorumInfos: []*core.BlobQuorumInfo{{
+			SecurityParam:      core.SecurityParam{QuorumID: 0, AdversaryThreshold: 50, QuorumThreshold: 100},
+			QuantizationFactor: 1,
+			EncodedBlobLength:  64,
+		}},
+	}
+}
+
+func TestConfirmationInfoJSON(t *testing.T) {
+	info := makeTestConfirmationInfo()
+	data, err := json.Marshal(info)
+	require.NoError(t, err)
+	assert.Equal(t, confirmationInfoJSON, string(data))
+
+	var decoded disperser.ConfirmationInfo
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, *info, decoded)
+	assert.Equal(t, confirmationInfoString, info.String())
+}
+
+func TestBlobMetadataJSON(t *testing.T) {
+	metadata := &disperser.BlobMetadata{
+		BlobHash:     testBlobKey.BlobHash,
+		MetadataHash: testBlobKey.MetadataHash,
+		BlobStatus:   disperser.Confirmed,
+		Expiry:       1700000000,
+		NumRetries:   1,
+		RequestMetadata: &disperser.RequestMetadata{
+			BlobRequestHeader: core.BlobRequestHeader{
+				SecurityParams: []*core.SecurityParam{{QuorumID: 0, AdversaryThreshold: 50, QuorumThreshold: 100}},
+				AccountID:      "ip:1.2.3.4",
+				Namespace:      "rollup",
+			},
+			BlobSize:    1000,
+			RequestedAt: 1699999000000000000,
+		},
+		ConfirmationInfo: makeTestConfirmationInfo(),
+	}
+	data, err := json.Marshal(metadata)
+	require.NoError(t, err)
+	assert.Equal(t, blobMetadataJSON, string(data))
+
+	var decoded disperser.BlobMetadata
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, *metadata, decoded)
+	assert.Equal(t, blobMetadataString, metadata.String())
+
+	// The status is encoded by name
+	_, err = json.Marshal(&disperser.BlobMetadata{BlobStatus: disperser.BlobStatus(100)})
+	assert.ErrorContains(t, err, "unknown blob status 100")
+	assert.ErrorContains(t, json.Unmarshal([]byte(`{"blob_status":"Unknown"}`), &decoded), `unknown blob status "Unknown"`)
+}
@@ -2,6 +2,7 @@ package disperser
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 
@@ -15,25 +16,60 @@ import (
 type MetricsConfig struct {
 	HTTPPort      string
 	EnableMetrics bool
+	// NamespaceAllowlist contains the namespaces that are reported as their own metric label. Blobs from other namespaces
+	// are reported under OtherNamespace, which keeps the cardinality of the metrics bounded.
+	NamespaceAllowlist []string
 }
 
+// OtherNamespace is the namespace label of blobs whose namespace is not in the allowlist
+const OtherNamespace = "other"
+
 type Metrics struct {
 	registry *prometheus.Registry
 
 	NumBlobRequests *prometheus.CounterVec
 	BlobSize        *prometheus.GaugeVec
 	Latency         *prometheus.SummaryVec
+	// NumTrustedRequests counts the requests of trusted callers, which bypass the rate limits
+	NumTrustedRequests *prometheus.CounterVec
+	// ReceivedBytes counts the bytes of the request messages, as received (compressed) and once decompressed, by method
+	// and compressor
+	ReceivedBytes *prometheus.CounterVec
+	// QueuedRequests is the number of requests waiting for a slot of the admission control of their method
+	QueuedRequests *prometheus.GaugeVec
+	// ShedRequests counts the requests shed by the admission control, by method and reason
+	ShedRequests *prometheus.CounterVec
+	// IPRateLimited counts the requests and connections rejected because their source IP exceeded its rate, by kind and
+	// method
+	IPRateLimited *prometheus.CounterVec
+	// ClientIPFallbacks counts the requests whose client address is taken from the connection because the client IP
+	// header is missing or unparsable, by reason
+	ClientIPFallbacks *prometheus.CounterVec
+	// FallbackReads counts the objects read from the replicas of the bucket in other regions, by region
+	FallbackReads *prometheus.CounterVec
+	// TransactorBreaker is 1 for the current state of the circuit breaker of the chain calls, and 0 for the others
+	TransactorBreaker *prometheus.GaugeVec
+	// StatusEventsDropped counts the blob status events which weren't published, by reason
+	StatusEventsDropped *prometheus.CounterVec
+
+	namespaces map[string]struct{}
 
-	httpPort string
-	logger   common.Logger
+	httpPort   string
+	httpServer *http.Server
+	logger     common.Logger
 }
 
-func NewMetrics(httpPort string, logger common.Logger) *Metrics {
+func NewMetrics(httpPort string, namespaceAllowlist []string, logger common.Logger) *Metrics {
 	namespace := "eigenda_disperser"
 	reg := prometheus.NewRegistry()
 	reg.MustRegister(collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}))
 	reg.MustRegister(collectors.NewGoCollector())
 
+	namespaces := make(map[string]struct{}, len(namespaceAllowlist))
+	for _, namespace := range namespaceAllowlist {
+		namespaces[namespace] = struct{}{}
+	}
+
 	metrics := &Metrics{
 		NumBlobRequests: promauto.With(reg).NewCounterVec(
 			prometheus.CounterOpts{
@@ -41,7 +77,7 @@ func NewMetrics(httpPort string, logger common.Logger) *Metrics {
 				Name:      "requests_total",
 				Help:      "the number of blob requests",
 			},
-			[]string{"status", "quorum", "method"},
+			[]string{"status", "quorum", "namespace", "method"},
 		),
 		BlobSize: promauto.With(reg).NewGaugeVec(
 			prometheus.GaugeOpts{
@@ -49,7 +85,7 @@ func NewMetrics(httpPort string, logger common.Logger) *Metrics {
 				Name:      "blob_size_bytes",
 				Help:      "the size of the blob in bytes",
 			},
-			[]string{"status", "quorum", "method"},
+			[]string{"status", "quorum", "namespace", "method"},
 		),
 		Latency: promauto.With(reg).NewSummaryVec(
 			prometheus.SummaryOpts{
@@ -60,9 +96,82 @@ func NewMetrics(httpPort string, logger common.Logger) *Metrics {
 			},
 			[]string{"method"},
 		),
-		registry: reg,
-		httpPort: httpPort,
-		logger:   logger,
+		NumTrustedRequests: promauto.With(reg).NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "trusted_requests_total",
+				Help:      "the number of requests from trusted callers, which bypass the rate limits",
+			},
+			[]string{"reason", "method"},
+		),
+		ReceivedBytes: promauto.With(reg).NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "received_bytes_total",
+				Help:      "the number of bytes of the request messages, compressed as received and decompressed",
+			},
+			[]string{"type", "compression", "method"},
+		),
+		QueuedRequests: promauto.With(reg).NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "queued_requests",
+				Help:      "the number of requests waiting to be admitted",
+			},
+			[]string{"method"},
+		),
+		ShedRequests: promauto.With(reg).NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "shed_requests_total",
+				Help:      "the number of requests shed because the server was at capacity",
+			},
+			[]string{"reason", "method"},
+		),
+		IPRateLimited: promauto.With(reg).NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "ip_rate_limited_total",
+				Help:      "the number of requests and connections rejected because their source IP exceeded its rate",
+			},
+			[]string{"kind", "method"},
+		),
+		ClientIPFallbacks: promauto.With(reg).NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "client_ip_fallbacks_total",
+				Help:      "the number of requests whose client address is taken from the connection instead of the client IP header",
+			},
+			[]string{"reason"},
+		),
+		FallbackReads: promauto.With(reg).NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "s3_fallback_reads_total",
+				Help:      "number of objects read from the replicas of the bucket in other regions",
+			},
+			[]string{"region"},
+		),
+		StatusEventsDropped: promauto.With(reg).NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "status_events_dropped_total",
+				Help:      "number of blob status events dropped instead of being published",
+			},
+			[]string{"reason"},
+		),
+		TransactorBreaker: promauto.With(reg).NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "transactor_breaker_state",
+				Help:      "state of the circuit breaker of the chain calls, 1 for the current state",
+			},
+			[]string{"state"},
+		),
+		namespaces: namespaces,
+		registry:   reg,
+		httpPort:   httpPort,
+		logger:     logger,
 	}
 	return metrics
 }
@@ -73,83 +182,197 @@ func (g *Metrics) ObserveLatency(method string, latencyMs float64) {
 }
 
 // IncrementSuccessfulBlobRequestNum increments the number of successful blob requests
-func (g *Metrics) IncrementSuccessfulBlobRequestNum(quorum string, method string) {
+func (g *Metrics) IncrementSuccessfulBlobRequestNum(quorum string, namespace string, method string) {
 	g.NumBlobRequests.With(prometheus.Labels{
-		"status": "success",
-		"quorum": quorum,
-		"method": method,
+		"status":    "success",
+		"quorum":    quorum,
+		"namespace": g.namespaceLabel(namespace),
+		"method":    method,
 	}).Inc()
 }
 
 // HandleSuccessfulRequest updates the number of successful blob requests and the size of the blob
-func (g *Metrics) HandleSuccessfulRequest(quorum string, blobBytes int, method string) {
-	g.IncrementSuccessfulBlobRequestNum(quorum, method)
+func (g *Metrics) HandleSuccessfulRequest(quorum string, namespace string, blobBytes int, method string) {
+	g.IncrementSuccessfulBlobRequestNum(quorum, namespace, method)
 	g.BlobSize.With(prometheus.Labels{
-		"status": "success",
-		"quorum": quorum,
-		"method": method,
+		"status":    "success",
+		"quorum":    quorum,
+		"namespace": g.namespaceLabel(namespace),
+		"method":    method,
 	}).Add(float64(blobBytes))
 }
 
+// IncrementTrustedRequestNum increments the number of requests which bypassed the rate limits because the caller is
+// trusted for the given reason
+func (g *Metrics) IncrementTrustedRequestNum(reason string, method string) {
+	g.NumTrustedRequests.With(prometheus.Labels{
+		"reason": reason,
+		"method": method,
+	}).Inc()
+}
+
+// AddReceivedBytes adds the compressed and decompressed sizes of a request message received with the given compressor,
+// or "identity" if it wasn't compressed
+func (g *Metrics) AddReceivedBytes(compressedBytes int, decompressedBytes int, compression string, method string) {
+	g.ReceivedBytes.With(prometheus.Labels{
+		"type":        "compressed",
+		"compression": compression,
+		"method":      method,
+	}).Add(float64(compressedBytes))
+	g.ReceivedBytes.With(prometheus.Labels{
+		"type":        "decompressed",
+		"compression": compression,
+		"method":      method,
+	}).Add(float64(decompressedBytes))
+}
+
 // IncrementFailedBlobRequestNum increments the number of failed blob requests
-func (g *Metrics) IncrementFailedBlobRequestNum(quorum string, method string) {
+func (g *Metrics) IncrementFailedBlobRequestNum(quorum string, namespace string, method string) {
 	g.NumBlobRequests.With(prometheus.Labels{
-		"status": "failed",
-		"quorum": quorum,
-		"method": method,
+		"status":    "failed",
+		"quorum":    quorum,
+		"namespace": g.namespaceLabel(namespace),
+		"method":    method,
 	}).Inc()
 }
 
 // HandleFailedRequest updates the number of failed requests and the size of the blob
-func (g *Metrics) HandleFailedRequest(quorum string, blobBytes int, method string) {
-	g.IncrementFailedBlobRequestNum(quorum, method)
+func (g *Metrics) HandleFailedRequest(quorum string, namespace string, blobBytes int, method string) {
+	g.IncrementFailedBlobRequestNum(quorum, namespace, method)
 	g.BlobSize.With(prometheus.Labels{
-		"status": "failed",
-		"quorum": quorum,
-		"method": method,
+		"status":    "failed",
+		"quorum":    quorum,
+		"namespace": g.namespaceLabel(namespace),
+		"method":    method,
 	}).Add(float64(blobBytes))
 }
 
 // HandleSystemRateLimitedRequest updates the number of system rate limited requests and the size of the blob
-func (g *Metrics) HandleSystemRateLimitedRequest(quorum string, blobBytes int, method string) {
+func (g *Metrics) HandleSystemRateLimitedRequest(quorum string, namespace string, blobBytes int, method string) {
 	g.NumBlobRequests.With(prometheus.Labels{
-		"status": "ratelimited-system",
-		"quorum": quorum,
-		"method": method,
+		"status":    "ratelimited-system",
+		"quorum":    quorum,
+		"namespace": g.namespaceLabel(namespace),
+		"method":    method,
 	}).Inc()
 	g.BlobSize.With(prometheus.Labels{
-		"status": "ratelimited-system",
-		"quorum": quorum,
-		"method": method,
+		"status":    "ratelimited-system",
+		"quorum":    quorum,
+		"namespace": g.namespaceLabel(namespace),
+		"method":    method,
 	}).Add(float64(blobBytes))
 }
 
 // HandleAccountRateLimitedRequest updates the number of account rate limited requests and the size of the blob
-func (g *Metrics) HandleAccountRateLimitedRequest(quorum string, blobBytes int, method string) {
+func (g *Metrics) HandleAccountRateLimitedRequest(quorum string, namespace string, blobBytes int, method string) {
 	g.NumBlobRequests.With(prometheus.Labels{
-		"status": "ratelimited-account",
-		"quorum": quorum,
-		"method": method,
+		"status":    "ratelimited-account",
+		"quorum":    quorum,
+		"namespace": g.namespaceLabel(namespace),
+		"method":    method,
 	}).Inc()
 	g.BlobSize.With(prometheus.Labels{
-		"status": "ratelimited-account",
-		"quorum": quorum,
-		"method": method,
+		"status":    "ratelimited-account",
+		"quorum":    quorum,
+		"namespace": g.namespaceLabel(namespace),
+		"method":    method,
 	}).Add(float64(blobBytes))
 }
 
+// HandleQuotaExceededRequest updates the number of requests rejected by the daily quotas and the size of the blob
+func (g *Metrics) HandleQuotaExceededRequest(quorum string, namespace string, blobBytes int, method string) {
+	g.NumBlobRequests.With(prometheus.Labels{
+		"status":    "quota-exceeded",
+		"quorum":    quorum,
+		"namespace": g.namespaceLabel(namespace),
+		"method":    method,
+	}).Inc()
+	g.BlobSize.With(prometheus.Labels{
+		"status":    "quota-exceeded",
+		"quorum":    quorum,
+		"namespace": g.namespaceLabel(namespace),
+		"method":    method,
+	}).Add(float64(blobBytes))
+}
+
+// AddQueuedRequests adds delta to the number of requests waiting to be admitted
+func (g *Metrics) AddQueuedRequests(delta int, method string) {
+	g.QueuedRequests.WithLabelValues(method).Add(float64(delta))
+}
+
+// IncrementShedRequestNum increments the number of requests shed for the given reason
+func (g *Metrics) IncrementShedRequestNum(reason string, method string) {
+	g.ShedRequests.With(prometheus.Labels{
+		"reason": reason,
+		"method": method,
+	}).Inc()
+}
+
+// IncrementIPRateLimitedNum increments the number of requests or connections rejected by the rate limit of their source
+// IP. The method is empty for the connections.
+func (g *Metrics) IncrementIPRateLimitedNum(kind string, method string) {
+	g.IPRateLimited.With(prometheus.Labels{
+		"kind":   kind,
+		"method": method,
+	}).Inc()
+}
+
+// IncrementClientIPFallbackNum increments the number of requests whose client address is taken from the connection
+// because the client IP header is missing or unparsable
+func (g *Metrics) IncrementClientIPFallbackNum(reason string) {
+	g.ClientIPFallbacks.WithLabelValues(reason).Inc()
+}
+
+// IncrementFallbackReads increments the number of objects read from the replica of the bucket in the region
+func (g *Metrics) IncrementFallbackReads(region string) {
+	g.FallbackReads.WithLabelValues(region).Inc()
+}
+
+// IncrementStatusEventsDropped adds the number of blob status events dropped for the reason
+func (g *Metrics) IncrementStatusEventsDropped(reason string, count int) {
+	g.StatusEventsDropped.WithLabelValues(reason).Add(float64(count))
+}
+
+// UpdateTransactorBreakerState reports the current state of the circuit breaker of the chain calls
+func (g *Metrics) UpdateTransactorBreakerState(state string) {
+	g.TransactorBreaker.Reset()
+	g.TransactorBreaker.WithLabelValues(state).Set(1)
+}
+
+// namespaceLabel returns the label reporting the namespace: the namespace itself if it is allowlisted, and OtherNamespace
+// otherwise. Requests without a namespace are reported with an empty label.
+func (g *Metrics) namespaceLabel(namespace string) string {
+	if namespace == "" {
+		return ""
+	}
+	if _, ok := g.namespaces[namespace]; ok {
+		return namespace
+	}
+	return OtherNamespace
+}
+
 // Start starts the metrics server
 func (g *Metrics) Start(ctx context.Context) {
 	g.logger.Info("Starting metrics server at ", "port", g.httpPort)
 	addr := fmt.Sprintf(":%s", g.httpPort)
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(
+		g.registry,
+		promhttp.HandlerOpts{},
+	))
+	g.httpServer = &http.Server{Addr: addr, Handler: mux}
 	go func() {
-		log := g.logger
-		mux := http.NewServeMux()
-		mux.Handle("/metrics", promhttp.HandlerFor(
-			g.registry,
-			promhttp.HandlerOpts{},
-		))
-		err := http.ListenAndServe(addr, mux)
-		log.Error("Prometheus server failed", "err", err)
+		err := g.httpServer.ListenAndServe()
+		if !errors.Is(err, http.ErrServerClosed) {
+			g.logger.Error("Prometheus server failed", "err", err)
+		}
 	}()
 }
+
+// Stop stops the metrics server started by Start
+func (g *Metrics) Stop(ctx context.Context) error {
+	if g.httpServer == nil {
+		return nil
+	}
+	return g.httpServer.Shutdown(ctx)
+}
@@ -0,0 +1,37 @@
+package disperser_test
+
+import (
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/common/logging"
+	"github.com/Layr-Labs/eigenda/disperser"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNamespaceLabelAllowlist(t *testing.T) {
+	logger, err := logging.GetLogger(logging.DefaultCLIConfig())
+	assert.NoError(t, err)
+	metrics := disperser.NewMetrics("9100", []string{"rollup-a"}, logger)
+
+	metrics.HandleSuccessfulRequest("0", "rollup-a", 100, "DisperseBlob")
+	metrics.HandleSuccessfulRequest("0", "rollup-b", 100, "DisperseBlob")
+	metrics.HandleSuccessfulRequest("0", "rollup-c", 100, "DisperseBlob")
+	metrics.HandleSuccessfulRequest("0", "", 100, "DisperseBlob")
+
+	requests := func(namespace string) float64 {
+		return testutil.ToFloat64(metrics.NumBlobRequests.With(prometheus.Labels{
+			"status":    "success",
+			"quorum":    "0",
+			"namespace": namespace,
+			"method":    "DisperseBlob",
+		}))
+	}
+
+	assert.Equal(t, float64(1), requests("rollup-a"))
+	assert.Equal(t, float64(2), requests(disperser.OtherNamespace))
+	assert.Equal(t, float64(1), requests(""))
+	// Namespaces outside of the allowlist never become labels
+	assert.Equal(t, 3, testutil.CollectAndCount(metrics.NumBlobRequests))
+}
@@ -19,11 +19,15 @@ func NewBatchConfirmer() *MockBatchConfirmer {
 	return &MockBatchConfirmer{}
 }
 
-func (b *MockBatchConfirmer) ConfirmBatch(ctx context.Context, header *core.BatchHeader, quorums map[core.QuorumID]*core.QuorumResult, sig *core.SignatureAggregation) (*types.Receipt, error) {
+func (b *MockBatchConfirmer) ConfirmBatch(ctx context.Context, header *core.BatchHeader, quorums map[core.QuorumID]*core.QuorumResult, sig *core.SignatureAggregation, replaced *types.Transaction) (*types.Receipt, *types.Transaction, error) {
 	args := b.Called()
 	var receipt *types.Receipt
 	if args.Get(0) != nil {
 		receipt = args.Get(0).(*types.Receipt)
 	}
-	return receipt, args.Error(1)
+	var sent *types.Transaction
+	if args.Get(1) != nil {
+		sent = args.Get(1).(*types.Transaction)
+	}
+	return receipt, sent, args.Error(2)
 }
@@ -2,6 +2,7 @@ package mock
 
 import (
 	"context"
+	"errors"
 
 	"github.com/Layr-Labs/eigenda/core"
 	"github.com/Layr-Labs/eigenda/core/mock"
@@ -10,6 +11,12 @@ import (
 
 type Dispatcher struct {
 	state *mock.PrivateOperatorState
+	// numNonSigners is the number of operators which don't sign the batches
+	numNonSigners int
+	// numExpired is the number of operators, after the non-signers, which refuse the batches past their deadline
+	numExpired int
+	// versions are the versions advertised by the operators along with their signatures
+	versions map[core.OperatorID]core.NodeVersion
 }
 
 var _ disperser.Dispatcher = (*Dispatcher)(nil)
@@ -20,6 +27,34 @@ func NewDispatcher(state *mock.PrivateOperatorState) disperser.Dispatcher {
 	}
 }
 
+// NewDispatcherWithNonSigners creates a dispatcher for which numNonSigners operators, chosen at random, don't sign each
+// batch
+func NewDispatcherWithNonSigners(state *mock.PrivateOperatorState, numNonSigners int) disperser.Dispatcher {
+	return &Dispatcher{
+		state:         state,
+		numNonSigners: numNonSigners,
+	}
+}
+
+// NewDispatcherWithExpiredBatches creates a dispatcher for which numNonSigners operators don't sign each batch, and
+// numExpired other operators refuse it past its dispersal deadline
+func NewDispatcherWithExpiredBatches(state *mock.PrivateOperatorState, numNonSigners, numExpired int) disperser.Dispatcher {
+	return &Dispatcher{
+		state:         state,
+		numNonSigners: numNonSigners,
+		numExpired:    numExpired,
+	}
+}
+
+// NewDispatcherWithVersions creates a dispatcher for which the operators advertise the given versions, the operators
+// missing from versions advertising no version
+func NewDispatcherWithVersions(state *mock.PrivateOperatorState, versions map[core.OperatorID]core.NodeVersion) disperser.Dispatcher {
+	return &Dispatcher{
+		state:    state,
+		versions: versions,
+	}
+}
+
 func (d *Dispatcher) DisperseBatch(ctx context.Context, state *core.IndexedOperatorState, blobs []core.EncodedBlob, header *core.BatchHeader) chan core.SignerMessage {
 	update := make(chan core.SignerMessage)
 	message, err := header.GetBatchHeaderHash()
@@ -34,12 +69,32 @@ func (d *Dispatcher) DisperseBatch(ctx context.Context, state *core.IndexedOpera
 	}
 
 	go func() {
+		numNonSigners, numExpired := 0, 0
 		for id, op := range d.state.PrivateOperators {
+			if numNonSigners < d.numNonSigners {
+				numNonSigners++
+				update <- core.SignerMessage{
+					Signature: nil,
+					Operator:  id,
+					Err:       errors.New("operator did not sign"),
+				}
+				continue
+			}
+			if numExpired < d.numExpired {
+				numExpired++
+				update <- core.SignerMessage{
+					Signature: nil,
+					Operator:  id,
+					Err:       core.ErrBatchExpired,
+				}
+				continue
+			}
 			sig := op.KeyPair.SignMessage(message)
 
 			update <- core.SignerMessage{
 				Signature: sig,
 				Operator:  id,
+				Version:   d.versions[id],
 				Err:       nil,
 			}
 		}
@@ -0,0 +1,384 @@
+package disperser
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"fmt"
+	"math"
+	"math/big"
+
+	binding "github.com/Layr-Labs/eigenda/contracts/bindings/EigenDAServiceManager"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// BatchSignature is the aggregated signature of a batch as submitted with its confirmation, persisted in the batch
+// journal so that the proofs of the confirmed blobs can be exported with the signature material of their batch
+type BatchSignature struct {
+	// QuorumIDs are the quorums of the batch in increasing order, as in the batch header confirmed onchain
+	QuorumIDs []core.QuorumID
+	// QuorumAggPubKeys are the aggregated public keys of all the operators of each quorum, including those which didn't
+	// sign, in the order of QuorumIDs
+	QuorumAggPubKeys []*core.G1Point
+	// AggPubKey is the aggregated public key of the operators which signed the batch
+	AggPubKey    *core.G2Point
+	AggSignature *core.Signature
+	// NonSignerPubKeyHashes are the hashes of the public keys of the operators which didn't sign the batch, i.e. their
+	// operator IDs, from which the signatory record hash of the batch is computed
+	NonSignerPubKeyHashes []core.OperatorID
+}
+
+// NewBatchSignature makes the signature of a batch from the aggregation of the signatures of its operators over the
+// given quorums, in the order they were passed to the aggregator
+func NewBatchSignature(quorumIDs []core.QuorumID, aggregation *core.SignatureAggregation) *BatchSignature {
+	nonSignerPubKeyHashes := make([]core.OperatorID, len(aggregation.NonSigners))
+	for i, nonSigner := range aggregation.NonSigners {
+		nonSignerPubKeyHashes[i] = nonSigner.GetOperatorID()
+	}
+	return &BatchSignature{
+		QuorumIDs:             quorumIDs,
+		QuorumAggPubKeys:      aggregation.QuorumAggPubKeys,
+		AggPubKey:             aggregation.AggPubKey,
+		AggSignature:          aggregation.AggSignature,
+		NonSignerPubKeyHashes: nonSignerPubKeyHashes,
+	}
+}
+
+// batchSignatureWire is the serialized form of a BatchSignature, with the points serialized as bytes
+type batchSignatureWire struct {
+	QuorumIDs             []core.QuorumID
+	QuorumAggPubKeys      [][]byte
+	AggPubKey             []byte
+	AggSignature          []byte
+	NonSignerPubKeyHashes []core.OperatorID
+}
+
+func (s *BatchSignature) Serialize() ([]byte, error) {
+	wire := batchSignatureWire{
+		QuorumIDs:             s.QuorumIDs,
+		QuorumAggPubKeys:      make([][]byte, len(s.QuorumAggPubKeys)),
+		AggPubKey:             s.AggPubKey.Serialize(),
+		AggSignature:          s.AggSignature.Serialize(),
+		NonSignerPubKeyHashes: s.NonSignerPubKeyHashes,
+	}
+	for i, pubKey := range s.QuorumAggPubKeys {
+		wire.QuorumAggPubKeys[i] = pubKey.Serialize()
+	}
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(wire); err != nil {
+		return nil, fmt.Errorf("failed to serialize batch signature: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func DeserializeBatchSignature(data []byte) (*BatchSignature, error) {
+	var wire batchSignatureWire
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&wire); err != nil {
+		return nil, fmt.Errorf("failed to deserialize batch signature: %w", err)
+	}
+	if len(wire.AggPubKey) != 128 || len(wire.AggSignature) != 64 {
+		return nil, fmt.Errorf("failed to deserialize batch signature: invalid point lengths")
+	}
+
+	quorumAggPubKeys := make([]*core.G1Point, len(wire.QuorumAggPubKeys))
+	for i, pubKey := range wire.QuorumAggPubKeys {
+		if len(pubKey) != 64 {
+			return nil, fmt.Errorf("failed to deserialize batch signature: invalid point lengths")
+		}
+		quorumAggPubKeys[i] = new(core.G1Point).Deserialize(pubKey)
+	}
+	return &BatchSignature{
+		QuorumIDs:             wire.QuorumIDs,
+		QuorumAggPubKeys:      quorumAggPubKeys,
+		AggPubKey:             new(core.G2Point).Deserialize(wire.AggPubKey),
+		AggSignature:          &core.Signature{G1Point: new(core.G1Point).Deserialize(wire.AggSignature)},
+		NonSignerPubKeyHashes: wire.NonSignerPubKeyHashes,
+	}, nil
+}
+
+// BlobProofBundle is a self-contained proof of the confirmation of a blob, which a bridge can submit to a verifier
+// contract: the blob header and its verification proof as the EigenDA contracts take them, and the aggregate signature
+// of the batch of the blob. The types mirror the structs of the contracts, so that the bundle is ABI encoded as
+// abi.encode(blobHeader, blobVerificationProof, signature).
+type BlobProofBundle struct {
+	BlobHeader            ProofBundleBlobHeader
+	BlobVerificationProof ProofBundleVerificationProof
+	Signature             ProofBundleSignature
+}
+
+// ProofBundleG1Point is a BN254.G1Point of the contracts
+type ProofBundleG1Point struct {
+	X *big.Int
+	Y *big.Int
+}
+
+// ProofBundleG2Point is a BN254.G2Point of the contracts, whose coordinates are ordered as the contracts expect them,
+// with the imaginary part first
+type ProofBundleG2Point struct {
+	X [2]*big.Int
+	Y [2]*big.Int
+}
+
+// ProofBundleQuorumBlobParam is an IEigenDAServiceManager.QuorumBlobParam
+type ProofBundleQuorumBlobParam struct {
+	QuorumNumber                 uint8
+	AdversaryThresholdPercentage uint8
+	QuorumThresholdPercentage    uint8
+	QuantizationParameter        uint8
+}
+
+// ProofBundleBlobHeader is an IEigenDAServiceManager.BlobHeader, whose hash is the leaf of the blob in the batch root
+type ProofBundleBlobHeader struct {
+	Commitment       ProofBundleG1Point
+	DataLength       uint32
+	QuorumBlobParams []ProofBundleQuorumBlobParam
+}
+
+// ProofBundleBatchHeader is an IEigenDAServiceManager.BatchHeader, as the batch was confirmed onchain
+type ProofBundleBatchHeader struct {
+	BlobHeadersRoot            [32]byte
+	QuorumNumbers              []byte
+	QuorumThresholdPercentages []byte
+	ReferenceBlockNumber       uint32
+}
+
+// ProofBundleBatchMetadata is an IEigenDAServiceManager.BatchMetadata
+type ProofBundleBatchMetadata struct {
+	BatchHeader             ProofBundleBatchHeader
+	SignatoryRecordHash     [32]byte
+	Fee                     *big.Int
+	ConfirmationBlockNumber uint32
+}
+
+// Hash returns the hash of the batch metadata as EigenDAHasher.hashBatchMetadata computes it, which the
+// EigenDAServiceManager stores in batchIdToBatchMetadataHash once it verified the signature of the batch
+func (m *ProofBundleBatchMetadata) Hash() ([32]byte, error) {
+	batchHeaderHash, err := core.HashBatchHeader(binding.IEigenDAServiceManagerBatchHeader{
+		BlobHeadersRoot:            m.BatchHeader.BlobHeadersRoot,
+		QuorumNumbers:              m.BatchHeader.QuorumNumbers,
+		QuorumThresholdPercentages: m.BatchHeader.QuorumThresholdPercentages,
+		ReferenceBlockNumber:       m.BatchHeader.ReferenceBlockNumber,
+	})
+	if err != nil {
+		return [32]byte{}, err
+	}
+	if m.Fee == nil || m.Fee.Sign() < 0 || m.Fee.BitLen() > 96 {
+		return [32]byte{}, fmt.Errorf("fee %v is not a uint96", m.Fee)
+	}
+
+	// abi.encodePacked(batchHeaderHash, signatoryRecordHash, uint96(fee), uint32(confirmationBlockNumber))
+	packed := make([]byte, 0, 32+32+12+4)
+	packed = append(packed, batchHeaderHash[:]...)
+	packed = append(packed, m.SignatoryRecordHash[:]...)
+	packed = append(packed, m.Fee.FillBytes(make([]byte, 12))...)
+	packed = binary.BigEndian.AppendUint32(packed, m.ConfirmationBlockNumber)
+
+	var hash [32]byte
+	copy(hash[:], crypto.Keccak256(packed))
+	return hash, nil
+}
+
+// ProofBundleVerificationProof is an EigenDABlobUtils.BlobVerificationProof
+type ProofBundleVerificationProof struct {
+	BatchId       uint32
+	BlobIndex     uint8
+	BatchMetadata ProofBundleBatchMetadata
+	// InclusionProof is the Merkle proof of the blob header hash in the batch root, the concatenation of the sibling
+	// hashes from the leaf up
+	InclusionProof []byte
+	// QuorumThresholdIndexes are the indexes of the quorums of the blob in the quorums of the batch header
+	QuorumThresholdIndexes []byte
+}
+
+// ProofBundleSignature is the aggregate signature of the batch over its reduced batch header hash
+type ProofBundleSignature struct {
+	// ApkG2 is the aggregated public key of the operators which signed the batch
+	ApkG2 ProofBundleG2Point
+	Sigma ProofBundleG1Point
+	// QuorumApks are the aggregated public keys of all the operators of each quorum of the batch header
+	QuorumApks            []ProofBundleG1Point
+	NonSignerPubkeyHashes [][32]byte
+}
+
+var (
+	g1PointComponents = []abi.ArgumentMarshaling{
+		{Name: "X", Type: "uint256"},
+		{Name: "Y", Type: "uint256"},
+	}
+	g2PointComponents = []abi.ArgumentMarshaling{
+		{Name: "X", Type: "uint256[2]"},
+		{Name: "Y", Type: "uint256[2]"},
+	}
+
+	// proofBundleArguments are the ABI types of the bundle, matching the structs of IEigenDAServiceManager.sol and
+	// EigenDABlobUtils.sol
+	proofBundleArguments = mustProofBundleArguments()
+)
+
+func mustProofBundleArguments() abi.Arguments {
+	blobHeaderType, err := abi.NewType("tuple", "", []abi.ArgumentMarshaling{
+		{Name: "commitment", Type: "tuple", Components: g1PointComponents},
+		{Name: "dataLength", Type: "uint32"},
+		{Name: "quorumBlobParams", Type: "tuple[]", Components: []abi.ArgumentMarshaling{
+			{Name: "quorumNumber", Type: "uint8"},
+			{Name: "adversaryThresholdPercentage", Type: "uint8"},
+			{Name: "quorumThresholdPercentage", Type: "uint8"},
+			{Name: "quantizationParameter", Type: "uint8"},
+		}},
+	})
+	if err != nil {
+		panic(err)
+	}
+	verificationProofType, err := abi.NewType("tuple", "", []abi.ArgumentMarshaling{
+		{Name: "batchId", Type: "uint32"},
+		{Name: "blobIndex", Type: "uint8"},
+		{Name: "batchMetadata", Type: "tuple", Components: []abi.ArgumentMarshaling{
+			{Name: "batchHeader", Type: "tuple", Components: []abi.ArgumentMarshaling{
+				{Name: "blobHeadersRoot", Type: "bytes32"},
+				{Name: "quorumNumbers", Type: "bytes"},
+				{Name: "quorumThresholdPercentages", Type: "bytes"},
+				{Name: "referenceBlockNumber", Type: "uint32"},
+			}},
+			{Name: "signatoryRecordHash", Type: "bytes32"},
+			{Name: "fee", Type: "uint96"},
+			{Name: "confirmationBlockNumber", Type: "uint32"},
+		}},
+		{Name: "inclusionProof", Type: "bytes"},
+		{Name: "quorumThresholdIndexes", Type: "bytes"},
+	})
+	if err != nil {
+		panic(err)
+	}
+	signatureType, err := abi.NewType("tuple", "", []abi.ArgumentMarshaling{
+		{Name: "apkG2", Type: "tuple", Components: g2PointComponents},
+		{Name: "sigma", Type: "tuple", Components: g1PointComponents},
+		{Name: "quorumApks", Type: "tuple[]", Components: g1PointComponents},
+		{Name: "nonSignerPubkeyHashes", Type: "bytes32[]"},
+	})
+	if err != nil {
+		panic(err)
+	}
+	return abi.Arguments{
+		{Name: "blobHeader", Type: blobHeaderType},
+		{Name: "blobVerificationProof", Type: verificationProofType},
+		{Name: "signature", Type: signatureType},
+	}
+}
+
+// NewBlobProofBundle assembles the proof bundle of a confirmed blob from its confirmation info and the signature of its
+// batch
+func NewBlobProofBundle(info *ConfirmationInfo, signature *BatchSignature) (*BlobProofBundle, error) {
+	if info.BlobCommitment == nil || info.BlobCommitment.Commitment == nil {
+		return nil, core.ErrInvalidCommitment
+	}
+	// The blob index is a uint8 in the verification proof of the contracts
+	if info.BlobIndex > math.MaxUint8 {
+		return nil, fmt.Errorf("blob index %d overflows uint8", info.BlobIndex)
+	}
+	if len(info.BatchRoot) != 32 {
+		return nil, fmt.Errorf("invalid batch root length %d", len(info.BatchRoot))
+	}
+	if len(signature.QuorumAggPubKeys) != len(signature.QuorumIDs) {
+		return nil, fmt.Errorf("batch signature has %d quorum aggregate public keys for %d quorums", len(signature.QuorumAggPubKeys), len(signature.QuorumIDs))
+	}
+
+	quorumNumbers := make([]byte, len(signature.QuorumIDs))
+	quorumPercentSigned := make([]byte, len(signature.QuorumIDs))
+	quorumIndex := make(map[core.QuorumID]byte, len(signature.QuorumIDs))
+	for i, quorumID := range signature.QuorumIDs {
+		result, ok := info.QuorumResults[quorumID]
+		if !ok {
+			return nil, fmt.Errorf("no result for quorum %d of the batch", quorumID)
+		}
+		quorumNumbers[i] = quorumID
+		quorumPercentSigned[i] = result.PercentSigned
+		quorumIndex[quorumID] = byte(i)
+	}
+
+	quorumBlobParams := make([]ProofBundleQuorumBlobParam, len(info.BlobQuorumInfos))
+	quorumThresholdIndexes := make([]byte, len(info.BlobQuorumInfos))
+	for i, quorumInfo := range info.BlobQuorumInfos {
+		index, ok := quorumIndex[quorumInfo.QuorumID]
+		if !ok {
+			return nil, fmt.Errorf("quorum %d of blob %d is not in the batch", quorumInfo.QuorumID, info.BlobIndex)
+		}
+		quorumThresholdIndexes[i] = index
+		quorumBlobParams[i] = ProofBundleQuorumBlobParam{
+			QuorumNumber:                 quorumInfo.QuorumID,
+			AdversaryThresholdPercentage: quorumInfo.AdversaryThreshold,
+			QuorumThresholdPercentage:    quorumInfo.QuorumThreshold,
+			QuantizationParameter:        uint8(quorumInfo.QuantizationFactor),
+		}
+	}
+
+	quorumApks := make([]ProofBundleG1Point, len(signature.QuorumAggPubKeys))
+	for i, pubKey := range signature.QuorumAggPubKeys {
+		quorumApks[i] = ProofBundleG1Point{X: pubKey.X.BigInt(new(big.Int)), Y: pubKey.Y.BigInt(new(big.Int))}
+	}
+	nonSignerPubkeyHashes := make([][32]byte, len(signature.NonSignerPubKeyHashes))
+	copy(nonSignerPubkeyHashes, signature.NonSignerPubKeyHashes)
+
+	var batchRoot [32]byte
+	copy(batchRoot[:], info.BatchRoot)
+	commitment := info.BlobCommitment.Commitment
+	apk := signature.AggPubKey
+	return &BlobProofBundle{
+		BlobHeader: ProofBundleBlobHeader{
+			Commitment:       ProofBundleG1Point{X: commitment.X.BigInt(new(big.Int)), Y: commitment.Y.BigInt(new(big.Int))},
+			DataLength:       uint32(info.BlobCommitment.Length),
+			QuorumBlobParams: quorumBlobParams,
+		},
+		BlobVerificationProof: ProofBundleVerificationProof{
+			BatchId:   info.BatchID,
+			BlobIndex: uint8(info.BlobIndex),
+			BatchMetadata: ProofBundleBatchMetadata{
+				BatchHeader: ProofBundleBatchHeader{
+					BlobHeadersRoot:            batchRoot,
+					QuorumNumbers:              quorumNumbers,
+					QuorumThresholdPercentages: quorumPercentSigned,
+					ReferenceBlockNumber:       info.ReferenceBlockNumber,
+				},
+				SignatoryRecordHash:     info.SignatoryRecordHash,
+				Fee:                     new(big.Int).SetBytes(info.Fee),
+				ConfirmationBlockNumber: info.ConfirmationBlockNumber,
+			},
+			InclusionProof:         info.BlobInclusionProof,
+			QuorumThresholdIndexes: quorumThresholdIndexes,
+		},
+		Signature: ProofBundleSignature{
+			ApkG2: ProofBundleG2Point{
+				X: [2]*big.Int{apk.X.A1.BigInt(new(big.Int)), apk.X.A0.BigInt(new(big.Int))},
+				Y: [2]*big.Int{apk.Y.A1.BigInt(new(big.Int)), apk.Y.A0.BigInt(new(big.Int))},
+			},
+			Sigma:                 ProofBundleG1Point{X: signature.AggSignature.X.BigInt(new(big.Int)), Y: signature.AggSignature.Y.BigInt(new(big.Int))},
+			QuorumApks:            quorumApks,
+			NonSignerPubkeyHashes: nonSignerPubkeyHashes,
+		},
+	}, nil
+}
+
+// Encode returns the ABI encoding of the bundle, abi.encode(blobHeader, blobVerificationProof, signature)
+func (b *BlobProofBundle) Encode() ([]byte, error) {
+	data, err := proofBundleArguments.Pack(b.BlobHeader, b.BlobVerificationProof, b.Signature)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode proof bundle: %w", err)
+	}
+	return data, nil
+}
+
+// DecodeBlobProofBundle decodes the ABI encoding of a proof bundle
+func DecodeBlobProofBundle(data []byte) (*BlobProofBundle, error) {
+	values, err := proofBundleArguments.Unpack(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode proof bundle: %w", err)
+	}
+	// The unpacked values are anonymous structs of the same layout as the bundle types, which they are converted to
+	return &BlobProofBundle{
+		BlobHeader:            *abi.ConvertType(values[0], new(ProofBundleBlobHeader)).(*ProofBundleBlobHeader),
+		BlobVerificationProof: *abi.ConvertType(values[1], new(ProofBundleVerificationProof)).(*ProofBundleVerificationProof),
+		Signature:             *abi.ConvertType(values[2], new(ProofBundleSignature)).(*ProofBundleSignature),
+	}, nil
+}
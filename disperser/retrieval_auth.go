@@ -0,0 +1,71 @@
+package disperser
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// retrievalAuthDomain separates the signatures of the retrievals from the other messages signed with the key of a
+// submitter
+const retrievalAuthDomain = "EigenDA RetrieveBlob"
+
+// ErrRetrievalUnauthorized is returned when retrieving a private blob without a valid signature of its submitter
+var ErrRetrievalUnauthorized = errors.New("retrieval is not authorized")
+
+// ParseSubmitterPublicKey checks that the key is a compressed or uncompressed ECDSA public key, and returns its
+// compressed form, which is how it is stored with the blob
+func ParseSubmitterPublicKey(key []byte) ([]byte, error) {
+	var (
+		publicKey *ecdsa.PublicKey
+		err       error
+	)
+	switch len(key) {
+	case 33:
+		publicKey, err = crypto.DecompressPubkey(key)
+	case 65:
+		publicKey, err = crypto.UnmarshalPubkey(key)
+	default:
+		return nil, fmt.Errorf("public key must be 33 or 65 bytes, but found %d", len(key))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid public key: %w", err)
+	}
+	return crypto.CompressPubkey(publicKey), nil
+}
+
+// RetrievalAuthHash returns the hash the submitter of a private blob signs to retrieve it, which binds the signature to
+// the blob and to the time of the request
+func RetrievalAuthHash(batchHeaderHash [32]byte, blobIndex uint32, timestamp uint64) []byte {
+	message := make([]byte, 0, len(retrievalAuthDomain)+32+4+8)
+	message = append(message, retrievalAuthDomain...)
+	message = append(message, batchHeaderHash[:]...)
+	message = binary.BigEndian.AppendUint32(message, blobIndex)
+	message = binary.BigEndian.AppendUint64(message, timestamp)
+	return crypto.Keccak256(message)
+}
+
+// SignRetrieval signs the retrieval of a private blob with the key of its submitter
+func SignRetrieval(key *ecdsa.PrivateKey, batchHeaderHash [32]byte, blobIndex uint32, timestamp uint64) ([]byte, error) {
+	return crypto.Sign(RetrievalAuthHash(batchHeaderHash, blobIndex, timestamp), key)
+}
+
+// VerifyRetrievalSignature checks that the signature of the retrieval was made by the key of the submitter, given in
+// compressed form
+func VerifyRetrievalSignature(submitterPublicKey []byte, batchHeaderHash [32]byte, blobIndex uint32, timestamp uint64, signature []byte) error {
+	if len(signature) != crypto.SignatureLength {
+		return fmt.Errorf("%w: signature must be %d bytes, but found %d", ErrRetrievalUnauthorized, crypto.SignatureLength, len(signature))
+	}
+	signer, err := crypto.SigToPub(RetrievalAuthHash(batchHeaderHash, blobIndex, timestamp), signature)
+	if err != nil {
+		return fmt.Errorf("%w: invalid signature: %v", ErrRetrievalUnauthorized, err)
+	}
+	if !bytes.Equal(crypto.CompressPubkey(signer), submitterPublicKey) {
+		return fmt.Errorf("%w: the request isn't signed by the submitter of the blob", ErrRetrievalUnauthorized)
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+package disperser
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/Layr-Labs/eigenda/core"
+)
+
+// SecurityParamBounds bounds the security params of the blobs dispersed to a quorum. A bound which is 0 isn't enforced,
+// and any quantization factor is allowed when AllowedQuantizationFactors is empty.
+type SecurityParamBounds struct {
+	MinAdversaryThreshold      uint8  `json:"min_adversary_threshold"`
+	MaxAdversaryThreshold      uint8  `json:"max_adversary_threshold"`
+	MinQuorumThreshold         uint8  `json:"min_quorum_threshold"`
+	MaxQuorumThreshold         uint8  `json:"max_quorum_threshold"`
+	AllowedQuantizationFactors []uint `json:"allowed_quantization_factors"`
+}
+
+// SecurityPolicy is the policy the security params of the dispersed blobs must comply with, on top of the protocol
+// requirements checked by core.BlobRequestHeader.Validate
+type SecurityPolicy struct {
+	// Default are the bounds of the quorums which have no bounds of their own
+	Default SecurityParamBounds `json:"default"`
+	// Quorums are the bounds of each quorum, which apply instead of the default ones
+	Quorums map[core.QuorumID]SecurityParamBounds `json:"quorums"`
+}
+
+// ReadSecurityPolicy reads the security policy from the given JSON file, e.g.
+// {"default": {"min_adversary_threshold": 10}, "quorums": {"0": {"min_adversary_threshold": 33, "min_quorum_threshold": 55}}}
+func ReadSecurityPolicy(path string) (SecurityPolicy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return SecurityPolicy{}, fmt.Errorf("failed to read security policy file: %w", err)
+	}
+
+	var policy SecurityPolicy
+	if err := json.Unmarshal(data, &policy); err != nil {
+		return SecurityPolicy{}, fmt.Errorf("failed to parse security policy file: %w", err)
+	}
+	if err := policy.Validate(); err != nil {
+		return SecurityPolicy{}, err
+	}
+
+	return policy, nil
+}
+
+// Bounds returns the bounds of the security params of the quorum
+func (p SecurityPolicy) Bounds(quorumID core.QuorumID) SecurityParamBounds {
+	if bounds, ok := p.Quorums[quorumID]; ok {
+		return bounds
+	}
+	return p.Default
+}
+
+// Validate checks that the bounds of the policy are consistent, so that a misconfigured policy is rejected rather
+// than rejecting all the dispersals
+func (p SecurityPolicy) Validate() error {
+	if err := p.Default.Validate(); err != nil {
+		return fmt.Errorf("invalid default security policy: %w", err)
+	}
+	for quorumID, bounds := range p.Quorums {
+		if err := bounds.Validate(); err != nil {
+			return fmt.Errorf("invalid security policy of quorum %d: %w", quorumID, err)
+		}
+	}
+	return nil
+}
+
+// Validate checks that the bounds are percentages, that the min bounds don't exceed the max bounds, and that a blob can
+// comply with them
+func (b SecurityParamBounds) Validate() error {
+	if b.MaxAdversaryThreshold > 100 || b.MinAdversaryThreshold > 100 || b.MaxQuorumThreshold > 100 || b.MinQuorumThreshold > 100 {
+		return fmt.Errorf("thresholds must not exceed 100")
+	}
+	if b.MaxAdversaryThreshold != 0 && b.MinAdversaryThreshold > b.MaxAdversaryThreshold {
+		return fmt.Errorf("min_adversary_threshold %d exceeds max_adversary_threshold %d", b.MinAdversaryThreshold, b.MaxAdversaryThreshold)
+	}
+	if b.MaxQuorumThreshold != 0 && b.MinQuorumThreshold > b.MaxQuorumThreshold {
+		return fmt.Errorf("min_quorum_threshold %d exceeds max_quorum_threshold %d", b.MinQuorumThreshold, b.MaxQuorumThreshold)
+	}
+	// The adversary threshold of a blob must be below its quorum threshold, which no blob could comply with
+	if b.MaxQuorumThreshold != 0 && b.MinAdversaryThreshold >= b.MaxQuorumThreshold {
+		return fmt.Errorf("min_adversary_threshold %d must be below max_quorum_threshold %d", b.MinAdversaryThreshold, b.MaxQuorumThreshold)
+	}
+	for _, factor := range b.AllowedQuantizationFactors {
+		if factor == 0 {
+			return fmt.Errorf("allowed_quantization_factors must be positive")
+		}
+	}
+	return nil
+}
+
+// AllowsQuantizationFactor returns whether the chunks of a blob may be assigned with the quantization factor
+func (b SecurityParamBounds) AllowsQuantizationFactor(factor uint) bool {
+	if len(b.AllowedQuantizationFactors) == 0 {
+		return true
+	}
+	for _, allowed := range b.AllowedQuantizationFactors {
+		if allowed == factor {
+			return true
+		}
+	}
+	return false
+}
@@ -1,9 +1,114 @@
 package disperser
 
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	commongrpc "github.com/Layr-Labs/eigenda/common/grpc"
+	"github.com/Layr-Labs/eigenda/core"
+)
+
 const (
 	Localhost = "0.0.0.0"
 )
 
 type ServerConfig struct {
 	GrpcPort string
+	// TLS configures the transport security of the gRPC server. The server listens in plaintext when no certificate is set.
+	TLS commongrpc.TLSConfig
+	// MaxGRPCMessageSize is the max size in bytes of the replies of the gRPC server, and of the replies of the nodes to
+	// the retrieval fallback. The requests are limited to twice the max blob size regardless, once decompressed. The
+	// replies are not limited when it is 0.
+	MaxGRPCMessageSize int
+	// DisperseRequestTimeout bounds the time a DisperseBlob request may take, including the writes to the blob store,
+	// when the client set no earlier deadline. Requests are only bounded by the client's deadline when it is 0.
+	DisperseRequestTimeout time.Duration
+	// MaxBlobRetention bounds the total retention of a blob, from its dispersal, when its retention is extended. The
+	// retention of the blobs can't be extended when it is 0.
+	MaxBlobRetention time.Duration
+	// EnableReflection registers the gRPC reflection service, so that reflection clients such as grpcurl can list the
+	// methods of the server. It is meant for local debugging, and is off by default.
+	EnableReflection bool
+	// ExpectedConfirmationTime is the typical time from the dispersal of a blob to its confirmation, from which the
+	// delays after which the clients are recommended to poll the status of the processing blobs again are derived. No
+	// delay is recommended when it is 0.
+	ExpectedConfirmationTime time.Duration
+	// MaxQuorumsPerBlob bounds the number of quorums a blob may be dispersed to, which bounds the cost of its encoding.
+	// It is the onchain quorum count when it is 0.
+	MaxQuorumsPerBlob uint
+	// SecurityPolicy is the security policy in effect at startup
+	SecurityPolicy SecurityPolicy
+	// SecurityPolicyFile is the JSON file from which SecurityPolicy is loaded. If set, the file is periodically reloaded
+	// so that the policy can be changed without restarting the server.
+	SecurityPolicyFile string
+	// SecurityPolicyRefreshInterval is the interval at which SecurityPolicyFile is reloaded
+	SecurityPolicyRefreshInterval time.Duration
+	// MaxConcurrentDispersals bounds the number of DisperseBlob requests processed at once, so that the requests waiting
+	// on a slow blob store don't pile up with their blobs in memory. The requests aren't bounded when it is 0.
+	MaxConcurrentDispersals int
+	// MaxQueuedDispersals bounds the number of DisperseBlob requests waiting for one of the MaxConcurrentDispersals
+	// slots. The requests arriving once the queue is full are shed right away with a ResourceExhausted error.
+	MaxQueuedDispersals int
+	// DispersalQueueTimeout bounds the wait of a queued DisperseBlob request, after which it is shed as well. The
+	// queued requests are only bounded by their deadline when it is 0.
+	DispersalQueueTimeout time.Duration
+	// MinChunkLength is the floor of the chunk lengths of the batcher, with which the encoded lengths of the dry runs are
+	// estimated
+	MinChunkLength uint
+	// LargeQuorumOperatorThreshold and LargeQuorumMinChunkBytes are the large quorum chunk sizing of the batcher, with
+	// which the encoded lengths of the dry runs are estimated
+	LargeQuorumOperatorThreshold uint
+	LargeQuorumMinChunkBytes     uint
+	// WarmUpEncoder makes the server encode a blob of each length up to the max blob size as soon as its encoder is set,
+	// so that the first requests needing the encoder don't pay for loading the SRS and creating the encoders. The health
+	// check reports NOT_SERVING until the warm-up is done. It is off for a fast startup, e.g. in the tests.
+	WarmUpEncoder bool
+}
+
+// Validate checks the config before the server starts, and returns all the violations at once
+func (c ServerConfig) Validate() error {
+	var errs []error
+	if port, err := strconv.ParseUint(c.GrpcPort, 10, 16); err != nil || port == 0 {
+		errs = append(errs, fmt.Errorf("the grpc port must be a port number, but found %q", c.GrpcPort))
+	}
+	if err := c.TLS.Validate(); err != nil {
+		errs = append(errs, fmt.Errorf("invalid TLS config: %w", err))
+	}
+	if c.MaxGRPCMessageSize < 0 {
+		errs = append(errs, fmt.Errorf("the max grpc message size must not be negative (0 for no limit), but found %d", c.MaxGRPCMessageSize))
+	}
+	for _, d := range []struct {
+		name  string
+		value time.Duration
+	}{
+		{"disperse request timeout", c.DisperseRequestTimeout},
+		{"max blob retention", c.MaxBlobRetention},
+		{"expected confirmation time", c.ExpectedConfirmationTime},
+		{"dispersal queue timeout", c.DispersalQueueTimeout},
+	} {
+		if d.value < 0 {
+			errs = append(errs, fmt.Errorf("the %s must not be negative (0 to disable it), but found %s", d.name, d.value))
+		}
+	}
+	if err := c.SecurityPolicy.Validate(); err != nil {
+		errs = append(errs, err)
+	}
+	if c.SecurityPolicyFile != "" && c.SecurityPolicyRefreshInterval <= 0 {
+		errs = append(errs, fmt.Errorf("the security policy refresh interval must be positive with a security policy file, but found %s", c.SecurityPolicyRefreshInterval))
+	}
+	if c.MaxConcurrentDispersals < 0 {
+		errs = append(errs, fmt.Errorf("the max concurrent dispersals must not be negative (0 for no limit), but found %d", c.MaxConcurrentDispersals))
+	}
+	if c.MaxQueuedDispersals < 0 {
+		errs = append(errs, fmt.Errorf("the max queued dispersals must not be negative, but found %d", c.MaxQueuedDispersals))
+	}
+	if c.MinChunkLength > core.MaxMinChunkLength {
+		errs = append(errs, fmt.Errorf("the min chunk length must be at most %d, but found %d", core.MaxMinChunkLength, c.MinChunkLength))
+	}
+	if (c.LargeQuorumOperatorThreshold == 0) != (c.LargeQuorumMinChunkBytes == 0) {
+		errs = append(errs, errors.New("the large quorum operator threshold and min chunk bytes must be set together"))
+	}
+	return errors.Join(errs...)
 }
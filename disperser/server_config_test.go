@@ -0,0 +1,130 @@
+package disperser_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	commongrpc "github.com/Layr-Labs/eigenda/common/grpc"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/disperser"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServerConfigValidate(t *testing.T) {
+	valid := disperser.ServerConfig{
+		GrpcPort:                      "32001",
+		DisperseRequestTimeout:        5 * time.Second,
+		MaxQueuedDispersals:           100,
+		DispersalQueueTimeout:         time.Second,
+		SecurityPolicyRefreshInterval: time.Minute,
+	}
+	assert.NoError(t, valid.Validate())
+
+	tests := []struct {
+		name   string
+		modify func(*disperser.ServerConfig)
+		errors []string
+	}{
+		{
+			name:   "empty grpc port",
+			modify: func(c *disperser.ServerConfig) { c.GrpcPort = "" },
+			errors: []string{`the grpc port must be a port number, but found ""`},
+		},
+		{
+			name:   "grpc port out of range",
+			modify: func(c *disperser.ServerConfig) { c.GrpcPort = "70000" },
+			errors: []string{`the grpc port must be a port number, but found "70000"`},
+		},
+		{
+			name:   "key without certificate",
+			modify: func(c *disperser.ServerConfig) { c.TLS = commongrpc.TLSConfig{KeyFile: "server.key"} },
+			errors: []string{"invalid TLS config: the certificate file and key file must be set together"},
+		},
+		{
+			name:   "negative max grpc message size",
+			modify: func(c *disperser.ServerConfig) { c.MaxGRPCMessageSize = -1 },
+			errors: []string{"the max grpc message size must not be negative (0 for no limit), but found -1"},
+		},
+		{
+			name:   "negative disperse request timeout",
+			modify: func(c *disperser.ServerConfig) { c.DisperseRequestTimeout = -time.Second },
+			errors: []string{"the disperse request timeout must not be negative (0 to disable it), but found -1s"},
+		},
+		{
+			name:   "negative max blob retention",
+			modify: func(c *disperser.ServerConfig) { c.MaxBlobRetention = -time.Hour },
+			errors: []string{"the max blob retention must not be negative (0 to disable it), but found -1h0m0s"},
+		},
+		{
+			name:   "negative expected confirmation time",
+			modify: func(c *disperser.ServerConfig) { c.ExpectedConfirmationTime = -time.Minute },
+			errors: []string{"the expected confirmation time must not be negative (0 to disable it), but found -1m0s"},
+		},
+		{
+			name: "adversary threshold above the quorum threshold",
+			modify: func(c *disperser.ServerConfig) {
+				c.SecurityPolicy.Default = disperser.SecurityParamBounds{MinAdversaryThreshold: 60, MaxQuorumThreshold: 50}
+			},
+			errors: []string{"invalid default security policy: min_adversary_threshold 60 must be below max_quorum_threshold 50"},
+		},
+		{
+			name: "min quorum threshold above the max",
+			modify: func(c *disperser.ServerConfig) {
+				c.SecurityPolicy.Quorums = map[core.QuorumID]disperser.SecurityParamBounds{1: {MinQuorumThreshold: 90, MaxQuorumThreshold: 80}}
+			},
+			errors: []string{"invalid security policy of quorum 1: min_quorum_threshold 90 exceeds max_quorum_threshold 80"},
+		},
+		{
+			name: "security policy file without refresh interval",
+			modify: func(c *disperser.ServerConfig) {
+				c.SecurityPolicyFile = "policy.json"
+				c.SecurityPolicyRefreshInterval = 0
+			},
+			errors: []string{"the security policy refresh interval must be positive with a security policy file, but found 0s"},
+		},
+		{
+			name:   "negative max concurrent dispersals",
+			modify: func(c *disperser.ServerConfig) { c.MaxConcurrentDispersals = -1 },
+			errors: []string{"the max concurrent dispersals must not be negative (0 for no limit), but found -1"},
+		},
+		{
+			name:   "negative max queued dispersals",
+			modify: func(c *disperser.ServerConfig) { c.MaxQueuedDispersals = -1 },
+			errors: []string{"the max queued dispersals must not be negative, but found -1"},
+		},
+		{
+			name:   "min chunk length above the max",
+			modify: func(c *disperser.ServerConfig) { c.MinChunkLength = 2 * core.MaxMinChunkLength },
+			errors: []string{"the min chunk length must be at most 256, but found 512"},
+		},
+		{
+			name:   "large quorum threshold without min chunk bytes",
+			modify: func(c *disperser.ServerConfig) { c.LargeQuorumOperatorThreshold = 200 },
+			errors: []string{"the large quorum operator threshold and min chunk bytes must be set together"},
+		},
+		{
+			name: "every violation is reported",
+			modify: func(c *disperser.ServerConfig) {
+				c.GrpcPort = "port"
+				c.DispersalQueueTimeout = -time.Second
+				c.MaxConcurrentDispersals = -1
+			},
+			errors: []string{
+				`the grpc port must be a port number, but found "port"`,
+				"the dispersal queue timeout must not be negative (0 to disable it), but found -1s",
+				"the max concurrent dispersals must not be negative (0 for no limit), but found -1",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := valid
+			tt.modify(&config)
+			err := config.Validate()
+			if assert.Error(t, err) {
+				assert.Equal(t, strings.Join(tt.errors, "\n"), err.Error())
+			}
+		})
+	}
+}
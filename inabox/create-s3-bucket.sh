@@ -4,13 +4,19 @@ set -e
 S3_BUCKET="test-eigenda-blobstore"
 S3_REGION="us-east-1"
 
+# Skip the verification of the endpoint's certificate, e.g. for a localstack instance behind a self-signed certificate
+NO_VERIFY_SSL=""
+if [ "$AWS_NO_VERIFY_SSL" = "true" ]; then
+    NO_VERIFY_SSL="--no-verify-ssl"
+fi
+
 if AWS_ACCESS_KEY_ID=localstack AWS_SECRET_ACCESS_KEY=localstack \
-    aws s3api head-bucket --endpoint-url=$AWS_URL --bucket "$S3_BUCKET" 2>/dev/null; then
+    aws s3api head-bucket --endpoint-url=$AWS_URL $NO_VERIFY_SSL --bucket "$S3_BUCKET" 2>/dev/null; then
     echo "Bucket $S3_BUCKET already exists"
 else
     echo "Creating bucket $S3_BUCKET"
    AWS_ACCESS_KEY_ID=localstack AWS_SECRET_ACCESS_KEY=localstack aws s3api create-bucket \
-            --endpoint-url=$AWS_URL \
+            --endpoint-url=$AWS_URL $NO_VERIFY_SSL \
             --bucket "$S3_BUCKET" \
             --region "$S3_REGION" 
 fi
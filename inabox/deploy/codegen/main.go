@@ -68,6 +68,10 @@ func getFlag(flag cli.Flag) Flag {
 	if ok {
 		return Flag{uintFlag.Name, uintFlag.EnvVar}
 	}
+	float64Flag, ok := flag.(cli.Float64Flag)
+	if ok {
+		return Flag{float64Flag.Name, float64Flag.EnvVar}
+	}
 	durationFlag, ok := flag.(cli.DurationFlag)
 	if ok {
 		return Flag{durationFlag.Name, durationFlag.EnvVar}
@@ -15,14 +15,48 @@ type DisperserVars struct {
 
 	DISPERSER_SERVER_EIGENDA_SERVICE_MANAGER string
 
+	DISPERSER_SERVER_S3_KEY_PREFIX string
+
 	DISPERSER_SERVER_METRICS_HTTP_PORT string
 
 	DISPERSER_SERVER_ENABLE_METRICS string
 
+	DISPERSER_SERVER_METRICS_NAMESPACE_ALLOWLIST string
+
 	DISPERSER_SERVER_ENABLE_RATELIMITER string
 
 	DISPERSER_SERVER_RATE_BUCKET_STORE_SIZE string
 
+	DISPERSER_SERVER_ENABLE_RETRIEVAL_FALLBACK string
+
+	DISPERSER_SERVER_GRAPH_URL string
+
+	DISPERSER_SERVER_RETRIEVAL_TIMEOUT string
+
+	DISPERSER_SERVER_RETRIEVAL_NUM_CONNECTIONS string
+
+	DISPERSER_SERVER_DISPERSE_REQUEST_TIMEOUT string
+
+	DISPERSER_SERVER_MAX_BLOB_RETENTION string
+
+	DISPERSER_SERVER_ENABLE_REFLECTION string
+
+	DISPERSER_SERVER_EXPECTED_CONFIRMATION_TIME string
+
+	DISPERSER_SERVER_MAX_QUORUMS_PER_BLOB string
+
+	DISPERSER_SERVER_SECURITY_POLICY_FILE string
+
+	DISPERSER_SERVER_SECURITY_POLICY_REFRESH_INTERVAL string
+
+	DISPERSER_SERVER_AUDIT_SINK string
+
+	DISPERSER_SERVER_AUDIT_FILE string
+
+	DISPERSER_SERVER_AUDIT_TABLE_NAME string
+
+	DISPERSER_SERVER_AUDIT_SAMPLE_RATE string
+
 	DISPERSER_SERVER_CHAIN_RPC string
 
 	DISPERSER_SERVER_PRIVATE_KEY string
@@ -33,6 +67,20 @@ type DisperserVars struct {
 
 	DISPERSER_SERVER_LOG_PATH string
 
+	DISPERSER_SERVER_GRPC_TLS_CERT_FILE string
+
+	DISPERSER_SERVER_GRPC_TLS_KEY_FILE string
+
+	DISPERSER_SERVER_GRPC_TLS_CERT_PEM string
+
+	DISPERSER_SERVER_GRPC_TLS_KEY_PEM string
+
+	DISPERSER_SERVER_GRPC_TLS_CLIENT_CA_FILE string
+
+	DISPERSER_SERVER_GRPC_TLS_CLIENT_CA_PEM string
+
+	DISPERSER_SERVER_GRPC_MAX_MESSAGE_SIZE string
+
 	DISPERSER_SERVER_BUCKET_SIZES string
 
 	DISPERSER_SERVER_BUCKET_MULTIPLIERS string
@@ -49,6 +97,10 @@ type DisperserVars struct {
 
 	DISPERSER_SERVER_AWS_ENDPOINT_URL string
 
+	DISPERSER_SERVER_AWS_CA_CERT_FILE string
+
+	DISPERSER_SERVER_AWS_INSECURE_SKIP_VERIFY string
+
 	DISPERSER_SERVER_REGISTERED_QUORUM_ID string
 
 	DISPERSER_SERVER_TOTAL_UNAUTH_THROUGHPUT string
@@ -56,6 +108,40 @@ type DisperserVars struct {
 	DISPERSER_SERVER_PER_USER_UNAUTH_THROUGHPUT string
 
 	DISPERSER_SERVER_CLIENT_IP_HEADER string
+
+	DISPERSER_SERVER_RESERVATIONS_FILE string
+
+	DISPERSER_SERVER_RESERVATIONS_REFRESH_INTERVAL string
+
+	DISPERSER_SERVER_TRUSTED_API_KEYS string
+
+	DISPERSER_SERVER_TRUSTED_CIDRS string
+
+	DISPERSER_SERVER_DAILY_QUOTA string
+
+	DISPERSER_SERVER_ACCOUNT_DAILY_QUOTAS string
+
+	DISPERSER_SERVER_KZG_G1_PATH string
+
+	DISPERSER_SERVER_KZG_G2_PATH string
+
+	DISPERSER_SERVER_KZG_CACHE_PATH string
+
+	DISPERSER_SERVER_KZG_SRS_ORDER string
+
+	DISPERSER_SERVER_KZG_NUM_WORKERS string
+
+	DISPERSER_SERVER_KZG_VERBOSE string
+
+	DISPERSER_SERVER_KZG_CACHE_ENCODED_BLOBS string
+
+	DISPERSER_SERVER_KZG_PRELOAD_ENCODER string
+
+	DISPERSER_SERVER_KZG_ENCODER_CACHE_SIZE string
+
+	DISPERSER_SERVER_KZG_LAZY_LOAD_SRS string
+
+	DISPERSER_SERVER_KZG_VERIFY_SRS string
 }
 
 func (vars DisperserVars) getEnvMap() map[string]string {
@@ -78,8 +164,6 @@ type BatcherVars struct {
 
 	BATCHER_EIGENDA_SERVICE_MANAGER string
 
-	BATCHER_ENCODER_ADDRESS string
-
 	BATCHER_ENABLE_METRICS string
 
 	BATCHER_GRAPH_URL string
@@ -90,8 +174,16 @@ type BatcherVars struct {
 
 	BATCHER_SRS_ORDER string
 
+	BATCHER_ENCODER_ADDRESS string
+
+	BATCHER_ENCODER_HEALTH_CHECK_INTERVAL string
+
+	BATCHER_S3_KEY_PREFIX string
+
 	BATCHER_METRICS_HTTP_PORT string
 
+	BATCHER_METRICS_ACCOUNT_ALLOWLIST string
+
 	BATCHER_INDEXER_DATA_DIR string
 
 	BATCHER_ENCODING_TIMEOUT string
@@ -110,6 +202,38 @@ type BatcherVars struct {
 
 	BATCHER_MAX_NUM_RETRIES_PER_BLOB string
 
+	BATCHER_MIN_SIGNED_PERCENTAGE string
+
+	BATCHER_ENABLE_CONFIRMATION_QUEUE string
+
+	BATCHER_CONFIRMATION_QUEUE_TABLE_NAME string
+
+	BATCHER_CONFIRMATION_RETRY_INTERVAL string
+
+	BATCHER_MAX_CONFIRMATION_ATTEMPTS string
+
+	BATCHER_MAX_REFERENCE_BLOCK_AGE string
+
+	BATCHER_ENCODING_OVERPROVISION_PERCENT string
+
+	BATCHER_STUCK_BLOB_SLA string
+
+	BATCHER_WATCHDOG_INTERVAL string
+
+	BATCHER_REDRIVE_STUCK_BLOBS string
+
+	BATCHER_OPERATOR_SOCKET_REFRESH_INTERVAL string
+
+	BATCHER_NODE_MAX_GRPC_MESSAGE_SIZE string
+
+	BATCHER_ACCOUNT_USAGE_TABLE_NAME string
+
+	BATCHER_DAILY_QUOTA string
+
+	BATCHER_ACCOUNT_DAILY_QUOTAS string
+
+	BATCHER_QUOTA_GRACE_PERIOD string
+
 	BATCHER_CHAIN_RPC string
 
 	BATCHER_PRIVATE_KEY string
@@ -129,6 +253,34 @@ type BatcherVars struct {
 	BATCHER_AWS_SECRET_ACCESS_KEY string
 
 	BATCHER_AWS_ENDPOINT_URL string
+
+	BATCHER_AWS_CA_CERT_FILE string
+
+	BATCHER_AWS_INSECURE_SKIP_VERIFY string
+
+	BATCHER_GRPC_MAX_MESSAGE_SIZE string
+
+	BATCHER_KZG_G1_PATH string
+
+	BATCHER_KZG_G2_PATH string
+
+	BATCHER_KZG_CACHE_PATH string
+
+	BATCHER_KZG_SRS_ORDER string
+
+	BATCHER_KZG_NUM_WORKERS string
+
+	BATCHER_KZG_VERBOSE string
+
+	BATCHER_KZG_CACHE_ENCODED_BLOBS string
+
+	BATCHER_KZG_PRELOAD_ENCODER string
+
+	BATCHER_KZG_ENCODER_CACHE_SIZE string
+
+	BATCHER_KZG_LAZY_LOAD_SRS string
+
+	BATCHER_KZG_VERIFY_SRS string
 }
 
 func (vars BatcherVars) getEnvMap() map[string]string {
@@ -167,6 +319,12 @@ type EncoderVars struct {
 
 	DISPERSER_ENCODER_PRELOAD_ENCODER string
 
+	DISPERSER_ENCODER_ENCODER_CACHE_SIZE string
+
+	DISPERSER_ENCODER_LAZY_LOAD_SRS string
+
+	DISPERSER_ENCODER_VERIFY_SRS string
+
 	DISPERSER_ENCODER_STD_LOG_LEVEL string
 
 	DISPERSER_ENCODER_FILE_LOG_LEVEL string
@@ -226,6 +384,16 @@ type OperatorVars struct {
 
 	NODE_EXPIRATION_POLL_INTERVAL string
 
+	NODE_QUORUM_REGISTRATION_POLL_INTERVAL string
+
+	NODE_MAX_REFERENCE_BLOCK_AGE string
+
+	NODE_DISPERSAL_DEADLINE_SKEW string
+
+	NODE_RETRIEVAL_CACHE_SIZE string
+
+	NODE_DB_BACKEND string
+
 	NODE_ENABLE_TEST_MODE string
 
 	NODE_OVERRIDE_BLOCK_STALE_MEASURE string
@@ -236,12 +404,20 @@ type OperatorVars struct {
 
 	NODE_NUM_BATCH_VALIDATORS string
 
+	NODE_QUORUM_VALIDATION_CONCURRENCY string
+
 	NODE_INTERNAL_DISPERSAL_PORT string
 
 	NODE_INTERNAL_RETRIEVAL_PORT string
 
 	NODE_CLIENT_IP_HEADER string
 
+	NODE_DISPERSER_ADDRESS string
+
+	NODE_DISABLE_DISPERSER_AUTH string
+
+	NODE_DISPERSAL_RATE_LIMIT string
+
 	NODE_G1_PATH string
 
 	NODE_G2_PATH string
@@ -258,6 +434,12 @@ type OperatorVars struct {
 
 	NODE_PRELOAD_ENCODER string
 
+	NODE_ENCODER_CACHE_SIZE string
+
+	NODE_LAZY_LOAD_SRS string
+
+	NODE_VERIFY_SRS string
+
 	NODE_CHAIN_RPC string
 
 	NODE_PRIVATE_KEY string
@@ -267,6 +449,20 @@ type OperatorVars struct {
 	NODE_FILE_LOG_LEVEL string
 
 	NODE_LOG_PATH string
+
+	NODE_GRPC_TLS_CERT_FILE string
+
+	NODE_GRPC_TLS_KEY_FILE string
+
+	NODE_GRPC_TLS_CERT_PEM string
+
+	NODE_GRPC_TLS_KEY_PEM string
+
+	NODE_GRPC_TLS_CLIENT_CA_FILE string
+
+	NODE_GRPC_TLS_CLIENT_CA_PEM string
+
+	NODE_GRPC_MAX_MESSAGE_SIZE string
 }
 
 func (vars OperatorVars) getEnvMap() map[string]string {
@@ -295,6 +491,8 @@ type RetrieverVars struct {
 
 	RETRIEVER_METRICS_HTTP_PORT string
 
+	RETRIEVER_OPERATOR_SOCKET_REFRESH_INTERVAL string
+
 	RETRIEVER_G1_PATH string
 
 	RETRIEVER_G2_PATH string
@@ -311,6 +509,12 @@ type RetrieverVars struct {
 
 	RETRIEVER_PRELOAD_ENCODER string
 
+	RETRIEVER_ENCODER_CACHE_SIZE string
+
+	RETRIEVER_LAZY_LOAD_SRS string
+
+	RETRIEVER_VERIFY_SRS string
+
 	RETRIEVER_CHAIN_RPC string
 
 	RETRIEVER_PRIVATE_KEY string
@@ -321,6 +525,20 @@ type RetrieverVars struct {
 
 	RETRIEVER_LOG_PATH string
 
+	RETRIEVER_GRPC_TLS_CERT_FILE string
+
+	RETRIEVER_GRPC_TLS_KEY_FILE string
+
+	RETRIEVER_GRPC_TLS_CERT_PEM string
+
+	RETRIEVER_GRPC_TLS_KEY_PEM string
+
+	RETRIEVER_GRPC_TLS_CLIENT_CA_FILE string
+
+	RETRIEVER_GRPC_TLS_CLIENT_CA_PEM string
+
+	RETRIEVER_GRPC_MAX_MESSAGE_SIZE string
+
 	RETRIEVER_INDEXER_PULL_INTERVAL string
 }
 
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"log"
 	"net/http"
+	"os"
 	"path/filepath"
 	"runtime"
 	"time"
@@ -100,23 +101,18 @@ func DeployResources(pool *dockertest.Pool, localStackPort, metadataTableName, b
 	// exponential backoff-retry, because the application in
 	// the container might not be ready to accept connections yet
 	pool.MaxWait = 10 * time.Second
+	cfg := LocalstackClientConfig(localStackPort)
 	_, b, _, _ := runtime.Caller(0)
 	rootPath := filepath.Join(filepath.Dir(b), "../..")
 	changeDirectory(filepath.Join(rootPath, "inabox"))
 	if err := pool.Retry(func() error {
 		fmt.Println("Creating S3 bucket")
-		return execCmd("./create-s3-bucket.sh", []string{}, []string{fmt.Sprintf("AWS_URL=http://0.0.0.0:%s", localStackPort)})
+		return execCmd("./create-s3-bucket.sh", []string{}, localstackCLIEnv(cfg))
 	}); err != nil {
 		fmt.Println("Could not connect to docker:", err)
 		return err
 	}
 
-	cfg := aws.ClientConfig{
-		Region:          "us-east-1",
-		AccessKey:       "localstack",
-		SecretAccessKey: "localstack",
-		EndpointURL:     fmt.Sprintf("http://0.0.0.0:%s", localStackPort),
-	}
 	_, err := test_utils.CreateTable(context.Background(), cfg, metadataTableName, blobstore.GenerateTableSchema(metadataTableName, 10, 10))
 	if err != nil {
 		return err
@@ -128,6 +124,37 @@ func DeployResources(pool *dockertest.Pool, localStackPort, metadataTableName, b
 
 }
 
+// LocalstackClientConfig returns the config of the AWS clients connecting to the localstack instance used by the tests.
+// It defaults to the container listening on localStackPort. LOCALSTACK_ENDPOINT points the clients at another instance,
+// e.g. a shared one behind TLS, whose certificate is verified with LOCALSTACK_CA_CERT_FILE or not verified at all when
+// LOCALSTACK_INSECURE_SKIP_VERIFY is true.
+func LocalstackClientConfig(localStackPort string) aws.ClientConfig {
+	endpoint := os.Getenv("LOCALSTACK_ENDPOINT")
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("http://0.0.0.0:%s", localStackPort)
+	}
+	return aws.ClientConfig{
+		Region:             "us-east-1",
+		AccessKey:          "localstack",
+		SecretAccessKey:    "localstack",
+		EndpointURL:        endpoint,
+		CACertFile:         os.Getenv("LOCALSTACK_CA_CERT_FILE"),
+		InsecureSkipVerify: os.Getenv("LOCALSTACK_INSECURE_SKIP_VERIFY") == "true",
+	}
+}
+
+// localstackCLIEnv returns the environment of the scripts creating the resources with the AWS CLI
+func localstackCLIEnv(cfg aws.ClientConfig) []string {
+	env := []string{fmt.Sprintf("AWS_URL=%s", cfg.EndpointURL)}
+	if cfg.CACertFile != "" {
+		env = append(env, fmt.Sprintf("AWS_CA_BUNDLE=%s", cfg.CACertFile))
+	}
+	if cfg.InsecureSkipVerify {
+		env = append(env, "AWS_NO_VERIFY_SSL=true")
+	}
+	return env
+}
+
 func PurgeDockertestResources(pool *dockertest.Pool, resource *dockertest.Resource) {
 	fmt.Println("Stopping Dockertest resources")
 	if resource != nil {
@@ -0,0 +1,110 @@
+package e2e_test
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"os/exec"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api/grpc/disperser"
+	"github.com/Layr-Labs/eigenda/clients"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+const numBlobs = 3
+
+// TestEndToEnd disperses blobs through the disperser API server, waits for the batcher to confirm them with the
+// signatures of the nodes, then retrieves them from the disperser and from the nodes.
+//
+// The network runs in process against a local anvil chain, so the test needs foundry but neither docker nor the
+// binaries of the services. It is skipped in short mode.
+func TestEndToEnd(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping the end-to-end test in short mode")
+	}
+	for _, bin := range []string{"anvil", "forge"} {
+		if _, err := exec.LookPath(bin); err != nil {
+			t.Skipf("skipping the end-to-end test: %s is not installed", bin)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	h := newHarness(t)
+	h.startNodes(t, ctx)
+	port := h.startDisperser(t, ctx)
+	h.startBatcher(t, ctx)
+	retrievalClient := h.newRetrievalClient(t, ctx)
+
+	disperserClient := clients.NewDisperserClient(&clients.DisperserClientConfig{
+		Hostname:           "localhost",
+		Port:               port,
+		Timeout:            10 * time.Second,
+		StatusPollInterval: time.Second,
+	})
+	conn, err := grpc.Dial(fmt.Sprintf("localhost:%s", port), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer conn.Close()
+	apiClient := disperser.NewDisperserClient(conn)
+
+	securityParams := []*core.SecurityParam{{QuorumID: 0, AdversaryThreshold: 50, QuorumThreshold: 100}}
+	blobs := make([][]byte, numBlobs)
+	replies := make([]*disperser.BlobStatusReply, numBlobs)
+	for i := range blobs {
+		blobs[i] = make([]byte, 1024*(i+1))
+		_, err := rand.Read(blobs[i])
+		require.NoError(t, err)
+	}
+
+	waitCtx, waitCancel := context.WithTimeout(ctx, 3*time.Minute)
+	defer waitCancel()
+	errs := make(chan error, numBlobs)
+	for i := range blobs {
+		i := i
+		go func() {
+			var err error
+			replies[i], err = disperserClient.DisperseAndWait(waitCtx, blobs[i], securityParams)
+			errs <- err
+		}()
+	}
+	for range blobs {
+		require.NoError(t, <-errs)
+	}
+
+	for i, reply := range replies {
+		proof := reply.GetInfo().GetBlobVerificationProof()
+		batchHeader := proof.GetBatchMetadata().GetBatchHeader()
+
+		retrieved, err := apiClient.RetrieveBlob(ctx, &disperser.RetrieveBlobRequest{
+			BatchHeaderHash: proof.GetBatchMetadata().GetBatchHeaderHash(),
+			BlobIndex:       proof.GetBlobIndex(),
+		})
+		require.NoError(t, err)
+		assertBlob(t, blobs[i], retrieved.GetData(), "blob %d retrieved from the disperser", i)
+
+		data, err := retrievalClient.RetrieveBlob(ctx,
+			[32]byte(proof.GetBatchMetadata().GetBatchHeaderHash()),
+			proof.GetBlobIndex(),
+			uint(batchHeader.GetReferenceBlockNumber()),
+			[32]byte(batchHeader.GetBatchRoot()),
+			0,
+		)
+		require.NoError(t, err)
+		assertBlob(t, blobs[i], data, "blob %d retrieved from the nodes", i)
+	}
+}
+
+// assertBlob asserts that the retrieved data is the blob, padded with zeros to the length of its encoding
+func assertBlob(t *testing.T, blob, data []byte, msgAndArgs ...interface{}) {
+	if assert.GreaterOrEqual(t, len(data), len(blob), msgAndArgs...) {
+		assert.Equal(t, blob, data[:len(blob)], msgAndArgs...)
+		assert.Equal(t, make([]byte, len(data)-len(blob)), data[len(blob):], msgAndArgs...)
+	}
+}
@@ -0,0 +1,273 @@
+package e2e_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/clients"
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/common/geth"
+	"github.com/Layr-Labs/eigenda/common/logging"
+	"github.com/Layr-Labs/eigenda/common/pubip"
+	"github.com/Layr-Labs/eigenda/common/ratelimit"
+	"github.com/Layr-Labs/eigenda/common/store"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/core/encoding"
+	coreeth "github.com/Layr-Labs/eigenda/core/eth"
+	coreindexer "github.com/Layr-Labs/eigenda/core/indexer"
+	"github.com/Layr-Labs/eigenda/disperser"
+	"github.com/Layr-Labs/eigenda/disperser/apiserver"
+	"github.com/Layr-Labs/eigenda/disperser/batcher"
+	batchereth "github.com/Layr-Labs/eigenda/disperser/batcher/eth"
+	dispatcher "github.com/Layr-Labs/eigenda/disperser/batcher/grpc"
+	"github.com/Layr-Labs/eigenda/disperser/common/inmem"
+	"github.com/Layr-Labs/eigenda/inabox/deploy"
+	"github.com/Layr-Labs/eigenda/indexer"
+	inmemstore "github.com/Layr-Labs/eigenda/indexer/inmem"
+	"github.com/Layr-Labs/eigenda/node"
+	nodeflags "github.com/Layr-Labs/eigenda/node/flags"
+	nodegrpc "github.com/Layr-Labs/eigenda/node/grpc"
+	"github.com/Layr-Labs/eigenda/pkg/encoding/kzgEncoder"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli"
+)
+
+const (
+	// rootPath is the root of the repository, relative to the directory of the test
+	rootPath = "../.."
+	// templateName is the inabox template of the experiment. The indexers are built in, so that the experiment doesn't
+	// need a graph node.
+	templateName = "testconfig-anvil-nograph.yaml"
+
+	// The rate limits of the nodes, as set by the node binary
+	nodeBucketStoreSize          = 10000
+	nodeBucketMultiplier float32 = 2
+	nodeBucketDuration           = 450 * time.Second
+)
+
+// harness is an EigenDA network run in process against a local anvil chain: the nodes, the batcher and the disperser
+// API server run as goroutines of the test, sharing an in-memory blob store instead of S3 and DynamoDB
+type harness struct {
+	config    *deploy.Config
+	logger    common.Logger
+	blobStore disperser.BlobStore
+}
+
+// newHarness starts anvil, deploys the EigenDA contracts to it and generates the configuration of the experiment. The
+// anvil chain is stopped when the test completes.
+func newHarness(t *testing.T) *harness {
+	testName, err := deploy.CreateNewTestDirectory(templateName, rootPath)
+	require.NoError(t, err)
+	config := deploy.NewTestConfig(testName, rootPath)
+
+	config.StartAnvil()
+	t.Cleanup(config.StopAnvil)
+
+	config.DeployExperiment()
+	// The deployment logs to a file it has closed
+	log.SetOutput(os.Stderr)
+
+	logger, err := logging.GetLogger(logging.DefaultCLIConfig())
+	require.NoError(t, err)
+
+	return &harness{
+		config:    config,
+		logger:    logger,
+		blobStore: inmem.NewBlobStore(),
+	}
+}
+
+// loadEnv replaces the environment variables with the given prefix by the ones of the env file
+func loadEnv(t *testing.T, filename, prefix string) {
+	for _, variable := range os.Environ() {
+		name, _, _ := strings.Cut(variable, "=")
+		if strings.HasPrefix(name, prefix) {
+			require.NoError(t, os.Unsetenv(name))
+		}
+	}
+	for name, value := range deploy.ReadEnv(filename) {
+		t.Setenv(name, value)
+	}
+}
+
+// parseFlags runs the action on the cli context of the flags, read from the environment
+func parseFlags(t *testing.T, flags []cli.Flag, action func(ctx *cli.Context) error) {
+	app := cli.NewApp()
+	app.Flags = flags
+	app.Action = action
+	require.NoError(t, app.Run([]string{"e2e"}))
+}
+
+// encoderConfig is the configuration of the encoders of the batcher and the retrieval client, sharing the SRS of the
+// retriever
+func (h *harness) encoderConfig(t *testing.T) encoding.EncoderConfig {
+	vars := h.config.Retriever
+	srsOrder, err := strconv.ParseUint(vars.RETRIEVER_SRS_ORDER, 10, 64)
+	require.NoError(t, err)
+	return encoding.EncoderConfig{
+		KzgConfig: kzgEncoder.KzgConfig{
+			G1Path:         vars.RETRIEVER_G1_PATH,
+			G2Path:         vars.RETRIEVER_G2_PATH,
+			CacheDir:       vars.RETRIEVER_CACHE_PATH,
+			NumWorker:      1,
+			SRSOrder:       srsOrder,
+			PreloadEncoder: true,
+		},
+	}
+}
+
+// newIndexedChainState creates an indexed chain state backed by the built-in indexer
+func (h *harness) newIndexedChainState(t *testing.T, client *geth.EthClient, tx core.Transactor) core.IndexedChainState {
+	rpcClient, err := rpc.Dial(h.config.Deployers[0].RPC)
+	require.NoError(t, err)
+	cs := coreeth.NewChainState(tx, client)
+	ics, err := coreindexer.NewIndexedChainState(&indexer.Config{PullInterval: time.Second}, gethcommon.HexToAddress(h.config.EigenDA.ServiceManager), cs, inmemstore.NewHeaderStore(), client, rpcClient, h.logger)
+	require.NoError(t, err)
+	return ics
+}
+
+// newTransactor creates a transactor of the EigenDA contracts, sending the transactions with the private key
+func (h *harness) newTransactor(t *testing.T, privateKey string) (*geth.EthClient, core.Transactor) {
+	client, err := geth.NewClient(geth.EthClientConfig{
+		RPCURL:           h.config.Deployers[0].RPC,
+		PrivateKeyString: privateKey,
+	}, h.logger)
+	require.NoError(t, err)
+	tx, err := coreeth.NewTransactor(h.logger, client, h.config.EigenDA.OperatorStateRetreiver, h.config.EigenDA.ServiceManager)
+	require.NoError(t, err)
+	return client, tx
+}
+
+// startNodes starts the nodes of the experiment with the configuration of their env files. The nodes register
+// themselves on chain when they start.
+func (h *harness) startNodes(t *testing.T, ctx context.Context) {
+	for i := range h.config.Operators {
+		loadEnv(t, filepath.Join(h.config.Path, "envs", fmt.Sprintf("opr%d.env", i)), nodeflags.EnvVarPrefix+"_")
+		var config *node.Config
+		parseFlags(t, nodeflags.Flags, func(ctx *cli.Context) error {
+			var err error
+			config, err = node.NewConfig(ctx)
+			return err
+		})
+
+		n, err := node.NewNode(config, pubip.ProviderOrDefault(config.PubIPProvider), h.logger)
+		require.NoError(t, err)
+		require.NoError(t, n.Start(ctx))
+
+		bucketStore, err := store.NewLocalParamStore[common.RateBucketParams](nodeBucketStoreSize)
+		require.NoError(t, err)
+		ratelimiter := ratelimit.NewRateLimiter(common.GlobalRateParams{
+			BucketSizes: []time.Duration{nodeBucketDuration},
+			Multipliers: []float32{nodeBucketMultiplier},
+			CountFailed: true,
+		}, bucketStore, h.logger)
+		require.NoError(t, nodegrpc.NewServer(config, n, h.logger, ratelimiter).Start())
+		waitForPort(t, config.InternalDispersalPort)
+		waitForPort(t, config.InternalRetrievalPort)
+	}
+}
+
+// startDisperser starts the disperser API server, without rate limits
+func (h *harness) startDisperser(t *testing.T, ctx context.Context) string {
+	vars := h.config.Dispersers[0]
+	_, tx := h.newTransactor(t, vars.DISPERSER_SERVER_PRIVATE_KEY)
+	metrics := disperser.NewMetrics(vars.DISPERSER_SERVER_METRICS_HTTP_PORT, nil, h.logger)
+	server := apiserver.NewDispersalServer(disperser.ServerConfig{
+		GrpcPort:               vars.DISPERSER_SERVER_GRPC_PORT,
+		DisperseRequestTimeout: 30 * time.Second,
+	}, h.blobStore, tx, h.logger, metrics, nil, apiserver.RateConfig{})
+	go func() {
+		if err := server.Start(ctx); err != nil {
+			h.logger.Error("disperser API server failed", "err", err)
+		}
+	}()
+	waitForPort(t, vars.DISPERSER_SERVER_GRPC_PORT)
+	return vars.DISPERSER_SERVER_GRPC_PORT
+}
+
+// startBatcher starts the batcher, encoding the blobs in process and confirming each batch before making the next one
+func (h *harness) startBatcher(t *testing.T, ctx context.Context) {
+	vars := h.config.Batcher[0]
+	client, tx := h.newTransactor(t, vars.BATCHER_PRIVATE_KEY)
+	srsOrder, err := strconv.Atoi(vars.BATCHER_SRS_ORDER)
+	require.NoError(t, err)
+
+	timeouts := batcher.TimeoutConfig{
+		EncodingTimeout:    10 * time.Second,
+		AttestationTimeout: 20 * time.Second,
+		ChainReadTimeout:   5 * time.Second,
+		ChainWriteTimeout:  90 * time.Second,
+	}
+	config := batcher.Config{
+		PullInterval:              time.Second,
+		FinalizerInterval:         6 * time.Minute,
+		SRSOrder:                  srsOrder,
+		NumConnections:            50,
+		EncodingRequestQueueSize:  500,
+		BatchSizeMBLimit:          10240,
+		MaxNumRetriesPerBlob:      2,
+		ConfirmationRetryInterval: 5 * time.Second,
+		MaxConfirmationAttempts:   5,
+	}
+
+	confirmer, err := batchereth.NewBatchConfirmer(tx, timeouts.ChainWriteTimeout)
+	require.NoError(t, err)
+	signingKey, err := crypto.HexToECDSA(vars.BATCHER_PRIVATE_KEY)
+	require.NoError(t, err)
+	enc, err := encoding.NewEncoder(h.encoderConfig(t))
+	require.NoError(t, err)
+	rpcClient, err := rpc.Dial(h.config.Deployers[0].RPC)
+	require.NoError(t, err)
+
+	finalizer := batcher.NewFinalizer(timeouts.ChainReadTimeout, config.FinalizerInterval, h.blobStore, client, rpcClient, config.MaxNumRetriesPerBlob, h.logger)
+	b, err := batcher.NewBatcher(
+		config,
+		timeouts,
+		h.blobStore,
+		dispatcher.NewDispatcher(&dispatcher.Config{Timeout: timeouts.AttestationTimeout, SigningKey: signingKey}, h.logger),
+		confirmer,
+		h.newIndexedChainState(t, client, tx),
+		&core.StdAssignmentCoordinator{},
+		disperser.NewLocalEncoderClient(enc),
+		core.NewStdSignatureAggregator(h.logger),
+		client,
+		finalizer,
+		h.logger,
+		batcher.NewMetrics(vars.BATCHER_METRICS_HTTP_PORT, nil, h.logger),
+	)
+	require.NoError(t, err)
+	require.NoError(t, b.Start(ctx))
+}
+
+// newRetrievalClient creates a retrieval client, retrieving the blobs from the nodes like the retriever does
+func (h *harness) newRetrievalClient(t *testing.T, ctx context.Context) clients.RetrievalClient {
+	client, tx := h.newTransactor(t, h.config.Retriever.RETRIEVER_PRIVATE_KEY)
+	ics := h.newIndexedChainState(t, client, tx)
+	require.NoError(t, ics.Start(ctx))
+	enc, err := encoding.NewEncoder(h.encoderConfig(t))
+	require.NoError(t, err)
+	return clients.NewRetrievalClient(h.logger, ics, &core.StdAssignmentCoordinator{}, clients.NewNodeClient(20*time.Second, 0, nil), enc, 10)
+}
+
+// waitForPort waits for a server to listen on the local port
+func waitForPort(t *testing.T, port string) {
+	require.Eventually(t, func() bool {
+		conn, err := net.Dial("tcp", net.JoinHostPort("localhost", port))
+		if err != nil {
+			return false
+		}
+		_ = conn.Close()
+		return true
+	}, 30*time.Second, 100*time.Millisecond, "nothing listens on port %s", port)
+}
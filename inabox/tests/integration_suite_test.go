@@ -138,7 +138,7 @@ func setupRetrievalClient(testConfig *deploy.Config) error {
 	querier := graphql.NewClient(testConfig.Churner.CHURNER_GRAPH_URL, nil)
 	ics := thegraph.NewIndexedChainState(cs, querier, logger)
 	agn := &core.StdAssignmentCoordinator{}
-	nodeClient := clients.NewNodeClient(20 * time.Second)
+	nodeClient := clients.NewNodeClient(20*time.Second, 0, nil)
 	srsOrder, err := strconv.Atoi(testConfig.Retriever.RETRIEVER_SRS_ORDER)
 	if err != nil {
 		return err
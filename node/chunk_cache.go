@@ -0,0 +1,125 @@
+package node
+
+import (
+	"container/list"
+	"sync"
+
+	"github.com/Layr-Labs/eigenda/core"
+)
+
+// ChunkCacheKey identifies the chunks of a blob for a quorum
+type ChunkCacheKey struct {
+	BatchHeaderHash [32]byte
+	BlobIndex       int
+	QuorumID        core.QuorumID
+}
+
+// CachedChunks holds the serialized chunks of a blob for a quorum along with what is needed to serve them to
+// retrievers without reading the store.
+type CachedChunks struct {
+	Chunks [][]byte
+	// EncodedBlobSize is the size of the encoded blob for the quorum, which retrievals are rate limited by
+	EncodedBlobSize uint
+	// Rate is the quorum rate of the blob
+	Rate uint32
+}
+
+func (c *CachedChunks) size() uint64 {
+	var size uint64
+	for _, chunk := range c.Chunks {
+		size += uint64(len(chunk))
+	}
+	return size
+}
+
+// ChunkCache is an LRU cache of the chunks served to retrievers, so that retrievals of hot blobs don't read and
+// deserialize the same entries from the store again. It is bounded by the total size of the cached chunks and is safe
+// for concurrent use.
+type ChunkCache struct {
+	mu       sync.Mutex
+	capacity uint64
+	size     uint64
+
+	// order holds the entries from the most to the least recently used
+	order   *list.List
+	entries map[ChunkCacheKey]*list.Element
+}
+
+type chunkCacheEntry struct {
+	key    ChunkCacheKey
+	chunks *CachedChunks
+	size   uint64
+}
+
+// NewChunkCache creates a cache holding at most capacity bytes of chunks
+func NewChunkCache(capacity uint64) *ChunkCache {
+	return &ChunkCache{
+		capacity: capacity,
+		order:    list.New(),
+		entries:  make(map[ChunkCacheKey]*list.Element),
+	}
+}
+
+// Get returns the cached chunks for the key. The returned chunks must not be modified.
+func (c *ChunkCache) Get(key ChunkCacheKey) (*CachedChunks, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToFront(elem)
+	return elem.Value.(*chunkCacheEntry).chunks, true
+}
+
+// Add caches the chunks, evicting the least recently used entries until they fit. Chunks larger than the capacity are
+// not cached.
+func (c *ChunkCache) Add(key ChunkCacheKey, chunks *CachedChunks) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.entries[key]; ok {
+		c.remove(elem)
+	}
+
+	size := chunks.size()
+	if size > c.capacity {
+		return
+	}
+	for c.size+size > c.capacity {
+		c.remove(c.order.Back())
+	}
+
+	c.entries[key] = c.order.PushFront(&chunkCacheEntry{
+		key:    key,
+		chunks: chunks,
+		size:   size,
+	})
+	c.size += size
+}
+
+// RemoveBatch removes the chunks of all the blobs of the batch, e.g. once the batch has expired
+func (c *ChunkCache) RemoveBatch(batchHeaderHash [32]byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key, elem := range c.entries {
+		if key.BatchHeaderHash == batchHeaderHash {
+			c.remove(elem)
+		}
+	}
+}
+
+// Len returns the number of cached entries
+func (c *ChunkCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.entries)
+}
+
+func (c *ChunkCache) remove(elem *list.Element) {
+	entry := c.order.Remove(elem).(*chunkCacheEntry)
+	delete(c.entries, entry.key)
+	c.size -= entry.size
+}
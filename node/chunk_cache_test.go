@@ -0,0 +1,51 @@
+package node_test
+
+import (
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/node"
+	"github.com/stretchr/testify/assert"
+)
+
+func makeCachedChunks(numChunks, chunkSize int) *node.CachedChunks {
+	chunks := make([][]byte, numChunks)
+	for i := range chunks {
+		chunks[i] = make([]byte, chunkSize)
+	}
+	return &node.CachedChunks{Chunks: chunks}
+}
+
+func TestChunkCache(t *testing.T) {
+	cache := node.NewChunkCache(100)
+	batch1 := [32]byte{1}
+	batch2 := [32]byte{2}
+	key := func(batch [32]byte, blobIndex int) node.ChunkCacheKey {
+		return node.ChunkCacheKey{BatchHeaderHash: batch, BlobIndex: blobIndex}
+	}
+
+	cache.Add(key(batch1, 0), makeCachedChunks(4, 10))
+	cache.Add(key(batch1, 1), makeCachedChunks(4, 10))
+	assert.Equal(t, 2, cache.Len())
+
+	// Using the first entry makes the second one the least recently used
+	_, ok := cache.Get(key(batch1, 0))
+	assert.True(t, ok)
+	cache.Add(key(batch2, 0), makeCachedChunks(3, 10))
+	assert.Equal(t, 2, cache.Len())
+	_, ok = cache.Get(key(batch1, 1))
+	assert.False(t, ok)
+
+	// Chunks larger than the capacity aren't cached
+	cache.Add(key(batch2, 1), makeCachedChunks(2, 51))
+	_, ok = cache.Get(key(batch2, 1))
+	assert.False(t, ok)
+	assert.Equal(t, 2, cache.Len())
+
+	// Removing a batch only removes its own blobs
+	cache.RemoveBatch(batch1)
+	_, ok = cache.Get(key(batch1, 0))
+	assert.False(t, ok)
+	cached, ok := cache.Get(key(batch2, 0))
+	assert.True(t, ok)
+	assert.Len(t, cached.Chunks, 3)
+}
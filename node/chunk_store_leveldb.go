@@ -0,0 +1,91 @@
+package node
+
+import (
+	"errors"
+	"io/fs"
+	"path/filepath"
+
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+// LevelDBChunkStore is a ChunkStore with LevelDB as the backend engine.
+type LevelDBChunkStore struct {
+	db   *leveldb.DB
+	path string
+}
+
+var _ ChunkStore = (*LevelDBChunkStore)(nil)
+
+// NewLevelDBChunkStore opens the LevelDB database at path, creating it if it doesn't exist
+func NewLevelDBChunkStore(path string) (*LevelDBChunkStore, error) {
+	db, err := leveldb.OpenFile(path, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &LevelDBChunkStore{db: db, path: path}, nil
+}
+
+func (s *LevelDBChunkStore) PutBatch(keys, values [][]byte) error {
+	batch := new(leveldb.Batch)
+	for i, key := range keys {
+		batch.Put(key, values[i])
+	}
+	return s.db.Write(batch, nil)
+}
+
+func (s *LevelDBChunkStore) Get(key []byte) ([]byte, error) {
+	data, err := s.db.Get(key, nil)
+	if errors.Is(err, leveldb.ErrNotFound) {
+		return nil, ErrKeyNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+func (s *LevelDBChunkStore) DeleteBatch(keys [][]byte) error {
+	batch := new(leveldb.Batch)
+	for _, key := range keys {
+		batch.Delete(key)
+	}
+	return s.db.Write(batch, nil)
+}
+
+func (s *LevelDBChunkStore) Iterate(prefix []byte, f func(key, value []byte) bool) error {
+	iter := s.db.NewIterator(util.BytesPrefix(prefix), nil)
+	defer iter.Release()
+	for iter.Next() {
+		if !f(iter.Key(), iter.Value()) {
+			break
+		}
+	}
+	return iter.Error()
+}
+
+// Size returns the disk usage of the database, i.e. the total size of the files in its directory, which includes the
+// write-ahead log and the tables not compacted yet
+func (s *LevelDBChunkStore) Size() (uint64, error) {
+	size := uint64(0)
+	err := filepath.WalkDir(s.path, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		size += uint64(info.Size())
+		return nil
+	})
+	return size, err
+}
+
+// Close closes the database
+func (s *LevelDBChunkStore) Close() error {
+	return s.db.Close()
+}
@@ -0,0 +1,86 @@
+package node
+
+import (
+	"sort"
+	"strings"
+	"sync"
+)
+
+// MemoryChunkStore is a ChunkStore keeping the key-value pairs in memory
+type MemoryChunkStore struct {
+	mu     sync.RWMutex
+	values map[string][]byte
+}
+
+var _ ChunkStore = (*MemoryChunkStore)(nil)
+
+// NewMemoryChunkStore creates an empty MemoryChunkStore
+func NewMemoryChunkStore() *MemoryChunkStore {
+	return &MemoryChunkStore{
+		values: make(map[string][]byte),
+	}
+}
+
+func (s *MemoryChunkStore) PutBatch(keys, values [][]byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, key := range keys {
+		s.values[string(key)] = copyBytes(values[i])
+	}
+	return nil
+}
+
+func (s *MemoryChunkStore) Get(key []byte) ([]byte, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	value, ok := s.values[string(key)]
+	if !ok {
+		return nil, ErrKeyNotFound
+	}
+	return copyBytes(value), nil
+}
+
+func (s *MemoryChunkStore) DeleteBatch(keys [][]byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, key := range keys {
+		delete(s.values, string(key))
+	}
+	return nil
+}
+
+func (s *MemoryChunkStore) Iterate(prefix []byte, f func(key, value []byte) bool) error {
+	// Iterate over a snapshot of the matching pairs, so that f may write to the store
+	s.mu.RLock()
+	keys := make([]string, 0)
+	for key := range s.values {
+		if strings.HasPrefix(key, string(prefix)) {
+			keys = append(keys, key)
+		}
+	}
+	values := make(map[string][]byte, len(keys))
+	for _, key := range keys {
+		values[key] = s.values[key]
+	}
+	s.mu.RUnlock()
+
+	// Strings compare bytewise, as the keys of LevelDB
+	sort.Strings(keys)
+	for _, key := range keys {
+		if !f([]byte(key), copyBytes(values[key])) {
+			break
+		}
+	}
+	return nil
+}
+
+// Size returns the total size of the keys and values in memory
+func (s *MemoryChunkStore) Size() (uint64, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	size := uint64(0)
+	for key, value := range s.values {
+		size += uint64(len(key) + len(value))
+	}
+	return size, nil
+}
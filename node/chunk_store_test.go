@@ -0,0 +1,141 @@
+package node_test
+
+import (
+	"crypto/rand"
+	"fmt"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/node"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// chunkStoreBackends makes an empty chunk store of each backend
+var chunkStoreBackends = map[string]func(t testing.TB) node.ChunkStore{
+	node.LevelDBBackend: func(t testing.TB) node.ChunkStore {
+		store, err := node.NewLevelDBChunkStore(t.TempDir())
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = store.Close() })
+		return store
+	},
+	node.MemoryBackend: func(t testing.TB) node.ChunkStore {
+		return node.NewMemoryChunkStore()
+	},
+}
+
+// iterate returns the key-value pairs under the prefix, in iteration order
+func iterate(t *testing.T, store node.ChunkStore, prefix string) []string {
+	pairs := make([]string, 0)
+	err := store.Iterate([]byte(prefix), func(key, value []byte) bool {
+		pairs = append(pairs, string(key)+"="+string(value))
+		return true
+	})
+	require.NoError(t, err)
+	return pairs
+}
+
+// TestChunkStoreConformance checks that all the backends behave the same
+func TestChunkStoreConformance(t *testing.T) {
+	for backend, newStore := range chunkStoreBackends {
+		newStore := newStore
+		t.Run(backend, func(t *testing.T) {
+			t.Run("PutBatch", func(t *testing.T) {
+				store := newStore(t)
+				_, err := store.Get([]byte("a"))
+				assert.ErrorIs(t, err, node.ErrKeyNotFound)
+
+				value := []byte("1")
+				require.NoError(t, store.PutBatch([][]byte{[]byte("a"), []byte("b")}, [][]byte{value, []byte("2")}))
+				// The store keeps its own copy of the values
+				value[0] = 'x'
+				data, err := store.Get([]byte("a"))
+				assert.NoError(t, err)
+				assert.Equal(t, []byte("1"), data)
+
+				// Writing a key again overwrites its value
+				require.NoError(t, store.PutBatch([][]byte{[]byte("b")}, [][]byte{[]byte("3")}))
+				data, err = store.Get([]byte("b"))
+				assert.NoError(t, err)
+				assert.Equal(t, []byte("3"), data)
+
+				require.NoError(t, store.PutBatch(nil, nil))
+			})
+
+			t.Run("DeleteBatch", func(t *testing.T) {
+				store := newStore(t)
+				require.NoError(t, store.PutBatch([][]byte{[]byte("a"), []byte("b"), []byte("c")}, [][]byte{[]byte("1"), []byte("2"), []byte("3")}))
+				// Deleting keys which aren't stored is not an error
+				require.NoError(t, store.DeleteBatch([][]byte{[]byte("a"), []byte("c"), []byte("d")}))
+				_, err := store.Get([]byte("a"))
+				assert.ErrorIs(t, err, node.ErrKeyNotFound)
+				_, err = store.Get([]byte("c"))
+				assert.ErrorIs(t, err, node.ErrKeyNotFound)
+				assert.Equal(t, []string{"b=2"}, iterate(t, store, ""))
+			})
+
+			t.Run("Iterate", func(t *testing.T) {
+				store := newStore(t)
+				keys := [][]byte{[]byte("p\x02"), []byte("q"), []byte("p\x01"), []byte("p\xff"), []byte("o")}
+				values := [][]byte{[]byte("2"), []byte("q"), []byte("1"), []byte("3"), []byte("o")}
+				require.NoError(t, store.PutBatch(keys, values))
+
+				// The keys are iterated in bytewise order, restricted to the prefix
+				assert.Equal(t, []string{"p\x01=1", "p\x02=2", "p\xff=3"}, iterate(t, store, "p"))
+				assert.Equal(t, []string{"o=o", "p\x01=1", "p\x02=2", "p\xff=3", "q=q"}, iterate(t, store, ""))
+				assert.Empty(t, iterate(t, store, "r"))
+
+				// The iteration stops once f returns false
+				visited := 0
+				err := store.Iterate([]byte("p"), func(key, value []byte) bool {
+					visited++
+					return visited < 2
+				})
+				assert.NoError(t, err)
+				assert.Equal(t, 2, visited)
+			})
+
+			t.Run("Size", func(t *testing.T) {
+				store := newStore(t)
+				empty, err := store.Size()
+				require.NoError(t, err)
+
+				// The size covers at least the written values
+				value := make([]byte, 1<<16)
+				require.NoError(t, store.PutBatch([][]byte{[]byte("a")}, [][]byte{value}))
+				size, err := store.Size()
+				require.NoError(t, err)
+				assert.GreaterOrEqual(t, size, empty+uint64(len(value)))
+			})
+		})
+	}
+}
+
+// BenchmarkChunkStorePutBatch measures the write throughput of each backend for a 1 GiB batch of 1 MiB chunks
+func BenchmarkChunkStorePutBatch(b *testing.B) {
+	const chunkSize = 1 << 20
+	const numChunks = 1 << 10
+	chunk := make([]byte, chunkSize)
+	_, err := rand.Read(chunk)
+	require.NoError(b, err)
+	keys := make([][]byte, numChunks)
+	values := make([][]byte, numChunks)
+	for i := range keys {
+		keys[i] = []byte(fmt.Sprintf("chunk-%d", i))
+		values[i] = chunk
+	}
+
+	for backend, newStore := range chunkStoreBackends {
+		newStore := newStore
+		b.Run(backend, func(b *testing.B) {
+			b.SetBytes(chunkSize * numChunks)
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				store := newStore(b)
+				b.StartTimer()
+				if err := store.PutBatch(keys, values); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"os/signal"
+	"syscall"
 	"time"
 
 	"github.com/Layr-Labs/eigenda/common/pubip"
@@ -15,6 +17,7 @@ import (
 	"github.com/Layr-Labs/eigenda/common/logging"
 	"github.com/Layr-Labs/eigenda/common/ratelimit"
 	"github.com/Layr-Labs/eigenda/common/store"
+	"github.com/Layr-Labs/eigenda/core"
 	"github.com/Layr-Labs/eigenda/node"
 	"github.com/Layr-Labs/eigenda/node/flags"
 	"github.com/Layr-Labs/eigenda/node/grpc"
@@ -39,8 +42,6 @@ func main() {
 	if err != nil {
 		log.Fatalf("application failed: %v", err)
 	}
-
-	select {}
 }
 
 func NodeMain(ctx *cli.Context) error {
@@ -63,12 +64,6 @@ func NodeMain(ctx *cli.Context) error {
 		return err
 	}
 
-	err = node.Start(context.Background())
-	if err != nil {
-		node.Logger.Error("could not start node", "error", err)
-		return err
-	}
-
 	globalParams := common.GlobalRateParams{
 		BucketSizes: []time.Duration{bucketDuration},
 		Multipliers: []float32{bucketMultiplier},
@@ -84,7 +79,36 @@ func NodeMain(ctx *cli.Context) error {
 
 	// Creates the GRPC server.
 	server := grpc.NewServer(config, node, logger, ratelimiter)
-	server.Start()
 
-	return nil
+	// SIGHUP reloads the BLS key of the operator, e.g. once a new key is registered on chain
+	reloadSignal := make(chan os.Signal, 1)
+	signal.Notify(reloadSignal, syscall.SIGHUP)
+	go func() {
+		for range reloadSignal {
+			if err := rotateOperatorKey(ctx, node); err != nil {
+				logger.Error("failed to reload the BLS key of the operator, keeping the current one", "err", err)
+			}
+		}
+	}()
+
+	// The node registers itself before the servers accept the chunks of the batches
+	return common.Run(context.Background(), logger, 0,
+		common.NewBackgroundComponent("node", node.Start),
+		common.NewComponent("grpc server", func(ctx context.Context) error {
+			return server.Start()
+		}, server.Stop),
+	)
+}
+
+// rotateOperatorKey reads the BLS key of the operator again and rotates the node to it
+func rotateOperatorKey(ctx *cli.Context, n *node.Node) error {
+	privateBls, err := node.ReadPrivateBls(ctx)
+	if err != nil {
+		return err
+	}
+	keyPair, err := core.MakeKeyPairFromString(privateBls)
+	if err != nil {
+		return err
+	}
+	return n.RotateOperatorKey(context.Background(), keyPair)
 }
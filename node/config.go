@@ -8,13 +8,16 @@ import (
 	"strings"
 	"time"
 
+	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/common/geth"
+	commongrpc "github.com/Layr-Labs/eigenda/common/grpc"
 	"github.com/Layr-Labs/eigenda/common/logging"
 	"github.com/Layr-Labs/eigenda/core"
 	"github.com/Layr-Labs/eigenda/core/encoding"
 	"github.com/Layr-Labs/eigenda/node/flags"
 	"github.com/Layr-Labs/eigensdk-go/crypto/bls"
 	"github.com/ethereum/go-ethereum/accounts/keystore"
+	gethcommon "github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/urfave/cli"
 )
@@ -35,27 +38,38 @@ var (
 		0: "eth_quorum",
 		1: "permissioned_quorum",
 	}
+	// SupportedFeatures is the bitmask of the optional features of this software, advertised to the dispersers along
+	// with the signatures of the batches. No optional feature is implemented by the node yet.
+	SupportedFeatures core.NodeFeatures = 0
 )
 
 // Config contains all of the configuration information for a DA node.
 type Config struct {
-	Hostname                      string
-	RetrievalPort                 string
-	DispersalPort                 string
-	InternalRetrievalPort         string
-	InternalDispersalPort         string
-	EnableNodeApi                 bool
-	NodeApiPort                   string
-	EnableMetrics                 bool
-	MetricsPort                   string
-	Timeout                       time.Duration
-	RegisterNodeAtStart           bool
-	ExpirationPollIntervalSec     uint64
+	Hostname                  string
+	RetrievalPort             string
+	DispersalPort             string
+	InternalRetrievalPort     string
+	InternalDispersalPort     string
+	EnableNodeApi             bool
+	NodeApiPort               string
+	EnableMetrics             bool
+	MetricsPort               string
+	Timeout                   time.Duration
+	RegisterNodeAtStart       bool
+	ExpirationPollIntervalSec uint64
+	QuorumPollInterval        time.Duration
+	MaxReferenceBlockAge      uint
+	MinReferenceBlockAge      uint
+	// DispersalDeadlineSkew is the tolerated skew between the clocks of the node and the disperser, by which the
+	// batches are still stored and signed past their dispersal deadline
+	DispersalDeadlineSkew         time.Duration
+	RetrievalCacheSize            uint64
 	EnableTestMode                bool
 	OverrideBlockStaleMeasure     int64
 	OverrideStoreDurationBlocks   int64
 	QuorumIDList                  []core.QuorumID
 	DbPath                        string
+	DbBackend                     string
 	LogPath                       string
 	PrivateBls                    string
 	ID                            core.OperatorID
@@ -65,12 +79,41 @@ type Config struct {
 	PubIPCheckInterval            time.Duration
 	ChurnerUrl                    string
 	NumBatchValidators            int
+	QuorumValidationConcurrency   uint
 	ClientIPHeader                string
-	UseSecureGrpc                 bool
+	// DisperserAddress is the address of the key with which the disperser signs the batch headers of its StoreChunks
+	// requests
+	DisperserAddress gethcommon.Address
+	// DisableDisperserAuth accepts the StoreChunks requests without checking the signature of the disperser
+	DisableDisperserAuth bool
+	// DispersalRateLimit is the rate in bytes per second at which each peer may send StoreChunks requests, or 0 for
+	// no limit
+	DispersalRateLimit common.RateParam
 
 	EthClientConfig geth.EthClientConfig
 	LoggingConfig   logging.Config
 	EncoderConfig   encoding.EncoderConfig
+	// TLSConfig configures the transport security of the dispersal and retrieval gRPC servers
+	TLSConfig commongrpc.TLSConfig
+	// ChurnerTLSConfig configures the transport security of the client of the churner, which always uses TLS outside
+	// of the test mode
+	ChurnerTLSConfig commongrpc.ClientTLSConfig
+	// MaxGRPCMessageSize is the max size in bytes of the messages received and sent by the dispersal and retrieval
+	// gRPC servers. The dispersers must not send StoreChunks requests larger than this.
+	MaxGRPCMessageSize int
+}
+
+// ReadPrivateBls reads the BLS private key of the operator from its encrypted file, or from the test flag in test mode.
+// The key is read again when the node reloads it on SIGHUP.
+func ReadPrivateBls(ctx *cli.Context) (string, error) {
+	if ctx.GlobalBool(flags.EnableTestModeFlag.Name) {
+		return ctx.GlobalString(flags.TestPrivateBlsFlag.Name), nil
+	}
+	kp, err := bls.ReadPrivateKeyFromFile(ctx.GlobalString(flags.BlsKeyFileFlag.Name), ctx.GlobalString(flags.BlsKeyPasswordFlag.Name))
+	if err != nil {
+		return "", fmt.Errorf("could not read or decrypt the BLS private key: %v", err)
+	}
+	return kp.PrivKey.String(), nil
 }
 
 // NewConfig parses the Config from the provided flags or environment variables and
@@ -91,9 +134,10 @@ func NewConfig(ctx *cli.Context) (*Config, error) {
 		ids = append(ids, core.QuorumID(val))
 	}
 
-	expirationPollIntervalSec := ctx.GlobalUint64(flags.ExpirationPollIntervalSecFlag.Name)
-	if expirationPollIntervalSec <= minExpirationPollIntervalSec {
-		return nil, errors.New("the expiration-poll-interval flag must be greater than 3 seconds")
+	disableDisperserAuth := ctx.GlobalBool(flags.DisableDisperserAuthFlag.Name)
+	disperserAddress := ctx.GlobalString(flags.DisperserAddressFlag.Name)
+	if !disableDisperserAuth && !gethcommon.IsHexAddress(disperserAddress) {
+		return nil, fmt.Errorf("the disperser-address flag must be a valid address unless disable-disperser-auth is set, but found %q", disperserAddress)
 	}
 
 	testMode := ctx.GlobalBool(flags.EnableTestModeFlag.Name)
@@ -115,16 +159,14 @@ func NewConfig(ctx *cli.Context) (*Config, error) {
 		ethClientConfig = geth.ReadEthClientConfig(ctx)
 	}
 
-	// Decrypt BLS key
-	var privateBls string
+	privateBls, err := ReadPrivateBls(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	churnerTLSConfig := commongrpc.ReadClientTLSCLIConfig(ctx, flags.FlagPrefix)
 	if !testMode {
-		kp, err := bls.ReadPrivateKeyFromFile(ctx.GlobalString(flags.BlsKeyFileFlag.Name), ctx.GlobalString(flags.BlsKeyPasswordFlag.Name))
-		if err != nil {
-			return nil, fmt.Errorf("could not read or decrypt the BLS private key: %v", err)
-		}
-		privateBls = kp.PrivKey.String()
-	} else {
-		privateBls = ctx.GlobalString(flags.TestPrivateBlsFlag.Name)
+		churnerTLSConfig.Enabled = true
 	}
 
 	internalDispersalFlag := ctx.GlobalString(flags.InternalDispersalPortFlag.Name)
@@ -136,7 +178,7 @@ func NewConfig(ctx *cli.Context) (*Config, error) {
 		internalRetrievalFlag = ctx.GlobalString(flags.RetrievalPortFlag.Name)
 	}
 
-	return &Config{
+	config := &Config{
 		Hostname:                      ctx.GlobalString(flags.HostnameFlag.Name),
 		DispersalPort:                 ctx.GlobalString(flags.DispersalPortFlag.Name),
 		RetrievalPort:                 ctx.GlobalString(flags.RetrievalPortFlag.Name),
@@ -148,23 +190,103 @@ func NewConfig(ctx *cli.Context) (*Config, error) {
 		MetricsPort:                   ctx.GlobalString(flags.MetricsPortFlag.Name),
 		Timeout:                       timeout,
 		RegisterNodeAtStart:           ctx.GlobalBool(flags.RegisterAtNodeStartFlag.Name),
-		ExpirationPollIntervalSec:     expirationPollIntervalSec,
+		ExpirationPollIntervalSec:     ctx.GlobalUint64(flags.ExpirationPollIntervalSecFlag.Name),
+		QuorumPollInterval:            ctx.GlobalDuration(flags.QuorumRegistrationPollIntervalFlag.Name),
+		MaxReferenceBlockAge:          ctx.GlobalUint(flags.MaxReferenceBlockAgeFlag.Name),
+		MinReferenceBlockAge:          ctx.GlobalUint(flags.MinReferenceBlockAgeFlag.Name),
+		DispersalDeadlineSkew:         ctx.GlobalDuration(flags.DispersalDeadlineSkewFlag.Name),
+		RetrievalCacheSize:            ctx.GlobalUint64(flags.RetrievalCacheSizeFlag.Name),
 		EnableTestMode:                testMode,
 		OverrideBlockStaleMeasure:     ctx.GlobalInt64(flags.OverrideBlockStaleMeasureFlag.Name),
 		OverrideStoreDurationBlocks:   ctx.GlobalInt64(flags.OverrideStoreDurationBlocksFlag.Name),
 		QuorumIDList:                  ids,
 		DbPath:                        ctx.GlobalString(flags.DbPathFlag.Name),
+		DbBackend:                     ctx.GlobalString(flags.DbBackendFlag.Name),
 		PrivateBls:                    privateBls,
 		EthClientConfig:               ethClientConfig,
 		EncoderConfig:                 encoding.ReadCLIConfig(ctx),
 		LoggingConfig:                 logging.ReadCLIConfig(ctx, flags.FlagPrefix),
+		TLSConfig:                     commongrpc.ReadTLSCLIConfig(ctx, flags.FlagPrefix),
+		ChurnerTLSConfig:              churnerTLSConfig,
+		MaxGRPCMessageSize:            commongrpc.ReadMaxMessageSize(ctx, flags.FlagPrefix),
 		BLSOperatorStateRetrieverAddr: ctx.GlobalString(flags.BlsOperatorStateRetrieverFlag.Name),
 		EigenDAServiceManagerAddr:     ctx.GlobalString(flags.EigenDAServiceManagerFlag.Name),
 		PubIPProvider:                 ctx.GlobalString(flags.PubIPProviderFlag.Name),
 		PubIPCheckInterval:            ctx.GlobalDuration(flags.PubIPCheckIntervalFlag.Name),
 		ChurnerUrl:                    ctx.GlobalString(flags.ChurnerUrlFlag.Name),
 		NumBatchValidators:            ctx.GlobalInt(flags.NumBatchValidatorsFlag.Name),
+		QuorumValidationConcurrency:   ctx.GlobalUint(flags.QuorumValidationConcurrencyFlag.Name),
 		ClientIPHeader:                ctx.GlobalString(flags.ClientIPHeaderFlag.Name),
-		UseSecureGrpc:                 !testMode,
-	}, nil
+		DisperserAddress:              gethcommon.HexToAddress(disperserAddress),
+		DisableDisperserAuth:          disableDisperserAuth,
+		DispersalRateLimit:            common.RateParam(ctx.GlobalUint(flags.DispersalRateLimitFlag.Name)),
+	}
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// Validate checks the config before any component of the node starts, and returns all the violations at once
+func (c *Config) Validate() error {
+	var errs []error
+	for _, port := range []struct {
+		name  string
+		value string
+	}{
+		{"dispersal port", c.DispersalPort},
+		{"retrieval port", c.RetrievalPort},
+		{"internal dispersal port", c.InternalDispersalPort},
+		{"internal retrieval port", c.InternalRetrievalPort},
+	} {
+		if p, err := strconv.ParseUint(port.value, 10, 16); err != nil || p == 0 {
+			errs = append(errs, fmt.Errorf("the %s must be a port number, but found %q", port.name, port.value))
+		}
+	}
+	if c.EnableNodeApi && c.NodeApiPort == "" {
+		errs = append(errs, errors.New("the node api port must be set when the node api is enabled"))
+	}
+	if c.EnableMetrics && c.MetricsPort == "" {
+		errs = append(errs, errors.New("the metrics port must be set when the metrics are enabled"))
+	}
+	if c.Timeout <= 0 {
+		errs = append(errs, fmt.Errorf("the timeout must be positive, but found %s", c.Timeout))
+	}
+	if c.ExpirationPollIntervalSec <= minExpirationPollIntervalSec {
+		errs = append(errs, fmt.Errorf("the expiration poll interval must be greater than %d seconds, but found %d", minExpirationPollIntervalSec, c.ExpirationPollIntervalSec))
+	}
+	if c.MaxReferenceBlockAge > 0 && c.MinReferenceBlockAge > c.MaxReferenceBlockAge {
+		errs = append(errs, fmt.Errorf("the min reference block age (%d) exceeds the max reference block age (%d), so that no batch would be accepted", c.MinReferenceBlockAge, c.MaxReferenceBlockAge))
+	}
+	if c.DispersalDeadlineSkew < 0 {
+		errs = append(errs, fmt.Errorf("the dispersal deadline skew must not be negative, but found %s", c.DispersalDeadlineSkew))
+	}
+	if len(c.QuorumIDList) == 0 {
+		errs = append(errs, errors.New("the quorum ID list must not be empty"))
+	}
+	switch c.DbBackend {
+	case LevelDBBackend:
+		if c.DbPath == "" {
+			errs = append(errs, errors.New("the db path must be set with the leveldb backend"))
+		}
+	case MemoryBackend:
+	default:
+		errs = append(errs, fmt.Errorf("the db backend must be %q or %q, but found %q", LevelDBBackend, MemoryBackend, c.DbBackend))
+	}
+	if c.NumBatchValidators <= 0 {
+		errs = append(errs, fmt.Errorf("the number of batch validators must be positive, but found %d", c.NumBatchValidators))
+	}
+	if !c.DisableDisperserAuth && c.DisperserAddress == (gethcommon.Address{}) {
+		errs = append(errs, errors.New("the disperser address must be set unless the disperser auth is disabled"))
+	}
+	if err := c.TLSConfig.Validate(); err != nil {
+		errs = append(errs, fmt.Errorf("invalid TLS config: %w", err))
+	}
+	if err := c.ChurnerTLSConfig.Validate(); err != nil {
+		errs = append(errs, fmt.Errorf("invalid churner TLS config: %w", err))
+	}
+	if c.MaxGRPCMessageSize < 0 {
+		errs = append(errs, fmt.Errorf("the max grpc message size must not be negative (0 for the gRPC defaults), but found %d", c.MaxGRPCMessageSize))
+	}
+	return errors.Join(errs...)
 }
@@ -2,18 +2,43 @@ package node
 
 import (
 	"encoding/binary"
+	"fmt"
+)
 
-	"github.com/syndtr/goleveldb/leveldb/iterator"
+const (
+	// LevelDBBackend stores the chunks in a LevelDB database on disk
+	LevelDBBackend = "leveldb"
+	// MemoryBackend stores the chunks in memory, losing them when the node exits. It is meant for tests.
+	MemoryBackend = "memory"
 )
 
-// DB is an interface to access the local database, such as leveldb, rocksdb.
-type DB interface {
-	Put(key []byte, value []byte) error
+// ChunkStore is the storage backend of the Store, which keeps the headers and chunks of the batches as key-value pairs
+// in it. Implementations must be safe for concurrent use.
+type ChunkStore interface {
+	// PutBatch writes the key-value pairs atomically, i.e. either all or none of them are written
+	PutBatch(keys, values [][]byte) error
+	// Get returns the value stored at the key, or ErrKeyNotFound if there is none
 	Get(key []byte) ([]byte, error)
-	Delete(key []byte) error
+	// DeleteBatch removes the keys atomically. Removing a key which isn't stored is not an error.
 	DeleteBatch(keys [][]byte) error
-	WriteBatch(keys, values [][]byte) error
-	NewIterator(prefix []byte) iterator.Iterator
+	// Iterate calls f with the key-value pairs whose key starts with prefix, in increasing order of keys, until f
+	// returns false. The key and value are only valid until f returns.
+	Iterate(prefix []byte, f func(key, value []byte) bool) error
+	// Size returns the number of bytes taken by the key-value pairs in the backend, including its overhead
+	Size() (uint64, error)
+}
+
+// NewChunkStore creates the chunk store of the given backend. The path is where the LevelDB backend keeps its
+// database, and is ignored by the memory backend.
+func NewChunkStore(backend string, path string) (ChunkStore, error) {
+	switch backend {
+	case LevelDBBackend:
+		return NewLevelDBChunkStore(path)
+	case MemoryBackend:
+		return NewMemoryChunkStore(), nil
+	default:
+		return nil, fmt.Errorf("unknown chunk store backend %q", backend)
+	}
 }
 
 // ToByteArray converts an uint64 into byte array in big endian.
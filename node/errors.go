@@ -2,6 +2,8 @@ package node
 
 import (
 	"errors"
+
+	"github.com/Layr-Labs/eigenda/core"
 )
 
 var (
@@ -9,4 +11,34 @@ var (
 	ErrKeyNotFound          = errors.New("commit not found in db")
 	ErrKeyExpired           = errors.New("commit is expired")
 	ErrKeyNotFoundOrExpired = errors.New("data is either expired or not found")
+
+	ErrNotRegisteredInQuorum = errors.New("operator is not registered in quorum")
+	ErrStaleReferenceBlock   = errors.New("reference block is too old")
 )
+
+// validationErrorReasons are the reasons with which the rejected batches are counted, by the typed error of their
+// validation
+var validationErrorReasons = []struct {
+	err    error
+	reason string
+}{
+	{core.ErrBatchExpired, "batch_expired"},
+	{ErrStaleReferenceBlock, "stale_reference_block"},
+	{ErrNotRegisteredInQuorum, "not_registered_in_quorum"},
+	{core.ErrStaleOperatorState, "stale_operator_state"},
+	{core.ErrReferenceBlockTooRecent, "reference_block_too_recent"},
+	{core.ErrInvalidHeader, "invalid_header"},
+	{core.ErrChunkLengthMismatch, "chunk_length_mismatch"},
+	{core.ErrInvalidChunkIndices, "invalid_chunk_indices"},
+}
+
+// validationErrorReason returns the reason of the rejection of a batch which failed the validation with err, or
+// "other" if err isn't one of the typed validation errors
+func validationErrorReason(err error) string {
+	for _, r := range validationErrorReasons {
+		if errors.Is(err, r.err) {
+			return r.reason
+		}
+	}
+	return "other"
+}
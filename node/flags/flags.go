@@ -5,6 +5,7 @@ import (
 
 	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/common/geth"
+	commongrpc "github.com/Layr-Labs/eigenda/common/grpc"
 	"github.com/Layr-Labs/eigenda/common/logging"
 	"github.com/Layr-Labs/eigenda/core/encoding"
 	"github.com/urfave/cli"
@@ -169,6 +170,46 @@ var (
 		Value:    "180",
 		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "EXPIRATION_POLL_INTERVAL"),
 	}
+	QuorumRegistrationPollIntervalFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "quorum-registration-poll-interval"),
+		Usage:    "Interval at which to poll the chain for changes in the quorums the operator is registered in (Ex: 1m). If set to 0, the quorums will not be tracked.",
+		Required: false,
+		Value:    time.Minute,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "QUORUM_REGISTRATION_POLL_INTERVAL"),
+	}
+	MaxReferenceBlockAgeFlag = cli.UintFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "max-reference-block-age"),
+		Usage:    "Maximum number of blocks between the reference block of a batch and the current block for the batch to be accepted. It should be below the block stale measure of the EigenDA contracts. If set to 0, the age of the reference block is not checked.",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "MAX_REFERENCE_BLOCK_AGE"),
+	}
+	MinReferenceBlockAgeFlag = cli.UintFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "min-reference-block-age"),
+		Usage:    "Minimum number of blocks between the reference block of a blob and the current block for the blob to be validated and signed, so that the operator state it is validated against is final enough not to be reorged. If set to 0, any reference block up to the current block is accepted.",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "MIN_REFERENCE_BLOCK_AGE"),
+	}
+	DispersalDeadlineSkewFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "dispersal-deadline-skew"),
+		Usage:    "Tolerated skew between the clocks of the node and the disperser (Ex: 2s). The batches received later than this past their dispersal deadline are neither stored nor signed.",
+		Required: false,
+		Value:    2 * time.Second,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "DISPERSAL_DEADLINE_SKEW"),
+	}
+	RetrievalCacheSizeFlag = cli.Uint64Flag{
+		Name:     common.PrefixFlag(FlagPrefix, "retrieval-cache-size"),
+		Usage:    "Maximum size in bytes of the chunks cached in memory to serve repeated retrievals. If set to 0, the cache will be disabled.",
+		Required: false,
+		Value:    64 * 1024 * 1024,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "RETRIEVAL_CACHE_SIZE"),
+	}
+	DbBackendFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "db-backend"),
+		Usage:    "Storage backend of the chunks, either 'leveldb', stored under the db path, or 'memory', which loses the chunks on exit and is meant for tests",
+		Required: false,
+		Value:    "leveldb",
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "DB_BACKEND"),
+	}
 	// NumBatchValidators is the maximum number of parallel workers used to
 	// validate a batch (defaults to 128).
 	NumBatchValidatorsFlag = cli.IntFlag{
@@ -178,6 +219,13 @@ var (
 		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "NUM_BATCH_VALIDATORS"),
 		Value:    128,
 	}
+	QuorumValidationConcurrencyFlag = cli.UintFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "quorum-validation-concurrency"),
+		Usage:    "maximum number of quorums of a blob whose chunks are validated concurrently, or 0 to validate them sequentially",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "QUORUM_VALIDATION_CONCURRENCY"),
+		Value:    0,
+	}
 
 	// Test only, DO NOT USE the following flags in production
 
@@ -225,6 +273,24 @@ var (
 		Value:    "",
 		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "CLIENT_IP_HEADER"),
 	}
+	DisperserAddressFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "disperser-address"),
+		Usage:    "The address of the key with which the disperser signs the batch headers of its StoreChunks requests. Required unless the disperser authentication is disabled",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "DISPERSER_ADDRESS"),
+	}
+	DisableDisperserAuthFlag = cli.BoolFlag{
+		Name:   common.PrefixFlag(FlagPrefix, "disable-disperser-auth"),
+		Usage:  "Accept the StoreChunks requests without authenticating the disperser. Meant for devnets, not for production",
+		EnvVar: common.PrefixEnvVar(EnvVarPrefix, "DISABLE_DISPERSER_AUTH"),
+	}
+	DispersalRateLimitFlag = cli.UintFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "dispersal-rate-limit"),
+		Usage:    "The rate in bytes per second at which each peer may send StoreChunks requests. 0 disables the limit",
+		Required: false,
+		Value:    0,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "DISPERSAL_RATE_LIMIT"),
+	}
 )
 
 var requiredFlags = []cli.Flag{
@@ -252,14 +318,24 @@ var requiredFlags = []cli.Flag{
 var optionalFlags = []cli.Flag{
 	RegisterAtNodeStartFlag,
 	ExpirationPollIntervalSecFlag,
+	QuorumRegistrationPollIntervalFlag,
+	MaxReferenceBlockAgeFlag,
+	MinReferenceBlockAgeFlag,
+	DispersalDeadlineSkewFlag,
+	RetrievalCacheSizeFlag,
+	DbBackendFlag,
 	EnableTestModeFlag,
 	OverrideBlockStaleMeasureFlag,
 	OverrideStoreDurationBlocksFlag,
 	TestPrivateBlsFlag,
 	NumBatchValidatorsFlag,
+	QuorumValidationConcurrencyFlag,
 	InternalDispersalPortFlag,
 	InternalRetrievalPortFlag,
 	ClientIPHeaderFlag,
+	DisperserAddressFlag,
+	DisableDisperserAuthFlag,
+	DispersalRateLimitFlag,
 }
 
 func init() {
@@ -267,6 +343,10 @@ func init() {
 	Flags = append(Flags, encoding.CLIFlags(EnvVarPrefix)...)
 	Flags = append(Flags, geth.EthClientFlags(EnvVarPrefix)...)
 	Flags = append(Flags, logging.CLIFlags(EnvVarPrefix, FlagPrefix)...)
+	Flags = append(Flags, commongrpc.TLSCLIFlags(EnvVarPrefix, FlagPrefix)...)
+	Flags = append(Flags, commongrpc.ClientTLSCLIFlags(EnvVarPrefix, FlagPrefix)...)
+	// The StoreChunks requests hold all the chunks of a batch assigned to the operator
+	Flags = append(Flags, commongrpc.MaxMessageSizeCLIFlag(EnvVarPrefix, FlagPrefix, 1024*1024*1024)) // 1 GiB
 }
 
 // Flags contains the list of configuration options available to the binary.
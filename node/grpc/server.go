@@ -2,6 +2,7 @@ package grpc
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync"
 
@@ -9,12 +10,17 @@ import (
 
 	pb "github.com/Layr-Labs/eigenda/api/grpc/node"
 	"github.com/Layr-Labs/eigenda/common"
+	commongrpc "github.com/Layr-Labs/eigenda/common/grpc"
 	"github.com/Layr-Labs/eigenda/core"
 	"github.com/Layr-Labs/eigenda/node"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/prometheus/client_golang/prometheus"
 
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
 )
 
@@ -30,6 +36,13 @@ type Server struct {
 	logger common.Logger
 
 	ratelimiter common.RateLimiter
+	// pendingBatches are the batch headers of the StoreChunks requests being processed, so that a replay of a request
+	// in flight is rejected as well as the replay of a stored batch
+	pendingBatches map[[32]byte]struct{}
+	// grpcServers are the dispersal and retrieval servers serving the requests, which are no longer restarted once
+	// stopped is set
+	grpcServers map[string]*grpc.Server
+	stopped     bool
 
 	mu *sync.Mutex
 }
@@ -40,15 +53,22 @@ type Server struct {
 func NewServer(config *node.Config, node *node.Node, logger common.Logger, ratelimiter common.RateLimiter) *Server {
 
 	return &Server{
-		config:      config,
-		logger:      logger,
-		node:        node,
-		ratelimiter: ratelimiter,
-		mu:          &sync.Mutex{},
+		config:         config,
+		logger:         logger,
+		node:           node,
+		ratelimiter:    ratelimiter,
+		pendingBatches: make(map[[32]byte]struct{}),
+		grpcServers:    make(map[string]*grpc.Server),
+		mu:             &sync.Mutex{},
 	}
 }
 
-func (s *Server) Start() {
+func (s *Server) Start() error {
+
+	tlsOpts, err := commongrpc.ServerOptions(context.Background(), s.config.TLSConfig, s.logger)
+	if err != nil {
+		return fmt.Errorf("could not configure TLS: %w", err)
+	}
 
 	// TODO: In order to facilitate integration testing with multiple nodes, we need to be able to set the port.
 	// TODO: Properly implement the health check.
@@ -60,22 +80,64 @@ func (s *Server) Start() {
 
 	// TODO: Add monitoring
 	go func() {
-		for {
-			err := s.serveDispersal()
+		for !s.isStopped() {
+			err := s.serveDispersal(tlsOpts)
+			if s.isStopped() {
+				return
+			}
 			s.logger.Error("dispersal server failed; restarting.", "err", err)
 		}
 	}()
 
 	go func() {
-		for {
-			err := s.serveRetrieval()
+		for !s.isStopped() {
+			err := s.serveRetrieval(tlsOpts)
+			if s.isStopped() {
+				return
+			}
 			s.logger.Error("retrieval server failed; restarting.", "err", err)
 		}
 	}()
 
+	return nil
 }
 
-func (s *Server) serveDispersal() error {
+// Stop stops the dispersal and retrieval servers once the pending requests are served, or right away once the context
+// is done
+func (s *Server) Stop(ctx context.Context) error {
+	s.mu.Lock()
+	s.stopped = true
+	servers := make([]*grpc.Server, 0, len(s.grpcServers))
+	for _, gs := range s.grpcServers {
+		servers = append(servers, gs)
+	}
+	s.mu.Unlock()
+
+	var err error
+	for _, gs := range servers {
+		err = errors.Join(err, commongrpc.GracefulStop(ctx, gs))
+	}
+	return err
+}
+
+func (s *Server) isStopped() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.stopped
+}
+
+// trackServer keeps the server to stop it along with the node, and returns false if the node is already stopped
+func (s *Server) trackServer(name string, gs *grpc.Server) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.stopped {
+		return false
+	}
+	s.grpcServers[name] = gs
+	return true
+}
+
+func (s *Server) serveDispersal(tlsOpts []grpc.ServerOption) error {
 
 	addr := fmt.Sprintf("%s:%s", localhost, s.config.InternalDispersalPort)
 	listener, err := net.Listen("tcp", addr)
@@ -83,14 +145,16 @@ func (s *Server) serveDispersal() error {
 		s.logger.Fatalf("Could not start tcp listener: %w", err)
 	}
 
-	opt := grpc.MaxRecvMsgSize(1024 * 1024 * 1024) // 1 GiB
-	gs := grpc.NewServer(opt)
+	gs := grpc.NewServer(append(tlsOpts, commongrpc.MaxMessageSizeServerOptions(s.config.MaxGRPCMessageSize)...)...)
 
 	// Register reflection service on gRPC server
 	// This makes "grpcurl -plaintext localhost:9000 list" command work
 	reflection.Register(gs)
 
 	pb.RegisterDispersalServer(gs, s)
+	if !s.trackServer("dispersal", gs) {
+		return listener.Close()
+	}
 
 	s.logger.Info("port", s.config.InternalDispersalPort, "address", listener.Addr().String(), "GRPC Listening")
 	if err := gs.Serve(listener); err != nil {
@@ -100,21 +164,23 @@ func (s *Server) serveDispersal() error {
 
 }
 
-func (s *Server) serveRetrieval() error {
+func (s *Server) serveRetrieval(tlsOpts []grpc.ServerOption) error {
 	addr := fmt.Sprintf("%s:%s", localhost, s.config.InternalRetrievalPort)
 	listener, err := net.Listen("tcp", addr)
 	if err != nil {
 		s.logger.Fatalf("Could not start tcp listener: %w", err)
 	}
 
-	opt := grpc.MaxRecvMsgSize(1024 * 1024 * 300) // 300 MiB
-	gs := grpc.NewServer(opt)
+	gs := grpc.NewServer(append(tlsOpts, commongrpc.MaxMessageSizeServerOptions(s.config.MaxGRPCMessageSize)...)...)
 
 	// Register reflection service on gRPC server
 	// This makes "grpcurl -plaintext localhost:9000 list" command work
 	reflection.Register(gs)
 
 	pb.RegisterRetrievalServer(gs, s)
+	if !s.trackServer("retrieval", gs) {
+		return listener.Close()
+	}
 
 	s.logger.Info("port", s.config.InternalRetrievalPort, "address", listener.Addr().String(), "GRPC Listening")
 	if err := gs.Serve(listener); err != nil {
@@ -125,25 +191,141 @@ func (s *Server) serveRetrieval() error {
 }
 
 func (s *Server) handleStoreChunksRequest(ctx context.Context, in *pb.StoreChunksRequest) (*pb.StoreChunksReply, error) {
+	// The request is rate limited and authenticated before its chunks are deserialized
+	if err := s.allowDispersal(ctx, in); err != nil {
+		return nil, err
+	}
+
 	// Get batch header hash
 	batchHeader, err := GetBatchHeader(in)
 	if err != nil {
 		return nil, err
 	}
 
+	if !s.config.DisableDisperserAuth {
+		batchHeaderHash, err := batchHeader.GetBatchHeaderHash()
+		if err != nil {
+			return nil, err
+		}
+		if err := s.authenticateDisperser(batchHeaderHash, in.GetDisperserSignature()); err != nil {
+			return nil, err
+		}
+		stored, err := s.addPendingBatch(ctx, batchHeaderHash)
+		if err != nil {
+			return nil, err
+		}
+		// Storing a batch again is a no-op, so the batch is signed again without processing its chunks, as long as
+		// its dispersal deadline didn't pass
+		if stored {
+			if err := s.node.CheckDispersalDeadline(GetDispersalDeadline(in)); err != nil {
+				s.node.Metrics.RecordRejectedBatch(err)
+				return nil, batchExpiredError(err)
+			}
+			return storeChunksReply(s.node.SignBatchHeaderHash(batchHeaderHash)), nil
+		}
+		defer s.removePendingBatch(batchHeaderHash)
+	}
+
 	blobs, err := GetBlobMessages(in)
 	if err != nil {
 		return nil, err
 	}
+	// The signature of the batch header only covers the blob headers through the batch root
+	if err := ValidateBatchRoot(batchHeader, blobs); err != nil {
+		return nil, err
+	}
 
-	sig, err := s.node.ProcessBatch(ctx, batchHeader, blobs, in.GetBlobs())
+	sig, err := s.node.ProcessBatch(ctx, batchHeader, blobs, in.GetBlobs(), GetDispersalDeadline(in))
+	if errors.Is(err, core.ErrBatchExpired) {
+		return nil, batchExpiredError(err)
+	}
 	if err != nil {
 		return nil, err
 	}
 
+	return storeChunksReply(sig), nil
+}
+
+func storeChunksReply(sig *core.Signature) *pb.StoreChunksReply {
 	sigData := sig.Serialize()
 
-	return &pb.StoreChunksReply{Signature: sigData[:]}, nil
+	return &pb.StoreChunksReply{
+		Signature: sigData[:],
+		Version:   node.SemVer,
+		Features:  uint64(node.SupportedFeatures),
+	}
+}
+
+// batchExpiredError returns the FailedPrecondition error of a batch refused past its dispersal deadline, whose
+// BATCH_EXPIRED reason tells the disperser the node is healthy.
+func batchExpiredError(err error) error {
+	st := status.New(codes.FailedPrecondition, err.Error())
+	withDetails, detailsErr := st.WithDetails(&errdetails.ErrorInfo{Reason: core.BatchExpiredReason})
+	if detailsErr != nil {
+		return st.Err()
+	}
+	return withDetails.Err()
+}
+
+// allowDispersal applies the dispersal rate limit of the peer to the size of the request
+func (s *Server) allowDispersal(ctx context.Context, in *pb.StoreChunksRequest) error {
+	if s.config.DispersalRateLimit == 0 {
+		return nil
+	}
+
+	peerID, err := common.GetClientAddress(ctx, s.config.ClientIPHeader, 1, false)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	allow, err := s.ratelimiter.AllowRequest(ctx, "dispersal:"+peerID, uint(proto.Size(in)), s.config.DispersalRateLimit)
+	s.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	if !allow {
+		return fmt.Errorf("request rate limited")
+	}
+	return nil
+}
+
+// authenticateDisperser checks that the signature over the batch header hash is by the key of the disperser
+func (s *Server) authenticateDisperser(batchHeaderHash [32]byte, signature []byte) error {
+	if len(signature) != crypto.SignatureLength {
+		return fmt.Errorf("invalid disperser signature: expected %d bytes, but found %d", crypto.SignatureLength, len(signature))
+	}
+	publicKey, err := crypto.SigToPub(batchHeaderHash[:], signature)
+	if err != nil {
+		return fmt.Errorf("invalid disperser signature: %w", err)
+	}
+	if signer := crypto.PubkeyToAddress(*publicKey); signer != s.config.DisperserAddress {
+		return fmt.Errorf("invalid disperser signature: signed by %s instead of the disperser %s", signer.Hex(), s.config.DisperserAddress.Hex())
+	}
+	return nil
+}
+
+// addPendingBatch records that a request for the batch is being processed, unless the batch was already stored, in
+// which case it returns true. It fails if the batch is already being processed, i.e. if the request is a replay.
+func (s *Server) addPendingBatch(ctx context.Context, batchHeaderHash [32]byte) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.pendingBatches[batchHeaderHash]; ok {
+		return false, fmt.Errorf("batch %x is already being stored", batchHeaderHash)
+	}
+	if s.node.Store.HasKey(ctx, node.EncodeBatchHeaderKey(batchHeaderHash)) {
+		return true, nil
+	}
+	s.pendingBatches[batchHeaderHash] = struct{}{}
+	return false, nil
+}
+
+func (s *Server) removePendingBatch(batchHeaderHash [32]byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.pendingBatches, batchHeaderHash)
 }
 
 // StoreChunks is called by dispersers to store data.
@@ -179,39 +361,70 @@ func (s *Server) RetrieveChunks(ctx context.Context, in *pb.RetrieveChunksReques
 	var batchHeaderHash [32]byte
 	copy(batchHeaderHash[:], in.GetBatchHeaderHash())
 
-	blobHeader, _, err := s.getBlobHeader(ctx, batchHeaderHash, int(in.BlobIndex), uint8(in.GetQuorumId()))
+	retrieverID, err := common.GetClientAddress(ctx, s.config.ClientIPHeader, 1, false)
 	if err != nil {
 		return nil, err
 	}
 
-	retrieverID, err := common.GetClientAddress(ctx, s.config.ClientIPHeader, 1, false)
+	cacheKey := node.ChunkCacheKey{
+		BatchHeaderHash: batchHeaderHash,
+		BlobIndex:       int(in.GetBlobIndex()),
+		QuorumID:        uint8(in.GetQuorumId()),
+	}
+	if s.node.ChunkCache != nil {
+		cached, ok := s.node.ChunkCache.Get(cacheKey)
+		s.node.Metrics.RecordRetrievalCacheRequest(ok)
+		if ok {
+			if err := s.allowRetrieval(ctx, retrieverID, cached.EncodedBlobSize, cached.Rate); err != nil {
+				return nil, err
+			}
+			s.node.Metrics.RecordRPCRequest("RetrieveChunks", "success")
+			return &pb.RetrieveChunksReply{Chunks: cached.Chunks}, nil
+		}
+	}
+
+	blobHeader, _, err := s.getBlobHeader(ctx, batchHeaderHash, int(in.BlobIndex), uint8(in.GetQuorumId()))
 	if err != nil {
 		return nil, err
 	}
 
 	encodedBlobSize := core.GetBlobSize(blobHeader.QuorumInfos[in.GetQuorumId()].EncodedBlobLength)
 	rate := blobHeader.QuorumInfos[in.GetQuorumId()].QuorumRate
-
-	s.mu.Lock()
-	allow, err := s.ratelimiter.AllowRequest(ctx, retrieverID, encodedBlobSize, rate)
-	s.mu.Unlock()
-	if err != nil {
+	if err := s.allowRetrieval(ctx, retrieverID, encodedBlobSize, rate); err != nil {
 		return nil, err
 	}
 
-	if !allow {
-		return nil, fmt.Errorf("request rate limited")
-	}
-
 	chunks, ok := s.node.Store.GetChunks(ctx, batchHeaderHash, int(in.GetBlobIndex()), uint8(in.GetQuorumId()))
 	if !ok {
 		s.node.Metrics.RecordRPCRequest("RetrieveChunks", "failure")
 		return nil, fmt.Errorf("could not find chunks for batchHeaderHash %v, blob index: %v, quorumID: %v", batchHeaderHash, in.GetBlobIndex(), in.GetQuorumId())
 	}
+	if s.node.ChunkCache != nil {
+		s.node.ChunkCache.Add(cacheKey, &node.CachedChunks{
+			Chunks:          chunks,
+			EncodedBlobSize: encodedBlobSize,
+			Rate:            rate,
+		})
+	}
 	s.node.Metrics.RecordRPCRequest("RetrieveChunks", "success")
 	return &pb.RetrieveChunksReply{Chunks: chunks}, nil
 }
 
+// allowRetrieval applies the retrieval rate limit of the retriever
+func (s *Server) allowRetrieval(ctx context.Context, retrieverID string, encodedBlobSize uint, rate uint32) error {
+	s.mu.Lock()
+	allow, err := s.ratelimiter.AllowRequest(ctx, retrieverID, encodedBlobSize, rate)
+	s.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	if !allow {
+		return fmt.Errorf("request rate limited")
+	}
+	return nil
+}
+
 func (s *Server) GetBlobHeader(ctx context.Context, in *pb.GetBlobHeaderRequest) (*pb.GetBlobHeaderReply, error) {
 	var batchHeaderHash [32]byte
 	copy(batchHeaderHash[:], in.GetBatchHeaderHash())
@@ -29,7 +29,7 @@ func makeBatch(t *testing.T, blobSize int, numBlobs int, advThreshold, quorumThr
 		// encode data
 		operatorState, err := chainState.GetOperatorState(context.Background(), 0, []core.QuorumID{0})
 		assert.NoError(t, err)
-		assignments, info, err := asn.GetAssignments(operatorState, 0, batcher.QuantizationFactor)
+		assignments, info, err := asn.GetAssignments(operatorState, 0, batcher.QuantizationFactor, 0)
 		assert.NoError(t, err)
 		quorumInfo := batcher.QuorumInfo{
 			Assignments:        assignments,
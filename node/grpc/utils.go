@@ -3,7 +3,9 @@ package grpc
 import (
 	"context"
 	"errors"
+	"fmt"
 	"reflect"
+	"time"
 
 	pb "github.com/Layr-Labs/eigenda/api/grpc/node"
 	"github.com/Layr-Labs/eigenda/core"
@@ -18,12 +20,37 @@ func GetBatchHeader(in *pb.StoreChunksRequest) (*core.BatchHeader, error) {
 	var batchRoot [32]byte
 	copy(batchRoot[:], in.GetBatchHeader().GetBatchRoot())
 	batchHeader := core.BatchHeader{
-		ReferenceBlockNumber: uint(in.BatchHeader.ReferenceBlockNumber),
+		ReferenceBlockNumber: uint(in.GetBatchHeader().GetReferenceBlockNumber()),
 		BatchRoot:            batchRoot,
 	}
 	return &batchHeader, nil
 }
 
+// ValidateBatchRoot checks that the batch root of the batch header is the root of the merkle tree of the blob headers
+// of the batch.
+func ValidateBatchRoot(batchHeader *core.BatchHeader, blobs []*core.BlobMessage) error {
+	blobHeaders := make([]*core.BlobHeader, len(blobs))
+	for i, blob := range blobs {
+		blobHeaders[i] = blob.BlobHeader
+	}
+	expected := core.BatchHeader{ReferenceBlockNumber: batchHeader.ReferenceBlockNumber}
+	if _, err := expected.SetBatchRoot(blobHeaders); err != nil {
+		return fmt.Errorf("failed to compute the batch root: %w", err)
+	}
+	if expected.BatchRoot != batchHeader.BatchRoot {
+		return fmt.Errorf("invalid batch root: the batch header has root %x, but the blob headers have root %x", batchHeader.BatchRoot, expected.BatchRoot)
+	}
+	return nil
+}
+
+// GetDispersalDeadline returns the dispersal deadline of a pb.StoreChunksRequest, or the zero time if it has none.
+func GetDispersalDeadline(in *pb.StoreChunksRequest) time.Time {
+	if in.GetDispersalDeadline() == 0 {
+		return time.Time{}
+	}
+	return time.UnixMilli(int64(in.GetDispersalDeadline()))
+}
+
 // GetBlobMessages constructs a core.BlobMessage array from a proto of pb.StoreChunksRequest.
 func GetBlobMessages(in *pb.StoreChunksRequest) ([]*core.BlobMessage, error) {
 	blobs := make([]*core.BlobMessage, len(in.GetBlobs()))
@@ -75,8 +102,9 @@ func GetBlobHeaderFromProto(h *pb.BlobHeader) (*core.BlobHeader, error) {
 				QuorumThreshold:    uint8(header.GetQuorumThreshold()),
 				QuorumRate:         header.GetRatelimit(),
 			},
-			QuantizationFactor: uint(header.GetQuantizationFactor()),
-			EncodedBlobLength:  uint(header.GetEncodedBlobLength()),
+			QuantizationFactor:   uint(header.GetQuantizationFactor()),
+			OverprovisionPercent: uint(header.GetOverprovisionPercent()),
+			EncodedBlobLength:    uint(header.GetEncodedBlobLength()),
 		}
 	}
 
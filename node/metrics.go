@@ -2,9 +2,13 @@ package node
 
 import (
 	"context"
+	"strconv"
+	"time"
 
 	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/core"
 	eigenmetrics "github.com/Layr-Labs/eigensdk-go/metrics"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/collectors"
@@ -30,6 +34,24 @@ type Metrics struct {
 	CurrBatches *prometheus.GaugeVec
 	// Total number of changes in the node's socket address.
 	AccuSocketUpdates prometheus.Counter
+	// Accumulated number of chunk retrievals by whether they were served from the cache.
+	AccuRetrievalCacheRequests *prometheus.CounterVec
+	// The latency (in ms) to validate a batch, whether it is valid or not.
+	BatchValidationLatency prometheus.Histogram
+	// The latency (in ms) to write a batch to the chunk store.
+	StoreLatency prometheus.Histogram
+	// Accumulated size in bytes of the chunks stored by quorum.
+	AccuStoredChunkBytes *prometheus.CounterVec
+	// Accumulated number of batch signatures issued.
+	AccuSignatures prometheus.Counter
+	// Accumulated number of batches rejected by the validation, by the reason of their rejection.
+	AccuRejectedBatches *prometheus.CounterVec
+	// Current size in bytes of the chunk store.
+	ChunkStoreSize prometheus.Gauge
+	// Accumulated size in bytes of the chunks removed by the expiration.
+	AccuPrunedBytes prometheus.Counter
+	// Always 1, labeled with the current ID of the operator.
+	OperatorInfo *prometheus.GaugeVec
 	// avs node spec eigen_ metrics: https://eigen.nethermind.io/docs/spec/metrics/metrics-prom-spec
 	EigenMetrics eigenmetrics.Metrics
 
@@ -96,6 +118,78 @@ func NewMetrics(eigenMetrics eigenmetrics.Metrics, reg *prometheus.Registry, log
 				Help:      "the total number of node's socket address updates",
 			},
 		),
+		// The "result" label has values: hit, miss.
+		AccuRetrievalCacheRequests: promauto.With(reg).NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: Namespace,
+				Name:      "eigenda_retrieval_cache_requests_total",
+				Help:      "the total number of chunk retrievals by whether they were served from the cache",
+			},
+			[]string{"result"},
+		),
+		BatchValidationLatency: promauto.With(reg).NewHistogram(
+			prometheus.HistogramOpts{
+				Namespace: Namespace,
+				Name:      "eigenda_batch_validation_latency_ms",
+				Help:      "latency histogram in milliseconds of the validation of a batch",
+				Buckets:   prometheus.ExponentialBuckets(1, 2, 16),
+			},
+		),
+		StoreLatency: promauto.With(reg).NewHistogram(
+			prometheus.HistogramOpts{
+				Namespace: Namespace,
+				Name:      "eigenda_store_latency_ms",
+				Help:      "latency histogram in milliseconds of the writes of a batch to the chunk store",
+				Buckets:   prometheus.ExponentialBuckets(1, 2, 16),
+			},
+		),
+		AccuStoredChunkBytes: promauto.With(reg).NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: Namespace,
+				Name:      "eigenda_stored_chunk_bytes_total",
+				Help:      "the total size in bytes of the chunks stored by the DA node by quorum",
+			},
+			[]string{"quorum"},
+		),
+		AccuSignatures: promauto.With(reg).NewCounter(
+			prometheus.CounterOpts{
+				Namespace: Namespace,
+				Name:      "eigenda_signatures_total",
+				Help:      "the total number of batches signed by the DA node",
+			},
+		),
+		// The "reason" label has the values of validationErrorReason.
+		AccuRejectedBatches: promauto.With(reg).NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: Namespace,
+				Name:      "eigenda_rejected_batches_total",
+				Help:      "the total number of batches rejected by the validation of the DA node by reason",
+			},
+			[]string{"reason"},
+		),
+		ChunkStoreSize: promauto.With(reg).NewGauge(
+			prometheus.GaugeOpts{
+				Namespace: Namespace,
+				Name:      "eigenda_chunk_store_size_bytes",
+				Help:      "the current size in bytes of the chunk store of the DA node",
+			},
+		),
+		AccuPrunedBytes: promauto.With(reg).NewCounter(
+			prometheus.CounterOpts{
+				Namespace: Namespace,
+				Name:      "eigenda_pruned_bytes_total",
+				Help:      "the total size in bytes of the chunks of the expired batches removed by the DA node",
+			},
+		),
+		// The "operator_id" label has the hex encoded ID of the operator, which changes when it's rotated.
+		OperatorInfo: promauto.With(reg).NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: Namespace,
+				Name:      "eigenda_operator_info",
+				Help:      "the current ID of the operator of the DA node",
+			},
+			[]string{"operator_id"},
+		),
 		EigenMetrics: eigenMetrics,
 		logger:       logger,
 		registry:     reg,
@@ -113,10 +207,24 @@ func (g *Metrics) RecordRPCRequest(method string, status string) {
 	g.AccNumRequests.WithLabelValues(method, status).Inc()
 }
 
+func (g *Metrics) RecordRetrievalCacheRequest(hit bool) {
+	if hit {
+		g.AccuRetrievalCacheRequests.WithLabelValues("hit").Inc()
+	} else {
+		g.AccuRetrievalCacheRequests.WithLabelValues("miss").Inc()
+	}
+}
+
 func (g *Metrics) RecordSocketAddressChange() {
 	g.AccuSocketUpdates.Inc()
 }
 
+// SetOperatorID replaces the operator ID the metrics are labeled with
+func (g *Metrics) SetOperatorID(operatorID core.OperatorID) {
+	g.OperatorInfo.Reset()
+	g.OperatorInfo.WithLabelValues(hexutil.Encode(operatorID[:])).Set(1)
+}
+
 func (g *Metrics) ObserveLatency(method, stage string, latencyMs float64) {
 	g.RequestLatency.WithLabelValues(method, stage).Observe(latencyMs)
 }
@@ -140,3 +248,31 @@ func (g *Metrics) AcceptBatches(status string, batchSize int64) {
 	g.AccuBatches.WithLabelValues("number", status).Inc()
 	g.AccuBatches.WithLabelValues("size", status).Add(float64(batchSize))
 }
+
+func (g *Metrics) ObserveBatchValidation(latency time.Duration) {
+	g.BatchValidationLatency.Observe(float64(latency.Milliseconds()))
+}
+
+func (g *Metrics) ObserveStoreLatency(latency time.Duration) {
+	g.StoreLatency.Observe(float64(latency.Milliseconds()))
+}
+
+func (g *Metrics) AddStoredChunkBytes(quorumID uint8, size int) {
+	g.AccuStoredChunkBytes.WithLabelValues(strconv.Itoa(int(quorumID))).Add(float64(size))
+}
+
+func (g *Metrics) RecordSignature() {
+	g.AccuSignatures.Inc()
+}
+
+func (g *Metrics) RecordRejectedBatch(err error) {
+	g.AccuRejectedBatches.WithLabelValues(validationErrorReason(err)).Inc()
+}
+
+func (g *Metrics) SetChunkStoreSize(size uint64) {
+	g.ChunkStoreSize.Set(float64(size))
+}
+
+func (g *Metrics) AddPrunedBytes(size int64) {
+	g.AccuPrunedBytes.Add(float64(size))
+}
@@ -45,9 +45,27 @@ type Node struct {
 	Transactor              core.Transactor
 	PubIPProvider           pubip.Provider
 	OperatorSocketsFilterer indexer.OperatorSocketsFilterer
+	// ChunkCache caches the chunks served to retrievers. It is nil if the cache is disabled.
+	ChunkCache *ChunkCache
 
 	mu            sync.Mutex
 	CurrentSocket string
+
+	// idMu guards Config.ID and KeyPair, which are rotated together with RotateOperatorKey. The batches are validated
+	// and signed under its read lock, so that a batch is validated and signed for a single operator.
+	idMu sync.RWMutex
+
+	// socketWatchCtx is the context of the subscription to the socket updates of the operator on chain, and
+	// cancelSocketWatch cancels the current subscription. The subscription is restarted when the operator ID is
+	// rotated. socketWatchCtx is nil until the subscription is started.
+	socketWatchMu     sync.Mutex
+	socketWatchCtx    context.Context
+	cancelSocketWatch context.CancelFunc
+
+	// registeredQuorums are the quorums the operator is currently registered in, as last observed on chain. It is nil
+	// until the quorums are first fetched, or if they are not tracked.
+	quorumsMu         sync.RWMutex
+	registeredQuorums map[core.QuorumID]struct{}
 }
 
 // NewNode creates a new Node with the provided config.
@@ -67,6 +85,7 @@ func NewNode(config *Config, pubIPProvider pubip.Provider, logger common.Logger)
 	}
 
 	config.ID = keyPair.GetPubKeyG1().GetOperatorID()
+	metrics.SetOperatorID(config.ID)
 
 	// Make sure config folder exists.
 	err = os.MkdirAll(config.DbPath, os.ModePerm)
@@ -97,7 +116,8 @@ func NewNode(config *Config, pubIPProvider pubip.Provider, logger common.Logger)
 		return nil, err
 	}
 	asgn := &core.StdAssignmentCoordinator{}
-	validator := core.NewChunkValidator(enc, asgn, cst, config.ID)
+	// The node reads the operator state at the reference block of each batch, so it tolerates no gap
+	validator := core.NewChunkValidator(enc, asgn, cst, config.ID, 0, config.MinReferenceBlockAge, config.QuorumValidationConcurrency)
 
 	// Create new store
 
@@ -121,10 +141,17 @@ func NewNode(config *Config, pubIPProvider pubip.Provider, logger common.Logger)
 		}
 		storeDurationBlocks = storeDuration
 	}
-	store, err := NewLevelDBStore(config.DbPath+"/chunk", logger, metrics, blockStaleMeasure, storeDurationBlocks)
+	chunkStore, err := NewChunkStore(config.DbBackend, config.DbPath+"/chunk")
 	if err != nil {
 		return nil, fmt.Errorf("failed to create new store: %w", err)
 	}
+	store := NewStore(chunkStore, logger, metrics, blockStaleMeasure, storeDurationBlocks)
+
+	var chunkCache *ChunkCache
+	if config.RetrievalCacheSize > 0 {
+		chunkCache = NewChunkCache(config.RetrievalCacheSize)
+		store.OnBatchExpired(chunkCache.RemoveBatch)
+	}
 
 	eigenDAServiceManagerAddr := gethcommon.HexToAddress(config.EigenDAServiceManagerAddr)
 	socketsFilterer, err := indexer.NewOperatorSocketsFilterer(eigenDAServiceManagerAddr, client)
@@ -144,6 +171,7 @@ func NewNode(config *Config, pubIPProvider pubip.Provider, logger common.Logger)
 		Validator:               validator,
 		PubIPProvider:           pubIPProvider,
 		OperatorSocketsFilterer: socketsFilterer,
+		ChunkCache:              chunkCache,
 	}, nil
 }
 
@@ -163,19 +191,24 @@ func (n *Node) Start(ctx context.Context) error {
 
 	// Build the socket based on the hostname/IP provided in the CLI
 	socket := string(core.MakeOperatorSocket(n.Config.Hostname, n.Config.DispersalPort, n.Config.RetrievalPort))
+	operatorID, keyPair := n.operator()
 	if n.Config.RegisterNodeAtStart {
 		n.Logger.Info("Registering node on chain with the following parameters:", "operatorId",
-			n.Config.ID, "hostname", n.Config.Hostname, "dispersalPort", n.Config.DispersalPort,
+			operatorID, "hostname", n.Config.Hostname, "dispersalPort", n.Config.DispersalPort,
 			"retrievalPort", n.Config.RetrievalPort, "churnerUrl", n.Config.ChurnerUrl, "quorumIds", n.Config.QuorumIDList)
 		socket := string(core.MakeOperatorSocket(n.Config.Hostname, n.Config.DispersalPort, n.Config.RetrievalPort))
 		operator := &Operator{
 			Socket:     socket,
 			Timeout:    10 * time.Second,
-			KeyPair:    n.KeyPair,
-			OperatorId: n.Config.ID,
+			KeyPair:    keyPair,
+			OperatorId: operatorID,
 			QuorumIDs:  n.Config.QuorumIDList,
 		}
-		err := RegisterOperator(ctx, operator, n.Transactor, n.Config.ChurnerUrl, n.Config.UseSecureGrpc, n.Logger)
+		churnerCreds, err := n.Config.ChurnerTLSConfig.Credentials()
+		if err != nil {
+			return fmt.Errorf("failed to create the churner credentials: %w", err)
+		}
+		err = RegisterOperator(ctx, operator, n.Transactor, n.Config.ChurnerUrl, churnerCreds, n.Logger)
 		if err != nil {
 			return fmt.Errorf("failed to register the operator: %w", err)
 		}
@@ -184,9 +217,12 @@ func (n *Node) Start(ctx context.Context) error {
 	}
 
 	n.CurrentSocket = socket
+	if n.Config.QuorumPollInterval > 0 {
+		go n.watchQuorumRegistration(ctx)
+	}
 	// Start the Node IP updater only if the PUBLIC_IP_PROVIDER is greater than 0.
 	if n.Config.PubIPCheckInterval > 0 {
-		go n.checkRegisteredNodeIpOnChain(ctx)
+		n.startSocketWatch(ctx)
 		go n.checkCurrentNodeIp(ctx)
 	}
 
@@ -229,10 +265,17 @@ func (n *Node) expireLoop() {
 //   - If the batch is stored already, it's no-op to store it more than once
 //   - If the batch is stored, but the processing fails after that, these data items will not be rollback
 //   - These data items will be garbage collected eventually when they become stale.
-func (n *Node) ProcessBatch(ctx context.Context, header *core.BatchHeader, blobs []*core.BlobMessage, rawBlobs []*node.Blob) (*core.Signature, error) {
+//   - If the batch has a dispersal deadline, it's neither stored nor signed once the deadline passed, since the
+//     disperser no longer aggregates its signatures.
+func (n *Node) ProcessBatch(ctx context.Context, header *core.BatchHeader, blobs []*core.BlobMessage, rawBlobs []*node.Blob, deadline time.Time) (*core.Signature, error) {
 	start := time.Now()
 	log := n.Logger
 
+	if err := n.CheckDispersalDeadline(deadline); err != nil {
+		n.Metrics.RecordRejectedBatch(err)
+		return nil, err
+	}
+
 	// Measure num batches received and its size in bytes
 	batchSize := int64(0)
 	for _, blob := range blobs {
@@ -278,10 +321,16 @@ func (n *Node) ProcessBatch(ctx context.Context, header *core.BatchHeader, blobs
 		storeChan <- storeResult{err: nil, keys: keys}
 	}(n)
 
+	// The operator isn't rotated between the validation of the batch and its signature
+	n.idMu.RLock()
+	defer n.idMu.RUnlock()
+
 	// Validate batch.
 	stageTimer := time.Now()
-	err = n.ValidateBatch(ctx, header, blobs)
+	err = n.validateBatch(ctx, header, blobs)
+	n.Metrics.ObserveBatchValidation(time.Since(stageTimer))
 	if err != nil {
+		n.Metrics.RecordRejectedBatch(err)
 		// If we have already stored the batch into database, but it's not valid, we
 		// revert all the keys for that batch.
 		result := <-storeChan
@@ -302,11 +351,24 @@ func (n *Node) ProcessBatch(ctx context.Context, header *core.BatchHeader, blobs
 		return nil, err
 	}
 
+	// The deadline may have passed while the batch was stored and validated, in which case it's reverted like an
+	// invalid batch.
+	if err := n.CheckDispersalDeadline(deadline); err != nil {
+		n.Metrics.RecordRejectedBatch(err)
+		if result.keys != nil {
+			if !n.Store.DeleteKeys(ctx, result.keys) {
+				log.Error("Failed to delete the expired batch that should be rolled back", "batchHeaderHash", batchHeaderHash)
+			}
+		}
+		return nil, err
+	}
+
 	// Sign batch header hash if all validation checks pass and data items are writen to database.
 	stageTimer = time.Now()
 	sig := n.KeyPair.SignMessage(batchHeaderHash)
 	log.Trace("Signed batch header hash", "pubkey", hexutil.Encode(n.KeyPair.GetPubKeyG2().Serialize()))
 	n.Metrics.AcceptBatches("signed", batchSize)
+	n.Metrics.RecordSignature()
 	n.Metrics.ObserveLatency("StoreChunks", "signed", float64(time.Since(stageTimer).Milliseconds()))
 	log.Debug("Sign batch took", "duration", time.Since(stageTimer))
 
@@ -316,18 +378,75 @@ func (n *Node) ProcessBatch(ctx context.Context, header *core.BatchHeader, blobs
 	return sig, nil
 }
 
+// SignBatchHeaderHash signs the hash of a batch header with the key of the operator
+func (n *Node) SignBatchHeaderHash(batchHeaderHash [32]byte) *core.Signature {
+	n.idMu.RLock()
+	defer n.idMu.RUnlock()
+	return n.KeyPair.SignMessage(batchHeaderHash)
+}
+
+// CheckDispersalDeadline returns ErrBatchExpired if the dispersal deadline of a batch passed by more than the tolerated
+// skew between the clocks of the node and the disperser. A zero deadline never passes.
+func (n *Node) CheckDispersalDeadline(deadline time.Time) error {
+	if deadline.IsZero() {
+		return nil
+	}
+	if late := time.Since(deadline); late > n.Config.DispersalDeadlineSkew {
+		return fmt.Errorf("%w: the deadline %s passed %s ago, more than the tolerated skew of %s", core.ErrBatchExpired, deadline.UTC().Format(time.RFC3339Nano), late, n.Config.DispersalDeadlineSkew)
+	}
+	return nil
+}
+
+// ValidateBatch validates the blobs against the operator state at the batch's reference block. The operator state is
+// read for every batch, so changes in the operator's quorum registrations apply to the next batch without a restart,
+// and all the blobs of a batch are validated against the same state.
 func (n *Node) ValidateBatch(ctx context.Context, header *core.BatchHeader, blobs []*core.BlobMessage) error {
+	// The operator isn't rotated until the batch is validated
+	n.idMu.RLock()
+	defer n.idMu.RUnlock()
+	return n.validateBatch(ctx, header, blobs)
+}
+
+// validateBatch validates the batch under the read lock of idMu
+func (n *Node) validateBatch(ctx context.Context, header *core.BatchHeader, blobs []*core.BlobMessage) error {
+	// Reject the batches whose stake distribution is too old, e.g. including operators that have been ejected since
+	if n.Config.MaxReferenceBlockAge > 0 {
+		currentBlock, err := n.ChainState.GetCurrentBlockNumber()
+		if err != nil {
+			return fmt.Errorf("failed to get the current block number: %w", err)
+		}
+		if currentBlock > header.ReferenceBlockNumber+n.Config.MaxReferenceBlockAge {
+			return fmt.Errorf("%w: the reference block %d is %d blocks behind the current block %d, more than the max age of %d blocks", ErrStaleReferenceBlock, header.ReferenceBlockNumber, currentBlock-header.ReferenceBlockNumber, currentBlock, n.Config.MaxReferenceBlockAge)
+		}
+	}
+
+	registeredQuorums := n.RegisteredQuorums()
 	operatorState, err := n.ChainState.GetOperatorStateByOperator(ctx, header.ReferenceBlockNumber, n.Config.ID)
 	if err != nil {
 		return err
 	}
 
+	// Only accept chunks for the quorums the operator is registered in, both at the reference block and, when they
+	// are tracked, as currently registered on chain.
+	for _, blob := range blobs {
+		for quorumID, bundle := range blob.Bundles {
+			if len(bundle) == 0 {
+				continue
+			}
+			_, inState := operatorState.Operators[quorumID]
+			_, registered := registeredQuorums[quorumID]
+			if !inState || (registeredQuorums != nil && !registered) {
+				return fmt.Errorf("%w: received chunks for quorum %d", ErrNotRegisteredInQuorum, quorumID)
+			}
+		}
+	}
+
 	pool := workerpool.New(n.Config.NumBatchValidators)
 	out := make(chan error, len(blobs))
 	for _, blob := range blobs {
 		blob := blob
 		pool.Submit(func() {
-			n.validateBlob(ctx, blob, operatorState, out)
+			n.validateBlob(ctx, blob, operatorState, header.ReferenceBlockNumber, out)
 		})
 	}
 
@@ -342,6 +461,128 @@ func (n *Node) ValidateBatch(ctx context.Context, header *core.BatchHeader, blob
 
 }
 
+// OperatorID returns the current ID of the operator
+func (n *Node) OperatorID() core.OperatorID {
+	n.idMu.RLock()
+	defer n.idMu.RUnlock()
+	return n.Config.ID
+}
+
+// operator returns the current ID and key pair of the operator
+func (n *Node) operator() (core.OperatorID, *core.KeyPair) {
+	n.idMu.RLock()
+	defer n.idMu.RUnlock()
+	return n.Config.ID, n.KeyPair
+}
+
+// RotateOperatorKey changes the BLS key of the operator, and the operator ID derived from it, without restarting the
+// node, e.g. after its key changed on chain. The registered quorums of the new ID are fetched first if they are
+// tracked, and the rotation fails without any change if they can't be. The key pair and ID of the node, the ID of its
+// validator and the registered quorums are then replaced together, once the batches being validated and signed are
+// done, so that every batch is validated and signed for a single operator. The subscription to the socket updates of
+// the operator is restarted for the new ID, and the metrics are relabeled.
+func (n *Node) RotateOperatorKey(ctx context.Context, keyPair *core.KeyPair) error {
+	operatorID := keyPair.GetPubKeyG1().GetOperatorID()
+	previousID := n.OperatorID()
+	if operatorID == previousID {
+		return nil
+	}
+
+	var quorums map[core.QuorumID]struct{}
+	if n.RegisteredQuorums() != nil {
+		quorumIDs, err := n.Transactor.GetRegisteredQuorumIdsForOperator(ctx, operatorID)
+		if err != nil {
+			return fmt.Errorf("failed to get the registered quorums of the rotated operator: %w", err)
+		}
+		quorums = quorumSet(quorumIDs)
+	}
+
+	n.idMu.Lock()
+	n.Config.ID = operatorID
+	n.KeyPair = keyPair
+	n.Validator.UpdateOperatorID(operatorID)
+	n.quorumsMu.Lock()
+	n.registeredQuorums = quorums
+	n.quorumsMu.Unlock()
+	n.idMu.Unlock()
+
+	n.restartSocketWatch()
+	if n.Metrics != nil {
+		n.Metrics.SetOperatorID(operatorID)
+	}
+	n.Logger.Info("Rotated the operator ID", "previousOperatorId", hexutil.Encode(previousID[:]), "operatorId", hexutil.Encode(operatorID[:]))
+	return nil
+}
+
+// RegisteredQuorums returns the quorums the operator is registered in as last observed on chain, or nil if they are
+// not known. The returned map must not be modified.
+func (n *Node) RegisteredQuorums() map[core.QuorumID]struct{} {
+	n.quorumsMu.RLock()
+	defer n.quorumsMu.RUnlock()
+	return n.registeredQuorums
+}
+
+// RefreshRegisteredQuorums fetches the quorums the operator is currently registered in from the chain. Batches that
+// are already being validated keep the quorums they started with; the new quorums apply from the next batch.
+func (n *Node) RefreshRegisteredQuorums(ctx context.Context) error {
+	// The quorums of a rotated operator ID aren't stored
+	n.idMu.RLock()
+	defer n.idMu.RUnlock()
+
+	quorumIDs, err := n.Transactor.GetRegisteredQuorumIdsForOperator(ctx, n.Config.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get the registered quorums of the operator: %w", err)
+	}
+	quorums := quorumSet(quorumIDs)
+
+	n.quorumsMu.Lock()
+	defer n.quorumsMu.Unlock()
+	if n.registeredQuorums == nil || !sameQuorums(n.registeredQuorums, quorums) {
+		n.Logger.Info("Registered quorums of the operator changed", "operatorId", hexutil.Encode(n.Config.ID[:]), "quorumIds", quorumIDs)
+	}
+	// Replace rather than update the map, as the previous one may still be read by in-flight batches
+	n.registeredQuorums = quorums
+	return nil
+}
+
+func quorumSet(quorumIDs []core.QuorumID) map[core.QuorumID]struct{} {
+	quorums := make(map[core.QuorumID]struct{}, len(quorumIDs))
+	for _, id := range quorumIDs {
+		quorums[id] = struct{}{}
+	}
+	return quorums
+}
+
+func (n *Node) watchQuorumRegistration(ctx context.Context) {
+	n.Logger.Info("Start watchQuorumRegistration goroutine in background to track the quorums the operator is registered in")
+
+	ticker := time.NewTicker(n.Config.QuorumPollInterval)
+	defer ticker.Stop()
+	for {
+		if err := n.RefreshRegisteredQuorums(ctx); err != nil {
+			n.Logger.Error("failed to refresh the registered quorums", "err", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func sameQuorums(a, b map[core.QuorumID]struct{}) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for id := range a {
+		if _, ok := b[id]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
 func (n *Node) updateSocketAddress(ctx context.Context, newSocketAddr string) {
 	n.mu.Lock()
 	defer n.mu.Unlock()
@@ -359,11 +600,36 @@ func (n *Node) updateSocketAddress(ctx context.Context, newSocketAddr string) {
 	n.CurrentSocket = newSocketAddr
 }
 
-func (n *Node) checkRegisteredNodeIpOnChain(ctx context.Context) {
-	n.Logger.Info("Start checkRegisteredNodeIpOnChain goroutine in background to subscribe the operator socket change events onchain")
+// startSocketWatch subscribes to the socket updates of the operator on chain until the context is done
+func (n *Node) startSocketWatch(ctx context.Context) {
+	n.socketWatchMu.Lock()
+	n.socketWatchCtx = ctx
+	n.socketWatchMu.Unlock()
+	n.restartSocketWatch()
+}
 
-	socketChan, err := n.OperatorSocketsFilterer.WatchOperatorSocketUpdate(ctx, n.Config.ID)
+// restartSocketWatch replaces the subscription to the socket updates of the operator with one for its current ID, if
+// the subscription was started
+func (n *Node) restartSocketWatch() {
+	n.socketWatchMu.Lock()
+	defer n.socketWatchMu.Unlock()
+	if n.socketWatchCtx == nil {
+		return
+	}
+	if n.cancelSocketWatch != nil {
+		n.cancelSocketWatch()
+	}
+	ctx, cancel := context.WithCancel(n.socketWatchCtx)
+	n.cancelSocketWatch = cancel
+	go n.checkRegisteredNodeIpOnChain(ctx, n.OperatorID())
+}
+
+func (n *Node) checkRegisteredNodeIpOnChain(ctx context.Context, operatorID core.OperatorID) {
+	n.Logger.Info("Start checkRegisteredNodeIpOnChain goroutine in background to subscribe the operator socket change events onchain", "operatorId", hexutil.Encode(operatorID[:]))
+
+	socketChan, err := n.OperatorSocketsFilterer.WatchOperatorSocketUpdate(ctx, operatorID)
 	if err != nil {
+		n.Logger.Error("failed to subscribe to the operator socket change events", "err", err)
 		return
 	}
 
@@ -371,7 +637,13 @@ func (n *Node) checkRegisteredNodeIpOnChain(ctx context.Context) {
 		select {
 		case <-ctx.Done():
 			return
-		case socket := <-socketChan:
+		case socket, ok := <-socketChan:
+			if !ok {
+				if ctx.Err() == nil {
+					n.Logger.Error("the subscription to the operator socket change events ended", "operatorId", hexutil.Encode(operatorID[:]))
+				}
+				return
+			}
 			n.mu.Lock()
 			if socket != n.CurrentSocket {
 				n.Logger.Info("Detected socket registered onchain which is different than the socket kept at the DA Node", "socket kept at DA Node", n.CurrentSocket, "socket registered onchain", socket, "the action taken", "update the socket kept at DA Node")
@@ -448,8 +720,8 @@ func buildSdkClients(config *Config, logger common.Logger) (*constructor.Clients
 	return sdkClients, nil
 }
 
-func (n *Node) validateBlob(ctx context.Context, blob *core.BlobMessage, operatorState *core.OperatorState, out chan error) {
-	err := n.Validator.ValidateBlob(blob, operatorState)
+func (n *Node) validateBlob(ctx context.Context, blob *core.BlobMessage, operatorState *core.OperatorState, referenceBlockNumber uint, out chan error) {
+	err := n.Validator.ValidateBlob(blob, operatorState, referenceBlockNumber)
 	if err != nil {
 		out <- err
 		return
@@ -0,0 +1,328 @@
+package node_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common/mock"
+	"github.com/Layr-Labs/eigenda/core"
+	core_mock "github.com/Layr-Labs/eigenda/core/mock"
+	"github.com/Layr-Labs/eigenda/node"
+	"github.com/Layr-Labs/eigensdk-go/metrics"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var blobData = []byte("the quick brown fox jumps over the lazy dog, again and again and again")
+
+func makeOperatorID(id int) core.OperatorID {
+	data := [32]byte{}
+	copy(data[:], []byte(fmt.Sprintf("%d", id)))
+	return data
+}
+
+// makeBlob encodes a blob dispersed to the quorum and returns the message holding the operator's chunks
+func makeBlob(t *testing.T, cst core.ChainState, enc core.Encoder, quorumID core.QuorumID, operatorID core.OperatorID) *core.BlobMessage {
+	securityParam := core.SecurityParam{QuorumID: quorumID, AdversaryThreshold: 50, QuorumThreshold: 100}
+	asn := &core.StdAssignmentCoordinator{}
+	state, err := cst.GetOperatorState(context.Background(), 0, []core.QuorumID{quorumID})
+	require.NoError(t, err)
+
+	assignments, info, err := asn.GetAssignments(state, quorumID, 1, 0)
+	require.NoError(t, err)
+	numOperators := uint(len(state.Operators[quorumID]))
+	chunkLength, err := asn.GetMinimumChunkLength(numOperators, core.GetBlobLength(uint(len(blobData))), 1, securityParam.QuorumThreshold, securityParam.AdversaryThreshold)
+	require.NoError(t, err)
+	params, err := core.GetEncodingParams(chunkLength, info.TotalChunks)
+	require.NoError(t, err)
+
+	commitments, chunks, err := enc.Encode(blobData, params)
+	require.NoError(t, err)
+
+	assignment := assignments[operatorID]
+	return &core.BlobMessage{
+		BlobHeader: &core.BlobHeader{
+			BlobCommitments: commitments,
+			QuorumInfos: []*core.BlobQuorumInfo{{
+				SecurityParam:      securityParam,
+				QuantizationFactor: 1,
+				EncodedBlobLength:  params.ChunkLength * numOperators,
+			}},
+		},
+		Bundles: core.Bundles{
+			quorumID: chunks[assignment.StartIndex : assignment.StartIndex+assignment.NumChunks],
+		},
+	}
+}
+
+func newTestNode(cst *core_mock.ChainDataMock, tx core.Transactor, operatorID core.OperatorID) *node.Node {
+	return &node.Node{
+		Config: &node.Config{
+			ID:                 operatorID,
+			NumBatchValidators: 4,
+		},
+		Logger:     &mock.Logger{},
+		ChainState: cst,
+		Transactor: tx,
+		Validator:  core.NewChunkValidator(core_mock.NewSeededEncoder(1), &core.StdAssignmentCoordinator{}, cst, operatorID, 0, 0, 0),
+	}
+}
+
+func TestValidateBatchFollowsQuorumRegistration(t *testing.T) {
+	ctx := context.Background()
+	operatorID := makeOperatorID(3)
+	cst, err := core_mock.NewChainDataMock(core.OperatorIndex(4))
+	require.NoError(t, err)
+	tx := &core_mock.MockTransactor{}
+	n := newTestNode(cst, tx, operatorID)
+
+	enc := core_mock.NewSeededEncoder(1)
+	header := &core.BatchHeader{ReferenceBlockNumber: 1}
+	blobs := []*core.BlobMessage{makeBlob(t, cst, enc, 1, operatorID)}
+
+	// Quorums that aren't tracked only depend on the state at the reference block
+	assert.Nil(t, n.RegisteredQuorums())
+	assert.NoError(t, n.ValidateBatch(ctx, header, blobs))
+
+	// The operator is only registered in quorum 0
+	cst.SetOperatorQuorums(operatorID, []core.QuorumID{0})
+	tx.On("GetRegisteredQuorumIdsForOperator").Return([]core.QuorumID{0}, nil).Once()
+	require.NoError(t, n.RefreshRegisteredQuorums(ctx))
+	assert.ErrorIs(t, n.ValidateBatch(ctx, header, blobs), node.ErrNotRegisteredInQuorum)
+
+	// The operator registers in quorum 1 without restarting the node
+	cst.SetOperatorQuorums(operatorID, []core.QuorumID{0, 1})
+	tx.On("GetRegisteredQuorumIdsForOperator").Return([]core.QuorumID{0, 1}, nil).Once()
+	require.NoError(t, n.RefreshRegisteredQuorums(ctx))
+	assert.Len(t, n.RegisteredQuorums(), 2)
+	assert.NoError(t, n.ValidateBatch(ctx, header, blobs))
+
+	// Chunks for quorum 1 are still validated
+	tampered := *blobs[0]
+	tampered.Bundles = core.Bundles{1: blobs[0].Bundles[1][1:]}
+	assert.Error(t, n.ValidateBatch(ctx, header, []*core.BlobMessage{&tampered}))
+
+	// The operator deregisters from quorum 1, which isn't reflected at the reference block yet
+	tx.On("GetRegisteredQuorumIdsForOperator").Return([]core.QuorumID{0}, nil).Once()
+	require.NoError(t, n.RefreshRegisteredQuorums(ctx))
+	assert.ErrorIs(t, n.ValidateBatch(ctx, header, blobs), node.ErrNotRegisteredInQuorum)
+}
+
+func TestValidateBatchDuringQuorumRegistrationChange(t *testing.T) {
+	ctx := context.Background()
+	operatorID := makeOperatorID(3)
+	cst, err := core_mock.NewChainDataMock(core.OperatorIndex(4))
+	require.NoError(t, err)
+	tx := &core_mock.MockTransactor{}
+	tx.On("GetRegisteredQuorumIdsForOperator").Return([]core.QuorumID{0, 1}, nil)
+	n := newTestNode(cst, tx, operatorID)
+	require.NoError(t, n.RefreshRegisteredQuorums(ctx))
+
+	enc := core_mock.NewSeededEncoder(1)
+	header := &core.BatchHeader{ReferenceBlockNumber: 1}
+	blobs := []*core.BlobMessage{makeBlob(t, cst, enc, 0, operatorID), makeBlob(t, cst, enc, 0, operatorID)}
+
+	// Batches for a quorum the operator stays registered in are accepted while the registrations are refreshed
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				assert.NoError(t, n.ValidateBatch(ctx, header, blobs))
+			}
+		}()
+	}
+	for j := 0; j < 10; j++ {
+		assert.NoError(t, n.RefreshRegisteredQuorums(ctx))
+	}
+	wg.Wait()
+}
+
+func TestRotateOperatorKey(t *testing.T) {
+	ctx := context.Background()
+	operatorID := makeOperatorID(3)
+	cst, err := core_mock.NewChainDataMock(core.OperatorIndex(4))
+	require.NoError(t, err)
+	tx := &core_mock.MockTransactor{}
+	tx.On("GetRegisteredQuorumIdsForOperator").Return([]core.QuorumID{0, 1}, nil).Once()
+	n := newTestNode(cst, tx, operatorID)
+	n.KeyPair = cst.KeyPairs[3]
+	n.Metrics = node.NewMetrics(metrics.NewNoopMetrics(), prometheus.NewRegistry(), &mock.Logger{}, ":9090")
+	n.Metrics.SetOperatorID(operatorID)
+	require.NoError(t, n.RefreshRegisteredQuorums(ctx))
+
+	enc := core_mock.NewSeededEncoder(1)
+	header := &core.BatchHeader{ReferenceBlockNumber: 1}
+	blobs := []*core.BlobMessage{makeBlob(t, cst, enc, 0, operatorID)}
+	require.NoError(t, n.ValidateBatch(ctx, header, blobs))
+
+	rotatedKey, err := core.GenRandomBlsKeys()
+	require.NoError(t, err)
+	rotatedID := rotatedKey.GetPubKeyG1().GetOperatorID()
+
+	// The rotation fails without any change if the quorums of the new ID can't be fetched
+	tx.On("GetRegisteredQuorumIdsForOperator").Return([]core.QuorumID{}, errors.New("rpc error")).Once()
+	assert.ErrorContains(t, n.RotateOperatorKey(ctx, rotatedKey), "rpc error")
+	assert.Equal(t, operatorID, n.OperatorID())
+	assert.Equal(t, cst.KeyPairs[3], n.KeyPair)
+	assert.Len(t, n.RegisteredQuorums(), 2)
+	assert.NoError(t, n.ValidateBatch(ctx, header, blobs))
+
+	// The key and the ID derived from it are rotated together, along with the quorums the new ID is registered in
+	tx.On("GetRegisteredQuorumIdsForOperator").Return([]core.QuorumID{0}, nil).Once()
+	require.NoError(t, n.RotateOperatorKey(ctx, rotatedKey))
+	assert.Equal(t, rotatedID, n.OperatorID())
+	assert.Equal(t, rotatedKey, n.KeyPair)
+	assert.Equal(t, map[core.QuorumID]struct{}{0: {}}, n.RegisteredQuorums())
+	assert.Error(t, n.ValidateBatch(ctx, header, blobs))
+	assert.Equal(t, 1.0, testutil.ToFloat64(n.Metrics.OperatorInfo.WithLabelValues(hexutil.Encode(rotatedID[:]))))
+	assert.Equal(t, 1, testutil.CollectAndCount(n.Metrics.OperatorInfo))
+
+	// The batches are signed with the new key
+	batchHeaderHash, err := header.GetBatchHeaderHash()
+	require.NoError(t, err)
+	assert.True(t, n.SignBatchHeaderHash(batchHeaderHash).Verify(rotatedKey.GetPubKeyG2(), batchHeaderHash))
+
+	// Rotating to the current key is a no-op
+	require.NoError(t, n.RotateOperatorKey(ctx, rotatedKey))
+	tx.AssertExpectations(t)
+}
+
+func TestValidateBatchRejectsStaleReferenceBlock(t *testing.T) {
+	ctx := context.Background()
+	operatorID := makeOperatorID(3)
+	cst, err := core_mock.NewChainDataMock(core.OperatorIndex(4))
+	require.NoError(t, err)
+	n := newTestNode(cst, &core_mock.MockTransactor{}, operatorID)
+	n.Config.MaxReferenceBlockAge = 10
+
+	enc := core_mock.NewSeededEncoder(1)
+	header := &core.BatchHeader{ReferenceBlockNumber: 100}
+	blobs := []*core.BlobMessage{makeBlob(t, cst, enc, 0, operatorID)}
+
+	// The reference block may be exactly as old as the max age
+	cst.On("GetCurrentBlockNumber").Return(uint(110), nil).Once()
+	assert.NoError(t, n.ValidateBatch(ctx, header, blobs))
+
+	cst.On("GetCurrentBlockNumber").Return(uint(111), nil).Once()
+	err = n.ValidateBatch(ctx, header, blobs)
+	assert.ErrorIs(t, err, node.ErrStaleReferenceBlock)
+	assert.EqualError(t, err, "reference block is too old: the reference block 100 is 11 blocks behind the current block 111, more than the max age of 10 blocks")
+
+	cst.On("GetCurrentBlockNumber").Return(uint(0), errors.New("rpc unavailable")).Once()
+	assert.Error(t, n.ValidateBatch(ctx, header, blobs))
+
+	// The age isn't checked without a max age
+	n.Config.MaxReferenceBlockAge = 0
+	assert.NoError(t, n.ValidateBatch(ctx, header, blobs))
+}
+
+func TestCheckDispersalDeadline(t *testing.T) {
+	n := newTestNode(nil, nil, makeOperatorID(3))
+	n.Config.DispersalDeadlineSkew = time.Minute
+
+	// The deadline may have passed by up to the skew between the clocks of the node and the disperser
+	assert.NoError(t, n.CheckDispersalDeadline(time.Now().Add(time.Second)))
+	assert.NoError(t, n.CheckDispersalDeadline(time.Now().Add(-59*time.Second)))
+	err := n.CheckDispersalDeadline(time.Now().Add(-61 * time.Second))
+	assert.ErrorIs(t, err, core.ErrBatchExpired)
+	assert.Contains(t, err.Error(), "more than the tolerated skew of 1m0s")
+
+	// Without skew, the batches are refused as soon as their deadline passed
+	n.Config.DispersalDeadlineSkew = 0
+	assert.ErrorIs(t, n.CheckDispersalDeadline(time.Now().Add(-time.Millisecond)), core.ErrBatchExpired)
+
+	// The batches without deadline never expire
+	assert.NoError(t, n.CheckDispersalDeadline(time.Time{}))
+}
+
+func TestConfigValidate(t *testing.T) {
+	valid := func() *node.Config {
+		return &node.Config{
+			DispersalPort:             "32003",
+			RetrievalPort:             "32004",
+			InternalDispersalPort:     "32003",
+			InternalRetrievalPort:     "32004",
+			Timeout:                   10 * time.Second,
+			ExpirationPollIntervalSec: 10,
+			QuorumIDList:              []core.QuorumID{0},
+			DbPath:                    "/data/db",
+			DbBackend:                 node.LevelDBBackend,
+			NumBatchValidators:        128,
+			DisperserAddress:          gethcommon.HexToAddress("0x1234"),
+		}
+	}
+	assert.NoError(t, valid().Validate())
+
+	tests := []struct {
+		name   string
+		modify func(*node.Config)
+		errors []string
+	}{
+		{
+			name:   "invalid dispersal port",
+			modify: func(c *node.Config) { c.DispersalPort = "dispersal" },
+			errors: []string{`the dispersal port must be a port number, but found "dispersal"`},
+		},
+		{
+			name: "min reference block age above the max",
+			modify: func(c *node.Config) {
+				c.MinReferenceBlockAge = 10
+				c.MaxReferenceBlockAge = 5
+			},
+			errors: []string{"the min reference block age (10) exceeds the max reference block age (5), so that no batch would be accepted"},
+		},
+		{
+			name:   "leveldb backend without path",
+			modify: func(c *node.Config) { c.DbPath = "" },
+			errors: []string{"the db path must be set with the leveldb backend"},
+		},
+		{
+			name:   "missing disperser address",
+			modify: func(c *node.Config) { c.DisperserAddress = gethcommon.Address{} },
+			errors: []string{"the disperser address must be set unless the disperser auth is disabled"},
+		},
+		{
+			name:   "negative dispersal deadline skew",
+			modify: func(c *node.Config) { c.DispersalDeadlineSkew = -time.Second },
+			errors: []string{"the dispersal deadline skew must not be negative, but found -1s"},
+		},
+		{
+			name: "every violation is reported",
+			modify: func(c *node.Config) {
+				c.Timeout = 0
+				c.ExpirationPollIntervalSec = 3
+				c.QuorumIDList = nil
+				c.DbBackend = "rocksdb"
+				c.NumBatchValidators = 0
+			},
+			errors: []string{
+				"the timeout must be positive, but found 0s",
+				"the expiration poll interval must be greater than 3 seconds, but found 3",
+				"the quorum ID list must not be empty",
+				`the db backend must be "leveldb" or "memory", but found "rocksdb"`,
+				"the number of batch validators must be positive, but found 0",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := valid()
+			tt.modify(config)
+			err := config.Validate()
+			if assert.Error(t, err) {
+				assert.Equal(t, strings.Join(tt.errors, "\n"), err.Error())
+			}
+		})
+	}
+}
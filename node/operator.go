@@ -2,7 +2,6 @@ package node
 
 import (
 	"context"
-	"crypto/tls"
 	"errors"
 	"fmt"
 	"time"
@@ -14,7 +13,6 @@ import (
 	"github.com/ethereum/go-ethereum/crypto"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
-	"google.golang.org/grpc/credentials/insecure"
 )
 
 type Operator struct {
@@ -25,8 +23,9 @@ type Operator struct {
 	QuorumIDs  []core.QuorumID
 }
 
-// Register operator registers the operator with the given public key for the given quorum IDs.
-func RegisterOperator(ctx context.Context, operator *Operator, transactor core.Transactor, churnerUrl string, useSecureGrpc bool, logger common.Logger) error {
+// Register operator registers the operator with the given public key for the given quorum IDs. The churner is dialed
+// with the given transport credentials if one of the quorums is full.
+func RegisterOperator(ctx context.Context, operator *Operator, transactor core.Transactor, churnerUrl string, churnerCreds credentials.TransportCredentials, logger common.Logger) error {
 	registeredQuorumIds, err := transactor.GetRegisteredQuorumIdsForOperator(ctx, operator.OperatorId)
 	if err != nil {
 		return fmt.Errorf("failed to get registered quorum ids for an operator: %w", err)
@@ -74,7 +73,7 @@ func RegisterOperator(ctx context.Context, operator *Operator, transactor core.T
 
 	// if we should call the churner, call it
 	if shouldCallChurner {
-		churnReply, err := requestChurnApproval(ctx, operator, churnerUrl, useSecureGrpc, logger)
+		churnReply, err := requestChurnApproval(ctx, operator, churnerUrl, churnerCreds, logger)
 		if err != nil {
 			return fmt.Errorf("failed to request churn approval: %w", err)
 		}
@@ -95,18 +94,12 @@ func DeregisterOperator(ctx context.Context, KeyPair *core.KeyPair, transactor c
 	return transactor.DeregisterOperator(ctx, KeyPair.GetPubKeyG1(), blockNumber)
 }
 
-func requestChurnApproval(ctx context.Context, operator *Operator, churnerUrl string, useSecureGrpc bool, logger common.Logger) (*grpcchurner.ChurnReply, error) {
+func requestChurnApproval(ctx context.Context, operator *Operator, churnerUrl string, creds credentials.TransportCredentials, logger common.Logger) (*grpcchurner.ChurnReply, error) {
 	logger.Info("churner url", "url", churnerUrl)
 
-	var credential = insecure.NewCredentials()
-	if useSecureGrpc {
-		config := &tls.Config{}
-		credential = credentials.NewTLS(config)
-	}
-
 	conn, err := grpc.Dial(
 		churnerUrl,
-		grpc.WithTransportCredentials(credential),
+		grpc.WithTransportCredentials(creds),
 	)
 	if err != nil {
 		logger.Error("Node cannot connect to churner", "err", err)
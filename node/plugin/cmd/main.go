@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/Layr-Labs/eigenda/common/geth"
+	commongrpc "github.com/Layr-Labs/eigenda/common/grpc"
 	"github.com/Layr-Labs/eigenda/common/logging"
 	"github.com/Layr-Labs/eigenda/core"
 	"github.com/Layr-Labs/eigenda/core/eth"
@@ -121,7 +122,12 @@ func pluginOps(ctx *cli.Context) {
 	}
 	if config.Operation == "opt-in" {
 		log.Printf("Info: Operator with Operator Address: %x is opting in to EigenDA", sk.Address)
-		err = node.RegisterOperator(context.Background(), operator, tx, config.ChurnerUrl, true, logger)
+		churnerCreds, err := commongrpc.ClientTLSConfig{Enabled: true}.Credentials()
+		if err != nil {
+			log.Printf("Error: failed to create the churner credentials: %v", err)
+			return
+		}
+		err = node.RegisterOperator(context.Background(), operator, tx, config.ChurnerUrl, churnerCreds, logger)
 		if err != nil {
 			log.Printf("Error: failed to opt-in EigenDA Node Network for operator ID: %x, operator address: %x, error: %v", operatorID, sk.Address, err)
 			return
@@ -10,7 +10,6 @@ import (
 	"github.com/Layr-Labs/eigenda/api/grpc/node"
 	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/core"
-	"github.com/Layr-Labs/eigenda/node/leveldb"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"google.golang.org/protobuf/proto"
 )
@@ -25,7 +24,7 @@ var ErrBatchAlreadyExist = errors.New("batch already exists")
 
 // Store is a key-value database to store blob data (blob header, blob chunks etc).
 type Store struct {
-	db     DB
+	db     ChunkStore
 	logger common.Logger
 
 	blockStaleMeasure   uint32
@@ -33,26 +32,37 @@ type Store struct {
 
 	// The DA Node's metrics.
 	metrics *Metrics
+
+	// Called with the header hash of each batch removed by the expiration.
+	expirationListeners []func(batchHeaderHash [32]byte)
 }
 
-// NewLevelDBStore creates a new Store object with a db at the provided path and the given logger.
-// TODO(jianoaix): parameterize this so we can switch between different database backends.
+// NewLevelDBStore creates a new Store object with a LevelDB db at the provided path and the given logger.
 func NewLevelDBStore(path string, logger common.Logger, metrics *Metrics, blockStaleMeasure, storeDurationBlocks uint32) (*Store, error) {
-	// Create the db at the path. This is currently hardcoded to use
-	// levelDB.
-	db, err := leveldb.NewLevelDBStore(path)
+	db, err := NewLevelDBChunkStore(path)
 	if err != nil {
 		logger.Error("Could not create leveldb database", "err", err)
 		return nil, err
 	}
 
+	return NewStore(db, logger, metrics, blockStaleMeasure, storeDurationBlocks), nil
+}
+
+// NewStore creates a new Store object backed by the provided db.
+func NewStore(db ChunkStore, logger common.Logger, metrics *Metrics, blockStaleMeasure, storeDurationBlocks uint32) *Store {
 	return &Store{
 		db:                  db,
 		logger:              logger,
 		blockStaleMeasure:   blockStaleMeasure,
 		storeDurationBlocks: storeDurationBlocks,
 		metrics:             metrics,
-	}, nil
+	}
+}
+
+// OnBatchExpired registers a function to call with the header hash of each batch removed by the expiration, e.g. to
+// invalidate data cached from the batch. It must be called before the expiration starts.
+func (s *Store) OnBatchExpired(f func(batchHeaderHash [32]byte)) {
+	s.expirationListeners = append(s.expirationListeners, f)
 }
 
 // Delete expired entries in the store.
@@ -91,26 +101,25 @@ func (s *Store) DeleteExpiredEntries(currentTimeUnixSec int64, timeLimitSec uint
 // is set to -1 (invalid value) if the deletion status is an error.
 func (s *Store) deleteNBatches(currentTimeUnixSec int64, numBatches int) (int, error) {
 	// Scan for expired batches.
-	iter := s.db.NewIterator(EncodeBatchExpirationKeyPrefix())
 	expiredKeys := make([][]byte, 0)
 	expiredBatches := make([][]byte, 0)
-	for iter.Next() {
-		ts, err := DecodeBatchExpirationKey(iter.Key())
+	err := s.db.Iterate(EncodeBatchExpirationKeyPrefix(), func(key, value []byte) bool {
+		ts, err := DecodeBatchExpirationKey(key)
 		if err != nil {
-			s.logger.Error("Could not decode the expiration key", "key:", iter.Key(), "error:", err)
-			continue
+			s.logger.Error("Could not decode the expiration key", "key:", key, "error:", err)
+			return true
 		}
 		// No more rows expired up to current time.
 		if currentTimeUnixSec < ts {
-			break
-		}
-		expiredKeys = append(expiredKeys, copyBytes(iter.Key()))
-		expiredBatches = append(expiredBatches, copyBytes(iter.Value()))
-		if len(expiredKeys) == numBatches {
-			break
+			return false
 		}
+		expiredKeys = append(expiredKeys, copyBytes(key))
+		expiredBatches = append(expiredBatches, copyBytes(value))
+		return len(expiredKeys) < numBatches
+	})
+	if err != nil {
+		return -1, err
 	}
-	iter.Release()
 
 	// No expired batch found.
 	if len(expiredKeys) == 0 {
@@ -128,23 +137,27 @@ func (s *Store) deleteNBatches(currentTimeUnixSec int64, numBatches int) (int, e
 		expiredKeys = append(expiredKeys, EncodeBatchHeaderKey(batchHeaderHash))
 
 		// Blob headers.
-		blobHeaderIter := s.db.NewIterator(EncodeBlobHeaderKeyPrefix(batchHeaderHash))
-		for blobHeaderIter.Next() {
-			expiredKeys = append(expiredKeys, copyBytes(blobHeaderIter.Key()))
+		err := s.db.Iterate(EncodeBlobHeaderKeyPrefix(batchHeaderHash), func(key, value []byte) bool {
+			expiredKeys = append(expiredKeys, copyBytes(key))
+			return true
+		})
+		if err != nil {
+			return -1, err
 		}
-		blobHeaderIter.Release()
 
 		// Blob chunks.
-		blobIter := s.db.NewIterator(bytes.NewBuffer(hash).Bytes())
-		for blobIter.Next() {
-			expiredKeys = append(expiredKeys, copyBytes(blobIter.Key()))
-			size += int64(len(blobIter.Value()))
+		err = s.db.Iterate(hash, func(key, value []byte) bool {
+			expiredKeys = append(expiredKeys, copyBytes(key))
+			size += int64(len(value))
+			return true
+		})
+		if err != nil {
+			return -1, err
 		}
-		blobIter.Release()
 	}
 
 	// Perform the removal.
-	err := s.db.DeleteBatch(expiredKeys)
+	err = s.db.DeleteBatch(expiredKeys)
 	if err != nil {
 		s.logger.Error("Failed to delete the expired keys in batch", "keys:", expiredKeys, "error:", err)
 		return -1, err
@@ -152,10 +165,30 @@ func (s *Store) deleteNBatches(currentTimeUnixSec int64, numBatches int) (int, e
 
 	// Update the current live batch metric.
 	s.metrics.RemoveNCurrentBatch(len(expiredBatches), size)
+	s.metrics.AddPrunedBytes(size)
+	s.updateSizeMetric()
+
+	for _, hash := range expiredBatches {
+		var batchHeaderHash [32]byte
+		copy(batchHeaderHash[:], hash)
+		for _, listener := range s.expirationListeners {
+			listener(batchHeaderHash)
+		}
+	}
 
 	return len(expiredBatches), nil
 }
 
+// updateSizeMetric sets the chunk store size metric to the current size of the db
+func (s *Store) updateSizeMetric() {
+	size, err := s.db.Size()
+	if err != nil {
+		s.logger.Warn("Failed to get the size of the chunk store", "err", err)
+		return
+	}
+	s.metrics.SetChunkStoreSize(size)
+}
+
 // Store the batch into the store.
 //
 // The batch will be itemized into multiple entries when it's stored:
@@ -217,6 +250,7 @@ func (s *Store) StoreBatch(ctx context.Context, header *core.BatchHeader, blobs
 
 	// Generate key/value pairs for all blob headers and blob chunks .
 	size := int64(0)
+	quorumSizes := make(map[core.QuorumID]int)
 	for idx, blob := range blobs {
 		// blob header
 		blobHeaderKey, err := EncodeBlobHeaderKey(batchHeaderHash, idx)
@@ -254,6 +288,7 @@ func (s *Store) StoreBatch(ctx context.Context, header *core.BatchHeader, blobs
 				return nil, err
 			}
 			size += int64(len(chunkBytes))
+			quorumSizes[quorumInfo.QuorumID] += len(chunkBytes)
 
 			keys = append(keys, key)
 			values = append(values, chunkBytes)
@@ -262,12 +297,18 @@ func (s *Store) StoreBatch(ctx context.Context, header *core.BatchHeader, blobs
 	}
 
 	// Write all the key/value pairs to the local database atomically.
-	err = s.db.WriteBatch(keys, values)
+	start := time.Now()
+	err = s.db.PutBatch(keys, values)
 	if err != nil {
 		log.Error("Failed to write the batch into local database:", "err", err)
 		return nil, err
 	}
+	s.metrics.ObserveStoreLatency(time.Since(start))
 	s.metrics.AddCurrentBatch(size)
+	for quorumID, quorumSize := range quorumSizes {
+		s.metrics.AddStoredChunkBytes(quorumID, quorumSize)
+	}
+	s.updateSizeMetric()
 
 	return &keys, nil
 }
@@ -277,9 +318,6 @@ func (s *Store) GetBatchHeader(ctx context.Context, batchHeaderHash [32]byte) ([
 	batchHeaderKey := EncodeBatchHeaderKey(batchHeaderHash)
 	data, err := s.db.Get(batchHeaderKey)
 	if err != nil {
-		if errors.Is(err, leveldb.ErrNotFound) {
-			return nil, ErrKeyNotFound
-		}
 		return nil, err
 	}
 	return data, nil
@@ -293,9 +331,6 @@ func (s *Store) GetBlobHeader(ctx context.Context, batchHeaderHash [32]byte, blo
 	}
 	data, err := s.db.Get(blobHeaderKey)
 	if err != nil {
-		if errors.Is(err, leveldb.ErrNotFound) {
-			return nil, ErrKeyNotFound
-		}
 		return nil, err
 	}
 	return data, nil
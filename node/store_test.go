@@ -128,11 +128,20 @@ func CreateBatch(t *testing.T) (*core.BatchHeader, []*core.BlobMessage, []*pb.Bl
 }
 
 func TestStoringBlob(t *testing.T) {
+	for backend, newChunkStore := range chunkStoreBackends {
+		newChunkStore := newChunkStore
+		t.Run(backend, func(t *testing.T) {
+			testStoringBlob(t, newChunkStore(t))
+		})
+	}
+}
+
+func testStoringBlob(t *testing.T, chunkStore node.ChunkStore) {
 	staleMeasure := uint32(1)
 	storeDuration := uint32(1)
 	noopMetrics := metrics.NewNoopMetrics()
 	reg := prometheus.NewRegistry()
-	s, _ := node.NewLevelDBStore(t.TempDir(), &mock.Logger{}, node.NewMetrics(noopMetrics, reg, &mock.Logger{}, ":9090"), staleMeasure, storeDuration)
+	s := node.NewStore(chunkStore, &mock.Logger{}, node.NewMetrics(noopMetrics, reg, &mock.Logger{}, ":9090"), staleMeasure, storeDuration)
 	ctx := context.Background()
 
 	// Empty store
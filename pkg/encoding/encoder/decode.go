@@ -1,11 +1,28 @@
 package encoder
 
 import (
-	"errors"
+	"fmt"
 
 	bls "github.com/Layr-Labs/eigenda/pkg/kzg/bn254"
 )
 
+// ErrInsufficientChunks is returned when decoding from fewer distinct frames than needed to recover the data
+type ErrInsufficientChunks struct {
+	// Received is the number of distinct frames received, the duplicate frames being counted once
+	Received uint64
+	// Required is the number of distinct frames needed to recover the data
+	Required uint64
+}
+
+func (e *ErrInsufficientChunks) Error() string {
+	return fmt.Sprintf("insufficient chunks to decode: received %d distinct chunks but %d are required, %d more are needed", e.Received, e.Required, e.Needed())
+}
+
+// Needed returns the number of distinct frames missing to recover the data
+func (e *ErrInsufficientChunks) Needed() uint64 {
+	return e.Required - e.Received
+}
+
 // Decode data when some chunks from systematic nodes are lost. It first uses FFT to recover
 // the whole polynomial. Then it extracts only the systematic chunks.
 // It takes a list of available frame, and return the original encoded data
@@ -15,16 +32,39 @@ import (
 // maxInputSize is the upper bound of the original data size. This is needed because
 // the frames and indices don't encode the length of the original data. If maxInputSize
 // is smaller than the original input size, decoded data will be trimmed to fit the maxInputSize.
+// The frames can be any subset of the frames, in any order. A frame received several times is
+// only used once. Decoding fails with ErrInsufficientChunks if there are too few distinct frames.
 func (g *Encoder) Decode(frames []Frame, indices []uint64, maxInputSize uint64) ([]byte, error) {
-	numSys := GetNumSys(maxInputSize, g.ChunkLen)
+	if len(frames) != len(indices) {
+		return nil, fmt.Errorf("number of frames %d does not match number of indices %d", len(frames), len(indices))
+	}
 
-	if uint64(len(frames)) < numSys {
-		return nil, errors.New("number of frame must be sufficient")
+	// The data polynomial has at most as many coefficients as the data symbols, rounded up to whole
+	// frames, so that many distinct frames are enough to recover it. All the frames always are.
+	numSys := RoundUpDivision(GetNumElement(maxInputSize, bls.BYTES_PER_COEFFICIENT), g.ChunkLen)
+	if numSys > g.NumChunks {
+		numSys = g.NumChunks
+	}
+
+	distinct := make(map[uint64]int, len(indices))
+	for i, d := range indices {
+		if d >= g.NumChunks {
+			return nil, fmt.Errorf("invalid frame index %d: there are %d frames", d, g.NumChunks)
+		}
+		if _, ok := distinct[d]; !ok {
+			distinct[d] = i
+		}
+	}
+	if uint64(len(distinct)) < numSys {
+		return nil, &ErrInsufficientChunks{
+			Received: uint64(len(distinct)),
+			Required: numSys,
+		}
 	}
 
 	samples := make([]*bls.Fr, g.NumEvaluations())
 	// copy evals based on frame coeffs into samples
-	for i, d := range indices {
+	for d, i := range distinct {
 		f := frames[i]
 		e, err := GetLeadingCosetIndex(d, g.NumChunks)
 		if err != nil {
@@ -72,37 +72,78 @@ func (g *Encoder) MakeFrames(
 	if err != nil {
 		return nil, nil, err
 	}
-	k := uint64(0)
 
-	indices := make([]uint32, 0)
+	indices := make([]uint32, g.NumChunks)
 	frames := make([]Frame, g.NumChunks)
 
+	numWorker := g.numWorker
+	if numWorker == 0 {
+		numWorker = 1
+	}
+
+	jobChan := make(chan uint64, numWorker)
+	results := make(chan error, numWorker)
+
+	for w := uint64(0); w < numWorker; w++ {
+		go g.interpolationWorker(polyEvals, jobChan, frames, indices, results)
+	}
+
 	for i := uint64(0); i < uint64(g.NumChunks); i++ {
+		jobChan <- i
+	}
+	close(jobChan)
+
+	// return only first error
+	for w := uint64(0); w < numWorker; w++ {
+		interErr := <-results
+		if interErr != nil && err == nil {
+			err = interErr
+		}
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return frames, indices, nil
+}
+
+// interpolationWorker computes the interpolating polynomial of every chunk received on jobChan.
+// Each chunk occupies a disjoint range of polyEvals, frames and indices, so workers never share writes.
+func (g *Encoder) interpolationWorker(
+	polyEvals []bls.Fr,
+	jobChan <-chan uint64,
+	frames []Frame,
+	indices []uint32,
+	results chan<- error,
+) {
+	var err error
+	for i := range jobChan {
+		if err != nil {
+			// drain remaining jobs after a failure
+			continue
+		}
 
 		// finds out which coset leader i-th node is having
 		j := rb.ReverseBitsLimited(uint32(g.NumChunks), uint32(i))
 
 		// mutltiprover return proof in butterfly order
-		frame := Frame{}
-		indices = append(indices, j)
+		indices[i] = j
 
 		ys := polyEvals[g.ChunkLen*i : g.ChunkLen*(i+1)]
-		err := rb.ReverseBitOrderFr(ys)
+		err = rb.ReverseBitOrderFr(ys)
 		if err != nil {
-			return nil, nil, err
+			continue
 		}
-		coeffs, err := g.GetInterpolationPolyCoeff(ys, uint32(j))
+		var coeffs []bls.Fr
+		coeffs, err = g.GetInterpolationPolyCoeff(ys, uint32(j))
 		if err != nil {
-			return nil, nil, err
+			continue
 		}
 
-		frame.Coeffs = coeffs
-
-		frames[k] = frame
-		k++
+		frames[i] = Frame{Coeffs: coeffs}
 	}
 
-	return frames, indices, nil
+	results <- err
 }
 
 // Encoding Reed Solomon using FFT
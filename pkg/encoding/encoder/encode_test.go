@@ -76,5 +76,91 @@ func TestEncodeDecode_ErrorsWhenNotEnoughSampledFrames(t *testing.T) {
 	require.Nil(t, data)
 	require.NotNil(t, err)
 
-	assert.EqualError(t, err, "number of frame must be sufficient")
+	var insufficient *rs.ErrInsufficientChunks
+	require.ErrorAs(t, err, &insufficient)
+	assert.Equal(t, uint64(len(frames)-2), insufficient.Received)
+	assert.Equal(t, uint64(1), insufficient.Needed())
+}
+
+func TestEncodeDecode_FromAnySufficientSubset(t *testing.T) {
+	teardownSuite := setupSuite(t)
+	defer teardownSuite(t)
+
+	params := rs.GetEncodingParams(5, 3, uint64(len(GETTYSBURG_ADDRESS_BYTES)))
+	enc, _ := rs.NewEncoder(params, true)
+	require.NotNil(t, enc)
+
+	inputFr := rs.ToFrArray(GETTYSBURG_ADDRESS_BYTES)
+	_, frames, _, err := enc.Encode(inputFr)
+	require.Nil(t, err)
+	numFrames := len(frames)
+	required := int(rs.RoundUpDivision(uint64(len(inputFr)), enc.ChunkLen))
+	require.Less(t, required, numFrames)
+
+	// Decode from every subset of the frames, whose indices aren't contiguous in general
+	for subset := 0; subset < 1<<numFrames; subset++ {
+		samples := make([]rs.Frame, 0, numFrames)
+		indices := make([]uint64, 0, numFrames)
+		for i := numFrames - 1; i >= 0; i-- {
+			if subset&(1<<i) != 0 {
+				samples = append(samples, frames[i])
+				indices = append(indices, uint64(i))
+			}
+		}
+
+		data, err := enc.Decode(samples, indices, uint64(len(GETTYSBURG_ADDRESS_BYTES)))
+		if len(samples) >= required {
+			require.Nil(t, err, "subset %b", subset)
+			assert.Equal(t, GETTYSBURG_ADDRESS_BYTES, data, "subset %b", subset)
+			continue
+		}
+		assert.Nil(t, data)
+		var insufficient *rs.ErrInsufficientChunks
+		require.ErrorAs(t, err, &insufficient, "subset %b", subset)
+		assert.Equal(t, uint64(len(samples)), insufficient.Received)
+		assert.Equal(t, uint64(required-len(samples)), insufficient.Needed())
+	}
+}
+
+func TestEncodeDecode_DeduplicatesFrames(t *testing.T) {
+	teardownSuite := setupSuite(t)
+	defer teardownSuite(t)
+
+	params := rs.GetEncodingParams(5, 3, uint64(len(GETTYSBURG_ADDRESS_BYTES)))
+	enc, _ := rs.NewEncoder(params, true)
+	require.NotNil(t, enc)
+
+	inputFr := rs.ToFrArray(GETTYSBURG_ADDRESS_BYTES)
+	_, frames, _, err := enc.Encode(inputFr)
+	require.Nil(t, err)
+	required := int(rs.RoundUpDivision(uint64(len(inputFr)), enc.ChunkLen))
+
+	// The minimum number of distinct frames, each received twice
+	samples := make([]rs.Frame, 0, 2*required)
+	indices := make([]uint64, 0, 2*required)
+	for i := 0; i < required; i++ {
+		samples = append(samples, frames[2*i+1], frames[2*i+1])
+		indices = append(indices, uint64(2*i+1), uint64(2*i+1))
+	}
+	data, err := enc.Decode(samples, indices, uint64(len(GETTYSBURG_ADDRESS_BYTES)))
+	require.Nil(t, err)
+	assert.Equal(t, GETTYSBURG_ADDRESS_BYTES, data)
+
+	// The duplicates don't make up for a missing frame
+	data, err = enc.Decode(samples[2:], indices[2:], uint64(len(GETTYSBURG_ADDRESS_BYTES)))
+	assert.Nil(t, data)
+	var insufficient *rs.ErrInsufficientChunks
+	require.ErrorAs(t, err, &insufficient)
+	assert.Equal(t, uint64(required-1), insufficient.Received)
+	assert.Equal(t, uint64(1), insufficient.Needed())
+
+	// The data is trimmed to the max input size
+	data, err = enc.Decode(samples, indices, 100)
+	require.Nil(t, err)
+	assert.Equal(t, GETTYSBURG_ADDRESS_BYTES[:100], data)
+
+	_, err = enc.Decode(samples, indices[1:], uint64(len(GETTYSBURG_ADDRESS_BYTES)))
+	assert.ErrorContains(t, err, "does not match number of indices")
+	_, err = enc.Decode(frames[:1], []uint64{uint64(len(frames))}, uint64(len(GETTYSBURG_ADDRESS_BYTES)))
+	assert.ErrorContains(t, err, "invalid frame index")
 }
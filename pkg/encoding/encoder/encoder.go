@@ -2,6 +2,7 @@ package encoder
 
 import (
 	"math"
+	"runtime"
 
 	kzg "github.com/Layr-Labs/eigenda/pkg/kzg"
 )
@@ -12,6 +13,9 @@ type Encoder struct {
 	Fs *kzg.FFTSettings
 
 	verbose bool
+
+	// numWorker bounds the number of chunks interpolated concurrently when making frames
+	numWorker uint64
 }
 
 // The function creates a high level struct that determines the encoding the a data of a
@@ -23,12 +27,24 @@ type Encoder struct {
 // available, the receive can go through a Reed Solomon decoding to reconstruct the
 // original data.
 func NewEncoder(params EncodingParams, verbose bool) (*Encoder, error) {
+	return NewEncoderWithWorkers(params, verbose, uint64(runtime.GOMAXPROCS(0)))
+}
+
+// NewEncoderWithWorkers is identical to NewEncoder but bounds the intra-blob parallelism of
+// frame generation to numWorker goroutines. A value of 0 defaults to GOMAXPROCS. Every worker
+// holds the interpolation buffers of the chunk it is processing, so peak memory grows linearly
+// with the number of workers.
+func NewEncoderWithWorkers(params EncodingParams, verbose bool, numWorker uint64) (*Encoder, error) {
 
 	err := params.Validate()
 	if err != nil {
 		return nil, err
 	}
 
+	if numWorker == 0 {
+		numWorker = uint64(runtime.GOMAXPROCS(0))
+	}
+
 	n := uint8(math.Log2(float64(params.NumEvaluations())))
 	fs := kzg.NewFFTSettings(n)
 
@@ -36,6 +52,7 @@ func NewEncoder(params EncodingParams, verbose bool) (*Encoder, error) {
 		EncodingParams: params,
 		Fs:             fs,
 		verbose:        verbose,
+		numWorker:      numWorker,
 	}, nil
 
 }
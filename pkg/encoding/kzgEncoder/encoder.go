@@ -20,21 +20,36 @@ import (
 )
 
 type KzgConfig struct {
-	G1Path         string
-	G2Path         string
-	CacheDir       string
+	G1Path   string
+	G2Path   string
+	CacheDir string
+	// NumWorker bounds the intra-blob parallelism used for chunk interpolation and proof computation,
+	// defaulting to GOMAXPROCS when unset. Each worker holds its own intermediate buffers, so peak
+	// memory per blob grows roughly linearly with the number of workers; cap it lower when the
+	// encoder shares the host with other services.
 	NumWorker      uint64
 	SRSOrder       uint64 // Order is the total size of SRS
 	Verbose        bool
 	PreloadEncoder bool
+	// EncoderCacheSize bounds the memory, in bytes, held by the encoders cached for each set of encoding parameters.
+	// The least recently used encoders are evicted first. The cache is unbounded when unset.
+	EncoderCacheSize uint64
+	// LazyLoadSRS defers reading the SRS files to the first encoding or verification, so that the processes which
+	// seldom encode start without them. The SRS is read when the group is created if the encoders are preloaded.
+	LazyLoadSRS bool
+	// VerifySRS checks with pairings that the loaded SRS points are the successive powers of the same secret. The
+	// check runs a multi-exponentiation over the whole SRS, so it delays loading a large SRS.
+	VerifySRS bool
 }
 
 type KzgEncoderGroup struct {
 	*KzgConfig
 	Srs *kzg.SRS
 	mu  sync.Mutex
+	// srsMu guards the loading of the SRS, which happens while holding mu
+	srsMu sync.Mutex
 
-	Encoders  map[rs.EncodingParams]*KzgEncoder
+	encoders  *encoderCache
 	Verifiers map[rs.EncodingParams]*KzgVerifier
 }
 
@@ -52,33 +67,24 @@ type KzgEncoder struct {
 }
 
 func NewKzgEncoderGroup(config *KzgConfig) (*KzgEncoderGroup, error) {
-	// read the whole order, and treat it as entire SRS for low degree proof
-	s1, err := utils.ReadG1Points(config.G1Path, config.SRSOrder, config.NumWorker)
-	if err != nil {
-		log.Println("failed to read G1 points", err)
-		return nil, err
-	}
-	s2, err := utils.ReadG2Points(config.G2Path, config.SRSOrder, config.NumWorker)
-	if err != nil {
-		log.Println("failed to read G2 points", err)
-		return nil, err
-	}
-
-	srs, err := kzg.NewSrs(s1, s2)
-	if err != nil {
-		log.Println("Could not create srs", err)
-		return nil, err
+	if config.NumWorker == 0 {
+		config.NumWorker = uint64(runtime.GOMAXPROCS(0))
 	}
 
 	fmt.Println("numthread", runtime.GOMAXPROCS(0))
 
 	encoderGroup := &KzgEncoderGroup{
 		KzgConfig: config,
-		Srs:       srs,
-		Encoders:  make(map[rs.EncodingParams]*KzgEncoder),
+		encoders:  newEncoderCache(config.EncoderCacheSize),
 		Verifiers: make(map[rs.EncodingParams]*KzgVerifier),
 	}
 
+	if !config.LazyLoadSRS || config.PreloadEncoder {
+		if _, err := encoderGroup.loadSrs(); err != nil {
+			return nil, err
+		}
+	}
+
 	if config.PreloadEncoder {
 		// create table dir if not exist
 		err := os.MkdirAll(config.CacheDir, os.ModePerm)
@@ -97,6 +103,43 @@ func NewKzgEncoderGroup(config *KzgConfig) (*KzgEncoderGroup, error) {
 
 }
 
+// loadSrs returns the SRS, reading it from the SRS files on the first call. A failed read is retried on the next call.
+func (g *KzgEncoderGroup) loadSrs() (*kzg.SRS, error) {
+	g.srsMu.Lock()
+	defer g.srsMu.Unlock()
+	if g.Srs != nil {
+		return g.Srs, nil
+	}
+
+	// read the whole order, and treat it as entire SRS for low degree proof
+	s1, err := utils.ReadG1Points(g.G1Path, g.SRSOrder, g.NumWorker)
+	if err != nil {
+		log.Println("failed to read G1 points", err)
+		return nil, fmt.Errorf("failed to load the SRS: %w", err)
+	}
+	s2, err := utils.ReadG2Points(g.G2Path, g.SRSOrder, g.NumWorker)
+	if err != nil {
+		log.Println("failed to read G2 points", err)
+		return nil, fmt.Errorf("failed to load the SRS: %w", err)
+	}
+
+	srs, err := kzg.NewSrs(s1, s2)
+	if err != nil {
+		log.Println("Could not create srs", err)
+		return nil, fmt.Errorf("failed to load the SRS: %w", err)
+	}
+	if err := srs.Validate(g.SRSOrder); err != nil {
+		return nil, fmt.Errorf("failed to load the SRS from %s and %s: %w", g.G1Path, g.G2Path, err)
+	}
+	if g.VerifySRS {
+		if err := srs.VerifyPowers(); err != nil {
+			return nil, fmt.Errorf("failed to load the SRS from %s and %s: %w", g.G1Path, g.G2Path, err)
+		}
+	}
+	g.Srs = srs
+	return srs, nil
+}
+
 func (g *KzgEncoderGroup) PreloadAllEncoders() error {
 	paramsAll, err := GetAllPrecomputedSrsMap(g.CacheDir)
 	if err != nil {
@@ -112,12 +155,11 @@ func (g *KzgEncoderGroup) PreloadAllEncoders() error {
 	}
 
 	for _, params := range paramsAll {
-		// get those encoders and store them
-		enc, err := g.GetKzgEncoder(params)
+		// get those encoders, which caches them
+		_, err := g.GetKzgEncoder(params)
 		if err != nil {
 			return err
 		}
-		g.Encoders[params] = enc
 	}
 
 	return nil
@@ -126,19 +168,26 @@ func (g *KzgEncoderGroup) PreloadAllEncoders() error {
 func (g *KzgEncoderGroup) GetKzgEncoder(params rs.EncodingParams) (*KzgEncoder, error) {
 	g.mu.Lock()
 	defer g.mu.Unlock()
-	enc, ok := g.Encoders[params]
+	enc, ok := g.encoders.get(params)
 	if ok {
 		return enc, nil
 	}
 
 	enc, err := g.newKzgEncoder(params)
 	if err == nil {
-		g.Encoders[params] = enc
+		g.encoders.add(params, enc)
 	}
 
 	return enc, err
 }
 
+// NumCachedEncoders returns the number of encoders currently cached by the group
+func (g *KzgEncoderGroup) NumCachedEncoders() int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.encoders.len()
+}
+
 func (g *KzgEncoderGroup) NewKzgEncoder(params rs.EncodingParams) (*KzgEncoder, error) {
 	g.mu.Lock()
 	defer g.mu.Unlock()
@@ -153,13 +202,18 @@ func (g *KzgEncoderGroup) newKzgEncoder(params rs.EncodingParams) (*KzgEncoder,
 		return nil, fmt.Errorf("the supplied encoding parameters are not valid with respect to the SRS")
 	}
 
-	encoder, err := rs.NewEncoder(params, g.Verbose)
+	encoder, err := rs.NewEncoderWithWorkers(params, g.Verbose, g.NumWorker)
 	if err != nil {
 		log.Println("Could not create encoder: ", err)
 		return nil, err
 	}
 
-	subTable, err := NewSRSTable(g.CacheDir, g.Srs.G1, g.NumWorker)
+	srs, err := g.loadSrs()
+	if err != nil {
+		return nil, err
+	}
+
+	subTable, err := NewSRSTable(g.CacheDir, srs.G1, g.NumWorker)
 	if err != nil {
 		log.Println("Could not create srs table:", err)
 		return nil, err
@@ -184,7 +238,7 @@ func (g *KzgEncoderGroup) newKzgEncoder(params rs.EncodingParams) (*KzgEncoder,
 	}
 	fs := kzg.NewFFTSettings(n)
 
-	ks, err := kzg.NewKZGSettings(fs, g.Srs)
+	ks, err := kzg.NewKZGSettings(fs, srs)
 	if err != nil {
 		return nil, err
 	}
@@ -195,7 +249,7 @@ func (g *KzgEncoderGroup) newKzgEncoder(params rs.EncodingParams) (*KzgEncoder,
 	return &KzgEncoder{
 		Encoder:    encoder,
 		KzgConfig:  g.KzgConfig,
-		Srs:        g.Srs,
+		Srs:        srs,
 		Fs:         fs,
 		Ks:         ks,
 		SFs:        sfs,
@@ -0,0 +1,106 @@
+package kzgEncoder
+
+import (
+	"container/list"
+	"unsafe"
+
+	rs "github.com/Layr-Labs/eigenda/pkg/encoding/encoder"
+	kzg "github.com/Layr-Labs/eigenda/pkg/kzg"
+	bls "github.com/Layr-Labs/eigenda/pkg/kzg/bn254"
+)
+
+// encoderCache is an LRU cache of the encoders for each set of encoding parameters. Building an encoder computes the FFT
+// domains and loads the SRS sub tables for its parameters, which dominates the encoding time of small blobs, so blobs
+// of similar sizes should share an encoder. The cache is bounded by the estimated memory held by the cached encoders;
+// a capacity of 0 leaves it unbounded. It is not safe for concurrent use.
+type encoderCache struct {
+	capacity uint64
+	size     uint64
+
+	// order holds the entries from the most to the least recently used
+	order   *list.List
+	entries map[rs.EncodingParams]*list.Element
+}
+
+type encoderCacheEntry struct {
+	params  rs.EncodingParams
+	encoder *KzgEncoder
+	size    uint64
+}
+
+func newEncoderCache(capacity uint64) *encoderCache {
+	return &encoderCache{
+		capacity: capacity,
+		order:    list.New(),
+		entries:  make(map[rs.EncodingParams]*list.Element),
+	}
+}
+
+func (c *encoderCache) get(params rs.EncodingParams) (*KzgEncoder, bool) {
+	elem, ok := c.entries[params]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToFront(elem)
+	return elem.Value.(*encoderCacheEntry).encoder, true
+}
+
+// add caches the encoder, evicting the least recently used encoders until it fits. Encoders larger than the capacity
+// are not cached.
+func (c *encoderCache) add(params rs.EncodingParams, enc *KzgEncoder) {
+	if elem, ok := c.entries[params]; ok {
+		c.remove(elem)
+	}
+
+	size := enc.memorySize()
+	if c.capacity > 0 && size > c.capacity {
+		return
+	}
+	for c.capacity > 0 && c.size+size > c.capacity {
+		c.remove(c.order.Back())
+	}
+
+	c.entries[params] = c.order.PushFront(&encoderCacheEntry{
+		params:  params,
+		encoder: enc,
+		size:    size,
+	})
+	c.size += size
+}
+
+func (c *encoderCache) remove(elem *list.Element) {
+	entry := c.order.Remove(elem).(*encoderCacheEntry)
+	delete(c.entries, entry.params)
+	c.size -= entry.size
+}
+
+func (c *encoderCache) len() int {
+	return len(c.entries)
+}
+
+// memorySize estimates the memory held by the encoder's precomputed state: its FFT domains and SRS sub tables. The SRS
+// itself is shared by all the encoders of a group and isn't included.
+func (g *KzgEncoder) memorySize() uint64 {
+	g1Size := uint64(unsafe.Sizeof(bls.G1Point{}))
+
+	var size uint64
+	for _, points := range [][][]bls.G1Point{g.FFTPoints, g.FFTPointsT} {
+		for _, row := range points {
+			size += uint64(len(row)) * g1Size
+		}
+	}
+
+	// Ks shares its FFT settings with Fs
+	size += fftSettingsSize(g.Fs) + fftSettingsSize(g.SFs)
+	if g.Encoder != nil {
+		size += fftSettingsSize(g.Encoder.Fs)
+	}
+	return size
+}
+
+func fftSettingsSize(fs *kzg.FFTSettings) uint64 {
+	if fs == nil {
+		return 0
+	}
+	return uint64(len(fs.ExpandedRootsOfUnity)+len(fs.ReverseRootsOfUnity)) * uint64(unsafe.Sizeof(bls.Fr{}))
+}
@@ -0,0 +1,62 @@
+package kzgEncoder
+
+import (
+	"testing"
+	"unsafe"
+
+	rs "github.com/Layr-Labs/eigenda/pkg/encoding/encoder"
+	bls "github.com/Layr-Labs/eigenda/pkg/kzg/bn254"
+	"github.com/stretchr/testify/assert"
+)
+
+// makeEncoder returns an encoder whose precomputed state is numPoints G1 points
+func makeEncoder(numPoints int) *KzgEncoder {
+	return &KzgEncoder{
+		FFTPoints: [][]bls.G1Point{make([]bls.G1Point, numPoints)},
+	}
+}
+
+func TestEncoderCacheEviction(t *testing.T) {
+	g1Size := uint64(unsafe.Sizeof(bls.G1Point{}))
+	params := func(i uint64) rs.EncodingParams {
+		return rs.EncodingParams{NumChunks: i, ChunkLen: i}
+	}
+
+	cache := newEncoderCache(10 * g1Size)
+	cache.add(params(1), makeEncoder(4))
+	cache.add(params(2), makeEncoder(4))
+	assert.Equal(t, 2, cache.len())
+
+	// Using the first encoder makes the second one the least recently used
+	_, ok := cache.get(params(1))
+	assert.True(t, ok)
+
+	cache.add(params(3), makeEncoder(4))
+	assert.Equal(t, 2, cache.len())
+	assert.Equal(t, 8*g1Size, cache.size)
+	_, ok = cache.get(params(2))
+	assert.False(t, ok)
+	_, ok = cache.get(params(1))
+	assert.True(t, ok)
+	_, ok = cache.get(params(3))
+	assert.True(t, ok)
+
+	// Replacing an encoder doesn't count it twice
+	cache.add(params(3), makeEncoder(6))
+	assert.Equal(t, 2, cache.len())
+	assert.Equal(t, 10*g1Size, cache.size)
+
+	// Encoders larger than the cache are not cached and don't evict anything
+	cache.add(params(4), makeEncoder(11))
+	_, ok = cache.get(params(4))
+	assert.False(t, ok)
+	assert.Equal(t, 2, cache.len())
+}
+
+func TestEncoderCacheUnbounded(t *testing.T) {
+	cache := newEncoderCache(0)
+	for i := uint64(1); i <= 100; i++ {
+		cache.add(rs.EncodingParams{NumChunks: i, ChunkLen: i}, makeEncoder(1000))
+	}
+	assert.Equal(t, 100, cache.len())
+}
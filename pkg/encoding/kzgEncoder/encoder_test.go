@@ -0,0 +1,137 @@
+package kzgEncoder_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	rs "github.com/Layr-Labs/eigenda/pkg/encoding/encoder"
+	kzgRs "github.com/Layr-Labs/eigenda/pkg/encoding/kzgEncoder"
+	"github.com/Layr-Labs/eigenda/pkg/encoding/utils"
+	"github.com/Layr-Labs/eigenda/pkg/kzg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLazyLoadSRS(t *testing.T) {
+	teardownSuite := setupSuite(t)
+	defer teardownSuite(t)
+
+	params := rs.GetEncodingParams(numSys, numPar, uint64(len(GETTYSBURG_ADDRESS_BYTES)))
+
+	// The SRS files are only needed on the first encoding
+	config := *kzgConfig
+	config.G1Path = filepath.Join(t.TempDir(), "g1.point")
+	config.LazyLoadSRS = true
+	group, err := kzgRs.NewKzgEncoderGroup(&config)
+	require.Nil(t, err)
+	assert.Nil(t, group.Srs)
+
+	_, err = group.GetKzgEncoder(params)
+	assert.ErrorContains(t, err, "failed to open the G1 SRS file "+config.G1Path)
+	_, err = group.GetKzgVerifier(params)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+
+	// The SRS is read once it is mounted
+	g1, err := os.ReadFile(kzgConfig.G1Path)
+	require.Nil(t, err)
+	err = os.WriteFile(config.G1Path, g1, 0644)
+	require.Nil(t, err)
+	enc, err := group.GetKzgEncoder(params)
+	require.Nil(t, err)
+	commit, lowDegreeProof, _, _, err := enc.EncodeBytes(GETTYSBURG_ADDRESS_BYTES)
+	require.Nil(t, err)
+	assert.NotNil(t, group.Srs)
+	err = group.VerifyCommit(commit, lowDegreeProof, uint64(len(rs.ToFrArray(GETTYSBURG_ADDRESS_BYTES))-1))
+	assert.Nil(t, err)
+
+	// Without lazy loading, the group can't be created without the SRS files
+	config.G2Path = filepath.Join(t.TempDir(), "g2.point")
+	config.LazyLoadSRS = false
+	_, err = kzgRs.NewKzgEncoderGroup(&config)
+	assert.ErrorContains(t, err, "failed to open the G2 SRS file "+config.G2Path)
+}
+
+func TestTruncatedSRS(t *testing.T) {
+	teardownSuite := setupSuite(t)
+	defer teardownSuite(t)
+
+	g1, err := os.ReadFile(kzgConfig.G1Path)
+	require.Nil(t, err)
+	g2, err := os.ReadFile(kzgConfig.G2Path)
+	require.Nil(t, err)
+
+	config := *kzgConfig
+	config.G1Path = filepath.Join(t.TempDir(), "g1.point")
+	err = os.WriteFile(config.G1Path, g1[:64*100], 0644)
+	require.Nil(t, err)
+	_, err = kzgRs.NewKzgEncoderGroup(&config)
+	assert.ErrorContains(t, err, "the G1 SRS file "+config.G1Path+" is truncated: it contains 100 points but 3000 are required")
+
+	config = *kzgConfig
+	config.G2Path = filepath.Join(t.TempDir(), "g2.point")
+	err = os.WriteFile(config.G2Path, g2[:128*100], 0644)
+	require.Nil(t, err)
+	config.LazyLoadSRS = true
+	group, err := kzgRs.NewKzgEncoderGroup(&config)
+	require.Nil(t, err)
+	params := rs.GetEncodingParams(numSys, numPar, uint64(len(GETTYSBURG_ADDRESS_BYTES)))
+	_, err = group.GetKzgEncoder(params)
+	assert.ErrorContains(t, err, "the G2 SRS file "+config.G2Path+" is truncated: it contains 100 points but 3000 are required")
+}
+
+func TestInvalidSRS(t *testing.T) {
+	teardownSuite := setupSuite(t)
+	defer teardownSuite(t)
+
+	g1, err := os.ReadFile(kzgConfig.G1Path)
+	require.Nil(t, err)
+	g2, err := os.ReadFile(kzgConfig.G2Path)
+	require.Nil(t, err)
+
+	// The SRS of the tests is valid
+	config := *kzgConfig
+	config.VerifySRS = true
+	group, err := kzgRs.NewKzgEncoderGroup(&config)
+	require.Nil(t, err)
+	assert.Nil(t, group.Srs.VerifyPowers())
+
+	// A point which isn't on the curve
+	corrupted := bytes.Clone(g1)
+	copy(corrupted[64*10:64*11], bytes.Repeat([]byte("1"), 64))
+	config = *kzgConfig
+	config.G1Path = filepath.Join(t.TempDir(), "g1.point")
+	err = os.WriteFile(config.G1Path, corrupted, 0644)
+	require.Nil(t, err)
+	_, err = kzgRs.NewKzgEncoderGroup(&config)
+	var invalidPoint *utils.InvalidPointError
+	require.ErrorAs(t, err, &invalidPoint)
+	assert.Equal(t, uint64(10), invalidPoint.Index)
+	assert.ErrorContains(t, err, "the G1 SRS file "+config.G1Path+" is invalid: point 10 is invalid")
+
+	// Points which are valid, but not the powers of the secret
+	swapped := bytes.Clone(g2)
+	copy(swapped[128*20:128*21], g2[128*21:128*22])
+	copy(swapped[128*21:128*22], g2[128*20:128*21])
+	config = *kzgConfig
+	config.G2Path = filepath.Join(t.TempDir(), "g2.point")
+	err = os.WriteFile(config.G2Path, swapped, 0644)
+	require.Nil(t, err)
+	_, err = kzgRs.NewKzgEncoderGroup(&config)
+	require.Nil(t, err)
+	config.VerifySRS = true
+	_, err = kzgRs.NewKzgEncoderGroup(&config)
+	assert.ErrorIs(t, err, kzg.ErrInvalidSRS)
+	assert.ErrorContains(t, err, "the G2 points aren't the powers of the secret of the G1 points")
+
+	// An SRS which doesn't start with the generators
+	config = *kzgConfig
+	config.G1Path = filepath.Join(t.TempDir(), "g1.point")
+	err = os.WriteFile(config.G1Path, g1[64:], 0644)
+	require.Nil(t, err)
+	config.SRSOrder = 2000
+	_, err = kzgRs.NewKzgEncoderGroup(&config)
+	assert.ErrorIs(t, err, kzg.ErrInvalidSRS)
+	assert.ErrorContains(t, err, "the first G1 point isn't the generator")
+}
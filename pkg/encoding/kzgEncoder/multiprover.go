@@ -57,14 +57,22 @@ func (p *KzgEncoder) ProveAllCosetThreads(polyFr []bls.Fr, numChunks, chunkLen,
 	t0 := time.Now()
 
 	// compute proof by multi scaler mulplication
+	msmChan := make(chan uint64, numWorker)
 	var wg sync.WaitGroup
-	for i := uint64(0); i < dimE*2; i++ {
+	for w := uint64(0); w < numWorker; w++ {
 		wg.Add(1)
-		go func(k uint64) {
+		go func() {
 			defer wg.Done()
-			sumVec[k] = *bls.LinCombG1(p.FFTPointsT[k], coeffStore[k])
-		}(i)
+			for k := range msmChan {
+				sumVec[k] = *bls.LinCombG1(p.FFTPointsT[k], coeffStore[k])
+			}
+		}()
+	}
+
+	for i := uint64(0); i < dimE*2; i++ {
+		msmChan <- i
 	}
+	close(msmChan)
 
 	wg.Wait()
 
@@ -2,6 +2,7 @@ package kzgEncoder_test
 
 import (
 	"fmt"
+	"runtime"
 	"testing"
 
 	rs "github.com/Layr-Labs/eigenda/pkg/encoding/encoder"
@@ -40,3 +41,37 @@ func TestProveAllCosetThreads(t *testing.T) {
 		assert.True(t, f.Verify(enc.Ks, commit, &lc), "Proof %v failed\n", i)
 	}
 }
+
+func TestEncodeIsIndependentOfNumWorker(t *testing.T) {
+	teardownSuite := setupSuite(t)
+	defer teardownSuite(t)
+
+	params := rs.GetEncodingParams(numSys, numPar, uint64(len(GETTYSBURG_ADDRESS_BYTES)))
+	inputFr := rs.ToFrArray(GETTYSBURG_ADDRESS_BYTES)
+
+	encode := func(numWorker uint64) (*kzgRs.KzgEncoder, []kzgRs.Frame, []uint32) {
+		config := *kzgConfig
+		config.NumWorker = numWorker
+		group, err := kzgRs.NewKzgEncoderGroup(&config)
+		require.Nil(t, err)
+
+		enc, err := group.NewKzgEncoder(params)
+		require.Nil(t, err)
+
+		_, _, frames, fIndices, err := enc.Encode(inputFr)
+		require.Nil(t, err)
+		return enc, frames, fIndices
+	}
+
+	_, sequentialFrames, sequentialIndices := encode(1)
+	enc, parallelFrames, parallelIndices := encode(8)
+	assert.Equal(t, uint64(8), enc.NumWorker)
+
+	assert.Equal(t, sequentialIndices, parallelIndices)
+	assert.Equal(t, sequentialFrames, parallelFrames)
+
+	// Unset worker count defaults to GOMAXPROCS
+	enc, defaultFrames, _ := encode(0)
+	assert.Equal(t, uint64(runtime.GOMAXPROCS(0)), enc.NumWorker)
+	assert.Equal(t, sequentialFrames, defaultFrames)
+}
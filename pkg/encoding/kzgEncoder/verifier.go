@@ -52,9 +52,14 @@ func (g *KzgEncoderGroup) newKzgVerifier(params rs.EncodingParams) (*KzgVerifier
 		return nil, err
 	}
 
+	srs, err := g.loadSrs()
+	if err != nil {
+		return nil, err
+	}
+
 	n := uint8(math.Log2(float64(params.NumEvaluations())))
 	fs := kzg.NewFFTSettings(n)
-	ks, err := kzg.NewKZGSettings(fs, g.Srs)
+	ks, err := kzg.NewKZGSettings(fs, srs)
 
 	if err != nil {
 		return nil, err
@@ -62,7 +67,7 @@ func (g *KzgEncoderGroup) newKzgVerifier(params rs.EncodingParams) (*KzgVerifier
 
 	return &KzgVerifier{
 		KzgConfig:      g.KzgConfig,
-		Srs:            g.Srs,
+		Srs:            srs,
 		EncodingParams: params,
 		Fs:             fs,
 		Ks:             ks,
@@ -72,8 +77,12 @@ func (g *KzgEncoderGroup) newKzgVerifier(params rs.EncodingParams) (*KzgVerifier
 // VerifyCommit verifies the low degree proof; since it doesn't depend on the encoding parameters
 // we leave it as a method of the KzgEncoderGroup
 func (v *KzgEncoderGroup) VerifyCommit(commit, lowDegreeProof *wbls.G1Point, degree uint64) error {
+	srs, err := v.loadSrs()
+	if err != nil {
+		return err
+	}
 
-	if !VerifyLowDegreeProof(commit, lowDegreeProof, degree, v.SRSOrder, v.Srs.G2) {
+	if !VerifyLowDegreeProof(commit, lowDegreeProof, degree, v.SRSOrder, srs.G2) {
 		return errors.New("low degree proof fails")
 	}
 	return nil
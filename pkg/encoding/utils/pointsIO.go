@@ -2,6 +2,7 @@ package utils
 
 import (
 	"bufio"
+	"fmt"
 	"log"
 	"os"
 	"sync"
@@ -10,6 +11,20 @@ import (
 	bls "github.com/Layr-Labs/eigenda/pkg/kzg/bn254"
 )
 
+// InvalidPointError reports a point of an SRS file which can't be decoded, or isn't on the curve or in the subgroup
+type InvalidPointError struct {
+	Index uint64
+	Err   error
+}
+
+func (e *InvalidPointError) Error() string {
+	return fmt.Sprintf("point %d is invalid: %v", e.Index, e.Err)
+}
+
+func (e *InvalidPointError) Unwrap() error {
+	return e.Err
+}
+
 type EncodeParams struct {
 	NumNodeE  uint64
 	ChunkLenE uint64
@@ -19,7 +34,7 @@ func ReadG1Points(filepath string, n uint64, numWorker uint64) ([]bls.G1Point, e
 	g1f, err := os.Open(filepath)
 	if err != nil {
 		log.Println("Cannot ReadG1Points", filepath, err)
-		return nil, err
+		return nil, fmt.Errorf("failed to open the G1 SRS file %s: %w", filepath, err)
 	}
 
 	//todo: resolve panic
@@ -38,14 +53,12 @@ func ReadG1Points(filepath string, n uint64, numWorker uint64) ([]bls.G1Point, e
 
 	buf, _, err := g1r.ReadLine()
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to read the G1 SRS file %s: %w", filepath, err)
 	}
 
 	if uint64(len(buf)) < 64*n {
 		log.Printf("Error. Insufficient G1 points. Only contains %v. Requesting %v\n", len(buf)/64, n)
-		log.Println()
-		log.Println("ReadG1Points.ERR.1", err)
-		return nil, err
+		return nil, fmt.Errorf("the G1 SRS file %s is truncated: it contains %d points but %d are required", filepath, len(buf)/64, n)
 	}
 
 	// measure reading time
@@ -58,6 +71,7 @@ func ReadG1Points(filepath string, n uint64, numWorker uint64) ([]bls.G1Point, e
 
 	var wg sync.WaitGroup
 	wg.Add(int(numWorker))
+	errs := make(chan error, numWorker)
 
 	start := uint64(0)
 	end := uint64(0)
@@ -72,10 +86,13 @@ func ReadG1Points(filepath string, n uint64, numWorker uint64) ([]bls.G1Point, e
 			end = (i + 1) * size
 		}
 		//fmt.Printf("worker %v start %v end %v. size %v\n", i, start, end, end - start)
-		//todo: handle error?
-		go readG1Worker(buf, s1Outs, start, end, 64, &wg)
+		go readG1Worker(buf, s1Outs, start, end, 64, errs, &wg)
 	}
 	wg.Wait()
+	close(errs)
+	if err := <-errs; err != nil {
+		return nil, fmt.Errorf("the G1 SRS file %s is invalid: %w", filepath, err)
+	}
 
 	// measure parsing time
 	t = time.Now()
@@ -88,7 +105,7 @@ func ReadG1PointSection(filepath string, from, to uint64, numWorker uint64) ([]b
 	g1f, err := os.Open(filepath)
 	if err != nil {
 		log.Println("ReadG1PointSection.ERR.0", err)
-		return nil, err
+		return nil, fmt.Errorf("failed to open the G1 SRS file %s: %w", filepath, err)
 	}
 
 	//todo: how to handle?
@@ -114,14 +131,12 @@ func ReadG1PointSection(filepath string, from, to uint64, numWorker uint64) ([]b
 
 	buf, _, err := g1r.ReadLine()
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to read the G1 SRS file %s: %w", filepath, err)
 	}
 
 	if uint64(len(buf)) < 64*n {
 		log.Printf("Error. Insufficient G1 points. Only contains %v. Requesting %v\n", len(buf)/64, n)
-		log.Println()
-		log.Println("ReadG1PointSection.ERR.1", err)
-		return nil, err
+		return nil, fmt.Errorf("the G1 SRS file %s is truncated: it contains %d points from point %d but %d are required", filepath, len(buf)/64, from, n)
 	}
 
 	// measure reading time
@@ -134,6 +149,7 @@ func ReadG1PointSection(filepath string, from, to uint64, numWorker uint64) ([]b
 
 	var wg sync.WaitGroup
 	wg.Add(int(numWorker))
+	errs := make(chan error, numWorker)
 
 	start := uint64(0)
 	end := uint64(0)
@@ -147,10 +163,13 @@ func ReadG1PointSection(filepath string, from, to uint64, numWorker uint64) ([]b
 		} else {
 			end = (i + 1) * size
 		}
-		//todo: handle error?
-		go readG1Worker(buf, s1Outs, start, end, 64, &wg)
+		go readG1Worker(buf, s1Outs, start, end, 64, errs, &wg)
 	}
 	wg.Wait()
+	close(errs)
+	if err := <-errs; err != nil {
+		return nil, fmt.Errorf("the G1 SRS file %s is invalid from point %d: %w", filepath, from, err)
+	}
 
 	// measure parsing time
 	t = time.Now()
@@ -159,40 +178,47 @@ func ReadG1PointSection(filepath string, from, to uint64, numWorker uint64) ([]b
 	return s1Outs, nil
 }
 
+// readG1Worker decodes the G1 points in [start, end). The decoding checks that each point is on the curve and in the
+// subgroup, and the first point which fails is reported as invalid.
 func readG1Worker(
 	buf []byte,
 	outs []bls.G1Point,
 	start uint64, // in element, not in byte
 	end uint64,
 	step uint64,
+	errs chan<- error,
 	wg *sync.WaitGroup,
 ) {
+	defer wg.Done()
 	for i := start; i < end; i++ {
 		g1 := buf[i*step : (i+1)*step]
 		err := outs[i].UnmarshalText(g1[:])
 		if err != nil {
-			panic(err)
+			errs <- &InvalidPointError{Index: i, Err: err}
+			return
 		}
 	}
-	wg.Done()
 }
 
+// readG2Worker decodes the G2 points in [start, end), like readG1Worker
 func readG2Worker(
 	buf []byte,
 	outs []bls.G2Point,
 	start uint64, // in element, not in byte
 	end uint64,
 	step uint64,
+	errs chan<- error,
 	wg *sync.WaitGroup,
 ) {
+	defer wg.Done()
 	for i := start; i < end; i++ {
-		g1 := buf[i*step : (i+1)*step]
-		err := outs[i].UnmarshalText(g1[:])
+		g2 := buf[i*step : (i+1)*step]
+		err := outs[i].UnmarshalText(g2[:])
 		if err != nil {
-			log.Println("Unmarshalling error:", err)
+			errs <- &InvalidPointError{Index: i, Err: err}
+			return
 		}
 	}
-	wg.Done()
 }
 
 func ReadG2Points(filepath string, n uint64, numWorker uint64) ([]bls.G2Point, error) {
@@ -200,7 +226,7 @@ func ReadG2Points(filepath string, n uint64, numWorker uint64) ([]bls.G2Point, e
 	if err != nil {
 		log.Println("Cannot ReadG2Points", filepath)
 		log.Println("ReadG2Points.ERR.0", err)
-		return nil, err
+		return nil, fmt.Errorf("failed to open the G2 SRS file %s: %w", filepath, err)
 	}
 	//todo: resolve panic
 	defer func() {
@@ -218,14 +244,12 @@ func ReadG2Points(filepath string, n uint64, numWorker uint64) ([]bls.G2Point, e
 
 	buf, _, err := g1r.ReadLine()
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to read the G2 SRS file %s: %w", filepath, err)
 	}
 
 	if uint64(len(buf)) < 128*n {
-		log.Printf("Error. Insufficient G1 points. Only contains %v. Requesting %v\n", len(buf)/128, n)
-		log.Println()
-		log.Println("ReadG2Points.ERR.1", err)
-		return nil, err
+		log.Printf("Error. Insufficient G2 points. Only contains %v. Requesting %v\n", len(buf)/128, n)
+		return nil, fmt.Errorf("the G2 SRS file %s is truncated: it contains %d points but %d are required", filepath, len(buf)/128, n)
 	}
 
 	// measure reading time
@@ -239,6 +263,7 @@ func ReadG2Points(filepath string, n uint64, numWorker uint64) ([]bls.G2Point, e
 
 	var wg sync.WaitGroup
 	wg.Add(int(numWorker))
+	errs := make(chan error, numWorker)
 
 	start := uint64(0)
 	end := uint64(0)
@@ -251,10 +276,13 @@ func ReadG2Points(filepath string, n uint64, numWorker uint64) ([]bls.G2Point, e
 		} else {
 			end = (i + 1) * size
 		}
-		//todo: handle error?
-		go readG2Worker(buf, s2Outs, start, end, 128, &wg)
+		go readG2Worker(buf, s2Outs, start, end, 128, errs, &wg)
 	}
 	wg.Wait()
+	close(errs)
+	if err := <-errs; err != nil {
+		return nil, fmt.Errorf("the G2 SRS file %s is invalid: %w", filepath, err)
+	}
 
 	// measure parsing time
 	t = time.Now()
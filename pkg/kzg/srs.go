@@ -1,11 +1,15 @@
 package kzg
 
 import (
+	"crypto/rand"
 	"errors"
+	"fmt"
 
 	bls "github.com/Layr-Labs/eigenda/pkg/kzg/bn254"
 )
 
+var ErrInvalidSRS = errors.New("invalid SRS")
+
 type SRS struct {
 
 	// [b.multiply(b.G1, pow(s, i, MODULUS)) for i in range(WIDTH+1)],
@@ -24,3 +28,63 @@ func NewSrs(G1 []bls.G1Point, G2 []bls.G2Point) (*SRS, error) {
 		G2: G2,
 	}, nil
 }
+
+// Validate checks that the SRS has the order, and that its points are powers of the generators. The points are
+// expected to be on the curve and in the subgroup, which is checked when they are decoded.
+func (s *SRS) Validate(order uint64) error {
+	if uint64(len(s.G1)) != order || uint64(len(s.G2)) != order {
+		return fmt.Errorf("%w: it contains %d G1 points and %d G2 points but its order is %d", ErrInvalidSRS, len(s.G1), len(s.G2), order)
+	}
+	if order == 0 {
+		return nil
+	}
+	if !bls.EqualG1(&s.G1[0], &bls.GenG1) {
+		return fmt.Errorf("%w: the first G1 point isn't the generator", ErrInvalidSRS)
+	}
+	if !bls.EqualG2(&s.G2[0], &bls.GenG2) {
+		return fmt.Errorf("%w: the first G2 point isn't the generator", ErrInvalidSRS)
+	}
+	for i := range s.G1 {
+		if bls.EqualG1(&s.G1[i], &bls.ZeroG1) {
+			return fmt.Errorf("%w: G1 point %d is the point at infinity", ErrInvalidSRS, i)
+		}
+		if bls.EqualG2(&s.G2[i], &bls.ZeroG2) {
+			return fmt.Errorf("%w: G2 point %d is the point at infinity", ErrInvalidSRS, i)
+		}
+	}
+	return nil
+}
+
+// VerifyPowers checks with pairings that the G1 and G2 points are the successive powers of the same secret, i.e. that
+// e(G1[i+1], G2[0]) = e(G1[i], G2[1]) and e(G1[0], G2[i+1]) = e(G1[1], G2[i]) for every i. The relations are checked
+// at once on random linear combinations of the points, which costs a multi-exponentiation over each list.
+func (s *SRS) VerifyPowers() error {
+	n := len(s.G1)
+	if n < 2 || len(s.G2) != n {
+		return nil
+	}
+
+	factors := make([]bls.Fr, n-1)
+	for i := range factors {
+		var b [32]byte
+		if _, err := rand.Read(b[:]); err != nil {
+			return fmt.Errorf("failed to sample the random factors: %w", err)
+		}
+		bls.FrSetBytes(&factors[i], b[:])
+	}
+
+	// sum_i r_i * G1[i+1] = tau * sum_i r_i * G1[i]
+	low1 := bls.LinCombG1(s.G1[:n-1], factors)
+	high1 := bls.LinCombG1(s.G1[1:], factors)
+	if !bls.PairingsVerify(high1, &s.G2[0], low1, &s.G2[1]) {
+		return fmt.Errorf("%w: the G1 points aren't the powers of the secret of the G2 points", ErrInvalidSRS)
+	}
+
+	// sum_i r_i * G2[i+1] = tau * sum_i r_i * G2[i]
+	low2 := bls.LinCombG2(s.G2[:n-1], factors)
+	high2 := bls.LinCombG2(s.G2[1:], factors)
+	if !bls.PairingsVerify(&s.G1[0], high2, &s.G1[1], low2) {
+		return fmt.Errorf("%w: the G2 points aren't the powers of the secret of the G1 points", ErrInvalidSRS)
+	}
+	return nil
+}
@@ -9,7 +9,9 @@ import (
 
 	pb "github.com/Layr-Labs/eigenda/api/grpc/retriever"
 	"github.com/Layr-Labs/eigenda/clients"
+	dacommon "github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/common/geth"
+	commongrpc "github.com/Layr-Labs/eigenda/common/grpc"
 	"github.com/Layr-Labs/eigenda/common/healthcheck"
 	"github.com/Layr-Labs/eigenda/common/logging"
 	"github.com/Layr-Labs/eigenda/core"
@@ -44,12 +46,15 @@ func main() {
 	if err := app.Run(os.Args); err != nil {
 		log.Fatalf("application failed: %v", err)
 	}
-
-	select {}
 }
 
 func RetrieverMain(ctx *cli.Context) error {
 	log.Println("Initializing Retriever")
+	config := retriever.NewConfig(ctx)
+	if err := config.Validate(); err != nil {
+		return err
+	}
+
 	hostname := ctx.String(flags.HostnameFlag.Name)
 	port := ctx.String(flags.GrpcPortFlag.Name)
 	addr := fmt.Sprintf("%s:%s", hostname, port)
@@ -58,23 +63,31 @@ func RetrieverMain(ctx *cli.Context) error {
 		log.Fatalln("could not start tcp listener", err)
 	}
 
-	opt := grpc.MaxRecvMsgSize(1024 * 1024 * 300)
-	gs := grpc.NewServer(
-		opt,
-		grpc.ChainUnaryInterceptor(
-		// TODO(ian-shim): Add interceptors
-		// correlation.UnaryServerInterceptor(),
-		// logger.UnaryServerInterceptor(*s.logger.Logger),
-		),
-	)
-
-	config := retriever.NewConfig(ctx)
 	logger, err := logging.GetLogger(config.LoggerConfig)
 	if err != nil {
 		return err
 	}
 
-	nodeClient := clients.NewNodeClient(config.Timeout)
+	tlsOpts, err := commongrpc.ServerOptions(context.Background(), config.TLSConfig, logger)
+	if err != nil {
+		log.Fatalln("could not configure TLS", err)
+	}
+
+	gs := grpc.NewServer(
+		append(append(tlsOpts, commongrpc.MaxMessageSizeServerOptions(config.MaxGRPCMessageSize)...),
+			grpc.ChainUnaryInterceptor(
+			// TODO(ian-shim): Add interceptors
+			// correlation.UnaryServerInterceptor(),
+			// logger.UnaryServerInterceptor(*s.logger.Logger),
+			),
+		)...,
+	)
+
+	nodeCreds, err := config.NodeTLSConfig.Credentials()
+	if err != nil {
+		log.Fatalln("could not create the node credentials", err)
+	}
+	nodeClient := clients.NewNodeClient(config.Timeout, config.MaxGRPCMessageSize, nodeCreds)
 	encoder, err := encoding.NewEncoder(config.EncoderConfig)
 	if err != nil {
 		log.Fatalln("could not start tcp listener", err)
@@ -107,12 +120,15 @@ func RetrieverMain(ctx *cli.Context) error {
 
 	agn := &core.StdAssignmentCoordinator{}
 	retrievalClient := clients.NewRetrievalClient(logger, indexedState, agn, nodeClient, encoder, config.NumConnections)
+	socketsFilterer, err := indexer.NewOperatorSocketsFilterer(common.HexToAddress(config.EigenDAServiceManagerAddr), gethClient)
+	if err != nil {
+		log.Fatalln("could not create the operator sockets filterer", err)
+	}
+	socketIndexer := core.NewOperatorSocketIndexer(indexer.NewOperatorSocketSource(socketsFilterer, tx, indexedState), config.OperatorSocketRefreshInterval, logger)
+	retrievalClient.SetOperatorSocketIndexer(socketIndexer)
 
 	chainClient := retrivereth.NewChainClient(gethClient, logger)
 	retrieverServiceServer := retriever.NewServer(config, logger, retrievalClient, encoder, indexedState, chainClient)
-	if err = retrieverServiceServer.Start(context.Background()); err != nil {
-		log.Fatalln("failed to start retriever service server", err)
-	}
 
 	// Register reflection service on gRPC server
 	// This makes "grpcurl -plaintext localhost:9000 list" command work
@@ -124,5 +140,16 @@ func RetrieverMain(ctx *cli.Context) error {
 	healthcheck.RegisterHealthServer(gs)
 
 	log.Printf("server listening at %s", addr)
-	return gs.Serve(listener)
+	return dacommon.Run(context.Background(), logger, 0,
+		dacommon.NewBackgroundComponent("retriever service", retrieverServiceServer.Start),
+		dacommon.NewBackgroundComponent("operator socket indexer", func(ctx context.Context) error {
+			socketIndexer.Start(ctx)
+			return nil
+		}),
+		dacommon.NewServerComponent("grpc server", func() error {
+			return gs.Serve(listener)
+		}, func(ctx context.Context) error {
+			return commongrpc.GracefulStop(ctx, gs)
+		}),
+	)
 }
@@ -1,13 +1,17 @@
 package retriever
 
 import (
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/Layr-Labs/eigenda/common/geth"
+	commongrpc "github.com/Layr-Labs/eigenda/common/grpc"
 	"github.com/Layr-Labs/eigenda/common/logging"
 	"github.com/Layr-Labs/eigenda/core/encoding"
 	"github.com/Layr-Labs/eigenda/indexer"
 	"github.com/Layr-Labs/eigenda/retriever/flags"
+	gethcommon "github.com/ethereum/go-ethereum/common"
 	"github.com/urfave/cli"
 )
 
@@ -17,10 +21,17 @@ type Config struct {
 	LoggerConfig    logging.Config
 	IndexerConfig   indexer.Config
 	MetricsConfig   MetricsConfig
+	TLSConfig       commongrpc.TLSConfig
+	// NodeTLSConfig configures the transport security of the connections to the retrieval servers of the nodes
+	NodeTLSConfig commongrpc.ClientTLSConfig
+	// MaxGRPCMessageSize is the max size in bytes of the messages of the retrieval server, and of the replies of the
+	// nodes
+	MaxGRPCMessageSize int
 
 	IndexerDataDir                string
 	Timeout                       time.Duration
 	NumConnections                int
+	OperatorSocketRefreshInterval time.Duration
 	BLSOperatorStateRetrieverAddr string
 	EigenDAServiceManagerAddr     string
 }
@@ -34,10 +45,44 @@ func NewConfig(ctx *cli.Context) *Config {
 		MetricsConfig: MetricsConfig{
 			HTTPPort: ctx.GlobalString(flags.MetricsHTTPPortFlag.Name),
 		},
+		TLSConfig:                     commongrpc.ReadTLSCLIConfig(ctx, flags.FlagPrefix),
+		NodeTLSConfig:                 commongrpc.ReadClientTLSCLIConfig(ctx, flags.FlagPrefix),
+		MaxGRPCMessageSize:            commongrpc.ReadMaxMessageSize(ctx, flags.FlagPrefix),
 		IndexerDataDir:                ctx.GlobalString(flags.IndexerDataDirFlag.Name),
 		Timeout:                       ctx.Duration(flags.TimeoutFlag.Name),
 		NumConnections:                ctx.Int(flags.NumConnectionsFlag.Name),
+		OperatorSocketRefreshInterval: ctx.GlobalDuration(flags.OperatorSocketRefreshIntervalFlag.Name),
 		BLSOperatorStateRetrieverAddr: ctx.GlobalString(flags.BlsOperatorStateRetrieverFlag.Name),
 		EigenDAServiceManagerAddr:     ctx.GlobalString(flags.EigenDAServiceManagerFlag.Name),
 	}
 }
+
+// Validate checks the config before any component of the retriever starts, and returns all the violations at once
+func (c *Config) Validate() error {
+	var errs []error
+	if c.Timeout <= 0 {
+		errs = append(errs, fmt.Errorf("the timeout must be positive, but found %s", c.Timeout))
+	}
+	if c.NumConnections <= 0 {
+		errs = append(errs, fmt.Errorf("the number of connections must be positive, but found %d", c.NumConnections))
+	}
+	if c.OperatorSocketRefreshInterval < 0 {
+		errs = append(errs, fmt.Errorf("the operator socket refresh interval must not be negative, but found %s", c.OperatorSocketRefreshInterval))
+	}
+	if err := c.TLSConfig.Validate(); err != nil {
+		errs = append(errs, fmt.Errorf("invalid TLS config: %w", err))
+	}
+	if err := c.NodeTLSConfig.Validate(); err != nil {
+		errs = append(errs, fmt.Errorf("invalid node TLS config: %w", err))
+	}
+	if c.MaxGRPCMessageSize < 0 {
+		errs = append(errs, fmt.Errorf("the max grpc message size must not be negative (0 for the gRPC defaults), but found %d", c.MaxGRPCMessageSize))
+	}
+	if !gethcommon.IsHexAddress(c.BLSOperatorStateRetrieverAddr) {
+		errs = append(errs, fmt.Errorf("the BLS operator state retriever address must be an address, but found %q", c.BLSOperatorStateRetrieverAddr))
+	}
+	if !gethcommon.IsHexAddress(c.EigenDAServiceManagerAddr) {
+		errs = append(errs, fmt.Errorf("the EigenDA service manager address must be an address, but found %q", c.EigenDAServiceManagerAddr))
+	}
+	return errors.Join(errs...)
+}
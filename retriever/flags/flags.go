@@ -1,8 +1,11 @@
 package flags
 
 import (
+	"time"
+
 	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/common/geth"
+	commongrpc "github.com/Layr-Labs/eigenda/common/grpc"
 	"github.com/Layr-Labs/eigenda/common/logging"
 	"github.com/Layr-Labs/eigenda/core/encoding"
 	"github.com/Layr-Labs/eigenda/indexer"
@@ -68,6 +71,13 @@ var (
 		Value:    "9100",
 		EnvVar:   common.PrefixEnvVar(envPrefix, "METRICS_HTTP_PORT"),
 	}
+	OperatorSocketRefreshIntervalFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "operator-socket-refresh-interval"),
+		Usage:    "interval at which the operator sockets are fully refreshed from the chain, in case socket update events are missed",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "OPERATOR_SOCKET_REFRESH_INTERVAL"),
+		Value:    5 * time.Minute,
+	}
 )
 
 var requiredFlags = []cli.Flag{
@@ -82,6 +92,7 @@ var optionalFlags = []cli.Flag{
 	NumConnectionsFlag,
 	IndexerDataDirFlag,
 	MetricsHTTPPortFlag,
+	OperatorSocketRefreshIntervalFlag,
 }
 
 // Flags contains the list of configuration options available to the binary.
@@ -92,5 +103,8 @@ func init() {
 	Flags = append(Flags, encoding.CLIFlags(envPrefix)...)
 	Flags = append(Flags, geth.EthClientFlags(envPrefix)...)
 	Flags = append(Flags, logging.CLIFlags(envPrefix, FlagPrefix)...)
+	Flags = append(Flags, commongrpc.TLSCLIFlags(envPrefix, FlagPrefix)...)
+	Flags = append(Flags, commongrpc.ClientTLSCLIFlags(envPrefix, FlagPrefix)...)
+	Flags = append(Flags, commongrpc.MaxMessageSizeCLIFlag(envPrefix, FlagPrefix, 1024*1024*300)) // 300 MiB
 	Flags = append(Flags, indexer.CLIFlags(envPrefix)...)
 }
@@ -45,7 +45,7 @@ func makeTestEncoder() (core.Encoder, error) {
 	}
 
 	return &encoding.Encoder{
-		EncoderGroup: kzgEncoderGroup,
+		Backend: &encoding.Bn254Backend{EncoderGroup: kzgEncoderGroup},
 	}, nil
 }
 func newTestServer(t *testing.T) *retriever.Server {
@@ -3,6 +3,7 @@ package integration_test
 import (
 	"bytes"
 	"context"
+	"crypto/ecdsa"
 	"encoding/hex"
 	"fmt"
 	"log"
@@ -49,6 +50,7 @@ import (
 	gethcommon "github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -57,6 +59,8 @@ import (
 var (
 	enc core.Encoder
 	asn core.AssignmentCoordinator
+	// disperserKey is the key with which the disperser signs the StoreChunks requests to the operators
+	disperserKey *ecdsa.PrivateKey
 
 	gettysburgAddressBytes = []byte("Fourscore and seven years ago our fathers brought forth, on this continent, a new nation, conceived in liberty, and dedicated to the proposition that all men are created equal. Now we are engaged in a great civil war, testing whether that nation, or any nation so conceived, and so dedicated, can long endure. We are met on a great battle-field of that war. We have come to dedicate a portion of that field, as a final resting-place for those who here gave their lives, that that nation might live. It is altogether fitting and proper that we should do this. But, in a larger sense, we cannot dedicate, we cannot consecrate—we cannot hallow—this ground. The brave men, living and dead, who struggled here, have consecrated it far above our poor power to add or detract. The world will little note, nor long remember what we say here, but it can never forget what they did here. It is for us the living, rather, to be dedicated here to the unfinished work which they who fought here have thus far so nobly advanced. It is rather for us to be here dedicated to the great task remaining before us—that from these honored dead we take increased devotion to that cause for which they here gave the last full measure of devotion—that we here highly resolve that these dead shall not have died in vain—that this nation, under God, shall have a new birth of freedom, and that government of the people, by the people, for the people, shall not perish from the earth.")
 	serviceManagerAddress  = gethcommon.HexToAddress("0x0000000000000000000000000000000000000000")
@@ -73,6 +77,12 @@ const (
 func init() {
 	enc = mustMakeTestEncoder()
 	asn = &core.StdAssignmentCoordinator{}
+
+	var err error
+	disperserKey, err = crypto.GenerateKey()
+	if err != nil {
+		log.Fatalln("failed to generate the disperser key")
+	}
 }
 
 // makeTestEncoder makes an encoder currently using the only supported backend.
@@ -119,7 +129,8 @@ type TestDisperser struct {
 
 func mustMakeDisperser(t *testing.T, cst core.IndexedChainState, store disperser.BlobStore, logger common.Logger) TestDisperser {
 	dispatcherConfig := &dispatcher.Config{
-		Timeout: time.Second,
+		Timeout:    time.Second,
+		SigningKey: disperserKey,
 	}
 	dispatcher := dispatcher.NewDispatcher(dispatcherConfig, logger)
 
@@ -141,12 +152,12 @@ func mustMakeDisperser(t *testing.T, cst core.IndexedChainState, store disperser
 		},
 		BlockNumber: big.NewInt(123),
 	}
-	confirmer.On("ConfirmBatch", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(receipt, nil)
+	confirmer.On("ConfirmBatch", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(receipt, nil, nil)
 
 	batcherConfig := batcher.Config{
 		PullInterval:             5 * time.Second,
 		NumConnections:           1,
-		EncoderSocket:            fmt.Sprintf("localhost:%s", encoderPort),
+		EncoderSockets:           []string{fmt.Sprintf("localhost:%s", encoderPort)},
 		EncodingRequestQueueSize: 100,
 		SRSOrder:                 3000,
 	}
@@ -165,14 +176,14 @@ func mustMakeDisperser(t *testing.T, cst core.IndexedChainState, store disperser
 		RequestPoolSize:       32,
 	}, logger, enc0, metrics)
 
-	encoderClient, err := encoder.NewEncoderClient(batcherConfig.EncoderSocket, 10*time.Second)
+	encoderClient, err := encoder.NewEncoderClient(batcherConfig.EncoderSockets[0], 10*time.Second)
 	if err != nil {
 		t.Fatal(err)
 	}
 	finalizer := batchermock.NewFinalizer()
 
-	disperserMetrics := disperser.NewMetrics("9100", logger)
-	batcherMetrics := batcher.NewMetrics("9100", logger)
+	disperserMetrics := disperser.NewMetrics("9100", nil, logger)
+	batcherMetrics := batcher.NewMetrics("9100", nil, logger)
 
 	batcher, err := batcher.NewBatcher(batcherConfig, timeoutConfig, store, dispatcher, confirmer, cst, asn, encoderClient, agg, &commonmock.MockEthClient{}, finalizer, logger, batcherMetrics)
 	if err != nil {
@@ -261,11 +272,12 @@ func mustMakeOperators(t *testing.T, cst *coremock.ChainDataMock, logger common.
 			PrivateBls:                string(op.KeyPair.GetPubKeyG1().Serialize()),
 			ID:                        id,
 			QuorumIDList:              registeredQuorums,
+			DisperserAddress:          crypto.PubkeyToAddress(disperserKey.PublicKey),
 		}
 
 		// creating a new instance of encoder instead of sharing enc because enc is not thread safe
 		encoder := mustMakeTestEncoder()
-		val := core.NewChunkValidator(encoder, asn, cst, id)
+		val := core.NewChunkValidator(encoder, asn, cst, id, 0, 0, 0)
 
 		noopMetrics := metrics.NewNoopMetrics()
 		reg := prometheus.NewRegistry()
@@ -460,7 +472,7 @@ func TestDispersalAndRetrieval(t *testing.T) {
 
 	operatorState, err := cst.GetOperatorState(ctx, 0, []core.QuorumID{0})
 	assert.NoError(t, err)
-	assignments, info, err := asn.GetAssignments(operatorState, 0, batcher.QuantizationFactor)
+	assignments, info, err := asn.GetAssignments(operatorState, 0, batcher.QuantizationFactor, 0)
 	assert.NoError(t, err)
 
 	var indices []core.ChunkNumber
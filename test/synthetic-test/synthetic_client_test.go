@@ -215,7 +215,7 @@ func setupRetrievalClient(ethClient common.EthClient, retrievalClientConfig *Ret
 	agn := &core.StdAssignmentCoordinator{}
 
 	// TODO: What should be the value here?
-	nodeClient := clients.NewNodeClient(20 * time.Second)
+	nodeClient := clients.NewNodeClient(20*time.Second, 0, nil)
 	srsOrder, err := strconv.Atoi(retrievalClientConfig.RetrieverSrsOrder)
 	if err != nil {
 		return err
@@ -592,7 +592,7 @@ func TestEncodeBlob(t *testing.T) {
 	encoder, _ := encoding.NewEncoder(encodingConfig)
 
 	maxInputSize := uint64(len(gettysburgAddressBytes)) + 10
-	decoded, err := encoder.Decode(chunksData, indices, *encodingParams, maxInputSize)
+	decoded, err := encoder.Decode(chunksData[:len(indices)], indices, *encodingParams, maxInputSize)
 	assert.Nil(t, err)
 	assert.Equal(t, decoded, gettysburgAddressBytes)
 }
@@ -630,7 +630,7 @@ func encodeBlob(data []byte) (*encoder_rpc.EncodeBlobReply, *core.EncodingParams
 		logger.Printf("failed to get operator state: %s", err)
 	}
 	coordinator := &core.StdAssignmentCoordinator{}
-	_, info, err := coordinator.GetAssignments(operatorState, quorumID, quantizationFactor)
+	_, info, err := coordinator.GetAssignments(operatorState, quorumID, quantizationFactor, 0)
 	if err != nil {
 		logger.Printf("failed to get assignments: %s", err)
 	}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"log"
+	"os"
+
+	"github.com/Layr-Labs/eigenda/test/vectors"
+	"github.com/urfave/cli"
+)
+
+var (
+	seedFlag = cli.Int64Flag{
+		Name:  "seed",
+		Usage: "seed from which the blobs of the vectors are generated",
+		Value: vectors.DefaultSeed,
+	}
+	kzgDirFlag = cli.StringFlag{
+		Name:  "kzg-dir",
+		Usage: "directory holding the g1.point and g2.point SRS files",
+		Value: "inabox/resources/kzg",
+	}
+	outputFlag = cli.StringFlag{
+		Name:  "output",
+		Usage: "path of the JSON file to which the vectors are written",
+		Value: "test/vectors/testdata/vectors.json",
+	}
+)
+
+func main() {
+	app := cli.NewApp()
+	app.Name = "test-vectors"
+	app.Usage = "EigenDA Encoding Test Vector Generator"
+	app.Description = "Regenerates the golden test vectors of the encoding pipeline. Run it from the root of the repo, only when the encoding is deliberately changed."
+	app.Flags = []cli.Flag{seedFlag, kzgDirFlag, outputFlag}
+	app.Action = generate
+	if err := app.Run(os.Args); err != nil {
+		log.Fatalf("application failed: %v", err)
+	}
+}
+
+func generate(ctx *cli.Context) error {
+	cacheDir, err := os.MkdirTemp("", "srs-tables")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(cacheDir)
+
+	enc, err := vectors.NewEncoder(ctx.String(kzgDirFlag.Name), cacheDir)
+	if err != nil {
+		return err
+	}
+	v, err := vectors.Generate(enc, ctx.Int64(seedFlag.Name))
+	if err != nil {
+		return err
+	}
+	return v.Write(ctx.String(outputFlag.Name))
+}
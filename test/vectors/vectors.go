@@ -0,0 +1,224 @@
+// Package vectors generates the golden test vectors of the encoding pipeline: the commitments, chunks and proofs of a
+// few blobs encoded with the KZG encoder, along with the hashes of their headers and the root of the batch holding
+// them. The vectors are committed under testdata so that other implementations can check their encoding against them,
+// and the tests of this package fail whenever the Go code stops reproducing them, so that a change of the encoding
+// comes with a deliberate regeneration of the vectors.
+package vectors
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/core/encoding"
+	"github.com/Layr-Labs/eigenda/pkg/encoding/kzgEncoder"
+	"github.com/Layr-Labs/eigenda/pkg/kzg/bn254"
+)
+
+const (
+	// DefaultSeed is the seed from which the blobs of the committed vectors are generated
+	DefaultSeed = 1
+	// SRSOrder is the number of points of the SRS used by the encoder. The length proofs depend on it.
+	SRSOrder = 3000
+	// ReferenceBlockNumber is the reference block of the batch of the vectors
+	ReferenceBlockNumber = 1000
+)
+
+// blobSpec describes a blob of the vectors, whose data is generated from the seed
+type blobSpec struct {
+	size          int
+	chunkLength   uint
+	numChunks     uint
+	securityParam core.SecurityParam
+}
+
+var blobSpecs = []blobSpec{
+	{size: 100, chunkLength: 2, numChunks: 4, securityParam: core.SecurityParam{QuorumID: 0, AdversaryThreshold: 50, QuorumThreshold: 100}},
+	{size: 500, chunkLength: 4, numChunks: 8, securityParam: core.SecurityParam{QuorumID: 0, AdversaryThreshold: 33, QuorumThreshold: 67}},
+	{size: 1000, chunkLength: 8, numChunks: 8, securityParam: core.SecurityParam{QuorumID: 1, AdversaryThreshold: 80, QuorumThreshold: 90}},
+}
+
+// Vectors are the golden test vectors generated from a seed. The field elements are hex encoded as 32 big endian bytes,
+// and the G1 points are hex encoded in their compressed form.
+type Vectors struct {
+	Seed     int64        `json:"seed"`
+	SRSOrder uint64       `json:"srs_order"`
+	Blobs    []BlobVector `json:"blobs"`
+	Batch    BatchVector  `json:"batch"`
+}
+
+// BlobVector is the encoding of a blob in a single quorum
+type BlobVector struct {
+	// Data is the hex encoded blob
+	Data           string               `json:"data"`
+	EncodingParams EncodingParamsVector `json:"encoding_params"`
+	QuorumInfo     QuorumInfoVector     `json:"quorum_info"`
+	Commitment     string               `json:"commitment"`
+	LengthProof    string               `json:"length_proof"`
+	// Length is the length of the blob in symbols
+	Length uint          `json:"length"`
+	Chunks []ChunkVector `json:"chunks"`
+	// BlobHeaderHash is the hex encoded hash of the header of the blob, with its commitments and quorum info
+	BlobHeaderHash string `json:"blob_header_hash"`
+}
+
+type EncodingParamsVector struct {
+	ChunkLength uint `json:"chunk_length"`
+	NumChunks   uint `json:"num_chunks"`
+}
+
+type QuorumInfoVector struct {
+	QuorumID             core.QuorumID `json:"quorum_id"`
+	AdversaryThreshold   uint8         `json:"adversary_threshold"`
+	QuorumThreshold      uint8         `json:"quorum_threshold"`
+	QuantizationFactor   uint          `json:"quantization_factor"`
+	OverprovisionPercent uint          `json:"overprovision_percent"`
+	EncodedBlobLength    uint          `json:"encoded_blob_length"`
+}
+
+// ChunkVector is a chunk of a blob, in the order of the chunk indices
+type ChunkVector struct {
+	Coeffs []string `json:"coeffs"`
+	Proof  string   `json:"proof"`
+}
+
+// BatchVector is the batch holding all the blobs of the vectors, in order
+type BatchVector struct {
+	ReferenceBlockNumber uint   `json:"reference_block_number"`
+	BatchRoot            string `json:"batch_root"`
+	BatchHeaderHash      string `json:"batch_header_hash"`
+}
+
+// NewEncoder creates the KZG encoder with which the vectors are generated, from the SRS points under kzgDir, e.g.
+// inabox/resources/kzg, caching its tables under cacheDir
+func NewEncoder(kzgDir string, cacheDir string) (core.Encoder, error) {
+	return encoding.NewEncoder(encoding.EncoderConfig{
+		KzgConfig: kzgEncoder.KzgConfig{
+			G1Path:    filepath.Join(kzgDir, "g1.point"),
+			G2Path:    filepath.Join(kzgDir, "g2.point"),
+			CacheDir:  cacheDir,
+			SRSOrder:  SRSOrder,
+			NumWorker: uint64(runtime.GOMAXPROCS(0)),
+		},
+	})
+}
+
+// Generate encodes the blobs generated from the seed and returns their vectors
+func Generate(enc core.Encoder, seed int64) (*Vectors, error) {
+	rng := rand.New(rand.NewSource(seed))
+
+	vectors := &Vectors{
+		Seed:     seed,
+		SRSOrder: SRSOrder,
+		Blobs:    make([]BlobVector, len(blobSpecs)),
+	}
+	headers := make([]*core.BlobHeader, len(blobSpecs))
+	for i, spec := range blobSpecs {
+		data := make([]byte, spec.size)
+		_, _ = rng.Read(data)
+
+		params, err := core.GetEncodingParams(spec.chunkLength, spec.numChunks)
+		if err != nil {
+			return nil, fmt.Errorf("blob %d: %w", i, err)
+		}
+		commitments, chunks, err := enc.Encode(data, params)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode blob %d: %w", i, err)
+		}
+
+		quorumInfo := &core.BlobQuorumInfo{
+			SecurityParam:      spec.securityParam,
+			QuantizationFactor: 1,
+			EncodedBlobLength:  params.ChunkLength * params.NumChunks,
+		}
+		headers[i] = &core.BlobHeader{
+			BlobCommitments: commitments,
+			QuorumInfos:     []*core.BlobQuorumInfo{quorumInfo},
+		}
+		headerHash, err := headers[i].GetBlobHeaderHash()
+		if err != nil {
+			return nil, fmt.Errorf("failed to hash the header of blob %d: %w", i, err)
+		}
+
+		chunkVectors := make([]ChunkVector, len(chunks))
+		for j, chunk := range chunks {
+			chunkVectors[j] = ChunkVector{
+				Coeffs: make([]string, len(chunk.Coeffs)),
+				Proof:  encodeG1(&chunk.Proof),
+			}
+			for k := range chunk.Coeffs {
+				chunkVectors[j].Coeffs[k] = encodeFr(&chunk.Coeffs[k])
+			}
+		}
+
+		vectors.Blobs[i] = BlobVector{
+			Data:           hex.EncodeToString(data),
+			EncodingParams: EncodingParamsVector{ChunkLength: params.ChunkLength, NumChunks: params.NumChunks},
+			QuorumInfo: QuorumInfoVector{
+				QuorumID:             quorumInfo.QuorumID,
+				AdversaryThreshold:   quorumInfo.AdversaryThreshold,
+				QuorumThreshold:      quorumInfo.QuorumThreshold,
+				QuantizationFactor:   quorumInfo.QuantizationFactor,
+				OverprovisionPercent: quorumInfo.OverprovisionPercent,
+				EncodedBlobLength:    quorumInfo.EncodedBlobLength,
+			},
+			Commitment:     encodeG1(commitments.Commitment.G1Point),
+			LengthProof:    encodeG1(commitments.LengthProof.G1Point),
+			Length:         commitments.Length,
+			Chunks:         chunkVectors,
+			BlobHeaderHash: hex.EncodeToString(headerHash[:]),
+		}
+	}
+
+	batchHeader := &core.BatchHeader{ReferenceBlockNumber: ReferenceBlockNumber}
+	if _, err := batchHeader.SetBatchRoot(headers); err != nil {
+		return nil, fmt.Errorf("failed to set the batch root: %w", err)
+	}
+	batchHeaderHash, err := batchHeader.GetBatchHeaderHash()
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash the batch header: %w", err)
+	}
+	vectors.Batch = BatchVector{
+		ReferenceBlockNumber: batchHeader.ReferenceBlockNumber,
+		BatchRoot:            hex.EncodeToString(batchHeader.BatchRoot[:]),
+		BatchHeaderHash:      hex.EncodeToString(batchHeaderHash[:]),
+	}
+
+	return vectors, nil
+}
+
+// Load reads the vectors from a JSON file
+func Load(path string) (*Vectors, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var vectors Vectors
+	if err := json.Unmarshal(data, &vectors); err != nil {
+		return nil, fmt.Errorf("failed to decode the vectors in %s: %w", path, err)
+	}
+	return &vectors, nil
+}
+
+// Write writes the vectors to a JSON file
+func (v *Vectors) Write(path string) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0644)
+}
+
+func encodeFr(fr *bn254.Fr) string {
+	b := bn254.FrToBytes(fr)
+	return hex.EncodeToString(b[:])
+}
+
+func encodeG1(p *bn254.G1Point) string {
+	return hex.EncodeToString(bn254.ToCompressedG1(p))
+}
@@ -0,0 +1,75 @@
+package vectors_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/pkg/kzg/bn254"
+	"github.com/Layr-Labs/eigenda/test/vectors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	kzgDir      = "../../inabox/resources/kzg"
+	vectorsPath = "testdata/vectors.json"
+)
+
+func TestGenerateReproducesVectors(t *testing.T) {
+	expected, err := vectors.Load(vectorsPath)
+	require.NoError(t, err)
+
+	enc, err := vectors.NewEncoder(kzgDir, t.TempDir())
+	require.NoError(t, err)
+	actual, err := vectors.Generate(enc, expected.Seed)
+	require.NoError(t, err)
+
+	// A change of the encoding must come with the regeneration of the vectors with make generate-test-vectors
+	assert.Equal(t, expected, actual)
+}
+
+func TestVectorsVerify(t *testing.T) {
+	v, err := vectors.Load(vectorsPath)
+	require.NoError(t, err)
+	enc, err := vectors.NewEncoder(kzgDir, t.TempDir())
+	require.NoError(t, err)
+
+	// The chunks of the vectors open the commitments, independently of the encoder that produced them
+	for i, blob := range v.Blobs {
+		commitments := core.BlobCommitments{
+			Commitment:  &core.Commitment{G1Point: decodeG1(t, blob.Commitment)},
+			LengthProof: &core.Commitment{G1Point: decodeG1(t, blob.LengthProof)},
+			Length:      blob.Length,
+		}
+		require.NoError(t, enc.VerifyBlobLength(commitments), "blob %d", i)
+
+		chunks := make([]*core.Chunk, len(blob.Chunks))
+		indices := make([]core.ChunkNumber, len(blob.Chunks))
+		for j, c := range blob.Chunks {
+			chunks[j] = &core.Chunk{Coeffs: make([]core.Symbol, len(c.Coeffs)), Proof: *decodeG1(t, c.Proof)}
+			for k, coeff := range c.Coeffs {
+				bn254.FrSetBytes(&chunks[j].Coeffs[k], decodeHex(t, coeff))
+			}
+			indices[j] = core.ChunkNumber(j)
+		}
+		params := core.EncodingParams{ChunkLength: blob.EncodingParams.ChunkLength, NumChunks: blob.EncodingParams.NumChunks}
+		require.NoError(t, enc.VerifyChunks(chunks, indices, commitments, params), "blob %d", i)
+
+		data, err := enc.Decode(chunks, indices, params, uint64(len(blob.Data)/2))
+		require.NoError(t, err)
+		assert.Equal(t, blob.Data, hex.EncodeToString(data), "blob %d", i)
+	}
+}
+
+func decodeHex(t *testing.T, s string) []byte {
+	b, err := hex.DecodeString(s)
+	require.NoError(t, err)
+	return b
+}
+
+func decodeG1(t *testing.T, s string) *bn254.G1Point {
+	p, err := bn254.FromCompressedG1(decodeHex(t, s))
+	require.NoError(t, err)
+	return p
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/Layr-Labs/eigenda/core/encoding"
+	"github.com/Layr-Labs/eigenda/tools/assignmentsim"
+	"github.com/Layr-Labs/eigenda/tools/assignmentsim/flags"
+	"github.com/urfave/cli"
+)
+
+var (
+	version   = ""
+	gitCommit = ""
+	gitDate   = ""
+)
+
+func main() {
+	app := cli.NewApp()
+	app.Version = fmt.Sprintf("%s-%s-%s", version, gitCommit, gitDate)
+	app.Name = "da-assignment-sim"
+	app.Usage = "EigenDA Assignment Simulator"
+	app.Description = "Offline utility that simulates the dispersal of a blob to randomized operator states, checking that the chunks split by the batcher validate against the assignments of the operators, and reports the operator state of every divergence"
+	app.Flags = flags.Flags
+	app.Action = assignmentSimMain
+	if err := app.Run(os.Args); err != nil {
+		log.Fatalf("application failed: %v", err)
+	}
+}
+
+func assignmentSimMain(ctx *cli.Context) error {
+	config, err := assignmentsim.NewConfig(ctx)
+	if err != nil {
+		return err
+	}
+
+	encoder, err := encoding.NewEncoder(config.EncoderConfig)
+	if err != nil {
+		return fmt.Errorf("failed to create encoder: %w", err)
+	}
+
+	simulator := assignmentsim.NewSimulator(encoder, config.EncoderConfig.KzgConfig.SRSOrder)
+	numDivergences := 0
+	for seed := config.Seed; seed < config.Seed+int64(config.NumSeeds); seed++ {
+		err := simulator.Run(seed)
+		var divergence *assignmentsim.Divergence
+		switch {
+		case err == nil:
+			fmt.Printf("seed %d: ok\n", seed)
+		case errors.As(err, &divergence):
+			numDivergences++
+			fmt.Print(divergence.Report())
+		default:
+			return err
+		}
+	}
+
+	if numDivergences > 0 {
+		return fmt.Errorf("%d of %d seeds diverged", numDivergences, config.NumSeeds)
+	}
+	return nil
+}
@@ -0,0 +1,29 @@
+package assignmentsim
+
+import (
+	"fmt"
+
+	"github.com/Layr-Labs/eigenda/core/encoding"
+	"github.com/Layr-Labs/eigenda/tools/assignmentsim/flags"
+	"github.com/urfave/cli"
+)
+
+type Config struct {
+	EncoderConfig encoding.EncoderConfig
+
+	Seed     int64
+	NumSeeds uint
+}
+
+func NewConfig(ctx *cli.Context) (*Config, error) {
+	numSeeds := ctx.GlobalUint(flags.NumSeedsFlag.Name)
+	if numSeeds == 0 {
+		return nil, fmt.Errorf("number of seeds must be positive")
+	}
+
+	return &Config{
+		EncoderConfig: encoding.ReadCLIConfig(ctx),
+		Seed:          ctx.GlobalInt64(flags.SeedFlag.Name),
+		NumSeeds:      numSeeds,
+	}, nil
+}
@@ -0,0 +1,43 @@
+package flags
+
+import (
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/core/encoding"
+	"github.com/urfave/cli"
+)
+
+const (
+	FlagPrefix = "assignment-sim"
+	envPrefix  = "ASSIGNMENT_SIM"
+)
+
+var (
+	/* Optional Flags */
+
+	SeedFlag = cli.Int64Flag{
+		Name:     common.PrefixFlag(FlagPrefix, "seed"),
+		Usage:    "Seed of the first scenario to simulate, e.g. the seed of a divergence reported by the tests",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "SEED"),
+		Value:    0,
+	}
+	NumSeedsFlag = cli.UintFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "num-seeds"),
+		Usage:    "Number of consecutive seeds to simulate, from the seed",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "NUM_SEEDS"),
+		Value:    1,
+	}
+)
+
+var optionalFlags = []cli.Flag{
+	SeedFlag,
+	NumSeedsFlag,
+}
+
+// Flags contains the list of configuration options available to the binary.
+var Flags []cli.Flag
+
+func init() {
+	Flags = append(optionalFlags, encoding.CLIFlags(envPrefix)...)
+}
@@ -0,0 +1,303 @@
+package assignmentsim
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"math/rand"
+	"sort"
+	"strings"
+
+	"github.com/Layr-Labs/eigenda/core"
+)
+
+const (
+	// The scenarios are kept small enough for the encodings of their blobs to fit in the SRS of the tests, of order 3000,
+	// and to be simulated quickly: at most 10 operators with a quantization factor of at most 2 and an overprovisioning
+	// of at most 100% yield at most 64 chunks, and blobs of at most 300 bytes yield chunks of at most 16 symbols.
+	maxOperators          = 10
+	maxQuorums            = 3
+	maxQuantizationFactor = 2
+	maxBlobSize           = 300
+)
+
+// Scenario is a dispersal of a blob to randomized operator state, generated from a seed
+type Scenario struct {
+	Seed                 int64
+	State                *core.OperatorState
+	SecurityParams       []*core.SecurityParam
+	QuantizationFactor   uint
+	OverprovisionPercent uint
+	Data                 []byte
+}
+
+// Divergence is returned when the chunks the batcher sends to an operator don't validate against the assignment the
+// operator computes, or when the assignments are inconsistent
+type Divergence struct {
+	Scenario *Scenario
+	Err      error
+}
+
+func (d *Divergence) Error() string {
+	return fmt.Sprintf("seed %d: %v", d.Scenario.Seed, d.Err)
+}
+
+func (d *Divergence) Unwrap() error {
+	return d.Err
+}
+
+// Report describes the divergence along with the full operator state, so that it can be reproduced in a bug report
+func (d *Divergence) Report() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "divergence: %s\n", d.Error())
+	d.Scenario.describe(&b)
+	return b.String()
+}
+
+// Simulator checks that the assignments computed by the batcher, the split of the encoded blobs it derives from them,
+// and the validation by the operators agree, for randomized operator states
+type Simulator struct {
+	Encoder               core.Encoder
+	AssignmentCoordinator core.AssignmentCoordinator
+	SRSOrder              uint64
+}
+
+func NewSimulator(encoder core.Encoder, srsOrder uint64) *Simulator {
+	return &Simulator{
+		Encoder:               encoder,
+		AssignmentCoordinator: &core.StdAssignmentCoordinator{},
+		SRSOrder:              srsOrder,
+	}
+}
+
+// NewScenario generates the scenario of a seed. The same seed always yields the same scenario.
+func NewScenario(seed int64) *Scenario {
+	rng := rand.New(rand.NewSource(seed))
+
+	numQuorums := 1 + rng.Intn(maxQuorums)
+	numOperators := 1 + rng.Intn(maxOperators)
+	ids := make([]core.OperatorID, numOperators)
+	for i := range ids {
+		_, _ = rng.Read(ids[i][:])
+	}
+
+	state := &core.OperatorState{
+		Operators:   make(map[core.QuorumID]map[core.OperatorID]*core.OperatorInfo),
+		Totals:      make(map[core.QuorumID]*core.OperatorInfo),
+		BlockNumber: uint(rng.Intn(1_000_000)),
+	}
+	securityParams := make([]*core.SecurityParam, numQuorums)
+	for q := 0; q < numQuorums; q++ {
+		quorumID := core.QuorumID(q)
+
+		// The operators are members of all the quorums, as the operators reject the blobs of the quorums they aren't
+		// members of, but they are indexed in a different order in each quorum
+		members := make([]core.OperatorID, numOperators)
+		copy(members, ids)
+		rng.Shuffle(len(members), func(i, j int) { members[i], members[j] = members[j], members[i] })
+
+		stakes := randomStakes(rng, len(members))
+		operators := make(map[core.OperatorID]*core.OperatorInfo, len(members))
+		total := new(big.Int)
+		for i, id := range members {
+			operators[id] = &core.OperatorInfo{Stake: stakes[i], Index: core.OperatorIndex(i)}
+			total.Add(total, stakes[i])
+		}
+		state.Operators[quorumID] = operators
+		state.Totals[quorumID] = &core.OperatorInfo{Stake: total, Index: core.OperatorIndex(len(members))}
+
+		adversaryThreshold := uint8(1 + rng.Intn(90))
+		securityParams[q] = &core.SecurityParam{
+			QuorumID:           quorumID,
+			AdversaryThreshold: adversaryThreshold,
+			QuorumThreshold:    adversaryThreshold + 10 + uint8(rng.Intn(int(100-adversaryThreshold-10)+1)),
+		}
+	}
+
+	data := make([]byte, 1+rng.Intn(maxBlobSize))
+	_, _ = rng.Read(data)
+
+	return &Scenario{
+		Seed:                 seed,
+		State:                state,
+		SecurityParams:       securityParams,
+		QuantizationFactor:   uint(1 + rng.Intn(maxQuantizationFactor)),
+		OverprovisionPercent: uint(rng.Intn(core.MaxOverprovisionPercent + 1)),
+		Data:                 data,
+	}
+}
+
+// randomStakes draws the stakes of the operators of a quorum from one of the distributions which stress the rounding
+// of the assignments: equal stakes, small stakes, a whale among small stakes, and stakes of the magnitude of wei. Some
+// operators have no stake, but at least one has.
+func randomStakes(rng *rand.Rand, n int) []*big.Int {
+	distribution := rng.Intn(4)
+	stakes := make([]*big.Int, n)
+	for i := range stakes {
+		switch distribution {
+		case 0:
+			stakes[i] = big.NewInt(1)
+		case 1:
+			stakes[i] = big.NewInt(rng.Int63n(1000))
+		case 2:
+			stakes[i] = big.NewInt(1 + rng.Int63n(10))
+		default:
+			stakes[i] = new(big.Int).Rand(rng, new(big.Int).Lsh(big.NewInt(1), 96))
+		}
+	}
+	if distribution == 2 {
+		stakes[rng.Intn(n)] = new(big.Int).Lsh(big.NewInt(1), 80)
+	}
+	if rng.Intn(5) == 0 {
+		stakes[rng.Intn(n)] = new(big.Int)
+	}
+
+	for _, stake := range stakes {
+		if stake.Sign() > 0 {
+			return stakes
+		}
+	}
+	stakes[rng.Intn(n)] = big.NewInt(1)
+	return stakes
+}
+
+// Run generates the scenario of the seed and simulates it, returning a *Divergence if the batcher and the operators
+// disagree
+func (s *Simulator) Run(seed int64) error {
+	return s.Simulate(NewScenario(seed))
+}
+
+// Simulate computes the encoding params and assignments of the blob of the scenario like the batcher, encodes the blob
+// and splits its chunks among the operators like the batcher, and validates the chunks of every operator like the
+// operators do. It returns a *Divergence if they disagree.
+func (s *Simulator) Simulate(scenario *Scenario) error {
+	diverge := func(format string, args ...any) error {
+		return &Divergence{Scenario: scenario, Err: fmt.Errorf(format, args...)}
+	}
+
+	blobLength := core.GetBlobLength(uint(len(scenario.Data)))
+	encodings, err := core.GetBatchEncodingParams(s.AssignmentCoordinator, scenario.State, scenario.QuantizationFactor, scenario.OverprovisionPercent, []core.BlobEncodingRequest{{
+		BlobLength:     blobLength,
+		SecurityParams: scenario.SecurityParams,
+	}})
+	if err != nil {
+		return diverge("failed to get the encoding params: %w", err)
+	}
+
+	quorumInfos := make([]*core.BlobQuorumInfo, len(encodings[0]))
+	for i, encoding := range encodings[0] {
+		quorumInfos[i] = &encoding.BlobQuorumInfo
+	}
+	blobHeader := &core.BlobHeader{QuorumInfos: quorumInfos}
+	blobMessages := make(core.EncodedBlob)
+	for _, encoding := range encodings[0] {
+		quorumID := encoding.QuorumID
+		if err := core.ValidateEncodingParams(encoding.EncodingParams, int(blobLength), int(s.SRSOrder)); err != nil {
+			return fmt.Errorf("seed %d: the encoding of quorum %d doesn't fit in the SRS: %w", scenario.Seed, quorumID, err)
+		}
+		if err := s.checkAssignments(scenario.State, encoding); err != nil {
+			return diverge("quorum %d: %w", quorumID, err)
+		}
+
+		commitments, chunks, err := s.Encoder.Encode(scenario.Data, encoding.EncodingParams)
+		if err != nil {
+			return diverge("failed to encode the blob for quorum %d: %w", quorumID, err)
+		}
+		// The commitments don't depend on the encoding params
+		blobHeader.BlobCommitments = commitments
+
+		// Split the chunks like the batcher does
+		for id, assignment := range encoding.Assignments {
+			if assignment.StartIndex+assignment.NumChunks > uint(len(chunks)) {
+				return diverge("quorum %d: operator %x is assigned the chunks [%d, %d) of only %d chunks", quorumID, id, assignment.StartIndex, assignment.StartIndex+assignment.NumChunks, len(chunks))
+			}
+			blobMessage, ok := blobMessages[id]
+			if !ok {
+				blobMessage = &core.BlobMessage{BlobHeader: blobHeader, Bundles: make(core.Bundles)}
+				blobMessages[id] = blobMessage
+			}
+			blobMessage.Bundles[quorumID] = append(blobMessage.Bundles[quorumID], chunks[assignment.StartIndex:assignment.StartIndex+assignment.NumChunks]...)
+		}
+	}
+
+	// Validate the chunks of each operator like the operator does, in the order of their IDs for reproducibility
+	ids := make([]core.OperatorID, 0, len(blobMessages))
+	for id := range blobMessages {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return bytes.Compare(ids[i][:], ids[j][:]) < 0 })
+	for _, id := range ids {
+		validator := core.NewChunkValidator(s.Encoder, s.AssignmentCoordinator, nil, id, 0, 0, 0)
+		if err := validator.ValidateBlob(blobMessages[id], scenario.State, scenario.State.BlockNumber); err != nil {
+			return diverge("operator %x rejected its chunks: %w", id, err)
+		}
+	}
+	return nil
+}
+
+// checkAssignments checks that the assignments of a quorum partition the chunks of the encoding, and that each operator
+// computes the same assignment as the batcher
+func (s *Simulator) checkAssignments(state *core.OperatorState, encoding *core.BlobQuorumEncoding) error {
+	quorumID := encoding.QuorumID
+	if len(encoding.Assignments) != len(state.Operators[quorumID]) {
+		return fmt.Errorf("%d operators are assigned chunks, but the quorum has %d operators", len(encoding.Assignments), len(state.Operators[quorumID]))
+	}
+
+	assignments := make([]core.Assignment, 0, len(encoding.Assignments))
+	for id, assignment := range encoding.Assignments {
+		operatorAssignment, operatorInfo, err := s.AssignmentCoordinator.GetOperatorAssignment(state, quorumID, encoding.QuantizationFactor, encoding.OverprovisionPercent, id)
+		if err != nil {
+			return fmt.Errorf("operator %x failed to get its assignment: %w", id, err)
+		}
+		if operatorAssignment != assignment || operatorInfo != encoding.AssignmentInfo {
+			return fmt.Errorf("operator %x computes the assignment %+v of %d chunks, but the batcher computes %+v of %d chunks", id, operatorAssignment, operatorInfo.TotalChunks, assignment, encoding.AssignmentInfo.TotalChunks)
+		}
+		assignments = append(assignments, assignment)
+	}
+
+	sort.Slice(assignments, func(i, j int) bool { return assignments[i].StartIndex < assignments[j].StartIndex })
+	next := uint(0)
+	for _, assignment := range assignments {
+		if assignment.NumChunks == 0 {
+			continue
+		}
+		if assignment.StartIndex != next {
+			return fmt.Errorf("the assignments leave a gap or overlap at chunk %d", next)
+		}
+		next += assignment.NumChunks
+	}
+	if next != encoding.AssignmentInfo.TotalChunks {
+		return fmt.Errorf("the assignments cover %d chunks, but the total is %d", next, encoding.AssignmentInfo.TotalChunks)
+	}
+	if next > encoding.EncodingParams.NumChunks {
+		return errors.New("the assignments exceed the chunks of the encoding")
+	}
+	return nil
+}
+
+// describe writes the scenario, with the stake of every operator in every quorum
+func (s *Scenario) describe(b *strings.Builder) {
+	fmt.Fprintf(b, "block number: %d\n", s.State.BlockNumber)
+	fmt.Fprintf(b, "quantization factor: %d\n", s.QuantizationFactor)
+	fmt.Fprintf(b, "overprovision percent: %d\n", s.OverprovisionPercent)
+	fmt.Fprintf(b, "blob: %d bytes, %s\n", len(s.Data), hex.EncodeToString(s.Data))
+	for _, param := range s.SecurityParams {
+		quorumID := param.QuorumID
+		total := s.State.Totals[quorumID]
+		fmt.Fprintf(b, "quorum %d: adversary threshold %d, quorum threshold %d, %d operators, total stake %s\n", quorumID, param.AdversaryThreshold, param.QuorumThreshold, total.Index, (*big.Int)(total.Stake))
+
+		operators := make([]core.OperatorID, 0, len(s.State.Operators[quorumID]))
+		for id := range s.State.Operators[quorumID] {
+			operators = append(operators, id)
+		}
+		sort.Slice(operators, func(i, j int) bool {
+			return s.State.Operators[quorumID][operators[i]].Index < s.State.Operators[quorumID][operators[j]].Index
+		})
+		for _, id := range operators {
+			info := s.State.Operators[quorumID][id]
+			fmt.Fprintf(b, "  operator %x: index %d, stake %s\n", id, info.Index, (*big.Int)(info.Stake))
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package assignmentsim_test
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"runtime"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/core/encoding"
+	"github.com/Layr-Labs/eigenda/pkg/encoding/kzgEncoder"
+	"github.com/Layr-Labs/eigenda/tools/assignmentsim"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	srsOrder = 3000
+	numSeeds = 200
+)
+
+func newSimulator(t *testing.T) *assignmentsim.Simulator {
+	encoder, err := encoding.NewEncoder(encoding.EncoderConfig{
+		KzgConfig: kzgEncoder.KzgConfig{
+			G1Path:    "../../inabox/resources/kzg/g1.point",
+			G2Path:    "../../inabox/resources/kzg/g2.point",
+			CacheDir:  "../../inabox/resources/kzg/SRSTables",
+			SRSOrder:  srsOrder,
+			NumWorker: uint64(runtime.GOMAXPROCS(0)),
+		},
+	})
+	require.NoError(t, err)
+	return assignmentsim.NewSimulator(encoder, srsOrder)
+}
+
+func TestSimulate(t *testing.T) {
+	simulator := newSimulator(t)
+	for seed := int64(0); seed < numSeeds; seed++ {
+		seed := seed
+		t.Run(fmt.Sprintf("seed %d", seed), func(t *testing.T) {
+			t.Parallel()
+			if err := simulator.Run(seed); err != nil {
+				// Reproduce with make run SEED=<seed> NUM_SEEDS=1 in tools/assignmentsim
+				var divergence *assignmentsim.Divergence
+				if errors.As(err, &divergence) {
+					t.Fatal(divergence.Report())
+				}
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestNewScenarioIsDeterministic(t *testing.T) {
+	assert.Equal(t, assignmentsim.NewScenario(7), assignmentsim.NewScenario(7))
+	assert.NotEqual(t, assignmentsim.NewScenario(7), assignmentsim.NewScenario(8))
+}
+
+// shiftedAssignmentCoordinator makes the operators compute assignments shifted by one chunk from the ones of the batcher
+type shiftedAssignmentCoordinator struct {
+	core.StdAssignmentCoordinator
+}
+
+func (c *shiftedAssignmentCoordinator) GetOperatorAssignment(state *core.OperatorState, quorum core.QuorumID, quantizationFactor, overprovisionPercent uint, id core.OperatorID) (core.Assignment, core.AssignmentInfo, error) {
+	assignment, info, err := c.StdAssignmentCoordinator.GetOperatorAssignment(state, quorum, quantizationFactor, overprovisionPercent, id)
+	assignment.StartIndex++
+	return assignment, info, err
+}
+
+func TestSimulateReportsDivergence(t *testing.T) {
+	simulator := newSimulator(t)
+	simulator.AssignmentCoordinator = &shiftedAssignmentCoordinator{}
+
+	err := simulator.Run(1)
+	var divergence *assignmentsim.Divergence
+	require.ErrorAs(t, err, &divergence)
+	assert.Equal(t, int64(1), divergence.Scenario.Seed)
+
+	// The report holds the stake of every operator, for the divergence to be reproducible from it
+	report := divergence.Report()
+	assert.Contains(t, report, "divergence: seed 1: quorum 0: operator")
+	for quorumID, operators := range divergence.Scenario.State.Operators {
+		for id, info := range operators {
+			assert.Contains(t, report, fmt.Sprintf("operator %x: index %d, stake %s", id, info.Index, (*big.Int)(info.Stake)), "quorum %d", quorumID)
+		}
+	}
+}
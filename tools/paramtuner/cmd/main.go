@@ -0,0 +1,51 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/Layr-Labs/eigenda/core/encoding"
+	"github.com/Layr-Labs/eigenda/tools/paramtuner"
+	"github.com/Layr-Labs/eigenda/tools/paramtuner/flags"
+	"github.com/urfave/cli"
+)
+
+var (
+	version   = ""
+	gitCommit = ""
+	gitDate   = ""
+)
+
+func main() {
+	app := cli.NewApp()
+	app.Version = fmt.Sprintf("%s-%s-%s", version, gitCommit, gitDate)
+	app.Name = "da-param-tuner"
+	app.Usage = "EigenDA Encoding Parameter Tuner"
+	app.Description = "Offline utility that sweeps quantization factors for given operator set and blob sizes, reporting chunk counts, proof sizes and encoding time"
+	app.Flags = flags.Flags
+	app.Action = paramTunerMain
+	if err := app.Run(os.Args); err != nil {
+		log.Fatalf("application failed: %v", err)
+	}
+}
+
+func paramTunerMain(ctx *cli.Context) error {
+	config, err := paramtuner.NewConfig(ctx)
+	if err != nil {
+		return err
+	}
+
+	encoder, err := encoding.NewEncoder(config.EncoderConfig)
+	if err != nil {
+		return fmt.Errorf("failed to create encoder: %w", err)
+	}
+
+	tuner := paramtuner.NewTuner(encoder, config.EncoderConfig.KzgConfig.SRSOrder)
+	results, err := tuner.Sweep(config)
+	if err != nil {
+		return err
+	}
+
+	return paramtuner.WriteReport(os.Stdout, results)
+}
@@ -0,0 +1,60 @@
+package paramtuner
+
+import (
+	"fmt"
+
+	"github.com/Layr-Labs/eigenda/core/encoding"
+	"github.com/Layr-Labs/eigenda/tools/paramtuner/flags"
+	"github.com/urfave/cli"
+)
+
+type Config struct {
+	EncoderConfig encoding.EncoderConfig
+
+	NumOperators        []uint
+	BlobSizes           []uint
+	QuantizationFactors []uint
+	QuorumThreshold     uint8
+	AdversaryThreshold  uint8
+	NumTrials           uint
+}
+
+func NewConfig(ctx *cli.Context) (*Config, error) {
+	numOperators, err := toPositiveUints(ctx.GlobalIntSlice(flags.NumOperatorsFlag.Name), "number of operators")
+	if err != nil {
+		return nil, err
+	}
+	blobSizes, err := toPositiveUints(ctx.GlobalIntSlice(flags.BlobSizesFlag.Name), "blob size")
+	if err != nil {
+		return nil, err
+	}
+	quantizationFactors, err := toPositiveUints(ctx.GlobalIntSlice(flags.QuantizationFactorsFlag.Name), "quantization factor")
+	if err != nil {
+		return nil, err
+	}
+
+	encoderConfig := encoding.ReadCLIConfig(ctx)
+	// Every trial must run the encoder rather than hit the cache
+	encoderConfig.CacheEncodedBlobs = false
+
+	return &Config{
+		EncoderConfig:       encoderConfig,
+		NumOperators:        numOperators,
+		BlobSizes:           blobSizes,
+		QuantizationFactors: quantizationFactors,
+		QuorumThreshold:     uint8(ctx.GlobalUint(flags.QuorumThresholdFlag.Name)),
+		AdversaryThreshold:  uint8(ctx.GlobalUint(flags.AdversarialThresholdFlag.Name)),
+		NumTrials:           ctx.GlobalUint(flags.NumTrialsFlag.Name),
+	}, nil
+}
+
+func toPositiveUints(values []int, name string) ([]uint, error) {
+	res := make([]uint, len(values))
+	for i, v := range values {
+		if v <= 0 {
+			return nil, fmt.Errorf("%s must be positive, got %d", name, v)
+		}
+		res[i] = uint(v)
+	}
+	return res, nil
+}
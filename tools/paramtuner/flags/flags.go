@@ -0,0 +1,80 @@
+package flags
+
+import (
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/core/encoding"
+	"github.com/urfave/cli"
+)
+
+const (
+	FlagPrefix = "param-tuner"
+	envPrefix  = "PARAM_TUNER"
+)
+
+var (
+	/* Required Flags */
+
+	NumOperatorsFlag = cli.IntSliceFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "num-operators"),
+		Usage:    "Operator set sizes to evaluate",
+		Required: true,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "NUM_OPERATORS"),
+	}
+	BlobSizesFlag = cli.IntSliceFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "blob-sizes"),
+		Usage:    "Blob sizes in bytes to evaluate",
+		Required: true,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "BLOB_SIZES"),
+	}
+
+	/* Optional Flags */
+
+	QuantizationFactorsFlag = cli.IntSliceFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "quantization-factors"),
+		Usage:    "Candidate quantization factors to sweep",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "QUANTIZATION_FACTORS"),
+		Value:    &cli.IntSlice{1, 2, 4, 8},
+	}
+	AdversarialThresholdFlag = cli.UintFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "adv-threshold"),
+		Usage:    "Adversarial threshold between 0 and 100",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "ADV_THRESHOLD"),
+		Value:    80,
+	}
+	QuorumThresholdFlag = cli.UintFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "quorum-threshold"),
+		Usage:    "Quorum threshold between 0 and 100",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "QUORUM_THRESHOLD"),
+		Value:    100,
+	}
+	NumTrialsFlag = cli.UintFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "num-trials"),
+		Usage:    "Number of times each blob is encoded to estimate the encoding time",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "NUM_TRIALS"),
+		Value:    3,
+	}
+)
+
+var requiredFlags = []cli.Flag{
+	NumOperatorsFlag,
+	BlobSizesFlag,
+}
+
+var optionalFlags = []cli.Flag{
+	QuantizationFactorsFlag,
+	AdversarialThresholdFlag,
+	QuorumThresholdFlag,
+	NumTrialsFlag,
+}
+
+// Flags contains the list of configuration options available to the binary.
+var Flags []cli.Flag
+
+func init() {
+	Flags = append(requiredFlags, optionalFlags...)
+	Flags = append(Flags, encoding.CLIFlags(envPrefix)...)
+}
@@ -0,0 +1,158 @@
+package paramtuner
+
+import (
+	"crypto/rand"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/core"
+	gnarkbn254 "github.com/consensys/gnark-crypto/ecc/bn254"
+)
+
+// proofSize is the size in bytes of a single chunk proof, which is a compressed G1 point
+const proofSize = gnarkbn254.SizeOfG1AffineCompressed
+
+// Result describes the encoding of a blob of a given size among a given number of operators for a single quantization factor.
+// All operators are assumed to have equal stake, so that each operator is assigned exactly QuantizationFactor chunks.
+type Result struct {
+	NumOperators       uint
+	BlobSize           uint
+	QuantizationFactor uint
+
+	// ChunkLength and NumChunks are the encoding parameters used by the encoder. NumChunks is rounded up to a power of 2,
+	// so it may exceed the number of chunks actually assigned to operators.
+	ChunkLength uint
+	NumChunks   uint
+	// EncodedSize is the total size in bytes of the chunks assigned to operators, excluding proofs
+	EncodedSize uint
+	// ProofSize is the total size in bytes of the proofs of the chunks assigned to operators
+	ProofSize uint
+	// BytesPerOperator is the amount of data, including proofs, that each operator receives for the blob
+	BytesPerOperator uint
+	// EncodeTime is the average time taken by the encoder to encode the blob
+	EncodeTime time.Duration
+
+	// Err is set if the blob could not be encoded with these parameters, e.g. because they exceed the SRS
+	Err error
+}
+
+// Overhead returns the ratio of the total bytes sent to operators to the size of the original blob
+func (r Result) Overhead() float64 {
+	return float64(r.EncodedSize+r.ProofSize) / float64(r.BlobSize)
+}
+
+// Tuner sweeps encoding parameters using a real encoder, so that operators of a DA network can choose a quantization
+// factor based on measured rather than guessed costs.
+type Tuner struct {
+	Encoder               core.Encoder
+	AssignmentCoordinator core.AssignmentCoordinator
+	SRSOrder              uint64
+}
+
+func NewTuner(encoder core.Encoder, srsOrder uint64) *Tuner {
+	return &Tuner{
+		Encoder:               encoder,
+		AssignmentCoordinator: &core.StdAssignmentCoordinator{},
+		SRSOrder:              srsOrder,
+	}
+}
+
+// Sweep returns a Result for every combination of operator count, blob size and quantization factor in the config
+func (t *Tuner) Sweep(config *Config) ([]Result, error) {
+	if config.QuorumThreshold <= config.AdversaryThreshold {
+		return nil, fmt.Errorf("quorum threshold (%d) must exceed adversary threshold (%d)", config.QuorumThreshold, config.AdversaryThreshold)
+	}
+	if config.NumTrials == 0 {
+		return nil, fmt.Errorf("number of trials must be positive")
+	}
+
+	results := make([]Result, 0, len(config.NumOperators)*len(config.BlobSizes)*len(config.QuantizationFactors))
+	for _, numOperators := range config.NumOperators {
+		for _, blobSize := range config.BlobSizes {
+			data := make([]byte, blobSize)
+			_, err := rand.Read(data)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, quantizationFactor := range config.QuantizationFactors {
+				results = append(results, t.evaluate(data, numOperators, quantizationFactor, config))
+			}
+		}
+	}
+
+	return results, nil
+}
+
+func (t *Tuner) evaluate(data []byte, numOperators, quantizationFactor uint, config *Config) Result {
+	result := Result{
+		NumOperators:       numOperators,
+		BlobSize:           uint(len(data)),
+		QuantizationFactor: quantizationFactor,
+	}
+
+	blobLength := core.GetBlobLength(uint(len(data)))
+	chunkLength, err := t.AssignmentCoordinator.GetMinimumChunkLength(numOperators, blobLength, quantizationFactor, config.QuorumThreshold, config.AdversaryThreshold)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+
+	numAssignedChunks := numOperators * quantizationFactor
+	params, err := core.GetEncodingParams(chunkLength, numAssignedChunks)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	result.ChunkLength = params.ChunkLength
+	result.NumChunks = params.NumChunks
+
+	err = core.ValidateEncodingParams(params, int(blobLength), int(t.SRSOrder))
+	if err != nil {
+		result.Err = err
+		return result
+	}
+
+	result.EncodedSize = numAssignedChunks * core.GetBlobSize(params.ChunkLength)
+	result.ProofSize = numAssignedChunks * proofSize
+	result.BytesPerOperator = quantizationFactor * (core.GetBlobSize(params.ChunkLength) + proofSize)
+
+	// The first encoding with a given set of parameters also sets up the encoder for them, so exclude it from the timing
+	_, _, err = t.Encoder.Encode(data, params)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+
+	var total time.Duration
+	for i := uint(0); i < config.NumTrials; i++ {
+		start := time.Now()
+		_, _, err := t.Encoder.Encode(data, params)
+		if err != nil {
+			result.Err = err
+			return result
+		}
+		total += time.Since(start)
+	}
+	result.EncodeTime = total / time.Duration(config.NumTrials)
+
+	return result
+}
+
+// WriteReport writes the results as a table
+func WriteReport(w io.Writer, results []Result) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "operators\tblob size\tquantization\tchunk length\tnum chunks\tencoded size\tproof size\tper operator\toverhead\tencode time\terror\t")
+	for _, r := range results {
+		if r.Err != nil {
+			fmt.Fprintf(tw, "%d\t%d\t%d\t%d\t%d\t-\t-\t-\t-\t-\t%v\t\n", r.NumOperators, r.BlobSize, r.QuantizationFactor, r.ChunkLength, r.NumChunks, r.Err)
+			continue
+		}
+		fmt.Fprintf(tw, "%d\t%d\t%d\t%d\t%d\t%d\t%d\t%d\t%.2fx\t%v\t\t\n",
+			r.NumOperators, r.BlobSize, r.QuantizationFactor, r.ChunkLength, r.NumChunks,
+			r.EncodedSize, r.ProofSize, r.BytesPerOperator, r.Overhead(), r.EncodeTime.Round(time.Microsecond))
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,78 @@
+package paramtuner_test
+
+import (
+	"bytes"
+	"runtime"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/core/encoding"
+	"github.com/Layr-Labs/eigenda/pkg/encoding/kzgEncoder"
+	"github.com/Layr-Labs/eigenda/tools/paramtuner"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSweep(t *testing.T) {
+	encoder, err := encoding.NewEncoder(encoding.EncoderConfig{
+		KzgConfig: kzgEncoder.KzgConfig{
+			G1Path:    "../../inabox/resources/kzg/g1.point",
+			G2Path:    "../../inabox/resources/kzg/g2.point",
+			CacheDir:  "../../inabox/resources/kzg/SRSTables",
+			SRSOrder:  3000,
+			NumWorker: uint64(runtime.GOMAXPROCS(0)),
+		},
+	})
+	require.NoError(t, err)
+
+	tuner := paramtuner.NewTuner(encoder, 3000)
+	results, err := tuner.Sweep(&paramtuner.Config{
+		NumOperators:        []uint{4},
+		BlobSizes:           []uint{1000, 100_000},
+		QuantizationFactors: []uint{1, 2},
+		QuorumThreshold:     100,
+		AdversaryThreshold:  80,
+		NumTrials:           1,
+	})
+	require.NoError(t, err)
+	require.Len(t, results, 4)
+
+	// With 80/100 thresholds the 4 chunks only provide a single systematic chunk, so the 33 symbols of the blob are
+	// rounded up to a chunk length of 64
+	small := results[0]
+	assert.NoError(t, small.Err)
+	assert.Equal(t, uint(64), small.ChunkLength)
+	assert.Equal(t, uint(4), small.NumChunks)
+	assert.Equal(t, uint(4*64*31), small.EncodedSize)
+	assert.Equal(t, uint(4*32), small.ProofSize)
+	assert.Equal(t, uint(64*31+32), small.BytesPerOperator)
+	assert.Greater(t, small.EncodeTime.Nanoseconds(), int64(0))
+
+	// Doubling the quantization factor halves the chunk length and doubles the number of proofs
+	doubled := results[1]
+	assert.NoError(t, doubled.Err)
+	assert.Equal(t, small.ChunkLength/2, doubled.ChunkLength)
+	assert.Equal(t, small.NumChunks*2, doubled.NumChunks)
+	assert.Equal(t, small.ProofSize*2, doubled.ProofSize)
+
+	// Blobs that don't fit in the SRS are reported rather than aborting the sweep
+	assert.Error(t, results[2].Err)
+	assert.Error(t, results[3].Err)
+
+	var buf bytes.Buffer
+	require.NoError(t, paramtuner.WriteReport(&buf, results))
+	assert.Contains(t, buf.String(), "encode time")
+	assert.Contains(t, buf.String(), "not valid with respect to the SRS")
+}
+
+func TestSweepInvalidThresholds(t *testing.T) {
+	tuner := paramtuner.NewTuner(nil, 3000)
+	_, err := tuner.Sweep(&paramtuner.Config{
+		NumOperators:        []uint{4},
+		BlobSizes:           []uint{1000},
+		QuantizationFactors: []uint{1},
+		QuorumThreshold:     50,
+		AdversaryThreshold:  50,
+		NumTrials:           1,
+	})
+	assert.Error(t, err)
+}